@@ -0,0 +1,48 @@
+package main
+
+import (
+	"time"
+
+	"github.com/abneribeiro/godev/internal/config"
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/runner"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// requestDefaults returns the timeout and retries of the settings, which
+// requests without a policy of their own get. A timeout set with the
+// -timeout flag of a subcommand replaces the one of the settings.
+func requestDefaults(cfg *config.Config, timeout time.Duration) runner.Defaults {
+	defaults := runner.Defaults{
+		Timeout: cfg.HTTPTimeout,
+		Retry:   httpclient.RetryPolicy{Retries: cfg.MaxRetries, Backoff: cfg.RetryBackoff},
+	}
+	if timeout > 0 {
+		defaults.Timeout = timeout
+	}
+	return defaults
+}
+
+// newHTTPClient builds the client a subcommand sends with, configured as
+// the UI configures its own: the proxy and TLS settings of the profile,
+// with the proxy of env winning over the global one and its client
+// certificate sent. env may be nil.
+func newHTTPClient(cfg *config.Config, env *storage.Environment, timeout time.Duration) (*httpclient.Client, error) {
+	proxy := cfg.ProxySettings()
+	tls := cfg.TLSSettings()
+	if env != nil {
+		if env.Proxy != "" {
+			proxy.URL = env.Proxy
+		}
+		tls.CertFile, tls.KeyFile = env.ClientCert, env.ClientKey
+	}
+
+	client := httpclient.NewClient(timeout)
+	if err := client.SetProxy(proxy); err != nil {
+		return nil, err
+	}
+	if err := client.SetTLS(tls); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/abneribeiro/godev/internal/config"
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/runner"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// runCollectionCommand runs every request of a collection as a suite
+func runCollectionCommand(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("collection", flag.ContinueOnError)
+	concurrency := fs.Int("concurrency", 0, "max steps of a parallel group in flight (default: the collection's max_concurrency, or 4)")
+	timeout := fs.Duration("timeout", 0, "timeout of each request (default: GODEV_HTTP_TIMEOUT of the settings, or 30s)")
+	timeBudget := fs.Int64("time-budget", 0, "response time budget in ms for steps without one (default: the collection's latency_budget_ms)")
+	sizeBudget := fs.Int64("size-budget", 0, "response size budget in bytes for steps without one (default: the collection's size_budget_bytes)")
+	jsonReport := fs.String("json", "", "write the report as JSON to file")
+	junitReport := fs.String("junit", "", "write the report as JUnit XML to file")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: godev collection run [-concurrency N] [-json file] [-junit file] <collection name>")
+		fmt.Fprintln(fs.Output(), `Consecutive requests with the same "group" run in parallel; "depends_on" lists steps that must pass first.`)
+		fs.PrintDefaults()
+	}
+
+	if len(args) == 0 || args[0] != "run" {
+		fs.Usage()
+		return fmt.Errorf("expected the run action")
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected a collection name")
+	}
+
+	cfg, err := config.LoadFromEnv()
+	if err != nil {
+		return err
+	}
+	ignore, err := httpclient.ParseIgnoreRules(cfg.DiffIgnore)
+	if err != nil {
+		return err
+	}
+
+	store, err := storage.NewStorage()
+	if err != nil {
+		return err
+	}
+
+	config, err := store.LoadCollections()
+	if err != nil {
+		return err
+	}
+
+	collection := storage.FindCollectionByName(config.Collections, fs.Arg(0))
+	if collection == nil {
+		return fmt.Errorf("no collection named %q", fs.Arg(0))
+	}
+
+	vars, err := store.GetActiveEnvironmentVariables()
+	if err != nil {
+		return err
+	}
+	aliases, err := store.GetActiveAliases()
+	if err != nil {
+		return err
+	}
+
+	envs, err := store.LoadEnvironments()
+	if err != nil {
+		return err
+	}
+	defaults := requestDefaults(cfg, *timeout)
+	client, err := newHTTPClient(cfg, envs.Active(), defaults.Timeout)
+	if err != nil {
+		return err
+	}
+
+	report, err := runner.RunCollection(ctx, client, *collection, runner.Options{
+		MaxConcurrency:  *concurrency,
+		Ignore:          ignore,
+		Variables:       vars,
+		LatencyBudgetMs: *timeBudget,
+		SizeBudgetBytes: *sizeBudget,
+		Defaults:        defaults,
+		Prepare: func(step storage.SavedRequest) httpclient.Request {
+			return runner.ResolveRequest(step, vars, aliases)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(runner.FormatReport(report))
+
+	if err := writeRunReport(*jsonReport, report, runner.WriteJSON); err != nil {
+		return err
+	}
+	if err := writeRunReport(*junitReport, report, runner.WriteJUnit); err != nil {
+		return err
+	}
+
+	if _, failed, skipped := report.Counts(); failed+skipped > 0 {
+		return fmt.Errorf("%d steps failed, %d skipped", failed, skipped)
+	}
+	return nil
+}
+
+// writeRunReport exports the report to path with write; an empty path skips it
+func writeRunReport(path string, report runner.Report, write func(io.Writer, runner.Report) error) error {
+	if path == "" {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := write(&buf, report); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// runDocsCommand exports a collection, or all saved requests, as API documentation
+func runDocsCommand(_ context.Context, args []string) error {
+	fs := flag.NewFlagSet("docs", flag.ContinueOnError)
+	format := fs.String("format", "markdown", "output format: markdown or html")
+	output := fs.String("o", "", "write documentation to file instead of stdout")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: godev docs [-format markdown|html] [-o file] [collection name]")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return fmt.Errorf("expected at most one collection name")
+	}
+
+	docFormat := storage.DocFormat(*format)
+	if *format == "md" {
+		docFormat = storage.DocFormatMarkdown
+	}
+
+	store, err := storage.NewStorage()
+	if err != nil {
+		return err
+	}
+
+	collection, err := loadDocsCollection(store, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	docs, err := storage.GenerateDocs(collection, store.GetHistory(), docFormat)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		fmt.Print(docs)
+		return nil
+	}
+
+	if err := os.WriteFile(*output, []byte(docs), 0644); err != nil {
+		return fmt.Errorf("failed to write documentation: %w", err)
+	}
+	fmt.Printf("Documented %d requests in %s\n", len(collection.Requests), *output)
+	return nil
+}
+
+// loadDocsCollection finds the named collection, or groups all saved requests when no name is given
+func loadDocsCollection(store *storage.Storage, name string) (*storage.Collection, error) {
+	if name == "" {
+		collection := storage.CreateCollection("Saved Requests", "")
+		collection.Requests = store.GetRequests()
+		return &collection, nil
+	}
+
+	config, err := store.LoadCollections()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range config.Collections {
+		if config.Collections[i].Name == name {
+			return &config.Collections[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no collection named %q", name)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// runImportCommand imports a shared collection or environments file from a
+// URL or a local file. Besides godev's own format it reads Postman, OpenAPI,
+// Hoppscotch and Thunder Client exports.
+func runImportCommand(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	header := fs.String("header", "", `optional auth header, e.g. "Authorization: Bearer <token>"`)
+	envs := fs.Bool("env", false, "import environments instead of a collection")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: godev import [-env] [-header \"Name: value\"] <https://... | file>")
+		fmt.Fprintln(fs.Output(), "Formats: godev, Postman, OpenAPI, Hoppscotch and Thunder Client collections and environments")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected a URL or file to import from")
+	}
+
+	store, err := storage.NewStorage()
+	if err != nil {
+		return err
+	}
+
+	source := fs.Arg(0)
+	remote := strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://")
+	var data []byte
+	if !remote {
+		if data, err = os.ReadFile(paths.ExpandHome(source)); err != nil {
+			return fmt.Errorf("failed to read %s: %w", source, err)
+		}
+	}
+
+	var result *storage.RemoteImportResult
+	switch {
+	case *envs && remote:
+		result, err = store.ImportRemoteEnvironments(ctx, source, *header)
+	case *envs:
+		result, err = store.ImportEnvironments(data)
+	case remote:
+		result, err = store.ImportRemoteCollection(ctx, source, *header)
+	default:
+		result, err = store.ImportCollection(data, "")
+	}
+	if err != nil {
+		return err
+	}
+
+	if *envs {
+		fmt.Printf("Imported environments: %s\n", strings.Join(result.Environments, ", "))
+		return nil
+	}
+	printImportedCollection(result)
+	return nil
+}
+
+// runPullCommand refreshes every collection that was imported from a URL
+func runPullCommand(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("pull", flag.ContinueOnError)
+	header := fs.String("header", "", `optional auth header, e.g. "Authorization: Bearer <token>"`)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: godev pull [-header \"Name: value\"]")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	store, err := storage.NewStorage()
+	if err != nil {
+		return err
+	}
+
+	sources, err := store.RemoteCollectionSources()
+	if err != nil {
+		return err
+	}
+	if len(sources) == 0 {
+		fmt.Println("No collections were imported from a URL")
+		return nil
+	}
+
+	failed := 0
+	for _, source := range sources {
+		result, err := store.ImportRemoteCollection(ctx, source, *header)
+		if err != nil {
+			fmt.Printf("✗ %s: %v\n", source, err)
+			failed++
+			continue
+		}
+		printImportedCollection(result)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d collections failed to update", failed, len(sources))
+	}
+	return nil
+}
+
+func printImportedCollection(result *storage.RemoteImportResult) {
+	action := "Imported"
+	if result.Replaced {
+		action = "Updated"
+	}
+	fmt.Printf("✓ %s collection %q (%d requests)\n", action, result.Collection.Name, result.Collection.RequestCount())
+}
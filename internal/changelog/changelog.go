@@ -0,0 +1,126 @@
+// Package changelog reads release notes from a Keep a Changelog file, such
+// as the CHANGELOG.md embedded in the binary, to tell users what changed
+// since the version they last ran.
+package changelog
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Release is one released version of the changelog
+type Release struct {
+	Version string
+	Date    string
+	// Notes is the markdown of the release without its heading
+	Notes string
+}
+
+// Parse returns the releases of a changelog in the order they appear,
+// newest first by convention. Sections that are not released versions,
+// like "Unreleased" or a trailing version history, are left out.
+func Parse(text string) []Release {
+	var releases []Release
+	var current *Release
+	var notes []string
+
+	flush := func() {
+		if current != nil {
+			current.Notes = strings.TrimSpace(strings.Join(notes, "\n"))
+			releases = append(releases, *current)
+		}
+		current = nil
+		notes = nil
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "## "):
+			flush()
+			if release, ok := parseHeading(strings.TrimPrefix(line, "## ")); ok {
+				current = &release
+			}
+		case strings.TrimSpace(line) == "---":
+			flush()
+		case current != nil:
+			notes = append(notes, line)
+		}
+	}
+	flush()
+
+	return releases
+}
+
+// parseHeading reads a release heading like "[0.4.0] - 2025-10-19"
+func parseHeading(heading string) (Release, bool) {
+	version, date, _ := strings.Cut(heading, " - ")
+	version = strings.Trim(strings.TrimSpace(version), "[]")
+	version = strings.TrimPrefix(version, "v")
+	if _, ok := parseVersion(version); !ok {
+		return Release{}, false
+	}
+	return Release{Version: version, Date: strings.TrimSpace(date)}, true
+}
+
+// Since returns the releases newer than version, up to and including
+// current. An empty version returns nothing: there is nothing new to a
+// user who has not run any version before.
+func Since(releases []Release, version, current string) []Release {
+	if version == "" {
+		return nil
+	}
+	var newer []Release
+	for _, release := range releases {
+		if Compare(release.Version, version) > 0 && Compare(release.Version, current) <= 0 {
+			newer = append(newer, release)
+		}
+	}
+	return newer
+}
+
+// Compare compares two dotted versions numerically, returning -1, 0 or 1.
+// Versions that do not parse sort before those that do.
+func Compare(a, b string) int {
+	va, okA := parseVersion(strings.TrimPrefix(a, "v"))
+	vb, okB := parseVersion(strings.TrimPrefix(b, "v"))
+	switch {
+	case !okA && !okB:
+		return 0
+	case !okA:
+		return -1
+	case !okB:
+		return 1
+	}
+
+	for i := 0; i < max(len(va), len(vb)); i++ {
+		var x, y int
+		if i < len(va) {
+			x = va[i]
+		}
+		if i < len(vb) {
+			y = vb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func parseVersion(version string) ([]int, bool) {
+	if version == "" {
+		return nil, false
+	}
+	var parts []int
+	for _, part := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		parts = append(parts, n)
+	}
+	return parts, true
+}
@@ -0,0 +1,89 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+)
+
+const sample = `# Changelog
+
+## [Unreleased]
+
+- Work in progress
+
+## [0.4.0] - 2025-10-19
+
+### Added
+
+- Database mode
+
+## [0.2.0] - 2025-10-17
+
+### Fixed
+
+- Clipboard
+
+---
+
+## Version History
+
+- **0.2.0**: Full-featured release
+`
+
+func TestParse(t *testing.T) {
+	releases := Parse(sample)
+	if len(releases) != 2 {
+		t.Fatalf("Expected 2 releases, got %+v", releases)
+	}
+	if releases[0].Version != "0.4.0" || releases[0].Date != "2025-10-19" {
+		t.Errorf("releases[0] = %+v", releases[0])
+	}
+	if releases[0].Notes != "### Added\n\n- Database mode" {
+		t.Errorf("releases[0].Notes = %q", releases[0].Notes)
+	}
+	if strings.Contains(releases[1].Notes, "Version History") {
+		t.Errorf("Expected the notes to stop at the rule, got %q", releases[1].Notes)
+	}
+}
+
+func TestSince(t *testing.T) {
+	releases := Parse(sample)
+
+	tests := []struct {
+		seen, current string
+		want          []string
+	}{
+		{"0.1.0", "0.4.0", []string{"0.4.0", "0.2.0"}},
+		{"0.2.0", "0.4.0", []string{"0.4.0"}},
+		{"0.4.0", "0.4.0", nil},
+		{"0.1.0", "0.2.0", []string{"0.2.0"}},
+		{"", "0.4.0", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, release := range Since(releases, tt.seen, tt.current) {
+			got = append(got, release.Version)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("Since(%s, %s) = %v, want %v", tt.seen, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"0.10.0", "0.9.0", 1},
+		{"1.0", "1.0.0", 0},
+		{"v0.4.0", "0.4.0", 0},
+		{"0.4.0", "0.4.1", -1},
+		{"dev", "0.1.0", -1},
+	}
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Package codec holds the encodings, hashes and timestamp conversions of
+// the utility screens: the small conversions that otherwise send you to a
+// shell one-liner.
+package codec
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Op is one conversion of the input text
+type Op struct {
+	Name  string
+	Apply func(string) (string, error)
+}
+
+// Ops lists the conversions in the order they are shown
+var Ops = []Op{
+	{"Base64 encode", func(s string) (string, error) {
+		return base64.StdEncoding.EncodeToString([]byte(s)), nil
+	}},
+	{"Base64 decode", decodeBase64},
+	{"Base64URL encode", func(s string) (string, error) {
+		return base64.RawURLEncoding.EncodeToString([]byte(s)), nil
+	}},
+	{"URL encode", func(s string) (string, error) {
+		return url.QueryEscape(s), nil
+	}},
+	{"URL decode", url.QueryUnescape},
+	{"Hex encode", func(s string) (string, error) {
+		return hex.EncodeToString([]byte(s)), nil
+	}},
+	{"Hex decode", func(s string) (string, error) {
+		data, err := hex.DecodeString(strings.Join(strings.Fields(s), ""))
+		return string(data), err
+	}},
+	{"MD5", func(s string) (string, error) {
+		sum := md5.Sum([]byte(s))
+		return hex.EncodeToString(sum[:]), nil
+	}},
+	{"SHA-1", func(s string) (string, error) {
+		sum := sha1.Sum([]byte(s))
+		return hex.EncodeToString(sum[:]), nil
+	}},
+	{"SHA-256", func(s string) (string, error) {
+		sum := sha256.Sum256([]byte(s))
+		return hex.EncodeToString(sum[:]), nil
+	}},
+}
+
+// decodeBase64 decodes standard or URL-safe base64, padded or not, so a
+// value can be pasted without knowing which alphabet produced it
+func decodeBase64(s string) (string, error) {
+	s = strings.Join(strings.Fields(s), "")
+	s = strings.TrimRight(s, "=")
+	if strings.ContainsAny(s, "-_") {
+		data, err := base64.RawURLEncoding.DecodeString(s)
+		return string(data), err
+	}
+	data, err := base64.RawStdEncoding.DecodeString(s)
+	return string(data), err
+}
+
+// NewUUID returns a random (version 4) UUID
+func NewUUID() string {
+	return uuid.NewString()
+}
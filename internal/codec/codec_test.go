@@ -0,0 +1,60 @@
+package codec
+
+import (
+	"regexp"
+	"testing"
+)
+
+func apply(t *testing.T, name, input string) (string, error) {
+	t.Helper()
+	for _, op := range Ops {
+		if op.Name == name {
+			return op.Apply(input)
+		}
+	}
+	t.Fatalf("no op named %q", name)
+	return "", nil
+}
+
+func TestOps(t *testing.T) {
+	tests := []struct {
+		op, input, want string
+	}{
+		{"Base64 encode", "hello?>", "aGVsbG8/Pg=="},
+		{"Base64 decode", "aGVsbG8/Pg==", "hello?>"},
+		{"Base64 decode", "aGVsbG8_Pg", "hello?>"},
+		{"Base64 decode", "aGVs\nbG8=", "hello"},
+		{"Base64URL encode", "hello?>", "aGVsbG8_Pg"},
+		{"URL encode", "a b&c=d/é", "a+b%26c%3Dd%2F%C3%A9"},
+		{"URL decode", "a+b%26c%3Dd%2F%C3%A9", "a b&c=d/é"},
+		{"Hex encode", "hi", "6869"},
+		{"Hex decode", "68 69", "hi"},
+		{"MD5", "abc", "900150983cd24fb0d6963f7d28e17f72"},
+		{"SHA-1", "abc", "a9993e364706816aba3e25717850c26c9cd0d89d"},
+		{"SHA-256", "abc", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+	}
+	for _, tt := range tests {
+		got, err := apply(t, tt.op, tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("%s(%q) = %q, %v; want %q", tt.op, tt.input, got, err, tt.want)
+		}
+	}
+
+	for _, invalid := range []struct{ op, input string }{
+		{"Base64 decode", "a$b"},
+		{"URL decode", "%zz"},
+		{"Hex decode", "abc"},
+	} {
+		if _, err := apply(t, invalid.op, invalid.input); err == nil {
+			t.Errorf("%s(%q) expected an error", invalid.op, invalid.input)
+		}
+	}
+}
+
+func TestNewUUID(t *testing.T) {
+	v4 := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	first, second := NewUUID(), NewUUID()
+	if !v4.MatchString(first) || first == second {
+		t.Errorf("NewUUID() = %q, %q", first, second)
+	}
+}
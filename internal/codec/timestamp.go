@@ -0,0 +1,165 @@
+package codec
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	// Embedded so the zones convert the same on machines without tzdata
+	_ "time/tzdata"
+)
+
+// TimeUnit is how a timestamp was written
+type TimeUnit string
+
+const (
+	UnitSeconds TimeUnit = "epoch seconds"
+	UnitMillis  TimeUnit = "epoch milliseconds"
+	UnitMicros  TimeUnit = "epoch microseconds"
+	UnitNanos   TimeUnit = "epoch nanoseconds"
+	UnitDate    TimeUnit = "date"
+)
+
+// TimeZones are shown by the converter after UTC and local time
+var TimeZones = []string{
+	"America/Sao_Paulo",
+	"America/New_York",
+	"America/Los_Angeles",
+	"Europe/London",
+	"Europe/Berlin",
+	"Asia/Kolkata",
+	"Asia/Tokyo",
+	"Australia/Sydney",
+}
+
+// dateLayouts are the date formats ParseTimestamp accepts, tried in order
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+	time.RFC1123,
+	time.RFC1123Z,
+	time.RFC850,
+	time.ANSIC,
+}
+
+// ParseTimestamp reads an epoch timestamp or a date. The unit of an epoch
+// number is told by its size, so 1700000000 and 1700000000000 are the same
+// moment in seconds and in milliseconds. A date without a zone is UTC.
+func ParseTimestamp(s string) (time.Time, TimeUnit, error) {
+	s = strings.Trim(strings.TrimSpace(s), `"'`)
+	if s == "" {
+		return time.Time{}, "", fmt.Errorf("empty timestamp")
+	}
+
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		abs := math.Abs(float64(n))
+		switch {
+		case abs < 1e11:
+			return time.Unix(n, 0), UnitSeconds, nil
+		case abs < 1e14:
+			return time.UnixMilli(n), UnitMillis, nil
+		case abs < 1e17:
+			return time.UnixMicro(n), UnitMicros, nil
+		default:
+			return time.Unix(0, n), UnitNanos, nil
+		}
+	}
+
+	// Fractional seconds, as Python's time.time() prints them
+	if f, err := strconv.ParseFloat(s, 64); err == nil && !strings.ContainsAny(s, "eE") {
+		sec, frac := math.Modf(f)
+		return time.Unix(int64(sec), int64(math.Round(frac*1e9))), UnitSeconds, nil
+	}
+
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, UnitDate, nil
+		}
+	}
+	return time.Time{}, "", fmt.Errorf("not a timestamp: use epoch seconds or milliseconds, or an ISO-8601 date")
+}
+
+// Conversion is the time written one way
+type Conversion struct {
+	Name  string
+	Value string
+}
+
+// ConvertTime writes t as epoch seconds and milliseconds, as ISO-8601 in
+// UTC, local time and every zone of TimeZones, and as an HTTP date
+func ConvertTime(t time.Time) []Conversion {
+	conversions := []Conversion{
+		{"Epoch seconds", strconv.FormatInt(t.Unix(), 10)},
+		{"Epoch milliseconds", strconv.FormatInt(t.UnixMilli(), 10)},
+		{"ISO-8601 UTC", formatISO(t.UTC())},
+		{"Local (" + t.Local().Format("MST") + ")", formatISO(t.Local())},
+	}
+	for _, name := range TimeZones {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			continue
+		}
+		conversions = append(conversions, Conversion{name, formatISO(t.In(loc))})
+	}
+	return append(conversions, Conversion{"HTTP date", t.UTC().Format("Mon, 02 Jan 2006 15:04:05 GMT")})
+}
+
+// formatISO writes t as RFC 3339, with milliseconds only when it has them
+func formatISO(t time.Time) string {
+	if t.Nanosecond() == 0 {
+		return t.Format(time.RFC3339)
+	}
+	return t.Format("2006-01-02T15:04:05.000Z07:00")
+}
+
+var (
+	epochPattern = regexp.MustCompile(`\b\d{10}(?:\d{3})?(?:\.\d+)?\b`)
+	datePattern  = regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:\d{2})?`)
+
+	// Epoch numbers are only taken for timestamps between these, so ids
+	// and counts of the same length are left alone
+	earliestTimestamp = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	latestTimestamp   = time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// FindTimestamps returns the values in text that look like timestamps:
+// epoch seconds or milliseconds from this century and ISO-8601 dates, in
+// the order they appear and without repeats
+func FindTimestamps(text string) []string {
+	type match struct {
+		start int
+		value string
+	}
+	var matches []match
+	for _, loc := range datePattern.FindAllStringIndex(text, -1) {
+		matches = append(matches, match{loc[0], text[loc[0]:loc[1]]})
+	}
+	for _, loc := range epochPattern.FindAllStringIndex(text, -1) {
+		value := text[loc[0]:loc[1]]
+		t, _, err := ParseTimestamp(value)
+		if err != nil || t.Before(earliestTimestamp) || t.After(latestTimestamp) {
+			continue
+		}
+		matches = append(matches, match{loc[0], value})
+	}
+
+	// Two passes found them, so put them back in text order
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+
+	var found []string
+	seen := make(map[string]bool)
+	for _, m := range matches {
+		if !seen[m.value] {
+			seen[m.value] = true
+			found = append(found, m.value)
+		}
+	}
+	return found
+}
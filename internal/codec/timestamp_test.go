@@ -0,0 +1,85 @@
+package codec
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	moment := time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC)
+	tests := []struct {
+		input string
+		want  time.Time
+		unit  TimeUnit
+	}{
+		{"1700000000", moment, UnitSeconds},
+		{" \"1700000000\" ", moment, UnitSeconds},
+		{"1700000000000", moment, UnitMillis},
+		{"1700000000000000", moment, UnitMicros},
+		{"1700000000000000000", moment, UnitNanos},
+		{"1700000000.25", moment.Add(250 * time.Millisecond), UnitSeconds},
+		{"2023-11-14T22:13:20Z", moment, UnitDate},
+		{"2023-11-14T19:13:20-03:00", moment, UnitDate},
+		{"2023-11-14T22:13:20", moment, UnitDate},
+		{"2023-11-14 22:13:20.5", moment.Add(500 * time.Millisecond), UnitDate},
+		{"2023-11-14", time.Date(2023, 11, 14, 0, 0, 0, 0, time.UTC), UnitDate},
+		{"Tue, 14 Nov 2023 22:13:20 GMT", moment, UnitDate},
+	}
+	for _, tt := range tests {
+		got, unit, err := ParseTimestamp(tt.input)
+		if err != nil || !got.Equal(tt.want) || unit != tt.unit {
+			t.Errorf("ParseTimestamp(%q) = %v, %q, %v; want %v, %q", tt.input, got, unit, err, tt.want, tt.unit)
+		}
+	}
+
+	for _, input := range []string{"", "soon", "1e9", "14/11/2023"} {
+		if _, _, err := ParseTimestamp(input); err == nil {
+			t.Errorf("ParseTimestamp(%q) expected an error", input)
+		}
+	}
+}
+
+func TestConvertTime(t *testing.T) {
+	conversions := ConvertTime(time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC))
+	values := make(map[string]string, len(conversions))
+	for _, c := range conversions {
+		values[c.Name] = c.Value
+	}
+
+	want := map[string]string{
+		"Epoch seconds":      "1700000000",
+		"Epoch milliseconds": "1700000000000",
+		"ISO-8601 UTC":       "2023-11-14T22:13:20Z",
+		"America/Sao_Paulo":  "2023-11-14T19:13:20-03:00",
+		"Asia/Tokyo":         "2023-11-15T07:13:20+09:00",
+		"HTTP date":          "Tue, 14 Nov 2023 22:13:20 GMT",
+	}
+	for name, value := range want {
+		if values[name] != value {
+			t.Errorf("%s = %q, want %q", name, values[name], value)
+		}
+	}
+	if len(conversions) != 5+len(TimeZones) {
+		t.Errorf("Expected a conversion per zone, got %d", len(conversions))
+	}
+
+	millis := ConvertTime(time.UnixMilli(1700000000250))
+	if millis[2].Value != "2023-11-14T22:13:20.250Z" {
+		t.Errorf("Expected milliseconds to be kept, got %q", millis[2].Value)
+	}
+}
+
+func TestFindTimestamps(t *testing.T) {
+	text := `{"id": 1234567890123456, "count": 42, "created_at": "2023-11-14T22:13:20Z",
+		"updated": 1700000000, "expires_ms": 1700003600000, "phone": 5511999999999,
+		"again": 1700000000, "seen": "2023-11-14 22:13:20.5+01:00"}`
+
+	want := []string{"2023-11-14T22:13:20Z", "1700000000", "1700003600000", "2023-11-14 22:13:20.5+01:00"}
+	if got := FindTimestamps(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindTimestamps() = %q, want %q", got, want)
+	}
+	if got := FindTimestamps("no dates here"); got != nil {
+		t.Errorf("FindTimestamps() = %q, want none", got)
+	}
+}
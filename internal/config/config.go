@@ -3,11 +3,14 @@ package config
 
 import (
 	"os"
-	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/abneribeiro/godev/internal/errors"
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/paths"
 )
 
 // Config holds the application configuration
@@ -18,10 +21,30 @@ type Config struct {
 
 	// Storage settings
 	ConfigDir string
+	// HistorySync is the fsync policy of the background history writer:
+	// "always" or "never"
+	HistorySync          string
+	HistoryFlushInterval time.Duration
 
 	// HTTP settings
 	HTTPTimeout time.Duration
 	MaxRetries  int
+	// RetryBackoff is the wait before the first retry, doubled before each
+	// one after it
+	RetryBackoff time.Duration
+	// DiffIgnore lists headers and body fields left out of response
+	// comparisons, e.g. "$.data[*].updated_at" or "header:Date"
+	DiffIgnore []string
+	// Proxy is the proxy for every request (http://, https:// or
+	// socks5://), NoProxy the hosts reached directly, and ProxyFromEnv
+	// uses HTTP_PROXY, HTTPS_PROXY and NO_PROXY when Proxy is empty
+	Proxy        string
+	NoProxy      string
+	ProxyFromEnv bool
+	// TLSInsecure skips verifying server certificates and CAFile is a PEM
+	// bundle trusted on top of the system roots
+	TLSInsecure bool
+	CAFile      string
 
 	// Database settings
 	DBConnectTimeout time.Duration
@@ -39,6 +62,23 @@ type Config struct {
 
 	// UI settings
 	EnableColors bool
+	ReadOnly     bool
+	Language     string
+	Theme        map[string]string
+	KeyBindings  map[string][]string
+	// AutoSave saves or updates the request definition on every 2xx response
+	AutoSave bool
+	// ListDensity is "compact", one line per list item, or "detailed", a
+	// second line with the URL or a preview
+	ListDensity string
+	// RestoreSession reopens the screen, request and SQL editor left on the
+	// last exit
+	RestoreSession bool
+
+	// Notification settings
+	NotifyAfter time.Duration
+	NotifyBell  bool
+	NotifyOSC   bool
 }
 
 // DefaultConfig returns the default configuration
@@ -47,12 +87,18 @@ func DefaultConfig() *Config {
 		Version: "0.4.0",
 		AppName: "godev",
 
-		// Use XDG Base Directory spec
-		ConfigDir: getConfigDir(),
+		// --config-dir, GODEV_HOME or the XDG Base Directory spec
+		ConfigDir: paths.ConfigDir(),
+
+		// History is written in batches, fsynced once per batch
+		HistorySync:          "always",
+		HistoryFlushInterval: 250 * time.Millisecond,
 
 		// HTTP defaults
-		HTTPTimeout: 30 * time.Second,
-		MaxRetries:  3,
+		HTTPTimeout:  30 * time.Second,
+		MaxRetries:   3,
+		RetryBackoff: 500 * time.Millisecond,
+		ProxyFromEnv: true,
 
 		// Database defaults
 		DBConnectTimeout: 10 * time.Second,
@@ -70,13 +116,23 @@ func DefaultConfig() *Config {
 
 		// UI defaults
 		EnableColors: true,
+		Language:     "en",
+		ListDensity:  "compact",
+
+		// Notification defaults
+		NotifyAfter: 5 * time.Second,
 	}
 }
 
-// LoadFromEnv loads configuration from environment variables
+// LoadFromEnv loads configuration from the stored profile and environment variables
 func LoadFromEnv() (*Config, error) {
 	config := DefaultConfig()
 
+	// Settings from the stored profile come before environment variables
+	if err := applyStoredProfile(config); err != nil {
+		return nil, err
+	}
+
 	// Override with environment variables if present
 	if timeout := os.Getenv("GODEV_HTTP_TIMEOUT"); timeout != "" {
 		if d, err := time.ParseDuration(timeout); err == nil {
@@ -90,6 +146,12 @@ func LoadFromEnv() (*Config, error) {
 		}
 	}
 
+	if backoff := os.Getenv("GODEV_RETRY_BACKOFF"); backoff != "" {
+		if d, err := time.ParseDuration(backoff); err == nil {
+			config.RetryBackoff = d
+		}
+	}
+
 	if dbTimeout := os.Getenv("GODEV_DB_TIMEOUT"); dbTimeout != "" {
 		if d, err := time.ParseDuration(dbTimeout); err == nil {
 			config.DBConnectTimeout = d
@@ -102,6 +164,10 @@ func LoadFromEnv() (*Config, error) {
 		}
 	}
 
+	if diffIgnore := os.Getenv("GODEV_DIFF_IGNORE"); diffIgnore != "" {
+		config.DiffIgnore = strings.Split(diffIgnore, ",")
+	}
+
 	if logLevel := os.Getenv("GODEV_LOG_LEVEL"); logLevel != "" {
 		config.LogLevel = logLevel
 	}
@@ -116,10 +182,55 @@ func LoadFromEnv() (*Config, error) {
 		}
 	}
 
+	// NO_COLOR (https://no-color.org) disables colors whatever its value
+	if os.Getenv("NO_COLOR") != "" {
+		config.EnableColors = false
+	}
+
 	if colors := os.Getenv("GODEV_ENABLE_COLORS"); colors != "" {
 		config.EnableColors = colors != "false" && colors != "0"
 	}
 
+	if notifyAfter := os.Getenv("GODEV_NOTIFY_AFTER"); notifyAfter != "" {
+		if d, err := time.ParseDuration(notifyAfter); err == nil {
+			config.NotifyAfter = d
+		}
+	}
+
+	if bell := os.Getenv("GODEV_NOTIFY_BELL"); bell != "" {
+		config.NotifyBell = bell == "true" || bell == "1"
+	}
+
+	if osc := os.Getenv("GODEV_NOTIFY_OSC"); osc != "" {
+		config.NotifyOSC = osc == "true" || osc == "1"
+	}
+
+	if lang := os.Getenv("GODEV_LANG"); lang != "" {
+		config.Language = lang
+	}
+
+	if historySync := os.Getenv("GODEV_HISTORY_SYNC"); historySync != "" {
+		config.HistorySync = historySync
+	}
+
+	if flushInterval := os.Getenv("GODEV_HISTORY_FLUSH_INTERVAL"); flushInterval != "" {
+		if d, err := time.ParseDuration(flushInterval); err == nil {
+			config.HistoryFlushInterval = d
+		}
+	}
+
+	if readOnly := os.Getenv("GODEV_READ_ONLY"); readOnly != "" {
+		config.ReadOnly = readOnly == "true" || readOnly == "1"
+	}
+
+	if autoSave := os.Getenv("GODEV_AUTO_SAVE"); autoSave != "" {
+		config.AutoSave = autoSave == "true" || autoSave == "1"
+	}
+
+	if restore := os.Getenv("GODEV_RESTORE_SESSION"); restore != "" {
+		config.RestoreSession = restore == "true" || restore == "1"
+	}
+
 	// Validate configuration
 	if err := config.Validate(); err != nil {
 		return nil, err
@@ -138,6 +249,10 @@ func (c *Config) Validate() error {
 		return errors.NewConfigError("max retries cannot be negative", nil)
 	}
 
+	if c.RetryBackoff < 0 {
+		return errors.NewConfigError("retry backoff cannot be negative", nil)
+	}
+
 	if c.DBConnectTimeout <= 0 {
 		return errors.NewConfigError("database connect timeout must be positive", nil)
 	}
@@ -170,23 +285,45 @@ func (c *Config) Validate() error {
 		return errors.NewConfigError("invalid log format", nil)
 	}
 
+	if c.HistorySync != "always" && c.HistorySync != "never" {
+		return errors.NewConfigError("history sync must be always or never", nil)
+	}
+
+	if c.HistoryFlushInterval <= 0 {
+		return errors.NewConfigError("history flush interval must be positive", nil)
+	}
+
+	if _, err := i18n.Parse(c.Language); err != nil {
+		return errors.NewConfigError("invalid language", err)
+	}
+
+	if _, err := httpclient.ParseIgnoreRules(c.DiffIgnore); err != nil {
+		return errors.NewConfigError("invalid diff ignore rule", err)
+	}
+
+	if err := c.ProxySettings().Validate(); err != nil {
+		return errors.NewConfigError("invalid proxy", err)
+	}
+
 	return nil
 }
 
-// getConfigDir returns the configuration directory following XDG Base Directory spec
-func getConfigDir() string {
-	// Try XDG_CONFIG_HOME first
-	if configHome := os.Getenv("XDG_CONFIG_HOME"); configHome != "" {
-		return filepath.Join(configHome, "godev")
+// ProxySettings returns the proxy settings of the HTTP client
+func (c *Config) ProxySettings() httpclient.ProxySettings {
+	return httpclient.ProxySettings{
+		URL:             c.Proxy,
+		NoProxy:         c.NoProxy,
+		FromEnvironment: c.ProxyFromEnv,
 	}
+}
 
-	// Fall back to ~/.config/godev
-	if homeDir, err := os.UserHomeDir(); err == nil {
-		return filepath.Join(homeDir, ".config", "godev")
+// TLSSettings returns the TLS settings of the HTTP client. Client
+// certificates belong to environments.
+func (c *Config) TLSSettings() httpclient.TLSSettings {
+	return httpclient.TLSSettings{
+		InsecureSkipVerify: c.TLSInsecure,
+		CAFile:             c.CAFile,
 	}
-
-	// Ultimate fallback to current directory
-	return ".godev"
 }
 
 // EnsureConfigDir ensures the configuration directory exists
@@ -0,0 +1,331 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/abneribeiro/godev/internal/errors"
+	httpclient "github.com/abneribeiro/godev/internal/http"
+)
+
+// ProfileFile is the name of the settings profile inside the config directory
+const ProfileFile = "profile.json"
+
+const profileVersion = "1"
+
+// ProfileSettings are the settings carried by a profile. Empty fields keep
+// the default value.
+type ProfileSettings struct {
+	HTTPTimeout  string `json:"http_timeout,omitempty"`
+	MaxRetries   *int   `json:"max_retries,omitempty"`
+	RetryBackoff string `json:"retry_backoff,omitempty"`
+	LogLevel     string `json:"log_level,omitempty"`
+	LogFormat    string `json:"log_format,omitempty"`
+	EnableColors *bool  `json:"enable_colors,omitempty"`
+	ReadOnly     *bool  `json:"read_only,omitempty"`
+	AutoSave     *bool  `json:"auto_save,omitempty"`
+	Language     string `json:"language,omitempty"`
+	NotifyAfter  string `json:"notify_after,omitempty"`
+	NotifyBell   *bool  `json:"notify_bell,omitempty"`
+	NotifyOSC    *bool  `json:"notify_osc,omitempty"`
+	// DiffIgnore lists headers and body fields left out of response comparisons
+	DiffIgnore []string `json:"diff_ignore,omitempty"`
+	// Proxy, NoProxy and ProxyFromEnv choose the proxy of the HTTP client
+	Proxy        string `json:"proxy,omitempty"`
+	NoProxy      string `json:"no_proxy,omitempty"`
+	ProxyFromEnv *bool  `json:"proxy_from_env,omitempty"`
+	// TLSInsecure and CAFile choose how server certificates are verified
+	TLSInsecure *bool  `json:"tls_insecure,omitempty"`
+	CAFile      string `json:"ca_file,omitempty"`
+	// ListDensity is "compact" or "detailed"
+	ListDensity string `json:"list_density,omitempty"`
+	// RestoreSession reopens where the last run left off
+	RestoreSession *bool `json:"restore_session,omitempty"`
+}
+
+// Profile bundles settings, theme colors and key bindings so a customized
+// setup can be moved to another machine
+type Profile struct {
+	Version     string              `json:"version"`
+	Settings    ProfileSettings     `json:"settings"`
+	Theme       map[string]string   `json:"theme,omitempty"`
+	KeyBindings map[string][]string `json:"keybindings,omitempty"`
+}
+
+// ProfilePath returns where the active profile is stored
+func (c *Config) ProfilePath() string {
+	return filepath.Join(c.ConfigDir, ProfileFile)
+}
+
+// NewProfile captures the current configuration as a profile
+func NewProfile(c *Config) *Profile {
+	maxRetries := c.MaxRetries
+	enableColors := c.EnableColors
+	readOnly := c.ReadOnly
+	autoSave := c.AutoSave
+	notifyBell := c.NotifyBell
+	notifyOSC := c.NotifyOSC
+	proxyFromEnv := c.ProxyFromEnv
+	tlsInsecure := c.TLSInsecure
+	restoreSession := c.RestoreSession
+
+	return &Profile{
+		Version: profileVersion,
+		Settings: ProfileSettings{
+			HTTPTimeout:    c.HTTPTimeout.String(),
+			MaxRetries:     &maxRetries,
+			RetryBackoff:   c.RetryBackoff.String(),
+			LogLevel:       c.LogLevel,
+			LogFormat:      c.LogFormat,
+			EnableColors:   &enableColors,
+			ReadOnly:       &readOnly,
+			AutoSave:       &autoSave,
+			Language:       c.Language,
+			NotifyAfter:    c.NotifyAfter.String(),
+			NotifyBell:     &notifyBell,
+			NotifyOSC:      &notifyOSC,
+			DiffIgnore:     c.DiffIgnore,
+			Proxy:          c.Proxy,
+			NoProxy:        c.NoProxy,
+			ProxyFromEnv:   &proxyFromEnv,
+			TLSInsecure:    &tlsInsecure,
+			CAFile:         c.CAFile,
+			ListDensity:    c.ListDensity,
+			RestoreSession: &restoreSession,
+		},
+		Theme:       c.Theme,
+		KeyBindings: c.KeyBindings,
+	}
+}
+
+// LoadProfile reads a profile file
+func LoadProfile(path string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var profile Profile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, errors.NewConfigError("failed to parse profile "+path, err)
+	}
+
+	return &profile, nil
+}
+
+// Save writes the profile as indented JSON
+func (p *Profile) Save(path string) error {
+	if p.Version == "" {
+		p.Version = profileVersion
+	}
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return errors.NewConfigError("failed to marshal profile", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return errors.NewConfigError("failed to create profile directory", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return errors.NewConfigError("failed to write profile", err)
+	}
+
+	return nil
+}
+
+// Apply overrides the configuration with the values set in the profile
+func (p *Profile) Apply(c *Config) error {
+	s := p.Settings
+
+	if s.HTTPTimeout != "" {
+		d, err := time.ParseDuration(s.HTTPTimeout)
+		if err != nil {
+			return errors.NewConfigError("invalid http_timeout in profile", err)
+		}
+		c.HTTPTimeout = d
+	}
+
+	if s.MaxRetries != nil {
+		c.MaxRetries = *s.MaxRetries
+	}
+
+	if s.RetryBackoff != "" {
+		d, err := time.ParseDuration(s.RetryBackoff)
+		if err != nil {
+			return errors.NewConfigError("invalid retry_backoff in profile", err)
+		}
+		c.RetryBackoff = d
+	}
+
+	if s.LogLevel != "" {
+		c.LogLevel = s.LogLevel
+	}
+
+	if s.LogFormat != "" {
+		c.LogFormat = s.LogFormat
+	}
+
+	if s.EnableColors != nil {
+		c.EnableColors = *s.EnableColors
+	}
+
+	if s.ReadOnly != nil {
+		c.ReadOnly = *s.ReadOnly
+	}
+
+	if s.AutoSave != nil {
+		c.AutoSave = *s.AutoSave
+	}
+
+	if s.Language != "" {
+		c.Language = s.Language
+	}
+
+	if s.NotifyAfter != "" {
+		d, err := time.ParseDuration(s.NotifyAfter)
+		if err != nil {
+			return errors.NewConfigError("invalid notify_after in profile", err)
+		}
+		c.NotifyAfter = d
+	}
+
+	if s.NotifyBell != nil {
+		c.NotifyBell = *s.NotifyBell
+	}
+
+	if s.NotifyOSC != nil {
+		c.NotifyOSC = *s.NotifyOSC
+	}
+
+	if len(s.DiffIgnore) > 0 {
+		c.DiffIgnore = s.DiffIgnore
+	}
+
+	if s.Proxy != "" {
+		c.Proxy = s.Proxy
+	}
+
+	if s.NoProxy != "" {
+		c.NoProxy = s.NoProxy
+	}
+
+	if s.ProxyFromEnv != nil {
+		c.ProxyFromEnv = *s.ProxyFromEnv
+	}
+
+	if s.TLSInsecure != nil {
+		c.TLSInsecure = *s.TLSInsecure
+	}
+
+	if s.CAFile != "" {
+		c.CAFile = s.CAFile
+	}
+
+	if s.ListDensity != "" {
+		c.ListDensity = s.ListDensity
+	}
+
+	if s.RestoreSession != nil {
+		c.RestoreSession = *s.RestoreSession
+	}
+
+	if len(p.Theme) > 0 {
+		c.Theme = p.Theme
+	}
+
+	if len(p.KeyBindings) > 0 {
+		c.KeyBindings = p.KeyBindings
+	}
+
+	return nil
+}
+
+// applyStoredProfile applies the profile kept in the config directory, if any
+func applyStoredProfile(c *Config) error {
+	profile, err := LoadProfile(c.ProfilePath())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return profile.Apply(c)
+}
+
+// updateStoredProfile changes the stored profile with update, leaving the
+// settings it does not touch as they are
+func (c *Config) updateStoredProfile(update func(*ProfileSettings)) error {
+	profile, err := LoadProfile(c.ProfilePath())
+	if os.IsNotExist(err) {
+		profile = &Profile{}
+	} else if err != nil {
+		return err
+	}
+
+	update(&profile.Settings)
+	return profile.Save(c.ProfilePath())
+}
+
+// SaveProxySettings keeps the proxy settings in the stored profile and
+// applies them to the configuration
+func (c *Config) SaveProxySettings(s httpclient.ProxySettings) error {
+	if err := s.Validate(); err != nil {
+		return errors.NewConfigError("invalid proxy", err)
+	}
+
+	fromEnv := s.FromEnvironment
+	err := c.updateStoredProfile(func(p *ProfileSettings) {
+		p.Proxy = s.URL
+		p.NoProxy = s.NoProxy
+		p.ProxyFromEnv = &fromEnv
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Proxy = s.URL
+	c.NoProxy = s.NoProxy
+	c.ProxyFromEnv = s.FromEnvironment
+	return nil
+}
+
+// SaveTLSSettings keeps the verification settings in the stored profile
+// and applies them to the configuration. Client certificates are left to
+// environments.
+func (c *Config) SaveTLSSettings(s httpclient.TLSSettings) error {
+	global := httpclient.TLSSettings{InsecureSkipVerify: s.InsecureSkipVerify, CAFile: s.CAFile}
+	if err := global.Validate(); err != nil {
+		return errors.NewConfigError("invalid TLS settings", err)
+	}
+
+	insecure := s.InsecureSkipVerify
+	err := c.updateStoredProfile(func(p *ProfileSettings) {
+		p.TLSInsecure = &insecure
+		p.CAFile = s.CAFile
+	})
+	if err != nil {
+		return err
+	}
+
+	c.TLSInsecure = s.InsecureSkipVerify
+	c.CAFile = s.CAFile
+	return nil
+}
+
+// SaveListDensity keeps the density of the lists in the stored profile and
+// applies it to the configuration
+func (c *Config) SaveListDensity(density string) error {
+	err := c.updateStoredProfile(func(p *ProfileSettings) {
+		p.ListDensity = density
+	})
+	if err != nil {
+		return err
+	}
+
+	c.ListDensity = density
+	return nil
+}
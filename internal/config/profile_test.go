@@ -0,0 +1,207 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+)
+
+func TestProfileRoundTrip(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.HTTPTimeout = 45 * time.Second
+	cfg.RetryBackoff = 2 * time.Second
+	cfg.ReadOnly = true
+	cfg.Theme = map[string]string{"accent": "#00AAFF"}
+	cfg.KeyBindings = map[string][]string{"quit": {"ctrl+x"}}
+
+	path := filepath.Join(t.TempDir(), "work.json")
+	if err := NewProfile(cfg).Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	profile, err := LoadProfile(path)
+	if err != nil {
+		t.Fatalf("LoadProfile() error = %v", err)
+	}
+
+	imported := DefaultConfig()
+	if err := profile.Apply(imported); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	if imported.HTTPTimeout != 45*time.Second || !imported.ReadOnly {
+		t.Errorf("Settings not applied: timeout=%v read-only=%v", imported.HTTPTimeout, imported.ReadOnly)
+	}
+	if imported.RetryBackoff != 2*time.Second {
+		t.Errorf("Retry backoff not applied: %v", imported.RetryBackoff)
+	}
+	if imported.Theme["accent"] != "#00AAFF" {
+		t.Errorf("Theme not applied: %v", imported.Theme)
+	}
+	if keys := imported.KeyBindings["quit"]; len(keys) != 1 || keys[0] != "ctrl+x" {
+		t.Errorf("Key bindings not applied: %v", imported.KeyBindings)
+	}
+}
+
+func TestProfileApplyKeepsUnsetSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "partial.json")
+	if err := os.WriteFile(path, []byte(`{"version": "1", "settings": {"notify_bell": true}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	profile, err := LoadProfile(path)
+	if err != nil {
+		t.Fatalf("LoadProfile() error = %v", err)
+	}
+
+	cfg := DefaultConfig()
+	if err := profile.Apply(cfg); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if !cfg.NotifyBell {
+		t.Error("Expected notify_bell to be applied")
+	}
+	if cfg.HTTPTimeout != DefaultConfig().HTTPTimeout || cfg.MaxRetries != DefaultConfig().MaxRetries {
+		t.Errorf("Unset settings changed: timeout=%v retries=%d", cfg.HTTPTimeout, cfg.MaxRetries)
+	}
+}
+
+func TestProfileApplyInvalidDuration(t *testing.T) {
+	profile := &Profile{Settings: ProfileSettings{HTTPTimeout: "soon"}}
+	if err := profile.Apply(DefaultConfig()); err == nil {
+		t.Error("Expected error for invalid http_timeout")
+	}
+}
+
+func TestLoadFromEnvAppliesStoredProfile(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Setenv("GODEV_HTTP_TIMEOUT", "")
+
+	profile := &Profile{Settings: ProfileSettings{HTTPTimeout: "12s"}}
+	if err := profile.Save(filepath.Join(configHome, "godev", ProfileFile)); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv() error = %v", err)
+	}
+	if cfg.HTTPTimeout != 12*time.Second {
+		t.Errorf("HTTPTimeout = %v, want 12s", cfg.HTTPTimeout)
+	}
+
+	// Environment variables still win over the profile
+	t.Setenv("GODEV_HTTP_TIMEOUT", "20s")
+	cfg, err = LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv() error = %v", err)
+	}
+	if cfg.HTTPTimeout != 20*time.Second {
+		t.Errorf("HTTPTimeout = %v, want 20s", cfg.HTTPTimeout)
+	}
+}
+
+func TestDiffIgnoreFromEnv(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GODEV_DIFF_IGNORE", "$.data[*].updated_at,header:Date")
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv() error = %v", err)
+	}
+	if len(cfg.DiffIgnore) != 2 || cfg.DiffIgnore[1] != "header:Date" {
+		t.Errorf("DiffIgnore = %v", cfg.DiffIgnore)
+	}
+
+	t.Setenv("GODEV_DIFF_IGNORE", "$.data[x]")
+	if _, err := LoadFromEnv(); err == nil {
+		t.Error("Expected an error for an invalid ignore rule")
+	}
+}
+
+func TestSaveProxySettingsKeepsProfile(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ConfigDir = t.TempDir()
+	if err := os.WriteFile(cfg.ProfilePath(), []byte(`{"version": "1", "settings": {"notify_bell": true}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	settings := httpclient.ProxySettings{URL: "socks5://localhost:1080", NoProxy: "internal.example.com"}
+	if err := cfg.SaveProxySettings(settings); err != nil {
+		t.Fatalf("SaveProxySettings() error = %v", err)
+	}
+	if cfg.ProxySettings() != settings {
+		t.Errorf("ProxySettings() = %+v, want %+v", cfg.ProxySettings(), settings)
+	}
+
+	loaded := DefaultConfig()
+	loaded.ConfigDir = cfg.ConfigDir
+	if err := applyStoredProfile(loaded); err != nil {
+		t.Fatalf("applyStoredProfile() error = %v", err)
+	}
+	if loaded.ProxySettings() != settings || !loaded.NotifyBell {
+		t.Errorf("Expected the proxy saved next to notify_bell, got %+v bell=%v", loaded.ProxySettings(), loaded.NotifyBell)
+	}
+
+	if err := cfg.SaveProxySettings(httpclient.ProxySettings{URL: "ftp://proxy"}); err == nil {
+		t.Error("Expected an invalid proxy to be rejected")
+	}
+}
+
+func TestSaveTLSSettingsKeepsProfile(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ConfigDir = t.TempDir()
+	if err := cfg.SaveProxySettings(httpclient.ProxySettings{URL: "http://proxy:3128"}); err != nil {
+		t.Fatalf("SaveProxySettings() error = %v", err)
+	}
+
+	settings := httpclient.TLSSettings{InsecureSkipVerify: true}
+	if err := cfg.SaveTLSSettings(settings); err != nil {
+		t.Fatalf("SaveTLSSettings() error = %v", err)
+	}
+	if cfg.TLSSettings() != settings {
+		t.Errorf("TLSSettings() = %+v, want %+v", cfg.TLSSettings(), settings)
+	}
+
+	loaded := DefaultConfig()
+	loaded.ConfigDir = cfg.ConfigDir
+	if err := applyStoredProfile(loaded); err != nil {
+		t.Fatalf("applyStoredProfile() error = %v", err)
+	}
+	if loaded.TLSSettings() != settings || loaded.Proxy != "http://proxy:3128" {
+		t.Errorf("Expected the TLS settings saved next to the proxy, got %+v proxy=%q", loaded.TLSSettings(), loaded.Proxy)
+	}
+
+	missing := httpclient.TLSSettings{CAFile: cfg.ConfigDir + "/missing.pem"}
+	if err := cfg.SaveTLSSettings(missing); err == nil {
+		t.Error("Expected a missing CA bundle to be rejected")
+	}
+}
+
+func TestSaveListDensityKeepsProfile(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ConfigDir = t.TempDir()
+	if err := cfg.SaveProxySettings(httpclient.ProxySettings{URL: "http://proxy:3128"}); err != nil {
+		t.Fatalf("SaveProxySettings() error = %v", err)
+	}
+
+	if err := cfg.SaveListDensity("detailed"); err != nil {
+		t.Fatalf("SaveListDensity() error = %v", err)
+	}
+	if cfg.ListDensity != "detailed" {
+		t.Errorf("ListDensity = %q, want detailed", cfg.ListDensity)
+	}
+
+	loaded := DefaultConfig()
+	loaded.ConfigDir = cfg.ConfigDir
+	if err := applyStoredProfile(loaded); err != nil {
+		t.Fatalf("applyStoredProfile() error = %v", err)
+	}
+	if loaded.ListDensity != "detailed" || loaded.Proxy != "http://proxy:3128" {
+		t.Errorf("Expected the density saved next to the proxy, got %q proxy=%q", loaded.ListDensity, loaded.Proxy)
+	}
+}
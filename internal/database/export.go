@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/abneribeiro/godev/internal/paths"
 )
 
 type ExportFormat string
@@ -30,15 +32,9 @@ func ExportQueryResult(result *QueryResult, format ExportFormat, tableName strin
 		return ExportResult{Error: fmt.Errorf("no data to export")}
 	}
 
-	homeDir, err := os.UserHomeDir()
+	exportDir, err := paths.ExportDir()
 	if err != nil {
-		return ExportResult{Error: fmt.Errorf("failed to get home directory: %w", err)}
-	}
-
-	exportDir := filepath.Join(homeDir, ".godev", "exports")
-	// Use secure directory permissions (0700 - only owner can access)
-	if err := os.MkdirAll(exportDir, 0o700); err != nil {
-		return ExportResult{Error: fmt.Errorf("failed to create export directory: %w", err)}
+		return ExportResult{Error: err}
 	}
 
 	timestamp := time.Now().Format("20060102_150405")
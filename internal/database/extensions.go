@@ -0,0 +1,298 @@
+package database
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExtensionInfo describes an installed PostgreSQL extension
+type ExtensionInfo struct {
+	Name    string
+	Version string
+}
+
+// maxVectorPreview limits how many vector components are shown in results
+const maxVectorPreview = 8
+
+// GetExtensions returns the extensions installed in the connected database
+func (c *PostgresClient) GetExtensions() ([]ExtensionInfo, error) {
+	if c.db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+
+	rows, err := c.db.Query(`SELECT extname, extversion FROM pg_extension ORDER BY extname`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var extensions []ExtensionInfo
+	for rows.Next() {
+		var ext ExtensionInfo
+		if err := rows.Scan(&ext.Name, &ext.Version); err != nil {
+			return nil, err
+		}
+		extensions = append(extensions, ext)
+	}
+
+	return extensions, rows.Err()
+}
+
+// HasExtension reports whether the extension was detected when connecting
+func (c *PostgresClient) HasExtension(name string) bool {
+	return c.extensions[name]
+}
+
+// DetectedExtensions returns the sorted names of extensions found when connecting
+func (c *PostgresClient) DetectedExtensions() []string {
+	names := make([]string, 0, len(c.extensions))
+	for name := range c.extensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// detectExtensions caches the installed extension names for result rendering
+func (c *PostgresClient) detectExtensions() error {
+	extensions, err := c.GetExtensions()
+	if err != nil {
+		return err
+	}
+
+	c.extensions = make(map[string]bool, len(extensions))
+	for _, ext := range extensions {
+		c.extensions[ext.Name] = true
+	}
+	return nil
+}
+
+// formatExtensionValue renders values of extension types (pgvector, hstore,
+// PostGIS) readably. The driver does not know the OIDs of extension types, so
+// the value is recognised by its wire format, only for installed extensions.
+func formatExtensionValue(raw []byte, extensions map[string]bool) (string, bool) {
+	text := string(raw)
+
+	if extensions["vector"] {
+		if formatted, ok := formatVector(text); ok {
+			return formatted, true
+		}
+	}
+
+	if extensions["postgis"] {
+		if formatted, ok := formatEWKB(text); ok {
+			return formatted, true
+		}
+	}
+
+	if extensions["hstore"] {
+		if formatted, ok := formatHstore(text); ok {
+			return formatted, true
+		}
+	}
+
+	return "", false
+}
+
+// formatVector renders a pgvector value such as "[1,2,3]" as a short preview
+func formatVector(text string) (string, bool) {
+	if !strings.HasPrefix(text, "[") || !strings.HasSuffix(text, "]") {
+		return "", false
+	}
+
+	inner := strings.TrimSpace(text[1 : len(text)-1])
+	if inner == "" {
+		return "vector(0) []", true
+	}
+
+	parts := strings.Split(inner, ",")
+	for _, p := range parts {
+		if _, err := strconv.ParseFloat(strings.TrimSpace(p), 64); err != nil {
+			return "", false
+		}
+	}
+
+	preview := parts
+	suffix := ""
+	if len(parts) > maxVectorPreview {
+		preview = parts[:maxVectorPreview]
+		suffix = ", …"
+	}
+
+	for i := range preview {
+		preview[i] = strings.TrimSpace(preview[i])
+	}
+
+	return fmt.Sprintf("vector(%d) [%s%s]", len(parts), strings.Join(preview, ", "), suffix), true
+}
+
+// formatHstore renders an hstore value such as `"a"=>"1", "b"=>NULL` as {a: 1, b: NULL}
+func formatHstore(text string) (string, bool) {
+	if !strings.HasPrefix(text, `"`) || !strings.Contains(text, `"=>`) {
+		return "", false
+	}
+
+	var pairs []string
+	rest := text
+	for rest != "" {
+		key, remaining, ok := readHstoreToken(rest)
+		if !ok || !strings.HasPrefix(remaining, "=>") {
+			return "", false
+		}
+
+		value, remaining, ok := readHstoreToken(strings.TrimPrefix(remaining, "=>"))
+		if !ok {
+			return "", false
+		}
+
+		pairs = append(pairs, fmt.Sprintf("%s: %s", key, value))
+		rest = strings.TrimPrefix(strings.TrimSpace(remaining), ",")
+		rest = strings.TrimSpace(rest)
+	}
+
+	return "{" + strings.Join(pairs, ", ") + "}", true
+}
+
+// readHstoreToken reads a quoted string or NULL from the start of s
+func readHstoreToken(s string) (string, string, bool) {
+	if strings.HasPrefix(s, "NULL") {
+		return "NULL", s[len("NULL"):], true
+	}
+	if !strings.HasPrefix(s, `"`) {
+		return "", "", false
+	}
+
+	var sb strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				sb.WriteByte(s[i])
+			}
+		case '"':
+			return sb.String(), s[i+1:], true
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+
+	return "", "", false
+}
+
+// EWKB geometry type codes and flags used by PostGIS
+const (
+	wkbPoint              = 1
+	wkbLineString         = 2
+	wkbPolygon            = 3
+	wkbMultiPoint         = 4
+	wkbMultiLineString    = 5
+	wkbMultiPolygon       = 6
+	wkbGeometryCollection = 7
+
+	ewkbZFlag    = 0x80000000
+	ewkbMFlag    = 0x40000000
+	ewkbSRIDFlag = 0x20000000
+)
+
+var wkbTypeNames = map[uint32]string{
+	wkbPoint:              "POINT",
+	wkbLineString:         "LINESTRING",
+	wkbPolygon:            "POLYGON",
+	wkbMultiPoint:         "MULTIPOINT",
+	wkbMultiLineString:    "MULTILINESTRING",
+	wkbMultiPolygon:       "MULTIPOLYGON",
+	wkbGeometryCollection: "GEOMETRYCOLLECTION",
+}
+
+// formatEWKB summarises a hex-encoded PostGIS geometry as WKT. Points are
+// shown in full, other geometries as their type and element count.
+func formatEWKB(text string) (string, bool) {
+	if len(text) < 18 || len(text)%2 != 0 {
+		return "", false
+	}
+
+	data, err := hex.DecodeString(text)
+	if err != nil {
+		return "", false
+	}
+
+	var order binary.ByteOrder
+	switch data[0] {
+	case 0:
+		order = binary.BigEndian
+	case 1:
+		order = binary.LittleEndian
+	default:
+		return "", false
+	}
+
+	typ := order.Uint32(data[1:5])
+	geomType := typ & 0x0FFFFFFF
+	name, ok := wkbTypeNames[geomType]
+	if !ok {
+		return "", false
+	}
+
+	dims := 2
+	dimSuffix := ""
+	if typ&ewkbZFlag != 0 {
+		dims++
+		dimSuffix += "Z"
+	}
+	if typ&ewkbMFlag != 0 {
+		dims++
+		dimSuffix += "M"
+	}
+	if dimSuffix != "" {
+		name += " " + dimSuffix
+	}
+
+	offset := 5
+	prefix := ""
+	if typ&ewkbSRIDFlag != 0 {
+		if len(data) < offset+4 {
+			return "", false
+		}
+		prefix = fmt.Sprintf("SRID=%d;", order.Uint32(data[offset:offset+4]))
+		offset += 4
+	}
+
+	if geomType == wkbPoint {
+		if len(data) < offset+8*dims {
+			return "", false
+		}
+		coords := make([]string, dims)
+		for i := 0; i < dims; i++ {
+			bits := order.Uint64(data[offset+8*i : offset+8*(i+1)])
+			coords[i] = strconv.FormatFloat(math.Float64frombits(bits), 'f', -1, 64)
+		}
+		return fmt.Sprintf("%s%s(%s)", prefix, name, strings.Join(coords, " ")), true
+	}
+
+	if len(data) < offset+4 {
+		return "", false
+	}
+	count := order.Uint32(data[offset : offset+4])
+
+	unit := "geometries"
+	switch geomType {
+	case wkbLineString:
+		unit = "points"
+	case wkbPolygon:
+		unit = "rings"
+	case wkbMultiPoint:
+		unit = "points"
+	case wkbMultiLineString:
+		unit = "lines"
+	case wkbMultiPolygon:
+		unit = "polygons"
+	}
+
+	return fmt.Sprintf("%s%s(%d %s)", prefix, name, count, unit), true
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"testing"
+)
+
+func TestFormatVector(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		ok       bool
+	}{
+		{"short vector", "[1,2,3]", "vector(3) [1, 2, 3]", true},
+		{"empty vector", "[]", "vector(0) []", true},
+		{"long vector", "[1,2,3,4,5,6,7,8,9,10]", "vector(10) [1, 2, 3, 4, 5, 6, 7, 8, …]", true},
+		{"not a vector", "[a,b]", "", false},
+		{"plain text", "hello", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := formatVector(tt.input)
+			if ok != tt.ok || got != tt.expected {
+				t.Errorf("formatVector(%q) = %q, %v; want %q, %v", tt.input, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestFormatHstore(t *testing.T) {
+	got, ok := formatHstore(`"a"=>"1", "b"=>NULL, "c\"q"=>"x"`)
+	if !ok {
+		t.Fatal("Expected hstore value to be recognised")
+	}
+
+	expected := `{a: 1, b: NULL, c"q: x}`
+	if got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	if _, ok := formatHstore("plain text"); ok {
+		t.Error("Expected plain text not to be recognised as hstore")
+	}
+}
+
+func TestFormatEWKB(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "point",
+			input:    "0101000000000000000000F03F0000000000000040",
+			expected: "POINT(1 2)",
+		},
+		{
+			name:     "point with SRID",
+			input:    "0101000020E6100000000000000000F03F0000000000000040",
+			expected: "SRID=4326;POINT(1 2)",
+		},
+		{
+			name:     "linestring",
+			input:    "01020000000200000000000000000000000000000000000000000000000000F03F000000000000F03F",
+			expected: "LINESTRING(2 points)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := formatEWKB(tt.input)
+			if !ok {
+				t.Fatalf("Expected %q to be recognised as EWKB", tt.input)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	if _, ok := formatEWKB("not hex at all"); ok {
+		t.Error("Expected invalid input to be rejected")
+	}
+}
+
+func TestFormatExtensionValueRequiresExtension(t *testing.T) {
+	if _, ok := formatExtensionValue([]byte("[1,2]"), map[string]bool{}); ok {
+		t.Error("Expected no formatting when the extension is not installed")
+	}
+
+	got, ok := formatExtensionValue([]byte("[1,2]"), map[string]bool{"vector": true})
+	if !ok || got != "vector(2) [1, 2]" {
+		t.Errorf("Unexpected result: %q, %v", got, ok)
+	}
+}
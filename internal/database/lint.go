@@ -0,0 +1,224 @@
+package database
+
+import (
+	"regexp"
+	"strings"
+)
+
+// LintRule names a mistake the query linter looks for
+type LintRule string
+
+const (
+	// LintSelectStar is SELECT *, which fetches every column
+	LintSelectStar LintRule = "select_star"
+	// LintMissingWhere is an UPDATE or DELETE that touches every row
+	LintMissingWhere LintRule = "missing_where"
+	// LintCrossJoin is a comma join without a WHERE to join on
+	LintCrossJoin LintRule = "cross_join"
+	// LintNonSargable is a filter no index on the column can serve: a
+	// function around the column or a LIKE starting with a wildcard
+	LintNonSargable LintRule = "non_sargable"
+)
+
+// LintWarning is one mistake found in a query
+type LintWarning struct {
+	Rule LintRule
+	// Line is where the mistake is, counted from 1
+	Line int
+	// Detail is the part of the query at fault, e.g. "lower(email)"
+	Detail string
+}
+
+var (
+	lintSelectStar   = regexp.MustCompile(`(?i)\bSELECT\s+(?:DISTINCT\s+)?(?:\w+\.)?\*`)
+	lintFirstWord    = regexp.MustCompile(`^\s*(\w+)`)
+	lintWhere        = regexp.MustCompile(`(?i)\bWHERE\b`)
+	lintFrom         = regexp.MustCompile(`(?i)\bFROM\b`)
+	lintLeadingLike  = regexp.MustCompile(`(?i)\bI?LIKE\s+'%`)
+	lintFunctionCall = regexp.MustCompile(`(?i)\b(\w+)\s*\(([^()]*)\)\s*(?:=|<>|!=|<=|>=|<|>|\bI?LIKE\b|\bBETWEEN\b|\bIN\b)`)
+	lintIdentifier   = regexp.MustCompile(`^[A-Za-z_][\w.]*$`)
+	// lintFromEnd are the keywords that end a FROM list
+	lintFromEnd = regexp.MustCompile(`(?i)^(WHERE|GROUP|ORDER|LIMIT|OFFSET|HAVING|UNION|INTERSECT|EXCEPT|WINDOW|FOR|RETURNING|ON|USING|JOIN|INNER|LEFT|RIGHT|FULL|CROSS|NATURAL)$`)
+	lintWord    = regexp.MustCompile(`\w+|[(),]`)
+)
+
+// lintNotFunctions are words followed by parentheses that are not calls on
+// a column
+var lintNotFunctions = map[string]bool{
+	"IN": true, "EXISTS": true, "ANY": true, "ALL": true, "SOME": true,
+	"AND": true, "OR": true, "NOT": true, "VALUES": true, "WHERE": true, "ON": true,
+}
+
+// LintQuery looks for common mistakes in the statements of query: SELECT
+// *, UPDATE or DELETE without WHERE, comma joins without WHERE and filters
+// that keep indexes from being used. It is a heuristic, not a parser;
+// comments and string literals are skipped.
+func LintQuery(query string) []LintWarning {
+	code, literal := maskSQL(query)
+	var warnings []LintWarning
+	lineAt := func(offset int) int {
+		return strings.Count(query[:offset], "\n") + 1
+	}
+
+	start := 0
+	for _, end := range append(statementEnds(code), len(code)) {
+		stmt := code[start:end]
+		if strings.TrimSpace(stmt) == "" {
+			start = end + 1
+			continue
+		}
+
+		for _, loc := range lintSelectStar.FindAllStringIndex(stmt, -1) {
+			warnings = append(warnings, LintWarning{Rule: LintSelectStar, Line: lineAt(start + loc[0]),
+				Detail: strings.Join(strings.Fields(stmt[loc[0]:loc[1]]), " ")})
+		}
+
+		if m := lintFirstWord.FindStringSubmatchIndex(stmt); m != nil {
+			verb := strings.ToUpper(stmt[m[2]:m[3]])
+			if (verb == "UPDATE" || verb == "DELETE") && !lintWhere.MatchString(stmt) {
+				warnings = append(warnings, LintWarning{Rule: LintMissingWhere, Line: lineAt(start + m[2]), Detail: verb})
+			}
+		}
+
+		for _, loc := range lintFrom.FindAllStringIndex(stmt, -1) {
+			if tables, ok := commaJoin(stmt[loc[1]:]); ok {
+				warnings = append(warnings, LintWarning{Rule: LintCrossJoin, Line: lineAt(start + loc[0]), Detail: tables})
+			}
+		}
+
+		for _, m := range lintFunctionCall.FindAllStringSubmatchIndex(stmt, -1) {
+			name := stmt[m[2]:m[3]]
+			args := strings.TrimSpace(stmt[m[4]:m[5]])
+			if lintNotFunctions[strings.ToUpper(name)] || !hasColumnArg(args) {
+				continue
+			}
+			warnings = append(warnings, LintWarning{Rule: LintNonSargable, Line: lineAt(start + m[2]),
+				Detail: name + "(" + strings.TrimSpace(literal[start+m[4]:start+m[5]]) + ")"})
+		}
+
+		// Patterns are in the literals, which code has blanked
+		for _, loc := range lintLeadingLike.FindAllStringIndex(literal[start:end], -1) {
+			detail := literal[start+loc[0] : start+loc[1]]
+			if close := strings.IndexByte(literal[start+loc[1]:end], '\''); close >= 0 {
+				detail = literal[start+loc[0] : start+loc[1]+close+1]
+			}
+			warnings = append(warnings, LintWarning{Rule: LintNonSargable, Line: lineAt(start + loc[0]), Detail: detail})
+		}
+
+		start = end + 1
+	}
+	return warnings
+}
+
+// commaJoin reports the tables of a FROM list, the text after FROM, that
+// joins them with commas and has no WHERE to join them on. A FROM inside
+// a call, like EXTRACT(YEAR FROM d), ends at the closing parenthesis.
+func commaJoin(rest string) (string, bool) {
+	depth := 0
+	commas := false
+	var tables []string
+	table := ""
+	for _, word := range lintWord.FindAllString(rest, -1) {
+		switch {
+		case word == "(":
+			depth++
+			continue
+		case word == ")":
+			depth--
+			if depth < 0 {
+				return strings.Join(append(tables, table), ", "), commas
+			}
+			continue
+		}
+		if depth > 0 {
+			continue
+		}
+		if word == "," {
+			commas = true
+			tables = append(tables, table)
+			table = ""
+			continue
+		}
+		if lintFromEnd.MatchString(word) {
+			if strings.EqualFold(word, "WHERE") {
+				return "", false
+			}
+			break
+		}
+		if table == "" {
+			table = word
+		}
+	}
+	return strings.Join(append(tables, table), ", "), commas
+}
+
+// hasColumnArg reports whether one of the arguments of a call is a column,
+// as in lower(email) or date_trunc('day', created_at)
+func hasColumnArg(args string) bool {
+	for _, arg := range strings.Split(args, ",") {
+		if fields := strings.Fields(arg); len(fields) > 0 && lintIdentifier.MatchString(fields[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// statementEnds returns where the semicolons between statements are
+func statementEnds(code string) []int {
+	var ends []int
+	for i := 0; i < len(code); i++ {
+		if code[i] == ';' {
+			ends = append(ends, i)
+		}
+	}
+	return ends
+}
+
+// maskSQL blanks the comments of query with spaces, keeping newlines so
+// offsets and lines still match. code has string literals blanked as well,
+// leaving their quotes; literal keeps them.
+func maskSQL(query string) (code, literal string) {
+	c := []byte(query)
+	l := []byte(query)
+	blank := func(b []byte, i int) {
+		if b[i] != '\n' {
+			b[i] = ' '
+		}
+	}
+
+	for i := 0; i < len(query); i++ {
+		switch {
+		case strings.HasPrefix(query[i:], "--"):
+			for ; i < len(query) && query[i] != '\n'; i++ {
+				blank(c, i)
+				blank(l, i)
+			}
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			stop := len(query)
+			if end >= 0 {
+				stop = i + 2 + end + 2
+			}
+			for ; i < stop; i++ {
+				blank(c, i)
+				blank(l, i)
+			}
+			i--
+		case query[i] == '\'':
+			// '' inside a literal is an escaped quote
+			for i++; i < len(query); i++ {
+				if query[i] == '\'' {
+					if i+1 < len(query) && query[i+1] == '\'' {
+						blank(c, i)
+						blank(c, i+1)
+						i++
+						continue
+					}
+					break
+				}
+				blank(c, i)
+			}
+		}
+	}
+	return string(c), string(l)
+}
@@ -0,0 +1,59 @@
+package database
+
+import "testing"
+
+func TestLintQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		rule   LintRule
+		line   int
+		detail string
+	}{
+		{"select star", "SELECT * FROM users", LintSelectStar, 1, "SELECT *"},
+		{"qualified select star", "SELECT DISTINCT u.* FROM users u", LintSelectStar, 1, "SELECT DISTINCT u.*"},
+		{"update without where", "UPDATE users SET active = false", LintMissingWhere, 1, "UPDATE"},
+		{"delete without where", "SELECT 1;\ndelete from users", LintMissingWhere, 2, "DELETE"},
+		{"comma join", "SELECT u.id, o.id\nFROM users u, orders o", LintCrossJoin, 2, "users, orders"},
+		{"comma join in subquery", "SELECT id FROM (SELECT a.id FROM a, b) s WHERE s.id = 1", LintCrossJoin, 1, "a, b"},
+		{"function on column", "SELECT id FROM users\nWHERE lower(email) = 'a@b.c'", LintNonSargable, 2, "lower(email)"},
+		{"function with literal first", "SELECT id FROM t WHERE date_trunc('day', created_at) = '2024-01-01'", LintNonSargable, 1, "date_trunc('day', created_at)"},
+		{"leading wildcard", "SELECT id FROM users WHERE name LIKE '%son'", LintNonSargable, 1, "LIKE '%son'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := LintQuery(tt.query)
+			for _, w := range warnings {
+				if w.Rule == tt.rule {
+					if w.Line != tt.line || w.Detail != tt.detail {
+						t.Errorf("LintQuery() = %+v, want line %d detail %q", w, tt.line, tt.detail)
+					}
+					return
+				}
+			}
+			t.Errorf("LintQuery() = %+v, want a %s warning", warnings, tt.rule)
+		})
+	}
+}
+
+func TestLintQueryClean(t *testing.T) {
+	queries := []string{
+		"SELECT id, name FROM users WHERE id = 1",
+		"SELECT COUNT(*) FROM users",
+		"UPDATE users SET active = false WHERE id = 7",
+		"DELETE FROM sessions WHERE expires_at < now()",
+		"SELECT a.id FROM a, b WHERE a.id = b.a_id",
+		"SELECT a.id FROM a CROSS JOIN b",
+		"SELECT EXTRACT(YEAR FROM created_at), id FROM users WHERE id IN (1, 2)",
+		"SELECT id FROM users WHERE name LIKE 'jo%'",
+		"SELECT id FROM users WHERE note = 'SELECT * FROM x; DELETE FROM y'",
+		"-- SELECT * FROM users\nSELECT id FROM users /* DELETE FROM users */",
+		"SELECT id FROM users WHERE name = 'it''s' AND created_at > now()",
+		"INSERT INTO users (id, name) VALUES (1, 'a')",
+	}
+	for _, query := range queries {
+		if warnings := LintQuery(query); len(warnings) > 0 {
+			t.Errorf("LintQuery(%q) = %+v, want no warnings", query, warnings)
+		}
+	}
+}
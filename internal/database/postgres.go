@@ -81,8 +81,10 @@ type ColumnInfo struct {
 }
 
 type PostgresClient struct {
-	db     *sql.DB
-	config ConnectionConfig
+	db         *sql.DB
+	config     ConnectionConfig
+	extensions map[string]bool
+	readOnly   bool
 }
 
 func NewPostgresClient() *PostgresClient {
@@ -105,6 +107,11 @@ func (c *PostgresClient) ConnectWithContext(ctx context.Context, config Connecti
 	connStr := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		config.Host, config.Port, config.User, config.Password, config.Database, config.SSLMode)
 
+	// Let the server reject writes too, e.g. data-modifying CTEs that pass the prefix check
+	if c.readOnly {
+		connStr += " default_transaction_read_only=on"
+	}
+
 	logger.Debug("Opening database connection")
 	db, err := sql.Open("postgres", connStr)
 	if err != nil {
@@ -129,10 +136,21 @@ func (c *PostgresClient) ConnectWithContext(ctx context.Context, config Connecti
 
 	c.db = db
 	c.config = config
+
+	if err := c.detectExtensions(); err != nil {
+		logger.Debug("Failed to detect extensions", "error", err)
+	}
+
 	logger.Info("Database connection established successfully")
 	return nil
 }
 
+// SetReadOnly restricts the client to read-only queries. It applies to the
+// next connection on the server side and immediately on the client side.
+func (c *PostgresClient) SetReadOnly(readOnly bool) {
+	c.readOnly = readOnly
+}
+
 func (c *PostgresClient) IsConnected() bool {
 	return c.db != nil
 }
@@ -193,6 +211,12 @@ func removeComments(query string) string {
 }
 
 func (c *PostgresClient) ExecuteQuery(query string) QueryResult {
+	return c.ExecuteQueryContext(context.Background(), query)
+}
+
+// ExecuteQueryContext runs query like ExecuteQuery. Canceling ctx stops
+// the query on the server, and the result carries the context error.
+func (c *PostgresClient) ExecuteQueryContext(ctx context.Context, query string) QueryResult {
 	if c.db == nil {
 		return QueryResult{Error: fmt.Errorf("not connected to database")}
 	}
@@ -206,10 +230,14 @@ func (c *PostgresClient) ExecuteQuery(query string) QueryResult {
 
 	// Detect if query returns rows (SELECT-like) or just affects rows (INSERT/UPDATE/DELETE)
 	if isReadOnlyQuery(query) {
-		return c.executeSelectQuery(query, startTime)
+		return c.executeSelectQuery(ctx, query, startTime)
 	}
 
-	return c.executeNonSelectQuery(query, startTime)
+	if c.readOnly {
+		return QueryResult{Error: fmt.Errorf("read-only mode: only SELECT, SHOW, EXPLAIN and WITH queries are allowed")}
+	}
+
+	return c.executeNonSelectQuery(ctx, query, startTime)
 }
 
 // formatValue converts a database value to a string representation
@@ -241,8 +269,8 @@ func formatValue(val interface{}) string {
 	}
 }
 
-func (c *PostgresClient) executeSelectQuery(query string, startTime time.Time) QueryResult {
-	rows, err := c.db.Query(query)
+func (c *PostgresClient) executeSelectQuery(ctx context.Context, query string, startTime time.Time) QueryResult {
+	rows, err := c.db.QueryContext(ctx, query)
 	if err != nil {
 		return QueryResult{
 			Error:         err,
@@ -259,6 +287,16 @@ func (c *PostgresClient) executeSelectQuery(query string, startTime time.Time) Q
 		}
 	}
 
+	// Extension types (vector, hstore, geometry) have no name known to the driver
+	unknownType := make([]bool, len(columns))
+	if len(c.extensions) > 0 {
+		if colTypes, err := rows.ColumnTypes(); err == nil {
+			for i, ct := range colTypes {
+				unknownType[i] = ct.DatabaseTypeName() == ""
+			}
+		}
+	}
+
 	var resultRows [][]string
 	rowCount := 0
 	truncated := false
@@ -285,6 +323,12 @@ func (c *PostgresClient) executeSelectQuery(query string, startTime time.Time) Q
 
 		row := make([]string, len(columns))
 		for i, val := range values {
+			if raw, ok := val.([]byte); ok && unknownType[i] {
+				if formatted, ok := formatExtensionValue(raw, c.extensions); ok {
+					row[i] = formatted
+					continue
+				}
+			}
 			row[i] = formatValue(val)
 		}
 		resultRows = append(resultRows, row)
@@ -307,8 +351,8 @@ func (c *PostgresClient) executeSelectQuery(query string, startTime time.Time) Q
 	}
 }
 
-func (c *PostgresClient) executeNonSelectQuery(query string, startTime time.Time) QueryResult {
-	result, err := c.db.Exec(query)
+func (c *PostgresClient) executeNonSelectQuery(ctx context.Context, query string, startTime time.Time) QueryResult {
+	result, err := c.db.ExecContext(ctx, query)
 	if err != nil {
 		return QueryResult{
 			Error:         err,
@@ -325,6 +369,12 @@ func (c *PostgresClient) executeNonSelectQuery(query string, startTime time.Time
 }
 
 func (c *PostgresClient) GetTables() ([]string, error) {
+	return c.GetTablesContext(context.Background())
+}
+
+// GetTablesContext lists the tables like GetTables, stopping when ctx is
+// canceled
+func (c *PostgresClient) GetTablesContext(ctx context.Context) ([]string, error) {
 	if c.db == nil {
 		return nil, fmt.Errorf("not connected to database")
 	}
@@ -336,7 +386,7 @@ func (c *PostgresClient) GetTables() ([]string, error) {
 		ORDER BY table_name
 	`
 
-	rows, err := c.db.Query(query)
+	rows, err := c.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,167 @@
+package database
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// QueryTemplate is a common query pattern inserted into the SQL editor.
+// Its body marks what to fill in with tab stops: ${1:table_name} is stop
+// 1 with table_name as its default, and every ${1:...} takes the value
+// typed for stop 1.
+type QueryTemplate struct {
+	Name        string
+	Description string
+	Body        string
+}
+
+// BuiltInQueryTemplates returns the query templates that ship with godev,
+// written for PostgreSQL
+func BuiltInQueryTemplates() []QueryTemplate {
+	return []QueryTemplate{
+		{
+			Name:        "Top N per group",
+			Description: "The first rows of each group, ranked by a column",
+			Body: `SELECT ${2:group_column}, ${3:columns}
+FROM (
+    SELECT ${2:group_column}, ${3:columns},
+        row_number() OVER (PARTITION BY ${2:group_column} ORDER BY ${4:order_column} DESC) AS rank
+    FROM ${1:table_name}
+) ranked
+WHERE rank <= ${5:3}
+ORDER BY ${2:group_column}, rank;`,
+		},
+		{
+			Name:        "Duplicate finder",
+			Description: "Values that appear in more than one row",
+			Body: `SELECT ${2:column}, count(*) AS copies
+FROM ${1:table_name}
+GROUP BY ${2:column}
+HAVING count(*) > 1
+ORDER BY copies DESC;`,
+		},
+		{
+			Name:        "Table sizes",
+			Description: "The largest tables with the space taken by their rows and indexes",
+			Body: `SELECT relname AS table_name,
+    pg_size_pretty(pg_total_relation_size(relid)) AS total_size,
+    pg_size_pretty(pg_relation_size(relid)) AS table_size,
+    pg_size_pretty(pg_total_relation_size(relid) - pg_relation_size(relid)) AS index_size
+FROM pg_catalog.pg_statio_user_tables
+ORDER BY pg_total_relation_size(relid) DESC
+LIMIT ${1:20};`,
+		},
+		{
+			Name:        "Rows per period",
+			Description: "How many rows were created per day, week or month",
+			Body: `SELECT date_trunc('${3:day}', ${2:created_at}) AS period, count(*) AS rows
+FROM ${1:table_name}
+GROUP BY period
+ORDER BY period DESC
+LIMIT ${4:30};`,
+		},
+		{
+			Name:        "Orphaned rows",
+			Description: "Rows whose foreign key points at a row that no longer exists",
+			Body: `SELECT child.${4:id}, child.${3:parent_id}
+FROM ${1:child_table} child
+LEFT JOIN ${2:parent_table} parent ON parent.${5:id} = child.${3:parent_id}
+WHERE child.${3:parent_id} IS NOT NULL
+  AND parent.${5:id} IS NULL;`,
+		},
+		{
+			Name:        "Running queries",
+			Description: "Queries running now, the longest first",
+			Body: `SELECT pid, now() - query_start AS duration, state, query
+FROM pg_stat_activity
+WHERE state <> 'idle'
+  AND query_start < now() - interval '${1:5 seconds}'
+ORDER BY duration DESC;`,
+		},
+		{
+			Name:        "Unused indexes",
+			Description: "Indexes scanned no more than a few times since statistics were reset",
+			Body: `SELECT relname AS table_name, indexrelname AS index_name, idx_scan,
+    pg_size_pretty(pg_relation_size(indexrelid)) AS index_size
+FROM pg_stat_user_indexes
+WHERE idx_scan <= ${1:0}
+ORDER BY pg_relation_size(indexrelid) DESC;`,
+		},
+	}
+}
+
+// TabStop is a place to fill in a query template
+type TabStop struct {
+	Number  int
+	Default string
+}
+
+// snippetPart is text, or a tab stop when stop is above zero
+type snippetPart struct {
+	text string
+	stop int
+}
+
+// Snippet is a query template split at its tab stops
+type Snippet struct {
+	parts []snippetPart
+	// Stops are the tab stops in the order they are filled; a stop used
+	// more than once is listed once, with the default it first had
+	Stops []TabStop
+}
+
+var tabStopPattern = regexp.MustCompile(`\$\{(\d+):([^}]*)\}`)
+
+// ParseSnippet splits body at its ${n:default} tab stops
+func ParseSnippet(body string) Snippet {
+	var s Snippet
+	seen := make(map[int]bool)
+	last := 0
+	for _, m := range tabStopPattern.FindAllStringSubmatchIndex(body, -1) {
+		number, err := strconv.Atoi(body[m[2]:m[3]])
+		if err != nil || number <= 0 {
+			continue
+		}
+		s.parts = append(s.parts, snippetPart{text: body[last:m[0]]}, snippetPart{stop: number})
+		if !seen[number] {
+			seen[number] = true
+			s.Stops = append(s.Stops, TabStop{Number: number, Default: body[m[4]:m[5]]})
+		}
+		last = m[1]
+	}
+	s.parts = append(s.parts, snippetPart{text: body[last:]})
+	sort.SliceStable(s.Stops, func(i, j int) bool { return s.Stops[i].Number < s.Stops[j].Number })
+	return s
+}
+
+// Defaults returns the default value of every stop
+func (s Snippet) Defaults() map[int]string {
+	values := make(map[int]string, len(s.Stops))
+	for _, stop := range s.Stops {
+		values[stop.Number] = stop.Default
+	}
+	return values
+}
+
+// Expand fills the stops with values and returns the query, with the byte
+// offset where the first use of stop ends, to put the cursor there
+func (s Snippet) Expand(values map[int]string, stop int) (string, int) {
+	var b strings.Builder
+	cursor := -1
+	for _, part := range s.parts {
+		if part.stop == 0 {
+			b.WriteString(part.text)
+			continue
+		}
+		b.WriteString(values[part.stop])
+		if part.stop == stop && cursor < 0 {
+			cursor = b.Len()
+		}
+	}
+	if cursor < 0 {
+		cursor = b.Len()
+	}
+	return b.String(), cursor
+}
@@ -0,0 +1,42 @@
+package database
+
+import "testing"
+
+func TestParseSnippetFillsEveryUseOfAStop(t *testing.T) {
+	snippet := ParseSnippet("SELECT ${2:col} FROM ${1:t} GROUP BY ${2:col};")
+	if len(snippet.Stops) != 2 || snippet.Stops[0] != (TabStop{1, "t"}) || snippet.Stops[1] != (TabStop{2, "col"}) {
+		t.Fatalf("Stops = %+v, want 1:t and 2:col in order", snippet.Stops)
+	}
+
+	text, cursor := snippet.Expand(map[int]string{1: "users", 2: "email"}, 2)
+	if text != "SELECT email FROM users GROUP BY email;" {
+		t.Errorf("Expand() = %q", text)
+	}
+	if cursor != len("SELECT email") {
+		t.Errorf("cursor = %d, want after the first use of stop 2", cursor)
+	}
+
+	if text, _ := snippet.Expand(snippet.Defaults(), 0); text != "SELECT col FROM t GROUP BY col;" {
+		t.Errorf("Expand(defaults) = %q", text)
+	}
+}
+
+func TestBuiltInQueryTemplates(t *testing.T) {
+	for _, tmpl := range BuiltInQueryTemplates() {
+		snippet := ParseSnippet(tmpl.Body)
+		if len(snippet.Stops) == 0 {
+			t.Errorf("%s has no tab stops", tmpl.Name)
+		}
+		for i, stop := range snippet.Stops {
+			if stop.Number != i+1 {
+				t.Errorf("%s numbers its stops %+v, want 1 to %d", tmpl.Name, snippet.Stops, len(snippet.Stops))
+				break
+			}
+		}
+		// The templates should pass the linter they are inserted next to
+		text, _ := snippet.Expand(snippet.Defaults(), 0)
+		if warnings := LintQuery(text); len(warnings) > 0 {
+			t.Errorf("%s has lint warnings: %+v", tmpl.Name, warnings)
+		}
+	}
+}
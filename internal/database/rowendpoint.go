@@ -0,0 +1,141 @@
+package database
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// queryTableRegex finds the first table a SELECT reads from
+var queryTableRegex = regexp.MustCompile(`(?is)^\s*select\b.*?\bfrom\s+(?:(?:"[^"]+"|[\w$]+)\.)?("[^"]+"|[\w$]+)`)
+
+// QueryTable returns the first table query selects from, without its
+// schema, or "" when query is not a SELECT
+func QueryTable(query string) string {
+	match := queryTableRegex.FindStringSubmatch(query)
+	if match == nil {
+		return ""
+	}
+	return strings.Trim(match[1], `"`)
+}
+
+// DefaultRowEndpoint suggests the endpoint of a row of table, with a path
+// segment for each of its key columns
+func DefaultRowEndpoint(table string, keys []string) string {
+	var sb strings.Builder
+	sb.WriteString("GET {{API_URL}}")
+	if table != "" {
+		sb.WriteString("/" + table)
+	}
+	for _, key := range keys {
+		sb.WriteString("/{" + key + "}")
+	}
+	return sb.String()
+}
+
+// FillRowEndpoint turns the endpoint of a row into the request for it.
+// template is a request line like
+//
+//	GET {{API_URL}}/users/{id}
+//
+// whose {column} placeholders take the values of row, escaped for a URL
+// path, while {{VAR}} placeholders are left for the environment. The
+// method is GET when template does not start with one.
+func FillRowEndpoint(template string, columns, row []string) (method, target string, err error) {
+	template = strings.TrimSpace(template)
+	method = "GET"
+	if first, rest, ok := strings.Cut(template, " "); ok && isMethodName(first) {
+		method = strings.ToUpper(first)
+		template = strings.TrimSpace(rest)
+	}
+	if template == "" {
+		return "", "", fmt.Errorf("the endpoint has no URL")
+	}
+
+	var sb strings.Builder
+	for template != "" {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			sb.WriteString(template)
+			break
+		}
+		sb.WriteString(template[:start])
+		template = template[start:]
+
+		// Environment variables are kept as they are
+		if strings.HasPrefix(template, "{{") {
+			end := strings.Index(template, "}}")
+			if end < 0 {
+				return "", "", fmt.Errorf("unclosed {{ in the endpoint")
+			}
+			sb.WriteString(template[:end+2])
+			template = template[end+2:]
+			continue
+		}
+
+		end := strings.IndexByte(template, '}')
+		if end < 0 {
+			return "", "", fmt.Errorf("unclosed { in the endpoint")
+		}
+		column := strings.TrimSpace(template[1:end])
+		value, err := rowValue(column, columns, row)
+		if err != nil {
+			return "", "", err
+		}
+		sb.WriteString(url.PathEscape(value))
+		template = template[end+1:]
+	}
+	return method, sb.String(), nil
+}
+
+// rowValue returns the value of column in row, matching the name without
+// regard to case when no column has it exactly
+func rowValue(column string, columns, row []string) (string, error) {
+	index := -1
+	for i, name := range columns {
+		if name == column {
+			index = i
+			break
+		}
+		if index < 0 && strings.EqualFold(name, column) {
+			index = i
+		}
+	}
+	if index < 0 || index >= len(row) {
+		return "", fmt.Errorf("the row has no column %s", column)
+	}
+	if row[index] == "NULL" {
+		return "", fmt.Errorf("column %s is NULL in this row", column)
+	}
+	return row[index], nil
+}
+
+// isMethodName reports whether s looks like an HTTP method
+func isMethodName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return false
+		}
+	}
+	return true
+}
+
+// RowEndpoint returns the endpoint saved for the rows of table, or ""
+func (s *DatabaseStorage) RowEndpoint(table string) string {
+	return s.config.RowEndpoints[table]
+}
+
+// SaveRowEndpoint keeps template as the endpoint of the rows of table
+func (s *DatabaseStorage) SaveRowEndpoint(table, template string) error {
+	return s.edit(func(c *DatabaseConfig) error {
+		if c.RowEndpoints == nil {
+			c.RowEndpoints = make(map[string]string)
+		}
+		c.RowEndpoints[table] = template
+		return nil
+	})
+}
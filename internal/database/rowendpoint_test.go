@@ -0,0 +1,88 @@
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQueryTable(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"SELECT * FROM users WHERE id = 1", "users"},
+		{"select id,\n  email\nfrom public.users u join orders o on o.user_id = u.id", "users"},
+		{`SELECT * FROM "Audit Log" LIMIT 10`, "Audit Log"},
+		{`SELECT * FROM "app"."orders"`, "orders"},
+		{"UPDATE users SET name = 'x'", ""},
+		{"SELECT now()", ""},
+	}
+	for _, tt := range tests {
+		if got := QueryTable(tt.query); got != tt.want {
+			t.Errorf("QueryTable(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultRowEndpoint(t *testing.T) {
+	if got := DefaultRowEndpoint("users", []string{"id"}); got != "GET {{API_URL}}/users/{id}" {
+		t.Errorf("DefaultRowEndpoint() = %q", got)
+	}
+	if got := DefaultRowEndpoint("", []string{"org_id", "user_id"}); got != "GET {{API_URL}}/{org_id}/{user_id}" {
+		t.Errorf("DefaultRowEndpoint() without a table = %q", got)
+	}
+}
+
+func TestFillRowEndpoint(t *testing.T) {
+	columns := []string{"id", "Slug", "deleted_at"}
+	row := []string{"42", "a b/c", "NULL"}
+
+	tests := []struct {
+		template   string
+		wantMethod string
+		wantURL    string
+		wantErr    string
+	}{
+		{"GET {{API_URL}}/users/{id}", "GET", "{{API_URL}}/users/42", ""},
+		{"delete https://api.test/posts/{slug}?force=1", "DELETE", "https://api.test/posts/a%20b%2Fc?force=1", ""},
+		{"{{API_URL}}/users/{ id }", "GET", "{{API_URL}}/users/42", ""},
+		{"GET /users/{email}", "", "", "the row has no column email"},
+		{"GET /users/{deleted_at}", "", "", "column deleted_at is NULL"},
+		{"GET /users/{id", "", "", "unclosed {"},
+		{"  ", "", "", "the endpoint has no URL"},
+	}
+	for _, tt := range tests {
+		method, url, err := FillRowEndpoint(tt.template, columns, row)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("FillRowEndpoint(%q) error = %v, want %q", tt.template, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || method != tt.wantMethod || url != tt.wantURL {
+			t.Errorf("FillRowEndpoint(%q) = %q %q, %v; want %q %q", tt.template, method, url, err, tt.wantMethod, tt.wantURL)
+		}
+	}
+}
+
+func TestSaveRowEndpoint(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewDatabaseStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewDatabaseStorageAt() error = %v", err)
+	}
+	if err := store.SaveRowEndpoint("users", "GET {{API_URL}}/v2/users/{id}"); err != nil {
+		t.Fatalf("SaveRowEndpoint() error = %v", err)
+	}
+
+	reopened, err := NewDatabaseStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewDatabaseStorageAt() error = %v", err)
+	}
+	if got := reopened.RowEndpoint("users"); got != "GET {{API_URL}}/v2/users/{id}" {
+		t.Errorf("RowEndpoint(users) = %q after reopening", got)
+	}
+	if got := reopened.RowEndpoint("orders"); got != "" {
+		t.Errorf("RowEndpoint(orders) = %q, want none", got)
+	}
+}
@@ -0,0 +1,221 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	sampleFirstWord = regexp.MustCompile(`^\s*(\w+)`)
+	sampleLimit     = regexp.MustCompile(`(?i)^LIMIT\s+(\d+|ALL)\b`)
+	sampleLocking   = regexp.MustCompile(`(?i)^FOR\s+(UPDATE|SHARE|NO\s+KEY|KEY)\b`)
+	// sampleTarget is the table after FROM, with its schema and alias
+	sampleTarget = regexp.MustCompile(`(?i)^\s+((?:"[^"]*"|[\w$]+)(?:\s*\.\s*(?:"[^"]*"|[\w$]+))*)(\s+(?:AS\s+)?("[^"]*"|[\w$]+))?`)
+	sampleClause = regexp.MustCompile(`(?i)^\s+TABLESAMPLE\s+\w+\s*\(\s*([^)]*?)\s*\)(?:\s*REPEATABLE\s*\([^)]*\))?`)
+)
+
+// errNotSelect is returned for a query whose rows cannot be limited
+var errNotSelect = errors.New("only a single SELECT can be limited or sampled")
+
+// limitableQuery is a SELECT with its comments and literals blanked, so
+// keywords are only found in the SQL itself
+type limitableQuery struct {
+	query string
+	code  string
+	// end is where the statement ends, before a trailing semicolon or
+	// comment
+	end int
+}
+
+func parseLimitable(query string) (limitableQuery, error) {
+	code, _ := maskSQL(query)
+	end := len(strings.TrimRight(code, " \t\r\n;"))
+	if strings.Contains(code[:end], ";") {
+		return limitableQuery{}, errNotSelect
+	}
+	first := sampleFirstWord.FindStringSubmatch(code)
+	if first == nil || (!strings.EqualFold(first[1], "SELECT") && !strings.EqualFold(first[1], "WITH")) {
+		return limitableQuery{}, errNotSelect
+	}
+	return limitableQuery{query: query, code: code, end: end}, nil
+}
+
+// topLevel returns the offsets of keyword in the statement where it is
+// not inside parentheses, so the LIMIT of a subquery is left alone
+func (q limitableQuery) topLevel(keyword string) []int {
+	var offsets []int
+	depth := 0
+	for i := 0; i < q.end; i++ {
+		switch c := q.code[i]; {
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == '"':
+			if j := strings.IndexByte(q.code[i+1:q.end], '"'); j >= 0 {
+				i += j + 1
+			}
+		case depth == 0 && (i == 0 || !isWordByte(q.code[i-1])) && i+len(keyword) <= q.end &&
+			strings.EqualFold(q.code[i:i+len(keyword)], keyword) &&
+			(i+len(keyword) == q.end || !isWordByte(q.code[i+len(keyword)])):
+			offsets = append(offsets, i)
+		}
+	}
+	return offsets
+}
+
+// limit finds the LIMIT of the statement: where the clause starts and
+// ends, and where its count is. ok is false when there is none.
+func (q limitableQuery) limit() (clause, count [2]int, ok bool, err error) {
+	offsets := q.topLevel("LIMIT")
+	if len(offsets) == 0 {
+		return clause, count, false, nil
+	}
+	at := offsets[len(offsets)-1]
+	match := sampleLimit.FindStringSubmatchIndex(q.code[at:q.end])
+	if match == nil {
+		return clause, count, false, errors.New("the LIMIT of the query is not a number")
+	}
+	return [2]int{at, at + match[1]}, [2]int{at + match[2], at + match[3]}, true, nil
+}
+
+// QueryLimit returns the number of rows the LIMIT of query allows, 0 when
+// it has none
+func QueryLimit(query string) int {
+	q, err := parseLimitable(query)
+	if err != nil {
+		return 0
+	}
+	_, count, ok, err := q.limit()
+	if !ok || err != nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(q.query[count[0]:count[1]])
+	return n
+}
+
+// SetQueryLimit returns query with its LIMIT set to limit rows: the count
+// of its LIMIT is replaced, or a LIMIT is added at the end. A limit of 0
+// removes the LIMIT.
+func SetQueryLimit(query string, limit int) (string, error) {
+	if limit < 0 {
+		return "", fmt.Errorf("the limit must be a positive number, not %d", limit)
+	}
+	q, err := parseLimitable(query)
+	if err != nil {
+		return "", err
+	}
+	clause, count, ok, err := q.limit()
+	if err != nil {
+		return "", err
+	}
+
+	n := strconv.Itoa(limit)
+	switch {
+	case ok && limit > 0:
+		return query[:count[0]] + n + query[count[1]:], nil
+	case ok:
+		start := len(strings.TrimRight(query[:clause[0]], " \t\r\n"))
+		return query[:start] + query[clause[1]:], nil
+	case limit == 0:
+		return query, nil
+	}
+
+	// LIMIT goes before FOR UPDATE
+	for _, at := range q.topLevel("FOR") {
+		if sampleLocking.MatchString(q.code[at:q.end]) {
+			return query[:at] + "LIMIT " + n + " " + query[at:], nil
+		}
+	}
+	separator := " "
+	if strings.Contains(query[:q.end], "\n") {
+		separator = "\n"
+	}
+	return query[:q.end] + separator + "LIMIT " + n + query[q.end:], nil
+}
+
+// sample finds the table the statement selects from first: where its
+// reference ends, after any alias, and the TABLESAMPLE clause that
+// follows it, whose percentage is in percent. ok is false when there is
+// no such clause.
+func (q limitableQuery) sample() (at int, clause, percent [2]int, ok bool, err error) {
+	froms := q.topLevel("FROM")
+	if len(froms) == 0 {
+		return 0, clause, percent, false, errors.New("the query reads no table to sample")
+	}
+	start := froms[0] + len("FROM")
+	rest := q.code[start:q.end]
+	target := sampleTarget.FindStringSubmatchIndex(rest)
+	if target == nil {
+		return 0, clause, percent, false, errors.New("the query reads from a subquery, not a table")
+	}
+
+	at = target[3]
+	if target[4] >= 0 {
+		alias := strings.ToUpper(rest[target[6]:target[7]])
+		if !lintFromEnd.MatchString(alias) && alias != "TABLESAMPLE" && alias != "WITH" {
+			at = target[5]
+		}
+	}
+	if at == target[3] && strings.HasPrefix(strings.TrimLeft(rest[at:], " \t\r\n"), "(") {
+		return 0, clause, percent, false, fmt.Errorf("the query reads from the function %s, not a table", rest[target[2]:target[3]])
+	}
+
+	match := sampleClause.FindStringSubmatchIndex(rest[at:])
+	at += start
+	if match == nil {
+		return at, clause, percent, false, nil
+	}
+	return at, [2]int{at + match[0], at + match[1]}, [2]int{at + match[2], at + match[3]}, true, nil
+}
+
+// QuerySample returns the percentage of rows the TABLESAMPLE of query
+// reads, 0 when it has none
+func QuerySample(query string) float64 {
+	q, err := parseLimitable(query)
+	if err != nil {
+		return 0
+	}
+	_, _, percent, ok, err := q.sample()
+	if !ok || err != nil {
+		return 0
+	}
+	p, _ := strconv.ParseFloat(q.query[percent[0]:percent[1]], 64)
+	return p
+}
+
+// SetQuerySample returns query reading a sample of percent of the rows of
+// the first table it selects from, with TABLESAMPLE BERNOULLI. The
+// percentage of an existing TABLESAMPLE is replaced, keeping its method. A
+// percent of 0 removes the TABLESAMPLE.
+func SetQuerySample(query string, percent float64) (string, error) {
+	if percent < 0 || percent > 100 {
+		return "", fmt.Errorf("the sample must be between 0 and 100 percent, not %g", percent)
+	}
+	q, err := parseLimitable(query)
+	if err != nil {
+		return "", err
+	}
+	at, clause, span, ok, err := q.sample()
+	if err != nil {
+		return "", err
+	}
+
+	p := strconv.FormatFloat(percent, 'f', -1, 64)
+	switch {
+	case ok && percent > 0:
+		return query[:span[0]] + p + query[span[1]:], nil
+	case ok:
+		return query[:clause[0]] + query[clause[1]:], nil
+	case percent == 0:
+		return query, nil
+	}
+	return query[:at] + " TABLESAMPLE BERNOULLI (" + p + ")" + query[at:], nil
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
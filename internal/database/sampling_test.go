@@ -0,0 +1,99 @@
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetQueryLimit(t *testing.T) {
+	tests := []struct {
+		query string
+		limit int
+		want  string
+	}{
+		{"SELECT * FROM users", 50, "SELECT * FROM users LIMIT 50"},
+		{"SELECT * FROM users;", 50, "SELECT * FROM users LIMIT 50;"},
+		{"SELECT *\nFROM users\nLIMIT 100;", 10, "SELECT *\nFROM users\nLIMIT 10;"},
+		{"SELECT * FROM users LIMIT ALL OFFSET 5", 10, "SELECT * FROM users LIMIT 10 OFFSET 5"},
+		{"SELECT * FROM users LIMIT 100 OFFSET 5", 0, "SELECT * FROM users OFFSET 5"},
+		{"SELECT * FROM users", 0, "SELECT * FROM users"},
+		{"SELECT * FROM users -- all of them", 5, "SELECT * FROM users LIMIT 5 -- all of them"},
+		{"SELECT * FROM (SELECT * FROM users LIMIT 3) u", 5, "SELECT * FROM (SELECT * FROM users LIMIT 3) u LIMIT 5"},
+		{"SELECT * FROM jobs WHERE note = 'limit 2' FOR UPDATE", 1, "SELECT * FROM jobs WHERE note = 'limit 2' LIMIT 1 FOR UPDATE"},
+		{"WITH recent AS (SELECT * FROM orders) SELECT * FROM recent", 20, "WITH recent AS (SELECT * FROM orders) SELECT * FROM recent LIMIT 20"},
+	}
+	for _, tt := range tests {
+		got, err := SetQueryLimit(tt.query, tt.limit)
+		if err != nil || got != tt.want {
+			t.Errorf("SetQueryLimit(%q, %d) = %q, %v; want %q", tt.query, tt.limit, got, err, tt.want)
+		}
+	}
+}
+
+func TestSetQueryLimitRejects(t *testing.T) {
+	tests := map[string]string{
+		"UPDATE users SET active = false":       "only a single SELECT",
+		"SELECT 1; SELECT 2":                    "only a single SELECT",
+		"SELECT * FROM users LIMIT $1":          "not a number",
+		"SELECT * FROM users LIMIT {{PAGE}}":    "not a number",
+		"DELETE FROM users WHERE id = 'SELECT'": "only a single SELECT",
+	}
+	for query, want := range tests {
+		if _, err := SetQueryLimit(query, 10); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("SetQueryLimit(%q) error = %v, want %q", query, err, want)
+		}
+	}
+}
+
+func TestSetQuerySample(t *testing.T) {
+	tests := []struct {
+		query   string
+		percent float64
+		want    string
+	}{
+		{"SELECT * FROM events", 10, "SELECT * FROM events TABLESAMPLE BERNOULLI (10)"},
+		{"SELECT * FROM public.events e WHERE e.kind = 'click'", 2.5, "SELECT * FROM public.events e TABLESAMPLE BERNOULLI (2.5) WHERE e.kind = 'click'"},
+		{"SELECT * FROM events AS e JOIN users u ON u.id = e.user_id", 1, "SELECT * FROM events AS e TABLESAMPLE BERNOULLI (1) JOIN users u ON u.id = e.user_id"},
+		{"SELECT * FROM \"Events\"\nORDER BY 1", 5, "SELECT * FROM \"Events\" TABLESAMPLE BERNOULLI (5)\nORDER BY 1"},
+		{"SELECT * FROM events TABLESAMPLE SYSTEM (10) LIMIT 5", 20, "SELECT * FROM events TABLESAMPLE SYSTEM (20) LIMIT 5"},
+		{"SELECT * FROM events e TABLESAMPLE BERNOULLI (10) REPEATABLE (7) WHERE true", 0, "SELECT * FROM events e WHERE true"},
+		{"SELECT count(*) FROM events", 0, "SELECT count(*) FROM events"},
+	}
+	for _, tt := range tests {
+		got, err := SetQuerySample(tt.query, tt.percent)
+		if err != nil || got != tt.want {
+			t.Errorf("SetQuerySample(%q, %g) = %q, %v; want %q", tt.query, tt.percent, got, err, tt.want)
+		}
+	}
+}
+
+func TestSetQuerySampleRejects(t *testing.T) {
+	tests := []struct {
+		query   string
+		percent float64
+		want    string
+	}{
+		{"SELECT 1", 10, "no table to sample"},
+		{"SELECT * FROM (SELECT * FROM events) e", 10, "subquery"},
+		{"SELECT * FROM generate_series(1, 10)", 10, "function generate_series"},
+		{"SELECT * FROM events", 120, "between 0 and 100"},
+	}
+	for _, tt := range tests {
+		if _, err := SetQuerySample(tt.query, tt.percent); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("SetQuerySample(%q, %g) error = %v, want %q", tt.query, tt.percent, err, tt.want)
+		}
+	}
+}
+
+func TestQueryLimitAndSample(t *testing.T) {
+	query := "SELECT * FROM events TABLESAMPLE SYSTEM (0.5) LIMIT 200"
+	if got := QueryLimit(query); got != 200 {
+		t.Errorf("QueryLimit() = %d, want 200", got)
+	}
+	if got := QuerySample(query); got != 0.5 {
+		t.Errorf("QuerySample() = %g, want 0.5", got)
+	}
+	if QueryLimit("SELECT 1") != 0 || QuerySample("SELECT * FROM events") != 0 {
+		t.Error("A query without LIMIT or TABLESAMPLE reported one")
+	}
+}
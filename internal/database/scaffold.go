@@ -0,0 +1,308 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ScaffoldMaxRows bounds the rows a scaffold inserts, so a large response
+// does not turn into a query the editor struggles with
+const ScaffoldMaxRows = 500
+
+var (
+	scaffoldUUID       = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	scaffoldIdentifier = regexp.MustCompile(`[^a-z0-9_]+`)
+)
+
+// ScaffoldColumn is a column of a scaffold with the type its values fit
+type ScaffoldColumn struct {
+	Name string
+	Type string
+}
+
+// Scaffold is a scratch table built from JSON: its columns and the values
+// of its rows, by column name
+type Scaffold struct {
+	Table   string
+	Columns []ScaffoldColumn
+	Rows    []map[string]interface{}
+	// Total is the number of rows in the JSON, more than len(Rows) when
+	// they were cut at ScaffoldMaxRows
+	Total int
+}
+
+// ScaffoldTableName turns the last word of a URL path into a table name,
+// skipping ids, or returns api_data
+func ScaffoldTableName(rawURL string) string {
+	path := rawURL
+	if i := strings.Index(path, "://"); i >= 0 {
+		path = path[i+3:]
+		if j := strings.IndexByte(path, '/'); j >= 0 {
+			path = path[j:]
+		} else {
+			path = ""
+		}
+	}
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+
+	segments := strings.Split(path, "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		segment := segments[i]
+		if segment == "" || strings.Contains(segment, "{{") || scaffoldUUID.MatchString(segment) || isNumeric(segment) {
+			continue
+		}
+		name := strings.Trim(scaffoldIdentifier.ReplaceAllString(strings.ToLower(segment), "_"), "_")
+		if name == "" {
+			continue
+		}
+		if name[0] >= '0' && name[0] <= '9' {
+			name = "t_" + name
+		}
+		return name
+	}
+	return "api_data"
+}
+
+// NewScaffold reads the rows of a scratch table from a JSON body. An array
+// of objects gives a row per object; an object gives one row, unless its
+// only array of objects holds the records, as in {"items": [...], "total": 2}.
+// Objects and arrays inside a row are kept as jsonb.
+func NewScaffold(table, body string) (*Scaffold, error) {
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("the body is not JSON: %w", err)
+	}
+
+	records, err := scaffoldRecords(data)
+	if err != nil {
+		return nil, err
+	}
+
+	scaffold := &Scaffold{Table: table, Total: len(records)}
+	if len(records) > ScaffoldMaxRows {
+		records = records[:ScaffoldMaxRows]
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, record := range records {
+		for key := range record {
+			if !seen[key] {
+				seen[key] = true
+				names = append(names, key)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("the objects have no fields")
+	}
+
+	for _, name := range names {
+		values := make([]interface{}, 0, len(records))
+		for _, record := range records {
+			values = append(values, record[name])
+		}
+		scaffold.Columns = append(scaffold.Columns, ScaffoldColumn{Name: name, Type: inferColumnType(values)})
+	}
+	scaffold.Rows = records
+	scaffold.OrderColumns(body)
+	return scaffold, nil
+}
+
+// scaffoldRecords finds the objects that become rows
+func scaffoldRecords(data interface{}) ([]map[string]interface{}, error) {
+	switch v := data.(type) {
+	case []interface{}:
+		return objectsOf(v)
+	case map[string]interface{}:
+		var only []interface{}
+		arrays := 0
+		for _, value := range v {
+			if items, ok := value.([]interface{}); ok && len(items) > 0 {
+				if _, isObject := items[0].(map[string]interface{}); isObject {
+					only = items
+					arrays++
+				}
+			}
+		}
+		if arrays == 1 {
+			return objectsOf(only)
+		}
+		return []map[string]interface{}{v}, nil
+	}
+	return nil, fmt.Errorf("the body must be an object or an array of objects")
+}
+
+// objectsOf returns the items of an array that are all objects
+func objectsOf(items []interface{}) ([]map[string]interface{}, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("the array is empty")
+	}
+	records := make([]map[string]interface{}, 0, len(items))
+	for i, item := range items {
+		record, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("item %d of the array is not an object", i)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// OrderColumns sorts the columns in the order source first mentions
+// their fields, as decoding loses it; fields it does not mention go last.
+// source is the body, or the one it was filtered from.
+func (s *Scaffold) OrderColumns(source string) {
+	position := make(map[string]int, len(s.Columns))
+	for _, col := range s.Columns {
+		quoted, _ := json.Marshal(col.Name)
+		position[col.Name] = strings.Index(source, string(quoted))
+		if position[col.Name] < 0 {
+			position[col.Name] = len(source)
+		}
+	}
+	sort.SliceStable(s.Columns, func(i, j int) bool {
+		a, b := position[s.Columns[i].Name], position[s.Columns[j].Name]
+		if a != b {
+			return a < b
+		}
+		return s.Columns[i].Name < s.Columns[j].Name
+	})
+}
+
+// inferColumnType picks the narrowest PostgreSQL type every non-null value
+// fits, falling back to text, or to jsonb when any value is structured
+func inferColumnType(values []interface{}) string {
+	kinds := make(map[string]bool)
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		kinds[valueKind(value)] = true
+	}
+	if kinds["jsonb"] {
+		return "jsonb"
+	}
+	if len(kinds) == 0 {
+		return "text"
+	}
+	if len(kinds) == 1 {
+		for kind := range kinds {
+			return kind
+		}
+	}
+	if len(kinds) == 2 && kinds["bigint"] && kinds["numeric"] {
+		return "numeric"
+	}
+	if len(kinds) == 2 && kinds["date"] && kinds["timestamptz"] {
+		return "timestamptz"
+	}
+	return "text"
+}
+
+// valueKind returns the type a single JSON value fits
+func valueKind(value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		return "boolean"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "bigint"
+		}
+		return "numeric"
+	case string:
+		if scaffoldUUID.MatchString(v) {
+			return "uuid"
+		}
+		if _, err := time.Parse("2006-01-02", v); err == nil {
+			return "date"
+		}
+		if _, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return "timestamptz"
+		}
+		return "text"
+	}
+	return "jsonb"
+}
+
+// SQL returns the CREATE TABLE and the INSERT of the rows
+func (s *Scaffold) SQL() string {
+	var sb strings.Builder
+	if s.Total > len(s.Rows) {
+		sb.WriteString(fmt.Sprintf("-- First %d of %d rows\n", len(s.Rows), s.Total))
+	}
+
+	sb.WriteString(fmt.Sprintf("CREATE TABLE %s (\n", quoteIdentifier(s.Table)))
+	columns := make([]string, len(s.Columns))
+	for i, col := range s.Columns {
+		columns[i] = quoteIdentifier(col.Name)
+		sb.WriteString(fmt.Sprintf("    %s %s", columns[i], col.Type))
+		if i < len(s.Columns)-1 {
+			sb.WriteString(",")
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(");\n\n")
+
+	sb.WriteString(fmt.Sprintf("INSERT INTO %s (%s) VALUES\n", quoteIdentifier(s.Table), strings.Join(columns, ", ")))
+	for i, row := range s.Rows {
+		values := make([]string, len(s.Columns))
+		for j, col := range s.Columns {
+			values[j] = scaffoldLiteral(row[col.Name], col.Type)
+		}
+		sb.WriteString("    (" + strings.Join(values, ", ") + ")")
+		if i < len(s.Rows)-1 {
+			sb.WriteString(",\n")
+		}
+	}
+	sb.WriteString(";")
+	return sb.String()
+}
+
+// scaffoldLiteral writes value as a literal of a column of type colType.
+// Quotes are doubled only: with standard_conforming_strings backslashes
+// are kept as they are.
+func scaffoldLiteral(value interface{}, colType string) string {
+	quote := func(s string) string {
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+
+	if value == nil {
+		return "NULL"
+	}
+	if colType == "jsonb" {
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		encoder.Encode(value)
+		return quote(strings.TrimSpace(buf.String()))
+	}
+
+	switch v := value.(type) {
+	case bool:
+		if colType != "boolean" {
+			return quote(fmt.Sprint(v))
+		}
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case json.Number:
+		if colType == "bigint" || colType == "numeric" {
+			return v.String()
+		}
+		return quote(v.String())
+	case string:
+		return quote(v)
+	}
+	return quote(fmt.Sprint(value))
+}
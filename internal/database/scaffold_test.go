@@ -0,0 +1,96 @@
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewScaffoldInfersColumnTypes(t *testing.T) {
+	body := `{"total": 2, "items": [
+		{"id": 1, "name": "O'Hara", "price": 10, "active": true, "born": "1990-05-01",
+		 "created_at": "2024-01-02T03:04:05Z", "ref": "6f1c2b8e-3a4d-4e5f-9a0b-1c2d3e4f5a6b", "tags": ["a"]},
+		{"id": 2, "name": null, "price": 9.5, "active": false, "born": "1991-06-02",
+		 "created_at": "2024-01-03", "ref": "not-a-uuid", "tags": null, "note": "only here"}
+	]}`
+
+	scaffold, err := NewScaffold("orders", body)
+	if err != nil {
+		t.Fatalf("NewScaffold() error = %v", err)
+	}
+
+	want := `CREATE TABLE "orders" (
+    "id" bigint,
+    "name" text,
+    "price" numeric,
+    "active" boolean,
+    "born" date,
+    "created_at" timestamptz,
+    "ref" text,
+    "tags" jsonb,
+    "note" text
+);
+
+INSERT INTO "orders" ("id", "name", "price", "active", "born", "created_at", "ref", "tags", "note") VALUES
+    (1, 'O''Hara', 10, TRUE, '1990-05-01', '2024-01-02T03:04:05Z', '6f1c2b8e-3a4d-4e5f-9a0b-1c2d3e4f5a6b', '["a"]', NULL),
+    (2, NULL, 9.5, FALSE, '1991-06-02', '2024-01-03', 'not-a-uuid', NULL, 'only here');`
+	if got := scaffold.SQL(); got != want {
+		t.Errorf("SQL() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestNewScaffoldRecords(t *testing.T) {
+	tests := []struct {
+		body    string
+		rows    int
+		wantErr string
+	}{
+		{`[{"a": 1}, {"a": 2}, {"b": "x"}]`, 3, ""},
+		{`{"id": 7, "roles": [{"name": "admin"}], "teams": [{"name": "core"}]}`, 1, ""},
+		{`{"id": 7, "name": "single"}`, 1, ""},
+		{`[1, 2]`, 0, "item 0 of the array is not an object"},
+		{`[]`, 0, "the array is empty"},
+		{`"text"`, 0, "must be an object or an array of objects"},
+		{`not json`, 0, "the body is not JSON"},
+	}
+	for _, tt := range tests {
+		scaffold, err := NewScaffold("t", tt.body)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("NewScaffold(%s) error = %v, want %q", tt.body, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || len(scaffold.Rows) != tt.rows {
+			t.Errorf("NewScaffold(%s) = %v, %v; want %d rows", tt.body, scaffold, err, tt.rows)
+		}
+	}
+}
+
+func TestNewScaffoldLimitsRows(t *testing.T) {
+	body := "[" + strings.TrimSuffix(strings.Repeat(`{"id": 1},`, ScaffoldMaxRows+5), ",") + "]"
+	scaffold, err := NewScaffold("t", body)
+	if err != nil {
+		t.Fatalf("NewScaffold() error = %v", err)
+	}
+	if len(scaffold.Rows) != ScaffoldMaxRows || scaffold.Total != ScaffoldMaxRows+5 {
+		t.Errorf("Rows = %d of %d, want %d of %d", len(scaffold.Rows), scaffold.Total, ScaffoldMaxRows, ScaffoldMaxRows+5)
+	}
+	if sql := scaffold.SQL(); !strings.HasPrefix(sql, "-- First 500 of 505 rows\n") {
+		t.Errorf("SQL() does not say rows were left out: %.60s", sql)
+	}
+}
+
+func TestScaffoldTableName(t *testing.T) {
+	tests := map[string]string{
+		"https://api.test/v1/users/42?expand=1":                        "users",
+		"{{API_URL}}/order-items/6f1c2b8e-3a4d-4e5f-9a0b-1c2d3e4f5a6b": "order_items",
+		"https://api.test":       "api_data",
+		"users-api/2024/reports": "reports",
+		"https://api.test/v2/":   "v2",
+	}
+	for url, want := range tests {
+		if got := ScaffoldTableName(url); got != want {
+			t.Errorf("ScaffoldTableName(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
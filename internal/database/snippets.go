@@ -0,0 +1,155 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SnippetKind identifies the type of SQL snippet to generate for a table
+type SnippetKind string
+
+const (
+	SnippetSelect SnippetKind = "select"
+	SnippetInsert SnippetKind = "insert"
+	SnippetUpdate SnippetKind = "update"
+)
+
+// GenerateSnippet returns a SQL snippet of the given kind for the table
+func GenerateSnippet(kind SnippetKind, metadata *TableMetadata) (string, error) {
+	if metadata == nil || len(metadata.Columns) == 0 {
+		return "", fmt.Errorf("no column metadata available")
+	}
+
+	switch kind {
+	case SnippetSelect:
+		return GenerateSelectSnippet(metadata), nil
+	case SnippetInsert:
+		return GenerateInsertSnippet(metadata), nil
+	case SnippetUpdate:
+		return GenerateUpdateSnippet(metadata)
+	default:
+		return "", fmt.Errorf("unknown snippet kind: %s", kind)
+	}
+}
+
+// GenerateSelectSnippet builds a SELECT listing every column of the table
+func GenerateSelectSnippet(metadata *TableMetadata) string {
+	columns := make([]string, 0, len(metadata.Columns))
+	for _, col := range metadata.Columns {
+		columns = append(columns, quoteIdentifier(col.Name))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("SELECT\n    ")
+	sb.WriteString(strings.Join(columns, ",\n    "))
+	sb.WriteString(fmt.Sprintf("\nFROM %s\nLIMIT 100;", quoteIdentifier(metadata.Name)))
+	return sb.String()
+}
+
+// GenerateInsertSnippet builds an INSERT skeleton with typed placeholders.
+// Columns filled by a sequence default (serial/identity) are skipped.
+func GenerateInsertSnippet(metadata *TableMetadata) string {
+	var columns, values []string
+	for _, col := range metadata.Columns {
+		if isGeneratedDefault(col.DefaultValue) {
+			continue
+		}
+		columns = append(columns, quoteIdentifier(col.Name))
+		values = append(values, placeholderFor(col))
+	}
+
+	// Every column is generated, fall back to DEFAULT VALUES
+	if len(columns) == 0 {
+		return fmt.Sprintf("INSERT INTO %s DEFAULT VALUES;", quoteIdentifier(metadata.Name))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("INSERT INTO %s (\n    ", quoteIdentifier(metadata.Name)))
+	sb.WriteString(strings.Join(columns, ",\n    "))
+	sb.WriteString("\n) VALUES (\n    ")
+	sb.WriteString(strings.Join(values, ",\n    "))
+	sb.WriteString("\n);")
+	return sb.String()
+}
+
+// GenerateUpdateSnippet builds an UPDATE skeleton keyed by the primary key
+func GenerateUpdateSnippet(metadata *TableMetadata) (string, error) {
+	if len(metadata.PrimaryKeys) == 0 {
+		return "", fmt.Errorf("table %s has no primary key", metadata.Name)
+	}
+
+	isPK := make(map[string]bool, len(metadata.PrimaryKeys))
+	for _, pk := range metadata.PrimaryKeys {
+		isPK[pk] = true
+	}
+
+	var sets, conditions []string
+	for _, col := range metadata.Columns {
+		if isPK[col.Name] || col.IsPrimaryKey {
+			continue
+		}
+		sets = append(sets, fmt.Sprintf("%s = %s", quoteIdentifier(col.Name), placeholderFor(col)))
+	}
+	for _, pk := range metadata.PrimaryKeys {
+		col := ColumnMetadata{Name: pk}
+		for _, c := range metadata.Columns {
+			if c.Name == pk {
+				col = c
+				break
+			}
+		}
+		conditions = append(conditions, fmt.Sprintf("%s = %s", quoteIdentifier(pk), placeholderFor(col)))
+	}
+
+	if len(sets) == 0 {
+		return "", fmt.Errorf("table %s has no non-key columns to update", metadata.Name)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("UPDATE %s\nSET\n    ", quoteIdentifier(metadata.Name)))
+	sb.WriteString(strings.Join(sets, ",\n    "))
+	sb.WriteString("\nWHERE ")
+	sb.WriteString(strings.Join(conditions, "\n  AND "))
+	sb.WriteString(";")
+	return sb.String(), nil
+}
+
+// isGeneratedDefault reports whether a column default is produced by a sequence
+func isGeneratedDefault(defaultValue string) bool {
+	return strings.HasPrefix(strings.ToLower(defaultValue), "nextval(")
+}
+
+// placeholderFor returns a type-appropriate placeholder literal annotated with the column type
+func placeholderFor(col ColumnMetadata) string {
+	colType := strings.ToLower(col.Type)
+
+	var value string
+	switch {
+	case colType == "boolean":
+		value = "false"
+	case strings.Contains(colType, "int"), colType == "numeric", colType == "decimal",
+		colType == "real", colType == "double precision", colType == "money":
+		value = "0"
+	case colType == "uuid":
+		value = "'00000000-0000-0000-0000-000000000000'"
+	case colType == "json", colType == "jsonb":
+		value = "'{}'"
+	case strings.HasPrefix(colType, "timestamp"):
+		value = "now()"
+	case colType == "date":
+		value = "current_date"
+	case strings.HasPrefix(colType, "time"):
+		value = "current_time"
+	case colType == "array":
+		value = "'{}'"
+	case colType == "bytea":
+		value = "'\\x'"
+	default:
+		value = "''"
+	}
+
+	if col.Type == "" {
+		return value
+	}
+	return fmt.Sprintf("%s /* %s */", value, col.Type)
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+func snippetTestTable() *TableMetadata {
+	return &TableMetadata{
+		Name:   "users",
+		Schema: "public",
+		Columns: []ColumnMetadata{
+			{Name: "id", Type: "integer", DefaultValue: "nextval('users_id_seq'::regclass)"},
+			{Name: "name", Type: "character varying"},
+			{Name: "active", Type: "boolean"},
+			{Name: "created_at", Type: "timestamp without time zone"},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+}
+
+func TestGenerateSelectSnippet(t *testing.T) {
+	snippet := GenerateSelectSnippet(snippetTestTable())
+
+	for _, col := range []string{`"id"`, `"name"`, `"active"`, `"created_at"`} {
+		if !strings.Contains(snippet, col) {
+			t.Errorf("Expected SELECT to contain column %s, got:\n%s", col, snippet)
+		}
+	}
+
+	if !strings.Contains(snippet, `FROM "users"`) {
+		t.Errorf("Expected FROM clause, got:\n%s", snippet)
+	}
+}
+
+func TestGenerateInsertSnippet(t *testing.T) {
+	snippet := GenerateInsertSnippet(snippetTestTable())
+
+	if strings.Contains(snippet, `"id"`) {
+		t.Errorf("Expected serial column to be skipped, got:\n%s", snippet)
+	}
+
+	expected := []string{
+		`INSERT INTO "users"`,
+		"'' /* character varying */",
+		"false /* boolean */",
+		"now() /* timestamp without time zone */",
+	}
+	for _, want := range expected {
+		if !strings.Contains(snippet, want) {
+			t.Errorf("Expected INSERT to contain %q, got:\n%s", want, snippet)
+		}
+	}
+}
+
+func TestGenerateInsertSnippetAllGenerated(t *testing.T) {
+	table := &TableMetadata{
+		Name:    "counters",
+		Columns: []ColumnMetadata{{Name: "id", Type: "bigint", DefaultValue: "nextval('s')"}},
+	}
+
+	snippet := GenerateInsertSnippet(table)
+	if snippet != `INSERT INTO "counters" DEFAULT VALUES;` {
+		t.Errorf("Unexpected snippet: %s", snippet)
+	}
+}
+
+func TestGenerateUpdateSnippet(t *testing.T) {
+	snippet, err := GenerateUpdateSnippet(snippetTestTable())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.Contains(snippet, `WHERE "id" = 0 /* integer */`) {
+		t.Errorf("Expected WHERE on primary key, got:\n%s", snippet)
+	}
+
+	if strings.Contains(snippet, `"id" = 0 /* integer */,`) {
+		t.Errorf("Primary key should not be in SET clause, got:\n%s", snippet)
+	}
+}
+
+func TestGenerateUpdateSnippetWithoutPrimaryKey(t *testing.T) {
+	table := snippetTestTable()
+	table.PrimaryKeys = nil
+
+	if _, err := GenerateUpdateSnippet(table); err == nil {
+		t.Error("Expected error for table without primary key")
+	}
+}
+
+func TestGenerateSnippetNoColumns(t *testing.T) {
+	if _, err := GenerateSnippet(SnippetSelect, &TableMetadata{Name: "empty"}); err == nil {
+		t.Error("Expected error when no columns are available")
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/abneribeiro/godev/internal/filelock"
+	"github.com/abneribeiro/godev/internal/paths"
 )
 
 type SavedQuery struct {
@@ -17,6 +20,9 @@ type SavedQuery struct {
 	Query     string    `json:"query"`
 	CreatedAt time.Time `json:"created_at"`
 	LastUsed  time.Time `json:"last_used"`
+	// UseVariables fills the {{VARIABLES}} of the active environment into
+	// the query before it runs
+	UseVariables bool `json:"use_variables,omitempty"`
 }
 
 type QueryExecution struct {
@@ -34,11 +40,15 @@ type DatabaseConfig struct {
 	SavedQueries     []SavedQuery       `json:"saved_queries"`
 	QueryHistory     []QueryExecution   `json:"query_history"`
 	SavedConnections []ConnectionConfig `json:"saved_connections"`
+	// RowEndpoints maps a table to the API endpoint of its rows
+	RowEndpoints map[string]string `json:"row_endpoints,omitempty"`
 }
 
 type DatabaseStorage struct {
 	configPath string
 	config     *DatabaseConfig
+	// stamp identifies the version of database.json that config was read from
+	stamp filelock.Stamp
 }
 
 const (
@@ -48,12 +58,16 @@ const (
 )
 
 func NewDatabaseStorage() (*DatabaseStorage, error) {
-	homeDir, err := os.UserHomeDir()
+	dir, err := paths.DataDir()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get home directory: %w", err)
+		return nil, err
 	}
 
-	configDirPath := filepath.Join(homeDir, ".godev")
+	return NewDatabaseStorageAt(dir)
+}
+
+// NewDatabaseStorageAt opens the database storage kept in the given directory
+func NewDatabaseStorageAt(configDirPath string) (*DatabaseStorage, error) {
 	// Use secure directory permissions (0700 - only owner can access)
 	if err := os.MkdirAll(configDirPath, 0o700); err != nil {
 		return nil, fmt.Errorf("failed to create config directory: %w", err)
@@ -77,21 +91,13 @@ func NewDatabaseStorage() (*DatabaseStorage, error) {
 		}
 	}
 
-	if storage.config.SavedQueries == nil {
-		storage.config.SavedQueries = []SavedQuery{}
-	}
-	if storage.config.QueryHistory == nil {
-		storage.config.QueryHistory = []QueryExecution{}
-	}
-	if storage.config.SavedConnections == nil {
-		storage.config.SavedConnections = []ConnectionConfig{}
-	}
+	storage.fillDefaults()
 
 	return storage, nil
 }
 
 func (s *DatabaseStorage) load() error {
-	data, err := os.ReadFile(s.configPath)
+	data, stamp, err := filelock.ReadFile(s.configPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return err
@@ -105,6 +111,7 @@ func (s *DatabaseStorage) load() error {
 	}
 
 	s.config = &config
+	s.stamp = stamp
 	return nil
 }
 
@@ -116,13 +123,58 @@ func (s *DatabaseStorage) save() error {
 
 	// Use secure file permissions (0600 - only owner can read/write)
 	// This is critical as the file may contain database passwords
-	if err := os.WriteFile(s.configPath, data, 0o600); err != nil {
+	if err := filelock.WriteFile(s.configPath, data, 0o600); err != nil {
 		return fmt.Errorf("failed to write database config file: %w", err)
 	}
+	s.stamp = filelock.StampOf(s.configPath)
 
 	return nil
 }
 
+// edit applies fn to the latest config and saves it while holding the lock
+// on database.json, so another godev instance's changes are not overwritten
+func (s *DatabaseStorage) edit(fn func(*DatabaseConfig) error) error {
+	lock, err := filelock.Acquire(s.configPath)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	if _, err := s.Refresh(); err != nil {
+		return err
+	}
+	if err := fn(s.config); err != nil {
+		return err
+	}
+	return s.save()
+}
+
+// Refresh reloads saved queries, query history and connections when another
+// godev instance changed them on disk and reports whether it did
+func (s *DatabaseStorage) Refresh() (bool, error) {
+	stamp := filelock.StampOf(s.configPath)
+	if stamp == (filelock.Stamp{}) || stamp == s.stamp {
+		return false, nil
+	}
+	if err := s.load(); err != nil {
+		return false, err
+	}
+	s.fillDefaults()
+	return true, nil
+}
+
+func (s *DatabaseStorage) fillDefaults() {
+	if s.config.SavedQueries == nil {
+		s.config.SavedQueries = []SavedQuery{}
+	}
+	if s.config.QueryHistory == nil {
+		s.config.QueryHistory = []QueryExecution{}
+	}
+	if s.config.SavedConnections == nil {
+		s.config.SavedConnections = []ConnectionConfig{}
+	}
+}
+
 func (s *DatabaseStorage) SaveQuery(name, query string) error {
 	now := time.Now()
 
@@ -134,8 +186,10 @@ func (s *DatabaseStorage) SaveQuery(name, query string) error {
 		LastUsed:  now,
 	}
 
-	s.config.SavedQueries = append(s.config.SavedQueries, savedQuery)
-	return s.save()
+	return s.edit(func(c *DatabaseConfig) error {
+		c.SavedQueries = append(c.SavedQueries, savedQuery)
+		return nil
+	})
 }
 
 func (s *DatabaseStorage) GetQueries() []SavedQuery {
@@ -143,13 +197,43 @@ func (s *DatabaseStorage) GetQueries() []SavedQuery {
 }
 
 func (s *DatabaseStorage) DeleteQuery(id string) error {
-	for i := range s.config.SavedQueries {
-		if s.config.SavedQueries[i].ID == id {
-			s.config.SavedQueries = append(s.config.SavedQueries[:i], s.config.SavedQueries[i+1:]...)
-			return s.save()
+	return s.edit(func(c *DatabaseConfig) error {
+		for i := range c.SavedQueries {
+			if c.SavedQueries[i].ID == id {
+				c.SavedQueries = append(c.SavedQueries[:i], c.SavedQueries[i+1:]...)
+				return nil
+			}
 		}
-	}
-	return fmt.Errorf("query not found: %s", id)
+		return fmt.Errorf("query not found: %s", id)
+	})
+}
+
+// SetQueryVariables turns filling in environment variables on or off for a
+// saved query
+func (s *DatabaseStorage) SetQueryVariables(id string, useVariables bool) error {
+	return s.edit(func(c *DatabaseConfig) error {
+		for i := range c.SavedQueries {
+			if c.SavedQueries[i].ID == id {
+				c.SavedQueries[i].UseVariables = useVariables
+				return nil
+			}
+		}
+		return fmt.Errorf("query not found: %s", id)
+	})
+}
+
+// RestoreQuery adds back a query that was deleted
+func (s *DatabaseStorage) RestoreQuery(query SavedQuery) error {
+	return s.edit(func(c *DatabaseConfig) error {
+		for _, existing := range c.SavedQueries {
+			if existing.ID == query.ID {
+				return fmt.Errorf("query already exists: %s", query.Name)
+			}
+		}
+
+		c.SavedQueries = append(c.SavedQueries, query)
+		return nil
+	})
 }
 
 func (s *DatabaseStorage) QueryExists(name string) bool {
@@ -193,13 +277,14 @@ func (s *DatabaseStorage) AddToQueryHistory(query, connectionInfo string, rowsAf
 		execution.Error = err.Error()
 	}
 
-	s.config.QueryHistory = append([]QueryExecution{execution}, s.config.QueryHistory...)
-
-	if len(s.config.QueryHistory) > maxQueryHistory {
-		s.config.QueryHistory = s.config.QueryHistory[:maxQueryHistory]
-	}
+	return s.edit(func(c *DatabaseConfig) error {
+		c.QueryHistory = append([]QueryExecution{execution}, c.QueryHistory...)
 
-	return s.save()
+		if len(c.QueryHistory) > maxQueryHistory {
+			c.QueryHistory = c.QueryHistory[:maxQueryHistory]
+		}
+		return nil
+	})
 }
 
 func (s *DatabaseStorage) GetQueryHistory() []QueryExecution {
@@ -207,30 +292,36 @@ func (s *DatabaseStorage) GetQueryHistory() []QueryExecution {
 }
 
 func (s *DatabaseStorage) ClearQueryHistory() error {
-	s.config.QueryHistory = []QueryExecution{}
-	return s.save()
+	return s.edit(func(c *DatabaseConfig) error {
+		c.QueryHistory = []QueryExecution{}
+		return nil
+	})
 }
 
 func (s *DatabaseStorage) DeleteQueryHistoryItem(id string) error {
-	for i := range s.config.QueryHistory {
-		if s.config.QueryHistory[i].ID == id {
-			s.config.QueryHistory = append(s.config.QueryHistory[:i], s.config.QueryHistory[i+1:]...)
-			return s.save()
+	return s.edit(func(c *DatabaseConfig) error {
+		for i := range c.QueryHistory {
+			if c.QueryHistory[i].ID == id {
+				c.QueryHistory = append(c.QueryHistory[:i], c.QueryHistory[i+1:]...)
+				return nil
+			}
 		}
-	}
-	return fmt.Errorf("query history item not found: %s", id)
+		return fmt.Errorf("query history item not found: %s", id)
+	})
 }
 
 func (s *DatabaseStorage) SaveConnection(config ConnectionConfig) error {
-	for i, conn := range s.config.SavedConnections {
-		if conn.Host == config.Host && conn.Port == config.Port && conn.Database == config.Database {
-			s.config.SavedConnections[i] = config
-			return s.save()
+	return s.edit(func(c *DatabaseConfig) error {
+		for i, conn := range c.SavedConnections {
+			if conn.Host == config.Host && conn.Port == config.Port && conn.Database == config.Database {
+				c.SavedConnections[i] = config
+				return nil
+			}
 		}
-	}
 
-	s.config.SavedConnections = append(s.config.SavedConnections, config)
-	return s.save()
+		c.SavedConnections = append(c.SavedConnections, config)
+		return nil
+	})
 }
 
 func (s *DatabaseStorage) GetSavedConnections() []ConnectionConfig {
@@ -238,12 +329,14 @@ func (s *DatabaseStorage) GetSavedConnections() []ConnectionConfig {
 }
 
 func (s *DatabaseStorage) DeleteConnection(host string, port int, database string) error {
-	for i := range s.config.SavedConnections {
-		conn := s.config.SavedConnections[i]
-		if conn.Host == host && conn.Port == port && conn.Database == database {
-			s.config.SavedConnections = append(s.config.SavedConnections[:i], s.config.SavedConnections[i+1:]...)
-			return s.save()
+	return s.edit(func(c *DatabaseConfig) error {
+		for i := range c.SavedConnections {
+			conn := c.SavedConnections[i]
+			if conn.Host == host && conn.Port == port && conn.Database == database {
+				c.SavedConnections = append(c.SavedConnections[:i], c.SavedConnections[i+1:]...)
+				return nil
+			}
 		}
-	}
-	return fmt.Errorf("connection not found")
+		return fmt.Errorf("connection not found")
+	})
 }
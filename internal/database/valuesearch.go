@@ -0,0 +1,157 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ValueSearchLimit bounds the rows a value search reads from each table
+const ValueSearchLimit = 20
+
+// searchableTypes are the column types a value search looks in, as
+// information_schema names them. UUID and JSON columns are compared as
+// text, since ids and payloads sent to an API end up there.
+var searchableTypes = map[string]bool{
+	"text":              true,
+	"character varying": true,
+	"character":         true,
+	"name":              true,
+	"uuid":              true,
+	"json":              true,
+	"jsonb":             true,
+}
+
+// ValueMatch is a column of a row that holds the value searched for
+type ValueMatch struct {
+	Column string
+	Value  string
+}
+
+// TableSearch is what a value search found in one table
+type TableSearch struct {
+	Table string
+	// Columns are the columns searched; a table without text columns is
+	// not queried
+	Columns []string
+	// Rows is how many rows hold the value, up to the limit
+	Rows int
+	// Truncated tells the table has more rows than the limit
+	Truncated bool
+	Matches   []ValueMatch
+	Err       error
+}
+
+// SearchableColumns returns the columns of table a value search looks in
+func SearchableColumns(table *TableInfo) []string {
+	var columns []string
+	for _, col := range table.Columns {
+		if searchableTypes[strings.ToLower(col.Type)] {
+			columns = append(columns, col.Name)
+		}
+	}
+	return columns
+}
+
+// escapeLike escapes the wildcards of value for a LIKE pattern
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// ValueSearchQuery returns the query for the rows of table with $1 in one
+// of columns, case-insensitively. It reads one row past limit to tell
+// whether there are more.
+func ValueSearchQuery(table string, columns []string, limit int) string {
+	selects := make([]string, len(columns))
+	filters := make([]string, len(columns))
+	for i, col := range columns {
+		selects[i] = quoteIdentifier(col) + "::text"
+		filters[i] = quoteIdentifier(col) + "::text ILIKE $1"
+	}
+	return fmt.Sprintf("SELECT %s FROM %s WHERE %s LIMIT %d",
+		strings.Join(selects, ", "), quoteIdentifier(table), strings.Join(filters, " OR "), limit+1)
+}
+
+// ValueMatchQuery returns a query to open in the editor for the rows of
+// table with value in column
+func ValueMatchQuery(table, column, value string, limit int) string {
+	pattern := "%" + escapeLike(value) + "%"
+	return fmt.Sprintf("SELECT *\nFROM %s\nWHERE %s::text ILIKE '%s'\nLIMIT %d;",
+		quoteIdentifier(table), quoteIdentifier(column), strings.ReplaceAll(pattern, "'", "''"), limit)
+}
+
+// matchRow returns the columns of a row whose values hold value, the way
+// ILIKE compares them
+func matchRow(columns []string, row []*string, value string) []ValueMatch {
+	var matches []ValueMatch
+	needle := strings.ToLower(value)
+	for i, v := range row {
+		if v != nil && strings.Contains(strings.ToLower(*v), needle) {
+			matches = append(matches, ValueMatch{Column: columns[i], Value: *v})
+		}
+	}
+	return matches
+}
+
+// SearchValue looks for value in the text columns of tables, reading at
+// most limit rows of each. A table that cannot be searched has Err set
+// and the search goes on with the next one; canceling ctx stops it.
+func (c *PostgresClient) SearchValue(ctx context.Context, value string, tables []string, limit int) ([]TableSearch, error) {
+	if c.db == nil {
+		return nil, fmt.Errorf("not connected to database")
+	}
+	if value == "" {
+		return nil, fmt.Errorf("value cannot be empty")
+	}
+
+	results := make([]TableSearch, 0, len(tables))
+	for _, table := range tables {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		results = append(results, c.searchTable(ctx, table, value, limit))
+	}
+	return results, nil
+}
+
+func (c *PostgresClient) searchTable(ctx context.Context, table, value string, limit int) TableSearch {
+	result := TableSearch{Table: table}
+	info, err := c.GetTableInfo(table)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Columns = SearchableColumns(info)
+	if len(result.Columns) == 0 {
+		return result
+	}
+
+	rows, err := c.db.QueryContext(ctx, ValueSearchQuery(table, result.Columns, limit), "%"+escapeLike(value)+"%")
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if result.Rows == limit {
+			result.Truncated = true
+			break
+		}
+		row := make([]*string, len(result.Columns))
+		ptrs := make([]interface{}, len(row))
+		for i := range row {
+			ptrs[i] = &row[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			result.Err = err
+			return result
+		}
+		result.Rows++
+		result.Matches = append(result.Matches, matchRow(result.Columns, row, value)...)
+	}
+	if err := rows.Err(); err != nil {
+		result.Err = err
+	}
+	return result
+}
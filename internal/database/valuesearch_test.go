@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSearchableColumns(t *testing.T) {
+	table := &TableInfo{Name: "users", Columns: []ColumnInfo{
+		{Name: "id", Type: "integer"},
+		{Name: "email", Type: "character varying"},
+		{Name: "external_id", Type: "uuid"},
+		{Name: "created_at", Type: "timestamp without time zone"},
+		{Name: "profile", Type: "jsonb"},
+	}}
+
+	got := SearchableColumns(table)
+	want := []string{"email", "external_id", "profile"}
+	if len(got) != len(want) {
+		t.Fatalf("SearchableColumns() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("SearchableColumns()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestValueSearchQuery(t *testing.T) {
+	got := ValueSearchQuery(`odd"name`, []string{"email", "note"}, 20)
+	want := `SELECT "email"::text, "note"::text FROM "odd""name" WHERE "email"::text ILIKE $1 OR "note"::text ILIKE $1 LIMIT 21`
+	if got != want {
+		t.Errorf("ValueSearchQuery() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestValueMatchQueryEscapesTheValue(t *testing.T) {
+	got := ValueMatchQuery("users", "email", `o'hara_100%`, 50)
+	want := "SELECT *\nFROM \"users\"\nWHERE \"email\"::text ILIKE '%o''hara\\_100\\%%'\nLIMIT 50;"
+	if got != want {
+		t.Errorf("ValueMatchQuery() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMatchRow(t *testing.T) {
+	email, note := "Test-User@example.com", "created by test-user"
+	other := "unrelated"
+	columns := []string{"email", "note", "name", "deleted"}
+
+	matches := matchRow(columns, []*string{&email, &note, &other, nil}, "test-user")
+	if len(matches) != 2 {
+		t.Fatalf("matchRow() = %+v, want email and note", matches)
+	}
+	if matches[0].Column != "email" || matches[0].Value != email || matches[1].Column != "note" {
+		t.Errorf("matchRow() = %+v", matches)
+	}
+}
+
+func TestSearchValueRequiresConnection(t *testing.T) {
+	if _, err := NewPostgresClient().SearchValue(context.Background(), "x", []string{"users"}, ValueSearchLimit); err == nil {
+		t.Error("SearchValue() without a connection returned no error")
+	}
+}
@@ -0,0 +1,165 @@
+// Package dns looks up the records of a name, through the system resolver
+// or a chosen DNS server, for checking what a host resolves to while
+// debugging an API.
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RecordType is a kind of DNS record that can be looked up
+type RecordType string
+
+const (
+	A     RecordType = "A"
+	AAAA  RecordType = "AAAA"
+	CNAME RecordType = "CNAME"
+	TXT   RecordType = "TXT"
+	SRV   RecordType = "SRV"
+	MX    RecordType = "MX"
+	NS    RecordType = "NS"
+)
+
+// RecordTypes lists the record types in the order they are offered
+var RecordTypes = []RecordType{A, AAAA, CNAME, TXT, SRV, MX, NS}
+
+// Resolvers lists well-known public resolvers to pick from; the empty
+// address is the system resolver
+var Resolvers = []string{"", "1.1.1.1", "8.8.8.8", "9.9.9.9"}
+
+// Result is the answer to one lookup
+type Result struct {
+	Name     string
+	Type     RecordType
+	Resolver string
+	// Records holds the answers formatted one per line
+	Records  []string
+	Duration time.Duration
+	Err      error
+}
+
+// NotFound reports whether the lookup failed because the name or the
+// records do not exist, rather than because the resolver could not answer
+func (r Result) NotFound() bool {
+	var dnsErr *net.DNSError
+	return errors.As(r.Err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// ResolverAddress returns server as host:port, adding port 53 when none
+// is given. The empty server stays empty and means the system resolver.
+func ResolverAddress(server string) string {
+	server = strings.TrimSpace(server)
+	if server == "" {
+		return ""
+	}
+	if _, _, err := net.SplitHostPort(server); err == nil {
+		return server
+	}
+	return net.JoinHostPort(strings.Trim(server, "[]"), "53")
+}
+
+// NewResolver returns a resolver that sends its queries to server, or the
+// system resolver when server is empty
+func NewResolver(server string) *net.Resolver {
+	addr := ResolverAddress(server)
+	if addr == "" {
+		return net.DefaultResolver
+	}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, addr)
+		},
+	}
+}
+
+// Lookup asks resolver for the records of type t for name
+func Lookup(ctx context.Context, name string, t RecordType, server string) Result {
+	name = strings.TrimSpace(name)
+	result := Result{Name: name, Type: t, Resolver: ResolverAddress(server)}
+	if name == "" {
+		result.Err = fmt.Errorf("no name to look up")
+		return result
+	}
+
+	start := time.Now()
+	result.Records, result.Err = lookup(ctx, NewResolver(server), name, t)
+	result.Duration = time.Since(start)
+	return result
+}
+
+func lookup(ctx context.Context, r *net.Resolver, name string, t RecordType) ([]string, error) {
+	switch t {
+	case A, AAAA:
+		network := "ip4"
+		if t == AAAA {
+			network = "ip6"
+		}
+		ips, err := r.LookupIP(ctx, network, name)
+		if err != nil {
+			return nil, err
+		}
+		records := make([]string, len(ips))
+		for i, ip := range ips {
+			records[i] = ip.String()
+		}
+		return records, nil
+
+	case CNAME:
+		cname, err := r.LookupCNAME(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		// A name without a CNAME record is its own canonical name
+		if strings.EqualFold(strings.TrimSuffix(cname, "."), strings.TrimSuffix(name, ".")) {
+			return nil, nil
+		}
+		return []string{cname}, nil
+
+	case TXT:
+		return r.LookupTXT(ctx, name)
+
+	case SRV:
+		// The name is the full record name, like _sip._tcp.example.com
+		_, srvs, err := r.LookupSRV(ctx, "", "", name)
+		if err != nil {
+			return nil, err
+		}
+		records := make([]string, len(srvs))
+		for i, srv := range srvs {
+			records[i] = fmt.Sprintf("%s:%d priority %d weight %d", srv.Target, srv.Port, srv.Priority, srv.Weight)
+		}
+		return records, nil
+
+	case MX:
+		mxs, err := r.LookupMX(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		records := make([]string, len(mxs))
+		for i, mx := range mxs {
+			records[i] = fmt.Sprintf("%s preference %d", mx.Host, mx.Pref)
+		}
+		return records, nil
+
+	case NS:
+		nss, err := r.LookupNS(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		records := make([]string, len(nss))
+		for i, ns := range nss {
+			records[i] = ns.Host
+		}
+		sort.Strings(records)
+		return records, nil
+	}
+	return nil, fmt.Errorf("unknown record type %q", t)
+}
@@ -0,0 +1,198 @@
+package dns
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Record types on the wire
+const (
+	typeA     = 1
+	typeNS    = 2
+	typeCNAME = 5
+	typeMX    = 15
+	typeTXT   = 16
+	typeAAAA  = 28
+	typeSRV   = 33
+)
+
+// zone maps a lower-case FQDN to the rdata of its records by type
+type zone map[string]map[uint16][][]byte
+
+// serveZone answers UDP queries from z and returns the server address.
+// Names not in z get NXDOMAIN; a name with a CNAME answers every A, AAAA
+// and CNAME query with it.
+func serveZone(t *testing.T, z zone) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if reply := answer(z, buf[:n]); reply != nil {
+				conn.WriteTo(reply, addr)
+			}
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func answer(z zone, query []byte) []byte {
+	if len(query) < 12 {
+		return nil
+	}
+	var labels []string
+	i := 12
+	for i < len(query) && query[i] != 0 {
+		l := int(query[i])
+		labels = append(labels, string(query[i+1:i+1+l]))
+		i += 1 + l
+	}
+	qend := i + 5
+	if qend > len(query) {
+		return nil
+	}
+	name := strings.ToLower(strings.Join(labels, ".")) + "."
+	qtype := binary.BigEndian.Uint16(query[i+1:])
+
+	records, found := z[name]
+	answerType := qtype
+	if cname, ok := records[typeCNAME]; ok && (qtype == typeA || qtype == typeAAAA || qtype == typeCNAME) {
+		answerType = typeCNAME
+		records = map[uint16][][]byte{typeCNAME: cname}
+	}
+
+	flags := uint16(0x8180) // response, recursion desired and available
+	if !found {
+		flags |= 3 // NXDOMAIN
+	}
+	reply := make([]byte, 12, 512)
+	copy(reply, query[:2])
+	binary.BigEndian.PutUint16(reply[2:], flags)
+	binary.BigEndian.PutUint16(reply[4:], 1)
+	binary.BigEndian.PutUint16(reply[6:], uint16(len(records[answerType])))
+	reply = append(reply, query[12:qend]...)
+	for _, rdata := range records[answerType] {
+		reply = append(reply, 0xc0, 12) // pointer to the question name
+		reply = binary.BigEndian.AppendUint16(reply, answerType)
+		reply = binary.BigEndian.AppendUint16(reply, 1)
+		reply = binary.BigEndian.AppendUint32(reply, 60)
+		reply = binary.BigEndian.AppendUint16(reply, uint16(len(rdata)))
+		reply = append(reply, rdata...)
+	}
+	return reply
+}
+
+func wireName(name string) []byte {
+	var b []byte
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		b = append(b, byte(len(label)))
+		b = append(b, label...)
+	}
+	return append(b, 0)
+}
+
+func wireTXT(texts ...string) []byte {
+	var b []byte
+	for _, text := range texts {
+		b = append(b, byte(len(text)))
+		b = append(b, text...)
+	}
+	return b
+}
+
+func testZone() zone {
+	srv := binary.BigEndian.AppendUint16(nil, 10)
+	srv = binary.BigEndian.AppendUint16(srv, 5)
+	srv = binary.BigEndian.AppendUint16(srv, 5060)
+	srv = append(srv, wireName("sip.example.test")...)
+
+	return zone{
+		"api.example.test.": {
+			typeA:    {{10, 0, 0, 1}, {10, 0, 0, 2}},
+			typeAAAA: {net.ParseIP("2001:db8::1").To16()},
+			typeTXT:  {wireTXT("v=spf1 -all")},
+			typeMX:   {append([]byte{0, 10}, wireName("mail.example.test")...)},
+			typeNS:   {wireName("ns2.example.test"), wireName("ns1.example.test")},
+		},
+		"www.example.test.":       {typeCNAME: {wireName("api.example.test")}},
+		"_sip._tcp.example.test.": {typeSRV: {srv}},
+	}
+}
+
+func TestLookupRecordTypes(t *testing.T) {
+	server := serveZone(t, testZone())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tests := []struct {
+		name string
+		t    RecordType
+		want []string
+	}{
+		{"api.example.test.", A, []string{"10.0.0.1", "10.0.0.2"}},
+		{"api.example.test.", AAAA, []string{"2001:db8::1"}},
+		{"api.example.test.", TXT, []string{"v=spf1 -all"}},
+		{"api.example.test.", MX, []string{"mail.example.test. preference 10"}},
+		{"api.example.test.", NS, []string{"ns1.example.test.", "ns2.example.test."}},
+		{"www.example.test.", CNAME, []string{"api.example.test."}},
+		{"_sip._tcp.example.test.", SRV, []string{"sip.example.test.:5060 priority 10 weight 5"}},
+	}
+	for _, tt := range tests {
+		result := Lookup(ctx, tt.name, tt.t, server)
+		if result.Err != nil {
+			t.Errorf("Lookup(%s, %s) error = %v", tt.name, tt.t, result.Err)
+			continue
+		}
+		if !reflect.DeepEqual(result.Records, tt.want) {
+			t.Errorf("Lookup(%s, %s) = %v, want %v", tt.name, tt.t, result.Records, tt.want)
+		}
+		if result.Resolver != server {
+			t.Errorf("Resolver = %q, want %q", result.Resolver, server)
+		}
+	}
+}
+
+func TestLookupMissingName(t *testing.T) {
+	server := serveZone(t, testZone())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result := Lookup(ctx, "missing.example.test.", A, server)
+	if result.Err == nil || !result.NotFound() {
+		t.Errorf("Expected a not found error, got %v", result.Err)
+	}
+
+	if result := Lookup(ctx, " ", A, server); result.Err == nil {
+		t.Error("Expected an empty name to be rejected")
+	}
+}
+
+func TestResolverAddress(t *testing.T) {
+	tests := map[string]string{
+		"":                "",
+		"1.1.1.1":         "1.1.1.1:53",
+		"1.1.1.1:5353":    "1.1.1.1:5353",
+		"2606:4700::1111": "[2606:4700::1111]:53",
+		"[::1]:53":        "[::1]:53",
+		"dns.local":       "dns.local:53",
+	}
+	for server, want := range tests {
+		if got := ResolverAddress(server); got != want {
+			t.Errorf("ResolverAddress(%q) = %q, want %q", server, got, want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package errors
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+// Category groups errors by what the user can do about them
+type Category string
+
+const (
+	CategoryNetwork Category = "network"
+	CategoryTimeout Category = "timeout"
+	CategoryTLS     Category = "tls"
+	CategoryParse   Category = "parse"
+	CategoryStorage Category = "storage"
+	CategoryUnknown Category = "unknown"
+)
+
+// Categories lists every category, in the order Classify checks them
+var Categories = []Category{CategoryTimeout, CategoryTLS, CategoryParse, CategoryStorage, CategoryNetwork, CategoryUnknown}
+
+// Classify reports the category of an error by looking through its chain.
+// Timeouts win over network errors, since a timed-out dial is both.
+func Classify(err error) Category {
+	switch {
+	case err == nil:
+		return CategoryUnknown
+	case isTimeout(err):
+		return CategoryTimeout
+	case isTLS(err):
+		return CategoryTLS
+	case isParse(err):
+		return CategoryParse
+	case isStorage(err):
+		return CategoryStorage
+	case isNetwork(err):
+		return CategoryNetwork
+	}
+	return CategoryUnknown
+}
+
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrNetworkTimeout) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func isTLS(err error) bool {
+	var (
+		verifyErr    *tls.CertificateVerificationError
+		recordErr    tls.RecordHeaderError
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+	)
+	if errors.As(err, &verifyErr) || errors.As(err, &recordErr) || errors.As(err, &authorityErr) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &invalidErr) {
+		return true
+	}
+	// Handshake failures from crypto/tls are plain errors prefixed with "tls:"
+	return strings.Contains(err.Error(), "tls: ")
+}
+
+func isParse(err error) bool {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+		urlErr    *url.Error
+	)
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) || errors.Is(err, ErrInvalidQuery) || errors.Is(err, ErrInvalidRequest) {
+		return true
+	}
+	if errors.As(err, &urlErr) && urlErr.Op == "parse" {
+		return true
+	}
+	return IsType(err, "VALIDATION_ERROR")
+}
+
+func isStorage(err error) bool {
+	var pathErr *fs.PathError
+	return IsStorageError(err) || IsType(err, "CONFIG_ERROR") || errors.As(err, &pathErr) ||
+		errors.Is(err, fs.ErrPermission) || errors.Is(err, ErrPermissionDenied)
+}
+
+func isNetwork(err error) bool {
+	var (
+		opErr  *net.OpError
+		dnsErr *net.DNSError
+	)
+	return errors.As(err, &opErr) || errors.As(err, &dnsErr) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) ||
+		errors.Is(err, ErrDatabaseConnection)
+}
@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	var syntaxErr error
+	if err := json.Unmarshal([]byte(`{"a":`), new(map[string]interface{})); err != nil {
+		syntaxErr = err
+	}
+	_, parseErr := url.Parse("http://[::1")
+	_, pathErr := os.ReadFile("/nonexistent/godev/config.json")
+
+	tests := []struct {
+		name string
+		err  error
+		want Category
+	}{
+		{"nil", nil, CategoryUnknown},
+		{"plain", errors.New("boom"), CategoryUnknown},
+		{"deadline", fmt.Errorf("request: %w", context.DeadlineExceeded), CategoryTimeout},
+		{"dial timeout", &url.Error{Op: "Get", URL: "http://x", Err: &net.OpError{Op: "dial", Err: timeoutError{}}}, CategoryTimeout},
+		{"refused", NewHTTPError("request failed", &url.Error{Op: "Get", URL: "http://x", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}), CategoryNetwork},
+		{"dns", &net.DNSError{Err: "no such host", Name: "api.invalid"}, CategoryNetwork},
+		{"unknown authority", &url.Error{Op: "Get", URL: "https://x", Err: x509.UnknownAuthorityError{}}, CategoryTLS},
+		{"handshake", errors.New("remote error: tls: handshake failure"), CategoryTLS},
+		{"json", syntaxErr, CategoryParse},
+		{"url", NewHTTPError("invalid URL", parseErr), CategoryParse},
+		{"validation", NewValidationError("bad header", nil), CategoryParse},
+		{"storage", NewStorageError("failed to write", nil), CategoryStorage},
+		{"path", fmt.Errorf("failed to read config: %w", pathErr), CategoryStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Classify(tt.err); got != tt.want {
+				t.Errorf("Classify(%v) = %s, want %s", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
@@ -0,0 +1,152 @@
+// Package filelock keeps concurrent godev instances from corrupting the JSON
+// stores they share. Writers hold an advisory lock on a sidecar .lock file
+// while they read, change and write a store, and every write replaces the
+// store atomically so readers never see a half-written file.
+package filelock
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Timeout is how long Acquire waits for another instance to release a lock
+var Timeout = 5 * time.Second
+
+const retryInterval = 20 * time.Millisecond
+
+// ErrLocked is returned when a lock is still held after Timeout
+var ErrLocked = errors.New("locked by another godev instance")
+
+// Lock is an exclusive lock on a store file
+type Lock struct {
+	file *os.File
+}
+
+// Acquire locks the store at path, waiting up to Timeout for another
+// process to release it. The lock lives in path + ".lock".
+func Acquire(path string) (*Lock, error) {
+	file, err := os.OpenFile(path+".lock", os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	deadline := time.Now().Add(Timeout)
+	for {
+		ok, err := tryLock(file)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", filepath.Base(path), err)
+		}
+		if ok {
+			return &Lock{file: file}, nil
+		}
+		if time.Now().After(deadline) {
+			file.Close()
+			return nil, fmt.Errorf("%s is %w", filepath.Base(path), ErrLocked)
+		}
+		time.Sleep(retryInterval)
+	}
+}
+
+// Unlock releases the lock
+func (l *Lock) Unlock() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	err := unlock(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	l.file = nil
+	return err
+}
+
+// WriteFile writes data to a temporary file next to path, syncs it and
+// renames it into place, so the file at path is always either the old or the
+// new content
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	return writeFile(path, data, perm, true)
+}
+
+// WriteFileNoSync is WriteFile without the fsync: a crash can lose the new
+// content, but never leaves a half-written file behind
+func WriteFileNoSync(path string, data []byte, perm os.FileMode) error {
+	return writeFile(path, data, perm, false)
+}
+
+func writeFile(path string, data []byte, perm os.FileMode, sync bool) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if sync {
+		if err := tmp.Sync(); err != nil {
+			tmp.Close()
+			os.Remove(tmpPath)
+			return err
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// Stamp identifies a version of a file on disk
+type Stamp struct {
+	ModTime time.Time
+	Size    int64
+}
+
+// StampOf returns the stamp of the file at path, or the zero Stamp when it
+// does not exist
+func StampOf(path string) Stamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Stamp{}
+	}
+	return stampOf(info)
+}
+
+// ReadFile reads the file at path together with the stamp of the content read
+func ReadFile(path string) ([]byte, Stamp, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, Stamp{}, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, Stamp{}, err
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, Stamp{}, err
+	}
+	return data, stampOf(info), nil
+}
+
+func stampOf(info os.FileInfo) Stamp {
+	return Stamp{ModTime: info.ModTime(), Size: info.Size()}
+}
@@ -0,0 +1,77 @@
+package filelock
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestAcquireIsExclusive(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no lock arbitration on this platform")
+	}
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	first, err := Acquire(path)
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+
+	orig := Timeout
+	Timeout = 50 * time.Millisecond
+	defer func() { Timeout = orig }()
+
+	if _, err := Acquire(path); !errors.Is(err, ErrLocked) {
+		t.Fatalf("Acquire() while held error = %v, want ErrLocked", err)
+	}
+
+	if err := first.Unlock(); err != nil {
+		t.Fatalf("Unlock() error = %v", err)
+	}
+	second, err := Acquire(path)
+	if err != nil {
+		t.Fatalf("Acquire() after unlock error = %v", err)
+	}
+	second.Unlock()
+}
+
+func TestWriteFileReplacesAtomically(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+
+	if err := WriteFile(path, []byte(`{"v": 1}`), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	before := StampOf(path)
+
+	if err := WriteFile(path, []byte(`{"v": 22}`), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	data, stamp, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(data) != `{"v": 22}` {
+		t.Errorf("ReadFile() = %s, want the new content", data)
+	}
+	if stamp == before || stamp != StampOf(path) {
+		t.Errorf("Expected a new stamp after the write, got %+v (before %+v)", stamp, before)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected mode 0600, got %v", info.Mode().Perm())
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("Expected no temporary files to be left behind, got %d entries", len(entries))
+	}
+}
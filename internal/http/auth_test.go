@@ -0,0 +1,108 @@
+package http
+
+import (
+	"testing"
+)
+
+func TestAuthApply(t *testing.T) {
+	base := Request{
+		Method:  "GET",
+		URL:     "https://api.example.com/users?page=2",
+		Headers: map[string]string{"authorization": "hand written", "Accept": "application/json"},
+	}
+
+	tests := []struct {
+		name       string
+		auth       Auth
+		wantHeader string
+		wantValue  string
+		wantURL    string
+	}{
+		{
+			name:       "basic",
+			auth:       Auth{Type: AuthBasic, Username: "aladdin", Password: "opensesame"},
+			wantHeader: "Authorization",
+			wantValue:  "Basic YWxhZGRpbjpvcGVuc2VzYW1l",
+		},
+		{
+			name:       "bearer",
+			auth:       Auth{Type: AuthBearer, Token: " abc.def.ghi "},
+			wantHeader: "Authorization",
+			wantValue:  "Bearer abc.def.ghi",
+		},
+		{
+			name:       "API key in header",
+			auth:       Auth{Type: AuthAPIKey, KeyName: "X-API-Key", KeyValue: "secret", In: AuthInHeader},
+			wantHeader: "X-API-Key",
+			wantValue:  "secret",
+		},
+		{
+			name:    "API key in query",
+			auth:    Auth{Type: AuthAPIKey, KeyName: "api_key", KeyValue: "s3cr&t", In: AuthInQuery},
+			wantURL: "https://api.example.com/users?api_key=s3cr%26t&page=2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.auth.Apply(base)
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if tt.wantURL != "" {
+				if got.URL != tt.wantURL {
+					t.Errorf("URL = %q, want %q", got.URL, tt.wantURL)
+				}
+				if got.Headers["authorization"] != "hand written" {
+					t.Errorf("Expected headers to be left alone, got %v", got.Headers)
+				}
+				return
+			}
+			if got.Headers[tt.wantHeader] != tt.wantValue {
+				t.Errorf("Headers[%s] = %q, want %q", tt.wantHeader, got.Headers[tt.wantHeader], tt.wantValue)
+			}
+			if tt.wantHeader == "Authorization" {
+				if _, ok := got.Headers["authorization"]; ok {
+					t.Errorf("Expected the hand-written header to be replaced, got %v", got.Headers)
+				}
+			}
+			if got.Headers["Accept"] != "application/json" {
+				t.Errorf("Expected other headers to be kept, got %v", got.Headers)
+			}
+		})
+	}
+
+	if base.Headers["authorization"] != "hand written" || len(base.Headers) != 2 {
+		t.Errorf("Apply() modified the headers of its input: %v", base.Headers)
+	}
+}
+
+func TestAuthValidate(t *testing.T) {
+	invalid := []Auth{
+		{Type: AuthBasic},
+		{Type: AuthBasic, Username: "a:b"},
+		{Type: AuthBearer, Token: "  "},
+		{Type: AuthAPIKey, KeyValue: "secret"},
+		{Type: AuthAPIKey, KeyName: "key", In: "cookie"},
+		{Type: "digest"},
+	}
+	for _, auth := range invalid {
+		if err := auth.Validate(); err == nil {
+			t.Errorf("Validate(%+v) expected an error", auth)
+		}
+		if _, err := auth.Apply(Request{URL: "https://example.com"}); err == nil {
+			t.Errorf("Apply(%+v) expected an error", auth)
+		}
+	}
+
+	valid := []Auth{
+		{Type: AuthBasic, Username: "user"},
+		{Type: AuthBearer, Token: "t"},
+		{Type: AuthAPIKey, KeyName: "key"},
+	}
+	for _, auth := range valid {
+		if err := auth.Validate(); err != nil {
+			t.Errorf("Validate(%+v) = %v", auth, err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"image"
+	_ "image/gif"  // decoders for BodySummary
+	_ "image/jpeg" // decoders for BodySummary
+	_ "image/png"  // decoders for BodySummary
+	"mime"
+	"strings"
+	"unicode/utf8"
+)
+
+// binaryMediaTypes are the media types whose bodies are never text, besides
+// the image, audio, video and font types
+var binaryMediaTypes = map[string]bool{
+	"application/octet-stream": true,
+	"application/pdf":          true,
+	"application/zip":          true,
+	"application/gzip":         true,
+	"application/x-gzip":       true,
+	"application/x-tar":        true,
+	"application/x-protobuf":   true,
+	"application/protobuf":     true,
+	"application/grpc":         true,
+	"application/msgpack":      true,
+	"application/x-msgpack":    true,
+	"application/wasm":         true,
+}
+
+// IsBinaryBody reports whether a body would garble a terminal if printed:
+// its Content-Type is not a text type, or its bytes are not UTF-8 text
+func IsBinaryBody(contentType, body string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != "image/svg+xml" {
+		kind, _, _ := strings.Cut(mediaType, "/")
+		switch kind {
+		case "image", "audio", "video", "font":
+			return true
+		}
+	}
+	if binaryMediaTypes[mediaType] {
+		return true
+	}
+	return !utf8.ValidString(body) || strings.ContainsRune(body, 0)
+}
+
+// BodySummary describes a binary body in place of its bytes
+type BodySummary struct {
+	// MediaType is the type of the Content-Type, "" when none was sent
+	MediaType string
+	Size      int64
+	// ImageFormat, Width and Height are set for GIF, JPEG and PNG images
+	ImageFormat string
+	Width       int
+	Height      int
+}
+
+// SummarizeBody describes body, reading the dimensions of images from
+// their header
+func SummarizeBody(contentType, body string) BodySummary {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	summary := BodySummary{MediaType: mediaType, Size: int64(len(body))}
+	if config, format, err := image.DecodeConfig(strings.NewReader(body)); err == nil {
+		summary.ImageFormat = strings.ToUpper(format)
+		summary.Width = config.Width
+		summary.Height = config.Height
+	}
+	return summary
+}
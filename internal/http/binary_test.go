@@ -0,0 +1,47 @@
+package http
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+)
+
+func TestIsBinaryBody(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+		want        bool
+	}{
+		{"application/json", `{"ok": true}`, false},
+		{"text/plain; charset=utf-8", "olá", false},
+		{"image/svg+xml", "<svg/>", false},
+		{"image/png", "\x89PNG", true},
+		{"application/pdf", "%PDF-1.7", true},
+		{"", "plain text", false},
+		{"", "\xff\xfe\x00\x01", true},
+		{"text/plain", "a\x00b", true},
+	}
+	for _, tt := range tests {
+		if got := IsBinaryBody(tt.contentType, tt.body); got != tt.want {
+			t.Errorf("IsBinaryBody(%q, %q) = %v, want %v", tt.contentType, tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestSummarizeBodyReadsImageSize(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 3, 2))); err != nil {
+		t.Fatal(err)
+	}
+
+	got := SummarizeBody("image/png", buf.String())
+	want := BodySummary{MediaType: "image/png", Size: int64(buf.Len()), ImageFormat: "PNG", Width: 3, Height: 2}
+	if got != want {
+		t.Errorf("SummarizeBody() = %+v, want %+v", got, want)
+	}
+
+	if got := SummarizeBody("application/octet-stream", "\x00\x01"); got.ImageFormat != "" || got.Size != 2 {
+		t.Errorf("Expected no image details for other bodies, got %+v", got)
+	}
+}
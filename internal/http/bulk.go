@@ -0,0 +1,158 @@
+package http
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// bulkConcurrency is how many URLs of a bulk run are in flight at once
+const bulkConcurrency = 5
+
+// MaxBulkURLs limits how many URLs are read from a list
+const MaxBulkURLs = 1000
+
+// BulkResult holds the outcome of one URL of a bulk run
+type BulkResult struct {
+	URL          string
+	StatusCode   int
+	Status       string
+	ResponseTime time.Duration
+	Size         int64
+	Error        error
+}
+
+// OK reports whether the URL answered with a 2xx or 3xx status
+func (r BulkResult) OK() bool {
+	return r.Error == nil && r.StatusCode >= 200 && r.StatusCode < 400
+}
+
+// ReadURLList reads one URL per line, skipping blank lines and # comments
+func ReadURLList(r io.Reader) ([]string, error) {
+	var urls []string
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// URLs with {{variables}} are checked once the environment is applied
+		if !strings.Contains(line, "{{") {
+			parsed, err := url.Parse(line)
+			if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+				return nil, fmt.Errorf("line %d: invalid URL %q", lineNo, line)
+			}
+		}
+
+		if len(urls) == MaxBulkURLs {
+			return nil, fmt.Errorf("URL list has more than %d entries", MaxBulkURLs)
+		}
+		urls = append(urls, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read URL list: %w", err)
+	}
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("URL list is empty")
+	}
+
+	return urls, nil
+}
+
+// ReadURLListFile reads a URL list from a file
+func ReadURLListFile(path string) ([]string, error) {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = home + path[1:]
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open URL list: %w", err)
+	}
+	defer file.Close()
+
+	return ReadURLList(file)
+}
+
+// RunBulk sends the template request to every URL, a few at a time, and
+// returns the results in the order of the list
+func RunBulk(client *Client, template Request, urls []string) []BulkResult {
+	results := make([]BulkResult, len(urls))
+	sem := make(chan struct{}, bulkConcurrency)
+	var wg sync.WaitGroup
+
+	for i, u := range urls {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, u string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			req := template
+			req.URL = u
+			resp := client.Send(req)
+
+			results[i] = BulkResult{
+				URL:          u,
+				StatusCode:   resp.StatusCode,
+				Status:       resp.Status,
+				ResponseTime: resp.ResponseTime,
+				Size:         resp.Size,
+				Error:        resp.Error,
+			}
+		}(i, u)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// FormatBulkResults renders a summary followed by one row per URL
+func FormatBulkResults(results []BulkResult) string {
+	var sb strings.Builder
+
+	ok := 0
+	times := make([]time.Duration, 0, len(results))
+	for _, r := range results {
+		if r.OK() {
+			ok++
+		}
+		if r.Error == nil {
+			times = append(times, r.ResponseTime)
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("%d URLs: %d ok, %d failed", len(results), ok, len(results)-ok))
+	if len(times) > 0 {
+		sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+		sb.WriteString(fmt.Sprintf(" • p50 %s • max %s", FormatDuration(times[len(times)/2]), FormatDuration(times[len(times)-1])))
+	}
+	sb.WriteString("\n\n")
+
+	for _, r := range results {
+		marker := "✓"
+		if !r.OK() {
+			marker = "✗"
+		}
+
+		if r.Error != nil {
+			sb.WriteString(fmt.Sprintf("%s %-4s %8s  %s\n    error: %v\n", marker, "ERR", "-", r.URL, r.Error))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s %-4d %8s  %s\n", marker, r.StatusCode, FormatDuration(r.ResponseTime), r.URL))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadURLList(t *testing.T) {
+	input := `
+# health checks
+https://a.example.com/health
+
+http://b.example.com/status
+{{API_URL}}/ping
+`
+	urls, err := ReadURLList(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadURLList() error = %v", err)
+	}
+	if len(urls) != 3 || urls[0] != "https://a.example.com/health" {
+		t.Errorf("Unexpected URLs: %v", urls)
+	}
+
+	if _, err := ReadURLList(strings.NewReader("https://ok.example.com\nnot a url\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected error on line 2, got %v", err)
+	}
+
+	if _, err := ReadURLList(strings.NewReader("# only comments\n")); err == nil {
+		t.Error("Expected error for empty list")
+	}
+}
+
+func TestRunBulk(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Token") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	urls := []string{server.URL + "/a", server.URL + "/down", server.URL + "/b", "http://127.0.0.1:1/unreachable"}
+	template := Request{Method: "GET", Headers: map[string]string{"X-Token": "secret"}}
+
+	results := RunBulk(NewClient(5*time.Second), template, urls)
+
+	if len(results) != 4 {
+		t.Fatalf("Expected 4 results, got %d", len(results))
+	}
+	for i, r := range results {
+		if r.URL != urls[i] {
+			t.Errorf("Result %d is for %s, want %s", i, r.URL, urls[i])
+		}
+	}
+	if !results[0].OK() || results[1].OK() || results[1].StatusCode != 503 || results[3].Error == nil {
+		t.Errorf("Unexpected results: %+v", results)
+	}
+
+	report := FormatBulkResults(results)
+	if !strings.HasPrefix(report, "4 URLs: 2 ok, 2 failed") {
+		t.Errorf("Unexpected summary:\n%s", report)
+	}
+}
@@ -9,13 +9,14 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/abneribeiro/godev/internal/errors"
 )
 
 const (
-	// MaxResponseSize limits response body to 100MB to prevent DoS
+	// MaxResponseSize is the default of SpoolThreshold
 	MaxResponseSize = 100 * 1024 * 1024 // 100MB
 )
 
@@ -24,6 +25,17 @@ type Request struct {
 	URL     string
 	Headers map[string]string
 	Body    string
+	// NewConnection sends the request on a new connection instead of one
+	// kept alive from an earlier request
+	NewConnection bool
+	// Timeout limits each attempt, in place of the timeout of the client
+	// when set
+	Timeout time.Duration
+	// Retry tries the request again when it fails
+	Retry RetryPolicy
+	// NoFollowRedirects returns a redirect as the response instead of
+	// following it
+	NoFollowRedirects bool
 }
 
 type Response struct {
@@ -34,10 +46,47 @@ type Response struct {
 	ResponseTime time.Duration
 	Size         int64
 	Error        error
+
+	// Proto is the protocol the response came over, like "HTTP/2.0"
+	Proto string
+	// Trailers holds the trailer fields sent after the body, as gRPC and
+	// chunked responses do
+	Trailers map[string][]string
+
+	// Download is set when the body was written to a file by Download
+	Download *DownloadResult
+	// Conn is the connection the request was sent on, nil when none was made
+	Conn *ConnInfo
+	// Timing breaks ResponseTime into DNS, connect, TLS, time to first
+	// byte and download
+	Timing *Timing
+	// Attempts is how many times the request was sent, retries included
+	Attempts int
+	// Redirects are the redirects followed to get the response, in order
+	Redirects []RedirectHop
+	// Spooled is set when the body was larger than MaxResponseSize and
+	// went to a temporary file; Body then holds only its beginning
+	Spooled *SpooledBody
+
+	// raw is the body as received when Body holds it reformatted
+	raw string
+}
+
+// RawBody returns the body as the server sent it, before JSON bodies were
+// indented for display
+func (r Response) RawBody() string {
+	if r.raw != "" {
+		return r.raw
+	}
+	return r.Body
 }
 
 type Client struct {
 	httpClient *http.Client
+	conns      connStats
+	jar        *CookieJar
+	proxy      ProxySettings
+	tls        TLSSettings
 }
 
 func NewClient(timeout time.Duration) *Client {
@@ -45,6 +94,7 @@ func NewClient(timeout time.Duration) *Client {
 		httpClient: &http.Client{
 			Timeout: timeout,
 		},
+		proxy: DefaultProxySettings(),
 	}
 }
 
@@ -53,46 +103,139 @@ func (c *Client) Send(req Request) Response {
 }
 
 func (c *Client) SendWithContext(ctx context.Context, req Request) Response {
+	return c.SendWithProgress(ctx, req, nil)
+}
+
+// SendWithProgress sends req like SendWithContext. A multipart/form-data
+// body is streamed, reading its files as it goes, and progress, when not
+// nil, is called with the bytes sent so far.
+func (c *Client) SendWithProgress(ctx context.Context, req Request, progress func(sent, total int64)) Response {
+	return c.send(ctx, req, progress, nil)
+}
+
+// SendReceiving sends req like SendWithContext, calling received, when not
+// nil, with the bytes of the response body read so far and the length the
+// server announced, -1 when it announced none.
+func (c *Client) SendReceiving(ctx context.Context, req Request, received func(done, total int64)) Response {
+	return c.send(ctx, req, nil, received)
+}
+
+// send sends req, trying it again as its Retry policy says
+func (c *Client) send(ctx context.Context, req Request, progress, received func(done, total int64)) Response {
+	for attempt := 1; ; attempt++ {
+		resp, sent := c.attempt(ctx, req, progress, received)
+		resp.Attempts = attempt
+		if !req.Retry.retryable(attempt, resp, sent) || ctx.Err() != nil {
+			return resp
+		}
+
+		delay := req.Retry.delay(attempt)
+		slog.Info("Retrying request", "method", req.Method, "url", req.URL,
+			"attempt", attempt+1, "delay", delay, "status_code", resp.StatusCode)
+		if resp.Spooled != nil {
+			resp.Spooled.Remove()
+		}
+		select {
+		case <-ctx.Done():
+			return resp
+		case <-time.After(delay):
+		}
+	}
+}
+
+// attempt sends req once. sent is false when the request could not be
+// built.
+func (c *Client) attempt(ctx context.Context, req Request, progress, received func(done, total int64)) (resp Response, sent bool) {
 	startTime := time.Now()
 	logger := slog.With("method", req.Method, "url", req.URL)
 
+	httpReq, err := newHTTPRequest(ctx, req, progress, logger)
+	if err != nil {
+		return Response{Error: err, ResponseTime: time.Since(startTime)}, false
+	}
+
+	logger.Debug("Sending HTTP request")
+	timer := newRequestTimer()
+	chain := newRedirectChain(req)
+	httpResp, conn, err := c.do(httpReq, req, timer, chain)
+	if err != nil {
+		logger.Error("Request failed", "error", err)
+		return Response{
+			Error:        errors.NewHTTPError("request failed", err),
+			ResponseTime: time.Since(startTime),
+			Conn:         conn,
+			Redirects:    chain.hops,
+		}, true
+	}
+	defer httpResp.Body.Close()
+
+	if received != nil {
+		httpResp.Body = &progressReader{ReadCloser: httpResp.Body, total: httpResp.ContentLength, progress: received}
+	}
+	resp = readResponse(httpResp, startTime, logger)
+	resp.Conn = conn
+	resp.Timing = timer.finish(time.Now())
+	resp.Redirects = chain.hops
+	return resp, true
+}
+
+// newHTTPRequest builds the request to send for req. A multipart/form-data
+// body is streamed, and progress, when not nil, is called with the bytes
+// sent so far. A form-urlencoded body written one field per line is
+// encoded.
+func newHTTPRequest(ctx context.Context, req Request, progress func(sent, total int64), logger *slog.Logger) (*http.Request, error) {
 	// Validate URL before sending
 	if _, err := url.ParseRequestURI(req.URL); err != nil {
 		logger.Error("Invalid URL", "error", err)
-		return Response{
-			Error:        errors.NewHTTPError("invalid URL", err),
-			ResponseTime: time.Since(startTime),
+		return nil, errors.NewHTTPError("invalid URL", err)
+	}
+
+	var form *multipartBody
+	if IsMultipart(headerValue(req.Headers, "Content-Type")) {
+		fields, err := ParseFormFields(req.Body)
+		if err == nil {
+			form, err = newMultipartBody(fields)
+		}
+		if err != nil {
+			logger.Error("Invalid multipart body", "error", err)
+			return nil, errors.NewHTTPError("invalid multipart body", err)
 		}
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.URL, bytes.NewBufferString(req.Body))
+	bodyText := req.Body
+	if IsFormURLEncoded(headerValue(req.Headers, "Content-Type")) {
+		bodyText = EncodeFormBody(req.Body)
+	}
+	var body io.Reader = bytes.NewBufferString(bodyText)
+	if form != nil {
+		body = nil
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.URL, body)
 	if err != nil {
 		logger.Error("Failed to create request", "error", err)
-		return Response{
-			Error:        errors.NewHTTPError("failed to create request", err),
-			ResponseTime: time.Since(startTime),
-		}
+		return nil, errors.NewHTTPError("failed to create request", err)
 	}
 
 	for key, value := range req.Headers {
 		httpReq.Header.Set(key, value)
 	}
 
-	logger.Debug("Sending HTTP request")
-	httpResp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		logger.Error("Request failed", "error", err)
-		return Response{
-			Error:        errors.NewHTTPError("request failed", err),
-			ResponseTime: time.Since(startTime),
+	if form != nil {
+		// The boundary is only known here, so it replaces the bare type
+		httpReq.Header.Set("Content-Type", form.contentType)
+		httpReq.ContentLength = form.length
+		httpReq.Body = form.reader()
+		if progress != nil {
+			httpReq.Body = &progressReader{ReadCloser: httpReq.Body, total: form.length, progress: progress}
 		}
 	}
-	defer httpResp.Body.Close()
 
-	// Limit response size to prevent DoS attacks
-	// Read up to MaxResponseSize + 1 to detect if response exceeds limit
-	limitedReader := io.LimitReader(httpResp.Body, MaxResponseSize+1)
-	bodyBytes, err := io.ReadAll(limitedReader)
+	return httpReq, nil
+}
+
+// readResponse reads the body of httpResp into a Response, formatting JSON
+func readResponse(httpResp *http.Response, startTime time.Time, logger *slog.Logger) Response {
+	bodyBytes, spool, err := readBody(httpResp.Body, httpResp.ContentLength)
 	if err != nil {
 		logger.Error("Failed to read response body", "error", err)
 		return Response{
@@ -101,22 +244,29 @@ func (c *Client) SendWithContext(ctx context.Context, req Request) Response {
 		}
 	}
 
-	// Check if response was truncated (read more than MaxResponseSize)
-	if int64(len(bodyBytes)) > MaxResponseSize {
-		err := fmt.Errorf("response too large (exceeds %d bytes)", MaxResponseSize)
-		logger.Warn("Response too large", "max_size", MaxResponseSize, "actual_size", len(bodyBytes))
+	// A body larger than SpoolThreshold is paged from its temporary file,
+	// and only its beginning is kept in memory
+	if spool != nil {
+		logger.Info("Response too large for memory, spooled to disk", "max_size", SpoolThreshold)
 		return Response{
-			Error:        errors.NewHTTPError("response too large", err),
+			StatusCode:   httpResp.StatusCode,
+			Status:       httpResp.Status,
+			Body:         previewBody(bodyBytes),
+			Headers:      httpResp.Header,
 			ResponseTime: time.Since(startTime),
+			Size:         spool.Size,
+			Proto:        httpResp.Proto,
+			Trailers:     receivedTrailers(httpResp),
+			Spooled:      spool,
 		}
 	}
 
 	responseTime := time.Since(startTime)
-	bodyString := string(bodyBytes)
+	bodyString, raw := string(bodyBytes), ""
 
 	formattedBody, err := formatJSON(bodyString)
-	if err == nil {
-		bodyString = formattedBody
+	if err == nil && formattedBody != bodyString {
+		bodyString, raw = formattedBody, bodyString
 	}
 
 	logger.Info("Request completed successfully",
@@ -133,9 +283,29 @@ func (c *Client) SendWithContext(ctx context.Context, req Request) Response {
 		ResponseTime: responseTime,
 		Size:         int64(len(bodyBytes)),
 		Error:        nil,
+		Proto:        httpResp.Proto,
+		Trailers:     receivedTrailers(httpResp),
+		raw:          raw,
 	}
 }
 
+// receivedTrailers returns the trailers of httpResp that arrived. It must
+// be called after the body was read, as trailers only arrive then;
+// declared trailers the server never sent are left out.
+func receivedTrailers(httpResp *http.Response) map[string][]string {
+	var trailers map[string][]string
+	for key, values := range httpResp.Trailer {
+		if len(values) == 0 {
+			continue
+		}
+		if trailers == nil {
+			trailers = make(map[string][]string)
+		}
+		trailers[key] = values
+	}
+	return trailers
+}
+
 // formatJSON formats JSON using json.Indent for better performance
 // This avoids the unnecessary unmarshal/marshal cycle
 func formatJSON(data string) (string, error) {
@@ -187,12 +357,26 @@ func RequestToCurl(req Request) string {
 		parts = append(parts, "-X", req.Method)
 	}
 
+	multipart := IsMultipart(headerValue(req.Headers, "Content-Type"))
 	for key, value := range req.Headers {
+		if multipart && strings.EqualFold(key, "Content-Type") {
+			// curl sets the type itself, with the boundary
+			continue
+		}
 		parts = append(parts, "-H", fmt.Sprintf("'%s: %s'", key, value))
 	}
 
-	if req.Body != "" {
+	if multipart {
+		for _, line := range strings.Split(req.Body, "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				parts = append(parts, "-F", fmt.Sprintf("'%s'", line))
+			}
+		}
+	} else if req.Body != "" {
 		escapedBody := req.Body
+		if IsFormURLEncoded(headerValue(req.Headers, "Content-Type")) {
+			escapedBody = EncodeFormBody(req.Body)
+		}
 		parts = append(parts, "-d", fmt.Sprintf("'%s'", escapedBody))
 	}
 
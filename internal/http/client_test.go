@@ -1,8 +1,10 @@
 package http
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -375,8 +377,8 @@ func TestClientSendExactlyMaxSize(t *testing.T) {
 }
 
 func TestClientSendExceedsMaxSize(t *testing.T) {
-	// Create response larger than MaxResponseSize
-	// This SHOULD cause an error
+	// A response larger than MaxResponseSize is spooled to disk instead
+	// of failing
 	responseData := make([]byte, MaxResponseSize+1000)
 	for i := range responseData {
 		responseData[i] = 'B'
@@ -395,13 +397,86 @@ func TestClientSendExceedsMaxSize(t *testing.T) {
 	}
 
 	resp := client.Send(req)
+	if resp.Error != nil {
+		t.Fatalf("Unexpected error for response exceeding MaxResponseSize: %v", resp.Error)
+	}
+	if resp.Spooled == nil {
+		t.Fatal("Expected the body to be spooled to disk")
+	}
+	defer resp.Spooled.Remove()
 
-	// Should error - exceeds MaxResponseSize
-	if resp.Error == nil {
-		t.Error("Expected error for response exceeding MaxResponseSize")
+	if resp.Size != int64(len(responseData)) || resp.Spooled.Size != resp.Size {
+		t.Errorf("Size = %d, spooled %d, want %d", resp.Size, resp.Spooled.Size, len(responseData))
+	}
+	if len(resp.Body) > spoolPreviewSize {
+		t.Errorf("Expected only the beginning of the body in memory, got %d bytes", len(resp.Body))
 	}
+}
 
-	if !strings.Contains(resp.Error.Error(), "response too large") {
-		t.Errorf("Error should mention 'response too large', got: %v", resp.Error)
+func TestSendReadsTrailers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Trailer", "Grpc-Status, Grpc-Message, X-Never-Sent")
+		w.Write([]byte("{}"))
+		w.Header().Set("Grpc-Status", "0")
+		w.Header().Set("Grpc-Message", "OK")
+	}))
+	defer server.Close()
+
+	resp := NewClient(5 * time.Second).Send(Request{Method: "GET", URL: server.URL})
+	if resp.Error != nil {
+		t.Fatalf("Send() error = %v", resp.Error)
+	}
+	if resp.Proto != "HTTP/1.1" {
+		t.Errorf("Proto = %q, want HTTP/1.1", resp.Proto)
+	}
+	want := map[string][]string{"Grpc-Status": {"0"}, "Grpc-Message": {"OK"}}
+	if len(resp.Trailers) != len(want) {
+		t.Fatalf("Trailers = %v, want %v", resp.Trailers, want)
+	}
+	for key, values := range want {
+		if got := resp.Trailers[key]; len(got) != 1 || got[0] != values[0] {
+			t.Errorf("Trailers[%s] = %v, want %v", key, got, values)
+		}
+	}
+}
+
+func TestSendReceivingReportsBodyProgress(t *testing.T) {
+	body := strings.Repeat("x", 100000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	var done, total int64
+	resp := NewClient(5*time.Second).SendReceiving(context.Background(), Request{Method: "GET", URL: server.URL},
+		func(d, t int64) { done, total = d, t })
+	if resp.Error != nil {
+		t.Fatalf("SendReceiving() error = %v", resp.Error)
+	}
+	if done != int64(len(body)) || total != int64(len(body)) {
+		t.Errorf("progress = %d/%d, want %d/%d", done, total, len(body), len(body))
+	}
+}
+
+func TestSendReceivingCanBeCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("first"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	resp := NewClient(5*time.Second).SendReceiving(ctx, Request{Method: "GET", URL: server.URL},
+		func(done, total int64) {
+			if total != -1 {
+				t.Errorf("total = %d, want -1 without a Content-Length", total)
+			}
+			cancel()
+		})
+	if resp.Error == nil || !strings.Contains(resp.Error.Error(), "canceled") {
+		t.Errorf("Expected the request to be canceled, got %v", resp.Error)
 	}
 }
@@ -0,0 +1,147 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptrace"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ConnInfo describes the connection a request was sent on
+type ConnInfo struct {
+	Host       string
+	RemoteAddr string
+	// Reused is true when the connection came from the keep-alive pool
+	Reused bool
+	// WasIdle and IdleTime tell how long a reused connection sat unused
+	WasIdle  bool
+	IdleTime time.Duration
+	// Forced is true when the request asked for a new connection
+	Forced bool
+	// InsecureTLS is true when the server certificate was not verified
+	InsecureTLS bool
+}
+
+// HostConnStats counts the connections the requests to a host were sent on
+type HostConnStats struct {
+	Host   string
+	New    int
+	Reused int
+}
+
+// connStats keeps HostConnStats per host for the lifetime of a Client
+type connStats struct {
+	mu    sync.Mutex
+	hosts map[string]*HostConnStats
+}
+
+func (s *connStats) record(info ConnInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.hosts == nil {
+		s.hosts = make(map[string]*HostConnStats)
+	}
+	stats := s.hosts[info.Host]
+	if stats == nil {
+		stats = &HostConnStats{Host: info.Host}
+		s.hosts[info.Host] = stats
+	}
+	if info.Reused {
+		stats.Reused++
+	} else {
+		stats.New++
+	}
+}
+
+// ConnStats returns how many requests to host reused a connection and how
+// many opened a new one
+func (c *Client) ConnStats(host string) HostConnStats {
+	c.conns.mu.Lock()
+	defer c.conns.mu.Unlock()
+	if stats := c.conns.hosts[host]; stats != nil {
+		return *stats
+	}
+	return HostConnStats{Host: host}
+}
+
+// AllConnStats returns the connection counts of every host, sorted by host
+func (c *Client) AllConnStats() []HostConnStats {
+	c.conns.mu.Lock()
+	defer c.conns.mu.Unlock()
+	all := make([]HostConnStats, 0, len(c.conns.hosts))
+	for _, stats := range c.conns.hosts {
+		all = append(all, *stats)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Host < all[j].Host })
+	return all
+}
+
+// do sends httpReq, built from req, and reports the connection it went out
+// on. A new connection is forced by sending on a transport of its own
+// without keep-alives, so the pool of the client is neither used nor
+// disturbed. A timeout of req above zero replaces the timeout of the
+// client. timer, when not nil, records the phases of the request, and
+// chain the redirects it follows.
+func (c *Client) do(httpReq *http.Request, req Request, timer *requestTimer, chain *redirectChain) (*http.Response, *ConnInfo, error) {
+	newConn, timeout := req.NewConnection, req.Timeout
+	var conn *ConnInfo
+	insecure := c.tls.InsecureSkipVerify && httpReq.URL.Scheme == "https"
+	trace := &httptrace.ClientTrace{
+		GotConn: func(got httptrace.GotConnInfo) {
+			info := ConnInfo{
+				Host:     httpReq.URL.Host,
+				Reused:   got.Reused,
+				WasIdle:  got.WasIdle,
+				IdleTime: got.IdleTime,
+				Forced:   newConn,
+			}
+			info.InsecureTLS = insecure
+			if got.Conn != nil {
+				info.RemoteAddr = got.Conn.RemoteAddr().String()
+			}
+			conn = &info
+			if timer != nil {
+				timer.gotConnection()
+			}
+		},
+	}
+	if timer != nil {
+		timer.hook(trace)
+	}
+	httpReq = httpReq.WithContext(httptrace.WithClientTrace(httpReq.Context(), trace))
+
+	client := c.httpClient
+	if newConn || timeout > 0 || chain != nil {
+		fresh := *c.httpClient
+		if newConn {
+			transport := c.cloneTransport()
+			transport.DisableKeepAlives = true
+			fresh.Transport = transport
+		}
+		if timeout > 0 {
+			fresh.Timeout = timeout
+		}
+		if chain != nil {
+			fresh.CheckRedirect = chain.check
+		}
+		client = &fresh
+	}
+
+	httpResp, err := client.Do(httpReq)
+	// GotConn runs before Do returns, also when the request fails afterwards
+	if conn != nil {
+		c.conns.record(*conn)
+	}
+	return httpResp, conn, err
+}
+
+// cloneTransport returns a copy of the transport of the client to change
+// settings on
+func (c *Client) cloneTransport() *http.Transport {
+	base, ok := c.httpClient.Transport.(*http.Transport)
+	if !ok {
+		base = http.DefaultTransport.(*http.Transport)
+	}
+	return base.Clone()
+}
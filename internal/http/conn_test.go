@@ -0,0 +1,70 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSendReportsConnectionReuse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	host := mustHost(t, server.URL)
+
+	client := NewClient(5 * time.Second)
+	first := client.Send(Request{Method: "GET", URL: server.URL})
+	if first.Conn == nil || first.Conn.Reused {
+		t.Fatalf("Expected the first request to open a connection, got %+v", first.Conn)
+	}
+	if first.Conn.Host != host || first.Conn.RemoteAddr == "" {
+		t.Errorf("Expected the connection to %s with its address, got %+v", host, first.Conn)
+	}
+
+	second := client.Send(Request{Method: "GET", URL: server.URL})
+	if second.Conn == nil || !second.Conn.Reused || !second.Conn.WasIdle {
+		t.Fatalf("Expected the second request to reuse the idle connection, got %+v", second.Conn)
+	}
+
+	forced := client.Send(Request{Method: "GET", URL: server.URL, NewConnection: true})
+	if forced.Conn == nil || forced.Conn.Reused || !forced.Conn.Forced {
+		t.Fatalf("Expected a forced new connection, got %+v", forced.Conn)
+	}
+
+	// Forcing a new connection leaves the pooled one alone
+	after := client.Send(Request{Method: "GET", URL: server.URL})
+	if after.Conn == nil || !after.Conn.Reused {
+		t.Errorf("Expected the pooled connection to be reused again, got %+v", after.Conn)
+	}
+
+	want := HostConnStats{Host: host, New: 2, Reused: 2}
+	if got := client.ConnStats(host); got != want {
+		t.Errorf("ConnStats() = %+v, want %+v", got, want)
+	}
+	if all := client.AllConnStats(); len(all) != 1 || all[0] != want {
+		t.Errorf("AllConnStats() = %+v, want [%+v]", all, want)
+	}
+}
+
+func TestSendWithoutConnection(t *testing.T) {
+	client := NewClient(time.Second)
+	resp := client.Send(Request{Method: "GET", URL: "not a url"})
+	if resp.Conn != nil {
+		t.Errorf("Expected no connection for an invalid URL, got %+v", resp.Conn)
+	}
+	if got := client.ConnStats("example.com"); got.New+got.Reused != 0 {
+		t.Errorf("Expected no counts for an unused host, got %+v", got)
+	}
+}
+
+func mustHost(t *testing.T, rawURL string) string {
+	t.Helper()
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u.Host
+}
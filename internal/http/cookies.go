@@ -0,0 +1,206 @@
+package http
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JarCookie is a cookie kept by a CookieJar
+type JarCookie struct {
+	// Domain is the host the cookie was set by, or the domain it was set
+	// for when HostOnly is false
+	Domain   string
+	Path     string
+	Name     string
+	Value    string
+	HostOnly bool
+	// Expires is zero for a session cookie
+	Expires  time.Time
+	Secure   bool
+	HttpOnly bool
+
+	// origin is the URL of the response that set the cookie, to set it
+	// again from when it is edited or deleted
+	origin *url.URL
+}
+
+type jarKey struct {
+	domain, path, name string
+}
+
+// CookieJar keeps the cookies responses set and sends them back with later
+// requests, like a browser session. Unlike a plain cookiejar.Jar it can list
+// what it holds, and edit or delete single cookies.
+type CookieJar struct {
+	mu      sync.Mutex
+	jar     *cookiejar.Jar
+	cookies map[jarKey]JarCookie
+}
+
+// NewCookieJar returns an empty jar
+func NewCookieJar() *CookieJar {
+	j := &CookieJar{}
+	j.Clear()
+	return j
+}
+
+// SetCookies implements http.CookieJar
+func (j *CookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.jar.SetCookies(u, cookies)
+
+	now := time.Now()
+	host := strings.ToLower(u.Hostname())
+	for _, c := range cookies {
+		kept := JarCookie{
+			Domain:   host,
+			Path:     c.Path,
+			Name:     c.Name,
+			Value:    c.Value,
+			HostOnly: true,
+			Expires:  c.Expires,
+			Secure:   c.Secure,
+			HttpOnly: c.HttpOnly,
+			origin:   u,
+		}
+		if c.Domain != "" {
+			domain := strings.ToLower(strings.TrimPrefix(c.Domain, "."))
+			// The jar ignores a cookie for a domain the host is not in
+			if host != domain && !strings.HasSuffix(host, "."+domain) {
+				continue
+			}
+			kept.Domain = domain
+			kept.HostOnly = false
+		}
+		if !strings.HasPrefix(kept.Path, "/") {
+			kept.Path = defaultCookiePath(u.Path)
+		}
+
+		key := jarKey{kept.Domain, kept.Path, kept.Name}
+		switch {
+		case c.MaxAge < 0:
+			delete(j.cookies, key)
+		case c.MaxAge > 0:
+			kept.Expires = now.Add(time.Duration(c.MaxAge) * time.Second)
+			j.cookies[key] = kept
+		case !c.Expires.IsZero() && !c.Expires.After(now):
+			delete(j.cookies, key)
+		default:
+			j.cookies[key] = kept
+		}
+	}
+}
+
+// Cookies implements http.CookieJar
+func (j *CookieJar) Cookies(u *url.URL) []*http.Cookie {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.jar.Cookies(u)
+}
+
+// All returns the cookies that have not expired, sorted by domain, path
+// and name
+func (j *CookieJar) All() []JarCookie {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now()
+	all := make([]JarCookie, 0, len(j.cookies))
+	for key, c := range j.cookies {
+		if !c.Expires.IsZero() && !c.Expires.After(now) {
+			delete(j.cookies, key)
+			continue
+		}
+		all = append(all, c)
+	}
+	sort.Slice(all, func(a, b int) bool {
+		if all[a].Domain != all[b].Domain {
+			return all[a].Domain < all[b].Domain
+		}
+		if all[a].Path != all[b].Path {
+			return all[a].Path < all[b].Path
+		}
+		return all[a].Name < all[b].Name
+	})
+	return all
+}
+
+// Len returns the number of cookies that have not expired
+func (j *CookieJar) Len() int {
+	return len(j.All())
+}
+
+// SetValue changes the value of a kept cookie, keeping its other attributes
+func (j *CookieJar) SetValue(c JarCookie, value string) {
+	edited := c.httpCookie()
+	edited.Value = value
+	j.SetCookies(c.origin, []*http.Cookie{edited})
+}
+
+// Delete removes a kept cookie
+func (j *CookieJar) Delete(c JarCookie) {
+	deleted := c.httpCookie()
+	deleted.MaxAge = -1
+	j.SetCookies(c.origin, []*http.Cookie{deleted})
+}
+
+// Clear removes every cookie
+func (j *CookieJar) Clear() {
+	// cookiejar.New only fails on options it is not given
+	jar, _ := cookiejar.New(nil)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.jar = jar
+	j.cookies = make(map[jarKey]JarCookie)
+}
+
+// httpCookie returns c as set by a response, to set it again
+func (c JarCookie) httpCookie() *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     c.Name,
+		Value:    c.Value,
+		Path:     c.Path,
+		Expires:  c.Expires,
+		Secure:   c.Secure,
+		HttpOnly: c.HttpOnly,
+	}
+	if !c.HostOnly {
+		cookie.Domain = c.Domain
+	}
+	return cookie
+}
+
+// defaultCookiePath returns the path a cookie without one applies to: the
+// directory of the request path (RFC 6265 section 5.1.4)
+func defaultCookiePath(path string) string {
+	i := strings.LastIndex(path, "/")
+	if i <= 0 {
+		return "/"
+	}
+	return path[:i]
+}
+
+// SetCookieJar makes the client keep the cookies responses set and send
+// them back with later requests. A nil jar stops keeping cookies.
+func (c *Client) SetCookieJar(jar *CookieJar) {
+	c.jar = jar
+	if jar == nil {
+		c.httpClient.Jar = nil
+		return
+	}
+	c.httpClient.Jar = jar
+}
+
+// CookieJar returns the jar the client keeps cookies in, nil when it keeps
+// none
+func (c *Client) CookieJar() *CookieJar {
+	return c.jar
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCookieJarKeepsSession(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc", Path: "/", HttpOnly: true})
+			http.SetCookie(w, &http.Cookie{Name: "theme", Value: "dark", MaxAge: 3600})
+			http.SetCookie(w, &http.Cookie{Name: "stale", Value: "x", Expires: time.Unix(1, 0)})
+		default:
+			for _, c := range r.Cookies() {
+				w.Write([]byte(c.Name + "=" + c.Value + ";"))
+			}
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(5 * time.Second)
+	if resp := client.Send(Request{Method: "GET", URL: server.URL + "/login"}); resp.Error != nil {
+		t.Fatalf("Send() error = %v", resp.Error)
+	}
+	if resp := client.Send(Request{Method: "GET", URL: server.URL + "/me"}); resp.Body != "" {
+		t.Errorf("Expected no cookies without a jar, got %q", resp.Body)
+	}
+
+	jar := NewCookieJar()
+	client.SetCookieJar(jar)
+	client.Send(Request{Method: "GET", URL: server.URL + "/login"})
+
+	all := jar.All()
+	if len(all) != 2 {
+		t.Fatalf("All() = %+v, want session and theme", all)
+	}
+	session, theme := all[0], all[1]
+	if session.Name != "session" || session.Path != "/" || !session.HttpOnly || !session.Expires.IsZero() {
+		t.Errorf("Unexpected session cookie %+v", session)
+	}
+	if theme.Name != "theme" || theme.Path != "/" || theme.Expires.IsZero() {
+		t.Errorf("Unexpected theme cookie %+v", theme)
+	}
+	if session.Domain != "127.0.0.1" || !session.HostOnly {
+		t.Errorf("Expected a host-only cookie of 127.0.0.1, got %+v", session)
+	}
+
+	if resp := client.Send(Request{Method: "GET", URL: server.URL + "/me"}); resp.Body != "session=abc;theme=dark;" {
+		t.Errorf("Body = %q, want the kept cookies", resp.Body)
+	}
+
+	jar.SetValue(session, "edited")
+	jar.Delete(theme)
+	if resp := client.Send(Request{Method: "GET", URL: server.URL + "/me"}); resp.Body != "session=edited;" {
+		t.Errorf("Body = %q, want the edited cookie only", resp.Body)
+	}
+	if all := jar.All(); len(all) != 1 || all[0].Value != "edited" {
+		t.Errorf("All() = %+v, want the edited session cookie", all)
+	}
+
+	jar.Clear()
+	if resp := client.Send(Request{Method: "GET", URL: server.URL + "/me"}); resp.Body != "" || jar.Len() != 0 {
+		t.Errorf("Expected no cookies after Clear(), got %q", resp.Body)
+	}
+}
+
+func TestDefaultCookiePath(t *testing.T) {
+	tests := map[string]string{
+		"":            "/",
+		"/":           "/",
+		"/login":      "/",
+		"/api/login":  "/api",
+		"/api/v1/me/": "/api/v1/me",
+	}
+	for path, want := range tests {
+		if got := defaultCookiePath(path); got != want {
+			t.Errorf("defaultCookiePath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
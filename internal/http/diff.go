@@ -44,8 +44,9 @@ type TimeDiff struct {
 	DiffPercent float64
 }
 
-// CompareResponses compares two HTTP responses and returns differences
-func CompareResponses(old, new Response) *DiffResult {
+// CompareResponses compares two HTTP responses and returns differences,
+// leaving out the headers and body fields the ignore rules match
+func CompareResponses(old, new Response, ignore ...IgnoreRules) *DiffResult {
 	result := &DiffResult{
 		HeadersDiff: make(map[string]*ValueDiff),
 	}
@@ -106,6 +107,10 @@ func CompareResponses(old, new Response) *DiffResult {
 		}
 	}
 
+	for _, rules := range ignore {
+		rules.apply(result)
+	}
+
 	return result
 }
 
@@ -126,13 +131,18 @@ func compareBodies(old, new string) *BodyDiff {
 
 // compareJSON compares two JSON structures
 func compareJSON(old, new interface{}, path string) *BodyDiff {
+	return jsonBodyDiff(findJSONDifferences(old, new, path))
+}
+
+// jsonBodyDiff wraps JSON changes in a body diff with its summary
+func jsonBodyDiff(changes []Change) *BodyDiff {
 	diff := &BodyDiff{
 		Type:    "json",
-		Changes: []Change{},
+		Changes: changes,
+	}
+	if diff.Changes == nil {
+		diff.Changes = []Change{}
 	}
-
-	changes := findJSONDifferences(old, new, path)
-	diff.Changes = changes
 
 	// Generate summary
 	added := 0
@@ -0,0 +1,245 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abneribeiro/godev/internal/errors"
+)
+
+// ContentRange is a parsed Content-Range header. Start and End are -1 in
+// the unsatisfied form "bytes */total", and Total is -1 when the server
+// does not know the complete length.
+type ContentRange struct {
+	Start int64
+	End   int64
+	Total int64
+}
+
+// ParseContentRange parses a Content-Range header of bytes
+func ParseContentRange(value string) (ContentRange, error) {
+	spec, ok := strings.CutPrefix(strings.TrimSpace(value), "bytes ")
+	if !ok {
+		return ContentRange{}, fmt.Errorf("unsupported Content-Range %q", value)
+	}
+	span, total, ok := strings.Cut(spec, "/")
+	if !ok {
+		return ContentRange{}, fmt.Errorf("invalid Content-Range %q", value)
+	}
+
+	r := ContentRange{Start: -1, End: -1, Total: -1}
+	if total != "*" {
+		n, err := strconv.ParseInt(total, 10, 64)
+		if err != nil || n < 0 {
+			return ContentRange{}, fmt.Errorf("invalid Content-Range %q", value)
+		}
+		r.Total = n
+	}
+	if span == "*" {
+		if r.Total < 0 {
+			return ContentRange{}, fmt.Errorf("invalid Content-Range %q", value)
+		}
+		return r, nil
+	}
+
+	first, last, ok := strings.Cut(span, "-")
+	start, err1 := strconv.ParseInt(first, 10, 64)
+	end, err2 := strconv.ParseInt(last, 10, 64)
+	if !ok || err1 != nil || err2 != nil || start < 0 || end < start || (r.Total >= 0 && end >= r.Total) {
+		return ContentRange{}, fmt.Errorf("invalid Content-Range %q", value)
+	}
+	r.Start, r.End = start, end
+	return r, nil
+}
+
+// String describes the range for display, as "bytes 0-99 of 1000"
+func (r ContentRange) String() string {
+	total := "unknown"
+	if r.Total >= 0 {
+		total = strconv.FormatInt(r.Total, 10)
+	}
+	if r.Start < 0 {
+		return "none of " + total + " bytes"
+	}
+	return fmt.Sprintf("bytes %d-%d of %s", r.Start, r.End, total)
+}
+
+// DownloadResult is what a download did to its file
+type DownloadResult struct {
+	Path string
+
+	// Offset is the size of the partial file the response was appended
+	// to; it is 0 when the file was written from the start
+	Offset int64
+
+	// Written is the number of bytes this response added to the file
+	Written int64
+
+	// Total is the size of the complete file, or -1 when it is unknown
+	Total int64
+
+	// Range is the Content-Range of a 206 or 416 response
+	Range *ContentRange
+
+	// Restarted is set when a partial file existed but the server ignored
+	// the Range header and sent the whole body, which replaced the file
+	Restarted bool
+
+	// Complete is set once the whole file is on disk
+	Complete bool
+}
+
+// Size is the number of bytes on disk after the download
+func (d DownloadResult) Size() int64 {
+	return d.Offset + d.Written
+}
+
+// Download sends req and writes the body of a successful response to path
+// instead of keeping it in memory, so the size limit of Send does not
+// apply. When path already holds part of the file, the request asks for
+// the rest with a Range header and appends to it; a server that ignores
+// the range sends the whole body, which replaces the file. A Range header
+// set on req is sent as is and its response replaces the file.
+//
+// An interrupted download leaves the bytes received on disk, so sending
+// again resumes it. Error responses are read like Send and leave the file
+// alone. progress, when not nil, is called with the bytes on disk and the
+// complete size, -1 when it is unknown.
+func (c *Client) Download(ctx context.Context, req Request, path string, progress func(received, total int64)) (resp Response) {
+	startTime := time.Now()
+	logger := slog.With("method", req.Method, "url", req.URL, "path", path)
+	var conn *ConnInfo
+	defer func() { resp.Conn = conn }()
+	fail := func(message string, err error) Response {
+		logger.Error("Download failed", "error", err)
+		return Response{
+			Error:        errors.NewHTTPError(message, err),
+			ResponseTime: time.Since(startTime),
+		}
+	}
+
+	path = ExpandHome(path)
+	var offset int64
+	if info, err := os.Stat(path); err == nil {
+		if info.IsDir() {
+			return fail("invalid download path", fmt.Errorf("%s is a directory", path))
+		}
+		offset = info.Size()
+	}
+
+	explicitRange := headerValue(req.Headers, "Range") != ""
+	if offset > 0 && !explicitRange {
+		req.Headers = maps.Clone(req.Headers)
+		if req.Headers == nil {
+			req.Headers = map[string]string{}
+		}
+		req.Headers["Range"] = fmt.Sprintf("bytes=%d-", offset)
+	}
+	if explicitRange {
+		offset = 0
+	}
+
+	httpReq, err := newHTTPRequest(ctx, req, nil, logger)
+	if err != nil {
+		return Response{Error: err, ResponseTime: time.Since(startTime)}
+	}
+
+	logger.Debug("Sending download request", "offset", offset)
+	httpResp, conn, err := c.do(httpReq, req, nil, newRedirectChain(req))
+	if err != nil {
+		return fail("request failed", err)
+	}
+	defer httpResp.Body.Close()
+
+	result := &DownloadResult{Path: path, Total: -1}
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+
+	switch code := httpResp.StatusCode; {
+	case code == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		// Asking for the bytes past the end of a whole file is how a
+		// finished download shows up again
+		cr, err := ParseContentRange(httpResp.Header.Get("Content-Range"))
+		if err != nil || cr.Total != offset {
+			resp := readResponse(httpResp, startTime, logger)
+			resp.Error = errors.NewHTTPError("cannot resume download",
+				fmt.Errorf("the server cannot send bytes from %d of %s", offset, path))
+			return resp
+		}
+		result.Offset, result.Total, result.Range, result.Complete = offset, cr.Total, &cr, true
+		return downloadResponse(httpResp, startTime, result, nil)
+
+	case code == http.StatusPartialContent:
+		cr, err := ParseContentRange(httpResp.Header.Get("Content-Range"))
+		if err != nil {
+			return fail("invalid partial content", err)
+		}
+		if !explicitRange && cr.Start != offset {
+			return fail("cannot resume download",
+				fmt.Errorf("the server sent bytes from %d but %s holds %d", cr.Start, path, offset))
+		}
+		result.Range = &cr
+		result.Total = cr.Total
+		if offset > 0 {
+			result.Offset = offset
+			flags = os.O_WRONLY | os.O_APPEND
+		}
+
+	case code >= 200 && code < 300:
+		result.Restarted = offset > 0
+		result.Total = httpResp.ContentLength
+
+	default:
+		// An error page is not the file; show it like any other response
+		return readResponse(httpResp, startTime, logger)
+	}
+
+	f, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		return fail("failed to open download file", err)
+	}
+	body := io.ReadCloser(httpResp.Body)
+	if progress != nil {
+		progress(result.Offset, result.Total)
+		body = &progressReader{ReadCloser: body, sent: result.Offset, total: result.Total, progress: progress}
+	}
+	result.Written, err = io.Copy(f, body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		logger.Warn("Download interrupted", "written", result.Written, "error", err)
+		return downloadResponse(httpResp, startTime, result,
+			errors.NewHTTPError("download interrupted", err))
+	}
+
+	result.Complete = result.Total < 0 || result.Size() == result.Total
+	logger.Info("Download finished",
+		"status_code", httpResp.StatusCode,
+		"offset", result.Offset,
+		"written", result.Written,
+		"complete", result.Complete,
+	)
+	return downloadResponse(httpResp, startTime, result, nil)
+}
+
+func downloadResponse(httpResp *http.Response, startTime time.Time, result *DownloadResult, err error) Response {
+	return Response{
+		StatusCode:   httpResp.StatusCode,
+		Status:       httpResp.Status,
+		Headers:      httpResp.Header,
+		ResponseTime: time.Since(startTime),
+		Size:         result.Written,
+		Download:     result,
+		Error:        err,
+		Proto:        httpResp.Proto,
+		Trailers:     receivedTrailers(httpResp),
+	}
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseContentRange(t *testing.T) {
+	tests := []struct {
+		value string
+		want  ContentRange
+	}{
+		{"bytes 0-99/1000", ContentRange{Start: 0, End: 99, Total: 1000}},
+		{"bytes 500-999/*", ContentRange{Start: 500, End: 999, Total: -1}},
+		{"bytes */1000", ContentRange{Start: -1, End: -1, Total: 1000}},
+	}
+	for _, tt := range tests {
+		got, err := ParseContentRange(tt.value)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseContentRange(%q) = %+v, %v; want %+v", tt.value, got, err, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "items 0-9/10", "bytes 10-5/100", "bytes 0-100/100", "bytes */*", "bytes 0-9"} {
+		if _, err := ParseContentRange(value); err == nil {
+			t.Errorf("ParseContentRange(%q) expected an error", value)
+		}
+	}
+
+	if got := (ContentRange{Start: 0, End: 99, Total: 1000}).String(); got != "bytes 0-99 of 1000" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func artifactServer(t *testing.T, content []byte, ranges *[]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*ranges = append(*ranges, r.Header.Get("Range"))
+		http.ServeContent(w, r, "artifact.bin", time.Time{}, bytes.NewReader(content))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDownloadWritesAndResumes(t *testing.T) {
+	content := []byte(strings.Repeat("0123456789", 1000))
+	var ranges []string
+	server := artifactServer(t, content, &ranges)
+	path := filepath.Join(t.TempDir(), "artifact.bin")
+	client := NewClient(5 * time.Second)
+	req := Request{Method: "GET", URL: server.URL}
+
+	// A partial file is completed with a Range request
+	if err := os.WriteFile(path, content[:4000], 0600); err != nil {
+		t.Fatal(err)
+	}
+	var lastReceived, lastTotal int64
+	resp := client.Download(context.Background(), req, path, func(received, total int64) {
+		lastReceived, lastTotal = received, total
+	})
+	if resp.Error != nil {
+		t.Fatalf("Download() error = %v", resp.Error)
+	}
+	d := resp.Download
+	if resp.StatusCode != http.StatusPartialContent || d.Offset != 4000 || d.Written != 6000 || d.Total != 10000 || !d.Complete {
+		t.Errorf("Download() = %d %+v, want 206 resumed at 4000 of 10000", resp.StatusCode, d)
+	}
+	if d.Range == nil || d.Range.Start != 4000 || ranges[0] != "bytes=4000-" {
+		t.Errorf("Download() sent Range %q, got Content-Range %v", ranges[0], d.Range)
+	}
+	if lastReceived != 10000 || lastTotal != 10000 {
+		t.Errorf("progress ended at %d of %d, want 10000 of 10000", lastReceived, lastTotal)
+	}
+	if got, _ := os.ReadFile(path); !bytes.Equal(got, content) {
+		t.Errorf("file holds %d bytes that differ from the artifact", len(got))
+	}
+
+	// Asking for more of a whole file reports it as complete
+	resp = client.Download(context.Background(), req, path, nil)
+	if resp.Error != nil || resp.StatusCode != http.StatusRequestedRangeNotSatisfiable || !resp.Download.Complete || resp.Download.Written != 0 {
+		t.Errorf("Download() of a whole file = %d %+v, %v", resp.StatusCode, resp.Download, resp.Error)
+	}
+}
+
+func TestDownloadRestartsWhenRangeIsIgnored(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("the whole artifact"))
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "artifact.bin")
+	if err := os.WriteFile(path, []byte("stale partial bytes that are longer"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	resp := NewClient(5*time.Second).Download(context.Background(), Request{Method: "GET", URL: server.URL}, path, nil)
+	if resp.Error != nil || !resp.Download.Restarted || resp.Download.Offset != 0 || !resp.Download.Complete {
+		t.Errorf("Download() = %+v, %v; want a restarted, complete download", resp.Download, resp.Error)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "the whole artifact" {
+		t.Errorf("file = %q, want it replaced by the whole body", got)
+	}
+}
+
+func TestDownloadRejectsMismatchedRange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Range", "bytes 0-9/10")
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte("0123456789"))
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "artifact.bin")
+	if err := os.WriteFile(path, []byte("01234"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	resp := NewClient(5*time.Second).Download(context.Background(), Request{Method: "GET", URL: server.URL}, path, nil)
+	if resp.Error == nil {
+		t.Fatal("Download() expected an error when the server resumes at the wrong byte")
+	}
+	if got, _ := os.ReadFile(path); string(got) != "01234" {
+		t.Errorf("file = %q, want the partial file untouched", got)
+	}
+}
+
+func TestDownloadLeavesFileOnErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": "expired link"}`, http.StatusForbidden)
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "artifact.bin")
+
+	resp := NewClient(5*time.Second).Download(context.Background(), Request{Method: "GET", URL: server.URL}, path, nil)
+	if resp.StatusCode != http.StatusForbidden || resp.Download != nil || !strings.Contains(resp.Body, "expired link") {
+		t.Errorf("Download() = %d %+v %q, want the error page as the body", resp.StatusCode, resp.Download, resp.Body)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected no file for an error response, got %v", err)
+	}
+}
+
+func TestDownloadKeepsExplicitRange(t *testing.T) {
+	content := []byte("0123456789")
+	var ranges []string
+	server := artifactServer(t, content, &ranges)
+	path := filepath.Join(t.TempDir(), "slice.bin")
+	if err := os.WriteFile(path, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	req := Request{Method: "GET", URL: server.URL, Headers: map[string]string{"Range": "bytes=2-5"}}
+	resp := NewClient(5*time.Second).Download(context.Background(), req, path, nil)
+	if resp.Error != nil || ranges[0] != "bytes=2-5" || resp.Download.Range == nil || resp.Download.Range.Start != 2 {
+		t.Fatalf("Download() = %+v, %v with Range %q", resp.Download, resp.Error, ranges[0])
+	}
+	if got, _ := os.ReadFile(path); string(got) != "2345" {
+		t.Errorf("file = %q, want the requested slice", got)
+	}
+}
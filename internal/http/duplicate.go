@@ -0,0 +1,121 @@
+package http
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MaxDuplicateSends caps how many copies of a request are sent at once
+const MaxDuplicateSends = 20
+
+// DuplicateResult holds the responses of a request sent concurrently and the
+// differences of every response against the first one
+type DuplicateResult struct {
+	Request   Request
+	Responses []Response
+	Diffs     []*DiffResult
+}
+
+// SendConcurrently sends n copies of the request at the same time. All
+// goroutines are released together to maximise overlap on the server.
+func SendConcurrently(client *Client, req Request, n int) []Response {
+	if n < 1 {
+		n = 1
+	}
+	if n > MaxDuplicateSends {
+		n = MaxDuplicateSends
+	}
+
+	responses := make([]Response, n)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			responses[i] = client.Send(req)
+		}(i)
+	}
+
+	close(start)
+	wg.Wait()
+
+	return responses
+}
+
+// CompareDuplicates diffs every response against the first one, ignoring
+// response times, headers that are expected to change per response and
+// whatever the ignore rules match
+func CompareDuplicates(req Request, responses []Response, ignore ...IgnoreRules) *DuplicateResult {
+	result := &DuplicateResult{Request: req, Responses: responses}
+	if len(responses) < 2 {
+		return result
+	}
+
+	base := responses[0]
+	for _, resp := range responses[1:] {
+		diff := CompareResponses(base, resp, append([]IgnoreRules{volatileRules()}, ignore...)...)
+		diff.ResponseTimeDiff = nil
+		result.Diffs = append(result.Diffs, diff)
+	}
+
+	return result
+}
+
+// Consistent reports whether all responses matched the first one
+func (r *DuplicateResult) Consistent() bool {
+	for _, resp := range r.Responses {
+		if resp.Error != nil {
+			return false
+		}
+	}
+	for _, diff := range r.Diffs {
+		if diff.HasDifferences() {
+			return false
+		}
+	}
+	return true
+}
+
+// FormatDuplicateResult renders a summary of the responses followed by the
+// differences of each response against the first one
+func FormatDuplicateResult(r *DuplicateResult) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("Sent %s %s %d times concurrently\n\n", r.Request.Method, r.Request.URL, len(r.Responses)))
+
+	for i, resp := range r.Responses {
+		if resp.Error != nil {
+			b.WriteString(fmt.Sprintf("✗ #%d  error: %v\n", i+1, resp.Error))
+			continue
+		}
+
+		mark := "✓"
+		if i > 0 && r.Diffs[i-1].HasDifferences() {
+			mark = "✗"
+		}
+		b.WriteString(fmt.Sprintf("%s #%d  %s • %s • %s\n", mark, i+1, resp.Status,
+			FormatDuration(resp.ResponseTime), FormatSize(resp.Size)))
+	}
+	b.WriteString("\n")
+
+	if r.Consistent() {
+		b.WriteString("All responses are identical\n")
+		return b.String()
+	}
+
+	for i, diff := range r.Diffs {
+		if !diff.HasDifferences() || r.Responses[i+1].Error != nil {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("#1 vs #%d\n", i+2))
+		report := strings.TrimPrefix(FormatDiff(diff), "Response Comparison\n===================\n\n")
+		b.WriteString(report)
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
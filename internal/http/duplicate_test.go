@@ -0,0 +1,81 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSendConcurrentlyConsistent(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Date", time.Now().Format(time.RFC1123))
+		w.Header().Set("X-Request-Id", fmt.Sprintf("%d", time.Now().UnixNano()))
+		w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(5 * time.Second)
+	req := Request{Method: "GET", URL: server.URL}
+
+	result := CompareDuplicates(req, SendConcurrently(client, req, 3))
+
+	if atomic.LoadInt32(&hits) != 3 {
+		t.Fatalf("Expected 3 requests, got %d", hits)
+	}
+	if len(result.Diffs) != 2 {
+		t.Fatalf("Expected 2 diffs, got %d", len(result.Diffs))
+	}
+	if !result.Consistent() {
+		t.Errorf("Expected volatile headers to be ignored, got:\n%s", FormatDuplicateResult(result))
+	}
+	if !strings.Contains(FormatDuplicateResult(result), "All responses are identical") {
+		t.Errorf("Expected identical summary, got:\n%s", FormatDuplicateResult(result))
+	}
+}
+
+func TestSendConcurrentlyDetectsRace(t *testing.T) {
+	var counter int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&counter, 1)
+		if n > 1 {
+			w.WriteHeader(http.StatusConflict)
+		}
+		fmt.Fprintf(w, `{"attempt": %d}`, n)
+	}))
+	defer server.Close()
+
+	client := NewClient(5 * time.Second)
+	req := Request{Method: "POST", URL: server.URL}
+
+	result := CompareDuplicates(req, SendConcurrently(client, req, 2))
+
+	if result.Consistent() {
+		t.Fatal("Expected responses to differ")
+	}
+
+	report := FormatDuplicateResult(result)
+	if !strings.Contains(report, "#1 vs #2") {
+		t.Errorf("Expected pairwise diff in report, got:\n%s", report)
+	}
+}
+
+func TestSendConcurrentlyClampsCount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := NewClient(5 * time.Second)
+	req := Request{Method: "GET", URL: server.URL}
+
+	if got := len(SendConcurrently(client, req, 0)); got != 1 {
+		t.Errorf("Expected 1 response for n=0, got %d", got)
+	}
+	if got := len(SendConcurrently(client, req, MaxDuplicateSends+5)); got != MaxDuplicateSends {
+		t.Errorf("Expected %d responses, got %d", MaxDuplicateSends, got)
+	}
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"mime"
+	"net/url"
+	"strings"
+)
+
+// Body modes of a request. The mode is carried by the Content-Type header,
+// so saved requests and history keep it with their headers.
+const (
+	// BodyRaw sends the body as written, JSON unless told otherwise
+	BodyRaw = "raw"
+	// BodyForm sends name=value lines as an application/x-www-form-urlencoded body
+	BodyForm = "form"
+	// BodyMultipart sends name=value and name=@path lines as a
+	// multipart/form-data body with the files attached
+	BodyMultipart = "multipart"
+)
+
+// BodyModes lists the modes in the order the body editor cycles them
+var BodyModes = []string{BodyRaw, BodyForm, BodyMultipart}
+
+// Content types of the body modes. Raw bodies are JSON by default.
+const (
+	ContentTypeJSON      = "application/json"
+	ContentTypeForm      = "application/x-www-form-urlencoded"
+	ContentTypeMultipart = "multipart/form-data"
+)
+
+// IsFormURLEncoded reports whether contentType asks for a form-urlencoded
+// body
+func IsFormURLEncoded(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == ContentTypeForm
+}
+
+// BodyModeOf returns the body mode contentType asks for
+func BodyModeOf(contentType string) string {
+	switch {
+	case IsMultipart(contentType):
+		return BodyMultipart
+	case IsFormURLEncoded(contentType):
+		return BodyForm
+	default:
+		return BodyRaw
+	}
+}
+
+// BodyModeContentType returns the Content-Type a body mode is sent with
+func BodyModeContentType(mode string) string {
+	switch mode {
+	case BodyForm:
+		return ContentTypeForm
+	case BodyMultipart:
+		return ContentTypeMultipart
+	default:
+		return ContentTypeJSON
+	}
+}
+
+// EncodeFormBody encodes a form-urlencoded body written one name=value per
+// line, keeping the order of the fields. Lines may also hold several
+// fields joined by &, and values already percent-encoded are decoded first,
+// so a body that is encoded already comes out the same. Blank lines and
+// lines starting with # are skipped.
+func EncodeFormBody(body string) string {
+	var fields []string
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, field := range strings.Split(line, "&") {
+			if field == "" {
+				continue
+			}
+			name, value, hasValue := strings.Cut(field, "=")
+			encoded := url.QueryEscape(unescapeFormValue(strings.TrimSpace(name)))
+			if hasValue {
+				encoded += "=" + url.QueryEscape(unescapeFormValue(value))
+			}
+			fields = append(fields, encoded)
+		}
+	}
+	return strings.Join(fields, "&")
+}
+
+// unescapeFormValue decodes s, leaving it as written when it is not valid
+// form encoding, like a lone % in a value
+func unescapeFormValue(s string) string {
+	unescaped, err := url.QueryUnescape(s)
+	if err != nil {
+		return s
+	}
+	return unescaped
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeFormBody(t *testing.T) {
+	tests := map[string]string{
+		"name=John Doe\nemail=john@example.com": "name=John+Doe&email=john%40example.com",
+		"# login\nuser=a&pass=p%26w\n\n":        "user=a&pass=p%26w",
+		"q=a+b&debug":                           "q=a+b&debug",
+		"discount=100%":                         "discount=100%25",
+	}
+	for body, want := range tests {
+		if got := EncodeFormBody(body); got != want {
+			t.Errorf("EncodeFormBody(%q) = %q, want %q", body, got, want)
+		}
+		if again := EncodeFormBody(want); again != want {
+			t.Errorf("EncodeFormBody(%q) = %q, want an encoded body unchanged", want, again)
+		}
+	}
+}
+
+func TestBodyModeOf(t *testing.T) {
+	for contentType, want := range map[string]string{
+		"":                                       BodyRaw,
+		"application/json":                       BodyRaw,
+		"application/x-www-form-urlencoded":      BodyForm,
+		"multipart/form-data; boundary=abc":      BodyMultipart,
+		"Application/X-WWW-Form-Urlencoded; a=b": BodyForm,
+	} {
+		if got := BodyModeOf(contentType); got != want {
+			t.Errorf("BodyModeOf(%q) = %q, want %q", contentType, got, want)
+		}
+		if want != BodyRaw && BodyModeOf(BodyModeContentType(want)) != want {
+			t.Errorf("BodyModeContentType(%q) does not read back as the mode", want)
+		}
+	}
+}
+
+func TestSendEncodesFormBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.PostForm.Get("name") != "John Doe" || r.PostForm.Get("note") != "a & b" {
+			http.Error(w, "unexpected form", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	req := Request{
+		Method:  "POST",
+		URL:     server.URL,
+		Headers: map[string]string{"Content-Type": ContentTypeForm},
+		Body:    "name=John Doe\nnote=a %26 b",
+	}
+	resp := NewClient(5 * time.Second).Send(req)
+	if resp.Error != nil || resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("Expected the form to be accepted, got %d %v: %s", resp.StatusCode, resp.Error, resp.Body)
+	}
+
+	if curl := RequestToCurl(req); !strings.Contains(curl, "'name=John+Doe&note=a+%26+b'") {
+		t.Errorf("Expected curl to send the encoded form, got:\n%s", curl)
+	}
+}
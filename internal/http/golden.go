@@ -0,0 +1,37 @@
+package http
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CompareToGolden diffs a response against a pinned golden response. Response
+// time and the VolatileHeaders are left out, as is whatever the rules ignore.
+func CompareToGolden(golden, current Response, rules IgnoreRules) *DiffResult {
+	diff := CompareResponses(golden, current, volatileRules(), rules)
+	diff.ResponseTimeDiff = nil
+	return diff
+}
+
+// Summary describes the differences in one line, e.g.
+// "status 200 -> 500 • 1 header • 2 modified, 0 added, 0 removed"
+func (d *DiffResult) Summary() string {
+	var parts []string
+	if d.StatusCodeDiff != nil {
+		parts = append(parts, fmt.Sprintf("status %s -> %s", d.StatusCodeDiff.Old, d.StatusCodeDiff.New))
+	}
+	switch n := len(d.HeadersDiff); n {
+	case 0:
+	case 1:
+		parts = append(parts, "1 header")
+	default:
+		parts = append(parts, fmt.Sprintf("%d headers", n))
+	}
+	if d.BodyDiff != nil && len(d.BodyDiff.Changes) > 0 {
+		parts = append(parts, d.BodyDiff.Summary)
+	}
+	if d.ResponseTimeDiff != nil {
+		parts = append(parts, fmt.Sprintf("%+dms", d.ResponseTimeDiff.DiffMs))
+	}
+	return strings.Join(parts, " • ")
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompareToGoldenIgnoresVolatileFields(t *testing.T) {
+	golden := Response{
+		StatusCode:   200,
+		Headers:      map[string][]string{"Date": {"Mon, 01 Jan 2024 00:00:00 GMT"}, "X-Trace": {"a"}},
+		Body:         `{"data": {"id": "1", "items": [{"name": "a", "updated_at": "2024-01-01"}]}, "meta": {"request_id": "r1", "count": 1}}`,
+		ResponseTime: 100 * time.Millisecond,
+	}
+	current := Response{
+		StatusCode:   200,
+		Headers:      map[string][]string{"Date": {"Tue, 02 Jan 2024 00:00:00 GMT"}, "X-Trace": {"b"}},
+		Body:         `{"data": {"id": "2", "items": [{"name": "a", "updated_at": "2024-01-02"}]}, "meta": {"request_id": "r2", "count": 1}}`,
+		ResponseTime: 300 * time.Millisecond,
+	}
+
+	diff := CompareToGolden(golden, current, mustIgnoreRules(t, "updated_at", "data.id", "meta.request_id", "x-trace"))
+	if diff.HasDifferences() {
+		t.Errorf("Expected only volatile differences, got:\n%s", FormatDiff(diff))
+	}
+
+	diff = CompareToGolden(golden, current, mustIgnoreRules(t, "updated_at"))
+	if !diff.HasDifferences() {
+		t.Fatal("Expected unexpected differences to be flagged")
+	}
+	if _, ok := diff.HeadersDiff["Date"]; ok {
+		t.Error("Expected the Date header to always be ignored")
+	}
+	if len(diff.BodyDiff.Changes) != 2 {
+		t.Errorf("Expected data.id and meta.request_id to differ, got %+v", diff.BodyDiff.Changes)
+	}
+	if !strings.Contains(diff.Summary(), "1 ignored") || !strings.Contains(diff.Summary(), "1 header") {
+		t.Errorf("Summary() = %q", diff.Summary())
+	}
+	if diff.ResponseTimeDiff != nil {
+		t.Error("Expected response time to be left out of golden comparisons")
+	}
+}
+
+func TestCompareToGoldenStatusChange(t *testing.T) {
+	golden := Response{StatusCode: 200, Body: `{"ok": true}`}
+	current := Response{StatusCode: 500, Body: `{"ok": true}`}
+
+	diff := CompareToGolden(golden, current, IgnoreRules{})
+	if got := diff.Summary(); got != "status 200 -> 500" {
+		t.Errorf("Summary() = %q", got)
+	}
+}
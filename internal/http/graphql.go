@@ -185,6 +185,12 @@ fragment TypeRef on __Type {
 
 // SendGraphQLRequest sends a GraphQL request
 func SendGraphQLRequest(client *Client, endpoint string, query string, variables map[string]interface{}) (*GraphQLResponse, error) {
+	return SendGraphQLRequestWithHeaders(client, endpoint, query, variables, nil)
+}
+
+// SendGraphQLRequestWithHeaders sends a GraphQL request with extra headers,
+// such as authorization
+func SendGraphQLRequestWithHeaders(client *Client, endpoint string, query string, variables map[string]interface{}, headers map[string]string) (*GraphQLResponse, error) {
 	gqlReq := GraphQLRequest{
 		Query:     query,
 		Variables: variables,
@@ -204,6 +210,9 @@ func SendGraphQLRequest(client *Client, endpoint string, query string, variables
 		},
 		Body: string(bodyBytes),
 	}
+	for key, value := range headers {
+		req.Headers[key] = value
+	}
 
 	resp := client.Send(req)
 	if resp.Error != nil {
@@ -220,7 +229,12 @@ func SendGraphQLRequest(client *Client, endpoint string, query string, variables
 
 // IntrospectSchema performs introspection on a GraphQL endpoint
 func IntrospectSchema(client *Client, endpoint string) (*GraphQLSchema, error) {
-	gqlResp, err := SendGraphQLRequest(client, endpoint, IntrospectionQuery, nil)
+	return IntrospectSchemaWithHeaders(client, endpoint, nil)
+}
+
+// IntrospectSchemaWithHeaders performs introspection sending extra headers
+func IntrospectSchemaWithHeaders(client *Client, endpoint string, headers map[string]string) (*GraphQLSchema, error) {
+	gqlResp, err := SendGraphQLRequestWithHeaders(client, endpoint, IntrospectionQuery, nil, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,398 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Schema entry kinds listed by the schema browser
+const (
+	SchemaEntryType       = "type"
+	SchemaEntryField      = "field"
+	SchemaEntryInputField = "input"
+	SchemaEntryArg        = "arg"
+	SchemaEntryEnumValue  = "enum"
+	SchemaEntryDirective  = "directive"
+)
+
+// SchemaEntry is a single browsable item of an introspected schema
+type SchemaEntry struct {
+	Kind        string
+	Name        string
+	Parent      string // owning type, field or directive
+	Detail      string // type signature or kind
+	Description string
+	Target      string // named type to jump to, if any
+}
+
+// FindType looks up a named type
+func (s *GraphQLSchema) FindType(name string) *GraphQLType {
+	for i := range s.Types {
+		if s.Types[i].Name == name {
+			return &s.Types[i]
+		}
+	}
+	return nil
+}
+
+// RootOperation returns the operation keyword for a root type name, or an
+// empty string when the type is not a root type
+func (s *GraphQLSchema) RootOperation(typeName string) string {
+	switch {
+	case s.QueryType != nil && s.QueryType.Name == typeName:
+		return "query"
+	case s.MutationType != nil && s.MutationType.Name == typeName:
+		return "mutation"
+	case s.SubscriptionType != nil && s.SubscriptionType.Name == typeName:
+		return "subscription"
+	}
+	return ""
+}
+
+// SchemaTypeEntries lists the root operation types first, then the remaining
+// named types alphabetically and finally the directives. Introspection types
+// are left out.
+func SchemaTypeEntries(s *GraphQLSchema) []SchemaEntry {
+	var roots, others []SchemaEntry
+
+	for _, t := range s.Types {
+		if strings.HasPrefix(t.Name, "__") {
+			continue
+		}
+		entry := SchemaEntry{
+			Kind:        SchemaEntryType,
+			Name:        t.Name,
+			Detail:      t.Kind,
+			Description: t.Description,
+			Target:      t.Name,
+		}
+		if op := s.RootOperation(t.Name); op != "" {
+			entry.Detail = op + " root"
+			roots = append(roots, entry)
+		} else {
+			others = append(others, entry)
+		}
+	}
+
+	rootOrder := map[string]int{"query root": 0, "mutation root": 1, "subscription root": 2}
+	sort.SliceStable(roots, func(i, j int) bool { return rootOrder[roots[i].Detail] < rootOrder[roots[j].Detail] })
+	sort.SliceStable(others, func(i, j int) bool { return others[i].Name < others[j].Name })
+
+	entries := append(roots, others...)
+	for _, d := range s.Directives {
+		entries = append(entries, SchemaEntry{
+			Kind:        SchemaEntryDirective,
+			Name:        "@" + d.Name,
+			Detail:      strings.Join(d.Locations, " | "),
+			Description: d.Description,
+		})
+	}
+
+	return entries
+}
+
+// TypeMemberEntries lists the fields, input fields, enum values and possible
+// types of a type
+func TypeMemberEntries(t *GraphQLType) []SchemaEntry {
+	var entries []SchemaEntry
+
+	for _, f := range t.Fields {
+		entries = append(entries, SchemaEntry{
+			Kind:        SchemaEntryField,
+			Name:        f.Name,
+			Parent:      t.Name,
+			Detail:      formatFieldSignature(f),
+			Description: f.Description,
+			Target:      unwrapType(f.Type).Name,
+		})
+	}
+
+	for _, f := range t.InputFields {
+		entries = append(entries, SchemaEntry{
+			Kind:        SchemaEntryInputField,
+			Name:        f.Name,
+			Parent:      t.Name,
+			Detail:      FormatGraphQLType(f.Type),
+			Description: f.Description,
+			Target:      unwrapType(f.Type).Name,
+		})
+	}
+
+	for _, v := range t.EnumValues {
+		entries = append(entries, SchemaEntry{
+			Kind:        SchemaEntryEnumValue,
+			Name:        v.Name,
+			Parent:      t.Name,
+			Description: v.Description,
+		})
+	}
+
+	for _, ref := range t.PossibleTypes {
+		entries = append(entries, SchemaEntry{
+			Kind:   SchemaEntryType,
+			Name:   ref.Name,
+			Parent: t.Name,
+			Detail: "possible type",
+			Target: ref.Name,
+		})
+	}
+
+	return entries
+}
+
+// SearchSchema finds types, fields, arguments, enum values and directives
+// whose name contains the term, case-insensitively
+func SearchSchema(s *GraphQLSchema, term string) []SchemaEntry {
+	term = strings.ToLower(strings.TrimSpace(term))
+	if term == "" {
+		return SchemaTypeEntries(s)
+	}
+
+	matches := func(name string) bool {
+		return strings.Contains(strings.ToLower(name), term)
+	}
+
+	var results []SchemaEntry
+	for _, entry := range SchemaTypeEntries(s) {
+		if matches(entry.Name) {
+			results = append(results, entry)
+		}
+	}
+
+	for i := range s.Types {
+		t := &s.Types[i]
+		if strings.HasPrefix(t.Name, "__") {
+			continue
+		}
+		for _, entry := range TypeMemberEntries(t) {
+			if entry.Kind != SchemaEntryType && matches(entry.Name) {
+				results = append(results, entry)
+			}
+		}
+		for _, f := range t.Fields {
+			for _, arg := range f.Args {
+				if matches(arg.Name) {
+					results = append(results, argEntry(t.Name+"."+f.Name, arg))
+				}
+			}
+		}
+	}
+
+	for _, d := range s.Directives {
+		for _, arg := range d.Args {
+			if matches(arg.Name) {
+				results = append(results, argEntry("@"+d.Name, arg))
+			}
+		}
+	}
+
+	return results
+}
+
+func argEntry(parent string, arg GraphQLInputValue) SchemaEntry {
+	detail := FormatGraphQLType(arg.Type)
+	if arg.DefaultValue != "" {
+		detail += " = " + arg.DefaultValue
+	}
+	return SchemaEntry{
+		Kind:        SchemaEntryArg,
+		Name:        arg.Name,
+		Parent:      parent,
+		Detail:      detail,
+		Description: arg.Description,
+		Target:      unwrapType(arg.Type).Name,
+	}
+}
+
+// formatFieldSignature renders a field as "(id: ID!, first: Int): [User]"
+func formatFieldSignature(f GraphQLField) string {
+	var sb strings.Builder
+	if len(f.Args) > 0 {
+		args := make([]string, len(f.Args))
+		for i, arg := range f.Args {
+			args[i] = arg.Name + ": " + FormatGraphQLType(arg.Type)
+		}
+		sb.WriteString("(" + strings.Join(args, ", ") + ")")
+	}
+	sb.WriteString(": " + FormatGraphQLType(f.Type))
+	return sb.String()
+}
+
+// GraphQLFieldSnippet builds the selection for a field, passing every
+// argument as a variable and selecting the sub fields of object types
+func GraphQLFieldSnippet(schema *GraphQLSchema, field GraphQLField, maxDepth int) string {
+	var sb strings.Builder
+	sb.WriteString(field.Name)
+
+	if len(field.Args) > 0 {
+		args := make([]string, len(field.Args))
+		for i, arg := range field.Args {
+			args[i] = fmt.Sprintf("%s: $%s", arg.Name, arg.Name)
+		}
+		sb.WriteString("(" + strings.Join(args, ", ") + ")")
+	}
+
+	fieldType := schema.FindType(unwrapType(field.Type).Name)
+	if fieldType != nil && (fieldType.Kind == "OBJECT" || fieldType.Kind == "INTERFACE") {
+		sub := generateFields(schema, fieldType, 2, maxDepth, make(map[string]bool))
+		if sub == "" {
+			sub = "  __typename\n"
+		}
+		sb.WriteString(" {\n" + sub + "}")
+	}
+
+	return sb.String()
+}
+
+// InsertGraphQLSelection adds a selection to the outermost selection set of
+// a query, creating a new operation when the query is empty
+func InsertGraphQLSelection(query, selection, operation string) string {
+	if operation == "" {
+		operation = "query"
+	}
+
+	indented := indentLines(selection, "  ")
+
+	end := strings.LastIndex(query, "}")
+	if strings.TrimSpace(query) == "" || end < 0 {
+		return fmt.Sprintf("%s {\n%s\n}\n", operation, indented)
+	}
+
+	head := strings.TrimRight(query[:end], " \t\n")
+	return head + "\n" + indented + "\n" + query[end:]
+}
+
+// InsertIntoGraphQLBody inserts a selection into a request body. JSON bodies
+// with a "query" string are updated in place, empty bodies become a new JSON
+// GraphQL request and anything else is treated as a raw query.
+func InsertIntoGraphQLBody(body, selection, operation string) (string, error) {
+	return editGraphQLBody(body, func(query string) string {
+		return InsertGraphQLSelection(query, selection, operation)
+	}, nil)
+}
+
+// InsertGraphQLField inserts the selection of a root field into a request
+// body like InsertIntoGraphQLBody, declaring the field's arguments as
+// variables of the operation. JSON bodies also get a placeholder value for
+// each variable they do not set yet.
+func InsertGraphQLField(schema *GraphQLSchema, body string, field GraphQLField, operation string, maxDepth int) (string, error) {
+	selection := GraphQLFieldSnippet(schema, field, maxDepth)
+	return editGraphQLBody(body, func(query string) string {
+		return DeclareGraphQLVariables(InsertGraphQLSelection(query, selection, operation), field.Args)
+	}, field.Args)
+}
+
+// editGraphQLBody applies edit to the query of a request body and adds
+// placeholders for the variables of args to JSON bodies
+func editGraphQLBody(body string, edit func(query string) string, args []GraphQLInputValue) (string, error) {
+	trimmed := strings.TrimSpace(body)
+
+	payload := make(map[string]interface{})
+	if trimmed != "" {
+		if !strings.HasPrefix(trimmed, "{") || json.Unmarshal([]byte(trimmed), &payload) != nil {
+			return edit(body), nil
+		}
+	}
+
+	query, ok := payload["query"].(string)
+	if !ok && payload["query"] != nil {
+		return "", fmt.Errorf("body \"query\" is not a string")
+	}
+	payload["query"] = edit(query)
+
+	if len(args) > 0 {
+		variables, _ := payload["variables"].(map[string]interface{})
+		if variables == nil {
+			variables = make(map[string]interface{})
+		}
+		for _, arg := range args {
+			if _, ok := variables[arg.Name]; !ok {
+				variables[arg.Name] = GraphQLPlaceholder(arg.Type)
+			}
+		}
+		payload["variables"] = variables
+	}
+
+	out, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// DeclareGraphQLVariables adds a variable definition for each argument to
+// the header of the first operation in query, skipping the ones already
+// declared. A shorthand query becomes a named "query" operation.
+func DeclareGraphQLVariables(query string, args []GraphQLInputValue) string {
+	open := strings.Index(query, "{")
+	if open < 0 {
+		return query
+	}
+	header := query[:open]
+
+	var definitions []string
+	for _, arg := range args {
+		declared := regexp.MustCompile(`\$` + regexp.QuoteMeta(arg.Name) + `\s*:`)
+		if !declared.MatchString(header) {
+			definitions = append(definitions, "$"+arg.Name+": "+FormatGraphQLType(arg.Type))
+		}
+	}
+	if len(definitions) == 0 {
+		return query
+	}
+	list := strings.Join(definitions, ", ")
+
+	if end := strings.LastIndex(header, ")"); end >= 0 && strings.Contains(header, "(") {
+		existing := strings.TrimSpace(header[strings.Index(header, "(")+1 : end])
+		if existing != "" {
+			list = ", " + list
+		}
+		header = strings.TrimRight(header[:end], " \t\n") + list + header[end:]
+	} else {
+		operation := strings.TrimSpace(header)
+		if operation == "" {
+			operation = "query"
+		}
+		header = operation + "(" + list + ") "
+	}
+	return header + query[open:]
+}
+
+// GraphQLPlaceholder returns an empty value of a type, used to fill in the
+// variables of a generated query
+func GraphQLPlaceholder(typeRef GraphQLTypeRef) interface{} {
+	switch typeRef.Kind {
+	case "NON_NULL":
+		if typeRef.OfType != nil {
+			return GraphQLPlaceholder(*typeRef.OfType)
+		}
+	case "LIST":
+		return []interface{}{}
+	case "INPUT_OBJECT":
+		return map[string]interface{}{}
+	case "ENUM":
+		return ""
+	case "SCALAR":
+		switch typeRef.Name {
+		case "Int", "Float":
+			return 0
+		case "Boolean":
+			return false
+		}
+		return ""
+	}
+	return nil
+}
+
+func indentLines(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
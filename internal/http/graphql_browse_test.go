@@ -0,0 +1,196 @@
+package http
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func browseTestSchema() *GraphQLSchema {
+	nonNull := func(name string) GraphQLTypeRef {
+		return GraphQLTypeRef{Kind: "NON_NULL", OfType: &GraphQLTypeRef{Kind: "SCALAR", Name: name}}
+	}
+
+	return &GraphQLSchema{
+		QueryType:    &GraphQLType{Name: "Query"},
+		MutationType: &GraphQLType{Name: "Mutation"},
+		Types: []GraphQLType{
+			{Kind: "OBJECT", Name: "User", Fields: []GraphQLField{
+				{Name: "id", Type: nonNull("ID")},
+				{Name: "email", Type: GraphQLTypeRef{Kind: "SCALAR", Name: "String"}},
+				{Name: "role", Type: GraphQLTypeRef{Kind: "ENUM", Name: "Role"}},
+			}},
+			{Kind: "OBJECT", Name: "Mutation", Fields: []GraphQLField{
+				{Name: "deleteUser", Args: []GraphQLInputValue{{Name: "id", Type: nonNull("ID")}}, Type: GraphQLTypeRef{Kind: "SCALAR", Name: "Boolean"}},
+			}},
+			{Kind: "OBJECT", Name: "Query", Fields: []GraphQLField{
+				{Name: "user", Args: []GraphQLInputValue{{Name: "userId", Type: nonNull("ID")}}, Type: GraphQLTypeRef{Kind: "OBJECT", Name: "User"}},
+			}},
+			{Kind: "ENUM", Name: "Role", EnumValues: []GraphQLEnumValue{{Name: "ADMIN"}, {Name: "MEMBER"}}},
+			{Kind: "SCALAR", Name: "ID"},
+			{Kind: "OBJECT", Name: "__Schema"},
+		},
+		Directives: []GraphQLDirective{
+			{Name: "deprecated", Locations: []string{"FIELD_DEFINITION"}, Args: []GraphQLInputValue{{Name: "reason", Type: GraphQLTypeRef{Kind: "SCALAR", Name: "String"}}}},
+		},
+	}
+}
+
+func TestSchemaTypeEntries(t *testing.T) {
+	entries := SchemaTypeEntries(browseTestSchema())
+
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name)
+	}
+
+	expected := []string{"Query", "Mutation", "ID", "Role", "User", "@deprecated"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+}
+
+func TestTypeMemberEntries(t *testing.T) {
+	schema := browseTestSchema()
+
+	entries := TypeMemberEntries(schema.FindType("Query"))
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	if entries[0].Detail != "(userId: ID!): User" || entries[0].Target != "User" {
+		t.Errorf("Unexpected field entry: %+v", entries[0])
+	}
+
+	enums := TypeMemberEntries(schema.FindType("Role"))
+	if len(enums) != 2 || enums[0].Kind != SchemaEntryEnumValue {
+		t.Errorf("Expected enum values, got %+v", enums)
+	}
+}
+
+func TestSearchSchema(t *testing.T) {
+	schema := browseTestSchema()
+
+	results := SearchSchema(schema, "user")
+
+	kinds := make(map[string]bool)
+	for _, r := range results {
+		kinds[r.Kind+":"+r.Name] = true
+	}
+
+	for _, want := range []string{"type:User", "field:user", "field:deleteUser", "arg:userId"} {
+		if !kinds[want] {
+			t.Errorf("Expected %s in results, got %+v", want, results)
+		}
+	}
+
+	if got := SearchSchema(schema, "reason"); len(got) != 1 || got[0].Parent != "@deprecated" {
+		t.Errorf("Expected directive argument match, got %+v", got)
+	}
+
+	if got := SearchSchema(schema, "schema"); len(got) != 0 {
+		t.Errorf("Expected introspection types to be skipped, got %+v", got)
+	}
+}
+
+func TestGraphQLFieldSnippet(t *testing.T) {
+	schema := browseTestSchema()
+	field := schema.FindType("Query").Fields[0]
+
+	snippet := GraphQLFieldSnippet(schema, field, 2)
+
+	expected := "user(userId: $userId) {\n  id\n  email\n  role\n}"
+	if snippet != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, snippet)
+	}
+}
+
+func TestInsertGraphQLSelection(t *testing.T) {
+	created := InsertGraphQLSelection("", "users {\n  id\n}", "query")
+	if created != "query {\n  users {\n    id\n  }\n}\n" {
+		t.Errorf("Unexpected new query:\n%s", created)
+	}
+
+	appended := InsertGraphQLSelection("query {\n  me\n}\n", "version", "query")
+	if appended != "query {\n  me\n  version\n}\n" {
+		t.Errorf("Unexpected appended query:\n%s", appended)
+	}
+}
+
+func TestInsertIntoGraphQLBody(t *testing.T) {
+	body, err := InsertIntoGraphQLBody(`{"query": "query {\n  me\n}", "variables": {"id": 1}}`, "version", "query")
+	if err != nil {
+		t.Fatalf("InsertIntoGraphQLBody failed: %v", err)
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &payload); err != nil {
+		t.Fatalf("Expected JSON body, got %s", body)
+	}
+	if payload["query"] != "query {\n  me\n  version\n}" {
+		t.Errorf("Unexpected query: %q", payload["query"])
+	}
+	if payload["variables"] == nil {
+		t.Error("Expected variables to be kept")
+	}
+
+	raw, err := InsertIntoGraphQLBody("{ me }", "version", "query")
+	if err != nil || raw != "{ me\n  version\n}" {
+		t.Errorf("Expected raw query to be updated, got %q (%v)", raw, err)
+	}
+
+	empty, err := InsertIntoGraphQLBody("", "deleteUser(id: $id)", "mutation")
+	if err != nil || !strings.Contains(empty, `"query": "mutation {\n  deleteUser(id: $id)\n}\n"`) {
+		t.Errorf("Expected new JSON body, got %s (%v)", empty, err)
+	}
+}
+
+func TestInsertGraphQLField(t *testing.T) {
+	schema := browseTestSchema()
+	field := schema.FindType("Query").Fields[0]
+
+	body, err := InsertGraphQLField(schema, `{"query": "query GetUser {\n  version\n}", "variables": {"userId": "42"}}`, field, "query", 1)
+	if err != nil {
+		t.Fatalf("InsertGraphQLField failed: %v", err)
+	}
+
+	var payload struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	if err := json.Unmarshal([]byte(body), &payload); err != nil {
+		t.Fatalf("Expected JSON body, got %s", body)
+	}
+	if !strings.HasPrefix(payload.Query, "query GetUser($userId: ID!) {\n  version\n  user(userId: $userId)") {
+		t.Errorf("Expected the argument to be declared, got:\n%s", payload.Query)
+	}
+	if payload.Variables["userId"] != "42" {
+		t.Errorf("Expected the variable already set to be kept, got %v", payload.Variables)
+	}
+
+	empty, err := InsertGraphQLField(schema, "", schema.FindType("Mutation").Fields[0], "mutation", 1)
+	if err != nil || !strings.Contains(empty, `mutation($id: ID!) {`) || !strings.Contains(empty, `"id": ""`) {
+		t.Errorf("Expected a new mutation with a placeholder variable, got %s (%v)", empty, err)
+	}
+}
+
+func TestDeclareGraphQLVariables(t *testing.T) {
+	args := []GraphQLInputValue{
+		{Name: "id", Type: GraphQLTypeRef{Kind: "NON_NULL", OfType: &GraphQLTypeRef{Kind: "SCALAR", Name: "ID"}}},
+		{Name: "first", Type: GraphQLTypeRef{Kind: "SCALAR", Name: "Int"}},
+	}
+
+	tests := []struct {
+		query, want string
+	}{
+		{"{ me }", "query($id: ID!, $first: Int) { me }"},
+		{"query Users($first: Int) {\n  users\n}", "query Users($first: Int, $id: ID!) {\n  users\n}"},
+		{"query Users($id: ID!, $first: Int) { users }", "query Users($id: ID!, $first: Int) { users }"},
+		{"mutation () { x }", "mutation ($id: ID!, $first: Int) { x }"},
+		{"not a query", "not a query"},
+	}
+	for _, tt := range tests {
+		if got := DeclareGraphQLVariables(tt.query, args); got != tt.want {
+			t.Errorf("DeclareGraphQLVariables(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
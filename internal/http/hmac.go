@@ -0,0 +1,260 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HMAC algorithms
+const (
+	HMACSHA256 = "sha256"
+	HMACSHA512 = "sha512"
+	HMACSHA1   = "sha1"
+)
+
+// HMACAlgorithms lists the supported algorithms, the default first
+var HMACAlgorithms = []string{HMACSHA256, HMACSHA512, HMACSHA1}
+
+// Signature encodings
+const (
+	EncodingHex    = "hex"
+	EncodingBase64 = "base64"
+)
+
+// HMACEncodings lists the supported signature encodings, the default first
+var HMACEncodings = []string{EncodingHex, EncodingBase64}
+
+// DefaultHMACTemplate is the string-to-sign used when none is set
+const DefaultHMACTemplate = `{method}\n{path}\n{timestamp}\n{body_sha256}`
+
+// HMACPlaceholders documents the placeholders a string-to-sign template can use
+var HMACPlaceholders = []string{
+	"{method}", "{url}", "{host}", "{path}", "{query}",
+	"{body}", "{body_sha256}", "{timestamp}", "{date}", "{header:Name}",
+}
+
+// hmacPlaceholder matches a {name} or {header:Name} placeholder
+var hmacPlaceholder = regexp.MustCompile(`\{([a-z_0-9]+(?::[^{}]+)?)\}`)
+
+// HMACSigner signs requests with an HMAC over a canonical string built from
+// a template. Keys prefixed with "base64:" are decoded before use.
+type HMACSigner struct {
+	Algorithm string
+	Key       string
+	// Template builds the string to sign; \n stands for a newline
+	Template string
+	// Header receives Prefix followed by the signature, Authorization by default
+	Header string
+	Prefix string
+	// Encoding of the signature, hex by default
+	Encoding string
+	// TimestampHeader, when set, sends the {timestamp} that was signed
+	TimestampHeader string
+}
+
+// HMACPreview is what a signer computed for one request
+type HMACPreview struct {
+	StringToSign string
+	Signature    string
+	Header       string
+	HeaderValue  string
+	Timestamp    string
+}
+
+// Validate checks that the signer has a key, a known algorithm and encoding
+// and a template without unknown placeholders
+func (s HMACSigner) Validate() error {
+	if s.Key == "" {
+		return fmt.Errorf("HMAC key cannot be empty")
+	}
+	if _, err := s.key(); err != nil {
+		return err
+	}
+	if _, err := hmacHash(s.Algorithm); err != nil {
+		return err
+	}
+	if enc := s.Encoding; enc != "" && enc != EncodingHex && enc != EncodingBase64 {
+		return fmt.Errorf("unknown signature encoding %q (use hex or base64)", enc)
+	}
+	for _, match := range hmacPlaceholder.FindAllStringSubmatch(s.template(), -1) {
+		if !knownHMACPlaceholder(match[1]) {
+			return fmt.Errorf("unknown placeholder {%s} in string to sign", match[1])
+		}
+	}
+	return nil
+}
+
+// Preview computes the string to sign and the signature for req at now
+// without changing the request
+func (s HMACSigner) Preview(req Request, now time.Time) (HMACPreview, error) {
+	if err := s.Validate(); err != nil {
+		return HMACPreview{}, err
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	stringToSign, err := s.stringToSign(req, now, timestamp)
+	if err != nil {
+		return HMACPreview{}, err
+	}
+
+	newHash, _ := hmacHash(s.Algorithm)
+	key, _ := s.key()
+	mac := hmac.New(newHash, key)
+	mac.Write([]byte(stringToSign))
+	sum := mac.Sum(nil)
+
+	signature := hex.EncodeToString(sum)
+	if s.Encoding == EncodingBase64 {
+		signature = base64.StdEncoding.EncodeToString(sum)
+	}
+
+	header := s.Header
+	if header == "" {
+		header = "Authorization"
+	}
+	return HMACPreview{
+		StringToSign: stringToSign,
+		Signature:    signature,
+		Header:       header,
+		HeaderValue:  s.Prefix + signature,
+		Timestamp:    timestamp,
+	}, nil
+}
+
+// Sign returns a copy of req carrying the signature header, and the
+// timestamp header when one is configured
+func (s HMACSigner) Sign(req Request, now time.Time) (Request, HMACPreview, error) {
+	headers := make(map[string]string, len(req.Headers)+2)
+	for k, v := range req.Headers {
+		headers[k] = v
+	}
+	if s.TimestampHeader != "" {
+		headers[s.TimestampHeader] = strconv.FormatInt(now.Unix(), 10)
+	}
+	req.Headers = headers
+
+	preview, err := s.Preview(req, now)
+	if err != nil {
+		return req, preview, fmt.Errorf("failed to sign request: %w", err)
+	}
+	headers[preview.Header] = preview.HeaderValue
+	return req, preview, nil
+}
+
+func (s HMACSigner) template() string {
+	if s.Template == "" {
+		return DefaultHMACTemplate
+	}
+	return s.Template
+}
+
+func (s HMACSigner) key() ([]byte, error) {
+	if encoded, ok := strings.CutPrefix(s.Key, "base64:"); ok {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("HMAC key is not valid base64: %w", err)
+		}
+		return key, nil
+	}
+	return []byte(s.Key), nil
+}
+
+// stringToSign fills in the template placeholders from the request
+func (s HMACSigner) stringToSign(req Request, now time.Time, timestamp string) (string, error) {
+	parsed, err := url.Parse(req.URL)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+
+	template := strings.ReplaceAll(s.template(), `\n`, "\n")
+	return hmacPlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		switch name {
+		case "method":
+			return strings.ToUpper(req.Method)
+		case "url":
+			return req.URL
+		case "host":
+			return parsed.Host
+		case "path":
+			if parsed.EscapedPath() == "" {
+				return "/"
+			}
+			return parsed.EscapedPath()
+		case "query":
+			return canonicalQuery(parsed.Query())
+		case "body":
+			return req.Body
+		case "body_sha256":
+			sum := sha256.Sum256([]byte(req.Body))
+			return hex.EncodeToString(sum[:])
+		case "timestamp":
+			return timestamp
+		case "date":
+			return now.UTC().Format(http.TimeFormat)
+		}
+		if headerName, ok := strings.CutPrefix(name, "header:"); ok {
+			for k, v := range req.Headers {
+				if strings.EqualFold(k, headerName) {
+					return strings.TrimSpace(v)
+				}
+			}
+			return ""
+		}
+		return placeholder
+	}), nil
+}
+
+// canonicalQuery encodes the query with keys and values sorted
+func canonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		vs := append([]string(nil), values[k]...)
+		sort.Strings(vs)
+		for _, v := range vs {
+			parts = append(parts, url.QueryEscape(k)+"="+url.QueryEscape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func knownHMACPlaceholder(name string) bool {
+	switch name {
+	case "method", "url", "host", "path", "query", "body", "body_sha256", "timestamp", "date":
+		return true
+	}
+	headerName, ok := strings.CutPrefix(name, "header:")
+	return ok && headerName != ""
+}
+
+func hmacHash(algorithm string) (func() hash.Hash, error) {
+	switch algorithm {
+	case HMACSHA256, "":
+		return sha256.New, nil
+	case HMACSHA512:
+		return sha512.New, nil
+	case HMACSHA1:
+		return sha1.New, nil
+	default:
+		return nil, fmt.Errorf("unknown HMAC algorithm %q (use sha256, sha512 or sha1)", algorithm)
+	}
+}
@@ -0,0 +1,91 @@
+package http
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHMACSignerPreview(t *testing.T) {
+	signer := HMACSigner{
+		Key:             "secret",
+		Template:        `{method}\n{path}\n{query}\n{timestamp}\n{body_sha256}`,
+		Header:          "X-Signature",
+		Prefix:          "v1=",
+		TimestampHeader: "X-Timestamp",
+	}
+	req := Request{
+		Method:  "post",
+		URL:     "https://api.example.com/v1/orders?z=1&b=2",
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Body:    `{"a":1}`,
+	}
+	now := time.Unix(1700000000, 0)
+
+	signed, preview, err := signer.Sign(req, now)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	wantString := "POST\n/v1/orders\nb=2&z=1\n1700000000\n015abd7f5cc57a2dd94b7590f04ad8084273905ee33ec5cebeae62276a97f862"
+	if preview.StringToSign != wantString {
+		t.Errorf("StringToSign = %q, want %q", preview.StringToSign, wantString)
+	}
+	wantSignature := "c8cdadab5eb767283449d1705d160cf13c9967528aa2fd523e5b56fc5e7a481a"
+	if preview.Signature != wantSignature {
+		t.Errorf("Signature = %s, want %s", preview.Signature, wantSignature)
+	}
+	if signed.Headers["X-Signature"] != "v1="+wantSignature || signed.Headers["X-Timestamp"] != "1700000000" {
+		t.Errorf("Unexpected signed headers: %v", signed.Headers)
+	}
+	if len(req.Headers) != 1 {
+		t.Errorf("Expected the original request to be left alone, got %v", req.Headers)
+	}
+}
+
+func TestHMACSignerBase64(t *testing.T) {
+	signer := HMACSigner{
+		Algorithm: HMACSHA512,
+		Key:       "base64:AAFiaW5hcnk=",
+		Template:  "{method} {path}",
+		Encoding:  EncodingBase64,
+	}
+
+	preview, err := signer.Preview(Request{Method: "GET", URL: "https://api.example.com"}, time.Now())
+	if err != nil {
+		t.Fatalf("Preview() error = %v", err)
+	}
+	if preview.StringToSign != "GET /" {
+		t.Errorf("StringToSign = %q, want %q", preview.StringToSign, "GET /")
+	}
+	want := "+rLxOCr0nuSyY1x2ujwgEUEwc844qbs7ZFQsm3AuolScma5SqZln+J+t/Q2oumWZtiSSHH4/+akJDsRQ3mLoyA=="
+	if preview.Signature != want || preview.Header != "Authorization" {
+		t.Errorf("Unexpected preview: %+v", preview)
+	}
+}
+
+func TestHMACSignerValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		signer HMACSigner
+		want   string
+	}{
+		{"missing key", HMACSigner{}, "key cannot be empty"},
+		{"bad key", HMACSigner{Key: "base64:%%"}, "not valid base64"},
+		{"algorithm", HMACSigner{Key: "k", Algorithm: "md5"}, "unknown HMAC algorithm"},
+		{"encoding", HMACSigner{Key: "k", Encoding: "base32"}, "unknown signature encoding"},
+		{"placeholder", HMACSigner{Key: "k", Template: "{method}\n{nonce}"}, "{nonce}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.signer.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+
+	if err := (HMACSigner{Key: "k", Template: "{header:X-Date}\n{date}"}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v for header placeholders", err)
+	}
+}
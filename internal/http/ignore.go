@@ -0,0 +1,244 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// VolatileHeaders differ between any two responses, or change with the body
+// anyway, and are never compared against a golden response or between
+// concurrent responses
+var VolatileHeaders = []string{
+	"Age",
+	"Cf-Ray",
+	"Content-Length",
+	"Date",
+	"ETag",
+	"Expires",
+	"Last-Modified",
+	"Server-Timing",
+	"Set-Cookie",
+	"X-Amzn-Requestid",
+	"X-Amzn-Trace-Id",
+	"X-Correlation-Id",
+	"X-Envoy-Upstream-Service-Time",
+	"X-Request-Id",
+	"X-Response-Time",
+	"X-Runtime",
+}
+
+// IgnoreRules leave noisy headers and body fields out of a response
+// comparison. Build them with ParseIgnoreRules.
+type IgnoreRules struct {
+	headers map[string]bool
+	paths   []ignorePath
+}
+
+// ignorePath is one body field rule. anyDepth rules match a key wherever it
+// appears; the others match a path from the root and everything below it.
+type ignorePath struct {
+	tokens   []string
+	anyDepth bool
+}
+
+// ignoreToken matches the keys and [n] / [*] segments of a path
+var ignoreToken = regexp.MustCompile(`[^.\[\]]+|\[[^\]]*\]`)
+
+// ParseIgnoreRules reads rules such as:
+//
+//	$.data[*].updated_at   a JSON path; [*] matches any index, * any key
+//	data.id                the same without the leading $
+//	$..request_id          a key at any depth
+//	header:Date            a header
+//	updated_at             a bare name: a key at any depth and a header
+//
+// A path rule also covers everything below the field. Path rules without
+// indexes match whatever the indexes in the response are.
+func ParseIgnoreRules(specs []string) (IgnoreRules, error) {
+	rules := IgnoreRules{headers: make(map[string]bool)}
+
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		if name, ok := cutPrefixFold(spec, "header:"); ok {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				return IgnoreRules{}, fmt.Errorf("invalid ignore rule %q: missing header name", spec)
+			}
+			rules.headers[http.CanonicalHeaderKey(name)] = true
+			continue
+		}
+
+		if key, ok := strings.CutPrefix(spec, "$.."); ok {
+			if key == "" || strings.ContainsAny(key, ".[]") {
+				return IgnoreRules{}, fmt.Errorf("invalid ignore rule %q: expected a single key after $..", spec)
+			}
+			rules.paths = append(rules.paths, ignorePath{tokens: []string{key}, anyDepth: true})
+			continue
+		}
+
+		if !strings.ContainsAny(spec, "$.[]") {
+			rules.headers[http.CanonicalHeaderKey(spec)] = true
+			rules.paths = append(rules.paths, ignorePath{tokens: []string{spec}, anyDepth: true})
+			continue
+		}
+
+		tokens, err := parseIgnorePath(spec)
+		if err != nil {
+			return IgnoreRules{}, err
+		}
+		rules.paths = append(rules.paths, ignorePath{tokens: tokens})
+	}
+
+	return rules, nil
+}
+
+func parseIgnorePath(spec string) ([]string, error) {
+	path := strings.TrimPrefix(strings.TrimPrefix(spec, "$"), ".")
+	tokens := ignoreToken.FindAllString(path, -1)
+	if len(tokens) == 0 || strings.Join(tokens, "") != strings.ReplaceAll(path, ".", "") {
+		return nil, fmt.Errorf("invalid ignore rule %q", spec)
+	}
+
+	for _, token := range tokens {
+		if !strings.HasPrefix(token, "[") {
+			continue
+		}
+		index := token[1 : len(token)-1]
+		if index == "" || (index != "*" && strings.Trim(index, "0123456789") != "") {
+			return nil, fmt.Errorf("invalid ignore rule %q: index %s must be a number or *", spec, token)
+		}
+	}
+	return tokens, nil
+}
+
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		return s[len(prefix):], true
+	}
+	return s, false
+}
+
+// Merge returns the rules of both sets
+func (r IgnoreRules) Merge(other IgnoreRules) IgnoreRules {
+	merged := IgnoreRules{headers: make(map[string]bool, len(r.headers)+len(other.headers))}
+	for name := range r.headers {
+		merged.headers[name] = true
+	}
+	for name := range other.headers {
+		merged.headers[name] = true
+	}
+	merged.paths = append(append(merged.paths, r.paths...), other.paths...)
+	return merged
+}
+
+// Empty reports whether there are no rules
+func (r IgnoreRules) Empty() bool {
+	return len(r.headers) == 0 && len(r.paths) == 0
+}
+
+// IgnoresHeader reports whether the header is left out of comparisons
+func (r IgnoreRules) IgnoresHeader(name string) bool {
+	return r.headers[http.CanonicalHeaderKey(name)]
+}
+
+// IgnoresPath reports whether the body field at a diff path such as
+// "data.items[0].updated_at" is left out of comparisons
+func (r IgnoreRules) IgnoresPath(path string) bool {
+	tokens := ignoreToken.FindAllString(path, -1)
+	for _, rule := range r.paths {
+		if rule.matches(tokens) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p ignorePath) matches(path []string) bool {
+	if p.anyDepth {
+		for _, token := range path {
+			if token == p.tokens[0] {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !hasIndex(p.tokens) {
+		path = withoutIndexes(path)
+	}
+	if len(path) < len(p.tokens) {
+		return false
+	}
+	for i, token := range p.tokens {
+		switch {
+		case token == "[*]":
+			if !strings.HasPrefix(path[i], "[") {
+				return false
+			}
+		case token == "*":
+			if strings.HasPrefix(path[i], "[") {
+				return false
+			}
+		case token != path[i]:
+			return false
+		}
+	}
+	return true
+}
+
+func hasIndex(tokens []string) bool {
+	for _, token := range tokens {
+		if strings.HasPrefix(token, "[") {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutIndexes(tokens []string) []string {
+	keys := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if !strings.HasPrefix(token, "[") {
+			keys = append(keys, token)
+		}
+	}
+	return keys
+}
+
+// apply drops the differences the rules ignore from a diff
+func (r IgnoreRules) apply(diff *DiffResult) {
+	for header := range diff.HeadersDiff {
+		if r.IgnoresHeader(header) {
+			delete(diff.HeadersDiff, header)
+		}
+	}
+
+	if diff.BodyDiff == nil || diff.BodyDiff.Type != "json" || len(r.paths) == 0 {
+		return
+	}
+	var kept []Change
+	for _, change := range diff.BodyDiff.Changes {
+		if !r.IgnoresPath(change.Path) {
+			kept = append(kept, change)
+		}
+	}
+	if ignored := len(diff.BodyDiff.Changes) - len(kept); ignored > 0 {
+		diff.BodyDiff = jsonBodyDiff(kept)
+		diff.BodyDiff.Summary += fmt.Sprintf(", %d ignored", ignored)
+	}
+}
+
+// volatileRules ignores the VolatileHeaders
+func volatileRules() IgnoreRules {
+	rules := IgnoreRules{headers: make(map[string]bool, len(VolatileHeaders))}
+	for _, name := range VolatileHeaders {
+		rules.headers[http.CanonicalHeaderKey(name)] = true
+	}
+	return rules
+}
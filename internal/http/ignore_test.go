@@ -0,0 +1,95 @@
+package http
+
+import "testing"
+
+func mustIgnoreRules(t *testing.T, specs ...string) IgnoreRules {
+	t.Helper()
+	rules, err := ParseIgnoreRules(specs)
+	if err != nil {
+		t.Fatalf("ParseIgnoreRules(%q) error = %v", specs, err)
+	}
+	return rules
+}
+
+func TestIgnoreRulesPaths(t *testing.T) {
+	tests := []struct {
+		rule string
+		path string
+		want bool
+	}{
+		{"$.data[*].updated_at", "data[3].updated_at", true},
+		{"$.data[*].updated_at", "data[3].created_at", false},
+		{"$.data[*].updated_at", "meta.updated_at", false},
+		{"$.data[0].id", "data[0].id", true},
+		{"$.data[0].id", "data[1].id", false},
+		{"data.items.updated_at", "data.items[2].updated_at", true},
+		{"$.meta", "meta.request.id", true},
+		{"$.meta", "metadata", false},
+		{"$.*.etag", "users.etag", true},
+		{"$[*].id", "[0].id", true},
+		{"$..request_id", "a.b[0].request_id", true},
+		{"updated_at", "items[0].updated_at", true},
+		{"updated_at", "items[0].updated", false},
+	}
+
+	for _, tt := range tests {
+		rules := mustIgnoreRules(t, tt.rule)
+		if got := rules.IgnoresPath(tt.path); got != tt.want {
+			t.Errorf("rule %q on path %q = %v, want %v", tt.rule, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestIgnoreRulesHeaders(t *testing.T) {
+	rules := mustIgnoreRules(t, "header:date", "x-trace-id", "$.data.id")
+
+	if !rules.IgnoresHeader("Date") || !rules.IgnoresHeader("X-Trace-Id") {
+		t.Error("Expected header rules and bare names to match headers in any case")
+	}
+	if rules.IgnoresHeader("Data") {
+		t.Error("Expected path rules not to match headers")
+	}
+	if rules.IgnoresPath("date") {
+		t.Error("Expected header: rules not to match body fields")
+	}
+}
+
+func TestParseIgnoreRulesRejectsBadRules(t *testing.T) {
+	for _, spec := range []string{"$.data[x].id", "$.data[0", "header:", "$..a.b", "$.data[]"} {
+		if _, err := ParseIgnoreRules([]string{spec}); err == nil {
+			t.Errorf("ParseIgnoreRules(%q) expected an error", spec)
+		}
+	}
+}
+
+func TestCompareResponsesHonorsIgnoreRules(t *testing.T) {
+	old := Response{
+		StatusCode: 200,
+		Headers:    map[string][]string{"Date": {"Mon"}, "X-Version": {"1"}},
+		Body:       `{"data": [{"id": 1, "updated_at": "a"}, {"id": 2, "updated_at": "b"}]}`,
+	}
+	new := Response{
+		StatusCode: 200,
+		Headers:    map[string][]string{"Date": {"Tue"}, "X-Version": {"2"}},
+		Body:       `{"data": [{"id": 1, "updated_at": "c"}, {"id": 3, "updated_at": "d"}]}`,
+	}
+
+	diff := CompareResponses(old, new, mustIgnoreRules(t, "$.data[*].updated_at", "header:Date"))
+
+	if _, ok := diff.HeadersDiff["Date"]; ok {
+		t.Error("Expected the Date header to be ignored")
+	}
+	if _, ok := diff.HeadersDiff["X-Version"]; !ok {
+		t.Error("Expected other headers to still be compared")
+	}
+	if len(diff.BodyDiff.Changes) != 1 || diff.BodyDiff.Changes[0].Path != "data[1].id" {
+		t.Errorf("Expected only data[1].id to differ, got %+v", diff.BodyDiff.Changes)
+	}
+	if diff.BodyDiff.Summary != "1 modified, 0 added, 0 removed, 2 ignored" {
+		t.Errorf("Summary = %q", diff.BodyDiff.Summary)
+	}
+
+	if diff := CompareResponses(old, new); len(diff.BodyDiff.Changes) != 3 {
+		t.Errorf("Expected every change without rules, got %+v", diff.BodyDiff.Changes)
+	}
+}
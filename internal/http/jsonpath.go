@@ -0,0 +1,374 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// evalJSONPath evaluates a JSONPath expression against input. Supported
+// syntax:
+//
+//	$.store.book[0]     child and index access, negative indexes from the end
+//	$['odd key']        quoted names
+//	$.items[*], $.a.*   every element or value
+//	$.items[1:3]        slices
+//	$..id               recursive descent
+//	$.items[?(@.price < 10)]  filters comparing with a literal, or [?(@.tag)]
+//
+// Unlike jq paths, a name or index that does not match yields nothing.
+func evalJSONPath(expr string, input interface{}) ([]interface{}, error) {
+	if !strings.HasPrefix(expr, "$") {
+		return nil, fmt.Errorf("JSONPath must start with $: %s", expr)
+	}
+	current := []interface{}{input}
+	rest := expr[1:]
+
+	for rest != "" {
+		if strings.HasPrefix(rest, "..") {
+			rest = rest[2:]
+			current = descendants(current)
+			if rest == "" || rest[0] == '[' {
+				continue
+			}
+		} else if rest[0] == '.' {
+			rest = rest[1:]
+		}
+
+		var step func(interface{}) []interface{}
+		switch {
+		case rest == "":
+			return nil, fmt.Errorf("path ends with a dot: %s", expr)
+
+		case rest[0] == '[':
+			end := closingBracket(rest)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated bracket in %s", expr)
+			}
+			var err error
+			if step, err = bracketStep(strings.TrimSpace(rest[1:end])); err != nil {
+				return nil, err
+			}
+			rest = rest[end+1:]
+
+		case rest[0] == '*':
+			step = children
+			rest = rest[1:]
+
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			step = childNamed(rest[:end])
+			rest = rest[end:]
+		}
+
+		next := []interface{}{}
+		for _, value := range current {
+			next = append(next, step(value)...)
+		}
+		current = next
+	}
+
+	return current, nil
+}
+
+// bracketStep parses what is inside [ ]: a filter, a wildcard, a quoted
+// name, a slice or an index
+func bracketStep(inner string) (func(interface{}) []interface{}, error) {
+	switch {
+	case strings.HasPrefix(inner, "?"):
+		cond := strings.TrimSpace(inner[1:])
+		if !strings.HasPrefix(cond, "(") || !strings.HasSuffix(cond, ")") {
+			return nil, fmt.Errorf("filter must be written [?(...)]: [%s]", inner)
+		}
+		filter, err := parseFilter(strings.TrimSpace(cond[1 : len(cond)-1]))
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(filter.path, "@") {
+			return nil, fmt.Errorf("filter must test @, the current item: %s", filter.path)
+		}
+		path := "$" + filter.path[1:]
+		if _, err := evalJSONPath(path, nil); err != nil {
+			return nil, err
+		}
+		return func(value interface{}) []interface{} {
+			var kept []interface{}
+			for _, child := range children(value) {
+				if values, _ := evalJSONPath(path, child); filter.holds(values) {
+					kept = append(kept, child)
+				}
+			}
+			return kept
+		}, nil
+
+	case inner == "*":
+		return children, nil
+
+	case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+		return childNamed(inner[1 : len(inner)-1]), nil
+
+	case strings.Contains(inner, ":"):
+		from, to, _ := strings.Cut(inner, ":")
+		start, errStart := optionalInt(from)
+		end, errEnd := optionalInt(to)
+		if errStart != nil || errEnd != nil {
+			return nil, fmt.Errorf("invalid slice [%s]", inner)
+		}
+		return func(value interface{}) []interface{} {
+			arr, ok := value.([]interface{})
+			if !ok {
+				return nil
+			}
+			lo, hi := sliceBound(start, 0, len(arr)), sliceBound(end, len(arr), len(arr))
+			if lo >= hi {
+				return nil
+			}
+			return arr[lo:hi]
+		}, nil
+
+	default:
+		index, err := strconv.Atoi(inner)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index [%s]", inner)
+		}
+		return func(value interface{}) []interface{} {
+			arr, ok := value.([]interface{})
+			if !ok {
+				return nil
+			}
+			i := index
+			if i < 0 {
+				i += len(arr)
+			}
+			if i < 0 || i >= len(arr) {
+				return nil
+			}
+			return []interface{}{arr[i]}
+		}, nil
+	}
+}
+
+// optionalInt parses a slice bound, nil when it is left out
+func optionalInt(s string) (*int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(s)
+	return &n, err
+}
+
+// sliceBound resolves a slice bound against an array of length n,
+// counting negative bounds from the end
+func sliceBound(bound *int, fallback, n int) int {
+	if bound == nil {
+		return fallback
+	}
+	i := *bound
+	if i < 0 {
+		i += n
+	}
+	return max(0, min(i, n))
+}
+
+// closingBracket returns the index of the ] that closes the [ s starts
+// with, skipping brackets inside quotes and parentheses
+func closingBracket(s string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[' || c == '(':
+			depth++
+		case c == ']' || c == ')':
+			depth--
+			if depth == 0 && c == ']' {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// childNamed returns the step to the value of key in an object
+func childNamed(key string) func(interface{}) []interface{} {
+	return func(value interface{}) []interface{} {
+		if obj, ok := value.(map[string]interface{}); ok {
+			if child, ok := obj[key]; ok {
+				return []interface{}{child}
+			}
+		}
+		return nil
+	}
+}
+
+// children returns the elements of an array or the values of an object,
+// in key order
+func children(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		out := make([]interface{}, 0, len(v))
+		for _, key := range sortedKeys(v) {
+			out = append(out, v[key])
+		}
+		return out
+	}
+	return nil
+}
+
+// descendants returns every value and all the values nested in it, each
+// before its children
+func descendants(values []interface{}) []interface{} {
+	var out []interface{}
+	var walk func(interface{})
+	walk = func(value interface{}) {
+		out = append(out, value)
+		for _, child := range children(value) {
+			walk(child)
+		}
+	}
+	for _, value := range values {
+		walk(value)
+	}
+	return out
+}
+
+// filterOperators are tried longest first, so <= is not read as <
+var filterOperators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// filterCondition is a test on a path of an item: a comparison with a
+// literal, or without op whether the path yields a value other than null
+// or false
+type filterCondition struct {
+	path string
+	op   string
+	want interface{}
+}
+
+// parseFilter parses a condition like "@.price < 10" or `.status ==
+// "failed"`
+func parseFilter(cond string) (filterCondition, error) {
+	if cond == "" {
+		return filterCondition{}, fmt.Errorf("empty filter")
+	}
+	for _, op := range filterOperators {
+		i := indexOutsideQuotes(cond, op)
+		if i < 0 {
+			continue
+		}
+		want, err := parseFilterLiteral(strings.TrimSpace(cond[i+len(op):]))
+		if err != nil {
+			return filterCondition{}, err
+		}
+		return filterCondition{path: strings.TrimSpace(cond[:i]), op: op, want: want}, nil
+	}
+	return filterCondition{path: cond}, nil
+}
+
+// holds tells whether the condition is true of the values its path
+// yielded on an item; only the first one counts
+func (f filterCondition) holds(values []interface{}) bool {
+	if len(values) == 0 {
+		return false
+	}
+	if f.op == "" {
+		return values[0] != nil && values[0] != false
+	}
+	return compareFilterValue(values[0], f.op, f.want)
+}
+
+// parseFilterLiteral reads the right side of a comparison: a number, a
+// string in single or double quotes, true, false or null
+func parseFilterLiteral(literal string) (interface{}, error) {
+	if len(literal) >= 2 && literal[0] == '\'' && literal[len(literal)-1] == '\'' {
+		return literal[1 : len(literal)-1], nil
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(literal), &value); err != nil {
+		return nil, fmt.Errorf("cannot compare with %s: use a number, a quoted string, true, false or null", literal)
+	}
+	if _, ok := value.([]interface{}); ok {
+		return nil, fmt.Errorf("cannot compare with an array: %s", literal)
+	}
+	if _, ok := value.(map[string]interface{}); ok {
+		return nil, fmt.Errorf("cannot compare with an object: %s", literal)
+	}
+	return value, nil
+}
+
+// compareFilterValue compares got with want. Numbers and strings are ordered,
+// anything else is only equal or not.
+func compareFilterValue(got interface{}, op string, want interface{}) bool {
+	switch op {
+	case "==":
+		return got == want
+	case "!=":
+		return got != want
+	}
+
+	var cmp int
+	switch g := got.(type) {
+	case float64:
+		w, ok := want.(float64)
+		if !ok {
+			return false
+		}
+		switch {
+		case g < w:
+			cmp = -1
+		case g > w:
+			cmp = 1
+		}
+	case string:
+		w, ok := want.(string)
+		if !ok {
+			return false
+		}
+		cmp = strings.Compare(g, w)
+	default:
+		return false
+	}
+
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+// indexOutsideQuotes returns where sub first appears in s outside quotes
+func indexOutsideQuotes(s, sub string) int {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case strings.HasPrefix(s[i:], sub):
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const jsonPathTestBody = `{
+	"store": {
+		"name": "corner",
+		"book": [
+			{"title": "Go", "price": 8.5, "tags": ["dev"]},
+			{"title": "SQL", "price": 12, "status": "failed"},
+			{"title": "TUI", "price": 22, "status": "ok", "tags": []}
+		],
+		"owner": {"id": 7, "contact": {"id": 9}}
+	}
+}`
+
+func TestEvalTransformJSONPath(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want string
+	}{
+		{"root", "$.store.name", `["corner"]`},
+		{"index", "$.store.book[1].title", `["SQL"]`},
+		{"negative index", "$.store.book[-1].title", `["TUI"]`},
+		{"quoted name", "$['store']['owner'].id", `[7]`},
+		{"wildcard", "$.store.book[*].price", `[8.5,12,22]`},
+		{"object wildcard", "$.store.owner.*", `[{"id":9},7]`},
+		{"slice", "$.store.book[0:2].title", `["Go","SQL"]`},
+		{"open slice", "$.store.book[-2:].title", `["SQL","TUI"]`},
+		{"recursive descent", "$..id", `[7,9]`},
+		{"filter comparison", "$.store.book[?(@.price < 20)].title", `["Go","SQL"]`},
+		{"filter string", "$.store.book[?(@.status == 'failed')].title", `["SQL"]`},
+		{"filter existence", "$.store.book[?(@.tags)].title", `["Go","TUI"]`},
+		{"missing name yields nothing", "$.store.missing", `[]`},
+		{"then jq", "$.store.book[*] | .title", `["Go","SQL","TUI"]`},
+		{"jq select", `.store.book[] | select(.status == "failed") | .title`, `["SQL"]`},
+		{"jq select number", ".store.book[] | select(.price >= 12) | .title", `["SQL","TUI"]`},
+		{"jq recursive", `.. | select(.id == 9) | .id`, `[9]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := EvalTransform(jsonPathTestBody, tt.expr)
+			if err != nil {
+				t.Fatalf("EvalTransform(%q) error = %v", tt.expr, err)
+			}
+			if results == nil {
+				results = []interface{}{}
+			}
+			got, _ := json.Marshal(results)
+			if string(got) != tt.want {
+				t.Errorf("EvalTransform(%q) = %s, want %s", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvalTransformJSONPathErrors(t *testing.T) {
+	for _, expr := range []string{
+		"$.store.",
+		"$.store.book[",
+		"$.store.book[x]",
+		"$.store.book[?(.price < 1)]",
+		"$.store.book[?(@.price < cheap)]",
+		"select(status == 1)",
+	} {
+		if _, err := EvalTransform(jsonPathTestBody, expr); err == nil {
+			t.Errorf("EvalTransform(%q) returned no error", expr)
+		}
+	}
+}
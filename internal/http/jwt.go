@@ -0,0 +1,123 @@
+package http
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// JWT is a decoded JSON Web Token. The signature is not verified: the key
+// is rarely at hand while debugging, and the point is to read the claims.
+type JWT struct {
+	Raw    string
+	Header map[string]any
+	Claims map[string]any
+	// Alg is the signing algorithm named in the header
+	Alg string
+	// SignatureSize is the length of the decoded signature in bytes
+	SignatureSize int
+}
+
+// jwtPattern matches the three base64url parts of a compact JWT. Both the
+// header and the payload are JSON objects, so they start with "eyJ", the
+// encoding of `{"`.
+var jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]*\.eyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]*`)
+
+// FindJWTs returns the tokens in text that decode, in the order they
+// appear and without repeats
+func FindJWTs(text string) []JWT {
+	var tokens []JWT
+	seen := make(map[string]bool)
+	for _, raw := range jwtPattern.FindAllString(text, -1) {
+		if seen[raw] {
+			continue
+		}
+		seen[raw] = true
+		if token, err := ParseJWT(raw); err == nil {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// ParseJWT decodes the header and claims of a compact JWT
+func ParseJWT(raw string) (JWT, error) {
+	raw = strings.TrimSpace(raw)
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return JWT{}, fmt.Errorf("a JWT has 3 dot-separated parts, got %d", len(parts))
+	}
+
+	token := JWT{Raw: raw}
+	if err := decodeJWTPart(parts[0], &token.Header); err != nil {
+		return JWT{}, fmt.Errorf("invalid JWT header: %w", err)
+	}
+	if err := decodeJWTPart(parts[1], &token.Claims); err != nil {
+		return JWT{}, fmt.Errorf("invalid JWT payload: %w", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return JWT{}, fmt.Errorf("invalid JWT signature: %w", err)
+	}
+	token.SignatureSize = len(signature)
+	token.Alg, _ = token.Header["alg"].(string)
+	return token, nil
+}
+
+// decodeJWTPart decodes one base64url part holding a JSON object. Numbers
+// are kept as written, so large ids are not rounded.
+func decodeJWTPart(part string, v *map[string]any) error {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(part, "="))
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// Time returns a NumericDate claim such as exp, iat or nbf
+func (t JWT) Time(claim string) (time.Time, bool) {
+	number, ok := t.Claims[claim].(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	seconds, err := number.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, int64(seconds*float64(time.Second))), true
+}
+
+// Expired reports whether the token has an exp claim at or before now
+func (t JWT) Expired(now time.Time) bool {
+	exp, ok := t.Time("exp")
+	return ok && !exp.After(now)
+}
+
+// Unsigned reports whether the header says the token carries no signature
+func (t JWT) Unsigned() bool {
+	return strings.EqualFold(t.Alg, "none")
+}
+
+// HeaderJSON returns the header as indented JSON
+func (t JWT) HeaderJSON() string {
+	return indentJWTPart(t.Header)
+}
+
+// ClaimsJSON returns the claims as indented JSON
+func (t JWT) ClaimsJSON() string {
+	return indentJWTPart(t.Claims)
+}
+
+func indentJWTPart(v map[string]any) string {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
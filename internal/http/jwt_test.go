@@ -0,0 +1,66 @@
+package http
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+// makeJWT encodes a token with the given header and payload JSON
+func makeJWT(header, payload string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(header)) + "." + enc.EncodeToString([]byte(payload)) + "." + enc.EncodeToString([]byte("signature"))
+}
+
+func TestParseJWT(t *testing.T) {
+	raw := makeJWT(`{"alg":"HS256","typ":"JWT"}`, `{"sub":"1234567890123456789","exp":1700000000,"iat":1699996400.5}`)
+
+	token, err := ParseJWT(raw)
+	if err != nil {
+		t.Fatalf("ParseJWT() error = %v", err)
+	}
+	if token.Alg != "HS256" || token.SignatureSize != len("signature") {
+		t.Errorf("Unexpected token %+v", token)
+	}
+	if !strings.Contains(token.ClaimsJSON(), `"sub": "1234567890123456789"`) || !strings.Contains(token.HeaderJSON(), `"typ": "JWT"`) {
+		t.Errorf("Unexpected JSON:\n%s\n%s", token.HeaderJSON(), token.ClaimsJSON())
+	}
+
+	exp, ok := token.Time("exp")
+	if !ok || !exp.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Time(exp) = %v, %v", exp, ok)
+	}
+	if iat, _ := token.Time("iat"); !iat.Equal(time.Unix(1699996400, int64(500*time.Millisecond))) {
+		t.Errorf("Time(iat) = %v", iat)
+	}
+	if _, ok := token.Time("nbf"); ok {
+		t.Error("Expected no nbf claim")
+	}
+	if !token.Expired(time.Unix(1700000000, 0)) || token.Expired(time.Unix(1699999999, 0)) {
+		t.Error("Expected the token to expire at exp")
+	}
+
+	for _, invalid := range []string{"abc", "a.b", "eyJ!.eyJ.x", makeJWT(`{"alg":"HS256"}`, `[1,2]`)} {
+		if _, err := ParseJWT(invalid); err == nil {
+			t.Errorf("ParseJWT(%q) expected an error", invalid)
+		}
+	}
+}
+
+func TestFindJWTs(t *testing.T) {
+	first := makeJWT(`{"alg":"RS256"}`, `{"sub":"a"}`)
+	second := makeJWT(`{"alg":"none"}`, `{"sub":"b"}`)
+	text := `{"access_token":"` + first + `","id_token":"` + second + `","again":"` + first + `"}`
+
+	tokens := FindJWTs(text)
+	if len(tokens) != 2 || tokens[0].Raw != first || tokens[1].Raw != second {
+		t.Fatalf("FindJWTs() = %+v", tokens)
+	}
+	if tokens[0].Unsigned() || !tokens[1].Unsigned() {
+		t.Error("Expected only the alg none token to be unsigned")
+	}
+	if got := FindJWTs("Bearer eyJhbGciOi.eyJzdWIi.not-json"); len(got) != 0 {
+		t.Errorf("Expected tokens that do not decode to be skipped, got %+v", got)
+	}
+}
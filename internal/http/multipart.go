@@ -0,0 +1,198 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FormField is one field of a multipart/form-data body. A field with a File
+// is sent as the contents of that file.
+type FormField struct {
+	Name  string
+	Value string
+	File  string
+}
+
+// IsMultipart reports whether contentType asks for a multipart/form-data body
+func IsMultipart(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == ContentTypeMultipart
+}
+
+// ParseFormFields reads a multipart body written one field per line in the
+// syntax of curl -F: name=value, or name=@path to send a file. Blank lines
+// and lines starting with # are skipped.
+func ParseFormFields(body string) ([]FormField, error) {
+	var fields []FormField
+	for i, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("line %d: expected name=value or name=@file", i+1)
+		}
+		if path, ok := strings.CutPrefix(value, "@"); ok {
+			if path == "" {
+				return nil, fmt.Errorf("line %d: missing file path after @", i+1)
+			}
+			fields = append(fields, FormField{Name: name, File: ExpandHome(path)})
+			continue
+		}
+		fields = append(fields, FormField{Name: name, Value: value})
+	}
+	return fields, nil
+}
+
+func headerValue(headers map[string]string, name string) string {
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+// ExpandHome replaces a leading ~/ in path with the home directory
+func ExpandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, rest)
+}
+
+// multipartBody streams form fields as a multipart body. The length is
+// worked out up front from the sizes of the files, so the body is sent with
+// a Content-Length and its progress can be shown as a fraction.
+type multipartBody struct {
+	fields      []FormField
+	boundary    string
+	contentType string
+	length      int64
+}
+
+func newMultipartBody(fields []FormField) (*multipartBody, error) {
+	sizes := make([]int64, len(fields))
+	for i, field := range fields {
+		if field.File == "" {
+			sizes[i] = int64(len(field.Value))
+			continue
+		}
+		info, err := os.Stat(field.File)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if info.IsDir() {
+			return nil, fmt.Errorf("field %s: %s is a directory", field.Name, field.File)
+		}
+		sizes[i] = info.Size()
+	}
+
+	// Lay the body out without the contents to count the framing
+	counter := &countingWriter{}
+	w := multipart.NewWriter(counter)
+	for i, field := range fields {
+		if _, err := createPart(w, field); err != nil {
+			return nil, err
+		}
+		counter.n += sizes[i]
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return &multipartBody{
+		fields:      fields,
+		boundary:    w.Boundary(),
+		contentType: w.FormDataContentType(),
+		length:      counter.n,
+	}, nil
+}
+
+func createPart(w *multipart.Writer, field FormField) (io.Writer, error) {
+	if field.File != "" {
+		return w.CreateFormFile(field.Name, filepath.Base(field.File))
+	}
+	return w.CreateFormField(field.Name)
+}
+
+// reader returns the body as a stream. Files are read while the body is
+// sent; closing the reader stops the writing.
+func (b *multipartBody) reader() io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(b.write(pw))
+	}()
+	return pr
+}
+
+func (b *multipartBody) write(dst io.Writer) error {
+	w := multipart.NewWriter(dst)
+	if err := w.SetBoundary(b.boundary); err != nil {
+		return err
+	}
+	for _, field := range b.fields {
+		part, err := createPart(w, field)
+		if err != nil {
+			return err
+		}
+		if field.File == "" {
+			if _, err := io.WriteString(part, field.Value); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := copyFile(part, field.File); err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	}
+	return w.Close()
+}
+
+func copyFile(dst io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(dst, f)
+	return err
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// progressReader reports the bytes read from a body as they go out or
+// come in
+type progressReader struct {
+	io.ReadCloser
+	sent, total int64
+	progress    func(sent, total int64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.sent += int64(n)
+		r.progress(r.sent, r.total)
+	}
+	return n, err
+}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFormFields(t *testing.T) {
+	fields, err := ParseFormFields("# the upload\ntitle=Q3 = final\n\nfile=@/tmp/report.pdf\n")
+	if err != nil {
+		t.Fatalf("ParseFormFields() error = %v", err)
+	}
+	want := []FormField{{Name: "title", Value: "Q3 = final"}, {Name: "file", File: "/tmp/report.pdf"}}
+	if len(fields) != len(want) || fields[0] != want[0] || fields[1] != want[1] {
+		t.Errorf("ParseFormFields() = %+v, want %+v", fields, want)
+	}
+
+	for _, body := range []string{"no separator", "=value", "file=@"} {
+		if _, err := ParseFormFields(body); err == nil {
+			t.Errorf("ParseFormFields(%q) expected an error", body)
+		}
+	}
+}
+
+func TestIsMultipart(t *testing.T) {
+	if !IsMultipart("multipart/form-data") || !IsMultipart("Multipart/Form-Data; boundary=x") || IsMultipart("application/json") {
+		t.Error("IsMultipart() misclassified a content type")
+	}
+}
+
+func TestSendStreamsMultipartBody(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.bin")
+	content := strings.Repeat("0123456789", 100000)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var gotLength int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotLength = r.ContentLength
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(file)
+		if string(data) != content || header.Filename != "data.bin" || r.FormValue("title") != "Report" {
+			http.Error(w, "unexpected form", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	var sent, total int64
+	resp := NewClient(5*time.Second).SendWithProgress(context.Background(), Request{
+		Method:  "POST",
+		URL:     server.URL,
+		Headers: map[string]string{"content-type": "multipart/form-data"},
+		Body:    "title=Report\nfile=@" + path,
+	}, func(s, t int64) { sent, total = s, t })
+
+	if resp.Error != nil || resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Expected the form to be accepted, got %d %v: %s", resp.StatusCode, resp.Error, resp.Body)
+	}
+	if total != gotLength || sent != total || total <= int64(len(content)) {
+		t.Errorf("Expected progress to reach the Content-Length %d, got %d/%d", gotLength, sent, total)
+	}
+}
+
+func TestSendMultipartCanBeCanceled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "big.bin")
+	if err := os.WriteFile(path, make([]byte, 8<<20), 0600); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	resp := NewClient(5*time.Second).SendWithProgress(ctx, Request{
+		Method:  "POST",
+		URL:     server.URL,
+		Headers: map[string]string{"Content-Type": "multipart/form-data"},
+		Body:    "file=@" + path,
+	}, func(sent, total int64) {
+		if sent > 0 {
+			cancel()
+		}
+	})
+	if resp.Error == nil || !strings.Contains(resp.Error.Error(), "canceled") {
+		t.Errorf("Expected the upload to be canceled, got %v", resp.Error)
+	}
+}
+
+func TestSendMultipartMissingFile(t *testing.T) {
+	resp := NewClient(5 * time.Second).Send(Request{
+		Method:  "POST",
+		URL:     "http://127.0.0.1:1/upload",
+		Headers: map[string]string{"Content-Type": "multipart/form-data"},
+		Body:    "file=@" + filepath.Join(t.TempDir(), "missing.bin"),
+	})
+	if resp.Error == nil || !strings.Contains(resp.Error.Error(), "invalid multipart body") {
+		t.Errorf("Expected a missing file to be reported before sending, got %v", resp.Error)
+	}
+}
+
+func TestRequestToCurlMultipart(t *testing.T) {
+	got := RequestToCurl(Request{
+		Method:  "POST",
+		URL:     "https://api.example.com/upload",
+		Headers: map[string]string{"Content-Type": "multipart/form-data"},
+		Body:    "title=Report\nfile=@report.pdf",
+	})
+	if strings.Count(got, "-F") != 2 || !strings.Contains(got, "'file=@report.pdf'") || strings.Contains(got, "Content-Type") {
+		t.Errorf("RequestToCurl() = %s", got)
+	}
+
+	// The command imports back to the same form
+	parsed, err := ParseCurl(got)
+	if err != nil || parsed.Body != "title=Report\nfile=@report.pdf" || !IsMultipart(parsed.Headers["Content-Type"]) {
+		t.Errorf("ParseCurl() = %+v, %v", parsed, err)
+	}
+}
@@ -0,0 +1,411 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abneribeiro/godev/internal/paths"
+)
+
+// Pagination modes
+const (
+	PaginateLink   = "link"
+	PaginateCursor = "cursor"
+	PaginatePage   = "page"
+)
+
+const (
+	defaultMaxPages = 10
+	maxPagesLimit   = 100
+)
+
+// PaginationRule describes how to find the next page of a response
+type PaginationRule struct {
+	Mode string
+	// CursorPath is the jq-style path of the next cursor in the body (cursor mode)
+	CursorPath string
+	// Param is the query parameter carrying the cursor or page number
+	Param string
+	// StartPage is the number of the first page (page mode)
+	StartPage int
+	// ItemsPath is the jq-style path of the items array; empty detects it
+	ItemsPath string
+	// MaxPages limits how many pages are fetched
+	MaxPages int
+}
+
+// ParsePaginationRule reads a rule written as space separated settings:
+//
+//	link                              follow Link: <...>; rel="next"
+//	cursor=.meta.next param=after     send the body cursor as ?after=
+//	page=page start=1                 increment ?page= until a page is empty
+//
+// Every mode also accepts items=<path> and max=<pages>.
+func ParsePaginationRule(spec string) (PaginationRule, error) {
+	rule := PaginationRule{MaxPages: defaultMaxPages, StartPage: 1}
+
+	for _, token := range strings.Fields(spec) {
+		key, value, hasValue := strings.Cut(token, "=")
+
+		switch key {
+		case PaginateLink:
+			rule.Mode = PaginateLink
+		case PaginateCursor:
+			rule.Mode = PaginateCursor
+			rule.CursorPath = normalizePath(value)
+		case PaginatePage:
+			rule.Mode = PaginatePage
+			rule.Param = value
+		case "param":
+			rule.Param = value
+		case "items":
+			rule.ItemsPath = normalizePath(value)
+		case "start":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return rule, fmt.Errorf("invalid start page %q", value)
+			}
+			rule.StartPage = n
+		case "max":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return rule, fmt.Errorf("invalid page limit %q", value)
+			}
+			if n > maxPagesLimit {
+				n = maxPagesLimit
+			}
+			rule.MaxPages = n
+		default:
+			return rule, fmt.Errorf("unknown pagination setting %q", token)
+		}
+
+		if hasValue && value == "" {
+			return rule, fmt.Errorf("missing value for %s", key)
+		}
+	}
+
+	switch rule.Mode {
+	case "":
+		return rule, fmt.Errorf("pagination rule needs one of link, cursor=<path> or page=<param>")
+	case PaginateCursor:
+		if rule.CursorPath == "" {
+			return rule, fmt.Errorf("cursor mode needs the cursor path, e.g. cursor=.meta.next")
+		}
+		if rule.Param == "" {
+			rule.Param = "cursor"
+		}
+	case PaginatePage:
+		if rule.Param == "" {
+			rule.Param = "page"
+		}
+	}
+
+	return rule, nil
+}
+
+// SuggestPaginationRule guesses a rule from the first response
+func SuggestPaginationRule(resp Response) string {
+	if nextLink(resp.Headers) != "" {
+		return PaginateLink
+	}
+
+	var data interface{}
+	if json.Unmarshal([]byte(resp.Body), &data) == nil {
+		for _, path := range []string{".next_cursor", ".meta.next_cursor", ".pagination.next_cursor", ".cursor.next", ".nextCursor", ".pageInfo.endCursor"} {
+			if values, err := transformPath(path, data); err == nil && len(values) == 1 && values[0] != nil {
+				return "cursor=" + path
+			}
+		}
+	}
+
+	return "page=page"
+}
+
+func normalizePath(path string) string {
+	if path == "" || strings.HasPrefix(path, ".") {
+		return path
+	}
+	return "." + path
+}
+
+// PageFetch is the outcome of fetching a single page
+type PageFetch struct {
+	URL          string
+	StatusCode   int
+	Status       string
+	Items        int
+	ResponseTime time.Duration
+	Error        error
+}
+
+// PaginationResult holds every page fetched and the merged items
+type PaginationResult struct {
+	Rule  PaginationRule
+	Pages []PageFetch
+	Items []interface{}
+	// LimitReached is set when more pages were available than MaxPages
+	LimitReached bool
+	Err          error
+}
+
+// FollowPagination fetches pages starting at the request until the rule
+// finds no next page, a page fails or the page limit is reached
+func FollowPagination(client *Client, req Request, rule PaginationRule) *PaginationResult {
+	if rule.MaxPages < 1 {
+		rule.MaxPages = defaultMaxPages
+	}
+	result := &PaginationResult{Rule: rule}
+
+	pageURL := req.URL
+	page := rule.StartPage
+	if rule.Mode == PaginatePage {
+		var err error
+		if pageURL, err = setQueryParam(req.URL, rule.Param, strconv.Itoa(page)); err != nil {
+			result.Err = err
+			return result
+		}
+	}
+
+	seen := map[string]bool{}
+
+	for len(result.Pages) < rule.MaxPages {
+		pageReq := req
+		pageReq.URL = pageURL
+		seen[pageURL] = true
+
+		resp := client.Send(pageReq)
+		fetch := PageFetch{
+			URL:          pageURL,
+			StatusCode:   resp.StatusCode,
+			Status:       resp.Status,
+			ResponseTime: resp.ResponseTime,
+			Error:        resp.Error,
+		}
+
+		if resp.Error == nil && (resp.StatusCode < 200 || resp.StatusCode >= 300) {
+			fetch.Error = fmt.Errorf("unexpected status %s", resp.Status)
+		}
+		if fetch.Error != nil {
+			result.Pages = append(result.Pages, fetch)
+			result.Err = fmt.Errorf("page %d: %w", len(result.Pages), fetch.Error)
+			return result
+		}
+
+		var data interface{}
+		if err := json.Unmarshal([]byte(resp.Body), &data); err != nil {
+			result.Pages = append(result.Pages, fetch)
+			result.Err = fmt.Errorf("page %d is not valid JSON: %w", len(result.Pages), err)
+			return result
+		}
+
+		items, err := pageItems(data, rule.ItemsPath)
+		if err != nil {
+			result.Pages = append(result.Pages, fetch)
+			result.Err = fmt.Errorf("page %d: %w", len(result.Pages), err)
+			return result
+		}
+		fetch.Items = len(items)
+		result.Pages = append(result.Pages, fetch)
+		result.Items = append(result.Items, items...)
+
+		next := ""
+		switch rule.Mode {
+		case PaginateLink:
+			if link := nextLink(resp.Headers); link != "" {
+				next, err = resolveURL(pageURL, link)
+			}
+		case PaginateCursor:
+			if cursor := cursorValue(data, rule.CursorPath); cursor != "" {
+				next, err = setQueryParam(pageURL, rule.Param, cursor)
+			}
+		case PaginatePage:
+			if len(items) > 0 {
+				page++
+				next, err = setQueryParam(pageURL, rule.Param, strconv.Itoa(page))
+			}
+		}
+		if err != nil {
+			result.Err = err
+			return result
+		}
+
+		// A repeated URL would loop forever
+		if next == "" || seen[next] {
+			return result
+		}
+		pageURL = next
+	}
+
+	result.LimitReached = true
+	return result
+}
+
+// MergedJSON returns all items as one indented JSON array
+func (r *PaginationResult) MergedJSON() (string, error) {
+	items := r.Items
+	if items == nil {
+		items = []interface{}{}
+	}
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal merged pages: %w", err)
+	}
+	return string(data), nil
+}
+
+// Export writes the merged items to the exports directory and returns the file path
+func (r *PaginationResult) Export() (string, error) {
+	merged, err := r.MergedJSON()
+	if err != nil {
+		return "", err
+	}
+
+	exportDir, err := paths.ExportDir()
+	if err != nil {
+		return "", err
+	}
+
+	filePath := filepath.Join(exportDir, fmt.Sprintf("pages_%s.json", time.Now().Format("20060102_150405")))
+	if err := os.WriteFile(filePath, []byte(merged), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	return filePath, nil
+}
+
+// pageItems finds the items of a page, either at the given path or as the
+// body itself or its first array field
+func pageItems(data interface{}, path string) ([]interface{}, error) {
+	if path != "" {
+		values, err := transformPath(path, data)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != 1 {
+			return nil, fmt.Errorf("items path %s matched %d values", path, len(values))
+		}
+		switch v := values[0].(type) {
+		case []interface{}:
+			return v, nil
+		case nil:
+			return nil, nil
+		default:
+			return nil, fmt.Errorf("items path %s is %s, not an array", path, jsonTypeName(v))
+		}
+	}
+
+	switch v := data.(type) {
+	case []interface{}:
+		return v, nil
+	case map[string]interface{}:
+		for _, key := range []string{"data", "items", "results", "records"} {
+			if arr, ok := v[key].([]interface{}); ok {
+				return arr, nil
+			}
+		}
+		for _, key := range sortedKeys(v) {
+			if arr, ok := v[key].([]interface{}); ok {
+				return arr, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no items array found, set one with items=<path>")
+}
+
+func cursorValue(data interface{}, path string) string {
+	values, err := transformPath(path, data)
+	if err != nil || len(values) != 1 {
+		return ""
+	}
+
+	switch v := values[0].(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		if v {
+			return "true"
+		}
+	}
+	return ""
+}
+
+var linkNextPattern = regexp.MustCompile(`<([^>]*)>\s*;[^,]*rel="?([^",]*)"?`)
+
+// nextLink returns the rel="next" target of the Link headers
+func nextLink(headers map[string][]string) string {
+	for key, values := range headers {
+		if !strings.EqualFold(key, "Link") {
+			continue
+		}
+		for _, value := range values {
+			for _, match := range linkNextPattern.FindAllStringSubmatch(value, -1) {
+				for _, rel := range strings.Fields(match[2]) {
+					if rel == "next" {
+						return match[1]
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func resolveURL(base, ref string) (string, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid page URL: %w", err)
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return "", fmt.Errorf("invalid next link %q: %w", ref, err)
+	}
+	return baseURL.ResolveReference(refURL).String(), nil
+}
+
+func setQueryParam(rawURL, key, value string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid page URL: %w", err)
+	}
+	query := u.Query()
+	query.Set(key, value)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// FormatPaginationResult renders a line per page and a summary
+func FormatPaginationResult(r *PaginationResult) string {
+	var b strings.Builder
+
+	for i, page := range r.Pages {
+		if page.Error != nil {
+			b.WriteString(fmt.Sprintf("✗ %3d  %s\n      %v\n", i+1, page.URL, page.Error))
+			continue
+		}
+		b.WriteString(fmt.Sprintf("✓ %3d  %-4d %5d items  %8s  %s\n", i+1, page.StatusCode, page.Items,
+			FormatDuration(page.ResponseTime), page.URL))
+	}
+
+	b.WriteString(fmt.Sprintf("\n%d items from %d pages", len(r.Items), len(r.Pages)))
+	switch {
+	case r.Err != nil:
+		b.WriteString(fmt.Sprintf(" • stopped: %v", r.Err))
+	case r.LimitReached:
+		b.WriteString(fmt.Sprintf(" • stopped at the %d page limit, raise it with max=", r.Rule.MaxPages))
+	default:
+		b.WriteString(" • no more pages")
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
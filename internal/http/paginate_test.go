@@ -0,0 +1,136 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePaginationRule(t *testing.T) {
+	rule, err := ParsePaginationRule("cursor=meta.next param=after items=.data max=500")
+	if err != nil {
+		t.Fatalf("ParsePaginationRule() error = %v", err)
+	}
+	if rule.Mode != PaginateCursor || rule.CursorPath != ".meta.next" || rule.Param != "after" ||
+		rule.ItemsPath != ".data" || rule.MaxPages != maxPagesLimit {
+		t.Errorf("Unexpected rule: %+v", rule)
+	}
+
+	rule, err = ParsePaginationRule("page")
+	if err != nil || rule.Param != "page" || rule.StartPage != 1 || rule.MaxPages != defaultMaxPages {
+		t.Errorf("Unexpected page rule: %+v (%v)", rule, err)
+	}
+
+	for _, spec := range []string{"", "cursor", "max=0 link", "link what=1", "page=page start=x"} {
+		if _, err := ParsePaginationRule(spec); err == nil {
+			t.Errorf("Expected error for %q", spec)
+		}
+	}
+}
+
+func TestFollowPaginationLinkHeader(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("p"))
+		if page < 2 {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/items?p=%d>; rel="next", <%s/items?p=2>; rel="last"`, server.URL, page+1, server.URL))
+		}
+		fmt.Fprintf(w, `[{"page": %d}, {"page": %d}]`, page, page)
+	}))
+	defer server.Close()
+
+	rule, _ := ParsePaginationRule("link")
+	result := FollowPagination(NewClient(5*time.Second), Request{Method: "GET", URL: server.URL + "/items?p=0"}, rule)
+
+	if result.Err != nil {
+		t.Fatalf("FollowPagination() error = %v", result.Err)
+	}
+	if len(result.Pages) != 3 || len(result.Items) != 6 || result.LimitReached {
+		t.Errorf("Expected 3 pages and 6 items, got %d pages, %d items", len(result.Pages), len(result.Items))
+	}
+}
+
+func TestFollowPaginationCursor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next := map[string]interface{}{"": "c1", "c1": "c2", "c2": nil}[r.URL.Query().Get("after")]
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"results": []string{r.URL.Query().Get("after")},
+			"meta":    map[string]interface{}{"next": next},
+		})
+	}))
+	defer server.Close()
+
+	rule, _ := ParsePaginationRule("cursor=.meta.next param=after items=.results")
+	result := FollowPagination(NewClient(5*time.Second), Request{Method: "GET", URL: server.URL}, rule)
+
+	merged, err := result.MergedJSON()
+	if err != nil {
+		t.Fatalf("MergedJSON() error = %v", err)
+	}
+	if result.Err != nil || len(result.Pages) != 3 {
+		t.Fatalf("Expected 3 pages, got %d (%v)", len(result.Pages), result.Err)
+	}
+	if strings.Join(strings.Fields(merged), "") != `["","c1","c2"]` {
+		t.Errorf("Unexpected merged items: %s", merged)
+	}
+}
+
+func TestFollowPaginationPageLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": [1, 2]}`))
+	}))
+	defer server.Close()
+
+	rule, _ := ParsePaginationRule("page=page max=3")
+	result := FollowPagination(NewClient(5*time.Second), Request{Method: "GET", URL: server.URL + "/list?size=2"}, rule)
+
+	if !result.LimitReached || len(result.Pages) != 3 || len(result.Items) != 6 {
+		t.Errorf("Expected to stop at 3 pages, got %d pages (limit %v)", len(result.Pages), result.LimitReached)
+	}
+	if !strings.Contains(result.Pages[2].URL, "page=3") || !strings.Contains(result.Pages[2].URL, "size=2") {
+		t.Errorf("Expected page param to be set, got %s", result.Pages[2].URL)
+	}
+	if !strings.Contains(FormatPaginationResult(result), "page limit") {
+		t.Errorf("Expected limit in summary, got:\n%s", FormatPaginationResult(result))
+	}
+}
+
+func TestFollowPaginationStopsOnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`[1]`))
+	}))
+	defer server.Close()
+
+	rule, _ := ParsePaginationRule("page=page")
+	result := FollowPagination(NewClient(5*time.Second), Request{Method: "GET", URL: server.URL}, rule)
+
+	if result.Err == nil || len(result.Pages) != 2 || len(result.Items) != 1 {
+		t.Errorf("Expected failure on page 2, got %d pages, err %v", len(result.Pages), result.Err)
+	}
+}
+
+func TestSuggestPaginationRule(t *testing.T) {
+	tests := []struct {
+		resp Response
+		want string
+	}{
+		{Response{Headers: map[string][]string{"Link": {`</x?page=2>; rel="next"`}}}, "link"},
+		{Response{Body: `{"items": [], "meta": {"next_cursor": "abc"}}`}, "cursor=.meta.next_cursor"},
+		{Response{Body: `[1, 2]`}, "page=page"},
+	}
+
+	for _, tt := range tests {
+		if got := SuggestPaginationRule(tt.resp); got != tt.want {
+			t.Errorf("SuggestPaginationRule() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// MaxRedirects is how many redirects a request follows before it fails
+const MaxRedirects = 10
+
+// RedirectHop is a redirect a request followed: URL answered with
+// StatusCode and sent it on to Location
+type RedirectHop struct {
+	URL        string
+	StatusCode int
+	Status     string
+	Location   string
+}
+
+// Revisits reports whether the hop leads to a URL the chain already went
+// through, which is how a redirect loop shows up
+func Revisits(hops []RedirectHop, i int) bool {
+	for _, hop := range hops[:i+1] {
+		if hop.URL == hops[i].Location {
+			return true
+		}
+	}
+	return false
+}
+
+// redirectChain records the redirects of one attempt, or stops at the
+// first one when they are not followed
+type redirectChain struct {
+	follow bool
+	hops   []RedirectHop
+}
+
+func newRedirectChain(req Request) *redirectChain {
+	return &redirectChain{follow: !req.NoFollowRedirects}
+}
+
+// check is the CheckRedirect of the client for the attempt. req.Response
+// is the redirect that led to req.
+func (r *redirectChain) check(req *http.Request, via []*http.Request) error {
+	if !r.follow {
+		return http.ErrUseLastResponse
+	}
+	hop := RedirectHop{URL: via[len(via)-1].URL.String(), Location: req.URL.String()}
+	if req.Response != nil {
+		hop.StatusCode = req.Response.StatusCode
+		hop.Status = req.Response.Status
+	}
+	r.hops = append(r.hops, hop)
+	if len(via) >= MaxRedirects {
+		return fmt.Errorf("stopped after %d redirects", MaxRedirects)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func redirectServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/moved", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new", http.StatusFound)
+	})
+	mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("arrived"))
+	})
+	mux.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/pong", http.StatusFound)
+	})
+	mux.HandleFunc("/pong", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ping", http.StatusFound)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestSendRecordsRedirectChain(t *testing.T) {
+	server := redirectServer()
+	defer server.Close()
+
+	resp := NewClient(5 * time.Second).Send(Request{Method: "GET", URL: server.URL + "/old"})
+	if resp.Error != nil || resp.Body != "arrived" {
+		t.Fatalf("Send() = %d %q, %v; want the body of /new", resp.StatusCode, resp.Body, resp.Error)
+	}
+
+	want := []RedirectHop{
+		{URL: server.URL + "/old", StatusCode: 301, Status: "301 Moved Permanently", Location: server.URL + "/moved"},
+		{URL: server.URL + "/moved", StatusCode: 302, Status: "302 Found", Location: server.URL + "/new"},
+	}
+	if len(resp.Redirects) != len(want) {
+		t.Fatalf("Redirects = %+v, want %+v", resp.Redirects, want)
+	}
+	for i := range want {
+		if resp.Redirects[i] != want[i] {
+			t.Errorf("Redirects[%d] = %+v, want %+v", i, resp.Redirects[i], want[i])
+		}
+		if Revisits(resp.Redirects, i) {
+			t.Errorf("Revisits(%d) = true for a chain without a loop", i)
+		}
+	}
+}
+
+func TestSendStopsRedirectLoop(t *testing.T) {
+	server := redirectServer()
+	defer server.Close()
+
+	resp := NewClient(5 * time.Second).Send(Request{Method: "GET", URL: server.URL + "/ping"})
+	if resp.Error == nil || !strings.Contains(resp.Error.Error(), "stopped after 10 redirects") {
+		t.Fatalf("Error = %v, want the loop stopped", resp.Error)
+	}
+	if len(resp.Redirects) != MaxRedirects {
+		t.Fatalf("Recorded %d redirects, want %d", len(resp.Redirects), MaxRedirects)
+	}
+	if Revisits(resp.Redirects, 0) || !Revisits(resp.Redirects, 1) {
+		t.Errorf("Revisits() should mark the hop back to /ping, not the first one")
+	}
+}
+
+func TestSendWithoutFollowingRedirects(t *testing.T) {
+	server := redirectServer()
+	defer server.Close()
+
+	resp := NewClient(5 * time.Second).Send(Request{Method: "GET", URL: server.URL + "/old", NoFollowRedirects: true})
+	if resp.Error != nil || resp.StatusCode != http.StatusMovedPermanently {
+		t.Fatalf("Send() = %d, %v; want the 301 itself", resp.StatusCode, resp.Error)
+	}
+	if location := resp.Headers["Location"]; len(location) != 1 || location[0] != "/moved" {
+		t.Errorf("Location = %v, want /moved", location)
+	}
+	if len(resp.Redirects) != 0 {
+		t.Errorf("Redirects = %+v, want none", resp.Redirects)
+	}
+}
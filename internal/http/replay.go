@@ -0,0 +1,142 @@
+package http
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ReplayRequest is a previously successful request replayed as a smoke check
+type ReplayRequest struct {
+	Request        Request
+	ExpectedStatus int
+	ExpectedBody   string
+}
+
+// ReplayResult holds the outcome of replaying a single request
+type ReplayResult struct {
+	Method        string
+	URL           string
+	OldStatus     int
+	NewStatus     int
+	ResponseTime  time.Duration
+	Error         error
+	SchemaChanges []Change
+}
+
+// StatusChanged reports whether the replayed status differs from the recorded one
+func (r ReplayResult) StatusChanged() bool {
+	return r.Error == nil && r.OldStatus != r.NewStatus
+}
+
+// Passed reports whether the replay matched the recorded status and response shape
+func (r ReplayResult) Passed() bool {
+	return r.Error == nil && !r.StatusChanged() && len(r.SchemaChanges) == 0
+}
+
+// RunReplay sends each request in order and compares the response against the
+// recorded status code and JSON structure
+func RunReplay(client *Client, requests []ReplayRequest) []ReplayResult {
+	results := make([]ReplayResult, 0, len(requests))
+
+	for _, rr := range requests {
+		resp := client.Send(rr.Request)
+
+		result := ReplayResult{
+			Method:       rr.Request.Method,
+			URL:          rr.Request.URL,
+			OldStatus:    rr.ExpectedStatus,
+			NewStatus:    resp.StatusCode,
+			ResponseTime: resp.ResponseTime,
+			Error:        resp.Error,
+		}
+
+		if resp.Error == nil {
+			result.SchemaChanges = CompareJSONShape(rr.ExpectedBody, resp.Body)
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// CompareJSONShape compares the structure of two JSON documents, ignoring values.
+// It reports fields that were added or removed and fields whose type changed.
+// Non-JSON baselines are not compared.
+func CompareJSONShape(oldBody, newBody string) []Change {
+	oldSchema, err := InferJSONSchema(oldBody)
+	if err != nil {
+		return nil
+	}
+
+	newSchema, err := InferJSONSchema(newBody)
+	if err != nil {
+		return []Change{{Type: "modified", Path: "$", OldValue: "json", NewValue: "non-json"}}
+	}
+
+	return CompareSchemas(oldSchema, newSchema).Changes
+}
+
+// jsonTypeName returns the JSON type of a decoded value
+func jsonTypeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return "unknown"
+	}
+}
+
+// FormatReplayResults returns a human-readable smoke test report
+func FormatReplayResults(results []ReplayResult) string {
+	var sb strings.Builder
+
+	passed := 0
+	for _, r := range results {
+		if r.Passed() {
+			passed++
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("Replayed %d requests: %d passed, %d failed\n\n", len(results), passed, len(results)-passed))
+
+	for _, r := range results {
+		marker := "✓"
+		if !r.Passed() {
+			marker = "✗"
+		}
+
+		sb.WriteString(fmt.Sprintf("%s %s %s\n", marker, r.Method, r.URL))
+
+		switch {
+		case r.Error != nil:
+			sb.WriteString(fmt.Sprintf("    error: %v\n", r.Error))
+		case r.StatusChanged():
+			sb.WriteString(fmt.Sprintf("    status: %d -> %d\n", r.OldStatus, r.NewStatus))
+		}
+
+		for _, c := range r.SchemaChanges {
+			switch c.Type {
+			case "added":
+				sb.WriteString(fmt.Sprintf("    + %s (%s)\n", c.Path, c.NewValue))
+			case "removed":
+				sb.WriteString(fmt.Sprintf("    - %s (%s)\n", c.Path, c.OldValue))
+			default:
+				sb.WriteString(fmt.Sprintf("    ~ %s: %s -> %s\n", c.Path, c.OldValue, c.NewValue))
+			}
+		}
+	}
+
+	return sb.String()
+}
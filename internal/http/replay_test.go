@@ -0,0 +1,85 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompareJSONShape(t *testing.T) {
+	old := `{"id": 1, "name": "a", "tags": ["x"], "meta": {"count": 2}, "note": null}`
+	new := `{"id": "1", "tags": ["y"], "meta": {"count": 3, "next": "p2"}, "note": "hi"}`
+
+	changes := CompareJSONShape(old, new)
+
+	expected := map[string]string{
+		"id":        "modified",
+		"name":      "removed",
+		"meta.next": "added",
+	}
+
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %d: %+v", len(expected), len(changes), changes)
+	}
+
+	for _, c := range changes {
+		if expected[c.Path] != c.Type {
+			t.Errorf("Unexpected change %s at %s", c.Type, c.Path)
+		}
+	}
+}
+
+func TestCompareJSONShapeArrayItems(t *testing.T) {
+	changes := CompareJSONShape(`[{"id": 1}]`, `[{"id": 1, "extra": true}]`)
+
+	if len(changes) != 1 || changes[0].Path != "[].extra" {
+		t.Errorf("Expected added field in array items, got %+v", changes)
+	}
+}
+
+func TestCompareJSONShapeNonJSON(t *testing.T) {
+	if changes := CompareJSONShape("plain text", "other text"); len(changes) != 0 {
+		t.Errorf("Expected no changes for non-JSON baseline, got %+v", changes)
+	}
+
+	if changes := CompareJSONShape(`{"a": 1}`, "<html>"); len(changes) != 1 {
+		t.Errorf("Expected a change when the body stops being JSON, got %+v", changes)
+	}
+}
+
+func TestRunReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/same":
+			w.Write([]byte(`{"id": 2}`))
+		case "/gone":
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(5 * time.Second)
+	results := RunReplay(client, []ReplayRequest{
+		{Request: Request{Method: "GET", URL: server.URL + "/same"}, ExpectedStatus: 200, ExpectedBody: `{"id": 1}`},
+		{Request: Request{Method: "GET", URL: server.URL + "/gone"}, ExpectedStatus: 200, ExpectedBody: `{"id": 1}`},
+	})
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+
+	if !results[0].Passed() {
+		t.Errorf("Expected first replay to pass, got %+v", results[0])
+	}
+
+	if results[1].Passed() || !results[1].StatusChanged() {
+		t.Errorf("Expected second replay to fail on status, got %+v", results[1])
+	}
+
+	report := FormatReplayResults(results)
+	if !strings.Contains(report, "1 passed, 1 failed") || !strings.Contains(report, "status: 200 -> 404") {
+		t.Errorf("Unexpected report:\n%s", report)
+	}
+}
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"net/http"
+	"time"
+)
+
+// RetryPolicy is how a request is tried again after it failed on the
+// network, or with a 429, 502, 503 or 504
+type RetryPolicy struct {
+	// Retries is how many attempts follow the first one
+	Retries int
+	// Backoff is the wait before the first retry, doubled before each
+	// one after it
+	Backoff time.Duration
+}
+
+// retryStatus are the statuses worth another attempt: the server or a
+// gateway in front of it may answer the next one
+var retryStatus = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// retryable reports whether resp, the answer to attempt, is tried again.
+// sent is false when the request could not be built, which no retry fixes.
+func (p RetryPolicy) retryable(attempt int, resp Response, sent bool) bool {
+	if attempt > p.Retries || !sent {
+		return false
+	}
+	return resp.Error != nil || retryStatus[resp.StatusCode]
+}
+
+// delay is the wait before the retry following attempt
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	return p.Backoff << (attempt - 1)
+}
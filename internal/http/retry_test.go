@@ -0,0 +1,98 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSendRetriesFailedAttempts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := NewClient(5 * time.Second)
+	resp := client.Send(Request{Method: "GET", URL: server.URL, Retry: RetryPolicy{Retries: 3, Backoff: time.Millisecond}})
+	if resp.StatusCode != http.StatusOK || resp.Attempts != 3 {
+		t.Errorf("Expected 200 on the third attempt, got %d after %d", resp.StatusCode, resp.Attempts)
+	}
+
+	calls.Store(0)
+	resp = client.Send(Request{Method: "GET", URL: server.URL, Retry: RetryPolicy{Retries: 1, Backoff: time.Millisecond}})
+	if resp.StatusCode != http.StatusServiceUnavailable || resp.Attempts != 2 {
+		t.Errorf("Expected the last 503 after 2 attempts, got %d after %d", resp.StatusCode, resp.Attempts)
+	}
+}
+
+func TestSendDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	resp := NewClient(5 * time.Second).Send(Request{Method: "GET", URL: server.URL, Retry: RetryPolicy{Retries: 3}})
+	if resp.Attempts != 1 || calls.Load() != 1 {
+		t.Errorf("Expected a 400 not to be retried, got %d attempts", calls.Load())
+	}
+
+	resp = NewClient(5 * time.Second).Send(Request{Method: "GET", URL: "not a url", Retry: RetryPolicy{Retries: 3}})
+	if resp.Error == nil || resp.Attempts != 1 {
+		t.Errorf("Expected an invalid URL to fail once, got %d attempts: %v", resp.Attempts, resp.Error)
+	}
+}
+
+func TestRetryPolicyDelayDoubles(t *testing.T) {
+	p := RetryPolicy{Retries: 3, Backoff: 100 * time.Millisecond}
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond} {
+		if got := p.delay(attempt); got != want {
+			t.Errorf("delay(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+}
+
+func TestSendRequestTimeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	start := time.Now()
+	resp := NewClient(30 * time.Second).Send(Request{Method: "GET", URL: server.URL, Timeout: 50 * time.Millisecond})
+	if resp.Error == nil || time.Since(start) > 5*time.Second {
+		t.Errorf("Expected the request timeout to replace the client one, got %v after %v", resp.Error, time.Since(start))
+	}
+}
+
+func TestSendStopsRetryingWhenCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+	start := time.Now()
+	resp := NewClient(5*time.Second).SendWithContext(ctx, Request{Method: "GET", URL: server.URL, Retry: RetryPolicy{Retries: 5, Backoff: time.Minute}})
+	if resp.Attempts != 1 || time.Since(start) > 5*time.Second {
+		t.Errorf("Expected the backoff to end on cancel, got %d attempts after %v", resp.Attempts, time.Since(start))
+	}
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// defaultBodyFilename names a saved body when neither the response nor the
+// URL suggests a name
+const defaultBodyFilename = "response"
+
+// SuggestFilename returns a file name to save a response body under: the
+// filename of its Content-Disposition, else the last segment of the request
+// URL, else "response". A name without an extension gets the usual one of
+// the Content-Type.
+func SuggestFilename(headers map[string][]string, rawURL string) string {
+	name := dispositionFilename(http.Header(headers).Get("Content-Disposition"))
+	if name == "" {
+		if u, err := url.Parse(rawURL); err == nil {
+			name = safeFilename(path.Base(u.Path))
+		}
+	}
+	if name == "" {
+		name = defaultBodyFilename
+	}
+	if filepath.Ext(name) == "" {
+		name += contentTypeExtension(http.Header(headers).Get("Content-Type"))
+	}
+	return name
+}
+
+// SaveBody writes body to file, expanding a leading ~/, readable only by
+// the user as bodies may hold tokens or personal data
+func SaveBody(file, body string) error {
+	return os.WriteFile(ExpandHome(file), []byte(body), 0o600)
+}
+
+// dispositionFilename returns the filename a Content-Disposition header
+// gives, RFC 5987 filename* included
+func dispositionFilename(disposition string) string {
+	if disposition == "" {
+		return ""
+	}
+	_, params, err := mime.ParseMediaType(disposition)
+	if err != nil {
+		return ""
+	}
+	return safeFilename(params["filename"])
+}
+
+// safeFilename keeps only the base name of name, so a suggestion never
+// points outside the directory it is saved in
+func safeFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(strings.TrimSpace(name), "\\", "/"))
+	switch name {
+	case ".", "..", "/":
+		return ""
+	}
+	return name
+}
+
+// contentTypeExtension returns the extension of a Content-Type, ".json" and
+// ".txt" for the common API types whatever the system MIME table holds
+func contentTypeExtension(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	switch {
+	case mediaType == ContentTypeJSON || strings.HasSuffix(mediaType, "+json"):
+		return ".json"
+	case mediaType == "text/plain":
+		return ".txt"
+	}
+	if extensions, err := mime.ExtensionsByType(mediaType); err == nil && len(extensions) > 0 {
+		return extensions[0]
+	}
+	return ""
+}
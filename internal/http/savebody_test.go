@@ -0,0 +1,60 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSuggestFilename(t *testing.T) {
+	tests := []struct {
+		headers map[string][]string
+		url     string
+		want    string
+	}{
+		{map[string][]string{"Content-Disposition": {`attachment; filename="report Q3.pdf"`}}, "https://api.test/export", "report Q3.pdf"},
+		{map[string][]string{"Content-Disposition": {`attachment; filename*=UTF-8''r%C3%A9sum%C3%A9.txt`}}, "https://api.test/x", "résumé.txt"},
+		{map[string][]string{"Content-Disposition": {`attachment; filename="../../etc/passwd"`}}, "https://api.test/x", "passwd"},
+		{map[string][]string{"Content-Type": {"application/json; charset=utf-8"}}, "https://api.test/users/42?full=1", "42.json"},
+		{map[string][]string{"Content-Type": {"image/png"}}, "https://api.test/logo.png", "logo.png"},
+		{map[string][]string{"Content-Type": {"application/problem+json"}}, "https://api.test/", "response.json"},
+		{nil, "not a url %zz", "response"},
+	}
+	for _, tt := range tests {
+		if got := SuggestFilename(tt.headers, tt.url); got != tt.want {
+			t.Errorf("SuggestFilename(%v, %q) = %q, want %q", tt.headers, tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestSaveBodyKeepsRawJSON(t *testing.T) {
+	const raw = `{"id":1,"tags":["a","b"]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(raw))
+	}))
+	defer server.Close()
+
+	resp := NewClient(5 * time.Second).Send(Request{Method: "GET", URL: server.URL})
+	if resp.Error != nil {
+		t.Fatalf("Send failed: %v", resp.Error)
+	}
+	if resp.Body == raw {
+		t.Fatalf("Expected the body to be indented for display")
+	}
+
+	path := filepath.Join(t.TempDir(), "body.json")
+	if err := SaveBody(path, resp.RawBody()); err != nil {
+		t.Fatalf("SaveBody failed: %v", err)
+	}
+	saved, err := os.ReadFile(path)
+	if err != nil || string(saved) != raw {
+		t.Errorf("Expected the file to hold the body as sent, got %q (%v)", saved, err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected the file to be readable only by the user, got %v", info.Mode())
+	}
+}
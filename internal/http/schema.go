@@ -0,0 +1,185 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// JSONSchema is a minimal JSON Schema describing the structure of a response body
+type JSONSchema struct {
+	Type       string                 `json:"type"`
+	Properties map[string]*JSONSchema `json:"properties,omitempty"`
+	Required   []string               `json:"required,omitempty"`
+	Items      *JSONSchema            `json:"items,omitempty"`
+}
+
+// InferJSONSchema infers a schema from a JSON document. For arrays, the item
+// schema is the union of all elements; properties missing from some elements
+// are not marked as required.
+func InferJSONSchema(body string) (*JSONSchema, error) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		return nil, fmt.Errorf("response body is not valid JSON: %w", err)
+	}
+
+	return inferSchema(data), nil
+}
+
+// inferSchema builds the schema for a decoded JSON value
+func inferSchema(v interface{}) *JSONSchema {
+	schema := &JSONSchema{Type: jsonTypeName(v)}
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		schema.Properties = make(map[string]*JSONSchema, len(val))
+		for k, child := range val {
+			schema.Properties[k] = inferSchema(child)
+			schema.Required = append(schema.Required, k)
+		}
+		sort.Strings(schema.Required)
+
+	case []interface{}:
+		for _, item := range val {
+			schema.Items = mergeSchemas(schema.Items, inferSchema(item))
+		}
+	}
+
+	return schema
+}
+
+// mergeSchemas combines two schemas observed for the same location
+func mergeSchemas(a, b *JSONSchema) *JSONSchema {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	// A null observation does not tell us anything about the real type
+	if a.Type == "null" {
+		return b
+	}
+	if b.Type == "null" || a.Type != b.Type {
+		return a
+	}
+
+	merged := &JSONSchema{Type: a.Type}
+
+	switch a.Type {
+	case "object":
+		merged.Properties = make(map[string]*JSONSchema)
+		for k, prop := range a.Properties {
+			merged.Properties[k] = mergeSchemas(prop, b.Properties[k])
+		}
+		for k, prop := range b.Properties {
+			if _, ok := merged.Properties[k]; !ok {
+				merged.Properties[k] = prop
+			}
+		}
+
+		inB := make(map[string]bool, len(b.Required))
+		for _, k := range b.Required {
+			inB[k] = true
+		}
+		for _, k := range a.Required {
+			if inB[k] {
+				merged.Required = append(merged.Required, k)
+			}
+		}
+
+	case "array":
+		merged.Items = mergeSchemas(a.Items, b.Items)
+	}
+
+	return merged
+}
+
+// CompareSchemas reports structural drift between a stored schema and a newly
+// inferred one as a body diff, so it can be rendered with FormatDiff
+func CompareSchemas(old, new *JSONSchema) *BodyDiff {
+	changes := findSchemaDifferences(old, new, "")
+
+	added, removed, modified := 0, 0, 0
+	for _, change := range changes {
+		switch change.Type {
+		case "added":
+			added++
+		case "removed":
+			removed++
+		case "modified":
+			modified++
+		}
+	}
+
+	return &BodyDiff{
+		Type:    "schema",
+		Changes: changes,
+		Summary: fmt.Sprintf("%d type changes, %d added, %d removed", modified, added, removed),
+	}
+}
+
+// findSchemaDifferences walks both schemas and collects structural differences
+func findSchemaDifferences(old, new *JSONSchema, path string) []Change {
+	if old == nil || new == nil {
+		return nil
+	}
+
+	displayPath := path
+	if displayPath == "" {
+		displayPath = "$"
+	}
+
+	// null is compatible with any type, values are often optional
+	if old.Type == "null" || new.Type == "null" {
+		return nil
+	}
+	if old.Type != new.Type {
+		return []Change{{Type: "modified", Path: displayPath, OldValue: old.Type, NewValue: new.Type}}
+	}
+
+	var changes []Change
+
+	switch old.Type {
+	case "object":
+		keys := make([]string, 0, len(old.Properties)+len(new.Properties))
+		for k := range old.Properties {
+			keys = append(keys, k)
+		}
+		for k := range new.Properties {
+			if _, ok := old.Properties[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			childPath := k
+			if path != "" {
+				childPath = path + "." + k
+			}
+
+			oldChild, inOld := old.Properties[k]
+			newChild, inNew := new.Properties[k]
+			switch {
+			case !inNew:
+				changes = append(changes, Change{Type: "removed", Path: childPath, OldValue: oldChild.Type})
+			case !inOld:
+				changes = append(changes, Change{Type: "added", Path: childPath, NewValue: newChild.Type})
+			default:
+				changes = append(changes, findSchemaDifferences(oldChild, newChild, childPath)...)
+			}
+		}
+
+	case "array":
+		changes = append(changes, findSchemaDifferences(old.Items, new.Items, path+"[]")...)
+	}
+
+	return changes
+}
+
+// FormatSchemaDrift renders schema drift using the response diff format
+func FormatSchemaDrift(drift *BodyDiff) string {
+	return FormatDiff(&DiffResult{BodyDiff: drift})
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInferJSONSchema(t *testing.T) {
+	body := `{"id": 1, "name": "a", "tags": ["x"], "items": [{"id": 1}, {"id": 2, "extra": true}]}`
+
+	schema, err := InferJSONSchema(body)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if schema.Type != "object" {
+		t.Fatalf("Expected object schema, got %s", schema.Type)
+	}
+
+	if schema.Properties["id"].Type != "number" {
+		t.Errorf("Expected id to be number, got %s", schema.Properties["id"].Type)
+	}
+
+	if schema.Properties["tags"].Items.Type != "string" {
+		t.Errorf("Expected tags items to be string, got %s", schema.Properties["tags"].Items.Type)
+	}
+
+	items := schema.Properties["items"].Items
+	if _, ok := items.Properties["extra"]; !ok {
+		t.Error("Expected array item schema to include properties from all elements")
+	}
+
+	if len(items.Required) != 1 || items.Required[0] != "id" {
+		t.Errorf("Expected only 'id' to be required in items, got %v", items.Required)
+	}
+}
+
+func TestInferJSONSchemaInvalid(t *testing.T) {
+	if _, err := InferJSONSchema("not json"); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
+
+func TestCompareSchemas(t *testing.T) {
+	old, _ := InferJSONSchema(`{"id": 1, "name": "a", "meta": {"count": 1}}`)
+	new, _ := InferJSONSchema(`{"id": "1", "meta": {"count": 1, "next": null}}`)
+
+	drift := CompareSchemas(old, new)
+
+	if len(drift.Changes) != 3 {
+		t.Fatalf("Expected 3 changes, got %d: %+v", len(drift.Changes), drift.Changes)
+	}
+
+	if drift.Summary != "1 type changes, 1 added, 1 removed" {
+		t.Errorf("Unexpected summary: %s", drift.Summary)
+	}
+
+	report := FormatSchemaDrift(drift)
+	for _, want := range []string{"~ id: number -> string", "- name: string", "+ meta.next: null"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected report to contain %q, got:\n%s", want, report)
+		}
+	}
+}
+
+func TestCompareSchemasNoDrift(t *testing.T) {
+	old, _ := InferJSONSchema(`{"id": 1, "tags": []}`)
+	new, _ := InferJSONSchema(`{"id": 2, "tags": ["a"]}`)
+
+	if drift := CompareSchemas(old, new); len(drift.Changes) != 0 {
+		t.Errorf("Expected no drift, got %+v", drift.Changes)
+	}
+}
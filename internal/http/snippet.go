@@ -0,0 +1,412 @@
+package http
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	httpVariablePattern = regexp.MustCompile(`^@([A-Za-z0-9_.-]+)\s*=\s*(.*)$`)
+	httpVariableRef     = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+	httpMethods         = map[string]bool{
+		"GET": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true,
+		"HEAD": true, "OPTIONS": true, "TRACE": true, "CONNECT": true,
+	}
+)
+
+// ParseSnippet reads a request written either as a curl command or in the
+// .http format used by editor REST clients
+func ParseSnippet(text string) (Request, error) {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return Request{}, fmt.Errorf("empty request")
+	}
+
+	if first := strings.Fields(trimmed)[0]; first == "curl" || strings.HasSuffix(first, "/curl") {
+		return ParseCurl(trimmed)
+	}
+	return ParseHTTPRequest(trimmed)
+}
+
+// ParseHTTPRequest parses the first request of a .http snippet:
+//
+//	@host = https://api.example.com
+//	POST {{host}}/users HTTP/1.1
+//	Content-Type: application/json
+//
+//	{"name": "Alice"}
+//
+// Lines starting with # or // are comments and ### separates requests.
+// File variables declared with @name = value are substituted; other {{refs}}
+// are left for environment variables.
+func ParseHTTPRequest(text string) (Request, error) {
+	vars := make(map[string]string)
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	req := Request{Headers: make(map[string]string)}
+	var body []string
+	inBody := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "###") {
+			if req.URL != "" {
+				break
+			}
+			continue
+		}
+
+		if inBody {
+			body = append(body, line)
+			continue
+		}
+
+		if trimmed == "" {
+			if req.URL != "" {
+				inBody = true
+			}
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "//") {
+			continue
+		}
+
+		if m := httpVariablePattern.FindStringSubmatch(trimmed); m != nil && req.URL == "" {
+			vars[m[1]] = strings.TrimSpace(m[2])
+			continue
+		}
+
+		if req.URL == "" {
+			method, target, err := parseRequestLine(trimmed)
+			if err != nil {
+				return Request{}, err
+			}
+			req.Method = method
+			req.URL = target
+			continue
+		}
+
+		// Lines starting with ? or & continue the query string
+		if strings.HasPrefix(trimmed, "?") || strings.HasPrefix(trimmed, "&") {
+			req.URL += trimmed
+			continue
+		}
+
+		name, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return Request{}, fmt.Errorf("invalid header line %q", trimmed)
+		}
+		req.Headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+
+	if req.URL == "" {
+		return Request{}, fmt.Errorf("no request line found")
+	}
+
+	req.Body = strings.TrimRight(strings.Join(body, "\n"), "\n\t ")
+
+	expand := func(s string) string {
+		return httpVariableRef.ReplaceAllStringFunc(s, func(ref string) string {
+			name := httpVariableRef.FindStringSubmatch(ref)[1]
+			if value, ok := vars[name]; ok {
+				return value
+			}
+			return ref
+		})
+	}
+	req.URL = expand(req.URL)
+	req.Body = expand(req.Body)
+	for k, v := range req.Headers {
+		req.Headers[k] = expand(v)
+	}
+
+	return req, nil
+}
+
+// parseRequestLine splits "METHOD URL [HTTP/x]"; a bare URL means GET
+func parseRequestLine(line string) (string, string, error) {
+	fields := strings.Fields(line)
+	if len(fields) > 1 && strings.HasPrefix(strings.ToUpper(fields[len(fields)-1]), "HTTP/") {
+		fields = fields[:len(fields)-1]
+	}
+
+	switch len(fields) {
+	case 1:
+		return "GET", fields[0], nil
+	case 2:
+		method := strings.ToUpper(fields[0])
+		if !httpMethods[method] {
+			return "", "", fmt.Errorf("unknown method %q", fields[0])
+		}
+		return method, fields[1], nil
+	}
+	return "", "", fmt.Errorf("invalid request line %q", line)
+}
+
+// ParseCurl turns a curl command line into a request. Options that do not
+// change the request itself, such as -s, -L or -o, are ignored.
+func ParseCurl(command string) (Request, error) {
+	args, err := splitShellWords(command)
+	if err != nil {
+		return Request{}, err
+	}
+	if len(args) == 0 || (args[0] != "curl" && !strings.HasSuffix(args[0], "/curl")) {
+		return Request{}, fmt.Errorf("not a curl command")
+	}
+
+	req := Request{Headers: make(map[string]string)}
+	var data, form []string
+	useGet := false
+	head := false
+
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+
+		// --name=value
+		if strings.HasPrefix(arg, "--") {
+			if name, value, ok := strings.Cut(arg, "="); ok {
+				arg = name
+				args = append(args[:i+1], append([]string{value}, args[i+1:]...)...)
+			}
+		}
+
+		// -XPOST and -H'Accept: x' carry their value in the same word
+		if len(arg) > 2 && arg[0] == '-' && arg[1] != '-' && strings.ContainsRune("XHdAbeuF", rune(arg[1])) {
+			args = append(args[:i+1], append([]string{arg[2:]}, args[i+1:]...)...)
+			arg = arg[:2]
+		}
+
+		next := func() (string, error) {
+			if i+1 >= len(args) {
+				return "", fmt.Errorf("option %s needs a value", arg)
+			}
+			i++
+			return args[i], nil
+		}
+
+		switch arg {
+		case "-X", "--request":
+			value, err := next()
+			if err != nil {
+				return Request{}, err
+			}
+			req.Method = strings.ToUpper(value)
+		case "-H", "--header":
+			value, err := next()
+			if err != nil {
+				return Request{}, err
+			}
+			name, val, ok := strings.Cut(value, ":")
+			if !ok {
+				return Request{}, fmt.Errorf("invalid header %q", value)
+			}
+			req.Headers[strings.TrimSpace(name)] = strings.TrimSpace(val)
+		case "-d", "--data", "--data-raw", "--data-binary", "--data-ascii":
+			value, err := next()
+			if err != nil {
+				return Request{}, err
+			}
+			data = append(data, value)
+		case "--data-urlencode":
+			value, err := next()
+			if err != nil {
+				return Request{}, err
+			}
+			data = append(data, urlencodeData(value))
+		case "-F", "--form":
+			value, err := next()
+			if err != nil {
+				return Request{}, err
+			}
+			form = append(form, value)
+		case "--json":
+			value, err := next()
+			if err != nil {
+				return Request{}, err
+			}
+			data = append(data, value)
+			req.Headers["Content-Type"] = "application/json"
+			req.Headers["Accept"] = "application/json"
+		case "-u", "--user":
+			value, err := next()
+			if err != nil {
+				return Request{}, err
+			}
+			req.Headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(value))
+		case "-A", "--user-agent":
+			value, err := next()
+			if err != nil {
+				return Request{}, err
+			}
+			req.Headers["User-Agent"] = value
+		case "-b", "--cookie":
+			value, err := next()
+			if err != nil {
+				return Request{}, err
+			}
+			req.Headers["Cookie"] = value
+		case "-e", "--referer":
+			value, err := next()
+			if err != nil {
+				return Request{}, err
+			}
+			req.Headers["Referer"] = value
+		case "--url":
+			value, err := next()
+			if err != nil {
+				return Request{}, err
+			}
+			req.URL = value
+		case "-G", "--get":
+			useGet = true
+		case "-I", "--head":
+			head = true
+		case "-o", "--output", "-m", "--max-time", "--connect-timeout", "-w", "--write-out", "--retry", "-x", "--proxy", "--cacert", "--cert", "--key":
+			if _, err := next(); err != nil {
+				return Request{}, err
+			}
+		default:
+			if strings.HasPrefix(arg, "-") && len(arg) > 1 {
+				// Flags without a value such as -s, -sSL, --compressed or --location
+				continue
+			}
+			if req.URL != "" {
+				return Request{}, fmt.Errorf("unexpected argument %q", arg)
+			}
+			req.URL = arg
+		}
+	}
+
+	if req.URL == "" {
+		return Request{}, fmt.Errorf("no URL in curl command")
+	}
+
+	body := strings.Join(data, "&")
+	switch {
+	case len(form) > 0:
+		// One field per line, in the syntax -F takes
+		req.Body = strings.Join(form, "\n")
+		if !hasHeader(req.Headers, "Content-Type") {
+			req.Headers["Content-Type"] = "multipart/form-data"
+		}
+	case useGet && body != "":
+		separator := "?"
+		if strings.Contains(req.URL, "?") {
+			separator = "&"
+		}
+		req.URL += separator + body
+	case body != "":
+		req.Body = body
+		if !hasHeader(req.Headers, "Content-Type") {
+			req.Headers["Content-Type"] = "application/x-www-form-urlencoded"
+		}
+	}
+
+	if req.Method == "" {
+		switch {
+		case head:
+			req.Method = "HEAD"
+		case req.Body != "":
+			req.Method = "POST"
+		default:
+			req.Method = "GET"
+		}
+	}
+
+	if _, err := url.Parse(req.URL); err != nil {
+		return Request{}, fmt.Errorf("invalid URL %q: %w", req.URL, err)
+	}
+
+	return req, nil
+}
+
+// urlencodeData encodes a --data-urlencode value like curl does: in
+// "name=content" only the content is encoded, and a leading '=' is dropped
+func urlencodeData(value string) string {
+	escape := func(s string) string {
+		return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+	}
+	name, content, ok := strings.Cut(value, "=")
+	switch {
+	case !ok:
+		return escape(value)
+	case name == "":
+		return escape(content)
+	}
+	return name + "=" + escape(content)
+}
+
+func hasHeader(headers map[string]string, name string) bool {
+	for key := range headers {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitShellWords splits a command line the way a POSIX shell would for the
+// quoting used in copied curl commands: single quotes, double quotes,
+// backslash escapes and backslash-newline continuations
+func splitShellWords(s string) ([]string, error) {
+	var words []string
+	var current strings.Builder
+	inWord := false
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && (s[i+1] == '\n' || s[i+1] == '\r'):
+			i++
+			if s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n' {
+				i++
+			}
+		case c == '\\' && i+1 < len(s):
+			i++
+			current.WriteByte(s[i])
+			inWord = true
+		case c == '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated single quote")
+			}
+			current.WriteString(s[i+1 : i+1+end])
+			i += end + 1
+			inWord = true
+		case c == '"':
+			i++
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) && strings.IndexByte("\"\\$`\n", s[i+1]) >= 0 {
+					i++
+					if s[i] == '\n' {
+						continue
+					}
+				}
+				current.WriteByte(s[i])
+			}
+			if i >= len(s) {
+				return nil, fmt.Errorf("unterminated double quote")
+			}
+			inWord = true
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, current.String())
+	}
+
+	return words, nil
+}
@@ -0,0 +1,179 @@
+package http
+
+import (
+	"testing"
+)
+
+func TestParseHTTPRequest(t *testing.T) {
+	snippet := `# create a user
+@host = https://api.example.com
+@token = abc
+
+POST {{host}}/users HTTP/1.1
+Content-Type: application/json
+Authorization: Bearer {{token}}
+X-Env: {{ENV_ONLY}}
+
+{"name": "Alice"}
+
+### second request
+GET {{host}}/users
+`
+
+	req, err := ParseHTTPRequest(snippet)
+	if err != nil {
+		t.Fatalf("ParseHTTPRequest() error = %v", err)
+	}
+
+	if req.Method != "POST" || req.URL != "https://api.example.com/users" {
+		t.Errorf("Request line = %s %s", req.Method, req.URL)
+	}
+	if req.Headers["Authorization"] != "Bearer abc" {
+		t.Errorf("Authorization = %q", req.Headers["Authorization"])
+	}
+	if req.Headers["X-Env"] != "{{ENV_ONLY}}" {
+		t.Errorf("Expected unknown variables to be kept, got %q", req.Headers["X-Env"])
+	}
+	if req.Body != `{"name": "Alice"}` {
+		t.Errorf("Body = %q", req.Body)
+	}
+}
+
+func TestParseHTTPRequestBareURL(t *testing.T) {
+	req, err := ParseHTTPRequest("https://api.example.com/users\n  ?page=2\n  &size=10")
+	if err != nil {
+		t.Fatalf("ParseHTTPRequest() error = %v", err)
+	}
+	if req.Method != "GET" || req.URL != "https://api.example.com/users?page=2&size=10" {
+		t.Errorf("Request = %s %s", req.Method, req.URL)
+	}
+
+	if _, err := ParseHTTPRequest("FETCH https://api.example.com"); err == nil {
+		t.Error("Expected an error for an unknown method")
+	}
+}
+
+func TestParseCurl(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    Request
+	}{
+		{
+			name:    "simple GET",
+			command: "curl -sSL https://api.example.com/users",
+			want:    Request{Method: "GET", URL: "https://api.example.com/users", Headers: map[string]string{}},
+		},
+		{
+			name: "POST with continuation lines",
+			command: `curl 'https://api.example.com/users' \
+  -X POST \
+  -H 'Content-Type: application/json' \
+  -d '{"name":"Alice"}'`,
+			want: Request{
+				Method:  "POST",
+				URL:     "https://api.example.com/users",
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Body:    `{"name":"Alice"}`,
+			},
+		},
+		{
+			name:    "data implies POST and form encoding",
+			command: `curl https://api.example.com/login --data "user=a" --data-raw 'pass=b'`,
+			want: Request{
+				Method:  "POST",
+				URL:     "https://api.example.com/login",
+				Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+				Body:    "user=a&pass=b",
+			},
+		},
+		{
+			name:    "attached values and basic auth",
+			command: `curl -XPUT -u admin:secret --url=https://api.example.com/items/1 -H"Accept: text/plain"`,
+			want: Request{
+				Method:  "PUT",
+				URL:     "https://api.example.com/items/1",
+				Headers: map[string]string{"Authorization": "Basic YWRtaW46c2VjcmV0", "Accept": "text/plain"},
+			},
+		},
+		{
+			name:    "data-urlencode encodes the content only",
+			command: `curl https://api.example.com/search --data-urlencode 'q=a b&c' --data-urlencode '=x/y' -G`,
+			want: Request{
+				Method:  "GET",
+				URL:     "https://api.example.com/search?q=a%20b%26c&x%2Fy",
+				Headers: map[string]string{},
+			},
+		},
+		{
+			name:    "form fields make a multipart body",
+			command: `curl https://api.example.com/upload -F title=Report -F'file=@report.pdf'`,
+			want: Request{
+				Method:  "POST",
+				URL:     "https://api.example.com/upload",
+				Headers: map[string]string{"Content-Type": "multipart/form-data"},
+				Body:    "title=Report\nfile=@report.pdf",
+			},
+		},
+		{
+			name:    "get moves data to the query string",
+			command: `curl -G https://api.example.com/search -d q=go -o out.json`,
+			want: Request{
+				Method:  "GET",
+				URL:     "https://api.example.com/search?q=go",
+				Headers: map[string]string{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCurl(tt.command)
+			if err != nil {
+				t.Fatalf("ParseCurl() error = %v", err)
+			}
+			if got.Method != tt.want.Method || got.URL != tt.want.URL || got.Body != tt.want.Body {
+				t.Errorf("ParseCurl() = %s %s %q, want %s %s %q", got.Method, got.URL, got.Body, tt.want.Method, tt.want.URL, tt.want.Body)
+			}
+			if len(got.Headers) != len(tt.want.Headers) {
+				t.Errorf("Headers = %v, want %v", got.Headers, tt.want.Headers)
+			}
+			for k, v := range tt.want.Headers {
+				if got.Headers[k] != v {
+					t.Errorf("Header %s = %q, want %q", k, got.Headers[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestParseCurlErrors(t *testing.T) {
+	for _, command := range []string{
+		"curl",
+		"curl 'https://api.example.com",
+		"curl https://a.example.com https://b.example.com",
+		"curl https://api.example.com -H",
+	} {
+		if _, err := ParseCurl(command); err == nil {
+			t.Errorf("ParseCurl(%q) expected an error", command)
+		}
+	}
+}
+
+func TestParseSnippetRoundTripsRequestToCurl(t *testing.T) {
+	original := Request{
+		Method:  "PATCH",
+		URL:     "https://api.example.com/users/1",
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Body:    `{"name":"Bob"}`,
+	}
+
+	got, err := ParseSnippet(RequestToCurl(original))
+	if err != nil {
+		t.Fatalf("ParseSnippet() error = %v", err)
+	}
+	if got.Method != original.Method || got.URL != original.URL || got.Body != original.Body ||
+		got.Headers["Content-Type"] != "application/json" {
+		t.Errorf("ParseSnippet() = %+v, want %+v", got, original)
+	}
+}
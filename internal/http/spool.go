@@ -0,0 +1,199 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// spoolPreviewSize is how much of a spooled body is kept in
+	// Response.Body, for the features that work on the body in memory
+	spoolPreviewSize = 1024 * 1024 // 1MB
+	// spoolLineSize is the longest line a spooled body is paged in, so a
+	// minified export on one line still pages
+	spoolLineSize = 4096
+)
+
+// SpoolThreshold is the largest body held in memory; larger ones are
+// spooled to a temporary file
+var SpoolThreshold int64 = MaxResponseSize
+
+// SpooledBody is a response body too large to hold in memory, written to
+// a temporary file as it arrived and read back a page of lines at a time
+type SpooledBody struct {
+	Path string
+	Size int64
+
+	// offsets holds where each line starts in the file
+	offsets []int64
+}
+
+// Lines returns how many lines the body is paged in
+func (b *SpooledBody) Lines() int {
+	return len(b.offsets)
+}
+
+// ReadLines reads n lines from the file starting at line start, fewer at
+// the end of the body
+func (b *SpooledBody) ReadLines(start, n int) ([]string, error) {
+	if start < 0 || start >= len(b.offsets) || n <= 0 {
+		return nil, nil
+	}
+	end := min(start+n, len(b.offsets))
+	from, to := b.offsets[start], b.Size
+	if end < len(b.offsets) {
+		to = b.offsets[end]
+	}
+
+	f, err := os.Open(b.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open spooled response: %w", err)
+	}
+	defer f.Close()
+	data := make([]byte, to-from)
+	if _, err := f.ReadAt(data, from); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read spooled response: %w", err)
+	}
+
+	lines := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		lineEnd := to
+		if i+1 < end {
+			lineEnd = b.offsets[i+1]
+		}
+		line := string(data[b.offsets[i]-from : lineEnd-from])
+		lines = append(lines, strings.TrimRight(line, "\r\n"))
+	}
+	return lines, nil
+}
+
+// SaveTo copies the body to file, expanding a leading ~
+func (b *SpooledBody) SaveTo(file string) error {
+	src, err := os.Open(b.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open spooled response: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(ExpandHome(file), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// Remove deletes the temporary file
+func (b *SpooledBody) Remove() error {
+	return os.Remove(b.Path)
+}
+
+// readBody reads a response body of length bytes, -1 when unknown, into
+// memory, or into a spool file when it is larger than SpoolThreshold. A
+// body that may be larger goes to the file once it is past the preview, so
+// a large one is never held in memory whole; it is read back when it turns
+// out small enough. With a spool file, the bytes returned are only the
+// beginning of the body.
+func readBody(body io.Reader, length int64) ([]byte, *SpooledBody, error) {
+	inMemory := min(SpoolThreshold, spoolPreviewSize)
+	if length >= 0 && length <= SpoolThreshold {
+		inMemory = SpoolThreshold
+	}
+
+	head, err := io.ReadAll(io.LimitReader(body, inMemory+1))
+	if err != nil || int64(len(head)) <= inMemory {
+		return head, nil, err
+	}
+	spool, err := spoolBody(head, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if spool.Size > SpoolThreshold {
+		return head, spool, nil
+	}
+
+	data, err := os.ReadFile(spool.Path)
+	spool.Remove()
+	return data, nil, err
+}
+
+// spoolBody writes head, the part of the body already read, and the rest
+// of body to a temporary file, indexing its lines on the way
+func spoolBody(head []byte, body io.Reader) (*SpooledBody, error) {
+	f, err := os.CreateTemp("", "godev-response-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create spool file: %w", err)
+	}
+	index := &lineIndex{offsets: []int64{0}}
+	w := io.MultiWriter(f, index)
+
+	_, err = w.Write(head)
+	if err == nil {
+		_, err = io.Copy(w, body)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return nil, err
+	}
+	return &SpooledBody{Path: f.Name(), Size: index.pos, offsets: index.offsets}, nil
+}
+
+// lineIndex records where the lines of what is written to it start. A
+// line longer than spoolLineSize is broken at the next rune that starts
+// after it.
+type lineIndex struct {
+	offsets   []int64
+	pos       int64
+	lineStart int64
+}
+
+func (x *lineIndex) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		// Only the bytes up to the limit are searched, as a body on one
+		// line would otherwise be scanned again for every break
+		limit := int(x.lineStart + spoolLineSize - x.pos)
+		next := bytes.IndexByte(p[:min(max(limit+1, 0), len(p))], '\n')
+		if next < 0 {
+			// No newline before the limit: break the line there
+			at := max(limit, 0)
+			for at < len(p) && !utf8.RuneStart(p[at]) {
+				at++
+			}
+			if at >= len(p) {
+				x.pos += int64(len(p))
+				return n, nil
+			}
+			x.pos += int64(at)
+			x.lineStart = x.pos
+			x.offsets = append(x.offsets, x.pos)
+			p = p[at:]
+			continue
+		}
+		x.pos += int64(next + 1)
+		x.lineStart = x.pos
+		x.offsets = append(x.offsets, x.pos)
+		p = p[next+1:]
+	}
+	return n, nil
+}
+
+// previewBody is the beginning of a spooled body kept in memory, cut at a
+// line so it ends on whole runes
+func previewBody(head []byte) string {
+	preview := head[:min(len(head), spoolPreviewSize)]
+	if i := bytes.LastIndexByte(preview, '\n'); i > 0 {
+		preview = preview[:i]
+	}
+	return string(preview)
+}
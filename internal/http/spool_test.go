@@ -0,0 +1,106 @@
+package http
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSpoolBodyPagesLines(t *testing.T) {
+	body := "first\nsecond\r\nthird"
+	spool, err := spoolBody([]byte(body[:4]), strings.NewReader(body[4:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer spool.Remove()
+
+	if spool.Size != int64(len(body)) || spool.Lines() != 3 {
+		t.Fatalf("Size = %d, Lines = %d, want %d and 3", spool.Size, spool.Lines(), len(body))
+	}
+	lines, err := spool.ReadLines(1, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(lines, "|") != "second|third" {
+		t.Errorf("ReadLines(1, 5) = %q", lines)
+	}
+	if lines, _ := spool.ReadLines(3, 1); lines != nil {
+		t.Errorf("Expected no lines past the end, got %q", lines)
+	}
+}
+
+func TestSpoolBodyBreaksLongLines(t *testing.T) {
+	// A one-line export is paged in lines of spoolLineSize, never
+	// splitting a rune
+	body := strings.Repeat("é", spoolLineSize)
+	spool, err := spoolBody(nil, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer spool.Remove()
+
+	if spool.Lines() != 2 {
+		t.Fatalf("Lines = %d, want 2", spool.Lines())
+	}
+	lines, err := spool.ReadLines(0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines[0]+lines[1] != body || len(lines[0]) != spoolLineSize {
+		t.Errorf("Expected the line broken after %d bytes, got %d and %d", spoolLineSize, len(lines[0]), len(lines[1]))
+	}
+}
+
+func TestSpooledBodySaveTo(t *testing.T) {
+	spool, err := spoolBody([]byte("a\nb"), strings.NewReader("\nc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer spool.Remove()
+
+	file := t.TempDir() + "/export.txt"
+	if err := spool.SaveTo(file); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := os.ReadFile(file)
+	if err != nil || string(saved) != "a\nb\nc" {
+		t.Errorf("Saved %q, %v", saved, err)
+	}
+}
+
+func TestReadBodySpoolsWhileReading(t *testing.T) {
+	defer func(threshold int64) { SpoolThreshold = threshold }(SpoolThreshold)
+	SpoolThreshold = 64
+
+	small := strings.Repeat("a", 64)
+	data, spool, err := readBody(strings.NewReader(small), -1)
+	if err != nil || spool != nil || string(data) != small {
+		t.Fatalf("readBody(64 bytes) = %q, %v, %v; want the body in memory", data, spool, err)
+	}
+
+	large := strings.Repeat("b\n", 99) + "b"
+	data, spool, err = readBody(strings.NewReader(large), -1)
+	if err != nil || spool == nil {
+		t.Fatalf("readBody(199 bytes) = %v, %v; want the body spooled", spool, err)
+	}
+	defer spool.Remove()
+	if spool.Size != int64(len(large)) || spool.Lines() != 100 {
+		t.Errorf("Size = %d, Lines = %d, want %d and 100", spool.Size, spool.Lines(), len(large))
+	}
+	if len(data) > 65 {
+		t.Errorf("Expected only the beginning of the body in memory, got %d bytes", len(data))
+	}
+}
+
+func TestReadBodyReadsBackBodyPastThePreview(t *testing.T) {
+	defer func(threshold int64) { SpoolThreshold = threshold }(SpoolThreshold)
+	SpoolThreshold = 2 * spoolPreviewSize
+
+	// Past the preview the body goes to disk, but it ends under the
+	// threshold, so it is read back and the file removed
+	body := strings.Repeat("c", spoolPreviewSize+10)
+	data, spool, err := readBody(strings.NewReader(body), -1)
+	if err != nil || spool != nil || string(data) != body {
+		t.Fatalf("readBody() = %d bytes, %v, %v; want the whole body in memory", len(data), spool, err)
+	}
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Phases of a request, in the order they happen
+const (
+	PhaseDNS      = "dns"
+	PhaseConnect  = "connect"
+	PhaseTLS      = "tls"
+	PhaseTTFB     = "ttfb"
+	PhaseDownload = "download"
+)
+
+// TimingPhase is one step of a request, Start counted from when it was sent
+type TimingPhase struct {
+	Name     string
+	Start    time.Duration
+	Duration time.Duration
+}
+
+// Timing breaks the time of a request into its phases. A reused
+// connection has no DNS, connect or TLS phase.
+type Timing struct {
+	Phases []TimingPhase
+	Total  time.Duration
+}
+
+// Phase returns the phase called name and whether the request went
+// through it
+func (t *Timing) Phase(name string) (TimingPhase, bool) {
+	for _, phase := range t.Phases {
+		if phase.Name == name {
+			return phase, true
+		}
+	}
+	return TimingPhase{}, false
+}
+
+// requestTimer records when each phase of a request starts and ends. The
+// transport calls the trace hooks from its own goroutines, so they lock.
+type requestTimer struct {
+	mu        sync.Mutex
+	start     time.Time
+	dnsStart  time.Time
+	dnsDone   time.Time
+	dialStart time.Time
+	dialDone  time.Time
+	tlsStart  time.Time
+	tlsDone   time.Time
+	gotConn   time.Time
+	firstByte time.Time
+}
+
+func newRequestTimer() *requestTimer {
+	return &requestTimer{start: time.Now()}
+}
+
+// stamp sets *at to now under the lock, once
+func (t *requestTimer) stamp(at *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if at.IsZero() {
+		*at = time.Now()
+	}
+}
+
+// hook adds the hooks of the timer to trace
+func (t *requestTimer) hook(trace *httptrace.ClientTrace) {
+	trace.DNSStart = func(httptrace.DNSStartInfo) { t.stamp(&t.dnsStart) }
+	trace.DNSDone = func(httptrace.DNSDoneInfo) { t.stamp(&t.dnsDone) }
+	trace.ConnectStart = func(string, string) { t.stamp(&t.dialStart) }
+	// With several addresses the first dial to finish is the one used
+	trace.ConnectDone = func(_, _ string, err error) {
+		if err == nil {
+			t.stamp(&t.dialDone)
+		}
+	}
+	trace.TLSHandshakeStart = func() { t.stamp(&t.tlsStart) }
+	trace.TLSHandshakeDone = func(tls.ConnectionState, error) { t.stamp(&t.tlsDone) }
+	trace.GotFirstResponseByte = func() { t.stamp(&t.firstByte) }
+}
+
+// gotConnection records that the request has its connection; from then on
+// it is waiting for the response
+func (t *requestTimer) gotConnection() {
+	t.stamp(&t.gotConn)
+}
+
+// finish returns the phases up to end, when the body was read
+func (t *requestTimer) finish(end time.Time) *Timing {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	timing := &Timing{Total: end.Sub(t.start)}
+	add := func(name string, from, to time.Time) {
+		if from.IsZero() || to.IsZero() || to.Before(from) {
+			return
+		}
+		timing.Phases = append(timing.Phases, TimingPhase{Name: name, Start: from.Sub(t.start), Duration: to.Sub(from)})
+	}
+	add(PhaseDNS, t.dnsStart, t.dnsDone)
+	add(PhaseConnect, t.dialStart, t.dialDone)
+	add(PhaseTLS, t.tlsStart, t.tlsDone)
+	add(PhaseTTFB, t.gotConn, t.firstByte)
+	add(PhaseDownload, t.firstByte, end)
+	return timing
+}
@@ -0,0 +1,70 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSendTimingPhases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	client := NewClient(5 * time.Second)
+
+	// localhost rather than the IP, so the name is looked up
+	url := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	resp := client.Send(Request{Method: "GET", URL: url})
+	if resp.Error != nil {
+		t.Fatalf("Send() error = %v", resp.Error)
+	}
+	timing := resp.Timing
+	if timing == nil {
+		t.Fatal("Timing = nil")
+	}
+	for _, name := range []string{PhaseDNS, PhaseConnect, PhaseTTFB, PhaseDownload} {
+		if _, ok := timing.Phase(name); !ok {
+			t.Errorf("Phases = %+v, missing %s", timing.Phases, name)
+		}
+	}
+	if _, ok := timing.Phase(PhaseTLS); ok {
+		t.Error("A plain HTTP request has a TLS phase")
+	}
+	if ttfb, _ := timing.Phase(PhaseTTFB); ttfb.Duration < 20*time.Millisecond {
+		t.Errorf("TTFB = %v, want the 20ms the server waited", ttfb.Duration)
+	}
+	for i := 1; i < len(timing.Phases); i++ {
+		if timing.Phases[i].Start < timing.Phases[i-1].Start {
+			t.Errorf("Phases out of order: %+v", timing.Phases)
+		}
+	}
+
+	// The second request reuses the connection
+	resp = client.Send(Request{Method: "GET", URL: url})
+	if _, ok := resp.Timing.Phase(PhaseConnect); ok {
+		t.Errorf("Reused connection has a connect phase: %+v", resp.Timing.Phases)
+	}
+}
+
+func TestSendTimingTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := NewClient(5 * time.Second)
+	if err := client.SetTLS(TLSSettings{InsecureSkipVerify: true}); err != nil {
+		t.Fatalf("SetTLS() error = %v", err)
+	}
+	resp := client.Send(Request{Method: "GET", URL: server.URL})
+	if resp.Error != nil {
+		t.Fatalf("Send() error = %v", resp.Error)
+	}
+	if _, ok := resp.Timing.Phase(PhaseTLS); !ok {
+		t.Errorf("Phases = %+v, missing tls", resp.Timing.Phases)
+	}
+}
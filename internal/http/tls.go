@@ -0,0 +1,86 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// TLSSettings choose how the client verifies servers and which certificate
+// it presents to them
+type TLSSettings struct {
+	// InsecureSkipVerify accepts any server certificate, for self-signed
+	// test servers
+	InsecureSkipVerify bool
+	// CAFile is a PEM bundle trusted on top of the system roots
+	CAFile string
+	// CertFile and KeyFile are the PEM client certificate and key sent for
+	// mutual TLS. Both or neither are set.
+	CertFile string
+	KeyFile  string
+}
+
+// Config builds the tls.Config for the settings, reading the files they
+// name. A leading ~/ in a path is the home directory.
+func (s TLSSettings) Config() (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: s.InsecureSkipVerify, // #nosec G402 -- opt-in for test servers, flagged on every response
+	}
+
+	if path := strings.TrimSpace(s.CAFile); path != "" {
+		pem, err := os.ReadFile(ExpandHome(path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates in CA bundle %s", path)
+		}
+		config.RootCAs = pool
+	}
+
+	certFile, keyFile := strings.TrimSpace(s.CertFile), strings.TrimSpace(s.KeyFile)
+	switch {
+	case certFile == "" && keyFile == "":
+	case certFile == "" || keyFile == "":
+		return nil, fmt.Errorf("a client certificate needs both a certificate and a key file")
+	default:
+		cert, err := tls.LoadX509KeyPair(ExpandHome(certFile), ExpandHome(keyFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// Validate checks that the files the settings name can be loaded
+func (s TLSSettings) Validate() error {
+	_, err := s.Config()
+	return err
+}
+
+// SetTLS applies the TLS settings to the requests of the client. The
+// connection pool starts over.
+func (c *Client) SetTLS(s TLSSettings) error {
+	config, err := s.Config()
+	if err != nil {
+		return err
+	}
+	transport := c.cloneTransport()
+	transport.TLSClientConfig = config
+	c.httpClient.Transport = transport
+	c.tls = s
+	return nil
+}
+
+// TLS returns the TLS settings of the client
+func (c *Client) TLS() TLSSettings {
+	return c.tls
+}
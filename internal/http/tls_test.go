@@ -0,0 +1,159 @@
+package http
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writePEM writes blocks of the given type to a file in dir
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// newClientCert writes a self-signed client certificate and its key to dir
+func newClientCert(t *testing.T, dir string) (certFile, keyFile string, cert *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "godev test client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err = x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return writePEM(t, dir, "client.pem", "CERTIFICATE", der), writePEM(t, dir, "client-key.pem", "EC PRIVATE KEY", keyDER), cert
+}
+
+func TestClientTLSVerification(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("secure"))
+	}))
+	defer server.Close()
+	caFile := writePEM(t, t.TempDir(), "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+
+	client := NewClient(5 * time.Second)
+	if resp := client.Send(Request{Method: "GET", URL: server.URL}); resp.Error == nil {
+		t.Fatal("Expected a self-signed server to be rejected by default")
+	}
+
+	if err := client.SetTLS(TLSSettings{InsecureSkipVerify: true}); err != nil {
+		t.Fatalf("SetTLS() error = %v", err)
+	}
+	resp := client.Send(Request{Method: "GET", URL: server.URL})
+	if resp.Error != nil || resp.Body != "secure" {
+		t.Fatalf("Expected verification to be skipped, got %q, %v", resp.Body, resp.Error)
+	}
+	if resp.Conn == nil || !resp.Conn.InsecureTLS {
+		t.Errorf("Expected the connection to be flagged as unverified, got %+v", resp.Conn)
+	}
+
+	if err := client.SetTLS(TLSSettings{CAFile: caFile}); err != nil {
+		t.Fatalf("SetTLS() error = %v", err)
+	}
+	resp = client.Send(Request{Method: "GET", URL: server.URL})
+	if resp.Error != nil || resp.Body != "secure" {
+		t.Fatalf("Expected the custom CA to be trusted, got %q, %v", resp.Body, resp.Error)
+	}
+	if resp.Conn == nil || resp.Conn.InsecureTLS {
+		t.Errorf("Expected a verified connection, got %+v", resp.Conn)
+	}
+}
+
+func TestClientTLSClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, cert := newClientCert(t, dir)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello " + r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(cert)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	client := NewClient(5 * time.Second)
+	if err := client.SetTLS(TLSSettings{InsecureSkipVerify: true}); err != nil {
+		t.Fatalf("SetTLS() error = %v", err)
+	}
+	if resp := client.Send(Request{Method: "GET", URL: server.URL}); resp.Error == nil {
+		t.Fatalf("Expected the server to require a client certificate, got %q", resp.Body)
+	}
+
+	if err := client.SetTLS(TLSSettings{InsecureSkipVerify: true, CertFile: certFile, KeyFile: keyFile}); err != nil {
+		t.Fatalf("SetTLS() error = %v", err)
+	}
+	resp := client.Send(Request{Method: "GET", URL: server.URL})
+	if resp.Error != nil || resp.Body != "hello godev test client" {
+		t.Errorf("Expected the client certificate to be sent, got %q, %v", resp.Body, resp.Error)
+	}
+}
+
+func TestTLSSettingsValidate(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, _ := newClientCert(t, dir)
+	notPEM := filepath.Join(dir, "not.pem")
+	os.WriteFile(notPEM, []byte("hello"), 0o600)
+
+	tests := []struct {
+		name     string
+		settings TLSSettings
+		wantErr  bool
+	}{
+		{"empty", TLSSettings{}, false},
+		{"client certificate", TLSSettings{CertFile: certFile, KeyFile: keyFile}, false},
+		{"certificate without key", TLSSettings{CertFile: certFile}, true},
+		{"key without certificate", TLSSettings{KeyFile: keyFile}, true},
+		{"missing CA bundle", TLSSettings{CAFile: filepath.Join(dir, "missing.pem")}, true},
+		{"CA bundle without certificates", TLSSettings{CAFile: notPEM}, true},
+		{"key that is no certificate", TLSSettings{CertFile: keyFile, KeyFile: keyFile}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.settings.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	client := NewClient(time.Second)
+	if err := client.SetTLS(TLSSettings{CertFile: certFile}); err == nil {
+		t.Error("Expected SetTLS to reject invalid settings")
+	}
+	if client.TLS() != (TLSSettings{}) {
+		t.Errorf("Expected rejected settings to be ignored, got %+v", client.TLS())
+	}
+}
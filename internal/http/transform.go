@@ -0,0 +1,299 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ApplyTransform runs a jq-style expression over a JSON body and returns the
+// indented result. Supported syntax is a subset of jq:
+//
+//	.                  identity
+//	.data.items[0]     field and index access
+//	.items[]           iterate over an array (or object values)
+//	..                 every value, recursively
+//	{id, name: .n}     object construction
+//	keys, length       builtins
+//	select(.n > 1)     keep inputs passing a comparison with a literal
+//	.data | .items[]   pipes
+//
+// A stage starting with $ is a JSONPath expression, see evalJSONPath.
+// Multiple outputs are printed one after another, like jq does.
+func ApplyTransform(body, expr string) (string, error) {
+	if strings.TrimSpace(expr) == "" {
+		return body, nil
+	}
+	results, err := EvalTransform(body, expr)
+	if err != nil {
+		return "", err
+	}
+	return FormatTransformResults(results)
+}
+
+// EvalTransform runs expr over a JSON body like ApplyTransform and returns
+// its outputs
+func EvalTransform(body, expr string) ([]interface{}, error) {
+	expr = strings.TrimSpace(expr)
+	var data interface{}
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		return nil, fmt.Errorf("response is not valid JSON: %w", err)
+	}
+
+	results := []interface{}{data}
+	for _, stage := range splitTopLevel(expr, '|') {
+		var next []interface{}
+		for _, input := range results {
+			out, err := evalTransformStage(strings.TrimSpace(stage), input)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, out...)
+		}
+		results = next
+	}
+	return results, nil
+}
+
+// FormatTransformResults indents every output of a transform, one after
+// another
+func FormatTransformResults(results []interface{}) (string, error) {
+	parts := make([]string, 0, len(results))
+	for _, result := range results {
+		formatted, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to format result: %w", err)
+		}
+		parts = append(parts, string(formatted))
+	}
+
+	return strings.Join(parts, "\n"), nil
+}
+
+// evalTransformStage evaluates one pipe stage against a single input
+func evalTransformStage(stage string, input interface{}) ([]interface{}, error) {
+	switch {
+	case stage == "":
+		return nil, fmt.Errorf("empty expression in pipe")
+	case stage == "keys":
+		return transformKeys(input)
+	case stage == "length":
+		return transformLength(input)
+	case stage == "..":
+		return descendants([]interface{}{input}), nil
+	case strings.HasPrefix(stage, "select(") && strings.HasSuffix(stage, ")"):
+		return transformSelect(strings.TrimSpace(stage[len("select("):len(stage)-1]), input)
+	case strings.HasPrefix(stage, "$"):
+		return evalJSONPath(stage, input)
+	case strings.HasPrefix(stage, "{"):
+		return transformObject(stage, input)
+	case strings.HasPrefix(stage, "."):
+		return transformPath(stage, input)
+	default:
+		return nil, fmt.Errorf("unsupported expression: %s", stage)
+	}
+}
+
+// transformPath walks a path such as .data.items[0] or .items[].name
+func transformPath(path string, input interface{}) ([]interface{}, error) {
+	current := []interface{}{input}
+	rest := strings.TrimPrefix(path, ".")
+
+	for rest != "" {
+		var next []interface{}
+
+		switch {
+		case strings.HasPrefix(rest, "[]"):
+			for _, value := range current {
+				switch v := value.(type) {
+				case []interface{}:
+					next = append(next, v...)
+				case map[string]interface{}:
+					for _, key := range sortedKeys(v) {
+						next = append(next, v[key])
+					}
+				default:
+					return nil, fmt.Errorf("cannot iterate over %s", jsonTypeName(value))
+				}
+			}
+			rest = rest[2:]
+
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated index in %s", path)
+			}
+			index, err := strconv.Atoi(strings.TrimSpace(rest[1:end]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid index %q in %s", rest[1:end], path)
+			}
+			for _, value := range current {
+				arr, ok := value.([]interface{})
+				if !ok {
+					return nil, fmt.Errorf("cannot index %s with number", jsonTypeName(value))
+				}
+				// Negative indexes count from the end, out of range yields null
+				i := index
+				if i < 0 {
+					i += len(arr)
+				}
+				if i < 0 || i >= len(arr) {
+					next = append(next, nil)
+				} else {
+					next = append(next, arr[i])
+				}
+			}
+			rest = rest[end+1:]
+
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+			continue
+
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			key := rest[:end]
+			for _, value := range current {
+				switch v := value.(type) {
+				case map[string]interface{}:
+					next = append(next, v[key])
+				case nil:
+					next = append(next, nil)
+				default:
+					return nil, fmt.Errorf("cannot index %s with %q", jsonTypeName(value), key)
+				}
+			}
+			rest = rest[end:]
+		}
+
+		current = next
+	}
+
+	return current, nil
+}
+
+// transformSelect passes input on when it meets cond, like
+// select(.status == "failed"); a path that does not apply to input fails
+// the condition
+func transformSelect(cond string, input interface{}) ([]interface{}, error) {
+	filter, err := parseFilter(cond)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(filter.path, ".") {
+		return nil, fmt.Errorf("select must test a path like .status: %s", filter.path)
+	}
+	values, err := transformPath(filter.path, input)
+	if err != nil || !filter.holds(values) {
+		return nil, nil
+	}
+	return []interface{}{input}, nil
+}
+
+// transformObject builds an object from "{a, b: .path}" style fields
+func transformObject(expr string, input interface{}) ([]interface{}, error) {
+	if !strings.HasSuffix(expr, "}") {
+		return nil, fmt.Errorf("unterminated object in %s", expr)
+	}
+
+	result := make(map[string]interface{})
+	inner := strings.TrimSpace(expr[1 : len(expr)-1])
+	if inner == "" {
+		return []interface{}{result}, nil
+	}
+
+	for _, field := range splitTopLevel(inner, ',') {
+		field = strings.TrimSpace(field)
+		key, valueExpr, hasValue := strings.Cut(field, ":")
+		key = strings.Trim(strings.TrimSpace(key), `"`)
+		if !hasValue {
+			valueExpr = "." + key
+		}
+
+		values, err := evalTransformStage(strings.TrimSpace(valueExpr), input)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != 1 {
+			return nil, fmt.Errorf("field %s must produce exactly one value", key)
+		}
+		result[key] = values[0]
+	}
+
+	return []interface{}{result}, nil
+}
+
+// transformKeys returns the sorted keys of an object or the indexes of an array
+func transformKeys(input interface{}) ([]interface{}, error) {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		keys := make([]interface{}, 0, len(v))
+		for _, key := range sortedKeys(v) {
+			keys = append(keys, key)
+		}
+		return []interface{}{keys}, nil
+	case []interface{}:
+		keys := make([]interface{}, len(v))
+		for i := range v {
+			keys[i] = float64(i)
+		}
+		return []interface{}{keys}, nil
+	default:
+		return nil, fmt.Errorf("%s has no keys", jsonTypeName(input))
+	}
+}
+
+// transformLength returns the length of a string, array or object
+func transformLength(input interface{}) ([]interface{}, error) {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		return []interface{}{float64(len(v))}, nil
+	case []interface{}:
+		return []interface{}{float64(len(v))}, nil
+	case string:
+		return []interface{}{float64(len([]rune(v)))}, nil
+	case nil:
+		return []interface{}{float64(0)}, nil
+	default:
+		return nil, fmt.Errorf("%s has no length", jsonTypeName(input))
+	}
+}
+
+// sortedKeys returns the keys of an object in a stable order
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// splitTopLevel splits s on sep, ignoring separators inside braces, brackets or quotes
+func splitTopLevel(s string, sep rune) []string {
+	var parts []string
+	depth := 0
+	inString := false
+	start := 0
+
+	for i, r := range s {
+		switch {
+		case r == '"':
+			inString = !inString
+		case inString:
+		case r == '{' || r == '[':
+			depth++
+		case r == '}' || r == ']':
+			depth--
+		case r == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
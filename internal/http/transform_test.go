@@ -0,0 +1,71 @@
+package http
+
+import (
+	"strings"
+	"testing"
+)
+
+const transformTestBody = `{"status": "ok", "data": {"items": [{"id": 1, "name": "a"}, {"id": 2, "name": "b"}], "total": 2}}`
+
+func TestApplyTransform(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want string
+	}{
+		{"identity", ".", `"status": "ok"`},
+		{"field path", ".data.total", "2"},
+		{"index", ".data.items[1].name", `"b"`},
+		{"negative index", ".data.items[-1].id", "2"},
+		{"iterate", ".data.items[].name", "\"a\"\n\"b\""},
+		{"pipe", ".data | .items[0] | .id", "1"},
+		{"object construction", ".data.items[0] | {name, key: .id}", `"key": 1`},
+		{"length", ".data.items | length", "2"},
+		{"keys", ".data | keys", "\"items\",\n  \"total\""},
+		{"missing field", ".data.missing", "null"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyTransform(transformTestBody, tt.expr)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("ApplyTransform(%q) = %q, want it to contain %q", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyTransformEmptyExpression(t *testing.T) {
+	got, err := ApplyTransform("not json", "  ")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got != "not json" {
+		t.Errorf("Expected body to be returned unchanged, got %q", got)
+	}
+}
+
+func TestApplyTransformErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		expr string
+	}{
+		{"invalid json", "not json", ".data"},
+		{"unsupported expression", transformTestBody, "map(.id)"},
+		{"iterate over string", transformTestBody, ".status[]"},
+		{"index object with number", transformTestBody, ".data[0]"},
+		{"empty pipe stage", transformTestBody, ".data | "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ApplyTransform(tt.body, tt.expr); err == nil {
+				t.Errorf("Expected error for %q", tt.expr)
+			}
+		})
+	}
+}
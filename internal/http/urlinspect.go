@@ -0,0 +1,216 @@
+package http
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// QueryParam is one query parameter in the order it appears in the URL
+type QueryParam struct {
+	Key   string
+	Value string
+}
+
+// URLInspection breaks a URL into the parts the server will see and lists
+// the encoding surprises in it
+type URLInspection struct {
+	Scheme string
+	// User is the username of credentials embedded in the URL
+	User string
+	// Host is the host as typed; ASCIIHost is what is sent, with
+	// internationalized labels in punycode
+	Host      string
+	ASCIIHost string
+	Port      string
+	// Segments are the decoded path segments
+	Segments []string
+	Query    []QueryParam
+	Fragment string
+	// Sent is the URL as the client puts it on the wire
+	Sent     string
+	Warnings []string
+}
+
+// doubleEncoded matches an escaped percent sign followed by a hex pair,
+// such as %2520, which decodes to another escape
+var doubleEncoded = regexp.MustCompile(`%25[0-9A-Fa-f]{2}`)
+
+// InspectURL parses rawURL and reports how it will be sent
+func InspectURL(rawURL string) (*URLInspection, error) {
+	var warnings []string
+	if trimmed := strings.TrimSpace(rawURL); trimmed != rawURL {
+		warnings = append(warnings, "The URL has leading or trailing whitespace; it is removed before sending")
+		rawURL = trimmed
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	if parsed.Host == "" {
+		return nil, fmt.Errorf("url must include a scheme and host, like https://example.com")
+	}
+
+	inspection := &URLInspection{
+		Scheme:   parsed.Scheme,
+		Host:     parsed.Hostname(),
+		Port:     parsed.Port(),
+		Fragment: parsed.Fragment,
+	}
+
+	inspection.ASCIIHost, err = ToASCIIHost(inspection.Host)
+	if err != nil {
+		return nil, err
+	}
+	if inspection.ASCIIHost != inspection.Host {
+		warnings = append(warnings, fmt.Sprintf("Host %q is internationalized and is sent as %s", inspection.Host, inspection.ASCIIHost))
+	}
+
+	if parsed.User != nil {
+		inspection.User = parsed.User.Username()
+		warnings = append(warnings, "Credentials in the URL are sent as a Basic Authorization header and end up in history")
+	}
+
+	rawPath := parsed.RawPath
+	if rawPath == "" {
+		rawPath = parsed.EscapedPath()
+	}
+	warnings = append(warnings, pathWarnings(rawPath)...)
+	for _, segment := range strings.Split(strings.TrimPrefix(parsed.Path, "/"), "/") {
+		inspection.Segments = append(inspection.Segments, segment)
+	}
+	if len(inspection.Segments) == 1 && inspection.Segments[0] == "" {
+		inspection.Segments = nil
+	}
+
+	inspection.Query = parseQueryInOrder(parsed.RawQuery)
+	warnings = append(warnings, queryWarnings(parsed.RawQuery)...)
+
+	if parsed.Fragment != "" || strings.HasSuffix(rawURL, "#") {
+		warnings = append(warnings, fmt.Sprintf("The fragment #%s stays in the client and is not sent to the server", parsed.Fragment))
+	}
+
+	host := inspection.ASCIIHost
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if inspection.Port != "" {
+		host += ":" + inspection.Port
+	}
+	inspection.Sent = parsed.Scheme + "://" + host + parsed.EscapedPath()
+	if parsed.RawQuery != "" || parsed.ForceQuery {
+		inspection.Sent += "?" + parsed.RawQuery
+	}
+
+	inspection.Warnings = warnings
+	return inspection, nil
+}
+
+// pathWarnings lists the characters of an escaped-as-typed path that the
+// client will percent-encode and escapes that servers treat inconsistently
+func pathWarnings(rawPath string) []string {
+	var warnings []string
+	for _, c := range unsafeChars(rawPath, "/") {
+		warnings = append(warnings, fmt.Sprintf("%s in the path is sent as %s", describeChar(c), percentEncode(c)))
+	}
+	if strings.Contains(strings.ToUpper(rawPath), "%2F") {
+		warnings = append(warnings, "%2F in the path is an encoded slash; some servers and proxies decode it and route to a different path")
+	}
+	if doubleEncoded.MatchString(rawPath) {
+		warnings = append(warnings, "The path looks double-encoded (%25 followed by hex); the server sees a literal percent sign")
+	}
+	if strings.Contains(strings.TrimPrefix(rawPath, "/"), "//") {
+		warnings = append(warnings, "The path has an empty segment (//); many routers do not match it")
+	}
+	return warnings
+}
+
+// queryWarnings lists characters the client sends in the query unencoded,
+// which servers reject or read differently
+func queryWarnings(rawQuery string) []string {
+	var warnings []string
+	for _, c := range unsafeChars(rawQuery, "/?") {
+		warnings = append(warnings, fmt.Sprintf("%s in the query is sent unencoded and many servers reject the request; encode it as %s", describeChar(c), percentEncode(c)))
+	}
+	if strings.Contains(rawQuery, "+") {
+		warnings = append(warnings, "+ in the query is read as a space by most servers; use %2B for a literal plus")
+	}
+	if doubleEncoded.MatchString(rawQuery) {
+		warnings = append(warnings, "The query looks double-encoded (%25 followed by hex); the server sees a literal percent sign")
+	}
+	return warnings
+}
+
+// unsafeChars returns, in order of first appearance, the characters of s
+// that are not allowed unencoded in a URL component. Besides unreserved
+// characters, sub-delimiters, ':' and '@', extra lists what the component
+// also allows; '%' is allowed as part of escapes.
+func unsafeChars(s, extra string) []string {
+	seen := make(map[string]bool)
+	var chars []string
+	for i, r := range s {
+		if r == '%' || isURLSafe(r) || strings.ContainsRune(extra, r) {
+			continue
+		}
+		c := string(r)
+		if r == utf8.RuneError {
+			c = s[i : i+1]
+		}
+		if !seen[c] {
+			seen[c] = true
+			chars = append(chars, c)
+		}
+	}
+	return chars
+}
+
+func isURLSafe(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	}
+	return strings.ContainsRune("-._~!$&'()*+,;=:@", r)
+}
+
+func percentEncode(c string) string {
+	var b strings.Builder
+	for i := 0; i < len(c); i++ {
+		fmt.Fprintf(&b, "%%%02X", c[i])
+	}
+	return b.String()
+}
+
+func describeChar(c string) string {
+	switch c {
+	case " ":
+		return "A space"
+	case "\t":
+		return "A tab"
+	}
+	return fmt.Sprintf("%q", c)
+}
+
+// parseQueryInOrder decodes the query parameters keeping their order and
+// duplicates; undecodable parts are shown as typed
+func parseQueryInOrder(rawQuery string) []QueryParam {
+	var params []QueryParam
+	for _, part := range strings.Split(rawQuery, "&") {
+		if part == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(part, "=")
+		params = append(params, QueryParam{Key: queryUnescape(key), Value: queryUnescape(value)})
+	}
+	return params
+}
+
+func queryUnescape(s string) string {
+	decoded, err := url.QueryUnescape(s)
+	if err != nil {
+		return s
+	}
+	return decoded
+}
@@ -0,0 +1,91 @@
+package http
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToASCIIHost(t *testing.T) {
+	tests := map[string]string{
+		"example.com":    "example.com",
+		"münchen.de":     "xn--mnchen-3ya.de",
+		"Bücher.example": "xn--bcher-kva.example",
+		"例え.テスト":         "xn--r8jz45g.xn--zckzah",
+		"пример.рф":      "xn--e1afmkfd.xn--p1ai",
+		"abc-ü.io":       "xn--abc--3ra.io",
+	}
+	for host, want := range tests {
+		got, err := ToASCIIHost(host)
+		if err != nil || got != want {
+			t.Errorf("ToASCIIHost(%q) = %q, %v, want %q", host, got, err, want)
+		}
+	}
+}
+
+func TestInspectURL(t *testing.T) {
+	inspection, err := InspectURL("https://user@bücher.example:8443/files/my report/ü?q=a b&tag=c%2B%2B&sum=1+2&tag=x#top")
+	if err != nil {
+		t.Fatalf("InspectURL() error = %v", err)
+	}
+
+	if inspection.ASCIIHost != "xn--bcher-kva.example" || inspection.Port != "8443" || inspection.User != "user" {
+		t.Errorf("Unexpected host parts: %+v", inspection)
+	}
+	if got := strings.Join(inspection.Segments, "|"); got != "files|my report|ü" {
+		t.Errorf("Segments = %q", got)
+	}
+	wantQuery := []QueryParam{{"q", "a b"}, {"tag", "c++"}, {"sum", "1 2"}, {"tag", "x"}}
+	if len(inspection.Query) != len(wantQuery) {
+		t.Fatalf("Query = %+v, want %+v", inspection.Query, wantQuery)
+	}
+	for i, want := range wantQuery {
+		if inspection.Query[i] != want {
+			t.Errorf("Query[%d] = %+v, want %+v", i, inspection.Query[i], want)
+		}
+	}
+
+	wantSent := "https://xn--bcher-kva.example:8443/files/my%20report/%C3%BC?q=a b&tag=c%2B%2B&sum=1+2&tag=x"
+	if inspection.Sent != wantSent {
+		t.Errorf("Sent = %s, want %s", inspection.Sent, wantSent)
+	}
+
+	warnings := strings.Join(inspection.Warnings, "\n")
+	for _, want := range []string{
+		"sent as xn--bcher-kva.example",
+		"Basic Authorization header",
+		"A space in the path is sent as %20",
+		`"ü" in the path is sent as %C3%BC`,
+		"A space in the query is sent unencoded",
+		"use %2B for a literal plus",
+		"#top stays in the client",
+	} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("Expected a warning containing %q, got:\n%s", want, warnings)
+		}
+	}
+}
+
+func TestInspectURLClean(t *testing.T) {
+	inspection, err := InspectURL("https://api.example.com/v1/users?page=2&sort=name")
+	if err != nil {
+		t.Fatalf("InspectURL() error = %v", err)
+	}
+	if len(inspection.Warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", inspection.Warnings)
+	}
+	if inspection.Sent != "https://api.example.com/v1/users?page=2&sort=name" {
+		t.Errorf("Sent = %s", inspection.Sent)
+	}
+
+	inspection, err = InspectURL("https://api.example.com/a%2Fb//c/%2520")
+	if err != nil {
+		t.Fatalf("InspectURL() error = %v", err)
+	}
+	if len(inspection.Warnings) != 3 {
+		t.Errorf("Expected encoded slash, empty segment and double-encoding warnings, got %v", inspection.Warnings)
+	}
+
+	if _, err := InspectURL("/relative/path"); err == nil {
+		t.Error("Expected an error for a URL without host")
+	}
+}
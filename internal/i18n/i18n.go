@@ -0,0 +1,70 @@
+// Package i18n holds the translated user interface strings
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Lang is a supported interface language
+type Lang string
+
+const (
+	English      Lang = "en"
+	PortugueseBR Lang = "pt-BR"
+)
+
+var current = English
+
+// Languages returns the supported languages
+func Languages() []Lang {
+	return []Lang{English, PortugueseBR}
+}
+
+// Parse matches a language tag such as en, en_US, pt, pt-BR or pt_BR.UTF-8
+func Parse(tag string) (Lang, error) {
+	tag = strings.TrimSpace(tag)
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+
+	switch strings.ToLower(strings.ReplaceAll(tag, "_", "-")) {
+	case "", "en", "en-us", "en-gb", "c", "posix":
+		return English, nil
+	case "pt", "pt-br":
+		return PortugueseBR, nil
+	}
+	return "", fmt.Errorf("unsupported language %q (supported: en, pt-BR)", tag)
+}
+
+// SetLanguage selects the language used by T and Tf
+func SetLanguage(tag string) error {
+	lang, err := Parse(tag)
+	if err != nil {
+		return err
+	}
+	current = lang
+	return nil
+}
+
+// Language returns the selected language
+func Language() Lang {
+	return current
+}
+
+// T returns the message for key in the selected language, falling back to
+// English and then to the key itself
+func T(key string) string {
+	if msg, ok := messages[current][key]; ok {
+		return msg
+	}
+	if msg, ok := messages[English][key]; ok {
+		return msg
+	}
+	return key
+}
+
+// Tf formats the message for key with args
+func Tf(key string, args ...interface{}) string {
+	return fmt.Sprintf(T(key), args...)
+}
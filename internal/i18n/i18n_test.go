@@ -0,0 +1,88 @@
+package i18n
+
+import (
+	"regexp"
+	"testing"
+)
+
+var verbPattern = regexp.MustCompile(`%[a-z]`)
+
+func TestCatalogComplete(t *testing.T) {
+	for _, lang := range Languages() {
+		if _, ok := messages[lang]; !ok {
+			t.Fatalf("No messages for %s", lang)
+		}
+	}
+
+	for key, english := range messages[English] {
+		for _, lang := range Languages() {
+			translated, ok := messages[lang][key]
+			if !ok {
+				t.Errorf("%s is missing %q", lang, key)
+				continue
+			}
+
+			want := verbPattern.FindAllString(english, -1)
+			got := verbPattern.FindAllString(translated, -1)
+			if len(want) != len(got) {
+				t.Errorf("%s %q has verbs %v, English has %v", lang, key, got, want)
+				continue
+			}
+			for i := range want {
+				if want[i] != got[i] {
+					t.Errorf("%s %q has verbs %v, English has %v", lang, key, got, want)
+					break
+				}
+			}
+		}
+	}
+
+	for _, lang := range Languages() {
+		for key := range messages[lang] {
+			if _, ok := messages[English][key]; !ok {
+				t.Errorf("%s has %q which English does not", lang, key)
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := map[string]Lang{
+		"":            English,
+		"en":          English,
+		"en_US.UTF-8": English,
+		"pt":          PortugueseBR,
+		"pt-BR":       PortugueseBR,
+		"pt_BR.UTF-8": PortugueseBR,
+	}
+	for tag, want := range tests {
+		got, err := Parse(tag)
+		if err != nil || got != want {
+			t.Errorf("Parse(%q) = %q, %v, want %q", tag, got, err, want)
+		}
+	}
+
+	if _, err := Parse("fr"); err == nil {
+		t.Error("Expected error for unsupported language")
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	defer SetLanguage("en")
+
+	if got := Tf("title.trash", 3); got != "Trash (3)" {
+		t.Errorf("Tf() = %q", got)
+	}
+
+	if err := SetLanguage("pt_BR"); err != nil {
+		t.Fatalf("SetLanguage() error = %v", err)
+	}
+	if got := Tf("title.trash", 3); got != "Lixeira (3)" {
+		t.Errorf("Tf() = %q", got)
+	}
+
+	// Unknown keys are shown as is so a missing entry is easy to spot
+	if got := T("no.such.key"); got != "no.such.key" {
+		t.Errorf("T() = %q", got)
+	}
+}
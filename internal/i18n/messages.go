@@ -0,0 +1,1781 @@
+package i18n
+
+// messages is the catalog of user interface strings. Every key must exist in
+// every language; formatting verbs must match across translations.
+var messages = map[Lang]map[string]string{
+	English: {
+		// Home screen
+		"home.subtitle":       "Professional API Testing & Database Tool",
+		"home.workspace":      "Workspace: %s",
+		"home.select_mode":    "SELECT MODE",
+		"home.api_mode":       "[ 1 ] API Testing (HTTP)",
+		"home.api_mode_desc":  "      Test REST APIs, GraphQL & WebSocket",
+		"home.db_mode":        "[ 2 ] Database Explorer (SQL)",
+		"home.db_mode_desc":   "      PostgreSQL queries, schema browser & more",
+		"home.raw_mode":       "[ 3 ] Raw Socket (TCP/TLS/UDP)",
+		"home.raw_mode_desc":  "      Send bytes to services that do not speak HTTP",
+		"home.dns_mode":       "[ 4 ] DNS Lookup",
+		"home.dns_mode_desc":  "      A, AAAA, CNAME, TXT, SRV, MX and NS records",
+		"home.util_mode":      "[ 5 ] Utilities",
+		"home.util_mode_desc": "      Base64, URL and hex encoding, hashes and UUIDs",
+		"home.time_mode":      "[ 6 ] Timestamps",
+		"home.time_mode_desc": "      Epoch seconds and milliseconds to ISO-8601 in several time zones",
+		"home.features":       "Features: Environment Variables • cURL Import • Request Collections • Query History",
+		"footer.home":         "1: API Mode • 2: Database Mode • 3: Raw socket • 4: DNS lookup • 5: Utilities • 6: Timestamps • p: Proxy • L: TLS • w: Workspaces • t: Trash • o: Tour • n: What's new • u: Usage stats • ?: Help • Q: Quit",
+		"title.saved":         " [SAVED]",
+		"title.autosave":      " [AUTO-SAVE]",
+		"title.new_conn":      " [NEW CONN]",
+		"title.auth":          " [AUTH: %s]",
+		"title.cookie_jar":    " [COOKIES: %d]",
+		"title.proxy":         " [PROXY: %s]",
+		"title.env":           " [ENV: %s]",
+		"title.executing":     "Executing Query",
+		"loading.query":       "Executing query...",
+		"loading.query_hint":  "Please wait while the database processes your query",
+		"title.connecting":    "Connecting to Database",
+		"loading.schema":      "Loading database schema...",
+		"loading.schema_hint": "Fetching tables and database information",
+		"title.sending":       "Sending Request",
+
+		// Help screen
+		"help.title":           "GoDev - Help",
+		"help.global":          "Global Shortcuts:",
+		"help.quit":            "Quit application",
+		"help.show_help":       "Show this help",
+		"help.back":            "Back/Cancel",
+		"help.next_field":      "Next field",
+		"help.request_builder": "Request Builder:",
+		"help.send":            "Send request",
+		"help.load_saved":      "Load saved requests",
+		"help.history":         "View request history",
+		"help.change_method":   "Change method",
+		"help.graphql_schema":  "Browse GraphQL schema",
+		"help.graphql_ops":     "GraphQL operation library",
+		"help.graphql_mode":    "GraphQL mode: edit the body as a query and variables",
+		"help.templates":       "Start from a request template",
+		"help.bulk":            "Run method/headers against a URL list",
+		"help.variant":         "Save as a variant of the loaded request",
+		"help.signing":         "HMAC request signing with a string-to-sign preview",
+		"help.auth":            "Basic, Bearer or API key auth attached to every send",
+		"help.dns":             "Look up the DNS records of the request host",
+		"help.cookies":         "Cookie jar: keep cookies between requests, view, edit or delete them",
+		"help.jwt":             "Decode the JWTs in the request headers",
+		"help.utilities":       "Encode, decode and hash text from the clipboard",
+		"help.proxy":           "Proxy settings: global, per environment or from HTTP_PROXY",
+		"help.tls":             "TLS settings: skip verification, custom CA, client certificate",
+		"help.inspect":         "Inspect the URL as sent: host, path, query, encoding",
+		"help.curl_import":     "Import a curl command",
+		"help.download":        "Download responses to a file, resuming partial files",
+		"help.new_conn":        "Toggle sending on a new connection instead of a kept-alive one",
+		"help.record":          "Record a session / stop and save it as a collection",
+		"help.response_view":   "Response View:",
+		"help.save_request":    "Save request",
+		"help.plugin_viewer":   "Render with viewer plugin",
+		"help.fix_resend":      "Fix a 4xx request and resend it",
+		"help.assertions":      "Assertions checked on every response",
+		"help.extractions":     "Extract variables from every response into the environment",
+		"help.save_body":       "Save the raw response body to a file",
+		"help.split":           "Pin for side-by-side / compare with the pinned one",
+		"help.golden":          "Pin response as golden / show diff against it",
+		"help.volatile":        "Fields ignored when diffing against golden",
+		"help.timestamps":      "Convert the timestamps in the response between epoch and ISO-8601",
+		"help.scroll":          "Scroll",
+		"help.request_list":    "Request List:",
+		"help.load_request":    "Load request",
+		"help.trash_request":   "Move request to trash (restore with t on the home screen)",
+		"help.new_request":     "New request",
+		"help.group_hosts":     "Group by host",
+		"help.collections":     "Manage collections",
+		"help.run_all":         "Run every saved request in order",
+		"help.density":         "Compact or detailed lists (one or two lines per item)",
+		"help.close":           "Press any key to close",
+
+		// Screen titles
+		"title.saved_requests": "Saved Requests (%d)",
+		"title.history":        "Request History (%d)",
+		"title.database":       "Database Explorer (PostgreSQL)",
+		"title.db_connect":     "Connect to PostgreSQL Database",
+		"title.query_editor":   "SQL Query Editor",
+		"title.saved_queries":  "Saved Queries (%d)",
+		"title.schema":         "Database Schema",
+		"title.query_history":  "Query History (%d)",
+		"title.export":         "Export Query Results",
+		"title.environments":   "Environment Variables (%d)",
+		"title.active_env":     " | Active: %s",
+		"title.new_env":        "New Environment",
+		"title.edit_env":       "Environment: %s",
+		"title.trash":          "Trash (%d)",
+		"title.bookmarks":      "Bookmarks (%d)",
+		"title.storage":        "Storage unavailable",
+		"title.assertions":     "Assertions (%d)",
+		"title.extractions":    "Extract Variables (%d)",
+		"title.signing":        "Request Signing (HMAC)",
+		"title.inspect":        "URL Inspector",
+		"title.aliases":        "Service Aliases: %s (%d)",
+		"title.collections":    "Collections (%d)",
+		"title.collection":     "Collection: %s (%d requests)",
+		"title.stats":          "Usage Statistics",
+		"title.curl_import":    "Import cURL",
+
+		// Footers
+		"footer.builder":       "Ctrl+H: help • Ctrl+Enter: send • Ctrl+L: load • Ctrl+R: history • Ctrl+D: database • Ctrl+E: env • h: headers • b: body • q: query • g: GraphQL schema • G: GraphQL mode • o: GraphQL ops • u: bulk URLs • s: save • v: save variant • a: HMAC signing • A: auth • t: timeout & retries • d: DNS lookup • K: cookies • J: decode JWT • U: utilities • P: proxy • L: TLS • i: inspect URL • c: import cURL • f: download to file • N: new connection • T: templates • R: record session • x: cURL",
+		"footer.response":      "Esc: back • s: save • c: copy response • x: copy as cURL • h: toggle headers • t: filter (jq/JSONPath) • /: search (n/N) • C: colors on/off • b: budget • p: parallel compare • f: follow pages • v: viewer plugin • e: fix and resend • J: decode JWT • U: encode/hash body • T: timestamps • a: assertions • E: extract variables • w: save body • Q: SQL table from JSON • W: timing • |: side by side • G: pin golden • g: golden diff • i: volatile fields • ↑↓/PgUp/PgDn: scroll",
+		"footer.request_list":  "↑↓: navigate • /: search • Enter: load • d: delete • n: new • g: group by host • c: collections • r: run all • Esc: back",
+		"footer.history":       "↑↓: navigate • Enter: load • s: save as request • d: delete item • b: bookmark • n: note • B: bookmarks • m: mark • D: diff marked • e: this environment only • c: clear all • r: replay smoke test • Esc: back",
+		"footer.database":      "q: query • s: schema • l: saved queries • f: find value • h: history • d: disconnect • Esc: back",
+		"footer.db_connect":    "Tab: next field • Enter: connect • Esc: cancel",
+		"footer.query_editor":  "Ctrl+K: execute • Ctrl+S: save query • Ctrl+O: snippets • Ctrl+G: environment variables • Esc: back",
+		"footer.saved_queries": "↑↓: navigate • Enter: load • d: move to trash • Esc: back",
+		"footer.schema":        "↑↓: navigate • Enter: view columns • S/I/U: select/insert/update snippet • f: find value • q: query editor • l: saved queries • Esc: back",
+		"footer.query_history": "↑↓: navigate • Enter: load • d: delete item • c: clear all • Esc: back",
+		"footer.export":        "↑↓: select format • Tab: edit table name • Enter: export • Esc: cancel",
+		"footer.environments":  "↑↓: navigate • Enter: edit • n: new • s: set active • d: delete • Esc: back",
+		"footer.env_new":       "Tab: next field • Enter: save • Esc: cancel",
+		"footer.env_save":      "Ctrl+S: save environment • Esc: back",
+		"footer.env_vars":      "↑↓: navigate • n: add variable • e: edit • d: delete • l: aliases • Esc: back",
+		"footer.trash":         "↑↓: navigate • Enter/r: restore • d: delete permanently • D: empty trash • Esc: back",
+		"footer.fix_body":      "Ctrl+S: save & resend • Esc: cancel",
+		"footer.bookmarks":     "↑↓: navigate • Enter: load • n: edit note • b: remove bookmark • x: export Markdown • Esc: back",
+		"footer.storage":       "r: retry • c: choose another directory • Enter: continue without saving • q: quit",
+		"footer.storage_dir":   "Enter: open directory • Esc: cancel",
+		"footer.fix_headers":   "↑↓: navigate • n: add • e: edit • d: delete • Ctrl+S: resend • Esc: cancel",
+		"footer.assertions":    "↑↓: navigate • n: new assertion • d: delete • Esc: back",
+		"footer.extractions":   "↑↓: navigate • n: new extraction • d: delete • Esc: back",
+		"footer.extract_form":  "Tab: complete / next field • ↑↓: field • ←→: source • Enter/Ctrl+S: save • Esc: cancel",
+		"footer.signing":       "Tab/↑↓: field • ←→: change option • Enter/Ctrl+S: save • Ctrl+D: remove signing • Esc: back",
+		"footer.inspect":       "Esc: back",
+		"footer.aliases":       "↑↓: navigate • n: add alias • e: edit • d: delete • Esc: back",
+		"footer.collections":   "↑↓: navigate • Enter: open • r: run • n: new collection • e: edit • d: delete • Esc: back",
+		"footer.collection":    "↑↓: navigate • Enter: load • r: run • a: add saved request • m: move • c: copy • d: remove • Esc: collections",
+		"footer.stats":         "r: refresh • Esc: back",
+		"footer.curl_import":   "Ctrl+S: import into the builder • Esc: cancel",
+		"footer.assert_build":  "↑↓: choose • Tab: complete • Enter: next • Esc: cancel",
+
+		// Confirmations
+		"confirm.delete_request":   "⚠ Delete '%s'? Press 'y' to confirm, 'Esc' to cancel",
+		"confirm.delete_variants":  "⚠ Delete '%s' and its %d variants? Press 'y' to confirm, 'Esc' to cancel",
+		"confirm.clear_history":    "⚠ Clear all history except bookmarks? Press 'y' to confirm, 'Esc' to cancel",
+		"confirm.delete_env":       "⚠ Delete environment '%s'? Press 'y' to confirm, 'Esc' to cancel",
+		"confirm.delete_variable":  "⚠ Delete variable '%s'? Press 'y' to confirm, 'Esc' to cancel",
+		"confirm.purge_trash_item": "⚠ Permanently delete '%s'? Press 'y' to confirm, 'Esc' to cancel",
+		"confirm.empty_trash":      "⚠ Permanently delete all %d items? Press 'y' to confirm, 'Esc' to cancel",
+		"confirm.delete_alias":     "⚠ Delete alias '%s'? Press 'y' to confirm, 'Esc' to cancel",
+
+		// Request groups
+		"requests.grouped_hint": "←/h: collapse • →/l: expand • Enter/Space on a host: toggle",
+
+		// Onboarding
+		"title.tour":       "Welcome to GoDev (%d/%d)",
+		"title.whats_new":  "What's New in GoDev v%s",
+		"footer.tour":      "→/Enter: next • ←: back • Esc: skip tour",
+		"footer.tour_done": "Enter: start using GoDev • ←: back",
+		"footer.whats_new": "↑↓: scroll • Enter/Esc: close",
+		"whats_new.empty":  "No release notes for this version.",
+		"whats_new.more":   "↓ %d more lines",
+		"tour.0.title":     "Two tools in one",
+		"tour.0.body":      "GoDev tests HTTP APIs and explores PostgreSQL databases from the terminal.\nOn the home screen, 1 opens API testing and 2 the database explorer.\nw switches workspaces to keep clients or projects apart.",
+		"tour.1.title":     "Building requests",
+		"tour.1.body":      "Type a URL and press Enter to send it; ←/→ change the method.\nh edits headers, b the body and q the query parameters.\nTab moves between fields and Ctrl+H lists every shortcut.",
+		"tour.2.title":     "Reading responses",
+		"tour.2.body":      "The response opens when the request finishes: ↑↓ scroll, h toggles the headers and c copies the body.\ns saves the request, a adds assertions and G pins a golden response to diff later runs against.",
+		"tour.3.title":     "Saved requests and history",
+		"tour.3.body":      "Ctrl+L lists saved requests: / searches them and g groups them by host.\nCtrl+R opens the history of every request sent, ready to load, bookmark or replay.",
+		"tour.4.title":     "Environments",
+		"tour.4.body":      "Ctrl+E manages environments. Variables written as {{API_URL}} in URLs, headers and bodies are replaced with the values of the active environment when a request is sent.",
+		"tour.5.title":     "Database explorer",
+		"tour.5.body":      "Ctrl+D, or 2 on the home screen, connects to PostgreSQL.\nWrite SQL in the query editor, browse the schema, keep favorite queries and export results to CSV or JSON.",
+		"tour.6.title":     "You're all set",
+		"tour.6.body":      "Press ? or F1 for help at any time.\nOn the home screen, o shows this tour again and n shows what's new in this version.",
+
+		// Environment-scoped history
+		"history.env_scope": " • environment: %s",
+		"history.no_env":    "none",
+
+		// Auto-save
+		"autosave.created": "✓ Auto-saved as a new request",
+		"autosave.updated": "✓ Auto-saved: updated the saved request with the same method and URL",
+
+		// Trash screen
+		"trash.subtitle": "Deleted requests, queries and environments stay here until removed for good",
+		"trash.empty":    "Trash is empty",
+		"trash.deleted":  "deleted %s",
+
+		// Fix and resend
+		"fix.pinned": "Server said",
+		"fix.hint":   "e: fix and resend",
+
+		// Bookmarks
+		"bookmarks.empty":       "No bookmarks yet. Press b on a history entry to bookmark it",
+		"bookmarks.no_note":     "(no note, press n to add one)",
+		"bookmarks.note_prompt": "Note (Enter: save • Esc: cancel):",
+		"bookmarks.exported":    "✓ Exported to %s",
+
+		// Assertions
+		"assertions.empty":      "No assertions yet. Press n to build one from this response",
+		"assertions.save_first": "Save the request first (s) to add assertions",
+		"assertions.summary":    "Assertions: %d/%d passed",
+		"assertions.draft":      "New: %s",
+		"assertions.pick_type":  "What should be checked?",
+		"assertions.pick_field": "Header or JSON path (type to filter the last response):",
+		"assertions.pick_op":    "How should it compare?",
+		"assertions.value":      "Expected value (prefilled from the last response):",
+		"assertions.regex":      "Regular expression:",
+		"assertions.no_suggest": "No suggestions from the last response; type the name or path",
+		"assertions.need_field": "Enter a header name or JSON path",
+
+		// Latency budgets
+		"budget.save_first": "Save the request first (s) to set a latency budget",
+		"budget.exceeded":   "⚠ %s exceeds the %dms latency budget",
+		"budget.prompt":     "Latency budget in ms (Enter: save • Esc: cancel • empty: remove):",
+
+		// Golden responses
+		"golden.matches":    "✓ Matches the golden response pinned %s",
+		"golden.differs":    "⚠ Differs from the golden response (%s) • g: details • G: pin this one",
+		"golden.save_first": "Save the request first (s) to pin a golden response",
+		"golden.volatile":   "Volatile fields, comma-separated, e.g. updated_at, $.data[*].id, header:X-Trace (Enter: save • Esc: cancel):",
+
+		// HMAC signing
+		"signing.active":       "signing every send",
+		"signing.saved":        "✓ Signing saved; every send of this request is signed",
+		"signing.removed":      "✓ Signing removed",
+		"signing.placeholders": "Placeholders: %s • \\n is a newline",
+		"signing.preview":      "Preview for the current request:",
+		"signing.signature":    "Signature: ",
+		"signing.sent_as":      "Sent as:   ",
+
+		// Auth helper
+		"title.auth_panel":     "Request Auth",
+		"footer.auth":          "Tab/↑↓: next field • ←/→: change option • Enter/Ctrl+S: save • Ctrl+D: remove auth • Esc: back",
+		"auth.active":          "%s credentials attached",
+		"auth.saved":           "✓ Auth saved; every send of this request carries the credentials",
+		"auth.removed":         "✓ Auth removed",
+		"auth.variables":       "{{VARIABLES}} of the active environment are resolved when sending",
+		"auth.sent_as_header":  "Sent as:     ",
+		"auth.sent_as_query":   "Query param: ",
+		"auth.replaces_header": "⚠ Replaces the %s header set in the header editor",
+
+		// URL inspector
+		"inspect.hint":     "⚠ %d URL encoding notes • i: inspect URL",
+		"inspect.segments": "Path segments (%d), decoded:",
+		"inspect.query":    "Query parameters (%d), decoded:",
+		"inspect.clean":    "✓ No encoding surprises",
+
+		// Service aliases
+		"aliases.empty":  "No aliases yet. Press n to map a name like users-api to {{API_URL}}/users",
+		"aliases.name":   "Alias",
+		"aliases.target": "Target",
+
+		// Collections
+		"collections.empty":        "No collections yet. Press n to group saved requests into one",
+		"collections.no_requests":  "No requests in this collection. Press a to add a saved request",
+		"collections.no_saved":     "No saved requests yet",
+		"collections.no_storage":   "Storage is unavailable, collections cannot be loaded",
+		"collections.no_targets":   "Create another collection to move or copy requests to",
+		"collections.count":        "%d requests",
+		"collections.name":         "Name",
+		"collections.description":  "Description",
+		"collections.pick_request": "Add a saved request to %s:",
+		"collections.pick_move":    "Move '%s' to:",
+		"collections.pick_copy":    "Copy '%s' to:",
+		"collections.added":        "Added '%s' to %s",
+		"collections.moved":        "Moved '%s' to %s",
+		"collections.copied":       "Copied '%s' to %s",
+		"collections.deleted":      "Deleted collection '%s'",
+
+		"footer.collection_pick":         "↑↓: choose • Enter: confirm • Esc: cancel",
+		"confirm.delete_collection":      "⚠ Delete collection '%s' and its %d requests? Press 'y' to confirm, 'Esc' to cancel",
+		"confirm.remove_from_collection": "⚠ Remove '%s' from '%s'? Press 'y' to confirm, 'Esc' to cancel",
+
+		// Usage statistics
+		"stats.local_only":     "Computed on this machine from your history. Nothing is sent anywhere.",
+		"stats.activity":       "Activity",
+		"stats.requests":       "%d HTTP requests in history (%d failed), since %s",
+		"stats.no_requests":    "No HTTP requests in history yet",
+		"stats.queries":        "%d SQL queries in history (%d failed), %dms on average",
+		"stats.endpoints":      "Most used endpoints",
+		"stats.services":       "Average latency by service",
+		"stats.service":        "%d requests • avg %dms",
+		"stats.service_failed": "%d failed",
+		"stats.storage":        "Storage",
+		"stats.disk":           "%s in %s",
+
+		// cURL import
+		"curl_import.hint": "Paste a curl command (or a .http request). -X, -H, -d, --data-urlencode, -u and --json are read; the current request is replaced.",
+		"curl_import.body": "  body: %d bytes",
+
+		// GraphQL mode
+		"title.graphql_editor":     "GraphQL Request",
+		"footer.graphql_editor":    "Tab: query/variables • Ctrl+G: browse schema • Ctrl+S: save • Esc: cancel",
+		"graphql.query":            "Query",
+		"graphql.variables":        "Variables (JSON)",
+		"graphql.not_graphql":      "The body is not a GraphQL request (%v); saving replaces it",
+		"graphql.variables_object": "variables must be a JSON object",
+		"graphql.preview":          "GraphQL: %s • %d variables",
+		"graphql.preview_empty":    "GraphQL: empty",
+
+		// History diff
+		"title.history_diff":              "Compare Responses",
+		"footer.history_diff":             "↑↓/PgUp/PgDn: scroll • Esc: back to history",
+		"history_diff.need_two":           "Mark two entries with m, or mark one and select the other, then press D",
+		"history_diff.marked":             "%d/2 marked for comparison • D: compare",
+		"history_diff.identical":          "✓ The responses are identical",
+		"history_diff.different_requests": "⚠ The entries are different requests",
+
+		// Multipart uploads
+		"title.uploading":  "Uploading...",
+		"footer.upload":    "Esc: cancel upload • Ctrl+C: quit",
+		"upload.progress":  "%s of %s • %s/s",
+		"upload.canceling": "Canceling the upload...",
+		"upload.canceled":  "upload canceled",
+		"upload.preview":   "form: %d fields, %d files",
+
+		// Body modes
+		"title.body_editor":   "Body Editor (%s)",
+		"body.raw":            "JSON",
+		"body.form":           "form-urlencoded",
+		"body.multipart":      "multipart/form-data",
+		"body.hint_raw":       "JSON, sent as written",
+		"body.hint_form":      "One name=value per line, encoded when sent",
+		"body.hint_multipart": "One name=value per line; name=@path attaches a file",
+		"body.form_preview":   "form: %s",
+		"footer.body_editor":  "Ctrl+S: save & validate • Ctrl+O: body mode • Esc: cancel",
+
+		// Collection runner
+		"title.run":            "Run: %s",
+		"run.all_saved":        "all saved requests",
+		"run.progress":         "%d/%d done • %d passed • %d failed • %d skipped • %s",
+		"run.sending":          "sending",
+		"run.pending":          "pending",
+		"run.skipped":          "skipped: %s",
+		"run.assertion_failed": "assertion failed: %s: %v",
+		"run.extract_failed":   "extraction failed: %s: %v",
+		"run.over_budget":      "over time budget: %s > %s",
+		"run.empty":            "Nothing to run: there are no requests",
+		"run.read_only":        "Read-only mode: the run has requests that change data",
+		"run.history_note":     "run %s %d/%d",
+		"run.recorded":         "%d requests recorded in history",
+		"run.extracted":        "%d variables stored in %s",
+		"run.summary":          "Run %s: %d/%d passed",
+		"footer.run":           "↑↓: navigate • Enter: load request • r: run again • Esc: back",
+		"footer.run_running":   "Esc: cancel the remaining requests • Ctrl+C: quit",
+
+		// Downloads to a file
+		"title.downloading":         "Downloading...",
+		"footer.download":           "Esc: cancel download • Ctrl+C: quit",
+		"download.to":               "to %s",
+		"download.progress_unknown": "%s received • %s/s",
+		"download.resumed_at":       "resumed at %s already on disk",
+		"download.canceling":        "Canceling the download...",
+		"download.canceled":         "download canceled; the partial file is kept and sending again resumes it",
+		"download.path":             "Download responses to file:",
+		"download.path_hint":        "Enter: download to this file • empty: show responses again • Esc: cancel",
+		"download.target":           "↓ Downloading to %s • f: change",
+		"download.target_partial":   "↓ Downloading to %s • %s on disk, sending resumes it • f: change",
+		"download.saved":            "✓ Saved %s to %s",
+		"download.resumed":          "✓ Saved %s to %s, resuming at %s",
+		"download.already_complete": "✓ %s is already complete (%s)",
+		"download.partial_content":  "Partial content: %s",
+		"download.restarted":        "⚠ The server ignored the Range header; the file was downloaded again from the start",
+		"download.incomplete":       "⚠ %s of %s on disk • send again to resume",
+
+		// Connection reuse
+		"conn.new":         "Connection: new to %s",
+		"conn.forced":      "Connection: new to %s (forced)",
+		"conn.reused":      "Connection: reused to %s",
+		"conn.reused_idle": "Connection: reused to %s, idle for %s",
+		"conn.host_stats":  "%s this session: %d reused, %d new",
+
+		// Response trailers
+		"trailers.heading": "── Trailers ──",
+		"trailers.hint":    "Trailers: %s • h: view",
+
+		// Raw socket scratchpad
+		"title.raw_socket":   "Raw Socket",
+		"footer.raw_socket":  "Enter: connect/send • Tab: switch field • Ctrl+N: TCP/TLS/UDP • Ctrl+K: skip TLS verification • Ctrl+X: hex input • Ctrl+O: hex view • Ctrl+D: disconnect • ↑↓: scroll • Esc: back",
+		"raw.settings":       "Transport: %s • Ctrl+N: change",
+		"raw.insecure":       "(certificate not verified)",
+		"raw.address":        "Address (host:port):",
+		"raw.connecting":     "Connecting...",
+		"raw.disconnected":   "Not connected • Enter: connect",
+		"raw.connected":      "● Connected to %s",
+		"raw.closed_by_peer": "Connection closed by the server",
+		"raw.closed_error":   "Connection ended: %v",
+		"raw.closed":         "Disconnected",
+		"raw.transcript":     "TRANSCRIPT",
+		"raw.empty":          "Nothing sent or received yet",
+		"raw.data_text":      "Send as text (\\r \\n \\t \\xNN escapes):",
+		"raw.data_hex":       "Send as hex bytes:",
+		"raw.no_address":     "Enter an address as host:port",
+		"raw.not_connected":  "Not connected: focus the address and press Enter",
+
+		// DNS lookup
+		"title.dns":           "DNS Lookup",
+		"footer.dns":          "Enter: look up • Tab: switch field • Ctrl+N/Ctrl+P: record type • Ctrl+R: next resolver • Esc: back",
+		"dns.type":            "Record type: %s • Ctrl+N: change",
+		"dns.all_types":       "all types",
+		"dns.name":            "Name:",
+		"dns.resolver":        "Resolver (host or host:port):",
+		"dns.system_resolver": "system resolver",
+		"dns.resolvers":       "Ctrl+R cycles: system, %s",
+		"dns.looking_up":      "Looking up...",
+		"dns.answered_by":     "%s via %s",
+		"dns.not_found":       "no such record",
+		"dns.no_records":      "no records",
+
+		// Cookie jar
+		"title.cookies":       "Cookie Jar (%d)",
+		"title.cookies_off":   "Cookie Jar (off)",
+		"footer.cookies":      "↑↓: select • t: jar on/off • e/Enter: edit value • d: delete • D: delete all • Esc: back",
+		"footer.cookies_edit": "Enter: save • Esc: cancel",
+		"cookies.off_hint":    "Cookies set by responses are not kept. Press t to keep them for this session.",
+		"cookies.empty":       "No cookies yet. Responses that set cookies fill the jar.",
+		"cookies.enabled":     "✓ Cookie jar on: cookies are sent back with later requests",
+		"cookies.disabled":    "✓ Cookie jar off: kept cookies discarded",
+		"cookies.deleted":     "✓ Deleted cookie %s",
+		"cookies.cleared":     "✓ Deleted all cookies",
+		"cookies.saved":       "✓ Saved cookie %s",
+		"cookies.edit":        "Value of %s:",
+		"cookies.session":     "session",
+		"cookies.expires":     "expires %s",
+
+		// JWT decoder
+		"title.jwt":            "JWT Decoder",
+		"footer.jwt":           "←→/Tab: next token • ↑↓: scroll • Esc: back",
+		"jwt.position":         " (%d of %d)",
+		"jwt.in_header":        "Found in the %s header",
+		"jwt.in_body":          "Found in the response body",
+		"jwt.none_in_request":  "No JWT in the request headers. Every header is searched, including the one the auth panel adds.",
+		"jwt.none_in_response": "No JWT in the response headers or body.",
+		"jwt.hint":             "🔑 %d JWT in the response • J: decode",
+		"jwt.hint_expired":     "⚠ Expired JWT in the response (%d found) • J: decode",
+		"jwt.no_exp":           "No exp claim: the token does not expire",
+		"jwt.expired":          "⚠ Expired %s ago, at %s",
+		"jwt.expires_in":       "✓ Expires in %s, at %s",
+		"jwt.not_yet_valid":    "⚠ Not valid yet: nbf is %s from now",
+		"jwt.unsigned":         "⚠ alg is none: the token is not signed",
+		"jwt.signature":        "Signature: %s, %d bytes (not verified)",
+		"jwt.header":           "Header",
+		"jwt.claims":           "Claims",
+		"jwt.ago":              "%s ago",
+		"jwt.from_now":         "in %s",
+
+		// Utilities
+		"title.utilities":   "Utilities",
+		"footer.utilities":  "↑↓/Tab: select • Enter: copy result • Ctrl+E: use result as input • Ctrl+G: new UUID • Esc: back",
+		"utils.input":       "Input:",
+		"utils.placeholder": "text to encode, decode or hash",
+		"utils.copied":      "✓ %s result copied to the clipboard",
+		"utils.copy_failed": "could not copy: %v",
+		"utils.uuid":        "✓ New random UUID (version 4)",
+
+		// Proxy settings
+		"title.proxy_settings":  "Proxy Settings",
+		"footer.proxy":          "Tab/↑↓: next field • Space: toggle • Enter/Ctrl+S: save • Esc: back",
+		"proxy.url":             "Proxy (every request):",
+		"proxy.no_proxy":        "Bypass for (NO_PROXY):",
+		"proxy.from_env":        "Use HTTP_PROXY, HTTPS_PROXY and NO_PROXY when no proxy is set",
+		"proxy.env_proxy":       "Proxy for environment %s (replaces the one above):",
+		"proxy.env_placeholder": "empty: use the global proxy",
+		"proxy.in_use":          "Requests go through %s",
+		"proxy.in_use_env":      "Requests follow the proxy environment variables",
+		"proxy.in_use_none":     "Requests are sent directly",
+		"proxy.saved":           "✓ Proxy settings saved",
+
+		// TLS settings
+		"title.tls_settings":   "TLS Settings",
+		"footer.tls":           "Tab/↑↓: next field • Space: toggle • Enter/Ctrl+S: save • Esc: back",
+		"tls.insecure":         "Skip verifying server certificates",
+		"tls.insecure_warning": "⚠ Any certificate is accepted: use only with test servers",
+		"tls.ca_file":          "CA bundle (PEM, trusted on top of the system roots):",
+		"tls.client_cert":      "Client certificate for environment %s (mutual TLS)",
+		"tls.cert_file":        "Certificate (PEM):",
+		"tls.key_file":         "Private key (PEM):",
+		"tls.no_env":           "Activate an environment to set a client certificate for it",
+		"tls.saved":            "✓ TLS settings saved",
+		"tls.badge_insecure":   "⚠ TLS NOT VERIFIED",
+
+		// Timestamp converter
+		"title.timestamps":  "Timestamp Converter",
+		"footer.timestamps": "Tab/Shift+Tab: next/previous value found • ↑↓: select • Enter: copy • Ctrl+N: now • Esc: back",
+		"time.input":        "Timestamp:",
+		"time.placeholder":  "1700000000, 1700000000000 or 2023-11-14T22:13:20Z",
+		"time.found":        "Value %d of %d • %s",
+		"time.read_as":      "Read as %s • %s",
+		"time.in_column":    "column %s",
+		"time.seconds":      "epoch seconds",
+		"time.millis":       "epoch milliseconds",
+		"time.micros":       "epoch microseconds",
+		"time.nanos":        "epoch nanoseconds",
+		"time.date":         "a date",
+
+		// Variable extraction
+		"extract.empty":       "No extractions yet. Press n to read a value of this response into a variable",
+		"extract.save_first":  "Save the request first (s) to extract variables",
+		"extract.target":      "Values found are written to environment %s after every response",
+		"extract.no_env":      "Activate an environment to store extracted variables",
+		"extract.read_only":   "Read-only mode: extracted variables are not stored",
+		"extract.no_storage":  "Storage is unavailable, extracted variables cannot be stored",
+		"extract.summary":     "Extracted %d/%d variables into %s: %s",
+		"extract.name":        "Variable name (used as {{NAME}}):",
+		"extract.source":      "Read from:",
+		"extract.json_path":   "JSON path:",
+		"extract.header":      "Header name:",
+		"extract.regex":       "Regular expression with a capture group:",
+		"extract.suggestions": "In the last response: %s",
+
+		// Saving the response body
+		"savebody.path":       "Save the response body to:",
+		"savebody.hint":       "Enter: save • Esc: cancel • ~/ is your home directory",
+		"savebody.exists":     "%s already exists. Enter: replace it • edit the path to keep it",
+		"savebody.saved":      "Saved %s to %s",
+		"savebody.nothing":    "There is no response body to save",
+		"savebody.empty_path": "Enter a file to save the body to",
+		"savebody.is_dir":     "%s is a directory, add a file name",
+
+		// Restoring the last session
+		"restore.db_connect": "Enter the password to reconnect and return to %s, where you left off",
+
+		// Binary response bodies
+		"binary.title":        "Binary body, not shown to keep the terminal readable",
+		"binary.type":         "Type:  %s",
+		"binary.size":         "Size:  %s",
+		"binary.image":        "Image: %s, %d×%d px",
+		"binary.unknown_type": "unknown (no Content-Type)",
+		"binary.hint":         "w: save it to a file to open it with another program",
+
+		// Side-by-side comparison
+		"title.split":       "Side by Side",
+		"footer.split":      "↑↓/PgUp/PgDn/Home/End: scroll both • s: swap sides • x: unpin • Esc: back",
+		"split.pinned":      "|: compare side by side with the pinned %s",
+		"split.pinned_side": "Pinned:",
+		"split.lines":       "Lines %d-%d of %d",
+		"split.rows":        "%d rows • %dms",
+
+		// Loading progress
+		"loading.elapsed":          "Elapsed: %s",
+		"loading.received":         "Received: %s",
+		"loading.received_of":      "Received: %s of %s",
+		"loading.canceling":        "Canceling...",
+		"loading.request_canceled": "request canceled",
+		"loading.query_canceled":   "query canceled",
+		"footer.loading":           "Esc: cancel • Ctrl+C: quit",
+
+		// Spooled responses
+		"spool.notice":      "Large response (%s) paged from a temporary file • copy, transforms and assertions use the first 1 MB",
+		"spool.read_failed": "✗ Failed to read the response: %v",
+
+		// Pre-send checks
+		"precheck.title":           "Request not sent, fix these first:",
+		"precheck.url":             "URL",
+		"precheck.body":            "Body",
+		"precheck.auth":            "Auth",
+		"precheck.signing":         "HMAC signing",
+		"precheck.content_length":  "Content-Length",
+		"precheck.undefined_var":   "uses {{%s}}, which the active environment does not define",
+		"precheck.length_invalid":  "%q is not a byte count",
+		"precheck.length_mismatch": "header says %d bytes but the body is %d",
+
+		// Timeout and retries
+		"title.policy":             "Timeout & Retries",
+		"footer.policy":            "Tab/↑↓: next field • Ctrl+F: follow redirects • Enter/Ctrl+S: save • Ctrl+D: use defaults • Esc: back",
+		"policy.default":           "default %s",
+		"policy.custom":            "custom",
+		"policy.hint":              "Durations like 10s or 500ms; the backoff doubles before each retry. Empty fields keep the settings.",
+		"policy.effective":         "%s is sent with a %s timeout per attempt and %d retries",
+		"policy.effective_backoff": "first retry after %s",
+		"policy.safe_only":         "Retries from the settings only apply to GET, HEAD and OPTIONS; set them here to retry this request",
+		"policy.invalid_duration":  "%s: %q is not a duration like 10s or 500ms",
+		"policy.invalid_retries":   "Retries: %q is not a number from 0 to %d",
+		"policy.saved":             "✓ Timeout and retries saved",
+		"policy.removed":           "✓ The request uses the default timeout and retries",
+		"policy.attempts":          "%d attempts",
+		"policy.follow_redirects":  "Follow redirects",
+
+		// Query linting
+		"lint.line":          "line %d: %s",
+		"lint.select_star":   "%s fetches every column; name the ones you need",
+		"lint.missing_where": "%s without WHERE changes every row of the table",
+		"lint.cross_join":    "%s are joined without a condition, pairing every row with every row",
+		"lint.non_sargable":  "%s keeps an index on the column from being used",
+
+		// Timing breakdown
+		"title.timing":     "Response Timing",
+		"timing.dns":       "DNS",
+		"timing.connect":   "TCP",
+		"timing.tls":       "TLS",
+		"timing.ttfb":      "TTFB",
+		"timing.download":  "Download",
+		"timing.total":     "Total",
+		"timing.hint":      "W: waterfall",
+		"timing.starts_at": "at +%s",
+		"timing.reused":    "The connection was reused, so there was no DNS lookup, connect or TLS handshake",
+
+		// Query snippets
+		"title.query_snippets":  "Query Snippets (%d)",
+		"footer.query_snippets": "↑↓: select • Enter: insert into the editor • Esc: back",
+		"snippets.filling":      "Snippet %s: filling %d of %d (%s)",
+		"snippets.fill_hint":    "Type to replace • Tab: next • Shift+Tab: previous • Enter/Esc: done",
+
+		// Value search
+		"title.value_search":           "Find Value in Tables",
+		"footer.value_search":          "Enter: search • Tab: value/tables/matches • Space: pick table • a: all/none • Enter on a match: open query • Esc: back",
+		"value_search.value":           "Value (case-insensitive, part of a text column)",
+		"value_search.tables":          "Tables to search (%d of %d)",
+		"value_search.no_tables":       "No tables in this database",
+		"value_search.searching":       "Searching for %q...",
+		"value_search.summary":         "Tables with %q: %d of %d.",
+		"value_search.no_text_columns": "Without text columns: %d.",
+		"value_search.rows":            "rows: %d",
+		"value_search.rows_limited":    "rows: %d+",
+		"value_search.table_failed":    "⚠ %s not searched: %s",
+
+		// Redirects
+		"redirects.title":        "Redirects followed: %d",
+		"redirects.final":        "final",
+		"redirects.loop":         "↺ loop",
+		"redirects.not_followed": "↪ Redirect not followed: Location %s (t: timeout & retries, Ctrl+F to follow)",
+
+		// Row request
+		"title.row_request":    "API Request for Row %d",
+		"footer.row_request":   "Enter: open in request builder • Tab: table/endpoint • Esc: back",
+		"row_request.table":    "Table",
+		"row_request.endpoint": "Endpoint",
+		"row_request.hint":     "{column} takes the value of the row, {{VAR}} the environment. Saved for the table on Enter.",
+		"row_request.preview":  "Request",
+
+		// Response search
+		"response_search.prompt":      "Search the response (Enter: keep • Esc: clear):",
+		"response_search.placeholder": "text to find",
+		"response_search.status":      "Search %q: match %d of %d • n/N: next/previous • /: edit • Esc: clear",
+		"response_search.none":        "⚠ No matches for %q",
+		"response_search.unavailable": "Search works on the body and the headers (h)",
+
+		// SQL scaffold
+		"scaffold.failed":  "Cannot build a SQL table from the response: %s",
+		"scaffold.spooled": "The body is too large to build a SQL table from",
+
+		// Query variables
+		"query_vars.off":       "{{VARIABLES}} are sent as written • Ctrl+G: fill them from the environment",
+		"query_vars.on":        "✓ {{VARIABLES}} filled from environment %s • Ctrl+G: off",
+		"query_vars.on_no_env": "{{VARIABLES}} are filled from the active environment, but none is active • Ctrl+G: off",
+		"query_vars.missing":   "⚠ Not run until these variables have a value: %s • Ctrl+G: off",
+
+		// Result limit
+		"result_limit.limit":        "Limit:",
+		"result_limit.sample":       "Sample %:",
+		"result_limit.no_limit":     "no limit",
+		"result_limit.all_rows":     "all rows",
+		"result_limit.hint":         "Enter: run again with this LIMIT and TABLESAMPLE • Tab: switch field • empty removes the clause • Esc: cancel",
+		"result_limit.bad_limit":    "The limit must be a whole number of rows, not %s",
+		"result_limit.bad_sample":   "The sample must be a percentage above 0 and up to 100, not %s",
+		"result_limit.failed":       "Cannot change the query: %s",
+		"result_limit.missing_vars": "Not run until these variables have a value: %s",
+
+		// Read-only mode
+		"readonly.banner":  "READ-ONLY MODE",
+		"readonly.blocked": "✗ Disabled in read-only mode: %s",
+
+		// Bulk URL runner
+		"title.bulk":       "Bulk URL Runner",
+		"bulk.summary":     "Bulk run: %d/%d ok",
+		"bulk.no_headers":  "no headers",
+		"bulk.headers":     "%d headers",
+		"bulk.intro":       "Sends %s with %s to every URL in the file (one per line, # for comments)",
+		"bulk.file":        "URL list file:",
+		"bulk.running":     "Running...",
+		"footer.bulk_file": "Enter: run • Esc: cancel",
+		"footer.bulk":      "Enter: run again • f: change file • ↑↓: scroll • Esc: back",
+
+		// Concurrent duplicate send
+		"title.duplicates":      "Concurrent Duplicate Send",
+		"duplicate.intro":       "Send %d copies at once and compare every response with the first",
+		"duplicate.read_only":   "Read-only mode: %s requests are disabled",
+		"duplicate.sending":     "Sending %d requests...",
+		"duplicate.press_enter": "Press Enter to send",
+		"footer.duplicates":     "←/→: change N • Enter: send • ↑↓: scroll • Esc: back",
+
+		// Pagination
+		"title.pagination":        "Follow Pagination",
+		"pagination.rule":         "Next page rule:",
+		"pagination.rule_hint":    "link • cursor=.meta.next param=after • page=page start=1 — also items=.data max=10",
+		"pagination.fetching":     "Fetching pages...",
+		"pagination.exported":     "✓ Exported to %s",
+		"footer.pagination_rule":  "Enter: fetch all pages • Esc: cancel",
+		"footer.pagination":       "v: view merged • e: export JSON • r: edit rule • ↑↓: scroll • Esc: back",
+		"footer.pagination_empty": "r: edit rule • Esc: back",
+
+		// Replay smoke test
+		"title.replay":   "Replay Smoke Test",
+		"replay.intro":   "Last %d distinct successful GET requests",
+		"replay.no_env":  "no active environment",
+		"replay.env":     "environment: %s",
+		"replay.found":   "%d found in history • %s",
+		"replay.running": "Replaying %d requests...",
+		"replay.empty":   "No successful GET requests in history to replay",
+		"footer.replay":  "←/→: change N • Enter: run replay • ↑↓: scroll • Esc: back",
+
+		// Workspaces
+		"title.workspaces":     "Workspaces (%d)",
+		"workspaces.intro":     "Each workspace keeps its own requests, history, environments and queries",
+		"workspaces.active":    " (active)",
+		"workspaces.new_name":  "New workspace name:",
+		"footer.workspace_new": "Enter: create and switch • Esc: cancel",
+		"footer.workspaces":    "↑↓: navigate • Enter: switch • n: new workspace • Esc: back",
+
+		// Request variants
+		"variant.load_first": "Load a saved request first (Ctrl+L) to add a variant",
+		"variant.saved":      "✓ Saved variant %q",
+		"variant.name":       "Variant name:",
+		"variant.hint":       "Enter: save current method, URL, headers, params and body as a variant • Esc: cancel",
+
+		// GraphQL operations
+		"title.gql_operations":   "GraphQL Operations (%d)",
+		"gql_ops.saved":          "✓ Saved operation %s",
+		"gql_ops.empty":          "No saved operations. Press a to save the current GraphQL request.",
+		"gql_ops.query":          "Query:",
+		"gql_ops.variables":      "Variables (%d/%d, used %s):",
+		"gql_ops.no_variables":   "No variables used yet",
+		"gql_ops.confirm_delete": "Press d again to delete this operation, Esc to cancel",
+		"footer.gql_operations":  "↑↓: navigate • ←/→: variable sets • Enter: load • a: save current request • d: delete • Esc: back",
+
+		// GraphQL schema
+		"title.gql_schema":          "GraphQL Schema",
+		"gql_schema.fields_only":    "Only fields can be inserted into the query",
+		"gql_schema.root_only":      "Only fields of the query, mutation or subscription root can be inserted",
+		"gql_schema.inserted":       "✓ Inserted %s into the request body",
+		"gql_schema.no_type":        "No type to open for %s",
+		"gql_schema.introspecting":  "Running introspection query...",
+		"gql_schema.types":          "Types",
+		"gql_schema.nothing_found":  "Nothing found",
+		"footer.gql_schema_loading": "Esc: back",
+		"footer.gql_schema_failed":  "r: retry • Esc: back",
+		"footer.gql_schema_empty":   "r: introspect • Esc: back",
+		"footer.gql_schema_search":  "Type to search • Enter/Esc: done",
+		"footer.gql_schema":         "↑↓: navigate • Enter: open type • i: insert field • /: search • r: refresh • Esc: back",
+
+		// Environment editor
+		"env.saved":             "✓ Environment saved/activated successfully!",
+		"env.trashed":           "✓ Environment moved to trash (t on the home screen to restore)",
+		"env.empty":             "No environments found",
+		"env.empty_hint":        "Press 'n' to create your first environment (e.g., dev, staging, prod)",
+		"env.var_count":         "(%d vars)",
+		"env.var_saved":         "✓ Saved successfully!",
+		"env.var_deleted":       "✓ Variable deleted successfully!",
+		"env.name":              "Environment Name:",
+		"env.save_hint":         "Press Ctrl+S to save environment",
+		"env.variables":         "Variables (%d):",
+		"env.no_vars":           "No variables yet",
+		"env.no_vars_hint":      "Press 'n' to add a variable (e.g., API_URL, API_KEY)",
+		"env.edit_var":          "Add/Edit Variable:",
+		"env.key":               "Key: ",
+		"env.value":             "Value: ",
+		"env.name_placeholder":  "environment name (e.g., dev, staging, prod)",
+		"env.key_placeholder":   "Variable Name (e.g., API_URL)",
+		"env.value_placeholder": "Variable Value",
+
+		// Query results
+		"query_result.returned":      "Query returned %d rows",
+		"query_result.affected":      "Query affected %d rows",
+		"query_result.failed":        "Query failed",
+		"query_result.time":          "Execution time: %dms",
+		"query_result.chart":         "%s by %s",
+		"query_result.chart_rows":    "Showing %d of %d rows",
+		"query_result.large":         "Large dataset • ~%dKB memory",
+		"query_result.executed":      "✓ Query executed successfully",
+		"query_result.rows_affected": "Rows affected: %d",
+		"query_result.saved":         "✓ Query saved successfully",
+		"query_result.export_failed": "✗ Export failed: %v",
+
+		// Navigation
+		"nav.home":           "Home",
+		"nav.request":        "Request",
+		"nav.loading":        "Sending",
+		"nav.response":       "Response",
+		"nav.saved_requests": "Saved requests",
+		"nav.headers":        "Headers",
+		"nav.body":           "Body",
+		"nav.query_params":   "Query params",
+		"nav.help":           "Help",
+		"nav.history":        "History",
+		"nav.database":       "Database",
+		"nav.db_connect":     "Connect",
+		"nav.db_query":       "Query",
+		"nav.db_result":      "Results",
+		"nav.db_saved":       "Saved queries",
+		"nav.db_schema":      "Schema",
+		"nav.db_history":     "Query history",
+		"nav.db_export":      "Export",
+		"nav.environments":   "Environments",
+		"nav.variables":      "Variables",
+		"nav.replay":         "Replay",
+		"nav.workspaces":     "Workspaces",
+		"nav.duplicates":     "Duplicates",
+		"nav.gql_schema":     "GraphQL schema",
+		"nav.gql_operations": "GraphQL operations",
+		"nav.pagination":     "Pagination",
+		"nav.bulk":           "Bulk run",
+		"nav.trash":          "Trash",
+		"nav.bookmarks":      "Bookmarks",
+		"nav.storage":        "Storage unavailable",
+		"nav.assertions":     "Assertions",
+		"nav.signing":        "Signing",
+		"nav.url_inspector":  "URL inspector",
+		"nav.aliases":        "Aliases",
+		"nav.tour":           "Tour",
+		"nav.whats_new":      "What's new",
+		"nav.collections":    "Collections",
+		"nav.stats":          "Stats",
+		"nav.curl_import":    "cURL import",
+		"nav.gql_editor":     "GraphQL editor",
+		"nav.history_diff":   "Diff",
+		"nav.templates":      "Templates",
+		"nav.collection_run": "Collection run",
+		"nav.raw_socket":     "Raw socket",
+		"nav.auth":           "Auth",
+		"nav.dns":            "DNS lookup",
+		"nav.cookies":        "Cookies",
+		"nav.jwt":            "JWT",
+		"nav.utilities":      "Utilities",
+		"nav.proxy":          "Proxy",
+		"nav.tls":            "TLS",
+		"nav.timestamps":     "Timestamps",
+		"nav.extractions":    "Extractions",
+		"nav.split":          "Side by side",
+		"nav.policy":         "Timeout & retries",
+		"nav.query_snippets": "Snippets",
+		"nav.value_search":   "Find Value",
+		"nav.row_request":    "Row Request",
+
+		// Request templates
+		"title.templates":      "Request Templates (%d)",
+		"footer.templates":     "↑↓: select • Enter: use template • Esc: back",
+		"footer.template_form": "Tab/↑↓: next field • Enter: next/apply • Ctrl+S: apply • Esc: back to templates",
+		"templates.empty_hint": "Empty fields keep their {{NAME}} placeholder for the active environment",
+
+		// Session recording
+		"session.name":       "Session name:",
+		"session.name_hint":  "Enter: start recording every request sent • Esc: cancel",
+		"session.need_name":  "Give the session a name",
+		"session.exists":     "A collection named %q already exists",
+		"session.no_storage": "Storage is unavailable, sessions cannot be saved",
+		"session.recording":  "● REC %s • %d requests • %s • R: stop and save",
+		"session.empty":      "Stopped recording %q: no requests were sent, nothing saved",
+		"session.saved":      "✓ Saved session %q with %d requests • replay it with: godev collection run %q",
+
+		// Error categories
+		"error.network.title": "Could not reach the server",
+		"error.network.hint":  "Check the host and port, that the server is running, and your network or VPN connection",
+		"error.timeout.title": "The request timed out",
+		"error.timeout.hint":  "The server is slow or unreachable; retry, or raise GODEV_HTTP_TIMEOUT",
+		"error.tls.title":     "Secure connection failed",
+		"error.tls.hint":      "Check the certificate (expired, self-signed or wrong host name) or whether the server speaks HTTPS at all",
+		"error.parse.title":   "Invalid input",
+		"error.parse.hint":    "Check the URL, JSON body or query for typos and unbalanced quotes or brackets",
+		"error.storage.title": "Could not save your data",
+		"error.storage.hint":  "Check free disk space and the permissions of the godev data directory",
+		"error.unknown.title": "Something went wrong",
+		"error.unknown.hint":  "Retry the action; run with GODEV_LOG_LEVEL=debug for details",
+
+		// Degraded storage
+		"storage.disabled":   "godev could not open its data directory. You can keep working, but requests, history and environments will not be saved.",
+		"storage.dir_prompt": "Directory to keep godev data in:",
+		"storage.banner":     "⚠ Persistence disabled: nothing is saved (S on the home screen to retry)",
+
+		// Crash recovery
+		"title.crash":       "godev ran into a problem",
+		"footer.crash":      "Esc: back to home • Q: quit",
+		"crash.body":        "An internal error interrupted what you were doing. godev caught it, so your session is still open.",
+		"crash.saved":       "A report with the app state and your draft request was saved to:",
+		"crash.secrets":     "The report includes the draft's headers and body; check it for secrets before sharing it.",
+		"crash.save_failed": "The crash report could not be saved: %v",
+		"crash.error":       "Error: %s",
+	},
+
+	PortugueseBR: {
+		// Home screen
+		"home.subtitle":       "Ferramenta Profissional de Testes de API e Banco de Dados",
+		"home.workspace":      "Workspace: %s",
+		"home.select_mode":    "SELECIONE O MODO",
+		"home.api_mode":       "[ 1 ] Testes de API (HTTP)",
+		"home.api_mode_desc":  "      Teste APIs REST, GraphQL e WebSocket",
+		"home.db_mode":        "[ 2 ] Explorador de Banco de Dados (SQL)",
+		"home.db_mode_desc":   "      Consultas PostgreSQL, navegador de schema e mais",
+		"home.raw_mode":       "[ 3 ] Socket Bruto (TCP/TLS/UDP)",
+		"home.raw_mode_desc":  "      Envie bytes para serviços que não falam HTTP",
+		"home.dns_mode":       "[ 4 ] Consulta DNS",
+		"home.dns_mode_desc":  "      Registros A, AAAA, CNAME, TXT, SRV, MX e NS",
+		"home.util_mode":      "[ 5 ] Utilitários",
+		"home.util_mode_desc": "      Codificação Base64, URL e hex, hashes e UUIDs",
+		"home.time_mode":      "[ 6 ] Timestamps",
+		"home.time_mode_desc": "      Segundos e milissegundos epoch para ISO-8601 em vários fusos horários",
+		"home.features":       "Recursos: Variáveis de Ambiente • Importação de cURL • Coleções de Requisições • Histórico de Consultas",
+		"footer.home":         "1: Modo API • 2: Modo Banco de Dados • 3: Socket bruto • 4: Consulta DNS • 5: Utilitários • 6: Timestamps • p: Proxy • L: TLS • w: Workspaces • t: Lixeira • o: Tour • n: Novidades • u: Estatísticas • ?: Ajuda • Q: Sair",
+		"title.saved":         " [SALVA]",
+		"title.autosave":      " [AUTO-SALVAR]",
+		"title.new_conn":      " [NOVA CONEXÃO]",
+		"title.auth":          " [AUTH: %s]",
+		"title.cookie_jar":    " [COOKIES: %d]",
+		"title.proxy":         " [PROXY: %s]",
+		"title.env":           " [AMBIENTE: %s]",
+		"title.executing":     "Executando Consulta",
+		"loading.query":       "Executando consulta...",
+		"loading.query_hint":  "Aguarde enquanto o banco de dados processa sua consulta",
+		"title.connecting":    "Conectando ao Banco de Dados",
+		"loading.schema":      "Carregando schema do banco de dados...",
+		"loading.schema_hint": "Buscando tabelas e informações do banco de dados",
+		"title.sending":       "Enviando Requisição",
+
+		// Help screen
+		"help.title":           "GoDev - Ajuda",
+		"help.global":          "Atalhos Globais:",
+		"help.quit":            "Sair do aplicativo",
+		"help.show_help":       "Mostrar esta ajuda",
+		"help.back":            "Voltar/Cancelar",
+		"help.next_field":      "Próximo campo",
+		"help.request_builder": "Construtor de Requisições:",
+		"help.send":            "Enviar requisição",
+		"help.load_saved":      "Carregar requisições salvas",
+		"help.history":         "Ver histórico de requisições",
+		"help.change_method":   "Mudar método",
+		"help.graphql_schema":  "Navegar pelo schema GraphQL",
+		"help.graphql_ops":     "Biblioteca de operações GraphQL",
+		"help.graphql_mode":    "Modo GraphQL: editar o corpo como query e variáveis",
+		"help.templates":       "Começar a partir de um modelo de requisição",
+		"help.bulk":            "Executar método/cabeçalhos em uma lista de URLs",
+		"help.variant":         "Salvar como variante da requisição carregada",
+		"help.signing":         "Assinatura HMAC com prévia da string a assinar",
+		"help.auth":            "Autenticação Basic, Bearer ou chave de API anexada a cada envio",
+		"help.dns":             "Consultar os registros DNS do host da requisição",
+		"help.cookies":         "Pote de cookies: manter cookies entre requisições, ver, editar ou excluir",
+		"help.jwt":             "Decodificar os JWTs nos cabeçalhos da requisição",
+		"help.utilities":       "Codificar, decodificar e calcular hashes do texto da área de transferência",
+		"help.proxy":           "Configurações de proxy: global, por ambiente ou via HTTP_PROXY",
+		"help.tls":             "Configurações de TLS: pular verificação, CA própria, certificado de cliente",
+		"help.inspect":         "Inspecionar a URL enviada: host, caminho, query, codificação",
+		"help.curl_import":     "Importar um comando curl",
+		"help.download":        "Baixar respostas para um arquivo, retomando arquivos parciais",
+		"help.new_conn":        "Alternar envio em uma nova conexão em vez de uma mantida viva",
+		"help.record":          "Gravar uma sessão / parar e salvá-la como coleção",
+		"help.response_view":   "Visualização da Resposta:",
+		"help.save_request":    "Salvar requisição",
+		"help.plugin_viewer":   "Renderizar com plugin visualizador",
+		"help.fix_resend":      "Corrigir uma requisição 4xx e reenviar",
+		"help.assertions":      "Asserções verificadas em cada resposta",
+		"help.extractions":     "Extrair variáveis de cada resposta para o ambiente",
+		"help.save_body":       "Salvar o corpo bruto da resposta em um arquivo",
+		"help.split":           "Fixar para lado a lado / comparar com a fixada",
+		"help.golden":          "Fixar resposta como golden / ver diferenças",
+		"help.volatile":        "Campos ignorados ao comparar com a golden",
+		"help.timestamps":      "Converter os timestamps da resposta entre epoch e ISO-8601",
+		"help.scroll":          "Rolar",
+		"help.request_list":    "Lista de Requisições:",
+		"help.load_request":    "Carregar requisição",
+		"help.trash_request":   "Mover requisição para a lixeira (restaure com t na tela inicial)",
+		"help.new_request":     "Nova requisição",
+		"help.group_hosts":     "Agrupar por host",
+		"help.collections":     "Gerenciar coleções",
+		"help.run_all":         "Executar todas as requisições salvas em ordem",
+		"help.density":         "Listas compactas ou detalhadas (uma ou duas linhas por item)",
+		"help.close":           "Pressione qualquer tecla para fechar",
+
+		// Screen titles
+		"title.saved_requests": "Requisições Salvas (%d)",
+		"title.history":        "Histórico de Requisições (%d)",
+		"title.database":       "Explorador de Banco de Dados (PostgreSQL)",
+		"title.db_connect":     "Conectar ao Banco de Dados PostgreSQL",
+		"title.query_editor":   "Editor de Consultas SQL",
+		"title.saved_queries":  "Consultas Salvas (%d)",
+		"title.schema":         "Schema do Banco de Dados",
+		"title.query_history":  "Histórico de Consultas (%d)",
+		"title.export":         "Exportar Resultados da Consulta",
+		"title.environments":   "Variáveis de Ambiente (%d)",
+		"title.active_env":     " | Ativo: %s",
+		"title.new_env":        "Novo Ambiente",
+		"title.edit_env":       "Ambiente: %s",
+		"title.trash":          "Lixeira (%d)",
+		"title.bookmarks":      "Favoritos (%d)",
+		"title.storage":        "Armazenamento indisponível",
+		"title.assertions":     "Asserções (%d)",
+		"title.extractions":    "Extrair Variáveis (%d)",
+		"title.signing":        "Assinatura de Requisição (HMAC)",
+		"title.inspect":        "Inspetor de URL",
+		"title.aliases":        "Aliases de Serviço: %s (%d)",
+		"title.collections":    "Coleções (%d)",
+		"title.collection":     "Coleção: %s (%d requisições)",
+		"title.stats":          "Estatísticas de Uso",
+		"title.curl_import":    "Importar cURL",
+
+		// Footers
+		"footer.builder":       "Ctrl+H: ajuda • Ctrl+Enter: enviar • Ctrl+L: carregar • Ctrl+R: histórico • Ctrl+D: banco de dados • Ctrl+E: ambiente • h: cabeçalhos • b: corpo • q: parâmetros • g: schema GraphQL • G: modo GraphQL • o: operações GraphQL • u: URLs em lote • s: salvar • v: salvar variante • a: assinatura HMAC • A: autenticação • t: tempo limite e novas tentativas • d: consulta DNS • K: cookies • J: decodificar JWT • U: utilitários • P: proxy • L: TLS • i: inspecionar URL • c: importar cURL • f: baixar para arquivo • N: nova conexão • T: modelos • R: gravar sessão • x: cURL",
+		"footer.response":      "Esc: voltar • s: salvar • c: copiar resposta • x: copiar como cURL • h: mostrar cabeçalhos • t: filtrar (jq/JSONPath) • /: buscar (n/N) • C: ligar/desligar cores • b: orçamento • p: comparar em paralelo • f: seguir páginas • v: plugin visualizador • e: corrigir e reenviar • J: decodificar JWT • U: codificar/hash do corpo • T: timestamps • a: asserções • E: extrair variáveis • w: salvar corpo • Q: tabela SQL do JSON • W: tempos • |: lado a lado • G: fixar golden • g: diferenças da golden • i: campos voláteis • ↑↓/PgUp/PgDn: rolar",
+		"footer.request_list":  "↑↓: navegar • /: buscar • Enter: carregar • d: excluir • n: nova • g: agrupar por host • c: coleções • r: executar todas • Esc: voltar",
+		"footer.history":       "↑↓: navegar • Enter: carregar • s: salvar como requisição • d: excluir item • b: favoritar • n: nota • B: favoritos • m: marcar • D: comparar marcados • e: só este ambiente • c: limpar tudo • r: smoke test de replay • Esc: voltar",
+		"footer.database":      "q: consulta • s: schema • l: consultas salvas • f: buscar valor • h: histórico • d: desconectar • Esc: voltar",
+		"footer.db_connect":    "Tab: próximo campo • Enter: conectar • Esc: cancelar",
+		"footer.query_editor":  "Ctrl+K: executar • Ctrl+S: salvar consulta • Ctrl+O: snippets • Ctrl+G: variáveis de ambiente • Esc: voltar",
+		"footer.saved_queries": "↑↓: navegar • Enter: carregar • d: mover para a lixeira • Esc: voltar",
+		"footer.schema":        "↑↓: navegar • Enter: ver colunas • S/I/U: snippet select/insert/update • f: buscar valor • q: editor de consultas • l: consultas salvas • Esc: voltar",
+		"footer.query_history": "↑↓: navegar • Enter: carregar • d: excluir item • c: limpar tudo • Esc: voltar",
+		"footer.export":        "↑↓: escolher formato • Tab: editar nome da tabela • Enter: exportar • Esc: cancelar",
+		"footer.environments":  "↑↓: navegar • Enter: editar • n: novo • s: ativar • d: excluir • Esc: voltar",
+		"footer.env_new":       "Tab: próximo campo • Enter: salvar • Esc: cancelar",
+		"footer.env_save":      "Ctrl+S: salvar ambiente • Esc: voltar",
+		"footer.env_vars":      "↑↓: navegar • n: adicionar variável • e: editar • d: excluir • l: aliases • Esc: voltar",
+		"footer.trash":         "↑↓: navegar • Enter/r: restaurar • d: excluir definitivamente • D: esvaziar lixeira • Esc: voltar",
+		"footer.fix_body":      "Ctrl+S: salvar e reenviar • Esc: cancelar",
+		"footer.bookmarks":     "↑↓: navegar • Enter: carregar • n: editar nota • b: remover favorito • x: exportar Markdown • Esc: voltar",
+		"footer.storage":       "r: tentar novamente • c: escolher outro diretório • Enter: continuar sem salvar • q: sair",
+		"footer.storage_dir":   "Enter: abrir diretório • Esc: cancelar",
+		"footer.fix_headers":   "↑↓: navegar • n: adicionar • e: editar • d: excluir • Ctrl+S: reenviar • Esc: cancelar",
+		"footer.assertions":    "↑↓: navegar • n: nova asserção • d: excluir • Esc: voltar",
+		"footer.extractions":   "↑↓: navegar • n: nova extração • d: excluir • Esc: voltar",
+		"footer.extract_form":  "Tab: completar / próximo campo • ↑↓: campo • ←→: origem • Enter/Ctrl+S: salvar • Esc: cancelar",
+		"footer.signing":       "Tab/↑↓: campo • ←→: mudar opção • Enter/Ctrl+S: salvar • Ctrl+D: remover assinatura • Esc: voltar",
+		"footer.inspect":       "Esc: voltar",
+		"footer.aliases":       "↑↓: navegar • n: adicionar alias • e: editar • d: excluir • Esc: voltar",
+		"footer.collections":   "↑↓: navegar • Enter: abrir • r: executar • n: nova coleção • e: editar • d: excluir • Esc: voltar",
+		"footer.collection":    "↑↓: navegar • Enter: carregar • r: executar • a: adicionar requisição salva • m: mover • c: copiar • d: remover • Esc: coleções",
+		"footer.stats":         "r: atualizar • Esc: voltar",
+		"footer.curl_import":   "Ctrl+S: importar no construtor • Esc: cancelar",
+		"footer.assert_build":  "↑↓: escolher • Tab: completar • Enter: avançar • Esc: cancelar",
+
+		// Confirmations
+		"confirm.delete_request":   "⚠ Excluir '%s'? Pressione 'y' para confirmar, 'Esc' para cancelar",
+		"confirm.delete_variants":  "⚠ Excluir '%s' e suas %d variantes? Pressione 'y' para confirmar, 'Esc' para cancelar",
+		"confirm.clear_history":    "⚠ Limpar todo o histórico exceto favoritos? Pressione 'y' para confirmar, 'Esc' para cancelar",
+		"confirm.delete_env":       "⚠ Excluir o ambiente '%s'? Pressione 'y' para confirmar, 'Esc' para cancelar",
+		"confirm.delete_variable":  "⚠ Excluir a variável '%s'? Pressione 'y' para confirmar, 'Esc' para cancelar",
+		"confirm.purge_trash_item": "⚠ Excluir '%s' definitivamente? Pressione 'y' para confirmar, 'Esc' para cancelar",
+		"confirm.empty_trash":      "⚠ Excluir definitivamente todos os %d itens? Pressione 'y' para confirmar, 'Esc' para cancelar",
+		"confirm.delete_alias":     "⚠ Excluir o alias '%s'? Pressione 'y' para confirmar, 'Esc' para cancelar",
+
+		// Request groups
+		"requests.grouped_hint": "←/h: recolher • →/l: expandir • Enter/Espaço em um host: alternar",
+
+		// Onboarding
+		"title.tour":       "Bem-vindo ao GoDev (%d/%d)",
+		"title.whats_new":  "Novidades do GoDev v%s",
+		"footer.tour":      "→/Enter: próximo • ←: voltar • Esc: pular tour",
+		"footer.tour_done": "Enter: começar a usar o GoDev • ←: voltar",
+		"footer.whats_new": "↑↓: rolar • Enter/Esc: fechar",
+		"whats_new.empty":  "Sem notas de versão para esta versão.",
+		"whats_new.more":   "↓ mais %d linhas",
+		"tour.0.title":     "Duas ferramentas em uma",
+		"tour.0.body":      "O GoDev testa APIs HTTP e explora bancos PostgreSQL pelo terminal.\nNa tela inicial, 1 abre os testes de API e 2 o explorador de banco de dados.\nw troca de workspace para separar clientes ou projetos.",
+		"tour.1.title":     "Montando requisições",
+		"tour.1.body":      "Digite uma URL e pressione Enter para enviá-la; ←/→ trocam o método.\nh edita os cabeçalhos, b o corpo e q os parâmetros de query.\nTab alterna entre os campos e Ctrl+H lista todos os atalhos.",
+		"tour.2.title":     "Lendo respostas",
+		"tour.2.body":      "A resposta abre quando a requisição termina: ↑↓ rolam, h mostra os cabeçalhos e c copia o corpo.\ns salva a requisição, a adiciona asserções e G fixa uma resposta golden para comparar execuções futuras.",
+		"tour.3.title":     "Requisições salvas e histórico",
+		"tour.3.body":      "Ctrl+L lista as requisições salvas: / busca e g agrupa por host.\nCtrl+R abre o histórico de todas as requisições enviadas, prontas para carregar, favoritar ou repetir.",
+		"tour.4.title":     "Ambientes",
+		"tour.4.body":      "Ctrl+E gerencia os ambientes. Variáveis escritas como {{API_URL}} em URLs, cabeçalhos e corpos são substituídas pelos valores do ambiente ativo no envio.",
+		"tour.5.title":     "Explorador de banco de dados",
+		"tour.5.body":      "Ctrl+D, ou 2 na tela inicial, conecta ao PostgreSQL.\nEscreva SQL no editor, navegue pelo schema, guarde consultas favoritas e exporte resultados para CSV ou JSON.",
+		"tour.6.title":     "Tudo pronto",
+		"tour.6.body":      "Pressione ? ou F1 para ajuda a qualquer momento.\nNa tela inicial, o mostra este tour de novo e n mostra as novidades desta versão.",
+
+		// Environment-scoped history
+		"history.env_scope": " • ambiente: %s",
+		"history.no_env":    "nenhum",
+
+		// Auto-save
+		"autosave.created": "✓ Salva automaticamente como nova requisição",
+		"autosave.updated": "✓ Salva automaticamente: requisição salva com o mesmo método e URL atualizada",
+
+		// Trash screen
+		"trash.subtitle": "Requisições, consultas e ambientes excluídos ficam aqui até serem removidos de vez",
+		"trash.empty":    "A lixeira está vazia",
+		"trash.deleted":  "excluído em %s",
+
+		// Fix and resend
+		"fix.pinned": "Resposta do servidor",
+		"fix.hint":   "e: corrigir e reenviar",
+
+		// Bookmarks
+		"bookmarks.empty":       "Nenhum favorito ainda. Pressione b em um item do histórico para favoritá-lo",
+		"bookmarks.no_note":     "(sem nota, pressione n para adicionar)",
+		"bookmarks.note_prompt": "Nota (Enter: salvar • Esc: cancelar):",
+		"bookmarks.exported":    "✓ Exportado para %s",
+
+		// Assertions
+		"assertions.empty":      "Nenhuma asserção ainda. Pressione n para criar uma a partir desta resposta",
+		"assertions.save_first": "Salve a requisição primeiro (s) para adicionar asserções",
+		"assertions.summary":    "Asserções: %d/%d passaram",
+		"assertions.draft":      "Nova: %s",
+		"assertions.pick_type":  "O que deve ser verificado?",
+		"assertions.pick_field": "Cabeçalho ou caminho JSON (digite para filtrar a última resposta):",
+		"assertions.pick_op":    "Como comparar?",
+		"assertions.value":      "Valor esperado (preenchido com a última resposta):",
+		"assertions.regex":      "Expressão regular:",
+		"assertions.no_suggest": "Sem sugestões da última resposta; digite o nome ou caminho",
+		"assertions.need_field": "Informe um nome de cabeçalho ou caminho JSON",
+
+		// Latency budgets
+		"budget.save_first": "Salve a requisição primeiro (s) para definir um orçamento de latência",
+		"budget.exceeded":   "⚠ %s excede o orçamento de latência de %dms",
+		"budget.prompt":     "Orçamento de latência em ms (Enter: salvar • Esc: cancelar • vazio: remover):",
+
+		// Golden responses
+		"golden.matches":    "✓ Igual à resposta golden fixada em %s",
+		"golden.differs":    "⚠ Difere da resposta golden (%s) • g: detalhes • G: fixar esta",
+		"golden.save_first": "Salve a requisição primeiro (s) para fixar uma resposta golden",
+		"golden.volatile":   "Campos voláteis, separados por vírgula, ex.: updated_at, $.data[*].id, header:X-Trace (Enter: salvar • Esc: cancelar):",
+
+		// HMAC signing
+		"signing.active":       "assinando cada envio",
+		"signing.saved":        "✓ Assinatura salva; cada envio desta requisição é assinado",
+		"signing.removed":      "✓ Assinatura removida",
+		"signing.placeholders": "Marcadores: %s • \\n é uma quebra de linha",
+		"signing.preview":      "Prévia para a requisição atual:",
+		"signing.signature":    "Assinatura:   ",
+		"signing.sent_as":      "Enviado como: ",
+
+		// Auth helper
+		"title.auth_panel":     "Autenticação da Requisição",
+		"footer.auth":          "Tab/↑↓: próximo campo • ←/→: mudar opção • Enter/Ctrl+S: salvar • Ctrl+D: remover autenticação • Esc: voltar",
+		"auth.active":          "credenciais %s anexadas",
+		"auth.saved":           "✓ Autenticação salva; cada envio desta requisição leva as credenciais",
+		"auth.removed":         "✓ Autenticação removida",
+		"auth.variables":       "{{VARIÁVEIS}} do ambiente ativo são resolvidas ao enviar",
+		"auth.sent_as_header":  "Enviado como: ",
+		"auth.sent_as_query":   "Parâmetro:    ",
+		"auth.replaces_header": "⚠ Substitui o cabeçalho %s definido no editor de cabeçalhos",
+
+		// URL inspector
+		"inspect.hint":     "⚠ %d observações de codificação na URL • i: inspecionar URL",
+		"inspect.segments": "Segmentos do caminho (%d), decodificados:",
+		"inspect.query":    "Parâmetros de query (%d), decodificados:",
+		"inspect.clean":    "✓ Nenhuma surpresa de codificação",
+
+		// Service aliases
+		"aliases.empty":  "Nenhum alias ainda. Pressione n para mapear um nome como users-api para {{API_URL}}/users",
+		"aliases.name":   "Alias",
+		"aliases.target": "Destino",
+
+		// Collections
+		"collections.empty":        "Nenhuma coleção ainda. Pressione n para agrupar requisições salvas em uma",
+		"collections.no_requests":  "Nenhuma requisição nesta coleção. Pressione a para adicionar uma requisição salva",
+		"collections.no_saved":     "Nenhuma requisição salva ainda",
+		"collections.no_storage":   "Armazenamento indisponível, não é possível carregar as coleções",
+		"collections.no_targets":   "Crie outra coleção para mover ou copiar requisições para ela",
+		"collections.count":        "%d requisições",
+		"collections.name":         "Nome",
+		"collections.description":  "Descrição",
+		"collections.pick_request": "Adicionar uma requisição salva a %s:",
+		"collections.pick_move":    "Mover '%s' para:",
+		"collections.pick_copy":    "Copiar '%s' para:",
+		"collections.added":        "'%s' adicionada a %s",
+		"collections.moved":        "'%s' movida para %s",
+		"collections.copied":       "'%s' copiada para %s",
+		"collections.deleted":      "Coleção '%s' excluída",
+
+		"footer.collection_pick":         "↑↓: escolher • Enter: confirmar • Esc: cancelar",
+		"confirm.delete_collection":      "⚠ Excluir a coleção '%s' e suas %d requisições? Pressione 'y' para confirmar, 'Esc' para cancelar",
+		"confirm.remove_from_collection": "⚠ Remover '%s' de '%s'? Pressione 'y' para confirmar, 'Esc' para cancelar",
+
+		// Usage statistics
+		"stats.local_only":     "Calculadas nesta máquina a partir do seu histórico. Nada é enviado para lugar nenhum.",
+		"stats.activity":       "Atividade",
+		"stats.requests":       "%d requisições HTTP no histórico (%d com falha), desde %s",
+		"stats.no_requests":    "Nenhuma requisição HTTP no histórico ainda",
+		"stats.queries":        "%d consultas SQL no histórico (%d com falha), %dms em média",
+		"stats.endpoints":      "Endpoints mais usados",
+		"stats.services":       "Latência média por serviço",
+		"stats.service":        "%d requisições • média %dms",
+		"stats.service_failed": "%d com falha",
+		"stats.storage":        "Armazenamento",
+		"stats.disk":           "%s em %s",
+
+		// cURL import
+		"curl_import.hint": "Cole um comando curl (ou uma requisição .http). -X, -H, -d, --data-urlencode, -u e --json são lidos; a requisição atual é substituída.",
+		"curl_import.body": "  corpo: %d bytes",
+
+		// GraphQL mode
+		"title.graphql_editor":     "Requisição GraphQL",
+		"footer.graphql_editor":    "Tab: query/variáveis • Ctrl+G: navegar pelo schema • Ctrl+S: salvar • Esc: cancelar",
+		"graphql.query":            "Query",
+		"graphql.variables":        "Variáveis (JSON)",
+		"graphql.not_graphql":      "O corpo não é uma requisição GraphQL (%v); salvar o substitui",
+		"graphql.variables_object": "as variáveis devem ser um objeto JSON",
+		"graphql.preview":          "GraphQL: %s • %d variáveis",
+		"graphql.preview_empty":    "GraphQL: vazio",
+
+		// History diff
+		"title.history_diff":              "Comparar Respostas",
+		"footer.history_diff":             "↑↓/PgUp/PgDn: rolar • Esc: voltar ao histórico",
+		"history_diff.need_two":           "Marque duas entradas com m, ou marque uma e selecione a outra, e pressione D",
+		"history_diff.marked":             "%d/2 marcadas para comparação • D: comparar",
+		"history_diff.identical":          "✓ As respostas são idênticas",
+		"history_diff.different_requests": "⚠ As entradas são requisições diferentes",
+
+		// Multipart uploads
+		"title.uploading":  "Enviando...",
+		"footer.upload":    "Esc: cancelar envio • Ctrl+C: sair",
+		"upload.progress":  "%s de %s • %s/s",
+		"upload.canceling": "Cancelando o envio...",
+		"upload.canceled":  "envio cancelado",
+		"upload.preview":   "formulário: %d campos, %d arquivos",
+
+		// Body modes
+		"title.body_editor":   "Editor de Corpo (%s)",
+		"body.raw":            "JSON",
+		"body.form":           "form-urlencoded",
+		"body.multipart":      "multipart/form-data",
+		"body.hint_raw":       "JSON, enviado como escrito",
+		"body.hint_form":      "Um nome=valor por linha, codificado no envio",
+		"body.hint_multipart": "Um nome=valor por linha; nome=@caminho anexa um arquivo",
+		"body.form_preview":   "formulário: %s",
+		"footer.body_editor":  "Ctrl+S: salvar e validar • Ctrl+O: modo do corpo • Esc: cancelar",
+
+		// Collection runner
+		"title.run":            "Execução: %s",
+		"run.all_saved":        "todas as requisições salvas",
+		"run.progress":         "%d/%d concluídas • %d aprovadas • %d falharam • %d ignoradas • %s",
+		"run.sending":          "enviando",
+		"run.pending":          "pendente",
+		"run.skipped":          "ignorada: %s",
+		"run.assertion_failed": "asserção falhou: %s: %v",
+		"run.extract_failed":   "extração falhou: %s: %v",
+		"run.over_budget":      "acima do orçamento de tempo: %s > %s",
+		"run.empty":            "Nada para executar: não há requisições",
+		"run.read_only":        "Modo somente leitura: a execução tem requisições que alteram dados",
+		"run.history_note":     "execução %s %d/%d",
+		"run.recorded":         "%d requisições registradas no histórico",
+		"run.extracted":        "%d variáveis gravadas em %s",
+		"run.summary":          "Execução %s: %d/%d aprovadas",
+		"footer.run":           "↑↓: navegar • Enter: carregar requisição • r: executar de novo • Esc: voltar",
+		"footer.run_running":   "Esc: cancelar as requisições restantes • Ctrl+C: sair",
+
+		// Downloads to a file
+		"title.downloading":         "Baixando...",
+		"footer.download":           "Esc: cancelar download • Ctrl+C: sair",
+		"download.to":               "para %s",
+		"download.progress_unknown": "%s recebidos • %s/s",
+		"download.resumed_at":       "retomado a partir de %s já em disco",
+		"download.canceling":        "Cancelando o download...",
+		"download.canceled":         "download cancelado; o arquivo parcial foi mantido e enviar de novo o retoma",
+		"download.path":             "Baixar respostas para o arquivo:",
+		"download.path_hint":        "Enter: baixar para este arquivo • vazio: mostrar respostas de novo • Esc: cancelar",
+		"download.target":           "↓ Baixando para %s • f: alterar",
+		"download.target_partial":   "↓ Baixando para %s • %s em disco, enviar retoma o download • f: alterar",
+		"download.saved":            "✓ %s salvos em %s",
+		"download.resumed":          "✓ %s salvos em %s, retomando a partir de %s",
+		"download.already_complete": "✓ %s já está completo (%s)",
+		"download.partial_content":  "Conteúdo parcial: %s",
+		"download.restarted":        "⚠ O servidor ignorou o cabeçalho Range; o arquivo foi baixado de novo desde o início",
+		"download.incomplete":       "⚠ %s de %s em disco • envie de novo para retomar",
+
+		// Connection reuse
+		"conn.new":         "Conexão: nova para %s",
+		"conn.forced":      "Conexão: nova para %s (forçada)",
+		"conn.reused":      "Conexão: reutilizada para %s",
+		"conn.reused_idle": "Conexão: reutilizada para %s, ociosa por %s",
+		"conn.host_stats":  "%s nesta sessão: %d reutilizadas, %d novas",
+
+		// Response trailers
+		"trailers.heading": "── Trailers ──",
+		"trailers.hint":    "Trailers: %s • h: ver",
+
+		// Raw socket scratchpad
+		"title.raw_socket":   "Socket Bruto",
+		"footer.raw_socket":  "Enter: conectar/enviar • Tab: trocar campo • Ctrl+N: TCP/TLS/UDP • Ctrl+K: pular verificação TLS • Ctrl+X: entrada hex • Ctrl+O: visão hex • Ctrl+D: desconectar • ↑↓: rolar • Esc: voltar",
+		"raw.settings":       "Transporte: %s • Ctrl+N: alterar",
+		"raw.insecure":       "(certificado não verificado)",
+		"raw.address":        "Endereço (host:porta):",
+		"raw.connecting":     "Conectando...",
+		"raw.disconnected":   "Não conectado • Enter: conectar",
+		"raw.connected":      "● Conectado a %s",
+		"raw.closed_by_peer": "Conexão fechada pelo servidor",
+		"raw.closed_error":   "Conexão encerrada: %v",
+		"raw.closed":         "Desconectado",
+		"raw.transcript":     "TRANSCRIÇÃO",
+		"raw.empty":          "Nada enviado ou recebido ainda",
+		"raw.data_text":      "Enviar como texto (escapes \\r \\n \\t \\xNN):",
+		"raw.data_hex":       "Enviar como bytes hex:",
+		"raw.no_address":     "Informe um endereço como host:porta",
+		"raw.not_connected":  "Não conectado: foque o endereço e pressione Enter",
+
+		// DNS lookup
+		"title.dns":           "Consulta DNS",
+		"footer.dns":          "Enter: consultar • Tab: trocar campo • Ctrl+N/Ctrl+P: tipo de registro • Ctrl+R: próximo resolvedor • Esc: voltar",
+		"dns.type":            "Tipo de registro: %s • Ctrl+N: alterar",
+		"dns.all_types":       "todos os tipos",
+		"dns.name":            "Nome:",
+		"dns.resolver":        "Resolvedor (host ou host:porta):",
+		"dns.system_resolver": "resolvedor do sistema",
+		"dns.resolvers":       "Ctrl+R alterna: sistema, %s",
+		"dns.looking_up":      "Consultando...",
+		"dns.answered_by":     "%s via %s",
+		"dns.not_found":       "registro inexistente",
+		"dns.no_records":      "nenhum registro",
+
+		// Cookie jar
+		"title.cookies":       "Pote de Cookies (%d)",
+		"title.cookies_off":   "Pote de Cookies (desligado)",
+		"footer.cookies":      "↑↓: selecionar • t: ligar/desligar • e/Enter: editar valor • d: excluir • D: excluir todos • Esc: voltar",
+		"footer.cookies_edit": "Enter: salvar • Esc: cancelar",
+		"cookies.off_hint":    "Cookies definidos pelas respostas não são mantidos. Pressione t para mantê-los nesta sessão.",
+		"cookies.empty":       "Nenhum cookie ainda. Respostas que definem cookies enchem o pote.",
+		"cookies.enabled":     "✓ Pote de cookies ligado: cookies são reenviados nas próximas requisições",
+		"cookies.disabled":    "✓ Pote de cookies desligado: cookies mantidos descartados",
+		"cookies.deleted":     "✓ Cookie %s excluído",
+		"cookies.cleared":     "✓ Todos os cookies excluídos",
+		"cookies.saved":       "✓ Cookie %s salvo",
+		"cookies.edit":        "Valor de %s:",
+		"cookies.session":     "sessão",
+		"cookies.expires":     "expira em %s",
+
+		// JWT decoder
+		"title.jwt":            "Decodificador de JWT",
+		"footer.jwt":           "←→/Tab: próximo token • ↑↓: rolar • Esc: voltar",
+		"jwt.position":         " (%d de %d)",
+		"jwt.in_header":        "Encontrado no cabeçalho %s",
+		"jwt.in_body":          "Encontrado no corpo da resposta",
+		"jwt.none_in_request":  "Nenhum JWT nos cabeçalhos da requisição. Todos os cabeçalhos são procurados, inclusive o que o painel de autenticação adiciona.",
+		"jwt.none_in_response": "Nenhum JWT nos cabeçalhos ou no corpo da resposta.",
+		"jwt.hint":             "🔑 %d JWT na resposta • J: decodificar",
+		"jwt.hint_expired":     "⚠ JWT expirado na resposta (%d encontrados) • J: decodificar",
+		"jwt.no_exp":           "Sem claim exp: o token não expira",
+		"jwt.expired":          "⚠ Expirou há %s, em %s",
+		"jwt.expires_in":       "✓ Expira em %s, às %s",
+		"jwt.not_yet_valid":    "⚠ Ainda não é válido: nbf é daqui a %s",
+		"jwt.unsigned":         "⚠ alg é none: o token não é assinado",
+		"jwt.signature":        "Assinatura: %s, %d bytes (não verificada)",
+		"jwt.header":           "Cabeçalho",
+		"jwt.claims":           "Claims",
+		"jwt.ago":              "há %s",
+		"jwt.from_now":         "daqui a %s",
+
+		// Utilities
+		"title.utilities":   "Utilitários",
+		"footer.utilities":  "↑↓/Tab: selecionar • Enter: copiar resultado • Ctrl+E: usar resultado como entrada • Ctrl+G: novo UUID • Esc: voltar",
+		"utils.input":       "Entrada:",
+		"utils.placeholder": "texto para codificar, decodificar ou calcular hash",
+		"utils.copied":      "✓ Resultado de %s copiado para a área de transferência",
+		"utils.copy_failed": "não foi possível copiar: %v",
+		"utils.uuid":        "✓ Novo UUID aleatório (versão 4)",
+
+		// Proxy settings
+		"title.proxy_settings":  "Configurações de Proxy",
+		"footer.proxy":          "Tab/↑↓: próximo campo • Espaço: alternar • Enter/Ctrl+S: salvar • Esc: voltar",
+		"proxy.url":             "Proxy (todas as requisições):",
+		"proxy.no_proxy":        "Ignorar para (NO_PROXY):",
+		"proxy.from_env":        "Usar HTTP_PROXY, HTTPS_PROXY e NO_PROXY quando nenhum proxy estiver definido",
+		"proxy.env_proxy":       "Proxy do ambiente %s (substitui o de cima):",
+		"proxy.env_placeholder": "vazio: usar o proxy global",
+		"proxy.in_use":          "As requisições passam por %s",
+		"proxy.in_use_env":      "As requisições seguem as variáveis de ambiente de proxy",
+		"proxy.in_use_none":     "As requisições são enviadas diretamente",
+		"proxy.saved":           "✓ Configurações de proxy salvas",
+
+		// TLS settings
+		"title.tls_settings":   "Configurações de TLS",
+		"footer.tls":           "Tab/↑↓: próximo campo • Espaço: alternar • Enter/Ctrl+S: salvar • Esc: voltar",
+		"tls.insecure":         "Não verificar os certificados dos servidores",
+		"tls.insecure_warning": "⚠ Qualquer certificado é aceito: use só com servidores de teste",
+		"tls.ca_file":          "Pacote de CAs (PEM, confiável além das raízes do sistema):",
+		"tls.client_cert":      "Certificado de cliente do ambiente %s (TLS mútuo)",
+		"tls.cert_file":        "Certificado (PEM):",
+		"tls.key_file":         "Chave privada (PEM):",
+		"tls.no_env":           "Ative um ambiente para definir um certificado de cliente para ele",
+		"tls.saved":            "✓ Configurações de TLS salvas",
+		"tls.badge_insecure":   "⚠ TLS NÃO VERIFICADO",
+
+		// Timestamp converter
+		"title.timestamps":  "Conversor de Timestamps",
+		"footer.timestamps": "Tab/Shift+Tab: próximo/anterior valor encontrado • ↑↓: selecionar • Enter: copiar • Ctrl+N: agora • Esc: voltar",
+		"time.input":        "Timestamp:",
+		"time.placeholder":  "1700000000, 1700000000000 ou 2023-11-14T22:13:20Z",
+		"time.found":        "Valor %d de %d • %s",
+		"time.read_as":      "Lido como %s • %s",
+		"time.in_column":    "coluna %s",
+		"time.seconds":      "segundos epoch",
+		"time.millis":       "milissegundos epoch",
+		"time.micros":       "microssegundos epoch",
+		"time.nanos":        "nanossegundos epoch",
+		"time.date":         "uma data",
+
+		// Variable extraction
+		"extract.empty":       "Nenhuma extração ainda. Pressione n para ler um valor desta resposta em uma variável",
+		"extract.save_first":  "Salve a requisição primeiro (s) para extrair variáveis",
+		"extract.target":      "Os valores encontrados são gravados no ambiente %s após cada resposta",
+		"extract.no_env":      "Ative um ambiente para guardar as variáveis extraídas",
+		"extract.read_only":   "Modo somente leitura: as variáveis extraídas não são gravadas",
+		"extract.no_storage":  "Armazenamento indisponível, as variáveis extraídas não podem ser gravadas",
+		"extract.summary":     "%d/%d variáveis extraídas para %s: %s",
+		"extract.name":        "Nome da variável (usada como {{NAME}}):",
+		"extract.source":      "Ler de:",
+		"extract.json_path":   "Caminho JSON:",
+		"extract.header":      "Nome do cabeçalho:",
+		"extract.regex":       "Expressão regular com um grupo de captura:",
+		"extract.suggestions": "Na última resposta: %s",
+
+		// Saving the response body
+		"savebody.path":       "Salvar o corpo da resposta em:",
+		"savebody.hint":       "Enter: salvar • Esc: cancelar • ~/ é sua pasta pessoal",
+		"savebody.exists":     "%s já existe. Enter: substituir • edite o caminho para mantê-lo",
+		"savebody.saved":      "%s salvos em %s",
+		"savebody.nothing":    "Não há corpo de resposta para salvar",
+		"savebody.empty_path": "Informe um arquivo para salvar o corpo",
+		"savebody.is_dir":     "%s é uma pasta, adicione um nome de arquivo",
+
+		// Restoring the last session
+		"restore.db_connect": "Informe a senha para reconectar e voltar para %s, onde você parou",
+
+		// Binary response bodies
+		"binary.title":        "Corpo binário, não exibido para manter o terminal legível",
+		"binary.type":         "Tipo:    %s",
+		"binary.size":         "Tamanho: %s",
+		"binary.image":        "Imagem:  %s, %d×%d px",
+		"binary.unknown_type": "desconhecido (sem Content-Type)",
+		"binary.hint":         "w: salve em um arquivo para abri-lo com outro programa",
+
+		// Side-by-side comparison
+		"title.split":       "Lado a Lado",
+		"footer.split":      "↑↓/PgUp/PgDn/Home/End: rolar ambos • s: trocar lados • x: desafixar • Esc: voltar",
+		"split.pinned":      "|: comparar lado a lado com o fixado %s",
+		"split.pinned_side": "Fixado:",
+		"split.lines":       "Linhas %d-%d de %d",
+		"split.rows":        "%d linhas • %dms",
+
+		// Loading progress
+		"loading.elapsed":          "Tempo decorrido: %s",
+		"loading.received":         "Recebido: %s",
+		"loading.received_of":      "Recebido: %s de %s",
+		"loading.canceling":        "Cancelando...",
+		"loading.request_canceled": "requisição cancelada",
+		"loading.query_canceled":   "consulta cancelada",
+		"footer.loading":           "Esc: cancelar • Ctrl+C: sair",
+
+		// Spooled responses
+		"spool.notice":      "Resposta grande (%s) paginada de um arquivo temporário • copiar, transformações e asserções usam o primeiro 1 MB",
+		"spool.read_failed": "✗ Falha ao ler a resposta: %v",
+
+		// Pre-send checks
+		"precheck.title":           "Requisição não enviada, corrija antes:",
+		"precheck.url":             "URL",
+		"precheck.body":            "Corpo",
+		"precheck.auth":            "Autenticação",
+		"precheck.signing":         "Assinatura HMAC",
+		"precheck.content_length":  "Content-Length",
+		"precheck.undefined_var":   "usa {{%s}}, que o ambiente ativo não define",
+		"precheck.length_invalid":  "%q não é um número de bytes",
+		"precheck.length_mismatch": "o cabeçalho diz %d bytes mas o corpo tem %d",
+
+		// Timeout and retries
+		"title.policy":             "Tempo Limite e Novas Tentativas",
+		"footer.policy":            "Tab/↑↓: próximo campo • Ctrl+F: seguir redirecionamentos • Enter/Ctrl+S: salvar • Ctrl+D: usar padrões • Esc: voltar",
+		"policy.default":           "padrão %s",
+		"policy.custom":            "personalizado",
+		"policy.hint":              "Durações como 10s ou 500ms; a espera dobra antes de cada nova tentativa. Campos vazios mantêm as configurações.",
+		"policy.effective":         "%s é enviada com tempo limite de %s por tentativa e %d novas tentativas",
+		"policy.effective_backoff": "primeira nova tentativa após %s",
+		"policy.safe_only":         "Novas tentativas das configurações só valem para GET, HEAD e OPTIONS; defina-as aqui para repetir esta requisição",
+		"policy.invalid_duration":  "%s: %q não é uma duração como 10s ou 500ms",
+		"policy.invalid_retries":   "Retries: %q não é um número de 0 a %d",
+		"policy.saved":             "✓ Tempo limite e novas tentativas salvos",
+		"policy.removed":           "✓ A requisição usa o tempo limite e as novas tentativas padrão",
+		"policy.attempts":          "%d tentativas",
+		"policy.follow_redirects":  "Seguir redirecionamentos",
+
+		// Query linting
+		"lint.line":          "linha %d: %s",
+		"lint.select_star":   "%s busca todas as colunas; nomeie as que você precisa",
+		"lint.missing_where": "%s sem WHERE altera todas as linhas da tabela",
+		"lint.cross_join":    "%s são unidas sem condição, combinando cada linha com todas as outras",
+		"lint.non_sargable":  "%s impede o uso de um índice na coluna",
+
+		// Timing breakdown
+		"title.timing":     "Tempos da Resposta",
+		"timing.dns":       "DNS",
+		"timing.connect":   "TCP",
+		"timing.tls":       "TLS",
+		"timing.ttfb":      "TTFB",
+		"timing.download":  "Download",
+		"timing.total":     "Total",
+		"timing.hint":      "W: cascata",
+		"timing.starts_at": "em +%s",
+		"timing.reused":    "A conexão foi reaproveitada, então não houve consulta DNS, conexão nem handshake TLS",
+
+		// Query snippets
+		"title.query_snippets":  "Snippets de Consulta (%d)",
+		"footer.query_snippets": "↑↓: selecionar • Enter: inserir no editor • Esc: voltar",
+		"snippets.filling":      "Snippet %s: preenchendo %d de %d (%s)",
+		"snippets.fill_hint":    "Digite para substituir • Tab: próximo • Shift+Tab: anterior • Enter/Esc: concluir",
+
+		// Value search
+		"title.value_search":           "Buscar Valor nas Tabelas",
+		"footer.value_search":          "Enter: buscar • Tab: valor/tabelas/resultados • Espaço: marcar tabela • a: todas/nenhuma • Enter num resultado: abrir consulta • Esc: voltar",
+		"value_search.value":           "Valor (sem diferenciar maiúsculas, parte de uma coluna de texto)",
+		"value_search.tables":          "Tabelas a buscar (%d de %d)",
+		"value_search.no_tables":       "Nenhuma tabela neste banco",
+		"value_search.searching":       "Buscando %q...",
+		"value_search.summary":         "Tabelas com %q: %d de %d.",
+		"value_search.no_text_columns": "Sem colunas de texto: %d.",
+		"value_search.rows":            "linhas: %d",
+		"value_search.rows_limited":    "linhas: %d+",
+		"value_search.table_failed":    "⚠ %s não buscada: %s",
+
+		// Redirects
+		"redirects.title":        "Redirecionamentos seguidos: %d",
+		"redirects.final":        "final",
+		"redirects.loop":         "↺ ciclo",
+		"redirects.not_followed": "↪ Redirecionamento não seguido: Location %s (t: tempo limite e tentativas, Ctrl+F para seguir)",
+
+		// Row request
+		"title.row_request":    "Requisição da API para a Linha %d",
+		"footer.row_request":   "Enter: abrir no construtor de requisições • Tab: tabela/endpoint • Esc: voltar",
+		"row_request.table":    "Tabela",
+		"row_request.endpoint": "Endpoint",
+		"row_request.hint":     "{coluna} recebe o valor da linha, {{VAR}} o ambiente. Salvo para a tabela ao pressionar Enter.",
+		"row_request.preview":  "Requisição",
+
+		// Response search
+		"response_search.prompt":      "Buscar na resposta (Enter: manter • Esc: limpar):",
+		"response_search.placeholder": "texto a encontrar",
+		"response_search.status":      "Busca %q: ocorrência %d de %d • n/N: próxima/anterior • /: editar • Esc: limpar",
+		"response_search.none":        "⚠ Nenhuma ocorrência de %q",
+		"response_search.unavailable": "A busca funciona no corpo e nos cabeçalhos (h)",
+
+		// SQL scaffold
+		"scaffold.failed":  "Não é possível montar uma tabela SQL a partir da resposta: %s",
+		"scaffold.spooled": "O corpo é grande demais para montar uma tabela SQL",
+
+		// Query variables
+		"query_vars.off":       "{{VARIÁVEIS}} são enviadas como escritas • Ctrl+G: preenchê-las pelo ambiente",
+		"query_vars.on":        "✓ {{VARIÁVEIS}} preenchidas pelo ambiente %s • Ctrl+G: desligar",
+		"query_vars.on_no_env": "{{VARIÁVEIS}} são preenchidas pelo ambiente ativo, mas nenhum está ativo • Ctrl+G: desligar",
+		"query_vars.missing":   "⚠ Não executada até estas variáveis terem valor: %s • Ctrl+G: desligar",
+
+		// Result limit
+		"result_limit.limit":        "Limite:",
+		"result_limit.sample":       "Amostra %:",
+		"result_limit.no_limit":     "sem limite",
+		"result_limit.all_rows":     "todas as linhas",
+		"result_limit.hint":         "Enter: executar de novo com este LIMIT e TABLESAMPLE • Tab: trocar campo • vazio remove a cláusula • Esc: cancelar",
+		"result_limit.bad_limit":    "O limite deve ser um número inteiro de linhas, não %s",
+		"result_limit.bad_sample":   "A amostra deve ser uma porcentagem acima de 0 e até 100, não %s",
+		"result_limit.failed":       "Não foi possível alterar a consulta: %s",
+		"result_limit.missing_vars": "Não executada até estas variáveis terem valor: %s",
+
+		// Read-only mode
+		"readonly.banner":  "MODO SOMENTE LEITURA",
+		"readonly.blocked": "✗ Desativado no modo somente leitura: %s",
+
+		// Bulk URL runner
+		"title.bulk":       "Execução de URLs em Lote",
+		"bulk.summary":     "Execução em lote: %d/%d ok",
+		"bulk.no_headers":  "nenhum cabeçalho",
+		"bulk.headers":     "%d cabeçalhos",
+		"bulk.intro":       "Envia %s com %s para cada URL do arquivo (uma por linha, # para comentários)",
+		"bulk.file":        "Arquivo com a lista de URLs:",
+		"bulk.running":     "Executando...",
+		"footer.bulk_file": "Enter: executar • Esc: cancelar",
+		"footer.bulk":      "Enter: executar de novo • f: trocar arquivo • ↑↓: rolar • Esc: voltar",
+
+		// Concurrent duplicate send
+		"title.duplicates":      "Envio Duplicado Concorrente",
+		"duplicate.intro":       "Envia %d cópias de uma vez e compara cada resposta com a primeira",
+		"duplicate.read_only":   "Modo somente leitura: requisições %s estão desativadas",
+		"duplicate.sending":     "Enviando %d requisições...",
+		"duplicate.press_enter": "Pressione Enter para enviar",
+		"footer.duplicates":     "←/→: mudar N • Enter: enviar • ↑↓: rolar • Esc: voltar",
+
+		// Pagination
+		"title.pagination":        "Seguir Paginação",
+		"pagination.rule":         "Regra da próxima página:",
+		"pagination.rule_hint":    "link • cursor=.meta.next param=after • page=page start=1 — também items=.data max=10",
+		"pagination.fetching":     "Buscando páginas...",
+		"pagination.exported":     "✓ Exportado para %s",
+		"footer.pagination_rule":  "Enter: buscar todas as páginas • Esc: cancelar",
+		"footer.pagination":       "v: ver mescladas • e: exportar JSON • r: editar regra • ↑↓: rolar • Esc: voltar",
+		"footer.pagination_empty": "r: editar regra • Esc: voltar",
+
+		// Replay smoke test
+		"title.replay":   "Teste de Fumaça por Replay",
+		"replay.intro":   "Últimas %d requisições GET distintas com sucesso",
+		"replay.no_env":  "nenhum ambiente ativo",
+		"replay.env":     "ambiente: %s",
+		"replay.found":   "%d encontradas no histórico • %s",
+		"replay.running": "Reenviando %d requisições...",
+		"replay.empty":   "Nenhuma requisição GET com sucesso no histórico para reenviar",
+		"footer.replay":  "←/→: mudar N • Enter: reenviar • ↑↓: rolar • Esc: voltar",
+
+		// Workspaces
+		"title.workspaces":     "Espaços de Trabalho (%d)",
+		"workspaces.intro":     "Cada espaço de trabalho tem suas próprias requisições, histórico, ambientes e consultas",
+		"workspaces.active":    " (ativo)",
+		"workspaces.new_name":  "Nome do novo espaço de trabalho:",
+		"footer.workspace_new": "Enter: criar e alternar • Esc: cancelar",
+		"footer.workspaces":    "↑↓: navegar • Enter: alternar • n: novo espaço de trabalho • Esc: voltar",
+
+		// Request variants
+		"variant.load_first": "Carregue uma requisição salva primeiro (Ctrl+L) para adicionar uma variante",
+		"variant.saved":      "✓ Variante %q salva",
+		"variant.name":       "Nome da variante:",
+		"variant.hint":       "Enter: salvar método, URL, cabeçalhos, parâmetros e corpo atuais como variante • Esc: cancelar",
+
+		// GraphQL operations
+		"title.gql_operations":   "Operações GraphQL (%d)",
+		"gql_ops.saved":          "✓ Operação %s salva",
+		"gql_ops.empty":          "Nenhuma operação salva. Pressione a para salvar a requisição GraphQL atual.",
+		"gql_ops.query":          "Consulta:",
+		"gql_ops.variables":      "Variáveis (%d/%d, usadas em %s):",
+		"gql_ops.no_variables":   "Nenhuma variável usada ainda",
+		"gql_ops.confirm_delete": "Pressione d de novo para excluir esta operação, Esc para cancelar",
+		"footer.gql_operations":  "↑↓: navegar • ←/→: conjuntos de variáveis • Enter: carregar • a: salvar requisição atual • d: excluir • Esc: voltar",
+
+		// GraphQL schema
+		"title.gql_schema":          "Schema GraphQL",
+		"gql_schema.fields_only":    "Só campos podem ser inseridos na consulta",
+		"gql_schema.root_only":      "Só campos da raiz query, mutation ou subscription podem ser inseridos",
+		"gql_schema.inserted":       "✓ %s inserido no corpo da requisição",
+		"gql_schema.no_type":        "Nenhum tipo para abrir em %s",
+		"gql_schema.introspecting":  "Executando a consulta de introspecção...",
+		"gql_schema.types":          "Tipos",
+		"gql_schema.nothing_found":  "Nada encontrado",
+		"footer.gql_schema_loading": "Esc: voltar",
+		"footer.gql_schema_failed":  "r: tentar de novo • Esc: voltar",
+		"footer.gql_schema_empty":   "r: introspectar • Esc: voltar",
+		"footer.gql_schema_search":  "Digite para buscar • Enter/Esc: concluir",
+		"footer.gql_schema":         "↑↓: navegar • Enter: abrir tipo • i: inserir campo • /: buscar • r: atualizar • Esc: voltar",
+
+		// Environment editor
+		"env.saved":             "✓ Ambiente salvo/ativado com sucesso!",
+		"env.trashed":           "✓ Ambiente movido para a lixeira (t na tela inicial para restaurar)",
+		"env.empty":             "Nenhum ambiente encontrado",
+		"env.empty_hint":        "Pressione 'n' para criar seu primeiro ambiente (ex.: dev, staging, prod)",
+		"env.var_count":         "(%d variáveis)",
+		"env.var_saved":         "✓ Salvo com sucesso!",
+		"env.var_deleted":       "✓ Variável excluída com sucesso!",
+		"env.name":              "Nome do ambiente:",
+		"env.save_hint":         "Pressione Ctrl+S para salvar o ambiente",
+		"env.variables":         "Variáveis (%d):",
+		"env.no_vars":           "Nenhuma variável ainda",
+		"env.no_vars_hint":      "Pressione 'n' para adicionar uma variável (ex.: API_URL, API_KEY)",
+		"env.edit_var":          "Adicionar/editar variável:",
+		"env.key":               "Chave: ",
+		"env.value":             "Valor: ",
+		"env.name_placeholder":  "nome do ambiente (ex.: dev, staging, prod)",
+		"env.key_placeholder":   "Nome da variável (ex.: API_URL)",
+		"env.value_placeholder": "Valor da variável",
+
+		// Query results
+		"query_result.returned":      "A consulta retornou %d linhas",
+		"query_result.affected":      "A consulta afetou %d linhas",
+		"query_result.failed":        "A consulta falhou",
+		"query_result.time":          "Tempo de execução: %dms",
+		"query_result.chart":         "%s por %s",
+		"query_result.chart_rows":    "Mostrando %d de %d linhas",
+		"query_result.large":         "Conjunto grande • ~%dKB de memória",
+		"query_result.executed":      "✓ Consulta executada com sucesso",
+		"query_result.rows_affected": "Linhas afetadas: %d",
+		"query_result.saved":         "✓ Consulta salva com sucesso",
+		"query_result.export_failed": "✗ Falha ao exportar: %v",
+
+		// Navigation
+		"nav.home":           "Início",
+		"nav.request":        "Requisição",
+		"nav.loading":        "Enviando",
+		"nav.response":       "Resposta",
+		"nav.saved_requests": "Requisições salvas",
+		"nav.headers":        "Cabeçalhos",
+		"nav.body":           "Corpo",
+		"nav.query_params":   "Parâmetros",
+		"nav.help":           "Ajuda",
+		"nav.history":        "Histórico",
+		"nav.database":       "Banco de dados",
+		"nav.db_connect":     "Conectar",
+		"nav.db_query":       "Consulta",
+		"nav.db_result":      "Resultados",
+		"nav.db_saved":       "Consultas salvas",
+		"nav.db_schema":      "Esquema",
+		"nav.db_history":     "Histórico de consultas",
+		"nav.db_export":      "Exportar",
+		"nav.environments":   "Ambientes",
+		"nav.variables":      "Variáveis",
+		"nav.replay":         "Repetição",
+		"nav.workspaces":     "Workspaces",
+		"nav.duplicates":     "Duplicatas",
+		"nav.gql_schema":     "Esquema GraphQL",
+		"nav.gql_operations": "Operações GraphQL",
+		"nav.pagination":     "Paginação",
+		"nav.bulk":           "Execução em lote",
+		"nav.trash":          "Lixeira",
+		"nav.bookmarks":      "Favoritos",
+		"nav.storage":        "Armazenamento indisponível",
+		"nav.assertions":     "Asserções",
+		"nav.signing":        "Assinatura",
+		"nav.url_inspector":  "Inspetor de URL",
+		"nav.aliases":        "Aliases",
+		"nav.tour":           "Tour",
+		"nav.whats_new":      "Novidades",
+		"nav.collections":    "Coleções",
+		"nav.stats":          "Estatísticas",
+		"nav.curl_import":    "Importar cURL",
+		"nav.gql_editor":     "Editor GraphQL",
+		"nav.history_diff":   "Diferenças",
+		"nav.templates":      "Modelos",
+		"nav.collection_run": "Execução da coleção",
+		"nav.raw_socket":     "Socket bruto",
+		"nav.auth":           "Autenticação",
+		"nav.dns":            "Consulta DNS",
+		"nav.cookies":        "Cookies",
+		"nav.jwt":            "JWT",
+		"nav.utilities":      "Utilitários",
+		"nav.proxy":          "Proxy",
+		"nav.tls":            "TLS",
+		"nav.timestamps":     "Timestamps",
+		"nav.extractions":    "Extrações",
+		"nav.split":          "Lado a lado",
+		"nav.policy":         "Tempo limite",
+		"nav.query_snippets": "Snippets",
+		"nav.value_search":   "Buscar Valor",
+		"nav.row_request":    "Requisição da Linha",
+
+		// Request templates
+		"title.templates":      "Modelos de Requisição (%d)",
+		"footer.templates":     "↑↓: selecionar • Enter: usar modelo • Esc: voltar",
+		"footer.template_form": "Tab/↑↓: próximo campo • Enter: próximo/aplicar • Ctrl+S: aplicar • Esc: voltar aos modelos",
+		"templates.empty_hint": "Campos vazios mantêm o marcador {{NAME}} para o ambiente ativo",
+
+		// Session recording
+		"session.name":       "Nome da sessão:",
+		"session.name_hint":  "Enter: gravar cada requisição enviada • Esc: cancelar",
+		"session.need_name":  "Dê um nome à sessão",
+		"session.exists":     "Já existe uma coleção chamada %q",
+		"session.no_storage": "Armazenamento indisponível, não é possível salvar sessões",
+		"session.recording":  "● GRAV %s • %d requisições • %s • R: parar e salvar",
+		"session.empty":      "Gravação de %q parada: nenhuma requisição enviada, nada salvo",
+		"session.saved":      "✓ Sessão %q salva com %d requisições • reproduza com: godev collection run %q",
+
+		// Error categories
+		"error.network.title": "Não foi possível alcançar o servidor",
+		"error.network.hint":  "Verifique o host e a porta, se o servidor está no ar e sua conexão de rede ou VPN",
+		"error.timeout.title": "A requisição excedeu o tempo limite",
+		"error.timeout.hint":  "O servidor está lento ou inacessível; tente de novo ou aumente GODEV_HTTP_TIMEOUT",
+		"error.tls.title":     "Falha na conexão segura",
+		"error.tls.hint":      "Verifique o certificado (expirado, autoassinado ou com host errado) ou se o servidor usa HTTPS",
+		"error.parse.title":   "Entrada inválida",
+		"error.parse.hint":    "Verifique a URL, o corpo JSON ou a consulta em busca de erros de digitação e aspas ou colchetes sem par",
+		"error.storage.title": "Não foi possível salvar seus dados",
+		"error.storage.hint":  "Verifique o espaço em disco e as permissões do diretório de dados do godev",
+		"error.unknown.title": "Algo deu errado",
+		"error.unknown.hint":  "Tente de novo; execute com GODEV_LOG_LEVEL=debug para mais detalhes",
+
+		// Degraded storage
+		"storage.disabled":   "O godev não conseguiu abrir seu diretório de dados. Você pode continuar trabalhando, mas requisições, histórico e ambientes não serão salvos.",
+		"storage.dir_prompt": "Diretório para guardar os dados do godev:",
+		"storage.banner":     "⚠ Persistência desativada: nada é salvo (S na tela inicial para tentar novamente)",
+
+		// Crash recovery
+		"title.crash":       "O godev encontrou um problema",
+		"footer.crash":      "Esc: voltar ao início • Q: sair",
+		"crash.body":        "Um erro interno interrompeu o que você estava fazendo. O godev o capturou, então sua sessão continua aberta.",
+		"crash.saved":       "Um relatório com o estado do app e sua requisição em edição foi salvo em:",
+		"crash.secrets":     "O relatório inclui os cabeçalhos e o corpo da requisição; verifique se há segredos antes de compartilhá-lo.",
+		"crash.save_failed": "Não foi possível salvar o relatório de falha: %v",
+		"crash.error":       "Erro: %s",
+	},
+}
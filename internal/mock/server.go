@@ -0,0 +1,92 @@
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/abneribeiro/godev/internal/errors"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// Server serves example responses for every operation in an OpenAPI document
+type Server struct {
+	spec *storage.OpenAPISpec
+}
+
+// NewServer creates a mock server for the given spec
+func NewServer(spec *storage.OpenAPISpec) *Server {
+	return &Server{spec: spec}
+}
+
+// Routes lists the mocked operations as "METHOD /path"
+func (s *Server) Routes() []string {
+	var routes []string
+	for path, item := range s.spec.Paths {
+		for method := range item {
+			routes = append(routes, fmt.Sprintf("%s %s", strings.ToUpper(method), path))
+		}
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+// ServeHTTP matches the request against the spec and writes the example response
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	op, template, ok := s.spec.FindOperation(r.Method, r.URL.Path)
+	if !ok {
+		slog.Debug("Mock route not found", "method", r.Method, "path", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"error": fmt.Sprintf("no mock route for %s %s", r.Method, r.URL.Path),
+		})
+		return
+	}
+
+	status, body := s.spec.ExampleResponse(op)
+	slog.Debug("Serving mock response", "method", r.Method, "route", template, "status", status)
+
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+
+	data, err := json.MarshalIndent(body, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+// ListenAndServe runs the mock server until the context is cancelled
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return errors.NewHTTPError("mock server failed", err)
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return httpServer.Shutdown(shutdownCtx)
+	}
+}
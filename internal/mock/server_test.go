@@ -0,0 +1,158 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+const testSpec = `{
+	"openapi": "3.0.0",
+	"info": {"title": "Pets", "version": "1.0.0"},
+	"servers": [{"url": "http://localhost:8080/api"}],
+	"paths": {
+		"/pets/{id}": {
+			"get": {
+				"responses": {
+					"200": {
+						"description": "ok",
+						"content": {"application/json": {"example": {"id": 1, "name": "Rex"}}}
+					},
+					"404": {"description": "not found"}
+				}
+			},
+			"delete": {
+				"responses": {"204": {"description": "deleted"}}
+			}
+		},
+		"/pets": {
+			"post": {
+				"responses": {
+					"201": {
+						"description": "created",
+						"content": {
+							"application/json": {
+								"schema": {
+									"type": "object",
+									"properties": {
+										"id": {"type": "integer"},
+										"tags": {"type": "array", "items": {"type": "string"}}
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}`
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	spec, err := storage.ParseOpenAPISpec([]byte(testSpec))
+	if err != nil {
+		t.Fatalf("Failed to parse spec: %v", err)
+	}
+
+	server := httptest.NewServer(NewServer(spec))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestServeExampleResponse(t *testing.T) {
+	server := newTestServer(t)
+
+	resp, err := http.Get(server.URL + "/api/pets/42")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode body: %v", err)
+	}
+
+	if body["name"] != "Rex" {
+		t.Errorf("Expected example body, got %v", body)
+	}
+}
+
+func TestServeGeneratedResponse(t *testing.T) {
+	server := newTestServer(t)
+
+	resp, err := http.Post(server.URL+"/api/pets", "application/json", nil)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("Expected status 201, got %d", resp.StatusCode)
+	}
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode body: %v", err)
+	}
+
+	if _, ok := body["id"]; !ok {
+		t.Errorf("Expected generated body with id, got %v", body)
+	}
+	if tags, ok := body["tags"].([]interface{}); !ok || len(tags) != 1 {
+		t.Errorf("Expected generated tags array, got %v", body["tags"])
+	}
+}
+
+func TestServeNoContent(t *testing.T) {
+	server := newTestServer(t)
+
+	req, _ := http.NewRequest(http.MethodDelete, server.URL+"/api/pets/1", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Expected status 204, got %d", resp.StatusCode)
+	}
+}
+
+func TestServeUnknownRoute(t *testing.T) {
+	server := newTestServer(t)
+
+	resp, err := http.Get(server.URL + "/api/owners")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", resp.StatusCode)
+	}
+}
+
+func TestRoutes(t *testing.T) {
+	spec, _ := storage.ParseOpenAPISpec([]byte(testSpec))
+	routes := NewServer(spec).Routes()
+
+	expected := []string{"DELETE /pets/{id}", "GET /pets/{id}", "POST /pets"}
+	if len(routes) != len(expected) {
+		t.Fatalf("Expected %d routes, got %v", len(expected), routes)
+	}
+	for i := range expected {
+		if routes[i] != expected[i] {
+			t.Errorf("Expected route %q, got %q", expected[i], routes[i])
+		}
+	}
+}
@@ -0,0 +1,248 @@
+// Package paths resolves where godev keeps its configuration and data.
+//
+// The data directory holds saved requests, history, environments, database
+// connections and exports; the config directory holds the settings profile.
+// Both are chosen in this order:
+//
+//  1. the --config-dir flag (SetOverride), used for both
+//  2. GODEV_HOME, used for both
+//  3. XDG_DATA_HOME/godev for data and XDG_CONFIG_HOME/godev for config
+//  4. ~/.godev for data and ~/.config/godev for config
+package paths
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// LegacyDirName is the data directory used before the location was configurable
+const LegacyDirName = ".godev"
+
+// HomeEnv overrides both the data and the config directory
+const HomeEnv = "GODEV_HOME"
+
+var (
+	mu       sync.RWMutex
+	override string
+)
+
+// SetOverride makes dir the data and config directory, ahead of every
+// environment variable. An empty dir removes the override.
+func SetOverride(dir string) {
+	mu.Lock()
+	defer mu.Unlock()
+	override = dir
+}
+
+func explicitDir() string {
+	mu.RLock()
+	dir := override
+	mu.RUnlock()
+	if dir != "" {
+		return dir
+	}
+	return os.Getenv(HomeEnv)
+}
+
+// DataDir returns the directory godev keeps its data in
+func DataDir() (string, error) {
+	if dir := explicitDir(); dir != "" {
+		return filepath.Abs(ExpandHome(dir))
+	}
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, "godev"), nil
+	}
+	return LegacyDir()
+}
+
+// LegacyDir returns ~/.godev
+func LegacyDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, LegacyDirName), nil
+}
+
+// ConfigDir returns the directory godev keeps its settings profile in
+func ConfigDir() string {
+	if dir := explicitDir(); dir != "" {
+		if abs, err := filepath.Abs(ExpandHome(dir)); err == nil {
+			return abs
+		}
+		return dir
+	}
+	return xdgConfigDir()
+}
+
+func xdgConfigDir() string {
+	if configHome := os.Getenv("XDG_CONFIG_HOME"); configHome != "" {
+		return filepath.Join(configHome, "godev")
+	}
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(homeDir, ".config", "godev")
+	}
+	return LegacyDirName
+}
+
+// ExportDir returns the directory exported files are written to, creating it
+func ExportDir() (string, error) {
+	dir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	exportDir := filepath.Join(dir, "exports")
+	if err := os.MkdirAll(exportDir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+	return exportDir, nil
+}
+
+// ExpandHome replaces a leading ~ with the home directory
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, strings.TrimPrefix(path, "~"))
+}
+
+// Migrate moves the data in ~/.godev to XDG_DATA_HOME/godev the first time
+// godev runs with XDG_DATA_HOME set: that is, while the new location has no
+// config.json yet. A directory given with --config-dir or GODEV_HOME is
+// never migrated into, as it may only be meant for one run. Files already
+// at the new location are never overwritten, and lock files stay behind.
+// It reports whether anything was moved.
+func Migrate() (bool, error) {
+	if explicitDir() != "" {
+		return false, nil
+	}
+	dataDir, err := DataDir()
+	if err != nil {
+		return false, err
+	}
+	legacyDir, err := LegacyDir()
+	if err != nil {
+		return false, err
+	}
+	if dataDir == legacyDir {
+		return false, nil
+	}
+
+	moved, err := moveDir(legacyDir, dataDir, "config.json")
+	if err != nil {
+		return moved, fmt.Errorf("failed to migrate %s to %s: %w", legacyDir, dataDir, err)
+	}
+	return moved, nil
+}
+
+// moveDir moves the entries of src into dst unless dst already has marker.
+// Entries are renamed, falling back to a copy across file systems, in which
+// case src keeps its copy. Lock files are left in src, as they belong to
+// the processes that hold them there.
+func moveDir(src, dst, marker string) (bool, error) {
+	if _, err := os.Stat(filepath.Join(dst, marker)); err == nil {
+		return false, nil
+	}
+	if rel, err := filepath.Rel(src, dst); err == nil && !strings.HasPrefix(rel, "..") {
+		// The new location is inside the old one
+		return false, nil
+	}
+
+	entries, err := os.ReadDir(src)
+	if os.IsNotExist(err) || (err == nil && len(entries) == 0) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := os.MkdirAll(dst, 0o700); err != nil {
+		return false, err
+	}
+
+	moved := false
+	for _, entry := range entries {
+		to := filepath.Join(dst, entry.Name())
+		if _, err := os.Lstat(to); err == nil {
+			continue
+		}
+		ok, err := moveEntry(filepath.Join(src, entry.Name()), to, entry)
+		moved = moved || ok
+		if err != nil {
+			return moved, err
+		}
+	}
+	return moved, nil
+}
+
+// moveEntry moves from to the new path to. A directory is moved entry by
+// entry, so the lock files in it stay behind, and is removed once empty.
+func moveEntry(from, to string, entry os.DirEntry) (bool, error) {
+	if !entry.IsDir() {
+		if isLockFile(entry.Name()) {
+			return false, nil
+		}
+		if err := os.Rename(from, to); err == nil {
+			return true, nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return false, err
+		}
+		if !info.Mode().IsRegular() {
+			// Sockets and other special files are recreated on demand
+			return false, nil
+		}
+		return true, copyFile(from, to, info.Mode().Perm())
+	}
+
+	entries, err := os.ReadDir(from)
+	if err != nil {
+		return false, err
+	}
+	if err := os.MkdirAll(to, 0o700); err != nil {
+		return false, err
+	}
+	moved := false
+	for _, child := range entries {
+		ok, err := moveEntry(filepath.Join(from, child.Name()), filepath.Join(to, child.Name()), child)
+		moved = moved || ok
+		if err != nil {
+			return moved, err
+		}
+	}
+	// Fails while lock files or copied entries are left, keeping them
+	os.Remove(from)
+	return moved, nil
+}
+
+// isLockFile reports whether name is the lock of a file
+func isLockFile(name string) bool {
+	return strings.HasSuffix(name, ".lock")
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,140 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func setupHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(HomeEnv, "")
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	SetOverride("")
+	t.Cleanup(func() { SetOverride("") })
+	return home
+}
+
+func TestDirectoryPrecedence(t *testing.T) {
+	home := setupHome(t)
+
+	if dir, _ := DataDir(); dir != filepath.Join(home, ".godev") {
+		t.Errorf("DataDir() = %s, want ~/.godev by default", dir)
+	}
+	if dir := ConfigDir(); dir != filepath.Join(home, ".config", "godev") {
+		t.Errorf("ConfigDir() = %s, want ~/.config/godev by default", dir)
+	}
+
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "config"))
+	if dir, _ := DataDir(); dir != filepath.Join(home, "data", "godev") {
+		t.Errorf("DataDir() = %s, want XDG_DATA_HOME/godev", dir)
+	}
+	if dir := ConfigDir(); dir != filepath.Join(home, "config", "godev") {
+		t.Errorf("ConfigDir() = %s, want XDG_CONFIG_HOME/godev", dir)
+	}
+
+	t.Setenv(HomeEnv, "~/godev-home")
+	want := filepath.Join(home, "godev-home")
+	if dir, _ := DataDir(); dir != want {
+		t.Errorf("DataDir() = %s, want GODEV_HOME %s", dir, want)
+	}
+	if dir := ConfigDir(); dir != want {
+		t.Errorf("ConfigDir() = %s, want GODEV_HOME %s", dir, want)
+	}
+
+	override := filepath.Join(home, "flag")
+	SetOverride(override)
+	if dir, _ := DataDir(); dir != override {
+		t.Errorf("DataDir() = %s, want the override %s", dir, override)
+	}
+	if dir := ConfigDir(); dir != override {
+		t.Errorf("ConfigDir() = %s, want the override %s", dir, override)
+	}
+}
+
+func TestMigrateMovesLegacyData(t *testing.T) {
+	home := setupHome(t)
+
+	legacy := filepath.Join(home, ".godev")
+	if err := os.MkdirAll(filepath.Join(legacy, "workspaces", "client-a"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(legacy, "config.json"), `{"version": "0.4.0"}`)
+	writeFile(t, filepath.Join(legacy, "config.json.lock"), "")
+	writeFile(t, filepath.Join(legacy, "workspaces", "client-a", "config.json"), `{}`)
+	writeFile(t, filepath.Join(legacy, "workspaces", "client-a", "config.json.lock"), "")
+
+	// A directory given for one run is never migrated into
+	override := filepath.Join(home, "one-off")
+	t.Setenv(HomeEnv, override)
+	if moved, err := Migrate(); err != nil || moved {
+		t.Errorf("Migrate() = %v, %v with GODEV_HOME, want nothing moved", moved, err)
+	}
+	t.Setenv(HomeEnv, "")
+	SetOverride(override)
+	if moved, err := Migrate(); err != nil || moved {
+		t.Errorf("Migrate() = %v, %v with --config-dir, want nothing moved", moved, err)
+	}
+	SetOverride("")
+	if _, err := os.Stat(override); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be left alone, stat error = %v", override, err)
+	}
+
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+	target := filepath.Join(home, "data", "godev")
+
+	moved, err := Migrate()
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if !moved {
+		t.Fatal("Expected data to be migrated")
+	}
+
+	for _, name := range []string{"config.json", filepath.Join("workspaces", "client-a", "config.json")} {
+		if _, err := os.Stat(filepath.Join(target, name)); err != nil {
+			t.Errorf("Expected %s at the new location: %v", name, err)
+		}
+		if _, err := os.Stat(filepath.Join(legacy, name)); !os.IsNotExist(err) {
+			t.Errorf("Expected legacy %s to be moved, stat error = %v", name, err)
+		}
+		if _, err := os.Stat(filepath.Join(legacy, name+".lock")); err != nil {
+			t.Errorf("Expected the lock of %s to stay behind: %v", name, err)
+		}
+		if _, err := os.Stat(filepath.Join(target, name+".lock")); !os.IsNotExist(err) {
+			t.Errorf("Expected the lock of %s not to be moved, stat error = %v", name, err)
+		}
+	}
+
+	// A second run finds the data in place and leaves newer legacy files alone
+	writeFile(t, filepath.Join(legacy, "config.json"), `{"version": "old"}`)
+	if moved, err := Migrate(); err != nil || moved {
+		t.Errorf("Migrate() = %v, %v on second run, want nothing moved", moved, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(target, "config.json")); string(data) != `{"version": "0.4.0"}` {
+		t.Errorf("Expected migrated config to be kept, got %s", data)
+	}
+}
+
+func TestMigrateWithoutCustomLocation(t *testing.T) {
+	home := setupHome(t)
+	writeFile(t, filepath.Join(home, ".godev", "config.json"), `{}`)
+
+	if moved, err := Migrate(); err != nil || moved {
+		t.Errorf("Migrate() = %v, %v, want nothing to do for the default location", moved, err)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,291 @@
+// Package plugin loads exporters, auth providers and response viewers that run
+// as separate programs, so teams can add their own formats without forking.
+//
+// Each plugin lives in its own directory under <data dir>/plugins with a
+// plugin.json manifest. godev starts the plugin command for every call, writes
+// one JSON request to its stdin and reads one JSON reply from its stdout.
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/abneribeiro/godev/internal/paths"
+)
+
+// Plugin kinds
+const (
+	KindExporter = "exporter"
+	KindAuth     = "auth"
+	KindViewer   = "viewer"
+)
+
+// ManifestFile is the name of the manifest inside a plugin directory
+const ManifestFile = "plugin.json"
+
+// AuthHeaderPrefix marks a header value that is produced by an auth plugin,
+// for example "Authorization: plugin:vault"
+const AuthHeaderPrefix = "plugin:"
+
+// Table is the query result handed to exporters
+type Table struct {
+	Name    string     `json:"name,omitempty"`
+	Columns []string   `json:"columns"`
+	Rows    [][]string `json:"rows"`
+}
+
+// AuthRequest is the outgoing request handed to auth providers
+type AuthRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// ViewRequest is the response handed to viewers
+type ViewRequest struct {
+	StatusCode  int                 `json:"status_code"`
+	ContentType string              `json:"content_type"`
+	Headers     map[string][]string `json:"headers"`
+	Body        string              `json:"body"`
+}
+
+// Exporter writes query results in a custom format
+type Exporter interface {
+	Name() string
+	Description() string
+	Extension() string
+	Export(ctx context.Context, table Table) ([]byte, error)
+}
+
+// AuthProvider returns the headers that authorize a request
+type AuthProvider interface {
+	Name() string
+	Authorize(ctx context.Context, req AuthRequest) (map[string]string, error)
+}
+
+// ResponseViewer renders response bodies of the content types it handles
+type ResponseViewer interface {
+	Name() string
+	CanView(contentType string) bool
+	View(ctx context.Context, resp ViewRequest) (string, error)
+}
+
+// Registry holds the loaded plugins
+type Registry struct {
+	exporters []Exporter
+	auth      map[string]AuthProvider
+	viewers   []ResponseViewer
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{auth: make(map[string]AuthProvider)}
+}
+
+// Dir returns the default plugin directory
+func Dir() (string, error) {
+	dir, err := paths.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "plugins"), nil
+}
+
+// Load reads every plugin manifest under dir. A missing directory yields an
+// empty registry; broken plugins are skipped and reported in the error list.
+func Load(dir string) (*Registry, []error) {
+	registry := NewRegistry()
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return registry, nil
+	}
+	if err != nil {
+		return registry, []error{fmt.Errorf("failed to read plugin directory: %w", err)}
+	}
+
+	var errs []error
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		manifest, err := LoadManifest(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := registry.Add(newProcess(manifest)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return registry, errs
+}
+
+// Add registers a plugin under every interface it implements
+func (r *Registry) Add(p interface{}) error {
+	added := false
+
+	if exporter, ok := p.(Exporter); ok && kindOf(p, KindExporter) {
+		r.exporters = append(r.exporters, exporter)
+		sort.SliceStable(r.exporters, func(i, j int) bool {
+			return r.exporters[i].Name() < r.exporters[j].Name()
+		})
+		added = true
+	}
+
+	if provider, ok := p.(AuthProvider); ok && kindOf(p, KindAuth) {
+		if _, exists := r.auth[provider.Name()]; exists {
+			return fmt.Errorf("auth plugin %q is already registered", provider.Name())
+		}
+		r.auth[provider.Name()] = provider
+		added = true
+	}
+
+	if viewer, ok := p.(ResponseViewer); ok && kindOf(p, KindViewer) {
+		r.viewers = append(r.viewers, viewer)
+		added = true
+	}
+
+	if !added {
+		return fmt.Errorf("%T does not implement a plugin interface", p)
+	}
+	return nil
+}
+
+// kindOf keeps subprocess plugins, which implement every interface, to the
+// kind declared in their manifest
+func kindOf(p interface{}, kind string) bool {
+	if proc, ok := p.(*process); ok {
+		return proc.manifest.Kind == kind
+	}
+	return true
+}
+
+// Exporters returns the registered exporters sorted by name
+func (r *Registry) Exporters() []Exporter {
+	if r == nil {
+		return nil
+	}
+	return r.exporters
+}
+
+// AuthProvider returns the auth provider with the given name
+func (r *Registry) AuthProvider(name string) (AuthProvider, bool) {
+	if r == nil {
+		return nil, false
+	}
+	provider, ok := r.auth[name]
+	return provider, ok
+}
+
+// Viewer returns the first viewer that handles contentType
+func (r *Registry) Viewer(contentType string) (ResponseViewer, bool) {
+	if r == nil {
+		return nil, false
+	}
+	for _, viewer := range r.viewers {
+		if viewer.CanView(contentType) {
+			return viewer, true
+		}
+	}
+	return nil, false
+}
+
+// Count returns the number of registered plugins
+func (r *Registry) Count() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.exporters) + len(r.auth) + len(r.viewers)
+}
+
+// Authorize replaces every header whose value is "plugin:<name>" with the
+// headers returned by that auth provider
+func (r *Registry) Authorize(ctx context.Context, req AuthRequest) (map[string]string, error) {
+	headers := make(map[string]string, len(req.Headers))
+	var providers []string
+	for key, value := range req.Headers {
+		if strings.HasPrefix(value, AuthHeaderPrefix) {
+			providers = append(providers, strings.TrimSpace(strings.TrimPrefix(value, AuthHeaderPrefix)))
+			continue
+		}
+		headers[key] = value
+	}
+	if len(providers) == 0 {
+		return req.Headers, nil
+	}
+	sort.Strings(providers)
+
+	req.Headers = headers
+	for _, name := range providers {
+		provider, ok := r.AuthProvider(name)
+		if !ok {
+			return nil, fmt.Errorf("auth plugin %q is not installed", name)
+		}
+		added, err := provider.Authorize(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("auth plugin %q failed: %w", name, err)
+		}
+		for key, value := range added {
+			headers[key] = value
+		}
+	}
+
+	return headers, nil
+}
+
+// ExportFile runs an exporter and writes its output to the exports directory
+func ExportFile(ctx context.Context, exporter Exporter, table Table, timestamp string) (string, error) {
+	if len(table.Columns) == 0 {
+		return "", fmt.Errorf("no data to export")
+	}
+
+	data, err := exporter.Export(ctx, table)
+	if err != nil {
+		return "", err
+	}
+
+	exportDir, err := paths.ExportDir()
+	if err != nil {
+		return "", err
+	}
+
+	ext := strings.TrimPrefix(exporter.Extension(), ".")
+	if ext == "" {
+		ext = "txt"
+	}
+	filePath := filepath.Join(exportDir, fmt.Sprintf("export_%s.%s", timestamp, ext))
+	if err := os.WriteFile(filePath, data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	return filePath, nil
+}
+
+// LoadManifest reads and validates the manifest of the plugin in dir
+func LoadManifest(dir string) (Manifest, error) {
+	path := filepath.Join(dir, ManifestFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to read plugin manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return Manifest{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	manifest.Dir = dir
+
+	if err := manifest.Validate(); err != nil {
+		return Manifest{}, fmt.Errorf("invalid plugin %s: %w", path, err)
+	}
+
+	return manifest, nil
+}
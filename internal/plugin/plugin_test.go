@@ -0,0 +1,177 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// writePlugin creates a plugin directory whose command is a shell script
+func writePlugin(t *testing.T, root, name, manifest, script string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins are not supported on Windows")
+	}
+
+	dir := filepath.Join(root, name)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), []byte(manifest), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "run.sh"), []byte("#!/bin/sh\n"+script), 0o700); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+func TestLoadMissingDirectory(t *testing.T) {
+	registry, errs := Load(filepath.Join(t.TempDir(), "plugins"))
+	if len(errs) != 0 {
+		t.Fatalf("Load() errors = %v", errs)
+	}
+	if registry.Count() != 0 {
+		t.Errorf("Count() = %d, want 0", registry.Count())
+	}
+}
+
+func TestLoadSkipsInvalidManifests(t *testing.T) {
+	root := t.TempDir()
+	writePlugin(t, root, "broken", `{"name": "broken", "kind": "exporter", "command": ["./run.sh"]}`, "")
+	writePlugin(t, root, "yaml", `{"name": "yaml", "kind": "exporter", "extension": "yaml", "command": ["./run.sh"]}`, "")
+
+	registry, errs := Load(root)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "extension") {
+		t.Fatalf("Load() errors = %v, want one extension error", errs)
+	}
+	if len(registry.Exporters()) != 1 || registry.Exporters()[0].Name() != "yaml" {
+		t.Fatalf("Exporters() = %v, want yaml", registry.Exporters())
+	}
+}
+
+func TestExporterPlugin(t *testing.T) {
+	root := t.TempDir()
+	writePlugin(t, root, "rows", `{"name": "rows", "kind": "exporter", "extension": ".txt", "command": ["./run.sh"]}`,
+		`input=$(cat)
+case "$input" in
+*'"action":"export"'*'"columns":["id"]'*) echo '{"output": "1 row"}' ;;
+*) echo '{"error": "unexpected input"}' ;;
+esac
+`)
+
+	registry, errs := Load(root)
+	if len(errs) != 0 {
+		t.Fatalf("Load() errors = %v", errs)
+	}
+
+	data, err := registry.Exporters()[0].Export(context.Background(), Table{Columns: []string{"id"}, Rows: [][]string{{"1"}}})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if string(data) != "1 row" {
+		t.Errorf("Export() = %q, want %q", data, "1 row")
+	}
+
+	t.Setenv("HOME", t.TempDir())
+	path, err := ExportFile(context.Background(), registry.Exporters()[0], Table{Columns: []string{"id"}}, "20240101_000000")
+	if err != nil {
+		t.Fatalf("ExportFile() error = %v", err)
+	}
+	if filepath.Base(path) != "export_20240101_000000.txt" {
+		t.Errorf("ExportFile() path = %s", path)
+	}
+}
+
+func TestAuthorizeReplacesPluginHeaders(t *testing.T) {
+	root := t.TempDir()
+	writePlugin(t, root, "vault", `{"name": "vault", "kind": "auth", "command": ["./run.sh"]}`,
+		`cat >/dev/null
+echo '{"headers": {"Authorization": "Bearer secret"}}'
+`)
+
+	registry, _ := Load(root)
+	headers, err := registry.Authorize(context.Background(), AuthRequest{
+		Method: "GET",
+		URL:    "https://api.example.com",
+		Headers: map[string]string{
+			"Authorization": "plugin:vault",
+			"Accept":        "application/json",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+	if headers["Authorization"] != "Bearer secret" || headers["Accept"] != "application/json" {
+		t.Errorf("Authorize() headers = %v", headers)
+	}
+
+	_, err = registry.Authorize(context.Background(), AuthRequest{Headers: map[string]string{"X-Token": "plugin:missing"}})
+	if err == nil || !strings.Contains(err.Error(), "not installed") {
+		t.Errorf("Authorize() error = %v, want not installed", err)
+	}
+}
+
+func TestPluginErrors(t *testing.T) {
+	root := t.TempDir()
+	writePlugin(t, root, "failing", `{"name": "failing", "kind": "auth", "command": ["./run.sh"]}`,
+		`echo "token expired" >&2
+exit 1
+`)
+	writePlugin(t, root, "refusing", `{"name": "refusing", "kind": "auth", "command": ["./run.sh"]}`,
+		`echo '{"error": "no credentials"}'
+`)
+	writePlugin(t, root, "slow", `{"name": "slow", "kind": "auth", "timeout": "100ms", "command": ["./run.sh"]}`,
+		`exec sleep 5
+`)
+
+	registry, errs := Load(root)
+	if len(errs) != 0 {
+		t.Fatalf("Load() errors = %v", errs)
+	}
+
+	tests := map[string]string{
+		"failing":  "token expired",
+		"refusing": "no credentials",
+		"slow":     "timed out",
+	}
+	for name, want := range tests {
+		provider, ok := registry.AuthProvider(name)
+		if !ok {
+			t.Fatalf("AuthProvider(%q) not found", name)
+		}
+		_, err := provider.Authorize(context.Background(), AuthRequest{})
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: Authorize() error = %v, want %q", name, err, want)
+		}
+	}
+}
+
+func TestViewerMatchesContentType(t *testing.T) {
+	viewer := newProcess(Manifest{Name: "proto", Kind: KindViewer, ContentTypes: []string{"application/x-protobuf", "image/*"}})
+
+	tests := map[string]bool{
+		"application/x-protobuf":               true,
+		"Application/X-Protobuf; charset=utf8": true,
+		"image/png":                            true,
+		"application/json":                     false,
+	}
+	for contentType, want := range tests {
+		if got := viewer.CanView(contentType); got != want {
+			t.Errorf("CanView(%q) = %v, want %v", contentType, got, want)
+		}
+	}
+
+	registry := NewRegistry()
+	if err := registry.Add(viewer); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if _, ok := registry.Viewer("image/gif"); !ok {
+		t.Error("Viewer(image/gif) not found")
+	}
+	if _, ok := registry.AuthProvider("proto"); ok {
+		t.Error("viewer plugin registered as auth provider")
+	}
+}
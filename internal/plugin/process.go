@@ -0,0 +1,174 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// Manifest describes a plugin and how to start it
+type Manifest struct {
+	Name        string   `json:"name"`
+	Kind        string   `json:"kind"`
+	Description string   `json:"description,omitempty"`
+	Command     []string `json:"command"`
+	// Extension is the file extension written by exporters
+	Extension string `json:"extension,omitempty"`
+	// ContentTypes lists what viewers render; a trailing * matches a prefix
+	ContentTypes []string `json:"content_types,omitempty"`
+	Timeout      string   `json:"timeout,omitempty"`
+
+	// Dir is the plugin directory; relative command paths resolve against it
+	Dir string `json:"-"`
+}
+
+// Validate checks the fields required for the plugin kind
+func (m Manifest) Validate() error {
+	if strings.TrimSpace(m.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(m.Command) == 0 || m.Command[0] == "" {
+		return fmt.Errorf("command is required")
+	}
+	if m.Timeout != "" {
+		if _, err := time.ParseDuration(m.Timeout); err != nil {
+			return fmt.Errorf("invalid timeout: %w", err)
+		}
+	}
+
+	switch m.Kind {
+	case KindExporter:
+		if m.Extension == "" {
+			return fmt.Errorf("exporter plugins need an extension")
+		}
+	case KindAuth:
+	case KindViewer:
+		if len(m.ContentTypes) == 0 {
+			return fmt.Errorf("viewer plugins need content_types")
+		}
+	default:
+		return fmt.Errorf("unknown kind %q (expected %s, %s or %s)", m.Kind, KindExporter, KindAuth, KindViewer)
+	}
+
+	return nil
+}
+
+// call is the JSON written to the plugin's stdin
+type call struct {
+	Action   string       `json:"action"`
+	Table    *Table       `json:"table,omitempty"`
+	Request  *AuthRequest `json:"request,omitempty"`
+	Response *ViewRequest `json:"response,omitempty"`
+}
+
+// reply is the JSON the plugin writes to stdout
+type reply struct {
+	Output  string            `json:"output,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// process runs a plugin command once per call
+type process struct {
+	manifest Manifest
+}
+
+func newProcess(manifest Manifest) *process {
+	return &process{manifest: manifest}
+}
+
+func (p *process) Name() string        { return p.manifest.Name }
+func (p *process) Description() string { return p.manifest.Description }
+func (p *process) Extension() string   { return p.manifest.Extension }
+
+func (p *process) Export(ctx context.Context, table Table) ([]byte, error) {
+	out, err := p.run(ctx, call{Action: "export", Table: &table})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(out.Output), nil
+}
+
+func (p *process) Authorize(ctx context.Context, req AuthRequest) (map[string]string, error) {
+	out, err := p.run(ctx, call{Action: "authorize", Request: &req})
+	if err != nil {
+		return nil, err
+	}
+	return out.Headers, nil
+}
+
+func (p *process) CanView(contentType string) bool {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0]))
+	for _, pattern := range p.manifest.ContentTypes {
+		pattern = strings.ToLower(pattern)
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(mediaType, prefix) {
+				return true
+			}
+		} else if mediaType == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *process) View(ctx context.Context, resp ViewRequest) (string, error) {
+	out, err := p.run(ctx, call{Action: "view", Response: &resp})
+	if err != nil {
+		return "", err
+	}
+	return out.Output, nil
+}
+
+func (p *process) run(ctx context.Context, c call) (reply, error) {
+	timeout := defaultTimeout
+	if p.manifest.Timeout != "" {
+		timeout, _ = time.ParseDuration(p.manifest.Timeout)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	input, err := json.Marshal(c)
+	if err != nil {
+		return reply{}, fmt.Errorf("failed to encode plugin request: %w", err)
+	}
+
+	name := p.manifest.Command[0]
+	if !filepath.IsAbs(name) && strings.ContainsRune(name, filepath.Separator) {
+		name = filepath.Join(p.manifest.Dir, name)
+	}
+
+	cmd := exec.CommandContext(ctx, name, p.manifest.Command[1:]...)
+	cmd.Dir = p.manifest.Dir
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return reply{}, fmt.Errorf("plugin %s timed out after %s", p.manifest.Name, timeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return reply{}, fmt.Errorf("plugin %s failed: %s", p.manifest.Name, msg)
+		}
+		return reply{}, fmt.Errorf("plugin %s failed: %w", p.manifest.Name, err)
+	}
+
+	var out reply
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return reply{}, fmt.Errorf("plugin %s returned invalid JSON: %w", p.manifest.Name, err)
+	}
+	if out.Error != "" {
+		return reply{}, fmt.Errorf("%s", out.Error)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,283 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/abneribeiro/godev/internal/errors"
+)
+
+// maxRecordedBodySize limits how much of each body is kept in the recording
+const maxRecordedBodySize = 1 << 20
+
+// hopHeaders are connection-specific headers that must not be forwarded
+var hopHeaders = []string{
+	"Connection",
+	"Proxy-Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// Exchange is a single proxied request and its response
+type Exchange struct {
+	Method       string
+	URL          string
+	Headers      map[string]string
+	Body         string
+	StatusCode   int
+	Status       string
+	ResponseBody string
+	ResponseTime time.Duration
+	Error        error
+}
+
+// Recorder receives every exchange that passes through the proxy. It may be
+// called concurrently.
+type Recorder func(Exchange)
+
+// Proxy is a forward HTTP proxy that records traffic. HTTPS traffic is
+// tunnelled with CONNECT without TLS interception, so only the target host is
+// recorded for it.
+type Proxy struct {
+	transport *http.Transport
+	record    Recorder
+}
+
+// New creates a recording proxy
+func New(record Recorder) *Proxy {
+	return &Proxy{
+		transport: &http.Transport{
+			Proxy:                 nil,
+			DialContext:           (&net.Dialer{Timeout: 30 * time.Second}).DialContext,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: 60 * time.Second,
+		},
+		record: record,
+	}
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodConnect {
+		p.handleConnect(w, r)
+		return
+	}
+
+	if !r.URL.IsAbs() {
+		http.Error(w, "godev proxy: requests must use an absolute URL", http.StatusBadRequest)
+		return
+	}
+
+	p.handleHTTP(w, r)
+}
+
+func (p *Proxy) handleHTTP(w http.ResponseWriter, r *http.Request) {
+	startTime := time.Now()
+	logger := slog.With("method", r.Method, "url", r.URL.String())
+
+	exchange := Exchange{
+		Method:  r.Method,
+		URL:     r.URL.String(),
+		Headers: flattenHeaders(r.Header),
+	}
+
+	// Stream the body upstream while keeping a bounded copy for the recording
+	capturedReq := &limitedBuffer{limit: maxRecordedBodySize}
+	var reqBody io.Reader = http.NoBody
+	if r.Body != nil && r.Body != http.NoBody {
+		reqBody = io.TeeReader(r.Body, capturedReq)
+	}
+
+	outReq, err := http.NewRequestWithContext(r.Context(), r.Method, r.URL.String(), reqBody)
+	if err != nil {
+		http.Error(w, "godev proxy: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	outReq.ContentLength = r.ContentLength
+	outReq.Header = r.Header.Clone()
+	removeHopHeaders(outReq.Header)
+
+	resp, err := p.transport.RoundTrip(outReq)
+	exchange.Body = capturedReq.String()
+	if err != nil {
+		logger.Warn("Proxied request failed", "error", err)
+		exchange.Error = errors.NewHTTPError("proxied request failed", err)
+		exchange.ResponseTime = time.Since(startTime)
+		p.emit(exchange)
+		http.Error(w, "godev proxy: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	removeHopHeaders(resp.Header)
+	for key, values := range resp.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+
+	// Stream the body to the client while keeping a bounded copy for the recording
+	captured := &limitedBuffer{limit: maxRecordedBodySize}
+	if _, err := io.Copy(w, io.TeeReader(resp.Body, captured)); err != nil {
+		logger.Debug("Failed to stream proxied response", "error", err)
+	}
+
+	exchange.StatusCode = resp.StatusCode
+	exchange.Status = resp.Status
+	exchange.ResponseBody = captured.String()
+	exchange.ResponseTime = time.Since(startTime)
+	p.emit(exchange)
+
+	logger.Debug("Proxied request completed", "status_code", resp.StatusCode)
+}
+
+func (p *Proxy) handleConnect(w http.ResponseWriter, r *http.Request) {
+	startTime := time.Now()
+
+	exchange := Exchange{
+		Method:  http.MethodConnect,
+		URL:     "https://" + r.Host,
+		Headers: flattenHeaders(r.Header),
+	}
+
+	target, err := net.DialTimeout("tcp", r.Host, 30*time.Second)
+	if err != nil {
+		exchange.Error = errors.NewHTTPError("tunnel failed", err)
+		exchange.ResponseTime = time.Since(startTime)
+		p.emit(exchange)
+		http.Error(w, "godev proxy: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		target.Close()
+		http.Error(w, "godev proxy: hijacking not supported", http.StatusInternalServerError)
+		return
+	}
+
+	client, rw, err := hijacker.Hijack()
+	if err != nil {
+		target.Close()
+		return
+	}
+
+	client.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n"))
+
+	// The client may have sent more than the CONNECT request, such as the
+	// start of its TLS handshake, and the server read it into its buffer
+	if n := rw.Reader.Buffered(); n > 0 {
+		buffered, _ := rw.Reader.Peek(n)
+		if _, err := target.Write(buffered); err != nil {
+			client.Close()
+			target.Close()
+			return
+		}
+	}
+
+	exchange.StatusCode = http.StatusOK
+	exchange.Status = "200 Connection Established"
+	exchange.ResponseTime = time.Since(startTime)
+	p.emit(exchange)
+
+	go tunnel(target, client)
+	tunnel(client, target)
+}
+
+// tunnel copies from src to dst and closes both ends when done
+func tunnel(dst, src net.Conn) {
+	defer dst.Close()
+	defer src.Close()
+	io.Copy(dst, src)
+}
+
+func (p *Proxy) emit(exchange Exchange) {
+	if p.record != nil {
+		p.record(exchange)
+	}
+}
+
+// ListenAndServe runs the proxy until the context is cancelled
+func (p *Proxy) ListenAndServe(ctx context.Context, addr string) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           p,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return errors.NewHTTPError("proxy server failed", err)
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+func removeHopHeaders(header http.Header) {
+	for _, h := range hopHeaders {
+		header.Del(h)
+	}
+}
+
+// flattenHeaders keeps the first value of each header, matching saved requests
+func flattenHeaders(header http.Header) map[string]string {
+	flat := make(map[string]string, len(header))
+	for key, values := range header {
+		if strings.HasPrefix(strings.ToLower(key), "proxy-") {
+			continue
+		}
+		if len(values) > 0 {
+			flat[key] = values[0]
+		}
+	}
+	return flat
+}
+
+// limitedBuffer keeps at most limit bytes and silently drops the rest. It
+// may be read while written, as the transport can still be sending a request
+// body when the response arrives.
+type limitedBuffer struct {
+	mu    sync.Mutex
+	buf   bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if remaining := b.limit - b.buf.Len(); remaining > 0 {
+		if len(p) > remaining {
+			b.buf.Write(p[:remaining])
+		} else {
+			b.buf.Write(p)
+		}
+	}
+	return len(p), nil
+}
+
+func (b *limitedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,186 @@
+package proxy
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestProxyRecordsExchange(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"echo": "` + string(body) + `"}`))
+	}))
+	defer backend.Close()
+
+	var mu sync.Mutex
+	var recorded []Exchange
+	proxyServer := httptest.NewServer(New(func(e Exchange) {
+		mu.Lock()
+		defer mu.Unlock()
+		recorded = append(recorded, e)
+	}))
+	defer proxyServer.Close()
+
+	proxyURL, _ := url.Parse(proxyServer.URL)
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+
+	req, _ := http.NewRequest(http.MethodPost, backend.URL+"/items?x=1", strings.NewReader("hi"))
+	req.Header.Set("X-Test", "yes")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Request through proxy failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("Expected status 201, got %d", resp.StatusCode)
+	}
+	if string(body) != `{"echo": "hi"}` {
+		t.Errorf("Unexpected body through proxy: %s", body)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(recorded) != 1 {
+		t.Fatalf("Expected 1 recorded exchange, got %d", len(recorded))
+	}
+
+	e := recorded[0]
+	if e.Method != "POST" || e.URL != backend.URL+"/items?x=1" {
+		t.Errorf("Unexpected request recorded: %s %s", e.Method, e.URL)
+	}
+	if e.Headers["X-Test"] != "yes" || e.Body != "hi" {
+		t.Errorf("Expected request headers and body to be recorded, got %+v", e)
+	}
+	if e.StatusCode != http.StatusCreated || e.ResponseBody != `{"echo": "hi"}` {
+		t.Errorf("Expected response to be recorded, got %d %q", e.StatusCode, e.ResponseBody)
+	}
+}
+
+func TestProxyRejectsRelativeURL(t *testing.T) {
+	proxyServer := httptest.NewServer(New(nil))
+	defer proxyServer.Close()
+
+	resp, err := http.Get(proxyServer.URL + "/not-proxied")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestProxyStreamsLargeRequestBody(t *testing.T) {
+	received := make(chan int64, 1)
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, _ := io.Copy(io.Discard, r.Body)
+		received <- n
+	}))
+	defer backend.Close()
+
+	var mu sync.Mutex
+	var recorded []Exchange
+	proxyServer := httptest.NewServer(New(func(e Exchange) {
+		mu.Lock()
+		defer mu.Unlock()
+		recorded = append(recorded, e)
+	}))
+	defer proxyServer.Close()
+
+	proxyURL, _ := url.Parse(proxyServer.URL)
+	client := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+
+	size := int64(3*maxRecordedBodySize + 7)
+	resp, err := client.Post(backend.URL+"/upload", "application/octet-stream", io.LimitReader(zeros{}, size))
+	if err != nil {
+		t.Fatalf("Request through proxy failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if got := <-received; got != size {
+		t.Errorf("Expected the backend to receive %d bytes, got %d", size, got)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(recorded) != 1 || len(recorded[0].Body) != maxRecordedBodySize {
+		t.Errorf("Expected the recording to keep %d bytes of the body", maxRecordedBodySize)
+	}
+}
+
+// zeros reads as an endless stream of zero bytes
+type zeros struct{}
+
+func (zeros) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+func TestProxyTunnelKeepsBytesSentWithConnect(t *testing.T) {
+	echo, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	defer echo.Close()
+	go func() {
+		conn, err := echo.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		io.Copy(conn, conn)
+	}()
+
+	proxyServer := httptest.NewServer(New(nil))
+	defer proxyServer.Close()
+
+	conn, err := net.Dial("tcp", proxyServer.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	// The first bytes of the tunnel arrive with the CONNECT request, as a
+	// pipelined TLS ClientHello would
+	addr := echo.Addr().String()
+	if _, err := conn.Write([]byte("CONNECT " + addr + " HTTP/1.1\r\nHost: " + addr + "\r\n\r\nhello")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, &http.Request{Method: http.MethodConnect})
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("CONNECT = %v, %v", resp, err)
+	}
+	got := make([]byte, len("hello"))
+	if _, err := io.ReadFull(reader, got); err != nil || string(got) != "hello" {
+		t.Errorf("Expected the bytes sent with CONNECT to reach the target, got %q, %v", got, err)
+	}
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	buf := &limitedBuffer{limit: 4}
+
+	n, err := buf.Write([]byte("abcdef"))
+	if err != nil || n != 6 {
+		t.Errorf("Expected write to report full length, got %d, %v", n, err)
+	}
+
+	if buf.String() != "abcd" {
+		t.Errorf("Expected buffer to keep 4 bytes, got %q", buf.String())
+	}
+}
@@ -0,0 +1,255 @@
+// Package rawsock is a scratchpad for probing services that do not speak
+// HTTP: it connects over TCP, TLS or UDP, sends typed bytes and keeps a
+// transcript of what went out and what came back.
+package rawsock
+
+import (
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Network is the transport a Conn talks over
+type Network string
+
+const (
+	TCP Network = "tcp"
+	TLS Network = "tls"
+	UDP Network = "udp"
+)
+
+// Networks lists the transports in the order they are offered
+var Networks = []Network{TCP, TLS, UDP}
+
+// readBuffer is the most read at once; for UDP it is the largest datagram kept
+const readBuffer = 64 * 1024
+
+// writeTimeout bounds a single Send so a stalled peer cannot hang the caller
+const writeTimeout = 10 * time.Second
+
+// Exchange is one entry of a transcript: bytes sent or bytes received
+type Exchange struct {
+	Sent bool
+	Data []byte
+	At   time.Time
+}
+
+// Options configure Dial
+type Options struct {
+	Timeout time.Duration
+	// Insecure skips verifying the certificate of a TLS server
+	Insecure bool
+}
+
+// Conn is an open connection and the transcript of what it carried. The
+// transcript is filled by a goroutine reading from the connection, so it is
+// safe to call Transcript while data arrives.
+type Conn struct {
+	network Network
+	addr    string
+	conn    net.Conn
+
+	mu         sync.Mutex
+	transcript []Exchange
+	closed     bool
+	// err is why reading stopped; io.EOF when the peer closed the connection
+	err error
+}
+
+// Dial connects to addr ("host:port") over network and starts reading
+func Dial(network Network, addr string, opts Options) (*Conn, error) {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return nil, fmt.Errorf("invalid address %q: want host:port", addr)
+	}
+	dialer := &net.Dialer{Timeout: opts.Timeout}
+
+	var conn net.Conn
+	var err error
+	switch network {
+	case TCP, UDP:
+		conn, err = dialer.Dial(string(network), addr)
+	case TLS:
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{
+			InsecureSkipVerify: opts.Insecure, // #nosec G402 -- opt-in for probing test servers
+		})
+	default:
+		return nil, fmt.Errorf("unknown network %q", network)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Conn{network: network, addr: addr, conn: conn}
+	go c.readLoop()
+	return c, nil
+}
+
+// Network returns the transport the connection uses
+func (c *Conn) Network() Network { return c.network }
+
+// Addr returns the address that was dialed
+func (c *Conn) Addr() string { return c.addr }
+
+// RemoteAddr returns the address the connection reached
+func (c *Conn) RemoteAddr() string { return c.conn.RemoteAddr().String() }
+
+func (c *Conn) readLoop() {
+	buf := make([]byte, readBuffer)
+	for {
+		n, err := c.conn.Read(buf)
+		if n > 0 {
+			c.record(false, buf[:n])
+		}
+		if err != nil {
+			c.mu.Lock()
+			if !c.closed {
+				c.err = err
+			}
+			c.closed = true
+			c.mu.Unlock()
+			return
+		}
+	}
+}
+
+func (c *Conn) record(sent bool, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.transcript = append(c.transcript, Exchange{Sent: sent, Data: append([]byte(nil), data...), At: time.Now()})
+}
+
+// Send writes data to the connection and adds it to the transcript
+func (c *Conn) Send(data []byte) error {
+	if c.Closed() {
+		return errors.New("connection is closed")
+	}
+	if err := c.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	if _, err := c.conn.Write(data); err != nil {
+		return err
+	}
+	c.record(true, data)
+	return nil
+}
+
+// Transcript returns what was sent and received so far
+func (c *Conn) Transcript() []Exchange {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Exchange(nil), c.transcript...)
+}
+
+// Closed reports whether the connection stopped, by Close or by the peer
+func (c *Conn) Closed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// Err returns why reading stopped: io.EOF when the peer closed the
+// connection, nil while it is open or after Close
+func (c *Conn) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// Close closes the connection
+func (c *Conn) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	return c.conn.Close()
+}
+
+// ClosedByPeer reports whether err means the other side closed the connection
+func ClosedByPeer(err error) bool {
+	return errors.Is(err, io.EOF)
+}
+
+// ParseText turns typed text into bytes. The escapes \r, \n, \t, \0, \\
+// and \xNN stand for the bytes they name, so a protocol line can be typed
+// as "PING\r\n".
+func ParseText(input string) ([]byte, error) {
+	var out []byte
+	for i := 0; i < len(input); i++ {
+		ch := input[i]
+		if ch != '\\' {
+			out = append(out, ch)
+			continue
+		}
+		if i+1 >= len(input) {
+			return nil, errors.New("trailing \\ at end of input")
+		}
+		i++
+		switch input[i] {
+		case 'r':
+			out = append(out, '\r')
+		case 'n':
+			out = append(out, '\n')
+		case 't':
+			out = append(out, '\t')
+		case '0':
+			out = append(out, 0)
+		case '\\':
+			out = append(out, '\\')
+		case 'x':
+			if i+2 >= len(input) {
+				return nil, errors.New("\\x needs two hex digits")
+			}
+			b, err := strconv.ParseUint(input[i+1:i+3], 16, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid escape \\x%s", input[i+1:i+3])
+			}
+			out = append(out, byte(b))
+			i += 2
+		default:
+			return nil, fmt.Errorf("unknown escape \\%c", input[i])
+		}
+	}
+	return out, nil
+}
+
+// ParseHex turns hex digits into bytes. Spaces, colons and an optional 0x
+// prefix are ignored, so "de ad be ef", "de:ad:be:ef" and "0xdeadbeef"
+// are the same four bytes.
+func ParseHex(input string) ([]byte, error) {
+	input = strings.TrimPrefix(strings.TrimSpace(input), "0x")
+	input = strings.NewReplacer(" ", "", ":", "", "\t", "").Replace(input)
+	data, err := hex.DecodeString(input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex: %w", err)
+	}
+	return data, nil
+}
+
+// Escape renders data as text, escaping the bytes that are not printable
+// ASCII the way ParseText reads them, so a line can be copied and sent back
+func Escape(data []byte) string {
+	var b strings.Builder
+	for _, ch := range data {
+		switch {
+		case ch == '\r':
+			b.WriteString(`\r`)
+		case ch == '\n':
+			b.WriteString(`\n`)
+		case ch == '\t':
+			b.WriteString(`\t`)
+		case ch == '\\':
+			b.WriteString(`\\`)
+		case ch < 0x20 || ch > 0x7e:
+			fmt.Fprintf(&b, `\x%02x`, ch)
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String()
+}
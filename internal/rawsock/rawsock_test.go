@@ -0,0 +1,182 @@
+package rawsock
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseText(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []byte
+	}{
+		{`PING\r\n`, []byte("PING\r\n")},
+		{`a\tb\\c`, []byte("a\tb\\c")},
+		{`\x00\xff\0`, []byte{0, 0xff, 0}},
+		{"plain", []byte("plain")},
+	}
+	for _, tt := range tests {
+		got, err := ParseText(tt.input)
+		if err != nil || !bytes.Equal(got, tt.want) {
+			t.Errorf("ParseText(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+
+	for _, input := range []string{`trailing\`, `\x1`, `\xzz`, `\q`} {
+		if _, err := ParseText(input); err == nil {
+			t.Errorf("ParseText(%q) expected an error", input)
+		}
+	}
+}
+
+func TestParseHex(t *testing.T) {
+	want := []byte{0xde, 0xad, 0xbe, 0xef}
+	for _, input := range []string{"deadbeef", "de ad be ef", "de:ad:be:ef", "0xDEADBEEF"} {
+		got, err := ParseHex(input)
+		if err != nil || !bytes.Equal(got, want) {
+			t.Errorf("ParseHex(%q) = %x, %v; want %x", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"abc", "zz"} {
+		if _, err := ParseHex(input); err == nil {
+			t.Errorf("ParseHex(%q) expected an error", input)
+		}
+	}
+}
+
+func TestEscapeRoundTrips(t *testing.T) {
+	data := []byte("OK\r\n\x00\x7f\tend\\")
+	escaped := Escape(data)
+	if escaped != `OK\r\n\x00\x7f\tend\\` {
+		t.Errorf("Escape() = %q", escaped)
+	}
+	if back, err := ParseText(escaped); err != nil || !bytes.Equal(back, data) {
+		t.Errorf("ParseText(Escape()) = %q, %v; want %q", back, err, data)
+	}
+}
+
+func TestDialTCPRecordsTranscript(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		conn.Write([]byte("+" + strings.TrimSpace(line) + "\r\n"))
+		conn.Close()
+	}()
+
+	c, err := Dial(TCP, listener.Addr().String(), Options{Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if err := c.Send([]byte("PING\r\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	waitFor(t, func() bool { return c.Closed() })
+	if !ClosedByPeer(c.Err()) {
+		t.Errorf("Err() = %v, want the peer to have closed the connection", c.Err())
+	}
+	transcript := c.Transcript()
+	if len(transcript) != 2 || !transcript[0].Sent || transcript[1].Sent {
+		t.Fatalf("Expected one exchange each way, got %+v", transcript)
+	}
+	if got := string(transcript[1].Data); got != "+PING\r\n" {
+		t.Errorf("Received %q, want +PING\\r\\n", got)
+	}
+	if err := c.Send([]byte("again")); err == nil {
+		t.Error("Expected sending on a closed connection to fail")
+	}
+}
+
+func TestDialTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello"))
+	}))
+	defer server.Close()
+	addr := strings.TrimPrefix(server.URL, "https://")
+
+	if _, err := Dial(TLS, addr, Options{Timeout: time.Second}); err == nil {
+		t.Fatal("Expected the self-signed certificate to be rejected")
+	}
+
+	c, err := Dial(TLS, addr, Options{Timeout: time.Second, Insecure: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if err := c.Send([]byte("GET / HTTP/1.0\r\n\r\n")); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return c.Closed() })
+	var received []byte
+	for _, e := range c.Transcript() {
+		if !e.Sent {
+			received = append(received, e.Data...)
+		}
+	}
+	if !bytes.HasPrefix(received, []byte("HTTP/1.0 200 OK")) || !bytes.HasSuffix(received, []byte("hello")) {
+		t.Errorf("Received %q, want an HTTP response", received)
+	}
+}
+
+func TestDialUDP(t *testing.T) {
+	server, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	go func() {
+		buf := make([]byte, 512)
+		n, addr, err := server.ReadFrom(buf)
+		if err == nil {
+			server.WriteTo(bytes.ToUpper(buf[:n]), addr)
+		}
+	}()
+
+	c, err := Dial(UDP, server.LocalAddr().String(), Options{Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if err := c.Send([]byte("ping")); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return len(c.Transcript()) == 2 })
+	if got := string(c.Transcript()[1].Data); got != "PING" {
+		t.Errorf("Received %q, want PING", got)
+	}
+}
+
+func TestDialRejectsBadAddress(t *testing.T) {
+	if _, err := Dial(TCP, "localhost", Options{}); err == nil {
+		t.Error("Expected an address without a port to be rejected")
+	}
+	if _, err := Dial("sctp", "localhost:1", Options{}); err == nil {
+		t.Error("Expected an unknown network to be rejected")
+	}
+}
+
+func waitFor(t *testing.T, done func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !done() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,342 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/abneribeiro/godev/internal/database"
+	"github.com/abneribeiro/godev/internal/runner"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// RequestSummary describes a saved request without its body
+type RequestSummary struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+// CollectionSummary describes a collection and the requests it holds
+type CollectionSummary struct {
+	ID             string              `json:"id"`
+	Name           string              `json:"name"`
+	Description    string              `json:"description,omitempty"`
+	Requests       []RequestSummary    `json:"requests"`
+	SubCollections []CollectionSummary `json:"sub_collections,omitempty"`
+}
+
+// QuerySummary describes a saved SQL query
+type QuerySummary struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+// RunRequestParams selects a saved request by id or name, or describes an
+// ad-hoc one. Environment variables of the active environment are applied.
+type RunRequestParams struct {
+	ID          string            `json:"id,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Method      string            `json:"method,omitempty"`
+	URL         string            `json:"url,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Body        string            `json:"body,omitempty"`
+	QueryParams map[string]string `json:"query_params,omitempty"`
+}
+
+// RunRequestResult is the response of request.run
+type RunRequestResult struct {
+	StatusCode     int                 `json:"status_code"`
+	Status         string              `json:"status"`
+	Headers        map[string][]string `json:"headers"`
+	Body           string              `json:"body"`
+	ResponseTimeMs int64               `json:"response_time_ms"`
+	Size           int64               `json:"size"`
+}
+
+// RunQueryParams selects the SQL by text or saved query name and the saved
+// connection by database name or user@host:port/database. The connection may
+// be omitted when only one is saved.
+type RunQueryParams struct {
+	Query      string `json:"query,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Connection string `json:"connection,omitempty"`
+}
+
+// RunQueryResult is the response of query.run
+type RunQueryResult struct {
+	Columns         []string   `json:"columns"`
+	Rows            [][]string `json:"rows"`
+	RowsAffected    int64      `json:"rows_affected"`
+	ExecutionTimeMs int64      `json:"execution_time_ms"`
+	Truncated       bool       `json:"truncated,omitempty"`
+}
+
+func summarizeRequests(requests []storage.SavedRequest) []RequestSummary {
+	summaries := make([]RequestSummary, 0, len(requests))
+	for _, req := range requests {
+		summaries = append(summaries, RequestSummary{ID: req.ID, Name: req.Name, Method: req.Method, URL: req.URL})
+	}
+	return summaries
+}
+
+func summarizeCollection(c storage.Collection) CollectionSummary {
+	summary := CollectionSummary{
+		ID:          c.ID,
+		Name:        c.Name,
+		Description: c.Description,
+		Requests:    summarizeRequests(c.Requests),
+	}
+	for _, sub := range c.SubCollections {
+		summary.SubCollections = append(summary.SubCollections, summarizeCollection(sub))
+	}
+	return summary
+}
+
+func (s *Server) listCollections(_ context.Context, _ json.RawMessage) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	config, err := s.store.LoadCollections()
+	if err != nil {
+		return nil, err
+	}
+
+	collections := make([]CollectionSummary, 0, len(config.Collections))
+	for _, c := range config.Collections {
+		collections = append(collections, summarizeCollection(c))
+	}
+	return collections, nil
+}
+
+func (s *Server) listRequests(_ context.Context, _ json.RawMessage) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return summarizeRequests(s.store.GetRequests()), nil
+}
+
+func (s *Server) listQueries(_ context.Context, _ json.RawMessage) (interface{}, error) {
+	if s.dbStore == nil {
+		return nil, fmt.Errorf("database storage is not available")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queries := s.dbStore.GetQueries()
+	summaries := make([]QuerySummary, 0, len(queries))
+	for _, q := range queries {
+		summaries = append(summaries, QuerySummary{ID: q.ID, Name: q.Name, Query: q.Query})
+	}
+	return summaries, nil
+}
+
+// findRequest resolves a saved request by id, or by name when no id is given
+func (s *Server) findRequest(params RunRequestParams) (*storage.SavedRequest, error) {
+	for _, req := range s.store.GetRequests() {
+		if (params.ID != "" && req.ID == params.ID) || (params.ID == "" && req.Name == params.Name) {
+			saved := req
+			return &saved, nil
+		}
+	}
+	if params.ID != "" {
+		return nil, invalidParams("no saved request with id %q", params.ID)
+	}
+	return nil, invalidParams("no saved request named %q", params.Name)
+}
+
+func (s *Server) runRequest(ctx context.Context, raw json.RawMessage) (interface{}, error) {
+	var params RunRequestParams
+	if err := decodeParams(raw, &params); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	var saved *storage.SavedRequest
+	if params.ID != "" || params.Name != "" {
+		var err error
+		saved, err = s.findRequest(params)
+		if err != nil {
+			s.mu.Unlock()
+			return nil, err
+		}
+		params.Method = saved.Method
+		params.URL = saved.URL
+		params.Headers = saved.Headers
+		params.Body = saved.Body
+		params.QueryParams = saved.QueryParams
+		// Read-only mode writes nothing, as in the UI
+		if !s.readOnly {
+			s.store.UpdateLastUsed(saved.ID)
+		}
+	}
+	vars, _ := s.store.GetActiveEnvironmentVariables()
+	aliases, _ := s.store.GetActiveAliases()
+	envName := s.store.ActiveEnvironmentName()
+	envs, _ := s.store.LoadEnvironments()
+	client, err := s.clientFor(envs.Active())
+	defaults := s.defaults
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if params.URL == "" {
+		return nil, invalidParams("expected id, name or url")
+	}
+	if params.Method == "" {
+		params.Method = "GET"
+	}
+	params.Method = strings.ToUpper(params.Method)
+
+	if s.readOnly && !isSafeMethod(params.Method) {
+		return nil, fmt.Errorf("read-only mode: %s requests are disabled", params.Method)
+	}
+
+	fullURL := storage.SavedRequest{URL: params.URL, QueryParams: params.QueryParams}.URLWithQueryParams()
+
+	// Saved requests are sent as the request builder sends them, with their
+	// auth and HMAC signature; ad-hoc ones only have variables replaced
+	if saved == nil {
+		saved = &storage.SavedRequest{
+			Method:      params.Method,
+			URL:         params.URL,
+			Headers:     params.Headers,
+			Body:        params.Body,
+			QueryParams: params.QueryParams,
+		}
+	}
+	prepared := runner.ResolveRequest(*saved, vars, aliases)
+	prepared.Timeout, prepared.Retry = defaults.Policy(prepared.Method, saved.Policy)
+	req, err := runner.Sign(*saved, prepared, vars, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := client.SendWithContext(ctx, req)
+
+	if !s.readOnly {
+		s.mu.Lock()
+		execution := storage.RequestExecution{
+			Method:       params.Method,
+			URL:          fullURL,
+			Headers:      params.Headers,
+			Body:         params.Body,
+			QueryParams:  params.QueryParams,
+			StatusCode:   resp.StatusCode,
+			Status:       resp.Status,
+			ResponseBody: resp.Body,
+			ResponseTime: resp.ResponseTime.Milliseconds(),
+			Environment:  envName,
+		}
+		if resp.Error != nil {
+			execution.Error = resp.Error.Error()
+		}
+		s.store.AddExecution(execution)
+		s.mu.Unlock()
+	}
+
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+
+	return RunRequestResult{
+		StatusCode:     resp.StatusCode,
+		Status:         resp.Status,
+		Headers:        resp.Headers,
+		Body:           resp.Body,
+		ResponseTimeMs: resp.ResponseTime.Milliseconds(),
+		Size:           resp.Size,
+	}, nil
+}
+
+// findConnection picks the saved connection matching name
+func (s *Server) findConnection(name string) (database.ConnectionConfig, error) {
+	connections := s.dbStore.GetSavedConnections()
+	if name == "" {
+		if len(connections) == 1 {
+			return connections[0], nil
+		}
+		return database.ConnectionConfig{}, invalidParams("expected a connection, %d are saved", len(connections))
+	}
+
+	for _, c := range connections {
+		full := fmt.Sprintf("%s@%s:%d/%s", c.User, c.Host, c.Port, c.Database)
+		if c.Database == name || full == name {
+			return c, nil
+		}
+	}
+	return database.ConnectionConfig{}, invalidParams("no saved connection matches %q", name)
+}
+
+func (s *Server) runQuery(ctx context.Context, raw json.RawMessage) (interface{}, error) {
+	if s.dbStore == nil {
+		return nil, fmt.Errorf("database storage is not available")
+	}
+
+	var params RunQueryParams
+	if err := decodeParams(raw, &params); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	query := params.Query
+	if query == "" && params.Name != "" {
+		for _, q := range s.dbStore.GetQueries() {
+			if q.Name == params.Name {
+				query = q.Query
+				break
+			}
+		}
+		if query == "" {
+			s.mu.Unlock()
+			return nil, invalidParams("no saved query named %q", params.Name)
+		}
+	}
+	conn, err := s.findConnection(params.Connection)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(query) == "" {
+		return nil, invalidParams("expected query or name")
+	}
+
+	client := database.NewPostgresClient()
+	client.SetReadOnly(s.readOnly)
+	if err := client.ConnectWithContext(ctx, conn); err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	result := client.ExecuteQuery(query)
+
+	s.mu.Lock()
+	s.dbStore.AddToQueryHistory(query, client.GetConnectionString(), result.RowsAffected, result.ExecutionTime.Milliseconds(), result.Error)
+	s.mu.Unlock()
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return RunQueryResult{
+		Columns:         result.Columns,
+		Rows:            result.Rows,
+		RowsAffected:    result.RowsAffected,
+		ExecutionTimeMs: result.ExecutionTime.Milliseconds(),
+		Truncated:       result.Truncated,
+	}, nil
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,267 @@
+// Package rpc exposes saved requests, queries and collections over a small
+// JSON-RPC 2.0 API so scripts and editors can drive godev.
+//
+// Messages are newline-delimited: each line read from a connection is one
+// request and each reply is written as one line.
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/abneribeiro/godev/internal/database"
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/logging"
+	"github.com/abneribeiro/godev/internal/runner"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// maxMessageSize bounds a single request line
+const maxMessageSize = 10 * 1024 * 1024
+
+// JSON-RPC 2.0 error codes
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeServerError    = -32000
+)
+
+// Request is a JSON-RPC request; an absent ID makes it a notification
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is a JSON-RPC response
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC error object
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func invalidParams(format string, args ...interface{}) *Error {
+	return &Error{Code: CodeInvalidParams, Message: fmt.Sprintf(format, args...)}
+}
+
+type handler func(ctx context.Context, params json.RawMessage) (interface{}, error)
+
+// Server answers JSON-RPC calls against the stored artifacts
+type Server struct {
+	store    *storage.Storage
+	dbStore  *database.DatabaseStorage
+	client   *httpclient.Client
+	readOnly bool
+	methods  map[string]handler
+	// defaults are the timeout and retries of requests whose policy
+	// leaves them unset
+	defaults runner.Defaults
+
+	// newClient builds the client request.run sends with in an
+	// environment; clientEnv is the setup client was built for
+	newClient func(env *storage.Environment) (*httpclient.Client, error)
+	clientEnv string
+
+	// Storage is not safe for concurrent use and clients may call in parallel
+	mu sync.Mutex
+}
+
+// NewServer creates a server; dbStore may be nil when queries are not needed
+func NewServer(store *storage.Storage, dbStore *database.DatabaseStorage, client *httpclient.Client) *Server {
+	s := &Server{
+		store:   store,
+		dbStore: dbStore,
+		client:  client,
+	}
+	s.methods = map[string]handler{
+		"collections.list": s.listCollections,
+		"requests.list":    s.listRequests,
+		"queries.list":     s.listQueries,
+		"request.run":      s.runRequest,
+		"query.run":        s.runQuery,
+	}
+	return s
+}
+
+// SetReadOnly refuses non-GET requests and non-SELECT SQL
+func (s *Server) SetReadOnly(readOnly bool) {
+	s.readOnly = readOnly
+}
+
+// SetRequestDefaults sets the timeout and retries request.run sends with
+// when the request's own policy leaves them unset
+func (s *Server) SetRequestDefaults(defaults runner.Defaults) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.defaults = defaults
+}
+
+// SetClientSetup makes request.run send with the client newClient builds
+// for the active environment, so its proxy and client certificate apply.
+// The client is built again when the environment in use changes them.
+func (s *Server) SetClientSetup(newClient func(env *storage.Environment) (*httpclient.Client, error)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.newClient = newClient
+	s.client = nil
+}
+
+// clientFor returns the client to send with in env. s.mu must be held.
+func (s *Server) clientFor(env *storage.Environment) (*httpclient.Client, error) {
+	if s.newClient == nil {
+		return s.client, nil
+	}
+	key := ""
+	if env != nil {
+		key = strings.Join([]string{env.Proxy, env.ClientCert, env.ClientKey}, "\x00")
+	}
+	if s.client != nil && key == s.clientEnv {
+		return s.client, nil
+	}
+	client, err := s.newClient(env)
+	if err != nil {
+		return nil, err
+	}
+	s.client, s.clientEnv = client, key
+	return client, nil
+}
+
+// Methods returns the names of the supported methods
+func (s *Server) Methods() []string {
+	names := make([]string, 0, len(s.methods))
+	for name := range s.methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Serve accepts connections until ctx is canceled
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		ln.Close()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.ServeConn(ctx, conn)
+		}()
+	}
+}
+
+// ServeConn answers requests from one connection until it is closed
+func (s *Server) ServeConn(ctx context.Context, conn io.ReadWriteCloser) {
+	defer conn.Close()
+
+	// Canceling ctx unblocks the read below; stop releases the callback
+	// once the connection ends on its own
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	logger := logging.GetLogger()
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	encoder := json.NewEncoder(conn)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		resp, ok := s.Handle(ctx, line)
+		if !ok {
+			continue
+		}
+		if err := encoder.Encode(resp); err != nil {
+			logger.Debug("RPC client went away", "error", err)
+			return
+		}
+	}
+}
+
+// Handle answers one raw request. It returns false for notifications, which
+// get no reply.
+func (s *Server) Handle(ctx context.Context, data []byte) (Response, bool) {
+	var req Request
+	if err := json.Unmarshal(data, &req); err != nil {
+		return errorResponse(nil, &Error{Code: CodeParseError, Message: "invalid JSON: " + err.Error()}), true
+	}
+
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, &Error{Code: CodeInvalidRequest, Message: `expected "jsonrpc": "2.0" and a method`}), true
+	}
+
+	method, ok := s.methods[req.Method]
+	if !ok {
+		return errorResponse(req.ID, &Error{Code: CodeMethodNotFound, Message: "unknown method " + req.Method}), len(req.ID) > 0
+	}
+
+	result, err := method(ctx, req.Params)
+	if len(req.ID) == 0 {
+		return Response{}, false
+	}
+	if err != nil {
+		var rpcErr *Error
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &Error{Code: CodeServerError, Message: err.Error()}
+		}
+		return errorResponse(req.ID, rpcErr), true
+	}
+
+	return Response{JSONRPC: "2.0", ID: req.ID, Result: result}, true
+}
+
+func errorResponse(id json.RawMessage, err *Error) Response {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return Response{JSONRPC: "2.0", ID: id, Error: err}
+}
+
+// decodeParams unmarshals params into target, allowing them to be omitted
+func decodeParams(params json.RawMessage, target interface{}) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(params, target); err != nil {
+		return invalidParams("invalid params: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,339 @@
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abneribeiro/godev/internal/database"
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/runner"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+func newTestServer(t *testing.T) (*Server, *storage.Storage) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	store, err := storage.NewStorage()
+	if err != nil {
+		t.Fatalf("NewStorage() error = %v", err)
+	}
+	dir, err := store.Dir()
+	if err != nil {
+		t.Fatalf("Dir() error = %v", err)
+	}
+	dbStore, err := database.NewDatabaseStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewDatabaseStorageAt() error = %v", err)
+	}
+
+	return NewServer(store, dbStore, httpclient.NewClient(5*time.Second)), store
+}
+
+func call(t *testing.T, s *Server, method string, params interface{}) Response {
+	t.Helper()
+	data, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	resp, ok := s.Handle(context.Background(), data)
+	if !ok {
+		t.Fatalf("%s: expected a response", method)
+	}
+	return resp
+}
+
+func TestHandleProtocolErrors(t *testing.T) {
+	s, _ := newTestServer(t)
+
+	tests := []struct {
+		input string
+		code  int
+	}{
+		{`{not json`, CodeParseError},
+		{`{"id": 1, "method": "requests.list"}`, CodeInvalidRequest},
+		{`{"jsonrpc": "2.0", "id": 1, "method": "nope"}`, CodeMethodNotFound},
+		{`{"jsonrpc": "2.0", "id": 1, "method": "request.run", "params": {}}`, CodeInvalidParams},
+		{`{"jsonrpc": "2.0", "id": 1, "method": "request.run", "params": {"name": "missing"}}`, CodeInvalidParams},
+	}
+	for _, tt := range tests {
+		resp, ok := s.Handle(context.Background(), []byte(tt.input))
+		if !ok {
+			t.Fatalf("%s: expected a response", tt.input)
+		}
+		if resp.Error == nil || resp.Error.Code != tt.code {
+			t.Errorf("%s: error = %+v, want code %d", tt.input, resp.Error, tt.code)
+		}
+	}
+
+	if _, ok := s.Handle(context.Background(), []byte(`{"jsonrpc": "2.0", "method": "requests.list"}`)); ok {
+		t.Error("Expected no response for a notification")
+	}
+}
+
+func TestRunSavedRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"page":"` + r.URL.Query().Get("page") + `","token":"` + r.Header.Get("X-Token") + `"}`))
+	}))
+	defer server.Close()
+
+	s, store := newTestServer(t)
+	if err := store.SaveRequest("list users", "GET", server.URL+"/users", map[string]string{"X-Token": "abc"}, "", map[string]string{"page": "2"}); err != nil {
+		t.Fatalf("SaveRequest() error = %v", err)
+	}
+
+	resp := call(t, s, "requests.list", nil)
+	if resp.Error != nil {
+		t.Fatalf("requests.list error = %v", resp.Error)
+	}
+	if list := resp.Result.([]RequestSummary); len(list) != 1 || list[0].Name != "list users" {
+		t.Fatalf("requests.list = %+v", resp.Result)
+	}
+
+	resp = call(t, s, "request.run", RunRequestParams{Name: "list users"})
+	if resp.Error != nil {
+		t.Fatalf("request.run error = %v", resp.Error)
+	}
+	result := resp.Result.(RunRequestResult)
+	if result.StatusCode != 200 || !strings.Contains(result.Body, `"page": "2"`) || !strings.Contains(result.Body, `"token": "abc"`) {
+		t.Errorf("request.run = %+v", result)
+	}
+	if len(store.GetHistory()) != 1 {
+		t.Errorf("Expected the run to be recorded in history, got %d entries", len(store.GetHistory()))
+	}
+}
+
+func TestRunSavedRequestSendsAuthAndSignature(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s|%s", r.Header.Get("Authorization"), r.Header.Get("X-Signature"))
+	}))
+	defer server.Close()
+
+	s, store := newTestServer(t)
+	if err := store.SaveRequest("me", "GET", server.URL+"/me", nil, "", nil); err != nil {
+		t.Fatalf("SaveRequest() error = %v", err)
+	}
+	id := store.GetRequests()[0].ID
+	for _, err := range []error{
+		store.UpdateRequestAuth(id, &storage.RequestAuth{Type: httpclient.AuthBearer, Token: "secret"}),
+		store.UpdateHMACAuth(id, &storage.HMACAuth{Key: "key", Header: "X-Signature"}),
+	} {
+		if err != nil {
+			t.Fatalf("setup error = %v", err)
+		}
+	}
+
+	resp := call(t, s, "request.run", RunRequestParams{ID: id})
+	if resp.Error != nil {
+		t.Fatalf("request.run error = %v", resp.Error)
+	}
+	parts := strings.SplitN(resp.Result.(RunRequestResult).Body, "|", 2)
+	if len(parts) != 2 || parts[0] != "Bearer secret" || parts[1] == "" {
+		t.Errorf("Expected the saved auth and HMAC signature to be sent, got %q", resp.Result.(RunRequestResult).Body)
+	}
+}
+
+func TestRunRequestAppliesDefaultsAndPolicy(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		http.Redirect(w, r, "/elsewhere", http.StatusFound)
+	}))
+	defer server.Close()
+
+	s, store := newTestServer(t)
+	s.SetRequestDefaults(runner.Defaults{Retry: httpclient.RetryPolicy{Retries: 1, Backoff: time.Millisecond}})
+	if err := store.SaveRequest("flaky", "GET", server.URL+"/flaky", nil, "", nil); err != nil {
+		t.Fatalf("SaveRequest() error = %v", err)
+	}
+	id := store.GetRequests()[0].ID
+	if err := store.UpdateRequestPolicy(id, &storage.RequestPolicy{NoFollowRedirects: true}); err != nil {
+		t.Fatalf("UpdateRequestPolicy() error = %v", err)
+	}
+
+	resp := call(t, s, "request.run", RunRequestParams{ID: id})
+	if resp.Error != nil {
+		t.Fatalf("request.run error = %v", resp.Error)
+	}
+	if result := resp.Result.(RunRequestResult); result.StatusCode != http.StatusFound || calls != 2 {
+		t.Errorf("Expected a retry and the redirect itself, got status %d after %d calls", result.StatusCode, calls)
+	}
+}
+
+func TestRunRequestUsesEnvironmentClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	s, store := newTestServer(t)
+	for _, err := range []error{
+		store.AddEnvironment("prod"),
+		store.SetActiveEnvironment("prod"),
+		store.SetEnvironmentClientCert("prod", "prod.crt", "prod.key"),
+	} {
+		if err != nil {
+			t.Fatalf("setup error = %v", err)
+		}
+	}
+
+	var built []string
+	s.SetClientSetup(func(env *storage.Environment) (*httpclient.Client, error) {
+		built = append(built, env.Name+" "+env.ClientCert+" "+env.Proxy)
+		if env.Proxy != "" {
+			return nil, fmt.Errorf("proxy refused")
+		}
+		return httpclient.NewClient(5 * time.Second), nil
+	})
+
+	for i := 0; i < 2; i++ {
+		if resp := call(t, s, "request.run", RunRequestParams{URL: server.URL}); resp.Error != nil {
+			t.Fatalf("request.run error = %v", resp.Error)
+		}
+	}
+	if err := store.SetEnvironmentProxy("prod", "http://127.0.0.1:1"); err != nil {
+		t.Fatalf("SetEnvironmentProxy() error = %v", err)
+	}
+	if resp := call(t, s, "request.run", RunRequestParams{URL: server.URL}); resp.Error == nil || !strings.Contains(resp.Error.Message, "proxy refused") {
+		t.Errorf("request.run error = %+v, want the error of the client setup", resp.Error)
+	}
+
+	want := []string{"prod prod.crt ", "prod prod.crt http://127.0.0.1:1"}
+	if strings.Join(built, "|") != strings.Join(want, "|") {
+		t.Errorf("Clients built for %q, want %q", built, want)
+	}
+}
+
+func TestRunRequestReadOnly(t *testing.T) {
+	s, _ := newTestServer(t)
+	s.SetReadOnly(true)
+
+	resp := call(t, s, "request.run", RunRequestParams{Method: "delete", URL: "http://127.0.0.1:1/users/1"})
+	if resp.Error == nil || !strings.Contains(resp.Error.Message, "read-only") {
+		t.Errorf("request.run error = %+v, want read-only", resp.Error)
+	}
+}
+
+func TestRunSavedRequestReadOnlyWritesNothing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	s, store := newTestServer(t)
+	if err := store.SaveRequest("health", "GET", server.URL+"/health", nil, "", nil); err != nil {
+		t.Fatalf("SaveRequest() error = %v", err)
+	}
+	lastUsed := store.GetRequests()[0].LastUsed
+	s.SetReadOnly(true)
+
+	if resp := call(t, s, "request.run", RunRequestParams{Name: "health"}); resp.Error != nil {
+		t.Fatalf("request.run error = %v", resp.Error)
+	}
+	if got := len(store.GetHistory()); got != 0 {
+		t.Errorf("Expected no history in read-only mode, got %d entries", got)
+	}
+	if got := store.GetRequests()[0].LastUsed; !got.Equal(lastUsed) {
+		t.Errorf("LastUsed changed from %v to %v in read-only mode", lastUsed, got)
+	}
+}
+
+func TestRunQueryNeedsConnection(t *testing.T) {
+	s, _ := newTestServer(t)
+
+	resp := call(t, s, "query.run", RunQueryParams{Query: "SELECT 1"})
+	if resp.Error == nil || resp.Error.Code != CodeInvalidParams {
+		t.Errorf("query.run error = %+v, want invalid params", resp.Error)
+	}
+}
+
+func TestServeOverSocket(t *testing.T) {
+	s, store := newTestServer(t)
+	collections, err := store.LoadCollections()
+	if err != nil {
+		t.Fatalf("LoadCollections() error = %v", err)
+	}
+	collections.Collections = append(collections.Collections, storage.CreateCollection("billing", "invoices API"))
+	if err := store.SaveCollections(collections); err != nil {
+		t.Fatalf("SaveCollections() error = %v", err)
+	}
+
+	ln, err := net.Listen("unix", filepath.Join(t.TempDir(), "godev.sock"))
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Serve(ctx, ln) }()
+
+	conn, err := net.Dial("unix", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(`{"jsonrpc": "2.0", "id": "a", "method": "collections.list"}` + "\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		t.Fatalf("ReadBytes() error = %v", err)
+	}
+	var resp struct {
+		ID     string              `json:"id"`
+		Result []CollectionSummary `json:"result"`
+	}
+	if err := json.Unmarshal(line, &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if resp.ID != "a" || len(resp.Result) != 1 || resp.Result[0].Name != "billing" {
+		t.Errorf("collections.list = %s", line)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Serve() error = %v", err)
+	}
+}
+
+func TestServeConnDoesNotLeakGoroutines(t *testing.T) {
+	s, _ := newTestServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	serve := func() {
+		client, server := net.Pipe()
+		done := make(chan struct{})
+		go func() {
+			s.ServeConn(ctx, server)
+			close(done)
+		}()
+		client.Close()
+		<-done
+	}
+
+	serve()
+	before := runtime.NumGoroutine()
+	for i := 0; i < 200; i++ {
+		serve()
+	}
+	// Goroutines of the runtime come and go; one per connection would not
+	if after := runtime.NumGoroutine(); after > before+10 {
+		t.Errorf("Goroutines grew from %d to %d over 200 connections", before, after)
+	}
+}
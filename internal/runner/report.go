@@ -0,0 +1,356 @@
+package runner
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Percentiles summarizes a distribution with nearest-rank percentiles
+type Percentiles struct {
+	P50 int64 `json:"p50"`
+	P90 int64 `json:"p90"`
+	P95 int64 `json:"p95"`
+	P99 int64 `json:"p99"`
+	Max int64 `json:"max"`
+}
+
+// Stats aggregates the steps of a run that got a response
+type Stats struct {
+	Samples int
+	// Time holds response times in nanoseconds, Size response sizes in bytes
+	Time           Percentiles
+	Size           Percentiles
+	OverTimeBudget int
+	OverSizeBudget int
+}
+
+// Stats computes the response time and size percentiles of the run and
+// counts the budget breaches. Skipped and failed-to-send steps are left out.
+func (r Report) Stats() Stats {
+	var stats Stats
+	var times, sizes []int64
+	for _, result := range r.Results {
+		if !result.ran() {
+			continue
+		}
+		times = append(times, int64(result.ResponseTime))
+		sizes = append(sizes, result.Size)
+		if result.OverTimeBudget() {
+			stats.OverTimeBudget++
+		}
+		if result.OverSizeBudget() {
+			stats.OverSizeBudget++
+		}
+	}
+	stats.Samples = len(times)
+	stats.Time = percentiles(times)
+	stats.Size = percentiles(sizes)
+	return stats
+}
+
+func percentiles(values []int64) Percentiles {
+	if len(values) == 0 {
+		return Percentiles{}
+	}
+	sorted := append([]int64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := func(p float64) int64 {
+		i := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+		return sorted[max(i, 0)]
+	}
+	return Percentiles{
+		P50: rank(50),
+		P90: rank(90),
+		P95: rank(95),
+		P99: rank(99),
+		Max: sorted[len(sorted)-1],
+	}
+}
+
+// percentileNames labels the values of Percentiles.values
+var percentileNames = [5]string{"p50", "p90", "p95", "p99", "max"}
+
+func (p Percentiles) values() [5]int64 {
+	return [5]int64{p.P50, p.P90, p.P95, p.P99, p.Max}
+}
+
+func (p Percentiles) format(unit func(int64) string) string {
+	parts := make([]string, 0, len(percentileNames))
+	for i, v := range p.values() {
+		parts = append(parts, percentileNames[i]+" "+unit(v))
+	}
+	return strings.Join(parts, " • ")
+}
+
+// jsonReport is the JSON export of a run. Times are in milliseconds.
+type jsonReport struct {
+	Collection string     `json:"collection"`
+	DurationMs float64    `json:"duration_ms"`
+	Passed     int        `json:"passed"`
+	Failed     int        `json:"failed"`
+	Skipped    int        `json:"skipped"`
+	Stats      jsonStats  `json:"stats"`
+	Steps      []jsonStep `json:"steps"`
+}
+
+type jsonStats struct {
+	Samples        int           `json:"samples"`
+	TimeMs         msPercentiles `json:"time_ms"`
+	SizeBytes      Percentiles   `json:"size_bytes"`
+	OverTimeBudget int           `json:"over_time_budget"`
+	OverSizeBudget int           `json:"over_size_budget"`
+}
+
+type msPercentiles struct {
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+	P95 float64 `json:"p95"`
+	P99 float64 `json:"p99"`
+	Max float64 `json:"max"`
+}
+
+type jsonStep struct {
+	Name              string   `json:"name"`
+	Method            string   `json:"method"`
+	URL               string   `json:"url"`
+	Group             string   `json:"group,omitempty"`
+	Passed            bool     `json:"passed"`
+	Skipped           bool     `json:"skipped,omitempty"`
+	SkipReason        string   `json:"skip_reason,omitempty"`
+	Error             string   `json:"error,omitempty"`
+	StatusCode        int      `json:"status_code,omitempty"`
+	TimeMs            float64  `json:"time_ms"`
+	SizeBytes         int64    `json:"size_bytes"`
+	TimeBudgetMs      int64    `json:"time_budget_ms,omitempty"`
+	SizeBudgetBytes   int64    `json:"size_budget_bytes,omitempty"`
+	OverTimeBudget    bool     `json:"over_time_budget,omitempty"`
+	OverSizeBudget    bool     `json:"over_size_budget,omitempty"`
+	FailedAssertions  []string `json:"failed_assertions,omitempty"`
+	FailedExtractions []string `json:"failed_extractions,omitempty"`
+	GoldenDiff        string   `json:"golden_diff,omitempty"`
+}
+
+// milliseconds converts d to milliseconds, keeping microsecond precision
+func milliseconds(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Microsecond)) / 1000
+}
+
+// WriteJSON writes the report with per-step budgets and the run's
+// percentiles as JSON, for tracking trends across CI runs
+func WriteJSON(w io.Writer, report Report) error {
+	passed, failed, skipped := report.Counts()
+	stats := report.Stats()
+	out := jsonReport{
+		Collection: report.Collection,
+		DurationMs: milliseconds(report.Duration),
+		Passed:     passed,
+		Failed:     failed,
+		Skipped:    skipped,
+		Stats: jsonStats{
+			Samples: stats.Samples,
+			TimeMs: msPercentiles{
+				P50: milliseconds(time.Duration(stats.Time.P50)),
+				P90: milliseconds(time.Duration(stats.Time.P90)),
+				P95: milliseconds(time.Duration(stats.Time.P95)),
+				P99: milliseconds(time.Duration(stats.Time.P99)),
+				Max: milliseconds(time.Duration(stats.Time.Max)),
+			},
+			SizeBytes:      stats.Size,
+			OverTimeBudget: stats.OverTimeBudget,
+			OverSizeBudget: stats.OverSizeBudget,
+		},
+		Steps: make([]jsonStep, 0, len(report.Results)),
+	}
+
+	for _, r := range report.Results {
+		step := jsonStep{
+			Name:            r.Name,
+			Method:          r.Method,
+			URL:             r.URL,
+			Group:           r.Group,
+			Passed:          r.Passed(),
+			Skipped:         r.Skipped,
+			SkipReason:      r.SkipReason,
+			StatusCode:      r.StatusCode,
+			TimeMs:          milliseconds(r.ResponseTime),
+			SizeBytes:       r.Size,
+			TimeBudgetMs:    r.TimeBudget.Milliseconds(),
+			SizeBudgetBytes: r.SizeBudget,
+			OverTimeBudget:  r.OverTimeBudget(),
+			OverSizeBudget:  r.OverSizeBudget(),
+		}
+		if r.Error != nil {
+			step.Error = r.Error.Error()
+		}
+		for _, a := range r.Assertions {
+			if !a.Passed() {
+				step.FailedAssertions = append(step.FailedAssertions, fmt.Sprintf("%s: %v", a.Assertion, a.Err))
+			}
+		}
+		for _, e := range r.Extractions {
+			if !e.Passed() {
+				step.FailedExtractions = append(step.FailedExtractions, fmt.Sprintf("%s: %v", e.Extract, e.Err))
+			}
+		}
+		if r.GoldenDiff != nil {
+			step.GoldenDiff = r.GoldenDiff.Summary()
+		}
+		out.Steps = append(out.Steps, step)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	Cases      []junitCase     `xml:"testcase"`
+}
+
+type junitProperties struct {
+	Items []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitCase struct {
+	Name       string           `xml:"name,attr"`
+	Classname  string           `xml:"classname,attr"`
+	Time       string           `xml:"time,attr"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	Failure    *junitMessage    `xml:"failure,omitempty"`
+	Skipped    *junitMessage    `xml:"skipped,omitempty"`
+	SystemOut  string           `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// WriteJUnit writes the report as a JUnit XML suite. Each step is a test
+// case carrying its size and budgets as properties; the run's percentiles
+// are properties of the suite. Budget breaches are reported in the output
+// of the case and do not fail it.
+func WriteJUnit(w io.Writer, report Report) error {
+	passed, failed, skipped := report.Counts()
+	stats := report.Stats()
+	suite := junitSuite{
+		Name:     report.Collection,
+		Tests:    passed + failed + skipped,
+		Failures: failed,
+		Skipped:  skipped,
+		Time:     seconds(report.Duration),
+	}
+
+	times, sizes := stats.Time.values(), stats.Size.values()
+	for i, name := range percentileNames {
+		suite.Properties = append(suite.Properties,
+			junitProperty{Name: "time_ms." + name, Value: fmt.Sprint(milliseconds(time.Duration(times[i])))},
+			junitProperty{Name: "size_bytes." + name, Value: fmt.Sprint(sizes[i])},
+		)
+	}
+	suite.Properties = append(suite.Properties,
+		junitProperty{Name: "over_time_budget", Value: fmt.Sprint(stats.OverTimeBudget)},
+		junitProperty{Name: "over_size_budget", Value: fmt.Sprint(stats.OverSizeBudget)},
+	)
+
+	for _, r := range report.Results {
+		tc := junitCase{
+			Name:      r.Name,
+			Classname: report.Collection,
+			Time:      seconds(r.ResponseTime),
+		}
+		var properties []junitProperty
+		if r.ran() {
+			properties = append(properties, junitProperty{Name: "size_bytes", Value: fmt.Sprint(r.Size)})
+		}
+		if r.TimeBudget > 0 {
+			properties = append(properties, junitProperty{Name: "time_budget_ms", Value: fmt.Sprint(r.TimeBudget.Milliseconds())})
+		}
+		if r.SizeBudget > 0 {
+			properties = append(properties, junitProperty{Name: "size_budget_bytes", Value: fmt.Sprint(r.SizeBudget)})
+		}
+		if len(properties) > 0 {
+			tc.Properties = &junitProperties{Items: properties}
+		}
+
+		var notes []string
+		if r.OverTimeBudget() {
+			notes = append(notes, fmt.Sprintf("over time budget: %dms > %dms", r.ResponseTime.Milliseconds(), r.TimeBudget.Milliseconds()))
+		}
+		if r.OverSizeBudget() {
+			notes = append(notes, fmt.Sprintf("over size budget: %d > %d bytes", r.Size, r.SizeBudget))
+		}
+		if r.GoldenDiff != nil {
+			notes = append(notes, "differs from golden: "+r.GoldenDiff.Summary())
+		}
+		tc.SystemOut = strings.Join(notes, "\n")
+
+		switch {
+		case r.Skipped:
+			tc.Skipped = &junitMessage{Message: r.SkipReason}
+		case r.Error != nil:
+			tc.Failure = &junitMessage{Message: r.Error.Error()}
+		case !r.Passed():
+			failure := &junitMessage{Message: fmt.Sprintf("status %d", r.StatusCode)}
+			var lines []string
+			for _, a := range r.Assertions {
+				if !a.Passed() {
+					lines = append(lines, fmt.Sprintf("assertion failed: %s: %v", a.Assertion, a.Err))
+				}
+			}
+			failedAssertions := len(lines)
+			for _, e := range r.Extractions {
+				if !e.Passed() {
+					lines = append(lines, fmt.Sprintf("extraction failed: %s: %v", e.Extract, e.Err))
+				}
+			}
+			if len(lines) > 0 {
+				failure.Message = fmt.Sprintf("%d assertions failed", failedAssertions)
+				if failedExtractions := len(lines) - failedAssertions; failedExtractions > 0 {
+					failure.Message = fmt.Sprintf("%d assertions and %d extractions failed", failedAssertions, failedExtractions)
+				}
+				failure.Text = strings.Join(lines, "\n")
+			}
+			tc.Failure = failure
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitTestSuites{Suites: []junitSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,103 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func budgetReport() Report {
+	results := []Result{
+		{Name: "slow", StatusCode: 200, ResponseTime: 900 * time.Millisecond, Size: 100, TimeBudget: 500 * time.Millisecond},
+		{Name: "big", StatusCode: 200, ResponseTime: 100 * time.Millisecond, Size: 5000, SizeBudget: 1000},
+		{Name: "failed", Error: errors.New("connection refused"), TimeBudget: time.Millisecond},
+		{Name: "skipped", Skipped: true, SkipReason: "run canceled"},
+	}
+	for i := 1; i <= 6; i++ {
+		results = append(results, Result{Name: "ok", StatusCode: 200, ResponseTime: time.Duration(i) * 10 * time.Millisecond, Size: int64(i)})
+	}
+	return Report{Collection: "api", Results: results, Duration: time.Second}
+}
+
+func TestReportStats(t *testing.T) {
+	stats := budgetReport().Stats()
+
+	if stats.Samples != 8 {
+		t.Errorf("Expected failed and skipped steps to be left out, got %d samples", stats.Samples)
+	}
+	if got := time.Duration(stats.Time.P50); got != 40*time.Millisecond {
+		t.Errorf("Time.P50 = %v, want 40ms", got)
+	}
+	if got := time.Duration(stats.Time.P90); got != 900*time.Millisecond {
+		t.Errorf("Time.P90 = %v, want 900ms", got)
+	}
+	if stats.Size.Max != 5000 || stats.Size.P50 != 4 {
+		t.Errorf("Size = %+v, want p50 4 and max 5000", stats.Size)
+	}
+	if stats.OverTimeBudget != 1 || stats.OverSizeBudget != 1 {
+		t.Errorf("Expected one breach of each budget, got %d time and %d size", stats.OverTimeBudget, stats.OverSizeBudget)
+	}
+
+	output := FormatReport(budgetReport())
+	for _, want := range []string{"budgets: 1 over time, 1 over size", "over time budget: 900ms > 500ms", "over size budget"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("FormatReport() does not contain %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, budgetReport()); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	var got jsonReport
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("WriteJSON() wrote invalid JSON: %v\n%s", err, buf.String())
+	}
+	if got.Passed != 8 || got.Failed != 1 || got.Skipped != 1 {
+		t.Errorf("Counts = %d/%d/%d, want 8/1/1", got.Passed, got.Failed, got.Skipped)
+	}
+	if got.Stats.TimeMs.P90 != 900 || got.Stats.SizeBytes.Max != 5000 {
+		t.Errorf("Stats = %+v", got.Stats)
+	}
+	slow := got.Steps[0]
+	if !slow.OverTimeBudget || slow.TimeBudgetMs != 500 || slow.TimeMs != 900 || !slow.Passed {
+		t.Errorf("Expected the slow step to be flagged without failing, got %+v", slow)
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, budgetReport()); err != nil {
+		t.Fatalf("WriteJUnit() error = %v", err)
+	}
+
+	var got junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("WriteJUnit() wrote invalid XML: %v\n%s", err, buf.String())
+	}
+	suite := got.Suites[0]
+	if suite.Tests != 10 || suite.Failures != 1 || suite.Skipped != 1 {
+		t.Errorf("Suite counts = %d/%d/%d, want 10/1/1", suite.Tests, suite.Failures, suite.Skipped)
+	}
+	if suite.Cases[2].Failure == nil || suite.Cases[3].Skipped == nil {
+		t.Errorf("Expected failed and skipped cases to be marked, got %+v", suite.Cases[2:4])
+	}
+	if suite.Cases[0].Failure != nil || !strings.Contains(suite.Cases[0].SystemOut, "over time budget") {
+		t.Errorf("Expected the budget breach in the case output, got %+v", suite.Cases[0])
+	}
+
+	properties := make(map[string]string)
+	for _, p := range suite.Properties {
+		properties[p.Name] = p.Value
+	}
+	if properties["time_ms.p90"] != "900" || properties["size_bytes.max"] != "5000" {
+		t.Errorf("Suite properties = %v", properties)
+	}
+}
@@ -0,0 +1,634 @@
+// Package runner executes the requests of a collection as a suite.
+//
+// Steps run in collection order. Consecutive steps that share a group form a
+// parallel stage and run together, up to a maximum concurrency; every other
+// step is a stage of its own, so ordered chains such as login-then-call keep
+// their order. A step can also declare the steps it depends on: it waits for
+// them and is skipped when one of them did not pass.
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// DefaultMaxConcurrency is used when a collection does not set one
+const DefaultMaxConcurrency = 4
+
+// Stage is a set of steps that may run at the same time
+type Stage struct {
+	Group string
+	Steps []int
+}
+
+// Result is the outcome of one step
+type Result struct {
+	Name         string
+	Method       string
+	URL          string
+	Group        string
+	Stage        int
+	StatusCode   int
+	Status       string
+	ResponseTime time.Duration
+	Size         int64
+	Error        error
+	Skipped      bool
+	SkipReason   string
+	// Assertions holds the outcome of the step's assertions, if it has any
+	Assertions []storage.AssertionResult
+	// Extractions holds the variables read from the response for the
+	// steps that follow
+	Extractions []storage.ExtractionResult
+	// GoldenDiff lists how the response differs from the step's golden
+	// response. Differences are flagged in the report but do not fail the step.
+	GoldenDiff *httpclient.DiffResult
+	// TimeBudget and SizeBudget are the budgets the step was held to, zero
+	// when it has none. Like golden differences, breaches are flagged in
+	// the report but do not fail the step.
+	TimeBudget time.Duration
+	SizeBudget int64
+}
+
+// OverTimeBudget reports whether the step answered slower than its budget
+func (r Result) OverTimeBudget() bool {
+	return r.ran() && r.TimeBudget > 0 && r.ResponseTime > r.TimeBudget
+}
+
+// OverSizeBudget reports whether the step's response was larger than its budget
+func (r Result) OverSizeBudget() bool {
+	return r.ran() && r.SizeBudget > 0 && r.Size > r.SizeBudget
+}
+
+// ran reports whether the step got a response
+func (r Result) ran() bool {
+	return !r.Skipped && r.Error == nil
+}
+
+// Passed reports whether the step ran, answered with a 2xx or 3xx status,
+// met all of its assertions and found every variable it extracts
+func (r Result) Passed() bool {
+	return !r.Skipped && r.Error == nil && r.StatusCode >= 200 && r.StatusCode < 400 &&
+		storage.CountPassed(r.Assertions) == len(r.Assertions) && r.failedExtractions() == 0
+}
+
+// failedExtractions counts the variables the step could not extract
+func (r Result) failedExtractions() int {
+	failed := 0
+	for _, e := range r.Extractions {
+		if !e.Passed() {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Report holds the results of a run in collection order
+type Report struct {
+	Collection string
+	Results    []Result
+	Duration   time.Duration
+}
+
+// Extracted returns the variables the steps extracted, later steps
+// winning over earlier ones
+func (r Report) Extracted() []storage.Variable {
+	var vars []storage.Variable
+	for _, result := range r.Results {
+		vars = storage.MergeVariables(vars, storage.ExtractedVariables(result.Extractions))
+	}
+	return vars
+}
+
+// Counts returns how many steps passed, failed and were skipped
+func (r Report) Counts() (passed, failed, skipped int) {
+	for _, result := range r.Results {
+		switch {
+		case result.Skipped:
+			skipped++
+		case result.Passed():
+			passed++
+		default:
+			failed++
+		}
+	}
+	return passed, failed, skipped
+}
+
+// Options tune a run
+type Options struct {
+	// MaxConcurrency caps the steps of a parallel stage in flight at once
+	MaxConcurrency int
+	// Prepare turns a step into the request to send. It is called right
+	// before the step runs, so values set by earlier steps are visible.
+	Prepare func(step storage.SavedRequest) httpclient.Request
+	// OnResult is called as each step finishes; calls are serialized
+	OnResult func(index int, result Result)
+	// Ignore leaves fields out of golden comparisons, on top of the
+	// volatile fields of each step
+	Ignore httpclient.IgnoreRules
+	// Variables resolve {{name}} references in the HMAC keys of steps
+	Variables []storage.Variable
+	// LatencyBudgetMs and SizeBudgetBytes apply to steps without budgets
+	// of their own
+	LatencyBudgetMs int64
+	SizeBudgetBytes int64
+	// Defaults are the timeout and retries of steps whose policy leaves
+	// them unset
+	Defaults Defaults
+	// Contract validates every response against an OpenAPI spec; each
+	// violation is reported as a failed assertion of the step
+	Contract *storage.OpenAPISpec
+}
+
+// Defaults are the timeout and retries of requests whose policy leaves
+// them unset, from the settings
+type Defaults struct {
+	Timeout time.Duration
+	Retry   httpclient.RetryPolicy
+}
+
+// Policy returns the timeout and retry policy a request with method and
+// policy is sent with. The retries of the defaults only apply to GET, HEAD
+// and OPTIONS, which are safe to send twice; a policy of the request
+// applies to any method.
+func (d Defaults) Policy(method string, policy *storage.RequestPolicy) (time.Duration, httpclient.RetryPolicy) {
+	timeout, retry := d.Timeout, d.Retry
+	if !isSafeMethod(method) {
+		retry.Retries = 0
+	}
+	if p := policy; p != nil {
+		if p.TimeoutMs > 0 {
+			timeout = time.Duration(p.TimeoutMs) * time.Millisecond
+		}
+		if p.Retries != nil {
+			retry.Retries = *p.Retries
+		}
+		if p.BackoffMs > 0 {
+			retry.Backoff = time.Duration(p.BackoffMs) * time.Millisecond
+		}
+	}
+	return timeout, retry
+}
+
+// isSafeMethod reports whether an HTTP method does not modify server state
+func isSafeMethod(method string) bool {
+	switch strings.ToUpper(method) {
+	case "GET", "HEAD", "OPTIONS":
+		return true
+	default:
+		return false
+	}
+}
+
+// PrepareRequest builds the request for a step without any substitution,
+// with the timeout, retries and redirect handling the step was saved with
+func PrepareRequest(step storage.SavedRequest) httpclient.Request {
+	headers := make(map[string]string, len(step.Headers))
+	for k, v := range step.Headers {
+		headers[k] = v
+	}
+	req := httpclient.Request{
+		Method:  step.Method,
+		URL:     step.URLWithQueryParams(),
+		Headers: headers,
+		Body:    step.Body,
+	}
+	req.Timeout, req.Retry = Defaults{}.Policy(step.Method, step.Policy)
+	if p := step.Policy; p != nil {
+		req.NoFollowRedirects = p.NoFollowRedirects
+	}
+	return req
+}
+
+// ResolveRequest builds the request for a step the way the request builder
+// does: aliases are expanded first, then variables are replaced in the URL,
+// headers and body, and last the credentials of its auth are attached
+func ResolveRequest(step storage.SavedRequest, vars []storage.Variable, aliases []storage.ServiceAlias) httpclient.Request {
+	req := PrepareRequest(step)
+	req.URL, _ = storage.ExpandAlias(req.URL, aliases)
+	req.URL = storage.ReplaceVariables(req.URL, vars)
+	req.Body = storage.ReplaceVariables(req.Body, vars)
+	for k, v := range req.Headers {
+		req.Headers[k] = storage.ReplaceVariables(v, vars)
+	}
+	return Authorize(req, step.Auth, vars)
+}
+
+// Authorize attaches the credentials of auth to req, resolving variables
+// in them first. Settings that cannot produce credentials, which the auth
+// panel does not save, leave req unchanged.
+func Authorize(req httpclient.Request, auth *storage.RequestAuth, vars []storage.Variable) httpclient.Request {
+	if auth == nil {
+		return req
+	}
+	resolved := httpclient.Auth(*auth)
+	resolved.Username = storage.ReplaceVariables(resolved.Username, vars)
+	resolved.Password = storage.ReplaceVariables(resolved.Password, vars)
+	resolved.Token = storage.ReplaceVariables(resolved.Token, vars)
+	resolved.KeyName = storage.ReplaceVariables(resolved.KeyName, vars)
+	resolved.KeyValue = storage.ReplaceVariables(resolved.KeyValue, vars)
+	if authorized, err := resolved.Apply(req); err == nil {
+		return authorized
+	}
+	return req
+}
+
+// Plan splits the steps into stages and checks their dependencies. A step
+// may depend on any earlier step, or on a step of its own parallel group as
+// long as the dependencies do not form a cycle.
+func Plan(steps []storage.SavedRequest) ([]Stage, error) {
+	stages, _, err := plan(steps)
+	return stages, err
+}
+
+// plan returns the stages and, for every step, the steps it waits for
+func plan(steps []storage.SavedRequest) ([]Stage, [][]int, error) {
+	var stages []Stage
+	stageOf := make([]int, len(steps))
+	for i, step := range steps {
+		group := strings.TrimSpace(step.Group)
+		if group == "" || len(stages) == 0 || stages[len(stages)-1].Group != group {
+			stages = append(stages, Stage{Group: group})
+		}
+		stages[len(stages)-1].Steps = append(stages[len(stages)-1].Steps, i)
+		stageOf[i] = len(stages) - 1
+	}
+
+	byName := make(map[string][]int)
+	for i, step := range steps {
+		byName[step.Name] = append(byName[step.Name], i)
+	}
+
+	deps, err := resolveDependencies(steps, byName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i := range steps {
+		for _, dep := range deps[i] {
+			if stageOf[dep] > stageOf[i] {
+				return nil, nil, fmt.Errorf("step %q depends on %q, which runs later", steps[i].Name, steps[dep].Name)
+			}
+		}
+	}
+
+	// Dependencies inside a stage must not form a cycle
+	state := make([]int, len(steps))
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case 1:
+			return fmt.Errorf("dependency cycle through step %q", steps[i].Name)
+		case 2:
+			return nil
+		}
+		state[i] = 1
+		for _, dep := range deps[i] {
+			if stageOf[dep] == stageOf[i] {
+				if err := visit(dep); err != nil {
+					return err
+				}
+			}
+		}
+		state[i] = 2
+		return nil
+	}
+	for i := range steps {
+		if err := visit(i); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return stages, deps, nil
+}
+
+func resolveDependencies(steps []storage.SavedRequest, byName map[string][]int) ([][]int, error) {
+	deps := make([][]int, len(steps))
+	for i, step := range steps {
+		for _, name := range step.DependsOn {
+			matches := byName[name]
+			switch {
+			case len(matches) == 0:
+				return nil, fmt.Errorf("step %q depends on unknown step %q", step.Name, name)
+			case len(matches) > 1:
+				return nil, fmt.Errorf("step %q depends on %q, which names %d steps", step.Name, name, len(matches))
+			case matches[0] == i:
+				return nil, fmt.Errorf("step %q depends on itself", step.Name)
+			}
+			deps[i] = append(deps[i], matches[0])
+		}
+	}
+	return deps, nil
+}
+
+// Run executes the steps stage by stage and returns the report
+func Run(ctx context.Context, client *httpclient.Client, steps []storage.SavedRequest, opts Options) (Report, error) {
+	stages, deps, err := plan(steps)
+	if err != nil {
+		return Report{}, err
+	}
+
+	if opts.MaxConcurrency < 1 {
+		opts.MaxConcurrency = DefaultMaxConcurrency
+	}
+	if opts.Prepare == nil {
+		opts.Prepare = PrepareRequest
+	}
+
+	start := time.Now()
+	results := make([]Result, len(steps))
+	done := make([]chan struct{}, len(steps))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	var reportMu sync.Mutex
+
+	// Variables extracted by finished steps, used by the steps after them
+	var extractedMu sync.Mutex
+	var extracted []storage.Variable
+	finish := func(i int, result Result) {
+		results[i] = result
+		if opts.OnResult != nil {
+			reportMu.Lock()
+			opts.OnResult(i, result)
+			reportMu.Unlock()
+		}
+		close(done[i])
+	}
+
+	for stageIdx, stage := range stages {
+		sem := make(chan struct{}, opts.MaxConcurrency)
+		var wg sync.WaitGroup
+
+		for _, i := range stage.Steps {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				step := steps[i]
+				result := Result{
+					Name:   step.Name,
+					Method: step.Method,
+					URL:    step.URLWithQueryParams(),
+					Group:  stage.Group,
+					Stage:  stageIdx,
+				}
+				result.TimeBudget, result.SizeBudget = stepBudgets(step, opts)
+
+				for _, dep := range deps[i] {
+					<-done[dep]
+					if !results[dep].Passed() {
+						result.Skipped = true
+						result.SkipReason = fmt.Sprintf("dependency %q did not pass", steps[dep].Name)
+						finish(i, result)
+						return
+					}
+				}
+
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-ctx.Done():
+				}
+				if ctx.Err() != nil {
+					result.Skipped = true
+					result.SkipReason = "run canceled"
+					finish(i, result)
+					return
+				}
+
+				extractedMu.Lock()
+				chained := extracted
+				extractedMu.Unlock()
+				step = withVariables(step, chained)
+
+				prepared := opts.Prepare(step)
+				prepared.Timeout, prepared.Retry = opts.Defaults.Policy(step.Method, step.Policy)
+				req, err := Sign(step, prepared, storage.MergeVariables(opts.Variables, chained), time.Now())
+				result.URL = req.URL
+				if err != nil {
+					result.Error = err
+					finish(i, result)
+					return
+				}
+				resp := client.SendWithContext(ctx, req)
+
+				result.StatusCode = resp.StatusCode
+				result.Status = resp.Status
+				result.ResponseTime = resp.ResponseTime
+				result.Size = resp.Size
+				result.Error = resp.Error
+				if resp.Error == nil {
+					result.Assertions = storage.CheckAssertions(step.Assertions, resp.StatusCode, resp.Body, resp.Headers, resp.ResponseTime.Milliseconds())
+					result.Assertions = append(result.Assertions, checkContract(opts.Contract, req, resp)...)
+					result.GoldenDiff = compareToGolden(step, resp, opts.Ignore)
+					result.Extractions = storage.RunExtractions(step.Extractions, resp.Body, resp.Headers)
+					extractedMu.Lock()
+					extracted = storage.MergeVariables(extracted, storage.ExtractedVariables(result.Extractions))
+					extractedMu.Unlock()
+				}
+				finish(i, result)
+			}(i)
+		}
+
+		wg.Wait()
+	}
+
+	return Report{Results: results, Duration: time.Since(start)}, nil
+}
+
+// withVariables replaces {{name}} references to vars in the URL, query,
+// headers, body and auth of a step. Variables extracted during the run are
+// replaced this way before Prepare, so they win over the environment.
+func withVariables(step storage.SavedRequest, vars []storage.Variable) storage.SavedRequest {
+	if len(vars) == 0 {
+		return step
+	}
+	step.URL = storage.ReplaceVariables(step.URL, vars)
+	step.Body = storage.ReplaceVariables(step.Body, vars)
+	headers := make(map[string]string, len(step.Headers))
+	for k, v := range step.Headers {
+		headers[k] = storage.ReplaceVariables(v, vars)
+	}
+	step.Headers = headers
+	query := make(map[string]string, len(step.QueryParams))
+	for k, v := range step.QueryParams {
+		query[k] = storage.ReplaceVariables(v, vars)
+	}
+	step.QueryParams = query
+	if step.Auth != nil {
+		auth := *step.Auth
+		auth.Username = storage.ReplaceVariables(auth.Username, vars)
+		auth.Password = storage.ReplaceVariables(auth.Password, vars)
+		auth.Token = storage.ReplaceVariables(auth.Token, vars)
+		auth.KeyName = storage.ReplaceVariables(auth.KeyName, vars)
+		auth.KeyValue = storage.ReplaceVariables(auth.KeyValue, vars)
+		step.Auth = &auth
+	}
+	return step
+}
+
+// stepBudgets returns the time and size budgets of a step, falling back to
+// the run's defaults
+func stepBudgets(step storage.SavedRequest, opts Options) (time.Duration, int64) {
+	timeMs, size := step.LatencyBudgetMs, step.SizeBudgetBytes
+	if timeMs <= 0 {
+		timeMs = opts.LatencyBudgetMs
+	}
+	if size <= 0 {
+		size = opts.SizeBudgetBytes
+	}
+	return time.Duration(timeMs) * time.Millisecond, size
+}
+
+// Sign signs the prepared request of a step that has HMAC signing at now,
+// resolving variables in its key. Signing comes last so the signature
+// covers the request as sent.
+func Sign(step storage.SavedRequest, req httpclient.Request, vars []storage.Variable, now time.Time) (httpclient.Request, error) {
+	if step.HMAC == nil {
+		return req, nil
+	}
+	signer := httpclient.HMACSigner(*step.HMAC)
+	signer.Key = storage.ReplaceVariables(signer.Key, vars)
+	signed, _, err := signer.Sign(req, now)
+	if err != nil {
+		return req, err
+	}
+	return signed, nil
+}
+
+// checkContract validates a response against the run's OpenAPI spec and
+// returns one failed contract assertion per violation
+func checkContract(spec *storage.OpenAPISpec, req httpclient.Request, resp httpclient.Response) []storage.AssertionResult {
+	if spec == nil {
+		return nil
+	}
+	var results []storage.AssertionResult
+	for _, err := range storage.ValidateContract(spec, req.Method, req.URL, resp.StatusCode, resp.Body) {
+		results = append(results, storage.AssertionResult{
+			Assertion: storage.ResponseAssertion{Type: storage.AssertContract},
+			Err:       err,
+		})
+	}
+	return results
+}
+
+// compareToGolden diffs a response against the step's golden response and
+// returns nil when there is none or nothing unexpected changed. When the
+// step's volatile fields do not parse, only the run's rules apply.
+func compareToGolden(step storage.SavedRequest, resp httpclient.Response, ignore httpclient.IgnoreRules) *httpclient.DiffResult {
+	if step.Golden == nil {
+		return nil
+	}
+	if rules, err := httpclient.ParseIgnoreRules(step.VolatileFields); err == nil {
+		ignore = ignore.Merge(rules)
+	}
+	golden := httpclient.Response{
+		StatusCode: step.Golden.StatusCode,
+		Headers:    step.Golden.Headers,
+		Body:       step.Golden.Body,
+	}
+	diff := httpclient.CompareToGolden(golden, resp, ignore)
+	if !diff.HasDifferences() {
+		return nil
+	}
+	return diff
+}
+
+// RunCollection runs the requests of a collection with its run settings;
+// positive options override the collection's own settings. Collections
+// imported from OpenAPI have their responses validated against the spec.
+func RunCollection(ctx context.Context, client *httpclient.Client, collection storage.Collection, opts Options) (Report, error) {
+	if opts.Contract == nil && len(collection.OpenAPISpec) > 0 {
+		spec, err := collection.Contract()
+		if err != nil {
+			return Report{Collection: collection.Name}, err
+		}
+		opts.Contract = spec
+	}
+	if run := collection.Run; run != nil {
+		if opts.MaxConcurrency < 1 {
+			opts.MaxConcurrency = run.MaxConcurrency
+		}
+		if opts.LatencyBudgetMs <= 0 {
+			opts.LatencyBudgetMs = run.LatencyBudgetMs
+		}
+		if opts.SizeBudgetBytes <= 0 {
+			opts.SizeBudgetBytes = run.SizeBudgetBytes
+		}
+	}
+
+	report, err := Run(ctx, client, collection.Requests, opts)
+	report.Collection = collection.Name
+	return report, err
+}
+
+// FormatReport renders a summary followed by one row per step
+func FormatReport(report Report) string {
+	var sb strings.Builder
+
+	passed, failed, skipped := report.Counts()
+	sb.WriteString(fmt.Sprintf("%s: %d steps • %d passed • %d failed • %d skipped • %s\n",
+		report.Collection, len(report.Results), passed, failed, skipped, httpclient.FormatDuration(report.Duration)))
+	if stats := report.Stats(); stats.Samples > 0 {
+		sb.WriteString(fmt.Sprintf("time %s\n", stats.Time.format(func(v int64) string { return httpclient.FormatDuration(time.Duration(v)) })))
+		sb.WriteString(fmt.Sprintf("size %s\n", stats.Size.format(httpclient.FormatSize)))
+		if stats.OverTimeBudget+stats.OverSizeBudget > 0 {
+			sb.WriteString(fmt.Sprintf("budgets: %d over time, %d over size\n", stats.OverTimeBudget, stats.OverSizeBudget))
+		}
+	}
+	sb.WriteString("\n")
+
+	for _, r := range report.Results {
+		marker := "✓"
+		switch {
+		case r.Skipped:
+			marker = "-"
+		case !r.Passed():
+			marker = "✗"
+		}
+
+		group := ""
+		if r.Group != "" {
+			group = " [" + r.Group + "]"
+		}
+
+		switch {
+		case r.Skipped:
+			sb.WriteString(fmt.Sprintf("%s %-4s %8s  %s%s\n    skipped: %s\n", marker, "SKIP", "-", r.Name, group, r.SkipReason))
+		case r.Error != nil:
+			sb.WriteString(fmt.Sprintf("%s %-4s %8s  %s%s\n    error: %v\n", marker, "ERR", "-", r.Name, group, r.Error))
+		default:
+			sb.WriteString(fmt.Sprintf("%s %-4d %8s  %s%s\n", marker, r.StatusCode, httpclient.FormatDuration(r.ResponseTime), r.Name, group))
+			for _, a := range r.Assertions {
+				if !a.Passed() {
+					sb.WriteString(fmt.Sprintf("    assertion failed: %s: %v\n", a.Assertion, a.Err))
+				}
+			}
+			for _, e := range r.Extractions {
+				if !e.Passed() {
+					sb.WriteString(fmt.Sprintf("    extraction failed: %s: %v\n", e.Extract, e.Err))
+				}
+			}
+			if r.GoldenDiff != nil {
+				sb.WriteString(fmt.Sprintf("    differs from golden: %s\n", r.GoldenDiff.Summary()))
+			}
+			if r.OverTimeBudget() {
+				sb.WriteString(fmt.Sprintf("    over time budget: %s > %s\n", httpclient.FormatDuration(r.ResponseTime), httpclient.FormatDuration(r.TimeBudget)))
+			}
+			if r.OverSizeBudget() {
+				sb.WriteString(fmt.Sprintf("    over size budget: %s > %s\n", httpclient.FormatSize(r.Size), httpclient.FormatSize(r.SizeBudget)))
+			}
+		}
+	}
+
+	return sb.String()
+}
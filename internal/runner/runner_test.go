@@ -0,0 +1,521 @@
+package runner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+func step(name, url, group string, dependsOn ...string) storage.SavedRequest {
+	return storage.SavedRequest{Name: name, Method: "GET", URL: url, Group: group, DependsOn: dependsOn}
+}
+
+func TestPlanStages(t *testing.T) {
+	steps := []storage.SavedRequest{
+		step("login", "/login", ""),
+		step("users", "/users", "reads"),
+		step("orders", "/orders", "reads"),
+		step("logout", "/logout", ""),
+		step("health", "/health", "reads"),
+	}
+
+	stages, err := Plan(steps)
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+
+	want := [][]int{{0}, {1, 2}, {3}, {4}}
+	if len(stages) != len(want) {
+		t.Fatalf("Plan() = %+v, want %d stages", stages, len(want))
+	}
+	for i, stage := range stages {
+		if len(stage.Steps) != len(want[i]) {
+			t.Errorf("stage %d = %v, want %v", i, stage.Steps, want[i])
+		}
+	}
+}
+
+func TestPlanRejectsBadDependencies(t *testing.T) {
+	tests := map[string][]storage.SavedRequest{
+		"unknown step": {step("a", "/a", "", "missing")},
+		"itself":       {step("a", "/a", "", "a")},
+		"runs later":   {step("a", "/a", "", "b"), step("b", "/b", "")},
+		"cycle":        {step("a", "/a", "g", "b"), step("b", "/b", "g", "a")},
+		"ambiguous":    {step("a", "/a", ""), step("a", "/a2", ""), step("b", "/b", "", "a")},
+	}
+
+	for name, steps := range tests {
+		if _, err := Plan(steps); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestRunParallelGroupRespectsMaxConcurrency(t *testing.T) {
+	var inFlight, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var steps []storage.SavedRequest
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		steps = append(steps, step(name, server.URL+"/"+name, "all"))
+	}
+
+	var mu sync.Mutex
+	var finished []int
+	report, err := Run(context.Background(), httpclient.NewClient(5*time.Second), steps, Options{
+		MaxConcurrency: 2,
+		OnResult: func(index int, result Result) {
+			mu.Lock()
+			finished = append(finished, index)
+			mu.Unlock()
+		},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if peak := atomic.LoadInt32(&peak); peak != 2 {
+		t.Errorf("Expected at most 2 requests in flight and some overlap, peak was %d", peak)
+	}
+	if passed, failed, skipped := report.Counts(); passed != 6 || failed != 0 || skipped != 0 {
+		t.Errorf("Counts() = %d/%d/%d", passed, failed, skipped)
+	}
+	if len(finished) != 6 {
+		t.Errorf("OnResult called %d times, want 6", len(finished))
+	}
+}
+
+func TestRunKeepsOrderAndSkipsFailedDependencies(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		order = append(order, r.URL.Path)
+		mu.Unlock()
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	steps := []storage.SavedRequest{
+		step("login", server.URL+"/login", ""),
+		step("broken", server.URL+"/broken", "calls"),
+		step("after broken", server.URL+"/after", "calls", "broken"),
+		step("profile", server.URL+"/profile", "calls", "login"),
+		step("logout", server.URL+"/logout", ""),
+	}
+
+	report, err := Run(context.Background(), httpclient.NewClient(5*time.Second), steps, Options{})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if order[0] != "/login" || order[len(order)-1] != "/logout" {
+		t.Errorf("Sequential steps ran out of order: %v", order)
+	}
+	if !report.Results[2].Skipped || !strings.Contains(report.Results[2].SkipReason, "broken") {
+		t.Errorf("Expected step after a failed dependency to be skipped, got %+v", report.Results[2])
+	}
+	if !report.Results[3].Passed() {
+		t.Errorf("Expected profile to pass, got %+v", report.Results[3])
+	}
+	if passed, failed, skipped := report.Counts(); passed != 3 || failed != 1 || skipped != 1 {
+		t.Errorf("Counts() = %d/%d/%d, want 3/1/1", passed, failed, skipped)
+	}
+
+	output := FormatReport(report)
+	for _, want := range []string{"5 steps", "SKIP", "[calls]"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("FormatReport() missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestRunCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report, err := Run(ctx, httpclient.NewClient(time.Second), []storage.SavedRequest{step("a", "http://127.0.0.1:1/a", "")}, Options{})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !report.Results[0].Skipped {
+		t.Errorf("Expected steps of a canceled run to be skipped, got %+v", report.Results[0])
+	}
+}
+
+func TestRunFailsStepsWithFailedAssertions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [1, 2]}`))
+	}))
+	defer server.Close()
+
+	ok := step("ok", server.URL+"/ok", "")
+	ok.Assertions = []storage.ResponseAssertion{
+		{Type: storage.AssertJSONLength, Field: "items", Operator: storage.OpEquals, Value: "2"},
+	}
+	bad := step("bad", server.URL+"/bad", "")
+	bad.Assertions = []storage.ResponseAssertion{
+		{Type: storage.AssertHeader, Field: "content-type", Operator: storage.OpMatches, Value: "xml"},
+	}
+
+	report, err := Run(context.Background(), httpclient.NewClient(5*time.Second), []storage.SavedRequest{ok, bad}, Options{})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if !report.Results[0].Passed() {
+		t.Errorf("Expected step with passing assertions to pass, got %+v", report.Results[0])
+	}
+	if report.Results[1].Passed() {
+		t.Errorf("Expected 200 response with a failed assertion to fail, got %+v", report.Results[1])
+	}
+	if output := FormatReport(report); !strings.Contains(output, "assertion failed: header content-type matches") {
+		t.Errorf("FormatReport() does not show the failed assertion:\n%s", output)
+	}
+}
+
+func TestRunChainsExtractedVariables(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			w.Write([]byte(`{"token": "fresh"}`))
+		case "/me":
+			if r.Header.Get("Authorization") != "Bearer fresh" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"id": 42}`))
+		}
+	}))
+	defer server.Close()
+
+	login := step("login", server.URL+"/login", "")
+	login.Extractions = []storage.VariableExtract{storage.NewVariableExtract("TOKEN", storage.ExtractJSON, "$.token")}
+	me := step("me", server.URL+"/me", "")
+	me.Headers = map[string]string{"Authorization": "Bearer {{TOKEN}}"}
+	me.Extractions = []storage.VariableExtract{
+		storage.NewVariableExtract("USER_ID", storage.ExtractJSON, "$.id"),
+		storage.NewVariableExtract("ROLE", storage.ExtractJSON, "$.role"),
+	}
+
+	// The environment value is stale; the value extracted during the run wins
+	vars := []storage.Variable{{Key: "TOKEN", Value: "stale"}}
+	report, err := Run(context.Background(), httpclient.NewClient(5*time.Second), []storage.SavedRequest{login, me}, Options{
+		Variables: vars,
+		Prepare: func(step storage.SavedRequest) httpclient.Request {
+			return ResolveRequest(step, vars, nil)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if !report.Results[0].Passed() || report.Results[1].StatusCode != http.StatusOK {
+		t.Fatalf("Expected the token of the first step to authorize the second, got %+v", report.Results)
+	}
+	if report.Results[1].Passed() {
+		t.Errorf("Expected a step with a failed extraction to fail, got %+v", report.Results[1])
+	}
+	want := []storage.Variable{{Key: "TOKEN", Value: "fresh"}, {Key: "USER_ID", Value: "42"}}
+	if got := report.Extracted(); len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Extracted() = %+v, want %+v", got, want)
+	}
+	if output := FormatReport(report); !strings.Contains(output, "extraction failed: ROLE from json $.role") {
+		t.Errorf("FormatReport() does not show the failed extraction:\n%s", output)
+	}
+}
+
+func TestRunFlagsGoldenDifferences(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 7, "name": "renamed", "updated_at": "now"}`))
+	}))
+	defer server.Close()
+
+	s := step("user", server.URL+"/users/7", "")
+	s.Golden = &storage.GoldenResponse{StatusCode: 200, Body: `{"id": 7, "name": "original", "updated_at": "then"}`}
+	s.VolatileFields = []string{"updated_at"}
+
+	report, err := Run(context.Background(), httpclient.NewClient(5*time.Second), []storage.SavedRequest{s}, Options{})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	result := report.Results[0]
+	if !result.Passed() {
+		t.Errorf("Expected golden differences to be flagged without failing the step, got %+v", result)
+	}
+	if result.GoldenDiff == nil || len(result.GoldenDiff.BodyDiff.Changes) != 1 {
+		t.Fatalf("Expected only the name change to be flagged, got %+v", result.GoldenDiff)
+	}
+	if output := FormatReport(report); !strings.Contains(output, "1 modified, 0 added, 0 removed, 1 ignored") {
+		t.Errorf("FormatReport() does not flag the golden difference:\n%s", output)
+	}
+}
+
+func TestRunSignsStepsWithHMAC(t *testing.T) {
+	var mu sync.Mutex
+	received := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received[r.URL.Path] = r.Header.Get("X-Signature")
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	signed := step("signed", server.URL+"/signed", "")
+	signed.HMAC = &storage.HMACAuth{Key: "{{secret}}", Template: "{method} {path}", Header: "X-Signature"}
+	broken := step("broken", server.URL+"/broken", "")
+	broken.HMAC = &storage.HMACAuth{Key: "k", Algorithm: "md5"}
+
+	report, err := Run(context.Background(), httpclient.NewClient(5*time.Second), []storage.SavedRequest{signed, broken}, Options{
+		Variables: []storage.Variable{{Key: "secret", Value: "s3cret"}},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	signer := httpclient.HMACSigner{Key: "s3cret", Template: "{method} {path}"}
+	want, err := signer.Preview(httpclient.Request{Method: "GET", URL: server.URL + "/signed"}, time.Now())
+	if err != nil {
+		t.Fatalf("Preview() error = %v", err)
+	}
+	if received["/signed"] != want.Signature {
+		t.Errorf("Expected the step to be signed with the resolved key, got %q want %q", received["/signed"], want.Signature)
+	}
+
+	if report.Results[1].Passed() || report.Results[1].Error == nil {
+		t.Errorf("Expected a step that cannot be signed to fail, got %+v", report.Results[1])
+	}
+	if _, sent := received["/broken"]; sent {
+		t.Error("Expected a step that cannot be signed not to be sent")
+	}
+}
+
+func TestRunCollectionAppliesBudgets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("x", 2048)))
+	}))
+	defer server.Close()
+
+	own := step("own", server.URL+"/own", "")
+	own.SizeBudgetBytes = 4096
+	collection := storage.Collection{
+		Name:     "budgets",
+		Requests: []storage.SavedRequest{own, step("default", server.URL+"/default", "")},
+		Run:      &storage.RunSettings{LatencyBudgetMs: 1000, SizeBudgetBytes: 1024},
+	}
+
+	report, err := RunCollection(context.Background(), httpclient.NewClient(5*time.Second), collection, Options{})
+	if err != nil {
+		t.Fatalf("RunCollection() error = %v", err)
+	}
+
+	if r := report.Results[0]; r.SizeBudget != 4096 || r.OverSizeBudget() {
+		t.Errorf("Expected the step's own size budget to apply, got %+v", r)
+	}
+	if r := report.Results[1]; r.SizeBudget != 1024 || !r.OverSizeBudget() || r.TimeBudget != time.Second {
+		t.Errorf("Expected the collection's budgets to apply, got %+v", r)
+	}
+	if !report.Results[1].Passed() {
+		t.Error("Expected a budget breach not to fail the step")
+	}
+}
+
+func TestRunCollectionValidatesContract(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/users/1":
+			w.Write([]byte(`{"id": 1, "name": "Ada"}`))
+		case "/users/2":
+			w.Write([]byte(`{"id": "2"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	spec := `{
+		"openapi": "3.0.0",
+		"info": {"title": "Users", "version": "1"},
+		"paths": {
+			"/users/{id}": {
+				"get": {
+					"responses": {
+						"200": {
+							"description": "ok",
+							"content": {"application/json": {"schema": {
+								"type": "object",
+								"required": ["id", "name"],
+								"properties": {"id": {"type": "integer"}, "name": {"type": "string"}}
+							}}}
+						}
+					}
+				}
+			}
+		}
+	}`
+	collection := storage.Collection{
+		Name:        "users",
+		Requests:    []storage.SavedRequest{step("valid", server.URL+"/users/1", ""), step("drifted", server.URL+"/users/2", "")},
+		OpenAPISpec: []byte(spec),
+	}
+
+	report, err := RunCollection(context.Background(), httpclient.NewClient(5*time.Second), collection, Options{})
+	if err != nil {
+		t.Fatalf("RunCollection() error = %v", err)
+	}
+
+	if r := report.Results[0]; !r.Passed() || len(r.Assertions) != 0 {
+		t.Errorf("Expected the valid response to pass, got %+v", r)
+	}
+	r := report.Results[1]
+	if r.Passed() {
+		t.Fatalf("Expected the response that breaks the contract to fail, got %+v", r)
+	}
+	if len(r.Assertions) != 2 {
+		t.Fatalf("Expected 2 contract violations, got %+v", r.Assertions)
+	}
+	if output := FormatReport(report); !strings.Contains(output, "assertion failed: contract: $.id expected integer") {
+		t.Errorf("FormatReport() does not show the contract violation:\n%s", output)
+	}
+}
+
+func TestResolveRequestExpandsAliasesThenVariables(t *testing.T) {
+	saved := storage.SavedRequest{
+		Method:      "POST",
+		URL:         "users/list",
+		Headers:     map[string]string{"Authorization": "Bearer {{TOKEN}}"},
+		Body:        `{"tenant": "{{TENANT}}"}`,
+		QueryParams: map[string]string{"page": "2"},
+	}
+	vars := []storage.Variable{{Key: "HOST", Value: "api.example.com"}, {Key: "TOKEN", Value: "t0k"}, {Key: "TENANT", Value: "acme"}}
+	aliases := []storage.ServiceAlias{{Name: "users", Target: "https://{{HOST}}/v1"}}
+
+	req := ResolveRequest(saved, vars, aliases)
+	if req.URL != "https://api.example.com/v1/list?page=2" {
+		t.Errorf("URL = %q", req.URL)
+	}
+	if req.Headers["Authorization"] != "Bearer t0k" || req.Body != `{"tenant": "acme"}` {
+		t.Errorf("Expected variables in headers and body, got %v and %q", req.Headers, req.Body)
+	}
+	if saved.Headers["Authorization"] != "Bearer {{TOKEN}}" {
+		t.Error("ResolveRequest() changed the headers of the saved request")
+	}
+}
+
+func TestResolveRequestAttachesAuth(t *testing.T) {
+	saved := storage.SavedRequest{
+		Method:  "GET",
+		URL:     "https://{{HOST}}/me",
+		Headers: map[string]string{"Authorization": "stale"},
+		Auth:    &storage.RequestAuth{Type: httpclient.AuthBearer, Token: "{{TOKEN}}"},
+	}
+	vars := []storage.Variable{{Key: "HOST", Value: "api.example.com"}, {Key: "TOKEN", Value: "t0k"}}
+
+	req := ResolveRequest(saved, vars, nil)
+	if req.Headers["Authorization"] != "Bearer t0k" {
+		t.Errorf("Authorization = %q, want the bearer token with its variable resolved", req.Headers["Authorization"])
+	}
+
+	saved.Auth = &storage.RequestAuth{Type: httpclient.AuthAPIKey, KeyName: "key", KeyValue: "{{TOKEN}}", In: httpclient.AuthInQuery}
+	if req := ResolveRequest(saved, vars, nil); req.URL != "https://api.example.com/me?key=t0k" {
+		t.Errorf("URL = %q, want the API key in the query", req.URL)
+	}
+
+	// Unusable settings send the request as it is
+	saved.Auth = &storage.RequestAuth{Type: httpclient.AuthBasic}
+	if req := ResolveRequest(saved, vars, nil); req.Headers["Authorization"] != "stale" {
+		t.Errorf("Authorization = %q, want the request unchanged", req.Headers["Authorization"])
+	}
+}
+
+func TestPrepareRequestAppliesPolicy(t *testing.T) {
+	retries := 2
+	saved := storage.SavedRequest{
+		Method: "POST",
+		URL:    "https://api.example.com/jobs",
+		Policy: &storage.RequestPolicy{TimeoutMs: 1500, Retries: &retries, BackoffMs: 200, NoFollowRedirects: true},
+	}
+
+	req := PrepareRequest(saved)
+	if req.Timeout != 1500*time.Millisecond || req.Retry.Retries != 2 || req.Retry.Backoff != 200*time.Millisecond {
+		t.Errorf("Request timeout=%v retry=%+v, want the saved policy", req.Timeout, req.Retry)
+	}
+	if !req.NoFollowRedirects {
+		t.Error("Request follows redirects, want the saved policy to stop it")
+	}
+}
+
+func TestRunAppliesDefaults(t *testing.T) {
+	var attempts sync.Map
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, _ := attempts.LoadOrStore(r.Method, new(atomic.Int32))
+		n.(*atomic.Int32).Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	retries := 1
+	steps := []storage.SavedRequest{
+		{Name: "read", Method: "GET", URL: server.URL},
+		{Name: "write", Method: "POST", URL: server.URL},
+		{Name: "retried write", Method: "PUT", URL: server.URL, Policy: &storage.RequestPolicy{Retries: &retries}},
+	}
+	_, err := Run(context.Background(), httpclient.NewClient(5*time.Second), steps, Options{
+		Defaults: Defaults{Timeout: time.Second, Retry: httpclient.RetryPolicy{Retries: 2, Backoff: time.Millisecond}},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	// The retries of the defaults only apply to safe methods, and the
+	// backoff of the defaults to a policy that leaves it unset
+	for method, want := range map[string]int32{"GET": 3, "POST": 1, "PUT": 2} {
+		n, _ := attempts.Load(method)
+		if got := n.(*atomic.Int32).Load(); got != want {
+			t.Errorf("%s was sent %d times, want %d", method, got, want)
+		}
+	}
+}
+
+func TestDefaultsPolicy(t *testing.T) {
+	defaults := Defaults{Timeout: 30 * time.Second, Retry: httpclient.RetryPolicy{Retries: 3, Backoff: 500 * time.Millisecond}}
+
+	if timeout, retry := defaults.Policy("get", nil); timeout != 30*time.Second || retry.Retries != 3 {
+		t.Errorf("Policy(GET) = %v, %+v; want the defaults", timeout, retry)
+	}
+	if _, retry := defaults.Policy("DELETE", nil); retry.Retries != 0 {
+		t.Errorf("Policy(DELETE) retries = %d, want 0", retry.Retries)
+	}
+	retries := 2
+	timeout, retry := defaults.Policy("POST", &storage.RequestPolicy{TimeoutMs: 1500, Retries: &retries})
+	if timeout != 1500*time.Millisecond || retry.Retries != 2 || retry.Backoff != 500*time.Millisecond {
+		t.Errorf("Policy(POST) = %v, %+v; want the request's timeout and retries with the default backoff", timeout, retry)
+	}
+}
@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ServiceAlias maps a short service name to a base URL, so "users-api/42"
+// can be typed instead of "{{API_URL}}/users/42"
+type ServiceAlias struct {
+	Name   string `json:"name"`
+	Target string `json:"target"`
+}
+
+// aliasNameRegex keeps alias names apart from host names and schemes
+var aliasNameRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ValidateAliasName checks that name can be used as a service alias
+func ValidateAliasName(name string) error {
+	if name == "" {
+		return fmt.Errorf("alias name cannot be empty")
+	}
+	if !aliasNameRegex.MatchString(name) {
+		return fmt.Errorf("alias name %q may only contain letters, digits, - and _", name)
+	}
+	return nil
+}
+
+// AddAlias adds or updates a service alias of an environment
+func (s *Storage) AddAlias(envName, name, target string) error {
+	if err := ValidateAliasName(name); err != nil {
+		return err
+	}
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return fmt.Errorf("alias target cannot be empty")
+	}
+
+	return s.editEnvironments(func(config *EnvironmentConfig) error {
+		env := config.Find(envName)
+		if env == nil {
+			return fmt.Errorf("environment not found: %s", envName)
+		}
+		for j, alias := range env.Aliases {
+			if alias.Name == name {
+				env.Aliases[j].Target = target
+				return nil
+			}
+		}
+		env.Aliases = append(env.Aliases, ServiceAlias{Name: name, Target: target})
+		return nil
+	})
+}
+
+// DeleteAlias removes a service alias from an environment
+func (s *Storage) DeleteAlias(envName, name string) error {
+	return s.editEnvironments(func(config *EnvironmentConfig) error {
+		env := config.Find(envName)
+		if env == nil {
+			return fmt.Errorf("environment not found: %s", envName)
+		}
+		for j, alias := range env.Aliases {
+			if alias.Name == name {
+				env.Aliases = append(env.Aliases[:j], env.Aliases[j+1:]...)
+				return nil
+			}
+		}
+		return fmt.Errorf("alias not found: %s", name)
+	})
+}
+
+// GetActiveAliases returns the service aliases of the active environment
+func (s *Storage) GetActiveAliases() ([]ServiceAlias, error) {
+	config, err := s.LoadEnvironments()
+	if err != nil {
+		return nil, err
+	}
+	if env := config.Active(); env != nil {
+		return env.Aliases, nil
+	}
+	return nil, nil
+}
+
+// Active returns the active environment, or nil when none is active
+func (c *EnvironmentConfig) Active() *Environment {
+	if c == nil || c.ActiveEnvironment == "" {
+		return nil
+	}
+	return c.Find(c.ActiveEnvironment)
+}
+
+// Find returns the environment called name, or nil when there is none
+func (c *EnvironmentConfig) Find(name string) *Environment {
+	if c == nil {
+		return nil
+	}
+	for i := range c.Environments {
+		if c.Environments[i].Name == name {
+			return &c.Environments[i]
+		}
+	}
+	return nil
+}
+
+// ExpandAlias replaces a leading service alias in rawURL with its target.
+// URLs with a scheme are left alone. The second result names the alias
+// that was expanded.
+func ExpandAlias(rawURL string, aliases []ServiceAlias) (string, string) {
+	if len(aliases) == 0 || strings.Contains(rawURL, "://") {
+		return rawURL, ""
+	}
+
+	name, rest := rawURL, ""
+	if i := strings.IndexAny(rawURL, "/?#"); i >= 0 {
+		name, rest = rawURL[:i], rawURL[i:]
+	}
+	for _, alias := range aliases {
+		if alias.Name == name {
+			return strings.TrimRight(alias.Target, "/") + rest, alias.Name
+		}
+	}
+	return rawURL, ""
+}
@@ -0,0 +1,98 @@
+package storage
+
+import "testing"
+
+func TestExpandAlias(t *testing.T) {
+	aliases := []ServiceAlias{
+		{Name: "users-api", Target: "{{API_URL}}/users/"},
+		{Name: "billing", Target: "https://billing.example.com"},
+	}
+
+	tests := []struct {
+		url, want, alias string
+	}{
+		{"users-api/42", "{{API_URL}}/users/42", "users-api"},
+		{"users-api", "{{API_URL}}/users", "users-api"},
+		{"billing?page=2", "https://billing.example.com?page=2", "billing"},
+		{"https://users-api/42", "https://users-api/42", ""},
+		{"users/42", "users/42", ""},
+		{"{{API_URL}}/users", "{{API_URL}}/users", ""},
+	}
+	for _, tt := range tests {
+		got, alias := ExpandAlias(tt.url, aliases)
+		if got != tt.want || alias != tt.alias {
+			t.Errorf("ExpandAlias(%q) = %q, %q, want %q, %q", tt.url, got, alias, tt.want, tt.alias)
+		}
+	}
+}
+
+func TestAliasesPerEnvironment(t *testing.T) {
+	s, err := NewStorageAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	for _, env := range []string{"dev", "prod"} {
+		if err := s.AddEnvironment(env); err != nil {
+			t.Fatalf("AddEnvironment() error = %v", err)
+		}
+	}
+
+	if err := s.AddAlias("dev", "users-api", "http://localhost:8080/users"); err != nil {
+		t.Fatalf("AddAlias() error = %v", err)
+	}
+	if err := s.AddAlias("prod", "users-api", "https://api.example.com/users"); err != nil {
+		t.Fatalf("AddAlias() error = %v", err)
+	}
+	if err := s.AddAlias("dev", "users-api", "http://localhost:9090/users"); err != nil {
+		t.Fatalf("AddAlias() update error = %v", err)
+	}
+	if err := s.AddAlias("dev", "users.api", "x"); err == nil {
+		t.Error("Expected an alias name with a dot to be refused")
+	}
+
+	if err := s.SetActiveEnvironment("prod"); err != nil {
+		t.Fatalf("SetActiveEnvironment() error = %v", err)
+	}
+	if aliases, err := s.GetActiveAliases(); err != nil || len(aliases) != 1 || aliases[0].Target != "https://api.example.com/users" {
+		t.Errorf("GetActiveAliases() = %v, %v, want the prod alias", aliases, err)
+	}
+
+	if err := s.SetActiveEnvironment("dev"); err != nil {
+		t.Fatalf("SetActiveEnvironment() error = %v", err)
+	}
+	aliases, err := s.GetActiveAliases()
+	if err != nil || len(aliases) != 1 || aliases[0].Target != "http://localhost:9090/users" {
+		t.Fatalf("GetActiveAliases() = %v, %v, want the updated dev alias", aliases, err)
+	}
+
+	if err := s.DeleteAlias("dev", "users-api"); err != nil {
+		t.Fatalf("DeleteAlias() error = %v", err)
+	}
+	if aliases, _ := s.GetActiveAliases(); len(aliases) != 0 {
+		t.Errorf("Expected the alias to be deleted, got %v", aliases)
+	}
+	if err := s.DeleteAlias("dev", "users-api"); err == nil {
+		t.Error("Expected an error deleting a missing alias")
+	}
+}
+
+func TestEnvironmentConfigFind(t *testing.T) {
+	config := &EnvironmentConfig{
+		Environments:      []Environment{{Name: "dev"}, {Name: "prod"}},
+		ActiveEnvironment: "dev",
+	}
+
+	if env := config.Find("prod"); env == nil || env.Name != "prod" {
+		t.Errorf("Find(prod) = %+v", env)
+	}
+	if env := config.Find("staging"); env != nil {
+		t.Errorf("Expected nil for a missing environment, got %+v", env)
+	}
+	if env := config.Active(); env == nil || env.Name != "dev" {
+		t.Errorf("Active() = %+v, want dev", env)
+	}
+	var missing *EnvironmentConfig
+	if env := missing.Find("dev"); env != nil {
+		t.Errorf("Expected nil from a nil config, got %+v", env)
+	}
+}
@@ -0,0 +1,335 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Assertion types
+const (
+	AssertStatusCode   = "status_code"
+	AssertResponseTime = "response_time"
+	AssertHeader       = "header"
+	AssertBodyContains = "body_contains"
+	AssertBodyMatches  = "body_matches"
+	AssertBodySize     = "body_size"
+	AssertJSONPath     = "json_path"
+	AssertJSONLength   = "json_length"
+	// AssertContract marks OpenAPI contract violations found by collection
+	// runs; it is not offered in the builder
+	AssertContract = "contract"
+)
+
+// Assertion operators
+const (
+	OpEquals      = "equals"
+	OpContains    = "contains"
+	OpMatches     = "matches"
+	OpGreaterThan = "greater_than"
+	OpLessThan    = "less_than"
+	OpExists      = "exists"
+)
+
+// AssertionTypes lists the assertion types in the order the builder offers them
+var AssertionTypes = []string{
+	AssertStatusCode,
+	AssertJSONPath,
+	AssertJSONLength,
+	AssertHeader,
+	AssertBodyContains,
+	AssertBodyMatches,
+	AssertBodySize,
+	AssertResponseTime,
+}
+
+// AssertionOperators returns the operators an assertion type supports, the
+// default one first
+func AssertionOperators(assertionType string) []string {
+	switch assertionType {
+	case AssertStatusCode, AssertJSONLength, AssertBodySize:
+		return []string{OpEquals, OpLessThan, OpGreaterThan}
+	case AssertResponseTime:
+		return []string{OpLessThan, OpGreaterThan}
+	case AssertHeader, AssertJSONPath:
+		return []string{OpExists, OpEquals, OpContains, OpMatches}
+	case AssertBodyContains:
+		return []string{OpContains}
+	case AssertBodyMatches:
+		return []string{OpMatches}
+	default:
+		return nil
+	}
+}
+
+// AssertionNeedsField reports whether the type checks a named header or JSON path
+func AssertionNeedsField(assertionType string) bool {
+	switch assertionType {
+	case AssertHeader, AssertJSONPath, AssertJSONLength:
+		return true
+	default:
+		return false
+	}
+}
+
+// Validate checks that the assertion is complete and its value usable
+func (a ResponseAssertion) Validate() error {
+	operators := AssertionOperators(a.Type)
+	if operators == nil {
+		return fmt.Errorf("unknown assertion type: %s", a.Type)
+	}
+	if AssertionNeedsField(a.Type) && strings.TrimSpace(a.Field) == "" {
+		return fmt.Errorf("%s assertion needs a header name or JSON path", a.Type)
+	}
+
+	operator := a.Operator
+	if operator == "" {
+		operator = operators[0]
+	}
+	supported := false
+	for _, op := range operators {
+		supported = supported || op == operator
+	}
+	if !supported {
+		return fmt.Errorf("operator %s is not supported by %s assertions", operator, a.Type)
+	}
+
+	switch {
+	case operator == OpMatches || a.Type == AssertBodyMatches:
+		if _, err := regexp.Compile(a.Value); err != nil {
+			return fmt.Errorf("invalid regex pattern %q: %w", a.Value, err)
+		}
+	case operator == OpExists:
+	case a.Type == AssertStatusCode, a.Type == AssertResponseTime, a.Type == AssertBodySize, a.Type == AssertJSONLength:
+		if _, err := strconv.ParseInt(strings.TrimSpace(a.Value), 10, 64); err != nil {
+			return fmt.Errorf("expected value %q is not a number", a.Value)
+		}
+	}
+	return nil
+}
+
+// String describes the assertion in one line, e.g. `header Content-Type matches "json"`
+func (a ResponseAssertion) String() string {
+	subject := strings.ReplaceAll(a.Type, "_", " ")
+	if a.Field != "" {
+		subject += " " + a.Field
+	}
+
+	operator := a.Operator
+	if operator == "" {
+		if ops := AssertionOperators(a.Type); len(ops) > 0 {
+			operator = ops[0]
+		}
+	}
+	switch operator {
+	case "":
+		return subject
+	case OpExists:
+		return subject + " exists"
+	}
+	return fmt.Sprintf("%s %s %q", subject, strings.ReplaceAll(operator, "_", " "), a.Value)
+}
+
+// AssertionResult is the outcome of one assertion against a response
+type AssertionResult struct {
+	Assertion ResponseAssertion
+	Err       error
+}
+
+// Passed reports whether the assertion held
+func (r AssertionResult) Passed() bool {
+	return r.Err == nil
+}
+
+// CheckAssertions validates every assertion against a response
+func CheckAssertions(assertions []ResponseAssertion, statusCode int, responseBody string, responseHeaders map[string][]string, responseTimeMs int64) []AssertionResult {
+	if len(assertions) == 0 {
+		return nil
+	}
+
+	headers := make(map[string]string, len(responseHeaders))
+	for name, values := range responseHeaders {
+		headers[name] = strings.Join(values, ", ")
+	}
+
+	results := make([]AssertionResult, len(assertions))
+	for i, assertion := range assertions {
+		results[i] = AssertionResult{
+			Assertion: assertion,
+			Err:       ValidateAssertion(assertion, statusCode, responseBody, headers, responseTimeMs),
+		}
+	}
+	return results
+}
+
+// CountPassed returns how many results passed
+func CountPassed(results []AssertionResult) int {
+	passed := 0
+	for _, r := range results {
+		if r.Passed() {
+			passed++
+		}
+	}
+	return passed
+}
+
+// lookupHeader finds a header by exact name, then case-insensitively
+func lookupHeader(headers map[string]string, name string) (string, bool) {
+	if value, ok := headers[name]; ok {
+		return value, true
+	}
+	if value, ok := headers[http.CanonicalHeaderKey(name)]; ok {
+		return value, true
+	}
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// matchPattern reports whether value matches the regular expression pattern
+func matchPattern(label, pattern, value string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid regex pattern %q: %w", pattern, err)
+	}
+	if !re.MatchString(value) {
+		return fmt.Errorf("%s does not match /%s/", label, pattern)
+	}
+	return nil
+}
+
+// compareNumber checks actual against the expected number with operator
+func compareNumber(label string, actual int64, operator, expected string) error {
+	want, err := strconv.ParseInt(strings.TrimSpace(expected), 10, 64)
+	if err != nil {
+		return fmt.Errorf("%s: expected value %q is not a number", label, expected)
+	}
+
+	switch operator {
+	case OpLessThan:
+		if actual >= want {
+			return fmt.Errorf("%s %d not less than %d", label, actual, want)
+		}
+	case OpGreaterThan:
+		if actual <= want {
+			return fmt.Errorf("%s %d not greater than %d", label, actual, want)
+		}
+	case OpEquals, "":
+		if actual != want {
+			return fmt.Errorf("%s expected %d, got %d", label, want, actual)
+		}
+	default:
+		return fmt.Errorf("unknown operator for %s: %s", label, operator)
+	}
+	return nil
+}
+
+// jsonLength returns the number of items of the array or object at path
+func jsonLength(responseBody, path string) (int64, error) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(responseBody), &data); err != nil {
+		return 0, fmt.Errorf("response is not valid JSON: %w", err)
+	}
+
+	value, err := extractJSONPath(data, path)
+	if err != nil {
+		return 0, err
+	}
+
+	switch v := value.(type) {
+	case []interface{}:
+		return int64(len(v)), nil
+	case map[string]interface{}:
+		return int64(len(v)), nil
+	default:
+		return 0, fmt.Errorf("JSON path '%s' is not an array, got %T", path, value)
+	}
+}
+
+// maxSuggestedPaths caps the paths SuggestJSONPaths returns
+const maxSuggestedPaths = 200
+
+// SuggestJSONPaths lists the paths of a JSON body in the syntax assertions
+// and extractions use, such as "data.items[0].id". With arraysOnly only
+// paths to arrays are listed. Only the first item of each array is walked.
+func SuggestJSONPaths(responseBody string, arraysOnly bool) []string {
+	var data interface{}
+	if err := json.Unmarshal([]byte(responseBody), &data); err != nil {
+		return nil
+	}
+
+	var paths []string
+	var walk func(value interface{}, path string)
+	walk = func(value interface{}, path string) {
+		if len(paths) >= maxSuggestedPaths {
+			return
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				child := k
+				if path != "" {
+					child = path + "." + k
+				}
+				walk(v[k], child)
+			}
+		case []interface{}:
+			if path != "" {
+				paths = append(paths, path)
+			}
+			if len(v) > 0 {
+				walk(v[0], path+"[0]")
+			}
+		default:
+			if !arraysOnly && path != "" {
+				paths = append(paths, path)
+			}
+		}
+	}
+	walk(data, "")
+
+	if len(paths) > maxSuggestedPaths {
+		paths = paths[:maxSuggestedPaths]
+	}
+	return paths
+}
+
+// SuggestAssertionValue returns what the assertion would compare against in
+// the given response, to prefill the expected value
+func SuggestAssertionValue(assertion ResponseAssertion, statusCode int, responseBody string, responseHeaders map[string][]string, responseTimeMs int64) string {
+	switch assertion.Type {
+	case AssertStatusCode:
+		return strconv.Itoa(statusCode)
+	case AssertResponseTime:
+		return strconv.FormatInt(responseTimeMs, 10)
+	case AssertBodySize:
+		return strconv.Itoa(len(responseBody))
+	case AssertHeader:
+		for name, values := range responseHeaders {
+			if strings.EqualFold(name, assertion.Field) {
+				return strings.Join(values, ", ")
+			}
+		}
+	case AssertJSONLength:
+		if n, err := jsonLength(responseBody, assertion.Field); err == nil {
+			return strconv.FormatInt(n, 10)
+		}
+	case AssertJSONPath:
+		if value, err := ExtractVariable(responseBody, VariableExtract{JSONPath: assertion.Field}); err == nil {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateAssertionContentChecks(t *testing.T) {
+	body := `{"data": {"items": [{"id": 1}, {"id": 2}, {"id": 3}], "meta": {"total": 3}}, "name": "widget-42"}`
+	headers := map[string]string{
+		"Content-Type": "application/json; charset=utf-8",
+	}
+
+	tests := []struct {
+		name        string
+		assertion   ResponseAssertion
+		shouldError bool
+	}{
+		{"body matches", ResponseAssertion{Type: AssertBodyMatches, Operator: OpMatches, Value: `widget-\d+`}, false},
+		{"body does not match", ResponseAssertion{Type: AssertBodyMatches, Operator: OpMatches, Value: `^\[`}, true},
+		{"invalid regex", ResponseAssertion{Type: AssertBodyMatches, Operator: OpMatches, Value: `(`}, true},
+		{"body size under", ResponseAssertion{Type: AssertBodySize, Operator: OpLessThan, Value: "1000"}, false},
+		{"body size over", ResponseAssertion{Type: AssertBodySize, Operator: OpGreaterThan, Value: "1000"}, true},
+		{"body size not a number", ResponseAssertion{Type: AssertBodySize, Operator: OpLessThan, Value: "big"}, true},
+		{"header any case", ResponseAssertion{Type: AssertHeader, Field: "content-type", Operator: OpExists}, false},
+		{"header matches", ResponseAssertion{Type: AssertHeader, Field: "Content-Type", Operator: OpMatches, Value: `^application/json`}, false},
+		{"header does not match", ResponseAssertion{Type: AssertHeader, Field: "Content-Type", Operator: OpMatches, Value: `xml`}, true},
+		{"json path matches", ResponseAssertion{Type: AssertJSONPath, Field: "name", Operator: OpMatches, Value: `^widget`}, false},
+		{"array length", ResponseAssertion{Type: AssertJSONLength, Field: "data.items", Operator: OpEquals, Value: "3"}, false},
+		{"array too short", ResponseAssertion{Type: AssertJSONLength, Field: "data.items", Operator: OpGreaterThan, Value: "5"}, true},
+		{"length of a number", ResponseAssertion{Type: AssertJSONLength, Field: "data.meta.total", Operator: OpEquals, Value: "3"}, true},
+		{"status under", ResponseAssertion{Type: AssertStatusCode, Operator: OpLessThan, Value: "300"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAssertion(tt.assertion, 200, body, headers, 0)
+
+			if tt.shouldError && err == nil {
+				t.Error("Expected error, got nil")
+			}
+			if !tt.shouldError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckAssertions(t *testing.T) {
+	assertions := []ResponseAssertion{
+		{Type: AssertStatusCode, Operator: OpEquals, Value: "200"},
+		{Type: AssertHeader, Field: "X-Request-Id", Operator: OpExists},
+		{Type: AssertResponseTime, Operator: OpLessThan, Value: "100"},
+	}
+	headers := map[string][]string{"X-Request-Id": {"abc"}}
+
+	results := CheckAssertions(assertions, 200, "", headers, 250)
+	if len(results) != 3 {
+		t.Fatalf("CheckAssertions() returned %d results, want 3", len(results))
+	}
+	if CountPassed(results) != 2 || results[2].Passed() {
+		t.Errorf("Expected only the response time assertion to fail, got %+v", results)
+	}
+	if CheckAssertions(nil, 200, "", headers, 0) != nil {
+		t.Error("Expected no results without assertions")
+	}
+}
+
+func TestSuggestJSONPaths(t *testing.T) {
+	body := `{"data": {"items": [{"id": 1, "tags": ["a"]}]}, "ok": true}`
+
+	all := SuggestJSONPaths(body, false)
+	want := []string{"data.items", "data.items[0].id", "data.items[0].tags", "data.items[0].tags[0]", "ok"}
+	if !reflect.DeepEqual(all, want) {
+		t.Errorf("SuggestJSONPaths() = %v, want %v", all, want)
+	}
+
+	arrays := SuggestJSONPaths(body, true)
+	want = []string{"data.items", "data.items[0].tags"}
+	if !reflect.DeepEqual(arrays, want) {
+		t.Errorf("SuggestJSONPaths(arraysOnly) = %v, want %v", arrays, want)
+	}
+
+	if paths := SuggestJSONPaths("not json", false); paths != nil {
+		t.Errorf("Expected no paths for a non-JSON body, got %v", paths)
+	}
+}
+
+func TestSuggestAssertionValue(t *testing.T) {
+	body := `{"items": [1, 2], "name": "widget"}`
+	headers := map[string][]string{"Content-Type": {"application/json"}}
+
+	tests := []struct {
+		assertion ResponseAssertion
+		want      string
+	}{
+		{ResponseAssertion{Type: AssertStatusCode}, "201"},
+		{ResponseAssertion{Type: AssertJSONLength, Field: "items"}, "2"},
+		{ResponseAssertion{Type: AssertJSONPath, Field: "name"}, "widget"},
+		{ResponseAssertion{Type: AssertHeader, Field: "content-type"}, "application/json"},
+		{ResponseAssertion{Type: AssertBodyMatches}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := SuggestAssertionValue(tt.assertion, 201, body, headers, 40); got != tt.want {
+			t.Errorf("SuggestAssertionValue(%s) = %q, want %q", tt.assertion.Type, got, tt.want)
+		}
+	}
+}
+
+func TestResponseAssertionString(t *testing.T) {
+	tests := map[string]ResponseAssertion{
+		`header Content-Type exists`:        {Type: AssertHeader, Field: "Content-Type", Operator: OpExists},
+		`json length data.items equals "3"`: {Type: AssertJSONLength, Field: "data.items", Operator: OpEquals, Value: "3"},
+		`body size equals "1024"`:           {Type: AssertBodySize, Value: "1024"},
+	}
+	for want, assertion := range tests {
+		if got := assertion.String(); got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestResponseAssertionValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		assertion ResponseAssertion
+		wantErr   bool
+	}{
+		{"complete", ResponseAssertion{Type: AssertJSONLength, Field: "items", Operator: OpGreaterThan, Value: "0"}, false},
+		{"header exists", ResponseAssertion{Type: AssertHeader, Field: "ETag", Operator: OpExists}, false},
+		{"unknown type", ResponseAssertion{Type: "body_shape"}, true},
+		{"missing field", ResponseAssertion{Type: AssertHeader, Operator: OpExists}, true},
+		{"unsupported operator", ResponseAssertion{Type: AssertBodySize, Operator: OpMatches, Value: "1"}, true},
+		{"bad regex", ResponseAssertion{Type: AssertBodyMatches, Value: "(unclosed"}, true},
+		{"bad number", ResponseAssertion{Type: AssertBodySize, Operator: OpLessThan, Value: "1kb"}, true},
+	}
+
+	for _, tt := range tests {
+		if err := tt.assertion.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package storage
+
+// RequestAuth attaches credentials to every send of a saved request. It
+// mirrors the Auth of the http package field for field; credentials may
+// reference environment variables.
+type RequestAuth struct {
+	Type     string `json:"type"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Token    string `json:"token,omitempty"`
+	KeyName  string `json:"key_name,omitempty"`
+	KeyValue string `json:"key_value,omitempty"`
+	In       string `json:"in,omitempty"`
+}
+
+// UpdateRequestAuth sets or, with nil, removes the auth of a saved request
+func (s *Storage) UpdateRequestAuth(id string, auth *RequestAuth) error {
+	return s.editRequest(id, func(req *SavedRequest) {
+		req.Auth = auth
+	})
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AutoSaveRequest saves a request that got a successful response. A saved
+// request with the same method and URL is updated in place instead of being
+// duplicated; variants are never matched. It returns the ID of the saved
+// request and whether it was created.
+func (s *Storage) AutoSaveRequest(method, url string, headers map[string]string, body string, queryParams map[string]string) (string, bool, error) {
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return "", false, fmt.Errorf("cannot auto-save a request without a URL")
+	}
+
+	var id string
+	created := false
+	err := s.edit(func(c *Config) error {
+		now := time.Now()
+		for i := range c.Requests {
+			req := &c.Requests[i]
+			if req.ParentID != "" || !strings.EqualFold(req.Method, method) || req.URL != url {
+				continue
+			}
+			req.Headers = headers
+			req.Body = body
+			req.QueryParams = queryParams
+			req.LastUsed = now
+			id = req.ID
+			return nil
+		}
+
+		id = uuid.New().String()
+		created = true
+		c.Requests = append(c.Requests, SavedRequest{
+			ID:          id,
+			Name:        uniqueRequestName(c.Requests, fmt.Sprintf("%s %s", method, url)),
+			Method:      method,
+			URL:         url,
+			Headers:     headers,
+			Body:        body,
+			QueryParams: queryParams,
+			CreatedAt:   now,
+			LastUsed:    now,
+		})
+		return nil
+	})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to auto-save request: %w", err)
+	}
+	return id, created, nil
+}
+
+// uniqueRequestName returns name, or name with a number appended when a
+// saved request already uses it
+func uniqueRequestName(requests []SavedRequest, name string) string {
+	taken := make(map[string]bool, len(requests))
+	for _, req := range requests {
+		taken[req.Name] = true
+	}
+	candidate := name
+	for n := 2; taken[candidate]; n++ {
+		candidate = fmt.Sprintf("%s (%d)", name, n)
+	}
+	return candidate
+}
@@ -0,0 +1,50 @@
+package storage
+
+import "testing"
+
+func TestAutoSaveRequestDedupesOnMethodAndURL(t *testing.T) {
+	s, err := NewStorageAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+
+	id, created, err := s.AutoSaveRequest("GET", "https://api.example.com/users", map[string]string{"Accept": "json"}, "", nil)
+	if err != nil || !created {
+		t.Fatalf("AutoSaveRequest() = %q, %v, %v, want a new request", id, created, err)
+	}
+
+	again, created, err := s.AutoSaveRequest("get", "https://api.example.com/users", map[string]string{"Accept": "xml"}, "", map[string]string{"page": "2"})
+	if err != nil || created || again != id {
+		t.Fatalf("AutoSaveRequest() = %q, %v, %v, want %q updated", again, created, err, id)
+	}
+	if _, created, _ := s.AutoSaveRequest("POST", "https://api.example.com/users", nil, "{}", nil); !created {
+		t.Error("Expected another method to be saved separately")
+	}
+
+	requests := s.GetRequests()
+	if len(requests) != 2 {
+		t.Fatalf("Expected 2 saved requests, got %d", len(requests))
+	}
+	req, _ := s.GetRequest(id)
+	if req.Headers["Accept"] != "xml" || req.QueryParams["page"] != "2" || req.Name != "GET https://api.example.com/users" {
+		t.Errorf("Expected the saved request to be updated, got %+v", req)
+	}
+}
+
+func TestAutoSaveRequestAvoidsNameClash(t *testing.T) {
+	s, err := NewStorageAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	if err := s.SaveRequest("GET https://api.example.com", "GET", "https://api.example.com/other", nil, "", nil); err != nil {
+		t.Fatalf("SaveRequest() error = %v", err)
+	}
+
+	id, _, err := s.AutoSaveRequest("GET", "https://api.example.com", nil, "", nil)
+	if err != nil {
+		t.Fatalf("AutoSaveRequest() error = %v", err)
+	}
+	if req, _ := s.GetRequest(id); req.Name != "GET https://api.example.com (2)" {
+		t.Errorf("Expected a numbered name, got %q", req.Name)
+	}
+}
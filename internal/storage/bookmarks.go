@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/abneribeiro/godev/internal/paths"
+)
+
+// SetHistoryBookmark bookmarks or unbookmarks a history entry and sets its note.
+// Bookmarked entries are never trimmed from history or removed by ClearHistory.
+func (s *Storage) SetHistoryBookmark(id string, bookmarked bool, note string) error {
+	return s.edit(func(c *Config) error {
+		for i := range c.History {
+			if c.History[i].ID == id {
+				c.History[i].Bookmarked = bookmarked
+				c.History[i].Note = strings.TrimSpace(note)
+				return nil
+			}
+		}
+		return fmt.Errorf("history item not found: %s", id)
+	})
+}
+
+// GetBookmarks returns the bookmarked history entries, newest first
+func (s *Storage) GetBookmarks() []RequestExecution {
+	var bookmarks []RequestExecution
+	for _, exec := range s.config.History {
+		if exec.Bookmarked {
+			bookmarks = append(bookmarks, exec)
+		}
+	}
+	return bookmarks
+}
+
+// trimHistory keeps the newest max entries plus every bookmarked one
+func trimHistory(history []RequestExecution, max int) []RequestExecution {
+	kept := make([]RequestExecution, 0, len(history))
+	unmarked := 0
+	for _, exec := range history {
+		if exec.Bookmarked {
+			kept = append(kept, exec)
+			continue
+		}
+		if unmarked < max {
+			kept = append(kept, exec)
+			unmarked++
+		}
+	}
+	return kept
+}
+
+// FormatBookmarks renders bookmarked entries as a Markdown investigation log
+func FormatBookmarks(bookmarks []RequestExecution) string {
+	var b strings.Builder
+
+	b.WriteString("# Bookmarked requests\n\n")
+
+	for _, exec := range bookmarks {
+		b.WriteString(fmt.Sprintf("## %s %s\n\n", exec.Method, exec.URL))
+		if exec.Note != "" {
+			b.WriteString("> " + strings.ReplaceAll(exec.Note, "\n", "\n> ") + "\n\n")
+		}
+
+		outcome := exec.Status
+		if exec.Error != "" {
+			outcome = "error: " + exec.Error
+		}
+		b.WriteString(fmt.Sprintf("_Recorded %s • %s • %dms_\n\n",
+			exec.Timestamp.Format(time.RFC3339), outcome, exec.ResponseTime))
+
+		if len(exec.Headers) > 0 {
+			names := make([]string, 0, len(exec.Headers))
+			for name := range exec.Headers {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			b.WriteString("### Headers\n\n")
+			b.WriteString("| Name | Value |\n|------|-------|\n")
+			for _, name := range names {
+				b.WriteString(fmt.Sprintf("| %s | %s |\n", escapeMarkdownCell(name), escapeMarkdownCell(exec.Headers[name])))
+			}
+			b.WriteString("\n")
+		}
+
+		if exec.Body != "" {
+			b.WriteString("### Request Body\n\n")
+			b.WriteString(markdownCodeBlock(prettyDocBody(exec.Body)))
+		}
+
+		if exec.ResponseBody != "" {
+			b.WriteString("### Response\n\n")
+			b.WriteString(markdownCodeBlock(truncateDocBody(prettyDocBody(exec.ResponseBody))))
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// ExportBookmarks writes the bookmarked entries to a Markdown file in the
+// exports directory and returns its path
+func (s *Storage) ExportBookmarks() (string, error) {
+	bookmarks := s.GetBookmarks()
+	if len(bookmarks) == 0 {
+		return "", fmt.Errorf("no bookmarked requests to export")
+	}
+
+	exportDir, err := paths.ExportDir()
+	if err != nil {
+		return "", err
+	}
+
+	filePath := filepath.Join(exportDir, fmt.Sprintf("bookmarks_%s.md", time.Now().Format("20060102_150405")))
+	if err := os.WriteFile(filePath, []byte(FormatBookmarks(bookmarks)), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	return filePath, nil
+}
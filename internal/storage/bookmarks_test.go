@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestBookmarksSurviveTrimAndClear(t *testing.T) {
+	tmpDir := t.TempDir()
+	origHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", origHome)
+	os.Setenv("HOME", tmpDir)
+
+	s, err := NewStorage()
+	if err != nil {
+		t.Fatalf("NewStorage() error = %v", err)
+	}
+
+	if err := s.AddToHistory("POST", "https://api.example.com/orders", map[string]string{"X-Trace": "abc"}, `{"qty": -1}`, nil,
+		500, "500 Internal Server Error", `{"error": "boom"}`, 42, nil); err != nil {
+		t.Fatalf("AddToHistory() error = %v", err)
+	}
+	bug := s.GetHistory()[0]
+
+	if err := s.SetHistoryBookmark(bug.ID, true, "  reproduction of bug #123 "); err != nil {
+		t.Fatalf("SetHistoryBookmark() error = %v", err)
+	}
+	if err := s.SetHistoryBookmark("missing", true, ""); err == nil {
+		t.Error("Expected an error for an unknown history item")
+	}
+
+	for i := 0; i < maxHistorySize+5; i++ {
+		if err := s.AddToHistory("GET", "https://api.example.com/health", nil, "", nil, 200, "200 OK", "", 1, nil); err != nil {
+			t.Fatalf("AddToHistory() error = %v", err)
+		}
+	}
+
+	bookmarks := s.GetBookmarks()
+	if len(bookmarks) != 1 || bookmarks[0].ID != bug.ID || bookmarks[0].Note != "reproduction of bug #123" {
+		t.Fatalf("Expected the bookmark to survive trimming, got %+v", bookmarks)
+	}
+	if got := len(s.GetHistory()); got != maxHistorySize+1 {
+		t.Errorf("Expected %d history entries, got %d", maxHistorySize+1, got)
+	}
+
+	if err := s.ClearHistory(); err != nil {
+		t.Fatalf("ClearHistory() error = %v", err)
+	}
+	if history := s.GetHistory(); len(history) != 1 || history[0].ID != bug.ID {
+		t.Fatalf("Expected only the bookmark after clearing, got %d entries", len(history))
+	}
+
+	output := FormatBookmarks(s.GetBookmarks())
+	for _, want := range []string{"## POST https://api.example.com/orders", "> reproduction of bug #123", "| X-Trace | abc |", "500 Internal Server Error", `"error": "boom"`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("FormatBookmarks() missing %q:\n%s", want, output)
+		}
+	}
+
+	path, err := s.ExportBookmarks()
+	if err != nil {
+		t.Fatalf("ExportBookmarks() error = %v", err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != output {
+		t.Errorf("Unexpected export file %s: %v", path, err)
+	}
+
+	if err := s.SetHistoryBookmark(bug.ID, false, ""); err != nil {
+		t.Fatalf("SetHistoryBookmark() error = %v", err)
+	}
+	if _, err := s.ExportBookmarks(); err == nil {
+		t.Error("Expected an error when there is nothing to export")
+	}
+}
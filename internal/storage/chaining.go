@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -25,10 +26,10 @@ type VariableExtract struct {
 
 // ResponseAssertion defines a test to validate response
 type ResponseAssertion struct {
-	Type     string `json:"type"`     // "status_code", "json_path", "response_time", "header", "body_contains"
-	Field    string `json:"field"`    // For json_path: path, for header: header name
-	Operator string `json:"operator"` // "equals", "contains", "greater_than", "less_than", "exists"
-	Value    string `json:"value"`    // Expected value
+	Type     string `json:"type"`     // One of AssertionTypes, e.g. "status_code", "json_path", "header"
+	Field    string `json:"field"`    // For json_path and json_length: path, for header: header name
+	Operator string `json:"operator"` // "equals", "contains", "matches", "greater_than", "less_than", "exists"
+	Value    string `json:"value"`    // Expected value, or a regex for the matches operator
 }
 
 // RequestChain represents a sequence of requests
@@ -76,7 +77,8 @@ func ExtractVariable(responseBody string, extraction VariableExtract) (string, e
 		case string:
 			return v, nil
 		case float64:
-			return fmt.Sprintf("%v", v), nil
+			// Not %v, which writes ids and epoch times like 1.7e+09
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
 		case bool:
 			return fmt.Sprintf("%v", v), nil
 		case nil:
@@ -95,8 +97,10 @@ func ExtractVariable(responseBody string, extraction VariableExtract) (string, e
 }
 
 // extractJSONPath extracts a value from nested JSON using dot notation
-// Supports: "data.user.id", "items[0].name", "data.items[1].id"
+// Supports: "data.user.id", "items[0].name", "data.items[1].id", and the
+// same with a leading "$." as in "$.data.user.id"
 func extractJSONPath(data interface{}, path string) (interface{}, error) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
 	if path == "" {
 		return data, nil
 	}
@@ -181,7 +185,10 @@ func parseJSONPath(path string) []pathPart {
 // ValidateAssertion checks if a response matches an assertion
 func ValidateAssertion(assertion ResponseAssertion, statusCode int, responseBody string, responseHeaders map[string]string, responseTimeMs int64) error {
 	switch assertion.Type {
-	case "status_code":
+	case AssertStatusCode:
+		if assertion.Operator == OpLessThan || assertion.Operator == OpGreaterThan {
+			return compareNumber("status", int64(statusCode), assertion.Operator, assertion.Value)
+		}
 		expected := 0
 		fmt.Sscanf(assertion.Value, "%d", &expected)
 		if statusCode != expected {
@@ -189,7 +196,7 @@ func ValidateAssertion(assertion ResponseAssertion, statusCode int, responseBody
 		}
 		return nil
 
-	case "response_time":
+	case AssertResponseTime:
 		var maxTime int64
 		fmt.Sscanf(assertion.Value, "%d", &maxTime)
 
@@ -205,8 +212,8 @@ func ValidateAssertion(assertion ResponseAssertion, statusCode int, responseBody
 		}
 		return nil
 
-	case "header":
-		headerValue, exists := responseHeaders[assertion.Field]
+	case AssertHeader:
+		headerValue, exists := lookupHeader(responseHeaders, assertion.Field)
 		if !exists {
 			if assertion.Operator == "exists" {
 				return fmt.Errorf("header '%s' does not exist", assertion.Field)
@@ -223,16 +230,31 @@ func ValidateAssertion(assertion ResponseAssertion, statusCode int, responseBody
 			if !strings.Contains(headerValue, assertion.Value) {
 				return fmt.Errorf("header '%s' does not contain '%s'", assertion.Field, assertion.Value)
 			}
+		case OpMatches:
+			return matchPattern(fmt.Sprintf("header '%s'", assertion.Field), assertion.Value, headerValue)
 		}
 		return nil
 
-	case "body_contains":
+	case AssertBodyContains:
 		if !strings.Contains(responseBody, assertion.Value) {
 			return fmt.Errorf("response body does not contain '%s'", assertion.Value)
 		}
 		return nil
 
-	case "json_path":
+	case AssertBodyMatches:
+		return matchPattern("response body", assertion.Value, responseBody)
+
+	case AssertBodySize:
+		return compareNumber("body size", int64(len(responseBody)), assertion.Operator, assertion.Value)
+
+	case AssertJSONLength:
+		length, err := jsonLength(responseBody, assertion.Field)
+		if err != nil {
+			return err
+		}
+		return compareNumber(fmt.Sprintf("length of '%s'", assertion.Field), length, assertion.Operator, assertion.Value)
+
+	case AssertJSONPath:
 		var data interface{}
 		if err := json.Unmarshal([]byte(responseBody), &data); err != nil {
 			return fmt.Errorf("response is not valid JSON: %w", err)
@@ -262,6 +284,8 @@ func ValidateAssertion(assertion ResponseAssertion, statusCode int, responseBody
 			if !strings.Contains(valueStr, assertion.Value) {
 				return fmt.Errorf("JSON path '%s' value '%s' does not contain '%s'", assertion.Field, valueStr, assertion.Value)
 			}
+		case OpMatches:
+			return matchPattern(fmt.Sprintf("JSON path '%s' value '%s'", assertion.Field, valueStr), assertion.Value, valueStr)
 		}
 		return nil
 
@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/abneribeiro/godev/internal/filelock"
 )
 
 // Collection represents a folder/group of saved requests
@@ -19,6 +22,22 @@ type Collection struct {
 	UpdatedAt      time.Time      `json:"updated_at"`
 	Requests       []SavedRequest `json:"requests"`
 	SubCollections []Collection   `json:"sub_collections,omitempty"`
+	// OpenAPISpec holds the source document for collections imported from OpenAPI
+	OpenAPISpec json.RawMessage `json:"openapi_spec,omitempty"`
+	// SourceURL is set for collections imported from a URL so they can be pulled again
+	SourceURL string `json:"source_url,omitempty"`
+	// Run holds the settings used when the collection is run as a suite
+	Run *RunSettings `json:"run,omitempty"`
+}
+
+// RunSettings controls how a collection run schedules and reports its steps
+type RunSettings struct {
+	// MaxConcurrency caps how many steps of a parallel group are in flight
+	MaxConcurrency int `json:"max_concurrency,omitempty"`
+	// LatencyBudgetMs and SizeBudgetBytes apply to steps that have no
+	// budget of their own
+	LatencyBudgetMs int64 `json:"latency_budget_ms,omitempty"`
+	SizeBudgetBytes int64 `json:"size_budget_bytes,omitempty"`
 }
 
 // CollectionConfig holds all collections
@@ -31,12 +50,11 @@ const collectionsFile = "collections.json"
 
 // LoadCollections loads all collections from disk
 func (s *Storage) LoadCollections() (*CollectionConfig, error) {
-	homeDir, err := os.UserHomeDir()
+	configDirPath, err := s.Dir()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get home directory: %w", err)
+		return nil, err
 	}
 
-	configDirPath := filepath.Join(homeDir, configDir)
 	collectionsPath := filepath.Join(configDirPath, collectionsFile)
 
 	// If file doesn't exist, return empty config
@@ -62,12 +80,16 @@ func (s *Storage) LoadCollections() (*CollectionConfig, error) {
 
 // SaveCollections saves all collections to disk
 func (s *Storage) SaveCollections(config *CollectionConfig) error {
-	homeDir, err := os.UserHomeDir()
+	configDirPath, err := s.Dir()
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
+	}
+
+	// Use secure directory permissions (0700 - only owner can access)
+	if err := os.MkdirAll(configDirPath, 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	configDirPath := filepath.Join(homeDir, configDir)
 	collectionsPath := filepath.Join(configDirPath, collectionsFile)
 
 	data, err := json.MarshalIndent(config, "", "  ")
@@ -76,7 +98,7 @@ func (s *Storage) SaveCollections(config *CollectionConfig) error {
 	}
 
 	// Use secure file permissions (0600 - only owner can read/write)
-	if err := os.WriteFile(collectionsPath, data, 0o600); err != nil {
+	if err := filelock.WriteFile(collectionsPath, data, 0o600); err != nil {
 		return fmt.Errorf("failed to write collections file: %w", err)
 	}
 
@@ -121,6 +143,16 @@ func AddSubCollection(parent *Collection, child Collection) {
 	parent.UpdatedAt = time.Now()
 }
 
+// RequestCount returns the number of requests in the collection and its
+// sub-collections
+func (c Collection) RequestCount() int {
+	count := len(c.Requests)
+	for _, sub := range c.SubCollections {
+		count += sub.RequestCount()
+	}
+	return count
+}
+
 // FindCollectionByID recursively finds a collection by ID
 func FindCollectionByID(collections []Collection, id string) *Collection {
 	for i := range collections {
@@ -134,6 +166,178 @@ func FindCollectionByID(collections []Collection, id string) *Collection {
 	return nil
 }
 
+// FindCollectionByName finds a top-level collection by name
+func FindCollectionByName(collections []Collection, name string) *Collection {
+	for i := range collections {
+		if collections[i].Name == name {
+			return &collections[i]
+		}
+	}
+	return nil
+}
+
+// editCollections applies fn to the collections and saves them when fn
+// succeeds. The store is locked and read again first, so that a change made
+// by another godev instance meanwhile is kept.
+func (s *Storage) editCollections(fn func(config *CollectionConfig) error) error {
+	configDirPath, err := s.Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(configDirPath, 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	lock, err := filelock.Acquire(filepath.Join(configDirPath, collectionsFile))
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	config, err := s.LoadCollections()
+	if err != nil {
+		return err
+	}
+	if err := fn(config); err != nil {
+		return err
+	}
+	return s.SaveCollections(config)
+}
+
+// NewCollection creates an empty top-level collection. Names are unique
+// since collections are run by name.
+func (s *Storage) NewCollection(name, description string) (*Collection, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("collection name cannot be empty")
+	}
+
+	var collection Collection
+	err := s.editCollections(func(config *CollectionConfig) error {
+		if FindCollectionByName(config.Collections, name) != nil {
+			return fmt.Errorf("a collection named %q already exists", name)
+		}
+		collection = CreateCollection(name, strings.TrimSpace(description))
+		config.Collections = append(config.Collections, collection)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// UpdateCollection renames a collection and replaces its description
+func (s *Storage) UpdateCollection(id, name, description string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("collection name cannot be empty")
+	}
+
+	return s.editCollections(func(config *CollectionConfig) error {
+		collection := FindCollectionByID(config.Collections, id)
+		if collection == nil {
+			return fmt.Errorf("collection not found: %s", id)
+		}
+		if other := FindCollectionByName(config.Collections, name); other != nil && other.ID != id {
+			return fmt.Errorf("a collection named %q already exists", name)
+		}
+		collection.Name = name
+		collection.Description = strings.TrimSpace(description)
+		collection.UpdatedAt = time.Now()
+		return nil
+	})
+}
+
+// DeleteCollection removes a collection with its requests and
+// sub-collections
+func (s *Storage) DeleteCollection(id string) error {
+	return s.editCollections(func(config *CollectionConfig) error {
+		collections, ok := removeCollection(config.Collections, id)
+		if !ok {
+			return fmt.Errorf("collection not found: %s", id)
+		}
+		config.Collections = collections
+		return nil
+	})
+}
+
+func removeCollection(collections []Collection, id string) ([]Collection, bool) {
+	for i := range collections {
+		if collections[i].ID == id {
+			return append(collections[:i], collections[i+1:]...), true
+		}
+		if subs, ok := removeCollection(collections[i].SubCollections, id); ok {
+			collections[i].SubCollections = subs
+			collections[i].UpdatedAt = time.Now()
+			return collections, true
+		}
+	}
+	return collections, false
+}
+
+// AddToCollection copies a request into a collection. The copy gets its own
+// ID, so later edits of the saved request do not change the collection.
+func (s *Storage) AddToCollection(collectionID string, request SavedRequest) error {
+	return s.editCollections(func(config *CollectionConfig) error {
+		collection := FindCollectionByID(config.Collections, collectionID)
+		if collection == nil {
+			return fmt.Errorf("collection not found: %s", collectionID)
+		}
+		request.ID = uuid.New().String()
+		AddRequestToCollection(collection, request)
+		return nil
+	})
+}
+
+// TransferCollectionRequest copies a request of one collection into
+// another, removing it from the first one when move is set
+func (s *Storage) TransferCollectionRequest(fromID, requestID, toID string, move bool) error {
+	if fromID == toID {
+		return fmt.Errorf("the request is already in this collection")
+	}
+
+	return s.editCollections(func(config *CollectionConfig) error {
+		from := FindCollectionByID(config.Collections, fromID)
+		to := FindCollectionByID(config.Collections, toID)
+		if from == nil || to == nil {
+			return fmt.Errorf("collection not found")
+		}
+
+		var request *SavedRequest
+		for i := range from.Requests {
+			if from.Requests[i].ID == requestID {
+				request = &from.Requests[i]
+				break
+			}
+		}
+		if request == nil {
+			return fmt.Errorf("request not found in collection: %s", requestID)
+		}
+
+		copied := *request
+		if !move {
+			copied.ID = uuid.New().String()
+		}
+		AddRequestToCollection(to, copied)
+		if move {
+			return RemoveRequestFromCollection(from, requestID)
+		}
+		return nil
+	})
+}
+
+// RemoveFromCollection removes a request from a collection
+func (s *Storage) RemoveFromCollection(collectionID, requestID string) error {
+	return s.editCollections(func(config *CollectionConfig) error {
+		collection := FindCollectionByID(config.Collections, collectionID)
+		if collection == nil {
+			return fmt.Errorf("collection not found: %s", collectionID)
+		}
+		return RemoveRequestFromCollection(collection, requestID)
+	})
+}
+
 // ImportPostmanCollection imports a Postman collection format
 type PostmanRequest struct {
 	Name    string                `json:"name"`
@@ -141,10 +345,11 @@ type PostmanRequest struct {
 }
 
 type PostmanRequestDetails struct {
-	Method string          `json:"method"`
-	URL    PostmanURL      `json:"url"`
-	Header []PostmanHeader `json:"header"`
-	Body   PostmanBody     `json:"body,omitempty"`
+	Method      string          `json:"method"`
+	URL         PostmanURL      `json:"url"`
+	Header      []PostmanHeader `json:"header"`
+	Body        PostmanBody     `json:"body,omitempty"`
+	Description string          `json:"description,omitempty"`
 }
 
 type PostmanURL struct {
@@ -195,6 +400,7 @@ func ImportFromPostman(data []byte) (*Collection, error) {
 		request := SavedRequest{
 			ID:          uuid.New().String(),
 			Name:        item.Name,
+			Description: item.Request.Description,
 			Method:      item.Request.Method,
 			URL:         item.Request.URL.Raw,
 			Headers:     headers,
@@ -235,10 +441,11 @@ func ExportToPostman(collection *Collection) ([]byte, error) {
 		postman.Item = append(postman.Item, PostmanRequest{
 			Name: req.Name,
 			Request: PostmanRequestDetails{
-				Method: req.Method,
-				URL:    PostmanURL{Raw: req.URL},
-				Header: headers,
-				Body:   body,
+				Method:      req.Method,
+				URL:         PostmanURL{Raw: req.URL},
+				Header:      headers,
+				Body:        body,
+				Description: req.Description,
 			},
 		})
 	}
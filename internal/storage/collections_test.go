@@ -284,3 +284,98 @@ func TestImportInvalidPostman(t *testing.T) {
 		t.Error("Expected error when importing invalid JSON")
 	}
 }
+
+func TestFindCollectionByName(t *testing.T) {
+	collections := []Collection{CreateCollection("users", ""), CreateCollection("orders", "")}
+
+	if found := FindCollectionByName(collections, "orders"); found == nil || found.ID != collections[1].ID {
+		t.Errorf("Expected to find the orders collection, got %+v", found)
+	}
+	if found := FindCollectionByName(collections, "missing"); found != nil {
+		t.Errorf("Expected nil for a missing collection, got %+v", found)
+	}
+}
+
+func TestFindRequestByName(t *testing.T) {
+	requests := []SavedRequest{{ID: "1", Name: "List users"}, {ID: "2", Name: "Create user"}}
+
+	if found := FindRequestByName(requests, "Create user"); found == nil || found.ID != "2" {
+		t.Errorf("Expected to find the Create user request, got %+v", found)
+	}
+	if found := FindRequestByName(requests, "create user"); found != nil {
+		t.Errorf("Expected names to match exactly, got %+v", found)
+	}
+}
+
+func TestURLWithQueryParams(t *testing.T) {
+	req := SavedRequest{URL: "https://api.example.com/users?sort=name", QueryParams: map[string]string{"page": "2"}}
+	if got := req.URLWithQueryParams(); got != "https://api.example.com/users?page=2&sort=name" {
+		t.Errorf("URLWithQueryParams() = %s", got)
+	}
+
+	req.QueryParams = nil
+	if got := req.URLWithQueryParams(); got != req.URL {
+		t.Errorf("URLWithQueryParams() = %s, want %s", got, req.URL)
+	}
+}
+
+func TestManageCollections(t *testing.T) {
+	s, err := NewStorageAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+
+	users, err := s.NewCollection("users", "User endpoints")
+	if err != nil {
+		t.Fatalf("NewCollection() error = %v", err)
+	}
+	orders, err := s.NewCollection("orders", "")
+	if err != nil {
+		t.Fatalf("NewCollection() error = %v", err)
+	}
+	if _, err := s.NewCollection(" users ", ""); err == nil {
+		t.Error("Expected a duplicate name to be rejected")
+	}
+	if err := s.UpdateCollection(orders.ID, "users", ""); err == nil {
+		t.Error("Expected renaming onto an existing name to be rejected")
+	}
+
+	saved := SavedRequest{ID: "saved-1", Name: "list users", Method: "GET", URL: "https://api.example.com/users"}
+	if err := s.AddToCollection(users.ID, saved); err != nil {
+		t.Fatalf("AddToCollection() error = %v", err)
+	}
+	config, _ := s.LoadCollections()
+	added := FindCollectionByID(config.Collections, users.ID).Requests[0]
+	if added.ID == saved.ID || added.Name != saved.Name {
+		t.Errorf("Expected a copy with its own ID, got %+v", added)
+	}
+
+	if err := s.TransferCollectionRequest(users.ID, added.ID, orders.ID, false); err != nil {
+		t.Fatalf("TransferCollectionRequest(copy) error = %v", err)
+	}
+	if err := s.TransferCollectionRequest(users.ID, added.ID, orders.ID, true); err != nil {
+		t.Fatalf("TransferCollectionRequest(move) error = %v", err)
+	}
+	config, _ = s.LoadCollections()
+	if n := len(FindCollectionByID(config.Collections, users.ID).Requests); n != 0 {
+		t.Errorf("Expected the move to empty the source, got %d requests", n)
+	}
+	moved := FindCollectionByID(config.Collections, orders.ID).Requests
+	if len(moved) != 2 || moved[1].ID != added.ID {
+		t.Errorf("Expected a copy and the moved request in the target, got %+v", moved)
+	}
+
+	if err := s.RemoveFromCollection(orders.ID, added.ID); err != nil {
+		t.Fatalf("RemoveFromCollection() error = %v", err)
+	}
+	if err := s.UpdateCollection(orders.ID, "checkout", "Checkout flow"); err != nil {
+		t.Fatalf("UpdateCollection() error = %v", err)
+	}
+	if err := s.DeleteCollection(users.ID); err != nil {
+		t.Fatalf("DeleteCollection() error = %v", err)
+	}
+	config, _ = s.LoadCollections()
+	if len(config.Collections) != 1 || config.Collections[0].Name != "checkout" || len(config.Collections[0].Requests) != 1 {
+		t.Errorf("Collections = %+v", config.Collections)
+	}
+}
@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentInstancesKeepEachOthersChanges(t *testing.T) {
+	dir := t.TempDir()
+
+	first, err := NewStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	second, err := NewStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+
+	if err := first.SaveRequest("From first", "GET", "https://api.example.com/a", nil, "", nil); err != nil {
+		t.Fatalf("SaveRequest() error = %v", err)
+	}
+	// second still has the config it opened with, and must not drop the request above
+	if err := second.SaveRequest("From second", "GET", "https://api.example.com/b", nil, "", nil); err != nil {
+		t.Fatalf("SaveRequest() error = %v", err)
+	}
+
+	changed, err := first.Refresh()
+	if err != nil || !changed {
+		t.Fatalf("Refresh() = %v, %v, want the other instance's write to be picked up", changed, err)
+	}
+	if got := len(first.GetRequests()); got != 2 {
+		t.Errorf("Expected both requests after refresh, got %d", got)
+	}
+	if changed, _ := first.Refresh(); changed {
+		t.Error("Expected no change without a new write")
+	}
+
+	var wg sync.WaitGroup
+	for i, s := range []*Storage{first, second} {
+		wg.Add(1)
+		go func(i int, s *Storage) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if err := s.AddToHistory("GET", fmt.Sprintf("https://api.example.com/%d/%d", i, j), nil, "", nil, 200, "200 OK", "", 1, nil); err != nil {
+					t.Errorf("AddToHistory() error = %v", err)
+				}
+			}
+		}(i, s)
+	}
+	wg.Wait()
+
+	reopened, err := NewStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	if got := len(reopened.GetHistory()); got != 40 {
+		t.Errorf("Expected 40 history entries from both instances, got %d", got)
+	}
+}
+
+func TestEnvironmentsChanged(t *testing.T) {
+	dir := t.TempDir()
+
+	first, err := NewStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	second, err := NewStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	if _, err := first.ReloadEnvironments(); err != nil {
+		t.Fatalf("ReloadEnvironments() error = %v", err)
+	}
+	if first.EnvironmentsChanged() {
+		t.Error("Expected no change right after loading")
+	}
+
+	if err := second.AddEnvironment("staging"); err != nil {
+		t.Fatalf("AddEnvironment() error = %v", err)
+	}
+	if !first.EnvironmentsChanged() {
+		t.Error("Expected the other instance's environment to be detected")
+	}
+
+	// Reading the environments to send a request leaves the change to be
+	// picked up by the copy on screen
+	if _, err := first.LoadEnvironments(); err != nil {
+		t.Fatalf("LoadEnvironments() error = %v", err)
+	}
+	if _, err := first.GetActiveEnvironmentVariables(); err != nil {
+		t.Fatalf("GetActiveEnvironmentVariables() error = %v", err)
+	}
+	if !first.EnvironmentsChanged() {
+		t.Error("Expected the change to still be reported after reading the environments")
+	}
+
+	if _, err := first.ReloadEnvironments(); err != nil {
+		t.Fatalf("ReloadEnvironments() error = %v", err)
+	}
+	if first.EnvironmentsChanged() {
+		t.Error("Expected no change after reloading")
+	}
+}
+
+func TestConcurrentInstancesKeepEachOthersEnvironmentsAndCollections(t *testing.T) {
+	dir := t.TempDir()
+
+	first, err := NewStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	second, err := NewStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	if err := first.AddEnvironment("dev"); err != nil {
+		t.Fatalf("AddEnvironment() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i, s := range []*Storage{first, second} {
+		wg.Add(1)
+		go func(i int, s *Storage) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if err := s.AddVariable("dev", fmt.Sprintf("VAR_%d_%d", i, j), "value"); err != nil {
+					t.Errorf("AddVariable() error = %v", err)
+				}
+				if _, err := s.NewCollection(fmt.Sprintf("Collection %d/%d", i, j), ""); err != nil {
+					t.Errorf("NewCollection() error = %v", err)
+				}
+			}
+		}(i, s)
+	}
+	wg.Wait()
+
+	envs, err := first.LoadEnvironments()
+	if err != nil {
+		t.Fatalf("LoadEnvironments() error = %v", err)
+	}
+	if got := len(envs.Find("dev").Variables); got != 40 {
+		t.Errorf("Expected 40 variables from both instances, got %d", got)
+	}
+	collections, err := first.LoadCollections()
+	if err != nil {
+		t.Fatalf("LoadCollections() error = %v", err)
+	}
+	if got := len(collections.Collections); got != 40 {
+		t.Errorf("Expected 40 collections from both instances, got %d", got)
+	}
+}
@@ -0,0 +1,248 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DocFormat selects the output format for generated API documentation
+type DocFormat string
+
+const (
+	DocFormatMarkdown DocFormat = "markdown"
+	DocFormatHTML     DocFormat = "html"
+)
+
+// maxDocResponseSize truncates recorded responses included in documentation
+const maxDocResponseSize = 4096
+
+// DocEntry is the documentation for a single saved request
+type DocEntry struct {
+	Name        string
+	Description string
+	Method      string
+	URL         string
+	Headers     []DocHeader
+	Body        string
+	Response    *RequestExecution
+}
+
+// DocHeader is a header name/value pair, kept in a stable order
+type DocHeader struct {
+	Name  string
+	Value string
+}
+
+// BuildDocEntries pairs each request with its latest successful execution from history
+func BuildDocEntries(requests []SavedRequest, history []RequestExecution) []DocEntry {
+	entries := make([]DocEntry, 0, len(requests))
+	for _, req := range requests {
+		entry := DocEntry{
+			Name:        req.Name,
+			Description: req.Description,
+			Method:      req.Method,
+			URL:         req.URL,
+			Body:        prettyDocBody(req.Body),
+		}
+
+		for name, value := range req.Headers {
+			entry.Headers = append(entry.Headers, DocHeader{Name: name, Value: value})
+		}
+		sort.Slice(entry.Headers, func(i, j int) bool {
+			return entry.Headers[i].Name < entry.Headers[j].Name
+		})
+
+		// History is stored newest first
+		for i := range history {
+			exec := history[i]
+			if exec.Method == req.Method && exec.URL == req.URL && exec.Error == "" {
+				exec.ResponseBody = truncateDocBody(prettyDocBody(exec.ResponseBody))
+				entry.Response = &exec
+				break
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// GenerateDocs renders API documentation for the collection in the given format
+func GenerateDocs(collection *Collection, history []RequestExecution, format DocFormat) (string, error) {
+	entries := BuildDocEntries(collection.Requests, history)
+
+	switch format {
+	case DocFormatMarkdown:
+		return generateMarkdownDocs(collection, entries), nil
+	case DocFormatHTML:
+		return generateHTMLDocs(collection, entries)
+	default:
+		return "", fmt.Errorf("unsupported documentation format: %s", format)
+	}
+}
+
+func generateMarkdownDocs(collection *Collection, entries []DocEntry) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("# %s\n\n", collection.Name))
+	if collection.Description != "" {
+		b.WriteString(collection.Description + "\n\n")
+	}
+
+	if len(entries) > 1 {
+		b.WriteString("## Endpoints\n\n")
+		for _, entry := range entries {
+			b.WriteString(fmt.Sprintf("- [%s](#%s) `%s %s`\n", entry.Name, markdownAnchor(entry.Name), entry.Method, entry.URL))
+		}
+		b.WriteString("\n")
+	}
+
+	for _, entry := range entries {
+		b.WriteString(fmt.Sprintf("## %s\n\n", entry.Name))
+		b.WriteString(fmt.Sprintf("`%s %s`\n\n", entry.Method, entry.URL))
+		if entry.Description != "" {
+			b.WriteString(entry.Description + "\n\n")
+		}
+
+		if len(entry.Headers) > 0 {
+			b.WriteString("### Headers\n\n")
+			b.WriteString("| Name | Value |\n|------|-------|\n")
+			for _, h := range entry.Headers {
+				b.WriteString(fmt.Sprintf("| %s | %s |\n", escapeMarkdownCell(h.Name), escapeMarkdownCell(h.Value)))
+			}
+			b.WriteString("\n")
+		}
+
+		if entry.Body != "" {
+			b.WriteString("### Request Body\n\n")
+			b.WriteString(markdownCodeBlock(entry.Body))
+		}
+
+		if entry.Response != nil {
+			b.WriteString(fmt.Sprintf("### Example Response (%s)\n\n", entry.Response.Status))
+			b.WriteString(fmt.Sprintf("_Recorded %s in %dms_\n\n",
+				entry.Response.Timestamp.Format(time.RFC3339), entry.Response.ResponseTime))
+			if entry.Response.ResponseBody != "" {
+				b.WriteString(markdownCodeBlock(entry.Response.ResponseBody))
+			}
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+var htmlDocsTemplate = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Collection.Name}}</title>
+<style>
+body { font-family: -apple-system, sans-serif; max-width: 960px; margin: 2rem auto; padding: 0 1rem; color: #222; }
+pre { background: #f5f5f5; padding: 1rem; overflow-x: auto; }
+table { border-collapse: collapse; }
+td, th { border: 1px solid #ddd; padding: 0.25rem 0.5rem; text-align: left; }
+.method { font-weight: bold; font-family: monospace; }
+.muted { color: #777; }
+</style>
+</head>
+<body>
+<h1>{{.Collection.Name}}</h1>
+{{if .Collection.Description}}<p>{{.Collection.Description}}</p>{{end}}
+{{if gt (len .Entries) 1}}<h2>Endpoints</h2>
+<ul>
+{{range $i, $e := .Entries}}<li><a href="#endpoint-{{$i}}">{{$e.Name}}</a> <span class="method">{{$e.Method}}</span> {{$e.URL}}</li>
+{{end}}</ul>
+{{end}}{{range $i, $e := .Entries}}<section id="endpoint-{{$i}}">
+<h2>{{$e.Name}}</h2>
+<p><span class="method">{{$e.Method}}</span> <code>{{$e.URL}}</code></p>
+{{if $e.Description}}<p>{{$e.Description}}</p>{{end}}
+{{if $e.Headers}}<h3>Headers</h3>
+<table>
+<tr><th>Name</th><th>Value</th></tr>
+{{range $e.Headers}}<tr><td>{{.Name}}</td><td>{{.Value}}</td></tr>
+{{end}}</table>
+{{end}}{{if $e.Body}}<h3>Request Body</h3>
+<pre>{{$e.Body}}</pre>
+{{end}}{{with $e.Response}}<h3>Example Response ({{.Status}})</h3>
+<p class="muted">Recorded {{.Timestamp.Format "2006-01-02T15:04:05Z07:00"}} in {{.ResponseTime}}ms</p>
+{{if .ResponseBody}}<pre>{{.ResponseBody}}</pre>
+{{end}}{{end}}</section>
+{{end}}</body>
+</html>
+`))
+
+func generateHTMLDocs(collection *Collection, entries []DocEntry) (string, error) {
+	var b strings.Builder
+	data := struct {
+		Collection *Collection
+		Entries    []DocEntry
+	}{collection, entries}
+
+	if err := htmlDocsTemplate.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render documentation: %w", err)
+	}
+	return b.String(), nil
+}
+
+// prettyDocBody indents JSON bodies, leaving other content unchanged
+func prettyDocBody(body string) string {
+	trimmed := strings.TrimSpace(body)
+	if trimmed == "" {
+		return ""
+	}
+
+	var data interface{}
+	if err := json.Unmarshal([]byte(trimmed), &data); err != nil {
+		return trimmed
+	}
+	formatted, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return trimmed
+	}
+	return string(formatted)
+}
+
+func truncateDocBody(body string) string {
+	if len(body) <= maxDocResponseSize {
+		return body
+	}
+	return body[:maxDocResponseSize] + "\n... (truncated)"
+}
+
+// markdownCodeBlock fences the content, using a longer fence if it contains backticks
+func markdownCodeBlock(content string) string {
+	fence := "```"
+	for strings.Contains(content, fence) {
+		fence += "`"
+	}
+
+	lang := ""
+	if json.Valid([]byte(content)) {
+		lang = "json"
+	}
+	return fmt.Sprintf("%s%s\n%s\n%s\n\n", fence, lang, content, fence)
+}
+
+func escapeMarkdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// markdownAnchor builds a GitHub-style heading anchor
+func markdownAnchor(heading string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		case r > 127:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
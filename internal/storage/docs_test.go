@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func docsTestCollection() *Collection {
+	collection := CreateCollection("Users API", "Manage users")
+	AddRequestToCollection(&collection, SavedRequest{
+		Name:        "List users",
+		Description: "Returns every user.",
+		Method:      "GET",
+		URL:         "https://api.example.com/users",
+		Headers:     map[string]string{"Accept": "application/json", "Authorization": "Bearer {{token}}"},
+	})
+	AddRequestToCollection(&collection, SavedRequest{
+		Name:   "Create user",
+		Method: "POST",
+		URL:    "https://api.example.com/users",
+		Body:   `{"name":"<b>ann</b>"}`,
+	})
+	return &collection
+}
+
+func docsTestHistory() []RequestExecution {
+	now := time.Now()
+	return []RequestExecution{
+		{Method: "GET", URL: "https://api.example.com/users", StatusCode: 500, Status: "500 Internal Server Error", Error: "boom", Timestamp: now},
+		{Method: "GET", URL: "https://api.example.com/users", StatusCode: 200, Status: "200 OK", ResponseBody: `[{"id":1}]`, ResponseTime: 42, Timestamp: now.Add(-time.Minute)},
+		{Method: "GET", URL: "https://api.example.com/users", StatusCode: 200, Status: "200 OK", ResponseBody: `[]`, Timestamp: now.Add(-time.Hour)},
+	}
+}
+
+func TestBuildDocEntries(t *testing.T) {
+	collection := docsTestCollection()
+	entries := BuildDocEntries(collection.Requests, docsTestHistory())
+
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	list := entries[0]
+	if list.Response == nil || list.Response.ResponseTime != 42 {
+		t.Errorf("Expected latest successful execution to be used, got %+v", list.Response)
+	}
+	if len(list.Headers) != 2 || list.Headers[0].Name != "Accept" {
+		t.Errorf("Expected headers sorted by name, got %v", list.Headers)
+	}
+
+	if entries[1].Response != nil {
+		t.Error("Expected no recorded response for POST request")
+	}
+	if !strings.Contains(entries[1].Body, "\n  \"name\"") {
+		t.Errorf("Expected JSON body to be indented, got %q", entries[1].Body)
+	}
+}
+
+func TestGenerateMarkdownDocs(t *testing.T) {
+	docs, err := GenerateDocs(docsTestCollection(), docsTestHistory(), DocFormatMarkdown)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"# Users API",
+		"- [List users](#list-users)",
+		"## List users",
+		"`GET https://api.example.com/users`",
+		"Returns every user.",
+		"| Authorization | Bearer {{token}} |",
+		"### Example Response (200 OK)",
+		"### Request Body",
+	}
+	for _, want := range expected {
+		if !strings.Contains(docs, want) {
+			t.Errorf("Expected markdown to contain %q, got:\n%s", want, docs)
+		}
+	}
+}
+
+func TestGenerateHTMLDocs(t *testing.T) {
+	docs, err := GenerateDocs(docsTestCollection(), docsTestHistory(), DocFormatHTML)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.Contains(docs, "<h1>Users API</h1>") {
+		t.Error("Expected collection title")
+	}
+	if strings.Contains(docs, "<b>ann</b>") {
+		t.Error("Expected request body to be HTML escaped")
+	}
+	if !strings.Contains(docs, "Example Response (200 OK)") {
+		t.Error("Expected recorded response section")
+	}
+}
+
+func TestGenerateDocsUnsupportedFormat(t *testing.T) {
+	if _, err := GenerateDocs(docsTestCollection(), nil, DocFormat("pdf")); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
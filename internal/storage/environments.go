@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	"github.com/abneribeiro/godev/internal/filelock"
 )
 
 // variableRegex is compiled once for better performance
@@ -20,6 +22,14 @@ type Variable struct {
 type Environment struct {
 	Name      string     `json:"name"`
 	Variables []Variable `json:"variables"`
+	// Aliases expand service names typed at the start of a URL
+	Aliases []ServiceAlias `json:"aliases,omitempty"`
+	// Proxy replaces the global proxy while the environment is active
+	Proxy string `json:"proxy,omitempty"`
+	// ClientCert and ClientKey are the PEM files of the client certificate
+	// presented to servers asking for mutual TLS
+	ClientCert string `json:"client_cert,omitempty"`
+	ClientKey  string `json:"client_key,omitempty"`
 }
 
 type EnvironmentConfig struct {
@@ -33,15 +43,33 @@ const (
 	envConfigVersion = "0.4.0"
 )
 
+// LoadEnvironments reads the environments from disk
 func (s *Storage) LoadEnvironments() (*EnvironmentConfig, error) {
-	homeDir, err := os.UserHomeDir()
+	config, _, err := s.loadEnvironments()
+	return config, err
+}
+
+// ReloadEnvironments reads the environments like LoadEnvironments and marks
+// what it read as seen, so that EnvironmentsChanged only reports later
+// changes. It is meant for the copy of the environments shown on screen.
+func (s *Storage) ReloadEnvironments() (*EnvironmentConfig, error) {
+	config, stamp, err := s.loadEnvironments()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get home directory: %w", err)
+		return nil, err
 	}
+	s.envStamp = stamp
+	return config, nil
+}
 
-	envPath := filepath.Join(homeDir, ".godev", envConfigFile)
+func (s *Storage) loadEnvironments() (*EnvironmentConfig, filelock.Stamp, error) {
+	configDir, err := s.Dir()
+	if err != nil {
+		return nil, filelock.Stamp{}, err
+	}
 
-	data, err := os.ReadFile(envPath)
+	envPath := filepath.Join(configDir, envConfigFile)
+
+	data, stamp, err := filelock.ReadFile(envPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			defaultConfig := &EnvironmentConfig{
@@ -50,28 +78,38 @@ func (s *Storage) LoadEnvironments() (*EnvironmentConfig, error) {
 				ActiveEnvironment: "",
 			}
 			if err := s.SaveEnvironments(defaultConfig); err != nil {
-				return nil, err
+				return nil, filelock.Stamp{}, err
 			}
-			return defaultConfig, nil
+			return defaultConfig, filelock.StampOf(envPath), nil
 		}
-		return nil, fmt.Errorf("failed to read environment config: %w", err)
+		return nil, filelock.Stamp{}, fmt.Errorf("failed to read environment config: %w", err)
 	}
 
 	var config EnvironmentConfig
 	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse environment config: %w", err)
+		return nil, filelock.Stamp{}, fmt.Errorf("failed to parse environment config: %w", err)
 	}
 
-	return &config, nil
+	return &config, stamp, nil
+}
+
+// EnvironmentsChanged reports whether environments.json was changed since
+// it was last read with ReloadEnvironments
+func (s *Storage) EnvironmentsChanged() bool {
+	configDir, err := s.Dir()
+	if err != nil {
+		return false
+	}
+	stamp := filelock.StampOf(filepath.Join(configDir, envConfigFile))
+	return stamp != (filelock.Stamp{}) && stamp != s.envStamp
 }
 
 func (s *Storage) SaveEnvironments(config *EnvironmentConfig) error {
-	homeDir, err := os.UserHomeDir()
+	configDir, err := s.Dir()
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
 
-	configDir := filepath.Join(homeDir, ".godev")
 	// Use secure directory permissions (0700 - only owner can access)
 	if err := os.MkdirAll(configDir, 0o700); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
@@ -86,134 +124,175 @@ func (s *Storage) SaveEnvironments(config *EnvironmentConfig) error {
 
 	// Use secure file permissions (0600 - only owner can read/write)
 	// This is critical as the file contains API keys and sensitive environment variables
-	if err := os.WriteFile(envPath, data, 0o600); err != nil {
+	if err := filelock.WriteFile(envPath, data, 0o600); err != nil {
 		return fmt.Errorf("failed to write environment config: %w", err)
 	}
 
 	return nil
 }
 
-func (s *Storage) AddEnvironment(name string) error {
-	config, err := s.LoadEnvironments()
+// editEnvironments applies fn to the environments and saves them when fn
+// succeeds. The store is locked and read again first, so that a change made
+// by another godev instance meanwhile is kept.
+func (s *Storage) editEnvironments(fn func(config *EnvironmentConfig) error) error {
+	configDir, err := s.Dir()
 	if err != nil {
 		return err
 	}
-
-	for _, env := range config.Environments {
-		if env.Name == name {
-			return fmt.Errorf("environment already exists: %s", name)
-		}
-	}
-
-	newEnv := Environment{
-		Name:      name,
-		Variables: []Variable{},
+	if err := os.MkdirAll(configDir, 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	config.Environments = append(config.Environments, newEnv)
-
-	if config.ActiveEnvironment == "" {
-		config.ActiveEnvironment = name
+	lock, err := filelock.Acquire(filepath.Join(configDir, envConfigFile))
+	if err != nil {
+		return err
 	}
+	defer lock.Unlock()
 
-	return s.SaveEnvironments(config)
-}
-
-func (s *Storage) DeleteEnvironment(name string) error {
 	config, err := s.LoadEnvironments()
 	if err != nil {
 		return err
 	}
+	if err := fn(config); err != nil {
+		return err
+	}
+	return s.SaveEnvironments(config)
+}
 
-	for i, env := range config.Environments {
-		if env.Name == name {
-			config.Environments = append(config.Environments[:i], config.Environments[i+1:]...)
-
-			if config.ActiveEnvironment == name {
-				if len(config.Environments) > 0 {
-					config.ActiveEnvironment = config.Environments[0].Name
-				} else {
-					config.ActiveEnvironment = ""
-				}
+func (s *Storage) AddEnvironment(name string) error {
+	return s.editEnvironments(func(config *EnvironmentConfig) error {
+		for _, env := range config.Environments {
+			if env.Name == name {
+				return fmt.Errorf("environment already exists: %s", name)
 			}
+		}
 
-			return s.SaveEnvironments(config)
+		newEnv := Environment{
+			Name:      name,
+			Variables: []Variable{},
 		}
-	}
 
-	return fmt.Errorf("environment not found: %s", name)
-}
+		config.Environments = append(config.Environments, newEnv)
 
-func (s *Storage) SetActiveEnvironment(name string) error {
-	config, err := s.LoadEnvironments()
-	if err != nil {
-		return err
-	}
+		if config.ActiveEnvironment == "" {
+			config.ActiveEnvironment = name
+		}
+		return nil
+	})
+}
 
-	found := false
-	for _, env := range config.Environments {
-		if env.Name == name {
-			found = true
-			break
+// DeleteEnvironment moves an environment to the trash
+func (s *Storage) DeleteEnvironment(name string) error {
+	return s.editEnvironments(func(config *EnvironmentConfig) error {
+		for i, env := range config.Environments {
+			if env.Name == name {
+				if err := s.MoveToTrash(TrashKindEnvironment, env.Name, env); err != nil {
+					return err
+				}
+				config.Environments = append(config.Environments[:i], config.Environments[i+1:]...)
+
+				if config.ActiveEnvironment == name {
+					if len(config.Environments) > 0 {
+						config.ActiveEnvironment = config.Environments[0].Name
+					} else {
+						config.ActiveEnvironment = ""
+					}
+				}
+				return nil
+			}
 		}
-	}
 
-	if !found {
 		return fmt.Errorf("environment not found: %s", name)
-	}
+	})
+}
 
-	config.ActiveEnvironment = name
-	return s.SaveEnvironments(config)
+func (s *Storage) SetActiveEnvironment(name string) error {
+	return s.editEnvironments(func(config *EnvironmentConfig) error {
+		if config.Find(name) == nil {
+			return fmt.Errorf("environment not found: %s", name)
+		}
+		config.ActiveEnvironment = name
+		return nil
+	})
 }
 
 func (s *Storage) AddVariable(envName, key, value string) error {
-	config, err := s.LoadEnvironments()
-	if err != nil {
-		return err
-	}
+	return s.editEnvironments(func(config *EnvironmentConfig) error {
+		env := config.Find(envName)
+		if env == nil {
+			return fmt.Errorf("environment not found: %s", envName)
+		}
 
-	for i, env := range config.Environments {
-		if env.Name == envName {
-			for j, v := range env.Variables {
-				if v.Key == key {
-					config.Environments[i].Variables[j].Value = value
-					return s.SaveEnvironments(config)
-				}
+		for j, v := range env.Variables {
+			if v.Key == key {
+				env.Variables[j].Value = value
+				return nil
 			}
-
-			config.Environments[i].Variables = append(config.Environments[i].Variables, Variable{
-				Key:   key,
-				Value: value,
-			})
-			return s.SaveEnvironments(config)
 		}
-	}
 
-	return fmt.Errorf("environment not found: %s", envName)
+		env.Variables = append(env.Variables, Variable{
+			Key:   key,
+			Value: value,
+		})
+		return nil
+	})
 }
 
 func (s *Storage) DeleteVariable(envName, key string) error {
-	config, err := s.LoadEnvironments()
-	if err != nil {
-		return err
-	}
+	return s.editEnvironments(func(config *EnvironmentConfig) error {
+		env := config.Find(envName)
+		if env == nil {
+			return fmt.Errorf("environment not found: %s", envName)
+		}
 
-	for i, env := range config.Environments {
-		if env.Name == envName {
-			for j, v := range env.Variables {
-				if v.Key == key {
-					config.Environments[i].Variables = append(
-						config.Environments[i].Variables[:j],
-						config.Environments[i].Variables[j+1:]...,
-					)
-					return s.SaveEnvironments(config)
-				}
+		for j, v := range env.Variables {
+			if v.Key == key {
+				env.Variables = append(env.Variables[:j], env.Variables[j+1:]...)
+				return nil
 			}
-			return fmt.Errorf("variable not found: %s", key)
 		}
-	}
+		return fmt.Errorf("variable not found: %s", key)
+	})
+}
 
-	return fmt.Errorf("environment not found: %s", envName)
+// SetVariables sets several variables of an environment at once, adding
+// those it does not have yet
+func (s *Storage) SetVariables(envName string, vars []Variable) error {
+	return s.editEnvironments(func(config *EnvironmentConfig) error {
+		env := config.Find(envName)
+		if env == nil {
+			return fmt.Errorf("environment not found: %s", envName)
+		}
+		env.Variables = MergeVariables(env.Variables, vars)
+		return nil
+	})
+}
+
+// SetEnvironmentProxy sets the proxy used while an environment is active;
+// an empty proxy falls back to the global one
+func (s *Storage) SetEnvironmentProxy(envName, proxy string) error {
+	return s.editEnvironments(func(config *EnvironmentConfig) error {
+		env := config.Find(envName)
+		if env == nil {
+			return fmt.Errorf("environment not found: %s", envName)
+		}
+		env.Proxy = strings.TrimSpace(proxy)
+		return nil
+	})
+}
+
+// SetEnvironmentClientCert sets the client certificate and key files sent
+// for mutual TLS while an environment is active; empty paths send none
+func (s *Storage) SetEnvironmentClientCert(envName, certFile, keyFile string) error {
+	return s.editEnvironments(func(config *EnvironmentConfig) error {
+		env := config.Find(envName)
+		if env == nil {
+			return fmt.Errorf("environment not found: %s", envName)
+		}
+		env.ClientCert = strings.TrimSpace(certFile)
+		env.ClientKey = strings.TrimSpace(keyFile)
+		return nil
+	})
 }
 
 // ReplaceVariables replaces {{VARIABLE}} placeholders with their values
@@ -242,6 +321,26 @@ func ReplaceVariables(text string, variables []Variable) string {
 	return result
 }
 
+// UnresolvedVariables returns the names of the {{VARIABLE}} placeholders
+// left in text, once ReplaceVariables found no value for them
+func UnresolvedVariables(text string) []string {
+	var names []string
+	for _, match := range variableRegex.FindAllStringSubmatch(text, -1) {
+		names = append(names, strings.TrimSpace(match[1]))
+	}
+	return names
+}
+
+// ActiveEnvironmentName returns the name of the active environment, or ""
+// when none is active or environments cannot be read
+func (s *Storage) ActiveEnvironmentName() string {
+	config, err := s.LoadEnvironments()
+	if err != nil {
+		return ""
+	}
+	return config.ActiveEnvironment
+}
+
 func (s *Storage) GetActiveEnvironmentVariables() ([]Variable, error) {
 	config, err := s.LoadEnvironments()
 	if err != nil {
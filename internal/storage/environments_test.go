@@ -81,6 +81,17 @@ func TestReplaceVariables(t *testing.T) {
 	}
 }
 
+func TestUnresolvedVariables(t *testing.T) {
+	text := ReplaceVariables("Bearer {{TOKEN}} for {{ USER }} at {{HOST}}", []Variable{{Key: "HOST", Value: "api"}})
+	got := UnresolvedVariables(text)
+	if len(got) != 2 || got[0] != "TOKEN" || got[1] != "USER" {
+		t.Errorf("UnresolvedVariables() = %v, want [TOKEN USER]", got)
+	}
+	if got := UnresolvedVariables("no placeholders"); got != nil {
+		t.Errorf("UnresolvedVariables() = %v, want none", got)
+	}
+}
+
 func TestReplaceVariablesPerformance(t *testing.T) {
 	// Create many variables to test map lookup performance
 	variables := make([]Variable, 100)
@@ -356,3 +367,82 @@ func TestStorageGetActiveEnvironmentVariables(t *testing.T) {
 		t.Errorf("Expected 2 variables, got %d", len(vars))
 	}
 }
+
+func TestStorageSetEnvironmentProxy(t *testing.T) {
+	s, err := NewStorageAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	if err := s.AddEnvironment("staging"); err != nil {
+		t.Fatalf("AddEnvironment() error = %v", err)
+	}
+
+	if err := s.SetEnvironmentProxy("staging", " socks5://bastion:1080 "); err != nil {
+		t.Fatalf("SetEnvironmentProxy() error = %v", err)
+	}
+	config, err := s.LoadEnvironments()
+	if err != nil {
+		t.Fatalf("LoadEnvironments() error = %v", err)
+	}
+	if got := config.Find("staging").Proxy; got != "socks5://bastion:1080" {
+		t.Errorf("Proxy = %q, want the trimmed proxy", got)
+	}
+
+	if err := s.SetEnvironmentProxy("missing", "http://proxy:3128"); err == nil {
+		t.Error("Expected error when setting the proxy of a non-existent environment")
+	}
+}
+
+func TestStorageSetEnvironmentClientCert(t *testing.T) {
+	s, err := NewStorageAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	if err := s.AddEnvironment("prod"); err != nil {
+		t.Fatalf("AddEnvironment() error = %v", err)
+	}
+
+	if err := s.SetEnvironmentClientCert("prod", " certs/client.pem", "certs/client-key.pem "); err != nil {
+		t.Fatalf("SetEnvironmentClientCert() error = %v", err)
+	}
+	config, err := s.LoadEnvironments()
+	if err != nil {
+		t.Fatalf("LoadEnvironments() error = %v", err)
+	}
+	if env := config.Find("prod"); env.ClientCert != "certs/client.pem" || env.ClientKey != "certs/client-key.pem" {
+		t.Errorf("ClientCert, ClientKey = %q, %q, want the trimmed paths", env.ClientCert, env.ClientKey)
+	}
+
+	if err := s.SetEnvironmentClientCert("missing", "client.pem", "client-key.pem"); err == nil {
+		t.Error("Expected error when setting the certificate of a non-existent environment")
+	}
+}
+
+func TestStorageSetVariables(t *testing.T) {
+	s, err := NewStorageAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	if err := s.AddEnvironment("dev"); err != nil {
+		t.Fatalf("AddEnvironment() error = %v", err)
+	}
+	if err := s.AddVariable("dev", "TOKEN", "old"); err != nil {
+		t.Fatalf("AddVariable() error = %v", err)
+	}
+
+	if err := s.SetVariables("dev", []Variable{{Key: "TOKEN", Value: "new"}, {Key: "USER_ID", Value: "42"}}); err != nil {
+		t.Fatalf("SetVariables() error = %v", err)
+	}
+	config, err := s.LoadEnvironments()
+	if err != nil {
+		t.Fatalf("LoadEnvironments() error = %v", err)
+	}
+	vars := config.Find("dev").Variables
+	if len(vars) != 2 || vars[0].Value != "new" || vars[1] != (Variable{Key: "USER_ID", Value: "42"}) {
+		t.Errorf("Variables = %+v, want TOKEN replaced and USER_ID added", vars)
+	}
+
+	if err := s.SetVariables("missing", vars); err == nil {
+		t.Error("Expected error when setting variables of a non-existent environment")
+	}
+}
@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Extraction sources
+const (
+	ExtractJSON   = "json"
+	ExtractHeader = "header"
+	ExtractRegex  = "regex"
+)
+
+// ExtractionSources lists the sources in the order the editor offers them
+var ExtractionSources = []string{ExtractJSON, ExtractHeader, ExtractRegex}
+
+// variableNamePattern is what an extraction may name, so the value can be
+// used as {{NAME}}
+var variableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// NewVariableExtract builds the extraction of a variable from one source
+func NewVariableExtract(name, source, expression string) VariableExtract {
+	e := VariableExtract{Name: strings.TrimSpace(name)}
+	expression = strings.TrimSpace(expression)
+	switch source {
+	case ExtractHeader:
+		e.Header = expression
+	case ExtractRegex:
+		e.Regex = expression
+	default:
+		e.JSONPath = expression
+	}
+	return e
+}
+
+// Source returns where the value is read from, one of ExtractionSources
+func (e VariableExtract) Source() string {
+	switch {
+	case e.Header != "":
+		return ExtractHeader
+	case e.Regex != "":
+		return ExtractRegex
+	default:
+		return ExtractJSON
+	}
+}
+
+// Expression returns the JSON path, header name or regex of the extraction
+func (e VariableExtract) Expression() string {
+	switch e.Source() {
+	case ExtractHeader:
+		return e.Header
+	case ExtractRegex:
+		return e.Regex
+	default:
+		return e.JSONPath
+	}
+}
+
+// Validate checks that the extraction names a usable variable and reads it
+// from exactly one place
+func (e VariableExtract) Validate() error {
+	if !variableNamePattern.MatchString(e.Name) {
+		return fmt.Errorf("variable name %q must start with a letter or _ and use letters, digits, _, . or -", e.Name)
+	}
+
+	sources := 0
+	for _, value := range []string{e.JSONPath, e.Regex, e.Header} {
+		if value != "" {
+			sources++
+		}
+	}
+	switch sources {
+	case 0:
+		return fmt.Errorf("%s needs a JSON path, header name or regex", e.Name)
+	case 1:
+	default:
+		return fmt.Errorf("%s must be read from only one of JSON path, header or regex", e.Name)
+	}
+
+	if e.Regex != "" {
+		re, err := regexp.Compile(e.Regex)
+		if err != nil {
+			return fmt.Errorf("invalid regex pattern %q: %w", e.Regex, err)
+		}
+		if re.NumSubexp() == 0 {
+			return fmt.Errorf("regex %q needs a capture group for the value", e.Regex)
+		}
+	}
+	return nil
+}
+
+// String describes the extraction in one line, e.g. `TOKEN from json $.token`
+func (e VariableExtract) String() string {
+	expression := e.Expression()
+	if e.Source() == ExtractRegex {
+		expression = fmt.Sprintf("%q", expression)
+	}
+	return fmt.Sprintf("%s from %s %s", e.Name, e.Source(), expression)
+}
+
+// ExtractionResult is the outcome of one extraction from a response
+type ExtractionResult struct {
+	Extract VariableExtract
+	Value   string
+	Err     error
+}
+
+// Passed reports whether the value was found
+func (r ExtractionResult) Passed() bool {
+	return r.Err == nil
+}
+
+// RunExtractions reads every extraction from a response
+func RunExtractions(extractions []VariableExtract, responseBody string, responseHeaders map[string][]string) []ExtractionResult {
+	if len(extractions) == 0 {
+		return nil
+	}
+
+	headers := make(map[string]string, len(responseHeaders))
+	for name, values := range responseHeaders {
+		headers[name] = strings.Join(values, ", ")
+	}
+
+	results := make([]ExtractionResult, len(extractions))
+	for i, e := range extractions {
+		results[i] = ExtractionResult{Extract: e}
+		if e.Header != "" {
+			value, ok := lookupHeader(headers, e.Header)
+			if !ok {
+				results[i].Err = fmt.Errorf("header '%s' not found", e.Header)
+			}
+			results[i].Value = value
+			continue
+		}
+		results[i].Value, results[i].Err = ExtractVariable(responseBody, e)
+	}
+	return results
+}
+
+// ExtractedVariables returns the variables of the extractions that passed
+func ExtractedVariables(results []ExtractionResult) []Variable {
+	var vars []Variable
+	for _, r := range results {
+		if r.Passed() {
+			vars = append(vars, Variable{Key: r.Extract.Name, Value: r.Value})
+		}
+	}
+	return vars
+}
+
+// MergeVariables returns vars with the values of overrides, which replace
+// variables of the same name and are added otherwise
+func MergeVariables(vars, overrides []Variable) []Variable {
+	merged := append([]Variable(nil), vars...)
+next:
+	for _, o := range overrides {
+		for i := range merged {
+			if merged[i].Key == o.Key {
+				merged[i].Value = o.Value
+				continue next
+			}
+		}
+		merged = append(merged, o)
+	}
+	return merged
+}
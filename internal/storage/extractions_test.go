@@ -0,0 +1,95 @@
+package storage
+
+import "testing"
+
+func TestRunExtractions(t *testing.T) {
+	body := `{"token": "abc123", "user": {"id": 1700000000123}, "items": [{"id": 7}]}`
+	headers := map[string][]string{"Location": {"/users/42"}}
+	extractions := []VariableExtract{
+		NewVariableExtract("TOKEN", ExtractJSON, "$.token"),
+		NewVariableExtract("USER_ID", ExtractJSON, "user.id"),
+		NewVariableExtract("FIRST", ExtractJSON, "$.items[0].id"),
+		NewVariableExtract("LOCATION", ExtractHeader, "location"),
+		NewVariableExtract("PATH_ID", ExtractRegex, `"id": (\d+)}]`),
+		NewVariableExtract("MISSING", ExtractJSON, "$.refresh_token"),
+	}
+
+	results := RunExtractions(extractions, body, headers)
+	want := []string{"abc123", "1700000000123", "7", "/users/42", "7"}
+	for i, value := range want {
+		if !results[i].Passed() || results[i].Value != value {
+			t.Errorf("%s = %q, %v, want %q", extractions[i].Name, results[i].Value, results[i].Err, value)
+		}
+	}
+	if results[5].Passed() {
+		t.Errorf("Expected a missing key to fail, got %q", results[5].Value)
+	}
+
+	vars := ExtractedVariables(results)
+	if len(vars) != 5 || vars[0] != (Variable{Key: "TOKEN", Value: "abc123"}) {
+		t.Errorf("ExtractedVariables() = %+v, want the 5 values found", vars)
+	}
+	if RunExtractions(nil, body, headers) != nil {
+		t.Error("Expected no results without extractions")
+	}
+}
+
+func TestVariableExtractValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		extract VariableExtract
+		wantErr bool
+	}{
+		{"json path", NewVariableExtract("TOKEN", ExtractJSON, "$.token"), false},
+		{"header", NewVariableExtract("request_id", ExtractHeader, "X-Request-Id"), false},
+		{"regex", NewVariableExtract("ID", ExtractRegex, `id=(\d+)`), false},
+		{"no name", NewVariableExtract("", ExtractJSON, "token"), true},
+		{"name with braces", NewVariableExtract("{{TOKEN}}", ExtractJSON, "token"), true},
+		{"name with spaces", NewVariableExtract("MY TOKEN", ExtractJSON, "token"), true},
+		{"no expression", NewVariableExtract("TOKEN", ExtractJSON, " "), true},
+		{"two sources", VariableExtract{Name: "TOKEN", JSONPath: "token", Header: "X-Token"}, true},
+		{"regex without group", NewVariableExtract("ID", ExtractRegex, `id=\d+`), true},
+		{"invalid regex", NewVariableExtract("ID", ExtractRegex, `id=(\d+`), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.extract.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVariableExtractString(t *testing.T) {
+	tests := []struct {
+		extract VariableExtract
+		want    string
+	}{
+		{NewVariableExtract("TOKEN", ExtractJSON, "$.token"), "TOKEN from json $.token"},
+		{NewVariableExtract("LOCATION", ExtractHeader, "Location"), "LOCATION from header Location"},
+		{NewVariableExtract("ID", ExtractRegex, `id=(\d+)`), `ID from regex "id=(\\d+)"`},
+	}
+	for _, tt := range tests {
+		if got := tt.extract.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestMergeVariables(t *testing.T) {
+	vars := []Variable{{Key: "HOST", Value: "api"}, {Key: "TOKEN", Value: "old"}}
+	merged := MergeVariables(vars, []Variable{{Key: "TOKEN", Value: "new"}, {Key: "USER", Value: "42"}})
+
+	want := []Variable{{Key: "HOST", Value: "api"}, {Key: "TOKEN", Value: "new"}, {Key: "USER", Value: "42"}}
+	if len(merged) != len(want) {
+		t.Fatalf("MergeVariables() = %+v, want %+v", merged, want)
+	}
+	for i := range want {
+		if merged[i] != want[i] {
+			t.Errorf("MergeVariables()[%d] = %+v, want %+v", i, merged[i], want[i])
+		}
+	}
+	if vars[1].Value != "old" {
+		t.Error("Expected MergeVariables to leave its input unchanged")
+	}
+}
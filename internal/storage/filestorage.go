@@ -9,13 +9,13 @@ import (
 	"path/filepath"
 
 	"github.com/abneribeiro/godev/internal/errors"
+	"github.com/abneribeiro/godev/internal/paths"
 )
 
 const (
-	configDirName = ".godev"
-	fileMode      = 0o600
-	dirMode       = 0o700
-	appVersion    = "0.4.0"
+	fileMode   = 0o600
+	dirMode    = 0o700
+	appVersion = "0.4.0"
 )
 
 // FileStorage provides a unified interface for file-based storage operations
@@ -25,12 +25,11 @@ type FileStorage struct {
 
 // NewFileStorage creates a new file storage instance
 func NewFileStorage() (*FileStorage, error) {
-	homeDir, err := os.UserHomeDir()
+	baseDir, err := paths.DataDir()
 	if err != nil {
-		return nil, errors.NewStorageError("failed to get home directory", err)
+		return nil, errors.NewStorageError("failed to resolve data directory", err)
 	}
 
-	baseDir := filepath.Join(homeDir, configDirName)
 	fs := &FileStorage{baseDir: baseDir}
 
 	// Ensure base directory exists
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GoldenResponse is a response pinned as the expected baseline of a saved
+// request; later responses are diffed against it
+type GoldenResponse struct {
+	StatusCode int                 `json:"status_code"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+	Body       string              `json:"body"`
+	PinnedAt   time.Time           `json:"pinned_at"`
+}
+
+// PinGoldenResponse stores the response as the golden baseline of a saved request
+func (s *Storage) PinGoldenResponse(id string, statusCode int, headers map[string][]string, body string) error {
+	golden := &GoldenResponse{
+		StatusCode: statusCode,
+		Headers:    headers,
+		Body:       body,
+		PinnedAt:   time.Now(),
+	}
+	return s.editRequest(id, func(req *SavedRequest) {
+		req.Golden = golden
+	})
+}
+
+// ClearGoldenResponse removes the golden baseline of a saved request
+func (s *Storage) ClearGoldenResponse(id string) error {
+	return s.editRequest(id, func(req *SavedRequest) {
+		req.Golden = nil
+	})
+}
+
+// UpdateVolatileFields sets the fields left out of golden comparisons
+func (s *Storage) UpdateVolatileFields(id string, fields []string) error {
+	return s.editRequest(id, func(req *SavedRequest) {
+		req.VolatileFields = fields
+	})
+}
+
+// ParseVolatileFields splits a comma-separated list of fields, dropping
+// blanks and duplicates
+func ParseVolatileFields(value string) ([]string, error) {
+	var fields []string
+	seen := make(map[string]bool)
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || seen[field] {
+			continue
+		}
+		if strings.ContainsAny(field, " \t") {
+			return nil, fmt.Errorf("invalid volatile field %q: fields cannot contain spaces", field)
+		}
+		seen[field] = true
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
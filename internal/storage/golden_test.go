@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPinGoldenResponse(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := NewStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	if err := s.SaveRequest("get user", "GET", "https://api.example.com/users/1", nil, "", nil); err != nil {
+		t.Fatalf("SaveRequest() error = %v", err)
+	}
+	id := s.GetRequests()[0].ID
+
+	headers := map[string][]string{"Content-Type": {"application/json"}}
+	if err := s.PinGoldenResponse(id, 200, headers, `{"id": 1}`); err != nil {
+		t.Fatalf("PinGoldenResponse() error = %v", err)
+	}
+	if err := s.UpdateVolatileFields(id, []string{"updated_at"}); err != nil {
+		t.Fatalf("UpdateVolatileFields() error = %v", err)
+	}
+
+	reopened, err := NewStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	req, err := reopened.GetRequest(id)
+	if err != nil {
+		t.Fatalf("GetRequest() error = %v", err)
+	}
+	if req.Golden == nil || req.Golden.StatusCode != 200 || req.Golden.Body != `{"id": 1}` || req.Golden.PinnedAt.IsZero() {
+		t.Errorf("Expected golden response to be stored, got %+v", req.Golden)
+	}
+	if !reflect.DeepEqual(req.VolatileFields, []string{"updated_at"}) {
+		t.Errorf("VolatileFields = %v", req.VolatileFields)
+	}
+
+	if err := reopened.ClearGoldenResponse(id); err != nil {
+		t.Fatalf("ClearGoldenResponse() error = %v", err)
+	}
+	if req, _ := reopened.GetRequest(id); req.Golden != nil {
+		t.Errorf("Expected golden response to be cleared, got %+v", req.Golden)
+	}
+}
+
+func TestParseVolatileFields(t *testing.T) {
+	fields, err := ParseVolatileFields(" updated_at, data.id ,, updated_at")
+	if err != nil {
+		t.Fatalf("ParseVolatileFields() error = %v", err)
+	}
+	if !reflect.DeepEqual(fields, []string{"updated_at", "data.id"}) {
+		t.Errorf("ParseVolatileFields() = %v", fields)
+	}
+
+	if _, err := ParseVolatileFields("created at"); err == nil {
+		t.Error("Expected an error for a field with a space")
+	}
+}
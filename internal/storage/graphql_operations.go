@@ -0,0 +1,305 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/abneribeiro/godev/internal/filelock"
+)
+
+// GraphQLOperation is a saved GraphQL query or mutation, kept apart from
+// generic saved requests
+type GraphQLOperation struct {
+	ID            string            `json:"id"`
+	OperationName string            `json:"operation_name"`
+	Endpoint      string            `json:"endpoint"`
+	Query         string            `json:"query"`
+	Variables     string            `json:"variables,omitempty"`
+	Headers       map[string]string `json:"headers,omitempty"`
+	// VariableHistory holds the variable sets used with the operation, newest first
+	VariableHistory []VariableSet `json:"variable_history,omitempty"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+	LastUsed        time.Time     `json:"last_used,omitempty"`
+}
+
+// VariableSet is a JSON object of variables sent with an operation
+type VariableSet struct {
+	Variables string    `json:"variables"`
+	UsedAt    time.Time `json:"used_at"`
+}
+
+// GraphQLOperationConfig holds all saved GraphQL operations
+type GraphQLOperationConfig struct {
+	Version    string             `json:"version"`
+	Operations []GraphQLOperation `json:"operations"`
+}
+
+const (
+	graphqlOperationsFile = "graphql_operations.json"
+	maxVariableHistory    = 20
+)
+
+var operationNamePattern = regexp.MustCompile(`(?m)^\s*(query|mutation|subscription)\s+([_A-Za-z][_0-9A-Za-z]*)`)
+
+// GraphQLOperationName extracts the name of the first named operation in a query
+func GraphQLOperationName(query string) string {
+	if match := operationNamePattern.FindStringSubmatch(query); match != nil {
+		return match[2]
+	}
+	return ""
+}
+
+// ParseGraphQLBody reads the query, variables and operation name from a JSON
+// GraphQL request body. Bodies that are not JSON are taken as a raw query.
+func ParseGraphQLBody(body string) (query, variables, operationName string, err error) {
+	trimmed := strings.TrimSpace(body)
+	if trimmed == "" {
+		return "", "", "", fmt.Errorf("request body is empty")
+	}
+
+	var payload struct {
+		Query         string          `json:"query"`
+		Variables     json.RawMessage `json:"variables"`
+		OperationName string          `json:"operationName"`
+	}
+	if jsonErr := json.Unmarshal([]byte(trimmed), &payload); jsonErr != nil {
+		return trimmed, "", GraphQLOperationName(trimmed), nil
+	}
+	if payload.Query == "" {
+		return "", "", "", fmt.Errorf("request body has no GraphQL query")
+	}
+
+	if len(payload.Variables) > 0 && string(payload.Variables) != "null" {
+		variables = string(payload.Variables)
+	}
+
+	operationName = payload.OperationName
+	if operationName == "" {
+		operationName = GraphQLOperationName(payload.Query)
+	}
+
+	return payload.Query, variables, operationName, nil
+}
+
+// BuildGraphQLBody creates a JSON request body for an operation with the given variables
+func BuildGraphQLBody(op GraphQLOperation, variables string) (string, error) {
+	payload := map[string]interface{}{"query": op.Query}
+	if op.OperationName != "" && GraphQLOperationName(op.Query) == op.OperationName {
+		payload["operationName"] = op.OperationName
+	}
+
+	if strings.TrimSpace(variables) != "" {
+		var vars interface{}
+		if err := json.Unmarshal([]byte(variables), &vars); err != nil {
+			return "", fmt.Errorf("invalid variables: %w", err)
+		}
+		payload["variables"] = vars
+	}
+
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal GraphQL body: %w", err)
+	}
+	return string(data), nil
+}
+
+// LoadGraphQLOperations loads all saved GraphQL operations from disk
+func (s *Storage) LoadGraphQLOperations() (*GraphQLOperationConfig, error) {
+	dir, err := s.Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, graphqlOperationsFile)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return &GraphQLOperationConfig{
+			Version:    version,
+			Operations: []GraphQLOperation{},
+		}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GraphQL operations file: %w", err)
+	}
+
+	var config GraphQLOperationConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse GraphQL operations file: %w", err)
+	}
+
+	return &config, nil
+}
+
+// SaveGraphQLOperations writes all GraphQL operations to disk
+func (s *Storage) SaveGraphQLOperations(config *GraphQLOperationConfig) error {
+	dir, err := s.Dir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal GraphQL operations: %w", err)
+	}
+
+	if err := filelock.WriteFile(filepath.Join(dir, graphqlOperationsFile), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write GraphQL operations file: %w", err)
+	}
+
+	return nil
+}
+
+// GetGraphQLOperations returns the saved operations sorted by operation name
+func (s *Storage) GetGraphQLOperations() ([]GraphQLOperation, error) {
+	config, err := s.LoadGraphQLOperations()
+	if err != nil {
+		return nil, err
+	}
+
+	ops := config.Operations
+	sort.SliceStable(ops, func(i, j int) bool {
+		return strings.ToLower(ops[i].OperationName) < strings.ToLower(ops[j].OperationName)
+	})
+	return ops, nil
+}
+
+// SaveGraphQLOperation stores an operation. An operation with the same name
+// and endpoint is replaced, keeping its ID and variable history.
+func (s *Storage) SaveGraphQLOperation(op GraphQLOperation) (GraphQLOperation, error) {
+	if strings.TrimSpace(op.Query) == "" {
+		return op, fmt.Errorf("GraphQL operation has no query")
+	}
+	if op.OperationName == "" {
+		op.OperationName = GraphQLOperationName(op.Query)
+	}
+	if op.OperationName == "" {
+		op.OperationName = "anonymous"
+	}
+
+	config, err := s.LoadGraphQLOperations()
+	if err != nil {
+		return op, err
+	}
+
+	now := time.Now()
+	op.UpdatedAt = now
+
+	replaced := false
+	for i, existing := range config.Operations {
+		if existing.OperationName == op.OperationName && existing.Endpoint == op.Endpoint {
+			op.ID = existing.ID
+			op.CreatedAt = existing.CreatedAt
+			op.VariableHistory = existing.VariableHistory
+			op.LastUsed = existing.LastUsed
+			config.Operations[i] = op
+			replaced = true
+			break
+		}
+	}
+
+	if !replaced {
+		op.ID = uuid.New().String()
+		op.CreatedAt = now
+		config.Operations = append(config.Operations, op)
+	}
+
+	if op.Variables != "" {
+		recordVariableSet(findOperation(config, op.ID), op.Variables, now)
+	}
+
+	if err := s.SaveGraphQLOperations(config); err != nil {
+		return op, err
+	}
+	return *findOperation(config, op.ID), nil
+}
+
+// RecordGraphQLVariables remembers a variable set used with an operation
+func (s *Storage) RecordGraphQLVariables(id, variables string) error {
+	config, err := s.LoadGraphQLOperations()
+	if err != nil {
+		return err
+	}
+
+	op := findOperation(config, id)
+	if op == nil {
+		return fmt.Errorf("GraphQL operation not found")
+	}
+
+	now := time.Now()
+	op.LastUsed = now
+	if strings.TrimSpace(variables) != "" {
+		op.Variables = variables
+		recordVariableSet(op, variables, now)
+	}
+
+	return s.SaveGraphQLOperations(config)
+}
+
+// DeleteGraphQLOperation removes a saved operation
+func (s *Storage) DeleteGraphQLOperation(id string) error {
+	config, err := s.LoadGraphQLOperations()
+	if err != nil {
+		return err
+	}
+
+	for i, op := range config.Operations {
+		if op.ID == id {
+			config.Operations = append(config.Operations[:i], config.Operations[i+1:]...)
+			return s.SaveGraphQLOperations(config)
+		}
+	}
+
+	return fmt.Errorf("GraphQL operation not found")
+}
+
+func findOperation(config *GraphQLOperationConfig, id string) *GraphQLOperation {
+	for i := range config.Operations {
+		if config.Operations[i].ID == id {
+			return &config.Operations[i]
+		}
+	}
+	return nil
+}
+
+// recordVariableSet moves the variable set to the front of the history,
+// comparing sets by their compacted JSON
+func recordVariableSet(op *GraphQLOperation, variables string, usedAt time.Time) {
+	key := compactJSON(variables)
+
+	history := []VariableSet{{Variables: variables, UsedAt: usedAt}}
+	for _, set := range op.VariableHistory {
+		if compactJSON(set.Variables) != key {
+			history = append(history, set)
+		}
+	}
+	if len(history) > maxVariableHistory {
+		history = history[:maxVariableHistory]
+	}
+	op.VariableHistory = history
+}
+
+func compactJSON(s string) string {
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return strings.TrimSpace(s)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return strings.TrimSpace(s)
+	}
+	return string(data)
+}
@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestGraphQLOperationName(t *testing.T) {
+	tests := map[string]string{
+		"query GetUser($id: ID!) { user(id: $id) { name } }": "GetUser",
+		"mutation DeleteUser { deleteUser }":                 "DeleteUser",
+		"# comment\nsubscription OnEvent { event }":          "OnEvent",
+		"{ me { name } }": "",
+		"query { me }":    "",
+	}
+
+	for query, want := range tests {
+		if got := GraphQLOperationName(query); got != want {
+			t.Errorf("GraphQLOperationName(%q) = %q, want %q", query, got, want)
+		}
+	}
+}
+
+func TestParseGraphQLBody(t *testing.T) {
+	query, variables, name, err := ParseGraphQLBody(`{"query": "query GetUser($id: ID!) { user(id: $id) { name } }", "variables": {"id": "1"}}`)
+	if err != nil {
+		t.Fatalf("ParseGraphQLBody() error = %v", err)
+	}
+	if name != "GetUser" || variables != `{"id": "1"}` || query == "" {
+		t.Errorf("Unexpected result: %q %q %q", query, variables, name)
+	}
+
+	query, _, _, err = ParseGraphQLBody("{ me { name } }")
+	if err != nil || query != "{ me { name } }" {
+		t.Errorf("Expected raw query, got %q (%v)", query, err)
+	}
+
+	if _, _, _, err := ParseGraphQLBody(`{"data": 1}`); err == nil {
+		t.Error("Expected error for JSON body without a query")
+	}
+}
+
+func TestBuildGraphQLBody(t *testing.T) {
+	op := GraphQLOperation{OperationName: "GetUser", Query: "query GetUser($id: ID!) { user(id: $id) { name } }"}
+
+	body, err := BuildGraphQLBody(op, `{"id": "2"}`)
+	if err != nil {
+		t.Fatalf("BuildGraphQLBody() error = %v", err)
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &payload); err != nil {
+		t.Fatalf("Expected JSON body, got %s", body)
+	}
+	if payload["operationName"] != "GetUser" || payload["variables"].(map[string]interface{})["id"] != "2" {
+		t.Errorf("Unexpected body: %s", body)
+	}
+
+	if _, err := BuildGraphQLBody(op, "{broken"); err == nil {
+		t.Error("Expected error for invalid variables")
+	}
+}
+
+func TestSaveGraphQLOperation(t *testing.T) {
+	tmpDir := t.TempDir()
+	origHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", origHome)
+	os.Setenv("HOME", tmpDir)
+
+	s := &Storage{}
+
+	saved, err := s.SaveGraphQLOperation(GraphQLOperation{
+		Endpoint:  "https://api.example.com/graphql",
+		Query:     "query GetUser($id: ID!) { user(id: $id) { name } }",
+		Variables: `{"id": "1"}`,
+	})
+	if err != nil {
+		t.Fatalf("SaveGraphQLOperation() error = %v", err)
+	}
+	if saved.ID == "" || saved.OperationName != "GetUser" || len(saved.VariableHistory) != 1 {
+		t.Fatalf("Unexpected saved operation: %+v", saved)
+	}
+
+	// Saving again with the same name and endpoint replaces the operation
+	again, err := s.SaveGraphQLOperation(GraphQLOperation{
+		Endpoint: "https://api.example.com/graphql",
+		Query:    "query GetUser($id: ID!) { user(id: $id) { name email } }",
+	})
+	if err != nil {
+		t.Fatalf("SaveGraphQLOperation() error = %v", err)
+	}
+	if again.ID != saved.ID || len(again.VariableHistory) != 1 {
+		t.Errorf("Expected operation to be replaced keeping history, got %+v", again)
+	}
+
+	ops, err := s.GetGraphQLOperations()
+	if err != nil || len(ops) != 1 {
+		t.Fatalf("Expected 1 operation, got %d (%v)", len(ops), err)
+	}
+}
+
+func TestRecordGraphQLVariables(t *testing.T) {
+	tmpDir := t.TempDir()
+	origHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", origHome)
+	os.Setenv("HOME", tmpDir)
+
+	s := &Storage{}
+
+	op, err := s.SaveGraphQLOperation(GraphQLOperation{Query: "query Items($page: Int) { items(page: $page) }"})
+	if err != nil {
+		t.Fatalf("SaveGraphQLOperation() error = %v", err)
+	}
+
+	for i := 0; i < maxVariableHistory+5; i++ {
+		if err := s.RecordGraphQLVariables(op.ID, fmt.Sprintf(`{"page": %d}`, i)); err != nil {
+			t.Fatalf("RecordGraphQLVariables() error = %v", err)
+		}
+	}
+	// A repeated set moves to the front instead of being duplicated
+	if err := s.RecordGraphQLVariables(op.ID, `{ "page": 10 }`); err != nil {
+		t.Fatalf("RecordGraphQLVariables() error = %v", err)
+	}
+
+	ops, _ := s.GetGraphQLOperations()
+	history := ops[0].VariableHistory
+	if len(history) != maxVariableHistory {
+		t.Fatalf("Expected history capped at %d, got %d", maxVariableHistory, len(history))
+	}
+	if history[0].Variables != `{ "page": 10 }` || history[1].Variables != `{"page": 24}` {
+		t.Errorf("Unexpected history order: %s, %s", history[0].Variables, history[1].Variables)
+	}
+
+	if err := s.DeleteGraphQLOperation(op.ID); err != nil {
+		t.Fatalf("DeleteGraphQLOperation() error = %v", err)
+	}
+	if ops, _ := s.GetGraphQLOperations(); len(ops) != 0 {
+		t.Errorf("Expected no operations after delete, got %d", len(ops))
+	}
+}
@@ -0,0 +1,279 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/abneribeiro/godev/internal/filelock"
+)
+
+// SyncPolicy controls whether batched history writes are fsynced
+type SyncPolicy string
+
+const (
+	// SyncAlways fsyncs every batch before it is considered written
+	SyncAlways SyncPolicy = "always"
+	// SyncNever leaves flushing to the operating system. A crash can lose the
+	// last batch, but never corrupts config.json.
+	SyncNever SyncPolicy = "never"
+)
+
+// DefaultHistoryFlushInterval is how long the history writer waits for more
+// executions before writing a batch
+const DefaultHistoryFlushInterval = 250 * time.Millisecond
+
+// ParseSyncPolicy validates a sync policy name
+func ParseSyncPolicy(name string) (SyncPolicy, error) {
+	switch policy := SyncPolicy(name); policy {
+	case SyncAlways, SyncNever:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown sync policy %q (use always or never)", name)
+	}
+}
+
+// HistoryWriterOptions configures the background history writer
+type HistoryWriterOptions struct {
+	Interval time.Duration
+	Sync     SyncPolicy
+}
+
+// historyWriter appends executions to config.json from a goroutine, writing
+// every execution recorded within one interval in a single batch
+type historyWriter struct {
+	configPath string
+	opts       HistoryWriterOptions
+
+	mu      sync.Mutex
+	pending []RequestExecution // oldest first
+	err     error
+	// base is the version of config.json the batches written since the
+	// last adopt started from, and head the version the last one left
+	base filelock.Stamp
+	head filelock.Stamp
+
+	// flushMu keeps the goroutine and explicit flushes from writing the
+	// same batch twice
+	flushMu sync.Mutex
+
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+// StartHistoryWriter moves history writes off the caller's goroutine.
+// AddExecution then updates the in-memory history at once and queues the
+// execution; Close flushes whatever is still queued.
+func (s *Storage) StartHistoryWriter(opts HistoryWriterOptions) {
+	if s.history != nil {
+		return
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultHistoryFlushInterval
+	}
+	if opts.Sync == "" {
+		opts.Sync = SyncAlways
+	}
+
+	s.history = &historyWriter{
+		configPath: s.configPath,
+		opts:       opts,
+		wake:       make(chan struct{}, 1),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	go s.history.run()
+}
+
+// Close stops the history writer after writing the executions still queued
+func (s *Storage) Close() error {
+	if s.history == nil {
+		return nil
+	}
+	w := s.history
+	s.history = nil
+
+	close(w.stop)
+	<-w.done
+	return w.takeErr()
+}
+
+// HistoryWriteErr returns, once, the last error the background history
+// writer ran into. Failed batches stay queued and are retried with the
+// next one.
+func (s *Storage) HistoryWriteErr() error {
+	if s.history == nil {
+		return nil
+	}
+	return s.history.takeErr()
+}
+
+// flushHistory writes the queued executions now
+func (s *Storage) flushHistory() error {
+	if s.history == nil {
+		return nil
+	}
+	return s.history.flush()
+}
+
+// pendingHistory returns the executions not written yet, oldest first
+func (s *Storage) pendingHistory() []RequestExecution {
+	if s.history == nil {
+		return nil
+	}
+	s.history.mu.Lock()
+	defer s.history.mu.Unlock()
+	return append([]RequestExecution(nil), s.history.pending...)
+}
+
+func (w *historyWriter) enqueue(execution RequestExecution) {
+	w.mu.Lock()
+	w.pending = append(w.pending, execution)
+	w.mu.Unlock()
+
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (w *historyWriter) run() {
+	defer close(w.done)
+
+	for {
+		select {
+		case <-w.wake:
+		case <-w.stop:
+			w.flush()
+			return
+		}
+
+		// Give a burst of requests the chance to land in the same batch
+		timer := time.NewTimer(w.opts.Interval)
+		select {
+		case <-timer.C:
+		case <-w.stop:
+			timer.Stop()
+			w.flush()
+			return
+		}
+		w.flush()
+	}
+}
+
+func (w *historyWriter) flush() error {
+	w.flushMu.Lock()
+	defer w.flushMu.Unlock()
+
+	w.mu.Lock()
+	batch := append([]RequestExecution(nil), w.pending...)
+	w.mu.Unlock()
+	if len(batch) == 0 {
+		return nil
+	}
+
+	err := w.write(batch)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err != nil {
+		w.err = err
+		return err
+	}
+	w.pending = w.pending[len(batch):]
+	return nil
+}
+
+// write adds the batch to the history on disk, skipping executions that are
+// already there because a regular save wrote them first
+func (w *historyWriter) write(batch []RequestExecution) error {
+	lock, err := filelock.Acquire(w.configPath)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	config := Config{Version: version}
+	data, read, err := filelock.ReadFile(w.configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &config); err != nil {
+			return fmt.Errorf("failed to parse config file: %w", err)
+		}
+	}
+
+	history, added := prependMissing(config.History, batch)
+	if !added {
+		return nil
+	}
+	config.History = trimHistory(history, maxHistorySize)
+
+	data, err = json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	write := filelock.WriteFile
+	if w.opts.Sync == SyncNever {
+		write = filelock.WriteFileNoSync
+	}
+	if err := write(w.configPath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	written := filelock.StampOf(w.configPath)
+	w.mu.Lock()
+	if w.head != read {
+		// Someone else wrote in between, so the chain starts over here
+		w.base = read
+	}
+	w.head = written
+	w.mu.Unlock()
+	return nil
+}
+
+// adopt reports whether the version of config.json at to is the one at
+// from with only the batches of this writer added, which the in-memory
+// history already has
+func (w *historyWriter) adopt(from, to filelock.Stamp) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.base != from || w.head != to {
+		return false
+	}
+	w.base = to
+	return true
+}
+
+func (w *historyWriter) takeErr() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	err := w.err
+	w.err = nil
+	return err
+}
+
+// prependMissing puts the executions of batch (oldest first) that history
+// does not contain yet at the top of history (newest first)
+func prependMissing(history, batch []RequestExecution) ([]RequestExecution, bool) {
+	known := make(map[string]bool, len(history))
+	for _, exec := range history {
+		known[exec.ID] = true
+	}
+
+	var missing []RequestExecution
+	for i := len(batch) - 1; i >= 0; i-- {
+		if !known[batch[i].ID] {
+			missing = append(missing, batch[i])
+		}
+	}
+	if len(missing) == 0 {
+		return history, false
+	}
+	return append(missing, history...), true
+}
@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestHistoryWriterBatchesInBackground(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := NewStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	// A long interval keeps the whole burst queued until Close
+	s.StartHistoryWriter(HistoryWriterOptions{Interval: time.Hour, Sync: SyncNever})
+
+	for i := 0; i < 5; i++ {
+		if err := s.AddToHistory("GET", fmt.Sprintf("https://api.example.com/%d", i), nil, "", nil, 200, "200 OK", "", 1, nil); err != nil {
+			t.Fatalf("AddToHistory() error = %v", err)
+		}
+	}
+
+	if got := s.GetHistory(); len(got) != 5 || got[0].URL != "https://api.example.com/4" {
+		t.Fatalf("Expected the burst in memory right away, newest first, got %d entries", len(got))
+	}
+
+	other, err := NewStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	if got := len(other.GetHistory()); got != 0 {
+		t.Errorf("Expected nothing on disk before the batch is written, got %d entries", got)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if _, err := other.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	history := other.GetHistory()
+	if len(history) != 5 {
+		t.Fatalf("Expected 5 entries on disk after Close, got %d", len(history))
+	}
+	for i, exec := range history {
+		if want := fmt.Sprintf("https://api.example.com/%d", 4-i); exec.URL != want {
+			t.Errorf("history[%d] = %s, want %s", i, exec.URL, want)
+		}
+	}
+}
+
+func TestHistoryWriterKeepsRegularSavesConsistent(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := NewStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	s.StartHistoryWriter(HistoryWriterOptions{Interval: time.Hour})
+
+	for i := 0; i < 3; i++ {
+		if err := s.AddToHistory("GET", fmt.Sprintf("https://api.example.com/%d", i), nil, "", nil, 200, "200 OK", "", 1, nil); err != nil {
+			t.Fatalf("AddToHistory() error = %v", err)
+		}
+	}
+
+	// Deleting a queued entry must not let the writer bring it back
+	deleted := s.GetHistory()[0].ID
+	if err := s.DeleteHistoryItem(deleted); err != nil {
+		t.Fatalf("DeleteHistoryItem() error = %v", err)
+	}
+	if err := s.AddToHistory("GET", "https://api.example.com/last", nil, "", nil, 200, "200 OK", "", 1, nil); err != nil {
+		t.Fatalf("AddToHistory() error = %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	reopened, err := NewStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	history := reopened.GetHistory()
+	if len(history) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(history))
+	}
+	seen := make(map[string]int)
+	for _, exec := range history {
+		if exec.ID == deleted {
+			t.Error("Expected the deleted entry to stay deleted")
+		}
+		seen[exec.ID]++
+	}
+	if len(seen) != 3 {
+		t.Errorf("Expected no duplicated entries, got %v", seen)
+	}
+	if history[0].URL != "https://api.example.com/last" {
+		t.Errorf("Expected the newest entry first, got %s", history[0].URL)
+	}
+}
+
+func TestHistoryWriterDoesNotTriggerReload(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := NewStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	s.StartHistoryWriter(HistoryWriterOptions{Interval: time.Hour, Sync: SyncNever})
+	defer s.Close()
+
+	for i := 0; i < 2; i++ {
+		if err := s.AddToHistory("GET", fmt.Sprintf("https://api.example.com/%d", i), nil, "", nil, 200, "200 OK", "", 1, nil); err != nil {
+			t.Fatalf("AddToHistory() error = %v", err)
+		}
+		if err := s.flushHistory(); err != nil {
+			t.Fatalf("flushHistory() error = %v", err)
+		}
+		if changed, err := s.Refresh(); err != nil || changed {
+			t.Fatalf("Refresh() after batch %d = %v, %v; want no reload of our own write", i, changed, err)
+		}
+	}
+
+	other, err := NewStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	if err := other.SaveRequest("Users", "GET", "https://api.example.com/users", nil, "", nil); err != nil {
+		t.Fatalf("SaveRequest() error = %v", err)
+	}
+	if changed, err := s.Refresh(); err != nil || !changed {
+		t.Errorf("Refresh() after another instance saved = %v, %v; want a reload", changed, err)
+	}
+	if got := len(s.GetRequests()); got != 1 {
+		t.Errorf("Expected the request saved by the other instance, got %d", got)
+	}
+}
+
+func TestParseSyncPolicy(t *testing.T) {
+	for _, name := range []string{"always", "never"} {
+		if policy, err := ParseSyncPolicy(name); err != nil || string(policy) != name {
+			t.Errorf("ParseSyncPolicy(%q) = %q, %v", name, policy, err)
+		}
+	}
+	if _, err := ParseSyncPolicy("sometimes"); err == nil {
+		t.Error("Expected an error for an unknown policy")
+	}
+}
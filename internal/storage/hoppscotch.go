@@ -0,0 +1,244 @@
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HoppscotchCollection is a collection exported from Hoppscotch. Folders
+// are nested collections.
+type HoppscotchCollection struct {
+	Name     string                 `json:"name"`
+	Folders  []HoppscotchCollection `json:"folders"`
+	Requests []HoppscotchRequest    `json:"requests"`
+	Auth     HoppscotchAuth         `json:"auth"`
+	Headers  []HoppscotchKeyValue   `json:"headers"`
+}
+
+type HoppscotchRequest struct {
+	Name     string               `json:"name"`
+	Method   string               `json:"method"`
+	Endpoint string               `json:"endpoint"`
+	Params   []HoppscotchKeyValue `json:"params"`
+	Headers  []HoppscotchKeyValue `json:"headers"`
+	Auth     HoppscotchAuth       `json:"auth"`
+	Body     HoppscotchBody       `json:"body"`
+}
+
+type HoppscotchKeyValue struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Active *bool  `json:"active"`
+}
+
+type HoppscotchAuth struct {
+	AuthType   string `json:"authType"`
+	AuthActive *bool  `json:"authActive"`
+	Token      string `json:"token"`
+	Username   string `json:"username"`
+	Password   string `json:"password"`
+	Key        string `json:"key"`
+	Value      string `json:"value"`
+	AddTo      string `json:"addTo"`
+}
+
+type HoppscotchBody struct {
+	ContentType string `json:"contentType"`
+	// Body is a string, or a list of fields for multipart bodies
+	Body json.RawMessage `json:"body"`
+}
+
+// hoppscotchVariable matches Hoppscotch's <<name>> variable syntax
+var hoppscotchVariable = regexp.MustCompile(`<<([^<>]+)>>`)
+
+// fromHoppscotchVariables rewrites <<name>> variables as {{name}}
+func fromHoppscotchVariables(s string) string {
+	return hoppscotchVariable.ReplaceAllString(s, "{{$1}}")
+}
+
+// isHoppscotchCollection reports whether a decoded JSON object looks like a
+// Hoppscotch collection
+func isHoppscotchCollection(probe map[string]json.RawMessage) bool {
+	return probe["folders"] != nil && probe["requests"] != nil && probe["name"] != nil
+}
+
+// ImportFromHoppscotch imports a Hoppscotch collection export. Folders become
+// sub-collections and auth becomes headers or query params; a list of
+// collections is imported as sub-collections of a "Hoppscotch" collection.
+func ImportFromHoppscotch(data []byte) (*Collection, error) {
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		var collections []HoppscotchCollection
+		if err := json.Unmarshal(data, &collections); err != nil {
+			return nil, fmt.Errorf("failed to parse Hoppscotch collections: %w", err)
+		}
+		if len(collections) == 1 {
+			collection := convertHoppscotchCollection(collections[0], importAuth{}, nil)
+			return &collection, nil
+		}
+
+		root := CreateCollection("Hoppscotch", "Imported from Hoppscotch")
+		for _, c := range collections {
+			AddSubCollection(&root, convertHoppscotchCollection(c, importAuth{}, nil))
+		}
+		return &root, nil
+	}
+
+	var hoppscotch HoppscotchCollection
+	if err := json.Unmarshal(data, &hoppscotch); err != nil {
+		return nil, fmt.Errorf("failed to parse Hoppscotch collection: %w", err)
+	}
+	collection := convertHoppscotchCollection(hoppscotch, importAuth{}, nil)
+	return &collection, nil
+}
+
+func convertHoppscotchCollection(c HoppscotchCollection, parentAuth importAuth, parentHeaders map[string]string) Collection {
+	collection := CreateCollection(c.Name, "")
+
+	auth := c.Auth.convert(parentAuth)
+	headers := make(map[string]string, len(parentHeaders)+len(c.Headers))
+	for k, v := range parentHeaders {
+		headers[k] = v
+	}
+	for _, h := range c.Headers {
+		if h.enabled() {
+			headers[h.Key] = fromHoppscotchVariables(h.Value)
+		}
+	}
+
+	for _, r := range c.Requests {
+		AddRequestToCollection(&collection, r.convert(auth, headers))
+	}
+	for _, folder := range c.Folders {
+		AddSubCollection(&collection, convertHoppscotchCollection(folder, auth, headers))
+	}
+	return collection
+}
+
+func (r HoppscotchRequest) convert(parentAuth importAuth, parentHeaders map[string]string) SavedRequest {
+	headers := make(map[string]string, len(parentHeaders)+len(r.Headers))
+	for k, v := range parentHeaders {
+		headers[k] = v
+	}
+	for _, h := range r.Headers {
+		if h.enabled() {
+			headers[h.Key] = fromHoppscotchVariables(h.Value)
+		}
+	}
+
+	params := make(map[string]string)
+	for _, p := range r.Params {
+		if p.enabled() {
+			params[p.Key] = fromHoppscotchVariables(p.Value)
+		}
+	}
+
+	var body string
+	if err := json.Unmarshal(r.Body.Body, &body); err == nil {
+		body = fromHoppscotchVariables(body)
+		if body != "" && r.Body.ContentType != "" && !hasHeader(headers, "Content-Type") {
+			headers["Content-Type"] = r.Body.ContentType
+		}
+	}
+
+	auth := r.Auth.convert(parentAuth)
+	description := auth.apply(headers, params)
+
+	now := time.Now()
+	return SavedRequest{
+		ID:          uuid.New().String(),
+		Name:        r.Name,
+		Description: description,
+		Method:      strings.ToUpper(r.Method),
+		URL:         fromHoppscotchVariables(r.Endpoint),
+		Headers:     headers,
+		Body:        body,
+		QueryParams: params,
+		CreatedAt:   now,
+		LastUsed:    now,
+	}
+}
+
+func (kv HoppscotchKeyValue) enabled() bool {
+	return kv.Key != "" && (kv.Active == nil || *kv.Active)
+}
+
+func (a HoppscotchAuth) convert(parent importAuth) importAuth {
+	if a.AuthActive != nil && !*a.AuthActive {
+		return importAuth{}
+	}
+
+	switch a.AuthType {
+	case "", "inherit":
+		return parent
+	case "bearer":
+		return importAuth{bearer: fromHoppscotchVariables(a.Token)}
+	case "basic":
+		return importAuth{
+			basic:    true,
+			username: fromHoppscotchVariables(a.Username),
+			password: fromHoppscotchVariables(a.Password),
+		}
+	case "api-key":
+		return importAuth{
+			apiKey:      fromHoppscotchVariables(a.Key),
+			apiValue:    fromHoppscotchVariables(a.Value),
+			apiKeyQuery: strings.EqualFold(strings.ReplaceAll(a.AddTo, " ", "_"), "query_params"),
+		}
+	case "none":
+		return importAuth{}
+	default:
+		return importAuth{unsupported: a.AuthType}
+	}
+}
+
+// importAuth is the auth of an imported request, applied as headers or
+// query params since saved requests have no auth settings of their own
+type importAuth struct {
+	bearer string
+
+	basic              bool
+	username, password string
+
+	apiKey, apiValue string
+	apiKeyQuery      bool
+
+	// unsupported names an auth type that could not be converted
+	unsupported string
+}
+
+// apply adds the auth to headers or params and returns a note for the
+// request description when the auth could not be converted
+func (a importAuth) apply(headers, params map[string]string) string {
+	switch {
+	case a.bearer != "":
+		headers["Authorization"] = "Bearer " + a.bearer
+	case a.basic:
+		credentials := a.username + ":" + a.password
+		if variableRegex.MatchString(credentials) {
+			return "Basic auth uses variables and was not imported; set the Authorization header by hand"
+		}
+		headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))
+	case a.apiKey != "" && a.apiKeyQuery:
+		params[a.apiKey] = a.apiValue
+	case a.apiKey != "":
+		headers[a.apiKey] = a.apiValue
+	case a.unsupported != "":
+		return fmt.Sprintf("%s auth is not supported and was not imported", a.unsupported)
+	}
+	return ""
+}
+
+func hasHeader(headers map[string]string, name string) bool {
+	for k := range headers {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+const hoppscotchExport = `{
+  "v": 2,
+  "name": "Store API",
+  "auth": {"authType": "bearer", "authActive": true, "token": "<<token>>"},
+  "headers": [{"key": "X-Client", "value": "godev", "active": true}],
+  "requests": [
+    {
+      "v": "1",
+      "name": "List products",
+      "method": "get",
+      "endpoint": "<<baseUrl>>/products",
+      "params": [
+        {"key": "page", "value": "1", "active": true},
+        {"key": "debug", "value": "true", "active": false}
+      ],
+      "headers": [],
+      "auth": {"authType": "inherit", "authActive": true},
+      "body": {"contentType": null, "body": null}
+    }
+  ],
+  "folders": [
+    {
+      "v": 2,
+      "name": "Admin",
+      "auth": {"authType": "basic", "authActive": true, "username": "admin", "password": "secret"},
+      "headers": [],
+      "folders": [],
+      "requests": [
+        {
+          "v": "1",
+          "name": "Create product",
+          "method": "POST",
+          "endpoint": "<<baseUrl>>/products",
+          "params": [],
+          "headers": [{"key": "X-Client", "value": "admin-ui", "active": true}],
+          "auth": {"authType": "inherit", "authActive": true},
+          "body": {"contentType": "application/json", "body": "{\"name\": \"<<name>>\"}"}
+        },
+        {
+          "v": "1",
+          "name": "Health",
+          "method": "GET",
+          "endpoint": "<<baseUrl>>/health",
+          "params": [],
+          "headers": [],
+          "auth": {"authType": "api-key", "authActive": true, "key": "api_key", "value": "k", "addTo": "QUERY_PARAMS"},
+          "body": {"contentType": null, "body": null}
+        }
+      ]
+    }
+  ]
+}`
+
+func TestImportFromHoppscotch(t *testing.T) {
+	collection, err := ParseCollection([]byte(hoppscotchExport))
+	if err != nil {
+		t.Fatalf("ParseCollection() error = %v", err)
+	}
+
+	if collection.Name != "Store API" || len(collection.Requests) != 1 || len(collection.SubCollections) != 1 {
+		t.Fatalf("Unexpected collection shape: %q, %d requests, %d folders", collection.Name, len(collection.Requests), len(collection.SubCollections))
+	}
+	if collection.RequestCount() != 3 {
+		t.Errorf("RequestCount() = %d, want 3", collection.RequestCount())
+	}
+
+	list := collection.Requests[0]
+	if list.Method != "GET" || list.URL != "{{baseUrl}}/products" {
+		t.Errorf("Expected GET {{baseUrl}}/products, got %s %s", list.Method, list.URL)
+	}
+	if list.Headers["Authorization"] != "Bearer {{token}}" || list.Headers["X-Client"] != "godev" {
+		t.Errorf("Expected inherited auth and headers, got %v", list.Headers)
+	}
+	if len(list.QueryParams) != 1 || list.QueryParams["page"] != "1" {
+		t.Errorf("Expected only active params, got %v", list.QueryParams)
+	}
+
+	admin := collection.SubCollections[0]
+	create := admin.Requests[0]
+	wantBasic := "Basic " + base64.StdEncoding.EncodeToString([]byte("admin:secret"))
+	if create.Headers["Authorization"] != wantBasic {
+		t.Errorf("Expected folder basic auth, got %q", create.Headers["Authorization"])
+	}
+	if create.Headers["X-Client"] != "admin-ui" || create.Headers["Content-Type"] != "application/json" {
+		t.Errorf("Expected request headers to override and a content type, got %v", create.Headers)
+	}
+	if create.Body != `{"name": "{{name}}"}` {
+		t.Errorf("Expected variables in the body to be converted, got %s", create.Body)
+	}
+
+	health := admin.Requests[1]
+	if health.QueryParams["api_key"] != "k" || health.Headers["Authorization"] != "" {
+		t.Errorf("Expected the API key as a query param, got params %v headers %v", health.QueryParams, health.Headers)
+	}
+}
+
+func TestImportHoppscotchList(t *testing.T) {
+	collection, err := ParseCollection([]byte(`[{"name": "A", "folders": [], "requests": []}, {"name": "B", "folders": [], "requests": []}]`))
+	if err != nil {
+		t.Fatalf("ParseCollection() error = %v", err)
+	}
+	if collection.Name != "Hoppscotch" || len(collection.SubCollections) != 2 || collection.SubCollections[1].Name != "B" {
+		t.Errorf("Expected both collections under one, got %+v", collection)
+	}
+}
+
+func TestParseHoppscotchEnvironments(t *testing.T) {
+	envs, err := ParseEnvironments([]byte(`[
+	  {"id": "1", "name": "Staging", "variables": [
+	    {"key": "baseUrl", "initialValue": "https://staging.example.com", "currentValue": "", "secret": false},
+	    {"key": "login", "value": "<<baseUrl>>/login"}
+	  ]}
+	]`))
+	if err != nil {
+		t.Fatalf("ParseEnvironments() error = %v", err)
+	}
+	if len(envs) != 1 || envs[0].Name != "Staging" || len(envs[0].Variables) != 2 {
+		t.Fatalf("Unexpected environments: %+v", envs)
+	}
+	if envs[0].Variables[0].Value != "https://staging.example.com" || envs[0].Variables[1].Value != "{{baseUrl}}/login" {
+		t.Errorf("Unexpected variables: %+v", envs[0].Variables)
+	}
+}
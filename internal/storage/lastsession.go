@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/abneribeiro/godev/internal/filelock"
+)
+
+// lastSessionFile is kept per workspace, as the requests and queries it
+// points at belong to one
+const lastSessionFile = "last_session.json"
+
+// LastSession is where the user left off, saved on exit so the next start
+// can reopen it
+type LastSession struct {
+	// Screen names the screen that was open, like "request" or "db_query"
+	Screen string `json:"screen"`
+
+	// The request being edited in the request builder
+	Method      string            `json:"method,omitempty"`
+	URL         string            `json:"url,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Body        string            `json:"body,omitempty"`
+	QueryParams map[string]string `json:"query_params,omitempty"`
+	// SavedRequestID and HistoryID are the selected items of the saved
+	// request list and the history
+	SavedRequestID string `json:"saved_request_id,omitempty"`
+	HistoryID      string `json:"history_id,omitempty"`
+
+	// Database is the connection the database screens used; the password
+	// is never saved and is asked again
+	Database *SessionDatabase `json:"database,omitempty"`
+
+	SavedAt time.Time `json:"saved_at"`
+}
+
+// SessionDatabase is the database side of a LastSession
+type SessionDatabase struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Database string `json:"database"`
+	User     string `json:"user"`
+	// Query is the content of the SQL editor
+	Query string `json:"query,omitempty"`
+	// Table is the table selected in the schema browser
+	Table string `json:"table,omitempty"`
+}
+
+// LoadLastSession returns the session saved on the last exit. found is
+// false when none was saved.
+func (s *Storage) LoadLastSession() (session LastSession, found bool, err error) {
+	dir, err := s.Dir()
+	if err != nil {
+		return LastSession{}, false, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, lastSessionFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return LastSession{}, false, nil
+		}
+		return LastSession{}, false, fmt.Errorf("failed to read last session: %w", err)
+	}
+	if err := json.Unmarshal(data, &session); err != nil {
+		return LastSession{}, false, fmt.Errorf("failed to parse last session: %w", err)
+	}
+	return session, true, nil
+}
+
+// SaveLastSession remembers where the user left off
+func (s *Storage) SaveLastSession(session LastSession) error {
+	dir, err := s.Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	session.SavedAt = time.Now()
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal last session: %w", err)
+	}
+	if err := filelock.WriteFile(filepath.Join(dir, lastSessionFile), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write last session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package storage
+
+import "testing"
+
+func TestLastSessionRoundTrip(t *testing.T) {
+	s, err := NewStorageAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+
+	if _, found, err := s.LoadLastSession(); err != nil || found {
+		t.Fatalf("Expected no session before the first exit, got found=%v err=%v", found, err)
+	}
+
+	session := LastSession{
+		Screen: "db_query",
+		URL:    "https://api.example.com/users",
+		Database: &SessionDatabase{
+			Host: "localhost", Port: 5432, Database: "shop", User: "app",
+			Query: "SELECT * FROM orders", Table: "orders",
+		},
+	}
+	if err := s.SaveLastSession(session); err != nil {
+		t.Fatalf("SaveLastSession() error = %v", err)
+	}
+
+	loaded, found, err := s.LoadLastSession()
+	if err != nil || !found {
+		t.Fatalf("LoadLastSession() found=%v err=%v", found, err)
+	}
+	if loaded.Screen != "db_query" || loaded.URL != session.URL || *loaded.Database != *session.Database {
+		t.Errorf("Expected the session back, got %+v", loaded)
+	}
+	if loaded.SavedAt.IsZero() {
+		t.Error("Expected the time of the save to be recorded")
+	}
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/abneribeiro/godev/internal/filelock"
+)
+
+// onboardingFile sits next to workspace.json: the tour and release notes
+// are shown once per installation, not once per workspace
+const onboardingFile = "onboarding.json"
+
+// OnboardingState records what the user has already been shown
+type OnboardingState struct {
+	TourCompleted bool `json:"tour_completed"`
+	// LastSeenVersion is the version whose release notes were last shown
+	LastSeenVersion string `json:"last_seen_version,omitempty"`
+}
+
+// LoadOnboardingState returns the saved onboarding state. found is false on
+// the first launch, when nothing has been saved yet.
+func LoadOnboardingState() (state OnboardingState, found bool, err error) {
+	base, err := baseDir()
+	if err != nil {
+		return OnboardingState{}, false, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(base, onboardingFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return OnboardingState{}, false, nil
+		}
+		return OnboardingState{}, false, fmt.Errorf("failed to read onboarding state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return OnboardingState{}, false, fmt.Errorf("failed to parse onboarding state: %w", err)
+	}
+	return state, true, nil
+}
+
+// SaveOnboardingState remembers what the user has been shown
+func SaveOnboardingState(state OnboardingState) error {
+	base, err := baseDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(base, 0o700); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal onboarding state: %w", err)
+	}
+	if err := filelock.WriteFile(filepath.Join(base, onboardingFile), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write onboarding state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+)
+
+func TestOnboardingState(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+
+	if _, found, err := LoadOnboardingState(); err != nil || found {
+		t.Fatalf("Expected no state on first launch, got found=%v err=%v", found, err)
+	}
+
+	want := OnboardingState{TourCompleted: true, LastSeenVersion: "0.4.0"}
+	if err := SaveOnboardingState(want); err != nil {
+		t.Fatalf("SaveOnboardingState() error = %v", err)
+	}
+	got, found, err := LoadOnboardingState()
+	if err != nil || !found || got != want {
+		t.Errorf("LoadOnboardingState() = %+v, %v, %v, want %+v", got, found, err, want)
+	}
+}
@@ -0,0 +1,636 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OpenAPISpec is the subset of an OpenAPI 3 document used for import and contract validation
+type OpenAPISpec struct {
+	OpenAPI    string                     `json:"openapi"`
+	Info       OpenAPIInfo                `json:"info"`
+	Servers    []OpenAPIServer            `json:"servers,omitempty"`
+	Paths      map[string]OpenAPIPathItem `json:"paths"`
+	Components OpenAPIComponents          `json:"components,omitempty"`
+}
+
+// OpenAPIPathItem maps lower-case HTTP methods to the operations of one path.
+// Path-level fields such as parameters, summary or servers are ignored.
+type OpenAPIPathItem map[string]OpenAPIOperation
+
+// UnmarshalJSON decodes only the HTTP method keys of a path item
+func (item *OpenAPIPathItem) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	*item = make(OpenAPIPathItem)
+	for _, method := range openAPIMethods {
+		raw, ok := fields[method]
+		if !ok {
+			continue
+		}
+		var op OpenAPIOperation
+		if err := json.Unmarshal(raw, &op); err != nil {
+			return fmt.Errorf("%s: %w", method, err)
+		}
+		(*item)[method] = op
+	}
+
+	return nil
+}
+
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type OpenAPIServer struct {
+	URL string `json:"url"`
+}
+
+type OpenAPIComponents struct {
+	Schemas map[string]*OpenAPISchema `json:"schemas,omitempty"`
+}
+
+type OpenAPIOperation struct {
+	Summary     string                     `json:"summary,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	OperationID string                     `json:"operationId,omitempty"`
+	RequestBody *OpenAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]OpenAPIResponse `json:"responses"`
+}
+
+type OpenAPIRequestBody struct {
+	Content map[string]OpenAPIMediaType `json:"content,omitempty"`
+}
+
+type OpenAPIResponse struct {
+	Description string                      `json:"description,omitempty"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty"`
+}
+
+type OpenAPIMediaType struct {
+	Schema   *OpenAPISchema            `json:"schema,omitempty"`
+	Example  interface{}               `json:"example,omitempty"`
+	Examples map[string]OpenAPIExample `json:"examples,omitempty"`
+}
+
+type OpenAPIExample struct {
+	Summary string      `json:"summary,omitempty"`
+	Value   interface{} `json:"value,omitempty"`
+}
+
+type OpenAPISchema struct {
+	Ref        string                    `json:"$ref,omitempty"`
+	Type       string                    `json:"type,omitempty"`
+	Format     string                    `json:"format,omitempty"`
+	Nullable   bool                      `json:"nullable,omitempty"`
+	Properties map[string]*OpenAPISchema `json:"properties,omitempty"`
+	Required   []string                  `json:"required,omitempty"`
+	Items      *OpenAPISchema            `json:"items,omitempty"`
+	Enum       []interface{}             `json:"enum,omitempty"`
+	AllOf      []*OpenAPISchema          `json:"allOf,omitempty"`
+	OneOf      []*OpenAPISchema          `json:"oneOf,omitempty"`
+	AnyOf      []*OpenAPISchema          `json:"anyOf,omitempty"`
+	Example    interface{}               `json:"example,omitempty"`
+}
+
+var openAPIMethods = []string{"get", "post", "put", "patch", "delete", "head", "options"}
+
+// ParseOpenAPISpec parses an OpenAPI 3 document in JSON format
+func ParseOpenAPISpec(data []byte) (*OpenAPISpec, error) {
+	var spec OpenAPISpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+
+	if !strings.HasPrefix(spec.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version: %q", spec.OpenAPI)
+	}
+
+	return &spec, nil
+}
+
+// ImportFromOpenAPI creates a collection with one request per operation. The
+// spec is kept with the collection so responses can be validated against it.
+func ImportFromOpenAPI(data []byte) (*Collection, error) {
+	spec, err := ParseOpenAPISpec(data)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := ""
+	if len(spec.Servers) > 0 {
+		baseURL = strings.TrimSuffix(spec.Servers[0].URL, "/")
+	}
+
+	collection := CreateCollection(spec.Info.Title, spec.Info.Description)
+	collection.OpenAPISpec = json.RawMessage(data)
+
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		for _, method := range openAPIMethods {
+			op, ok := spec.Paths[path][method]
+			if !ok {
+				continue
+			}
+
+			name := op.Summary
+			if name == "" {
+				name = op.OperationID
+			}
+			if name == "" {
+				name = strings.ToUpper(method) + " " + path
+			}
+
+			headers := make(map[string]string)
+			body := ""
+			if op.RequestBody != nil {
+				if contentType, media, ok := jsonMedia(op.RequestBody.Content); ok {
+					headers["Content-Type"] = contentType
+					if example := mediaExample(media); example != nil {
+						if data, err := json.MarshalIndent(example, "", "  "); err == nil {
+							body = string(data)
+						}
+					}
+				}
+			}
+
+			now := time.Now()
+			AddRequestToCollection(&collection, SavedRequest{
+				ID:          uuid.New().String(),
+				Name:        name,
+				Description: op.Description,
+				Method:      strings.ToUpper(method),
+				URL:         baseURL + path,
+				Headers:     headers,
+				Body:        body,
+				QueryParams: make(map[string]string),
+				CreatedAt:   now,
+				LastUsed:    now,
+			})
+		}
+	}
+
+	return &collection, nil
+}
+
+// mediaExample returns the first example declared for a media type
+func mediaExample(media OpenAPIMediaType) interface{} {
+	if media.Example != nil {
+		return media.Example
+	}
+
+	names := make([]string, 0, len(media.Examples))
+	for name := range media.Examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if media.Examples[name].Value != nil {
+			return media.Examples[name].Value
+		}
+	}
+
+	if media.Schema != nil && media.Schema.Example != nil {
+		return media.Schema.Example
+	}
+
+	return nil
+}
+
+// GenerateExample returns the declared example for a schema or synthesizes one
+// from its type, following component references
+func (spec *OpenAPISpec) GenerateExample(schema *OpenAPISchema) interface{} {
+	return spec.generateExample(schema, 0)
+}
+
+func (spec *OpenAPISpec) generateExample(schema *OpenAPISchema, depth int) interface{} {
+	schema = spec.resolveRef(schema)
+	if schema == nil || depth > maxSchemaDepth {
+		return nil
+	}
+
+	if schema.Example != nil {
+		return schema.Example
+	}
+	if len(schema.Enum) > 0 {
+		return schema.Enum[0]
+	}
+	if len(schema.AllOf) > 0 {
+		merged := make(map[string]interface{})
+		for _, sub := range schema.AllOf {
+			if obj, ok := spec.generateExample(sub, depth+1).(map[string]interface{}); ok {
+				for k, v := range obj {
+					merged[k] = v
+				}
+			}
+		}
+		return merged
+	}
+	for _, alternatives := range [][]*OpenAPISchema{schema.OneOf, schema.AnyOf} {
+		if len(alternatives) > 0 {
+			return spec.generateExample(alternatives[0], depth+1)
+		}
+	}
+
+	switch schema.Type {
+	case "object", "":
+		obj := make(map[string]interface{}, len(schema.Properties))
+		for name, prop := range schema.Properties {
+			obj[name] = spec.generateExample(prop, depth+1)
+		}
+		return obj
+	case "array":
+		return []interface{}{spec.generateExample(schema.Items, depth+1)}
+	case "integer":
+		return 0
+	case "number":
+		return 0.0
+	case "boolean":
+		return false
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			return "2024-01-01T00:00:00Z"
+		case "date":
+			return "2024-01-01"
+		case "uuid":
+			return "00000000-0000-0000-0000-000000000000"
+		case "email":
+			return "user@example.com"
+		case "uri":
+			return "https://example.com"
+		}
+		return "string"
+	}
+
+	return nil
+}
+
+// ExampleResponse picks the response a mock should return for an operation:
+// the lowest documented 2xx status (or the first documented one) and its
+// JSON example. The body is nil when the response has no JSON content.
+func (spec *OpenAPISpec) ExampleResponse(op *OpenAPIOperation) (int, interface{}) {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	chosen := ""
+	for _, code := range codes {
+		if strings.HasPrefix(code, "2") {
+			chosen = code
+			break
+		}
+	}
+	if chosen == "" && len(codes) > 0 {
+		chosen = codes[0]
+	}
+
+	status := 200
+	if n, err := strconv.Atoi(chosen); err == nil {
+		status = n
+	} else if len(chosen) == 3 && strings.HasSuffix(strings.ToUpper(chosen), "XX") {
+		// Range keys such as 2XX map to the first code in the range
+		if n, err := strconv.Atoi(chosen[:1]); err == nil {
+			status = n * 100
+		}
+	}
+
+	_, media, ok := jsonMedia(op.Responses[chosen].Content)
+	if !ok {
+		return status, nil
+	}
+
+	if example := mediaExample(media); example != nil {
+		return status, example
+	}
+	return status, spec.GenerateExample(media.Schema)
+}
+
+// Contract returns the OpenAPI spec the collection was imported from, if any
+func (c *Collection) Contract() (*OpenAPISpec, error) {
+	if len(c.OpenAPISpec) == 0 {
+		return nil, fmt.Errorf("collection %s was not imported from OpenAPI", c.Name)
+	}
+	return ParseOpenAPISpec(c.OpenAPISpec)
+}
+
+// FindOperation returns the operation and path template matching a request URL
+func (spec *OpenAPISpec) FindOperation(method, rawURL string) (*OpenAPIOperation, string, bool) {
+	requestPath := rawURL
+	if parsed, err := url.Parse(rawURL); err == nil {
+		requestPath = parsed.Path
+	}
+
+	// Strip the server base path (e.g. /v1) before matching templates
+	for _, server := range spec.Servers {
+		if parsed, err := url.Parse(server.URL); err == nil && parsed.Path != "" && parsed.Path != "/" {
+			basePath := strings.TrimSuffix(parsed.Path, "/")
+			if strings.HasPrefix(requestPath, basePath+"/") {
+				requestPath = strings.TrimPrefix(requestPath, basePath)
+				break
+			}
+		}
+	}
+
+	method = strings.ToLower(method)
+	var matches []string
+	for template, item := range spec.Paths {
+		if _, ok := item[method]; ok && matchPathTemplate(template, requestPath) {
+			matches = append(matches, template)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, "", false
+	}
+
+	// Prefer /users/me over /users/{id}: the template with the most literal
+	// segments wins, and ties fall back to lexical order so the pick is stable
+	sort.Slice(matches, func(i, j int) bool {
+		li, lj := literalSegments(matches[i]), literalSegments(matches[j])
+		if li != lj {
+			return li > lj
+		}
+		return matches[i] < matches[j]
+	})
+
+	op := spec.Paths[matches[0]][method]
+	return &op, matches[0], true
+}
+
+// literalSegments counts the path segments of a template that are not parameters
+func literalSegments(template string) int {
+	count := 0
+	for _, part := range strings.Split(strings.Trim(template, "/"), "/") {
+		if !strings.HasPrefix(part, "{") || !strings.HasSuffix(part, "}") {
+			count++
+		}
+	}
+	return count
+}
+
+// matchPathTemplate matches a concrete path against a template such as /users/{id}
+func matchPathTemplate(template, path string) bool {
+	templateParts := strings.Split(strings.Trim(template, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+
+	if len(templateParts) != len(pathParts) {
+		return false
+	}
+
+	for i, part := range templateParts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			if pathParts[i] == "" {
+				return false
+			}
+			continue
+		}
+		if part != pathParts[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// findResponse returns the documented response for a status code, falling back
+// to range keys (2XX) and then "default"
+func (op *OpenAPIOperation) findResponse(statusCode int) (*OpenAPIResponse, bool) {
+	code := strconv.Itoa(statusCode)
+	if resp, ok := op.Responses[code]; ok {
+		return &resp, true
+	}
+
+	rangeKey := code[:1] + "XX"
+	for key, resp := range op.Responses {
+		if strings.EqualFold(key, rangeKey) {
+			return &resp, true
+		}
+	}
+
+	if resp, ok := op.Responses["default"]; ok {
+		return &resp, true
+	}
+
+	return nil, false
+}
+
+// ValidateContract checks a response against the spec's documented status codes
+// and response schema. Each violation is returned as its own error.
+func ValidateContract(spec *OpenAPISpec, method, rawURL string, statusCode int, responseBody string) []error {
+	op, template, ok := spec.FindOperation(method, rawURL)
+	if !ok {
+		return []error{fmt.Errorf("%s %s is not described by the spec", method, rawURL)}
+	}
+
+	resp, ok := op.findResponse(statusCode)
+	if !ok {
+		return []error{fmt.Errorf("status %d is not documented for %s %s", statusCode, strings.ToUpper(method), template)}
+	}
+
+	_, media, ok := jsonMedia(resp.Content)
+	if !ok || media.Schema == nil {
+		return nil
+	}
+
+	var data interface{}
+	if err := json.Unmarshal([]byte(responseBody), &data); err != nil {
+		return []error{fmt.Errorf("response body is not valid JSON: %w", err)}
+	}
+
+	var failures []error
+	for _, msg := range spec.validateSchema(data, media.Schema, "$", 0) {
+		failures = append(failures, errors.New(msg))
+	}
+
+	return failures
+}
+
+// jsonMedia returns the JSON entry of a content map. Keys are matched by their
+// parsed type, so parameters such as charset and +json suffixes like
+// application/problem+json are accepted. A plain application/json key wins.
+func jsonMedia(content map[string]OpenAPIMediaType) (string, OpenAPIMediaType, bool) {
+	keys := make([]string, 0, len(content))
+	for key := range content {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	found := ""
+	for _, key := range keys {
+		mediaType, _, err := mime.ParseMediaType(key)
+		if err != nil {
+			continue
+		}
+		if mediaType == "application/json" {
+			return key, content[key], true
+		}
+		if found == "" && strings.HasSuffix(mediaType, "+json") {
+			found = key
+		}
+	}
+
+	if found == "" {
+		return "", OpenAPIMediaType{}, false
+	}
+	return found, content[found], true
+}
+
+// maxSchemaDepth guards against cyclic $ref chains
+const maxSchemaDepth = 32
+
+// resolveRef follows local component references
+func (spec *OpenAPISpec) resolveRef(schema *OpenAPISchema) *OpenAPISchema {
+	for i := 0; schema != nil && schema.Ref != "" && i < maxSchemaDepth; i++ {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		schema = spec.Components.Schemas[name]
+	}
+	return schema
+}
+
+// validateSchema validates a decoded JSON value and returns violation messages
+func (spec *OpenAPISpec) validateSchema(value interface{}, schema *OpenAPISchema, path string, depth int) []string {
+	schema = spec.resolveRef(schema)
+	if schema == nil || depth > maxSchemaDepth {
+		return nil
+	}
+
+	var violations []string
+
+	for _, sub := range schema.AllOf {
+		violations = append(violations, spec.validateSchema(value, sub, path, depth+1)...)
+	}
+
+	if len(schema.AnyOf) > 0 && spec.countMatches(value, schema.AnyOf, path, depth) == 0 {
+		violations = append(violations, fmt.Sprintf("%s does not match any allowed schema", path))
+	}
+
+	if len(schema.OneOf) > 0 {
+		switch n := spec.countMatches(value, schema.OneOf, path, depth); n {
+		case 1:
+		case 0:
+			violations = append(violations, fmt.Sprintf("%s does not match any allowed schema", path))
+		default:
+			violations = append(violations, fmt.Sprintf("%s matches %d schemas but must match exactly one", path, n))
+		}
+	}
+
+	if value == nil {
+		if !schema.Nullable && schema.Type != "" {
+			violations = append(violations, fmt.Sprintf("%s is null but expected %s", path, schema.Type))
+		}
+		return violations
+	}
+
+	if schema.Type != "" && !matchesOpenAPIType(value, schema.Type) {
+		return append(violations, fmt.Sprintf("%s expected %s, got %s", path, schema.Type, openAPITypeOf(value)))
+	}
+
+	if len(schema.Enum) > 0 {
+		found := false
+		for _, allowed := range schema.Enum {
+			if fmt.Sprint(allowed) == fmt.Sprint(value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			violations = append(violations, fmt.Sprintf("%s value %v is not one of the allowed values", path, value))
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range schema.Required {
+			if _, ok := v[name]; !ok {
+				violations = append(violations, fmt.Sprintf("%s.%s is required but missing", path, name))
+			}
+		}
+
+		names := make([]string, 0, len(schema.Properties))
+		for name := range schema.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if child, ok := v[name]; ok {
+				violations = append(violations, spec.validateSchema(child, schema.Properties[name], path+"."+name, depth+1)...)
+			}
+		}
+
+	case []interface{}:
+		if schema.Items != nil {
+			for i, item := range v {
+				violations = append(violations, spec.validateSchema(item, schema.Items, fmt.Sprintf("%s[%d]", path, i), depth+1)...)
+			}
+		}
+	}
+
+	return violations
+}
+
+// countMatches returns how many of the alternatives a value satisfies
+func (spec *OpenAPISpec) countMatches(value interface{}, alternatives []*OpenAPISchema, path string, depth int) int {
+	n := 0
+	for _, sub := range alternatives {
+		if len(spec.validateSchema(value, sub, path, depth+1)) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// matchesOpenAPIType checks a decoded JSON value against an OpenAPI type name
+func matchesOpenAPIType(value interface{}, typ string) bool {
+	switch typ {
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == float64(int64(n))
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return openAPITypeOf(value) == typ
+	}
+}
+
+// openAPITypeOf returns the OpenAPI type name of a decoded JSON value
+func openAPITypeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,259 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+)
+
+const testOpenAPISpec = `{
+	"openapi": "3.0.0",
+	"info": {"title": "Users API", "version": "1.0.0"},
+	"servers": [{"url": "https://api.example.com/v1"}],
+	"paths": {
+		"/users/{id}": {
+			"get": {
+				"summary": "Get user",
+				"responses": {
+					"200": {
+						"description": "ok",
+						"content": {
+							"application/json": {
+								"schema": {"$ref": "#/components/schemas/User"},
+								"example": {"id": 1, "name": "Ada", "role": "admin"}
+							}
+						}
+					},
+					"404": {"description": "not found"}
+				}
+			}
+		},
+		"/users": {
+			"post": {
+				"operationId": "createUser",
+				"requestBody": {
+					"content": {"application/json": {"example": {"name": "Ada"}}}
+				},
+				"responses": {"2XX": {"description": "created"}}
+			}
+		}
+	},
+	"components": {
+		"schemas": {
+			"User": {
+				"type": "object",
+				"required": ["id", "name"],
+				"properties": {
+					"id": {"type": "integer"},
+					"name": {"type": "string"},
+					"role": {"type": "string", "enum": ["admin", "member"]},
+					"tags": {"type": "array", "items": {"type": "string"}}
+				}
+			}
+		}
+	}
+}`
+
+func TestImportFromOpenAPI(t *testing.T) {
+	collection, err := ImportFromOpenAPI([]byte(testOpenAPISpec))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if collection.Name != "Users API" {
+		t.Errorf("Expected name 'Users API', got '%s'", collection.Name)
+	}
+
+	if len(collection.Requests) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(collection.Requests))
+	}
+
+	post := collection.Requests[0]
+	if post.Method != "POST" || post.URL != "https://api.example.com/v1/users" || post.Name != "createUser" {
+		t.Errorf("Unexpected POST request: %+v", post)
+	}
+
+	if !strings.Contains(post.Body, `"name": "Ada"`) {
+		t.Errorf("Expected request body from example, got %q", post.Body)
+	}
+
+	if _, err := collection.Contract(); err != nil {
+		t.Errorf("Expected collection to keep its contract: %v", err)
+	}
+}
+
+func TestImportFromOpenAPIRejectsSwagger2(t *testing.T) {
+	if _, err := ImportFromOpenAPI([]byte(`{"swagger": "2.0", "paths": {}}`)); err == nil {
+		t.Error("Expected error for non OpenAPI 3 document")
+	}
+}
+
+func TestValidateContract(t *testing.T) {
+	spec, err := ParseOpenAPISpec([]byte(testOpenAPISpec))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		status     int
+		body       string
+		violations int
+		contains   string
+	}{
+		{"valid response", "GET", "https://api.example.com/v1/users/7", 200, `{"id": 7, "name": "Ada", "role": "admin"}`, 0, ""},
+		{"documented error", "GET", "https://api.example.com/v1/users/7", 404, ``, 0, ""},
+		{"range status", "POST", "https://api.example.com/v1/users", 201, `{}`, 0, ""},
+		{"undocumented status", "GET", "https://api.example.com/v1/users/7", 500, ``, 1, "status 500"},
+		{"unknown path", "GET", "https://api.example.com/v1/orders", 200, ``, 1, "not described"},
+		{"missing field", "GET", "https://api.example.com/v1/users/7", 200, `{"id": 7}`, 1, "$.name is required"},
+		{"wrong type", "GET", "https://api.example.com/v1/users/7", 200, `{"id": 7.5, "name": "Ada"}`, 1, "$.id expected integer"},
+		{"bad enum", "GET", "https://api.example.com/v1/users/7", 200, `{"id": 7, "name": "Ada", "role": "root"}`, 1, "not one of"},
+		{"bad array item", "GET", "https://api.example.com/v1/users/7", 200, `{"id": 7, "name": "Ada", "tags": ["a", 1]}`, 1, "$.tags[1]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failures := ValidateContract(spec, tt.method, tt.url, tt.status, tt.body)
+			if len(failures) != tt.violations {
+				t.Fatalf("Expected %d violations, got %d: %v", tt.violations, len(failures), failures)
+			}
+			if tt.contains != "" && !strings.Contains(failures[0].Error(), tt.contains) {
+				t.Errorf("Expected violation to contain %q, got %q", tt.contains, failures[0].Error())
+			}
+		})
+	}
+}
+
+func TestParseOpenAPISpecPathLevelFields(t *testing.T) {
+	data := `{
+		"openapi": "3.0.3",
+		"info": {"title": "Orders", "version": "1"},
+		"paths": {
+			"/orders/{id}": {
+				"summary": "A single order",
+				"description": "Orders by id",
+				"servers": [{"url": "https://orders.example.com"}],
+				"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}],
+				"get": {"summary": "Get order", "responses": {"200": {"description": "ok"}}}
+			}
+		}
+	}`
+
+	spec, err := ParseOpenAPISpec([]byte(data))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	item := spec.Paths["/orders/{id}"]
+	if len(item) != 1 {
+		t.Fatalf("Expected only the get operation, got %v", item)
+	}
+	if item["get"].Summary != "Get order" {
+		t.Errorf("Expected the get operation to be decoded, got %+v", item["get"])
+	}
+
+	collection, err := ImportFromOpenAPI([]byte(data))
+	if err != nil {
+		t.Fatalf("Unexpected import error: %v", err)
+	}
+	if len(collection.Requests) != 1 || collection.Requests[0].Name != "Get order" {
+		t.Errorf("Unexpected requests: %+v", collection.Requests)
+	}
+}
+
+func TestFindOperationPrefersLiteralSegments(t *testing.T) {
+	spec, err := ParseOpenAPISpec([]byte(`{
+		"openapi": "3.0.0",
+		"info": {"title": "Users", "version": "1"},
+		"paths": {
+			"/users/{id}": {"get": {"operationId": "getUser", "responses": {"200": {"description": "ok"}}}},
+			"/users/me": {"get": {"operationId": "getMe", "responses": {"200": {"description": "ok"}}}},
+			"/{resource}/{id}": {"get": {"operationId": "getAny", "responses": {"200": {"description": "ok"}}}}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		url      string
+		template string
+		opID     string
+	}{
+		{"/users/me", "/users/me", "getMe"},
+		{"/users/42", "/users/{id}", "getUser"},
+		{"/orders/42", "/{resource}/{id}", "getAny"},
+	}
+
+	for _, tt := range tests {
+		// Map iteration order varies, so repeat to catch a non-deterministic pick
+		for i := 0; i < 20; i++ {
+			op, template, ok := spec.FindOperation("GET", tt.url)
+			if !ok {
+				t.Fatalf("Expected %s to match", tt.url)
+			}
+			if template != tt.template || op.OperationID != tt.opID {
+				t.Fatalf("Expected %s to match %s (%s), got %s (%s)", tt.url, tt.template, tt.opID, template, op.OperationID)
+			}
+		}
+	}
+}
+
+func TestValidateContractOneOfAndMediaTypes(t *testing.T) {
+	spec, err := ParseOpenAPISpec([]byte(`{
+		"openapi": "3.0.0",
+		"info": {"title": "Pets", "version": "1.0.0"},
+		"paths": {
+			"/pets": {
+				"get": {
+					"responses": {
+						"200": {
+							"description": "ok",
+							"content": {"application/json; charset=utf-8": {"schema": {"oneOf": [
+								{"type": "object", "required": ["bark"]},
+								{"type": "object", "required": ["meow"]}
+							]}}}
+						},
+						"400": {
+							"description": "bad request",
+							"content": {"application/problem+json": {"schema": {"type": "object", "required": ["title"]}}}
+						}
+					}
+				}
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		contains string
+	}{
+		{"one match", 200, `{"bark": true}`, ""},
+		{"no match", 200, `{}`, "does not match any"},
+		{"two matches", 200, `{"bark": true, "meow": true}`, "exactly one"},
+		{"problem json", 400, `{"title": "Bad"}`, ""},
+		{"problem json missing field", 400, `{}`, "$.title is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failures := ValidateContract(spec, "GET", "/pets", tt.status, tt.body)
+			if tt.contains == "" {
+				if len(failures) != 0 {
+					t.Errorf("Expected no violations, got %v", failures)
+				}
+				return
+			}
+			if len(failures) != 1 || !strings.Contains(failures[0].Error(), tt.contains) {
+				t.Errorf("Expected one violation containing %q, got %v", tt.contains, failures)
+			}
+		})
+	}
+}
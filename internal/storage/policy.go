@@ -0,0 +1,24 @@
+package storage
+
+// RequestPolicy overrides the timeout and retry policy of the settings
+// for every send of a saved request. Unset fields keep the settings, and
+// redirects are followed unless NoFollowRedirects is set.
+type RequestPolicy struct {
+	TimeoutMs int64 `json:"timeout_ms,omitempty"`
+	// Retries is how many attempts follow a failed first one
+	Retries *int `json:"retries,omitempty"`
+	// BackoffMs is the wait before the first retry, doubled before each
+	// one after it
+	BackoffMs int64 `json:"backoff_ms,omitempty"`
+	// NoFollowRedirects shows a redirect as the response instead of
+	// following it
+	NoFollowRedirects bool `json:"no_follow_redirects,omitempty"`
+}
+
+// UpdateRequestPolicy sets or, with nil, removes the timeout and retry
+// policy of a saved request
+func (s *Storage) UpdateRequestPolicy(id string, policy *RequestPolicy) error {
+	return s.editRequest(id, func(req *SavedRequest) {
+		req.Policy = policy
+	})
+}
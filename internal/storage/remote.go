@@ -0,0 +1,327 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// maxRemoteImportSize caps the size of documents fetched for import
+	maxRemoteImportSize = 10 * 1024 * 1024
+	remoteImportTimeout = 30 * time.Second
+)
+
+// RemoteImportResult summarises what an import from URL changed
+type RemoteImportResult struct {
+	Collection   *Collection
+	Environments []string
+	Replaced     bool
+}
+
+// FetchRemote downloads a JSON document for import. HTTPS is required except
+// for loopback hosts. authHeader is an optional "Name: value" header.
+func FetchRemote(ctx context.Context, rawURL, authHeader string) ([]byte, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	if parsed.Scheme != "https" && !(parsed.Scheme == "http" && isLoopbackHost(parsed.Hostname())) {
+		return nil, fmt.Errorf("import URL must use https: %s", rawURL)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, remoteImportTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	if authHeader != "" {
+		name, value, ok := strings.Cut(authHeader, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid header %q, expected \"Name: value\"", authHeader)
+		}
+		req.Header.Set(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to fetch %s: %s", rawURL, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteImportSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if len(data) > maxRemoteImportSize {
+		return nil, fmt.Errorf("document exceeds %d bytes", maxRemoteImportSize)
+	}
+
+	return data, nil
+}
+
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// ParseCollection reads a collection in godev, Postman, OpenAPI, Hoppscotch
+// or Thunder Client format
+func ParseCollection(data []byte) (*Collection, error) {
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		// Only Hoppscotch exports several collections as a list
+		return ImportFromHoppscotch(data)
+	}
+
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("document is not a JSON object: %w", err)
+	}
+
+	switch {
+	case probe["openapi"] != nil:
+		return ImportFromOpenAPI(data)
+	case probe["info"] != nil && probe["item"] != nil:
+		return ImportFromPostman(data)
+	case isThunderCollection(probe):
+		return ImportFromThunderClient(data)
+	case isHoppscotchCollection(probe):
+		return ImportFromHoppscotch(data)
+	case probe["requests"] != nil:
+		var collection Collection
+		if err := json.Unmarshal(data, &collection); err != nil {
+			return nil, fmt.Errorf("failed to parse collection: %w", err)
+		}
+		if collection.Name == "" {
+			return nil, fmt.Errorf("collection has no name")
+		}
+		return &collection, nil
+	default:
+		return nil, fmt.Errorf("unrecognised collection format")
+	}
+}
+
+// ParseEnvironments reads an environments file, a list of environments or a
+// single environment, in godev, Hoppscotch or Thunder Client format
+func ParseEnvironments(data []byte) ([]Environment, error) {
+	trimmed := strings.TrimSpace(string(data))
+
+	if strings.HasPrefix(trimmed, "[") {
+		var raw []json.RawMessage
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse environments: %w", err)
+		}
+		envs := make([]Environment, 0, len(raw))
+		for _, item := range raw {
+			env, err := parseEnvironment(item)
+			if err != nil {
+				return nil, err
+			}
+			envs = append(envs, env)
+		}
+		return envs, nil
+	}
+
+	var config EnvironmentConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse environments: %w", err)
+	}
+	if len(config.Environments) > 0 {
+		return config.Environments, nil
+	}
+
+	env, err := parseEnvironment(data)
+	if err != nil {
+		return nil, fmt.Errorf("no environments found in document")
+	}
+	return []Environment{env}, nil
+}
+
+// importedEnvironment covers the environment formats of godev, Hoppscotch
+// (initialValue/currentValue, <<name>> references) and Thunder Client
+// (envName and data)
+type importedEnvironment struct {
+	Name      string `json:"name"`
+	EnvName   string `json:"envName"`
+	Variables []struct {
+		Key          string `json:"key"`
+		Value        string `json:"value"`
+		InitialValue string `json:"initialValue"`
+		CurrentValue string `json:"currentValue"`
+	} `json:"variables"`
+	Data []ThunderHeader `json:"data"`
+}
+
+func parseEnvironment(data []byte) (Environment, error) {
+	var imported importedEnvironment
+	if err := json.Unmarshal(data, &imported); err != nil {
+		return Environment{}, fmt.Errorf("failed to parse environment: %w", err)
+	}
+
+	env := Environment{Name: imported.Name, Variables: []Variable{}}
+	if env.Name == "" {
+		env.Name = imported.EnvName
+	}
+	if env.Name == "" {
+		return Environment{}, fmt.Errorf("environment has no name")
+	}
+
+	for _, v := range imported.Variables {
+		value := v.Value
+		if value == "" {
+			value = v.CurrentValue
+		}
+		if value == "" {
+			value = v.InitialValue
+		}
+		env.Variables = append(env.Variables, Variable{Key: v.Key, Value: fromHoppscotchVariables(value)})
+	}
+	for _, v := range imported.Data {
+		env.Variables = append(env.Variables, Variable{Key: v.Name, Value: v.Value})
+	}
+	return env, nil
+}
+
+// ImportRemoteCollection fetches a collection and stores it, replacing the
+// collection previously imported from the same URL or with the same name
+func (s *Storage) ImportRemoteCollection(ctx context.Context, rawURL, authHeader string) (*RemoteImportResult, error) {
+	data, err := FetchRemote(ctx, rawURL, authHeader)
+	if err != nil {
+		return nil, err
+	}
+	return s.ImportCollection(data, rawURL)
+}
+
+// ImportCollection stores a collection document in any format ParseCollection
+// reads, replacing the collection previously imported from the same source
+// URL or with the same name. sourceURL is empty for local files.
+func (s *Storage) ImportCollection(data []byte, sourceURL string) (*RemoteImportResult, error) {
+	collection, err := ParseCollection(data)
+	if err != nil {
+		return nil, err
+	}
+	collection.SourceURL = sourceURL
+	collection.UpdatedAt = time.Now()
+
+	result := &RemoteImportResult{Collection: collection}
+	err = s.editCollections(func(config *CollectionConfig) error {
+		for i := range config.Collections {
+			existing := config.Collections[i]
+			if (sourceURL != "" && existing.SourceURL == sourceURL) || existing.Name == collection.Name {
+				collection.ID = existing.ID
+				collection.CreatedAt = existing.CreatedAt
+				config.Collections[i] = *collection
+				result.Replaced = true
+				break
+			}
+		}
+		if !result.Replaced {
+			config.Collections = append(config.Collections, *collection)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ImportRemoteEnvironments fetches environments and merges them into the
+// local ones. Remote variables overwrite local values with the same key,
+// variables only defined locally (e.g. personal secrets) are kept.
+func (s *Storage) ImportRemoteEnvironments(ctx context.Context, rawURL, authHeader string) (*RemoteImportResult, error) {
+	data, err := FetchRemote(ctx, rawURL, authHeader)
+	if err != nil {
+		return nil, err
+	}
+	return s.ImportEnvironments(data)
+}
+
+// ImportEnvironments merges the environments of a document in any format
+// ParseEnvironments reads into the local ones
+func (s *Storage) ImportEnvironments(data []byte) (*RemoteImportResult, error) {
+	remote, err := ParseEnvironments(data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RemoteImportResult{}
+	err = s.editEnvironments(func(config *EnvironmentConfig) error {
+		for _, env := range remote {
+			if env.Name == "" {
+				continue
+			}
+			result.Environments = append(result.Environments, env.Name)
+			config.Environments = mergeEnvironment(config.Environments, env)
+		}
+
+		if config.ActiveEnvironment == "" && len(result.Environments) > 0 {
+			config.ActiveEnvironment = result.Environments[0]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func mergeEnvironment(envs []Environment, remote Environment) []Environment {
+	for i := range envs {
+		if envs[i].Name != remote.Name {
+			continue
+		}
+		for _, v := range remote.Variables {
+			found := false
+			for j := range envs[i].Variables {
+				if envs[i].Variables[j].Key == v.Key {
+					envs[i].Variables[j].Value = v.Value
+					found = true
+					break
+				}
+			}
+			if !found {
+				envs[i].Variables = append(envs[i].Variables, v)
+			}
+		}
+		return envs
+	}
+
+	if remote.Variables == nil {
+		remote.Variables = []Variable{}
+	}
+	return append(envs, remote)
+}
+
+// RemoteCollectionSources returns the URLs of collections imported from URL
+func (s *Storage) RemoteCollectionSources() ([]string, error) {
+	config, err := s.LoadCollections()
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []string
+	for _, c := range config.Collections {
+		if c.SourceURL != "" {
+			sources = append(sources, c.SourceURL)
+		}
+	}
+	return sources, nil
+}
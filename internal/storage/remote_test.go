@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func remoteTestServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer team-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchRemoteRequiresHTTPS(t *testing.T) {
+	if _, err := FetchRemote(context.Background(), "http://example.com/collection.json", ""); err == nil {
+		t.Error("Expected plain HTTP to a remote host to be rejected")
+	}
+}
+
+func TestFetchRemoteAuthHeader(t *testing.T) {
+	server := remoteTestServer(t, `{}`)
+
+	if _, err := FetchRemote(context.Background(), server.URL, ""); err == nil {
+		t.Error("Expected error for unauthorized response")
+	}
+	if _, err := FetchRemote(context.Background(), server.URL, "Authorization: Bearer team-token"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err := FetchRemote(context.Background(), server.URL, "missing-colon"); err == nil {
+		t.Error("Expected error for malformed header")
+	}
+}
+
+func TestParseCollectionFormats(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"godev", `{"name": "Team", "requests": [{"name": "a", "method": "GET", "url": "https://x"}]}`, "Team"},
+		{"postman", `{"info": {"name": "Postman"}, "item": [{"name": "a", "request": {"method": "GET", "url": {"raw": "https://x"}}}]}`, "Postman"},
+		{"openapi", `{"openapi": "3.0.0", "info": {"title": "Spec"}, "paths": {}}`, "Spec"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collection, err := ParseCollection([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if collection.Name != tt.want {
+				t.Errorf("Expected collection %q, got %q", tt.want, collection.Name)
+			}
+		})
+	}
+
+	if _, err := ParseCollection([]byte(`{"foo": 1}`)); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
+
+func TestImportRemoteCollectionReplaces(t *testing.T) {
+	tmpDir := t.TempDir()
+	origHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", origHome)
+	os.Setenv("HOME", tmpDir)
+
+	server := remoteTestServer(t, `{"name": "Team", "requests": [{"name": "a", "method": "GET", "url": "https://x"}]}`)
+	store := &Storage{}
+
+	first, err := store.ImportRemoteCollection(context.Background(), server.URL, "Authorization: Bearer team-token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if first.Replaced {
+		t.Error("Expected first import to add a collection")
+	}
+
+	second, err := store.ImportRemoteCollection(context.Background(), server.URL, "Authorization: Bearer team-token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !second.Replaced {
+		t.Error("Expected second import to replace the collection")
+	}
+
+	config, err := store.LoadCollections()
+	if err != nil {
+		t.Fatalf("LoadCollections() error = %v", err)
+	}
+	if len(config.Collections) != 1 || config.Collections[0].SourceURL != server.URL {
+		t.Errorf("Expected one collection with source URL, got %+v", config.Collections)
+	}
+}
+
+func TestImportRemoteEnvironmentsMerges(t *testing.T) {
+	tmpDir := t.TempDir()
+	origHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", origHome)
+	os.Setenv("HOME", tmpDir)
+
+	store := &Storage{}
+	if err := store.AddEnvironment("dev"); err != nil {
+		t.Fatalf("AddEnvironment() error = %v", err)
+	}
+	store.AddVariable("dev", "API_URL", "http://old")
+	store.AddVariable("dev", "MY_TOKEN", "secret")
+
+	server := remoteTestServer(t, `[{"name": "dev", "variables": [{"key": "API_URL", "value": "https://dev.team"}]}, {"name": "prod", "variables": []}]`)
+	result, err := store.ImportRemoteEnvironments(context.Background(), server.URL, "Authorization: Bearer team-token")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Environments) != 2 {
+		t.Errorf("Expected 2 imported environments, got %v", result.Environments)
+	}
+
+	config, _ := store.LoadEnvironments()
+	if len(config.Environments) != 2 {
+		t.Fatalf("Expected 2 environments, got %d", len(config.Environments))
+	}
+
+	values := map[string]string{}
+	for _, v := range config.Environments[0].Variables {
+		values[v.Key] = v.Value
+	}
+	if values["API_URL"] != "https://dev.team" {
+		t.Errorf("Expected remote value to win, got %s", values["API_URL"])
+	}
+	if values["MY_TOKEN"] != "secret" {
+		t.Error("Expected local-only variable to be kept")
+	}
+}
@@ -0,0 +1,34 @@
+package storage
+
+// SelectReplayCandidates returns up to n of the most recent distinct successful
+// GET executions from history. Executions are matched on their URL, so the same
+// endpoint is only replayed once. History is expected newest first.
+func SelectReplayCandidates(history []RequestExecution, n int) []RequestExecution {
+	if n <= 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var candidates []RequestExecution
+
+	for _, exec := range history {
+		if exec.Method != "GET" || exec.Error != "" {
+			continue
+		}
+		if exec.StatusCode < 200 || exec.StatusCode >= 300 {
+			continue
+		}
+		if seen[exec.URL] {
+			continue
+		}
+
+		seen[exec.URL] = true
+		candidates = append(candidates, exec)
+
+		if len(candidates) == n {
+			break
+		}
+	}
+
+	return candidates
+}
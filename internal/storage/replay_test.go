@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"testing"
+)
+
+func TestSelectReplayCandidates(t *testing.T) {
+	history := []RequestExecution{
+		{ID: "1", Method: "GET", URL: "https://api.example.com/users", StatusCode: 200},
+		{ID: "2", Method: "POST", URL: "https://api.example.com/users", StatusCode: 201},
+		{ID: "3", Method: "GET", URL: "https://api.example.com/users", StatusCode: 200},
+		{ID: "4", Method: "GET", URL: "https://api.example.com/orders", StatusCode: 500},
+		{ID: "5", Method: "GET", URL: "https://api.example.com/health", Error: "timeout"},
+		{ID: "6", Method: "GET", URL: "https://api.example.com/items", StatusCode: 204},
+		{ID: "7", Method: "GET", URL: "https://api.example.com/tags", StatusCode: 200},
+	}
+
+	candidates := SelectReplayCandidates(history, 2)
+	if len(candidates) != 2 {
+		t.Fatalf("Expected 2 candidates, got %d", len(candidates))
+	}
+
+	if candidates[0].ID != "1" || candidates[1].ID != "6" {
+		t.Errorf("Expected executions 1 and 6, got %s and %s", candidates[0].ID, candidates[1].ID)
+	}
+}
+
+func TestSelectReplayCandidatesEmpty(t *testing.T) {
+	if got := SelectReplayCandidates(nil, 5); len(got) != 0 {
+		t.Errorf("Expected no candidates, got %d", len(got))
+	}
+
+	history := []RequestExecution{{Method: "GET", URL: "https://a", StatusCode: 200}}
+	if got := SelectReplayCandidates(history, 0); len(got) != 0 {
+		t.Errorf("Expected no candidates for n=0, got %d", len(got))
+	}
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"sort"
+	"strings"
+)
+
+// NoHostGroup names the group of requests whose URL has no host
+const NoHostGroup = "(no host)"
+
+// RequestGroup is a set of saved requests that talk to the same host
+type RequestGroup struct {
+	Host string
+	// Requests are indexes into the list that was grouped, in list order
+	Requests []int
+}
+
+// RequestHost returns the host a saved request talks to: the alias name for
+// URLs that start with an alias, the {{variable}} a URL starts with, or the
+// lowercased host and port of the URL
+func RequestHost(rawURL string, aliases []ServiceAlias) string {
+	rawURL = strings.TrimSpace(rawURL)
+	if _, alias := ExpandAlias(rawURL, aliases); alias != "" {
+		return alias
+	}
+
+	if strings.HasPrefix(rawURL, "{{") {
+		if end := strings.Index(rawURL, "}}"); end > 0 {
+			return rawURL[:end+2]
+		}
+	}
+
+	if _, rest, ok := strings.Cut(rawURL, "://"); ok {
+		rawURL = rest
+	}
+	host := rawURL
+	if i := strings.IndexAny(host, "/?#"); i >= 0 {
+		host = host[:i]
+	}
+	if i := strings.LastIndex(host, "@"); i >= 0 {
+		host = host[i+1:]
+	}
+	if host == "" {
+		return NoHostGroup
+	}
+	return strings.ToLower(host)
+}
+
+// GroupRequestsByHost groups requests by RequestHost, groups sorted by host.
+// Variants stay in the group of their parent when it is in the list.
+func GroupRequestsByHost(requests []SavedRequest, aliases []ServiceAlias) []RequestGroup {
+	hostOf := make(map[string]string, len(requests))
+	for _, req := range requests {
+		if !req.IsVariant() {
+			hostOf[req.ID] = RequestHost(req.URL, aliases)
+		}
+	}
+
+	index := make(map[string]int)
+	var groups []RequestGroup
+	for i, req := range requests {
+		host, ok := hostOf[req.ParentID]
+		if !req.IsVariant() || !ok {
+			host = RequestHost(req.URL, aliases)
+		}
+		g, ok := index[host]
+		if !ok {
+			g = len(groups)
+			index[host] = g
+			groups = append(groups, RequestGroup{Host: host})
+		}
+		groups[g].Requests = append(groups[g].Requests, i)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Host < groups[j].Host
+	})
+	return groups
+}
@@ -0,0 +1,41 @@
+package storage
+
+import "testing"
+
+func TestRequestHost(t *testing.T) {
+	aliases := []ServiceAlias{{Name: "users-api", Target: "{{API_URL}}/users"}}
+
+	tests := map[string]string{
+		"https://API.example.com/users?page=2": "api.example.com",
+		"http://localhost:8080/health":         "localhost:8080",
+		"https://user:pw@example.com":          "example.com",
+		"{{API_URL}}/orders":                   "{{API_URL}}",
+		"users-api/42":                         "users-api",
+		"":                                     NoHostGroup,
+	}
+	for url, want := range tests {
+		if got := RequestHost(url, aliases); got != want {
+			t.Errorf("RequestHost(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
+
+func TestGroupRequestsByHost(t *testing.T) {
+	requests := []SavedRequest{
+		{ID: "1", URL: "https://b.example.com/x"},
+		{ID: "2", URL: "https://a.example.com/y"},
+		{ID: "3", URL: "https://c.example.com/x", ParentID: "1", VariantName: "staging"},
+		{ID: "4", URL: "https://b.example.com/z"},
+	}
+
+	groups := GroupRequestsByHost(requests, nil)
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %+v", groups)
+	}
+	if groups[0].Host != "a.example.com" || len(groups[0].Requests) != 1 || groups[0].Requests[0] != 1 {
+		t.Errorf("Expected groups sorted by host, got %+v", groups[0])
+	}
+	if want := []int{0, 2, 3}; len(groups[1].Requests) != 3 || groups[1].Requests[1] != want[1] {
+		t.Errorf("Expected the variant to stay with its parent, got %+v", groups[1])
+	}
+}
@@ -3,17 +3,20 @@ package storage
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/abneribeiro/godev/internal/filelock"
 )
 
 const (
 	oldConfigDir = ".devscope"
-	configDir    = ".godev"
 	configFile   = "config.json"
 	version      = "0.4.0"
 )
@@ -31,11 +34,24 @@ type RequestExecution struct {
 	ResponseBody string            `json:"response_body"`
 	ResponseTime int64             `json:"response_time_ms"`
 	Error        string            `json:"error,omitempty"`
+	// BudgetMs is the latency budget of the saved request at the time of the run
+	BudgetMs int64 `json:"budget_ms,omitempty"`
+	// Environment is the environment that was active when the request was sent
+	Environment string `json:"environment,omitempty"`
+	// Bookmarked entries are kept when history is trimmed or cleared
+	Bookmarked bool   `json:"bookmarked,omitempty"`
+	Note       string `json:"note,omitempty"`
+}
+
+// OverBudget reports whether the execution was slower than its latency budget
+func (e RequestExecution) OverBudget() bool {
+	return e.BudgetMs > 0 && e.Error == "" && e.ResponseTime > e.BudgetMs
 }
 
 type SavedRequest struct {
 	ID          string            `json:"id"`
 	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
 	Method      string            `json:"method"`
 	URL         string            `json:"url"`
 	Headers     map[string]string `json:"headers"`
@@ -43,6 +59,57 @@ type SavedRequest struct {
 	QueryParams map[string]string `json:"query_params"`
 	CreatedAt   time.Time         `json:"created_at"`
 	LastUsed    time.Time         `json:"last_used"`
+	// ResponseSchema is the JSON schema inferred from the first successful response
+	ResponseSchema json.RawMessage `json:"response_schema,omitempty"`
+	// DisplayTransform is a jq-style expression applied to the body before display
+	DisplayTransform string `json:"display_transform,omitempty"`
+	// LatencyBudgetMs flags responses slower than this many milliseconds
+	LatencyBudgetMs int64 `json:"latency_budget_ms,omitempty"`
+	// SizeBudgetBytes flags collection run responses larger than this
+	SizeBudgetBytes int64 `json:"size_budget_bytes,omitempty"`
+	// Assertions are checked against every response of the request
+	Assertions []ResponseAssertion `json:"assertions,omitempty"`
+	// Extractions set variables of the active environment from every
+	// response of the request, for the requests that follow
+	Extractions []VariableExtract `json:"extractions,omitempty"`
+	// Golden is the pinned baseline response; VolatileFields are left out
+	// when diffing against it
+	Golden         *GoldenResponse `json:"golden,omitempty"`
+	VolatileFields []string        `json:"volatile_fields,omitempty"`
+	// HMAC signs the request when it is sent
+	HMAC *HMACAuth `json:"hmac,omitempty"`
+	// Auth attaches Basic, Bearer or API key credentials when it is sent
+	Auth *RequestAuth `json:"auth,omitempty"`
+	// Policy sets the timeout and retries of the request in place of the
+	// settings
+	Policy *RequestPolicy `json:"policy,omitempty"`
+	// ParentID links a variant to the saved request it was derived from
+	ParentID    string `json:"parent_id,omitempty"`
+	VariantName string `json:"variant_name,omitempty"`
+	// Group lets consecutive steps of a collection run with the same group run in parallel
+	Group string `json:"group,omitempty"`
+	// DependsOn names steps of the same collection that must pass before this one runs
+	DependsOn []string `json:"depends_on,omitempty"`
+}
+
+// URLWithQueryParams returns the URL with the saved query params applied
+func (r SavedRequest) URLWithQueryParams() string {
+	if len(r.QueryParams) == 0 {
+		return r.URL
+	}
+
+	parsedURL, err := url.Parse(r.URL)
+	if err != nil {
+		return r.URL
+	}
+
+	q := parsedURL.Query()
+	for key, value := range r.QueryParams {
+		q.Set(key, value)
+	}
+	parsedURL.RawQuery = q.Encode()
+
+	return parsedURL.String()
 }
 
 type Config struct {
@@ -54,21 +121,54 @@ type Config struct {
 type Storage struct {
 	configPath string
 	config     *Config
+	dir        string
+	workspace  string
+	// stamp identifies the version of config.json last read or written by
+	// this instance, envStamp the version of environments.json last read
+	// with ReloadEnvironments
+	stamp    filelock.Stamp
+	envStamp filelock.Stamp
+	// history is set while history is written in the background
+	history *historyWriter
 }
 
+// NewStorage opens the storage of the active workspace
 func NewStorage() (*Storage, error) {
+	return NewWorkspaceStorage(ActiveWorkspace())
+}
+
+// NewWorkspaceStorage opens the storage of the named workspace
+func NewWorkspaceStorage(workspace string) (*Storage, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get home directory: %w", err)
 	}
 
-	configDirPath := filepath.Join(homeDir, configDir)
-	oldConfigDirPath := filepath.Join(homeDir, oldConfigDir)
+	configDirPath, err := WorkspaceDir(workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	if base, err := baseDir(); err == nil && configDirPath == base {
+		oldConfigDirPath := filepath.Join(homeDir, oldConfigDir)
+		if err := migrateOldConfig(oldConfigDirPath, configDirPath); err != nil {
+			slog.Warn("Migration from .devscope failed", "error", err)
+		}
+	}
+
+	return openStorage(configDirPath, workspace)
+}
 
-	if err := migrateOldConfig(oldConfigDirPath, configDirPath); err != nil {
-		fmt.Printf("Warning: Migration from .devscope failed: %v\n", err)
+// NewStorageAt opens storage kept in the given directory instead of the
+// workspace directory under the home directory
+func NewStorageAt(dir string) (*Storage, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("storage directory is empty")
 	}
+	return openStorage(dir, DefaultWorkspace)
+}
 
+func openStorage(configDirPath, workspace string) (*Storage, error) {
 	// Use secure directory permissions (0700 - only owner can access)
 	if err := os.MkdirAll(configDirPath, 0o700); err != nil {
 		return nil, fmt.Errorf("failed to create config directory: %w", err)
@@ -78,6 +178,8 @@ func NewStorage() (*Storage, error) {
 
 	storage := &Storage{
 		configPath: configPath,
+		dir:        configDirPath,
+		workspace:  workspace,
 	}
 
 	if err := storage.load(); err != nil {
@@ -125,12 +227,12 @@ func migrateOldConfig(oldDir, newDir string) error {
 		return err
 	}
 
-	fmt.Println("✓ Successfully migrated config from ~/.devscope to ~/.godev")
+	slog.Info("Migrated config from ~/.devscope", "new_dir", newDir)
 	return nil
 }
 
 func (s *Storage) load() error {
-	data, err := os.ReadFile(s.configPath)
+	data, stamp, err := filelock.ReadFile(s.configPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return err
@@ -144,9 +246,11 @@ func (s *Storage) load() error {
 	}
 
 	s.config = &config
+	s.stamp = stamp
 	return nil
 }
 
+// save writes config.json. Mutations go through edit so they hold the lock.
 func (s *Storage) save() error {
 	data, err := json.MarshalIndent(s.config, "", "  ")
 	if err != nil {
@@ -155,13 +259,63 @@ func (s *Storage) save() error {
 
 	// Use secure file permissions (0600 - only owner can read/write)
 	// This is critical as the file may contain API tokens and sensitive data
-	if err := os.WriteFile(s.configPath, data, 0o600); err != nil {
+	if err := filelock.WriteFile(s.configPath, data, 0o600); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
+	s.stamp = filelock.StampOf(s.configPath)
 
 	return nil
 }
 
+// edit applies fn to the latest config and saves it while holding the config
+// lock, so changes made by another godev instance in the meantime are picked
+// up instead of overwritten
+func (s *Storage) edit(fn func(*Config) error) error {
+	// Queued history goes first, so the save below cannot race it and a
+	// deleted entry is not written back afterwards
+	if err := s.flushHistory(); err != nil {
+		return err
+	}
+
+	lock, err := filelock.Acquire(s.configPath)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	if _, err := s.Refresh(); err != nil {
+		return err
+	}
+	if err := fn(s.config); err != nil {
+		return err
+	}
+	return s.save()
+}
+
+// Refresh reloads saved requests and history when another godev instance
+// changed them on disk and reports whether it did
+func (s *Storage) Refresh() (bool, error) {
+	stamp := filelock.StampOf(s.configPath)
+	if stamp == (filelock.Stamp{}) || stamp == s.stamp {
+		return false, nil
+	}
+	if s.history != nil && s.history.adopt(s.stamp, stamp) {
+		s.stamp = stamp
+		return false, nil
+	}
+	if err := s.load(); err != nil {
+		return false, err
+	}
+	if s.config.History == nil {
+		s.config.History = []RequestExecution{}
+	}
+	// Keep showing executions the history writer has not written yet
+	if history, added := prependMissing(s.config.History, s.pendingHistory()); added {
+		s.config.History = trimHistory(history, maxHistorySize)
+	}
+	return true, nil
+}
+
 func (s *Storage) SaveRequest(name, method, url string, headers map[string]string, body string, queryParams map[string]string) error {
 	now := time.Now()
 
@@ -177,12 +331,15 @@ func (s *Storage) SaveRequest(name, method, url string, headers map[string]strin
 		LastUsed:    now,
 	}
 
-	s.config.Requests = append(s.config.Requests, request)
-	return s.save()
+	return s.edit(func(c *Config) error {
+		c.Requests = append(c.Requests, request)
+		return nil
+	})
 }
 
+// GetRequests returns the saved requests with each request followed by its variants
 func (s *Storage) GetRequests() []SavedRequest {
-	return s.config.Requests
+	return groupVariants(s.config.Requests)
 }
 
 func (s *Storage) GetRequest(id string) (*SavedRequest, error) {
@@ -195,23 +352,97 @@ func (s *Storage) GetRequest(id string) (*SavedRequest, error) {
 }
 
 func (s *Storage) UpdateLastUsed(id string) error {
-	for i := range s.config.Requests {
-		if s.config.Requests[i].ID == id {
-			s.config.Requests[i].LastUsed = time.Now()
-			return s.save()
+	return s.editRequest(id, func(req *SavedRequest) {
+		req.LastUsed = time.Now()
+	})
+}
+
+func (s *Storage) UpdateResponseSchema(id string, schema json.RawMessage) error {
+	return s.editRequest(id, func(req *SavedRequest) {
+		req.ResponseSchema = schema
+	})
+}
+
+func (s *Storage) UpdateDisplayTransform(id, expr string) error {
+	return s.editRequest(id, func(req *SavedRequest) {
+		req.DisplayTransform = expr
+	})
+}
+
+func (s *Storage) UpdateLatencyBudget(id string, budgetMs int64) error {
+	if budgetMs < 0 {
+		return fmt.Errorf("latency budget cannot be negative")
+	}
+	return s.editRequest(id, func(req *SavedRequest) {
+		req.LatencyBudgetMs = budgetMs
+	})
+}
+
+// UpdateAssertions replaces the assertions of a saved request
+func (s *Storage) UpdateAssertions(id string, assertions []ResponseAssertion) error {
+	for _, a := range assertions {
+		if err := a.Validate(); err != nil {
+			return err
 		}
 	}
-	return fmt.Errorf("request not found: %s", id)
+	return s.editRequest(id, func(req *SavedRequest) {
+		req.Assertions = assertions
+	})
 }
 
-func (s *Storage) DeleteRequest(id string) error {
-	for i := range s.config.Requests {
-		if s.config.Requests[i].ID == id {
-			s.config.Requests = append(s.config.Requests[:i], s.config.Requests[i+1:]...)
-			return s.save()
+// UpdateExtractions replaces the variable extractions of a saved request
+func (s *Storage) UpdateExtractions(id string, extractions []VariableExtract) error {
+	for _, e := range extractions {
+		if err := e.Validate(); err != nil {
+			return err
 		}
 	}
-	return fmt.Errorf("request not found: %s", id)
+	return s.editRequest(id, func(req *SavedRequest) {
+		req.Extractions = extractions
+	})
+}
+
+// editRequest applies fn to the saved request with the given ID
+func (s *Storage) editRequest(id string, fn func(*SavedRequest)) error {
+	return s.edit(func(c *Config) error {
+		for i := range c.Requests {
+			if c.Requests[i].ID == id {
+				fn(&c.Requests[i])
+				return nil
+			}
+		}
+		return fmt.Errorf("request not found: %s", id)
+	})
+}
+
+// DeleteRequest moves a saved request together with its variants to the trash
+func (s *Storage) DeleteRequest(id string) error {
+	return s.edit(func(c *Config) error {
+		var target *SavedRequest
+		var removed []SavedRequest
+		kept := make([]SavedRequest, 0, len(c.Requests))
+		for i, req := range c.Requests {
+			switch {
+			case req.ID == id:
+				target = &c.Requests[i]
+				removed = append(removed, req)
+			case req.ParentID == id:
+				removed = append(removed, req)
+			default:
+				kept = append(kept, req)
+			}
+		}
+		if target == nil {
+			return fmt.Errorf("request not found: %s", id)
+		}
+
+		if err := s.MoveToTrash(TrashKindRequest, target.Name, removed); err != nil {
+			return err
+		}
+
+		c.Requests = kept
+		return nil
+	})
 }
 
 func (s *Storage) RequestExists(name string) bool {
@@ -223,6 +454,17 @@ func (s *Storage) RequestExists(name string) bool {
 	return false
 }
 
+// FindRequestByName returns the saved request called name, or nil when there
+// is none
+func FindRequestByName(requests []SavedRequest, name string) *SavedRequest {
+	for i := range requests {
+		if requests[i].Name == name {
+			return &requests[i]
+		}
+	}
+	return nil
+}
+
 const maxHistorySize = 100
 
 func (s *Storage) AddToHistory(method, url string, headers map[string]string, body string, queryParams map[string]string, statusCode int, status, responseBody string, responseTimeMs int64, err error) error {
@@ -244,43 +486,76 @@ func (s *Storage) AddToHistory(method, url string, headers map[string]string, bo
 		execution.Error = err.Error()
 	}
 
-	s.config.History = append([]RequestExecution{execution}, s.config.History...)
+	return s.AddExecution(execution)
+}
 
-	if len(s.config.History) > maxHistorySize {
-		s.config.History = s.config.History[:maxHistorySize]
+// AddExecution records a prepared execution at the top of the history. With
+// the history writer running it returns before the execution is on disk.
+func (s *Storage) AddExecution(execution RequestExecution) error {
+	if execution.ID == "" {
+		execution.ID = uuid.New().String()
+	}
+	if execution.Timestamp.IsZero() {
+		execution.Timestamp = time.Now()
 	}
 
-	return s.save()
+	if s.history != nil {
+		s.config.History = trimHistory(append([]RequestExecution{execution}, s.config.History...), maxHistorySize)
+		s.history.enqueue(execution)
+		return nil
+	}
+
+	return s.edit(func(c *Config) error {
+		c.History = trimHistory(append([]RequestExecution{execution}, c.History...), maxHistorySize)
+		return nil
+	})
 }
 
 func (s *Storage) GetHistory() []RequestExecution {
 	return s.config.History
 }
 
+// FilterHistoryByEnvironment returns the executions sent while env was
+// active; an empty env matches executions sent without an environment
+func FilterHistoryByEnvironment(history []RequestExecution, env string) []RequestExecution {
+	filtered := make([]RequestExecution, 0, len(history))
+	for _, exec := range history {
+		if exec.Environment == env {
+			filtered = append(filtered, exec)
+		}
+	}
+	return filtered
+}
+
+// ClearHistory removes every history entry that is not bookmarked
 func (s *Storage) ClearHistory() error {
-	s.config.History = []RequestExecution{}
-	return s.save()
+	return s.edit(func(c *Config) error {
+		c.History = trimHistory(c.History, 0)
+		return nil
+	})
 }
 
 func (s *Storage) DeleteHistoryItem(id string) error {
-	for i := range s.config.History {
-		if s.config.History[i].ID == id {
-			s.config.History = append(s.config.History[:i], s.config.History[i+1:]...)
-			return s.save()
+	return s.edit(func(c *Config) error {
+		for i := range c.History {
+			if c.History[i].ID == id {
+				c.History = append(c.History[:i], c.History[i+1:]...)
+				return nil
+			}
 		}
-	}
-	return fmt.Errorf("history item not found: %s", id)
+		return fmt.Errorf("history item not found: %s", id)
+	})
 }
 
 func (s *Storage) FilterRequests(query string) []SavedRequest {
 	if query == "" {
-		return s.config.Requests
+		return s.GetRequests()
 	}
 
 	query = strings.ToLower(query)
 	filtered := []SavedRequest{}
 
-	for _, req := range s.config.Requests {
+	for _, req := range s.GetRequests() {
 		if strings.Contains(strings.ToLower(req.Name), query) ||
 			strings.Contains(strings.ToLower(req.Method), query) ||
 			strings.Contains(strings.ToLower(req.URL), query) {
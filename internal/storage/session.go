@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SessionStepName names a recorded request after its position and path,
+// e.g. "3. POST /users"
+func SessionStepName(position int, method, rawURL string) string {
+	path := rawURL
+	if parsed, err := url.Parse(rawURL); err == nil && parsed.Host != "" {
+		path = parsed.EscapedPath()
+		if path == "" {
+			path = "/"
+		}
+	}
+	return fmt.Sprintf("%d. %s %s", position, method, path)
+}
+
+// SaveSessionFlow saves the requests of a recorded session, in the order they
+// were sent, as a new collection so it can be replayed with
+// "godev collection run <name>"
+func (s *Storage) SaveSessionFlow(name string, requests []SavedRequest) (*Collection, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("session name cannot be empty")
+	}
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("session %q recorded no requests", name)
+	}
+
+	now := time.Now()
+	collection := CreateCollection(name, fmt.Sprintf("Recorded session, %d requests, %s", len(requests), now.Format("2006-01-02 15:04")))
+	for i, req := range requests {
+		req.ID = uuid.New().String()
+		req.Name = SessionStepName(i+1, req.Method, req.URL)
+		req.CreatedAt = now
+		req.LastUsed = now
+		AddRequestToCollection(&collection, req)
+	}
+
+	errExists := fmt.Errorf("a collection named %q already exists", name)
+	err := s.editCollections(func(config *CollectionConfig) error {
+		if FindCollectionByName(config.Collections, name) != nil {
+			return errExists
+		}
+		config.Collections = append(config.Collections, collection)
+		return nil
+	})
+	if err == errExists {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+	return &collection, nil
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSaveSessionFlow(t *testing.T) {
+	s, err := NewStorageAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+
+	recorded := []SavedRequest{
+		{Method: "POST", URL: "{{base_url}}/login", Body: `{"user": "a"}`},
+		{Method: "GET", URL: "https://api.example.com/users?page=2", Headers: map[string]string{"Authorization": "Bearer {{token}}"}},
+		{Method: "DELETE", URL: "https://api.example.com/users/7"},
+	}
+
+	collection, err := s.SaveSessionFlow(" checkout flow ", recorded)
+	if err != nil {
+		t.Fatalf("SaveSessionFlow() error = %v", err)
+	}
+	if collection.Name != "checkout flow" || !strings.HasPrefix(collection.Description, "Recorded session, 3 requests") {
+		t.Errorf("Unexpected collection: %q / %q", collection.Name, collection.Description)
+	}
+
+	config, err := s.LoadCollections()
+	if err != nil {
+		t.Fatalf("LoadCollections() error = %v", err)
+	}
+	saved := FindCollectionByName(config.Collections, "checkout flow")
+	if saved == nil || len(saved.Requests) != 3 {
+		t.Fatalf("Expected the flow to be saved with 3 requests, got %+v", saved)
+	}
+
+	wantNames := []string{"1. POST {{base_url}}/login", "2. GET /users", "3. DELETE /users/7"}
+	for i, req := range saved.Requests {
+		if req.Name != wantNames[i] {
+			t.Errorf("Step %d name = %q, want %q", i, req.Name, wantNames[i])
+		}
+		if req.ID == "" {
+			t.Errorf("Step %d has no ID", i)
+		}
+	}
+	if saved.Requests[1].Headers["Authorization"] != "Bearer {{token}}" {
+		t.Errorf("Expected variables to be kept unsubstituted, got %v", saved.Requests[1].Headers)
+	}
+
+	if _, err := s.SaveSessionFlow("checkout flow", recorded); err == nil {
+		t.Error("Expected an error for a name already taken")
+	}
+	if _, err := s.SaveSessionFlow("empty", nil); err == nil {
+		t.Error("Expected an error for a session without requests")
+	}
+}
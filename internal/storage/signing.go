@@ -0,0 +1,21 @@
+package storage
+
+// HMACAuth signs every send of a saved request with an HMAC. It mirrors
+// the signer of the http package field for field; the key may reference
+// environment variables.
+type HMACAuth struct {
+	Algorithm       string `json:"algorithm,omitempty"`
+	Key             string `json:"key"`
+	Template        string `json:"template,omitempty"`
+	Header          string `json:"header,omitempty"`
+	Prefix          string `json:"prefix,omitempty"`
+	Encoding        string `json:"encoding,omitempty"`
+	TimestampHeader string `json:"timestamp_header,omitempty"`
+}
+
+// UpdateHMACAuth sets or, with nil, removes the HMAC signing of a saved request
+func (s *Storage) UpdateHMACAuth(id string, auth *HMACAuth) error {
+	return s.editRequest(id, func(req *SavedRequest) {
+		req.HMAC = auth
+	})
+}
@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EndpointStat counts the requests sent to one endpoint
+type EndpointStat struct {
+	Method string
+	// Endpoint is the host and path, with numeric and UUID path segments
+	// replaced by :id so /users/1 and /users/2 count together
+	Endpoint string
+	Count    int
+}
+
+// ServiceStat summarizes the requests sent to one host
+type ServiceStat struct {
+	Host     string
+	Requests int
+	Failed   int
+	// AvgLatencyMs averages the requests that got a response
+	AvgLatencyMs int64
+}
+
+// UsageStats summarizes the request history. It is computed locally and
+// never leaves the machine.
+type UsageStats struct {
+	Requests int
+	Failed   int
+	// Since is the time of the oldest execution in the history
+	Since     time.Time
+	Endpoints []EndpointStat
+	Services  []ServiceStat
+}
+
+// ComputeUsageStats summarizes history, grouping hosts like the grouped
+// request list does. Endpoints are sorted by use, services by host.
+func ComputeUsageStats(history []RequestExecution, aliases []ServiceAlias) UsageStats {
+	var stats UsageStats
+
+	endpoints := make(map[EndpointStat]int)
+	services := make(map[string]*ServiceStat)
+	latency := make(map[string]int64)
+	for _, exec := range history {
+		stats.Requests++
+		if stats.Since.IsZero() || exec.Timestamp.Before(stats.Since) {
+			stats.Since = exec.Timestamp
+		}
+
+		host := RequestHost(exec.URL, aliases)
+		endpoints[EndpointStat{Method: strings.ToUpper(exec.Method), Endpoint: host + endpointPath(exec.URL)}]++
+
+		service := services[host]
+		if service == nil {
+			service = &ServiceStat{Host: host}
+			services[host] = service
+		}
+		service.Requests++
+		if exec.Error != "" {
+			stats.Failed++
+			service.Failed++
+			continue
+		}
+		latency[host] += exec.ResponseTime
+	}
+
+	for endpoint, count := range endpoints {
+		endpoint.Count = count
+		stats.Endpoints = append(stats.Endpoints, endpoint)
+	}
+	sort.Slice(stats.Endpoints, func(i, j int) bool {
+		a, b := stats.Endpoints[i], stats.Endpoints[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Endpoint != b.Endpoint {
+			return a.Endpoint < b.Endpoint
+		}
+		return a.Method < b.Method
+	})
+
+	for host, service := range services {
+		if answered := service.Requests - service.Failed; answered > 0 {
+			service.AvgLatencyMs = latency[host] / int64(answered)
+		}
+		stats.Services = append(stats.Services, *service)
+	}
+	sort.Slice(stats.Services, func(i, j int) bool {
+		return stats.Services[i].Host < stats.Services[j].Host
+	})
+
+	return stats
+}
+
+// endpointPath returns the path of rawURL without query or fragment, with
+// ID-like segments replaced by :id
+func endpointPath(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	if _, rest, ok := strings.Cut(rawURL, "://"); ok {
+		rawURL = rest
+	} else if strings.HasPrefix(rawURL, "{{") {
+		if end := strings.Index(rawURL, "}}"); end > 0 {
+			rawURL = rawURL[end+2:]
+		}
+	}
+	if i := strings.IndexAny(rawURL, "?#"); i >= 0 {
+		rawURL = rawURL[:i]
+	}
+	i := strings.Index(rawURL, "/")
+	if i < 0 {
+		return ""
+	}
+
+	segments := strings.Split(rawURL[i:], "/")
+	for j, segment := range segments {
+		if isIDSegment(segment) {
+			segments[j] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func isIDSegment(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	if len(segment) == 36 && strings.Count(segment, "-") == 4 {
+		return true
+	}
+	for _, r := range segment {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// DiskUsage returns the total size of the files under dir
+func DiskUsage(dir string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			total += info.Size()
+		}
+		return nil
+	})
+	return total, err
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestComputeUsageStats(t *testing.T) {
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	history := []RequestExecution{
+		{Method: "GET", URL: "https://api.example.com/users/1?expand=team", ResponseTime: 100, Timestamp: start.Add(time.Hour)},
+		{Method: "get", URL: "https://api.example.com/users/2", ResponseTime: 300, Timestamp: start},
+		{Method: "POST", URL: "https://api.example.com/users", Error: "connection refused", Timestamp: start.Add(2 * time.Hour)},
+		{Method: "GET", URL: "users-api/7", ResponseTime: 50, Timestamp: start.Add(3 * time.Hour)},
+	}
+	aliases := []ServiceAlias{{Name: "users-api", Target: "https://users.internal"}}
+
+	stats := ComputeUsageStats(history, aliases)
+	if stats.Requests != 4 || stats.Failed != 1 || !stats.Since.Equal(start) {
+		t.Errorf("Totals = %d requests, %d failed, since %v", stats.Requests, stats.Failed, stats.Since)
+	}
+
+	top := stats.Endpoints[0]
+	if top != (EndpointStat{Method: "GET", Endpoint: "api.example.com/users/:id", Count: 2}) {
+		t.Errorf("Top endpoint = %+v", top)
+	}
+
+	if len(stats.Services) != 2 {
+		t.Fatalf("Services = %+v", stats.Services)
+	}
+	api := stats.Services[0]
+	if api.Host != "api.example.com" || api.Requests != 3 || api.Failed != 1 || api.AvgLatencyMs != 200 {
+		t.Errorf("Expected failed requests left out of the average, got %+v", api)
+	}
+	if stats.Services[1].Host != "users-api" {
+		t.Errorf("Expected the alias as a service, got %+v", stats.Services[1])
+	}
+}
+
+func TestDiskUsage(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.json"), make([]byte, 100), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "workspaces", "client"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "workspaces", "client", "b.json"), make([]byte, 50), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := DiskUsage(dir); err != nil || got != 150 {
+		t.Errorf("DiskUsage() = %d, %v, want 150", got, err)
+	}
+}
@@ -3,6 +3,7 @@ package storage
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 )
 
 // RequestTemplate represents a pre-configured request template
@@ -233,11 +234,12 @@ func GetBuiltInTemplates() []RequestTemplate {
 			Method:      "POST",
 			URL:         "{{API_URL}}/upload",
 			Headers: map[string]string{
-				"Accept": "application/json",
+				"Accept":       "application/json",
+				"Content-Type": "multipart/form-data",
 			},
-			Body:        "Note: Use multipart/form-data encoding for file uploads",
+			Body:        "file=@{{FILE_PATH}}",
 			QueryParams: make(map[string]string),
-			Variables:   []string{"API_URL"},
+			Variables:   []string{"API_URL", "FILE_PATH"},
 		},
 		{
 			ID:          "search-query",
@@ -286,6 +288,7 @@ func GetTemplateCategories() []string {
 	for cat := range categoriesMap {
 		categories = append(categories, cat)
 	}
+	sort.Strings(categories)
 
 	return categories
 }
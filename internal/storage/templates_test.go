@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"sort"
 	"testing"
 )
 
@@ -64,6 +65,9 @@ func TestGetTemplateCategories(t *testing.T) {
 	if len(categories) == 0 {
 		t.Error("Expected at least one category")
 	}
+	if !sort.StringsAreSorted(categories) {
+		t.Errorf("Expected categories in order, got %v", categories)
+	}
 
 	expectedCategories := map[string]bool{
 		"REST":       true,
@@ -0,0 +1,252 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ThunderCollection is a collection exported from the Thunder Client VS Code
+// extension. Folders and requests are flat lists linked by containerId.
+type ThunderCollection struct {
+	Client         string           `json:"client"`
+	CollectionName string           `json:"collectionName"`
+	Folders        []ThunderFolder  `json:"folders"`
+	Requests       []ThunderRequest `json:"requests"`
+	Settings       ThunderSettings  `json:"settings"`
+}
+
+type ThunderFolder struct {
+	ID          string          `json:"_id"`
+	Name        string          `json:"name"`
+	ContainerID string          `json:"containerId"`
+	SortNum     float64         `json:"sortNum"`
+	Settings    ThunderSettings `json:"settings"`
+}
+
+type ThunderSettings struct {
+	Auth    *ThunderAuth    `json:"auth"`
+	Headers []ThunderHeader `json:"headers"`
+}
+
+type ThunderRequest struct {
+	Name        string          `json:"name"`
+	ContainerID string          `json:"containerId"`
+	URL         string          `json:"url"`
+	Method      string          `json:"method"`
+	SortNum     float64         `json:"sortNum"`
+	Headers     []ThunderHeader `json:"headers"`
+	Params      []ThunderParam  `json:"params"`
+	Body        *ThunderBody    `json:"body"`
+	Auth        *ThunderAuth    `json:"auth"`
+}
+
+type ThunderHeader struct {
+	Name       string `json:"name"`
+	Value      string `json:"value"`
+	IsDisabled bool   `json:"isDisabled"`
+}
+
+type ThunderParam struct {
+	Name       string `json:"name"`
+	Value      string `json:"value"`
+	IsPath     bool   `json:"isPath"`
+	IsDisabled bool   `json:"isDisabled"`
+}
+
+type ThunderBody struct {
+	Type    string          `json:"type"`
+	Raw     string          `json:"raw"`
+	Form    []ThunderHeader `json:"form"`
+	GraphQL *struct {
+		Query     string `json:"query"`
+		Variables string `json:"variables"`
+	} `json:"graphql"`
+}
+
+type ThunderAuth struct {
+	Type         string `json:"type"`
+	Bearer       string `json:"bearer"`
+	BearerPrefix string `json:"bearerPrefix"`
+	Basic        struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	} `json:"basic"`
+}
+
+// thunderContentTypes are the Content-Type headers of Thunder Client raw bodies
+var thunderContentTypes = map[string]string{
+	"json":        "application/json",
+	"xml":         "application/xml",
+	"text":        "text/plain",
+	"formencoded": "application/x-www-form-urlencoded",
+	"graphql":     "application/json",
+}
+
+// isThunderCollection reports whether a decoded JSON object looks like a
+// Thunder Client collection export
+func isThunderCollection(probe map[string]json.RawMessage) bool {
+	return probe["collectionName"] != nil && probe["requests"] != nil
+}
+
+// ImportFromThunderClient imports a Thunder Client collection export. Folders
+// become sub-collections and auth becomes headers.
+func ImportFromThunderClient(data []byte) (*Collection, error) {
+	var thunder ThunderCollection
+	if err := json.Unmarshal(data, &thunder); err != nil {
+		return nil, fmt.Errorf("failed to parse Thunder Client collection: %w", err)
+	}
+
+	sort.SliceStable(thunder.Folders, func(i, j int) bool {
+		return thunder.Folders[i].SortNum < thunder.Folders[j].SortNum
+	})
+	sort.SliceStable(thunder.Requests, func(i, j int) bool {
+		return thunder.Requests[i].SortNum < thunder.Requests[j].SortNum
+	})
+
+	collection := thunder.convertContainer("", thunder.CollectionName, thunder.Settings, importAuth{}, nil, map[string]bool{})
+	return &collection, nil
+}
+
+// convertContainer builds the collection for the folder with the given ID,
+// or for the collection itself when id is empty
+func (t ThunderCollection) convertContainer(id, name string, settings ThunderSettings, parentAuth importAuth, parentHeaders map[string]string, seen map[string]bool) Collection {
+	seen[id] = true
+	collection := CreateCollection(name, "")
+
+	auth := settings.Auth.convert(parentAuth)
+	headers := make(map[string]string, len(parentHeaders)+len(settings.Headers))
+	for k, v := range parentHeaders {
+		headers[k] = v
+	}
+	for _, h := range settings.Headers {
+		if !h.IsDisabled && h.Name != "" {
+			headers[h.Name] = h.Value
+		}
+	}
+
+	for _, r := range t.Requests {
+		if r.ContainerID == id {
+			AddRequestToCollection(&collection, r.convert(auth, headers))
+		}
+	}
+	for _, folder := range t.Folders {
+		if folder.ContainerID == id && !seen[folder.ID] {
+			AddSubCollection(&collection, t.convertContainer(folder.ID, folder.Name, folder.Settings, auth, headers, seen))
+		}
+	}
+	return collection
+}
+
+func (r ThunderRequest) convert(parentAuth importAuth, parentHeaders map[string]string) SavedRequest {
+	headers := make(map[string]string, len(parentHeaders)+len(r.Headers))
+	for k, v := range parentHeaders {
+		headers[k] = v
+	}
+	for _, h := range r.Headers {
+		if !h.IsDisabled && h.Name != "" {
+			headers[h.Name] = h.Value
+		}
+	}
+
+	// The URL usually carries the query string already; params only add
+	// what it lacks
+	params := make(map[string]string)
+	inURL := url.Values{}
+	if parsed, err := url.Parse(r.URL); err == nil {
+		inURL = parsed.Query()
+	}
+	for _, p := range r.Params {
+		if !p.IsDisabled && !p.IsPath && p.Name != "" && !inURL.Has(p.Name) {
+			params[p.Name] = p.Value
+		}
+	}
+
+	body := r.Body.text()
+	if body != "" && !hasHeader(headers, "Content-Type") {
+		if contentType, ok := thunderContentTypes[r.Body.Type]; ok {
+			headers["Content-Type"] = contentType
+		}
+	}
+
+	auth := r.Auth.convert(parentAuth)
+	description := auth.apply(headers, params)
+
+	now := time.Now()
+	return SavedRequest{
+		ID:          uuid.New().String(),
+		Name:        r.Name,
+		Description: description,
+		Method:      strings.ToUpper(r.Method),
+		URL:         r.URL,
+		Headers:     headers,
+		Body:        body,
+		QueryParams: params,
+		CreatedAt:   now,
+		LastUsed:    now,
+	}
+}
+
+// text returns the body as sent: raw bodies as is, url-encoded forms encoded
+// and GraphQL as a JSON query document. Multipart forms are not imported.
+func (b *ThunderBody) text() string {
+	if b == nil {
+		return ""
+	}
+
+	switch b.Type {
+	case "formencoded":
+		form := url.Values{}
+		for _, field := range b.Form {
+			if !field.IsDisabled && field.Name != "" {
+				form.Add(field.Name, field.Value)
+			}
+		}
+		return form.Encode()
+	case "graphql":
+		if b.GraphQL == nil {
+			return ""
+		}
+		doc := map[string]interface{}{"query": b.GraphQL.Query}
+		if vars := strings.TrimSpace(b.GraphQL.Variables); vars != "" {
+			doc["variables"] = json.RawMessage(vars)
+		}
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	case "formdata", "none", "":
+		return ""
+	default:
+		return b.Raw
+	}
+}
+
+func (a *ThunderAuth) convert(parent importAuth) importAuth {
+	if a == nil {
+		return parent
+	}
+
+	switch a.Type {
+	case "", "inherit":
+		return parent
+	case "bearer":
+		if a.BearerPrefix != "" && a.BearerPrefix != "Bearer" {
+			// A custom prefix goes in as a plain header
+			return importAuth{apiKey: "Authorization", apiValue: a.BearerPrefix + " " + a.Bearer}
+		}
+		return importAuth{bearer: a.Bearer}
+	case "basic":
+		return importAuth{basic: true, username: a.Basic.Username, password: a.Basic.Password}
+	case "none":
+		return importAuth{}
+	default:
+		return importAuth{unsupported: a.Type}
+	}
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"os"
+	"testing"
+)
+
+const thunderExport = `{
+  "client": "Thunder Client",
+  "collectionName": "Orders",
+  "dateExported": "2024-05-01T10:00:00.000Z",
+  "version": "1.1",
+  "folders": [
+    {"_id": "f2", "name": "Refunds", "containerId": "f1", "created": "", "sortNum": 10000},
+    {"_id": "f1", "name": "Admin", "containerId": "", "created": "", "sortNum": 20000,
+     "settings": {"auth": {"type": "basic", "basic": {"username": "{{user}}", "password": "{{pass}}"}}}}
+  ],
+  "requests": [
+    {"_id": "r2", "colId": "c1", "containerId": "", "name": "Create order", "url": "{{base}}/orders", "method": "POST", "sortNum": 20000,
+     "headers": [{"name": "Accept", "value": "application/json"}, {"name": "X-Debug", "value": "1", "isDisabled": true}],
+     "params": [],
+     "body": {"type": "json", "raw": "{\"sku\": \"A1\"}", "form": []},
+     "auth": {"type": "bearer", "bearer": "{{token}}"}},
+    {"_id": "r1", "colId": "c1", "containerId": "", "name": "List orders", "url": "{{base}}/orders?status=open", "method": "GET", "sortNum": 10000,
+     "params": [{"name": "status", "value": "open"}, {"name": "limit", "value": "10"}, {"name": "id", "value": "", "isPath": true}]},
+    {"_id": "r3", "colId": "c1", "containerId": "f2", "name": "Refund", "url": "{{base}}/refunds", "method": "POST", "sortNum": 10000,
+     "body": {"type": "formencoded", "form": [{"name": "order", "value": "42"}, {"name": "reason", "value": "damaged item"}]}}
+  ],
+  "settings": {"headers": [{"name": "X-Team", "value": "payments"}]}
+}`
+
+func TestImportFromThunderClient(t *testing.T) {
+	collection, err := ParseCollection([]byte(thunderExport))
+	if err != nil {
+		t.Fatalf("ParseCollection() error = %v", err)
+	}
+
+	if collection.Name != "Orders" || len(collection.Requests) != 2 {
+		t.Fatalf("Unexpected collection: %q with %d requests", collection.Name, len(collection.Requests))
+	}
+
+	list, create := collection.Requests[0], collection.Requests[1]
+	if list.Name != "List orders" {
+		t.Errorf("Expected requests in sortNum order, got %q first", list.Name)
+	}
+	if len(list.QueryParams) != 1 || list.QueryParams["limit"] != "10" {
+		t.Errorf("Expected only params missing from the URL, got %v", list.QueryParams)
+	}
+	if list.Headers["X-Team"] != "payments" {
+		t.Errorf("Expected collection headers, got %v", list.Headers)
+	}
+
+	if create.Headers["Authorization"] != "Bearer {{token}}" || create.Headers["Content-Type"] != "application/json" {
+		t.Errorf("Unexpected headers %v", create.Headers)
+	}
+	if _, ok := create.Headers["X-Debug"]; ok {
+		t.Error("Expected disabled headers to be skipped")
+	}
+	if create.Body != `{"sku": "A1"}` {
+		t.Errorf("Unexpected body %s", create.Body)
+	}
+
+	if len(collection.SubCollections) != 1 || collection.SubCollections[0].Name != "Admin" {
+		t.Fatalf("Expected the Admin folder, got %+v", collection.SubCollections)
+	}
+	admin := collection.SubCollections[0]
+	if len(admin.SubCollections) != 1 || len(admin.SubCollections[0].Requests) != 1 {
+		t.Fatalf("Expected the nested Refunds folder, got %+v", admin.SubCollections)
+	}
+	refund := admin.SubCollections[0].Requests[0]
+	if refund.Body != "order=42&reason=damaged+item" || refund.Headers["Content-Type"] != "application/x-www-form-urlencoded" {
+		t.Errorf("Unexpected form body %q with headers %v", refund.Body, refund.Headers)
+	}
+	if _, ok := refund.Headers["Authorization"]; ok || refund.Description == "" {
+		t.Errorf("Expected basic auth with variables to be left out with a note, got %v / %q", refund.Headers, refund.Description)
+	}
+}
+
+func TestParseThunderEnvironments(t *testing.T) {
+	envs, err := ParseEnvironments([]byte(`{"client": "Thunder Client", "envName": "Local", "data": [{"name": "base", "value": "http://localhost:8080"}]}`))
+	if err != nil {
+		t.Fatalf("ParseEnvironments() error = %v", err)
+	}
+	if len(envs) != 1 || envs[0].Name != "Local" || envs[0].Variables[0].Key != "base" {
+		t.Errorf("Unexpected environments: %+v", envs)
+	}
+}
+
+func TestImportCollectionFromFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	origHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", origHome)
+	os.Setenv("HOME", tmpDir)
+
+	s, err := NewStorage()
+	if err != nil {
+		t.Fatalf("NewStorage() error = %v", err)
+	}
+
+	if _, err := s.ImportCollection([]byte(thunderExport), ""); err != nil {
+		t.Fatalf("ImportCollection() error = %v", err)
+	}
+	result, err := s.ImportCollection([]byte(thunderExport), "")
+	if err != nil {
+		t.Fatalf("ImportCollection() error = %v", err)
+	}
+	if !result.Replaced {
+		t.Error("Expected a second import to replace the collection with the same name")
+	}
+
+	config, err := s.LoadCollections()
+	if err != nil {
+		t.Fatalf("LoadCollections() error = %v", err)
+	}
+	if len(config.Collections) != 1 || config.Collections[0].SourceURL != "" {
+		t.Errorf("Expected one local collection, got %+v", config.Collections)
+	}
+}
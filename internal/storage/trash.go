@@ -0,0 +1,234 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/abneribeiro/godev/internal/filelock"
+)
+
+// Kinds of items kept in the trash
+const (
+	TrashKindRequest     = "request"
+	TrashKindQuery       = "query"
+	TrashKindEnvironment = "environment"
+)
+
+const (
+	trashFile = "trash.json"
+	// maxTrashItems bounds the trash, the oldest items are dropped first
+	maxTrashItems = 200
+)
+
+// TrashItem is a deleted request, query or environment that can be restored
+type TrashItem struct {
+	ID        string          `json:"id"`
+	Kind      string          `json:"kind"`
+	Name      string          `json:"name"`
+	DeletedAt time.Time       `json:"deleted_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+type TrashConfig struct {
+	Version string      `json:"version"`
+	Items   []TrashItem `json:"items"`
+}
+
+// LoadTrash loads the trash of the workspace from disk
+func (s *Storage) LoadTrash() (*TrashConfig, error) {
+	dir, err := s.Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, trashFile)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return &TrashConfig{
+			Version: version,
+			Items:   []TrashItem{},
+		}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trash file: %w", err)
+	}
+
+	var config TrashConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse trash file: %w", err)
+	}
+
+	return &config, nil
+}
+
+// SaveTrash writes the trash to disk
+func (s *Storage) SaveTrash(config *TrashConfig) error {
+	dir, err := s.Dir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal trash: %w", err)
+	}
+
+	if err := filelock.WriteFile(filepath.Join(dir, trashFile), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write trash file: %w", err)
+	}
+
+	return nil
+}
+
+// GetTrash returns the items in the trash, most recently deleted first
+func (s *Storage) GetTrash() ([]TrashItem, error) {
+	config, err := s.LoadTrash()
+	if err != nil {
+		return nil, err
+	}
+
+	items := config.Items
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+	return items, nil
+}
+
+// MoveToTrash archives a deleted item so it can be restored later
+func (s *Storage) MoveToTrash(kind, name string, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal deleted %s: %w", kind, err)
+	}
+
+	config, err := s.LoadTrash()
+	if err != nil {
+		return err
+	}
+
+	config.Items = append(config.Items, TrashItem{
+		ID:        uuid.New().String(),
+		Kind:      kind,
+		Name:      name,
+		DeletedAt: time.Now(),
+		Data:      raw,
+	})
+	if len(config.Items) > maxTrashItems {
+		config.Items = config.Items[len(config.Items)-maxTrashItems:]
+	}
+
+	return s.SaveTrash(config)
+}
+
+// DeleteFromTrash permanently removes an item from the trash and returns it
+func (s *Storage) DeleteFromTrash(id string) (TrashItem, error) {
+	config, err := s.LoadTrash()
+	if err != nil {
+		return TrashItem{}, err
+	}
+
+	for i, item := range config.Items {
+		if item.ID == id {
+			config.Items = append(config.Items[:i], config.Items[i+1:]...)
+			return item, s.SaveTrash(config)
+		}
+	}
+
+	return TrashItem{}, fmt.Errorf("trash item not found: %s", id)
+}
+
+// EmptyTrash permanently removes every item from the trash
+func (s *Storage) EmptyTrash() error {
+	return s.SaveTrash(&TrashConfig{
+		Version: version,
+		Items:   []TrashItem{},
+	})
+}
+
+// RestoreFromTrash puts a deleted request or environment back and removes it
+// from the trash. Queries live in the database storage and are restored there.
+func (s *Storage) RestoreFromTrash(id string) error {
+	config, err := s.LoadTrash()
+	if err != nil {
+		return err
+	}
+
+	var item *TrashItem
+	for i := range config.Items {
+		if config.Items[i].ID == id {
+			item = &config.Items[i]
+			break
+		}
+	}
+	if item == nil {
+		return fmt.Errorf("trash item not found: %s", id)
+	}
+
+	switch item.Kind {
+	case TrashKindRequest:
+		var requests []SavedRequest
+		if err := json.Unmarshal(item.Data, &requests); err != nil {
+			return fmt.Errorf("failed to parse deleted request: %w", err)
+		}
+		if err := s.restoreRequests(requests); err != nil {
+			return err
+		}
+
+	case TrashKindEnvironment:
+		var env Environment
+		if err := json.Unmarshal(item.Data, &env); err != nil {
+			return fmt.Errorf("failed to parse deleted environment: %w", err)
+		}
+		if err := s.restoreEnvironment(env); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("cannot restore %s items here", item.Kind)
+	}
+
+	_, err = s.DeleteFromTrash(id)
+	return err
+}
+
+// restoreRequests adds back requests whose IDs are not in use
+func (s *Storage) restoreRequests(requests []SavedRequest) error {
+	return s.edit(func(c *Config) error {
+		existing := make(map[string]bool, len(c.Requests))
+		for _, req := range c.Requests {
+			existing[req.ID] = true
+		}
+
+		for _, req := range requests {
+			if !existing[req.ID] {
+				c.Requests = append(c.Requests, req)
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Storage) restoreEnvironment(env Environment) error {
+	return s.editEnvironments(func(config *EnvironmentConfig) error {
+		if config.Find(env.Name) != nil {
+			return fmt.Errorf("environment already exists: %s", env.Name)
+		}
+
+		config.Environments = append(config.Environments, env)
+		if config.ActiveEnvironment == "" {
+			config.ActiveEnvironment = env.Name
+		}
+		return nil
+	})
+}
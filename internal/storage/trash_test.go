@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDeleteRequestMovesToTrash(t *testing.T) {
+	tmpDir := t.TempDir()
+	origHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", origHome)
+	os.Setenv("HOME", tmpDir)
+
+	s, err := NewStorage()
+	if err != nil {
+		t.Fatalf("NewStorage() error = %v", err)
+	}
+
+	if err := s.SaveRequest("create user", "POST", "https://api.example.com/users", nil, `{"name": "a"}`, nil); err != nil {
+		t.Fatalf("SaveRequest() error = %v", err)
+	}
+	parent := s.GetRequests()[0]
+	if _, err := s.SaveVariant(parent.ID, "full", "POST", parent.URL, nil, `{}`, nil); err != nil {
+		t.Fatalf("SaveVariant() error = %v", err)
+	}
+
+	if err := s.DeleteRequest(parent.ID); err != nil {
+		t.Fatalf("DeleteRequest() error = %v", err)
+	}
+	if len(s.GetRequests()) != 0 {
+		t.Fatalf("Expected no requests after delete, got %d", len(s.GetRequests()))
+	}
+
+	items, err := s.GetTrash()
+	if err != nil {
+		t.Fatalf("GetTrash() error = %v", err)
+	}
+	if len(items) != 1 || items[0].Kind != TrashKindRequest || items[0].Name != "create user" {
+		t.Fatalf("Unexpected trash: %+v", items)
+	}
+
+	if err := s.RestoreFromTrash(items[0].ID); err != nil {
+		t.Fatalf("RestoreFromTrash() error = %v", err)
+	}
+
+	// The request comes back together with its variant
+	requests := s.GetRequests()
+	if len(requests) != 2 || requests[0].ID != parent.ID || requests[1].VariantName != "full" {
+		t.Errorf("Unexpected requests after restore: %+v", requests)
+	}
+
+	if items, _ := s.GetTrash(); len(items) != 0 {
+		t.Errorf("Expected empty trash after restore, got %d items", len(items))
+	}
+}
+
+func TestDeleteEnvironmentMovesToTrash(t *testing.T) {
+	tmpDir := t.TempDir()
+	origHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", origHome)
+	os.Setenv("HOME", tmpDir)
+
+	s := &Storage{}
+
+	if err := s.AddEnvironment("dev"); err != nil {
+		t.Fatalf("AddEnvironment() error = %v", err)
+	}
+	if err := s.AddVariable("dev", "API_URL", "http://localhost"); err != nil {
+		t.Fatalf("AddVariable() error = %v", err)
+	}
+	if err := s.DeleteEnvironment("dev"); err != nil {
+		t.Fatalf("DeleteEnvironment() error = %v", err)
+	}
+
+	items, err := s.GetTrash()
+	if err != nil {
+		t.Fatalf("GetTrash() error = %v", err)
+	}
+	if len(items) != 1 || items[0].Kind != TrashKindEnvironment {
+		t.Fatalf("Unexpected trash: %+v", items)
+	}
+
+	// Restoring over an environment with the same name is refused
+	if err := s.AddEnvironment("dev"); err != nil {
+		t.Fatalf("AddEnvironment() error = %v", err)
+	}
+	if err := s.RestoreFromTrash(items[0].ID); err == nil {
+		t.Error("Expected error restoring over an existing environment")
+	}
+	if err := s.DeleteEnvironment("dev"); err != nil {
+		t.Fatalf("DeleteEnvironment() error = %v", err)
+	}
+
+	items, _ = s.GetTrash()
+	if err := s.RestoreFromTrash(items[len(items)-1].ID); err != nil {
+		t.Fatalf("RestoreFromTrash() error = %v", err)
+	}
+
+	config, err := s.LoadEnvironments()
+	if err != nil {
+		t.Fatalf("LoadEnvironments() error = %v", err)
+	}
+	if len(config.Environments) != 1 || len(config.Environments[0].Variables) != 1 {
+		t.Errorf("Unexpected environments after restore: %+v", config.Environments)
+	}
+}
+
+func TestTrashPermanentDelete(t *testing.T) {
+	tmpDir := t.TempDir()
+	origHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", origHome)
+	os.Setenv("HOME", tmpDir)
+
+	s := &Storage{}
+
+	if err := s.MoveToTrash(TrashKindQuery, "active users", map[string]string{"query": "SELECT 1"}); err != nil {
+		t.Fatalf("MoveToTrash() error = %v", err)
+	}
+	if err := s.MoveToTrash(TrashKindQuery, "orders", map[string]string{"query": "SELECT 2"}); err != nil {
+		t.Fatalf("MoveToTrash() error = %v", err)
+	}
+
+	items, _ := s.GetTrash()
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 trash items, got %d", len(items))
+	}
+
+	// Queries are restored by the database storage, not here
+	if err := s.RestoreFromTrash(items[0].ID); err == nil {
+		t.Error("Expected error restoring a query through the request storage")
+	}
+
+	removed, err := s.DeleteFromTrash(items[0].ID)
+	if err != nil {
+		t.Fatalf("DeleteFromTrash() error = %v", err)
+	}
+	if removed.ID != items[0].ID {
+		t.Errorf("DeleteFromTrash() returned %+v", removed)
+	}
+	if _, err := s.DeleteFromTrash(items[0].ID); err == nil {
+		t.Error("Expected error deleting a missing trash item")
+	}
+
+	if err := s.EmptyTrash(); err != nil {
+		t.Fatalf("EmptyTrash() error = %v", err)
+	}
+	if items, _ := s.GetTrash(); len(items) != 0 {
+		t.Errorf("Expected empty trash, got %d items", len(items))
+	}
+}
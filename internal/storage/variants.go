@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IsVariant reports whether the request is a variant of another saved request
+func (r SavedRequest) IsVariant() bool {
+	return r.ParentID != ""
+}
+
+// SaveVariant stores a named variant under a saved request. Variants of a
+// variant are attached to the original request so the tree stays one level deep.
+func (s *Storage) SaveVariant(parentID, variantName, method, url string, headers map[string]string, body string, queryParams map[string]string) (SavedRequest, error) {
+	variantName = strings.TrimSpace(variantName)
+	if variantName == "" {
+		return SavedRequest{}, fmt.Errorf("variant name cannot be empty")
+	}
+
+	parent, err := s.GetRequest(parentID)
+	if err != nil {
+		return SavedRequest{}, err
+	}
+	if parent.IsVariant() {
+		if parent, err = s.GetRequest(parent.ParentID); err != nil {
+			return SavedRequest{}, err
+		}
+	}
+
+	for _, existing := range s.GetVariants(parent.ID) {
+		if strings.EqualFold(existing.VariantName, variantName) {
+			return SavedRequest{}, fmt.Errorf("variant %q already exists", variantName)
+		}
+	}
+
+	now := time.Now()
+	variant := SavedRequest{
+		ID:              uuid.New().String(),
+		Name:            parent.Name + " – " + variantName,
+		Description:     parent.Description,
+		Method:          method,
+		URL:             url,
+		Headers:         headers,
+		Body:            body,
+		QueryParams:     queryParams,
+		CreatedAt:       now,
+		LastUsed:        now,
+		LatencyBudgetMs: parent.LatencyBudgetMs,
+		SizeBudgetBytes: parent.SizeBudgetBytes,
+		Assertions:      append([]ResponseAssertion(nil), parent.Assertions...),
+		VolatileFields:  append([]string(nil), parent.VolatileFields...),
+		HMAC:            parent.HMAC,
+		Auth:            parent.Auth,
+		ParentID:        parent.ID,
+		VariantName:     variantName,
+	}
+
+	err = s.edit(func(c *Config) error {
+		c.Requests = append(c.Requests, variant)
+		return nil
+	})
+	if err != nil {
+		return SavedRequest{}, err
+	}
+	return variant, nil
+}
+
+// GetVariants returns the variants of a saved request
+func (s *Storage) GetVariants(parentID string) []SavedRequest {
+	var variants []SavedRequest
+	for _, req := range s.config.Requests {
+		if req.ParentID == parentID {
+			variants = append(variants, req)
+		}
+	}
+	return variants
+}
+
+// groupVariants orders requests so every variant follows its parent. Variants
+// whose parent is missing are kept as top level entries.
+func groupVariants(requests []SavedRequest) []SavedRequest {
+	ids := make(map[string]bool, len(requests))
+	children := make(map[string][]SavedRequest)
+	for _, req := range requests {
+		ids[req.ID] = true
+	}
+	for _, req := range requests {
+		if req.IsVariant() && ids[req.ParentID] {
+			children[req.ParentID] = append(children[req.ParentID], req)
+		}
+	}
+
+	grouped := make([]SavedRequest, 0, len(requests))
+	for _, req := range requests {
+		if req.IsVariant() && ids[req.ParentID] {
+			continue
+		}
+		grouped = append(grouped, req)
+		grouped = append(grouped, children[req.ID]...)
+	}
+	return grouped
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSaveVariant(t *testing.T) {
+	tmpDir := t.TempDir()
+	origHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", origHome)
+	os.Setenv("HOME", tmpDir)
+
+	s, err := NewStorage()
+	if err != nil {
+		t.Fatalf("NewStorage() error = %v", err)
+	}
+
+	if err := s.SaveRequest("create user", "POST", "https://api.example.com/users", nil, `{"name": "a"}`, nil); err != nil {
+		t.Fatalf("SaveRequest() error = %v", err)
+	}
+	if err := s.SaveRequest("list users", "GET", "https://api.example.com/users", nil, "", nil); err != nil {
+		t.Fatalf("SaveRequest() error = %v", err)
+	}
+	parent := s.GetRequests()[0]
+
+	full, err := s.SaveVariant(parent.ID, "full", "POST", parent.URL, nil, `{"name": "a", "email": "a@example.com"}`, nil)
+	if err != nil {
+		t.Fatalf("SaveVariant() error = %v", err)
+	}
+	if full.Name != "create user – full" || full.ParentID != parent.ID {
+		t.Errorf("Unexpected variant: %+v", full)
+	}
+
+	// A variant of a variant is attached to the original request
+	minimal, err := s.SaveVariant(full.ID, "minimal", "POST", parent.URL, nil, `{}`, nil)
+	if err != nil {
+		t.Fatalf("SaveVariant() error = %v", err)
+	}
+	if minimal.ParentID != parent.ID {
+		t.Errorf("Expected variant to be attached to %s, got %s", parent.ID, minimal.ParentID)
+	}
+
+	if _, err := s.SaveVariant(parent.ID, "FULL", "POST", parent.URL, nil, "", nil); err == nil {
+		t.Error("Expected duplicate variant name to be rejected")
+	}
+
+	var names []string
+	for _, req := range s.GetRequests() {
+		names = append(names, req.Name)
+	}
+	expected := []string{"create user", "create user – full", "create user – minimal", "list users"}
+	if len(names) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, names)
+			break
+		}
+	}
+
+	if err := s.DeleteRequest(parent.ID); err != nil {
+		t.Fatalf("DeleteRequest() error = %v", err)
+	}
+	if got := s.GetRequests(); len(got) != 1 || got[0].Name != "list users" {
+		t.Errorf("Expected variants to be deleted with their parent, got %+v", got)
+	}
+}
@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/abneribeiro/godev/internal/filelock"
+	"github.com/abneribeiro/godev/internal/paths"
+)
+
+// DefaultWorkspace is stored directly in ~/.godev for compatibility with
+// configurations created before workspaces existed
+const DefaultWorkspace = "default"
+
+const (
+	workspacesDir = "workspaces"
+	workspaceFile = "workspace.json"
+)
+
+var workspaceNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,63}$`)
+
+type workspaceState struct {
+	Active string `json:"active"`
+}
+
+// baseDir returns the root data directory (~/.godev unless configured otherwise)
+func baseDir() (string, error) {
+	return paths.DataDir()
+}
+
+// ValidateWorkspaceName checks that a workspace name is safe to use as a directory
+func ValidateWorkspaceName(name string) error {
+	if !workspaceNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid workspace name %q: use letters, digits, '-' or '_'", name)
+	}
+	return nil
+}
+
+// WorkspaceDir returns the storage root of the named workspace
+func WorkspaceDir(name string) (string, error) {
+	base, err := baseDir()
+	if err != nil {
+		return "", err
+	}
+
+	if name == "" || name == DefaultWorkspace {
+		return base, nil
+	}
+	if err := ValidateWorkspaceName(name); err != nil {
+		return "", err
+	}
+	return filepath.Join(base, workspacesDir, name), nil
+}
+
+// ListWorkspaces returns the default workspace followed by named workspaces, sorted
+func ListWorkspaces() ([]string, error) {
+	workspaces := []string{DefaultWorkspace}
+
+	base, err := baseDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(base, workspacesDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return workspaces, nil
+		}
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	var named []string
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() != DefaultWorkspace && ValidateWorkspaceName(entry.Name()) == nil {
+			named = append(named, entry.Name())
+		}
+	}
+	sort.Strings(named)
+
+	return append(workspaces, named...), nil
+}
+
+// ActiveWorkspace returns the workspace selected last, or the default one
+func ActiveWorkspace() string {
+	base, err := baseDir()
+	if err != nil {
+		return DefaultWorkspace
+	}
+
+	data, err := os.ReadFile(filepath.Join(base, workspaceFile))
+	if err != nil {
+		return DefaultWorkspace
+	}
+
+	var state workspaceState
+	if err := json.Unmarshal(data, &state); err != nil || state.Active == "" {
+		return DefaultWorkspace
+	}
+
+	dir, err := WorkspaceDir(state.Active)
+	if err != nil {
+		return DefaultWorkspace
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return DefaultWorkspace
+	}
+
+	return state.Active
+}
+
+// CreateWorkspace creates the storage directory for a new workspace
+func CreateWorkspace(name string) error {
+	dir, err := WorkspaceDir(name)
+	if err != nil {
+		return err
+	}
+
+	// Use secure directory permissions (0700 - only owner can access)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create workspace directory: %w", err)
+	}
+	return nil
+}
+
+// SetActiveWorkspace creates the workspace if needed and remembers it as active
+func SetActiveWorkspace(name string) error {
+	if err := CreateWorkspace(name); err != nil {
+		return err
+	}
+
+	base, err := baseDir()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(workspaceState{Active: name}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal workspace state: %w", err)
+	}
+
+	if err := filelock.WriteFile(filepath.Join(base, workspaceFile), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write workspace state: %w", err)
+	}
+	return nil
+}
+
+// Workspace returns the name of the workspace this storage belongs to
+func (s *Storage) Workspace() string {
+	if s.workspace == "" {
+		return DefaultWorkspace
+	}
+	return s.workspace
+}
+
+// Dir returns the storage root of the workspace
+func (s *Storage) Dir() (string, error) {
+	if s.dir != "" {
+		return s.dir, nil
+	}
+	return WorkspaceDir(s.workspace)
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+)
+
+func TestValidateWorkspaceName(t *testing.T) {
+	valid := []string{"client-a", "client_b", "Project1"}
+	for _, name := range valid {
+		if err := ValidateWorkspaceName(name); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", name, err)
+		}
+	}
+
+	invalid := []string{"", "../escape", "a/b", "-leading", "with space"}
+	for _, name := range invalid {
+		if err := ValidateWorkspaceName(name); err == nil {
+			t.Errorf("Expected %q to be invalid", name)
+		}
+	}
+}
+
+func TestWorkspacesAreIsolated(t *testing.T) {
+	tmpDir := t.TempDir()
+	origHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", origHome)
+	os.Setenv("HOME", tmpDir)
+
+	if got := ActiveWorkspace(); got != DefaultWorkspace {
+		t.Fatalf("Expected default workspace, got %s", got)
+	}
+
+	defaultStore, err := NewStorage()
+	if err != nil {
+		t.Fatalf("NewStorage() error = %v", err)
+	}
+	if err := defaultStore.SaveRequest("default req", "GET", "https://a.example.com", nil, "", nil); err != nil {
+		t.Fatalf("SaveRequest() error = %v", err)
+	}
+
+	if err := SetActiveWorkspace("client-b"); err != nil {
+		t.Fatalf("SetActiveWorkspace() error = %v", err)
+	}
+	if got := ActiveWorkspace(); got != "client-b" {
+		t.Fatalf("Expected client-b to be active, got %s", got)
+	}
+
+	store, err := NewStorage()
+	if err != nil {
+		t.Fatalf("NewStorage() error = %v", err)
+	}
+	if store.Workspace() != "client-b" {
+		t.Errorf("Expected storage for client-b, got %s", store.Workspace())
+	}
+	if len(store.GetRequests()) != 0 {
+		t.Errorf("Expected new workspace to have no requests, got %d", len(store.GetRequests()))
+	}
+
+	if err := store.SaveEnvironments(&EnvironmentConfig{Version: envConfigVersion}); err != nil {
+		t.Fatalf("SaveEnvironments() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, paths.LegacyDirName, workspacesDir, "client-b", envConfigFile)); err != nil {
+		t.Errorf("Expected environments to be stored in the workspace directory: %v", err)
+	}
+
+	workspaces, err := ListWorkspaces()
+	if err != nil {
+		t.Fatalf("ListWorkspaces() error = %v", err)
+	}
+	if len(workspaces) != 2 || workspaces[0] != DefaultWorkspace || workspaces[1] != "client-b" {
+		t.Errorf("Unexpected workspaces: %v", workspaces)
+	}
+}
@@ -0,0 +1,313 @@
+// Package tuitest drives a Bubble Tea model from tests the way a terminal
+// would: it feeds key presses and window sizes through Update, runs the
+// commands Update returns in the background and delivers their messages
+// back, and exposes the rendered view without styling for assertions.
+//
+// Messages from commands are delivered between the driver's own messages
+// and while waiting, so a flow reads like a script:
+//
+//	d := tuitest.New(t, model).Resize(120, 40)
+//	d.Type("https://example.com").Press("enter")
+//	d.WaitFor("200 OK")
+package tuitest
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DefaultTimeout is how long WaitFor waits for a view by default
+const DefaultTimeout = 5 * time.Second
+
+// Driver feeds messages to a model and runs the commands it returns
+type Driver struct {
+	t     testing.TB
+	model tea.Model
+	quit  bool
+
+	// Timeout bounds WaitFor; it defaults to DefaultTimeout
+	Timeout time.Duration
+
+	msgs chan tea.Msg
+	done chan struct{}
+	once sync.Once
+}
+
+// New returns a driver for model. Init is not run: tests start from the
+// state they set up, without timers or cursor blinks in flight.
+func New(t testing.TB, model tea.Model) *Driver {
+	t.Helper()
+	d := &Driver{
+		t:       t,
+		model:   model,
+		Timeout: DefaultTimeout,
+		msgs:    make(chan tea.Msg, 256),
+		done:    make(chan struct{}),
+	}
+	t.Cleanup(func() { d.once.Do(func() { close(d.done) }) })
+	return d
+}
+
+// Model returns the model as of the last message
+func (d *Driver) Model() tea.Model {
+	return d.model
+}
+
+// Quit reports whether the model asked the program to quit
+func (d *Driver) Quit() bool {
+	return d.quit
+}
+
+// Send delivers the messages from finished commands, then each of msgs
+func (d *Driver) Send(msgs ...tea.Msg) *Driver {
+	d.t.Helper()
+	d.drain()
+	for _, msg := range msgs {
+		d.update(msg)
+	}
+	return d
+}
+
+// Resize sends a window size
+func (d *Driver) Resize(width, height int) *Driver {
+	d.t.Helper()
+	return d.Send(tea.WindowSizeMsg{Width: width, Height: height})
+}
+
+// Press sends keys by the names tea.KeyMsg.String gives them, such as
+// "enter", "ctrl+s", "shift+tab", "alt+x" or "q"
+func (d *Driver) Press(keys ...string) *Driver {
+	d.t.Helper()
+	for _, key := range keys {
+		msg, ok := ParseKey(key)
+		if !ok {
+			d.t.Fatalf("tuitest: unknown key %q", key)
+		}
+		d.Send(msg)
+	}
+	return d
+}
+
+// Type sends text one key at a time; newlines are sent as enter
+func (d *Driver) Type(text string) *Driver {
+	d.t.Helper()
+	for _, r := range text {
+		if r == '\n' {
+			d.Send(tea.KeyMsg{Type: tea.KeyEnter})
+			continue
+		}
+		d.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return d
+}
+
+// View returns the current view with colors and styles removed
+func (d *Driver) View() string {
+	return StripANSI(d.model.View())
+}
+
+// WaitFor delivers messages from commands until the view contains every
+// one of texts, failing the test on timeout
+func (d *Driver) WaitFor(texts ...string) *Driver {
+	d.t.Helper()
+	if !d.waitUntil(func(view string) bool { return containsAll(view, texts) }) {
+		d.t.Fatalf("tuitest: view does not contain %q after %v:\n%s", texts, d.Timeout, d.View())
+	}
+	return d
+}
+
+// WaitForModel delivers messages from commands until cond holds for the
+// model, failing the test on timeout
+func (d *Driver) WaitForModel(cond func(tea.Model) bool) *Driver {
+	d.t.Helper()
+	if !d.waitUntil(func(string) bool { return cond(d.model) }) {
+		d.t.Fatalf("tuitest: condition not met after %v:\n%s", d.Timeout, d.View())
+	}
+	return d
+}
+
+// WaitForQuit delivers messages from commands until the model quits,
+// failing the test on timeout
+func (d *Driver) WaitForQuit() *Driver {
+	d.t.Helper()
+	if !d.waitUntil(func(string) bool { return d.quit }) {
+		d.t.Fatalf("tuitest: the model did not quit after %v:\n%s", d.Timeout, d.View())
+	}
+	return d
+}
+
+// AssertView fails the test unless the view contains every one of texts
+func (d *Driver) AssertView(texts ...string) *Driver {
+	d.t.Helper()
+	d.drain()
+	view := d.View()
+	for _, text := range texts {
+		if !strings.Contains(view, text) {
+			d.t.Errorf("tuitest: view does not contain %q:\n%s", text, view)
+		}
+	}
+	return d
+}
+
+// AssertNoView fails the test if the view contains any of texts
+func (d *Driver) AssertNoView(texts ...string) *Driver {
+	d.t.Helper()
+	d.drain()
+	view := d.View()
+	for _, text := range texts {
+		if strings.Contains(view, text) {
+			d.t.Errorf("tuitest: view unexpectedly contains %q:\n%s", text, view)
+		}
+	}
+	return d
+}
+
+func (d *Driver) waitUntil(cond func(view string) bool) bool {
+	deadline := time.NewTimer(d.Timeout)
+	defer deadline.Stop()
+	for {
+		d.drain()
+		if cond(d.View()) {
+			return true
+		}
+		select {
+		case msg := <-d.msgs:
+			d.update(msg)
+		case <-deadline.C:
+			return false
+		}
+	}
+}
+
+// drain delivers the messages of the commands that already finished
+func (d *Driver) drain() {
+	for {
+		select {
+		case msg := <-d.msgs:
+			d.update(msg)
+		default:
+			return
+		}
+	}
+}
+
+func (d *Driver) update(msg tea.Msg) {
+	switch msg := msg.(type) {
+	case nil:
+		return
+	case tea.QuitMsg:
+		d.quit = true
+		return
+	case tea.BatchMsg:
+		for _, cmd := range msg {
+			d.run(cmd)
+		}
+		return
+	}
+	// tea.Sequence returns an unexported list of commands, run in order
+	if cmds, ok := commandList(msg); ok {
+		d.runInOrder(cmds)
+		return
+	}
+
+	var cmd tea.Cmd
+	d.model, cmd = d.model.Update(msg)
+	d.run(cmd)
+}
+
+// run runs cmd in the background. Commands still running when the test
+// ends, such as timers, are abandoned.
+func (d *Driver) run(cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	go func() { d.deliver(cmd()) }()
+}
+
+func (d *Driver) runInOrder(cmds []tea.Cmd) {
+	go func() {
+		for _, cmd := range cmds {
+			if cmd != nil {
+				d.deliver(cmd())
+			}
+		}
+	}()
+}
+
+func (d *Driver) deliver(msg tea.Msg) {
+	if msg == nil {
+		return
+	}
+	select {
+	case d.msgs <- msg:
+	case <-d.done:
+	}
+}
+
+var cmdType = reflect.TypeOf((tea.Cmd)(nil))
+
+func commandList(msg tea.Msg) ([]tea.Cmd, bool) {
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Slice || v.Type().Elem() != cmdType {
+		return nil, false
+	}
+	cmds := make([]tea.Cmd, v.Len())
+	for i := range cmds {
+		cmds[i] = v.Index(i).Interface().(tea.Cmd)
+	}
+	return cmds, true
+}
+
+// keyTypes maps the names of the special keys to their types
+var keyTypes = func() map[string]tea.KeyType {
+	types := make(map[string]tea.KeyType)
+	for k := tea.KeyType(-128); k < 128; k++ {
+		if name := k.String(); name != "" && k != tea.KeyRunes {
+			types[name] = k
+		}
+	}
+	return types
+}()
+
+// ParseKey returns the key message whose String is key
+func ParseKey(key string) (tea.KeyMsg, bool) {
+	if key == "" {
+		return tea.KeyMsg{}, false
+	}
+	if k, ok := keyTypes[key]; ok {
+		return tea.KeyMsg{Type: k}, true
+	}
+	alt := false
+	if rest, ok := strings.CutPrefix(key, "alt+"); ok && rest != "" {
+		alt, key = true, rest
+		if k, ok := keyTypes[key]; ok {
+			return tea.KeyMsg{Type: k, Alt: true}, true
+		}
+	}
+	if runes := []rune(key); len(runes) == 1 {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: runes, Alt: alt}, true
+	}
+	return tea.KeyMsg{}, false
+}
+
+var ansiSequence = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(\x07|\x1b\\)`)
+
+// StripANSI removes terminal escape sequences from s
+func StripANSI(s string) string {
+	return ansiSequence.ReplaceAllString(s, "")
+}
+
+func containsAll(s string, texts []string) bool {
+	for _, text := range texts {
+		if !strings.Contains(s, text) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package tuitest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type loadedMsg string
+
+// counter counts "+" presses, loads a value in the background on "l" and
+// quits on "q"
+type counter struct {
+	count  int
+	loaded string
+	width  int
+}
+
+func (c counter) Init() tea.Cmd { return nil }
+
+func (c counter) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		c.width = msg.Width
+	case loadedMsg:
+		c.loaded = string(msg)
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "+":
+			c.count++
+		case "l":
+			return c, tea.Batch(func() tea.Msg {
+				time.Sleep(10 * time.Millisecond)
+				return loadedMsg("ready")
+			})
+		case "ctrl+s":
+			return c, tea.Sequence(func() tea.Msg { return loadedMsg("saved") }, tea.Quit)
+		case "q":
+			return c, tea.Quit
+		}
+	}
+	return c, nil
+}
+
+func (c counter) View() string {
+	return fmt.Sprintf("\x1b[1mcount %d\x1b[0m width %d loaded %q", c.count, c.width, c.loaded)
+}
+
+func TestDriver(t *testing.T) {
+	d := New(t, counter{})
+	d.Resize(80, 24).Type("++").Press("+")
+	d.AssertView("count 3", "width 80")
+
+	d.Press("l").WaitFor(`loaded "ready"`)
+	if d.Quit() {
+		t.Fatal("Expected the model to keep running")
+	}
+
+	d.Timeout = time.Second
+	d.Press("ctrl+s").WaitForQuit()
+	if d.Model().(counter).loaded != "saved" {
+		t.Error("Expected the sequence to run in order before quitting")
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	for _, key := range []string{"enter", "esc", "tab", "shift+tab", "ctrl+s", "ctrl+c", "up", "pgdown", "backspace", " ", "q", "G", "alt+x", "alt+enter"} {
+		msg, ok := ParseKey(key)
+		if !ok || msg.String() != key {
+			t.Errorf("ParseKey(%q) = %q, %v", key, msg.String(), ok)
+		}
+	}
+	if _, ok := ParseKey("ctrl+nothing"); ok {
+		t.Error("Expected an unknown key to be rejected")
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	if got := StripANSI("\x1b[38;2;1;2;3mhi\x1b[0m \x1b]0;title\x07there"); got != "hi there" {
+		t.Errorf("StripANSI() = %q", got)
+	}
+}
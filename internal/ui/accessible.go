@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// plainOutput is set in accessible mode and switches every border to ASCII
+var plainOutput bool
+
+// accessibleLabels spells out the symbols the views use, so screen readers
+// and terminals without Unicode get words instead
+var accessibleLabels = strings.NewReplacer(
+	"✓", "[OK]",
+	"✗", "[ERROR]",
+	"⚠", "[WARNING]",
+	"★", "(active)",
+	"•", "|",
+	"▸", "/",
+	"└", "-",
+	"–", "-",
+	"…", "...",
+	"↑↓", "Up/Down",
+	"↑/↓", "Up/Down",
+	"←/→", "Left/Right",
+	"↑", "Up",
+	"↓", "Down",
+	"←", "Left",
+	"→", "Right",
+)
+
+func roundedBorder() lipgloss.Border {
+	if plainOutput {
+		return lipgloss.ASCIIBorder()
+	}
+	return lipgloss.RoundedBorder()
+}
+
+func normalBorder() lipgloss.Border {
+	if plainOutput {
+		return lipgloss.ASCIIBorder()
+	}
+	return lipgloss.NormalBorder()
+}
+
+// SetAccessible turns on the no-color mode: styling is stripped, borders are
+// plain ASCII and symbols are replaced by text labels
+func (m *Model) SetAccessible(accessible bool) {
+	m.accessible = accessible
+	plainOutput = accessible
+	if accessible {
+		lipgloss.SetColorProfile(termenv.Ascii)
+		m.spinner.Spinner = spinner.Line
+	}
+	buildStyles()
+}
+
+// accessibleView replaces symbols with labels and drops the padding left at
+// the end of lines by centering, which screen readers would read as blanks
+func accessibleView(view string) string {
+	lines := strings.Split(accessibleLabels.Replace(view), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
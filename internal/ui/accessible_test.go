@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestAccessibleView(t *testing.T) {
+	view := "  ✓ Saved   \n⚠ Delete? • ↑↓: navigate • ←/→: method  \n … ▸ API ▸ Get user"
+	got := accessibleView(view)
+
+	want := "  [OK] Saved\n[WARNING] Delete? | Up/Down: navigate | Left/Right: method\n ... / API / Get user"
+	if got != want {
+		t.Errorf("accessibleView() = %q, want %q", got, want)
+	}
+}
+
+func TestPlainBorders(t *testing.T) {
+	defer func() { plainOutput = false }()
+
+	if roundedBorder() != lipgloss.RoundedBorder() {
+		t.Error("Expected rounded border by default")
+	}
+
+	plainOutput = true
+	box := lipgloss.NewStyle().Border(roundedBorder()).Render("url")
+	for _, r := range box {
+		if r > 127 {
+			t.Fatalf("Expected ASCII border, got %q", box)
+		}
+	}
+	if !strings.Contains(box, "+") {
+		t.Errorf("Expected ASCII corners, got %q", box)
+	}
+}
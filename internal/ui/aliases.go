@@ -0,0 +1,273 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// Aliases is the screen of the service aliases of the environment open in
+// the environment editor
+type Aliases struct {
+	nameInput   textinput.Model
+	targetInput textinput.Model
+	selected    int
+
+	// editing is set while an alias is typed; confirmingDelete while the
+	// deletion of the selected one is confirmed
+	editing          bool
+	confirmingDelete bool
+	err              string
+}
+
+func newAliases() Aliases {
+	nameInput := textinput.New()
+	nameInput.Placeholder = "users-api"
+	nameInput.CharLimit = 64
+	nameInput.Width = 30
+
+	targetInput := textinput.New()
+	targetInput.Placeholder = "{{API_URL}}/users"
+	targetInput.CharLimit = 500
+	targetInput.Width = 50
+
+	return Aliases{nameInput: nameInput, targetInput: targetInput}
+}
+
+// currentEnvironment returns the environment open in the editor
+func (e *Environments) currentEnvironment() *storage.Environment {
+	for i := range e.list {
+		if e.list[i].Name == e.current {
+			return &e.list[i]
+		}
+	}
+	return nil
+}
+
+// open lists the service aliases of the environment being edited
+func (a *Aliases) open(h host) {
+	if h.environments().currentEnvironment() == nil {
+		return
+	}
+
+	a.nameInput.SetValue("")
+	a.nameInput.Blur()
+	a.targetInput.SetValue("")
+	a.targetInput.Blur()
+	a.selected = 0
+	a.editing = false
+	a.confirmingDelete = false
+	a.err = ""
+	h.navigate(StateAliases)
+}
+
+// reload refreshes the environments after an alias was changed
+func (a *Aliases) reload(h host) {
+	if err := h.environments().reload(h.store()); err != nil {
+		a.err = err.Error()
+	}
+}
+
+func (a *Aliases) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	envs := h.environments()
+	env := envs.currentEnvironment()
+	if env == nil {
+		h.navigate(StateEnvironmentEditor)
+		return nil
+	}
+
+	if a.editing {
+		switch msg.String() {
+		case "ctrl+c", "ctrl+q":
+			return tea.Quit
+		case "esc":
+			a.editing = false
+			a.nameInput.Blur()
+			a.targetInput.Blur()
+			return nil
+		case "tab", "shift+tab":
+			if a.nameInput.Focused() {
+				a.nameInput.Blur()
+				a.targetInput.Focus()
+			} else {
+				a.targetInput.Blur()
+				a.nameInput.Focus()
+			}
+			return nil
+		case "enter":
+			if a.nameInput.Focused() {
+				a.nameInput.Blur()
+				a.targetInput.Focus()
+				return nil
+			}
+			name := strings.TrimSpace(a.nameInput.Value())
+			if err := h.store().AddAlias(envs.current, name, a.targetInput.Value()); err != nil {
+				a.err = err.Error()
+				return nil
+			}
+			a.reload(h)
+			a.editing = false
+			a.err = ""
+			a.targetInput.Blur()
+			return nil
+		}
+
+		if a.nameInput.Focused() {
+			a.nameInput, cmd = a.nameInput.Update(msg)
+		} else {
+			a.targetInput, cmd = a.targetInput.Update(msg)
+		}
+		return cmd
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		if a.confirmingDelete {
+			a.confirmingDelete = false
+			return nil
+		}
+		h.navigate(StateEnvironmentEditor)
+		return nil
+
+	case "up", "k":
+		if a.selected > 0 {
+			a.selected--
+		}
+		return nil
+
+	case "down", "j":
+		if a.selected < len(env.Aliases)-1 {
+			a.selected++
+		}
+		return nil
+
+	case "n", "a", "e":
+		if h.blockedByReadOnly("edit alias") {
+			return nil
+		}
+		a.nameInput.SetValue("")
+		a.targetInput.SetValue("")
+		if msg.String() == "e" {
+			if a.selected >= len(env.Aliases) {
+				return nil
+			}
+			alias := env.Aliases[a.selected]
+			a.nameInput.SetValue(alias.Name)
+			a.targetInput.SetValue(alias.Target)
+		}
+		a.editing = true
+		a.err = ""
+		a.targetInput.Blur()
+		a.nameInput.Focus()
+		return nil
+
+	case "d":
+		if h.blockedByReadOnly("delete alias") {
+			return nil
+		}
+		if a.selected < len(env.Aliases) {
+			a.confirmingDelete = true
+		}
+		return nil
+
+	case "y":
+		if !a.confirmingDelete || a.selected >= len(env.Aliases) {
+			return nil
+		}
+		a.confirmingDelete = false
+		if err := h.store().DeleteAlias(envs.current, env.Aliases[a.selected].Name); err != nil {
+			a.err = err.Error()
+			return nil
+		}
+		a.reload(h)
+		if env := envs.currentEnvironment(); env != nil && a.selected >= len(env.Aliases) && a.selected > 0 {
+			a.selected--
+		}
+		return nil
+	}
+
+	return nil
+}
+
+func (a *Aliases) View(h host) string {
+	var b strings.Builder
+
+	env := h.environments().currentEnvironment()
+	if env == nil {
+		return ""
+	}
+
+	b.WriteString(TitleStyle.Render(i18n.Tf("title.aliases", env.Name, len(env.Aliases))))
+	b.WriteString("\n\n")
+
+	if len(env.Aliases) == 0 {
+		b.WriteString(MutedStyle.Render(i18n.T("aliases.empty")))
+		b.WriteString("\n")
+	}
+	for i, alias := range env.Aliases {
+		prefix := "  "
+		style := ListItemStyle
+		if i == a.selected {
+			prefix = "> "
+			style = ListItemSelectedStyle
+		}
+		b.WriteString(style.Render(fmt.Sprintf("%s%s → %s", prefix, alias.Name, alias.Target)))
+		b.WriteString("\n")
+		if resolved := storage.ReplaceVariables(alias.Target, env.Variables); resolved != alias.Target {
+			b.WriteString(MutedStyle.Render("    = " + resolved))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+
+	footer := i18n.T("footer.aliases")
+	if a.editing {
+		for _, field := range []struct {
+			label string
+			input textinput.Model
+		}{
+			{i18n.T("aliases.name"), a.nameInput},
+			{i18n.T("aliases.target"), a.targetInput},
+		} {
+			border := ColorBorder
+			if field.input.Focused() {
+				border = ColorAccent
+			}
+			b.WriteString(TextStyle.Render(field.label))
+			b.WriteString("\n")
+			b.WriteString(lipgloss.NewStyle().
+				Border(roundedBorder()).
+				BorderForeground(lipgloss.Color(border)).
+				Padding(0, 1).
+				Width(field.input.Width + 2).
+				Render(field.input.View()))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		footer = i18n.T("footer.env_new")
+	}
+
+	if a.confirmingDelete && a.selected < len(env.Aliases) {
+		b.WriteString(WarningStyle.Render(i18n.Tf("confirm.delete_alias", env.Aliases[a.selected].Name)))
+		b.WriteString("\n\n")
+	}
+	if a.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + a.err))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(RenderFooter(footer))
+
+	width, height := h.size()
+	return Center(width, height, b.String())
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+func TestAliasExpandedInBuilder(t *testing.T) {
+	store, err := storage.NewStorageAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	if err := store.AddEnvironment("dev"); err != nil {
+		t.Fatalf("AddEnvironment() error = %v", err)
+	}
+	if err := store.AddVariable("dev", "API_URL", "http://localhost:8080"); err != nil {
+		t.Fatalf("AddVariable() error = %v", err)
+	}
+	if err := store.AddAlias("dev", "users-api", "{{API_URL}}/users"); err != nil {
+		t.Fatalf("AddAlias() error = %v", err)
+	}
+	envConfig, err := store.LoadEnvironments()
+	if err != nil {
+		t.Fatalf("LoadEnvironments() error = %v", err)
+	}
+
+	m := Model{storage: store, envs: Environments{config: envConfig}, builder: RequestBuilder{urlInput: textinput.New(), method: "GET"}}
+	m.builder.urlInput.SetValue("users-api/42")
+
+	if got := m.buildRequest().URL; got != "http://localhost:8080/users/42" {
+		t.Errorf("Expected the alias to expand at send time, got %s", got)
+	}
+	if got := m.builder.aliasPreview(m.activeEnvironment()); got != "→ users-api: http://localhost:8080/users/42" {
+		t.Errorf("Expected the resolved preview, got %q", got)
+	}
+
+	m.builder.urlInput.SetValue("https://example.com/users-api")
+	if got := m.builder.aliasPreview(m.activeEnvironment()); got != "" {
+		t.Errorf("Expected no preview for a full URL, got %q", got)
+	}
+}
+
+func TestAliasScreenAddsToTheEditedEnvironment(t *testing.T) {
+	store, err := storage.NewStorageAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	if err := store.AddEnvironment("dev"); err != nil {
+		t.Fatalf("AddEnvironment() error = %v", err)
+	}
+
+	m := Model{state: StateEnvironmentEditor, storage: store, envs: newEnvironments(), aliases: newAliases()}
+	if err := m.envs.reload(store); err != nil {
+		t.Fatalf("reload() error = %v", err)
+	}
+	m.envs.current = "dev"
+
+	m.openAliases()
+	if m.state != StateAliases {
+		t.Fatalf("Expected the aliases screen, got state %v", m.state)
+	}
+
+	for _, msg := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("n")},
+		{Type: tea.KeyRunes, Runes: []rune("users-api")},
+		{Type: tea.KeyEnter},
+		{Type: tea.KeyRunes, Runes: []rune("https://api.example.com/users")},
+		{Type: tea.KeyEnter},
+	} {
+		updated, _ := m.handleKeyPress(msg)
+		m = updated.(Model)
+	}
+
+	env := m.envs.currentEnvironment()
+	if env == nil || len(env.Aliases) != 1 || env.Aliases[0].Name != "users-api" {
+		t.Fatalf("Expected the alias in the edited environment, got %+v (%s)", env, m.aliases.err)
+	}
+	if !strings.Contains(m.View(), "users-api → https://api.example.com/users") {
+		t.Errorf("Expected the alias listed, got:\n%s", m.View())
+	}
+}
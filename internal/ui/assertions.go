@@ -0,0 +1,483 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// assertionStep is the step of the guided assertion builder
+type assertionStep int
+
+const (
+	assertionStepList assertionStep = iota
+	assertionStepType
+	assertionStepField
+	assertionStepOperator
+	assertionStepValue
+)
+
+// maxAssertionChoices caps the suggestions shown at once
+const maxAssertionChoices = 8
+
+// Assertions is the assertions screen: the assertions of the saved request
+// in the builder, how the last response did on them and the guided builder
+// of a new one
+type Assertions struct {
+	list    []storage.ResponseAssertion
+	results []storage.AssertionResult
+	err     string
+
+	selected  int
+	step      assertionStep
+	draft     storage.ResponseAssertion
+	choices   []string
+	choiceIdx int
+	input     textinput.Model
+}
+
+func newAssertions() Assertions {
+	input := textinput.New()
+	input.CharLimit = 500
+	input.Width = 50
+
+	return Assertions{input: input}
+}
+
+// active returns the assertions of the loaded saved request, if unchanged
+func (a *Assertions) active(h host) []storage.ResponseAssertion {
+	if h.savedRequestID() == "" {
+		return nil
+	}
+	return a.list
+}
+
+// check evaluates the active assertions against the current response
+func (a *Assertions) check(h host) {
+	a.results = nil
+	response := h.responseViewer().response
+	if response == nil || response.Error != nil {
+		return
+	}
+	a.results = storage.CheckAssertions(a.active(h), response.StatusCode,
+		response.Body, response.Headers, response.ResponseTime.Milliseconds())
+}
+
+// open shows the assertions of the current saved request
+func (a *Assertions) open(h host) {
+	a.err = ""
+	if h.savedRequestID() == "" {
+		a.err = i18n.T("assertions.save_first")
+		return
+	}
+
+	h.navigate(StateAssertions)
+	a.step = assertionStepList
+	a.selected = 0
+}
+
+// startBuilder begins a new assertion at the type step
+func (a *Assertions) startBuilder() {
+	a.err = ""
+	a.draft = storage.ResponseAssertion{}
+	a.step = assertionStepType
+	a.choices = storage.AssertionTypes
+	a.choiceIdx = 0
+}
+
+// advanceBuilder moves to the next step the draft needs, skipping
+// the field for types without one and the operator when there is no choice
+func (a *Assertions) advanceBuilder(h host) {
+	switch a.step {
+	case assertionStepType:
+		if storage.AssertionNeedsField(a.draft.Type) {
+			a.step = assertionStepField
+			a.input.Placeholder = "data.items"
+			if a.draft.Type == storage.AssertHeader {
+				a.input.Placeholder = "Content-Type"
+			}
+			a.input.SetValue("")
+			a.input.Focus()
+			a.filterFields(h)
+			return
+		}
+		fallthrough
+
+	case assertionStepField:
+		operators := storage.AssertionOperators(a.draft.Type)
+		if len(operators) > 1 {
+			a.step = assertionStepOperator
+			a.input.Blur()
+			a.choices = operators
+			a.choiceIdx = 0
+			return
+		}
+		a.draft.Operator = operators[0]
+		fallthrough
+
+	case assertionStepOperator:
+		response := h.responseViewer().response
+		if a.draft.Operator == storage.OpExists {
+			a.saveDraft(h)
+			return
+		}
+		a.step = assertionStepValue
+		a.choices = nil
+		a.input.Placeholder = ""
+		if response != nil && a.draft.Operator != storage.OpMatches {
+			a.input.SetValue(storage.SuggestAssertionValue(a.draft, response.StatusCode,
+				response.Body, response.Headers, response.ResponseTime.Milliseconds()))
+		} else {
+			a.input.SetValue("")
+		}
+		a.input.CursorEnd()
+		a.input.Focus()
+	}
+}
+
+// fieldSuggestions lists header names or JSON paths of the last response
+func (a *Assertions) fieldSuggestions(h host) []string {
+	response := h.responseViewer().response
+	if response == nil || response.Error != nil {
+		return nil
+	}
+
+	switch a.draft.Type {
+	case storage.AssertHeader:
+		names := make([]string, 0, len(response.Headers))
+		for name := range response.Headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	case storage.AssertJSONLength:
+		return storage.SuggestJSONPaths(response.Body, true)
+	default:
+		return storage.SuggestJSONPaths(response.Body, false)
+	}
+}
+
+// filterFields narrows the field suggestions to those containing the input
+func (a *Assertions) filterFields(h host) {
+	query := strings.ToLower(strings.TrimSpace(a.input.Value()))
+	a.choices = nil
+	for _, field := range a.fieldSuggestions(h) {
+		if strings.Contains(strings.ToLower(field), query) {
+			a.choices = append(a.choices, field)
+		}
+	}
+	a.choiceIdx = 0
+}
+
+// saveDraft appends the finished draft to the saved request
+func (a *Assertions) saveDraft(h host) {
+	if err := a.draft.Validate(); err != nil {
+		a.err = err.Error()
+		return
+	}
+
+	assertions := append(append([]storage.ResponseAssertion(nil), a.list...), a.draft)
+	if !a.update(h, assertions) {
+		return
+	}
+	a.step = assertionStepList
+	a.input.Blur()
+	a.selected = len(a.list) - 1
+}
+
+// update stores the assertions and re-checks the current response
+func (a *Assertions) update(h host, assertions []storage.ResponseAssertion) bool {
+	a.err = ""
+	if store := h.store(); store != nil {
+		if err := store.UpdateAssertions(h.savedRequestID(), assertions); err != nil {
+			a.err = err.Error()
+			return false
+		}
+		h.reloadSavedRequests()
+	}
+	a.list = assertions
+	a.check(h)
+	return true
+}
+
+func (a *Assertions) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	if a.step != assertionStepList {
+		return a.updateBuilder(h, msg)
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		h.navigate(StateViewResponse)
+		a.err = ""
+		return nil
+
+	case "up", "k":
+		if a.selected > 0 {
+			a.selected--
+		}
+		return nil
+
+	case "down", "j":
+		if a.selected < len(a.list)-1 {
+			a.selected++
+		}
+		return nil
+
+	case "n":
+		if h.blockedByReadOnly("add assertion") {
+			return nil
+		}
+		a.startBuilder()
+		return nil
+
+	case "d":
+		if h.blockedByReadOnly("delete assertion") {
+			return nil
+		}
+		if a.selected < len(a.list) {
+			assertions := append([]storage.ResponseAssertion(nil), a.list[:a.selected]...)
+			assertions = append(assertions, a.list[a.selected+1:]...)
+			if a.update(h, assertions) {
+				a.selected = clampIndex(a.selected, len(a.list))
+			}
+		}
+		return nil
+	}
+
+	return nil
+}
+
+// updateBuilder handles the steps of the guided builder
+func (a *Assertions) updateBuilder(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		a.step = assertionStepList
+		a.input.Blur()
+		a.err = ""
+		return nil
+
+	case "up":
+		if a.choiceIdx > 0 {
+			a.choiceIdx--
+		}
+		return nil
+
+	case "down":
+		if a.choiceIdx < len(a.choices)-1 {
+			a.choiceIdx++
+		}
+		return nil
+
+	case "tab":
+		if a.step == assertionStepField && a.choiceIdx < len(a.choices) {
+			a.input.SetValue(a.choices[a.choiceIdx])
+			a.input.CursorEnd()
+			a.filterFields(h)
+		}
+		return nil
+
+	case "enter":
+		a.err = ""
+		switch a.step {
+		case assertionStepType:
+			a.draft.Type = a.choices[a.choiceIdx]
+		case assertionStepField:
+			field := strings.TrimSpace(a.input.Value())
+			if a.choiceIdx < len(a.choices) {
+				field = a.choices[a.choiceIdx]
+			}
+			if field == "" {
+				a.err = i18n.T("assertions.need_field")
+				return nil
+			}
+			a.draft.Field = field
+		case assertionStepOperator:
+			a.draft.Operator = a.choices[a.choiceIdx]
+		case assertionStepValue:
+			a.draft.Value = a.input.Value()
+			a.saveDraft(h)
+			return nil
+		}
+		a.advanceBuilder(h)
+		return nil
+	}
+
+	switch a.step {
+	case assertionStepField:
+		a.input, cmd = a.input.Update(msg)
+		a.filterFields(h)
+		return cmd
+	case assertionStepValue:
+		a.input, cmd = a.input.Update(msg)
+		return cmd
+	}
+	return nil
+}
+
+// viewSummary renders the pass count and failures for the response view
+func (a *Assertions) viewSummary(h host) string {
+	var b strings.Builder
+
+	if a.err != "" && h.screenState() == StateViewResponse {
+		b.WriteString(ErrorStyle.Render("✗ " + a.err))
+		b.WriteString("\n\n")
+	}
+	if len(a.results) == 0 {
+		return b.String()
+	}
+
+	passed := storage.CountPassed(a.results)
+	summary := i18n.Tf("assertions.summary", passed, len(a.results))
+	if passed == len(a.results) {
+		b.WriteString(SuccessStyle.Render("✓ " + summary))
+	} else {
+		b.WriteString(ErrorStyle.Render("✗ " + summary))
+	}
+	b.WriteString("\n")
+	for _, r := range a.results {
+		if !r.Passed() {
+			b.WriteString(MutedStyle.Render(fmt.Sprintf("  ✗ %s: %v", r.Assertion, r.Err)))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+func (a *Assertions) View(h host) string {
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.Tf("title.assertions", len(a.list))))
+	b.WriteString("\n\n")
+
+	if len(a.list) == 0 {
+		b.WriteString(MutedStyle.Render(i18n.T("assertions.empty")))
+		b.WriteString("\n")
+	}
+	for i, assertion := range a.list {
+		marker := "•"
+		style := ListItemStyle
+		detail := ""
+		if i < len(a.results) {
+			if a.results[i].Passed() {
+				marker = SuccessStyle.Render("✓")
+			} else {
+				marker = ErrorStyle.Render("✗")
+				detail = a.results[i].Err.Error()
+			}
+		}
+
+		prefix := "  "
+		if i == a.selected && a.step == assertionStepList {
+			prefix = "> "
+			style = ListItemSelectedStyle
+		}
+		b.WriteString(style.Render(prefix) + marker + " " + style.Render(assertion.String()))
+		b.WriteString("\n")
+		if detail != "" {
+			b.WriteString(MutedStyle.Render("    " + detail))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+
+	footer := i18n.T("footer.assertions")
+	if a.step != assertionStepList {
+		b.WriteString(a.viewBuilder())
+		footer = i18n.T("footer.assert_build")
+	}
+
+	if a.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + a.err))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(RenderFooter(footer))
+
+	width, height := h.size()
+	return Center(width, height, b.String())
+}
+
+// viewBuilder renders the current step of the guided builder
+func (a *Assertions) viewBuilder() string {
+	var b strings.Builder
+
+	if a.draft.Type != "" {
+		b.WriteString(MutedStyle.Render(i18n.Tf("assertions.draft", a.draft.String())))
+		b.WriteString("\n\n")
+	}
+
+	switch a.step {
+	case assertionStepType:
+		b.WriteString(TextStyle.Render(i18n.T("assertions.pick_type")))
+	case assertionStepField:
+		b.WriteString(TextStyle.Render(i18n.T("assertions.pick_field")))
+	case assertionStepOperator:
+		b.WriteString(TextStyle.Render(i18n.T("assertions.pick_op")))
+	case assertionStepValue:
+		prompt := i18n.T("assertions.value")
+		if a.draft.Operator == storage.OpMatches {
+			prompt = i18n.T("assertions.regex")
+		}
+		b.WriteString(TextStyle.Render(prompt))
+	}
+	b.WriteString("\n")
+
+	if a.step == assertionStepField || a.step == assertionStepValue {
+		b.WriteString(lipgloss.NewStyle().
+			Border(roundedBorder()).
+			BorderForeground(lipgloss.Color(ColorAccent)).
+			Padding(0, 1).
+			Width(a.input.Width + 2).
+			Render(a.input.View()))
+		b.WriteString("\n")
+	}
+
+	if a.step == assertionStepField && len(a.choices) == 0 {
+		b.WriteString(MutedStyle.Render(i18n.T("assertions.no_suggest")))
+		b.WriteString("\n")
+	}
+
+	start := 0
+	if a.choiceIdx >= maxAssertionChoices {
+		start = a.choiceIdx - maxAssertionChoices + 1
+	}
+	end := start + maxAssertionChoices
+	if end > len(a.choices) {
+		end = len(a.choices)
+	}
+	for i := start; i < end; i++ {
+		label := strings.ReplaceAll(a.choices[i], "_", " ")
+		if a.step == assertionStepField {
+			label = a.choices[i]
+		}
+		if i == a.choiceIdx {
+			b.WriteString(ListItemSelectedStyle.Render("> " + label))
+		} else {
+			b.WriteString(ListItemStyle.Render("  " + label))
+		}
+		b.WriteString("\n")
+	}
+	if end < len(a.choices) {
+		b.WriteString(MutedStyle.Render(fmt.Sprintf("  … %d more", len(a.choices)-end)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
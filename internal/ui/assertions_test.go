@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+func pressKeys(t *testing.T, m Model, keys ...tea.KeyMsg) Model {
+	t.Helper()
+	for _, key := range keys {
+		m.assertions.Update(&m, key)
+	}
+	return m
+}
+
+func TestAssertionBuilderSuggestsFromLastResponse(t *testing.T) {
+	m := Model{
+		assertions: newAssertions(),
+		builder: RequestBuilder{
+			requestSaved:          true,
+			currentRequestSavedID: "req-1",
+		},
+		viewer: ResponseViewer{
+			response: &httpclient.Response{
+				StatusCode: 200,
+				Body:       `{"data": {"items": [{"id": 1}, {"id": 2}]}, "total": 2}`,
+				Headers:    map[string][]string{"Content-Type": {"application/json"}},
+			},
+		},
+	}
+
+	m.assertions.open(&m)
+	if m.state != StateAssertions {
+		t.Fatalf("Expected assertions screen, got state %v (%s)", m.state, m.assertions.err)
+	}
+
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	down := tea.KeyMsg{Type: tea.KeyDown}
+
+	// n, then pick json_length, the third type
+	m = pressKeys(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}, down, down, enter)
+	if m.assertions.step != assertionStepField {
+		t.Fatalf("Expected field step, got %v", m.assertions.step)
+	}
+	if len(m.assertions.choices) != 1 || m.assertions.choices[0] != "data.items" {
+		t.Fatalf("Expected only array paths to be suggested, got %v", m.assertions.choices)
+	}
+
+	// Take the suggestion, keep equals and the prefilled length
+	m = pressKeys(t, m, enter, enter)
+	if m.assertions.input.Value() != "2" {
+		t.Errorf("Expected value prefilled with the current length, got %q", m.assertions.input.Value())
+	}
+	m = pressKeys(t, m, enter)
+
+	if m.assertions.step != assertionStepList || len(m.assertions.list) != 1 {
+		t.Fatalf("Expected the assertion to be saved, got step %v and %v (%s)", m.assertions.step, m.assertions.list, m.assertions.err)
+	}
+	want := storage.ResponseAssertion{Type: storage.AssertJSONLength, Field: "data.items", Operator: storage.OpEquals, Value: "2"}
+	if m.assertions.list[0] != want {
+		t.Errorf("Saved %+v, want %+v", m.assertions.list[0], want)
+	}
+	if len(m.assertions.results) != 1 || !m.assertions.results[0].Passed() {
+		t.Errorf("Expected the new assertion to be checked against the response, got %+v", m.assertions.results)
+	}
+}
+
+func TestOpenAssertionsRequiresSavedRequest(t *testing.T) {
+	m := Model{state: StateViewResponse}
+
+	m.assertions.open(&m)
+
+	if m.state != StateViewResponse || m.assertions.err == "" {
+		t.Errorf("Expected an error instead of the assertions screen, got state %v error %q", m.state, m.assertions.err)
+	}
+}
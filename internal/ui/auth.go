@@ -0,0 +1,340 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/runner"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// Fields of the auth form. Type and location are picked from a list; the
+// others are typed. Which fields show depends on the type.
+const (
+	authFieldType = iota
+	authFieldUsername
+	authFieldPassword
+	authFieldToken
+	authFieldKeyName
+	authFieldKeyValue
+	authFieldIn
+	authFieldCount
+)
+
+var authFieldLabels = [authFieldCount]string{
+	"Type", "Username", "Password", "Token", "Name", "Value", "Send in",
+}
+
+// authForm holds the auth panel while it is open
+type authForm struct {
+	inputs   [authFieldCount]textinput.Model
+	authType string
+	in       string
+	focus    int
+	err      string
+	notice   string
+}
+
+// authFields returns the fields shown for an auth type, in focus order
+func authFields(authType string) []int {
+	switch authType {
+	case httpclient.AuthBearer:
+		return []int{authFieldType, authFieldToken}
+	case httpclient.AuthAPIKey:
+		return []int{authFieldType, authFieldKeyName, authFieldKeyValue, authFieldIn}
+	default:
+		return []int{authFieldType, authFieldUsername, authFieldPassword}
+	}
+}
+
+// authTypeLabel names an auth type for display
+func authTypeLabel(authType string) string {
+	switch authType {
+	case httpclient.AuthBearer:
+		return "Bearer"
+	case httpclient.AuthAPIKey:
+		return "API key"
+	default:
+		return "Basic"
+	}
+}
+
+// openAuth shows the auth panel filled with the current settings
+func (m *Model) openAuth() {
+	auth := storage.RequestAuth{Type: httpclient.AuthTypes[0], In: httpclient.AuthLocations[0]}
+	if m.requestAuth != nil {
+		auth = *m.requestAuth
+	}
+
+	width := m.layout.InputWidth
+	if width <= 0 {
+		width = 60
+	}
+	placeholders := [authFieldCount]string{
+		authFieldUsername: "{{API_USER}}",
+		authFieldPassword: "{{API_PASSWORD}}",
+		authFieldToken:    "{{API_TOKEN}}",
+		authFieldKeyName:  "X-API-Key",
+		authFieldKeyValue: "{{API_KEY}}",
+	}
+	values := [authFieldCount]string{
+		authFieldUsername: auth.Username,
+		authFieldPassword: auth.Password,
+		authFieldToken:    auth.Token,
+		authFieldKeyName:  auth.KeyName,
+		authFieldKeyValue: auth.KeyValue,
+	}
+	form := &m.authForm
+	for i := range form.inputs {
+		input := textinput.New()
+		input.Placeholder = placeholders[i]
+		input.CharLimit = 2048
+		input.Width = width
+		input.SetValue(values[i])
+		form.inputs[i] = input
+	}
+	form.inputs[authFieldPassword].EchoMode = textinput.EchoPassword
+
+	form.authType = auth.Type
+	form.in = auth.In
+	if form.in == "" {
+		form.in = httpclient.AuthLocations[0]
+	}
+	form.focus = authFieldType
+	form.err = ""
+	form.notice = ""
+	m.state = StateAuth
+}
+
+// authDraft returns the settings as currently typed in the form
+func (m Model) authDraft() storage.RequestAuth {
+	form := m.authForm
+	auth := storage.RequestAuth{Type: form.authType}
+	switch form.authType {
+	case httpclient.AuthBasic:
+		auth.Username = strings.TrimSpace(form.inputs[authFieldUsername].Value())
+		auth.Password = form.inputs[authFieldPassword].Value()
+	case httpclient.AuthBearer:
+		auth.Token = strings.TrimSpace(form.inputs[authFieldToken].Value())
+	case httpclient.AuthAPIKey:
+		auth.KeyName = strings.TrimSpace(form.inputs[authFieldKeyName].Value())
+		auth.KeyValue = form.inputs[authFieldKeyValue].Value()
+		auth.In = form.in
+	}
+	return auth
+}
+
+// saveAuth applies the auth settings to the request being edited and
+// stores them with the loaded saved request, if any. A nil auth removes it.
+func (m *Model) saveAuth(auth *storage.RequestAuth) {
+	if auth != nil {
+		if err := httpclient.Auth(*auth).Validate(); err != nil {
+			m.authForm.err = err.Error()
+			return
+		}
+	}
+
+	if m.storage != nil && m.builder.requestSaved && m.builder.currentRequestSavedID != "" {
+		if m.blockedByReadOnly("save auth") {
+			return
+		}
+		if err := m.storage.UpdateRequestAuth(m.builder.currentRequestSavedID, auth); err != nil {
+			m.authForm.err = err.Error()
+			return
+		}
+		m.requests.saved = m.storage.GetRequests()
+	}
+
+	m.requestAuth = auth
+	m.authForm.err = ""
+	if auth == nil {
+		m.authForm.notice = i18n.T("auth.removed")
+	} else {
+		m.authForm.notice = i18n.T("auth.saved")
+	}
+}
+
+// focusAuthField moves focus by delta through the fields of the current type
+func (m *Model) focusAuthField(delta int) {
+	form := &m.authForm
+	fields := authFields(form.authType)
+	current := 0
+	for i, field := range fields {
+		if field == form.focus {
+			current = i
+		}
+	}
+	form.inputs[form.focus].Blur()
+	form.focus = fields[(current+delta+len(fields))%len(fields)]
+	form.inputs[form.focus].Focus()
+}
+
+func (m Model) handleAuthKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	form := &m.authForm
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return m, tea.Quit
+
+	case "esc":
+		form.inputs[form.focus].Blur()
+		m.state = StateRequestBuilder
+		return m, nil
+
+	case "tab", "down":
+		m.focusAuthField(1)
+		return m, nil
+
+	case "shift+tab", "up":
+		m.focusAuthField(-1)
+		return m, nil
+
+	case "ctrl+s", "enter":
+		draft := m.authDraft()
+		m.saveAuth(&draft)
+		return m, nil
+
+	case "ctrl+d":
+		m.saveAuth(nil)
+		return m, nil
+
+	case "left", "right":
+		delta := 1
+		if msg.String() == "left" {
+			delta = -1
+		}
+		switch form.focus {
+		case authFieldType:
+			form.authType = cycleOption(httpclient.AuthTypes, form.authType, delta)
+			return m, nil
+		case authFieldIn:
+			form.in = cycleOption(httpclient.AuthLocations, form.in, delta)
+			return m, nil
+		}
+	}
+
+	if form.focus == authFieldType || form.focus == authFieldIn {
+		return m, nil
+	}
+	form.inputs[form.focus], cmd = form.inputs[form.focus].Update(msg)
+	return m, cmd
+}
+
+func (m Model) viewAuth() string {
+	var b strings.Builder
+	form := m.authForm
+
+	title := i18n.T("title.auth_panel")
+	if m.requestAuth != nil {
+		title += " • " + i18n.Tf("auth.active", authTypeLabel(m.requestAuth.Type))
+	}
+	b.WriteString(TitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	for _, field := range authFields(form.authType) {
+		label := fmt.Sprintf("%-10s", authFieldLabels[field]+":")
+		focused := field == form.focus
+
+		labelStyle := MutedStyle
+		if focused {
+			labelStyle = TextStyle
+		}
+		b.WriteString(labelStyle.Render(label))
+
+		switch field {
+		case authFieldType, authFieldIn:
+			value := authTypeLabel(form.authType)
+			if field == authFieldIn {
+				value = form.in
+			}
+			if focused {
+				b.WriteString(ButtonActive.Render("◂ " + value + " ▸"))
+			} else {
+				b.WriteString(TextStyle.Render(value))
+			}
+		default:
+			border := ColorBorder
+			if focused {
+				border = ColorAccent
+			}
+			b.WriteString("\n")
+			b.WriteString(lipgloss.NewStyle().
+				Border(roundedBorder()).
+				BorderForeground(lipgloss.Color(border)).
+				Padding(0, 1).
+				Width(form.inputs[field].Width + 2).
+				Render(form.inputs[field].View()))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(MutedStyle.Render(i18n.T("auth.variables")))
+	b.WriteString("\n\n")
+
+	b.WriteString(m.viewAuthPreview())
+
+	if form.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + form.err))
+		b.WriteString("\n")
+	} else if form.notice != "" {
+		b.WriteString(SuccessStyle.Render(form.notice))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(RenderFooter(i18n.T("footer.auth")))
+
+	return Center(m.width, m.height, b.String())
+}
+
+// viewAuthPreview shows the header or query param the typed settings
+// produce, with variables resolved, and warns about a hand-written
+// header it replaces
+func (m Model) viewAuthPreview() string {
+	var b strings.Builder
+
+	draft := m.authDraft()
+	auth := httpclient.Auth(draft)
+	if err := auth.Validate(); err != nil {
+		b.WriteString(WarningStyle.Render("⚠ " + err.Error()))
+		b.WriteString("\n\n")
+		return b.String()
+	}
+
+	req := runner.Authorize(httpclient.Request{}, &draft, m.activeVariables())
+	name, _ := auth.Credential()
+	if auth.InQuery() {
+		b.WriteString(MutedStyle.Render(i18n.T("auth.sent_as_query")) + TextStyle.Render(strings.TrimPrefix(req.URL, "?")))
+	} else {
+		b.WriteString(MutedStyle.Render(i18n.T("auth.sent_as_header")) + TextStyle.Render(name+": "+req.Headers[name]))
+	}
+	b.WriteString("\n")
+
+	if !auth.InQuery() {
+		for key := range m.builder.headers {
+			if strings.EqualFold(key, name) {
+				b.WriteString(WarningStyle.Render(i18n.Tf("auth.replaces_header", key)))
+				b.WriteString("\n")
+				break
+			}
+		}
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// activeVariables returns the variables of the active environment
+func (m Model) activeVariables() []storage.Variable {
+	if m.storage == nil {
+		return nil
+	}
+	vars, _ := m.storage.GetActiveEnvironmentVariables()
+	return vars
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"maps"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// SetAutoSave turns on saving the request definition whenever a send
+// returns a 2xx status
+func (m *Model) SetAutoSave(enabled bool) {
+	m.autoSave = enabled
+}
+
+// autoSaveRequest saves the request that was just sent, or updates the saved
+// request with the same method and URL, and makes it the loaded request so
+// later saves from the response view apply to it
+func (m *Model) autoSaveRequest(statusCode int) {
+	m.autoSaveNotice = ""
+	if !m.autoSave || m.readOnly || m.storage == nil || statusCode < 200 || statusCode >= 300 {
+		return
+	}
+
+	id, created, err := m.storage.AutoSaveRequest(m.builder.method, m.builder.urlInput.Value(),
+		maps.Clone(m.builder.headers), m.builder.body, maps.Clone(m.builder.queryParams))
+	if err != nil {
+		m.reportStorageError("failed to auto-save request", err)
+		return
+	}
+	if m.hmacAuth != nil {
+		m.reportStorageError("failed to save signing", m.storage.UpdateHMACAuth(id, m.hmacAuth))
+	}
+	if m.requestAuth != nil {
+		m.reportStorageError("failed to save auth", m.storage.UpdateRequestAuth(id, m.requestAuth))
+	}
+	if m.requestPolicy != nil {
+		m.reportStorageError("failed to save timeout and retries", m.storage.UpdateRequestPolicy(id, m.requestPolicy))
+	}
+
+	m.requests.saved = m.storage.GetRequests()
+	m.builder.currentRequestSavedID = id
+	m.builder.requestSaved = true
+
+	if created {
+		m.autoSaveNotice = i18n.T("autosave.created")
+	} else {
+		m.autoSaveNotice = i18n.T("autosave.updated")
+	}
+}
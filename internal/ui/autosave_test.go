@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+func TestAutoSaveOnlyOnSuccess(t *testing.T) {
+	store, err := storage.NewStorageAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	m := Model{storage: store, builder: RequestBuilder{urlInput: textinput.New(), method: "GET", headers: map[string]string{}}}
+	m.builder.urlInput.SetValue("https://api.example.com/users")
+
+	m.autoSaveRequest(200)
+	if len(store.GetRequests()) != 0 {
+		t.Fatal("Expected nothing to be saved while auto-save is off")
+	}
+
+	m.SetAutoSave(true)
+	m.autoSaveRequest(404)
+	if len(store.GetRequests()) != 0 {
+		t.Fatal("Expected a 404 not to be saved")
+	}
+
+	m.autoSaveRequest(201)
+	m.builder.headers["Authorization"] = "Bearer token"
+	m.autoSaveRequest(200)
+
+	requests := store.GetRequests()
+	if len(requests) != 1 {
+		t.Fatalf("Expected one saved request, got %d", len(requests))
+	}
+	if !m.builder.requestSaved || m.builder.currentRequestSavedID != requests[0].ID {
+		t.Error("Expected the auto-saved request to become the loaded request")
+	}
+	if requests[0].Headers["Authorization"] != "Bearer token" {
+		t.Errorf("Expected the second send to update the saved headers, got %v", requests[0].Headers)
+	}
+	if m.autoSaveNotice == "" {
+		t.Error("Expected an auto-save notice")
+	}
+
+	m.SetReadOnly(true)
+	m.builder.urlInput.SetValue("https://api.example.com/orders")
+	m.autoSaveRequest(200)
+	if len(store.GetRequests()) != 1 {
+		t.Error("Expected read-only mode to disable auto-save")
+	}
+}
@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"strings"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// responseIsBinary reports whether the response body is shown as a summary
+// instead of its bytes, which would garble the terminal
+func (v *ResponseViewer) responseIsBinary() bool {
+	if v.response == nil || v.response.Error != nil {
+		return false
+	}
+	return httpclient.IsBinaryBody(firstHeader(v.response.Headers, "Content-Type"), v.response.RawBody())
+}
+
+// viewBinarySummary describes the binary body of the response: its type,
+// its size and, for images, its dimensions
+func (v *ResponseViewer) viewBinarySummary() string {
+	summary := httpclient.SummarizeBody(firstHeader(v.response.Headers, "Content-Type"), v.response.RawBody())
+
+	mediaType := summary.MediaType
+	if mediaType == "" {
+		mediaType = i18n.T("binary.unknown_type")
+	}
+	lines := []string{
+		i18n.T("binary.title"),
+		"",
+		i18n.Tf("binary.type", mediaType),
+		i18n.Tf("binary.size", httpclient.FormatSize(summary.Size)),
+	}
+	if summary.ImageFormat != "" {
+		lines = append(lines, i18n.Tf("binary.image", summary.ImageFormat, summary.Width, summary.Height))
+	}
+	lines = append(lines, "", i18n.T("binary.hint"))
+	return strings.Join(lines, "\n")
+}
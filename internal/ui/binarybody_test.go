@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowBinaryResponseShowsSummary(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	var logo bytes.Buffer
+	if err := png.Encode(&logo, image.NewRGBA(image.Rect(0, 0, 64, 32))); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(logo.Bytes())
+	}))
+	defer server.Close()
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL + "/logo.png").Press("enter").WaitFor("200")
+	d.AssertView("Binary body", "Type:  image/png", "Image: PNG, 64×32 px", "w: save it to a file")
+	d.AssertNoView("IHDR")
+
+	d.Press("w").AssertView("logo.png")
+}
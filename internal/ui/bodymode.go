@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"strings"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// bodyPlaceholders show how a body of each mode is written
+var bodyPlaceholders = map[string]string{
+	httpclient.BodyRaw:       "{\n  \"key\": \"value\"\n}",
+	httpclient.BodyForm:      "name=John Doe\nemail=john@example.com",
+	httpclient.BodyMultipart: "title=Q3 report\nfile=@~/reports/q3.pdf",
+}
+
+// contentType returns the Content-Type of headers, whatever its case
+func contentType(headers map[string]string) string {
+	for key, value := range headers {
+		if strings.EqualFold(key, "Content-Type") {
+			return value
+		}
+	}
+	return ""
+}
+
+// bodyMode returns the body mode of the request being edited, which its
+// Content-Type chooses
+func (r *RequestBuilder) bodyMode() string {
+	return httpclient.BodyModeOf(contentType(r.headers))
+}
+
+// cycleBodyMode switches the body to the next of raw, form-urlencoded and
+// multipart by setting the Content-Type the mode is sent with
+func (r *RequestBuilder) cycleBodyMode() {
+	modes := httpclient.BodyModes
+	current, next := r.bodyMode(), modes[0]
+	for i, mode := range modes {
+		if mode == current {
+			next = modes[(i+1)%len(modes)]
+		}
+	}
+
+	if r.headers == nil {
+		r.headers = make(map[string]string)
+	}
+	for key := range r.headers {
+		if strings.EqualFold(key, "Content-Type") {
+			delete(r.headers, key)
+		}
+	}
+	r.headers["Content-Type"] = httpclient.BodyModeContentType(next)
+	r.bodyEditor.Placeholder = bodyPlaceholders[next]
+	r.bodyError = ""
+	r.requestSaved = false
+}
+
+// openBodyEditor edits the body in the editor of its mode
+func (r *RequestBuilder) openBodyEditor(h host) {
+	h.navigate(StateBodyEditor)
+	r.bodyEditor.Placeholder = bodyPlaceholders[r.bodyMode()]
+	r.bodyEditor.SetValue(r.body)
+	r.bodyEditor.Focus()
+}
+
+// bodyModeLabel names a body mode in the body editor
+func bodyModeLabel(mode string) string {
+	switch mode {
+	case httpclient.BodyForm:
+		return i18n.T("body.form")
+	case httpclient.BodyMultipart:
+		return i18n.T("body.multipart")
+	default:
+		return i18n.T("body.raw")
+	}
+}
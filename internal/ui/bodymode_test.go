@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowFormURLEncodedBody(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err == nil {
+			got = r.Header.Get("Content-Type") + " " + r.PostForm.Get("name")
+		}
+	}))
+	defer server.Close()
+
+	m := *NewModel()
+	m.builder.method = "POST"
+	d := tuitest.New(t, m).Resize(160, 50)
+	d.Press("a").Type(server.URL).Press("tab", "tab", "tab", "enter").AssertView("Body Editor (JSON)")
+
+	d.Press("ctrl+o").AssertView("Body Editor (form-urlencoded)", "encoded when sent")
+	d.Type("name=John Doe").Press("ctrl+s").AssertView("Body: (form: name=John+Doe)")
+
+	d.Press("shift+tab", "shift+tab", "shift+tab", "enter").WaitFor("200")
+	if got != "application/x-www-form-urlencoded John Doe" {
+		t.Errorf("Expected the server to read the form, got %q", got)
+	}
+}
+
+func TestCycleBodyMode(t *testing.T) {
+	m := testGraphQLModel()
+	m.builder.headers = map[string]string{"content-type": "application/json", "Accept": "*/*"}
+
+	for _, want := range []string{"application/x-www-form-urlencoded", "multipart/form-data", "application/json"} {
+		m.builder.cycleBodyMode()
+		if len(m.builder.headers) != 2 || m.builder.headers["Content-Type"] != want {
+			t.Errorf("Expected Content-Type %s in place of the old one, got %v", want, m.builder.headers)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// openBookmarks shows the bookmarked history entries
+func (l *History) openBookmarks(h host) {
+	h.navigate(StateBookmarks)
+	l.err = ""
+	l.notice = ""
+	l.reloadBookmarks(h)
+	l.selectedBookmark = 0
+}
+
+func (l *History) reloadBookmarks(h host) {
+	l.bookmarks = nil
+	store := h.store()
+	if store == nil {
+		return
+	}
+	l.refresh(h)
+	l.bookmarks = store.GetBookmarks()
+
+	if l.selectedBookmark >= len(l.bookmarks) {
+		l.selectedBookmark = len(l.bookmarks) - 1
+	}
+	if l.selectedBookmark < 0 {
+		l.selectedBookmark = 0
+	}
+}
+
+// toggleBookmark bookmarks a history entry, or removes its bookmark and note
+func (l *History) toggleBookmark(h host, exec storage.RequestExecution) {
+	store := h.store()
+	if store == nil {
+		return
+	}
+	l.err = ""
+	if err := store.SetHistoryBookmark(exec.ID, !exec.Bookmarked, ""); err != nil {
+		l.err = err.Error()
+	}
+	l.reloadBookmarks(h)
+}
+
+// startNote opens the note input for a history entry; saving a note also
+// bookmarks the entry
+func (l *History) startNote(exec storage.RequestExecution) {
+	l.editingNote = true
+	l.noteID = exec.ID
+	l.err = ""
+	l.noteInput.SetValue(exec.Note)
+	l.noteInput.CursorEnd()
+	l.noteInput.Focus()
+}
+
+// updateNote handles input while editing a bookmark note
+func (l *History) updateNote(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		l.editingNote = false
+		l.noteInput.Blur()
+		return nil
+
+	case "enter":
+		l.editingNote = false
+		l.noteInput.Blur()
+		if store := h.store(); store != nil {
+			if err := store.SetHistoryBookmark(l.noteID, true, l.noteInput.Value()); err != nil {
+				l.err = err.Error()
+			}
+		}
+		l.reloadBookmarks(h)
+		return nil
+	}
+
+	l.noteInput, cmd = l.noteInput.Update(msg)
+	return cmd
+}
+
+// viewNoteInput renders the note input while it is open
+func (l *History) viewNoteInput() string {
+	if !l.editingNote {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(TextStyle.Render(i18n.T("bookmarks.note_prompt")))
+	b.WriteString("\n")
+	b.WriteString(lipgloss.NewStyle().
+		Border(roundedBorder()).
+		BorderForeground(lipgloss.Color(ColorAccent)).
+		Padding(0, 1).
+		Width(l.noteInput.Width + 2).
+		Render(l.noteInput.View()))
+	b.WriteString("\n\n")
+	return b.String()
+}
+
+// loadExecution puts a recorded request back into the request builder
+func (m *Model) loadExecution(exec storage.RequestExecution) {
+	m.builder.method = exec.Method
+	m.builder.urlInput.SetValue(exec.URL)
+	m.builder.headers = exec.Headers
+	m.builder.body = exec.Body
+	if exec.QueryParams != nil {
+		m.builder.queryParams = exec.QueryParams
+	} else {
+		m.builder.queryParams = make(map[string]string)
+	}
+	m.state = StateRequestBuilder
+	m.builder.requestSaved = false
+	m.viewer.displayTransform = ""
+	m.latencyBudget = 0
+	m.assertions.list = nil
+	m.hmacAuth = nil
+	m.requestAuth = nil
+	m.requestPolicy = nil
+	m.currentGraphQLOpID = ""
+	m.graphqlMode = isGraphQLBody(exec.Body)
+}
+
+func (l *History) updateBookmarks(h host, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		h.navigate(StateHistory)
+		return nil
+
+	case "up", "k":
+		if l.selectedBookmark > 0 {
+			l.selectedBookmark--
+		}
+		return nil
+
+	case "down", "j":
+		if l.selectedBookmark < len(l.bookmarks)-1 {
+			l.selectedBookmark++
+		}
+		return nil
+
+	case "enter":
+		if l.selectedBookmark < len(l.bookmarks) {
+			h.loadExecution(l.bookmarks[l.selectedBookmark])
+		}
+		return nil
+
+	case "n":
+		if h.blockedByReadOnly("edit bookmark note") {
+			return nil
+		}
+		if l.selectedBookmark < len(l.bookmarks) {
+			l.startNote(l.bookmarks[l.selectedBookmark])
+		}
+		return nil
+
+	case "b", "d":
+		if h.blockedByReadOnly("remove bookmark") {
+			return nil
+		}
+		if l.selectedBookmark < len(l.bookmarks) {
+			l.notice = ""
+			l.toggleBookmark(h, l.bookmarks[l.selectedBookmark])
+		}
+		return nil
+
+	case "x":
+		store := h.store()
+		if store == nil {
+			return nil
+		}
+		l.err = ""
+		l.notice = ""
+		path, err := store.ExportBookmarks()
+		if err != nil {
+			l.err = err.Error()
+			return nil
+		}
+		l.notice = i18n.Tf("bookmarks.exported", path)
+		return nil
+	}
+
+	return nil
+}
+
+func (l *History) viewBookmarks(h host) string {
+	var b strings.Builder
+	_, height := h.size()
+
+	b.WriteString(TitleStyle.Render(i18n.Tf("title.bookmarks", len(l.bookmarks))))
+	b.WriteString("\n\n")
+
+	if len(l.bookmarks) == 0 {
+		b.WriteString(MutedStyle.Render(i18n.T("bookmarks.empty")))
+		b.WriteString("\n")
+	} else {
+		maxItems := (height - 14) / h.listItemLines()
+		if maxItems < 3 {
+			maxItems = 3
+		}
+		start := 0
+		if l.selectedBookmark >= maxItems {
+			start = l.selectedBookmark - maxItems + 1
+		}
+		end := start + maxItems
+		if end > len(l.bookmarks) {
+			end = len(l.bookmarks)
+		}
+
+		for i := start; i < end; i++ {
+			exec := l.bookmarks[i]
+			status := RenderStatusPill(exec.StatusCode, exec.Status)
+			if exec.Error != "" {
+				status = RenderStatusPill(0, "ERROR")
+			}
+			note := MutedStyle.Render(i18n.T("bookmarks.no_note"))
+			if exec.Note != "" {
+				note = TextStyle.Render(exec.Note)
+			}
+
+			b.WriteString(renderRequestItem(exec.Method, exec.URL, i == l.selectedBookmark))
+			if !h.detailedLists() {
+				b.WriteString("  " + status)
+				if exec.Note != "" {
+					b.WriteString(MutedStyle.Render(" • ") + note)
+				}
+				b.WriteString("\n")
+				continue
+			}
+			b.WriteString("\n")
+			b.WriteString(renderItemDetail("★ "+exec.Timestamp.Format(time.DateTime)+" • ") + status +
+				MutedStyle.Render(fmt.Sprintf(" • %dms • ", exec.ResponseTime)) + note)
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+
+	b.WriteString(l.viewNoteInput())
+
+	if l.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + l.err))
+		b.WriteString("\n\n")
+	}
+	if l.notice != "" {
+		b.WriteString(SuccessStyle.Render(l.notice))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(RenderFooter(i18n.T("footer.bookmarks")))
+
+	return b.String()
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/storage"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestBookmarkHistoryEntryWithNote(t *testing.T) {
+	store, err := storage.NewStorageAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	if err := store.AddExecution(storage.RequestExecution{Method: "GET", URL: "https://api.example.com/users"}); err != nil {
+		t.Fatalf("AddExecution() error = %v", err)
+	}
+
+	m := Model{storage: store, state: StateHistory, width: 120, height: 40, history: newHistory()}
+	m.history.refresh(&m)
+	press := func(key string) {
+		msg, _ := tuitest.ParseKey(key)
+		updated, _ := m.handleKeyPress(msg)
+		m = updated.(Model)
+	}
+
+	press("n")
+	if !m.history.editingNote {
+		t.Fatal("Expected n to open the note input")
+	}
+	for _, r := range "flaky" {
+		press(string(r))
+	}
+	press("enter")
+
+	press("B")
+	if m.state != StateBookmarks || len(m.history.bookmarks) != 1 {
+		t.Fatalf("Expected the noted entry among the bookmarks, got state %v %+v", m.state, m.history.bookmarks)
+	}
+	if note := m.history.bookmarks[0].Note; note != "flaky" {
+		t.Errorf("Expected the note to be saved, got %q", note)
+	}
+
+	press("d")
+	if len(m.history.bookmarks) != 0 {
+		t.Errorf("Expected d to remove the bookmark, got %+v", m.history.bookmarks)
+	}
+	press("esc")
+	if m.state != StateHistory {
+		t.Errorf("Expected Esc to go back to history, got %v", m.state)
+	}
+}
@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// activeLatencyBudget returns the budget of the loaded saved request, if unchanged
+func (m Model) activeLatencyBudget() int64 {
+	if !m.builder.requestSaved || m.builder.currentRequestSavedID == "" {
+		return 0
+	}
+	return m.latencyBudget
+}
+
+// responseOverBudget reports whether the current response exceeded the latency budget
+func (m Model) responseOverBudget() bool {
+	return m.viewer.overBudget(m.activeLatencyBudget())
+}
+
+// overBudget reports whether the response exceeded a budget of budget milliseconds
+func (v *ResponseViewer) overBudget(budget int64) bool {
+	if budget <= 0 || v.response == nil || v.response.Error != nil {
+		return false
+	}
+	return v.response.ResponseTime > time.Duration(budget)*time.Millisecond
+}
+
+// startBudgetEdit opens the latency budget input for the current saved request
+func (m *Model) startBudgetEdit() {
+	m.viewer.budgetError = ""
+	if !m.builder.requestSaved || m.builder.currentRequestSavedID == "" {
+		m.viewer.budgetError = i18n.T("budget.save_first")
+		return
+	}
+
+	m.viewer.editingBudget = true
+	m.viewer.budgetInput.SetValue("")
+	if m.latencyBudget > 0 {
+		m.viewer.budgetInput.SetValue(strconv.FormatInt(m.latencyBudget, 10))
+	}
+	m.viewer.budgetInput.CursorEnd()
+	m.viewer.budgetInput.Focus()
+}
+
+// updateBudget handles input while editing the latency budget
+func (v *ResponseViewer) updateBudget(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		v.editingBudget = false
+		v.budgetInput.Blur()
+		return nil
+
+	case "enter":
+		budget, err := parseLatencyBudget(v.budgetInput.Value())
+		if err != nil {
+			v.budgetError = err.Error()
+			return nil
+		}
+
+		v.editingBudget = false
+		v.budgetInput.Blur()
+		v.budgetError = ""
+
+		if err := h.saveLatencyBudget(budget); err != nil {
+			v.budgetError = err.Error()
+		}
+		return nil
+	}
+
+	v.budgetInput, cmd = v.budgetInput.Update(msg)
+	return cmd
+}
+
+// saveLatencyBudget stores budget for the current saved request
+func (m *Model) saveLatencyBudget(budget int64) error {
+	if m.storage != nil {
+		if err := m.storage.UpdateLatencyBudget(m.builder.currentRequestSavedID, budget); err != nil {
+			return err
+		}
+		m.requests.saved = m.storage.GetRequests()
+	}
+	m.latencyBudget = budget
+	return nil
+}
+
+// parseLatencyBudget reads a budget in milliseconds; "300", "300ms" and "1.5s"
+// are accepted and an empty value removes the budget
+func parseLatencyBudget(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "0" {
+		return 0, nil
+	}
+
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if ms < 0 {
+			return 0, fmt.Errorf("latency budget cannot be negative")
+		}
+		return ms, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid latency budget %q, use milliseconds like 300", value)
+	}
+	return d.Milliseconds(), nil
+}
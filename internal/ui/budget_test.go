@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+func TestParseLatencyBudget(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"300", 300, false},
+		{" 300ms ", 300, false},
+		{"1.5s", 1500, false},
+		{"-5", 0, true},
+		{"fast", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseLatencyBudget(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseLatencyBudget(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseLatencyBudget(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestResponseOverBudget(t *testing.T) {
+	m := Model{
+		latencyBudget: 300,
+		builder: RequestBuilder{
+			requestSaved:          true,
+			currentRequestSavedID: "req-1",
+		},
+		viewer: ResponseViewer{
+			response: &httpclient.Response{ResponseTime: 450 * time.Millisecond},
+		},
+	}
+
+	if !m.responseOverBudget() {
+		t.Error("Expected 450ms response to exceed 300ms budget")
+	}
+
+	m.builder.requestSaved = false
+	if m.responseOverBudget() {
+		t.Error("Expected budget to apply only to the unchanged saved request")
+	}
+}
+
+func TestExecutionOverBudget(t *testing.T) {
+	exec := storage.RequestExecution{ResponseTime: 450, BudgetMs: 300}
+	if !exec.OverBudget() {
+		t.Error("Expected execution to be over budget")
+	}
+
+	exec.Error = "timeout"
+	if exec.OverBudget() {
+		t.Error("Expected failed executions not to be flagged")
+	}
+
+	if (storage.RequestExecution{ResponseTime: 450}).OverBudget() {
+		t.Error("Expected executions without budget not to be flagged")
+	}
+}
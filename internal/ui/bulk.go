@@ -0,0 +1,255 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+type bulkResultMsg []httpclient.BulkResult
+
+func runBulkCmd(client *httpclient.Client, template httpclient.Request, urls []string) tea.Cmd {
+	return func() tea.Msg {
+		return bulkResultMsg(httpclient.RunBulk(client, template, urls))
+	}
+}
+
+// BulkRunner is the bulk URL runner: the method and headers of the request
+// being edited sent to every URL of a file
+type BulkRunner struct {
+	path    textinput.Model
+	running bool
+	results []httpclient.BulkResult
+	err     string
+	scroll  int
+
+	// method and headers are what the request being edited sends
+	method  string
+	headers int
+}
+
+func newBulkRunner() BulkRunner {
+	path := textinput.New()
+	path.Placeholder = "~/urls.txt"
+	path.CharLimit = 500
+	path.Width = 50
+	return BulkRunner{path: path}
+}
+
+// open shows the bulk URL runner with the file path input focused
+func (r *BulkRunner) open(h host) {
+	req := h.builtRequest()
+	r.method = req.Method
+	r.headers = len(req.Headers)
+	r.err = ""
+	r.path.CursorEnd()
+	r.path.Focus()
+	h.navigate(StateBulkRunner)
+}
+
+// start reads the URL list and sends the current method and headers to
+// every URL, with environment variables applied
+func (r *BulkRunner) start(h host) tea.Cmd {
+	urls, err := httpclient.ReadURLListFile(strings.TrimSpace(r.path.Value()))
+	if err != nil {
+		r.err = err.Error()
+		return nil
+	}
+
+	template := h.builtRequest()
+	template.URL = ""
+
+	if store := h.store(); store != nil {
+		if aliases, err := store.GetActiveAliases(); err == nil {
+			for i, u := range urls {
+				urls[i], _ = storage.ExpandAlias(u, aliases)
+			}
+		}
+		if vars, err := store.GetActiveEnvironmentVariables(); err == nil && len(vars) > 0 {
+			for i, u := range urls {
+				urls[i] = storage.ReplaceVariables(u, vars)
+			}
+		}
+	}
+
+	r.path.Blur()
+	r.err = ""
+	r.running = true
+	r.results = nil
+	r.scroll = 0
+	return tea.Batch(h.spinnerTick(), runBulkCmd(h.requestClient(), template, urls))
+}
+
+// finish shows the results of the run
+func (r *BulkRunner) finish(msg bulkResultMsg) {
+	r.running = false
+	r.results = []httpclient.BulkResult(msg)
+}
+
+// bulkSummary is used for the completion notification
+func bulkSummary(results []httpclient.BulkResult) (string, time.Duration, bool) {
+	var took time.Duration
+	failed := 0
+	for _, r := range results {
+		if r.ResponseTime > took {
+			took = r.ResponseTime
+		}
+		if !r.OK() {
+			failed++
+		}
+	}
+	return i18n.Tf("bulk.summary", len(results)-failed, len(results)), took, failed > 0
+}
+
+func (r *BulkRunner) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	if r.path.Focused() {
+		switch msg.String() {
+		case "ctrl+c", "ctrl+q":
+			return tea.Quit
+
+		case "esc":
+			r.path.Blur()
+			if r.results == nil {
+				h.navigate(StateRequestBuilder)
+			}
+			return nil
+
+		case "enter":
+			if h.blockedByReadOnly(r.method + " requests") {
+				return nil
+			}
+			return r.start(h)
+		}
+
+		r.path, cmd = r.path.Update(msg)
+		return cmd
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		if r.running {
+			return nil
+		}
+		h.navigate(StateRequestBuilder)
+		return nil
+
+	case "enter", "r":
+		if r.running {
+			return nil
+		}
+		if h.blockedByReadOnly(r.method + " requests") {
+			return nil
+		}
+		return r.start(h)
+
+	case "f":
+		if !r.running {
+			r.path.Focus()
+		}
+		return nil
+
+	case "up", "k":
+		if r.scroll > 0 {
+			r.scroll--
+		}
+		return nil
+
+	case "down", "j":
+		r.scroll++
+		return nil
+	}
+
+	return nil
+}
+
+func (r *BulkRunner) View(h host) string {
+	width, height := h.size()
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.bulk")))
+	b.WriteString("\n\n")
+
+	headerInfo := i18n.T("bulk.no_headers")
+	if r.headers > 0 {
+		headerInfo = i18n.Tf("bulk.headers", r.headers)
+	}
+	b.WriteString(MutedStyle.Render(i18n.Tf("bulk.intro", r.method, headerInfo)))
+	b.WriteString("\n\n")
+
+	borderColor := ColorMuted
+	if r.path.Focused() {
+		borderColor = ColorAccent
+	}
+	b.WriteString(TextStyle.Render(i18n.T("bulk.file")))
+	b.WriteString("\n")
+	b.WriteString(lipgloss.NewStyle().
+		Border(roundedBorder()).
+		BorderForeground(lipgloss.Color(borderColor)).
+		Padding(0, 1).
+		Width(r.path.Width + 2).
+		Render(r.path.View()))
+	b.WriteString("\n\n")
+
+	if r.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + r.err))
+		b.WriteString("\n\n")
+	}
+
+	switch {
+	case r.running:
+		b.WriteString(SpinnerStyle.Render(h.spinnerView()) + "  " + TextStyle.Render(i18n.T("bulk.running")))
+		b.WriteString("\n")
+
+	case r.results != nil:
+		lines := strings.Split(strings.TrimRight(httpclient.FormatBulkResults(r.results), "\n"), "\n")
+
+		maxLines := height - 20
+		if maxLines < 5 {
+			maxLines = 5
+		}
+		start := r.scroll
+		if start > len(lines)-maxLines {
+			start = len(lines) - maxLines
+		}
+		if start < 0 {
+			start = 0
+		}
+		end := start + maxLines
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		for _, line := range lines[start:end] {
+			switch {
+			case strings.HasPrefix(line, "✓"):
+				b.WriteString(SuccessStyle.Render(line))
+			case strings.HasPrefix(line, "✗"), strings.HasPrefix(line, "    "):
+				b.WriteString(ErrorStyle.Render(line))
+			default:
+				b.WriteString(HeaderStyle.Render(line))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	if r.path.Focused() {
+		b.WriteString(RenderFooter(i18n.T("footer.bulk_file")))
+	} else {
+		b.WriteString(RenderFooter(i18n.T("footer.bulk")))
+	}
+
+	return Center(width, height, b.String())
+}
@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowBulkRunnerSendsToEveryURL(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	list := filepath.Join(t.TempDir(), "urls.txt")
+	if err := os.WriteFile(list, []byte(fmt.Sprintf("%s/ok\n%s/missing\n", server.URL, server.URL)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL).Press("tab", "u").AssertView("Sends GET with no headers")
+	d.Type(list).Press("enter").WaitFor("/ok", "/missing")
+
+	m := d.Model().(Model)
+	if m.bulk.running || len(m.bulk.results) != 2 {
+		t.Fatalf("Expected 2 results, got %+v", m.bulk.results)
+	}
+	d.Press("esc").AssertView("u: bulk")
+}
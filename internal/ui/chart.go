@@ -0,0 +1,244 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ChartMode selects how a query result is visualised
+type ChartMode int
+
+const (
+	ChartNone ChartMode = iota
+	ChartBar
+	ChartLine
+)
+
+const (
+	// maxChartPoints caps the number of rows plotted to keep charts legible
+	maxChartPoints = 50
+	// maxChartLabelWidth truncates long labels in bar charts
+	maxChartLabelWidth = 20
+	// lineChartHeight is the number of rows used by the line chart plot area
+	lineChartHeight = 12
+)
+
+// ChartData holds the labels and values extracted from a two-column result
+type ChartData struct {
+	LabelColumn string
+	ValueColumn string
+	Labels      []string
+	Values      []float64
+}
+
+// ExtractChartData returns chart data when the result has exactly one label
+// column and one numeric column. NULL values are skipped.
+func ExtractChartData(columns []string, rows [][]string) (*ChartData, bool) {
+	if len(columns) != 2 || len(rows) == 0 {
+		return nil, false
+	}
+
+	labelIdx, valueIdx := -1, -1
+	for col := 0; col < 2; col++ {
+		if isNumericColumn(rows, col) {
+			valueIdx = col
+		} else {
+			labelIdx = col
+		}
+	}
+
+	// Two numeric columns: treat the first one as the label (e.g. status code)
+	if labelIdx == -1 && valueIdx == 1 {
+		labelIdx = 0
+	}
+	if labelIdx == -1 || valueIdx == -1 {
+		return nil, false
+	}
+
+	data := &ChartData{
+		LabelColumn: columns[labelIdx],
+		ValueColumn: columns[valueIdx],
+	}
+
+	for _, row := range rows {
+		if len(row) < 2 || row[valueIdx] == "NULL" || row[valueIdx] == "" {
+			continue
+		}
+		value, _ := strconv.ParseFloat(row[valueIdx], 64)
+		data.Labels = append(data.Labels, row[labelIdx])
+		data.Values = append(data.Values, value)
+		if len(data.Values) >= maxChartPoints {
+			break
+		}
+	}
+
+	if len(data.Values) == 0 {
+		return nil, false
+	}
+
+	return data, true
+}
+
+// isNumericColumn reports whether every non-NULL value in the column parses as a number
+func isNumericColumn(rows [][]string, col int) bool {
+	seen := false
+	for _, row := range rows {
+		if col >= len(row) || row[col] == "NULL" || row[col] == "" {
+			continue
+		}
+		if _, err := strconv.ParseFloat(row[col], 64); err != nil {
+			return false
+		}
+		seen = true
+	}
+	return seen
+}
+
+// RenderBarChart draws a horizontal bar chart fitting the given width
+func RenderBarChart(data *ChartData, width int) string {
+	labelWidth := 0
+	for _, label := range data.Labels {
+		if w := displayWidth(label); w > labelWidth {
+			labelWidth = w
+		}
+	}
+	if labelWidth > maxChartLabelWidth {
+		labelWidth = maxChartLabelWidth
+	}
+
+	maxValue := 0.0
+	valueWidth := 0
+	for _, v := range data.Values {
+		maxValue = math.Max(maxValue, math.Abs(v))
+		if w := len(formatChartValue(v)); w > valueWidth {
+			valueWidth = w
+		}
+	}
+
+	barWidth := width - labelWidth - valueWidth - 4
+	if barWidth < 10 {
+		barWidth = 10
+	}
+
+	var b strings.Builder
+	for i, label := range data.Labels {
+		value := data.Values[i]
+		length := 0
+		if maxValue > 0 {
+			length = int(math.Round(math.Abs(value) / maxValue * float64(barWidth)))
+		}
+
+		b.WriteString(padRightWidth(truncateChartLabel(label, labelWidth), labelWidth))
+		b.WriteString(" │")
+		b.WriteString(strings.Repeat("█", length))
+		b.WriteString(" ")
+		b.WriteString(formatChartValue(value))
+		if i < len(data.Labels)-1 {
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+// RenderLineChart draws a simple line plot of the values, in row order
+func RenderLineChart(data *ChartData, width int) string {
+	height := lineChartHeight
+
+	minValue, maxValue := data.Values[0], data.Values[0]
+	for _, v := range data.Values {
+		minValue = math.Min(minValue, v)
+		maxValue = math.Max(maxValue, v)
+	}
+
+	axisWidth := len(formatChartValue(maxValue))
+	if w := len(formatChartValue(minValue)); w > axisWidth {
+		axisWidth = w
+	}
+
+	plotWidth := width - axisWidth - 3
+	if plotWidth < len(data.Values) {
+		plotWidth = len(data.Values)
+	}
+
+	// Spread points evenly across the plot width
+	step := 1
+	if len(data.Values) > 1 {
+		step = (plotWidth - 1) / (len(data.Values) - 1)
+		if step < 1 {
+			step = 1
+		}
+	}
+	plotWidth = (len(data.Values)-1)*step + 1
+
+	grid := make([][]rune, height)
+	for y := range grid {
+		grid[y] = []rune(strings.Repeat(" ", plotWidth))
+	}
+
+	rowFor := func(v float64) int {
+		if maxValue == minValue {
+			return height / 2
+		}
+		return height - 1 - int(math.Round((v-minValue)/(maxValue-minValue)*float64(height-1)))
+	}
+
+	prevX, prevY := -1, -1
+	for i, v := range data.Values {
+		x, y := i*step, rowFor(v)
+		// Interpolate between consecutive points so the line reads as continuous
+		if prevX >= 0 {
+			for cx := prevX + 1; cx < x; cx++ {
+				grid[prevY+(y-prevY)*(cx-prevX)/(x-prevX)][cx] = '·'
+			}
+		}
+		grid[y][x] = '●'
+		prevX, prevY = x, y
+	}
+
+	var b strings.Builder
+	for y, line := range grid {
+		label := ""
+		switch y {
+		case 0:
+			label = formatChartValue(maxValue)
+		case height - 1:
+			label = formatChartValue(minValue)
+		}
+		b.WriteString(fmt.Sprintf("%*s", axisWidth, label))
+		b.WriteString(" │")
+		b.WriteString(string(line))
+		b.WriteString("\n")
+	}
+	b.WriteString(strings.Repeat(" ", axisWidth+1))
+	b.WriteString("└")
+	b.WriteString(strings.Repeat("─", plotWidth))
+	b.WriteString("\n")
+
+	first := data.Labels[0]
+	last := data.Labels[len(data.Labels)-1]
+	b.WriteString(strings.Repeat(" ", axisWidth+2))
+	gap := plotWidth - displayWidth(first) - displayWidth(last)
+	if len(data.Labels) > 1 && gap > 0 {
+		b.WriteString(first + strings.Repeat(" ", gap) + last)
+	} else {
+		b.WriteString(truncateChartLabel(first, plotWidth))
+	}
+
+	return b.String()
+}
+
+// truncateChartLabel shortens a label to width columns, marking the cut with an ellipsis
+func truncateChartLabel(s string, width int) string {
+	return truncateWidth(s, width, "…")
+}
+
+// formatChartValue prints integers without decimals and floats with two
+func formatChartValue(v float64) string {
+	if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+		return fmt.Sprintf("%d", int64(v))
+	}
+	return fmt.Sprintf("%.2f", v)
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractChartData(t *testing.T) {
+	columns := []string{"endpoint", "avg_ms"}
+	rows := [][]string{
+		{"/users", "120"},
+		{"/orders", "85.5"},
+		{"/health", "NULL"},
+	}
+
+	data, ok := ExtractChartData(columns, rows)
+	if !ok {
+		t.Fatal("Expected result to be chartable")
+	}
+
+	if data.LabelColumn != "endpoint" || data.ValueColumn != "avg_ms" {
+		t.Errorf("Unexpected columns: %s / %s", data.LabelColumn, data.ValueColumn)
+	}
+
+	if len(data.Values) != 2 {
+		t.Errorf("Expected NULL row to be skipped, got %d values", len(data.Values))
+	}
+}
+
+func TestExtractChartDataValueFirst(t *testing.T) {
+	data, ok := ExtractChartData([]string{"count", "status"}, [][]string{{"10", "ok"}, {"3", "failed"}})
+	if !ok {
+		t.Fatal("Expected result to be chartable")
+	}
+
+	if data.LabelColumn != "status" || data.Labels[0] != "ok" || data.Values[0] != 10 {
+		t.Errorf("Unexpected chart data: %+v", data)
+	}
+}
+
+func TestExtractChartDataNotChartable(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []string
+		rows    [][]string
+	}{
+		{"three columns", []string{"a", "b", "c"}, [][]string{{"x", "1", "2"}}},
+		{"no numeric column", []string{"a", "b"}, [][]string{{"x", "y"}}},
+		{"no rows", []string{"a", "b"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := ExtractChartData(tt.columns, tt.rows); ok {
+				t.Error("Expected result not to be chartable")
+			}
+		})
+	}
+}
+
+func TestRenderBarChart(t *testing.T) {
+	data := &ChartData{Labels: []string{"200", "404", "500"}, Values: []float64{100, 50, 0}}
+
+	chart := RenderBarChart(data, 40)
+	lines := strings.Split(chart, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 bars, got %d", len(lines))
+	}
+
+	full := strings.Count(lines[0], "█")
+	half := strings.Count(lines[1], "█")
+	if full == 0 || half*2 != full {
+		t.Errorf("Expected bar lengths proportional to values, got %d and %d", full, half)
+	}
+
+	if strings.Count(lines[2], "█") != 0 {
+		t.Error("Expected zero value to render an empty bar")
+	}
+}
+
+func TestRenderLineChart(t *testing.T) {
+	data := &ChartData{Labels: []string{"mon", "tue", "wed"}, Values: []float64{1, 5, 3}}
+
+	chart := RenderLineChart(data, 40)
+	if strings.Count(chart, "●") != 3 {
+		t.Errorf("Expected 3 points, got chart:\n%s", chart)
+	}
+
+	if !strings.Contains(chart, "mon") || !strings.Contains(chart, "wed") {
+		t.Errorf("Expected first and last labels on the axis, got:\n%s", chart)
+	}
+}
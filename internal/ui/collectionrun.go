@@ -0,0 +1,439 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/runner"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// runRefresh is how often the step list of a running collection is redrawn
+const runRefresh = 100 * time.Millisecond
+
+// CollectionRun is the collection runner screen: the requests of a
+// collection, or every saved request, sent one at a time in order with a
+// live pass/fail list. Finished runs are written to history.
+type CollectionRun struct {
+	name     string
+	steps    []storage.SavedRequest
+	settings *storage.RunSettings
+	// contract is the OpenAPI spec responses are validated against, if any
+	contract json.RawMessage
+	back     AppState
+
+	// progress is shared with the goroutine sending the steps
+	progress *runProgress
+	cancel   context.CancelFunc
+	report   *runner.Report
+	err      string
+	notice   string
+	selected int
+}
+
+// runProgress collects the results of a run as the steps finish. The
+// runner writes them from its goroutine; the view reads them on every tick.
+type runProgress struct {
+	mu      sync.Mutex
+	results []runner.Result
+	done    []bool
+	start   time.Time
+}
+
+func (p *runProgress) record(i int, result runner.Result) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.results[i] = result
+	p.done[i] = true
+}
+
+// snapshot returns the results so far and which steps finished
+func (p *runProgress) snapshot() ([]runner.Result, []bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]runner.Result(nil), p.results...), append([]bool(nil), p.done...)
+}
+
+type runTickMsg struct{}
+
+// runDoneMsg carries the report of the run that progress belongs to
+type runDoneMsg struct {
+	progress *runProgress
+	report   runner.Report
+	err      error
+}
+
+func runTickCmd() tea.Cmd {
+	return tea.Tick(runRefresh, func(time.Time) tea.Msg {
+		return runTickMsg{}
+	})
+}
+
+// sequential copies steps without their parallel groups, so they are sent
+// one at a time in order. Dependencies still skip steps whose dependency
+// did not pass.
+func sequential(steps []storage.SavedRequest) []storage.SavedRequest {
+	ordered := make([]storage.SavedRequest, len(steps))
+	for i, step := range steps {
+		step.Group = ""
+		ordered[i] = step
+	}
+	return ordered
+}
+
+// open runs the requests of collection and shows their progress; Esc
+// returns to back
+func (r *CollectionRun) open(h host, collection storage.Collection, back AppState) tea.Cmd {
+	*r = CollectionRun{
+		name:     collection.Name,
+		steps:    sequential(collection.Requests),
+		settings: collection.Run,
+		contract: collection.OpenAPISpec,
+		back:     back,
+	}
+	h.navigate(StateCollectionRun)
+	return r.start(h)
+}
+
+// running reports whether steps are still being sent
+func (r *CollectionRun) running() bool {
+	return r.progress != nil && r.report == nil
+}
+
+func (r *CollectionRun) start(h host) tea.Cmd {
+	r.report = nil
+	r.err = ""
+	r.notice = ""
+	r.selected = 0
+	if len(r.steps) == 0 {
+		r.err = i18n.T("run.empty")
+		return nil
+	}
+	for _, step := range r.steps {
+		if !isSafeMethod(step.Method) && h.blockedByReadOnly("run "+r.name) {
+			r.err = i18n.T("run.read_only")
+			return nil
+		}
+	}
+
+	var vars []storage.Variable
+	var aliases []storage.ServiceAlias
+	if store := h.store(); store != nil {
+		vars, _ = store.GetActiveEnvironmentVariables()
+		aliases, _ = store.GetActiveAliases()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	progress := &runProgress{
+		results: make([]runner.Result, len(r.steps)),
+		done:    make([]bool, len(r.steps)),
+		start:   time.Now(),
+	}
+	r.progress = progress
+	r.cancel = cancel
+
+	client := h.requestClient()
+	collection := storage.Collection{Name: r.name, Requests: r.steps, Run: r.settings, OpenAPISpec: r.contract}
+	opts := runner.Options{
+		MaxConcurrency: 1,
+		Ignore:         h.diffIgnoreRules(),
+		Defaults:       h.sendDefaults(),
+		Variables:      vars,
+		OnResult:       progress.record,
+		Prepare: func(step storage.SavedRequest) httpclient.Request {
+			return runner.ResolveRequest(step, vars, aliases)
+		},
+	}
+
+	return tea.Batch(runTickCmd(), func() tea.Msg {
+		defer cancel()
+		report, err := runner.RunCollection(ctx, client, collection, opts)
+		return runDoneMsg{progress: progress, report: report, err: err}
+	})
+}
+
+// finish keeps the report of the run and writes its steps to history. It
+// returns whether msg belonged to the current run.
+func (r *CollectionRun) finish(h host, msg runDoneMsg) bool {
+	if msg.progress != r.progress {
+		return false
+	}
+	if msg.err != nil {
+		r.err = msg.err.Error()
+		r.progress = nil
+		return true
+	}
+	r.report = &msg.report
+	r.record(h)
+	return true
+}
+
+// record adds every step that was sent to history, noted with the run it
+// belongs to, so the status codes and timings of a run stay browsable
+func (r *CollectionRun) record(h host) {
+	store := h.store()
+	if store == nil || r.report == nil {
+		return
+	}
+	env := store.ActiveEnvironmentName()
+	recorded := 0
+	for i, result := range r.report.Results {
+		if result.Skipped {
+			continue
+		}
+		step := r.steps[i]
+		execution := storage.RequestExecution{
+			Method:       step.Method,
+			URL:          step.URLWithQueryParams(),
+			Headers:      step.Headers,
+			Body:         step.Body,
+			QueryParams:  step.QueryParams,
+			StatusCode:   result.StatusCode,
+			Status:       result.Status,
+			ResponseTime: result.ResponseTime.Milliseconds(),
+			BudgetMs:     result.TimeBudget.Milliseconds(),
+			Environment:  env,
+			Note:         i18n.Tf("run.history_note", r.name, i+1, len(r.steps)),
+		}
+		if result.Error != nil {
+			execution.Error = result.Error.Error()
+		}
+		if err := store.AddExecution(execution); err != nil {
+			h.reportStorageError("failed to record history", err)
+			return
+		}
+		recorded++
+	}
+	if recorded > 0 {
+		r.notice = i18n.Tf("run.recorded", recorded)
+	}
+
+	// Values the steps extracted are kept for the requests sent next
+	if vars := r.report.Extracted(); len(vars) > 0 {
+		env, err := h.storeExtracted(vars)
+		if err != nil {
+			r.err = err.Error()
+			return
+		}
+		if r.notice != "" {
+			r.notice += " • "
+		}
+		r.notice += i18n.Tf("run.extracted", len(vars), env)
+	}
+}
+
+// summary is used for the completion notification
+func (r *CollectionRun) summary() (string, time.Duration, bool) {
+	passed, failed, skipped := r.report.Counts()
+	return i18n.Tf("run.summary", r.name, passed, len(r.report.Results)), r.report.Duration, failed+skipped > 0
+}
+
+func (r *CollectionRun) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc", "q":
+		if r.running() {
+			// The remaining steps are skipped and the report still arrives
+			r.cancel()
+			return nil
+		}
+		h.navigate(r.back)
+
+	case "up", "k":
+		if r.selected > 0 {
+			r.selected--
+		}
+
+	case "down", "j":
+		if r.selected < len(r.steps)-1 {
+			r.selected++
+		}
+
+	case "r":
+		if !r.running() {
+			return r.start(h)
+		}
+
+	case "enter":
+		if !r.running() && r.selected < len(r.steps) {
+			h.loadRequest(r.steps[r.selected])
+		}
+	}
+
+	return nil
+}
+
+func (r *CollectionRun) View(h host) string {
+	width, height := h.size()
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.Tf("title.run", r.name)))
+	b.WriteString("\n\n")
+
+	var results []runner.Result
+	var done []bool
+	if r.report != nil {
+		results = r.report.Results
+		done = make([]bool, len(results))
+		for i := range done {
+			done[i] = true
+		}
+	} else if r.progress != nil {
+		results, done = r.progress.snapshot()
+	}
+
+	if results != nil {
+		b.WriteString(r.viewCounts(results, done))
+		b.WriteString("\n\n")
+	}
+
+	// Only the steps around the cursor fit on small terminals
+	visible := max(height-14, 5)
+	first := min(max(r.selected-visible/2, 0), max(len(r.steps)-visible, 0))
+	running := r.running()
+	current := -1
+	for i := range r.steps {
+		if running && i < len(done) && !done[i] {
+			current = i
+			break
+		}
+	}
+	for i := first; i < min(first+visible, len(r.steps)); i++ {
+		var state runner.Result
+		finished := i < len(done) && done[i]
+		if finished {
+			state = results[i]
+		}
+		b.WriteString(r.viewStep(i, r.steps[i], state, finished, i == current))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if r.selected < len(results) && r.selected < len(done) && done[r.selected] {
+		b.WriteString(viewStepDetail(results[r.selected]))
+	}
+
+	if r.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + r.err))
+		b.WriteString("\n\n")
+	} else if r.notice != "" {
+		b.WriteString(SuccessStyle.Render("✓ " + r.notice))
+		b.WriteString("\n\n")
+	}
+
+	footer := i18n.T("footer.run")
+	if running {
+		footer = i18n.T("footer.run_running")
+	}
+	b.WriteString(RenderFooter(footer))
+
+	return Center(width, height, b.String())
+}
+
+// viewCounts shows how far the run got and how the finished steps did
+func (r *CollectionRun) viewCounts(results []runner.Result, done []bool) string {
+	finished := 0
+	partial := runner.Report{}
+	for i, result := range results {
+		if done[i] {
+			finished++
+			partial.Results = append(partial.Results, result)
+		}
+	}
+	passed, failed, skipped := partial.Counts()
+
+	elapsed := time.Since(r.progress.start)
+	if r.report != nil {
+		elapsed = r.report.Duration
+	}
+	line := i18n.Tf("run.progress", finished, len(results), passed, failed, skipped, httpclient.FormatDuration(elapsed))
+	style := TextStyle
+	switch {
+	case r.report != nil && failed+skipped > 0:
+		style = ErrorStyle
+	case r.report != nil:
+		style = SuccessStyle
+	}
+	return style.Render(line)
+}
+
+func (r *CollectionRun) viewStep(i int, step storage.SavedRequest, result runner.Result, finished, current bool) string {
+	name := step.Name
+	if name == "" {
+		name = step.URL
+	}
+
+	var marker, status, timing string
+	style := MutedStyle
+	switch {
+	case current:
+		marker, status, style = "…", i18n.T("run.sending"), TextStyle
+	case !finished:
+		marker, status = " ", i18n.T("run.pending")
+	case result.Skipped:
+		marker, status, style = "-", "SKIP", WarningStyle
+	case result.Error != nil:
+		marker, status, style = "✗", "ERR", ErrorStyle
+	default:
+		marker = "✓"
+		style = SuccessStyle
+		if !result.Passed() {
+			marker, style = "✗", ErrorStyle
+		}
+		timing = httpclient.FormatDuration(result.ResponseTime)
+	}
+
+	prefix := "  "
+	if i == r.selected {
+		prefix, style = "> ", ListItemSelectedStyle
+	}
+	// The status code is a pill colored by its class, the other states text
+	statusCell := style.Render(padRightWidth(" "+status, 8))
+	if status == "" {
+		pill := RenderStatusPill(result.StatusCode, fmt.Sprint(result.StatusCode))
+		statusCell = pill + strings.Repeat(" ", max(0, 8-lipgloss.Width(pill)))
+	}
+	return style.Render(prefix+marker) + statusCell + style.Render(fmt.Sprintf("%8s  ", timing)) +
+		RenderMethodBadge(step.Method) + " " + style.Render(name)
+}
+
+// viewStepDetail explains why the selected step did not pass
+func viewStepDetail(result runner.Result) string {
+	var lines []string
+	switch {
+	case result.Skipped:
+		lines = append(lines, i18n.Tf("run.skipped", result.SkipReason))
+	case result.Error != nil:
+		lines = append(lines, result.Error.Error())
+	default:
+		for _, a := range result.Assertions {
+			if !a.Passed() {
+				lines = append(lines, i18n.Tf("run.assertion_failed", a.Assertion, a.Err))
+			}
+		}
+		for _, e := range result.Extractions {
+			if !e.Passed() {
+				lines = append(lines, i18n.Tf("run.extract_failed", e.Extract, e.Err))
+			}
+		}
+		if result.OverTimeBudget() {
+			lines = append(lines, i18n.Tf("run.over_budget",
+				httpclient.FormatDuration(result.ResponseTime), httpclient.FormatDuration(result.TimeBudget)))
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return WarningStyle.Render(strings.Join(lines, "\n")) + "\n\n"
+}
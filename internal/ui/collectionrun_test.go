@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/storage"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowRunAllSavedRequests(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	var order []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		order = append(order, r.URL.Path)
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	store, err := storage.NewStorage()
+	if err != nil {
+		t.Fatalf("NewStorage() error = %v", err)
+	}
+	for _, path := range []string{"/health", "/broken", "/users"} {
+		if err := store.SaveRequest("GET "+path, "GET", server.URL+path, nil, "", nil); err != nil {
+			t.Fatalf("SaveRequest() error = %v", err)
+		}
+	}
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a", "tab", "ctrl+l", "r")
+	d.WaitFor("Run: all saved requests", "3/3 done • 2 passed • 1 failed • 0 skipped", "3 requests recorded in history")
+	d.AssertView("✓ 200", "✗ 500")
+	if strings.Join(order, " ") != "/health /broken /users" {
+		t.Errorf("Expected the requests to be sent in order, got %v", order)
+	}
+
+	m := d.Model().(Model)
+	history := m.storage.GetHistory()
+	if len(history) != 3 || history[0].Note != "run all saved requests 3/3" || history[1].StatusCode != 500 {
+		t.Errorf("Expected every step in history with a run note, got %+v", history)
+	}
+
+	d.Press("down", "enter")
+	if m := d.Model().(Model); m.state != StateRequestBuilder || m.builder.urlInput.Value() != server.URL+"/broken" {
+		t.Errorf("Expected enter to load the selected step, got state %v and URL %q", m.state, m.builder.urlInput.Value())
+	}
+}
+
+func TestSequentialDropsParallelGroups(t *testing.T) {
+	steps := []storage.SavedRequest{{Name: "a", Group: "warmup"}, {Name: "b", Group: "warmup", DependsOn: []string{"a"}}}
+	ordered := sequential(steps)
+	if ordered[0].Group != "" || ordered[1].Group != "" || len(ordered[1].DependsOn) != 1 {
+		t.Errorf("sequential() = %+v, want groups dropped and dependencies kept", ordered)
+	}
+	if steps[0].Group != "warmup" {
+		t.Error("sequential() changed the steps it was given")
+	}
+}
@@ -0,0 +1,679 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// collectionPick is what the picker of the collections screen chooses
+type collectionPick int
+
+const (
+	pickNone collectionPick = iota
+	// pickSavedRequest chooses a saved request to add to the open collection
+	pickSavedRequest
+	// pickMoveTarget and pickCopyTarget choose where the selected request of
+	// the open collection goes
+	pickMoveTarget
+	pickCopyTarget
+)
+
+// Collections is the collections screen: the collections of the workspace
+// and the requests of the one open
+type Collections struct {
+	list     []storage.Collection
+	selected int
+
+	// openID is set while the requests of a collection are listed
+	openID      string
+	selectedReq int
+
+	// nameInput and descInput edit the collection editingID, a new one when
+	// it is empty, while editing is set
+	nameInput textinput.Model
+	descInput textinput.Model
+	editing   bool
+	editingID string
+
+	picker           collectionPick
+	pickIdx          int
+	confirmingDelete bool
+	err              string
+	notice           string
+}
+
+func newCollections() Collections {
+	nameInput := textinput.New()
+	nameInput.Placeholder = "checkout"
+	nameInput.CharLimit = 100
+	nameInput.Width = 40
+
+	descInput := textinput.New()
+	descInput.Placeholder = "Cart, payment and order confirmation"
+	descInput.CharLimit = 500
+	descInput.Width = 60
+
+	return Collections{nameInput: nameInput, descInput: descInput}
+}
+
+// open lists the collections of the workspace
+func (cl *Collections) open(h host) {
+	cl.openID = ""
+	cl.selected = 0
+	cl.selectedReq = 0
+	cl.editing = false
+	cl.picker = pickNone
+	cl.confirmingDelete = false
+	cl.err = ""
+	cl.notice = ""
+	cl.reload(h)
+	h.navigate(StateCollections)
+}
+
+func (cl *Collections) typing() bool { return cl.editing }
+
+// running returns the collection the run shortcut runs: the open one, or
+// the one under the cursor of the list
+func (cl *Collections) running() *storage.Collection {
+	if cl.editing || cl.picker != pickNone {
+		return nil
+	}
+	if open := cl.openCollection(); open != nil {
+		return open
+	}
+	return cl.selectedCollection()
+}
+
+func (cl *Collections) reload(h host) {
+	cl.list = nil
+	store := h.store()
+	if store == nil {
+		cl.err = i18n.T("collections.no_storage")
+		return
+	}
+
+	config, err := store.LoadCollections()
+	if err != nil {
+		cl.err = err.Error()
+		return
+	}
+	cl.list = config.Collections
+
+	cl.selected = clampIndex(cl.selected, len(cl.list))
+	if open := cl.openCollection(); open != nil {
+		cl.selectedReq = clampIndex(cl.selectedReq, len(open.Requests))
+	} else {
+		cl.openID = ""
+	}
+}
+
+// openCollection returns the collection whose requests are listed, or nil
+// while the collections themselves are listed
+func (cl *Collections) openCollection() *storage.Collection {
+	if cl.openID == "" {
+		return nil
+	}
+	for i := range cl.list {
+		if cl.list[i].ID == cl.openID {
+			return &cl.list[i]
+		}
+	}
+	return nil
+}
+
+// selectedCollection returns the collection under the cursor of the list
+func (cl *Collections) selectedCollection() *storage.Collection {
+	if cl.selected < len(cl.list) {
+		return &cl.list[cl.selected]
+	}
+	return nil
+}
+
+// collectionTargets returns the collections the selected request can be
+// moved or copied to
+func (cl *Collections) targets() []storage.Collection {
+	var targets []storage.Collection
+	for _, c := range cl.list {
+		if c.ID != cl.openID {
+			targets = append(targets, c)
+		}
+	}
+	return targets
+}
+
+// pickerItems returns the labels of the picker's choices
+func (cl *Collections) pickerItems(h host) []string {
+	var items []string
+	switch cl.picker {
+	case pickSavedRequest:
+		saved := h.savedRequestList()
+		for _, req := range saved {
+			items = append(items, fmt.Sprintf("%s  %s", requestListLabel(req, saved), req.Method))
+		}
+	case pickMoveTarget, pickCopyTarget:
+		for _, c := range cl.targets() {
+			items = append(items, c.Name)
+		}
+	}
+	return items
+}
+
+// startForm shows the name and description inputs, filled from
+// collection when editing one
+func (cl *Collections) startForm(collection *storage.Collection) {
+	cl.nameInput.SetValue("")
+	cl.descInput.SetValue("")
+	cl.editingID = ""
+	if collection != nil {
+		cl.editingID = collection.ID
+		cl.nameInput.SetValue(collection.Name)
+		cl.descInput.SetValue(collection.Description)
+	}
+	cl.editing = true
+	cl.err = ""
+	cl.notice = ""
+	cl.descInput.Blur()
+	cl.nameInput.Focus()
+}
+
+// submitForm creates or updates the collection being edited
+func (cl *Collections) submitForm(h host) {
+	name := cl.nameInput.Value()
+	description := cl.descInput.Value()
+
+	var err error
+	if cl.editingID == "" {
+		var created *storage.Collection
+		created, err = h.store().NewCollection(name, description)
+		if err == nil {
+			cl.reload(h)
+			for i, c := range cl.list {
+				if c.ID == created.ID {
+					cl.selected = i
+				}
+			}
+		}
+	} else {
+		err = h.store().UpdateCollection(cl.editingID, name, description)
+		cl.reload(h)
+	}
+	if err != nil {
+		cl.err = err.Error()
+		return
+	}
+
+	cl.editing = false
+	cl.err = ""
+	cl.nameInput.Blur()
+	cl.descInput.Blur()
+}
+
+// pick applies the choice made in the picker
+func (cl *Collections) pick(h host, idx int) {
+	open := cl.openCollection()
+	if open == nil {
+		return
+	}
+
+	var err error
+	switch cl.picker {
+	case pickSavedRequest:
+		saved := h.savedRequestList()
+		if idx >= len(saved) {
+			return
+		}
+		req := saved[idx]
+		err = h.store().AddToCollection(open.ID, req)
+		if err == nil {
+			cl.notice = i18n.Tf("collections.added", req.Name, open.Name)
+		}
+
+	case pickMoveTarget, pickCopyTarget:
+		targets := cl.targets()
+		if idx >= len(targets) || cl.selectedReq >= len(open.Requests) {
+			return
+		}
+		req := open.Requests[cl.selectedReq]
+		move := cl.picker == pickMoveTarget
+		err = h.store().TransferCollectionRequest(open.ID, req.ID, targets[idx].ID, move)
+		if err == nil && move {
+			cl.notice = i18n.Tf("collections.moved", req.Name, targets[idx].Name)
+		} else if err == nil {
+			cl.notice = i18n.Tf("collections.copied", req.Name, targets[idx].Name)
+		}
+	}
+
+	cl.picker = pickNone
+	if err != nil {
+		cl.err = err.Error()
+		return
+	}
+	cl.err = ""
+	cl.reload(h)
+}
+
+// loadCollectionRequest puts a request of a collection into the request
+// builder. It is a copy, so it is not linked to a saved request.
+func (m *Model) loadCollectionRequest(req storage.SavedRequest) {
+	m.loadExecution(storage.RequestExecution{
+		Method:      req.Method,
+		URL:         req.URL,
+		Headers:     req.Headers,
+		Body:        req.Body,
+		QueryParams: req.QueryParams,
+	})
+	m.builder.currentRequestSavedID = ""
+	m.viewer.displayTransform = req.DisplayTransform
+	m.latencyBudget = req.LatencyBudgetMs
+	m.assertions.list = req.Assertions
+	m.hmacAuth = req.HMAC
+	m.requestAuth = req.Auth
+	m.requestPolicy = req.Policy
+}
+
+func (cl *Collections) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	if cl.editing {
+		return cl.updateForm(h, msg)
+	}
+	if cl.picker != pickNone {
+		return cl.updatePicker(h, msg)
+	}
+	if cl.openCollection() != nil {
+		return cl.updateRequests(h, msg)
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		if cl.confirmingDelete {
+			cl.confirmingDelete = false
+			return nil
+		}
+		h.navigate(StateRequestList)
+		return nil
+
+	case "up", "k":
+		if cl.selected > 0 {
+			cl.selected--
+		}
+		return nil
+
+	case "down", "j":
+		if cl.selected < len(cl.list)-1 {
+			cl.selected++
+		}
+		return nil
+
+	case "enter":
+		if c := cl.selectedCollection(); c != nil {
+			cl.openID = c.ID
+			cl.selectedReq = 0
+			cl.notice = ""
+		}
+		return nil
+
+	case "n", "e":
+		if h.store() == nil || h.blockedByReadOnly("edit collection") {
+			return nil
+		}
+		if msg.String() == "e" {
+			if c := cl.selectedCollection(); c != nil {
+				cl.startForm(c)
+			}
+			return nil
+		}
+		cl.startForm(nil)
+		return nil
+
+	case "d":
+		if h.blockedByReadOnly("delete collection") {
+			return nil
+		}
+		if cl.selectedCollection() != nil {
+			cl.confirmingDelete = true
+		}
+		return nil
+
+	case "y":
+		c := cl.selectedCollection()
+		if !cl.confirmingDelete || c == nil {
+			return nil
+		}
+		cl.confirmingDelete = false
+		if err := h.store().DeleteCollection(c.ID); err != nil {
+			cl.err = err.Error()
+			return nil
+		}
+		cl.notice = i18n.Tf("collections.deleted", c.Name)
+		cl.reload(h)
+		return nil
+	}
+
+	return nil
+}
+
+// handleCollectionRequestsKeys handles the keys while the requests of a
+// collection are listed
+func (cl *Collections) updateRequests(h host, msg tea.KeyMsg) tea.Cmd {
+	open := cl.openCollection()
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		if cl.confirmingDelete {
+			cl.confirmingDelete = false
+			return nil
+		}
+		cl.openID = ""
+		cl.notice = ""
+		return nil
+
+	case "up", "k":
+		if cl.selectedReq > 0 {
+			cl.selectedReq--
+		}
+		return nil
+
+	case "down", "j":
+		if cl.selectedReq < len(open.Requests)-1 {
+			cl.selectedReq++
+		}
+		return nil
+
+	case "enter":
+		if cl.selectedReq < len(open.Requests) {
+			h.loadCollectionRequest(open.Requests[cl.selectedReq])
+		}
+		return nil
+
+	case "a":
+		if h.blockedByReadOnly("add to collection") {
+			return nil
+		}
+		cl.picker = pickSavedRequest
+		cl.pickIdx = 0
+		cl.notice = ""
+		return nil
+
+	case "m", "c":
+		if h.blockedByReadOnly("move request") || cl.selectedReq >= len(open.Requests) {
+			return nil
+		}
+		if len(cl.targets()) == 0 {
+			cl.err = i18n.T("collections.no_targets")
+			return nil
+		}
+		cl.picker = pickCopyTarget
+		if msg.String() == "m" {
+			cl.picker = pickMoveTarget
+		}
+		cl.pickIdx = 0
+		cl.notice = ""
+		return nil
+
+	case "d":
+		if h.blockedByReadOnly("remove from collection") {
+			return nil
+		}
+		if cl.selectedReq < len(open.Requests) {
+			cl.confirmingDelete = true
+		}
+		return nil
+
+	case "y":
+		if !cl.confirmingDelete || cl.selectedReq >= len(open.Requests) {
+			return nil
+		}
+		cl.confirmingDelete = false
+		if err := h.store().RemoveFromCollection(open.ID, open.Requests[cl.selectedReq].ID); err != nil {
+			cl.err = err.Error()
+			return nil
+		}
+		cl.reload(h)
+		return nil
+	}
+
+	return nil
+}
+
+func (cl *Collections) updatePicker(h host, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		cl.picker = pickNone
+		return nil
+
+	case "up", "k":
+		if cl.pickIdx > 0 {
+			cl.pickIdx--
+		}
+		return nil
+
+	case "down", "j":
+		if cl.pickIdx < len(cl.pickerItems(h))-1 {
+			cl.pickIdx++
+		}
+		return nil
+
+	case "enter":
+		cl.pick(h, cl.pickIdx)
+		return nil
+	}
+
+	return nil
+}
+
+func (cl *Collections) updateForm(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		cl.editing = false
+		cl.err = ""
+		cl.nameInput.Blur()
+		cl.descInput.Blur()
+		return nil
+
+	case "tab", "shift+tab":
+		if cl.nameInput.Focused() {
+			cl.nameInput.Blur()
+			cl.descInput.Focus()
+		} else {
+			cl.descInput.Blur()
+			cl.nameInput.Focus()
+		}
+		return nil
+
+	case "enter":
+		if cl.nameInput.Focused() {
+			cl.nameInput.Blur()
+			cl.descInput.Focus()
+			return nil
+		}
+		cl.submitForm(h)
+		return nil
+	}
+
+	if cl.nameInput.Focused() {
+		cl.nameInput, cmd = cl.nameInput.Update(msg)
+	} else {
+		cl.descInput, cmd = cl.descInput.Update(msg)
+	}
+	return cmd
+}
+
+func (cl *Collections) View(h host) string {
+	var b strings.Builder
+
+	open := cl.openCollection()
+	footer := i18n.T("footer.collections")
+	if open != nil {
+		b.WriteString(TitleStyle.Render(i18n.Tf("title.collection", open.Name, len(open.Requests))))
+		b.WriteString("\n")
+		if open.Description != "" {
+			b.WriteString(MutedStyle.Render(open.Description))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(cl.viewRequests(h, *open))
+		footer = i18n.T("footer.collection")
+	} else {
+		b.WriteString(TitleStyle.Render(i18n.Tf("title.collections", len(cl.list))))
+		b.WriteString("\n\n")
+		b.WriteString(cl.viewList(h))
+	}
+	b.WriteString("\n")
+
+	if cl.picker != pickNone {
+		b.WriteString(cl.viewPicker(h))
+		footer = i18n.T("footer.collection_pick")
+	}
+
+	if cl.editing {
+		for _, field := range []struct {
+			label string
+			input textinput.Model
+		}{
+			{i18n.T("collections.name"), cl.nameInput},
+			{i18n.T("collections.description"), cl.descInput},
+		} {
+			border := ColorBorder
+			if field.input.Focused() {
+				border = ColorAccent
+			}
+			b.WriteString(TextStyle.Render(field.label))
+			b.WriteString("\n")
+			b.WriteString(lipgloss.NewStyle().
+				Border(roundedBorder()).
+				BorderForeground(lipgloss.Color(border)).
+				Padding(0, 1).
+				Width(field.input.Width + 2).
+				Render(field.input.View()))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		footer = i18n.T("footer.env_new")
+	}
+
+	if cl.confirmingDelete {
+		if open != nil && cl.selectedReq < len(open.Requests) {
+			b.WriteString(WarningStyle.Render(i18n.Tf("confirm.remove_from_collection", open.Requests[cl.selectedReq].Name, open.Name)))
+			b.WriteString("\n\n")
+		} else if c := cl.selectedCollection(); open == nil && c != nil {
+			b.WriteString(WarningStyle.Render(i18n.Tf("confirm.delete_collection", c.Name, c.RequestCount())))
+			b.WriteString("\n\n")
+		}
+	}
+	if cl.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + cl.err))
+		b.WriteString("\n\n")
+	} else if cl.notice != "" {
+		b.WriteString(SuccessStyle.Render("✓ " + cl.notice))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(RenderFooter(footer))
+
+	width, height := h.size()
+	return Center(width, height, b.String())
+}
+
+func (cl *Collections) viewList(h host) string {
+	var b strings.Builder
+
+	if len(cl.list) == 0 {
+		b.WriteString(MutedStyle.Render(i18n.T("collections.empty")))
+		b.WriteString("\n")
+	}
+	for i, c := range cl.list {
+		prefix := "  "
+		style := ListItemStyle
+		if i == cl.selected {
+			prefix = "> "
+			style = ListItemSelectedStyle
+		}
+		b.WriteString(style.Render(prefix + c.Name))
+		b.WriteString("  ")
+		b.WriteString(MutedStyle.Render(i18n.Tf("collections.count", c.RequestCount())))
+		b.WriteString("\n")
+		if c.Description != "" && h.detailedLists() {
+			b.WriteString(MutedStyle.Render("    " + c.Description))
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+func (cl *Collections) viewRequests(h host, c storage.Collection) string {
+	var b strings.Builder
+
+	if len(c.Requests) == 0 {
+		b.WriteString(MutedStyle.Render(i18n.T("collections.no_requests")))
+		b.WriteString("\n")
+	}
+	for i, req := range c.Requests {
+		label := req.Name
+		if label == "" {
+			label = req.URL
+		}
+		b.WriteString(renderRequestItem(req.Method, label, i == cl.selectedReq))
+		b.WriteString("\n")
+		if h.detailedLists() && label != req.URL {
+			b.WriteString(renderItemDetail(req.URL))
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+func (cl *Collections) viewPicker(h host) string {
+	var b strings.Builder
+
+	open := cl.openCollection()
+	var title string
+	switch cl.picker {
+	case pickSavedRequest:
+		title = i18n.Tf("collections.pick_request", open.Name)
+	case pickMoveTarget:
+		title = i18n.Tf("collections.pick_move", open.Requests[cl.selectedReq].Name)
+	case pickCopyTarget:
+		title = i18n.Tf("collections.pick_copy", open.Requests[cl.selectedReq].Name)
+	}
+	b.WriteString(HeaderStyle.Render(title))
+	b.WriteString("\n")
+
+	items := cl.pickerItems(h)
+	if len(items) == 0 {
+		b.WriteString(MutedStyle.Render(i18n.T("collections.no_saved")))
+		b.WriteString("\n")
+	}
+	for i, item := range items {
+		if i == cl.pickIdx {
+			b.WriteString(ListItemSelectedStyle.Render("> " + item))
+		} else {
+			b.WriteString(ListItemStyle.Render("  " + item))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
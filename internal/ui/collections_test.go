@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/storage"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func collectionKey(t *testing.T, m Model, key string) Model {
+	t.Helper()
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	switch key {
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case "down":
+		msg = tea.KeyMsg{Type: tea.KeyDown}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	m.collections.Update(&m, msg)
+	return m
+}
+
+func TestManageCollectionsFromTheList(t *testing.T) {
+	store, err := storage.NewStorageAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	if err := store.SaveRequest("list users", "GET", "https://api.example.com/users", nil, "", nil); err != nil {
+		t.Fatalf("SaveRequest() error = %v", err)
+	}
+	m := Model{storage: store, requests: RequestList{saved: store.GetRequests()}, width: 100, height: 40, builder: RequestBuilder{urlInput: textinput.New()}}
+	m.collections = newCollections()
+	m.collections.open(&m)
+
+	for _, name := range []string{"users", "smoke"} {
+		m = collectionKey(t, m, "n")
+		m.collections.nameInput.SetValue(name)
+		m = collectionKey(t, m, "enter")
+		m = collectionKey(t, m, "enter")
+		if m.collections.err != "" {
+			t.Fatalf("Creating %q failed: %s", name, m.collections.err)
+		}
+	}
+	if len(m.collections.list) != 2 || m.collections.selected != 1 {
+		t.Fatalf("Expected the new collection to be selected, got %d collections, index %d", len(m.collections.list), m.collections.selected)
+	}
+
+	// Open "smoke", add the saved request and move it to "users"
+	m = collectionKey(t, m, "enter")
+	m = collectionKey(t, m, "a")
+	m = collectionKey(t, m, "enter")
+	if open := m.collections.openCollection(); open == nil || len(open.Requests) != 1 {
+		t.Fatalf("Expected the saved request to be added, got %+v", open)
+	}
+	m = collectionKey(t, m, "m")
+	if !strings.Contains(m.collections.View(&m), "Move 'list users' to:") {
+		t.Errorf("Expected the move picker:\n%s", m.collections.View(&m))
+	}
+	m = collectionKey(t, m, "enter")
+	if n := len(m.collections.openCollection().Requests); n != 0 {
+		t.Errorf("Expected the request to leave the collection, %d left", n)
+	}
+	if m.collections.list[0].Name != "users" || len(m.collections.list[0].Requests) != 1 {
+		t.Errorf("Expected the request in users, got %+v", m.collections.list[0])
+	}
+
+	// Back on the list, delete "users"
+	m = collectionKey(t, m, "esc")
+	m.collections.selected = 0
+	m = collectionKey(t, m, "d")
+	if !strings.Contains(m.collections.View(&m), "Delete collection 'users' and its 1 requests?") {
+		t.Errorf("Expected a delete confirmation:\n%s", m.collections.View(&m))
+	}
+	m = collectionKey(t, m, "y")
+	if len(m.collections.list) != 1 || m.collections.list[0].Name != "smoke" {
+		t.Errorf("Collections after delete = %+v", m.collections.list)
+	}
+}
+
+func TestRunCollectionFromTheList(t *testing.T) {
+	store, err := storage.NewStorageAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	if _, err := store.NewCollection("smoke", ""); err != nil {
+		t.Fatalf("NewCollection() error = %v", err)
+	}
+	m := *NewModel()
+	m.storage = store
+	m.collections.open(&m)
+
+	press := func(key string) {
+		msg, _ := tuitest.ParseKey(key)
+		updated, _ := m.handleKeyPress(msg)
+		m = updated.(Model)
+	}
+
+	press("n")
+	press("r")
+	if m.state != StateCollections || m.collections.nameInput.Value() != "r" {
+		t.Fatalf("Expected r to be typed into the form, got state %v name %q", m.state, m.collections.nameInput.Value())
+	}
+
+	press("esc")
+	updated, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = updated.(Model)
+	if m.state != StateCollectionRun || m.collectionRun.name != "smoke" {
+		t.Errorf("Expected r to run the selected collection, got state %v run %q", m.state, m.collectionRun.name)
+	}
+}
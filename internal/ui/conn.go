@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"strings"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// viewConnection tells whether the response came over a reused keep-alive
+// connection or a new one, with the counts for its host so far
+func (v *ResponseViewer) viewConnection(h host) string {
+	conn := v.response.Conn
+	if conn == nil {
+		return ""
+	}
+
+	var line string
+	switch {
+	case conn.Reused && conn.WasIdle:
+		line = i18n.Tf("conn.reused_idle", conn.RemoteAddr, httpclient.FormatDuration(conn.IdleTime))
+	case conn.Reused:
+		line = i18n.Tf("conn.reused", conn.RemoteAddr)
+	case conn.Forced:
+		line = i18n.Tf("conn.forced", conn.RemoteAddr)
+	default:
+		line = i18n.Tf("conn.new", conn.RemoteAddr)
+	}
+
+	var b strings.Builder
+	b.WriteString(MutedStyle.Render(line))
+	b.WriteString("\n")
+	stats := h.connStats(conn.Host)
+	b.WriteString(MutedStyle.Render(i18n.Tf("conn.host_stats", stats.Host, stats.Reused, stats.New)))
+	b.WriteString("\n\n")
+	return b.String()
+}
@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowConnectionReuseAndForcedNewConnection(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL).Press("enter")
+	d.WaitFor("Connection: new to "+host, host+" this session: 0 reused, 1 new")
+
+	d.Press("esc").Press("enter")
+	d.WaitFor("Connection: reused to "+host+", idle for", host+" this session: 1 reused, 1 new")
+
+	d.Press("esc", "tab", "N").AssertView("[NEW CONN]")
+	d.Press("shift+tab", "enter")
+	d.WaitFor("Connection: new to "+host+" (forced)", host+" this session: 1 reused, 2 new")
+}
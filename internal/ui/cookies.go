@@ -0,0 +1,232 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// Cookies is the cookie jar screen: the cookies kept this session grouped
+// by domain, with the jar switched on or off and single cookies edited or
+// deleted
+type Cookies struct {
+	cookies  []httpclient.JarCookie
+	selected int
+
+	// editing is set while the value of the selected cookie is typed
+	editing bool
+	value   textinput.Model
+	notice  string
+}
+
+func newCookies() Cookies {
+	value := textinput.New()
+	value.CharLimit = 4096
+	value.Width = 60
+	return Cookies{value: value}
+}
+
+// open lists the cookies of the jar from the top
+func (c *Cookies) open(h host) {
+	c.selected = 0
+	c.editing = false
+	c.notice = ""
+	c.refresh(h)
+	h.navigate(StateCookies)
+}
+
+// refresh reads the cookies from the jar again, keeping the selection in
+// range
+func (c *Cookies) refresh(h host) {
+	c.cookies = nil
+	if jar := h.requestClient().CookieJar(); jar != nil {
+		c.cookies = jar.All()
+	}
+	c.selected = min(c.selected, max(len(c.cookies)-1, 0))
+}
+
+func (c *Cookies) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	if c.editing {
+		return c.updateEdit(h, msg)
+	}
+
+	client := h.requestClient()
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc", "q":
+		h.navigate(StateRequestBuilder)
+
+	case "up", "k":
+		if c.selected > 0 {
+			c.selected--
+		}
+
+	case "down", "j":
+		if c.selected < len(c.cookies)-1 {
+			c.selected++
+		}
+
+	case "t":
+		if client.CookieJar() == nil {
+			client.SetCookieJar(httpclient.NewCookieJar())
+			c.notice = i18n.T("cookies.enabled")
+		} else {
+			client.SetCookieJar(nil)
+			c.notice = i18n.T("cookies.disabled")
+		}
+		c.refresh(h)
+
+	case "e", "enter":
+		if len(c.cookies) == 0 {
+			return nil
+		}
+		c.value.SetValue(c.cookies[c.selected].Value)
+		c.value.CursorEnd()
+		c.value.Focus()
+		c.editing = true
+		c.notice = ""
+
+	case "d", "delete":
+		if len(c.cookies) == 0 {
+			return nil
+		}
+		cookie := c.cookies[c.selected]
+		client.CookieJar().Delete(cookie)
+		c.notice = i18n.Tf("cookies.deleted", cookie.Name)
+		c.refresh(h)
+
+	case "D":
+		if jar := client.CookieJar(); jar != nil && len(c.cookies) > 0 {
+			jar.Clear()
+			c.notice = i18n.T("cookies.cleared")
+			c.refresh(h)
+		}
+	}
+
+	return nil
+}
+
+func (c *Cookies) updateEdit(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		c.value.Blur()
+		c.editing = false
+		return nil
+
+	case "enter", "ctrl+s":
+		cookie := c.cookies[c.selected]
+		if jar := h.requestClient().CookieJar(); jar != nil {
+			jar.SetValue(cookie, c.value.Value())
+			c.notice = i18n.Tf("cookies.saved", cookie.Name)
+		}
+		c.value.Blur()
+		c.editing = false
+		c.refresh(h)
+		return nil
+	}
+
+	c.value, cmd = c.value.Update(msg)
+	return cmd
+}
+
+func (c *Cookies) View(h host) string {
+	width, height := h.size()
+	var b strings.Builder
+
+	jar := h.requestClient().CookieJar()
+	if jar == nil {
+		b.WriteString(TitleStyle.Render(i18n.T("title.cookies_off")))
+	} else {
+		b.WriteString(TitleStyle.Render(i18n.Tf("title.cookies", len(c.cookies))))
+	}
+	b.WriteString("\n\n")
+
+	switch {
+	case jar == nil:
+		b.WriteString(MutedStyle.Render(i18n.T("cookies.off_hint")))
+		b.WriteString("\n")
+	case len(c.cookies) == 0:
+		b.WriteString(MutedStyle.Render(i18n.T("cookies.empty")))
+		b.WriteString("\n")
+	default:
+		b.WriteString(c.viewList())
+	}
+
+	if c.editing {
+		b.WriteString("\n")
+		b.WriteString(viewLabeledInput(i18n.Tf("cookies.edit", c.cookies[c.selected].Name), c.value, true))
+	}
+	if c.notice != "" {
+		b.WriteString("\n")
+		b.WriteString(SuccessStyle.Render(c.notice))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if c.editing {
+		b.WriteString(RenderFooter(i18n.T("footer.cookies_edit")))
+	} else {
+		b.WriteString(RenderFooter(i18n.T("footer.cookies")))
+	}
+
+	return Center(width, height, b.String())
+}
+
+// viewList lists the cookies under a heading per domain
+func (c *Cookies) viewList() string {
+	var b strings.Builder
+
+	domain := ""
+	for i, cookie := range c.cookies {
+		if cookie.Domain != domain {
+			if domain != "" {
+				b.WriteString("\n")
+			}
+			domain = cookie.Domain
+			heading := domain
+			if !cookie.HostOnly {
+				heading = "." + domain
+			}
+			b.WriteString(HeaderStyle.Render(heading))
+			b.WriteString("\n")
+		}
+
+		line := fmt.Sprintf("%s=%s", cookie.Name, truncateWidth(cookie.Value, 40, "…"))
+		details := "  " + cookie.Path + " • " + cookieExpiry(cookie.Expires)
+		if cookie.Secure {
+			details += " • Secure"
+		}
+		if cookie.HttpOnly {
+			details += " • HttpOnly"
+		}
+		if i == c.selected {
+			b.WriteString(ListItemSelectedStyle.Render("> " + line))
+		} else {
+			b.WriteString(ListItemStyle.Render("  " + line))
+		}
+		b.WriteString(MutedStyle.Render(details))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// cookieExpiry tells when a cookie expires
+func cookieExpiry(expires time.Time) string {
+	if expires.IsZero() {
+		return i18n.T("cookies.session")
+	}
+	return i18n.Tf("cookies.expires", expires.Local().Format("2006-01-02 15:04"))
+}
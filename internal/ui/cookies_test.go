@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowCookieJarKeepsSession(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc", Path: "/", HttpOnly: true})
+		}
+		cookie, err := r.Cookie("session")
+		if err != nil {
+			w.Write([]byte("no session"))
+			return
+		}
+		w.Write([]byte("session is " + cookie.Value))
+	}))
+	defer server.Close()
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL).Press("tab", "K").AssertView("Cookie Jar (off)", "Press t to keep them")
+	d.Press("t").AssertView("Cookie Jar (0)", "✓ Cookie jar on")
+	d.Press("esc").AssertView("[COOKIES: 0]")
+
+	d.Press("shift+tab").Type("/login").Press("enter").WaitFor("no session")
+	d.Press("esc", "tab", "K").AssertView("Cookie Jar (1)", "127.0.0.1", "> session=abc", "/ • session • HttpOnly")
+
+	d.Press("e", "ctrl+u").Type("edited").Press("enter").AssertView("✓ Saved cookie session", "> session=edited")
+	d.Press("esc", "shift+tab", "ctrl+u").Type(server.URL + "/me").Press("enter").WaitFor("session is edited")
+
+	d.Press("esc", "tab", "K", "d").AssertView("✓ Deleted cookie session", "No cookies yet")
+	d.Press("t").AssertView("Cookie Jar (off)")
+	d.Press("esc").AssertNoView("[COOKIES:")
+}
@@ -0,0 +1,235 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/paths"
+)
+
+// crashDirName is the directory under the data directory crash reports are
+// written to
+const crashDirName = "crashes"
+
+// crashInfo is a recovered panic and where its report went
+type crashInfo struct {
+	message string
+	path    string
+	err     error
+}
+
+// crashReport is the state dump written when a panic is recovered
+type crashReport struct {
+	Time    time.Time  `json:"time"`
+	Version string     `json:"version,omitempty"`
+	Panic   string     `json:"panic"`
+	Stack   string     `json:"stack"`
+	State   crashState `json:"state"`
+	Draft   crashDraft `json:"draft"`
+}
+
+type crashState struct {
+	Screen         AppState `json:"screen"`
+	Width          int      `json:"width"`
+	Height         int      `json:"height"`
+	ReadOnly       bool     `json:"read_only,omitempty"`
+	SavedRequestID string   `json:"saved_request_id,omitempty"`
+	SavedRequests  int      `json:"saved_requests"`
+	Loading        bool     `json:"loading,omitempty"`
+}
+
+// crashDraft is the request being edited, as typed, so it can be recovered
+// by hand from the report
+type crashDraft struct {
+	Method      string            `json:"method"`
+	URL         string            `json:"url"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	QueryParams map[string]string `json:"query_params,omitempty"`
+	Body        string            `json:"body,omitempty"`
+	SQL         string            `json:"sql,omitempty"`
+}
+
+// viewCrash holds the crash of a View that panicked. View cannot change the
+// model, so the report is kept here, written once rather than on every
+// frame, until the next Update takes it over.
+var viewCrash struct {
+	sync.Mutex
+	crashed *crashInfo
+}
+
+// Update handles msg. A panic while handling it is recovered: the model as
+// it was before msg is kept and the crash screen is shown.
+func (m Model) Update(msg tea.Msg) (model tea.Model, cmd tea.Cmd) {
+	if m.crashed == nil {
+		m.crashed = takeViewCrash()
+	}
+	if m.crashed != nil {
+		m.lastCrashReport = m.crashed.path
+		return m.handleCrashKeys(msg)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			m.crashed = m.recordCrash(r, debug.Stack())
+			m.lastCrashReport = m.crashed.path
+			model, cmd = m, nil
+			// The panic cut the tick chain short
+			if _, ok := msg.(tickMsg); ok {
+				cmd = tickCmd()
+			}
+		}
+	}()
+	return m.update(msg)
+}
+
+// View renders the current screen, or the crash screen once a panic was
+// recovered
+func (m Model) View() (view string) {
+	if m.crashed != nil {
+		return m.viewCrash(m.crashed)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			viewCrash.Lock()
+			if viewCrash.crashed == nil {
+				viewCrash.crashed = m.recordCrash(r, debug.Stack())
+			}
+			crashed := viewCrash.crashed
+			viewCrash.Unlock()
+			view = m.viewCrash(crashed)
+		}
+	}()
+	return m.view()
+}
+
+func takeViewCrash() *crashInfo {
+	viewCrash.Lock()
+	defer viewCrash.Unlock()
+	crashed := viewCrash.crashed
+	viewCrash.crashed = nil
+	return crashed
+}
+
+// CrashReport returns the path of the last crash report written during the
+// session, if any, also once the session was resumed after it
+func (m Model) CrashReport() string {
+	return m.lastCrashReport
+}
+
+// recordCrash writes the report of a recovered panic
+func (m Model) recordCrash(value any, stack []byte) *crashInfo {
+	crashed := &crashInfo{message: fmt.Sprint(value)}
+	report := crashReport{
+		Time:    time.Now(),
+		Version: m.onboarding.version,
+		Panic:   crashed.message,
+		Stack:   string(stack),
+		State: crashState{
+			Screen:         m.state,
+			Width:          m.width,
+			Height:         m.height,
+			ReadOnly:       m.readOnly,
+			SavedRequestID: m.builder.currentRequestSavedID,
+			SavedRequests:  len(m.requests.saved),
+			Loading:        m.loading,
+		},
+		Draft: crashDraft{
+			Method:      m.builder.method,
+			URL:         m.builder.urlInput.Value(),
+			Headers:     m.builder.headers,
+			QueryParams: m.builder.queryParams,
+			Body:        m.builder.body,
+			SQL:         m.db.editor.Value(),
+		},
+	}
+	crashed.path, crashed.err = writeCrashReport(report)
+	return crashed
+}
+
+// writeCrashReport saves report in the crash directory and returns its path.
+// The report holds the draft's headers, so only the user can read it.
+func writeCrashReport(report crashReport) (string, error) {
+	dataDir, err := paths.DataDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(dataDir, crashDirName)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("crash-%s.json", report.Time.Format("20060102-150405.000"))
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// handleCrashKeys handles the crash screen: Esc goes back to the home screen
+// with the session as it was before the crash, Q quits
+func (m Model) handleCrashKeys(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	case tickMsg:
+		// Keep the tick chain alive for when the session resumes
+		return m, tickCmd()
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "ctrl+q":
+			return m, tea.Quit
+		case "esc", "enter":
+			m.crashed = nil
+			m.loading = false
+			m.state = StateHome
+		}
+	}
+	return m, nil
+}
+
+func (m Model) viewCrash(crashed *crashInfo) string {
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.crash")))
+	b.WriteString("\n\n")
+	b.WriteString(TextStyle.Render(i18n.T("crash.body")))
+	b.WriteString("\n\n")
+
+	if crashed.err != nil {
+		b.WriteString(ErrorStyle.Render("✗ " + i18n.Tf("crash.save_failed", crashed.err)))
+		b.WriteString("\n")
+	} else {
+		b.WriteString(TextStyle.Render(i18n.T("crash.saved")))
+		b.WriteString("\n")
+		b.WriteString(SuccessStyle.Render("  " + crashed.path))
+		b.WriteString("\n\n")
+		b.WriteString(WarningStyle.Render(i18n.T("crash.secrets")))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(MutedStyle.Render(i18n.Tf("crash.error", crashed.message)))
+	b.WriteString("\n\n")
+	b.WriteString(RenderFooter(i18n.T("footer.crash")))
+
+	view := Center(m.width, m.height, b.String())
+	if m.accessible {
+		return accessibleView(view)
+	}
+	return view
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/paths"
+)
+
+func TestUpdateRecoversFromPanic(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+
+	m := Model{state: StateLoading, builder: RequestBuilder{urlInput: textinput.New(), method: "POST", headers: map[string]string{"X-Token": "abc"}, body: `{"draft":true}`}}
+	m.builder.urlInput.SetValue("https://api.example.com/users")
+
+	// A nil result is never sent, so handling it panics
+	updated, cmd := m.Update(duplicateResultMsg(nil))
+	m = updated.(Model)
+	if cmd != nil {
+		t.Error("Expected no command after a crash")
+	}
+	path := m.CrashReport()
+	if path == "" {
+		t.Fatalf("Expected a crash report, got %+v", m.crashed)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read the report: %v", err)
+	}
+	var report crashReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("Invalid report: %v", err)
+	}
+	if report.Draft.URL != "https://api.example.com/users" || report.Draft.Body != `{"draft":true}` || report.Draft.Headers["X-Token"] != "abc" {
+		t.Errorf("Draft = %+v", report.Draft)
+	}
+	if report.State.Screen != StateLoading || !strings.Contains(report.Stack, "duplicate") {
+		t.Errorf("Expected the state and the stack in the report, got %+v", report.State)
+	}
+
+	if view := m.View(); !strings.Contains(view, path) {
+		t.Errorf("Expected the crash screen to show the report path, got:\n%s", view)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.crashed != nil || m.state != StateHome || m.builder.urlInput.Value() != "https://api.example.com/users" {
+		t.Errorf("Expected Esc to resume the session on the home screen, got state %v", m.state)
+	}
+	if got := m.CrashReport(); got != path {
+		t.Errorf("CrashReport() = %q after resuming, want %q", got, path)
+	}
+}
+
+func TestViewRecoversFromPanic(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+
+	// The body editor needs the textarea NewModel sets up
+	m := Model{state: StateBodyEditor}
+	first := m.View()
+	if !strings.Contains(first, "crash-") || m.View() != first {
+		t.Fatalf("Expected the same crash screen on every frame, got:\n%s", first)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = updated.(Model)
+	if m.crashed == nil {
+		t.Fatal("Expected the next update to take over the view's crash")
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}); cmd == nil || cmd() != tea.Quit() {
+		t.Error("Expected Q to quit from the crash screen")
+	}
+}
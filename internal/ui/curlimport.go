@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// CurlImport is the screen a curl command is pasted into and imported from
+// as the request being edited
+type CurlImport struct {
+	editor textarea.Model
+	err    string
+}
+
+// open shows an empty editor to paste a curl command into
+func (c *CurlImport) open(h host) {
+	editor := textarea.New()
+	editor.Placeholder = "curl https://api.example.com/users \\\n  -H 'Authorization: Bearer {{TOKEN}}' \\\n  -d '{\"name\": \"Alice\"}'"
+	editor.CharLimit = 100000
+	width := h.screenLayout().InputWidth
+	if width <= 0 {
+		width = 80
+	}
+	editor.SetWidth(width)
+	editor.SetHeight(8)
+	editor.Focus()
+
+	c.editor = editor
+	c.err = ""
+	h.navigate(StateCurlImport)
+}
+
+// isCurlCommand reports whether text looks like a pasted curl command
+func isCurlCommand(text string) bool {
+	fields := strings.Fields(text)
+	return len(fields) > 1 && (fields[0] == "curl" || strings.HasSuffix(fields[0], "/curl"))
+}
+
+// importCommand parses command into the request builder. The request is not
+// linked to a saved request until it is saved.
+func (c *CurlImport) importCommand(h host, command string) {
+	req, err := httpclient.ParseSnippet(command)
+	if err != nil {
+		c.err = err.Error()
+		return
+	}
+
+	headers := req.Headers
+	if headers == nil {
+		headers = make(map[string]string)
+	}
+	h.loadRequest(storage.SavedRequest{
+		Method:  req.Method,
+		URL:     req.URL,
+		Headers: headers,
+		Body:    req.Body,
+	})
+}
+
+func (c *CurlImport) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		c.editor.Blur()
+		h.navigate(StateRequestBuilder)
+		return nil
+
+	case "ctrl+s":
+		c.importCommand(h, c.editor.Value())
+		return nil
+	}
+
+	c.editor, cmd = c.editor.Update(msg)
+	c.err = ""
+	return cmd
+}
+
+func (c *CurlImport) View(h host) string {
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.curl_import")))
+	b.WriteString("\n\n")
+	b.WriteString(MutedStyle.Render(i18n.T("curl_import.hint")))
+	b.WriteString("\n\n")
+	b.WriteString(c.editor.View())
+	b.WriteString("\n\n")
+
+	if c.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + c.err))
+		b.WriteString("\n\n")
+	} else if strings.TrimSpace(c.editor.Value()) != "" {
+		b.WriteString(c.viewPreview())
+	}
+
+	b.WriteString(RenderFooter(i18n.T("footer.curl_import")))
+
+	width, height := h.size()
+	return Center(width, height, b.String())
+}
+
+// viewPreview shows what the pasted command imports as, or why it
+// cannot be imported yet
+func (c *CurlImport) viewPreview() string {
+	var b strings.Builder
+
+	req, err := httpclient.ParseSnippet(c.editor.Value())
+	if err != nil {
+		b.WriteString(WarningStyle.Render("⚠ " + err.Error()))
+		b.WriteString("\n\n")
+		return b.String()
+	}
+
+	b.WriteString(ButtonActive.Render(req.Method))
+	b.WriteString(" ")
+	b.WriteString(TextStyle.Render(req.URL))
+	b.WriteString("\n")
+
+	names := make([]string, 0, len(req.Headers))
+	for name := range req.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b.WriteString(MutedStyle.Render(fmt.Sprintf("  %s: %s", name, req.Headers[name])))
+		b.WriteString("\n")
+	}
+	if req.Body != "" {
+		b.WriteString(MutedStyle.Render(i18n.Tf("curl_import.body", len(req.Body))))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCurlImport(t *testing.T) {
+	m := Model{builder: RequestBuilder{urlInput: textinput.New(), method: "GET", headers: map[string]string{}, currentRequestSavedID: "saved-1", requestSaved: true}}
+	m.curlImport.open(&m)
+	m.curlImport.editor.SetValue(`curl -X PATCH https://api.example.com/users/1 \
+  -H 'Content-Type: application/json' \
+  -d '{"name":"Bob"}'`)
+
+	updated, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = updated.(Model)
+	if m.state != StateRequestBuilder {
+		t.Fatalf("Expected the builder after importing, got state %v (%s)", m.state, m.curlImport.err)
+	}
+	if m.builder.method != "PATCH" || m.builder.urlInput.Value() != "https://api.example.com/users/1" || m.builder.body != `{"name":"Bob"}` {
+		t.Errorf("Imported %s %s %q", m.builder.method, m.builder.urlInput.Value(), m.builder.body)
+	}
+	if m.builder.headers["Content-Type"] != "application/json" {
+		t.Errorf("Headers = %v", m.builder.headers)
+	}
+	if m.builder.requestSaved || m.builder.currentRequestSavedID != "" {
+		t.Error("Expected the import to be unlinked from the saved request")
+	}
+}
+
+func TestCurlPastedIntoURL(t *testing.T) {
+	m := Model{state: StateRequestBuilder, builder: RequestBuilder{urlInput: textinput.New(), method: "GET", headers: map[string]string{}, focusIndex: 1}}
+	m.builder.urlInput.SetValue("curl -u admin:secret https://api.example.com/admin")
+
+	updated, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.state != StateRequestBuilder || m.builder.urlInput.Value() != "https://api.example.com/admin" {
+		t.Fatalf("Expected the pasted command to be imported, got state %v URL %q", m.state, m.builder.urlInput.Value())
+	}
+	if m.builder.headers["Authorization"] != "Basic YWRtaW46c2VjcmV0" {
+		t.Errorf("Headers = %v", m.builder.headers)
+	}
+
+	m.builder.urlInput.SetValue("curl 'https://api.example.com")
+	updated, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.state != StateCurlImport || m.curlImport.err == "" {
+		t.Errorf("Expected a broken command to open the import screen with the error, got state %v", m.state)
+	}
+}
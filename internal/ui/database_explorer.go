@@ -0,0 +1,1569 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/abneribeiro/godev/internal/database"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/plugin"
+)
+
+// DatabaseExplorer is the database screen: the connection form, the SQL
+// editor and its results, the saved queries, the schema browser, the query
+// history and the export of results.
+type DatabaseExplorer struct {
+	client  *database.PostgresClient
+	storage *database.DatabaseStorage
+	// plugins adds the exporter plugins to the export formats
+	plugins  *plugin.Registry
+	readOnly bool
+
+	hostInput     textinput.Model
+	portInput     textinput.Model
+	nameInput     textinput.Model
+	userInput     textinput.Model
+	passwordInput textinput.Model
+	connectFocus  int
+	// err is why connecting or exporting failed
+	err error
+
+	editor textarea.Model
+	result *database.QueryResult
+	table  *BubblesTableWrapper
+	limit  resultLimiter
+
+	savedQueries    []database.SavedQuery
+	selectedQuery   int
+	queryListNotice string
+	// savedID is the saved query the editor was opened from; variables is
+	// whether the query fills in environment variables
+	savedID   string
+	variables bool
+
+	tables        []string
+	selectedTable int
+	tableInfo     *database.TableInfo
+
+	querySaveSuccess      bool
+	querySaveSuccessTimer int
+	connectSuccess        bool
+	connectSuccessTimer   int
+
+	history                []database.QueryExecution
+	selectedHistory        int
+	confirmingClearHistory bool
+
+	exportFormat       int
+	exportTableName    textinput.Model
+	exportSuccess      bool
+	exportSuccessTimer int
+	exportFilePath     string
+
+	snippetError string
+	chartMode    ChartMode
+	// snippetFill is set while the tab stops of a query template inserted
+	// into the editor are being filled
+	snippetFill *snippetFill
+
+	// restore is the screen of a restored session, opened once reconnected
+	restore *dbRestore
+}
+
+func newDatabaseExplorer(client *database.PostgresClient, dbStorage *database.DatabaseStorage) DatabaseExplorer {
+	hostInput := textinput.New()
+	hostInput.Placeholder = "localhost"
+	hostInput.CharLimit = 100
+	hostInput.Width = 40
+	hostInput.SetValue("localhost")
+
+	portInput := textinput.New()
+	portInput.Placeholder = "5432"
+	portInput.CharLimit = 10
+	portInput.Width = 15
+	portInput.SetValue("5432")
+
+	nameInput := textinput.New()
+	nameInput.Placeholder = "database name"
+	nameInput.CharLimit = 100
+	nameInput.Width = 40
+
+	userInput := textinput.New()
+	userInput.Placeholder = "username"
+	userInput.CharLimit = 100
+	userInput.Width = 40
+
+	passwordInput := textinput.New()
+	passwordInput.Placeholder = "password"
+	passwordInput.CharLimit = 100
+	passwordInput.Width = 40
+	passwordInput.EchoMode = textinput.EchoPassword
+	passwordInput.EchoCharacter = '•'
+
+	editor := textarea.New()
+	editor.Placeholder = "SELECT * FROM table_name;"
+	editor.CharLimit = 50000
+	editor.SetWidth(80)
+	editor.SetHeight(10)
+	// Disable Ctrl+K built-in behavior (delete line) so we can use it for query execution
+	editor.KeyMap.DeleteAfterCursor.SetEnabled(false)
+
+	exportTableName := textinput.New()
+	exportTableName.Placeholder = "table_name"
+	exportTableName.CharLimit = 100
+	exportTableName.Width = 40
+
+	d := DatabaseExplorer{
+		client:          client,
+		storage:         dbStorage,
+		hostInput:       hostInput,
+		portInput:       portInput,
+		nameInput:       nameInput,
+		userInput:       userInput,
+		passwordInput:   passwordInput,
+		editor:          editor,
+		savedQueries:    []database.SavedQuery{},
+		exportTableName: exportTableName,
+	}
+	if dbStorage != nil {
+		d.savedQueries = dbStorage.GetQueries()
+	}
+	return d
+}
+
+// connected reports whether a database is connected
+func (d *DatabaseExplorer) connected() bool {
+	return d.client != nil && d.client.IsConnected()
+}
+
+// connectedClient returns the connected database client, or nil
+func (d *DatabaseExplorer) connectedClient() *database.PostgresClient {
+	if !d.connected() {
+		return nil
+	}
+	return d.client
+}
+
+// setReadOnly keeps the connection from running statements that change
+// the database, and connections from being saved
+func (d *DatabaseExplorer) setReadOnly(readOnly bool) {
+	d.readOnly = readOnly
+	if d.client != nil {
+		d.client.SetReadOnly(readOnly)
+	}
+}
+
+// resize fits the connection form and the result table to layout
+func (d *DatabaseExplorer) resize(layout LayoutConfig) {
+	d.hostInput.Width = layout.InputWidth / 2
+	d.portInput.Width = layout.InputWidth / 4
+	d.nameInput.Width = layout.InputWidth / 2
+	d.userInput.Width = layout.InputWidth / 2
+	d.passwordInput.Width = layout.InputWidth / 2
+
+	if d.table != nil && d.result != nil && len(d.result.Columns) > 0 {
+		tableWidth, tableHeight := layout.GetTableDimensions()
+		d.table = NewBubblesTableWrapper(d.result.Columns, d.result.Rows, tableWidth, tableHeight)
+	}
+}
+
+// tick counts down the success notices
+func (d *DatabaseExplorer) tick() {
+	if d.querySaveSuccessTimer > 0 {
+		d.querySaveSuccessTimer--
+		if d.querySaveSuccessTimer == 0 {
+			d.querySaveSuccess = false
+		}
+	}
+	if d.connectSuccessTimer > 0 {
+		d.connectSuccessTimer--
+		if d.connectSuccessTimer == 0 {
+			d.connectSuccess = false
+		}
+	}
+	if d.exportSuccessTimer > 0 {
+		d.exportSuccessTimer--
+		if d.exportSuccessTimer == 0 {
+			d.exportSuccess = false
+		}
+	}
+}
+
+// showResult keeps the result of the query in the editor, lays it out as a
+// table when it has rows and adds it to the query history
+func (d *DatabaseExplorer) showResult(result database.QueryResult, layout LayoutConfig) {
+	d.result = &result
+	d.chartMode = ChartNone
+
+	if len(result.Columns) > 0 && len(result.Rows) > 0 {
+		tableWidth, tableHeight := layout.GetTableDimensions()
+		d.table = NewBubblesTableWrapper(result.Columns, result.Rows, tableWidth, tableHeight)
+	} else {
+		d.table = nil
+	}
+
+	if d.storage != nil {
+		query := strings.TrimSpace(d.editor.Value())
+		connectionInfo := d.client.GetConnectionString()
+		d.storage.AddToQueryHistory(query, connectionInfo, result.RowsAffected, result.ExecutionTime.Milliseconds(), result.Error)
+	}
+}
+
+// showTables opens the schema browser on the tables of a new connection
+func (d *DatabaseExplorer) showTables(tables []string) {
+	d.tables = tables
+	d.selectedTable = 0
+	d.connectSuccess = true
+	d.connectSuccessTimer = 3
+}
+
+// editQuery opens query in the SQL editor in place of what was there
+func (d *DatabaseExplorer) editQuery(h host, query string) {
+	d.snippetFill = nil
+	d.editor.SetValue(query)
+	d.linkQuery(nil)
+	d.editor.Focus()
+	h.navigate(StateDatabaseQueryEditor)
+}
+
+// Update handles a key for the part of the explorer that is showing
+func (d *DatabaseExplorer) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	switch h.screenState() {
+	case StateDatabaseConnect:
+		return d.updateConnect(h, msg)
+	case StateDatabaseQueryEditor:
+		return d.updateQueryEditor(h, msg)
+	case StateDatabaseResult:
+		return d.updateResult(h, msg)
+	case StateDatabaseQueryList:
+		return d.updateQueryList(h, msg)
+	case StateDatabaseSchema:
+		return d.updateSchema(h, msg)
+	case StateDatabaseQueryHistory:
+		return d.updateQueryHistory(h, msg)
+	case StateDatabaseExport:
+		return d.updateExport(h, msg)
+	}
+	return d.updateMenu(h, msg)
+}
+
+func (d *DatabaseExplorer) View(h host) string {
+	switch h.screenState() {
+	case StateDatabaseConnect:
+		return d.viewConnect(h)
+	case StateDatabaseQueryEditor:
+		return d.viewQueryEditor(h)
+	case StateDatabaseResult:
+		return d.viewResult(h)
+	case StateDatabaseQueryList:
+		return d.viewQueryList(h)
+	case StateDatabaseSchema:
+		return d.viewSchema(h)
+	case StateDatabaseQueryHistory:
+		return d.viewQueryHistory(h)
+	case StateDatabaseExport:
+		return d.viewExport(h)
+	}
+	return d.viewMenu(h)
+}
+
+// typing reports whether keys go to the limit bar of the result view, so
+// that shortcuts are typed instead
+func (d *DatabaseExplorer) typing() bool {
+	return d.limit.active
+}
+
+func (d *DatabaseExplorer) updateMenu(h host, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		if d.connected() {
+			d.client.Close()
+		}
+		h.navigate(StateRequestBuilder)
+		return nil
+
+	case "c":
+		h.navigate(StateDatabaseConnect)
+		d.err = nil
+		d.connectFocus = 0
+		d.updateConnectFocus()
+		return nil
+
+	case "q":
+		if d.connected() {
+			h.navigate(StateDatabaseQueryEditor)
+			d.editor.Focus()
+			return nil
+		}
+		return nil
+
+	case "l":
+		if d.connected() {
+			h.navigate(StateDatabaseQueryList)
+			d.selectedQuery = 0
+			d.queryListNotice = ""
+			return nil
+		}
+		return nil
+
+	case "s", "t":
+		if d.connected() {
+			h.navigate(StateDatabaseSchema)
+			return nil
+		}
+		return nil
+
+	case "h":
+		if d.connected() {
+			if d.storage != nil {
+				d.history = d.storage.GetQueryHistory()
+			}
+			h.navigate(StateDatabaseQueryHistory)
+			d.selectedHistory = 0
+			d.confirmingClearHistory = false
+			return nil
+		}
+		return nil
+
+	case "d":
+		if d.connected() {
+			d.client.Close()
+			return nil
+		}
+		return nil
+	}
+
+	return nil
+}
+
+func (d *DatabaseExplorer) viewMenu(h host) string {
+	width, height := h.size()
+
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.database")))
+	b.WriteString("\n\n")
+
+	if !d.connected() {
+		b.WriteString(TextStyle.Render("Welcome to the Database Explorer!"))
+		b.WriteString("\n\n")
+		b.WriteString(MutedStyle.Render("Connect to a PostgreSQL database to start"))
+		b.WriteString("\n\n")
+
+		menuPanel := lipgloss.NewStyle().
+			Border(roundedBorder()).
+			BorderForeground(lipgloss.Color(ColorAccent)).
+			Padding(1, 2).
+			Width(width - 10).
+			Render(HeaderStyle.Render("Actions") + "\n\n" +
+				ButtonActive.Render("[ c ] Connect to Database") + "\n\n" +
+				MutedStyle.Render("Press 'c' to open the connection form"))
+
+		b.WriteString(menuPanel)
+		b.WriteString("\n\n")
+
+		b.WriteString(MutedStyle.Render("Features: Execute SQL • Save Queries • Browse Tables • Query History"))
+	} else {
+		connectionInfo := d.client.GetConnectionString()
+		b.WriteString(SuccessStyle.Render("✓ Connected to: " + connectionInfo))
+		b.WriteString("\n\n")
+
+		menuPanel := lipgloss.NewStyle().
+			Border(roundedBorder()).
+			BorderForeground(lipgloss.Color(ColorBorder)).
+			Padding(1, 2).
+			Width(width - 10).
+			Render(HeaderStyle.Render("Menu") + "\n\n" +
+				TextStyle.Render("  [q] Execute Query") + "\n" +
+				TextStyle.Render("  [s] Schema Browser") + "\n" +
+				TextStyle.Render("  [l] Saved Queries") + "\n" +
+				TextStyle.Render("  [f] Find Value in Tables") + "\n" +
+				TextStyle.Render("  [h] Query History") + "\n" +
+				TextStyle.Render("  [d] Disconnect") + "\n")
+
+		b.WriteString(menuPanel)
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(RenderFooter(i18n.T("footer.database")))
+
+	return Center(width, height, b.String())
+}
+
+func (d *DatabaseExplorer) updateConnect(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		h.navigate(StateDatabase)
+		d.restore = nil
+		d.connectFocus = 0
+		d.hostInput.Blur()
+		d.portInput.Blur()
+		d.nameInput.Blur()
+		d.userInput.Blur()
+		d.passwordInput.Blur()
+		return nil
+
+	case "tab":
+		d.connectFocus++
+		if d.connectFocus > 4 {
+			d.connectFocus = 0
+		}
+		d.updateConnectFocus()
+		return nil
+
+	case "shift+tab":
+		d.connectFocus--
+		if d.connectFocus < 0 {
+			d.connectFocus = 4
+		}
+		d.updateConnectFocus()
+		return nil
+
+	case "enter":
+		hostname := strings.TrimSpace(d.hostInput.Value())
+		portStr := strings.TrimSpace(d.portInput.Value())
+		dbname := strings.TrimSpace(d.nameInput.Value())
+		user := strings.TrimSpace(d.userInput.Value())
+		password := d.passwordInput.Value()
+
+		if hostname == "" || portStr == "" || dbname == "" || user == "" {
+			return nil
+		}
+
+		port := 5432
+		fmt.Sscanf(portStr, "%d", &port)
+
+		config := database.ConnectionConfig{
+			Host:     hostname,
+			Port:     port,
+			Database: dbname,
+			User:     user,
+			Password: password,
+			SSLMode:  "disable",
+		}
+
+		err := d.client.Connect(config)
+		if err != nil {
+			d.err = err
+			return nil
+		}
+		d.err = nil
+
+		if d.storage != nil && !d.readOnly {
+			d.storage.SaveConnection(config)
+		}
+
+		ctx, tick := h.startLoading(opSchema)
+		return tea.Batch(tick, loadDatabaseSchemaCmd(ctx, d.client))
+
+	default:
+		switch d.connectFocus {
+		case 0:
+			d.hostInput, cmd = d.hostInput.Update(msg)
+		case 1:
+			d.portInput, cmd = d.portInput.Update(msg)
+		case 2:
+			d.nameInput, cmd = d.nameInput.Update(msg)
+		case 3:
+			d.userInput, cmd = d.userInput.Update(msg)
+		case 4:
+			d.passwordInput, cmd = d.passwordInput.Update(msg)
+		}
+		return cmd
+	}
+}
+
+func (d *DatabaseExplorer) updateConnectFocus() {
+	d.hostInput.Blur()
+	d.portInput.Blur()
+	d.nameInput.Blur()
+	d.userInput.Blur()
+	d.passwordInput.Blur()
+
+	switch d.connectFocus {
+	case 0:
+		d.hostInput.Focus()
+	case 1:
+		d.portInput.Focus()
+	case 2:
+		d.nameInput.Focus()
+	case 3:
+		d.userInput.Focus()
+	case 4:
+		d.passwordInput.Focus()
+	}
+}
+
+func (d *DatabaseExplorer) viewConnect(h host) string {
+	width, height := h.size()
+
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.db_connect")))
+	b.WriteString("\n\n")
+
+	if d.restore != nil {
+		b.WriteString(MutedStyle.Render(i18n.Tf("restore.db_connect", i18n.T(stateNames[d.restore.screen]))))
+		b.WriteString("\n\n")
+	}
+
+	if d.err != nil {
+		b.WriteString(ErrorStyle.Render(fmt.Sprintf("✗ Connection failed: %v", d.err)))
+		b.WriteString("\n")
+		b.WriteString(MutedStyle.Render("→ " + errorHint(d.err)))
+		b.WriteString("\n\n")
+	}
+
+	renderInput := func(label string, input textinput.Model, focused bool) string {
+		var result strings.Builder
+		result.WriteString(TextStyle.Render(label))
+		result.WriteString("\n")
+
+		inputView := input.View()
+		var styledInput string
+		if focused {
+			styledInput = lipgloss.NewStyle().
+				Border(roundedBorder()).
+				BorderForeground(lipgloss.Color(ColorAccent)).
+				Padding(0, 1).
+				Width(input.Width + 2).
+				Render(inputView)
+		} else {
+			styledInput = lipgloss.NewStyle().
+				Border(roundedBorder()).
+				BorderForeground(lipgloss.Color(ColorBorder)).
+				Padding(0, 1).
+				Width(input.Width + 2).
+				Render(inputView)
+		}
+		result.WriteString(styledInput)
+		result.WriteString("\n\n")
+		return result.String()
+	}
+
+	b.WriteString(renderInput("Host:", d.hostInput, d.connectFocus == 0))
+	b.WriteString(renderInput("Port:", d.portInput, d.connectFocus == 1))
+	b.WriteString(renderInput("Database:", d.nameInput, d.connectFocus == 2))
+	b.WriteString(renderInput("User:", d.userInput, d.connectFocus == 3))
+	b.WriteString(renderInput("Password:", d.passwordInput, d.connectFocus == 4))
+
+	buttons := RenderButton("Connect (Enter)", true) + "  "
+	buttons += RenderButton("Cancel (Esc)", false)
+	b.WriteString(buttons)
+
+	b.WriteString("\n\n")
+	b.WriteString(RenderFooter(i18n.T("footer.db_connect")))
+
+	return Center(width, height, b.String())
+}
+
+type databaseResultMsg database.QueryResult
+
+func executeDatabaseQueryCmd(ctx context.Context, client *database.PostgresClient, query string) tea.Cmd {
+	return func() tea.Msg {
+		result := client.ExecuteQueryContext(ctx, query)
+		return databaseResultMsg(result)
+	}
+}
+
+func loadDatabaseSchemaCmd(ctx context.Context, client *database.PostgresClient) tea.Cmd {
+	return func() tea.Msg {
+		tables, err := client.GetTablesContext(ctx)
+		if err != nil {
+			return databaseSchemaMsg([]string{})
+		}
+		return databaseSchemaMsg(tables)
+	}
+}
+
+func (d *DatabaseExplorer) updateQueryEditor(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	if d.snippetFill != nil && d.handleSnippetFillKeys(msg) {
+		return nil
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		h.navigate(StateDatabase)
+		d.editor.Blur()
+		return nil
+
+	case "ctrl+g":
+		d.toggleQueryVariables(h)
+		return nil
+
+	case "ctrl+k":
+		return d.runQuery(h)
+
+	case "ctrl+s":
+		if h.blockedByReadOnly("save query") {
+			return nil
+		}
+		query := strings.TrimSpace(d.editor.Value())
+		if query == "" || d.storage == nil {
+			return nil
+		}
+
+		name := fmt.Sprintf("Query %s", time.Now().Format("15:04:05"))
+		if !d.storage.QueryExists(name) {
+			d.storage.SaveQuery(name, query)
+			d.savedQueries = d.storage.GetQueries()
+			d.querySaveSuccess = true
+			d.querySaveSuccessTimer = 3
+			if n := len(d.savedQueries); n > 0 {
+				saved := d.savedQueries[n-1]
+				if d.variables {
+					h.reportStorageError("failed to save query variables", d.storage.SetQueryVariables(saved.ID, true))
+					d.savedQueries = d.storage.GetQueries()
+				}
+				d.savedID = saved.ID
+			}
+		}
+		return nil
+
+	default:
+		d.editor, cmd = d.editor.Update(msg)
+		return cmd
+	}
+}
+
+func (d *DatabaseExplorer) viewQueryEditor(h host) string {
+	width, height := h.size()
+
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.query_editor")))
+	b.WriteString("\n\n")
+
+	connectionInfo := d.client.GetConnectionString()
+	b.WriteString(MutedStyle.Render("Connected to: " + connectionInfo))
+	b.WriteString("\n\n")
+
+	editorPanel := lipgloss.NewStyle().
+		Border(roundedBorder()).
+		BorderForeground(lipgloss.Color(ColorAccent)).
+		Padding(1, 2).
+		Width(width - 10).
+		Render(d.editor.View())
+
+	b.WriteString(editorPanel)
+	b.WriteString("\n\n")
+	b.WriteString(d.viewSnippetFill())
+	b.WriteString(d.viewQueryVariables(h))
+	b.WriteString(d.viewQueryLint())
+
+	buttons := RenderButton("Execute (Ctrl+K)", true) + "  "
+	buttons += RenderButton("Save (Ctrl+S)", false) + "  "
+	buttons += RenderButton("Back (Esc)", false)
+	b.WriteString(buttons)
+
+	if d.querySaveSuccess {
+		b.WriteString("\n\n")
+		b.WriteString(SuccessStyle.Render(i18n.T("query_result.saved")))
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(RenderFooter(i18n.T("footer.query_editor")))
+
+	return Center(width, height, b.String())
+}
+
+func (d *DatabaseExplorer) updateResult(h host, msg tea.KeyMsg) tea.Cmd {
+	keys := h.keys()
+
+	if d.limit.active {
+		return d.updateLimit(h, msg)
+	}
+
+	// Handle global keys first
+	if key.Matches(msg, keys.Quit) {
+		return tea.Quit
+	}
+
+	if key.Matches(msg, keys.Back) {
+		h.navigate(StateDatabaseQueryEditor)
+		d.editor.Focus()
+		return nil
+	}
+
+	// Handle pagination controls
+	if key.Matches(msg, keys.Left, keys.VimLeft) {
+		if d.table != nil && d.table.CanPageUp() {
+			d.table.PrevPage()
+		}
+		return nil
+	}
+
+	if key.Matches(msg, keys.Right, keys.VimRight) {
+		if d.table != nil && d.table.CanPageDown() {
+			d.table.NextPage()
+		}
+		return nil
+	}
+
+	// Handle additional navigation for large datasets
+	if key.Matches(msg, keys.Home) {
+		if d.table != nil {
+			d.table.FirstPage()
+		}
+		return nil
+	}
+
+	if key.Matches(msg, keys.End) {
+		if d.table != nil {
+			d.table.LastPage()
+		}
+		return nil
+	}
+
+	if key.Matches(msg, keys.PageUp) {
+		if d.table != nil {
+			// Jump multiple pages for large datasets
+			currentPage := d.table.GetCurrentPage()
+			targetPage := currentPage - 5
+			if targetPage < 0 {
+				targetPage = 0
+			}
+			d.table.JumpToPage(targetPage)
+		}
+		return nil
+	}
+
+	if key.Matches(msg, keys.PageDown) {
+		if d.table != nil {
+			// Jump multiple pages for large datasets
+			currentPage := d.table.GetCurrentPage()
+			totalPages := d.table.GetTotalPages()
+			targetPage := currentPage + 5
+			if targetPage >= totalPages {
+				targetPage = totalPages - 1
+			}
+			d.table.JumpToPage(targetPage)
+		}
+		return nil
+	}
+
+	// Handle database-specific actions
+	if key.Matches(msg, keys.SaveQuery) {
+		if h.blockedByReadOnly("save query") {
+			return nil
+		}
+		query := strings.TrimSpace(d.editor.Value())
+		if query == "" || d.storage == nil {
+			return nil
+		}
+
+		name := fmt.Sprintf("Query %s", time.Now().Format("15:04:05"))
+		if !d.storage.QueryExists(name) {
+			d.storage.SaveQuery(name, query)
+			d.savedQueries = d.storage.GetQueries()
+			d.querySaveSuccess = true
+			d.querySaveSuccessTimer = 3
+		}
+		return nil
+	}
+
+	if key.Matches(msg, keys.ToggleChart) {
+		if d.result == nil {
+			return nil
+		}
+		if _, ok := ExtractChartData(d.result.Columns, d.result.Rows); ok {
+			d.chartMode = (d.chartMode + 1) % 3
+		}
+		return nil
+	}
+
+	if key.Matches(msg, keys.Up, keys.VimUp) {
+		if d.table != nil {
+			d.table.MoveCursor(-1)
+		}
+		return nil
+	}
+
+	if key.Matches(msg, keys.Down, keys.VimDown) {
+		if d.table != nil {
+			d.table.MoveCursor(1)
+		}
+		return nil
+	}
+
+	if msg.String() == "L" {
+		if d.result != nil && len(d.result.Columns) > 0 {
+			d.startLimit()
+		}
+		return nil
+	}
+
+	if key.Matches(msg, keys.ExportResults) {
+		if d.result != nil && len(d.result.Columns) > 0 {
+			h.navigate(StateDatabaseExport)
+			d.err = nil
+			d.exportFormat = 0
+			d.exportTableName.SetValue("")
+			d.exportTableName.Focus()
+			return nil
+		}
+		return nil
+	}
+
+	return nil
+}
+
+func (d *DatabaseExplorer) viewResult(h host) string {
+	layout := h.screenLayout()
+
+	var b strings.Builder
+
+	b.WriteString(GetResponsiveTitleStyle(layout).Render("Query Result"))
+	b.WriteString("\n\n")
+	b.WriteString(d.viewLimit())
+
+	if d.result == nil {
+		b.WriteString(MutedStyle.Render("No result"))
+		return CenterResponsive(layout, b.String())
+	}
+
+	if d.result.Error != nil {
+		errorPanel := GetResponsivePanelStyle(layout).
+			BorderForeground(lipgloss.Color(ColorError)).
+			Render(errorPanelContent(d.result.Error))
+
+		b.WriteString(errorPanel)
+	} else {
+		timeInfo := i18n.Tf("query_result.time", d.result.ExecutionTime.Milliseconds())
+		b.WriteString(MutedStyle.Render(timeInfo))
+		b.WriteString("\n\n")
+
+		chartData, chartable := ExtractChartData(d.result.Columns, d.result.Rows)
+
+		if chartable && d.chartMode != ChartNone {
+			chartWidth, _ := layout.GetTableDimensions()
+
+			var chart string
+			if d.chartMode == ChartBar {
+				chart = RenderBarChart(chartData, chartWidth)
+			} else {
+				chart = RenderLineChart(chartData, chartWidth)
+			}
+
+			b.WriteString(HeaderStyle.Render(i18n.Tf("query_result.chart", chartData.ValueColumn, chartData.LabelColumn)))
+			b.WriteString("\n\n")
+			b.WriteString(GetResponsivePanelStyle(layout).
+				BorderForeground(lipgloss.Color(ColorBorder)).
+				Render(chart))
+
+			if len(chartData.Values) < len(d.result.Rows) {
+				b.WriteString("\n\n")
+				b.WriteString(MutedStyle.Render(i18n.Tf("query_result.chart_rows", len(chartData.Values), len(d.result.Rows))))
+			}
+		} else if len(d.result.Columns) > 0 {
+			// Create or update the table wrapper if needed
+			if d.table == nil || len(d.result.Rows) != len(d.table.allRows) {
+				// Get responsive table dimensions
+				tableWidth, tableHeight := layout.GetTableDimensions()
+
+				// Create new table wrapper with all results
+				dbResultTable := NewBubblesTableWrapper(
+					d.result.Columns,
+					d.result.Rows,
+					tableWidth,
+					tableHeight,
+				)
+
+				tableContent := dbResultTable.Render()
+
+				resultPanel := GetResponsivePanelStyle(layout).
+					BorderForeground(lipgloss.Color(ColorBorder)).
+					Render(tableContent)
+
+				b.WriteString(resultPanel)
+				b.WriteString("\n\n")
+
+				// Show pagination summary and performance info
+				summary := dbResultTable.GetPerformanceStats()
+				b.WriteString(SuccessStyle.Render("✓ " + summary))
+
+				// Show additional info for large datasets
+				if dbResultTable.IsLargeDataset() {
+					memEstimate := dbResultTable.GetMemoryEstimate()
+					perfInfo := i18n.Tf("query_result.large", memEstimate)
+					b.WriteString("\n")
+					b.WriteString(MutedStyle.Render(perfInfo))
+				}
+
+				paginationFooter := dbResultTable.RenderPaginationFooter()
+				if paginationFooter != "" {
+					b.WriteString("\n")
+					b.WriteString(MutedStyle.Render(paginationFooter))
+				}
+			} else {
+				// Use existing table wrapper
+				tableContent := d.table.Render()
+
+				resultPanel := GetResponsivePanelStyle(layout).
+					BorderForeground(lipgloss.Color(ColorBorder)).
+					Render(tableContent)
+
+				b.WriteString(resultPanel)
+				b.WriteString("\n\n")
+
+				// Show pagination summary and performance info
+				summary := d.table.GetPerformanceStats()
+				b.WriteString(SuccessStyle.Render("✓ " + summary))
+
+				// Show additional info for large datasets
+				if d.table.IsLargeDataset() {
+					memEstimate := d.table.GetMemoryEstimate()
+					perfInfo := i18n.Tf("query_result.large", memEstimate)
+					b.WriteString("\n")
+					b.WriteString(MutedStyle.Render(perfInfo))
+				}
+
+				paginationFooter := d.table.RenderPaginationFooter()
+				if paginationFooter != "" {
+					b.WriteString("\n")
+					b.WriteString(MutedStyle.Render(paginationFooter))
+				}
+			}
+		} else {
+			b.WriteString(SuccessStyle.Render(i18n.T("query_result.executed")))
+			b.WriteString("\n\n")
+			b.WriteString(TextStyle.Render(i18n.Tf("query_result.rows_affected", d.result.RowsAffected)))
+		}
+
+		if pinned := h.viewPinned(paneQuery); pinned != "" && len(d.result.Columns) > 0 {
+			b.WriteString("\n\n")
+			b.WriteString(pinned)
+		}
+	}
+
+	if d.querySaveSuccess {
+		b.WriteString("\n\n")
+		b.WriteString(SuccessStyle.Render(i18n.T("query_result.saved")))
+	}
+
+	if d.exportSuccess {
+		b.WriteString("\n\n")
+		b.WriteString(SuccessStyle.Render(fmt.Sprintf("✓ Results exported to: %s", d.exportFilePath)))
+	}
+
+	b.WriteString("\n\n")
+
+	// Generate responsive footer
+	helpText := ""
+	if d.table != nil && d.table.GetTotalPages() > 1 {
+		if d.table.IsLargeDataset() {
+			// Extended navigation for large datasets
+			helpText = "←/→: page • home/end: first/last • pgup/pgdn: jump 5 pages • s: save • e: export • esc: back"
+		} else {
+			// Standard navigation for smaller datasets
+			helpText = "←/→: navigate pages • s: save query • e: export results • esc: back"
+		}
+	} else {
+		helpText = "s: save query • e: export results • esc: back"
+	}
+	if d.result != nil && len(d.result.Columns) > 0 {
+		helpText = "L: limit/sample • T: timestamps • |: side by side • " + helpText
+	}
+	if d.result != nil && len(d.result.Rows) > 0 {
+		helpText = "↑/↓: row • a: API request • " + helpText
+	}
+
+	if d.result != nil {
+		if _, ok := ExtractChartData(d.result.Columns, d.result.Rows); ok {
+			switch d.chartMode {
+			case ChartNone:
+				helpText = "c: bar chart • " + helpText
+			case ChartBar:
+				helpText = "c: line chart • " + helpText
+			default:
+				helpText = "c: table • " + helpText
+			}
+		}
+	}
+
+	b.WriteString(RenderResponsiveFooter(helpText, layout))
+
+	return CenterResponsive(layout, b.String())
+}
+
+func (d *DatabaseExplorer) updateQueryList(h host, msg tea.KeyMsg) tea.Cmd {
+	keys := h.keys()
+
+	// Handle global keys first
+	if key.Matches(msg, keys.Quit) {
+		return tea.Quit
+	}
+
+	if key.Matches(msg, keys.Back) {
+		h.navigate(StateDatabase)
+		return nil
+	}
+
+	// Handle navigation
+	if key.Matches(msg, keys.Up, keys.VimUp) {
+		if d.selectedQuery > 0 {
+			d.selectedQuery--
+		}
+		return nil
+	}
+
+	if key.Matches(msg, keys.Down, keys.VimDown) {
+		if d.selectedQuery < len(d.savedQueries)-1 {
+			d.selectedQuery++
+		}
+		return nil
+	}
+
+	// Handle selection and actions
+	if key.Matches(msg, keys.Enter, keys.SelectItem) {
+		if len(d.savedQueries) > 0 && d.selectedQuery < len(d.savedQueries) {
+			query := d.savedQueries[d.selectedQuery]
+			d.editor.SetValue(query.Query)
+			d.linkQuery(&query)
+			h.navigate(StateDatabaseQueryEditor)
+			d.editor.Focus()
+		}
+		return nil
+	}
+
+	if key.Matches(msg, keys.DeleteItem) {
+		if h.blockedByReadOnly("delete query") {
+			return nil
+		}
+		if len(d.savedQueries) > 0 && d.selectedQuery < len(d.savedQueries) && d.storage != nil {
+			query := d.savedQueries[d.selectedQuery]
+			if err := archiveQuery(h.store(), query); err != nil {
+				d.queryListNotice = "✗ " + err.Error()
+				return nil
+			}
+			d.storage.DeleteQuery(query.ID)
+			d.queryListNotice = fmt.Sprintf("✓ Moved '%s' to trash", query.Name)
+			d.savedQueries = d.storage.GetQueries()
+			if d.selectedQuery >= len(d.savedQueries) && d.selectedQuery > 0 {
+				d.selectedQuery--
+			}
+		}
+		return nil
+	}
+
+	return nil
+}
+
+func (d *DatabaseExplorer) viewQueryList(h host) string {
+	width, height := h.size()
+
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.Tf("title.saved_queries", len(d.savedQueries))))
+	b.WriteString("\n\n")
+
+	if len(d.savedQueries) == 0 {
+		b.WriteString(MutedStyle.Render("No saved queries"))
+		b.WriteString("\n\n")
+		b.WriteString(TextStyle.Render("Save queries from the editor with Ctrl+S"))
+	} else {
+		for i, query := range d.savedQueries {
+			if i == d.selectedQuery {
+				b.WriteString(ListItemSelectedStyle.Render("> " + query.Name))
+			} else {
+				b.WriteString(ListItemStyle.Render(query.Name))
+			}
+			if warnings := database.LintQuery(query.Query); len(warnings) > 0 {
+				b.WriteString(WarningStyle.Render(fmt.Sprintf(" ⚠ %d", len(warnings))))
+			}
+			// The selected query always shows its preview
+			if i == d.selectedQuery || h.detailedLists() {
+				b.WriteString("\n")
+				preview := strings.ReplaceAll(query.Query, "\n", " ")
+				preview = truncateWidth(preview, 83, "...")
+				b.WriteString(MutedStyle.Render("    " + preview))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n\n")
+	if d.queryListNotice != "" {
+		if strings.HasPrefix(d.queryListNotice, "✓") {
+			b.WriteString(SuccessStyle.Render(d.queryListNotice))
+		} else {
+			b.WriteString(ErrorStyle.Render(d.queryListNotice))
+		}
+		b.WriteString("\n\n")
+	}
+	b.WriteString(RenderFooter(i18n.T("footer.saved_queries")))
+
+	return Center(width, height, b.String())
+}
+
+func (d *DatabaseExplorer) updateSchema(h host, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		h.navigate(StateDatabase)
+		return nil
+
+	case "up", "k":
+		if d.selectedTable > 0 {
+			d.selectedTable--
+			d.tableInfo = nil
+			d.snippetError = ""
+		}
+		return nil
+
+	case "down", "j":
+		if d.selectedTable < len(d.tables)-1 {
+			d.selectedTable++
+			d.tableInfo = nil
+			d.snippetError = ""
+		}
+		return nil
+
+	case "S":
+		return d.insertTableSnippet(h, database.SnippetSelect)
+
+	case "I":
+		return d.insertTableSnippet(h, database.SnippetInsert)
+
+	case "U":
+		return d.insertTableSnippet(h, database.SnippetUpdate)
+
+	case "enter":
+		if len(d.tables) > 0 && d.selectedTable < len(d.tables) {
+			tableName := d.tables[d.selectedTable]
+			tableInfo, err := d.client.GetTableInfo(tableName)
+			if err == nil {
+				d.tableInfo = tableInfo
+			}
+		}
+		return nil
+
+	case "q":
+		h.navigate(StateDatabaseQueryEditor)
+		d.editor.Focus()
+		return nil
+
+	case "l":
+		h.navigate(StateDatabaseQueryList)
+		d.selectedQuery = 0
+		d.queryListNotice = ""
+		return nil
+	}
+
+	return nil
+}
+
+// insertTableSnippet generates a snippet for the selected table and opens it in the query editor
+func (d *DatabaseExplorer) insertTableSnippet(h host, kind database.SnippetKind) tea.Cmd {
+	if len(d.tables) == 0 || d.selectedTable >= len(d.tables) {
+		return nil
+	}
+
+	tableName := d.tables[d.selectedTable]
+	metadata, err := d.client.GetTableMetadata(tableName)
+	if err != nil {
+		d.snippetError = err.Error()
+		return nil
+	}
+
+	snippet, err := database.GenerateSnippet(kind, metadata)
+	if err != nil {
+		d.snippetError = err.Error()
+		return nil
+	}
+
+	d.snippetError = ""
+	d.editor.SetValue(snippet)
+	d.linkQuery(nil)
+	h.navigate(StateDatabaseQueryEditor)
+	d.editor.Focus()
+	return nil
+}
+
+func (d *DatabaseExplorer) viewSchema(h host) string {
+	width, height := h.size()
+
+	var b strings.Builder
+
+	connectionInfo := d.client.GetConnectionString()
+	b.WriteString(TitleStyle.Render(i18n.T("title.schema")))
+	b.WriteString("\n")
+	b.WriteString(MutedStyle.Render(connectionInfo))
+	b.WriteString("\n")
+
+	if extensions := d.client.DetectedExtensions(); len(extensions) > 0 {
+		b.WriteString(MutedStyle.Render("Extensions: " + strings.Join(extensions, ", ")))
+		b.WriteString("\n")
+	}
+
+	if d.connectSuccess {
+		b.WriteString("\n")
+		b.WriteString(SuccessStyle.Render("✓ Connected successfully to database"))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+
+	if len(d.tables) == 0 {
+		b.WriteString(MutedStyle.Render("No tables found in this database"))
+		b.WriteString("\n\n")
+		b.WriteString(TextStyle.Render("Press 'q' to open query editor"))
+	} else {
+		b.WriteString(HeaderStyle.Render(fmt.Sprintf("Tables (%d)", len(d.tables))))
+		b.WriteString("\n\n")
+
+		maxTablesToShow := 15
+		start := d.selectedTable
+		if start > len(d.tables)-maxTablesToShow {
+			start = len(d.tables) - maxTablesToShow
+		}
+		if start < 0 {
+			start = 0
+		}
+		end := start + maxTablesToShow
+		if end > len(d.tables) {
+			end = len(d.tables)
+		}
+
+		for i := start; i < end; i++ {
+			tableName := d.tables[i]
+			if i == d.selectedTable {
+				b.WriteString(ListItemSelectedStyle.Render("> " + tableName))
+			} else {
+				b.WriteString(ListItemStyle.Render(tableName))
+			}
+			b.WriteString("\n")
+		}
+
+		if d.tableInfo != nil {
+			b.WriteString("\n")
+			b.WriteString(HeaderStyle.Render(fmt.Sprintf("Table: %s", d.tableInfo.Name)))
+			b.WriteString("\n\n")
+
+			if len(d.tableInfo.Columns) > 0 {
+				columnData := [][]string{}
+				for _, col := range d.tableInfo.Columns {
+					nullable := "NO"
+					if col.Nullable {
+						nullable = "YES"
+					}
+					columnData = append(columnData, []string{col.Name, col.Type, nullable})
+				}
+
+				tableRenderer := NewTableRenderer(
+					[]string{"Column", "Type", "Nullable"},
+					columnData,
+					width-20,
+				)
+				b.WriteString(tableRenderer.Render())
+			}
+		}
+
+		if d.snippetError != "" {
+			b.WriteString("\n")
+			b.WriteString(ErrorStyle.Render(fmt.Sprintf("✗ Snippet failed: %s", d.snippetError)))
+		}
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(RenderFooter(i18n.T("footer.schema")))
+
+	return Center(width, height, b.String())
+}
+
+func (d *DatabaseExplorer) updateQueryHistory(h host, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		d.confirmingClearHistory = false
+		h.navigate(StateDatabase)
+		return nil
+
+	case "up", "k":
+		if d.selectedHistory > 0 {
+			d.selectedHistory--
+		}
+		return nil
+
+	case "down", "j":
+		if d.selectedHistory < len(d.history)-1 {
+			d.selectedHistory++
+		}
+		return nil
+
+	case "enter":
+		if len(d.history) > 0 && d.selectedHistory < len(d.history) {
+			execution := d.history[d.selectedHistory]
+			d.editor.SetValue(execution.Query)
+			d.linkQuery(nil)
+			h.navigate(StateDatabaseQueryEditor)
+			d.editor.Focus()
+			return nil
+		}
+		return nil
+
+	case "d":
+		if h.blockedByReadOnly("delete query history item") {
+			return nil
+		}
+		if len(d.history) > 0 && d.selectedHistory < len(d.history) {
+			execution := d.history[d.selectedHistory]
+			if d.storage != nil {
+				d.storage.DeleteQueryHistoryItem(execution.ID)
+				d.history = d.storage.GetQueryHistory()
+				if d.selectedHistory >= len(d.history) && len(d.history) > 0 {
+					d.selectedHistory = len(d.history) - 1
+				}
+			}
+		}
+		return nil
+
+	case "c":
+		if h.blockedByReadOnly("clear query history") {
+			return nil
+		}
+		if !d.confirmingClearHistory {
+			d.confirmingClearHistory = true
+		}
+		return nil
+
+	case "y":
+		if d.confirmingClearHistory && d.storage != nil {
+			d.storage.ClearQueryHistory()
+			d.history = []database.QueryExecution{}
+			d.selectedHistory = 0
+			d.confirmingClearHistory = false
+		}
+		return nil
+	}
+
+	return nil
+}
+
+func (d *DatabaseExplorer) viewQueryHistory(h host) string {
+	width, height := h.size()
+
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.Tf("title.query_history", len(d.history))))
+	b.WriteString("\n\n")
+
+	if len(d.history) == 0 {
+		b.WriteString(MutedStyle.Render("No query history"))
+		b.WriteString("\n\n")
+		b.WriteString(TextStyle.Render("Execute some queries to see them here"))
+	} else {
+		maxItems := (height - 15) / h.listItemLines()
+		start := d.selectedHistory
+		if start > len(d.history)-maxItems {
+			start = len(d.history) - maxItems
+		}
+		if start < 0 {
+			start = 0
+		}
+		end := start + maxItems
+		if end > len(d.history) {
+			end = len(d.history)
+		}
+
+		for i := start; i < end; i++ {
+			exec := d.history[i]
+
+			statusStyle := SuccessStyle
+			statusText := "SUCCESS"
+			if exec.Error != "" {
+				statusStyle = ErrorStyle
+				statusText = "ERROR"
+			}
+
+			timestamp := exec.Timestamp.Format("15:04:05")
+			queryPreview := exec.Query
+			queryPreview = truncateWidth(queryPreview, 63, "...")
+			queryPreview = strings.ReplaceAll(queryPreview, "\n", " ")
+
+			line := fmt.Sprintf("%s  %s", timestamp, queryPreview)
+
+			separator := "  "
+			if h.detailedLists() {
+				separator = "\n    "
+			}
+			if i == d.selectedHistory {
+				b.WriteString(ListItemSelectedStyle.Render("> " + line))
+				b.WriteString(separator)
+
+				info := statusStyle.Render(statusText)
+				if exec.Error == "" {
+					info += fmt.Sprintf(" • %dms • %d rows", exec.ExecutionTime, exec.RowsAffected)
+				} else {
+					info += fmt.Sprintf(" • %s", exec.Error)
+				}
+				b.WriteString(MutedStyle.Render(info))
+			} else {
+				b.WriteString(ListItemStyle.Render(line))
+				b.WriteString(separator)
+				info := fmt.Sprintf("%s • %dms", statusStyle.Render(statusText), exec.ExecutionTime)
+				b.WriteString(MutedStyle.Render(info))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+
+	if d.confirmingClearHistory {
+		b.WriteString(WarningStyle.Render(i18n.T("confirm.clear_history")))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(RenderFooter(i18n.T("footer.query_history")))
+
+	return Center(width, height, b.String())
+}
+
+func (d *DatabaseExplorer) updateExport(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		h.navigate(StateDatabaseResult)
+		d.exportTableName.Blur()
+		return nil
+
+	case "up", "k":
+		if d.exportFormat > 0 {
+			d.exportFormat--
+		}
+		return nil
+
+	case "down", "j":
+		if d.exportFormat < len(d.exportFormatLabels())-1 {
+			d.exportFormat++
+		}
+		return nil
+
+	case "tab", "shift+tab":
+		d.exportTableName.Focus()
+		return nil
+
+	case "enter":
+		tableName := strings.TrimSpace(d.exportTableName.Value())
+
+		if idx := d.exportFormat - len(builtinExportFormats); idx >= 0 {
+			filePath, err := d.exportWithPlugin(idx, tableName)
+			if err != nil {
+				d.err = err
+				return nil
+			}
+			d.exportFilePath = filePath
+			d.exportSuccess = true
+			d.exportSuccessTimer = 5
+			h.navigate(StateDatabaseResult)
+			d.exportTableName.Blur()
+			return nil
+		}
+
+		format := builtinExportFormats[d.exportFormat].format
+
+		if format == database.ExportFormatSQL && tableName == "" {
+			tableName = "exported_table"
+		}
+
+		result := database.ExportQueryResult(d.result, format, tableName)
+
+		if result.Error != nil {
+			d.err = result.Error
+			return nil
+		}
+
+		d.exportFilePath = result.FilePath
+		d.exportSuccess = true
+		d.exportSuccessTimer = 5
+		h.navigate(StateDatabaseResult)
+		d.exportTableName.Blur()
+
+		return nil
+
+	default:
+		if d.exportTableName.Focused() {
+			d.exportTableName, cmd = d.exportTableName.Update(msg)
+			return cmd
+		}
+		return nil
+	}
+}
+
+func (d *DatabaseExplorer) viewExport(h host) string {
+	width, height := h.size()
+
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.export")))
+	b.WriteString("\n\n")
+
+	b.WriteString(HeaderStyle.Render("Select Export Format"))
+	b.WriteString("\n\n")
+
+	for i, format := range d.exportFormatLabels() {
+		if i == d.exportFormat {
+			b.WriteString(ListItemSelectedStyle.Render("> " + format))
+		} else {
+			b.WriteString(ListItemStyle.Render(format))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(HeaderStyle.Render("Table Name (for SQL export)"))
+	b.WriteString("\n\n")
+
+	tableNameBox := lipgloss.NewStyle().
+		Border(roundedBorder()).
+		BorderForeground(lipgloss.Color(ColorAccent)).
+		Padding(0, 1).
+		Width(width - 10).
+		Render(d.exportTableName.View())
+
+	b.WriteString(tableNameBox)
+	b.WriteString("\n\n")
+
+	info := fmt.Sprintf("Exporting %d rows", len(d.result.Rows))
+	b.WriteString(MutedStyle.Render(info))
+
+	if d.err != nil {
+		b.WriteString("\n\n")
+		b.WriteString(ErrorStyle.Render(i18n.Tf("query_result.export_failed", d.err)))
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(RenderFooter(i18n.T("footer.export")))
+
+	return Center(width, height, b.String())
+}
@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// storageUnavailable reports whether the app runs without persistence
+// because storage could not be opened
+func (m Model) storageUnavailable() bool {
+	return m.storage == nil && m.storageInitErr != nil
+}
+
+// openStorageUnavailable shows why persistence is disabled and how to fix it
+func (m *Model) openStorageUnavailable() {
+	m.state = StateStorageUnavailable
+	m.choosingStorageDir = false
+	m.storageDirInput.Blur()
+}
+
+// attachStorage reopens every store from the given storage and reloads the UI data
+func (m *Model) attachStorage(store *storage.Storage) error {
+	dbStorage, err := openDatabaseStorage(store)
+	if err != nil {
+		return err
+	}
+
+	if m.storage != nil && m.storage != store {
+		m.reportStorageError("failed to record history", m.storage.Close())
+	}
+	if m.historyWriter != nil {
+		store.StartHistoryWriter(*m.historyWriter)
+	}
+
+	m.storage = store
+	m.db.storage = dbStorage
+	m.storageInitErr = nil
+
+	m.requests.saved = store.GetRequests()
+	m.requests.filtered = nil
+	m.requests.selected = 0
+	m.history.entries = store.GetHistory()
+	m.history.selected = 0
+	m.builder.requestSaved = false
+	m.builder.currentRequestSavedID = ""
+	m.currentGraphQLOpID = ""
+	m.viewer.displayTransform = ""
+	m.latencyBudget = 0
+	m.assertions.list = nil
+	m.hmacAuth = nil
+	m.requestAuth = nil
+	m.requestPolicy = nil
+	m.viewer.dropSpooledBody()
+	m.viewer.response = nil
+
+	m.envs.config = nil
+	m.envs.list = nil
+	m.envs.selected = 0
+	m.envs.reload(store)
+
+	m.db.savedQueries = dbStorage.GetQueries()
+	m.db.selectedQuery = 0
+
+	return nil
+}
+
+// retryStorage tries to open storage again, in dir when it is set and in
+// the default location otherwise
+func (m *Model) retryStorage(dir string) error {
+	var store *storage.Storage
+	var err error
+	if dir == "" {
+		store, err = storage.NewStorage()
+	} else {
+		store, err = storage.NewStorageAt(paths.ExpandHome(dir))
+	}
+	if err == nil {
+		err = m.attachStorage(store)
+	}
+	if err != nil {
+		m.storageInitErr = err
+		return err
+	}
+	return nil
+}
+
+func (m Model) handleStorageUnavailableKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	if m.choosingStorageDir {
+		switch msg.String() {
+		case "ctrl+c", "ctrl+q":
+			return m, tea.Quit
+		case "esc":
+			m.choosingStorageDir = false
+			m.storageDirInput.Blur()
+			return m, nil
+		case "enter":
+			dir := strings.TrimSpace(m.storageDirInput.Value())
+			if dir == "" {
+				return m, nil
+			}
+			m.choosingStorageDir = false
+			m.storageDirInput.Blur()
+			if err := m.retryStorage(dir); err == nil {
+				m.state = StateHome
+			}
+			return m, nil
+		}
+
+		m.storageDirInput, cmd = m.storageDirInput.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q", "q":
+		return m, tea.Quit
+
+	case "r":
+		if err := m.retryStorage(""); err == nil {
+			m.state = StateHome
+		}
+		return m, nil
+
+	case "c":
+		m.choosingStorageDir = true
+		m.storageDirInput.SetValue("")
+		m.storageDirInput.Focus()
+		return m, nil
+
+	case "enter", "esc":
+		m.state = StateHome
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m Model) viewStorageUnavailable() string {
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.storage")))
+	b.WriteString("\n\n")
+	b.WriteString(TextStyle.Render(i18n.T("storage.disabled")))
+	b.WriteString("\n\n")
+
+	if m.storageInitErr != nil {
+		b.WriteString(renderErrorPanel(m.storageInitErr, m.width-10))
+		b.WriteString("\n\n")
+	}
+
+	if m.choosingStorageDir {
+		b.WriteString(TextStyle.Render(i18n.T("storage.dir_prompt")))
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().
+			Border(roundedBorder()).
+			BorderForeground(lipgloss.Color(ColorAccent)).
+			Padding(0, 1).
+			Width(m.storageDirInput.Width + 2).
+			Render(m.storageDirInput.View()))
+		b.WriteString("\n\n")
+		b.WriteString(RenderFooter(i18n.T("footer.storage_dir")))
+	} else {
+		b.WriteString(RenderFooter(i18n.T("footer.storage")))
+	}
+
+	return Center(m.width, m.height, b.String())
+}
+
+// withStorageUnavailableBanner replaces the top line of a rendered view with
+// a reminder that nothing is being saved
+func (m Model) withStorageUnavailableBanner(view string) string {
+	banner := lipgloss.PlaceHorizontal(m.width, lipgloss.Center, WarningStyle.Render(i18n.T("storage.banner")))
+
+	lines := strings.Split(view, "\n")
+	if len(lines) > 1 && strings.TrimSpace(lines[0]) == "" {
+		lines[0] = banner
+		return strings.Join(lines, "\n")
+	}
+	return banner + "\n" + view
+}
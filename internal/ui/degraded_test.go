@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRetryStorageInChosenDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+
+	m := Model{
+		state:           StateStorageUnavailable,
+		width:           120,
+		storageInitErr:  fmt.Errorf("failed to create config directory: permission denied"),
+		storageDirInput: textinput.New(),
+	}
+	if !m.storageUnavailable() {
+		t.Fatal("Expected storage to be unavailable")
+	}
+	if view := m.withStorageUnavailableBanner("\nhome"); !strings.Contains(view, "Persistence disabled") {
+		t.Errorf("Expected the degraded banner, got:\n%s", view)
+	}
+
+	updated, _ := m.handleStorageUnavailableKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	m = updated.(Model)
+	if !m.choosingStorageDir {
+		t.Fatal("Expected the directory input to open")
+	}
+	m.storageDirInput.SetValue(dir)
+
+	updated, _ = m.handleStorageUnavailableKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.storage == nil || m.storageUnavailable() || m.state != StateHome {
+		t.Fatalf("Expected storage to open in %s, err = %v", dir, m.storageInitErr)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "config.json")); err != nil {
+		t.Errorf("Expected config to be created in the chosen directory: %v", err)
+	}
+	if m.db.storage == nil {
+		t.Error("Expected database storage to open alongside")
+	}
+}
+
+func TestRetryStorageKeepsError(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	m := Model{storageInitErr: fmt.Errorf("first failure")}
+	if err := m.retryStorage(filepath.Join(file, "sub")); err == nil {
+		t.Fatal("Expected opening storage below a file to fail")
+	}
+	if !m.storageUnavailable() || m.storageInitErr == nil || m.storageInitErr.Error() == "first failure" {
+		t.Errorf("Expected the new error to be kept, got %v", m.storageInitErr)
+	}
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ListDensity is how many lines each item of a list takes
+type ListDensity string
+
+const (
+	// DensityCompact shows one line per item, so small terminals fit more
+	DensityCompact ListDensity = "compact"
+	// DensityDetailed adds a second line with the URL or a preview
+	DensityDetailed ListDensity = "detailed"
+)
+
+// ParseListDensity reads a density setting; empty is compact
+func ParseListDensity(s string) (ListDensity, error) {
+	switch ListDensity(strings.ToLower(strings.TrimSpace(s))) {
+	case "", DensityCompact:
+		return DensityCompact, nil
+	case DensityDetailed:
+		return DensityDetailed, nil
+	default:
+		return "", fmt.Errorf("unknown list density %q: use compact or detailed", s)
+	}
+}
+
+// densityStates are the lists whose density Ctrl+T switches
+var densityStates = map[AppState]bool{
+	StateRequestList:          true,
+	StateHistory:              true,
+	StateBookmarks:            true,
+	StateCollections:          true,
+	StateHistoryReplay:        true,
+	StateDatabaseQueryList:    true,
+	StateDatabaseQueryHistory: true,
+}
+
+// detailIndent lines the second line of a detailed item up with the label
+// after the marker and method badge of renderRequestItem
+const detailIndent = 2 + methodBadgeWidth + 1
+
+// SetListDensity sets the density of the lists, and save keeps it when it
+// is switched with Ctrl+T. A nil save keeps changes for the session only.
+func (m *Model) SetListDensity(density ListDensity, save func(string) error) {
+	m.listDensity = density
+	m.saveDensity = save
+}
+
+// toggleListDensity switches the lists between compact and detailed
+func (m *Model) toggleListDensity() {
+	if m.detailedLists() {
+		m.listDensity = DensityCompact
+	} else {
+		m.listDensity = DensityDetailed
+	}
+	if m.saveDensity != nil {
+		m.reportStorageError("failed to save list density", m.saveDensity(string(m.listDensity)))
+	}
+}
+
+// detailedLists reports whether list items show their second line
+func (m Model) detailedLists() bool {
+	return m.listDensity == DensityDetailed
+}
+
+// listItemLines is how many lines an item of a two-line list takes
+func (m Model) listItemLines() int {
+	if m.detailedLists() {
+		return 2
+	}
+	return 1
+}
+
+// renderItemDetail renders the second line of a detailed list item
+func renderItemDetail(detail string) string {
+	return MutedStyle.Render(strings.Repeat(" ", detailIndent) + detail)
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abneribeiro/godev/internal/storage"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestParseListDensity(t *testing.T) {
+	for input, want := range map[string]ListDensity{"": DensityCompact, "compact": DensityCompact, " Detailed ": DensityDetailed} {
+		if got, err := ParseListDensity(input); err != nil || got != want {
+			t.Errorf("ParseListDensity(%q) = %q, %v, want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseListDensity("tall"); err == nil {
+		t.Error("Expected an unknown density to be rejected")
+	}
+}
+
+func TestListDensityToggle(t *testing.T) {
+	var saved []string
+	m := Model{state: StateHistory, width: 120, height: 40}
+	m.SetListDensity(DensityCompact, func(density string) error {
+		saved = append(saved, density)
+		return nil
+	})
+	m.history.entries = []storage.RequestExecution{{
+		ID:           "1",
+		Timestamp:    time.Now(),
+		Method:       "GET",
+		URL:          "https://api.example.com/users",
+		StatusCode:   200,
+		Status:       "200 OK",
+		ResponseTime: 42,
+	}}
+
+	urlLine := func(view string) string {
+		for _, line := range strings.Split(view, "\n") {
+			if strings.Contains(line, "api.example.com/users") {
+				return line
+			}
+		}
+		t.Fatalf("URL not in view:\n%s", view)
+		return ""
+	}
+
+	if line := urlLine(m.history.View(&m)); !strings.Contains(line, "200 OK") {
+		t.Errorf("Expected the status on the URL line in compact lists, got %q", line)
+	}
+
+	msg, _ := tuitest.ParseKey("ctrl+t")
+	updated, _ := m.handleKeyPress(msg)
+	m = updated.(Model)
+	if !m.detailedLists() || len(saved) != 1 || saved[0] != "detailed" {
+		t.Fatalf("Expected Ctrl+T to switch to detailed lists and save it, got %q saved %v", m.listDensity, saved)
+	}
+	if line := urlLine(m.history.View(&m)); strings.Contains(line, "200 OK") {
+		t.Errorf("Expected the status on a line of its own in detailed lists, got %q", line)
+	}
+
+	m.state = StateRequestBuilder
+	updated, _ = m.handleKeyPress(msg)
+	if m = updated.(Model); !m.detailedLists() || len(saved) != 1 {
+		t.Error("Expected Ctrl+T to leave the density alone outside lists")
+	}
+}
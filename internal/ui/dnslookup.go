@@ -0,0 +1,261 @@
+package ui
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/abneribeiro/godev/internal/dns"
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// dnsLookupTimeout bounds one lookup, or all of them when every type is asked
+const dnsLookupTimeout = 10 * time.Second
+
+// DNSLookup is the DNS tool: the records of a name looked up through the
+// system resolver or a chosen server
+type DNSLookup struct {
+	name     textinput.Model
+	resolver textinput.Model
+	focus    int
+	// recordType indexes dns.RecordTypes; one past the end asks every type
+	recordType int
+	back       AppState
+
+	// attempt tells the answer of the latest lookup from earlier ones
+	attempt int
+	loading bool
+	results []dns.Result
+}
+
+type dnsResultMsg struct {
+	attempt int
+	results []dns.Result
+}
+
+func newDNSLookup() DNSLookup {
+	name := textinput.New()
+	name.Placeholder = "api.example.com"
+	name.CharLimit = 255
+	name.Width = 50
+
+	resolver := textinput.New()
+	resolver.Placeholder = i18n.T("dns.system_resolver")
+	resolver.CharLimit = 100
+	resolver.Width = 30
+
+	return DNSLookup{name: name, resolver: resolver}
+}
+
+// open shows the tool for name, if any, and returns to back on Esc
+func (d *DNSLookup) open(h host, name string, back AppState) {
+	if name != "" {
+		d.name.SetValue(name)
+		d.name.CursorEnd()
+	}
+	d.back = back
+	d.focusInput(0)
+	h.navigate(StateDNSLookup)
+}
+
+// hostOf returns the host name of a URL, for looking up what a request
+// is sent to
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
+
+func (d *DNSLookup) focusInput(i int) {
+	d.focus = i
+	if i == 0 {
+		d.resolver.Blur()
+		d.name.Focus()
+	} else {
+		d.name.Blur()
+		d.resolver.Focus()
+	}
+}
+
+// types returns the record types a lookup asks for
+func (d *DNSLookup) types() []dns.RecordType {
+	if d.recordType == len(dns.RecordTypes) {
+		return dns.RecordTypes
+	}
+	return dns.RecordTypes[d.recordType : d.recordType+1]
+}
+
+func (d *DNSLookup) typeLabel() string {
+	if d.recordType == len(dns.RecordTypes) {
+		return i18n.T("dns.all_types")
+	}
+	return string(dns.RecordTypes[d.recordType])
+}
+
+// lookup asks for the records of every selected type at once
+func (d *DNSLookup) lookup() tea.Cmd {
+	d.attempt++
+	d.loading = true
+	d.results = nil
+
+	attempt, name, server, types := d.attempt, d.name.Value(), d.resolver.Value(), d.types()
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), dnsLookupTimeout)
+		defer cancel()
+
+		results := make([]dns.Result, len(types))
+		var wg sync.WaitGroup
+		for i, t := range types {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i] = dns.Lookup(ctx, name, t, server)
+			}()
+		}
+		wg.Wait()
+		return dnsResultMsg{attempt: attempt, results: results}
+	}
+}
+
+// finish keeps the answers of the latest lookup
+func (d *DNSLookup) finish(msg dnsResultMsg) {
+	if msg.attempt != d.attempt {
+		return
+	}
+	d.loading = false
+	d.results = msg.results
+}
+
+func (d *DNSLookup) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		d.attempt++
+		d.loading = false
+		h.navigate(d.back)
+		return nil
+
+	case "tab", "shift+tab":
+		d.focusInput(1 - d.focus)
+		return nil
+
+	case "enter":
+		return d.lookup()
+
+	case "ctrl+n":
+		d.recordType = (d.recordType + 1) % (len(dns.RecordTypes) + 1)
+		return nil
+
+	case "ctrl+p":
+		d.recordType = (d.recordType + len(dns.RecordTypes)) % (len(dns.RecordTypes) + 1)
+		return nil
+
+	case "ctrl+r":
+		// Cycle the well-known resolvers, starting over from a typed one
+		next := dns.Resolvers[0]
+		for i, server := range dns.Resolvers {
+			if server == strings.TrimSpace(d.resolver.Value()) {
+				next = dns.Resolvers[(i+1)%len(dns.Resolvers)]
+			}
+		}
+		d.resolver.SetValue(next)
+		d.resolver.CursorEnd()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	if d.focus == 0 {
+		d.name, cmd = d.name.Update(msg)
+	} else {
+		d.resolver, cmd = d.resolver.Update(msg)
+	}
+	return cmd
+}
+
+func (d *DNSLookup) View(h host) string {
+	width, height := h.size()
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.dns")))
+	b.WriteString("\n\n")
+
+	b.WriteString(TextStyle.Render(i18n.Tf("dns.type", d.typeLabel())))
+	b.WriteString("\n")
+	b.WriteString(viewLabeledInput(i18n.T("dns.name"), d.name, d.focus == 0))
+	b.WriteString(viewLabeledInput(i18n.T("dns.resolver"), d.resolver, d.focus == 1))
+	b.WriteString(MutedStyle.Render(i18n.Tf("dns.resolvers", strings.Join(dns.Resolvers[1:], ", "))))
+	b.WriteString("\n\n")
+
+	switch {
+	case d.loading:
+		b.WriteString(MutedStyle.Render(i18n.T("dns.looking_up")))
+		b.WriteString("\n\n")
+	case d.results != nil:
+		b.WriteString(d.viewResults())
+	}
+
+	b.WriteString(RenderFooter(i18n.T("footer.dns")))
+
+	return Center(width, height, b.String())
+}
+
+// viewResults lists the records of every type asked for, or why there
+// are none
+func (d *DNSLookup) viewResults() string {
+	var b strings.Builder
+
+	first := d.results[0]
+	resolver := first.Resolver
+	if resolver == "" {
+		resolver = i18n.T("dns.system_resolver")
+	}
+	b.WriteString(MutedStyle.Render(i18n.Tf("dns.answered_by", first.Name, resolver)))
+	b.WriteString("\n\n")
+
+	for _, result := range d.results {
+		label := lipgloss.NewStyle().Width(7).Render(string(result.Type))
+		timing := MutedStyle.Render(" " + httpclient.FormatDuration(result.Duration))
+		switch {
+		case result.NotFound():
+			b.WriteString(MutedStyle.Render(label+i18n.T("dns.not_found")) + timing)
+		case result.Err != nil:
+			b.WriteString(ErrorStyle.Render(label+"✗ "+result.Err.Error()) + timing)
+		case len(result.Records) == 0:
+			b.WriteString(MutedStyle.Render(label+i18n.T("dns.no_records")) + timing)
+		default:
+			b.WriteString(TextStyle.Render(label+result.Records[0]) + timing)
+			for _, record := range result.Records[1:] {
+				b.WriteString("\n")
+				b.WriteString(TextStyle.Render(strings.Repeat(" ", 7) + record))
+			}
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// viewLabeledInput renders a text input in a box under its label, with
+// the accent border when focused
+func viewLabeledInput(label string, input textinput.Model, focused bool) string {
+	border := ColorBorder
+	if focused {
+		border = ColorAccent
+	}
+	return TextStyle.Render(label) + "\n" + lipgloss.NewStyle().
+		Border(roundedBorder()).
+		BorderForeground(lipgloss.Color(border)).
+		Padding(0, 1).
+		Render(input.View()) + "\n"
+}
@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowDNSLookupFromBuilder(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type("https://api.example.com:8443/users").Press("tab", "d")
+	d.AssertView("DNS Lookup", "api.example.com", "Record type: A")
+
+	d.Press("ctrl+n").AssertView("Record type: AAAA")
+	d.Press("ctrl+p", "ctrl+p").AssertView("Record type: all types")
+
+	d.Press("tab", "ctrl+r").AssertView("1.1.1.1")
+	d.Press("esc").AssertView("A: auth • t: timeout & retries • d: DNS lookup")
+}
+
+func TestFlowDNSLookupWithoutName(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("4").AssertView("DNS Lookup", "system resolver")
+	d.Press("enter").WaitFor("no name to look up")
+	d.Press("esc").AssertView("[ 4 ] DNS Lookup")
+}
+
+func TestHostOf(t *testing.T) {
+	tests := map[string]string{
+		"https://api.example.com:8443/users": "api.example.com",
+		"http://[::1]:8080/":                 "::1",
+		"not a url":                          "",
+		"%zz":                                "",
+	}
+	for rawURL, want := range tests {
+		if got := hostOf(rawURL); got != want {
+			t.Errorf("hostOf(%q) = %q, want %q", rawURL, got, want)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// startDownloadPrompt asks for the file responses are downloaded to
+func (m *Model) startDownloadPrompt() {
+	m.choosingDownload = true
+	m.downloadInput.SetValue(m.downloadPath)
+	m.downloadInput.CursorEnd()
+	m.downloadInput.Focus()
+}
+
+// handleDownloadPathKeys handles input while choosing the download file.
+// An empty path turns downloads off.
+func (m Model) handleDownloadPathKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return m, tea.Quit
+
+	case "esc":
+		m.choosingDownload = false
+		m.downloadInput.Blur()
+		return m, nil
+
+	case "enter":
+		m.downloadPath = strings.TrimSpace(m.downloadInput.Value())
+		m.choosingDownload = false
+		m.downloadInput.Blur()
+		return m, nil
+	}
+
+	m.downloadInput, cmd = m.downloadInput.Update(msg)
+	return m, cmd
+}
+
+// viewDownloadSection renders the download file input and, while
+// downloading, where the body goes and whether sending resumes a partial file
+func (m Model) viewDownloadSection() string {
+	var b strings.Builder
+
+	if m.choosingDownload {
+		b.WriteString(TextStyle.Render(i18n.T("download.path")))
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().
+			Border(roundedBorder()).
+			BorderForeground(lipgloss.Color(ColorAccent)).
+			Padding(0, 1).
+			Width(m.downloadInput.Width + 2).
+			Render(m.downloadInput.View()))
+		b.WriteString("\n")
+		b.WriteString(MutedStyle.Render(i18n.T("download.path_hint")))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	if m.downloadPath == "" {
+		return ""
+	}
+	if info, err := os.Stat(httpclient.ExpandHome(m.downloadPath)); err == nil && !info.IsDir() && info.Size() > 0 {
+		b.WriteString(TextStyle.Render(i18n.Tf("download.target_partial", m.downloadPath, httpclient.FormatSize(info.Size()))))
+	} else {
+		b.WriteString(TextStyle.Render(i18n.Tf("download.target", m.downloadPath)))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// viewDownloadResult describes what a download did to its file, or the
+// range of a partial response that was shown as usual
+func (v *ResponseViewer) viewDownloadResult() string {
+	resp := v.response
+	d := resp.Download
+	if d == nil {
+		if resp.Error != nil || resp.StatusCode != 206 {
+			return ""
+		}
+		cr, err := httpclient.ParseContentRange(firstHeader(resp.Headers, "Content-Range"))
+		if err != nil {
+			return ""
+		}
+		return MutedStyle.Render(i18n.Tf("download.partial_content", cr.String())) + "\n\n"
+	}
+
+	var b strings.Builder
+	switch {
+	case d.Complete && d.Written == 0 && d.Range != nil && d.Range.Start < 0:
+		b.WriteString(SuccessStyle.Render(i18n.Tf("download.already_complete", d.Path, httpclient.FormatSize(d.Size()))))
+	case d.Offset > 0:
+		b.WriteString(SuccessStyle.Render(i18n.Tf("download.resumed",
+			httpclient.FormatSize(d.Written), d.Path, httpclient.FormatSize(d.Offset))))
+	default:
+		b.WriteString(SuccessStyle.Render(i18n.Tf("download.saved", httpclient.FormatSize(d.Written), d.Path)))
+	}
+	b.WriteString("\n")
+
+	if d.Range != nil && d.Range.Start >= 0 {
+		b.WriteString(MutedStyle.Render(i18n.Tf("download.partial_content", d.Range.String())))
+		b.WriteString("\n")
+	}
+	if d.Restarted {
+		b.WriteString(WarningStyle.Render(i18n.T("download.restarted")))
+		b.WriteString("\n")
+	}
+	if !d.Complete {
+		total := "?"
+		if d.Total >= 0 {
+			total = httpclient.FormatSize(d.Total)
+		}
+		b.WriteString(WarningStyle.Render(i18n.Tf("download.incomplete", httpclient.FormatSize(d.Size()), total)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// firstHeader returns the first value of a response header
+func firstHeader(headers map[string][]string, name string) string {
+	for key, values := range headers {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowDownloadResumesPartialFile(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	content := []byte(strings.Repeat("0123456789", 10000))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "artifact.bin", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "artifact.bin")
+	if err := os.WriteFile(path, content[:25000], 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a", "tab", "f").AssertView("Download responses to file:")
+	d.Type(path).Press("enter").AssertView("24.41 KB on disk, sending resumes it")
+
+	d.Press("shift+tab").Type(server.URL).Press("enter")
+	d.WaitFor("206 Partial Content", "Saved 73.24 KB to "+path+", resuming at 24.41 KB",
+		"Partial content: bytes 25000-99999 of 100000")
+	if got, _ := os.ReadFile(path); !bytes.Equal(got, content) {
+		t.Errorf("Expected the resumed file to match the artifact, got %d bytes", len(got))
+	}
+
+	d.Press("esc").Press("enter").WaitFor("is already complete (97.66 KB)")
+}
+
+func TestViewDownloadResultShowsPartialResponses(t *testing.T) {
+	m := testGraphQLModel()
+	m.viewer.response = &httpclient.Response{
+		StatusCode: http.StatusPartialContent,
+		Headers:    map[string][]string{"Content-Range": {"bytes 0-99/1000"}},
+	}
+	if got := m.viewer.viewDownloadResult(); !strings.Contains(got, "Partial content: bytes 0-99 of 1000") {
+		t.Errorf("viewDownloadResult() = %q, want the Content-Range described", got)
+	}
+
+	m.viewer.response = &httpclient.Response{
+		StatusCode: http.StatusPartialContent,
+		Download:   &httpclient.DownloadResult{Path: "/tmp/a.bin", Offset: 100, Written: 50, Total: 1000},
+	}
+	if got := m.viewer.viewDownloadResult(); !strings.Contains(got, "send again to resume") {
+		t.Errorf("viewDownloadResult() = %q, want an interrupted download to offer resuming", got)
+	}
+}
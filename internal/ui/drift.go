@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"encoding/json"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+)
+
+// checkSchemaDrift compares the response body against the schema stored with
+// the current saved request. The first successful response becomes the baseline.
+func (m *Model) checkSchemaDrift(resp httpclient.Response) {
+	m.viewer.schemaDrift = nil
+	m.viewer.viewSchemaDrift = false
+
+	if m.storage == nil || !m.builder.requestSaved || m.builder.currentRequestSavedID == "" {
+		return
+	}
+	if resp.Error != nil || resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return
+	}
+
+	schema, err := httpclient.InferJSONSchema(resp.Body)
+	if err != nil {
+		return
+	}
+
+	saved, err := m.storage.GetRequest(m.builder.currentRequestSavedID)
+	if err != nil {
+		return
+	}
+
+	if len(saved.ResponseSchema) == 0 {
+		m.storeResponseSchema(schema)
+		return
+	}
+
+	var baseline httpclient.JSONSchema
+	if err := json.Unmarshal(saved.ResponseSchema, &baseline); err != nil {
+		m.storeResponseSchema(schema)
+		return
+	}
+
+	drift := httpclient.CompareSchemas(&baseline, schema)
+	if len(drift.Changes) > 0 {
+		m.viewer.schemaDrift = drift
+	}
+}
+
+// acceptResponseSchema replaces the stored baseline with the current response structure
+func (m *Model) acceptResponseSchema() {
+	if m.viewer.response == nil || m.viewer.response.Error != nil {
+		return
+	}
+
+	schema, err := httpclient.InferJSONSchema(m.viewer.response.Body)
+	if err != nil {
+		return
+	}
+
+	m.storeResponseSchema(schema)
+	m.viewer.schemaDrift = nil
+	m.viewer.viewSchemaDrift = false
+}
+
+// storeResponseSchema saves the schema with the current saved request
+func (m *Model) storeResponseSchema(schema *httpclient.JSONSchema) {
+	if m.storage == nil || m.readOnly || m.builder.currentRequestSavedID == "" {
+		return
+	}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return
+	}
+
+	if err := m.storage.UpdateResponseSchema(m.builder.currentRequestSavedID, data); err == nil {
+		m.requests.saved = m.storage.GetRequests()
+	}
+}
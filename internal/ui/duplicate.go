@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+const defaultDuplicateCount = 2
+
+type duplicateResultMsg *httpclient.DuplicateResult
+
+func runDuplicateCmd(client *httpclient.Client, req httpclient.Request, n int, ignore httpclient.IgnoreRules) tea.Cmd {
+	return func() tea.Msg {
+		responses := httpclient.SendConcurrently(client, req, n)
+		return duplicateResultMsg(httpclient.CompareDuplicates(req, responses, ignore))
+	}
+}
+
+// DuplicateCompare is the concurrent send screen: the request being edited
+// sent several times at once and the responses compared
+type DuplicateCompare struct {
+	count   int
+	running bool
+	result  *httpclient.DuplicateResult
+	scroll  int
+
+	// method and url are the request sent
+	method string
+	url    string
+}
+
+// open shows the concurrent send screen for the request sent as method and url
+func (d *DuplicateCompare) open(h host, method, url string) {
+	h.navigate(StateDuplicateCompare)
+	d.method = method
+	d.url = url
+	d.result = nil
+	d.scroll = 0
+	if d.count < 2 {
+		d.count = defaultDuplicateCount
+	}
+}
+
+// finish shows the compared responses
+func (d *DuplicateCompare) finish(msg duplicateResultMsg) {
+	d.running = false
+	d.result = (*httpclient.DuplicateResult)(msg)
+}
+
+func (d *DuplicateCompare) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		if d.running {
+			return nil
+		}
+		h.navigate(StateViewResponse)
+		return nil
+
+	case "left", "-":
+		if !d.running && d.count > 2 {
+			d.count--
+		}
+		return nil
+
+	case "right", "+":
+		if !d.running && d.count < httpclient.MaxDuplicateSends {
+			d.count++
+		}
+		return nil
+
+	case "up", "k":
+		if d.scroll > 0 {
+			d.scroll--
+		}
+		return nil
+
+	case "down", "j":
+		d.scroll++
+		return nil
+
+	case "enter", "r":
+		if d.running {
+			return nil
+		}
+		if h.blockedByReadOnly(d.method + " requests") {
+			return nil
+		}
+
+		d.running = true
+		d.result = nil
+		d.scroll = 0
+		return tea.Batch(h.spinnerTick(), runDuplicateCmd(h.requestClient(), h.builtRequest(), d.count, h.diffIgnoreRules()))
+	}
+
+	return nil
+}
+
+// duplicateSummary is used for the completion notification
+func duplicateSummary(result *httpclient.DuplicateResult) (string, time.Duration) {
+	var took time.Duration
+	for _, resp := range result.Responses {
+		if resp.ResponseTime > took {
+			took = resp.ResponseTime
+		}
+	}
+
+	if result.Consistent() {
+		return fmt.Sprintf("%d concurrent responses identical", len(result.Responses)), took
+	}
+	return fmt.Sprintf("%d concurrent responses differ", len(result.Responses)), took
+}
+
+func (d *DuplicateCompare) View(h host) string {
+	width, height := h.size()
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.duplicates")))
+	b.WriteString("\n\n")
+
+	b.WriteString(TextStyle.Render(fmt.Sprintf("%s %s", d.method, d.url)))
+	b.WriteString("\n")
+	b.WriteString(MutedStyle.Render(i18n.Tf("duplicate.intro", d.count)))
+	b.WriteString("\n\n")
+
+	if h.readOnlyMode() && !isSafeMethod(d.method) {
+		b.WriteString(WarningStyle.Render(i18n.Tf("duplicate.read_only", d.method)))
+		b.WriteString("\n\n")
+	}
+
+	switch {
+	case d.running:
+		b.WriteString(SpinnerStyle.Render(h.spinnerView()) + "  " + TextStyle.Render(i18n.Tf("duplicate.sending", d.count)))
+		b.WriteString("\n")
+
+	case d.result != nil:
+		lines := strings.Split(strings.TrimRight(httpclient.FormatDuplicateResult(d.result), "\n"), "\n")
+
+		maxLines := height - 16
+		if maxLines < 5 {
+			maxLines = 5
+		}
+		start := d.scroll
+		if start > len(lines)-maxLines {
+			start = len(lines) - maxLines
+		}
+		if start < 0 {
+			start = 0
+		}
+		end := start + maxLines
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		for _, line := range lines[start:end] {
+			trimmed := strings.TrimSpace(line)
+			switch {
+			case strings.HasPrefix(trimmed, "✓"), strings.HasPrefix(trimmed, "All responses"):
+				b.WriteString(SuccessStyle.Render(line))
+			case strings.HasPrefix(trimmed, "✗"):
+				b.WriteString(ErrorStyle.Render(line))
+			case strings.HasPrefix(trimmed, "-"), strings.HasPrefix(trimmed, "+"), strings.HasPrefix(trimmed, "~"):
+				b.WriteString(WarningStyle.Render(line))
+			default:
+				b.WriteString(HeaderStyle.Render(line))
+			}
+			b.WriteString("\n")
+		}
+
+	default:
+		b.WriteString(MutedStyle.Render(i18n.T("duplicate.press_enter")))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(RenderFooter(i18n.T("footer.duplicates")))
+
+	return Center(width, height, b.String())
+}
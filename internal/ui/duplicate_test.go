@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowDuplicateCompareSendsConcurrently(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ok": true}`)
+	}))
+	defer server.Close()
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL).Press("enter").WaitFor("200")
+	d.Press("p").AssertView("GET "+server.URL, "Send 2 copies at once")
+	d.Press("+").AssertView("Send 3 copies at once")
+	d.Press("enter").WaitFor("All responses are identical")
+
+	if m := d.Model().(Model); m.duplicate.running || len(m.duplicate.result.Responses) != 3 {
+		t.Errorf("Expected 3 responses, got %+v", m.duplicate.result)
+	}
+	d.Press("esc")
+	if m := d.Model().(Model); m.state != StateViewResponse {
+		t.Errorf("Expected Esc to return to the response, got state %v", m.state)
+	}
+}
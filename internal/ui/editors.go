@@ -6,281 +6,316 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/abneribeiro/godev/internal/i18n"
 )
 
-func (m *Model) buildHeaderList() {
-	m.headerList = []string{}
-	for key := range m.headers {
-		m.headerList = append(m.headerList, key)
+// openHeaderEditor lists the headers for editing
+func (r *RequestBuilder) openHeaderEditor(h host) {
+	h.navigate(StateHeaderEditor)
+	r.buildHeaderList()
+}
+
+func (r *RequestBuilder) buildHeaderList() {
+	r.headerList = []string{}
+	for key := range r.headers {
+		r.headerList = append(r.headerList, key)
 	}
-	m.selectedHeader = 0
-	m.editingHeader = false
-	m.headerKeyInput.SetValue("")
-	m.headerValueInput.SetValue("")
+	r.selectedHeader = 0
+	r.editingHeader = false
+	r.headerKeyInput.SetValue("")
+	r.headerValueInput.SetValue("")
 }
 
-func (m Model) handleHeaderEditorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+func (r *RequestBuilder) updateHeaderEditor(h host, msg tea.KeyMsg) tea.Cmd {
 	var cmd tea.Cmd
 
-	if m.editingHeader {
+	if r.editingHeader {
 		switch msg.String() {
 		case "ctrl+c", "ctrl+q":
-			return m, tea.Quit
+			return tea.Quit
 		case "esc":
-			m.editingHeader = false
-			m.headerKeyInput.Blur()
-			m.headerValueInput.Blur()
-			m.headerKeyInput.SetValue("")
-			m.headerValueInput.SetValue("")
-			return m, nil
+			r.editingHeader = false
+			r.headerKeyInput.Blur()
+			r.headerValueInput.Blur()
+			r.headerKeyInput.SetValue("")
+			r.headerValueInput.SetValue("")
+			return nil
 		case "tab":
-			if m.headerKeyInput.Focused() {
-				m.headerKeyInput.Blur()
-				m.headerValueInput.Focus()
+			if r.headerKeyInput.Focused() {
+				r.headerKeyInput.Blur()
+				r.headerValueInput.Focus()
 			} else {
-				m.headerValueInput.Blur()
-				m.headerKeyInput.Focus()
+				r.headerValueInput.Blur()
+				r.headerKeyInput.Focus()
 			}
-			return m, nil
+			return nil
 		case "enter":
-			key := strings.TrimSpace(m.headerKeyInput.Value())
-			value := strings.TrimSpace(m.headerValueInput.Value())
+			key := strings.TrimSpace(r.headerKeyInput.Value())
+			value := strings.TrimSpace(r.headerValueInput.Value())
 			if key != "" && value != "" {
-				m.headers[key] = value
-				m.buildHeaderList()
+				r.headers[key] = value
+				r.buildHeaderList()
 			}
-			m.editingHeader = false
-			return m, nil
+			r.editingHeader = false
+			return nil
 		default:
-			if m.headerKeyInput.Focused() {
-				m.headerKeyInput, cmd = m.headerKeyInput.Update(msg)
-			} else if m.headerValueInput.Focused() {
-				m.headerValueInput, cmd = m.headerValueInput.Update(msg)
+			if r.headerKeyInput.Focused() {
+				r.headerKeyInput, cmd = r.headerKeyInput.Update(msg)
+			} else if r.headerValueInput.Focused() {
+				r.headerValueInput, cmd = r.headerValueInput.Update(msg)
 			}
-			return m, cmd
+			return cmd
 		}
 	}
 
 	switch msg.String() {
 	case "ctrl+c", "ctrl+q":
-		return m, tea.Quit
+		return tea.Quit
 
 	case "esc":
-		m.state = StateRequestBuilder
-		return m, nil
+		h.navigate(StateRequestBuilder)
+		r.stopFixAndResend()
+		return nil
+
+	case "ctrl+s":
+		if r.fixingRequest {
+			r.stopFixAndResend()
+			h.navigate(StateRequestBuilder)
+			cmd := h.sendRequest()
+			return cmd
+		}
+		return nil
 
 	case "up", "k":
-		if m.selectedHeader > 0 {
-			m.selectedHeader--
+		if r.selectedHeader > 0 {
+			r.selectedHeader--
 		}
-		return m, nil
+		return nil
 
 	case "down", "j":
-		if m.selectedHeader < len(m.headerList)-1 {
-			m.selectedHeader++
+		if r.selectedHeader < len(r.headerList)-1 {
+			r.selectedHeader++
 		}
-		return m, nil
+		return nil
 
 	case "n", "a":
-		m.editingHeader = true
-		m.headerKeyInput.Focus()
-		m.headerKeyInput.SetValue("")
-		m.headerValueInput.SetValue("")
-		return m, nil
+		r.editingHeader = true
+		r.headerKeyInput.Focus()
+		r.headerKeyInput.SetValue("")
+		r.headerValueInput.SetValue("")
+		return nil
 
 	case "d":
-		if len(m.headerList) > 0 && m.selectedHeader < len(m.headerList) {
-			key := m.headerList[m.selectedHeader]
-			delete(m.headers, key)
-			m.buildHeaderList()
-			if m.selectedHeader >= len(m.headerList) && m.selectedHeader > 0 {
-				m.selectedHeader--
+		if len(r.headerList) > 0 && r.selectedHeader < len(r.headerList) {
+			key := r.headerList[r.selectedHeader]
+			delete(r.headers, key)
+			r.buildHeaderList()
+			if r.selectedHeader >= len(r.headerList) && r.selectedHeader > 0 {
+				r.selectedHeader--
 			}
 		}
-		return m, nil
+		return nil
 
 	case "e", "enter":
-		if len(m.headerList) > 0 && m.selectedHeader < len(m.headerList) {
-			key := m.headerList[m.selectedHeader]
-			m.editingHeader = true
-			m.headerKeyInput.Focus()
-			m.headerKeyInput.SetValue(key)
-			m.headerValueInput.SetValue(m.headers[key])
-			delete(m.headers, key)
-			m.buildHeaderList()
+		if len(r.headerList) > 0 && r.selectedHeader < len(r.headerList) {
+			key := r.headerList[r.selectedHeader]
+			r.editingHeader = true
+			r.headerKeyInput.Focus()
+			r.headerKeyInput.SetValue(key)
+			r.headerValueInput.SetValue(r.headers[key])
+			delete(r.headers, key)
+			r.buildHeaderList()
 		}
-		return m, nil
+		return nil
 	}
 
-	return m, nil
+	return nil
 }
 
-func (m Model) handleBodyEditorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+func (r *RequestBuilder) updateBodyEditor(h host, msg tea.KeyMsg) tea.Cmd {
 	var cmd tea.Cmd
 
 	switch msg.String() {
 	case "ctrl+c", "ctrl+q":
-		return m, tea.Quit
+		return tea.Quit
 
 	case "esc":
-		m.state = StateRequestBuilder
-		m.bodyEditor.Blur()
-		return m, nil
+		h.navigate(StateRequestBuilder)
+		r.bodyEditor.Blur()
+		r.stopFixAndResend()
+		return nil
+
+	case "ctrl+o":
+		r.cycleBodyMode()
+		return nil
 
 	case "ctrl+s":
-		bodyValue := m.bodyEditor.Value()
-		if err := m.validateJSON(bodyValue); err != nil {
-			m.bodyError = err.Error()
-			return m, nil
+		bodyValue := r.bodyEditor.Value()
+		if err := r.validateBody(bodyValue); err != nil {
+			r.bodyError = err.Error()
+			return nil
+		}
+		r.body = bodyValue
+		r.bodyError = ""
+		h.navigate(StateRequestBuilder)
+		r.bodyEditor.Blur()
+		r.requestSaved = false
+		if r.fixingRequest {
+			r.stopFixAndResend()
+			cmd := h.sendRequest()
+			return cmd
 		}
-		m.body = bodyValue
-		m.bodyError = ""
-		m.state = StateRequestBuilder
-		m.bodyEditor.Blur()
-		m.requestSaved = false
-		return m, nil
+		return nil
 
 	default:
-		m.bodyEditor, cmd = m.bodyEditor.Update(msg)
-		return m, cmd
+		r.bodyEditor, cmd = r.bodyEditor.Update(msg)
+		return cmd
 	}
 }
 
-func (m *Model) buildQueryList() {
-	m.queryList = []string{}
-	for key := range m.queryParams {
-		m.queryList = append(m.queryList, key)
+// openQueryEditor lists the query params for editing
+func (r *RequestBuilder) openQueryEditor(h host) {
+	h.navigate(StateQueryEditor)
+	r.buildQueryList()
+}
+
+func (r *RequestBuilder) buildQueryList() {
+	r.queryList = []string{}
+	for key := range r.queryParams {
+		r.queryList = append(r.queryList, key)
 	}
-	m.selectedQuery = 0
-	m.editingQuery = false
-	m.queryKeyInput.SetValue("")
-	m.queryValueInput.SetValue("")
+	r.selectedQuery = 0
+	r.editingQuery = false
+	r.queryKeyInput.SetValue("")
+	r.queryValueInput.SetValue("")
 }
 
-func (m Model) handleQueryEditorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+func (r *RequestBuilder) updateQueryEditor(h host, msg tea.KeyMsg) tea.Cmd {
 	var cmd tea.Cmd
 
-	if m.editingQuery {
+	if r.editingQuery {
 		switch msg.String() {
 		case "ctrl+c", "ctrl+q":
-			return m, tea.Quit
+			return tea.Quit
 		case "esc":
-			m.editingQuery = false
-			m.queryKeyInput.Blur()
-			m.queryValueInput.Blur()
-			m.queryKeyInput.SetValue("")
-			m.queryValueInput.SetValue("")
-			return m, nil
+			r.editingQuery = false
+			r.queryKeyInput.Blur()
+			r.queryValueInput.Blur()
+			r.queryKeyInput.SetValue("")
+			r.queryValueInput.SetValue("")
+			return nil
 		case "tab":
-			if m.queryKeyInput.Focused() {
-				m.queryKeyInput.Blur()
-				m.queryValueInput.Focus()
+			if r.queryKeyInput.Focused() {
+				r.queryKeyInput.Blur()
+				r.queryValueInput.Focus()
 			} else {
-				m.queryValueInput.Blur()
-				m.queryKeyInput.Focus()
+				r.queryValueInput.Blur()
+				r.queryKeyInput.Focus()
 			}
-			return m, nil
+			return nil
 		case "enter":
-			key := strings.TrimSpace(m.queryKeyInput.Value())
-			value := strings.TrimSpace(m.queryValueInput.Value())
+			key := strings.TrimSpace(r.queryKeyInput.Value())
+			value := strings.TrimSpace(r.queryValueInput.Value())
 			if key != "" && value != "" {
-				m.queryParams[key] = value
-				m.buildQueryList()
+				r.queryParams[key] = value
+				r.buildQueryList()
 			}
-			m.editingQuery = false
-			return m, nil
+			r.editingQuery = false
+			return nil
 		default:
-			if m.queryKeyInput.Focused() {
-				m.queryKeyInput, cmd = m.queryKeyInput.Update(msg)
-			} else if m.queryValueInput.Focused() {
-				m.queryValueInput, cmd = m.queryValueInput.Update(msg)
+			if r.queryKeyInput.Focused() {
+				r.queryKeyInput, cmd = r.queryKeyInput.Update(msg)
+			} else if r.queryValueInput.Focused() {
+				r.queryValueInput, cmd = r.queryValueInput.Update(msg)
 			}
-			return m, cmd
+			return cmd
 		}
 	}
 
 	switch msg.String() {
 	case "ctrl+c", "ctrl+q":
-		return m, tea.Quit
+		return tea.Quit
 
 	case "esc":
-		m.state = StateRequestBuilder
-		return m, nil
+		h.navigate(StateRequestBuilder)
+		return nil
 
 	case "up", "k":
-		if m.selectedQuery > 0 {
-			m.selectedQuery--
+		if r.selectedQuery > 0 {
+			r.selectedQuery--
 		}
-		return m, nil
+		return nil
 
 	case "down", "j":
-		if m.selectedQuery < len(m.queryList)-1 {
-			m.selectedQuery++
+		if r.selectedQuery < len(r.queryList)-1 {
+			r.selectedQuery++
 		}
-		return m, nil
+		return nil
 
 	case "n", "a":
-		m.editingQuery = true
-		m.queryKeyInput.Focus()
-		m.queryKeyInput.SetValue("")
-		m.queryValueInput.SetValue("")
-		return m, nil
+		r.editingQuery = true
+		r.queryKeyInput.Focus()
+		r.queryKeyInput.SetValue("")
+		r.queryValueInput.SetValue("")
+		return nil
 
 	case "d":
-		if len(m.queryList) > 0 && m.selectedQuery < len(m.queryList) {
-			key := m.queryList[m.selectedQuery]
-			delete(m.queryParams, key)
-			m.buildQueryList()
-			if m.selectedQuery >= len(m.queryList) && m.selectedQuery > 0 {
-				m.selectedQuery--
+		if len(r.queryList) > 0 && r.selectedQuery < len(r.queryList) {
+			key := r.queryList[r.selectedQuery]
+			delete(r.queryParams, key)
+			r.buildQueryList()
+			if r.selectedQuery >= len(r.queryList) && r.selectedQuery > 0 {
+				r.selectedQuery--
 			}
 		}
-		return m, nil
+		return nil
 
 	case "e", "enter":
-		if len(m.queryList) > 0 && m.selectedQuery < len(m.queryList) {
-			key := m.queryList[m.selectedQuery]
-			m.editingQuery = true
-			m.queryKeyInput.Focus()
-			m.queryKeyInput.SetValue(key)
-			m.queryValueInput.SetValue(m.queryParams[key])
-			delete(m.queryParams, key)
-			m.buildQueryList()
+		if len(r.queryList) > 0 && r.selectedQuery < len(r.queryList) {
+			key := r.queryList[r.selectedQuery]
+			r.editingQuery = true
+			r.queryKeyInput.Focus()
+			r.queryKeyInput.SetValue(key)
+			r.queryValueInput.SetValue(r.queryParams[key])
+			delete(r.queryParams, key)
+			r.buildQueryList()
 		}
-		return m, nil
+		return nil
 	}
 
-	return m, nil
+	return nil
 }
 
-func (m Model) viewHeaderEditor() string {
+func (r *RequestBuilder) viewHeaderEditor(h host) string {
+	width, height := h.size()
 	var b strings.Builder
 
 	b.WriteString(TitleStyle.Render("Header Editor"))
 	b.WriteString("\n\n")
 
-	if m.editingHeader {
+	if r.editingHeader {
 		b.WriteString(TextStyle.Render("Add/Edit Header"))
 		b.WriteString("\n\n")
 
 		keyLabel := "Key: "
 		b.WriteString(TextStyle.Render(keyLabel))
 		b.WriteString("\n")
-		keyInput := m.headerKeyInput.View()
-		if m.headerKeyInput.Focused() {
+		keyInput := r.headerKeyInput.View()
+		if r.headerKeyInput.Focused() {
 			styledInput := lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
+				Border(roundedBorder()).
 				BorderForeground(lipgloss.Color(ColorAccent)).
 				Padding(0, 1).
-				Width(m.headerKeyInput.Width + 2).
+				Width(r.headerKeyInput.Width + 2).
 				Render(keyInput)
 			b.WriteString(styledInput)
 		} else {
 			styledInput := lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
+				Border(roundedBorder()).
 				BorderForeground(lipgloss.Color(ColorBorder)).
 				Padding(0, 1).
-				Width(m.headerKeyInput.Width + 2).
+				Width(r.headerKeyInput.Width + 2).
 				Render(keyInput)
 			b.WriteString(styledInput)
 		}
@@ -289,21 +324,21 @@ func (m Model) viewHeaderEditor() string {
 		valueLabel := "Value: "
 		b.WriteString(TextStyle.Render(valueLabel))
 		b.WriteString("\n")
-		valueInput := m.headerValueInput.View()
-		if m.headerValueInput.Focused() {
+		valueInput := r.headerValueInput.View()
+		if r.headerValueInput.Focused() {
 			styledInput := lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
+				Border(roundedBorder()).
 				BorderForeground(lipgloss.Color(ColorAccent)).
 				Padding(0, 1).
-				Width(m.headerValueInput.Width + 2).
+				Width(r.headerValueInput.Width + 2).
 				Render(valueInput)
 			b.WriteString(styledInput)
 		} else {
 			styledInput := lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
+				Border(roundedBorder()).
 				BorderForeground(lipgloss.Color(ColorBorder)).
 				Padding(0, 1).
-				Width(m.headerValueInput.Width + 2).
+				Width(r.headerValueInput.Width + 2).
 				Render(valueInput)
 			b.WriteString(styledInput)
 		}
@@ -316,109 +351,135 @@ func (m Model) viewHeaderEditor() string {
 		b.WriteString("\n\n")
 		b.WriteString(RenderFooter("Tab: switch field • Enter: save • Esc: cancel"))
 	} else {
-		if len(m.headerList) == 0 {
+		if len(r.headerList) == 0 {
 			b.WriteString(MutedStyle.Render("No headers"))
 			b.WriteString("\n\n")
 			b.WriteString(TextStyle.Render("Press 'n' to add a new header"))
 		} else {
 			headerPanel := lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
+				Border(roundedBorder()).
 				BorderForeground(lipgloss.Color(ColorBorder)).
 				Padding(1, 2).
-				Width(m.width - 10)
+				Width(width - 10)
 
 			var headerContent strings.Builder
-			for i, key := range m.headerList {
-				if i == m.selectedHeader {
-					headerContent.WriteString(ListItemSelectedStyle.Render(fmt.Sprintf("> %-20s : %s", key, m.headers[key])))
+			for i, key := range r.headerList {
+				if i == r.selectedHeader {
+					headerContent.WriteString(ListItemSelectedStyle.Render(fmt.Sprintf("> %s : %s", padRightWidth(key, 20), r.headers[key])))
 				} else {
-					headerContent.WriteString(ListItemStyle.Render(fmt.Sprintf("  %-20s : %s", key, m.headers[key])))
+					headerContent.WriteString(ListItemStyle.Render(fmt.Sprintf("  %s : %s", padRightWidth(key, 20), r.headers[key])))
 				}
 				headerContent.WriteString("\n")
 			}
 
-			b.WriteString(headerPanel.Render(headerContent.String()))
+			if r.fixingRequest {
+				headerPanel = headerPanel.Width(r.fixEditorWidth(width) + 6)
+			}
+			b.WriteString(r.withFixPanel(h, headerPanel.Render(headerContent.String())))
 		}
 
 		b.WriteString("\n\n")
 
 		buttons := RenderButton("Add (n)", false) + "  "
-		buttons += RenderButton("Edit (e)", len(m.headerList) > 0) + "  "
-		buttons += RenderButton("Delete (d)", len(m.headerList) > 0) + "  "
+		buttons += RenderButton("Edit (e)", len(r.headerList) > 0) + "  "
+		buttons += RenderButton("Delete (d)", len(r.headerList) > 0) + "  "
+		if r.fixingRequest {
+			buttons += RenderButton("Resend (Ctrl+S)", true) + "  "
+		}
 		buttons += RenderButton("Done (Esc)", false)
 		b.WriteString(buttons)
 
 		b.WriteString("\n\n")
-		b.WriteString(RenderFooter("↑↓: navigate • n: add • e: edit • d: delete • Esc: back"))
+		if r.fixingRequest {
+			b.WriteString(RenderFooter(i18n.T("footer.fix_headers")))
+		} else {
+			b.WriteString(RenderFooter("↑↓: navigate • n: add • e: edit • d: delete • Esc: back"))
+		}
 	}
 
-	return Center(m.width, m.height, b.String())
+	return Center(width, height, b.String())
 }
 
-func (m Model) viewBodyEditor() string {
+func (r *RequestBuilder) viewBodyEditor(h host) string {
+	width, height := h.size()
 	var b strings.Builder
 
-	b.WriteString(TitleStyle.Render("Body Editor (JSON)"))
+	b.WriteString(TitleStyle.Render(i18n.Tf("title.body_editor", bodyModeLabel(r.bodyMode()))))
+	b.WriteString("\n")
+	b.WriteString(MutedStyle.Render(i18n.T("body.hint_" + r.bodyMode())))
 	b.WriteString("\n\n")
 
-	if m.bodyError != "" {
-		b.WriteString(ErrorStyle.Render("✗ " + m.bodyError))
+	if r.bodyError != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + r.bodyError))
 		b.WriteString("\n\n")
 	}
 
-	editorView := m.bodyEditor.View()
+	editorView := r.bodyEditor.View()
 	borderColor := ColorAccent
-	if m.bodyError != "" {
+	if r.bodyError != "" {
 		borderColor = ColorError
 	}
+	editorWidth := width - 10
+	if r.fixingRequest {
+		editorWidth = r.fixEditorWidth(width) + 6
+	}
 	styledEditor := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(roundedBorder()).
 		BorderForeground(lipgloss.Color(borderColor)).
 		Padding(1, 2).
-		Width(m.width - 10).
+		Width(editorWidth).
 		Render(editorView)
 
-	b.WriteString(styledEditor)
+	b.WriteString(r.withFixPanel(h, styledEditor))
 	b.WriteString("\n\n")
 
-	buttons := RenderButton("Save (Ctrl+S)", true) + "  "
+	saveLabel := "Save (Ctrl+S)"
+	if r.fixingRequest {
+		saveLabel = "Save & Resend (Ctrl+S)"
+	}
+	buttons := RenderButton(saveLabel, true) + "  "
 	buttons += RenderButton("Cancel (Esc)", false)
 	b.WriteString(buttons)
 
 	b.WriteString("\n\n")
-	b.WriteString(RenderFooter("Ctrl+S: save & validate JSON • Esc: cancel"))
+	if r.fixingRequest {
+		b.WriteString(RenderFooter(i18n.T("footer.fix_body")))
+	} else {
+		b.WriteString(RenderFooter(i18n.T("footer.body_editor")))
+	}
 
-	return Center(m.width, m.height, b.String())
+	return Center(width, height, b.String())
 }
 
-func (m Model) viewQueryEditor() string {
+func (r *RequestBuilder) viewQueryEditor(h host) string {
+	width, height := h.size()
 	var b strings.Builder
 
 	b.WriteString(TitleStyle.Render("Query Parameters Editor"))
 	b.WriteString("\n\n")
 
-	if m.editingQuery {
+	if r.editingQuery {
 		b.WriteString(TextStyle.Render("Add/Edit Query Parameter"))
 		b.WriteString("\n\n")
 
 		keyLabel := "Parameter Name: "
 		b.WriteString(TextStyle.Render(keyLabel))
 		b.WriteString("\n")
-		keyInput := m.queryKeyInput.View()
-		if m.queryKeyInput.Focused() {
+		keyInput := r.queryKeyInput.View()
+		if r.queryKeyInput.Focused() {
 			styledInput := lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
+				Border(roundedBorder()).
 				BorderForeground(lipgloss.Color(ColorAccent)).
 				Padding(0, 1).
-				Width(m.queryKeyInput.Width + 2).
+				Width(r.queryKeyInput.Width + 2).
 				Render(keyInput)
 			b.WriteString(styledInput)
 		} else {
 			styledInput := lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
+				Border(roundedBorder()).
 				BorderForeground(lipgloss.Color(ColorBorder)).
 				Padding(0, 1).
-				Width(m.queryKeyInput.Width + 2).
+				Width(r.queryKeyInput.Width + 2).
 				Render(keyInput)
 			b.WriteString(styledInput)
 		}
@@ -427,21 +488,21 @@ func (m Model) viewQueryEditor() string {
 		valueLabel := "Parameter Value: "
 		b.WriteString(TextStyle.Render(valueLabel))
 		b.WriteString("\n")
-		valueInput := m.queryValueInput.View()
-		if m.queryValueInput.Focused() {
+		valueInput := r.queryValueInput.View()
+		if r.queryValueInput.Focused() {
 			styledInput := lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
+				Border(roundedBorder()).
 				BorderForeground(lipgloss.Color(ColorAccent)).
 				Padding(0, 1).
-				Width(m.queryValueInput.Width + 2).
+				Width(r.queryValueInput.Width + 2).
 				Render(valueInput)
 			b.WriteString(styledInput)
 		} else {
 			styledInput := lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
+				Border(roundedBorder()).
 				BorderForeground(lipgloss.Color(ColorBorder)).
 				Padding(0, 1).
-				Width(m.queryValueInput.Width + 2).
+				Width(r.queryValueInput.Width + 2).
 				Render(valueInput)
 			b.WriteString(styledInput)
 		}
@@ -454,23 +515,23 @@ func (m Model) viewQueryEditor() string {
 		b.WriteString("\n\n")
 		b.WriteString(RenderFooter("Tab: switch field • Enter: save • Esc: cancel"))
 	} else {
-		if len(m.queryList) == 0 {
+		if len(r.queryList) == 0 {
 			b.WriteString(MutedStyle.Render("No query parameters"))
 			b.WriteString("\n\n")
 			b.WriteString(TextStyle.Render("Press 'n' to add a new query parameter"))
 		} else {
 			queryPanel := lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
+				Border(roundedBorder()).
 				BorderForeground(lipgloss.Color(ColorBorder)).
 				Padding(1, 2).
-				Width(m.width - 10)
+				Width(width - 10)
 
 			var queryContent strings.Builder
-			for i, key := range m.queryList {
-				if i == m.selectedQuery {
-					queryContent.WriteString(ListItemSelectedStyle.Render(fmt.Sprintf("> %-20s = %s", key, m.queryParams[key])))
+			for i, key := range r.queryList {
+				if i == r.selectedQuery {
+					queryContent.WriteString(ListItemSelectedStyle.Render(fmt.Sprintf("> %s = %s", padRightWidth(key, 20), r.queryParams[key])))
 				} else {
-					queryContent.WriteString(ListItemStyle.Render(fmt.Sprintf("  %-20s = %s", key, m.queryParams[key])))
+					queryContent.WriteString(ListItemStyle.Render(fmt.Sprintf("  %s = %s", padRightWidth(key, 20), r.queryParams[key])))
 				}
 				queryContent.WriteString("\n")
 			}
@@ -481,8 +542,8 @@ func (m Model) viewQueryEditor() string {
 		b.WriteString("\n\n")
 
 		buttons := RenderButton("Add (n)", false) + "  "
-		buttons += RenderButton("Edit (e)", len(m.queryList) > 0) + "  "
-		buttons += RenderButton("Delete (d)", len(m.queryList) > 0) + "  "
+		buttons += RenderButton("Edit (e)", len(r.queryList) > 0) + "  "
+		buttons += RenderButton("Delete (d)", len(r.queryList) > 0) + "  "
 		buttons += RenderButton("Done (Esc)", false)
 		b.WriteString(buttons)
 
@@ -490,5 +551,5 @@ func (m Model) viewQueryEditor() string {
 		b.WriteString(RenderFooter("↑↓: navigate • n: add • e: edit • d: delete • Esc: back"))
 	}
 
-	return Center(m.width, m.height, b.String())
+	return Center(width, height, b.String())
 }
@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// historyEnvironment returns the environment the history list is scoped to
+func historyEnvironment(h host) string {
+	config := h.environments().config
+	if config == nil {
+		return ""
+	}
+	return config.ActiveEnvironment
+}
+
+// refresh reloads the history list, keeping only the executions of the
+// active environment when the list is scoped to it
+func (l *History) refresh(h host) {
+	store := h.store()
+	if store == nil {
+		return
+	}
+	l.entries = store.GetHistory()
+	if l.envOnly {
+		l.entries = storage.FilterHistoryByEnvironment(l.entries, historyEnvironment(h))
+	}
+}
+
+// historyScopeLabel names the scope shown in the history title
+func historyScopeLabel(env string) string {
+	if env == "" {
+		return i18n.T("history.no_env")
+	}
+	return env
+}
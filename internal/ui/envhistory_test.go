@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+func TestHistoryScopedToActiveEnvironment(t *testing.T) {
+	store, err := storage.NewStorageAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	for _, exec := range []storage.RequestExecution{
+		{Method: "GET", URL: "https://dev.example.com/a", Environment: "dev"},
+		{Method: "DELETE", URL: "https://prod.example.com/a", Environment: "prod"},
+		{Method: "GET", URL: "https://example.com/b"},
+	} {
+		if err := store.AddExecution(exec); err != nil {
+			t.Fatalf("AddExecution() error = %v", err)
+		}
+	}
+
+	m := Model{storage: store, envs: Environments{config: &storage.EnvironmentConfig{ActiveEnvironment: "dev"}}, state: StateHistory, width: 120, height: 40}
+	m.history.refresh(&m)
+	if len(m.history.entries) != 3 {
+		t.Fatalf("Expected the full history by default, got %d entries", len(m.history.entries))
+	}
+	if view := m.history.View(&m); !strings.Contains(view, "[prod]") {
+		t.Error("Expected unscoped history to tag entries with their environment")
+	}
+
+	m.history.Update(&m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if len(m.history.entries) != 1 || m.history.entries[0].Environment != "dev" {
+		t.Fatalf("Expected only the dev execution, got %+v", m.history.entries)
+	}
+	if view := m.history.View(&m); !strings.Contains(view, "environment: dev") {
+		t.Error("Expected the title to name the environment the list is scoped to")
+	}
+
+	m.envs.config.ActiveEnvironment = ""
+	m.history.refresh(&m)
+	if len(m.history.entries) != 1 || m.history.entries[0].Environment != "" {
+		t.Errorf("Expected only executions without an environment, got %+v", m.history.entries)
+	}
+}
@@ -0,0 +1,531 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// Environments is the environments screen: the list of environments and the
+// editor for the variables of one. The rest of the program reads the loaded
+// configuration from it.
+type Environments struct {
+	config   *storage.EnvironmentConfig
+	list     []storage.Environment
+	selected int
+
+	// current is the environment open in the editor, "" for a new one
+	current     string
+	nameInput   textinput.Model
+	keyInput    textinput.Model
+	valueInput  textinput.Model
+	vars        []storage.Variable
+	selectedVar int
+	editingVar  bool
+	focus       int
+
+	saveSuccess         bool
+	saveSuccessTimer    int
+	deleteSuccess       bool
+	deleteSuccessTimer  int
+	confirmingDelete    bool
+	confirmingDeleteVar bool
+}
+
+func newEnvironments() Environments {
+	nameInput := textinput.New()
+	nameInput.Placeholder = i18n.T("env.name_placeholder")
+	nameInput.CharLimit = 50
+	nameInput.Width = 50
+
+	keyInput := textinput.New()
+	keyInput.Placeholder = i18n.T("env.key_placeholder")
+	keyInput.CharLimit = 100
+	keyInput.Width = 30
+
+	valueInput := textinput.New()
+	valueInput.Placeholder = i18n.T("env.value_placeholder")
+	valueInput.CharLimit = 500
+	valueInput.Width = 50
+
+	return Environments{nameInput: nameInput, keyInput: keyInput, valueInput: valueInput}
+}
+
+// reload reads the environments from store again
+func (e *Environments) reload(store *storage.Storage) error {
+	config, err := store.ReloadEnvironments()
+	if err != nil {
+		return err
+	}
+	e.config = config
+	e.list = config.Environments
+	return nil
+}
+
+// reloadVars reads the environments again and refreshes the variables of
+// the one open in the editor
+func (e *Environments) reloadVars(store *storage.Storage) {
+	if e.reload(store) != nil {
+		return
+	}
+	for _, env := range e.list {
+		if env.Name == e.current {
+			e.vars = env.Variables
+			break
+		}
+	}
+}
+
+func (e *Environments) resize(inputWidth int) {
+	e.nameInput.Width = inputWidth
+	e.keyInput.Width = inputWidth / 2
+	e.valueInput.Width = inputWidth / 2
+}
+
+// tick counts down the success notices
+func (e *Environments) tick() {
+	if e.saveSuccessTimer > 0 {
+		e.saveSuccessTimer--
+		if e.saveSuccessTimer == 0 {
+			e.saveSuccess = false
+		}
+	}
+	if e.deleteSuccessTimer > 0 {
+		e.deleteSuccessTimer--
+		if e.deleteSuccessTimer == 0 {
+			e.deleteSuccess = false
+		}
+	}
+}
+
+func (e *Environments) flashSaved() {
+	e.saveSuccess = true
+	e.saveSuccessTimer = 3
+}
+
+func (e *Environments) flashDeleted() {
+	e.deleteSuccess = true
+	e.deleteSuccessTimer = 3
+}
+
+// Update handles a key for the list or the editor, whichever is showing
+func (e *Environments) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	if h.screenState() == StateEnvironmentEditor {
+		return e.updateEditor(h, msg)
+	}
+	return e.updateList(h, msg)
+}
+
+func (e *Environments) updateList(h host, msg tea.KeyMsg) tea.Cmd {
+	store := h.store()
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		if e.confirmingDelete {
+			e.confirmingDelete = false
+			return nil
+		}
+		h.navigate(StateRequestBuilder)
+
+	case "up", "k":
+		if e.selected > 0 {
+			e.selected--
+		}
+
+	case "down", "j":
+		if e.selected < len(e.list)-1 {
+			e.selected++
+		}
+
+	case "n", "a":
+		if h.blockedByReadOnly("create environment") {
+			return nil
+		}
+		e.nameInput.SetValue("")
+		e.nameInput.Focus()
+		e.current = ""
+		e.vars = []storage.Variable{}
+		e.selectedVar = 0
+		h.navigate(StateEnvironmentEditor)
+
+	case "enter":
+		if len(e.list) > 0 && e.selected < len(e.list) {
+			env := e.list[e.selected]
+			e.current = env.Name
+			e.vars = env.Variables
+			e.selectedVar = 0
+			e.nameInput.SetValue(env.Name)
+			h.navigate(StateEnvironmentEditor)
+		}
+
+	case "d":
+		if h.blockedByReadOnly("delete environment") {
+			return nil
+		}
+		if len(e.list) > 0 && e.selected < len(e.list) {
+			e.confirmingDelete = true
+		}
+
+	case "y":
+		if e.confirmingDelete && len(e.list) > 0 && e.selected < len(e.list) {
+			env := e.list[e.selected]
+			if store != nil && store.DeleteEnvironment(env.Name) == nil {
+				e.reload(store)
+				if e.selected >= len(e.list) && e.selected > 0 {
+					e.selected--
+				}
+				e.flashDeleted()
+			}
+			e.confirmingDelete = false
+		}
+
+	case "s":
+		if len(e.list) > 0 && e.selected < len(e.list) {
+			env := e.list[e.selected]
+			if store != nil {
+				h.reportStorageError("failed to set active environment", store.SetActiveEnvironment(env.Name))
+				e.reload(store)
+				e.flashSaved()
+			}
+		}
+	}
+
+	return nil
+}
+
+func (e *Environments) closeVarForm() {
+	e.editingVar = false
+	e.focus = 0
+	e.keyInput.Blur()
+	e.valueInput.Blur()
+	e.keyInput.SetValue("")
+	e.valueInput.SetValue("")
+}
+
+func (e *Environments) updateVarForm(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		e.closeVarForm()
+		return nil
+
+	case "enter", "tab":
+		if e.focus == 0 {
+			e.focus = 1
+			e.keyInput.Blur()
+			e.valueInput.Focus()
+			return nil
+		}
+		key := strings.TrimSpace(e.keyInput.Value())
+		store := h.store()
+		if key != "" && store != nil && e.current != "" {
+			if store.AddVariable(e.current, key, e.valueInput.Value()) == nil {
+				e.reloadVars(store)
+				e.flashSaved()
+			}
+		}
+		e.closeVarForm()
+		return nil
+	}
+
+	if e.focus == 0 {
+		e.keyInput, cmd = e.keyInput.Update(msg)
+	} else {
+		e.valueInput, cmd = e.valueInput.Update(msg)
+	}
+	return cmd
+}
+
+// updateNameForm names a new environment; its variables are edited once it
+// is saved
+func (e *Environments) updateNameForm(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		h.navigate(StateEnvironments)
+		return nil
+
+	case "ctrl+s":
+		name := strings.TrimSpace(e.nameInput.Value())
+		store := h.store()
+		if name != "" && store != nil && store.AddEnvironment(name) == nil {
+			e.current = name
+			e.nameInput.Blur()
+			e.reload(store)
+			e.flashSaved()
+		}
+		return nil
+	}
+
+	e.nameInput, cmd = e.nameInput.Update(msg)
+	return cmd
+}
+
+func (e *Environments) updateEditor(h host, msg tea.KeyMsg) tea.Cmd {
+	if e.current == "" {
+		return e.updateNameForm(h, msg)
+	}
+	if e.editingVar {
+		return e.updateVarForm(h, msg)
+	}
+	store := h.store()
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		if e.confirmingDeleteVar {
+			e.confirmingDeleteVar = false
+			return nil
+		}
+		h.navigate(StateEnvironments)
+		e.current = ""
+
+	case "up", "k":
+		if e.selectedVar > 0 {
+			e.selectedVar--
+		}
+
+	case "down", "j":
+		if e.selectedVar < len(e.vars)-1 {
+			e.selectedVar++
+		}
+
+	case "n", "a":
+		if h.blockedByReadOnly("add variable") {
+			return nil
+		}
+		e.editingVar = true
+		e.focus = 0
+		e.keyInput.SetValue("")
+		e.valueInput.SetValue("")
+		e.keyInput.Focus()
+
+	case "l":
+		h.openAliases()
+
+	case "e":
+		if len(e.vars) > 0 && e.selectedVar < len(e.vars) {
+			variable := e.vars[e.selectedVar]
+			e.editingVar = true
+			e.focus = 0
+			e.keyInput.SetValue(variable.Key)
+			e.valueInput.SetValue(variable.Value)
+			e.keyInput.Focus()
+		}
+
+	case "d":
+		if h.blockedByReadOnly("delete variable") {
+			return nil
+		}
+		if len(e.vars) > 0 && e.selectedVar < len(e.vars) {
+			e.confirmingDeleteVar = true
+		}
+
+	case "y":
+		if e.confirmingDeleteVar && len(e.vars) > 0 && e.selectedVar < len(e.vars) {
+			variable := e.vars[e.selectedVar]
+			if store != nil && e.current != "" && store.DeleteVariable(e.current, variable.Key) == nil {
+				e.reloadVars(store)
+				if e.selectedVar >= len(e.vars) && e.selectedVar > 0 {
+					e.selectedVar--
+				}
+				e.flashDeleted()
+			}
+			e.confirmingDeleteVar = false
+		}
+	}
+
+	return nil
+}
+
+// View renders the list or the editor, whichever is showing
+func (e *Environments) View(h host) string {
+	width, height := h.size()
+	if h.screenState() == StateEnvironmentEditor {
+		return Center(width, height, e.viewEditor())
+	}
+	return Center(width, height, e.viewList())
+}
+
+func (e *Environments) viewList() string {
+	var b strings.Builder
+
+	activeEnv := ""
+	if e.config != nil && e.config.ActiveEnvironment != "" {
+		activeEnv = e.config.ActiveEnvironment
+	}
+
+	title := i18n.Tf("title.environments", len(e.list))
+	if activeEnv != "" {
+		title += i18n.Tf("title.active_env", activeEnv)
+	}
+	b.WriteString(TitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	if e.saveSuccess {
+		b.WriteString(SuccessStyle.Render(i18n.T("env.saved")))
+		b.WriteString("\n\n")
+	}
+
+	if e.deleteSuccess {
+		b.WriteString(SuccessStyle.Render(i18n.T("env.trashed")))
+		b.WriteString("\n\n")
+	}
+
+	if len(e.list) == 0 {
+		b.WriteString(MutedStyle.Render(i18n.T("env.empty")))
+		b.WriteString("\n\n")
+		b.WriteString(TextStyle.Render(i18n.T("env.empty_hint")))
+	} else {
+		for i, env := range e.list {
+			prefix := "  "
+			if i == e.selected {
+				prefix = "> "
+			}
+
+			envName := env.Name
+			if activeEnv == env.Name {
+				envName += " ★"
+			}
+
+			varCount := i18n.Tf("env.var_count", len(env.Variables))
+
+			if i == e.selected {
+				b.WriteString(ListItemSelectedStyle.Render(prefix + envName))
+			} else {
+				b.WriteString(ListItemStyle.Render(prefix + envName))
+			}
+			b.WriteString("  ")
+			b.WriteString(MutedStyle.Render(varCount))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n\n")
+
+	if e.confirmingDelete && len(e.list) > 0 && e.selected < len(e.list) {
+		confirmMsg := i18n.Tf("confirm.delete_env", e.list[e.selected].Name)
+		b.WriteString(WarningStyle.Render(confirmMsg))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(RenderFooter(i18n.T("footer.environments")))
+
+	return b.String()
+}
+
+// inputBox frames an input, in the accent color when it has focus
+func inputBox(input textinput.Model, focused bool) string {
+	border := ColorBorder
+	if focused {
+		border = ColorAccent
+	}
+	return lipgloss.NewStyle().
+		Border(roundedBorder()).
+		BorderForeground(lipgloss.Color(border)).
+		Padding(0, 1).
+		Width(input.Width + 2).
+		Render(input.View())
+}
+
+func (e *Environments) viewEditor() string {
+	var b strings.Builder
+
+	if e.current == "" {
+		b.WriteString(TitleStyle.Render(i18n.T("title.new_env")))
+	} else {
+		b.WriteString(TitleStyle.Render(i18n.Tf("title.edit_env", e.current)))
+	}
+	b.WriteString("\n\n")
+
+	if e.saveSuccess {
+		b.WriteString(SuccessStyle.Render(i18n.T("env.var_saved")))
+		b.WriteString("\n\n")
+	}
+
+	if e.deleteSuccess {
+		b.WriteString(SuccessStyle.Render(i18n.T("env.var_deleted")))
+		b.WriteString("\n\n")
+	}
+
+	if e.current == "" {
+		b.WriteString(HeaderStyle.Render(i18n.T("env.name")))
+		b.WriteString("\n")
+		b.WriteString(inputBox(e.nameInput, true))
+		b.WriteString("\n\n")
+		b.WriteString(MutedStyle.Render(i18n.T("env.save_hint")))
+		b.WriteString("\n\n")
+	} else {
+		b.WriteString(HeaderStyle.Render(i18n.Tf("env.variables", len(e.vars))))
+		b.WriteString("\n\n")
+
+		if len(e.vars) == 0 {
+			b.WriteString(MutedStyle.Render(i18n.T("env.no_vars")))
+			b.WriteString("\n\n")
+			b.WriteString(TextStyle.Render(i18n.T("env.no_vars_hint")))
+		} else {
+			for i, variable := range e.vars {
+				varText := fmt.Sprintf("%s = %s", variable.Key, variable.Value)
+				if i == e.selectedVar {
+					b.WriteString(ListItemSelectedStyle.Render("> " + varText))
+				} else {
+					b.WriteString(ListItemStyle.Render("  " + varText))
+				}
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	b.WriteString("\n\n")
+
+	if e.editingVar {
+		b.WriteString(HeaderStyle.Render(i18n.T("env.edit_var")))
+		b.WriteString("\n\n")
+
+		b.WriteString(TextStyle.Render(i18n.T("env.key")))
+		b.WriteString("\n")
+		b.WriteString(inputBox(e.keyInput, e.focus == 0))
+		b.WriteString("\n\n")
+
+		b.WriteString(TextStyle.Render(i18n.T("env.value")))
+		b.WriteString("\n")
+		b.WriteString(inputBox(e.valueInput, e.focus == 1))
+		b.WriteString("\n\n")
+
+		b.WriteString(RenderFooter(i18n.T("footer.env_new")))
+		return b.String()
+	}
+
+	if e.confirmingDeleteVar && len(e.vars) > 0 && e.selectedVar < len(e.vars) {
+		confirmMsg := i18n.Tf("confirm.delete_variable", e.vars[e.selectedVar].Key)
+		b.WriteString(WarningStyle.Render(confirmMsg))
+		b.WriteString("\n\n")
+	}
+
+	if e.current == "" {
+		b.WriteString(RenderFooter(i18n.T("footer.env_save")))
+	} else {
+		b.WriteString(RenderFooter(i18n.T("footer.env_vars")))
+	}
+
+	return b.String()
+}
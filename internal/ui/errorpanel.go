@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/abneribeiro/godev/internal/errors"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// storageErrorTicks is how long a storage error banner stays visible
+const storageErrorTicks = 5
+
+// errorTitle returns the friendly headline for an error's category
+func errorTitle(err error) string {
+	return i18n.T("error." + string(errors.Classify(err)) + ".title")
+}
+
+// errorHint returns the suggested action for an error's category
+func errorHint(err error) string {
+	return i18n.T("error." + string(errors.Classify(err)) + ".hint")
+}
+
+// errorPanelContent shows an error as a headline, the underlying message
+// and a suggested next step
+func errorPanelContent(err error) string {
+	var b strings.Builder
+	b.WriteString(ErrorStyle.Render("✗ " + errorTitle(err)))
+	b.WriteString("\n\n")
+	b.WriteString(TextStyle.Render(err.Error()))
+	b.WriteString("\n\n")
+	b.WriteString(MutedStyle.Render("→ " + errorHint(err)))
+	return b.String()
+}
+
+// renderErrorPanel renders errorPanelContent in a bordered error panel
+func renderErrorPanel(err error, width int) string {
+	return lipgloss.NewStyle().
+		Border(roundedBorder()).
+		BorderForeground(lipgloss.Color(ColorError)).
+		Padding(1, 2).
+		Width(width).
+		Render(errorPanelContent(err))
+}
+
+// reportStorageError flashes a storage failure that would otherwise be lost
+// because the action it came from has no error display of its own
+func (m *Model) reportStorageError(action string, err error) {
+	if err == nil {
+		return
+	}
+	m.storageErr = fmt.Errorf("%s: %w", action, err)
+	m.storageErrTimer = storageErrorTicks
+}
+
+// withStorageErrorBanner replaces the top line of a rendered view with the
+// last storage error, keeping the view height unchanged
+func (m Model) withStorageErrorBanner(view string) string {
+	banner := ErrorStyle.Render(fmt.Sprintf("✗ %s: %v", errorTitle(m.storageErr), m.storageErr)) +
+		MutedStyle.Render(" → "+errorHint(m.storageErr))
+	banner = lipgloss.PlaceHorizontal(m.width, lipgloss.Center, banner)
+
+	lines := strings.Split(view, "\n")
+	if len(lines) > 1 && strings.TrimSpace(lines[0]) == "" {
+		lines[0] = banner
+		return strings.Join(lines, "\n")
+	}
+	return banner + "\n" + view
+}
@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/errors"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+func TestEveryErrorCategoryHasMessages(t *testing.T) {
+	for _, category := range errors.Categories {
+		for _, suffix := range []string{".title", ".hint"} {
+			key := "error." + string(category) + suffix
+			if i18n.T(key) == key {
+				t.Errorf("Missing message %q", key)
+			}
+		}
+	}
+}
+
+func TestReportStorageError(t *testing.T) {
+	m := Model{width: 120}
+	m.reportStorageError("failed to record history", nil)
+	if m.storageErr != nil {
+		t.Fatal("Expected nil errors to be ignored")
+	}
+
+	m.reportStorageError("failed to record history", errors.NewStorageError("failed to write", fmt.Errorf("disk full")))
+	if m.storageErrTimer == 0 {
+		t.Fatal("Expected the storage error to be shown")
+	}
+
+	view := m.withStorageErrorBanner("\nbody")
+	if !strings.Contains(view, "failed to record history") || !strings.Contains(view, "body") {
+		t.Errorf("Unexpected banner view:\n%s", view)
+	}
+
+	panel := errorPanelContent(fmt.Errorf("request: %w", context.DeadlineExceeded))
+	if !strings.Contains(panel, errorHint(context.DeadlineExceeded)) {
+		t.Errorf("Expected the timeout hint in the panel:\n%s", panel)
+	}
+}
@@ -0,0 +1,444 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// Fields of the extraction form
+const (
+	extractFieldName = iota
+	extractFieldSource
+	extractFieldExpression
+	extractFieldCount
+)
+
+// maxExtractSuggestions caps the JSON paths suggested at once
+const maxExtractSuggestions = 5
+
+// extractionPanel holds the variable extractions screen and what the
+// extractions of the last response found
+type extractionPanel struct {
+	results []storage.ExtractionResult
+	// stored is the environment the values found were written to
+	stored string
+	err    string
+
+	selected int
+	editing  bool
+	inputs   [extractFieldCount]textinput.Model
+	source   int
+	focus    int
+}
+
+// activeExtractions returns the extractions of the loaded saved request
+func (m Model) activeExtractions() []storage.VariableExtract {
+	if !m.builder.requestSaved || m.builder.currentRequestSavedID == "" {
+		return nil
+	}
+	for _, req := range m.requests.saved {
+		if req.ID == m.builder.currentRequestSavedID {
+			return req.Extractions
+		}
+	}
+	return nil
+}
+
+// storeExtracted writes extracted variables into the active environment
+// and returns its name
+func (m *Model) storeExtracted(vars []storage.Variable) (string, error) {
+	env := m.envs.config.Active()
+	switch {
+	case env == nil:
+		return "", errors.New(i18n.T("extract.no_env"))
+	case m.readOnly:
+		return "", errors.New(i18n.T("extract.read_only"))
+	case m.storage == nil:
+		return "", errors.New(i18n.T("extract.no_storage"))
+	}
+	name := env.Name
+	if err := m.storage.SetVariables(name, vars); err != nil {
+		return "", err
+	}
+	m.reportStorageError("failed to reload environments", m.envs.reload(m.storage))
+	return name, nil
+}
+
+// runExtractions reads the extractions of the saved request from the
+// current response and stores the values found in the active environment,
+// so the requests that follow can use them as {{NAME}}
+func (m *Model) runExtractions() {
+	p := &m.extractions
+	p.results, p.stored, p.err = nil, "", ""
+	extractions := m.activeExtractions()
+	if len(extractions) == 0 || m.viewer.response == nil || m.viewer.response.Error != nil {
+		return
+	}
+
+	p.results = storage.RunExtractions(extractions, m.viewer.response.Body, m.viewer.response.Headers)
+	vars := storage.ExtractedVariables(p.results)
+	if len(vars) == 0 {
+		return
+	}
+	env, err := m.storeExtracted(vars)
+	if err != nil {
+		p.err = err.Error()
+		return
+	}
+	p.stored = env
+}
+
+// openExtractions shows the extractions of the current saved request
+func (m *Model) openExtractions() {
+	m.extractions.err = ""
+	if !m.builder.requestSaved || m.builder.currentRequestSavedID == "" {
+		m.extractions.err = i18n.T("extract.save_first")
+		return
+	}
+	m.state = StateExtractions
+	m.extractions.editing = false
+	m.extractions.selected = 0
+}
+
+// startExtractionForm opens an empty form for a new extraction
+func (m *Model) startExtractionForm() {
+	width := m.layout.InputWidth
+	if width <= 0 {
+		width = 60
+	}
+	placeholders := [extractFieldCount]string{
+		extractFieldName:       "TOKEN",
+		extractFieldExpression: "$.token",
+	}
+	p := &m.extractions
+	for i := range p.inputs {
+		input := textinput.New()
+		input.Placeholder = placeholders[i]
+		input.CharLimit = 500
+		input.Width = width
+		p.inputs[i] = input
+	}
+	p.inputs[extractFieldName].CharLimit = 100
+	p.source = 0
+	p.focus = extractFieldName
+	p.inputs[p.focus].Focus()
+	p.editing = true
+	p.err = ""
+}
+
+func (m *Model) focusExtractionField(delta int) {
+	p := &m.extractions
+	p.inputs[p.focus].Blur()
+	p.focus = (p.focus + delta + extractFieldCount) % extractFieldCount
+	p.inputs[p.focus].Focus()
+}
+
+// extractionSuggestions lists the header names or JSON paths of the last
+// response that contain the typed expression
+func (m Model) extractionSuggestions() []string {
+	p := m.extractions
+	if m.viewer.response == nil || m.viewer.response.Error != nil {
+		return nil
+	}
+	var candidates []string
+	switch storage.ExtractionSources[p.source] {
+	case storage.ExtractJSON:
+		candidates = storage.SuggestJSONPaths(m.viewer.response.Body, false)
+	case storage.ExtractHeader:
+		for name := range m.viewer.response.Headers {
+			candidates = append(candidates, name)
+		}
+		sort.Strings(candidates)
+	}
+
+	query := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(p.inputs[extractFieldExpression].Value()), "$."))
+	var suggestions []string
+	for _, c := range candidates {
+		if strings.Contains(strings.ToLower(c), query) {
+			suggestions = append(suggestions, c)
+		}
+	}
+	return suggestions
+}
+
+// saveExtractionForm appends the extraction of the form to the saved request
+func (m *Model) saveExtractionForm() {
+	p := &m.extractions
+	e := storage.NewVariableExtract(p.inputs[extractFieldName].Value(), storage.ExtractionSources[p.source],
+		p.inputs[extractFieldExpression].Value())
+	if err := e.Validate(); err != nil {
+		p.err = err.Error()
+		return
+	}
+	extractions := append(append([]storage.VariableExtract(nil), m.activeExtractions()...), e)
+	if !m.updateExtractions(extractions) {
+		return
+	}
+	p.inputs[p.focus].Blur()
+	p.editing = false
+	p.selected = len(extractions) - 1
+}
+
+// updateExtractions stores the extractions of the saved request and runs
+// them on the current response
+func (m *Model) updateExtractions(extractions []storage.VariableExtract) bool {
+	if m.storage == nil {
+		return false
+	}
+	if err := m.storage.UpdateExtractions(m.builder.currentRequestSavedID, extractions); err != nil {
+		m.extractions.err = err.Error()
+		return false
+	}
+	m.requests.saved = m.storage.GetRequests()
+	m.runExtractions()
+	return true
+}
+
+func (m Model) handleExtractionsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.extractions.editing {
+		return m.handleExtractionFormKeys(msg)
+	}
+	p := &m.extractions
+	extractions := m.activeExtractions()
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return m, tea.Quit
+
+	case "esc":
+		m.state = StateViewResponse
+		p.err = ""
+		return m, nil
+
+	case "up", "k":
+		if p.selected > 0 {
+			p.selected--
+		}
+		return m, nil
+
+	case "down", "j":
+		if p.selected < len(extractions)-1 {
+			p.selected++
+		}
+		return m, nil
+
+	case "n":
+		if m.blockedByReadOnly("add extraction") {
+			return m, nil
+		}
+		m.startExtractionForm()
+		return m, nil
+
+	case "d":
+		if m.blockedByReadOnly("delete extraction") {
+			return m, nil
+		}
+		if p.selected < len(extractions) {
+			kept := append([]storage.VariableExtract(nil), extractions[:p.selected]...)
+			kept = append(kept, extractions[p.selected+1:]...)
+			if m.updateExtractions(kept) {
+				p.selected = clampIndex(p.selected, len(kept))
+			}
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// handleExtractionFormKeys handles the form of a new extraction
+func (m Model) handleExtractionFormKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	p := &m.extractions
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return m, tea.Quit
+
+	case "esc":
+		p.inputs[p.focus].Blur()
+		p.editing = false
+		p.err = ""
+		return m, nil
+
+	case "tab", "down":
+		// Tab on the expression completes the first suggestion
+		if msg.String() == "tab" && p.focus == extractFieldExpression {
+			if suggestions := m.extractionSuggestions(); len(suggestions) > 0 &&
+				p.inputs[extractFieldExpression].Value() != suggestions[0] {
+				p.inputs[extractFieldExpression].SetValue(suggestions[0])
+				p.inputs[extractFieldExpression].CursorEnd()
+				return m, nil
+			}
+		}
+		m.focusExtractionField(1)
+		return m, nil
+
+	case "shift+tab", "up":
+		m.focusExtractionField(-1)
+		return m, nil
+
+	case "enter", "ctrl+s":
+		m.saveExtractionForm()
+		return m, nil
+
+	case "left", "right", " ":
+		if p.focus == extractFieldSource {
+			delta := 1
+			if msg.String() == "left" {
+				delta = -1
+			}
+			p.source = (p.source + delta + len(storage.ExtractionSources)) % len(storage.ExtractionSources)
+			return m, nil
+		}
+	}
+
+	if p.focus == extractFieldSource {
+		return m, nil
+	}
+	p.inputs[p.focus], cmd = p.inputs[p.focus].Update(msg)
+	return m, cmd
+}
+
+// viewExtractionSummary renders what the extractions of the response found
+func (m Model) viewExtractionSummary() string {
+	var b strings.Builder
+	p := m.extractions
+
+	if p.err != "" && m.state == StateViewResponse {
+		b.WriteString(ErrorStyle.Render("✗ " + p.err))
+		b.WriteString("\n")
+	}
+	if len(p.results) == 0 {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		return b.String()
+	}
+
+	var names []string
+	for _, r := range p.results {
+		if r.Passed() {
+			names = append(names, r.Extract.Name)
+		}
+	}
+	if p.stored != "" {
+		b.WriteString(SuccessStyle.Render("✓ " + i18n.Tf("extract.summary", len(names), len(p.results), p.stored, strings.Join(names, ", "))))
+		b.WriteString("\n")
+	}
+	for _, r := range p.results {
+		if !r.Passed() {
+			b.WriteString(MutedStyle.Render(fmt.Sprintf("  ✗ %s: %v", r.Extract, r.Err)))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+func (m Model) viewExtractions() string {
+	var b strings.Builder
+	p := m.extractions
+	extractions := m.activeExtractions()
+
+	b.WriteString(TitleStyle.Render(i18n.Tf("title.extractions", len(extractions))))
+	b.WriteString("\n")
+	if env := m.envs.config.Active(); env != nil {
+		b.WriteString(MutedStyle.Render(i18n.Tf("extract.target", env.Name)))
+	} else {
+		b.WriteString(WarningStyle.Render(i18n.T("extract.no_env")))
+	}
+	b.WriteString("\n\n")
+
+	if len(extractions) == 0 {
+		b.WriteString(MutedStyle.Render(i18n.T("extract.empty")))
+		b.WriteString("\n")
+	}
+	for i, e := range extractions {
+		marker := "•"
+		detail := ""
+		for _, r := range p.results {
+			if r.Extract != e {
+				continue
+			}
+			if r.Passed() {
+				marker = SuccessStyle.Render("✓")
+				detail = "= " + truncateWidth(r.Value, 40, "…")
+			} else {
+				marker = ErrorStyle.Render("✗")
+				detail = r.Err.Error()
+			}
+			break
+		}
+
+		prefix, style := "  ", ListItemStyle
+		if i == p.selected && !p.editing {
+			prefix, style = "> ", ListItemSelectedStyle
+		}
+		b.WriteString(style.Render(prefix) + marker + " " + style.Render(e.String()))
+		if detail != "" {
+			b.WriteString(MutedStyle.Render("  " + detail))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	footer := i18n.T("footer.extractions")
+	if p.editing {
+		b.WriteString(m.viewExtractionForm())
+		footer = i18n.T("footer.extract_form")
+	}
+
+	if p.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + p.err))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(RenderFooter(footer))
+
+	return Center(m.width, m.height, b.String())
+}
+
+// viewExtractionForm renders the form of a new extraction
+func (m Model) viewExtractionForm() string {
+	var b strings.Builder
+	p := m.extractions
+
+	b.WriteString(viewLabeledInput(i18n.T("extract.name"), p.inputs[extractFieldName], p.focus == extractFieldName))
+
+	b.WriteString(TextStyle.Render(i18n.T("extract.source") + " "))
+	if p.focus == extractFieldSource {
+		b.WriteString(ButtonActive.Render("◂ " + storage.ExtractionSources[p.source] + " ▸"))
+	} else {
+		b.WriteString(TextStyle.Render(storage.ExtractionSources[p.source]))
+	}
+	b.WriteString("\n\n")
+
+	label := i18n.T("extract.json_path")
+	switch storage.ExtractionSources[p.source] {
+	case storage.ExtractHeader:
+		label = i18n.T("extract.header")
+	case storage.ExtractRegex:
+		label = i18n.T("extract.regex")
+	}
+	b.WriteString(viewLabeledInput(label, p.inputs[extractFieldExpression], p.focus == extractFieldExpression))
+
+	if suggestions := m.extractionSuggestions(); len(suggestions) > 0 {
+		if len(suggestions) > maxExtractSuggestions {
+			suggestions = suggestions[:maxExtractSuggestions]
+		}
+		b.WriteString(MutedStyle.Render(i18n.Tf("extract.suggestions", strings.Join(suggestions, ", "))))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
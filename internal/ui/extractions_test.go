@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/storage"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowExtractVariable(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	var sentToken string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/me" {
+			sentToken = r.URL.Query().Get("token")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"token": "fresh", "expires_in": 3600}`)
+	}))
+	defer server.Close()
+
+	store, err := storage.NewStorage()
+	if err != nil {
+		t.Fatalf("NewStorage() error = %v", err)
+	}
+	if err := store.AddEnvironment("dev"); err != nil {
+		t.Fatalf("AddEnvironment() error = %v", err)
+	}
+	if err := store.SetActiveEnvironment("dev"); err != nil {
+		t.Fatalf("SetActiveEnvironment() error = %v", err)
+	}
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL + "/login").Press("enter")
+	d.WaitFor("200")
+
+	// Extractions belong to a saved request
+	d.Press("E").AssertView("Save the request first (s) to extract variables")
+	d.Press("s", "E").AssertView("Extract Variables (0)", "Values found are written to environment dev")
+
+	// Tab on the path completes it from the last response
+	d.Press("n").Type("TOKEN").Press("tab", "tab").Type("tok").AssertView("In the last response: token")
+	d.Press("tab", "enter").AssertView("Extract Variables (1)", "TOKEN from json token", "= fresh")
+	d.Press("esc").AssertView("✓ Extracted 1/1 variables into dev: TOKEN")
+
+	vars, err := store.GetActiveEnvironmentVariables()
+	if err != nil || len(vars) != 1 || vars[0] != (storage.Variable{Key: "TOKEN", Value: "fresh"}) {
+		t.Fatalf("Expected TOKEN in the active environment, got %+v, %v", vars, err)
+	}
+
+	// The next request uses the extracted value
+	d.Press("esc", "ctrl+u").Type(server.URL + "/me?token={{TOKEN}}").Press("enter")
+	d.WaitFor("200")
+	if sentToken != "fresh" {
+		t.Errorf("Expected {{TOKEN}} to be sent as the extracted value, got %q", sentToken)
+	}
+}
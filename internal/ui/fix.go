@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// fixPanelWidth is the width of the pinned error panel beside the editor
+const fixPanelWidth = 40
+
+// fixPanelMaxLines bounds how much of a raw error body is pinned
+const fixPanelMaxLines = 15
+
+// errorMessageKeys are the fields APIs commonly use for error details
+var errorMessageKeys = []string{"message", "error", "error_description", "detail", "title", "description", "errors"}
+
+// startFixAndResend opens the body editor, or the header editor for
+// requests without a body, with the error of resp pinned beside it when it
+// is a 4xx response
+func (r *RequestBuilder) startFixAndResend(h host, resp *httpclient.Response) bool {
+	if resp == nil || resp.Error != nil || resp.StatusCode < 400 || resp.StatusCode >= 500 {
+		return false
+	}
+
+	r.fixingRequest = true
+	r.fixErrorStatus = resp.Status
+	r.fixErrorMessage = summarizeErrorBody(resp.Body)
+
+	if r.body != "" || methodTakesBody(r.method) {
+		width, _ := h.size()
+		h.navigate(StateBodyEditor)
+		r.bodyError = ""
+		r.bodyEditor.SetWidth(r.fixEditorWidth(width))
+		r.bodyEditor.SetValue(r.body)
+		r.bodyEditor.Focus()
+	} else {
+		r.openHeaderEditor(h)
+	}
+	return true
+}
+
+// stopFixAndResend leaves the fix flow and restores the editor size
+func (r *RequestBuilder) stopFixAndResend() {
+	r.fixingRequest = false
+	r.fixErrorStatus = ""
+	r.fixErrorMessage = ""
+	r.bodyEditor.SetWidth(80)
+}
+
+// fixEditorWidth is the width of the editor beside the pinned error on a
+// screen width wide
+func (r *RequestBuilder) fixEditorWidth(screenWidth int) int {
+	width := screenWidth - fixPanelWidth - 20
+	if width > 80 {
+		width = 80
+	}
+	if width < 30 {
+		width = 30
+	}
+	return width
+}
+
+func methodTakesBody(method string) bool {
+	switch method {
+	case "POST", "PUT", "PATCH":
+		return true
+	}
+	return false
+}
+
+// summarizeErrorBody pulls the error details out of a JSON error body, or
+// falls back to the first lines of the raw body
+func summarizeErrorBody(body string) string {
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &data); err == nil {
+		var parts []string
+		for _, key := range errorMessageKeys {
+			if value, ok := data[key]; ok {
+				parts = append(parts, describeErrorValue(key, value)...)
+			}
+		}
+		if len(parts) > 0 {
+			return strings.Join(parts, "\n")
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	if len(lines) > fixPanelMaxLines {
+		lines = append(lines[:fixPanelMaxLines], "…")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func describeErrorValue(key string, value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var lines []string
+		for _, item := range v {
+			for _, line := range describeErrorValue(key, item) {
+				lines = append(lines, "• "+line)
+			}
+		}
+		return lines
+	case map[string]interface{}:
+		for _, nested := range errorMessageKeys {
+			if inner, ok := v[nested]; ok {
+				lines := describeErrorValue(nested, inner)
+				if field, ok := v["field"].(string); ok && len(lines) > 0 {
+					lines[0] = field + ": " + lines[0]
+				}
+				return lines
+			}
+		}
+		data, _ := json.Marshal(v)
+		return []string{string(data)}
+	case nil:
+		return nil
+	default:
+		return []string{fmt.Sprintf("%s: %v", key, v)}
+	}
+}
+
+// withFixPanel places the pinned error beside the editor, or above it on
+// narrow terminals
+func (r *RequestBuilder) withFixPanel(h host, editor string) string {
+	if !r.fixingRequest {
+		return editor
+	}
+
+	var b strings.Builder
+	b.WriteString(ErrorStyle.Render("✗ " + r.fixErrorStatus))
+	b.WriteString("\n\n")
+	b.WriteString(TextStyle.Render(r.fixErrorMessage))
+
+	panel := lipgloss.NewStyle().
+		Border(roundedBorder()).
+		BorderForeground(lipgloss.Color(ColorError)).
+		Padding(0, 1).
+		Width(fixPanelWidth).
+		Render(MutedStyle.Render(i18n.T("fix.pinned")) + "\n\n" + b.String())
+
+	if h.screenLayout().StackVertical {
+		return lipgloss.JoinVertical(lipgloss.Left, panel, editor)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, editor, " ", panel)
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textarea"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+)
+
+func TestSummarizeErrorBody(t *testing.T) {
+	tests := map[string]struct {
+		body string
+		want []string
+	}{
+		"message field": {
+			body: `{"message": "email is required", "code": 42}`,
+			want: []string{"email is required"},
+		},
+		"errors array": {
+			body: `{"errors": [{"field": "email", "message": "is invalid"}, "name is too long"]}`,
+			want: []string{"• email: is invalid", "• name is too long"},
+		},
+		"plain text": {
+			body: "Bad Request\n",
+			want: []string{"Bad Request"},
+		},
+	}
+
+	for name, tt := range tests {
+		got := summarizeErrorBody(tt.body)
+		for _, want := range tt.want {
+			if !strings.Contains(got, want) {
+				t.Errorf("%s: summarizeErrorBody() = %q, missing %q", name, got, want)
+			}
+		}
+	}
+}
+
+func TestStartFixAndResend(t *testing.T) {
+	newModel := func(method, body string, status int) Model {
+		return Model{
+			width: 160,
+			builder: RequestBuilder{
+				method:     method,
+				body:       body,
+				bodyEditor: textarea.New(),
+			},
+			viewer: ResponseViewer{
+				response: &httpclient.Response{
+					StatusCode: status,
+					Status:     "422 Unprocessable Entity",
+					Body:       `{"error": "missing field"}`,
+				},
+			},
+		}
+	}
+
+	m := newModel("GET", "", 200)
+	if m.builder.startFixAndResend(&m, m.viewer.response) || m.builder.fixingRequest {
+		t.Fatal("Expected fix flow to be unavailable for a 2xx response")
+	}
+
+	m = newModel("POST", `{"name": ""}`, 422)
+	if !m.builder.startFixAndResend(&m, m.viewer.response) {
+		t.Fatal("Expected fix flow to start for a 4xx response")
+	}
+	if m.state != StateBodyEditor || m.builder.bodyEditor.Value() != `{"name": ""}` {
+		t.Errorf("Expected body editor with the request body, got state %v", m.state)
+	}
+	if m.builder.fixErrorMessage != "missing field" {
+		t.Errorf("Expected pinned error message, got %q", m.builder.fixErrorMessage)
+	}
+
+	m = newModel("GET", "", 401)
+	m.builder.startFixAndResend(&m, m.viewer.response)
+	if m.state != StateHeaderEditor {
+		t.Errorf("Expected header editor for a request without a body, got state %v", m.state)
+	}
+
+	m.builder.stopFixAndResend()
+	if m.builder.fixingRequest || m.builder.fixErrorMessage != "" {
+		t.Error("Expected fix flow to be cleared")
+	}
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abneribeiro/godev/internal/database"
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+// newFlowDriver drives a model built the way main builds it, with its data
+// in a temporary directory
+func newFlowDriver(t *testing.T) *tuitest.Driver {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	return tuitest.New(t, *NewModel()).Resize(160, 50)
+}
+
+func TestFlowSendSaveAndReload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"users": ["alice"]}`)
+	}))
+	defer server.Close()
+
+	d := newFlowDriver(t)
+	d.Press("a").Type(server.URL + "/users").Press("enter")
+	d.WaitFor("200", "alice")
+
+	// Back in the builder, move off the URL field so s saves instead of typing
+	d.Press("esc", "tab", "s")
+	d.Press("ctrl+l").AssertView("GET " + server.URL + "/users")
+
+	d.Press("n")
+	if m := d.Model().(Model); m.builder.urlInput.Value() != "" {
+		t.Fatalf("Expected n to start a new request, got URL %q", m.builder.urlInput.Value())
+	}
+	d.Press("ctrl+l", "enter")
+	if m := d.Model().(Model); m.state != StateRequestBuilder || m.builder.urlInput.Value() != server.URL+"/users" || !m.builder.requestSaved {
+		t.Errorf("Expected the saved request to be loaded, got state %v URL %q", m.state, m.builder.urlInput.Value())
+	}
+}
+
+func TestFlowQueryResultExport(t *testing.T) {
+	d := newFlowDriver(t)
+	d.Press("d")
+
+	// The query result arrives as it would from a connected database
+	d.Send(databaseResultMsg(database.QueryResult{
+		Columns:       []string{"id", "email"},
+		Rows:          [][]string{{"1", "alice@example.com"}, {"2", "bob@example.com"}},
+		ExecutionTime: 3 * time.Millisecond,
+	}))
+	d.AssertView("alice@example.com", "2 rows")
+
+	// e opens the export form; JSON is the second format
+	d.Press("e", "down", "tab").Type("users").Press("enter")
+	d.WaitFor("Results exported to:")
+
+	path := d.Model().(Model).db.exportFilePath
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read the export: %v", err)
+	}
+	if !strings.HasSuffix(path, ".json") || !strings.Contains(string(data), "bob@example.com") {
+		t.Errorf("Unexpected export %s:\n%s", path, data)
+	}
+}
+
+func TestFlowQuit(t *testing.T) {
+	d := newFlowDriver(t)
+	d.Press("a", "esc")
+	if d.Quit() {
+		t.Fatal("Expected Esc to stay in the app")
+	}
+	d.Press("ctrl+c").WaitForQuit()
+}
@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// SetDiffIgnore sets the ignore rules every response comparison honors, on
+// top of the volatile fields of each saved request
+func (m *Model) SetDiffIgnore(rules httpclient.IgnoreRules) {
+	m.diffIgnore = rules
+}
+
+// checkGolden diffs the response against the golden response of the current
+// saved request, if one is pinned
+func (m *Model) checkGolden(resp httpclient.Response) {
+	m.viewer.goldenDiff = nil
+	m.viewer.goldenPinnedAt = time.Time{}
+	m.viewer.viewGoldenDiff = false
+
+	if m.storage == nil || !m.builder.requestSaved || m.builder.currentRequestSavedID == "" || resp.Error != nil {
+		return
+	}
+	saved, err := m.storage.GetRequest(m.builder.currentRequestSavedID)
+	if err != nil || saved.Golden == nil {
+		return
+	}
+
+	golden := httpclient.Response{
+		StatusCode: saved.Golden.StatusCode,
+		Headers:    saved.Golden.Headers,
+		Body:       saved.Golden.Body,
+	}
+	rules, err := httpclient.ParseIgnoreRules(saved.VolatileFields)
+	if err != nil {
+		m.viewer.goldenError = err.Error()
+	}
+
+	m.viewer.goldenPinnedAt = saved.Golden.PinnedAt
+	if diff := httpclient.CompareToGolden(golden, resp, m.diffIgnore.Merge(rules)); diff.HasDifferences() {
+		m.viewer.goldenDiff = diff
+	}
+}
+
+// pinGolden makes the current response the golden response of the saved request
+func (m *Model) pinGolden() {
+	m.viewer.goldenError = ""
+	if !m.builder.requestSaved || m.builder.currentRequestSavedID == "" {
+		m.viewer.goldenError = i18n.T("golden.save_first")
+		return
+	}
+	if m.storage == nil || m.viewer.response == nil || m.viewer.response.Error != nil {
+		return
+	}
+
+	if err := m.storage.PinGoldenResponse(m.builder.currentRequestSavedID, m.viewer.response.StatusCode, m.viewer.response.Headers, m.viewer.response.Body); err != nil {
+		m.viewer.goldenError = err.Error()
+		return
+	}
+	m.requests.saved = m.storage.GetRequests()
+	m.checkGolden(*m.viewer.response)
+}
+
+// startVolatileEdit opens the volatile fields input for the current saved request
+func (m *Model) startVolatileEdit() {
+	m.viewer.goldenError = ""
+	if !m.builder.requestSaved || m.builder.currentRequestSavedID == "" || m.storage == nil {
+		m.viewer.goldenError = i18n.T("golden.save_first")
+		return
+	}
+	saved, err := m.storage.GetRequest(m.builder.currentRequestSavedID)
+	if err != nil {
+		m.viewer.goldenError = err.Error()
+		return
+	}
+
+	m.viewer.editingVolatile = true
+	m.viewer.volatileInput.SetValue(strings.Join(saved.VolatileFields, ", "))
+	m.viewer.volatileInput.CursorEnd()
+	m.viewer.volatileInput.Focus()
+}
+
+// updateVolatile handles input while editing the volatile fields
+func (v *ResponseViewer) updateVolatile(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		v.editingVolatile = false
+		v.volatileInput.Blur()
+		return nil
+
+	case "enter":
+		fields, err := storage.ParseVolatileFields(v.volatileInput.Value())
+		if err == nil {
+			_, err = httpclient.ParseIgnoreRules(fields)
+		}
+		if err != nil {
+			v.goldenError = err.Error()
+			return nil
+		}
+
+		v.editingVolatile = false
+		v.volatileInput.Blur()
+		v.goldenError = ""
+
+		if err := h.saveVolatileFields(fields); err != nil {
+			v.goldenError = err.Error()
+		}
+		return nil
+	}
+
+	v.volatileInput, cmd = v.volatileInput.Update(msg)
+	return cmd
+}
+
+// saveVolatileFields stores fields for the current saved request and compares
+// the response to the golden response again without them
+func (m *Model) saveVolatileFields(fields []string) error {
+	if m.storage != nil {
+		if err := m.storage.UpdateVolatileFields(m.builder.currentRequestSavedID, fields); err != nil {
+			return err
+		}
+		m.requests.saved = m.storage.GetRequests()
+	}
+	if m.viewer.response != nil {
+		m.checkGolden(*m.viewer.response)
+	}
+	return nil
+}
+
+// viewGoldenStatus renders the golden comparison banner and the volatile
+// fields input for the response view
+func (v *ResponseViewer) viewGoldenStatus() string {
+	var b strings.Builder
+
+	switch {
+	case v.goldenDiff != nil:
+		b.WriteString(WarningStyle.Render(i18n.Tf("golden.differs", v.goldenDiff.Summary())))
+		b.WriteString("\n\n")
+	case !v.goldenPinnedAt.IsZero():
+		b.WriteString(SuccessStyle.Render(i18n.Tf("golden.matches", v.goldenPinnedAt.Format(time.DateTime))))
+		b.WriteString("\n\n")
+	}
+
+	if v.editingVolatile {
+		b.WriteString(TextStyle.Render(i18n.T("golden.volatile")))
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().
+			Border(roundedBorder()).
+			BorderForeground(lipgloss.Color(ColorAccent)).
+			Padding(0, 1).
+			Width(v.volatileInput.Width + 2).
+			Render(v.volatileInput.View()))
+		b.WriteString("\n\n")
+	}
+
+	if v.goldenError != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + v.goldenError))
+		b.WriteString("\n\n")
+	}
+	return b.String()
+}
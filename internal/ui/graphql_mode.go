@@ -0,0 +1,225 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// Panes of the GraphQL editor, in focus order
+const (
+	gqlPaneQuery = iota
+	gqlPaneVariables
+)
+
+// isGraphQLBody reports whether body is a JSON GraphQL request
+func isGraphQLBody(body string) bool {
+	var payload struct {
+		Query *string `json:"query"`
+	}
+	return json.Unmarshal([]byte(body), &payload) == nil && payload.Query != nil
+}
+
+// toggleGraphQLMode switches the body between plain JSON and a GraphQL query
+// with variables. Turning it on makes the request a JSON POST.
+func (m *Model) toggleGraphQLMode() {
+	m.graphqlMode = !m.graphqlMode
+	if !m.graphqlMode {
+		return
+	}
+	m.builder.method = "POST"
+	if m.builder.headers == nil {
+		m.builder.headers = make(map[string]string)
+	}
+	if _, ok := m.builder.headers["Content-Type"]; !ok {
+		m.builder.headers["Content-Type"] = "application/json"
+	}
+}
+
+func newGraphQLTextarea(placeholder string, width, height int) textarea.Model {
+	editor := textarea.New()
+	editor.Placeholder = placeholder
+	editor.CharLimit = 100000
+	editor.SetWidth(width)
+	editor.SetHeight(height)
+	return editor
+}
+
+// GraphQLEditor is the editor of the query and the variables of a body in
+// GraphQL mode
+type GraphQLEditor struct {
+	query     textarea.Model
+	variables textarea.Model
+	pane      int
+	err       string
+}
+
+// open splits the body into its query and variables for editing
+func (e *GraphQLEditor) open(h host) {
+	width := h.screenLayout().InputWidth
+	if width <= 0 {
+		width = 80
+	}
+	e.query = newGraphQLTextarea("query {\n  me {\n    id\n  }\n}", width, 10)
+	e.variables = newGraphQLTextarea(`{"id": "42"}`, width, 4)
+	e.err = ""
+
+	if body := h.graphQLBody(); strings.TrimSpace(body) != "" {
+		query, variables, _, err := storage.ParseGraphQLBody(body)
+		if err != nil {
+			e.err = i18n.Tf("graphql.not_graphql", err)
+		}
+		var indented bytes.Buffer
+		if json.Indent(&indented, []byte(variables), "", "  ") == nil {
+			variables = indented.String()
+		}
+		e.query.SetValue(query)
+		e.variables.SetValue(variables)
+	}
+
+	e.pane = gqlPaneQuery
+	e.query.Focus()
+	h.navigate(StateGraphQLEditor)
+}
+
+// body builds the JSON body for the query and variables typed
+// in the editor
+func (e *GraphQLEditor) body() (string, error) {
+	query := e.query.Value()
+	if err := httpclient.ValidateGraphQLQuery(query); err != nil {
+		return "", err
+	}
+	if variables := strings.TrimSpace(e.variables.Value()); variables != "" {
+		var object map[string]interface{}
+		if json.Unmarshal([]byte(variables), &object) != nil {
+			return "", errors.New(i18n.T("graphql.variables_object"))
+		}
+	}
+	return storage.BuildGraphQLBody(storage.GraphQLOperation{
+		Query:         query,
+		OperationName: storage.GraphQLOperationName(query),
+	}, e.variables.Value())
+}
+
+// apply writes the editor's query and variables to the body
+func (e *GraphQLEditor) apply(h host) bool {
+	body, err := e.body()
+	if err != nil {
+		e.err = err.Error()
+		return false
+	}
+	h.setRequestBody(body)
+	e.err = ""
+	return true
+}
+
+func (e *GraphQLEditor) focusPane(pane int) {
+	e.pane = pane
+	if pane == gqlPaneQuery {
+		e.variables.Blur()
+		e.query.Focus()
+	} else {
+		e.query.Blur()
+		e.variables.Focus()
+	}
+}
+
+func (e *GraphQLEditor) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		e.query.Blur()
+		e.variables.Blur()
+		h.navigate(StateRequestBuilder)
+		return nil
+
+	case "tab", "shift+tab":
+		e.focusPane(1 - e.pane)
+		return nil
+
+	case "ctrl+s":
+		if e.apply(h) {
+			h.navigate(StateRequestBuilder)
+		}
+		return nil
+
+	}
+
+	if e.pane == gqlPaneQuery {
+		e.query, cmd = e.query.Update(msg)
+	} else {
+		e.variables, cmd = e.variables.Update(msg)
+	}
+	return cmd
+}
+
+func (e *GraphQLEditor) View(h host) string {
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.graphql_editor")))
+	b.WriteString("\n")
+	b.WriteString(MutedStyle.Render(h.requestLine()))
+	b.WriteString("\n\n")
+
+	panes := []struct {
+		label  string
+		editor textarea.Model
+	}{
+		{i18n.T("graphql.query"), e.query},
+		{i18n.T("graphql.variables"), e.variables},
+	}
+	for pane, p := range panes {
+		labelStyle, border := MutedStyle, ColorBorder
+		if pane == e.pane {
+			labelStyle, border = TextStyle, ColorAccent
+		}
+		b.WriteString(labelStyle.Render(p.label))
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().
+			Border(roundedBorder()).
+			BorderForeground(lipgloss.Color(border)).
+			Padding(0, 1).
+			Render(p.editor.View()))
+		b.WriteString("\n")
+	}
+
+	if e.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + e.err))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(RenderFooter(i18n.T("footer.graphql_editor")))
+
+	width, height := h.size()
+	return Center(width, height, b.String())
+}
+
+// graphqlBodyPreview summarizes a GraphQL body for the request builder
+func graphqlBodyPreview(body string) string {
+	query, variables, name, err := storage.ParseGraphQLBody(body)
+	if err != nil {
+		return i18n.T("graphql.preview_empty")
+	}
+	if name == "" {
+		name = strings.Join(strings.Fields(query), " ")
+	}
+	var vars map[string]interface{}
+	if json.Unmarshal([]byte(variables), &vars) != nil {
+		vars = nil
+	}
+	return i18n.Tf("graphql.preview", name, len(vars))
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+)
+
+func TestGraphQLEditor(t *testing.T) {
+	m := Model{state: StateRequestBuilder, builder: RequestBuilder{urlInput: textinput.New(), method: "GET", headers: map[string]string{}}}
+	m.builder.urlInput.SetValue("https://api.example.com/graphql")
+
+	updated, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	m = updated.(Model)
+	if !m.graphqlMode || m.builder.method != "POST" || m.builder.headers["Content-Type"] != "application/json" {
+		t.Fatalf("Expected GraphQL mode to make a JSON POST, got %s %v", m.builder.method, m.builder.headers)
+	}
+
+	updated, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	m = updated.(Model)
+	if m.state != StateGraphQLEditor {
+		t.Fatalf("Expected b to open the GraphQL editor, got state %v", m.state)
+	}
+
+	m.gqlEditor.query.SetValue("query GetUser($id: ID!) {\n  user(id: $id) { name }\n}")
+	m.gqlEditor.variables.SetValue(`["not", "an", "object"]`)
+	updated, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = updated.(Model)
+	if m.state != StateGraphQLEditor || m.gqlEditor.err == "" {
+		t.Fatalf("Expected variables that are not an object to be rejected, got state %v", m.state)
+	}
+
+	m.gqlEditor.variables.SetValue(`{"id": "42"}`)
+	updated, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = updated.(Model)
+	if m.state != StateRequestBuilder {
+		t.Fatalf("Expected to return to the builder, got state %v (%s)", m.state, m.gqlEditor.err)
+	}
+
+	var payload struct {
+		Query         string            `json:"query"`
+		OperationName string            `json:"operationName"`
+		Variables     map[string]string `json:"variables"`
+	}
+	if err := json.Unmarshal([]byte(m.builder.body), &payload); err != nil {
+		t.Fatalf("Expected a JSON body, got %s", m.builder.body)
+	}
+	if payload.OperationName != "GetUser" || payload.Variables["id"] != "42" || !strings.Contains(payload.Query, "user(id: $id)") {
+		t.Errorf("Unexpected body: %s", m.builder.body)
+	}
+	if got := graphqlBodyPreview(m.builder.body); got != "GraphQL: GetUser • 1 variables" {
+		t.Errorf("graphqlBodyPreview() = %q", got)
+	}
+
+	// Reopening splits the body back into the two panes
+	m.gqlEditor.open(&m)
+	if !strings.HasPrefix(m.gqlEditor.query.Value(), "query GetUser") || !strings.Contains(m.gqlEditor.variables.Value(), `"id": "42"`) {
+		t.Errorf("Expected the body to be split, got %q and %q", m.gqlEditor.query.Value(), m.gqlEditor.variables.Value())
+	}
+
+	m.httpClient = httpclient.NewClient(time.Second)
+	updated, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlG})
+	m = updated.(Model)
+	if m.state != StateGraphQLSchema || !m.schemaBrowser.fromEditor {
+		t.Errorf("Expected Ctrl+G to browse the schema from the editor, got state %v", m.state)
+	}
+}
+
+func TestGraphQLModeFollowsLoadedBody(t *testing.T) {
+	if !isGraphQLBody(`{"query": "{ me }"}`) || isGraphQLBody(`{"name": "Alice"}`) || isGraphQLBody("{ me }") {
+		t.Error("isGraphQLBody() misclassified a body")
+	}
+
+	m := testGraphQLModel()
+	m.schemaBrowser.insertField(&m, httpclient.SchemaEntry{Kind: httpclient.SchemaEntryField, Name: "me", Parent: "Query"})
+	if !m.graphqlMode {
+		t.Error("Expected inserting a field to switch to GraphQL mode")
+	}
+}
@@ -0,0 +1,324 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// GraphQLOperations is the saved GraphQL operation library: the operations
+// saved from the request being edited, loaded back with one of the variable
+// sets they were sent with
+type GraphQLOperations struct {
+	ops      []storage.GraphQLOperation
+	selected int
+	// varSet indexes the variable history of the selected operation
+	varSet int
+
+	confirmingDelete bool
+	err              string
+	notice           string
+}
+
+// open shows the saved GraphQL operation library
+func (g *GraphQLOperations) open(h host) {
+	h.navigate(StateGraphQLOperations)
+	g.err = ""
+	g.notice = ""
+	g.confirmingDelete = false
+	g.reload(h)
+}
+
+func (g *GraphQLOperations) reload(h host) {
+	g.ops = nil
+	if h.store() == nil {
+		return
+	}
+
+	ops, err := h.store().GetGraphQLOperations()
+	if err != nil {
+		g.err = err.Error()
+		return
+	}
+	g.ops = ops
+
+	if g.selected >= len(ops) {
+		g.selected = 0
+		g.varSet = 0
+	}
+}
+
+// save stores the request being edited as an operation and selects it
+func (g *GraphQLOperations) save(h host) {
+	op, err := h.saveGraphQLOperation()
+	if err != nil {
+		g.err = err.Error()
+		return
+	}
+
+	g.err = ""
+	g.notice = i18n.Tf("gql_ops.saved", op.OperationName)
+	g.reload(h)
+	for i, saved := range g.ops {
+		if saved.ID == op.ID {
+			g.selected = i
+			g.varSet = 0
+		}
+	}
+}
+
+// selectedVariables returns the chosen variable set of an operation
+func (g *GraphQLOperations) selectedVariables(op storage.GraphQLOperation) string {
+	if g.varSet < len(op.VariableHistory) {
+		return op.VariableHistory[g.varSet].Variables
+	}
+	return op.Variables
+}
+
+// saveGraphQLOperation stores the request being edited as an operation,
+// which the variables it is sent with are then recorded for
+func (m *Model) saveGraphQLOperation() (storage.GraphQLOperation, error) {
+	query, variables, name, err := storage.ParseGraphQLBody(m.builder.body)
+	if err != nil {
+		return storage.GraphQLOperation{}, err
+	}
+
+	headers := make(map[string]string)
+	for k, v := range m.builder.headers {
+		if k != "Content-Type" {
+			headers[k] = v
+		}
+	}
+
+	op, err := m.storage.SaveGraphQLOperation(storage.GraphQLOperation{
+		OperationName: name,
+		Endpoint:      m.builder.urlInput.Value(),
+		Query:         query,
+		Variables:     variables,
+		Headers:       headers,
+	})
+	if err != nil {
+		return storage.GraphQLOperation{}, err
+	}
+
+	m.currentGraphQLOpID = op.ID
+	return op, nil
+}
+
+// loadGraphQLOperation fills the request builder with an operation sent
+// with variables
+func (m *Model) loadGraphQLOperation(op storage.GraphQLOperation, variables string) error {
+	body, err := storage.BuildGraphQLBody(op, variables)
+	if err != nil {
+		return err
+	}
+
+	headers := make(map[string]string)
+	for k, v := range op.Headers {
+		headers[k] = v
+	}
+	headers["Content-Type"] = "application/json"
+
+	m.builder.method = "POST"
+	m.builder.urlInput.SetValue(op.Endpoint)
+	m.builder.headers = headers
+	m.builder.body = body
+	m.builder.queryParams = make(map[string]string)
+	m.builder.requestSaved = false
+	m.builder.currentRequestSavedID = ""
+	m.viewer.displayTransform = ""
+	m.latencyBudget = 0
+	m.assertions.list = nil
+	m.hmacAuth = nil
+	m.requestAuth = nil
+	m.requestPolicy = nil
+	m.currentGraphQLOpID = op.ID
+	m.graphqlMode = true
+	m.state = StateRequestBuilder
+	return nil
+}
+
+// forgetGraphQLOperation stops recording variables for a deleted operation
+func (m *Model) forgetGraphQLOperation(id string) {
+	if m.currentGraphQLOpID == id {
+		m.currentGraphQLOpID = ""
+	}
+}
+
+// recordGraphQLVariables remembers the variables sent with a loaded operation
+func (m *Model) recordGraphQLVariables() {
+	if m.storage == nil || m.readOnly || m.currentGraphQLOpID == "" {
+		return
+	}
+
+	_, variables, _, err := storage.ParseGraphQLBody(m.builder.body)
+	if err != nil {
+		return
+	}
+	if err := m.storage.RecordGraphQLVariables(m.currentGraphQLOpID, variables); err != nil {
+		m.currentGraphQLOpID = ""
+	}
+}
+
+func (g *GraphQLOperations) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		if g.confirmingDelete {
+			g.confirmingDelete = false
+			return nil
+		}
+		h.navigate(StateRequestBuilder)
+		return nil
+
+	case "up", "k":
+		g.confirmingDelete = false
+		if g.selected > 0 {
+			g.selected--
+			g.varSet = 0
+		}
+		return nil
+
+	case "down", "j":
+		g.confirmingDelete = false
+		if g.selected < len(g.ops)-1 {
+			g.selected++
+			g.varSet = 0
+		}
+		return nil
+
+	case "left":
+		if g.varSet > 0 {
+			g.varSet--
+		}
+		return nil
+
+	case "right":
+		if g.selected < len(g.ops) {
+			op := g.ops[g.selected]
+			if g.varSet < len(op.VariableHistory)-1 {
+				g.varSet++
+			}
+		}
+		return nil
+
+	case "enter":
+		if g.selected < len(g.ops) {
+			op := g.ops[g.selected]
+			if err := h.loadGraphQLOperation(op, g.selectedVariables(op)); err != nil {
+				g.err = err.Error()
+			}
+		}
+		return nil
+
+	case "a":
+		if h.blockedByReadOnly("save GraphQL operation") {
+			return nil
+		}
+		if h.store() != nil {
+			g.save(h)
+		}
+		return nil
+
+	case "d":
+		if h.blockedByReadOnly("delete GraphQL operation") {
+			return nil
+		}
+		if h.store() == nil || g.selected >= len(g.ops) {
+			return nil
+		}
+		if !g.confirmingDelete {
+			g.confirmingDelete = true
+			return nil
+		}
+
+		op := g.ops[g.selected]
+		if err := h.store().DeleteGraphQLOperation(op.ID); err != nil {
+			g.err = err.Error()
+		}
+		h.forgetGraphQLOperation(op.ID)
+		g.confirmingDelete = false
+		g.reload(h)
+		if g.selected > 0 && g.selected >= len(g.ops) {
+			g.selected = len(g.ops) - 1
+		}
+		return nil
+	}
+
+	return nil
+}
+
+func (g *GraphQLOperations) View(h host) string {
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.Tf("title.gql_operations", len(g.ops))))
+	b.WriteString("\n\n")
+
+	if len(g.ops) == 0 {
+		b.WriteString(MutedStyle.Render(i18n.T("gql_ops.empty")))
+		b.WriteString("\n")
+	}
+
+	for i, op := range g.ops {
+		line := fmt.Sprintf("%s %s", padRightWidth(op.OperationName, 28), op.Endpoint)
+		if i == g.selected {
+			b.WriteString(ListItemSelectedStyle.Render("> " + line))
+		} else {
+			b.WriteString(ListItemStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+
+	if g.selected < len(g.ops) {
+		op := g.ops[g.selected]
+
+		b.WriteString("\n")
+		b.WriteString(HeaderStyle.Render(i18n.T("gql_ops.query")))
+		b.WriteString("\n")
+		b.WriteString(TextStyle.Render(truncateLines(op.Query, 8)))
+		b.WriteString("\n\n")
+
+		if len(op.VariableHistory) > 0 {
+			set := op.VariableHistory[g.varSet]
+			b.WriteString(HeaderStyle.Render(i18n.Tf("gql_ops.variables", g.varSet+1, len(op.VariableHistory), set.UsedAt.Format("2006-01-02 15:04"))))
+			b.WriteString("\n")
+			b.WriteString(TextStyle.Render(truncateLines(set.Variables, 6)))
+			b.WriteString("\n\n")
+		} else {
+			b.WriteString(MutedStyle.Render(i18n.T("gql_ops.no_variables")))
+			b.WriteString("\n\n")
+		}
+	}
+
+	if g.confirmingDelete {
+		b.WriteString(WarningStyle.Render(i18n.T("gql_ops.confirm_delete")))
+		b.WriteString("\n\n")
+	}
+	if g.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + g.err))
+		b.WriteString("\n\n")
+	} else if g.notice != "" {
+		b.WriteString(SuccessStyle.Render(g.notice))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(RenderFooter(i18n.T("footer.gql_operations")))
+
+	width, height := h.size()
+	return Center(width, height, b.String())
+}
+
+// truncateLines keeps the first n lines of s
+func truncateLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) <= n {
+		return strings.Join(lines, "\n")
+	}
+	return strings.Join(lines[:n], "\n") + "\n…"
+}
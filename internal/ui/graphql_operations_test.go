@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+func TestLoadGraphQLOperationWithVariableSet(t *testing.T) {
+	m := Model{builder: RequestBuilder{urlInput: textinput.New()}}
+	op := storage.GraphQLOperation{
+		ID:            "op-1",
+		OperationName: "GetUser",
+		Endpoint:      "{{API_URL}}/graphql",
+		Query:         "query GetUser($id: ID!) { user(id: $id) { name } }",
+		Variables:     `{"id": "3"}`,
+		Headers:       map[string]string{"Authorization": "Bearer {{TOKEN}}"},
+		VariableHistory: []storage.VariableSet{
+			{Variables: `{"id": "3"}`, UsedAt: time.Now()},
+			{Variables: `{"id": "1"}`, UsedAt: time.Now().Add(-time.Hour)},
+		},
+	}
+
+	m.gqlOperations.varSet = 1
+	if err := m.loadGraphQLOperation(op, m.gqlOperations.selectedVariables(op)); err != nil {
+		t.Fatalf("loadGraphQLOperation() error = %v", err)
+	}
+
+	if m.builder.method != "POST" || m.builder.urlInput.Value() != op.Endpoint || m.currentGraphQLOpID != "op-1" {
+		t.Errorf("Unexpected request: %s %s (%s)", m.builder.method, m.builder.urlInput.Value(), m.currentGraphQLOpID)
+	}
+	if m.builder.headers["Authorization"] != "Bearer {{TOKEN}}" || m.builder.headers["Content-Type"] != "application/json" {
+		t.Errorf("Unexpected headers: %v", m.builder.headers)
+	}
+	if !strings.Contains(m.builder.body, `"id": "1"`) {
+		t.Errorf("Expected the older variable set in the body, got %s", m.builder.body)
+	}
+}
@@ -0,0 +1,358 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// graphqlSnippetDepth limits how deep object fields are expanded on insert
+const graphqlSnippetDepth = 2
+
+type graphqlSchemaMsg struct {
+	endpoint string
+	schema   *httpclient.GraphQLSchema
+	err      error
+}
+
+func introspectSchemaCmd(client *httpclient.Client, req httpclient.Request) tea.Cmd {
+	return func() tea.Msg {
+		schema, err := httpclient.IntrospectSchemaWithHeaders(client, req.URL, req.Headers)
+		return graphqlSchemaMsg{endpoint: req.URL, schema: schema, err: err}
+	}
+}
+
+// SchemaBrowser is the GraphQL schema browser: the types and fields of the
+// schema introspected from the request URL, searched and inserted into the
+// query of the body
+type SchemaBrowser struct {
+	schema   *httpclient.GraphQLSchema
+	endpoint string
+	loading  bool
+	err      string
+
+	// stack are the types opened, the last one shown
+	stack     []string
+	selected  int
+	search    textinput.Model
+	searching bool
+	notice    string
+
+	// fromEditor is set when the browser was opened from the GraphQL
+	// editor, which Esc returns to
+	fromEditor bool
+}
+
+func newSchemaBrowser() SchemaBrowser {
+	search := textinput.New()
+	search.Placeholder = "Search types, fields, args..."
+	search.CharLimit = 100
+	search.Width = 50
+	return SchemaBrowser{search: search}
+}
+
+// open shows the schema browser, introspecting the current URL unless its
+// schema is already loaded
+func (s *SchemaBrowser) open(h host, fromEditor bool) tea.Cmd {
+	h.navigate(StateGraphQLSchema)
+	s.fromEditor = fromEditor
+	s.notice = ""
+
+	req := h.builtRequest()
+	if s.schema != nil && s.endpoint == req.URL {
+		return nil
+	}
+	return s.refresh(h, req)
+}
+
+func (s *SchemaBrowser) refresh(h host, req httpclient.Request) tea.Cmd {
+	s.loading = true
+	s.err = ""
+	s.stack = nil
+	s.selected = 0
+	s.search.SetValue("")
+	return tea.Batch(h.spinnerTick(), introspectSchemaCmd(h.requestClient(), req))
+}
+
+// finish shows the schema introspected, or why it could not be
+func (s *SchemaBrowser) finish(msg graphqlSchemaMsg) {
+	s.loading = false
+	s.endpoint = msg.endpoint
+	if msg.err != nil {
+		s.schema = nil
+		s.err = msg.err.Error()
+		return
+	}
+	s.schema = msg.schema
+}
+
+// entries returns the entries for the current search or type
+func (s *SchemaBrowser) entries() []httpclient.SchemaEntry {
+	if s.schema == nil {
+		return nil
+	}
+	if term := s.search.Value(); term != "" {
+		return httpclient.SearchSchema(s.schema, term)
+	}
+	if len(s.stack) == 0 {
+		return httpclient.SchemaTypeEntries(s.schema)
+	}
+	t := s.schema.FindType(s.stack[len(s.stack)-1])
+	if t == nil {
+		return nil
+	}
+	return httpclient.TypeMemberEntries(t)
+}
+
+// jumpToType opens a named type, keeping the way back on the stack
+func (s *SchemaBrowser) jumpToType(name string) bool {
+	if s.schema.FindType(name) == nil {
+		return false
+	}
+	s.stack = append(s.stack, name)
+	s.search.SetValue("")
+	s.selected = 0
+	return true
+}
+
+// insertField adds the selected field to the query in the request body
+func (s *SchemaBrowser) insertField(h host, entry httpclient.SchemaEntry) {
+	if entry.Kind != httpclient.SchemaEntryField {
+		s.notice = i18n.T("gql_schema.fields_only")
+		return
+	}
+
+	operation := s.schema.RootOperation(entry.Parent)
+	if operation == "" {
+		s.notice = i18n.T("gql_schema.root_only")
+		return
+	}
+
+	parent := s.schema.FindType(entry.Parent)
+	if parent == nil {
+		return
+	}
+
+	for _, field := range parent.Fields {
+		if field.Name != entry.Name {
+			continue
+		}
+
+		body, err := httpclient.InsertGraphQLField(s.schema, h.graphQLBody(), field, operation, graphqlSnippetDepth)
+		if err != nil {
+			s.notice = err.Error()
+			return
+		}
+
+		h.setGraphQLBody(body)
+		s.notice = i18n.Tf("gql_schema.inserted", entry.Name)
+		return
+	}
+}
+
+func (s *SchemaBrowser) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	if s.searching {
+		switch msg.String() {
+		case "ctrl+c", "ctrl+q":
+			return tea.Quit
+		case "esc", "enter":
+			s.searching = false
+			s.search.Blur()
+			return nil
+		}
+
+		s.search, cmd = s.search.Update(msg)
+		s.selected = 0
+		return cmd
+	}
+
+	entries := s.entries()
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		s.notice = ""
+		switch {
+		case s.search.Value() != "":
+			s.search.SetValue("")
+			s.selected = 0
+		case len(s.stack) > 0:
+			s.stack = s.stack[:len(s.stack)-1]
+			s.selected = 0
+		case s.fromEditor:
+			s.fromEditor = false
+			h.openGraphQLEditor()
+		default:
+			h.navigate(StateRequestBuilder)
+		}
+		return nil
+
+	case "up", "k":
+		if s.selected > 0 {
+			s.selected--
+		}
+		return nil
+
+	case "down", "j":
+		if s.selected < len(entries)-1 {
+			s.selected++
+		}
+		return nil
+
+	case "/":
+		if s.schema != nil {
+			s.searching = true
+			s.search.Focus()
+		}
+		return nil
+
+	case "enter":
+		if s.selected < len(entries) {
+			entry := entries[s.selected]
+			if entry.Target == "" || !s.jumpToType(entry.Target) {
+				s.notice = i18n.Tf("gql_schema.no_type", entry.Name)
+			} else {
+				s.notice = ""
+			}
+		}
+		return nil
+
+	case "i":
+		if s.selected < len(entries) {
+			s.insertField(h, entries[s.selected])
+		}
+		return nil
+
+	case "r":
+		if s.loading {
+			return nil
+		}
+		return s.refresh(h, h.builtRequest())
+	}
+
+	return nil
+}
+
+func (s *SchemaBrowser) View(h host) string {
+	width, height := h.size()
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.gql_schema")))
+	b.WriteString("\n")
+	b.WriteString(MutedStyle.Render(s.endpoint))
+	b.WriteString("\n\n")
+
+	switch {
+	case s.loading:
+		b.WriteString(SpinnerStyle.Render(h.spinnerView()) + "  " + TextStyle.Render(i18n.T("gql_schema.introspecting")))
+		b.WriteString("\n\n")
+		b.WriteString(RenderFooter(i18n.T("footer.gql_schema_loading")))
+		return Center(width, height, b.String())
+
+	case s.err != "":
+		b.WriteString(ErrorStyle.Render("✗ " + s.err))
+		b.WriteString("\n\n")
+		b.WriteString(RenderFooter(i18n.T("footer.gql_schema_failed")))
+		return Center(width, height, b.String())
+
+	case s.schema == nil:
+		b.WriteString(RenderFooter(i18n.T("footer.gql_schema_empty")))
+		return Center(width, height, b.String())
+	}
+
+	path := append([]string{i18n.T("gql_schema.types")}, s.stack...)
+	b.WriteString(HeaderStyle.Render(strings.Join(path, " › ")))
+	b.WriteString("\n")
+
+	if s.searching || s.search.Value() != "" {
+		borderColor := ColorMuted
+		if s.searching {
+			borderColor = ColorAccent
+		}
+		b.WriteString(lipgloss.NewStyle().
+			Border(roundedBorder()).
+			BorderForeground(lipgloss.Color(borderColor)).
+			Padding(0, 1).
+			Width(s.search.Width + 2).
+			Render(s.search.View()))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	entries := s.entries()
+	if len(entries) == 0 {
+		b.WriteString(MutedStyle.Render(i18n.T("gql_schema.nothing_found")))
+		b.WriteString("\n")
+	}
+
+	maxLines := height - 18
+	if maxLines < 5 {
+		maxLines = 5
+	}
+	start := 0
+	if s.selected >= maxLines {
+		start = s.selected - maxLines + 1
+	}
+	end := start + maxLines
+	if end > len(entries) {
+		end = len(entries)
+	}
+
+	for i := start; i < end; i++ {
+		entry := entries[i]
+		line := entry.Name
+		if entry.Detail != "" {
+			sep := "  "
+			if entry.Kind == httpclient.SchemaEntryField {
+				sep = ""
+			}
+			line += sep + MutedStyle.Render(entry.Detail)
+		}
+		if s.search.Value() != "" && entry.Parent != "" {
+			line = MutedStyle.Render(entry.Parent+".") + line
+		}
+		line = fmt.Sprintf("%-9s %s", "["+entry.Kind+"]", line)
+
+		if i == s.selected {
+			b.WriteString(ListItemSelectedStyle.Render("> " + line))
+		} else {
+			b.WriteString(ListItemStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+
+	if s.selected < len(entries) && entries[s.selected].Description != "" {
+		b.WriteString("\n")
+		b.WriteString(MutedStyle.Render(entries[s.selected].Description))
+		b.WriteString("\n")
+	}
+
+	if s.notice != "" {
+		b.WriteString("\n")
+		if strings.HasPrefix(s.notice, "✓") {
+			b.WriteString(SuccessStyle.Render(s.notice))
+		} else {
+			b.WriteString(WarningStyle.Render(s.notice))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if s.searching {
+		b.WriteString(RenderFooter(i18n.T("footer.gql_schema_search")))
+	} else {
+		b.WriteString(RenderFooter(i18n.T("footer.gql_schema")))
+	}
+
+	return Center(width, height, b.String())
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+)
+
+func testGraphQLModel() Model {
+	return Model{
+		schemaBrowser: SchemaBrowser{schema: &httpclient.GraphQLSchema{
+			QueryType: &httpclient.GraphQLType{Name: "Query"},
+			Types: []httpclient.GraphQLType{
+				{Kind: "OBJECT", Name: "Query", Fields: []httpclient.GraphQLField{
+					{Name: "me", Type: httpclient.GraphQLTypeRef{Kind: "OBJECT", Name: "User"}},
+				}},
+				{Kind: "OBJECT", Name: "User", Fields: []httpclient.GraphQLField{
+					{Name: "name", Type: httpclient.GraphQLTypeRef{Kind: "SCALAR", Name: "String"}},
+				}},
+			},
+		}},
+		builder: RequestBuilder{
+			headers: make(map[string]string),
+			method:  "GET",
+		},
+	}
+}
+
+func TestInsertGraphQLField(t *testing.T) {
+	m := testGraphQLModel()
+
+	m.schemaBrowser.insertField(&m, httpclient.SchemaEntry{Kind: httpclient.SchemaEntryField, Name: "me", Parent: "Query"})
+
+	if m.builder.method != "POST" || m.builder.headers["Content-Type"] != "application/json" {
+		t.Errorf("Expected a JSON POST request, got %s %v", m.builder.method, m.builder.headers)
+	}
+	if !strings.Contains(m.builder.body, `query {\n  me {\n    name\n  }\n}`) {
+		t.Errorf("Unexpected body: %s", m.builder.body)
+	}
+}
+
+func TestInsertGraphQLFieldRequiresRootType(t *testing.T) {
+	m := testGraphQLModel()
+
+	m.schemaBrowser.insertField(&m, httpclient.SchemaEntry{Kind: httpclient.SchemaEntryField, Name: "name", Parent: "User"})
+
+	if m.builder.body != "" || m.schemaBrowser.notice == "" {
+		t.Errorf("Expected nested field to be rejected, body %q notice %q", m.builder.body, m.schemaBrowser.notice)
+	}
+}
+
+func TestJumpToType(t *testing.T) {
+	m := testGraphQLModel()
+
+	if !m.schemaBrowser.jumpToType("User") || len(m.schemaBrowser.stack) != 1 {
+		t.Fatalf("Expected to open User, stack %v", m.schemaBrowser.stack)
+	}
+	if entries := m.schemaBrowser.entries(); len(entries) != 1 || entries[0].Name != "name" {
+		t.Errorf("Expected User fields, got %+v", entries)
+	}
+	if m.schemaBrowser.jumpToType("Missing") {
+		t.Error("Expected unknown type to be rejected")
+	}
+}
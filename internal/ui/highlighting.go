@@ -25,6 +25,8 @@ type HighlightTheme struct {
 	Variable lipgloss.Style
 	Property lipgloss.Style
 	Error    lipgloss.Style
+	// Text styles what is between the tokens; left empty it is not styled
+	Text lipgloss.Style
 }
 
 // DefaultDarkTheme returns a default dark theme for syntax highlighting
@@ -143,54 +145,155 @@ func (sh *SyntaxHighlighter) HighlightSQL(sql string) string {
 	return result
 }
 
-// HighlightJSON highlights JSON syntax
+// HighlightJSON highlights JSON syntax. It scans the text instead of
+// parsing it, so a cut-off document or a page of a long one is colored too,
+// and the text itself is left as it was.
 func (sh *SyntaxHighlighter) HighlightJSON(json string) string {
-	result := json
-
-	// Highlight property names (keys)
-	propertyPattern := regexp.MustCompile(`"([^"\\]|\\.)*"\s*:`)
-	result = propertyPattern.ReplaceAllStringFunc(result, func(match string) string {
-		colonPos := strings.LastIndex(match, ":")
-		property := match[:colonPos]
-		return sh.Theme.Property.Render(property) + ":"
-	})
+	out := highlightWriter{text: json, base: sh.Theme.Text}
+	for i := 0; i < len(json); {
+		c := json[i]
+		switch {
+		case c == '"':
+			end := quotedEnd(json, i)
+			style := sh.Theme.String
+			if rest := strings.TrimLeft(json[end:], " \t\r\n"); strings.HasPrefix(rest, ":") {
+				style = sh.Theme.Property
+			}
+			out.token(i, end, style)
+			i = end
+		case c == '-' || isDigit(c):
+			end := i + 1
+			for end < len(json) && (isDigit(json[end]) || strings.IndexByte(".eE+-", json[end]) >= 0) {
+				end++
+			}
+			out.token(i, end, sh.Theme.Number)
+			i = end
+		case isLetter(c):
+			end := i + 1
+			for end < len(json) && isNameByte(json[end]) {
+				end++
+			}
+			switch json[i:end] {
+			case "true", "false", "null":
+				out.token(i, end, sh.Theme.Keyword)
+			}
+			i = end
+		default:
+			i++
+		}
+	}
+	return out.finish()
+}
 
-	// Highlight string values (but not keys which are already highlighted)
-	stringPattern := regexp.MustCompile(`:\s*"([^"\\]|\\.)*"`)
-	result = stringPattern.ReplaceAllStringFunc(result, func(match string) string {
-		colonPos := strings.Index(match, ":")
-		prefix := match[:colonPos+1]
-		value := strings.TrimSpace(match[colonPos+1:])
-		return prefix + " " + sh.Theme.String.Render(value)
-	})
+// HighlightXML highlights XML and HTML markup: tag names, attributes,
+// attribute values and comments
+func (sh *SyntaxHighlighter) HighlightXML(markup string) string {
+	out := highlightWriter{text: markup, base: sh.Theme.Text}
+	inTag := false
+	for i := 0; i < len(markup); {
+		c := markup[i]
+		switch {
+		case !inTag && strings.HasPrefix(markup[i:], "<!--"):
+			end := len(markup)
+			if j := strings.Index(markup[i+4:], "-->"); j >= 0 {
+				end = i + 4 + j + 3
+			}
+			out.token(i, end, sh.Theme.Comment)
+			i = end
+		case !inTag && c == '<' && i+1 < len(markup) && (isLetter(markup[i+1]) || strings.IndexByte("/?!", markup[i+1]) >= 0):
+			end := i + 2
+			for end < len(markup) && isNameByte(markup[end]) {
+				end++
+			}
+			out.token(i, end, sh.Theme.Keyword)
+			inTag = true
+			i = end
+		case inTag && (c == '"' || c == '\''):
+			end := strings.IndexByte(markup[i+1:], c)
+			if end < 0 {
+				end = len(markup)
+			} else {
+				end += i + 2
+			}
+			out.token(i, end, sh.Theme.String)
+			i = end
+		case inTag && isNameByte(c):
+			end := i + 1
+			for end < len(markup) && isNameByte(markup[end]) {
+				end++
+			}
+			out.token(i, end, sh.Theme.Property)
+			i = end
+		case inTag && (c == '>' || strings.HasPrefix(markup[i:], "/>") || strings.HasPrefix(markup[i:], "?>")):
+			end := strings.IndexByte(markup[i:], '>') + i + 1
+			out.token(i, end, sh.Theme.Keyword)
+			inTag = false
+			i = end
+		default:
+			i++
+		}
+	}
+	return out.finish()
+}
 
-	// Highlight numbers
-	numberPattern := regexp.MustCompile(`:\s*-?\d+(\.\d+)?([eE][+-]?\d+)?`)
-	result = numberPattern.ReplaceAllStringFunc(result, func(match string) string {
-		colonPos := strings.Index(match, ":")
-		prefix := match[:colonPos+1]
-		value := strings.TrimSpace(match[colonPos+1:])
-		return prefix + " " + sh.Theme.Number.Render(value)
-	})
+// highlightWriter builds highlighted text out of tokens, writing the text
+// between them in the base style. Every line of a token is styled on its
+// own, so the result can still be split into lines.
+type highlightWriter struct {
+	text string
+	base lipgloss.Style
+	sb   strings.Builder
+	pos  int
+}
 
-	// Highlight booleans
-	boolPattern := regexp.MustCompile(`:\s*(true|false)`)
-	result = boolPattern.ReplaceAllStringFunc(result, func(match string) string {
-		colonPos := strings.Index(match, ":")
-		prefix := match[:colonPos+1]
-		value := strings.TrimSpace(match[colonPos+1:])
-		return prefix + " " + sh.Theme.Keyword.Render(value)
-	})
+func (w *highlightWriter) token(start, end int, style lipgloss.Style) {
+	w.write(w.text[w.pos:start], w.base)
+	w.write(w.text[start:end], style)
+	w.pos = end
+}
 
-	// Highlight null
-	nullPattern := regexp.MustCompile(`:\s*null`)
-	result = nullPattern.ReplaceAllStringFunc(result, func(match string) string {
-		colonPos := strings.Index(match, ":")
-		prefix := match[:colonPos+1]
-		return prefix + " " + sh.Theme.Keyword.Render("null")
-	})
+func (w *highlightWriter) finish() string {
+	w.write(w.text[w.pos:], w.base)
+	return w.sb.String()
+}
 
-	return result
+func (w *highlightWriter) write(text string, style lipgloss.Style) {
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			w.sb.WriteString("\n")
+		}
+		if line != "" {
+			w.sb.WriteString(style.Render(line))
+		}
+	}
+}
+
+// quotedEnd returns the index just past the string that opens at start,
+// or the end of the text when it is not closed
+func quotedEnd(text string, start int) int {
+	for i := start + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		case '\n':
+			return i
+		}
+	}
+	return len(text)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isNameByte(c byte) bool {
+	return isLetter(c) || isDigit(c) || strings.IndexByte("-_:.", c) >= 0
 }
 
 // HighlightGraphQL highlights GraphQL syntax
@@ -296,3 +296,18 @@ func containsHighlightStr(s, substr string) bool {
 	}
 	return false
 }
+
+func TestHighlightKeepsText(t *testing.T) {
+	sh := NewSyntaxHighlighter()
+	tests := map[string]func(string) string{
+		"{\"a\":1, \"b\" : [true, null, \"x: 2\"], \"c\": -1.5e3}":    sh.HighlightJSON,
+		"{\n  \"unclosed\": \"text\n}":                                sh.HighlightJSON,
+		"<?xml version=\"1.0\"?>\n<a href='x'>text <!-- note --></a>": sh.HighlightXML,
+		"<div\n  class=\"box\">1 < 2</div>":                           sh.HighlightXML,
+	}
+	for text, highlight := range tests {
+		if got := StripANSI(highlight(text)); got != text {
+			t.Errorf("highlight(%q) changed the text to %q", text, got)
+		}
+	}
+}
@@ -0,0 +1,312 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// History is the history screen and the bookmarks kept from it
+type History struct {
+	entries         []storage.RequestExecution
+	selected        int
+	confirmingClear bool
+
+	// envOnly scopes the list to the active environment
+	envOnly bool
+
+	// marks are the IDs of the entries marked for comparison
+	marks   []string
+	diffErr string
+
+	bookmarks        []storage.RequestExecution
+	selectedBookmark int
+
+	// noteInput edits the note of the entry noteID while editingNote is set
+	noteInput   textinput.Model
+	editingNote bool
+	noteID      string
+
+	err    string
+	notice string
+
+	saveSuccess      bool
+	saveSuccessTimer int
+}
+
+func newHistory() History {
+	noteInput := textinput.New()
+	noteInput.Placeholder = "reproduction of bug #123"
+	noteInput.CharLimit = 500
+	noteInput.Width = 60
+
+	return History{noteInput: noteInput}
+}
+
+// open shows the history from its latest entry
+func (l *History) open(h host) {
+	h.navigate(StateHistory)
+	l.refresh(h)
+	l.selected = 0
+}
+
+func (l *History) typing() bool { return l.editingNote }
+
+// selectedEntry returns the selected entry of the history list
+func (l *History) selectedEntry() (storage.RequestExecution, bool) {
+	if l.selected < len(l.entries) {
+		return l.entries[l.selected], true
+	}
+	return storage.RequestExecution{}, false
+}
+
+// tick counts down the save notice
+func (l *History) tick() {
+	if l.saveSuccessTimer > 0 {
+		l.saveSuccessTimer--
+		if l.saveSuccessTimer == 0 {
+			l.saveSuccess = false
+		}
+	}
+}
+
+func (l *History) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	if l.editingNote {
+		return l.updateNote(h, msg)
+	}
+	if h.screenState() == StateBookmarks {
+		return l.updateBookmarks(h, msg)
+	}
+
+	store := h.store()
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		if l.confirmingClear {
+			l.confirmingClear = false
+			return nil
+		}
+		h.navigate(StateRequestBuilder)
+		return nil
+
+	case "up", "k":
+		if l.selected > 0 {
+			l.selected--
+		}
+		return nil
+
+	case "down", "j":
+		if l.selected < len(l.entries)-1 {
+			l.selected++
+		}
+		return nil
+
+	case "enter":
+		if exec, ok := l.selectedEntry(); ok {
+			h.loadExecution(exec)
+		}
+		return nil
+
+	case "b":
+		if h.blockedByReadOnly("bookmark history item") {
+			return nil
+		}
+		if exec, ok := l.selectedEntry(); ok {
+			l.toggleBookmark(h, exec)
+		}
+		return nil
+
+	case "n":
+		if h.blockedByReadOnly("edit bookmark note") {
+			return nil
+		}
+		if exec, ok := l.selectedEntry(); ok {
+			l.startNote(exec)
+		}
+		return nil
+
+	case "B":
+		l.openBookmarks(h)
+		return nil
+
+	case "m":
+		if exec, ok := l.selectedEntry(); ok {
+			l.toggleMark(exec.ID)
+			l.diffErr = ""
+		}
+		return nil
+
+	case "e":
+		l.envOnly = !l.envOnly
+		l.refresh(h)
+		l.selected = 0
+		return nil
+
+	case "d":
+		if h.blockedByReadOnly("delete history item") {
+			return nil
+		}
+		if exec, ok := l.selectedEntry(); ok && store != nil {
+			h.reportStorageError("failed to delete history item", store.DeleteHistoryItem(exec.ID))
+			l.refresh(h)
+			if l.selected >= len(l.entries) && l.selected > 0 {
+				l.selected--
+			}
+		}
+		return nil
+
+	case "s":
+		if h.blockedByReadOnly("save request") {
+			return nil
+		}
+		if exec, ok := l.selectedEntry(); ok && store != nil && h.saveExecution(exec) {
+			l.saveSuccess = true
+			l.saveSuccessTimer = 3
+		}
+		return nil
+
+	case "c":
+		if h.blockedByReadOnly("clear history") {
+			return nil
+		}
+		if len(l.entries) > 0 {
+			l.confirmingClear = true
+		}
+		return nil
+
+	case "y":
+		if l.confirmingClear && store != nil {
+			h.reportStorageError("failed to clear history", store.ClearHistory())
+			l.refresh(h)
+			l.selected = 0
+			l.confirmingClear = false
+		}
+		return nil
+	}
+
+	return nil
+}
+
+func (l *History) View(h host) string {
+	width, height := h.size()
+	if h.screenState() == StateBookmarks {
+		return Center(width, height, l.viewBookmarks(h))
+	}
+	return Center(width, height, l.viewList(h))
+}
+
+func (l *History) viewList(h host) string {
+	var b strings.Builder
+	_, height := h.size()
+
+	title := i18n.Tf("title.history", len(l.entries))
+	if l.envOnly {
+		title += i18n.Tf("history.env_scope", historyScopeLabel(historyEnvironment(h)))
+	}
+	b.WriteString(TitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	if len(l.entries) == 0 {
+		b.WriteString(MutedStyle.Render("No request history"))
+		b.WriteString("\n\n")
+		b.WriteString(TextStyle.Render("Execute some requests to see them here"))
+	} else {
+		maxItems := (height - 15) / h.listItemLines()
+		start := l.selected
+		if start > len(l.entries)-maxItems {
+			start = len(l.entries) - maxItems
+		}
+		if start < 0 {
+			start = 0
+		}
+		end := start + maxItems
+		if end > len(l.entries) {
+			end = len(l.entries)
+		}
+
+		for i := start; i < end; i++ {
+			exec := l.entries[i]
+			status := RenderStatusPill(0, "ERROR")
+			if exec.Error == "" {
+				status = RenderStatusPill(exec.StatusCode, exec.Status)
+			}
+
+			prefix := exec.Timestamp.Format("15:04:05")
+			if exec.Bookmarked {
+				prefix = "★ " + prefix
+			}
+			if l.marked(exec.ID) {
+				prefix = "◆ " + prefix
+			}
+			url := exec.URL
+			if !l.envOnly && exec.Environment != "" {
+				url += " [" + exec.Environment + "]"
+			}
+
+			timing := fmt.Sprintf("%dms", exec.ResponseTime)
+			if exec.OverBudget() {
+				timing = WarningStyle.Render(fmt.Sprintf("⚠ %dms (budget %dms)", exec.ResponseTime, exec.BudgetMs))
+			}
+
+			style := ListItemStyle
+			if i == l.selected {
+				style = ListItemSelectedStyle
+				prefix = "> " + prefix
+			}
+			b.WriteString(style.Render(prefix))
+			b.WriteString("  ")
+			b.WriteString(RenderMethodBadge(exec.Method))
+			b.WriteString(" ")
+			b.WriteString(style.UnsetPadding().Render(url))
+			if h.detailedLists() {
+				b.WriteString("\n    ")
+			} else {
+				b.WriteString("  ")
+			}
+			b.WriteString(status + MutedStyle.Render(" • "+timing))
+			if exec.Note != "" {
+				b.WriteString(MutedStyle.Render(" • " + exec.Note))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+
+	b.WriteString(l.viewNoteInput())
+
+	if l.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + l.err))
+		b.WriteString("\n\n")
+	}
+
+	if l.diffErr != "" {
+		b.WriteString(WarningStyle.Render(l.diffErr))
+		b.WriteString("\n\n")
+	} else if len(l.marks) > 0 {
+		b.WriteString(MutedStyle.Render(i18n.Tf("history_diff.marked", len(l.marks))))
+		b.WriteString("\n\n")
+	}
+
+	if l.saveSuccess {
+		b.WriteString(SuccessStyle.Render("✓ Saved as request!"))
+		b.WriteString("\n\n")
+	}
+
+	if l.confirmingClear {
+		b.WriteString(WarningStyle.Render(i18n.T("confirm.clear_history")))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(RenderFooter(i18n.T("footer.history")))
+
+	return b.String()
+}
@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// HistoryDiff is the screen comparing the responses of two history entries,
+// the older one first
+type HistoryDiff struct {
+	old, new storage.RequestExecution
+	result   *httpclient.DiffResult
+	scroll   int
+}
+
+// toggleMark marks an entry for comparison, or unmarks it. Only two entries
+// are marked at a time; marking a third drops the oldest mark.
+func (l *History) toggleMark(id string) {
+	for i, marked := range l.marks {
+		if marked == id {
+			l.marks = append(l.marks[:i:i], l.marks[i+1:]...)
+			return
+		}
+	}
+	l.marks = append(l.marks, id)
+	if len(l.marks) > 2 {
+		l.marks = l.marks[len(l.marks)-2:]
+	}
+}
+
+func (l *History) marked(id string) bool {
+	for _, marked := range l.marks {
+		if marked == id {
+			return true
+		}
+	}
+	return false
+}
+
+// diffPair returns the entries to compare: the two marked ones, or the
+// marked one and the selected one
+func (l *History) diffPair() (storage.RequestExecution, storage.RequestExecution, bool) {
+	var marked []storage.RequestExecution
+	for _, exec := range l.entries {
+		if l.marked(exec.ID) {
+			marked = append(marked, exec)
+		}
+	}
+	if len(marked) == 1 && l.selected < len(l.entries) {
+		if selected := l.entries[l.selected]; selected.ID != marked[0].ID {
+			marked = append(marked, selected)
+		}
+	}
+	if len(marked) != 2 {
+		return storage.RequestExecution{}, storage.RequestExecution{}, false
+	}
+	return marked[0], marked[1], true
+}
+
+// executionResponse rebuilds the response recorded with a history entry.
+// History keeps no response headers, so only status, body and time compare.
+func executionResponse(exec storage.RequestExecution) httpclient.Response {
+	return httpclient.Response{
+		StatusCode:   exec.StatusCode,
+		Status:       exec.Status,
+		Body:         exec.ResponseBody,
+		ResponseTime: time.Duration(exec.ResponseTime) * time.Millisecond,
+	}
+}
+
+// open compares two entries, the older one as the base
+func (d *HistoryDiff) open(h host, a, b storage.RequestExecution) {
+	if b.Timestamp.Before(a.Timestamp) {
+		a, b = b, a
+	}
+	d.old = a
+	d.new = b
+	d.result = httpclient.CompareResponses(executionResponse(a), executionResponse(b), h.diffIgnoreRules())
+	d.scroll = 0
+	h.navigate(StateHistoryDiff)
+}
+
+func (d *HistoryDiff) lines() []string {
+	if !d.result.HasDifferences() {
+		return []string{SuccessStyle.Render(i18n.T("history_diff.identical"))}
+	}
+	report := strings.TrimRight(httpclient.FormatDiff(d.result), "\n")
+	return strings.Split(HighlightDiff(report), "\n")
+}
+
+func (d *HistoryDiff) pageSize(h host) int {
+	_, height := h.size()
+	return max(height-16, 5)
+}
+
+func (d *HistoryDiff) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	maxScroll := max(len(d.lines())-d.pageSize(h), 0)
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc", "q":
+		h.navigate(StateHistory)
+		return nil
+
+	case "up", "k":
+		d.scroll = max(d.scroll-1, 0)
+		return nil
+
+	case "down", "j":
+		d.scroll = min(d.scroll+1, maxScroll)
+		return nil
+
+	case "pgup":
+		d.scroll = max(d.scroll-d.pageSize(h), 0)
+		return nil
+
+	case "pgdown", " ":
+		d.scroll = min(d.scroll+d.pageSize(h), maxScroll)
+		return nil
+	}
+
+	return nil
+}
+
+// historyDiffLabel describes one side of the comparison
+func historyDiffLabel(side string, exec storage.RequestExecution) string {
+	status := exec.Status
+	if exec.Error != "" {
+		status = "ERROR"
+	}
+	return fmt.Sprintf("%s  %s  %s %s • %s • %dms", side, exec.Timestamp.Format("2006-01-02 15:04:05"), exec.Method, exec.URL, status, exec.ResponseTime)
+}
+
+func (d *HistoryDiff) View(h host) string {
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.history_diff")))
+	b.WriteString("\n\n")
+	b.WriteString(MutedStyle.Render(historyDiffLabel("-", d.old)))
+	b.WriteString("\n")
+	b.WriteString(TextStyle.Render(historyDiffLabel("+", d.new)))
+	b.WriteString("\n")
+	if d.old.URL != d.new.URL || d.old.Method != d.new.Method {
+		b.WriteString(WarningStyle.Render(i18n.T("history_diff.different_requests")))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	lines := d.lines()
+	start := min(d.scroll, len(lines))
+	end := min(start+d.pageSize(h), len(lines))
+	b.WriteString(strings.Join(lines[start:end], "\n"))
+	b.WriteString("\n")
+	if len(lines) > d.pageSize(h) {
+		b.WriteString(MutedStyle.Render(fmt.Sprintf("[%d-%d/%d]", start+1, end, len(lines))))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(RenderFooter(i18n.T("footer.history_diff")))
+
+	width, height := h.size()
+	return Center(width, height, b.String())
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/storage"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func historyDiffModel() Model {
+	now := time.Now()
+	return Model{
+		state:  StateHistory,
+		width:  120,
+		height: 40,
+		history: History{entries: []storage.RequestExecution{
+			{ID: "new", Timestamp: now, Method: "GET", URL: "https://api.example.com/users/1", StatusCode: 500, Status: "500 Internal Server Error", ResponseBody: `{"name": "Alice", "role": "admin"}`, ResponseTime: 300},
+			{ID: "old", Timestamp: now.Add(-time.Hour), Method: "GET", URL: "https://api.example.com/users/1", StatusCode: 200, Status: "200 OK", ResponseBody: `{"name": "Alice"}`, ResponseTime: 100},
+			{ID: "other", Timestamp: now.Add(-2 * time.Hour), Method: "GET", URL: "https://api.example.com/users/2", StatusCode: 200, Status: "200 OK", ResponseBody: `{"name": "Bob"}`, ResponseTime: 100},
+		}},
+	}
+}
+
+func TestHistoryDiff(t *testing.T) {
+	m := historyDiffModel()
+	press := func(key string) {
+		msg, _ := tuitest.ParseKey(key)
+		updated, _ := m.handleKeyPress(msg)
+		m = updated.(Model)
+	}
+
+	press("D")
+	if m.state != StateHistory || m.history.diffErr == "" {
+		t.Fatal("Expected D without marks to ask for two entries")
+	}
+
+	// One mark compares with the selected entry, the older as the base
+	press("m")
+	press("down")
+	press("D")
+	if m.state != StateHistoryDiff || m.historyDiff.old.ID != "old" || m.historyDiff.new.ID != "new" {
+		t.Fatalf("Expected old→new diff, got state %v %+v", m.state, m.historyDiff)
+	}
+
+	view := m.historyDiff.View(&m)
+	for _, want := range []string{"Status Code: 200 -> 500", "role", "100ms -> 300ms"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Diff view does not contain %q:\n%s", want, view)
+		}
+	}
+
+	updated, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.state != StateHistory {
+		t.Errorf("Expected Esc to go back to history, got %v", m.state)
+	}
+}
+
+func TestHistoryMarks(t *testing.T) {
+	m := historyDiffModel()
+	m.history.toggleMark("new")
+	m.history.toggleMark("old")
+	m.history.toggleMark("other")
+	if len(m.history.marks) != 2 || m.history.marked("new") {
+		t.Errorf("Expected a third mark to drop the oldest, got %v", m.history.marks)
+	}
+	m.history.toggleMark("old")
+	if m.history.marked("old") || !m.history.marked("other") {
+		t.Errorf("Expected marking again to unmark, got %v", m.history.marks)
+	}
+}
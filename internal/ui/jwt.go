@@ -0,0 +1,285 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// jwtHintScanLimit bounds how much of a response body is searched for a
+// token on every redraw of the response view. Opening the decoder
+// searches all of it.
+const jwtHintScanLimit = 64 << 10
+
+// jwtTimeClaims are the NumericDate claims shown as times
+var jwtTimeClaims = []string{"iat", "nbf", "exp"}
+
+// foundJWT is a token and where it was found
+type foundJWT struct {
+	source string
+	token  httpclient.JWT
+}
+
+// appendJWTs adds the tokens in text to found, skipping ones already there
+func appendJWTs(found []foundJWT, source, text string) []foundJWT {
+next:
+	for _, token := range httpclient.FindJWTs(text) {
+		for _, f := range found {
+			if f.token.Raw == token.Raw {
+				continue next
+			}
+		}
+		found = append(found, foundJWT{source: source, token: token})
+	}
+	return found
+}
+
+// requestJWTs finds the tokens in the headers of the request being built,
+// including the Authorization header the auth panel adds
+func (m Model) requestJWTs() []foundJWT {
+	headers := m.buildRequest().Headers
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var found []foundJWT
+	for _, key := range keys {
+		found = appendJWTs(found, i18n.Tf("jwt.in_header", key), headers[key])
+	}
+	return found
+}
+
+// responseJWTs finds the tokens in the headers and body of the response,
+// searching at most limit bytes of the body when limit is positive
+func (v *ResponseViewer) responseJWTs(limit int) []foundJWT {
+	if v.response == nil || v.response.Error != nil {
+		return nil
+	}
+	keys := make([]string, 0, len(v.response.Headers))
+	for key := range v.response.Headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var found []foundJWT
+	for _, key := range keys {
+		found = appendJWTs(found, i18n.Tf("jwt.in_header", key), strings.Join(v.response.Headers[key], "\n"))
+	}
+	body := v.response.Body
+	if limit > 0 && len(body) > limit {
+		body = body[:limit]
+	}
+	return appendJWTs(found, i18n.T("jwt.in_body"), body)
+}
+
+// viewJWTHint points at the tokens in the response, and warns when one
+// has expired
+func (v *ResponseViewer) viewJWTHint() string {
+	found := v.responseJWTs(jwtHintScanLimit)
+	if len(found) == 0 {
+		return ""
+	}
+	now := time.Now()
+	for _, f := range found {
+		if f.token.Expired(now) {
+			return WarningStyle.Render(i18n.Tf("jwt.hint_expired", len(found))) + "\n\n"
+		}
+	}
+	return MutedStyle.Render(i18n.Tf("jwt.hint", len(found))) + "\n\n"
+}
+
+// JWTDecoder is the JWT screen: the header and claims of the tokens found
+// in a request or response, with a live countdown to expiry
+type JWTDecoder struct {
+	tokens   []foundJWT
+	selected int
+	// empty says where no token was found
+	empty  string
+	back   AppState
+	scroll int
+
+	// generation tells the ticks of the latest opening from earlier ones
+	generation int
+}
+
+type jwtTickMsg struct {
+	generation int
+}
+
+func jwtTickCmd(generation int) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return jwtTickMsg{generation: generation}
+	})
+}
+
+// open shows tokens, or empty when there are none, and returns to back
+// on Esc
+func (j *JWTDecoder) open(h host, tokens []foundJWT, empty string, back AppState) tea.Cmd {
+	j.tokens = tokens
+	j.selected = 0
+	j.scroll = 0
+	j.empty = empty
+	j.back = back
+	j.generation++
+	h.navigate(StateJWT)
+	return jwtTickCmd(j.generation)
+}
+
+// tick redraws the countdown every second while the screen is shown
+func (j *JWTDecoder) tick(h host, msg jwtTickMsg) tea.Cmd {
+	if msg.generation != j.generation || h.screenState() != StateJWT {
+		return nil
+	}
+	return jwtTickCmd(j.generation)
+}
+
+func (j *JWTDecoder) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc", "q":
+		h.navigate(j.back)
+
+	case "tab", "right", "l":
+		if len(j.tokens) > 0 {
+			j.selected = (j.selected + 1) % len(j.tokens)
+			j.scroll = 0
+		}
+
+	case "shift+tab", "left", "h":
+		if len(j.tokens) > 0 {
+			j.selected = (j.selected + len(j.tokens) - 1) % len(j.tokens)
+			j.scroll = 0
+		}
+
+	case "up", "k":
+		if j.scroll > 0 {
+			j.scroll--
+		}
+
+	case "down", "j":
+		if j.scroll < j.maxScroll(h) {
+			j.scroll++
+		}
+	}
+	return nil
+}
+
+// visibleJWTLines is how many lines of the decoded parts fit on screen
+func visibleJWTLines(height int) int {
+	return max(height-18, 5)
+}
+
+// maxScroll is how far the decoded parts of the selected token scroll
+func (j *JWTDecoder) maxScroll(h host) int {
+	if len(j.tokens) == 0 {
+		return 0
+	}
+	_, height := h.size()
+	lines := strings.Count(viewJWTParts(j.tokens[j.selected].token, time.Now()), "\n") + 1
+	return max(lines-visibleJWTLines(height), 0)
+}
+
+func (j *JWTDecoder) View(h host) string {
+	width, height := h.size()
+	var b strings.Builder
+
+	if len(j.tokens) == 0 {
+		b.WriteString(TitleStyle.Render(i18n.T("title.jwt")))
+		b.WriteString("\n\n")
+		b.WriteString(MutedStyle.Render(j.empty))
+		b.WriteString("\n\n")
+		b.WriteString(RenderFooter(i18n.T("footer.jwt")))
+		return Center(width, height, b.String())
+	}
+
+	found := j.tokens[j.selected]
+	title := i18n.T("title.jwt")
+	if len(j.tokens) > 1 {
+		title += i18n.Tf("jwt.position", j.selected+1, len(j.tokens))
+	}
+	b.WriteString(TitleStyle.Render(title))
+	b.WriteString("\n")
+	b.WriteString(MutedStyle.Render(found.source))
+	b.WriteString("\n\n")
+	b.WriteString(viewJWTStatus(found.token, time.Now()))
+	b.WriteString("\n")
+
+	// The decoded parts scroll under the fixed status lines
+	lines := strings.Split(viewJWTParts(found.token, time.Now()), "\n")
+	start := min(j.scroll, len(lines)-1)
+	end := min(start+visibleJWTLines(height), len(lines))
+	b.WriteString(strings.Join(lines[start:end], "\n"))
+	b.WriteString("\n\n")
+
+	b.WriteString(RenderFooter(i18n.T("footer.jwt")))
+	return Center(width, height, b.String())
+}
+
+// viewJWTStatus tells whether the token is usable at now: expiry,
+// not-before and signature algorithm
+func viewJWTStatus(token httpclient.JWT, now time.Time) string {
+	var b strings.Builder
+
+	if exp, ok := token.Time("exp"); !ok {
+		b.WriteString(MutedStyle.Render(i18n.T("jwt.no_exp")))
+	} else if token.Expired(now) {
+		b.WriteString(ErrorStyle.Render(i18n.Tf("jwt.expired", now.Sub(exp).Round(time.Second), formatJWTTime(exp))))
+	} else {
+		b.WriteString(SuccessStyle.Render(i18n.Tf("jwt.expires_in", exp.Sub(now).Round(time.Second), formatJWTTime(exp))))
+	}
+	b.WriteString("\n")
+
+	if nbf, ok := token.Time("nbf"); ok && nbf.After(now) {
+		b.WriteString(WarningStyle.Render(i18n.Tf("jwt.not_yet_valid", nbf.Sub(now).Round(time.Second))))
+		b.WriteString("\n")
+	}
+
+	if token.Unsigned() {
+		b.WriteString(WarningStyle.Render(i18n.T("jwt.unsigned")))
+	} else {
+		b.WriteString(TextStyle.Render(i18n.Tf("jwt.signature", token.Alg, token.SignatureSize)))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// viewJWTParts shows the decoded header and claims, followed by the time
+// claims in local time
+func viewJWTParts(token httpclient.JWT, now time.Time) string {
+	var b strings.Builder
+
+	b.WriteString(HeaderStyle.Render(i18n.T("jwt.header")))
+	b.WriteString("\n")
+	b.WriteString(TextStyle.Render(token.HeaderJSON()))
+	b.WriteString("\n\n")
+	b.WriteString(HeaderStyle.Render(i18n.T("jwt.claims")))
+	b.WriteString("\n")
+	b.WriteString(TextStyle.Render(token.ClaimsJSON()))
+
+	for _, claim := range jwtTimeClaims {
+		at, ok := token.Time(claim)
+		if !ok {
+			continue
+		}
+		relative := i18n.Tf("jwt.ago", now.Sub(at).Round(time.Second))
+		if at.After(now) {
+			relative = i18n.Tf("jwt.from_now", at.Sub(now).Round(time.Second))
+		}
+		b.WriteString("\n")
+		b.WriteString(MutedStyle.Render(padRightWidth(claim, 4) + formatJWTTime(at) + " (" + relative + ")"))
+	}
+	return b.String()
+}
+
+func formatJWTTime(t time.Time) string {
+	return t.Local().Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func testJWT(sub string, exp time.Time) string {
+	enc := base64.RawURLEncoding
+	header := enc.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload := enc.EncodeToString([]byte(fmt.Sprintf(`{"sub":%q,"exp":%d}`, sub, exp.Unix())))
+	return header + "." + payload + "." + enc.EncodeToString([]byte("signature"))
+}
+
+func TestFlowJWTDecoder(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	expired := testJWT("refresh", time.Now().Add(-time.Hour))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"token":"` + expired + `"}`))
+	}))
+	defer server.Close()
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL).Press("tab", "J").AssertView("JWT Decoder", "No JWT in the request headers")
+
+	d.Press("esc", "A", "right", "tab").Type(testJWT("alice", time.Now().Add(time.Hour))).Press("enter", "esc", "J")
+	d.AssertView("Found in the Authorization header", "✓ Expires in ", "Signature: HS256, 9 bytes (not verified)", `"sub": "alice"`)
+
+	d.Press("esc", "shift+tab", "enter").WaitFor("⚠ Expired JWT in the response (1 found)")
+	d.Press("J").AssertView("Found in the response body", "⚠ Expired 1h0m", `"sub": "refresh"`)
+	d.Press("esc").AssertView("⚠ Expired JWT in the response")
+}
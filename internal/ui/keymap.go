@@ -53,6 +53,7 @@ type KeyMap struct {
 	ConnectDB      key.Binding
 	ShowSchema     key.Binding
 	QueryHistory   key.Binding
+	ToggleChart    key.Binding
 
 	// List navigation
 	SelectItem     key.Binding
@@ -216,6 +217,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("ctrl+h"),
 			key.WithHelp("ctrl+h", "query history"),
 		),
+		ToggleChart: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "toggle chart"),
+		),
 
 		// List navigation
 		SelectItem: key.NewBinding(
@@ -289,7 +294,7 @@ func (k KeyMap) StateSpecificKeys(state AppState) []key.Binding {
 	case StateDatabaseResult:
 		return append(common, []key.Binding{
 			k.Left, k.Right, k.VimLeft, k.VimRight,
-			k.SaveQuery, k.ExportResults,
+			k.SaveQuery, k.ExportResults, k.ToggleChart,
 		}...)
 
 	case StateDatabaseQueryList:
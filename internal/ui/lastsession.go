@@ -0,0 +1,233 @@
+package ui
+
+import (
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// sessionScreens maps the screens a restored session can reopen to the
+// screen it reopens: a response or an editor of the request reopens the
+// request builder, query results the SQL editor
+var sessionScreens = map[AppState]AppState{
+	StateHome:                 StateHome,
+	StateRequestBuilder:       StateRequestBuilder,
+	StateLoading:              StateRequestBuilder,
+	StateViewResponse:         StateRequestBuilder,
+	StateHeaderEditor:         StateRequestBuilder,
+	StateBodyEditor:           StateRequestBuilder,
+	StateQueryEditor:          StateRequestBuilder,
+	StateRequestList:          StateRequestList,
+	StateHistory:              StateHistory,
+	StateDatabase:             StateDatabase,
+	StateDatabaseConnect:      StateDatabase,
+	StateDatabaseQueryEditor:  StateDatabaseQueryEditor,
+	StateDatabaseResult:       StateDatabaseQueryEditor,
+	StateDatabaseExport:       StateDatabaseQueryEditor,
+	StateDatabaseQueryList:    StateDatabaseQueryList,
+	StateDatabaseSchema:       StateDatabaseSchema,
+	StateDatabaseQueryHistory: StateDatabaseQueryHistory,
+}
+
+// dbRestore is the database screen a restored session reopens once the
+// connection is made again
+type dbRestore struct {
+	screen AppState
+	table  string
+}
+
+// SetRestoreSession turns on saving where the user left off on exit, and
+// reopens the session saved on the last exit
+func (m *Model) SetRestoreSession(enabled bool) {
+	m.restoreSession = enabled
+	if !enabled || m.storage == nil {
+		return
+	}
+	session, found, err := m.storage.LoadLastSession()
+	if err != nil {
+		m.reportStorageError("failed to restore the last session", err)
+		return
+	}
+	if found {
+		m.restoreLastSession(session)
+	}
+}
+
+// SaveSession saves where the user left off for the next start. Nothing is
+// saved unless restoring sessions is on, nor in read-only mode.
+func (m Model) SaveSession() error {
+	if !m.restoreSession || m.readOnly || m.storage == nil {
+		return nil
+	}
+	return m.storage.SaveLastSession(m.lastSession())
+}
+
+// sessionScreen returns the screen a restored session reopens: the
+// current screen, else the closest screen on the trail that can be reopened
+func (m Model) sessionScreen() AppState {
+	if screen, ok := sessionScreens[m.state]; ok {
+		return screen
+	}
+	for i := len(m.trail) - 1; i >= 0; i-- {
+		if screen, ok := sessionScreens[m.trail[i]]; ok {
+			return screen
+		}
+	}
+	return StateHome
+}
+
+// screenName is the name a screen is saved under, the key of its name in
+// the breadcrumb without the prefix
+func screenName(state AppState) string {
+	return strings.TrimPrefix(stateNames[state], "nav.")
+}
+
+// parseScreenName returns the screen saved under name
+func parseScreenName(name string) (AppState, bool) {
+	for state := range sessionScreens {
+		if screenName(state) == name {
+			return state, true
+		}
+	}
+	return StateHome, false
+}
+
+// lastSession captures the screen, the request being edited, the selected
+// items and the state of the database screens
+func (m Model) lastSession() storage.LastSession {
+	session := storage.LastSession{
+		Screen:      screenName(m.sessionScreen()),
+		Method:      m.builder.method,
+		URL:         m.builder.urlInput.Value(),
+		Headers:     m.builder.headers,
+		Body:        m.builder.body,
+		QueryParams: m.builder.queryParams,
+	}
+
+	requests := m.requests.saved
+	if m.requests.filtered != nil {
+		requests = m.requests.filtered
+	}
+	if m.requests.selected < len(requests) {
+		session.SavedRequestID = requests[m.requests.selected].ID
+	}
+	if exec, ok := m.history.selectedEntry(); ok {
+		session.HistoryID = exec.ID
+	}
+
+	if db := strings.TrimSpace(m.db.nameInput.Value()); db != "" || m.db.editor.Value() != "" {
+		port, _ := strconv.Atoi(strings.TrimSpace(m.db.portInput.Value()))
+		session.Database = &storage.SessionDatabase{
+			Host:     strings.TrimSpace(m.db.hostInput.Value()),
+			Port:     port,
+			Database: db,
+			User:     strings.TrimSpace(m.db.userInput.Value()),
+			Query:    m.db.editor.Value(),
+		}
+		if m.db.selectedTable < len(m.db.tables) {
+			session.Database.Table = m.db.tables[m.db.selectedTable]
+		}
+	}
+	return session
+}
+
+// restoreLastSession reopens a saved session. The database screens need
+// the connection again, so they open the connect form with everything but
+// the password filled in, and the saved screen follows once connected.
+func (m *Model) restoreLastSession(session storage.LastSession) {
+	if session.Method != "" {
+		m.builder.method = session.Method
+	}
+	m.builder.urlInput.SetValue(session.URL)
+	m.builder.urlInput.CursorEnd()
+	if session.Headers != nil {
+		m.builder.headers = maps.Clone(session.Headers)
+	}
+	m.builder.body = session.Body
+	if session.QueryParams != nil {
+		m.builder.queryParams = maps.Clone(session.QueryParams)
+	}
+
+	if db := session.Database; db != nil {
+		m.db.hostInput.SetValue(db.Host)
+		if db.Port > 0 {
+			m.db.portInput.SetValue(strconv.Itoa(db.Port))
+		}
+		m.db.nameInput.SetValue(db.Database)
+		m.db.userInput.SetValue(db.User)
+		m.db.editor.SetValue(db.Query)
+	}
+
+	screen, _ := parseScreenName(session.Screen)
+	switch screen {
+	case StateRequestBuilder:
+		m.state = StateRequestBuilder
+		m.trail = []AppState{StateHome}
+		m.builder.urlInput.Focus()
+
+	case StateRequestList:
+		m.state = StateRequestList
+		m.trail = []AppState{StateHome, StateRequestBuilder}
+		for i, req := range m.requests.saved {
+			if req.ID == session.SavedRequestID {
+				m.requests.selected = i
+			}
+		}
+
+	case StateHistory:
+		m.state = StateHistory
+		m.trail = []AppState{StateHome, StateRequestBuilder}
+		m.history.refresh(m)
+		for i, exec := range m.history.entries {
+			if exec.ID == session.HistoryID {
+				m.history.selected = i
+			}
+		}
+
+	case StateDatabase:
+		m.state = StateDatabase
+		m.trail = []AppState{StateHome}
+
+	case StateDatabaseQueryEditor, StateDatabaseQueryList, StateDatabaseSchema, StateDatabaseQueryHistory:
+		m.state = StateDatabase
+		m.trail = []AppState{StateHome}
+		if session.Database == nil || session.Database.Database == "" {
+			return
+		}
+		m.db.restore = &dbRestore{screen: screen, table: session.Database.Table}
+		m.state = StateDatabaseConnect
+		m.trail = []AppState{StateHome, StateDatabase}
+		m.db.connectFocus = 4
+		m.db.updateConnectFocus()
+	}
+}
+
+// finishDatabaseRestore opens the database screen of a restored session
+// once the schema of the new connection has loaded
+func (m *Model) finishDatabaseRestore() {
+	restore := m.db.restore
+	m.db.restore = nil
+	for i, table := range m.db.tables {
+		if table == restore.table {
+			m.db.selectedTable = i
+		}
+	}
+
+	m.trail = []AppState{StateHome, StateDatabase}
+	switch restore.screen {
+	case StateDatabaseQueryEditor:
+		m.state = StateDatabaseQueryEditor
+		m.db.editor.Focus()
+	case StateDatabaseQueryList:
+		m.state = StateDatabaseQueryList
+		m.db.selectedQuery = 0
+	case StateDatabaseQueryHistory:
+		if m.db.storage != nil {
+			m.db.history = m.db.storage.GetQueryHistory()
+		}
+		m.state = StateDatabaseQueryHistory
+		m.db.selectedHistory = 0
+	}
+}
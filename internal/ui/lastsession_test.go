@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+)
+
+func TestRestoreRequestBuilderSession(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+
+	m := *NewModel()
+	m.SetRestoreSession(true)
+	m.builder.method = "PATCH"
+	m.builder.urlInput.SetValue("https://api.example.com/users/1")
+	m.builder.body = `{"name": "Ana"}`
+	// Help is not reopened; the screen it was opened from is
+	m.trail = []AppState{StateHome, StateRequestBuilder}
+	m.state = StateHelp
+	if err := m.SaveSession(); err != nil {
+		t.Fatalf("SaveSession() error = %v", err)
+	}
+
+	restored := *NewModel()
+	restored.SetRestoreSession(true)
+	if restored.state != StateRequestBuilder || restored.builder.method != "PATCH" ||
+		restored.builder.urlInput.Value() != "https://api.example.com/users/1" || restored.builder.body != `{"name": "Ana"}` {
+		t.Errorf("Expected the request builder with the draft, got state %v %s %q %q",
+			restored.state, restored.builder.method, restored.builder.urlInput.Value(), restored.builder.body)
+	}
+
+	fresh := *NewModel()
+	fresh.SetRestoreSession(false)
+	if fresh.state != StateHome || fresh.builder.urlInput.Value() != "" {
+		t.Errorf("Expected nothing restored when turned off, got state %v", fresh.state)
+	}
+}
+
+func TestRestoreDatabaseSession(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+
+	m := *NewModel()
+	m.SetRestoreSession(true)
+	m.db.nameInput.SetValue("shop")
+	m.db.userInput.SetValue("app")
+	m.db.editor.SetValue("SELECT * FROM orders")
+	m.db.tables = []string{"customers", "orders"}
+	m.db.selectedTable = 1
+	m.state = StateDatabaseResult
+	if err := m.SaveSession(); err != nil {
+		t.Fatalf("SaveSession() error = %v", err)
+	}
+
+	restored := *NewModel()
+	restored.SetRestoreSession(true)
+	if restored.state != StateDatabaseConnect || restored.db.connectFocus != 4 ||
+		restored.db.nameInput.Value() != "shop" || restored.db.userInput.Value() != "app" {
+		t.Fatalf("Expected the connect form waiting for the password, got state %v", restored.state)
+	}
+
+	updated, _ := restored.Update(databaseSchemaMsg{"customers", "orders"})
+	restored = updated.(Model)
+	if restored.state != StateDatabaseQueryEditor || restored.db.editor.Value() != "SELECT * FROM orders" {
+		t.Errorf("Expected the SQL editor with its query once connected, got state %v %q",
+			restored.state, restored.db.editor.Value())
+	}
+	if restored.db.selectedTable != 1 {
+		t.Errorf("Expected the orders table selected again, got %d", restored.db.selectedTable)
+	}
+}
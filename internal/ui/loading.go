@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// What the loading screen can wait for besides a transfer
+const (
+	opRequest = iota
+	opQuery
+	opSchema
+)
+
+// operation is what the loading screen waits for: a request, a query or
+// the schema of a new connection. It can be canceled with Esc, and a
+// request counts the bytes of its response as they arrive.
+type operation struct {
+	kind     int
+	start    time.Time
+	cancel   context.CancelFunc
+	canceled bool
+	received atomic.Int64
+	total    atomic.Int64
+}
+
+// startOperation begins an operation of kind for the loading screen to
+// follow and returns the context that cancels it
+func (m *Model) startOperation(kind int) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	op := &operation{kind: kind, start: time.Now(), cancel: cancel}
+	op.total.Store(-1)
+	m.operation = op
+	return ctx
+}
+
+func (op *operation) receive(done, total int64) {
+	op.total.Store(total)
+	op.received.Store(done)
+}
+
+// finishOperation releases the operation once its result arrived, and
+// reports whether it was canceled
+func (m *Model) finishOperation() (canceled bool) {
+	if m.operation == nil {
+		return false
+	}
+	canceled = m.operation.canceled
+	m.operation.cancel()
+	m.operation = nil
+	return canceled
+}
+
+// cancelLoading stops what the loading screen waits for. Its result still
+// arrives, with the error of the cancellation, and closes the screen.
+func (m *Model) cancelLoading() {
+	if m.transfer != nil && !m.transfer.canceled {
+		m.transfer.canceled = true
+		m.transfer.cancel()
+	} else if m.operation != nil && !m.operation.canceled {
+		m.operation.canceled = true
+		m.operation.cancel()
+	}
+}
+
+// viewOperation shows how long the operation has run, the bytes of the
+// response received so far and how to cancel it
+func (m Model) viewOperation() string {
+	op := m.operation
+	if op == nil {
+		return ""
+	}
+	var b strings.Builder
+
+	status := i18n.Tf("loading.elapsed", time.Since(op.start).Round(100*time.Millisecond))
+	if done, total := op.received.Load(), op.total.Load(); done > 0 && total >= 0 {
+		status += " • " + i18n.Tf("loading.received_of", httpclient.FormatSize(done), httpclient.FormatSize(total))
+	} else if done > 0 {
+		status += " • " + i18n.Tf("loading.received", httpclient.FormatSize(done))
+	}
+	b.WriteString(MutedStyle.Render(status))
+	b.WriteString("\n\n")
+
+	if op.canceled {
+		b.WriteString(WarningStyle.Render(i18n.T("loading.canceling")))
+		b.WriteString("\n\n")
+	}
+	b.WriteString(RenderFooter(i18n.T("footer.loading")))
+	return b.String()
+}
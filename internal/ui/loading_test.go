@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abneribeiro/godev/internal/database"
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowLoadingShowsProgressAndCancels(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+
+	// Half the body is sent, then the server stalls until canceled
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "4096")
+		w.Write([]byte(strings.Repeat("x", 2048)))
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL).Press("enter")
+	d.WaitFor("Sending Request", "Elapsed:", "Received: 2.00 KB of 4.00 KB", "Esc: cancel")
+
+	d.Press("esc").WaitFor("request canceled")
+	if m := d.Model().(Model); m.operation != nil || m.state != StateViewResponse {
+		t.Errorf("Expected the canceled request to end in the response view, got state %v", m.state)
+	}
+}
+
+func TestCancelLoadingWithoutOperation(t *testing.T) {
+	m := *NewModel()
+	m.cancelLoading()
+	if m.finishOperation() {
+		t.Error("Expected nothing to be canceled without an operation")
+	}
+}
+
+func TestCanceledQueryEndsInResult(t *testing.T) {
+	m := *NewModel()
+	m.state = StateLoading
+	m.trail = []AppState{StateHome, StateDatabase, StateDatabaseQueryEditor}
+	m.startOperation(opQuery)
+	m.cancelLoading()
+
+	updated, _ := m.Update(databaseResultMsg(database.QueryResult{Error: context.Canceled}))
+	got := updated.(Model)
+	if got.state != StateDatabaseResult || got.db.result.Error == nil || got.db.result.Error.Error() != "query canceled" {
+		t.Errorf("Expected the result to report the canceled query, got state %v and %v", got.state, got.db.result.Error)
+	}
+}
+
+func TestCanceledSchemaLoadReturnsToConnectForm(t *testing.T) {
+	m := *NewModel()
+	m.state = StateLoading
+	m.trail = []AppState{StateHome, StateDatabase, StateDatabaseConnect}
+	m.startOperation(opSchema)
+	m.cancelLoading()
+
+	updated, _ := m.Update(databaseSchemaMsg(nil))
+	got := updated.(Model)
+	if got.state != StateDatabaseConnect || got.operation != nil {
+		t.Errorf("Expected the connect form after canceling, got state %v", got.state)
+	}
+}
+
+func TestFlowEscAbortsRequestBeforeResponse(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+
+	// The server never answers, so only canceling ends the request before
+	// the client timeout
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL).Press("enter").WaitFor("Sending Request")
+
+	d.Press("esc").WaitFor("request canceled")
+	if m := d.Model().(Model); m.viewer.response.ResponseTime >= 5*time.Second {
+		t.Errorf("Expected the request to end on Esc, it took %v", m.viewer.response.ResponseTime)
+	}
+}
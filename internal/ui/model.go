@@ -1,22 +1,22 @@
 package ui
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"net/url"
 	"strings"
 	"time"
 
-	"github.com/atotto/clipboard"
-	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
-	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/abneribeiro/godev/internal/database"
 	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/plugin"
+	"github.com/abneribeiro/godev/internal/runner"
 	"github.com/abneribeiro/godev/internal/storage"
 )
 
@@ -43,120 +43,207 @@ const (
 	StateDatabaseExport
 	StateEnvironments
 	StateEnvironmentEditor
+	StateHistoryReplay
+	StateWorkspaces
+	StateDuplicateCompare
+	StateGraphQLSchema
+	StateGraphQLOperations
+	StatePagination
+	StateBulkRunner
+	StateTrash
+	StateBookmarks
+	StateStorageUnavailable
+	StateAssertions
+	StateSigning
+	StateURLInspector
+	StateAliases
+	StateTour
+	StateWhatsNew
+	StateCollections
+	StateStats
+	StateCurlImport
+	StateGraphQLEditor
+	StateHistoryDiff
+	StateTemplates
+	StateCollectionRun
+	StateRawSocket
+	StateAuth
+	StateDNSLookup
+	StateCookies
+	StateJWT
+	StateUtilities
+	StateProxySettings
+	StateTLSSettings
+	StateTimestamps
+	StateExtractions
+	StateSplitView
+	StateRequestPolicy
+	StateQuerySnippets
+	StateDatabaseValueSearch
+	StateDatabaseRowRequest
 )
 
 type Model struct {
-	state   AppState
+	state AppState
+	// trail are the screens that led to the current one, oldest first;
+	// Esc returns to the last of them
+	trail   []AppState
 	width   int
 	height  int
 	layout  LayoutConfig
 	storage *storage.Storage
 	keymap  KeyMap
 
-	method     string
-	urlInput   textinput.Model
-	headers    map[string]string
-	body       string
-	focusIndex int
+	// builder is the request being edited
+	builder RequestBuilder
+
+	// viewer shows the response to the request
+	viewer ResponseViewer
 
 	httpClient *httpclient.Client
-	response   *httpclient.Response
 	spinner    spinner.Model
 	loading    bool
 
-	savedRequests    []storage.SavedRequest
-	filteredRequests []storage.SavedRequest
-	selectedReqIdx   int
-	scrollOffset     int
-	searchInput      textinput.Model
-	searchActive     bool
-
-	headerKeyInput   textinput.Model
-	headerValueInput textinput.Model
-	headerList       []string
-	selectedHeader   int
-	editingHeader    bool
-
-	bodyEditor  textarea.Model
-	editingBody bool
-	bodyError   string
-
-	queryParams     map[string]string
-	queryKeyInput   textinput.Model
-	queryValueInput textinput.Model
-	queryList       []string
-	selectedQuery   int
-	editingQuery    bool
-
-	viewResponseHeaders bool
-	responseScrollY     int
-
-	urlError              string
-	copySuccess           bool
-	copySuccessTimer      int
-	saveSuccess           bool
-	saveSuccessTimer      int
-	curlCopySuccess       bool
-	curlCopySuccessTimer  int
-	confirmingDelete      bool
-	requestToDelete       int
-	requestSaved          bool
-	currentRequestSavedID string
-
-	history                []storage.RequestExecution
-	selectedHistoryIdx     int
-	historyScrollOffset    int
-	confirmingClearHistory bool
-
-	dbClient                      *database.PostgresClient
-	dbStorage                     *database.DatabaseStorage
-	dbConnectHostInput            textinput.Model
-	dbConnectPortInput            textinput.Model
-	dbConnectDatabaseInput        textinput.Model
-	dbConnectUserInput            textinput.Model
-	dbConnectPasswordInput        textinput.Model
-	dbConnectFocusIndex           int
-	dbQueryEditor                 textarea.Model
-	dbQueryResult                 *database.QueryResult
-	dbResultTable                 *BubblesTableWrapper
-	dbSavedQueries                []database.SavedQuery
-	dbSelectedQueryIdx            int
-	dbMode                        string
-	dbTables                      []string
-	dbSelectedTableIdx            int
-	dbTableInfo                   *database.TableInfo
-	dbQuerySaveSuccess            bool
-	dbQuerySaveSuccessTimer       int
-	dbConnectSuccess              bool
-	dbConnectSuccessTimer         int
-	dbQueryHistory                []database.QueryExecution
-	dbSelectedQueryHistoryIdx     int
-	dbConfirmingClearQueryHistory bool
-	dbExportFormatIdx             int
-	dbExportTableName             textinput.Model
-	dbExportSuccess               bool
-	dbExportSuccessTimer          int
-	dbExportFilePath              string
-
-	envConfig              *storage.EnvironmentConfig
-	envList                []storage.Environment
-	selectedEnvIdx         int
-	envScrollOffset        int
-	envNameInput           textinput.Model
-	envVarKeyInput         textinput.Model
-	envVarValueInput       textinput.Model
-	envVarList             []storage.Variable
-	selectedEnvVarIdx      int
-	editingEnvVar          bool
-	envFocusIndex          int
-	envSaveSuccess         bool
-	envSaveSuccessTimer    int
-	envDeleteSuccess       bool
-	envDeleteSuccessTimer  int
-	currentEnvName         string
-	confirmingDeleteEnv    bool
-	confirmingDeleteEnvVar bool
-	// envVarToDelete          int
+	// transfer follows a multipart body being sent or a response body
+	// being downloaded
+	transfer *transfer
+
+	// operation is the request or query the loading screen waits for, when
+	// it is not a transfer
+	operation *operation
+
+	// downloadPath is the file the response body is written to; when empty
+	// responses are shown as usual
+	downloadPath     string
+	downloadInput    textinput.Model
+	choosingDownload bool
+
+	plugins       *plugin.Registry
+	latencyBudget int64
+	diffIgnore    httpclient.IgnoreRules
+
+	storageErr         error
+	storageErrTimer    int
+	storageInitErr     error
+	storageDirInput    textinput.Model
+	choosingStorageDir bool
+	historyWriter      *storage.HistoryWriterOptions
+	saveSuccess        bool
+	saveSuccessTimer   int
+
+	extractions extractionPanel
+
+	// currentGraphQLOpID is the saved operation loaded into the builder,
+	// whose variables are recorded when it is sent
+	currentGraphQLOpID string
+
+	// graphqlMode edits the body as a GraphQL query and its variables
+	graphqlMode bool
+
+	variantInput  textinput.Model
+	namingVariant bool
+	variantNotice string
+
+	hmacAuth    *storage.HMACAuth
+	signingForm signingForm
+
+	// requestAuth attaches Basic, Bearer or API key credentials to the
+	// request being edited
+	requestAuth *storage.RequestAuth
+	authForm    authForm
+
+	// requestPolicy sets the timeout and retries of the request being
+	// edited; requestDefaults apply where it leaves them unset
+	requestPolicy   *storage.RequestPolicy
+	requestDefaults RequestDefaults
+	policyForm      policyForm
+
+	// proxySettings are the global proxy settings; the active environment
+	// can replace the proxy. saveProxy keeps them, nil for the session only.
+	proxySettings httpclient.ProxySettings
+	saveProxy     func(httpclient.ProxySettings) error
+	proxyForm     proxyForm
+
+	// tlsSettings are the global TLS settings; the active environment adds
+	// its client certificate. saveTLS keeps them, nil for the session only.
+	tlsSettings httpclient.TLSSettings
+	saveTLS     func(httpclient.TLSSettings) error
+	tlsForm     tlsForm
+
+	autoSave       bool
+	autoSaveNotice string
+
+	// restoreSession saves where the user left off on exit
+	restoreSession bool
+
+	// listDensity is how many lines the items of lists take. saveDensity
+	// keeps it when switched, nil for the session only.
+	listDensity ListDensity
+	saveDensity func(string) error
+
+	// groupRequests shows saved requests under one header per host;
+	// selectedGroupHeader is set while the cursor is on a header
+	groupRequests       bool
+	collapsedGroups     map[string]bool
+	selectedGroupHeader string
+
+	onboarding Onboarding
+
+	// crashed is set once a panic was recovered; the crash screen replaces
+	// every other screen until it is dismissed
+	crashed *crashInfo
+	// lastCrashReport is the path of the last crash report, kept after the
+	// crash screen is dismissed so that it is printed on exit
+	lastCrashReport string
+
+	usage *usageReport
+
+	recording     *sessionRecording
+	sessionInput  textinput.Model
+	namingSession bool
+	sessionNotice string
+
+	db            DatabaseExplorer
+	envs          Environments
+	templates     Templates
+	collectionRun CollectionRun
+	rawSocket     RawSocket
+	dnsLookup     DNSLookup
+	cookies       Cookies
+	jwt           JWTDecoder
+	utilities     Utilities
+	timestamps    Timestamps
+	split         SplitView
+	querySnippets QuerySnippets
+	valueSearch   ValueSearch
+	rowRequest    RowRequest
+	trash         Trash
+	aliases       Aliases
+	workspaces    Workspaces
+	bulk          BulkRunner
+	pagination    Pagination
+	duplicate     DuplicateCompare
+	urlInspector  URLInspector
+	curlImport    CurlImport
+	schemaBrowser SchemaBrowser
+	gqlOperations GraphQLOperations
+	replay        HistoryReplay
+	historyDiff   HistoryDiff
+	history       History
+	collections   Collections
+	assertions    Assertions
+	requests      RequestList
+	gqlEditor     GraphQLEditor
+
+	readOnly            bool
+	readOnlyNotice      string
+	readOnlyNoticeTimer int
+
+	accessible bool
+
+	notify             NotifyConfig
+	terminalBlurred    bool
+	windowTitleChanged bool
 
 	err error
 }
@@ -168,199 +255,94 @@ type responseMsg httpclient.Response
 type databaseSchemaMsg []string
 
 func NewModel() *Model {
-	ti := textinput.New()
-	ti.Placeholder = "https://api.example.com/endpoint"
-	ti.Focus()
-	ti.CharLimit = 2000
-	ti.Width = 60
-
-	headerKey := textinput.New()
-	headerKey.Placeholder = "Header-Name"
-	headerKey.CharLimit = 100
-	headerKey.Width = 30
-
-	headerValue := textinput.New()
-	headerValue.Placeholder = "Header Value"
-	headerValue.CharLimit = 500
-	headerValue.Width = 50
-
-	queryKey := textinput.New()
-	queryKey.Placeholder = "Param Name"
-	queryKey.CharLimit = 100
-	queryKey.Width = 30
-
-	queryValue := textinput.New()
-	queryValue.Placeholder = "Param Value"
-	queryValue.CharLimit = 500
-	queryValue.Width = 50
-
-	bodyTextarea := textarea.New()
-	bodyTextarea.Placeholder = "{\n  \"key\": \"value\"\n}"
-	bodyTextarea.CharLimit = 10000
-	bodyTextarea.SetWidth(80)
-	bodyTextarea.SetHeight(10)
-
-	searchInput := textinput.New()
-	searchInput.Placeholder = "Search requests..."
-	searchInput.CharLimit = 100
-	searchInput.Width = 50
-
-	dbHostInput := textinput.New()
-	dbHostInput.Placeholder = "localhost"
-	dbHostInput.CharLimit = 100
-	dbHostInput.Width = 40
-	dbHostInput.SetValue("localhost")
-
-	dbPortInput := textinput.New()
-	dbPortInput.Placeholder = "5432"
-	dbPortInput.CharLimit = 10
-	dbPortInput.Width = 15
-	dbPortInput.SetValue("5432")
-
-	dbDatabaseInput := textinput.New()
-	dbDatabaseInput.Placeholder = "database name"
-	dbDatabaseInput.CharLimit = 100
-	dbDatabaseInput.Width = 40
-
-	dbUserInput := textinput.New()
-	dbUserInput.Placeholder = "username"
-	dbUserInput.CharLimit = 100
-	dbUserInput.Width = 40
-
-	dbPasswordInput := textinput.New()
-	dbPasswordInput.Placeholder = "password"
-	dbPasswordInput.CharLimit = 100
-	dbPasswordInput.Width = 40
-	dbPasswordInput.EchoMode = textinput.EchoPassword
-	dbPasswordInput.EchoCharacter = '•'
-
-	dbQueryTextarea := textarea.New()
-	dbQueryTextarea.Placeholder = "SELECT * FROM table_name;"
-	dbQueryTextarea.CharLimit = 50000
-	dbQueryTextarea.SetWidth(80)
-	dbQueryTextarea.SetHeight(10)
-	// Disable Ctrl+K built-in behavior (delete line) so we can use it for query execution
-	dbQueryTextarea.KeyMap.DeleteAfterCursor.SetEnabled(false)
-
-	dbExportTableName := textinput.New()
-	dbExportTableName.Placeholder = "table_name"
-	dbExportTableName.CharLimit = 100
-	dbExportTableName.Width = 40
-
-	envNameInput := textinput.New()
-	envNameInput.Placeholder = "environment name (e.g., dev, staging, prod)"
-	envNameInput.CharLimit = 50
-	envNameInput.Width = 50
-
-	envVarKey := textinput.New()
-	envVarKey.Placeholder = "Variable Name (e.g., API_URL)"
-	envVarKey.CharLimit = 100
-	envVarKey.Width = 30
-
-	envVarValue := textinput.New()
-	envVarValue.Placeholder = "Variable Value"
-	envVarValue.CharLimit = 500
-	envVarValue.Width = 50
+
+	variantInput := textinput.New()
+	variantInput.Placeholder = "minimal"
+	variantInput.CharLimit = 64
+	variantInput.Width = 40
+
+	sessionInput := textinput.New()
+	sessionInput.Placeholder = "checkout flow"
+	sessionInput.CharLimit = 64
+	sessionInput.Width = 40
+
+	downloadInput := textinput.New()
+	downloadInput.Placeholder = "~/Downloads/artifact.zip"
+	downloadInput.CharLimit = 500
+	downloadInput.Width = 50
 
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = SpinnerStyle
 
+	storageDirInput := textinput.New()
+	storageDirInput.Placeholder = "~/godev-data"
+	storageDirInput.CharLimit = 500
+	storageDirInput.Width = 50
+
 	store, storageErr := storage.NewStorage()
-	if storageErr != nil {
-		fmt.Printf("Warning: Failed to initialize storage: %v\n", storageErr)
-		fmt.Println("The application will continue but requests cannot be saved.")
-		fmt.Println("Press Enter to continue...")
-		fmt.Scanln()
-	}
 
+	var envErr error
 	if store != nil {
-		_, envErr := store.LoadEnvironments()
-		if envErr != nil {
-			fmt.Printf("Warning: Failed to initialize environments: %v\n", envErr)
-		}
+		_, envErr = store.LoadEnvironments()
 	}
 
-	dbStorage, dbStorageErr := database.NewDatabaseStorage()
-	if dbStorageErr != nil {
-		fmt.Printf("Warning: Failed to initialize database storage: %v\n", dbStorageErr)
-	}
+	dbStorage, dbStorageErr := openDatabaseStorage(store)
 
 	dbClient := database.NewPostgresClient()
 
 	m := &Model{
-		state:                  StateHome,
-		width:                  80,  // Default width
-		height:                 24,  // Default height
-		layout:                 NewLayoutConfig(80, 24),
-		keymap:                 DefaultKeyMap(),
-		method:                 "GET",
-		urlInput:               ti,
-		headers:                make(map[string]string),
-		body:                   "",
-		focusIndex:             1,
-		httpClient:             httpclient.NewClient(30 * time.Second),
-		spinner:                s,
-		storage:                store,
-		err:                    nil,
-		headerKeyInput:         headerKey,
-		headerValueInput:       headerValue,
-		headerList:             []string{},
-		selectedHeader:         0,
-		editingHeader:          false,
-		bodyEditor:             bodyTextarea,
-		editingBody:            false,
-		queryParams:            make(map[string]string),
-		queryKeyInput:          queryKey,
-		queryValueInput:        queryValue,
-		queryList:              []string{},
-		selectedQuery:          0,
-		editingQuery:           false,
-		viewResponseHeaders:    false,
-		responseScrollY:        0,
-		urlError:               "",
-		copySuccess:            false,
-		copySuccessTimer:       0,
-		searchInput:            searchInput,
-		searchActive:           false,
-		dbClient:               dbClient,
-		dbStorage:              dbStorage,
-		dbConnectHostInput:     dbHostInput,
-		dbConnectPortInput:     dbPortInput,
-		dbConnectDatabaseInput: dbDatabaseInput,
-		dbConnectUserInput:     dbUserInput,
-		dbConnectPasswordInput: dbPasswordInput,
-		dbConnectFocusIndex:    0,
-		dbQueryEditor:          dbQueryTextarea,
-		dbQueryResult:          nil,
-		dbSavedQueries:         []database.SavedQuery{},
-		dbSelectedQueryIdx:     0,
-		dbMode:                 "menu",
-		dbExportTableName:      dbExportTableName,
-		dbExportFormatIdx:      0,
-		envNameInput:           envNameInput,
-		envVarKeyInput:         envVarKey,
-		envVarValueInput:       envVarValue,
-		selectedEnvIdx:         0,
-		envScrollOffset:        0,
-		editingEnvVar:          false,
-		envFocusIndex:          0,
-		selectedEnvVarIdx:      0,
+		state:           StateHome,
+		width:           80, // Default width
+		height:          24, // Default height
+		layout:          NewLayoutConfig(80, 24),
+		keymap:          DefaultKeyMap(),
+		builder:         newRequestBuilder(),
+		viewer:          newResponseViewer(),
+		httpClient:      httpclient.NewClient(30 * time.Second),
+		spinner:         s,
+		storage:         store,
+		err:             nil,
+		variantInput:    variantInput,
+		sessionInput:    sessionInput,
+		downloadInput:   downloadInput,
+		storageDirInput: storageDirInput,
+		db:              newDatabaseExplorer(dbClient, dbStorage),
+		envs:            newEnvironments(),
+		templates:       newTemplates(),
+		querySnippets:   newQuerySnippets(),
+		valueSearch:     newValueSearch(),
+		rowRequest:      newRowRequest(),
+		rawSocket:       newRawSocket(),
+		dnsLookup:       newDNSLookup(),
+		cookies:         newCookies(),
+		utilities:       newUtilities(),
+		timestamps:      newTimestamps(),
+		aliases:         newAliases(),
+		workspaces:      newWorkspaces(),
+		bulk:            newBulkRunner(),
+		pagination:      newPagination(),
+		duplicate:       DuplicateCompare{count: defaultDuplicateCount},
+		schemaBrowser:   newSchemaBrowser(),
+		replay:          HistoryReplay{count: defaultReplayCount},
+		history:         newHistory(),
+		collections:     newCollections(),
+		assertions:      newAssertions(),
+		requests:        newRequestList(),
 	}
 
 	if m.storage != nil {
-		m.savedRequests = m.storage.GetRequests()
-		m.history = m.storage.GetHistory()
-		envConfig, _ := m.storage.LoadEnvironments()
-		if envConfig != nil {
-			m.envConfig = envConfig
-			m.envList = envConfig.Environments
-		}
+		m.requests.saved = m.storage.GetRequests()
+		m.history.entries = m.storage.GetHistory()
+		m.envs.reload(m.storage)
 	}
 
-	if m.dbStorage != nil {
-		m.dbSavedQueries = m.dbStorage.GetQueries()
+	if storageErr != nil {
+		m.storageInitErr = storageErr
+		m.openStorageUnavailable()
 	}
+	m.reportStorageError("failed to load environments", envErr)
+	m.reportStorageError("failed to open database storage", dbStorageErr)
 
 	return m
 }
@@ -369,6 +351,7 @@ func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		textinput.Blink,
 		tickCmd(),
+		tea.SetWindowTitle(defaultWindowTitle),
 	)
 }
 
@@ -378,28 +361,18 @@ func tickCmd() tea.Cmd {
 	})
 }
 
-func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+// update handles msg; Update wraps it with crash recovery
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		if m.state == StateRequestBuilder && m.focusIndex == 1 {
-			switch msg.String() {
-			case "ctrl+q", "tab", "shift+tab", "enter", "ctrl+l", "ctrl+?":
-				return m.handleKeyPress(msg)
-			case "ctrl+c":
-				if m.urlInput.Value() != "" {
-					m.urlInput, cmd = m.urlInput.Update(msg)
-					return m, cmd
-				}
-				return m.handleKeyPress(msg)
-			default:
-				m.urlInput, cmd = m.urlInput.Update(msg)
-				m.requestSaved = false
-				return m, cmd
-			}
+		if m.windowTitleChanged {
+			cmd = m.resetWindowTitle()
+			next, keyCmd := m.handleKeyMsg(msg)
+			return next, tea.Batch(cmd, keyCmd)
 		}
-		return m.handleKeyPress(msg)
+		return m.handleKeyMsg(msg)
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -409,77 +382,88 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.layout = NewLayoutConfig(m.width, m.height)
 
 		// Update input field widths based on new layout
-		m.urlInput.Width = m.layout.InputWidth
-
-		// Update other input fields
-		m.searchInput.Width = m.layout.InputWidth
-		m.headerKeyInput.Width = m.layout.InputWidth / 2
-		m.headerValueInput.Width = m.layout.InputWidth / 2
-		m.queryKeyInput.Width = m.layout.InputWidth / 2
-		m.queryValueInput.Width = m.layout.InputWidth / 2
-
-		// Update database-related input widths
-		m.dbConnectHostInput.Width = m.layout.InputWidth / 2
-		m.dbConnectPortInput.Width = m.layout.InputWidth / 4
-		m.dbConnectDatabaseInput.Width = m.layout.InputWidth / 2
-		m.dbConnectUserInput.Width = m.layout.InputWidth / 2
-		m.dbConnectPasswordInput.Width = m.layout.InputWidth / 2
+		m.requests.search.Width = m.layout.InputWidth
+
+		m.builder.resize(m.layout)
+		m.viewer.resize(m.layout)
+		m.db.resize(m.layout)
 
 		// Update environment input widths
-		m.envNameInput.Width = m.layout.InputWidth
-		m.envVarKeyInput.Width = m.layout.InputWidth / 2
-		m.envVarValueInput.Width = m.layout.InputWidth / 2
-
-		// Update table dimensions if we have a table
-		if m.dbResultTable != nil {
-			tableWidth, tableHeight := m.layout.GetTableDimensions()
-			// Recreate table with new dimensions
-			if m.dbQueryResult != nil && len(m.dbQueryResult.Columns) > 0 {
-				m.dbResultTable = NewBubblesTableWrapper(
-					m.dbQueryResult.Columns,
-					m.dbQueryResult.Rows,
-					tableWidth,
-					tableHeight,
-				)
-			}
-		}
+		m.envs.resize(m.layout.InputWidth)
 
 		return m, nil
 
 	case responseMsg:
 		m.loading = false
 		resp := httpclient.Response(msg)
-		m.response = &resp
+		if m.transfer != nil && m.transfer.canceled && resp.Error != nil {
+			resp.Error = errors.New(m.transfer.canceledMessage())
+		}
+		m.finishTransfer()
+		if m.finishOperation() && resp.Error != nil {
+			resp.Error = errors.New(i18n.T("loading.request_canceled"))
+		}
+		m.viewer.dropSpooledBody()
+		m.viewer.response = &resp
 		m.state = StateViewResponse
+		m.viewer.resetPluginView()
+		m.viewer.saveBody = bodySaver{}
+		m.viewer.search.current = 0
 
 		if m.storage != nil {
-			statusCode := 0
-			status := ""
-			responseBody := ""
-			responseTimeMs := int64(0)
-			var err error
+			execution := storage.RequestExecution{
+				Method:      m.builder.method,
+				URL:         m.builder.buildURLWithQueryParams(),
+				Headers:     m.builder.headers,
+				Body:        m.builder.body,
+				QueryParams: m.builder.queryParams,
+				BudgetMs:    m.activeLatencyBudget(),
+				Environment: m.storage.ActiveEnvironmentName(),
+			}
 
 			if resp.Error != nil {
-				err = resp.Error
+				execution.Error = resp.Error.Error()
 			} else {
-				statusCode = resp.StatusCode
-				status = resp.Status
-				responseBody = resp.Body
-				responseTimeMs = resp.ResponseTime.Milliseconds()
+				execution.StatusCode = resp.StatusCode
+				execution.Status = resp.Status
+				execution.ResponseBody = resp.Body
+				execution.ResponseTime = resp.ResponseTime.Milliseconds()
 			}
 
-			finalURL := m.buildURLWithQueryParams()
-			m.storage.AddToHistory(m.method, finalURL, m.headers, m.body, m.queryParams, statusCode, status, responseBody, responseTimeMs, err)
-			m.history = m.storage.GetHistory()
+			m.reportStorageError("failed to record history", m.storage.AddExecution(execution))
+			m.history.refresh(&m)
+			m.recordGraphQLVariables()
 		}
 
-		return m, nil
+		if resp.Error == nil {
+			m.recordSessionStep()
+			m.autoSaveRequest(resp.StatusCode)
+		} else {
+			m.autoSaveNotice = ""
+		}
+
+		m.checkSchemaDrift(resp)
+		m.assertions.check(&m)
+		m.runExtractions()
+		m.checkGolden(resp)
+
+		summary := fmt.Sprintf("%s %s", m.builder.method, m.builder.urlInput.Value())
+		if resp.Error != nil {
+			return m, m.notifyDone(summary, resp.ResponseTime, true)
+		}
+		return m, m.notifyDone(fmt.Sprintf("%s %s", resp.Status, summary), resp.ResponseTime, resp.StatusCode >= 400)
 
 	case tickMsg:
-		if m.copySuccessTimer > 0 {
-			m.copySuccessTimer--
-			if m.copySuccessTimer == 0 {
-				m.copySuccess = false
+		if m.readOnlyNoticeTimer > 0 {
+			m.readOnlyNoticeTimer--
+			if m.readOnlyNoticeTimer == 0 {
+				m.readOnlyNotice = ""
+			}
+		}
+		if m.storageErrTimer > 0 {
+			m.storageErrTimer--
+			if m.storageErrTimer == 0 {
+				m.storageErr = nil
 			}
 		}
 		if m.saveSuccessTimer > 0 {
@@ -488,620 +472,275 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.saveSuccess = false
 			}
 		}
-		if m.curlCopySuccessTimer > 0 {
-			m.curlCopySuccessTimer--
-			if m.curlCopySuccessTimer == 0 {
-				m.curlCopySuccess = false
-			}
-		}
-		if m.dbQuerySaveSuccessTimer > 0 {
-			m.dbQuerySaveSuccessTimer--
-			if m.dbQuerySaveSuccessTimer == 0 {
-				m.dbQuerySaveSuccess = false
-			}
-		}
-		if m.dbConnectSuccessTimer > 0 {
-			m.dbConnectSuccessTimer--
-			if m.dbConnectSuccessTimer == 0 {
-				m.dbConnectSuccess = false
-			}
-		}
-		if m.dbExportSuccessTimer > 0 {
-			m.dbExportSuccessTimer--
-			if m.dbExportSuccessTimer == 0 {
-				m.dbExportSuccess = false
-			}
-		}
-		if m.envSaveSuccessTimer > 0 {
-			m.envSaveSuccessTimer--
-			if m.envSaveSuccessTimer == 0 {
-				m.envSaveSuccess = false
-			}
-		}
-		if m.envDeleteSuccessTimer > 0 {
-			m.envDeleteSuccessTimer--
-			if m.envDeleteSuccessTimer == 0 {
-				m.envDeleteSuccess = false
-			}
-		}
+		m.history.tick()
+		m.builder.tick()
+		m.viewer.tick()
+		m.db.tick()
+		m.envs.tick()
+		m.syncExternalChanges()
 		return m, tickCmd()
 
 	case databaseResultMsg:
 		m.loading = false
 		result := database.QueryResult(msg)
-		m.dbQueryResult = &result
-
-		// Create table wrapper if we have columns and data
-		if len(result.Columns) > 0 && len(result.Rows) > 0 {
-			tableWidth, tableHeight := m.layout.GetTableDimensions()
-			m.dbResultTable = NewBubblesTableWrapper(
-				result.Columns,
-				result.Rows,
-				tableWidth,
-				tableHeight,
-			)
-		} else {
-			m.dbResultTable = nil
-		}
-
-		if m.dbStorage != nil {
-			query := strings.TrimSpace(m.dbQueryEditor.Value())
-			connectionInfo := m.dbClient.GetConnectionString()
-			m.dbStorage.AddToQueryHistory(query, connectionInfo, result.RowsAffected, result.ExecutionTime.Milliseconds(), result.Error)
+		if m.finishOperation() && result.Error != nil {
+			result.Error = errors.New(i18n.T("loading.query_canceled"))
 		}
-
+		m.db.showResult(result, m.layout)
 		m.state = StateDatabaseResult
-		return m, nil
-
-	case databaseSchemaMsg:
-		m.loading = false
-		m.dbTables = []string(msg)
-		m.dbSelectedTableIdx = 0
-		m.dbConnectSuccess = true
-		m.dbConnectSuccessTimer = 3
-		m.state = StateDatabaseSchema
-		return m, nil
 
-	case spinner.TickMsg:
-		m.spinner, cmd = m.spinner.Update(msg)
-		return m, cmd
-	}
-
-	return m, cmd
-}
-
-func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch m.state {
-	case StateHome:
-		return m.handleHomeKeys(msg)
-	case StateRequestBuilder:
-		return m.handleRequestBuilderKeys(msg)
-	case StateViewResponse:
-		return m.handleResponseViewKeys(msg)
-	case StateRequestList:
-		return m.handleRequestListKeys(msg)
-	case StateHeaderEditor:
-		return m.handleHeaderEditorKeys(msg)
-	case StateBodyEditor:
-		return m.handleBodyEditorKeys(msg)
-	case StateQueryEditor:
-		return m.handleQueryEditorKeys(msg)
-	case StateHelp:
-		return m.handleHelpKeys(msg)
-	case StateHistory:
-		return m.handleHistoryKeys(msg)
-	case StateDatabase:
-		return m.handleDatabaseKeys(msg)
-	case StateDatabaseConnect:
-		return m.handleDatabaseConnectKeys(msg)
-	case StateDatabaseQueryEditor:
-		return m.handleDatabaseQueryEditorKeys(msg)
-	case StateDatabaseResult:
-		return m.handleDatabaseResultKeys(msg)
-	case StateDatabaseQueryList:
-		return m.handleDatabaseQueryListKeys(msg)
-	case StateDatabaseSchema:
-		return m.handleDatabaseSchemaKeys(msg)
-	case StateDatabaseQueryHistory:
-		return m.handleDatabaseQueryHistoryKeys(msg)
-	case StateDatabaseExport:
-		return m.handleDatabaseExportKeys(msg)
-	case StateEnvironments:
-		return m.handleEnvironmentsKeys(msg)
-	case StateEnvironmentEditor:
-		return m.handleEnvironmentEditorKeys(msg)
-	case StateLoading:
-		if msg.String() == "ctrl+c" {
-			return m, tea.Quit
+		summary := i18n.Tf("query_result.returned", len(result.Rows))
+		if len(result.Columns) == 0 {
+			summary = i18n.Tf("query_result.affected", result.RowsAffected)
 		}
-		return m, nil
-	}
-	return m, nil
-}
-
-func (m Model) handleRequestBuilderKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "ctrl+q":
-		return m, tea.Quit
-
-	case "ctrl+h", "?":
-		m.state = StateHelp
-		return m, nil
-
-	case "ctrl+enter":
-		if m.urlInput.Value() != "" {
-			return m, m.sendRequest()
+		if result.Error != nil {
+			summary = i18n.T("query_result.failed")
 		}
-		return m, nil
-
-	case "ctrl+l":
-		m.state = StateRequestList
-		return m, nil
-
-	case "ctrl+r":
-		m.state = StateHistory
-		m.selectedHistoryIdx = 0
-		m.historyScrollOffset = 0
-		return m, nil
-
-	case "ctrl+d":
-		m.state = StateDatabase
-		return m, nil
+		return m, m.notifyDone(summary, result.ExecutionTime, result.Error != nil)
 
-	case "ctrl+e":
-		m.state = StateEnvironments
-		return m, nil
-
-	case "tab":
-		m.focusIndex++
-		if m.focusIndex > 7 {
-			m.focusIndex = 0
-		}
+	case replayResultMsg:
+		m.replay.finish(msg)
 
-		if m.focusIndex == 1 {
-			m.urlInput.Focus()
-		} else {
-			m.urlInput.Blur()
+		var took time.Duration
+		failed := 0
+		for _, r := range m.replay.results {
+			took += r.ResponseTime
+			if !r.Passed() {
+				failed++
+			}
 		}
-		return m, nil
+		summary := fmt.Sprintf("Replay: %d/%d passed", len(m.replay.results)-failed, len(m.replay.results))
+		return m, m.notifyDone(summary, took, failed > 0)
 
-	case "shift+tab":
-		m.focusIndex--
-		if m.focusIndex < 0 {
-			m.focusIndex = 7
-		}
+	case duplicateResultMsg:
+		m.duplicate.finish(msg)
 
-		if m.focusIndex == 1 {
-			m.urlInput.Focus()
-		} else {
-			m.urlInput.Blur()
-		}
-		return m, nil
+		summary, took := duplicateSummary(m.duplicate.result)
+		return m, m.notifyDone(summary, took, !m.duplicate.result.Consistent())
 
-	case "left":
-		if m.focusIndex == 0 {
-			methods := []string{"GET", "POST", "PUT", "DELETE", "PATCH"}
-			for i, method := range methods {
-				if m.method == method {
-					if i > 0 {
-						m.method = methods[i-1]
-					}
-					break
-				}
-			}
-		}
-		return m, nil
+	case paginationResultMsg:
+		m.pagination.finish(msg)
 
-	case "right":
-		if m.focusIndex == 0 {
-			methods := []string{"GET", "POST", "PUT", "DELETE", "PATCH"}
-			for i, method := range methods {
-				if m.method == method {
-					if i < len(methods)-1 {
-						m.method = methods[i+1]
-					}
-					break
-				}
-			}
+		var took time.Duration
+		for _, page := range m.pagination.result.Pages {
+			took += page.ResponseTime
 		}
-		return m, nil
-
-	case "h":
-		m.state = StateHeaderEditor
-		m.buildHeaderList()
-		return m, nil
+		summary := fmt.Sprintf("Fetched %d items from %d pages", len(m.pagination.result.Items), len(m.pagination.result.Pages))
+		return m, m.notifyDone(summary, took, m.pagination.result.Err != nil)
 
-	case "b":
-		m.state = StateBodyEditor
-		m.bodyEditor.SetValue(m.body)
-		m.bodyEditor.Focus()
-		return m, nil
+	case bulkResultMsg:
+		m.bulk.finish(msg)
 
-	case "q":
-		m.state = StateQueryEditor
-		m.buildQueryList()
-		return m, nil
+		summary, took, failed := bulkSummary(m.bulk.results)
+		return m, m.notifyDone(summary, took, failed)
 
-	case "enter":
-		switch m.focusIndex {
-		case 0:
-			return m, nil
-		case 1:
-			if m.urlInput.Value() != "" {
-				return m, m.sendRequest()
-			}
-			return m, nil
-		case 2:
-			m.state = StateQueryEditor
-			m.buildQueryList()
-			return m, nil
-		case 3:
-			m.state = StateHeaderEditor
-			m.buildHeaderList()
-			return m, nil
-		case 4:
-			m.state = StateBodyEditor
-			m.bodyEditor.SetValue(m.body)
-			m.bodyEditor.Focus()
+	case pluginViewMsg:
+		if msg.name != m.viewer.pluginViewName {
 			return m, nil
-		case 5:
-			if m.urlInput.Value() != "" {
-				return m, m.sendRequest()
-			}
-		case 6:
-			m.state = StateRequestList
-			return m, nil
-		case 7:
-			return m, tea.Quit
-		}
-		return m, nil
-
-	case "s":
-		if m.storage != nil && m.urlInput.Value() != "" {
-			name := fmt.Sprintf("%s %s", m.method, m.urlInput.Value())
-			if !m.storage.RequestExists(name) {
-				err := m.storage.SaveRequest(name, m.method, m.urlInput.Value(), m.headers, m.body, m.queryParams)
-				if err == nil {
-					m.savedRequests = m.storage.GetRequests()
-					m.saveSuccess = true
-					m.saveSuccessTimer = 3
-				}
-			}
 		}
-		return m, nil
-
-	case "x":
-		if m.urlInput.Value() != "" {
-			finalURL := m.buildURLWithQueryParams()
-			req := httpclient.Request{
-				Method:  m.method,
-				URL:     finalURL,
-				Headers: m.headers,
-				Body:    m.body,
-			}
-			curlCmd := httpclient.RequestToCurl(req)
-			err := clipboard.WriteAll(curlCmd)
-			if err == nil {
-				m.curlCopySuccess = true
-				m.curlCopySuccessTimer = 3
-			}
+		m.viewer.pluginViewLoading = false
+		if msg.err != nil {
+			m.viewer.pluginViewError = msg.err.Error()
+			return m, nil
 		}
+		m.viewer.pluginViewOutput = msg.output
 		return m, nil
-	}
 
-	return m, nil
-}
-
-func (m Model) handleResponseViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "ctrl+q":
-		return m, tea.Quit
-
-	case "esc":
-		m.state = StateRequestBuilder
-		m.response = nil
-		m.viewResponseHeaders = false
+	case graphqlSchemaMsg:
+		m.schemaBrowser.finish(msg)
 		return m, nil
 
-	case "s":
-		if m.storage != nil && m.response != nil {
-			name := fmt.Sprintf("%s %s", m.method, m.urlInput.Value())
-			if !m.storage.RequestExists(name) {
-				err := m.storage.SaveRequest(name, m.method, m.urlInput.Value(), m.headers, m.body, m.queryParams)
-				if err == nil {
-					m.savedRequests = m.storage.GetRequests()
-					m.saveSuccess = true
-					m.saveSuccessTimer = 3
-					m.requestSaved = true
-					if len(m.savedRequests) > 0 {
-						m.currentRequestSavedID = m.savedRequests[len(m.savedRequests)-1].ID
-					}
-				}
-			}
-		}
-		return m, nil
+	case tea.FocusMsg:
+		m.terminalBlurred = false
+		return m, m.resetWindowTitle()
 
-	case "c":
-		if m.response != nil && m.response.Error == nil {
-			err := clipboard.WriteAll(m.response.Body)
-			if err == nil {
-				m.copySuccess = true
-				m.copySuccessTimer = 3
-			}
-		}
+	case tea.BlurMsg:
+		m.terminalBlurred = true
 		return m, nil
 
-	case "x":
-		finalURL := m.buildURLWithQueryParams()
-		req := httpclient.Request{
-			Method:  m.method,
-			URL:     finalURL,
-			Headers: m.headers,
-			Body:    m.body,
+	case databaseSchemaMsg:
+		m.loading = false
+		if m.finishOperation() {
+			// Connecting was canceled, so the connection is dropped and
+			// the form shown again
+			m.db.client.Close()
+			m.followNavigation(StateLoading, true)
+			return m, nil
 		}
-		curlCmd := httpclient.RequestToCurl(req)
-		err := clipboard.WriteAll(curlCmd)
-		if err == nil {
-			m.curlCopySuccess = true
-			m.curlCopySuccessTimer = 3
+		m.db.showTables(msg)
+		m.state = StateDatabaseSchema
+		if m.db.restore != nil {
+			m.finishDatabaseRestore()
 		}
 		return m, nil
 
-	case "h":
-		m.viewResponseHeaders = !m.viewResponseHeaders
-		m.scrollOffset = 0
-		return m, nil
+	case spinner.TickMsg:
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
 
-	case "up", "k":
-		if m.scrollOffset > 0 {
-			m.scrollOffset--
+	case runTickMsg:
+		if m.collectionRun.running() {
+			return m, runTickCmd()
 		}
 		return m, nil
 
-	case "down", "j":
-		m.scrollOffset++
-		return m, nil
-	}
-
-	return m, nil
-}
-
-func (m Model) handleRequestListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	var cmd tea.Cmd
-
-	if m.searchActive {
-		switch msg.String() {
-		case "ctrl+c", "ctrl+q":
-			return m, tea.Quit
-		case "esc":
-			m.searchActive = false
-			m.searchInput.Blur()
-			m.searchInput.SetValue("")
-			m.filteredRequests = m.savedRequests
-			m.selectedReqIdx = 0
-			return m, nil
-		case "enter":
-			m.searchActive = false
-			m.searchInput.Blur()
-			return m, nil
-		default:
-			m.searchInput, cmd = m.searchInput.Update(msg)
-			if m.storage != nil {
-				m.filteredRequests = m.storage.FilterRequests(m.searchInput.Value())
-				if m.selectedReqIdx >= len(m.filteredRequests) {
-					m.selectedReqIdx = 0
-				}
-			}
-			return m, cmd
-		}
-	}
-
-	switch msg.String() {
-	case "ctrl+c", "ctrl+q":
-		return m, tea.Quit
-
-	case "esc":
-		if m.confirmingDelete {
-			m.confirmingDelete = false
+	case runDoneMsg:
+		if !m.collectionRun.finish(&m, msg) || m.collectionRun.report == nil {
 			return m, nil
 		}
-		m.state = StateRequestBuilder
-		m.searchInput.SetValue("")
-		m.filteredRequests = nil
-		return m, nil
+		m.history.refresh(&m)
+		summary, took, failed := m.collectionRun.summary()
+		return m, m.notifyDone(summary, took, failed)
 
-	case "/":
-		m.searchActive = true
-		m.searchInput.Focus()
-		if m.filteredRequests == nil {
-			m.filteredRequests = m.savedRequests
-		}
-		return m, nil
+	case rawDialMsg:
+		return m, m.rawSocket.dialed(msg)
 
-	case "up", "k":
-		if m.selectedReqIdx > 0 {
-			m.selectedReqIdx--
-		}
-		return m, nil
+	case rawTickMsg:
+		return m, m.rawSocket.tick()
 
-	case "down", "j":
-		displayList := m.savedRequests
-		if m.filteredRequests != nil {
-			displayList = m.filteredRequests
-		}
-		if m.selectedReqIdx < len(displayList)-1 {
-			m.selectedReqIdx++
-		}
+	case dnsResultMsg:
+		m.dnsLookup.finish(msg)
 		return m, nil
 
-	case "enter":
-		displayList := m.savedRequests
-		if m.filteredRequests != nil {
-			displayList = m.filteredRequests
-		}
-		if len(displayList) > 0 && m.selectedReqIdx < len(displayList) {
-			req := displayList[m.selectedReqIdx]
-			m.method = req.Method
-			m.urlInput.SetValue(req.URL)
-			m.headers = req.Headers
-			m.body = req.Body
-			if req.QueryParams != nil {
-				m.queryParams = req.QueryParams
-			} else {
-				m.queryParams = make(map[string]string)
-			}
-			m.state = StateRequestBuilder
-			m.requestSaved = true
-			m.currentRequestSavedID = req.ID
-
-			if m.storage != nil {
-				m.storage.UpdateLastUsed(req.ID)
-			}
-		}
+	case valueSearchMsg:
+		m.valueSearch.finish(msg)
 		return m, nil
 
-	case "d":
-		displayList := m.savedRequests
-		if m.filteredRequests != nil {
-			displayList = m.filteredRequests
-		}
-		if len(displayList) > 0 && m.selectedReqIdx < len(displayList) {
-			if !m.confirmingDelete {
-				m.confirmingDelete = true
-				m.requestToDelete = m.selectedReqIdx
-				return m, nil
-			}
-		}
-		return m, nil
+	case jwtTickMsg:
+		return m, m.jwt.tick(&m, msg)
 
-	case "y":
-		if m.confirmingDelete && m.storage != nil {
-			displayList := m.savedRequests
-			if m.filteredRequests != nil {
-				displayList = m.filteredRequests
-			}
-			if m.requestToDelete < len(displayList) {
-				req := displayList[m.requestToDelete]
-				m.storage.DeleteRequest(req.ID)
-				m.savedRequests = m.storage.GetRequests()
-				if m.searchInput.Value() != "" {
-					m.filteredRequests = m.storage.FilterRequests(m.searchInput.Value())
-				} else {
-					m.filteredRequests = nil
-				}
-				displayList = m.savedRequests
-				if m.filteredRequests != nil {
-					displayList = m.filteredRequests
-				}
-				if m.selectedReqIdx >= len(displayList) && m.selectedReqIdx > 0 {
-					m.selectedReqIdx--
-				}
-			}
-			m.confirmingDelete = false
-			return m, nil
+	case transferTickMsg:
+		if m.transfer != nil {
+			return m, transferTickCmd()
 		}
 		return m, nil
-
-	case "n":
-		m.method = "GET"
-		m.urlInput.SetValue("")
-		m.headers = make(map[string]string)
-		m.body = ""
-		m.state = StateRequestBuilder
-		return m, nil
 	}
 
-	return m, nil
-}
-
-func (m Model) handleHelpKeys(_ tea.KeyMsg) (tea.Model, tea.Cmd) {
-	m.state = StateRequestBuilder
-	return m, nil
+	return m, cmd
 }
 
-func (m *Model) validateURL(urlStr string) error {
-	if urlStr == "" {
-		return fmt.Errorf("url cannot be empty")
-	}
-
-	parsedURL, err := url.Parse(urlStr)
-	if err != nil {
-		return fmt.Errorf("invalid url: %v", err)
-	}
-
-	if parsedURL.Scheme == "" {
-		return fmt.Errorf("url must include protocol (http:// or https://)")
+func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.state == StateRequestBuilder && m.namingVariant {
+		return m.handleVariantNameKeys(msg)
 	}
 
-	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
-		return fmt.Errorf("protocol must be http or https")
+	if m.state == StateRequestBuilder && m.namingSession {
+		return m.handleSessionNameKeys(msg)
 	}
 
-	if parsedURL.Host == "" {
-		return fmt.Errorf("url must include a valid host")
+	if m.state == StateRequestBuilder && m.choosingDownload {
+		return m.handleDownloadPathKeys(msg)
 	}
 
-	return nil
+	return m.handleKeyPress(msg)
 }
 
-func (m *Model) validateJSON(body string) error {
-	if body == "" {
-		return nil
-	}
-
-	var js interface{}
-	if err := json.Unmarshal([]byte(body), &js); err != nil {
-		return fmt.Errorf("invalid json: %v", err)
-	}
-	return nil
+func (m Model) handleHelpKeys(_ tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.state = StateRequestBuilder
+	return m, nil
 }
 
-func (m *Model) buildURLWithQueryParams() string {
-	baseURL := m.urlInput.Value()
-	if len(m.queryParams) == 0 {
-		return baseURL
-	}
-
-	parsedURL, err := url.Parse(baseURL)
-	if err != nil {
-		return baseURL
+func (m *Model) sendRequest() tea.Cmd {
+	if m.readOnly && !isSafeMethod(m.builder.method) {
+		return func() tea.Msg {
+			return responseMsg(httpclient.Response{
+				Error: fmt.Errorf("read-only mode: %s requests are disabled", m.builder.method),
+			})
+		}
 	}
 
-	q := parsedURL.Query()
-	for key, value := range m.queryParams {
-		q.Set(key, value)
+	// Aliases and variables are resolved first, so "users-api/42" and
+	// "{{API_URL}}/users" validate as the URL that is sent. A request that
+	// fails a check is not sent; the builder shows the checklist instead.
+	req := m.buildRequest()
+	if checks := m.checkRequest(req); sendChecksFailed(checks) {
+		m.builder.sendChecks = checks
+		return nil
 	}
-	parsedURL.RawQuery = q.Encode()
-
-	return parsedURL.String()
-}
-
-func (m Model) sendRequest() tea.Cmd {
-	urlStr := m.urlInput.Value()
-
-	if err := m.validateURL(urlStr); err != nil {
+	m.builder.sendChecks = nil
+	// A client certificate that cannot be loaded fails the send rather
+	// than going out without it
+	if err := m.applyTLS(); err != nil {
 		return func() tea.Msg {
-			resp := httpclient.Response{
-				Error: err,
-			}
-			return responseMsg(resp)
+			return responseMsg(httpclient.Response{Error: err})
 		}
 	}
 
 	m.state = StateLoading
 	m.loading = true
-	m.scrollOffset = 0
-	m.urlError = ""
+	m.viewer.scrollOffset = 0
+	m.builder.urlError = ""
+
+	plugins := m.plugins
+	signer := m.signer()
+	client := m.requestClient()
+	cmds := []tea.Cmd{m.spinner.Tick}
+
+	// Every send can be canceled with Esc, auth plugins included.
+	// Downloads and multipart bodies are streamed with a progress bar;
+	// other requests count the bytes of the response as they arrive.
+	var ctx context.Context
+	var send func(httpclient.Request) httpclient.Response
+	if path := m.downloadPath; path != "" {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(context.Background())
+		download := newTransfer(cancel, true)
+		m.transfer = download
+		send = func(req httpclient.Request) httpclient.Response {
+			return client.Download(ctx, req, path, download.report)
+		}
+		cmds = append(cmds, transferTickCmd())
+	} else if isUpload(req) {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(context.Background())
+		upload := newTransfer(cancel, false)
+		m.transfer = upload
+		send = func(req httpclient.Request) httpclient.Response {
+			return client.SendWithProgress(ctx, req, upload.report)
+		}
+		cmds = append(cmds, transferTickCmd())
+	} else {
+		ctx = m.startOperation(opRequest)
+		op := m.operation
+		send = func(req httpclient.Request) httpclient.Response {
+			return client.SendReceiving(ctx, req, op.receive)
+		}
+	}
+
+	return tea.Batch(append(cmds, func() tea.Msg {
+		req, err := authorizeWithPlugins(ctx, plugins, req)
+		if err != nil {
+			return responseMsg(httpclient.Response{Error: err})
+		}
+		if signer != nil {
+			// Signing comes last so the signature covers the plugin headers too
+			if req, _, err = signer.Sign(req, time.Now()); err != nil {
+				return responseMsg(httpclient.Response{Error: err})
+			}
+		}
+		resp := send(req)
+		return responseMsg(resp)
+	})...)
+}
 
-	finalURL := m.buildURLWithQueryParams()
+// buildRequest resolves the query params and environment variables of the
+// request being edited
+func (m Model) buildRequest() httpclient.Request {
+	finalURL := m.builder.buildURLWithQueryParams()
 	finalHeaders := make(map[string]string)
-	for k, v := range m.headers {
+	for k, v := range m.builder.headers {
 		finalHeaders[k] = v
 	}
-	finalBody := m.body
+	finalBody := m.builder.body
 
+	var vars []storage.Variable
 	if m.storage != nil {
-		vars, err := m.storage.GetActiveEnvironmentVariables()
+		if aliases, err := m.storage.GetActiveAliases(); err == nil {
+			finalURL, _ = storage.ExpandAlias(finalURL, aliases)
+		}
+
+		var err error
+		vars, err = m.storage.GetActiveEnvironmentVariables()
 		if err == nil && len(vars) > 0 {
 			finalURL = storage.ReplaceVariables(finalURL, vars)
 			for k, v := range finalHeaders {
@@ -1111,488 +750,100 @@ func (m Model) sendRequest() tea.Cmd {
 		}
 	}
 
-	return tea.Batch(
-		m.spinner.Tick,
-		func() tea.Msg {
-			req := httpclient.Request{
-				Method:  m.method,
-				URL:     finalURL,
-				Headers: finalHeaders,
-				Body:    finalBody,
-			}
-			resp := m.httpClient.Send(req)
-			return responseMsg(resp)
-		},
-	)
+	req := runner.Authorize(httpclient.Request{
+		Method:        m.builder.method,
+		URL:           finalURL,
+		Headers:       finalHeaders,
+		Body:          finalBody,
+		NewConnection: m.builder.newConnection,
+	}, m.requestAuth, vars)
+	req.Timeout, req.Retry = m.sendPolicy()
+	req.NoFollowRedirects = m.requestPolicy != nil && m.requestPolicy.NoFollowRedirects
+	return req
 }
 
-func (m Model) handleEnvironmentsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "ctrl+q":
-		return m, tea.Quit
-
-	case "esc":
-		if m.confirmingDeleteEnv {
-			m.confirmingDeleteEnv = false
-			return m, nil
+// view renders the screen; View wraps it with crash recovery
+func (m Model) view() string {
+	var view string
+	if m.err != nil {
+		view = ErrorStyle.Render(fmt.Sprintf("Error: %v\nPress Ctrl+Q to quit", m.err))
+	} else {
+		view = m.withBreadcrumb(m.viewState())
+		switch {
+		case m.storageErr != nil:
+			view = m.withStorageErrorBanner(view)
+		case m.storageUnavailable() && m.state != StateStorageUnavailable:
+			view = m.withStorageUnavailableBanner(view)
+		case m.readOnly:
+			view = m.withReadOnlyBanner(view)
 		}
-		m.state = StateRequestBuilder
-		return m, nil
+	}
 
-	case "up", "k":
-		if m.selectedEnvIdx > 0 {
-			m.selectedEnvIdx--
-		}
-		return m, nil
+	if m.accessible {
+		return accessibleView(view)
+	}
+	return view
+}
 
-	case "down", "j":
-		if m.selectedEnvIdx < len(m.envList)-1 {
-			m.selectedEnvIdx++
-		}
-		return m, nil
+func (m Model) viewLoading() string {
+	if m.transfer != nil {
+		return m.viewTransfer()
+	}
 
-	case "n", "a":
-		m.envNameInput.SetValue("")
-		m.envNameInput.Focus()
-		m.currentEnvName = ""
-		m.envVarList = []storage.Variable{}
-		m.selectedEnvVarIdx = 0
-		m.state = StateEnvironmentEditor
-		return m, nil
+	var b strings.Builder
 
-	case "enter":
-		if len(m.envList) > 0 && m.selectedEnvIdx < len(m.envList) {
-			env := m.envList[m.selectedEnvIdx]
-			m.currentEnvName = env.Name
-			m.envVarList = env.Variables
-			m.selectedEnvVarIdx = 0
-			m.envNameInput.SetValue(env.Name)
-			m.state = StateEnvironmentEditor
-		}
-		return m, nil
+	kind := opRequest
+	if m.operation != nil {
+		kind = m.operation.kind
+	}
+	if kind == opQuery {
+		b.WriteString(TitleStyle.Render(i18n.T("title.executing")))
+		b.WriteString("\n\n")
 
-	case "d":
-		if len(m.envList) > 0 && m.selectedEnvIdx < len(m.envList) {
-			m.confirmingDeleteEnv = true
-		}
-		return m, nil
+		query := m.db.editor.Value()
+		queryPreview := truncateWidth(query, 103, "...")
+		b.WriteString(MutedStyle.Render(queryPreview))
+		b.WriteString("\n\n")
 
-	case "y":
-		if m.confirmingDeleteEnv && len(m.envList) > 0 && m.selectedEnvIdx < len(m.envList) {
-			env := m.envList[m.selectedEnvIdx]
-			if m.storage != nil {
-				err := m.storage.DeleteEnvironment(env.Name)
-				if err == nil {
-					envConfig, _ := m.storage.LoadEnvironments()
-					if envConfig != nil {
-						m.envConfig = envConfig
-						m.envList = envConfig.Environments
-					}
-					if m.selectedEnvIdx >= len(m.envList) && m.selectedEnvIdx > 0 {
-						m.selectedEnvIdx--
-					}
-					m.envDeleteSuccess = true
-					m.envDeleteSuccessTimer = 3
-				}
-			}
-			m.confirmingDeleteEnv = false
-		}
-		return m, nil
+		loadingBox := lipgloss.NewStyle().
+			Border(roundedBorder()).
+			BorderForeground(lipgloss.Color(ColorAccent)).
+			Padding(2, 4).
+			Render(SpinnerStyle.Render(m.spinner.View()) + "  " + TextStyle.Render(i18n.T("loading.query")))
 
-	case "s":
-		if len(m.envList) > 0 && m.selectedEnvIdx < len(m.envList) {
-			env := m.envList[m.selectedEnvIdx]
-			if m.storage != nil {
-				m.storage.SetActiveEnvironment(env.Name)
-				envConfig, _ := m.storage.LoadEnvironments()
-				if envConfig != nil {
-					m.envConfig = envConfig
-					m.envList = envConfig.Environments
-				}
-				m.envSaveSuccess = true
-				m.envSaveSuccessTimer = 3
-			}
-		}
-		return m, nil
-	}
-
-	return m, nil
-}
-
-func (m Model) handleEnvironmentEditorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	var cmd tea.Cmd
-
-	if m.editingEnvVar {
-		switch msg.String() {
-		case "ctrl+c", "ctrl+q":
-			return m, tea.Quit
-		case "esc":
-			m.editingEnvVar = false
-			m.envVarKeyInput.Blur()
-			m.envVarValueInput.Blur()
-			m.envVarKeyInput.SetValue("")
-			m.envVarValueInput.SetValue("")
-			return m, nil
-		case "enter", "tab":
-			if m.envFocusIndex == 0 {
-				m.envFocusIndex = 1
-				m.envVarKeyInput.Blur()
-				m.envVarValueInput.Focus()
-				return m, nil
-			} else {
-				key := strings.TrimSpace(m.envVarKeyInput.Value())
-				value := m.envVarValueInput.Value()
-				if key != "" && m.storage != nil && m.currentEnvName != "" {
-					err := m.storage.AddVariable(m.currentEnvName, key, value)
-					if err == nil {
-						envConfig, _ := m.storage.LoadEnvironments()
-						if envConfig != nil {
-							m.envConfig = envConfig
-							m.envList = envConfig.Environments
-							for _, env := range m.envList {
-								if env.Name == m.currentEnvName {
-									m.envVarList = env.Variables
-									break
-								}
-							}
-						}
-						m.envSaveSuccess = true
-						m.envSaveSuccessTimer = 3
-					}
-				}
-				m.editingEnvVar = false
-				m.envFocusIndex = 0
-				m.envVarKeyInput.Blur()
-				m.envVarValueInput.Blur()
-				m.envVarKeyInput.SetValue("")
-				m.envVarValueInput.SetValue("")
-				return m, nil
-			}
-		default:
-			if m.envFocusIndex == 0 {
-				m.envVarKeyInput, cmd = m.envVarKeyInput.Update(msg)
-			} else {
-				m.envVarValueInput, cmd = m.envVarValueInput.Update(msg)
-			}
-			return m, cmd
-		}
-	}
-
-	switch msg.String() {
-	case "ctrl+c", "ctrl+q":
-		return m, tea.Quit
-
-	case "esc":
-		if m.confirmingDeleteEnvVar {
-			m.confirmingDeleteEnvVar = false
-			return m, nil
-		}
-		m.state = StateEnvironments
-		m.currentEnvName = ""
-		return m, nil
-
-	case "ctrl+s":
-		name := strings.TrimSpace(m.envNameInput.Value())
-		if name != "" && m.storage != nil {
-			if m.currentEnvName == "" {
-				err := m.storage.AddEnvironment(name)
-				if err == nil {
-					m.currentEnvName = name
-					envConfig, _ := m.storage.LoadEnvironments()
-					if envConfig != nil {
-						m.envConfig = envConfig
-						m.envList = envConfig.Environments
-					}
-					m.envSaveSuccess = true
-					m.envSaveSuccessTimer = 3
-				}
-			}
-		}
-		return m, nil
-
-	case "up", "k":
-		if m.selectedEnvVarIdx > 0 {
-			m.selectedEnvVarIdx--
-		}
-		return m, nil
-
-	case "down", "j":
-		if m.selectedEnvVarIdx < len(m.envVarList)-1 {
-			m.selectedEnvVarIdx++
-		}
-		return m, nil
-
-	case "n", "a":
-		m.editingEnvVar = true
-		m.envFocusIndex = 0
-		m.envVarKeyInput.SetValue("")
-		m.envVarValueInput.SetValue("")
-		m.envVarKeyInput.Focus()
-		return m, nil
-
-	case "e":
-		if len(m.envVarList) > 0 && m.selectedEnvVarIdx < len(m.envVarList) {
-			variable := m.envVarList[m.selectedEnvVarIdx]
-			m.editingEnvVar = true
-			m.envFocusIndex = 0
-			m.envVarKeyInput.SetValue(variable.Key)
-			m.envVarValueInput.SetValue(variable.Value)
-			m.envVarKeyInput.Focus()
-		}
-		return m, nil
-
-	case "d":
-		if len(m.envVarList) > 0 && m.selectedEnvVarIdx < len(m.envVarList) {
-			m.confirmingDeleteEnvVar = true
-		}
-		return m, nil
-
-	case "y":
-		if m.confirmingDeleteEnvVar && len(m.envVarList) > 0 && m.selectedEnvVarIdx < len(m.envVarList) {
-			variable := m.envVarList[m.selectedEnvVarIdx]
-			if m.storage != nil && m.currentEnvName != "" {
-				err := m.storage.DeleteVariable(m.currentEnvName, variable.Key)
-				if err == nil {
-					envConfig, _ := m.storage.LoadEnvironments()
-					if envConfig != nil {
-						m.envConfig = envConfig
-						m.envList = envConfig.Environments
-						for _, env := range m.envList {
-							if env.Name == m.currentEnvName {
-								m.envVarList = env.Variables
-								break
-							}
-						}
-					}
-					if m.selectedEnvVarIdx >= len(m.envVarList) && m.selectedEnvVarIdx > 0 {
-						m.selectedEnvVarIdx--
-					}
-					m.envDeleteSuccess = true
-					m.envDeleteSuccessTimer = 3
-				}
-			}
-			m.confirmingDeleteEnvVar = false
-		}
-		return m, nil
-	}
-
-	return m, nil
-}
-
-func (m Model) View() string {
-	if m.err != nil {
-		return ErrorStyle.Render(fmt.Sprintf("Error: %v\nPress Ctrl+Q to quit", m.err))
-	}
-
-	switch m.state {
-	case StateHome:
-		return m.viewHome()
-	case StateRequestBuilder:
-		return m.viewRequestBuilder()
-	case StateLoading:
-		return m.viewLoading()
-	case StateViewResponse:
-		return m.viewResponse()
-	case StateRequestList:
-		return m.viewRequestList()
-	case StateHeaderEditor:
-		return m.viewHeaderEditor()
-	case StateBodyEditor:
-		return m.viewBodyEditor()
-	case StateQueryEditor:
-		return m.viewQueryEditor()
-	case StateHelp:
-		return m.viewHelp()
-	case StateHistory:
-		return m.viewHistory()
-	case StateDatabase:
-		return m.viewDatabase()
-	case StateDatabaseConnect:
-		return m.viewDatabaseConnect()
-	case StateDatabaseQueryEditor:
-		return m.viewDatabaseQueryEditor()
-	case StateDatabaseResult:
-		return m.viewDatabaseResult()
-	case StateDatabaseQueryList:
-		return m.viewDatabaseQueryList()
-	case StateDatabaseSchema:
-		return m.viewDatabaseSchema()
-	case StateDatabaseQueryHistory:
-		return m.viewDatabaseQueryHistory()
-	case StateDatabaseExport:
-		return m.viewDatabaseExport()
-	case StateEnvironments:
-		return m.viewEnvironments()
-	case StateEnvironmentEditor:
-		return m.viewEnvironmentEditor()
-	}
-
-	return ""
-}
-
-func (m Model) viewRequestBuilder() string {
-	var b strings.Builder
-
-	title := "GoDev v0.4.0"
-	if m.requestSaved {
-		title += " [SAVED]"
-	}
-	if m.envConfig != nil && m.envConfig.ActiveEnvironment != "" {
-		title += fmt.Sprintf(" [ENV: %s]", m.envConfig.ActiveEnvironment)
-	}
-	b.WriteString(TitleStyle.Render(title))
-	b.WriteString("\n\n")
-
-	methodLabel := "Method: "
-	methodSection := methodLabel
-	if m.focusIndex == 0 {
-		methodSection = TextStyle.Render(methodLabel) + ButtonActive.Render("[ "+m.method+" ▾ ]")
-	} else {
-		methodSection = MutedStyle.Render(methodLabel) + TextStyle.Render(m.method+" ▾")
-	}
-	b.WriteString(methodSection)
-	b.WriteString("\n\n")
-
-	urlLabel := "URL: "
-	b.WriteString(TextStyle.Render(urlLabel))
-	b.WriteString("\n")
-
-	if m.focusIndex == 1 {
-		inputView := m.urlInput.View()
-		styledInput := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color(ColorAccent)).
-			Padding(0, 1).
-			Width(m.urlInput.Width + 2).
-			Render(inputView)
-		b.WriteString(styledInput)
-	} else {
-		inputView := m.urlInput.View()
-		styledInput := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color(ColorBorder)).
-			Padding(0, 1).
-			Width(m.urlInput.Width + 2).
-			Render(inputView)
-		b.WriteString(styledInput)
-	}
-	b.WriteString("\n")
-
-	if len(m.queryParams) > 0 {
-		finalURL := m.buildURLWithQueryParams()
-		b.WriteString(MutedStyle.Render(fmt.Sprintf("    → Final URL: %s", finalURL)))
-		b.WriteString("\n")
-	}
-	b.WriteString("\n")
-
-	queryCount := len(m.queryParams)
-	queryText := fmt.Sprintf("Query Params: (%d)", queryCount)
-	if m.focusIndex == 2 {
-		b.WriteString(ButtonActive.Render("[ " + queryText + " ]"))
-	} else {
-		b.WriteString(MutedStyle.Render(queryText))
-	}
-	b.WriteString("\n")
-
-	headersCount := len(m.headers)
-	headersText := fmt.Sprintf("Headers: (%d)", headersCount)
-	if m.focusIndex == 3 {
-		b.WriteString(ButtonActive.Render("[ " + headersText + " ]"))
-	} else {
-		b.WriteString(MutedStyle.Render(headersText))
-	}
-	b.WriteString("\n")
-
-	bodyPreview := "empty"
-	if m.body != "" {
-		bodyStr := strings.ReplaceAll(m.body, "\n", " ")
-		bodyStr = strings.TrimSpace(bodyStr)
-		if len(bodyStr) > 80 {
-			bodyPreview = bodyStr[:80] + "..."
-		} else {
-			bodyPreview = bodyStr
-		}
-	}
-	bodyText := fmt.Sprintf("Body: (%s)", bodyPreview)
-	if m.focusIndex == 4 {
-		b.WriteString(ButtonActive.Render("[ " + bodyText + " ]"))
-	} else {
-		b.WriteString(MutedStyle.Render(bodyText))
-	}
-	b.WriteString("\n\n")
-
-	buttons := RenderButton("Send Request", m.focusIndex == 5) + "  "
-	buttons += RenderButton("Load Saved", m.focusIndex == 6) + "  "
-	buttons += RenderButton("Quit", m.focusIndex == 7)
-	b.WriteString(buttons)
-
-	b.WriteString("\n")
-
-	if m.curlCopySuccess {
-		b.WriteString(SuccessStyle.Render("✓ cURL command copied to clipboard!"))
-		b.WriteString("\n")
-	}
-
-	b.WriteString("\n")
-	b.WriteString(RenderFooter("Ctrl+H: help • Ctrl+Enter: send • Ctrl+L: load • Ctrl+R: history • Ctrl+D: database • Ctrl+E: env • h: headers • b: body • q: query • s: save • x: cURL"))
-
-	return Center(m.width, m.height, b.String())
-}
-
-func (m Model) viewLoading() string {
-	var b strings.Builder
-
-	if m.dbClient != nil && m.dbClient.IsConnected() && m.dbQueryEditor.Value() != "" {
-		b.WriteString(TitleStyle.Render("Executing Query"))
-		b.WriteString("\n\n")
-
-		query := m.dbQueryEditor.Value()
-		queryPreview := query
-		if len(queryPreview) > 100 {
-			queryPreview = queryPreview[:100] + "..."
-		}
-		b.WriteString(MutedStyle.Render(queryPreview))
-		b.WriteString("\n\n")
-
-		loadingBox := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color(ColorAccent)).
-			Padding(2, 4).
-			Render(SpinnerStyle.Render(m.spinner.View()) + "  " + TextStyle.Render("Executing query..."))
-
-		b.WriteString(loadingBox)
-		b.WriteString("\n\n")
-		b.WriteString(MutedStyle.Render("Please wait while the database processes your query"))
-	} else if m.dbClient != nil && m.dbQueryEditor.Value() == "" {
-		b.WriteString(TitleStyle.Render("Connecting to Database"))
-		b.WriteString("\n\n")
+		b.WriteString(loadingBox)
+		b.WriteString("\n\n")
+		b.WriteString(MutedStyle.Render(i18n.T("loading.query_hint")))
+	} else if kind == opSchema {
+		b.WriteString(TitleStyle.Render(i18n.T("title.connecting")))
+		b.WriteString("\n\n")
 
 		connectionInfo := fmt.Sprintf("%s:%s/%s",
-			m.dbConnectHostInput.Value(),
-			m.dbConnectPortInput.Value(),
-			m.dbConnectDatabaseInput.Value())
+			m.db.hostInput.Value(),
+			m.db.portInput.Value(),
+			m.db.nameInput.Value())
 		b.WriteString(TextStyle.Render(connectionInfo))
 		b.WriteString("\n\n")
 
 		loadingBox := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
+			Border(roundedBorder()).
 			BorderForeground(lipgloss.Color(ColorAccent)).
 			Padding(2, 4).
-			Render(SpinnerStyle.Render(m.spinner.View()) + "  " + TextStyle.Render("Loading database schema..."))
+			Render(SpinnerStyle.Render(m.spinner.View()) + "  " + TextStyle.Render(i18n.T("loading.schema")))
 
 		b.WriteString(loadingBox)
 		b.WriteString("\n\n")
-		b.WriteString(MutedStyle.Render("Fetching tables and database information"))
+		b.WriteString(MutedStyle.Render(i18n.T("loading.schema_hint")))
 	} else {
-		b.WriteString(TitleStyle.Render("Sending Request"))
+		b.WriteString(TitleStyle.Render(i18n.T("title.sending")))
 		b.WriteString("\n\n")
 
-		requestInfo := fmt.Sprintf("%s %s", m.method, m.urlInput.Value())
+		requestInfo := fmt.Sprintf("%s %s", m.builder.method, m.builder.urlInput.Value())
 		b.WriteString(TextStyle.Render(requestInfo))
 		b.WriteString("\n\n")
 
 		loadingBox := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
+			Border(roundedBorder()).
 			BorderForeground(lipgloss.Color(ColorAccent)).
 			Padding(2, 4).
 			Render(SpinnerStyle.Render(m.spinner.View()) + "  " + TextStyle.Render("Loading..."))
@@ -1602,1690 +853,194 @@ func (m Model) viewLoading() string {
 		b.WriteString(MutedStyle.Render("Please wait while we fetch the response"))
 	}
 
-	return Center(m.width, m.height, b.String())
-}
-
-func (m Model) viewResponse() string {
-	if m.response == nil {
-		return Center(m.width, m.height, ErrorStyle.Render("No response"))
-	}
-
-	var b strings.Builder
-
-	title := "Response"
-	if m.viewResponseHeaders {
-		title = "Response Headers"
-	}
-	b.WriteString(TitleStyle.Render(title))
-	b.WriteString("\n\n")
-
-	requestInfo := fmt.Sprintf("%s %s", m.method, m.buildURLWithQueryParams())
-	b.WriteString(MutedStyle.Render(requestInfo))
-	b.WriteString("\n\n")
-
-	if m.saveSuccess {
-		b.WriteString(SuccessStyle.Render("✓ Request saved successfully!"))
-		b.WriteString("\n\n")
-	}
-
-	if m.curlCopySuccess {
-		b.WriteString(SuccessStyle.Render("✓ cURL command copied to clipboard!"))
-		b.WriteString("\n\n")
-	}
-
-	if m.response.Error != nil {
-		errorPanel := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color(ColorError)).
-			Padding(1, 2).
-			Width(m.width - 10).
-			Render(ErrorStyle.Render(fmt.Sprintf("Error: %v", m.response.Error)))
-		b.WriteString(errorPanel)
-	} else {
-		statusStyle := GetStatusStyle(m.response.StatusCode)
-		statusLine := fmt.Sprintf("Status: %s • %s • %s",
-			m.response.Status,
-			httpclient.FormatDuration(m.response.ResponseTime),
-			httpclient.FormatSize(m.response.Size))
-		b.WriteString(statusStyle.Render(statusLine))
-		b.WriteString("\n\n")
-
-		if m.copySuccess {
-			b.WriteString(SuccessStyle.Render("✓ Copied to clipboard!"))
-			b.WriteString("\n\n")
-		}
-
-		var content string
-		if m.viewResponseHeaders {
-			var headerLines []string
-			for key, values := range m.response.Headers {
-				for _, value := range values {
-					headerLines = append(headerLines, fmt.Sprintf("%-30s : %s", key, value))
-				}
-			}
-			content = strings.Join(headerLines, "\n")
-		} else {
-			content = m.response.Body
-		}
-
-		maxLines := m.height - 17
-		lines := strings.Split(content, "\n")
-		totalLines := len(lines)
-
-		start := m.scrollOffset
-		end := start + maxLines
-		if end > totalLines {
-			end = totalLines
-		}
-		if start >= totalLines {
-			start = totalLines - maxLines
-			if start < 0 {
-				start = 0
-			}
-			m.scrollOffset = start
-		}
-
-		responsePanel := ""
-		if start < totalLines {
-			visibleLines := lines[start:end]
-			responseContent := strings.Join(visibleLines, "\n")
-
-			scrollInfo := ""
-			if totalLines > maxLines {
-				scrollInfo = fmt.Sprintf("\n\n%s Lines %d-%d of %d",
-					MutedStyle.Render("│"),
-					start+1,
-					end,
-					totalLines)
-			}
-
-			responsePanel = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color(ColorBorder)).
-				Padding(1, 2).
-				Width(m.width - 10).
-				Render(CodeStyle.Render(responseContent) + scrollInfo)
-		}
-		b.WriteString(responsePanel)
-	}
-
-	b.WriteString("\n\n")
-
-	buttons := RenderButton("Back (Esc)", true) + "  "
-	buttons += RenderButton("Save (s)", false) + "  "
-	if m.response.Error == nil {
-		buttons += RenderButton("Copy (c)", false) + "  "
-		if m.viewResponseHeaders {
-			buttons += RenderButton("Body (h)", false)
-		} else {
-			buttons += RenderButton("Headers (h)", false)
-		}
-	}
-	b.WriteString(buttons)
-
-	b.WriteString("\n\n")
-	b.WriteString(RenderFooter("Esc: back • s: save • c: copy response • x: copy as cURL • h: toggle headers • ↑↓: scroll"))
-
-	return Center(m.width, m.height, b.String())
-}
-
-func (m Model) viewRequestList() string {
-	var b strings.Builder
-
-	title := fmt.Sprintf("Saved Requests (%d)", len(m.savedRequests))
-	b.WriteString(TitleStyle.Render(title))
-	b.WriteString("\n\n")
-
-	if m.searchActive || m.searchInput.Value() != "" {
-		searchLabel := "Search: "
-		b.WriteString(TextStyle.Render(searchLabel))
-		b.WriteString("\n")
-
-		inputView := m.searchInput.View()
-		var styledInput string
-		if m.searchActive {
-			styledInput = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color(ColorAccent)).
-				Padding(0, 1).
-				Width(m.searchInput.Width + 2).
-				Render(inputView)
-		} else {
-			styledInput = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color(ColorBorder)).
-				Padding(0, 1).
-				Width(m.searchInput.Width + 2).
-				Render(inputView)
-		}
-		b.WriteString(styledInput)
-		b.WriteString("\n\n")
-	}
-
-	displayList := m.savedRequests
-	if m.filteredRequests != nil {
-		displayList = m.filteredRequests
-	}
-
-	if len(displayList) == 0 {
-		if m.searchInput.Value() != "" {
-			b.WriteString(MutedStyle.Render("No matching requests"))
-		} else {
-			b.WriteString(MutedStyle.Render("No saved requests"))
-		}
-	} else {
-		for i, req := range displayList {
-			if i == m.selectedReqIdx {
-				b.WriteString(ListItemSelectedStyle.Render("> " + req.Name))
-				b.WriteString("  ")
-				b.WriteString(ButtonActive.Render(req.Method))
-			} else {
-				b.WriteString(ListItemStyle.Render(req.Name))
-				b.WriteString("  ")
-				b.WriteString(MutedStyle.Render(req.Method))
-			}
-			b.WriteString("\n")
-		}
-	}
-
-	b.WriteString("\n\n")
-
-	if m.confirmingDelete && len(displayList) > 0 && m.requestToDelete < len(displayList) {
-		confirmMsg := fmt.Sprintf("⚠ Delete '%s'? Press 'y' to confirm, 'Esc' to cancel", displayList[m.requestToDelete].Name)
-		b.WriteString(WarningStyle.Render(confirmMsg))
+	if status := m.viewOperation(); status != "" {
 		b.WriteString("\n\n")
+		b.WriteString(status)
 	}
 
-	b.WriteString(RenderFooter("↑↓: navigate • /: search • Enter: load • d: delete • n: new • Esc: back"))
-
 	return Center(m.width, m.height, b.String())
 }
 
 func (m Model) viewHelp() string {
 	var b strings.Builder
 
-	b.WriteString(TitleStyle.Render("GoDev - Help"))
+	b.WriteString(TitleStyle.Render(i18n.T("help.title")))
 	b.WriteString("\n\n")
 
-	b.WriteString(HeaderStyle.Render("Global Shortcuts:"))
-	b.WriteString("\n")
-	b.WriteString(TextStyle.Render("  Ctrl+Q        Quit application"))
+	b.WriteString(HeaderStyle.Render(i18n.T("help.global")))
 	b.WriteString("\n")
-	b.WriteString(TextStyle.Render("  Ctrl+?        Show this help"))
+	b.WriteString(helpLine("Ctrl+Q", i18n.T("help.quit")))
+	b.WriteString(helpLine("Ctrl+?", i18n.T("help.show_help")))
+	b.WriteString(helpLine("Esc", i18n.T("help.back")))
+	b.WriteString(helpLine("Tab", i18n.T("help.next_field")))
 	b.WriteString("\n")
-	b.WriteString(TextStyle.Render("  Esc           Back/Cancel"))
-	b.WriteString("\n")
-	b.WriteString(TextStyle.Render("  Tab           Next field"))
-	b.WriteString("\n\n")
 
-	b.WriteString(HeaderStyle.Render("Request Builder:"))
+	b.WriteString(HeaderStyle.Render(i18n.T("help.request_builder")))
 	b.WriteString("\n")
-	b.WriteString(TextStyle.Render("  Enter         Send request"))
+	b.WriteString(helpLine("Enter", i18n.T("help.send")))
+	b.WriteString(helpLine("Ctrl+L", i18n.T("help.load_saved")))
+	b.WriteString(helpLine("Ctrl+R", i18n.T("help.history")))
+	b.WriteString(helpLine("←/→", i18n.T("help.change_method")))
+	b.WriteString(helpLine("g", i18n.T("help.graphql_schema")))
+	b.WriteString(helpLine("o", i18n.T("help.graphql_ops")))
+	b.WriteString(helpLine("G", i18n.T("help.graphql_mode")))
+	b.WriteString(helpLine("u", i18n.T("help.bulk")))
+	b.WriteString(helpLine("v", i18n.T("help.variant")))
+	b.WriteString(helpLine("a", i18n.T("help.signing")))
+	b.WriteString(helpLine("A", i18n.T("help.auth")))
+	b.WriteString(helpLine("d", i18n.T("help.dns")))
+	b.WriteString(helpLine("K", i18n.T("help.cookies")))
+	b.WriteString(helpLine("J", i18n.T("help.jwt")))
+	b.WriteString(helpLine("U", i18n.T("help.utilities")))
+	b.WriteString(helpLine("P", i18n.T("help.proxy")))
+	b.WriteString(helpLine("L", i18n.T("help.tls")))
+	b.WriteString(helpLine("i", i18n.T("help.inspect")))
+	b.WriteString(helpLine("c", i18n.T("help.curl_import")))
+	b.WriteString(helpLine("f", i18n.T("help.download")))
+	b.WriteString(helpLine("N", i18n.T("help.new_conn")))
+	b.WriteString(helpLine("T", i18n.T("help.templates")))
+	b.WriteString(helpLine("R", i18n.T("help.record")))
 	b.WriteString("\n")
-	b.WriteString(TextStyle.Render("  Ctrl+L        Load saved requests"))
-	b.WriteString("\n")
-	b.WriteString(TextStyle.Render("  Ctrl+R        View request history"))
-	b.WriteString("\n")
-	b.WriteString(TextStyle.Render("  ←/→           Change method"))
-	b.WriteString("\n\n")
 
-	b.WriteString(HeaderStyle.Render("Response View:"))
+	b.WriteString(HeaderStyle.Render(i18n.T("help.response_view")))
 	b.WriteString("\n")
-	b.WriteString(TextStyle.Render("  s             Save request"))
+	b.WriteString(helpLine("s", i18n.T("help.save_request")))
+	b.WriteString(helpLine("v", i18n.T("help.plugin_viewer")))
+	b.WriteString(helpLine("e", i18n.T("help.fix_resend")))
+	b.WriteString(helpLine("a", i18n.T("help.assertions")))
+	b.WriteString(helpLine("E", i18n.T("help.extractions")))
+	b.WriteString(helpLine("w", i18n.T("help.save_body")))
+	b.WriteString(helpLine("|", i18n.T("help.split")))
+	b.WriteString(helpLine("G/g", i18n.T("help.golden")))
+	b.WriteString(helpLine("i", i18n.T("help.volatile")))
+	b.WriteString(helpLine("T", i18n.T("help.timestamps")))
+	b.WriteString(helpLine("↑/↓", i18n.T("help.scroll")))
 	b.WriteString("\n")
-	b.WriteString(TextStyle.Render("  ↑/↓           Scroll"))
-	b.WriteString("\n\n")
 
-	b.WriteString(HeaderStyle.Render("Request List:"))
+	b.WriteString(HeaderStyle.Render(i18n.T("help.request_list")))
 	b.WriteString("\n")
-	b.WriteString(TextStyle.Render("  Enter         Load request"))
+	b.WriteString(helpLine("Enter", i18n.T("help.load_request")))
+	b.WriteString(helpLine("d", i18n.T("help.trash_request")))
+	b.WriteString(helpLine("n", i18n.T("help.new_request")))
+	b.WriteString(helpLine("g", i18n.T("help.group_hosts")))
+	b.WriteString(helpLine("c", i18n.T("help.collections")))
+	b.WriteString(helpLine("r", i18n.T("help.run_all")))
+	b.WriteString(helpLine("Ctrl+T", i18n.T("help.density")))
 	b.WriteString("\n")
-	b.WriteString(TextStyle.Render("  d             Delete request"))
-	b.WriteString("\n")
-	b.WriteString(TextStyle.Render("  n             New request"))
-	b.WriteString("\n\n")
 
-	b.WriteString(RenderFooter("Press any key to close"))
+	b.WriteString(RenderFooter(i18n.T("help.close")))
 
 	return Center(m.width, m.height, b.String())
 }
 
-func (m Model) handleHistoryKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+// helpLine renders one shortcut of the help screen
+func helpLine(keys, description string) string {
+	return TextStyle.Render("  "+padRightWidth(keys, 14)+description) + "\n"
+}
+
+func (m Model) handleHomeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
-	case "ctrl+c", "ctrl+q":
+	case "ctrl+c", "ctrl+q", "q":
 		return m, tea.Quit
 
-	case "esc":
-		if m.confirmingClearHistory {
-			m.confirmingClearHistory = false
-			return m, nil
-		}
+	case "1", "a":
 		m.state = StateRequestBuilder
+		m.builder.urlInput.Focus()
 		return m, nil
 
-	case "up", "k":
-		if m.selectedHistoryIdx > 0 {
-			m.selectedHistoryIdx--
-		}
+	case "2", "d":
+		m.state = StateDatabase
 		return m, nil
 
-	case "down", "j":
-		if m.selectedHistoryIdx < len(m.history)-1 {
-			m.selectedHistoryIdx++
-		}
+	case "3", "r":
+		m.rawSocket.open(&m)
 		return m, nil
 
-	case "enter":
-		if len(m.history) > 0 && m.selectedHistoryIdx < len(m.history) {
-			exec := m.history[m.selectedHistoryIdx]
-			m.method = exec.Method
-			m.urlInput.SetValue(exec.URL)
-			m.headers = exec.Headers
-			m.body = exec.Body
-			if exec.QueryParams != nil {
-				m.queryParams = exec.QueryParams
-			} else {
-				m.queryParams = make(map[string]string)
-			}
-			m.state = StateRequestBuilder
-			m.requestSaved = false
-		}
+	case "4", "l":
+		m.dnsLookup.open(&m, "", StateHome)
 		return m, nil
 
-	case "d":
-		if len(m.history) > 0 && m.selectedHistoryIdx < len(m.history) {
-			exec := m.history[m.selectedHistoryIdx]
-			if m.storage != nil {
-				m.storage.DeleteHistoryItem(exec.ID)
-				m.history = m.storage.GetHistory()
-				if m.selectedHistoryIdx >= len(m.history) && m.selectedHistoryIdx > 0 {
-					m.selectedHistoryIdx--
-				}
-			}
-		}
+	case "5":
+		m.utilities.open(&m, "", StateHome)
 		return m, nil
 
-	case "c":
-		if len(m.history) > 0 {
-			if !m.confirmingClearHistory {
-				m.confirmingClearHistory = true
-				return m, nil
-			}
-		}
+	case "6":
+		m.timestamps.open(&m, nil, StateHome)
 		return m, nil
 
-	case "y":
-		if m.confirmingClearHistory && m.storage != nil {
-			m.storage.ClearHistory()
-			m.history = m.storage.GetHistory()
-			m.selectedHistoryIdx = 0
-			m.confirmingClearHistory = false
-			return m, nil
-		}
+	case "p":
+		m.openProxySettings(StateHome)
 		return m, nil
-	}
-
-	return m, nil
-}
 
-func (m Model) viewHistory() string {
-	var b strings.Builder
+	case "L":
+		m.openTLSSettings(StateHome)
+		return m, nil
 
-	b.WriteString(TitleStyle.Render(fmt.Sprintf("Request History (%d)", len(m.history))))
-	b.WriteString("\n\n")
+	case "w":
+		m.workspaces.open(&m)
+		return m, nil
 
-	if len(m.history) == 0 {
-		b.WriteString(MutedStyle.Render("No request history"))
-		b.WriteString("\n\n")
-		b.WriteString(TextStyle.Render("Execute some requests to see them here"))
-	} else {
-		maxLines := m.height - 15
-		start := m.selectedHistoryIdx
-		if start > len(m.history)-maxLines {
-			start = len(m.history) - maxLines
-		}
-		if start < 0 {
-			start = 0
-		}
-		end := start + maxLines
-		if end > len(m.history) {
-			end = len(m.history)
-		}
+	case "t":
+		m.trash.open(&m)
+		return m, nil
 
-		for i := start; i < end; i++ {
-			exec := m.history[i]
-			statusStyle := TextStyle
-			statusText := "ERROR"
+	case "o":
+		m.onboarding.openTour(&m)
+		return m, nil
 
-			if exec.Error == "" {
-				statusStyle = GetStatusStyle(exec.StatusCode)
-				statusText = exec.Status
-			}
+	case "n":
+		m.onboarding.openWhatsNew(&m)
+		return m, nil
 
-			timestamp := exec.Timestamp.Format("15:04:05")
-			line := fmt.Sprintf("%s  %s  %s", timestamp, exec.Method, exec.URL)
+	case "u":
+		m.openStats()
+		return m, nil
 
-			if i == m.selectedHistoryIdx {
-				b.WriteString(ListItemSelectedStyle.Render("> " + line))
-				b.WriteString("\n")
-				b.WriteString(MutedStyle.Render(fmt.Sprintf("    %s • %dms", statusStyle.Render(statusText), exec.ResponseTime)))
-			} else {
-				b.WriteString(ListItemStyle.Render(line))
-				b.WriteString("\n")
-				b.WriteString(MutedStyle.Render(fmt.Sprintf("    %s • %dms", statusStyle.Render(statusText), exec.ResponseTime)))
-			}
-			b.WriteString("\n")
+	case "S":
+		if m.storageUnavailable() {
+			m.openStorageUnavailable()
 		}
-	}
-
-	b.WriteString("\n")
+		return m, nil
 
-	if m.confirmingClearHistory {
-		b.WriteString(WarningStyle.Render("⚠ Clear all history? Press 'y' to confirm, 'Esc' to cancel"))
-		b.WriteString("\n\n")
+	case "?", "f1":
+		m.state = StateHelp
+		return m, nil
 	}
 
-	b.WriteString(RenderFooter("↑↓: navigate • Enter: load • d: delete item • c: clear all • Esc: back"))
-
-	return Center(m.width, m.height, b.String())
+	return m, nil
 }
 
-func (m Model) handleDatabaseKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "ctrl+q":
-		return m, tea.Quit
-
-	case "esc":
-		if m.dbClient != nil && m.dbClient.IsConnected() {
-			m.dbClient.Close()
-		}
-		m.state = StateRequestBuilder
-		return m, nil
-
-	case "c":
-		m.state = StateDatabaseConnect
-		m.dbConnectFocusIndex = 0
-		m.updateDatabaseConnectFocus()
-		return m, nil
-
-	case "q":
-		if m.dbClient != nil && m.dbClient.IsConnected() {
-			m.state = StateDatabaseQueryEditor
-			m.dbQueryEditor.Focus()
-			return m, nil
-		}
-		return m, nil
-
-	case "l":
-		if m.dbClient != nil && m.dbClient.IsConnected() {
-			m.state = StateDatabaseQueryList
-			m.dbSelectedQueryIdx = 0
-			return m, nil
-		}
-		return m, nil
-
-	case "s", "t":
-		if m.dbClient != nil && m.dbClient.IsConnected() {
-			m.state = StateDatabaseSchema
-			return m, nil
-		}
-		return m, nil
-
-	case "h":
-		if m.dbClient != nil && m.dbClient.IsConnected() {
-			if m.dbStorage != nil {
-				m.dbQueryHistory = m.dbStorage.GetQueryHistory()
-			}
-			m.state = StateDatabaseQueryHistory
-			m.dbSelectedQueryHistoryIdx = 0
-			m.dbConfirmingClearQueryHistory = false
-			return m, nil
-		}
-		return m, nil
-
-	case "d":
-		if m.dbClient != nil && m.dbClient.IsConnected() {
-			m.dbClient.Close()
-			return m, nil
-		}
-		return m, nil
-	}
-
-	return m, nil
-}
-
-func (m Model) viewDatabase() string {
-	var b strings.Builder
-
-	b.WriteString(TitleStyle.Render("Database Explorer (PostgreSQL)"))
-	b.WriteString("\n\n")
-
-	if m.dbClient == nil || !m.dbClient.IsConnected() {
-		b.WriteString(TextStyle.Render("Welcome to the Database Explorer!"))
-		b.WriteString("\n\n")
-		b.WriteString(MutedStyle.Render("Connect to a PostgreSQL database to start"))
-		b.WriteString("\n\n")
-
-		menuPanel := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color(ColorAccent)).
-			Padding(1, 2).
-			Width(m.width - 10).
-			Render(HeaderStyle.Render("Actions") + "\n\n" +
-				ButtonActive.Render("[ c ] Connect to Database") + "\n\n" +
-				MutedStyle.Render("Press 'c' to open the connection form"))
-
-		b.WriteString(menuPanel)
-		b.WriteString("\n\n")
-
-		b.WriteString(MutedStyle.Render("Features: Execute SQL • Save Queries • Browse Tables • Query History"))
-	} else {
-		connectionInfo := m.dbClient.GetConnectionString()
-		b.WriteString(SuccessStyle.Render("✓ Connected to: " + connectionInfo))
-		b.WriteString("\n\n")
-
-		menuPanel := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color(ColorBorder)).
-			Padding(1, 2).
-			Width(m.width - 10).
-			Render(HeaderStyle.Render("Menu") + "\n\n" +
-				TextStyle.Render("  [q] Execute Query") + "\n" +
-				TextStyle.Render("  [s] Schema Browser") + "\n" +
-				TextStyle.Render("  [l] Saved Queries") + "\n" +
-				TextStyle.Render("  [h] Query History") + "\n" +
-				TextStyle.Render("  [d] Disconnect") + "\n")
-
-		b.WriteString(menuPanel)
-	}
-
-	b.WriteString("\n\n")
-	b.WriteString(RenderFooter("q: query • s: schema • l: saved queries • h: history • d: disconnect • Esc: back"))
-
-	return Center(m.width, m.height, b.String())
-}
-
-func (m Model) handleDatabaseConnectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	var cmd tea.Cmd
-
-	switch msg.String() {
-	case "ctrl+c", "ctrl+q":
-		return m, tea.Quit
-
-	case "esc":
-		m.state = StateDatabase
-		m.dbConnectFocusIndex = 0
-		m.dbConnectHostInput.Blur()
-		m.dbConnectPortInput.Blur()
-		m.dbConnectDatabaseInput.Blur()
-		m.dbConnectUserInput.Blur()
-		m.dbConnectPasswordInput.Blur()
-		return m, nil
-
-	case "tab":
-		m.dbConnectFocusIndex++
-		if m.dbConnectFocusIndex > 4 {
-			m.dbConnectFocusIndex = 0
-		}
-		m.updateDatabaseConnectFocus()
-		return m, nil
-
-	case "shift+tab":
-		m.dbConnectFocusIndex--
-		if m.dbConnectFocusIndex < 0 {
-			m.dbConnectFocusIndex = 4
-		}
-		m.updateDatabaseConnectFocus()
-		return m, nil
-
-	case "enter":
-		host := strings.TrimSpace(m.dbConnectHostInput.Value())
-		portStr := strings.TrimSpace(m.dbConnectPortInput.Value())
-		dbname := strings.TrimSpace(m.dbConnectDatabaseInput.Value())
-		user := strings.TrimSpace(m.dbConnectUserInput.Value())
-		password := m.dbConnectPasswordInput.Value()
-
-		if host == "" || portStr == "" || dbname == "" || user == "" {
-			return m, nil
-		}
-
-		port := 5432
-		fmt.Sscanf(portStr, "%d", &port)
-
-		config := database.ConnectionConfig{
-			Host:     host,
-			Port:     port,
-			Database: dbname,
-			User:     user,
-			Password: password,
-			SSLMode:  "disable",
-		}
-
-		err := m.dbClient.Connect(config)
-		if err != nil {
-			m.err = err
-			return m, nil
-		}
-
-		if m.dbStorage != nil {
-			m.dbStorage.SaveConnection(config)
-		}
-
-		m.state = StateLoading
-		m.loading = true
-		m.err = nil
-		return m, loadDatabaseSchemaCmd(m.dbClient)
-
-	default:
-		switch m.dbConnectFocusIndex {
-		case 0:
-			m.dbConnectHostInput, cmd = m.dbConnectHostInput.Update(msg)
-		case 1:
-			m.dbConnectPortInput, cmd = m.dbConnectPortInput.Update(msg)
-		case 2:
-			m.dbConnectDatabaseInput, cmd = m.dbConnectDatabaseInput.Update(msg)
-		case 3:
-			m.dbConnectUserInput, cmd = m.dbConnectUserInput.Update(msg)
-		case 4:
-			m.dbConnectPasswordInput, cmd = m.dbConnectPasswordInput.Update(msg)
-		}
-		return m, cmd
-	}
-}
-
-func (m *Model) updateDatabaseConnectFocus() {
-	m.dbConnectHostInput.Blur()
-	m.dbConnectPortInput.Blur()
-	m.dbConnectDatabaseInput.Blur()
-	m.dbConnectUserInput.Blur()
-	m.dbConnectPasswordInput.Blur()
-
-	switch m.dbConnectFocusIndex {
-	case 0:
-		m.dbConnectHostInput.Focus()
-	case 1:
-		m.dbConnectPortInput.Focus()
-	case 2:
-		m.dbConnectDatabaseInput.Focus()
-	case 3:
-		m.dbConnectUserInput.Focus()
-	case 4:
-		m.dbConnectPasswordInput.Focus()
-	}
-}
-
-func (m Model) viewDatabaseConnect() string {
-	var b strings.Builder
-
-	b.WriteString(TitleStyle.Render("Connect to PostgreSQL Database"))
-	b.WriteString("\n\n")
-
-	if m.err != nil {
-		b.WriteString(ErrorStyle.Render(fmt.Sprintf("✗ Connection failed: %v", m.err)))
-		b.WriteString("\n\n")
-	}
-
-	renderInput := func(label string, input textinput.Model, focused bool) string {
-		var result strings.Builder
-		result.WriteString(TextStyle.Render(label))
-		result.WriteString("\n")
-
-		inputView := input.View()
-		var styledInput string
-		if focused {
-			styledInput = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color(ColorAccent)).
-				Padding(0, 1).
-				Width(input.Width + 2).
-				Render(inputView)
-		} else {
-			styledInput = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color(ColorBorder)).
-				Padding(0, 1).
-				Width(input.Width + 2).
-				Render(inputView)
-		}
-		result.WriteString(styledInput)
-		result.WriteString("\n\n")
-		return result.String()
-	}
-
-	b.WriteString(renderInput("Host:", m.dbConnectHostInput, m.dbConnectFocusIndex == 0))
-	b.WriteString(renderInput("Port:", m.dbConnectPortInput, m.dbConnectFocusIndex == 1))
-	b.WriteString(renderInput("Database:", m.dbConnectDatabaseInput, m.dbConnectFocusIndex == 2))
-	b.WriteString(renderInput("User:", m.dbConnectUserInput, m.dbConnectFocusIndex == 3))
-	b.WriteString(renderInput("Password:", m.dbConnectPasswordInput, m.dbConnectFocusIndex == 4))
-
-	buttons := RenderButton("Connect (Enter)", true) + "  "
-	buttons += RenderButton("Cancel (Esc)", false)
-	b.WriteString(buttons)
-
-	b.WriteString("\n\n")
-	b.WriteString(RenderFooter("Tab: next field • Enter: connect • Esc: cancel"))
-
-	return Center(m.width, m.height, b.String())
-}
-
-type databaseResultMsg database.QueryResult
-
-func executeDatabaseQueryCmd(client *database.PostgresClient, query string) tea.Cmd {
-	return func() tea.Msg {
-		result := client.ExecuteQuery(query)
-		return databaseResultMsg(result)
-	}
-}
-
-func loadDatabaseSchemaCmd(client *database.PostgresClient) tea.Cmd {
-	return func() tea.Msg {
-		tables, err := client.GetTables()
-		if err != nil {
-			return databaseSchemaMsg([]string{})
-		}
-		return databaseSchemaMsg(tables)
-	}
-}
-
-func (m Model) handleDatabaseQueryEditorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	var cmd tea.Cmd
-
-	switch msg.String() {
-	case "ctrl+c", "ctrl+q":
-		return m, tea.Quit
-
-	case "esc":
-		m.state = StateDatabase
-		m.dbQueryEditor.Blur()
-		return m, nil
-
-	case "ctrl+k":
-		query := strings.TrimSpace(m.dbQueryEditor.Value())
-		if query == "" {
-			return m, nil
-		}
-
-		m.state = StateLoading
-		m.loading = true
-
-		return m, executeDatabaseQueryCmd(m.dbClient, query)
-
-	case "ctrl+s":
-		query := strings.TrimSpace(m.dbQueryEditor.Value())
-		if query == "" || m.dbStorage == nil {
-			return m, nil
-		}
-
-		name := fmt.Sprintf("Query %s", time.Now().Format("15:04:05"))
-		if !m.dbStorage.QueryExists(name) {
-			m.dbStorage.SaveQuery(name, query)
-			m.dbSavedQueries = m.dbStorage.GetQueries()
-			m.dbQuerySaveSuccess = true
-			m.dbQuerySaveSuccessTimer = 3
-		}
-		return m, nil
-
-	default:
-		m.dbQueryEditor, cmd = m.dbQueryEditor.Update(msg)
-		return m, cmd
-	}
-}
-
-func (m Model) viewDatabaseQueryEditor() string {
-	var b strings.Builder
-
-	b.WriteString(TitleStyle.Render("SQL Query Editor"))
-	b.WriteString("\n\n")
-
-	connectionInfo := m.dbClient.GetConnectionString()
-	b.WriteString(MutedStyle.Render("Connected to: " + connectionInfo))
-	b.WriteString("\n\n")
-
-	editorPanel := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color(ColorAccent)).
-		Padding(1, 2).
-		Width(m.width - 10).
-		Render(m.dbQueryEditor.View())
-
-	b.WriteString(editorPanel)
-	b.WriteString("\n\n")
-
-	buttons := RenderButton("Execute (Ctrl+K)", true) + "  "
-	buttons += RenderButton("Save (Ctrl+S)", false) + "  "
-	buttons += RenderButton("Back (Esc)", false)
-	b.WriteString(buttons)
-
-	if m.dbQuerySaveSuccess {
-		b.WriteString("\n\n")
-		b.WriteString(SuccessStyle.Render("✓ Query saved successfully"))
-	}
-
-	b.WriteString("\n\n")
-	b.WriteString(RenderFooter("Ctrl+K: execute • Ctrl+S: save query • Esc: back"))
-
-	return Center(m.width, m.height, b.String())
-}
-
-func (m Model) handleDatabaseResultKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Handle global keys first
-	if key.Matches(msg, m.keymap.Quit) {
-		return m, tea.Quit
-	}
-
-	if key.Matches(msg, m.keymap.Back) {
-		m.state = StateDatabaseQueryEditor
-		m.dbQueryEditor.Focus()
-		return m, nil
-	}
-
-	// Handle pagination controls
-	if key.Matches(msg, m.keymap.Left, m.keymap.VimLeft) {
-		if m.dbResultTable != nil && m.dbResultTable.CanPageUp() {
-			m.dbResultTable.PrevPage()
-		}
-		return m, nil
-	}
-
-	if key.Matches(msg, m.keymap.Right, m.keymap.VimRight) {
-		if m.dbResultTable != nil && m.dbResultTable.CanPageDown() {
-			m.dbResultTable.NextPage()
-		}
-		return m, nil
-	}
-
-	// Handle additional navigation for large datasets
-	if key.Matches(msg, m.keymap.Home) {
-		if m.dbResultTable != nil {
-			m.dbResultTable.FirstPage()
-		}
-		return m, nil
-	}
-
-	if key.Matches(msg, m.keymap.End) {
-		if m.dbResultTable != nil {
-			m.dbResultTable.LastPage()
-		}
-		return m, nil
-	}
-
-	if key.Matches(msg, m.keymap.PageUp) {
-		if m.dbResultTable != nil {
-			// Jump multiple pages for large datasets
-			currentPage := m.dbResultTable.GetCurrentPage()
-			targetPage := currentPage - 5
-			if targetPage < 0 {
-				targetPage = 0
-			}
-			m.dbResultTable.JumpToPage(targetPage)
-		}
-		return m, nil
-	}
-
-	if key.Matches(msg, m.keymap.PageDown) {
-		if m.dbResultTable != nil {
-			// Jump multiple pages for large datasets
-			currentPage := m.dbResultTable.GetCurrentPage()
-			totalPages := m.dbResultTable.GetTotalPages()
-			targetPage := currentPage + 5
-			if targetPage >= totalPages {
-				targetPage = totalPages - 1
-			}
-			m.dbResultTable.JumpToPage(targetPage)
-		}
-		return m, nil
-	}
-
-	// Handle database-specific actions
-	if key.Matches(msg, m.keymap.SaveQuery) {
-		query := strings.TrimSpace(m.dbQueryEditor.Value())
-		if query == "" || m.dbStorage == nil {
-			return m, nil
-		}
-
-		name := fmt.Sprintf("Query %s", time.Now().Format("15:04:05"))
-		if !m.dbStorage.QueryExists(name) {
-			m.dbStorage.SaveQuery(name, query)
-			m.dbSavedQueries = m.dbStorage.GetQueries()
-			m.dbQuerySaveSuccess = true
-			m.dbQuerySaveSuccessTimer = 3
-		}
-		return m, nil
-	}
-
-	if key.Matches(msg, m.keymap.ExportResults) {
-		if m.dbQueryResult != nil && len(m.dbQueryResult.Columns) > 0 {
-			m.state = StateDatabaseExport
-			m.dbExportFormatIdx = 0
-			m.dbExportTableName.SetValue("")
-			m.dbExportTableName.Focus()
-			return m, nil
-		}
-		return m, nil
-	}
-
-	return m, nil
-}
-
-func (m Model) viewDatabaseResult() string {
-	var b strings.Builder
-
-	b.WriteString(GetResponsiveTitleStyle(m.layout).Render("Query Result"))
-	b.WriteString("\n\n")
-
-	if m.dbQueryResult == nil {
-		b.WriteString(MutedStyle.Render("No result"))
-		return CenterResponsive(m.layout, b.String())
-	}
-
-	if m.dbQueryResult.Error != nil {
-		errorPanel := GetResponsivePanelStyle(m.layout).
-			BorderForeground(lipgloss.Color(ColorError)).
-			Render(ErrorStyle.Render(fmt.Sprintf("Error: %v", m.dbQueryResult.Error)))
-
-		b.WriteString(errorPanel)
-	} else {
-		timeInfo := fmt.Sprintf("Execution time: %dms", m.dbQueryResult.ExecutionTime.Milliseconds())
-		b.WriteString(MutedStyle.Render(timeInfo))
-		b.WriteString("\n\n")
-
-		if len(m.dbQueryResult.Columns) > 0 {
-			// Create or update the table wrapper if needed
-			if m.dbResultTable == nil || len(m.dbQueryResult.Rows) != len(m.dbResultTable.allRows) {
-				// Get responsive table dimensions
-				tableWidth, tableHeight := m.layout.GetTableDimensions()
-
-				// Create new table wrapper with all results
-				dbResultTable := NewBubblesTableWrapper(
-					m.dbQueryResult.Columns,
-					m.dbQueryResult.Rows,
-					tableWidth,
-					tableHeight,
-				)
-
-				tableContent := dbResultTable.Render()
-
-				resultPanel := GetResponsivePanelStyle(m.layout).
-					BorderForeground(lipgloss.Color(ColorBorder)).
-					Render(tableContent)
-
-				b.WriteString(resultPanel)
-				b.WriteString("\n\n")
-
-				// Show pagination summary and performance info
-				summary := dbResultTable.GetPerformanceStats()
-				b.WriteString(SuccessStyle.Render("✓ " + summary))
-
-				// Show additional info for large datasets
-				if dbResultTable.IsLargeDataset() {
-					memEstimate := dbResultTable.GetMemoryEstimate()
-					perfInfo := fmt.Sprintf("Large dataset • ~%dKB memory", memEstimate)
-					b.WriteString("\n")
-					b.WriteString(MutedStyle.Render(perfInfo))
-				}
-
-				paginationFooter := dbResultTable.RenderPaginationFooter()
-				if paginationFooter != "" {
-					b.WriteString("\n")
-					b.WriteString(MutedStyle.Render(paginationFooter))
-				}
-			} else {
-				// Use existing table wrapper
-				tableContent := m.dbResultTable.Render()
-
-				resultPanel := GetResponsivePanelStyle(m.layout).
-					BorderForeground(lipgloss.Color(ColorBorder)).
-					Render(tableContent)
-
-				b.WriteString(resultPanel)
-				b.WriteString("\n\n")
-
-				// Show pagination summary and performance info
-				summary := m.dbResultTable.GetPerformanceStats()
-				b.WriteString(SuccessStyle.Render("✓ " + summary))
-
-				// Show additional info for large datasets
-				if m.dbResultTable.IsLargeDataset() {
-					memEstimate := m.dbResultTable.GetMemoryEstimate()
-					perfInfo := fmt.Sprintf("Large dataset • ~%dKB memory", memEstimate)
-					b.WriteString("\n")
-					b.WriteString(MutedStyle.Render(perfInfo))
-				}
-
-				paginationFooter := m.dbResultTable.RenderPaginationFooter()
-				if paginationFooter != "" {
-					b.WriteString("\n")
-					b.WriteString(MutedStyle.Render(paginationFooter))
-				}
-			}
-		} else {
-			b.WriteString(SuccessStyle.Render("✓ Query executed successfully"))
-			b.WriteString("\n\n")
-			b.WriteString(TextStyle.Render(fmt.Sprintf("Rows affected: %d", m.dbQueryResult.RowsAffected)))
-		}
-	}
-
-	if m.dbQuerySaveSuccess {
-		b.WriteString("\n\n")
-		b.WriteString(SuccessStyle.Render("✓ Query saved successfully"))
-	}
-
-	if m.dbExportSuccess {
-		b.WriteString("\n\n")
-		b.WriteString(SuccessStyle.Render(fmt.Sprintf("✓ Results exported to: %s", m.dbExportFilePath)))
-	}
-
-	b.WriteString("\n\n")
-
-	// Generate responsive footer
-	helpText := ""
-	if m.dbResultTable != nil && m.dbResultTable.GetTotalPages() > 1 {
-		if m.dbResultTable.IsLargeDataset() {
-			// Extended navigation for large datasets
-			helpText = "←/→: page • home/end: first/last • pgup/pgdn: jump 5 pages • s: save • e: export • esc: back"
-		} else {
-			// Standard navigation for smaller datasets
-			helpText = "←/→: navigate pages • s: save query • e: export results • esc: back"
-		}
-	} else {
-		helpText = "s: save query • e: export results • esc: back"
-	}
-
-	b.WriteString(RenderResponsiveFooter(helpText, m.layout))
-
-	return CenterResponsive(m.layout, b.String())
-}
-
-func (m Model) handleDatabaseQueryListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Handle global keys first
-	if key.Matches(msg, m.keymap.Quit) {
-		return m, tea.Quit
-	}
-
-	if key.Matches(msg, m.keymap.Back) {
-		m.state = StateDatabase
-		return m, nil
-	}
-
-	// Handle navigation
-	if key.Matches(msg, m.keymap.Up, m.keymap.VimUp) {
-		if m.dbSelectedQueryIdx > 0 {
-			m.dbSelectedQueryIdx--
-		}
-		return m, nil
-	}
-
-	if key.Matches(msg, m.keymap.Down, m.keymap.VimDown) {
-		if m.dbSelectedQueryIdx < len(m.dbSavedQueries)-1 {
-			m.dbSelectedQueryIdx++
-		}
-		return m, nil
-	}
-
-	// Handle selection and actions
-	if key.Matches(msg, m.keymap.Enter, m.keymap.SelectItem) {
-		if len(m.dbSavedQueries) > 0 && m.dbSelectedQueryIdx < len(m.dbSavedQueries) {
-			query := m.dbSavedQueries[m.dbSelectedQueryIdx]
-			m.dbQueryEditor.SetValue(query.Query)
-			m.state = StateDatabaseQueryEditor
-			m.dbQueryEditor.Focus()
-		}
-		return m, nil
-	}
-
-	if key.Matches(msg, m.keymap.DeleteItem) {
-		if len(m.dbSavedQueries) > 0 && m.dbSelectedQueryIdx < len(m.dbSavedQueries) && m.dbStorage != nil {
-			query := m.dbSavedQueries[m.dbSelectedQueryIdx]
-			m.dbStorage.DeleteQuery(query.ID)
-			m.dbSavedQueries = m.dbStorage.GetQueries()
-			if m.dbSelectedQueryIdx >= len(m.dbSavedQueries) && m.dbSelectedQueryIdx > 0 {
-				m.dbSelectedQueryIdx--
-			}
-		}
-		return m, nil
-	}
-
-	return m, nil
-}
-
-func (m Model) viewDatabaseQueryList() string {
-	var b strings.Builder
-
-	b.WriteString(TitleStyle.Render(fmt.Sprintf("Saved Queries (%d)", len(m.dbSavedQueries))))
-	b.WriteString("\n\n")
-
-	if len(m.dbSavedQueries) == 0 {
-		b.WriteString(MutedStyle.Render("No saved queries"))
-		b.WriteString("\n\n")
-		b.WriteString(TextStyle.Render("Save queries from the editor with Ctrl+S"))
-	} else {
-		for i, query := range m.dbSavedQueries {
-			if i == m.dbSelectedQueryIdx {
-				b.WriteString(ListItemSelectedStyle.Render("> " + query.Name))
-				b.WriteString("\n")
-				preview := query.Query
-				if len(preview) > 80 {
-					preview = preview[:80] + "..."
-				}
-				b.WriteString(MutedStyle.Render("    " + preview))
-			} else {
-				b.WriteString(ListItemStyle.Render(query.Name))
-			}
-			b.WriteString("\n")
-		}
-	}
-
-	b.WriteString("\n\n")
-	b.WriteString(RenderFooter("↑↓: navigate • Enter: load • d: delete • Esc: back"))
-
-	return Center(m.width, m.height, b.String())
-}
-
-func (m Model) handleDatabaseSchemaKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "ctrl+q":
-		return m, tea.Quit
-
-	case "esc":
-		m.state = StateDatabase
-		return m, nil
-
-	case "up", "k":
-		if m.dbSelectedTableIdx > 0 {
-			m.dbSelectedTableIdx--
-			m.dbTableInfo = nil
-		}
-		return m, nil
-
-	case "down", "j":
-		if m.dbSelectedTableIdx < len(m.dbTables)-1 {
-			m.dbSelectedTableIdx++
-			m.dbTableInfo = nil
-		}
-		return m, nil
-
-	case "enter":
-		if len(m.dbTables) > 0 && m.dbSelectedTableIdx < len(m.dbTables) {
-			tableName := m.dbTables[m.dbSelectedTableIdx]
-			tableInfo, err := m.dbClient.GetTableInfo(tableName)
-			if err == nil {
-				m.dbTableInfo = tableInfo
-			}
-		}
-		return m, nil
-
-	case "q":
-		m.state = StateDatabaseQueryEditor
-		m.dbQueryEditor.Focus()
-		return m, nil
-
-	case "l":
-		m.state = StateDatabaseQueryList
-		m.dbSelectedQueryIdx = 0
-		return m, nil
-	}
-
-	return m, nil
-}
-
-func (m Model) viewDatabaseSchema() string {
-	var b strings.Builder
-
-	connectionInfo := m.dbClient.GetConnectionString()
-	b.WriteString(TitleStyle.Render("Database Schema"))
-	b.WriteString("\n")
-	b.WriteString(MutedStyle.Render(connectionInfo))
-	b.WriteString("\n")
-
-	if m.dbConnectSuccess {
-		b.WriteString("\n")
-		b.WriteString(SuccessStyle.Render("✓ Connected successfully to database"))
-		b.WriteString("\n")
-	}
-
-	b.WriteString("\n")
-
-	if len(m.dbTables) == 0 {
-		b.WriteString(MutedStyle.Render("No tables found in this database"))
-		b.WriteString("\n\n")
-		b.WriteString(TextStyle.Render("Press 'q' to open query editor"))
-	} else {
-		b.WriteString(HeaderStyle.Render(fmt.Sprintf("Tables (%d)", len(m.dbTables))))
-		b.WriteString("\n\n")
-
-		maxTablesToShow := 15
-		start := m.dbSelectedTableIdx
-		if start > len(m.dbTables)-maxTablesToShow {
-			start = len(m.dbTables) - maxTablesToShow
-		}
-		if start < 0 {
-			start = 0
-		}
-		end := start + maxTablesToShow
-		if end > len(m.dbTables) {
-			end = len(m.dbTables)
-		}
-
-		for i := start; i < end; i++ {
-			tableName := m.dbTables[i]
-			if i == m.dbSelectedTableIdx {
-				b.WriteString(ListItemSelectedStyle.Render("> " + tableName))
-			} else {
-				b.WriteString(ListItemStyle.Render(tableName))
-			}
-			b.WriteString("\n")
-		}
-
-		if m.dbTableInfo != nil {
-			b.WriteString("\n")
-			b.WriteString(HeaderStyle.Render(fmt.Sprintf("Table: %s", m.dbTableInfo.Name)))
-			b.WriteString("\n\n")
-
-			if len(m.dbTableInfo.Columns) > 0 {
-				columnData := [][]string{}
-				for _, col := range m.dbTableInfo.Columns {
-					nullable := "NO"
-					if col.Nullable {
-						nullable = "YES"
-					}
-					columnData = append(columnData, []string{col.Name, col.Type, nullable})
-				}
-
-				tableRenderer := NewTableRenderer(
-					[]string{"Column", "Type", "Nullable"},
-					columnData,
-					m.width-20,
-				)
-				b.WriteString(tableRenderer.Render())
-			}
-		}
-	}
-
-	b.WriteString("\n\n")
-	b.WriteString(RenderFooter("↑↓: navigate • Enter: view columns • q: query editor • l: saved queries • Esc: back"))
-
-	return Center(m.width, m.height, b.String())
-}
-
-func (m Model) handleDatabaseQueryHistoryKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "ctrl+q":
-		return m, tea.Quit
-
-	case "esc":
-		m.dbConfirmingClearQueryHistory = false
-		m.state = StateDatabase
-		return m, nil
-
-	case "up", "k":
-		if m.dbSelectedQueryHistoryIdx > 0 {
-			m.dbSelectedQueryHistoryIdx--
-		}
-		return m, nil
-
-	case "down", "j":
-		if m.dbSelectedQueryHistoryIdx < len(m.dbQueryHistory)-1 {
-			m.dbSelectedQueryHistoryIdx++
-		}
-		return m, nil
-
-	case "enter":
-		if len(m.dbQueryHistory) > 0 && m.dbSelectedQueryHistoryIdx < len(m.dbQueryHistory) {
-			execution := m.dbQueryHistory[m.dbSelectedQueryHistoryIdx]
-			m.dbQueryEditor.SetValue(execution.Query)
-			m.state = StateDatabaseQueryEditor
-			m.dbQueryEditor.Focus()
-			return m, nil
-		}
-		return m, nil
-
-	case "d":
-		if len(m.dbQueryHistory) > 0 && m.dbSelectedQueryHistoryIdx < len(m.dbQueryHistory) {
-			execution := m.dbQueryHistory[m.dbSelectedQueryHistoryIdx]
-			if m.dbStorage != nil {
-				m.dbStorage.DeleteQueryHistoryItem(execution.ID)
-				m.dbQueryHistory = m.dbStorage.GetQueryHistory()
-				if m.dbSelectedQueryHistoryIdx >= len(m.dbQueryHistory) && len(m.dbQueryHistory) > 0 {
-					m.dbSelectedQueryHistoryIdx = len(m.dbQueryHistory) - 1
-				}
-			}
-		}
-		return m, nil
-
-	case "c":
-		if !m.dbConfirmingClearQueryHistory {
-			m.dbConfirmingClearQueryHistory = true
-		}
-		return m, nil
-
-	case "y":
-		if m.dbConfirmingClearQueryHistory && m.dbStorage != nil {
-			m.dbStorage.ClearQueryHistory()
-			m.dbQueryHistory = []database.QueryExecution{}
-			m.dbSelectedQueryHistoryIdx = 0
-			m.dbConfirmingClearQueryHistory = false
-		}
-		return m, nil
-	}
-
-	return m, nil
-}
-
-func (m Model) viewDatabaseQueryHistory() string {
-	var b strings.Builder
-
-	b.WriteString(TitleStyle.Render(fmt.Sprintf("Query History (%d)", len(m.dbQueryHistory))))
-	b.WriteString("\n\n")
-
-	if len(m.dbQueryHistory) == 0 {
-		b.WriteString(MutedStyle.Render("No query history"))
-		b.WriteString("\n\n")
-		b.WriteString(TextStyle.Render("Execute some queries to see them here"))
-	} else {
-		maxLines := m.height - 15
-		start := m.dbSelectedQueryHistoryIdx
-		if start > len(m.dbQueryHistory)-maxLines {
-			start = len(m.dbQueryHistory) - maxLines
-		}
-		if start < 0 {
-			start = 0
-		}
-		end := start + maxLines
-		if end > len(m.dbQueryHistory) {
-			end = len(m.dbQueryHistory)
-		}
-
-		for i := start; i < end; i++ {
-			exec := m.dbQueryHistory[i]
-
-			statusStyle := SuccessStyle
-			statusText := "SUCCESS"
-			if exec.Error != "" {
-				statusStyle = ErrorStyle
-				statusText = "ERROR"
-			}
-
-			timestamp := exec.Timestamp.Format("15:04:05")
-			queryPreview := exec.Query
-			if len(queryPreview) > 60 {
-				queryPreview = queryPreview[:60] + "..."
-			}
-			queryPreview = strings.ReplaceAll(queryPreview, "\n", " ")
-
-			line := fmt.Sprintf("%s  %s", timestamp, queryPreview)
-
-			if i == m.dbSelectedQueryHistoryIdx {
-				b.WriteString(ListItemSelectedStyle.Render("> " + line))
-				b.WriteString("\n")
-
-				info := fmt.Sprintf("    %s", statusStyle.Render(statusText))
-				if exec.Error == "" {
-					info += fmt.Sprintf(" • %dms • %d rows", exec.ExecutionTime, exec.RowsAffected)
-				} else {
-					info += fmt.Sprintf(" • %s", exec.Error)
-				}
-				b.WriteString(MutedStyle.Render(info))
-			} else {
-				b.WriteString(ListItemStyle.Render(line))
-				b.WriteString("\n")
-				info := fmt.Sprintf("    %s • %dms", statusStyle.Render(statusText), exec.ExecutionTime)
-				b.WriteString(MutedStyle.Render(info))
-			}
-			b.WriteString("\n")
-		}
-	}
-
-	b.WriteString("\n")
-
-	if m.dbConfirmingClearQueryHistory {
-		b.WriteString(WarningStyle.Render("⚠ Clear all history? Press 'y' to confirm, 'Esc' to cancel"))
-		b.WriteString("\n\n")
-	}
-
-	b.WriteString(RenderFooter("↑↓: navigate • Enter: load • d: delete item • c: clear all • Esc: back"))
-
-	return Center(m.width, m.height, b.String())
-}
-
-func (m Model) handleDatabaseExportKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	var cmd tea.Cmd
-
-	switch msg.String() {
-	case "ctrl+c", "ctrl+q":
-		return m, tea.Quit
-
-	case "esc":
-		m.state = StateDatabaseResult
-		m.dbExportTableName.Blur()
-		return m, nil
-
-	case "up", "k":
-		if m.dbExportFormatIdx > 0 {
-			m.dbExportFormatIdx--
-		}
-		return m, nil
-
-	case "down", "j":
-		if m.dbExportFormatIdx < 2 {
-			m.dbExportFormatIdx++
-		}
-		return m, nil
-
-	case "tab", "shift+tab":
-		m.dbExportTableName.Focus()
-		return m, nil
-
-	case "enter":
-		formats := []database.ExportFormat{
-			database.ExportFormatCSV,
-			database.ExportFormatJSON,
-			database.ExportFormatSQL,
-		}
-
-		format := formats[m.dbExportFormatIdx]
-		tableName := strings.TrimSpace(m.dbExportTableName.Value())
-
-		if format == database.ExportFormatSQL && tableName == "" {
-			tableName = "exported_table"
-		}
-
-		result := database.ExportQueryResult(m.dbQueryResult, format, tableName)
-
-		if result.Error != nil {
-			m.err = result.Error
-			return m, nil
-		}
-
-		m.dbExportFilePath = result.FilePath
-		m.dbExportSuccess = true
-		m.dbExportSuccessTimer = 5
-		m.state = StateDatabaseResult
-		m.dbExportTableName.Blur()
-
-		return m, nil
-
-	default:
-		if m.dbExportTableName.Focused() {
-			m.dbExportTableName, cmd = m.dbExportTableName.Update(msg)
-			return m, cmd
-		}
-		return m, nil
-	}
-}
-
-func (m Model) viewDatabaseExport() string {
-	var b strings.Builder
-
-	b.WriteString(TitleStyle.Render("Export Query Results"))
-	b.WriteString("\n\n")
-
-	b.WriteString(HeaderStyle.Render("Select Export Format"))
-	b.WriteString("\n\n")
-
-	formats := []string{
-		"CSV (Comma-Separated Values)",
-		"JSON (JavaScript Object Notation)",
-		"SQL (INSERT Statements)",
-	}
-
-	for i, format := range formats {
-		if i == m.dbExportFormatIdx {
-			b.WriteString(ListItemSelectedStyle.Render("> " + format))
-		} else {
-			b.WriteString(ListItemStyle.Render(format))
-		}
-		b.WriteString("\n")
-	}
+func (m Model) viewHome() string {
+	var b strings.Builder
 
+	b.WriteString(TitleStyle.Render("GODEV v0.4.0"))
 	b.WriteString("\n")
-	b.WriteString(HeaderStyle.Render("Table Name (for SQL export)"))
-	b.WriteString("\n\n")
-
-	tableNameBox := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color(ColorAccent)).
-		Padding(0, 1).
-		Width(m.width - 10).
-		Render(m.dbExportTableName.View())
-
-	b.WriteString(tableNameBox)
-	b.WriteString("\n\n")
-
-	info := fmt.Sprintf("Exporting %d rows", len(m.dbQueryResult.Rows))
-	b.WriteString(MutedStyle.Render(info))
-
-	b.WriteString("\n\n")
-	b.WriteString(RenderFooter("↑↓: select format • Tab: edit table name • Enter: export • Esc: cancel"))
-
-	return Center(m.width, m.height, b.String())
-}
-
-func (m Model) handleHomeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "ctrl+q", "q":
-		return m, tea.Quit
-
-	case "1", "a":
-		m.state = StateRequestBuilder
-		m.urlInput.Focus()
-		return m, nil
-
-	case "2", "d":
-		m.state = StateDatabase
-		return m, nil
-
-	case "?", "f1":
-		m.state = StateHelp
-		return m, nil
-	}
-
-	return m, nil
-}
-
-func (m Model) viewEnvironments() string {
-	var b strings.Builder
-
-	activeEnv := ""
-	if m.envConfig != nil && m.envConfig.ActiveEnvironment != "" {
-		activeEnv = m.envConfig.ActiveEnvironment
-	}
-
-	title := fmt.Sprintf("Environment Variables (%d)", len(m.envList))
-	if activeEnv != "" {
-		title += fmt.Sprintf(" | Active: %s", activeEnv)
-	}
-	b.WriteString(TitleStyle.Render(title))
-	b.WriteString("\n\n")
-
-	if m.envSaveSuccess {
-		b.WriteString(SuccessStyle.Render("✓ Environment saved/activated successfully!"))
-		b.WriteString("\n\n")
-	}
-
-	if m.envDeleteSuccess {
-		b.WriteString(SuccessStyle.Render("✓ Environment deleted successfully!"))
-		b.WriteString("\n\n")
-	}
-
-	if len(m.envList) == 0 {
-		b.WriteString(MutedStyle.Render("No environments found"))
-		b.WriteString("\n\n")
-		b.WriteString(TextStyle.Render("Press 'n' to create your first environment (e.g., dev, staging, prod)"))
-	} else {
-		for i, env := range m.envList {
-			prefix := "  "
-			if i == m.selectedEnvIdx {
-				prefix = "> "
-			}
-
-			envName := env.Name
-			if activeEnv == env.Name {
-				envName += " ★"
-			}
-
-			varCount := fmt.Sprintf("(%d vars)", len(env.Variables))
-
-			if i == m.selectedEnvIdx {
-				b.WriteString(ListItemSelectedStyle.Render(prefix + envName))
-				b.WriteString("  ")
-				b.WriteString(MutedStyle.Render(varCount))
-			} else {
-				b.WriteString(ListItemStyle.Render(prefix + envName))
-				b.WriteString("  ")
-				b.WriteString(MutedStyle.Render(varCount))
-			}
-			b.WriteString("\n")
-		}
-	}
-
-	b.WriteString("\n\n")
-
-	if m.confirmingDeleteEnv && len(m.envList) > 0 && m.selectedEnvIdx < len(m.envList) {
-		confirmMsg := fmt.Sprintf("⚠ Delete environment '%s'? Press 'y' to confirm, 'Esc' to cancel", m.envList[m.selectedEnvIdx].Name)
-		b.WriteString(WarningStyle.Render(confirmMsg))
-		b.WriteString("\n\n")
-	}
-
-	b.WriteString(RenderFooter("↑↓: navigate • Enter: edit • n: new • s: set active • d: delete • Esc: back"))
-
-	return Center(m.width, m.height, b.String())
-}
-
-func (m Model) viewEnvironmentEditor() string {
-	var b strings.Builder
-
-	if m.currentEnvName == "" {
-		b.WriteString(TitleStyle.Render("New Environment"))
-	} else {
-		b.WriteString(TitleStyle.Render(fmt.Sprintf("Environment: %s", m.currentEnvName)))
-	}
+	b.WriteString(MutedStyle.Render(i18n.T("home.subtitle")))
 	b.WriteString("\n\n")
-
-	if m.envSaveSuccess {
-		b.WriteString(SuccessStyle.Render("✓ Saved successfully!"))
-		b.WriteString("\n\n")
-	}
-
-	if m.envDeleteSuccess {
-		b.WriteString(SuccessStyle.Render("✓ Variable deleted successfully!"))
-		b.WriteString("\n\n")
-	}
-
-	if m.currentEnvName == "" {
-		b.WriteString(HeaderStyle.Render("Environment Name:"))
-		b.WriteString("\n")
-		inputView := m.envNameInput.View()
-		styledInput := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color(ColorAccent)).
-			Padding(0, 1).
-			Width(m.envNameInput.Width + 2).
-			Render(inputView)
-		b.WriteString(styledInput)
-		b.WriteString("\n\n")
-		b.WriteString(MutedStyle.Render("Press Ctrl+S to save environment"))
-		b.WriteString("\n\n")
-	} else {
-		b.WriteString(HeaderStyle.Render(fmt.Sprintf("Variables (%d):", len(m.envVarList))))
-		b.WriteString("\n\n")
-
-		if len(m.envVarList) == 0 {
-			b.WriteString(MutedStyle.Render("No variables yet"))
-			b.WriteString("\n\n")
-			b.WriteString(TextStyle.Render("Press 'n' to add a variable (e.g., API_URL, API_KEY)"))
-		} else {
-			for i, variable := range m.envVarList {
-				prefix := "  "
-				if i == m.selectedEnvVarIdx {
-					prefix = "> "
-				}
-
-				varText := fmt.Sprintf("%s = %s", variable.Key, variable.Value)
-
-				if i == m.selectedEnvVarIdx {
-					b.WriteString(ListItemSelectedStyle.Render(prefix + varText))
-				} else {
-					b.WriteString(ListItemStyle.Render(prefix + varText))
-				}
-				b.WriteString("\n")
-			}
-		}
+	if m.storage != nil {
+		b.WriteString(TextStyle.Render(i18n.Tf("home.workspace", m.storage.Workspace())))
 	}
-
 	b.WriteString("\n\n")
 
-	if m.editingEnvVar {
-		b.WriteString(HeaderStyle.Render("Add/Edit Variable:"))
-		b.WriteString("\n\n")
-
-		b.WriteString(TextStyle.Render("Key: "))
-		b.WriteString("\n")
-		keyInput := m.envVarKeyInput.View()
-		keyStyle := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			Padding(0, 1).
-			Width(m.envVarKeyInput.Width + 2)
-		if m.envFocusIndex == 0 {
-			keyStyle = keyStyle.BorderForeground(lipgloss.Color(ColorAccent))
-		} else {
-			keyStyle = keyStyle.BorderForeground(lipgloss.Color(ColorBorder))
-		}
-		b.WriteString(keyStyle.Render(keyInput))
-		b.WriteString("\n\n")
-
-		b.WriteString(TextStyle.Render("Value: "))
-		b.WriteString("\n")
-		valueInput := m.envVarValueInput.View()
-		valueStyle := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			Padding(0, 1).
-			Width(m.envVarValueInput.Width + 2)
-		if m.envFocusIndex == 1 {
-			valueStyle = valueStyle.BorderForeground(lipgloss.Color(ColorAccent))
-		} else {
-			valueStyle = valueStyle.BorderForeground(lipgloss.Color(ColorBorder))
-		}
-		b.WriteString(valueStyle.Render(valueInput))
-		b.WriteString("\n\n")
-
-		b.WriteString(RenderFooter("Tab: next field • Enter: save • Esc: cancel"))
-		return Center(m.width, m.height, b.String())
-	}
-
-	if m.confirmingDeleteEnvVar && len(m.envVarList) > 0 && m.selectedEnvVarIdx < len(m.envVarList) {
-		confirmMsg := fmt.Sprintf("⚠ Delete variable '%s'? Press 'y' to confirm, 'Esc' to cancel", m.envVarList[m.selectedEnvVarIdx].Key)
-		b.WriteString(WarningStyle.Render(confirmMsg))
-		b.WriteString("\n\n")
-	}
-
-	if m.currentEnvName == "" {
-		b.WriteString(RenderFooter("Ctrl+S: save environment • Esc: back"))
-	} else {
-		b.WriteString(RenderFooter("↑↓: navigate • n: add variable • e: edit • d: delete • Esc: back"))
-	}
-
-	return Center(m.width, m.height, b.String())
-}
-
-func (m Model) viewHome() string {
-	var b strings.Builder
-
-	b.WriteString(TitleStyle.Render("GODEV v0.4.0"))
-	b.WriteString("\n")
-	b.WriteString(MutedStyle.Render("Professional API Testing & Database Tool"))
-	b.WriteString("\n\n\n")
-
 	menuPanel := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(roundedBorder()).
 		BorderForeground(lipgloss.Color(ColorAccent)).
 		Padding(2, 4).
 		Width(m.width - 20).
 		Render(
-			HeaderStyle.Render("SELECT MODE") + "\n\n" +
-				ButtonActive.Render("[ 1 ] API Testing (HTTP)") + "\n" +
-				MutedStyle.Render("      Test REST APIs, GraphQL & WebSocket") + "\n\n" +
-				ButtonActive.Render("[ 2 ] Database Explorer (SQL)") + "\n" +
-				MutedStyle.Render("      PostgreSQL queries, schema browser & more") + "\n",
+			HeaderStyle.Render(i18n.T("home.select_mode")) + "\n\n" +
+				ButtonActive.Render(i18n.T("home.api_mode")) + "\n" +
+				MutedStyle.Render(i18n.T("home.api_mode_desc")) + "\n\n" +
+				ButtonActive.Render(i18n.T("home.db_mode")) + "\n" +
+				MutedStyle.Render(i18n.T("home.db_mode_desc")) + "\n\n" +
+				ButtonActive.Render(i18n.T("home.raw_mode")) + "\n" +
+				MutedStyle.Render(i18n.T("home.raw_mode_desc")) + "\n\n" +
+				ButtonActive.Render(i18n.T("home.dns_mode")) + "\n" +
+				MutedStyle.Render(i18n.T("home.dns_mode_desc")) + "\n\n" +
+				ButtonActive.Render(i18n.T("home.util_mode")) + "\n" +
+				MutedStyle.Render(i18n.T("home.util_mode_desc")) + "\n\n" +
+				ButtonActive.Render(i18n.T("home.time_mode")) + "\n" +
+				MutedStyle.Render(i18n.T("home.time_mode_desc")) + "\n",
 		)
 
 	b.WriteString(menuPanel)
@@ -3293,11 +1048,11 @@ func (m Model) viewHome() string {
 
 	featuresInfo := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(ColorMuted)).
-		Render("Features: Environment Variables • cURL Import • Request Collections • Query History")
+		Render(i18n.T("home.features"))
 
 	b.WriteString(featuresInfo)
 	b.WriteString("\n\n")
-	b.WriteString(RenderFooter("1: API Mode • 2: Database Mode • ?: Help • Q: Quit"))
+	b.WriteString(RenderFooter(i18n.T("footer.home")))
 
 	return Center(m.width, m.height, b.String())
 }
@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// stateNames are the i18n keys of the screen names shown in the breadcrumb
+var stateNames = map[AppState]string{
+	StateHome:                 "nav.home",
+	StateRequestBuilder:       "nav.request",
+	StateLoading:              "nav.loading",
+	StateViewResponse:         "nav.response",
+	StateRequestList:          "nav.saved_requests",
+	StateHeaderEditor:         "nav.headers",
+	StateBodyEditor:           "nav.body",
+	StateQueryEditor:          "nav.query_params",
+	StateHelp:                 "nav.help",
+	StateHistory:              "nav.history",
+	StateDatabase:             "nav.database",
+	StateDatabaseConnect:      "nav.db_connect",
+	StateDatabaseQueryEditor:  "nav.db_query",
+	StateDatabaseResult:       "nav.db_result",
+	StateDatabaseQueryList:    "nav.db_saved",
+	StateDatabaseSchema:       "nav.db_schema",
+	StateDatabaseQueryHistory: "nav.db_history",
+	StateDatabaseExport:       "nav.db_export",
+	StateEnvironments:         "nav.environments",
+	StateEnvironmentEditor:    "nav.variables",
+	StateHistoryReplay:        "nav.replay",
+	StateWorkspaces:           "nav.workspaces",
+	StateDuplicateCompare:     "nav.duplicates",
+	StateGraphQLSchema:        "nav.gql_schema",
+	StateGraphQLOperations:    "nav.gql_operations",
+	StatePagination:           "nav.pagination",
+	StateBulkRunner:           "nav.bulk",
+	StateTrash:                "nav.trash",
+	StateBookmarks:            "nav.bookmarks",
+	StateStorageUnavailable:   "nav.storage",
+	StateAssertions:           "nav.assertions",
+	StateSigning:              "nav.signing",
+	StateURLInspector:         "nav.url_inspector",
+	StateAliases:              "nav.aliases",
+	StateTour:                 "nav.tour",
+	StateWhatsNew:             "nav.whats_new",
+	StateCollections:          "nav.collections",
+	StateStats:                "nav.stats",
+	StateCurlImport:           "nav.curl_import",
+	StateGraphQLEditor:        "nav.gql_editor",
+	StateHistoryDiff:          "nav.history_diff",
+	StateTemplates:            "nav.templates",
+	StateCollectionRun:        "nav.collection_run",
+	StateRawSocket:            "nav.raw_socket",
+	StateAuth:                 "nav.auth",
+	StateDNSLookup:            "nav.dns",
+	StateCookies:              "nav.cookies",
+	StateJWT:                  "nav.jwt",
+	StateUtilities:            "nav.utilities",
+	StateProxySettings:        "nav.proxy",
+	StateTLSSettings:          "nav.tls",
+	StateTimestamps:           "nav.timestamps",
+	StateExtractions:          "nav.extractions",
+	StateSplitView:            "nav.split",
+	StateRequestPolicy:        "nav.policy",
+	StateQuerySnippets:        "nav.query_snippets",
+	StateDatabaseValueSearch:  "nav.value_search",
+	StateDatabaseRowRequest:   "nav.row_request",
+}
+
+// followNavigation keeps the trail of screens up to date after a key moved
+// the UI from one screen to another. Going back returns to the screen
+// before, whichever screen the handler of the key went to, so a screen
+// reached from several places leaves to the one it came from.
+func (m *Model) followNavigation(from AppState, back bool) {
+	if back {
+		if n := len(m.trail); n > 0 {
+			m.state = m.trail[n-1]
+			m.trail = m.trail[:n-1]
+		}
+		return
+	}
+
+	if m.state == StateHome {
+		m.trail = nil
+		return
+	}
+	// Coming back to a screen on the trail drops what was opened after it
+	for i, state := range m.trail {
+		if state == m.state {
+			m.trail = m.trail[:i]
+			return
+		}
+	}
+	// Leaving the spinner is never a way back to it
+	if from != StateLoading {
+		m.trail = append(m.trail, from)
+	}
+}
+
+// breadcrumbs names the screens on the trail and the current screen, with
+// the table, collection or environment they were showing
+func (m Model) breadcrumbs() []string {
+	var crumbs []string
+	for i, state := range append(append([]AppState(nil), m.trail...), m.state) {
+		if state == StateHome {
+			continue
+		}
+		current := i == len(m.trail)
+		crumbs = append(crumbs, i18n.T(stateNames[state]))
+
+		switch state {
+		case StateDatabaseSchema:
+			// A table opened from the schema, like its snippets
+			if !current && m.db.selectedTable < len(m.db.tables) {
+				crumbs = append(crumbs, m.db.tables[m.db.selectedTable])
+			}
+		case StateCollections:
+			if c := m.collections.openCollection(); c != nil {
+				crumbs = append(crumbs, c.Name)
+			}
+		case StateEnvironmentEditor:
+			if m.envs.current != "" {
+				crumbs[len(crumbs)-1] = m.envs.current
+			}
+		}
+	}
+	return crumbs
+}
+
+// withBreadcrumb writes where the user is on the bottom line of a rendered
+// view, leaving the home screen and views that fill the screen as they are
+func (m Model) withBreadcrumb(view string) string {
+	crumbs := m.breadcrumbs()
+	lines := strings.Split(view, "\n")
+	last := len(lines) - 1
+	if len(crumbs) == 0 || last < 1 || strings.TrimSpace(lines[last]) != "" {
+		return view
+	}
+
+	// The screens furthest back give way on narrow terminals
+	line := " " + strings.Join(crumbs, " ▸ ")
+	for len(crumbs) > 1 && m.width > 0 && lipgloss.Width(line) > m.width {
+		crumbs = crumbs[1:]
+		line = " … ▸ " + strings.Join(crumbs, " ▸ ")
+	}
+	lines[last] = MutedStyle.Render(line)
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestNavigationTrail(t *testing.T) {
+	m := Model{state: StateHome, db: DatabaseExplorer{tables: []string{"orders", "users"}, selectedTable: 1}}
+	open := func(to AppState) {
+		from := m.state
+		m.state = to
+		m.followNavigation(from, false)
+	}
+
+	open(StateDatabase)
+	open(StateDatabaseSchema)
+	open(StateDatabaseQueryEditor)
+	open(StateDatabaseResult)
+
+	want := []string{"Database", "Schema", "users", "Query", "Results"}
+	if got := m.breadcrumbs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("breadcrumbs() = %v, want %v", got, want)
+	}
+
+	// The handler of the results goes back to the database screen; the
+	// trail knows the query editor came before
+	m.state = StateDatabase
+	m.followNavigation(StateDatabaseResult, true)
+	if m.state != StateDatabaseQueryEditor {
+		t.Errorf("Expected Esc to return to the query editor, got state %v", m.state)
+	}
+
+	// Opening a screen already on the trail goes back to it
+	open(StateDatabase)
+	if len(m.trail) != 1 || m.trail[0] != StateHome {
+		t.Errorf("Expected only home before the database screen, got %v", m.trail)
+	}
+
+	open(StateHome)
+	if len(m.trail) != 0 || len(m.breadcrumbs()) != 0 {
+		t.Errorf("Expected the home screen to clear the trail, got %v", m.trail)
+	}
+}
+
+func TestFlowHelpReturnsToPreviousScreen(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+
+	// Help used to always close to the request builder
+	d.Press("?").Press("esc")
+	if state := d.Model().(Model).state; state != StateHome {
+		t.Errorf("Expected help to close back to the home screen, got state %v", state)
+	}
+
+	d.Press("2", "esc")
+	if state := d.Model().(Model).state; state != StateHome {
+		t.Errorf("Expected Esc to return home, got state %v", state)
+	}
+}
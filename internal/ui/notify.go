@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const defaultWindowTitle = "godev"
+
+// NotifyConfig controls how finished long-running operations are announced
+type NotifyConfig struct {
+	// After is the minimum duration for an operation to be announced while
+	// the terminal has focus. Unfocused terminals are always notified.
+	After time.Duration
+	// Bell rings the terminal bell
+	Bell bool
+	// OSC sends a desktop notification escape sequence (OSC 9)
+	OSC bool
+}
+
+// SetNotifications configures completion notifications
+func (m *Model) SetNotifications(cfg NotifyConfig) {
+	m.notify = cfg
+}
+
+// notifyDone announces a finished request or query in the window title and,
+// when enabled, with the bell or a desktop notification. The title is reset
+// on the next key press or when the terminal regains focus.
+func (m *Model) notifyDone(summary string, took time.Duration, failed bool) tea.Cmd {
+	if took < m.notify.After && !m.terminalBlurred {
+		return nil
+	}
+
+	icon := "✓"
+	if failed {
+		icon = "✗"
+	}
+	message := fmt.Sprintf("%s %s (%s)", icon, summary, took.Round(10*time.Millisecond))
+	m.windowTitleChanged = true
+
+	cmds := []tea.Cmd{tea.SetWindowTitle(message + " - " + defaultWindowTitle)}
+	if seq := notificationSequence(message, m.notify.Bell, m.notify.OSC, os.Getenv("TMUX") != ""); seq != "" {
+		cmds = append(cmds, func() tea.Msg {
+			fmt.Fprint(os.Stdout, seq)
+			return nil
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// resetWindowTitle restores the default title once the user is back
+func (m *Model) resetWindowTitle() tea.Cmd {
+	if !m.windowTitleChanged {
+		return nil
+	}
+	m.windowTitleChanged = false
+	return tea.SetWindowTitle(defaultWindowTitle)
+}
+
+// notificationSequence builds the bell and OSC 9 escape sequences. Inside
+// tmux the OSC sequence is wrapped in a passthrough so it reaches the outer
+// terminal.
+func notificationSequence(message string, bell, osc, tmux bool) string {
+	var b strings.Builder
+
+	if osc {
+		// Control characters would terminate the sequence early
+		clean := strings.Map(func(r rune) rune {
+			if r < 0x20 || r == 0x7f {
+				return ' '
+			}
+			return r
+		}, defaultWindowTitle+": "+message)
+
+		seq := "\x1b]9;" + clean + "\x07"
+		if tmux {
+			seq = "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+		}
+		b.WriteString(seq)
+	}
+
+	if bell {
+		b.WriteString("\a")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNotificationSequence(t *testing.T) {
+	if seq := notificationSequence("done", false, false, false); seq != "" {
+		t.Errorf("Expected no sequence when disabled, got %q", seq)
+	}
+
+	if seq := notificationSequence("done", true, false, false); seq != "\a" {
+		t.Errorf("Expected bell, got %q", seq)
+	}
+
+	seq := notificationSequence("line\nbreak", false, true, false)
+	if seq != "\x1b]9;godev: line break\x07" {
+		t.Errorf("Unexpected OSC sequence: %q", seq)
+	}
+
+	wrapped := notificationSequence("done", false, true, true)
+	if wrapped != "\x1bPtmux;\x1b\x1b]9;godev: done\x07\x1b\\" {
+		t.Errorf("Unexpected tmux passthrough sequence: %q", wrapped)
+	}
+}
+
+func TestNotifyDoneThreshold(t *testing.T) {
+	m := Model{notify: NotifyConfig{After: 5 * time.Second}}
+
+	if cmd := m.notifyDone("GET /", time.Second, false); cmd != nil {
+		t.Error("Expected quick operations not to be announced while focused")
+	}
+
+	if cmd := m.notifyDone("GET /", 6*time.Second, false); cmd == nil || !m.windowTitleChanged {
+		t.Error("Expected long operation to update the window title")
+	}
+
+	if cmd := m.resetWindowTitle(); cmd == nil || m.windowTitleChanged {
+		t.Error("Expected title to be reset")
+	}
+
+	m.terminalBlurred = true
+	if cmd := m.notifyDone("GET /", time.Millisecond, false); cmd == nil {
+		t.Error("Expected operations to be announced while the terminal is unfocused")
+	}
+}
@@ -0,0 +1,263 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/abneribeiro/godev/internal/changelog"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// tourSteps is the number of pages of the first-run tour. Page i is
+// translated under tour.<i>.title and tour.<i>.body.
+const tourSteps = 7
+
+// Onboarding is the first-run tour and the release notes shown after an
+// upgrade
+type Onboarding struct {
+	// releases are the notes of the embedded changelog; notes are the ones
+	// being shown
+	version  string
+	releases []changelog.Release
+	notes    []changelog.Release
+	scroll   int
+	tourStep int
+}
+
+// SetOnboarding shows the tour on the first launch, or, after an upgrade,
+// the release notes of every version since the one last run. changelogText
+// is the CHANGELOG.md embedded in the binary.
+func (m *Model) SetOnboarding(version, changelogText string) {
+	o := &m.onboarding
+	o.version = version
+	o.releases = changelog.Parse(changelogText)
+
+	state, found, err := storage.LoadOnboardingState()
+	if err != nil {
+		// A broken state file should not show the tour on every launch
+		return
+	}
+	if !found || !state.TourCompleted {
+		o.openTour(m)
+		return
+	}
+
+	o.notes = changelog.Since(o.releases, state.LastSeenVersion, version)
+	if len(o.notes) > 0 {
+		o.scroll = 0
+		m.state = StateWhatsNew
+		return
+	}
+	if state.LastSeenVersion != version {
+		o.saveState(m)
+	}
+}
+
+func (o *Onboarding) openTour(h host) {
+	o.tourStep = 0
+	h.navigate(StateTour)
+}
+
+// openWhatsNew shows the release notes of the running version
+func (o *Onboarding) openWhatsNew(h host) {
+	o.notes = nil
+	for _, release := range o.releases {
+		if changelog.Compare(release.Version, o.version) == 0 {
+			o.notes = append(o.notes, release)
+		}
+	}
+	if len(o.notes) == 0 && len(o.releases) > 0 {
+		o.notes = o.releases[:1]
+	}
+	o.scroll = 0
+	h.navigate(StateWhatsNew)
+}
+
+// saveState records that the tour and the notes of the running
+// version have been seen, so neither shows again until the next upgrade
+func (o *Onboarding) saveState(h host) {
+	state := storage.OnboardingState{TourCompleted: true, LastSeenVersion: o.version}
+	h.reportStorageError("save onboarding state", storage.SaveOnboardingState(state))
+}
+
+// close leaves the tour or the release notes for the home screen
+func (o *Onboarding) close(h host) {
+	o.saveState(h)
+	h.navigate(StateHome)
+}
+
+func (o *Onboarding) updateTour(h host, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc", "q":
+		o.close(h)
+		return nil
+
+	case "right", "l", "enter", " ":
+		if o.tourStep == tourSteps-1 {
+			o.close(h)
+			return nil
+		}
+		o.tourStep++
+		return nil
+
+	case "left", "h":
+		if o.tourStep > 0 {
+			o.tourStep--
+		}
+		return nil
+	}
+
+	return nil
+}
+
+func (o *Onboarding) viewTour(h host) string {
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.Tf("title.tour", o.tourStep+1, tourSteps)))
+	b.WriteString("\n\n")
+
+	width, _ := h.size()
+	width -= 20
+	if width < 40 {
+		width = 40
+	}
+	panel := lipgloss.NewStyle().
+		Border(roundedBorder()).
+		BorderForeground(lipgloss.Color(ColorAccent)).
+		Padding(1, 3).
+		Width(width).
+		Render(
+			HeaderStyle.Render(i18n.T(fmt.Sprintf("tour.%d.title", o.tourStep))) + "\n\n" +
+				TextStyle.Render(i18n.T(fmt.Sprintf("tour.%d.body", o.tourStep))),
+		)
+	b.WriteString(panel)
+	b.WriteString("\n\n")
+
+	var dots strings.Builder
+	for i := 0; i < tourSteps; i++ {
+		if i == o.tourStep {
+			dots.WriteString("● ")
+		} else {
+			dots.WriteString("○ ")
+		}
+	}
+	b.WriteString(MutedStyle.Render(strings.TrimSpace(dots.String())))
+	b.WriteString("\n\n")
+
+	footer := i18n.T("footer.tour")
+	if o.tourStep == tourSteps-1 {
+		footer = i18n.T("footer.tour_done")
+	}
+	b.WriteString(RenderFooter(footer))
+
+	return b.String()
+}
+
+func (o *Onboarding) updateWhatsNew(h host, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc", "q", "enter":
+		o.close(h)
+		return nil
+
+	case "up", "k":
+		if o.scroll > 0 {
+			o.scroll--
+		}
+		return nil
+
+	case "down", "j":
+		if o.scroll < len(o.lines())-1 {
+			o.scroll++
+		}
+		return nil
+	}
+
+	return nil
+}
+
+// lines renders the release notes being shown, one entry per line
+func (o *Onboarding) lines() []string {
+	var lines []string
+	for i, release := range o.notes {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		heading := "v" + release.Version
+		if release.Date != "" {
+			heading += " • " + release.Date
+		}
+		lines = append(lines, HeaderStyle.Render(heading))
+
+		for _, line := range strings.Split(release.Notes, "\n") {
+			line = strings.ReplaceAll(line, "**", "")
+			switch {
+			case strings.HasPrefix(line, "### "):
+				lines = append(lines, TextStyle.Render(strings.TrimPrefix(line, "### ")))
+			case strings.HasPrefix(line, "  "):
+				lines = append(lines, MutedStyle.Render(line))
+			default:
+				lines = append(lines, TextStyle.Render(line))
+			}
+		}
+	}
+	return lines
+}
+
+func (o *Onboarding) viewWhatsNew(h host) string {
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.Tf("title.whats_new", o.version)))
+	b.WriteString("\n\n")
+
+	lines := o.lines()
+	if len(lines) == 0 {
+		b.WriteString(MutedStyle.Render(i18n.T("whats_new.empty")))
+		b.WriteString("\n")
+	}
+
+	_, height := h.size()
+	maxLines := height - 8
+	if maxLines < 5 {
+		maxLines = 5
+	}
+	start := clampIndex(o.scroll, len(lines))
+	end := min(start+maxLines, len(lines))
+	for _, line := range lines[start:end] {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	if end < len(lines) {
+		b.WriteString(MutedStyle.Render(i18n.Tf("whats_new.more", len(lines)-end)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(RenderFooter(i18n.T("footer.whats_new")))
+
+	return b.String()
+}
+
+func (o *Onboarding) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	if h.screenState() == StateTour {
+		return o.updateTour(h, msg)
+	}
+	return o.updateWhatsNew(h, msg)
+}
+
+func (o *Onboarding) View(h host) string {
+	width, height := h.size()
+	if h.screenState() == StateTour {
+		return Center(width, height, o.viewTour(h))
+	}
+	return Center(width, height, o.viewWhatsNew(h))
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+const testChangelog = `# Changelog
+
+## [0.5.0] - 2026-01-10
+
+### Added
+
+- **Request groups**: group saved requests by host
+
+## [0.4.0] - 2025-10-19
+
+### Added
+
+- Database mode
+`
+
+func TestTourOnFirstLaunchOnly(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+
+	m := Model{width: 100, height: 40}
+	m.SetOnboarding("0.4.0", testChangelog)
+	if m.state != StateTour {
+		t.Fatalf("Expected the tour on the first launch, got state %v", m.state)
+	}
+	if !strings.Contains(m.onboarding.View(&m), "1/7") {
+		t.Errorf("Expected the tour to show its progress:\n%s", m.onboarding.View(&m))
+	}
+
+	for i := 0; i < tourSteps; i++ {
+		m.onboarding.Update(&m, tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	if m.state != StateHome {
+		t.Fatalf("Expected the last page to close the tour, got state %v", m.state)
+	}
+	state, _, _ := storage.LoadOnboardingState()
+	if !state.TourCompleted || state.LastSeenVersion != "0.4.0" {
+		t.Errorf("Expected the tour to be recorded as seen, got %+v", state)
+	}
+
+	again := Model{}
+	again.SetOnboarding("0.4.0", testChangelog)
+	if again.state != StateHome {
+		t.Errorf("Expected nothing to show on the next launch, got state %v", again.state)
+	}
+}
+
+func TestWhatsNewAfterUpgrade(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	if err := storage.SaveOnboardingState(storage.OnboardingState{TourCompleted: true, LastSeenVersion: "0.4.0"}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := Model{width: 100, height: 40}
+	m.SetOnboarding("0.5.0", testChangelog)
+	if m.state != StateWhatsNew || len(m.onboarding.notes) != 1 || m.onboarding.notes[0].Version != "0.5.0" {
+		t.Fatalf("Expected the 0.5.0 notes after the upgrade, got state %v notes %+v", m.state, m.onboarding.notes)
+	}
+	view := m.onboarding.View(&m)
+	if !strings.Contains(view, "Request groups: group saved requests by host") || strings.Contains(view, "Database mode") {
+		t.Errorf("Expected only the notes of the new version:\n%s", view)
+	}
+
+	m.onboarding.Update(&m, tea.KeyMsg{Type: tea.KeyEsc})
+	state, _, _ := storage.LoadOnboardingState()
+	if m.state != StateHome || state.LastSeenVersion != "0.5.0" {
+		t.Errorf("Expected closing the notes to record 0.5.0 as seen, got state %v %+v", m.state, state)
+	}
+}
@@ -0,0 +1,276 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+type paginationResultMsg *httpclient.PaginationResult
+
+func followPaginationCmd(client *httpclient.Client, req httpclient.Request, rule httpclient.PaginationRule) tea.Cmd {
+	return func() tea.Msg {
+		return paginationResultMsg(httpclient.FollowPagination(client, req, rule))
+	}
+}
+
+// Pagination is the pagination follower: the pages of the request being
+// edited fetched by a rule, merged, exported or shown as one response
+type Pagination struct {
+	rule    textinput.Model
+	running bool
+	result  *httpclient.PaginationResult
+	err     string
+	notice  string
+	scroll  int
+
+	// method and url are the request whose pages are fetched
+	method string
+	url    string
+}
+
+func newPagination() Pagination {
+	rule := textinput.New()
+	rule.Placeholder = "link"
+	rule.CharLimit = 200
+	rule.Width = 50
+	return Pagination{rule: rule}
+}
+
+// open shows the pagination follower for the request sent as method and url,
+// with a rule guessed from its response
+func (p *Pagination) open(h host, method, url string, resp *httpclient.Response) {
+	h.navigate(StatePagination)
+	p.method = method
+	p.url = url
+	p.err = ""
+	p.notice = ""
+	p.result = nil
+	p.scroll = 0
+
+	if p.rule.Value() == "" && resp != nil {
+		p.rule.SetValue(httpclient.SuggestPaginationRule(*resp))
+	}
+	p.rule.CursorEnd()
+	p.rule.Focus()
+}
+
+// finish shows the pages fetched
+func (p *Pagination) finish(msg paginationResultMsg) {
+	p.running = false
+	p.result = (*httpclient.PaginationResult)(msg)
+}
+
+// viewMerged shows the merged items in the response view
+func (p *Pagination) viewMerged(h host) error {
+	merged, err := p.result.MergedJSON()
+	if err != nil {
+		return err
+	}
+
+	var took time.Duration
+	for _, page := range p.result.Pages {
+		took += page.ResponseTime
+	}
+
+	resp := httpclient.Response{
+		StatusCode:   200,
+		Status:       fmt.Sprintf("%d items from %d pages", len(p.result.Items), len(p.result.Pages)),
+		Headers:      map[string][]string{"Content-Type": {"application/json"}},
+		Body:         merged,
+		ResponseTime: took,
+		Size:         int64(len(merged)),
+	}
+
+	v := h.responseViewer()
+	v.dropSpooledBody()
+	v.response = &resp
+	v.schemaDrift = nil
+	v.viewSchemaDrift = false
+	v.scrollOffset = 0
+	h.navigate(StateViewResponse)
+	return nil
+}
+
+func (p *Pagination) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	if p.rule.Focused() {
+		switch msg.String() {
+		case "ctrl+c", "ctrl+q":
+			return tea.Quit
+
+		case "esc":
+			p.rule.Blur()
+			if p.result == nil {
+				h.navigate(StateViewResponse)
+			}
+			return nil
+
+		case "enter":
+			rule, err := httpclient.ParsePaginationRule(p.rule.Value())
+			if err != nil {
+				p.err = err.Error()
+				return nil
+			}
+			if h.blockedByReadOnly(p.method + " requests") {
+				return nil
+			}
+
+			p.rule.Blur()
+			p.err = ""
+			p.notice = ""
+			p.running = true
+			p.result = nil
+			p.scroll = 0
+			return tea.Batch(h.spinnerTick(), followPaginationCmd(h.requestClient(), h.builtRequest(), rule))
+		}
+
+		p.rule, cmd = p.rule.Update(msg)
+		return cmd
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		if p.running {
+			return nil
+		}
+		h.navigate(StateViewResponse)
+		return nil
+
+	case "e":
+		if p.running || p.result == nil {
+			return nil
+		}
+		path, err := p.result.Export()
+		if err != nil {
+			p.err = err.Error()
+			return nil
+		}
+		p.notice = i18n.Tf("pagination.exported", path)
+		return nil
+
+	case "v":
+		if p.running || p.result == nil {
+			return nil
+		}
+		if err := p.viewMerged(h); err != nil {
+			p.err = err.Error()
+		}
+		return nil
+
+	case "r":
+		if !p.running {
+			p.rule.Focus()
+		}
+		return nil
+
+	case "up", "k":
+		if p.scroll > 0 {
+			p.scroll--
+		}
+		return nil
+
+	case "down", "j":
+		p.scroll++
+		return nil
+	}
+
+	return nil
+}
+
+func (p *Pagination) View(h host) string {
+	width, height := h.size()
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.pagination")))
+	b.WriteString("\n\n")
+	b.WriteString(TextStyle.Render(fmt.Sprintf("%s %s", p.method, p.url)))
+	b.WriteString("\n\n")
+
+	borderColor := ColorMuted
+	if p.rule.Focused() {
+		borderColor = ColorAccent
+	}
+	b.WriteString(TextStyle.Render(i18n.T("pagination.rule")))
+	b.WriteString("\n")
+	b.WriteString(lipgloss.NewStyle().
+		Border(roundedBorder()).
+		BorderForeground(lipgloss.Color(borderColor)).
+		Padding(0, 1).
+		Width(p.rule.Width + 2).
+		Render(p.rule.View()))
+	b.WriteString("\n")
+	b.WriteString(MutedStyle.Render(i18n.T("pagination.rule_hint")))
+	b.WriteString("\n\n")
+
+	if p.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + p.err))
+		b.WriteString("\n\n")
+	}
+
+	switch {
+	case p.running:
+		b.WriteString(SpinnerStyle.Render(h.spinnerView()) + "  " + TextStyle.Render(i18n.T("pagination.fetching")))
+		b.WriteString("\n")
+
+	case p.result != nil:
+		lines := strings.Split(strings.TrimRight(httpclient.FormatPaginationResult(p.result), "\n"), "\n")
+
+		maxLines := height - 20
+		if maxLines < 5 {
+			maxLines = 5
+		}
+		start := p.scroll
+		if start > len(lines)-maxLines {
+			start = len(lines) - maxLines
+		}
+		if start < 0 {
+			start = 0
+		}
+		end := start + maxLines
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		for _, line := range lines[start:end] {
+			switch {
+			case strings.HasPrefix(line, "✓"):
+				b.WriteString(SuccessStyle.Render(line))
+			case strings.HasPrefix(line, "✗"), strings.HasPrefix(line, "      "):
+				b.WriteString(ErrorStyle.Render(line))
+			default:
+				b.WriteString(HeaderStyle.Render(line))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if p.notice != "" {
+		b.WriteString("\n")
+		b.WriteString(SuccessStyle.Render(p.notice))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	switch {
+	case p.rule.Focused():
+		b.WriteString(RenderFooter(i18n.T("footer.pagination_rule")))
+	case p.result != nil:
+		b.WriteString(RenderFooter(i18n.T("footer.pagination")))
+	default:
+		b.WriteString(RenderFooter(i18n.T("footer.pagination_empty")))
+	}
+
+	return Center(width, height, b.String())
+}
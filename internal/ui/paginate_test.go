@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowPaginationMergesPagesIntoTheResponse(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") != "2" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/items?page=2>; rel="next"`, server.URL))
+			fmt.Fprint(w, `[{"id": 1}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id": 2}]`)
+	}))
+	defer server.Close()
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL + "/items").Press("enter").WaitFor("200")
+	d.Press("f").AssertView("Follow Pagination", "GET "+server.URL+"/items")
+	d.Press("enter").WaitFor("v: view merged")
+
+	m := d.Model().(Model)
+	if m.pagination.running || m.pagination.result == nil || len(m.pagination.result.Pages) != 2 {
+		t.Fatalf("Expected 2 pages, got %+v", m.pagination.result)
+	}
+
+	d.Press("v").AssertView("2 items from 2 pages")
+}
@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/database"
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/plugin"
+)
+
+// builtinExportFormats are offered before any exporter plugins
+var builtinExportFormats = []struct {
+	format database.ExportFormat
+	label  string
+}{
+	{database.ExportFormatCSV, "CSV (Comma-Separated Values)"},
+	{database.ExportFormatJSON, "JSON (JavaScript Object Notation)"},
+	{database.ExportFormatSQL, "SQL (INSERT Statements)"},
+}
+
+type pluginViewMsg struct {
+	name   string
+	output string
+	err    error
+}
+
+// SetPlugins makes the loaded exporter, auth and viewer plugins available
+func (m *Model) SetPlugins(registry *plugin.Registry) {
+	m.plugins = registry
+	m.db.plugins = registry
+}
+
+// exportFormatLabels lists the built-in formats followed by exporter plugins
+func (d *DatabaseExplorer) exportFormatLabels() []string {
+	labels := make([]string, 0, len(builtinExportFormats))
+	for _, f := range builtinExportFormats {
+		labels = append(labels, f.label)
+	}
+	for _, exporter := range d.plugins.Exporters() {
+		label := exporter.Name() + " (plugin, ." + strings.TrimPrefix(exporter.Extension(), ".") + ")"
+		if exporter.Description() != "" {
+			label += " – " + exporter.Description()
+		}
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+// exportWithPlugin runs the exporter plugin chosen on the export screen
+func (d *DatabaseExplorer) exportWithPlugin(idx int, tableName string) (string, error) {
+	exporter := d.plugins.Exporters()[idx]
+	table := plugin.Table{
+		Name:    tableName,
+		Columns: d.result.Columns,
+		Rows:    d.result.Rows,
+	}
+	return plugin.ExportFile(context.Background(), exporter, table, time.Now().Format("20060102_150405"))
+}
+
+// authorizeWithPlugins fills in headers set to "plugin:<name>" by running
+// the named auth plugin; it runs inside the send command, off the UI loop,
+// and stops when ctx is canceled
+func authorizeWithPlugins(ctx context.Context, registry *plugin.Registry, req httpclient.Request) (httpclient.Request, error) {
+	headers, err := registry.Authorize(ctx, plugin.AuthRequest{
+		Method:  req.Method,
+		URL:     req.URL,
+		Headers: req.Headers,
+		Body:    req.Body,
+	})
+	if err != nil {
+		return req, err
+	}
+	req.Headers = headers
+	return req, nil
+}
+
+// responseContentType returns the Content-Type of the current response
+func (v *ResponseViewer) responseContentType() string {
+	if v.response == nil {
+		return ""
+	}
+	for key, values := range v.response.Headers {
+		if strings.EqualFold(key, "Content-Type") && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// togglePluginView renders the response with the viewer plugin for its
+// content type, or goes back to the regular body
+func (m *Model) togglePluginView() tea.Cmd {
+	if m.viewer.pluginViewName != "" {
+		m.viewer.pluginViewName = ""
+		m.viewer.pluginViewOutput = ""
+		m.viewer.pluginViewError = ""
+		m.viewer.scrollOffset = 0
+		return nil
+	}
+	if m.viewer.response == nil || m.viewer.response.Error != nil {
+		return nil
+	}
+
+	contentType := m.viewer.responseContentType()
+	viewer, ok := m.plugins.Viewer(contentType)
+	if !ok {
+		m.viewer.pluginViewError = "no viewer plugin for " + contentType
+		return nil
+	}
+
+	m.viewer.pluginViewName = viewer.Name()
+	m.viewer.pluginViewOutput = ""
+	m.viewer.pluginViewError = ""
+	m.viewer.pluginViewLoading = true
+	m.viewer.scrollOffset = 0
+
+	resp := plugin.ViewRequest{
+		StatusCode:  m.viewer.response.StatusCode,
+		ContentType: contentType,
+		Headers:     m.viewer.response.Headers,
+		Body:        m.viewer.response.Body,
+	}
+	return tea.Batch(m.spinner.Tick, func() tea.Msg {
+		output, err := viewer.View(context.Background(), resp)
+		return pluginViewMsg{name: viewer.Name(), output: output, err: err}
+	})
+}
+
+// resetPluginView drops the plugin rendering of the previous response
+func (v *ResponseViewer) resetPluginView() {
+	v.pluginViewName = ""
+	v.pluginViewOutput = ""
+	v.pluginViewError = ""
+	v.pluginViewLoading = false
+}
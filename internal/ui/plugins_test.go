@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/plugin"
+)
+
+type fakeExporter struct{}
+
+func (fakeExporter) Name() string        { return "yaml" }
+func (fakeExporter) Description() string { return "YAML documents" }
+func (fakeExporter) Extension() string   { return "yaml" }
+func (fakeExporter) Export(ctx context.Context, table plugin.Table) ([]byte, error) {
+	return []byte("rows: " + strings.Join(table.Columns, ",")), nil
+}
+
+func TestExportFormatLabelsIncludePlugins(t *testing.T) {
+	m := Model{}
+	if got := len(m.db.exportFormatLabels()); got != len(builtinExportFormats) {
+		t.Fatalf("Expected %d built-in formats, got %d", len(builtinExportFormats), got)
+	}
+
+	registry := plugin.NewRegistry()
+	if err := registry.Add(fakeExporter{}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	m.SetPlugins(registry)
+
+	labels := m.db.exportFormatLabels()
+	if len(labels) != len(builtinExportFormats)+1 {
+		t.Fatalf("Expected plugin exporter to be listed, got %v", labels)
+	}
+	if last := labels[len(labels)-1]; !strings.HasPrefix(last, "yaml (plugin, .yaml)") {
+		t.Errorf("Unexpected plugin label %q", last)
+	}
+}
+
+func TestAuthorizeWithoutPlugins(t *testing.T) {
+	req := httpclient.Request{
+		Method:  "GET",
+		URL:     "https://api.example.com",
+		Headers: map[string]string{"Accept": "application/json"},
+	}
+
+	got, err := authorizeWithPlugins(context.Background(), nil, req)
+	if err != nil {
+		t.Fatalf("authorizeWithPlugins() error = %v", err)
+	}
+	if got.Headers["Accept"] != "application/json" {
+		t.Errorf("Expected headers to be kept, got %v", got.Headers)
+	}
+
+	req.Headers["Authorization"] = "plugin:vault"
+	if _, err := authorizeWithPlugins(context.Background(), nil, req); err == nil {
+		t.Error("Expected an error for a missing auth plugin")
+	}
+}
@@ -0,0 +1,286 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/runner"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// maxPolicyRetries caps the retries of a request, so a typo does not keep
+// a failing request going for hours
+const maxPolicyRetries = 10
+
+// Fields of the timeout and retries form
+const (
+	policyFieldTimeout = iota
+	policyFieldRetries
+	policyFieldBackoff
+	policyFieldCount
+)
+
+var policyFieldLabels = [policyFieldCount]string{"Timeout", "Retries", "Backoff"}
+
+// RequestDefaults are the timeout and retries of requests whose policy
+// leaves them unset, from the settings
+type RequestDefaults = runner.Defaults
+
+// SetRequestDefaults sets the timeout and retries requests get when their
+// policy does not set them
+func (m *Model) SetRequestDefaults(defaults RequestDefaults) {
+	m.requestDefaults = defaults
+}
+
+// policyForm holds the timeout and retries panel while it is open
+type policyForm struct {
+	inputs [policyFieldCount]textinput.Model
+	focus  int
+	// noFollowRedirects is toggled with Ctrl+F and saved with the fields
+	noFollowRedirects bool
+	err               string
+	notice            string
+}
+
+// sendPolicy returns the timeout and retry policy the request is sent
+// with, the same a collection run or godev run would use
+func (m Model) sendPolicy() (time.Duration, httpclient.RetryPolicy) {
+	return m.requestDefaults.Policy(m.builder.method, m.requestPolicy)
+}
+
+// openRequestPolicy shows the timeout and retries panel filled with the
+// policy of the request; empty fields show the default they keep
+func (m *Model) openRequestPolicy() {
+	var policy storage.RequestPolicy
+	if m.requestPolicy != nil {
+		policy = *m.requestPolicy
+	}
+
+	defaults := m.requestDefaults
+	placeholders := [policyFieldCount]string{
+		policyFieldTimeout: i18n.Tf("policy.default", formatPolicyDuration(defaults.Timeout)),
+		policyFieldRetries: i18n.Tf("policy.default", strconv.Itoa(defaults.Retry.Retries)),
+		policyFieldBackoff: i18n.Tf("policy.default", formatPolicyDuration(defaults.Retry.Backoff)),
+	}
+	var values [policyFieldCount]string
+	if policy.TimeoutMs > 0 {
+		values[policyFieldTimeout] = formatPolicyDuration(time.Duration(policy.TimeoutMs) * time.Millisecond)
+	}
+	if policy.Retries != nil {
+		values[policyFieldRetries] = strconv.Itoa(*policy.Retries)
+	}
+	if policy.BackoffMs > 0 {
+		values[policyFieldBackoff] = formatPolicyDuration(time.Duration(policy.BackoffMs) * time.Millisecond)
+	}
+
+	form := &m.policyForm
+	for i := range form.inputs {
+		input := textinput.New()
+		input.Placeholder = placeholders[i]
+		input.CharLimit = 16
+		input.Width = 30
+		input.SetValue(values[i])
+		form.inputs[i] = input
+	}
+	form.focus = policyFieldTimeout
+	form.inputs[form.focus].Focus()
+	form.noFollowRedirects = policy.NoFollowRedirects
+	form.err = ""
+	form.notice = ""
+	m.state = StateRequestPolicy
+}
+
+// formatPolicyDuration shows a duration as it is typed in the form
+func formatPolicyDuration(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	return d.String()
+}
+
+// policyDraft parses the form. It returns nil when every field is empty,
+// as the request then keeps the defaults.
+func (m Model) policyDraft() (*storage.RequestPolicy, error) {
+	form := m.policyForm
+	var policy storage.RequestPolicy
+
+	parseDuration := func(field int) (int64, error) {
+		value := strings.TrimSpace(form.inputs[field].Value())
+		if value == "" {
+			return 0, nil
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil || d < time.Millisecond {
+			return 0, fmt.Errorf("%s", i18n.Tf("policy.invalid_duration", policyFieldLabels[field], value))
+		}
+		return d.Milliseconds(), nil
+	}
+
+	var err error
+	if policy.TimeoutMs, err = parseDuration(policyFieldTimeout); err != nil {
+		return nil, err
+	}
+	if policy.BackoffMs, err = parseDuration(policyFieldBackoff); err != nil {
+		return nil, err
+	}
+	if value := strings.TrimSpace(form.inputs[policyFieldRetries].Value()); value != "" {
+		retries, err := strconv.Atoi(value)
+		if err != nil || retries < 0 || retries > maxPolicyRetries {
+			return nil, fmt.Errorf("%s", i18n.Tf("policy.invalid_retries", value, maxPolicyRetries))
+		}
+		policy.Retries = &retries
+	}
+	policy.NoFollowRedirects = form.noFollowRedirects
+
+	if policy == (storage.RequestPolicy{}) {
+		return nil, nil
+	}
+	return &policy, nil
+}
+
+// saveRequestPolicy applies the policy to the request being edited and
+// stores it with the loaded saved request, if any. A nil policy removes it.
+func (m *Model) saveRequestPolicy(policy *storage.RequestPolicy) {
+	if m.storage != nil && m.builder.requestSaved && m.builder.currentRequestSavedID != "" {
+		if m.blockedByReadOnly("save timeout and retries") {
+			return
+		}
+		if err := m.storage.UpdateRequestPolicy(m.builder.currentRequestSavedID, policy); err != nil {
+			m.policyForm.err = err.Error()
+			return
+		}
+		m.requests.saved = m.storage.GetRequests()
+	}
+
+	m.requestPolicy = policy
+	m.policyForm.err = ""
+	if policy == nil {
+		m.policyForm.notice = i18n.T("policy.removed")
+	} else {
+		m.policyForm.notice = i18n.T("policy.saved")
+	}
+}
+
+func (m Model) handleRequestPolicyKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	form := &m.policyForm
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return m, tea.Quit
+
+	case "esc":
+		form.inputs[form.focus].Blur()
+		m.state = StateRequestBuilder
+		return m, nil
+
+	case "tab", "down", "shift+tab", "up":
+		delta := 1
+		if msg.String() == "shift+tab" || msg.String() == "up" {
+			delta = -1
+		}
+		form.inputs[form.focus].Blur()
+		form.focus = (form.focus + delta + policyFieldCount) % policyFieldCount
+		form.inputs[form.focus].Focus()
+		return m, nil
+
+	case "ctrl+s", "enter":
+		policy, err := m.policyDraft()
+		if err != nil {
+			form.err = err.Error()
+			form.notice = ""
+			return m, nil
+		}
+		m.saveRequestPolicy(policy)
+		return m, nil
+
+	case "ctrl+f":
+		form.noFollowRedirects = !form.noFollowRedirects
+		form.notice = ""
+		return m, nil
+
+	case "ctrl+d":
+		for i := range form.inputs {
+			form.inputs[i].SetValue("")
+		}
+		form.noFollowRedirects = false
+		m.saveRequestPolicy(nil)
+		return m, nil
+	}
+
+	form.inputs[form.focus], cmd = form.inputs[form.focus].Update(msg)
+	return m, cmd
+}
+
+func (m Model) viewRequestPolicy() string {
+	var b strings.Builder
+	form := m.policyForm
+
+	title := i18n.T("title.policy")
+	if m.requestPolicy != nil {
+		title += " • " + i18n.T("policy.custom")
+	}
+	b.WriteString(TitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	for field := range form.inputs {
+		labelStyle := MutedStyle
+		border := ColorBorder
+		if field == form.focus {
+			labelStyle = TextStyle
+			border = ColorAccent
+		}
+		b.WriteString(labelStyle.Render(policyFieldLabels[field] + ":"))
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().
+			Border(roundedBorder()).
+			BorderForeground(lipgloss.Color(border)).
+			Padding(0, 1).
+			Width(form.inputs[field].Width + 2).
+			Render(form.inputs[field].View()))
+		b.WriteString("\n")
+	}
+	b.WriteString(MutedStyle.Render(i18n.T("policy.hint")))
+	b.WriteString("\n\n")
+
+	check := "[x] "
+	if form.noFollowRedirects {
+		check = "[ ] "
+	}
+	b.WriteString(TextStyle.Render(check + i18n.T("policy.follow_redirects")))
+	b.WriteString("\n\n")
+
+	timeout, retry := m.sendPolicy()
+	summary := i18n.Tf("policy.effective", m.builder.method, formatPolicyDuration(timeout), retry.Retries)
+	if retry.Retries > 0 {
+		summary += " • " + i18n.Tf("policy.effective_backoff", formatPolicyDuration(retry.Backoff))
+	}
+	b.WriteString(TextStyle.Render(summary))
+	b.WriteString("\n")
+	if !isSafeMethod(m.builder.method) && (m.requestPolicy == nil || m.requestPolicy.Retries == nil) {
+		b.WriteString(MutedStyle.Render(i18n.T("policy.safe_only")))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if form.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + form.err))
+		b.WriteString("\n")
+	} else if form.notice != "" {
+		b.WriteString(SuccessStyle.Render(form.notice))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(RenderFooter(i18n.T("footer.policy")))
+
+	return Center(m.width, m.height, b.String())
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/storage"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowRequestPolicyRetriesAndShowsAttempts(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("recovered"))
+	}))
+	defer server.Close()
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL).Press("tab", "t").AssertView("Timeout & Retries", "0 retries")
+
+	d.Press("tab").Type("2").Press("tab").Type("1ms").Press("enter")
+	d.AssertView("✓ Timeout and retries saved", "Timeout & Retries • custom", "2 retries")
+
+	d.Press("esc", "shift+tab", "enter").WaitFor("recovered")
+	d.AssertView("3 attempts")
+	if calls.Load() != 3 {
+		t.Errorf("Server saw %d requests, want 3", calls.Load())
+	}
+}
+
+func TestSendPolicyDefaultRetriesOnlyForSafeMethods(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	m := NewModel()
+	m.SetRequestDefaults(RequestDefaults{
+		Timeout: 30 * time.Second,
+		Retry:   httpclient.RetryPolicy{Retries: 3, Backoff: 500 * time.Millisecond},
+	})
+
+	m.builder.method = "GET"
+	if timeout, retry := m.sendPolicy(); timeout != 30*time.Second || retry.Retries != 3 {
+		t.Errorf("GET policy = %v, %+v; want the defaults", timeout, retry)
+	}
+
+	m.builder.method = "POST"
+	if _, retry := m.sendPolicy(); retry.Retries != 0 {
+		t.Errorf("POST retries = %d, want 0 without a policy of its own", retry.Retries)
+	}
+
+	retries := 1
+	m.requestPolicy = &storage.RequestPolicy{TimeoutMs: 2000, Retries: &retries}
+	timeout, retry := m.sendPolicy()
+	if timeout != 2*time.Second || retry.Retries != 1 || retry.Backoff != 500*time.Millisecond {
+		t.Errorf("POST policy = %v, %+v; want 2s, 1 retry and the default backoff", timeout, retry)
+	}
+}
+
+func TestRequestPolicyStoredWithSavedRequest(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	m := NewModel()
+	if err := m.storage.SaveRequest("GET example", "GET", "https://example.com", nil, "", nil); err != nil {
+		t.Fatalf("SaveRequest() error = %v", err)
+	}
+	m.builder.currentRequestSavedID = m.storage.GetRequests()[0].ID
+	m.builder.requestSaved = true
+
+	m.openRequestPolicy()
+	m.policyForm.inputs[policyFieldRetries].SetValue("11")
+	if _, err := m.policyDraft(); err == nil {
+		t.Error("policyDraft() accepted 11 retries")
+	}
+
+	m.policyForm.inputs[policyFieldRetries].SetValue("4")
+	m.policyForm.inputs[policyFieldTimeout].SetValue("5s")
+	policy, err := m.policyDraft()
+	if err != nil {
+		t.Fatalf("policyDraft() error = %v", err)
+	}
+	m.saveRequestPolicy(policy)
+
+	stored := m.storage.GetRequests()[0].Policy
+	if stored == nil || stored.TimeoutMs != 5000 || stored.Retries == nil || *stored.Retries != 4 {
+		t.Fatalf("Stored policy = %+v, want 5s and 4 retries", stored)
+	}
+
+	m.saveRequestPolicy(nil)
+	if stored := m.storage.GetRequests()[0].Policy; stored != nil {
+		t.Errorf("Stored policy = %+v after removing it", stored)
+	}
+}
@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strconv"
+	"strings"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// sendCheck is one line of the checklist run before a request is sent
+type sendCheck struct {
+	// label is the i18n key naming what was checked
+	label string
+	err   error
+}
+
+// checkRequest runs the checks a request must pass before it is sent, on
+// req as it goes out: aliases and variables resolved. Checks that do not
+// apply, like auth on a request without any, are left out.
+func (m Model) checkRequest(req httpclient.Request) []sendCheck {
+	checks := []sendCheck{
+		{label: "precheck.url", err: validateURL(req.URL)},
+		{label: "precheck.body", err: m.checkBody(req)},
+	}
+	if m.requestAuth != nil {
+		checks = append(checks, sendCheck{label: "precheck.auth", err: m.checkAuth()})
+	}
+	if m.hmacAuth != nil {
+		checks = append(checks, sendCheck{label: "precheck.signing", err: m.signer().Validate()})
+	}
+	if value, ok := headerLookup(req.Headers, "Content-Length"); ok {
+		checks = append(checks, sendCheck{label: "precheck.content_length", err: checkContentLength(req, value)})
+	}
+	return checks
+}
+
+// sendChecksFailed reports whether any check failed
+func sendChecksFailed(checks []sendCheck) bool {
+	for _, check := range checks {
+		if check.err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// checkBody parses the body as its mode is sent: the form fields of a
+// multipart body, and JSON when the Content-Type says so or, without one,
+// when the body looks like JSON
+func (m Model) checkBody(req httpclient.Request) error {
+	ct := contentType(req.Headers)
+	switch httpclient.BodyModeOf(ct) {
+	case httpclient.BodyMultipart:
+		_, err := httpclient.ParseFormFields(req.Body)
+		return err
+	case httpclient.BodyForm:
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(ct)
+	isJSON := mediaType == httpclient.ContentTypeJSON || strings.HasSuffix(mediaType, "+json")
+	if ct == "" {
+		trimmed := strings.TrimSpace(req.Body)
+		isJSON = strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")
+	}
+	if !isJSON || strings.TrimSpace(req.Body) == "" {
+		return nil
+	}
+	var js interface{}
+	if err := json.Unmarshal([]byte(req.Body), &js); err != nil {
+		return fmt.Errorf("invalid json: %v", err)
+	}
+	return nil
+}
+
+// checkAuth reports auth that would not be attached: settings missing a
+// field, or credentials naming variables the environment does not define
+func (m Model) checkAuth() error {
+	auth := httpclient.Auth(*m.requestAuth)
+	auth.Username = m.resolveVariables(auth.Username)
+	auth.Password = m.resolveVariables(auth.Password)
+	auth.Token = m.resolveVariables(auth.Token)
+	auth.KeyName = m.resolveVariables(auth.KeyName)
+	auth.KeyValue = m.resolveVariables(auth.KeyValue)
+	if err := auth.Validate(); err != nil {
+		return err
+	}
+	for _, field := range []string{auth.Username, auth.Password, auth.Token, auth.KeyName, auth.KeyValue} {
+		if names := storage.UnresolvedVariables(field); len(names) > 0 {
+			return fmt.Errorf("%s", i18n.Tf("precheck.undefined_var", names[0]))
+		}
+	}
+	return nil
+}
+
+// checkContentLength reports a Content-Length header that is not a byte
+// count or does not match the body. Multipart bodies are measured as they
+// are streamed, so any value is replaced.
+func checkContentLength(req httpclient.Request, value string) error {
+	length, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || length < 0 {
+		return fmt.Errorf("%s", i18n.Tf("precheck.length_invalid", value))
+	}
+	ct := contentType(req.Headers)
+	body := req.Body
+	switch httpclient.BodyModeOf(ct) {
+	case httpclient.BodyMultipart:
+		return nil
+	case httpclient.BodyForm:
+		body = httpclient.EncodeFormBody(body)
+	}
+	if length != int64(len(body)) {
+		return fmt.Errorf("%s", i18n.Tf("precheck.length_mismatch", length, len(body)))
+	}
+	return nil
+}
+
+// headerLookup finds a header whatever the case of its name
+func headerLookup(headers map[string]string, name string) (string, bool) {
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/storage"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func failedLabels(checks []sendCheck) []string {
+	var labels []string
+	for _, check := range checks {
+		if check.err != nil {
+			labels = append(labels, check.label)
+		}
+	}
+	return labels
+}
+
+func TestCheckRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		auth   *storage.RequestAuth
+		req    httpclient.Request
+		failed string
+	}{
+		{
+			name: "valid request",
+			req:  httpclient.Request{URL: "https://api.example.com", Body: `{"a": 1}`},
+		},
+		{
+			name:   "invalid URL",
+			req:    httpclient.Request{URL: "api.example.com"},
+			failed: "precheck.url",
+		},
+		{
+			name:   "JSON body that does not parse",
+			req:    httpclient.Request{URL: "https://api.example.com", Headers: map[string]string{"Content-Type": "application/json"}, Body: `{"a": `},
+			failed: "precheck.body",
+		},
+		{
+			name: "XML body is not parsed as JSON",
+			req:  httpclient.Request{URL: "https://api.example.com", Headers: map[string]string{"Content-Type": "text/xml"}, Body: "<a/>"},
+		},
+		{
+			name:   "bearer auth without a token",
+			auth:   &storage.RequestAuth{Type: httpclient.AuthBearer},
+			req:    httpclient.Request{URL: "https://api.example.com"},
+			failed: "precheck.auth",
+		},
+		{
+			name:   "auth with an undefined variable",
+			auth:   &storage.RequestAuth{Type: httpclient.AuthBearer, Token: "{{TOKEN}}"},
+			req:    httpclient.Request{URL: "https://api.example.com"},
+			failed: "precheck.auth",
+		},
+		{
+			name:   "Content-Length that does not match the body",
+			req:    httpclient.Request{URL: "https://api.example.com", Headers: map[string]string{"content-length": "10"}, Body: "abc"},
+			failed: "precheck.content_length",
+		},
+		{
+			name: "Content-Length of a form body as encoded",
+			req: httpclient.Request{URL: "https://api.example.com", Headers: map[string]string{
+				"Content-Type": "application/x-www-form-urlencoded", "Content-Length": "7",
+			}, Body: "a=1\nb=2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{requestAuth: tt.auth}
+			got := strings.Join(failedLabels(m.checkRequest(tt.req)), ",")
+			if got != tt.failed {
+				t.Errorf("failed checks = %q, want %q", got, tt.failed)
+			}
+		})
+	}
+}
+
+func TestFlowFailedChecksKeepRequestInBuilder(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+
+	m := *NewModel()
+	m.builder.headers = map[string]string{"Content-Type": "application/json"}
+	m.builder.body = `{"name": `
+	d := tuitest.New(t, m).Resize(160, 50)
+	d.Press("a").Type("https://api.example.com/users").Press("enter")
+
+	d.AssertView("Request not sent, fix these first:", "✓ URL", "✗ Body: invalid json")
+	if got := d.Model().(Model).state; got != StateRequestBuilder {
+		t.Errorf("Expected to stay in the request builder, got state %v", got)
+	}
+}
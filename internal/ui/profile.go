@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// themeColors maps the color names used in profiles to the theme colors
+var themeColors = map[string]*string{
+	"background":    &ColorBg,
+	"panel":         &ColorPanel,
+	"border":        &ColorBorder,
+	"text":          &ColorText,
+	"muted":         &ColorMuted,
+	"dim":           &ColorDim,
+	"accent":        &ColorAccent,
+	"success":       &ColorSuccess,
+	"error":         &ColorError,
+	"warning":       &ColorWarning,
+	"status_2xx":    &Color2xx,
+	"status_3xx":    &Color3xx,
+	"status_4xx":    &Color4xx,
+	"status_5xx":    &Color5xx,
+	"method_get":    &ColorGET,
+	"method_post":   &ColorPOST,
+	"method_put":    &ColorPUT,
+	"method_patch":  &ColorPATCH,
+	"method_delete": &ColorDELETE,
+}
+
+// ThemeColors returns the current theme colors by name
+func ThemeColors() map[string]string {
+	colors := make(map[string]string, len(themeColors))
+	for name, color := range themeColors {
+		colors[name] = *color
+	}
+	return colors
+}
+
+// ApplyTheme changes the named theme colors and rebuilds the styles. Colors
+// are hex values (#FF8C00) or ANSI color numbers (0-255).
+func ApplyTheme(theme map[string]string) error {
+	if err := ValidateTheme(theme); err != nil {
+		return err
+	}
+
+	for name, value := range theme {
+		*themeColors[name] = value
+	}
+	buildStyles()
+	return nil
+}
+
+// ValidateTheme checks every color name and value of a theme
+func ValidateTheme(theme map[string]string) error {
+	for _, name := range sortedNames(theme) {
+		if _, ok := themeColors[name]; !ok {
+			return fmt.Errorf("unknown theme color %q", name)
+		}
+		if !validColor(theme[name]) {
+			return fmt.Errorf("invalid value %q for theme color %s", theme[name], name)
+		}
+	}
+	return nil
+}
+
+func validColor(value string) bool {
+	if strings.HasPrefix(value, "#") {
+		hex := value[1:]
+		if len(hex) != 3 && len(hex) != 6 {
+			return false
+		}
+		_, err := strconv.ParseUint(hex, 16, 32)
+		return err == nil
+	}
+
+	n, err := strconv.Atoi(value)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// bindings maps the action names used in profiles to the key bindings
+func (k *KeyMap) bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"quit":            &k.Quit,
+		"help":            &k.Help,
+		"back":            &k.Back,
+		"up":              &k.Up,
+		"down":            &k.Down,
+		"left":            &k.Left,
+		"right":           &k.Right,
+		"page_up":         &k.PageUp,
+		"page_down":       &k.PageDown,
+		"home":            &k.Home,
+		"end":             &k.End,
+		"vim_up":          &k.VimUp,
+		"vim_down":        &k.VimDown,
+		"vim_left":        &k.VimLeft,
+		"vim_right":       &k.VimRight,
+		"enter":           &k.Enter,
+		"tab":             &k.Tab,
+		"shift_tab":       &k.ShiftTab,
+		"delete":          &k.Delete,
+		"backspace":       &k.Backspace,
+		"execute_request": &k.ExecuteRequest,
+		"save_request":    &k.SaveRequest,
+		"copy_url":        &k.CopyURL,
+		"copy_curl":       &k.CopyCurl,
+		"switch_method":   &k.SwitchMethod,
+		"edit_headers":    &k.EditHeaders,
+		"edit_body":       &k.EditBody,
+		"edit_query":      &k.EditQuery,
+		"execute_query":   &k.ExecuteQuery,
+		"save_query":      &k.SaveQuery,
+		"export_results":  &k.ExportResults,
+		"connect_db":      &k.ConnectDB,
+		"show_schema":     &k.ShowSchema,
+		"query_history":   &k.QueryHistory,
+		"toggle_chart":    &k.ToggleChart,
+		"select_item":     &k.SelectItem,
+		"delete_item":     &k.DeleteItem,
+		"search_toggle":   &k.SearchToggle,
+		"add_env":         &k.AddEnv,
+		"edit_env":        &k.EditEnv,
+		"delete_env":      &k.DeleteEnv,
+		"switch_env":      &k.SwitchEnv,
+	}
+}
+
+// BindingKeys returns the keys of every action by name
+func (k KeyMap) BindingKeys() map[string][]string {
+	bindings := k.bindings()
+	keys := make(map[string][]string, len(bindings))
+	for name, binding := range bindings {
+		keys[name] = binding.Keys()
+	}
+	return keys
+}
+
+// ApplyBindings replaces the keys of the named actions
+func (k *KeyMap) ApplyBindings(overrides map[string][]string) error {
+	bindings := k.bindings()
+
+	for _, name := range sortedNames(overrides) {
+		if _, ok := bindings[name]; !ok {
+			return fmt.Errorf("unknown key binding %q", name)
+		}
+		if len(overrides[name]) == 0 {
+			return fmt.Errorf("key binding %s has no keys", name)
+		}
+	}
+
+	for name, keys := range overrides {
+		binding := bindings[name]
+		binding.SetKeys(keys...)
+		binding.SetHelp(strings.Join(keys, "/"), binding.Help().Desc)
+	}
+	return nil
+}
+
+// SetKeyBindings applies key binding overrides from a settings profile
+func (m *Model) SetKeyBindings(overrides map[string][]string) error {
+	keymap := DefaultKeyMap()
+	if err := keymap.ApplyBindings(overrides); err != nil {
+		return err
+	}
+	m.keymap = keymap
+	return nil
+}
+
+func sortedNames[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,52 @@
+package ui
+
+import "testing"
+
+func TestApplyTheme(t *testing.T) {
+	original := ThemeColors()
+	defer ApplyTheme(original)
+
+	if err := ApplyTheme(map[string]string{"accent": "#00AAFF", "muted": "245"}); err != nil {
+		t.Fatalf("ApplyTheme() error = %v", err)
+	}
+	if ColorAccent != "#00AAFF" || ThemeColors()["muted"] != "245" {
+		t.Errorf("Theme not applied: accent=%s muted=%s", ColorAccent, ColorMuted)
+	}
+
+	invalid := []map[string]string{
+		{"sparkle": "#FFFFFF"},
+		{"accent": "orange"},
+		{"accent": "#12345"},
+		{"accent": "300"},
+	}
+	for _, theme := range invalid {
+		if err := ApplyTheme(theme); err == nil {
+			t.Errorf("Expected error for theme %v", theme)
+		}
+	}
+	if ColorAccent != "#00AAFF" {
+		t.Errorf("Invalid theme changed the accent to %s", ColorAccent)
+	}
+}
+
+func TestApplyBindings(t *testing.T) {
+	keymap := DefaultKeyMap()
+	if err := keymap.ApplyBindings(map[string][]string{"delete_item": {"x", "delete"}}); err != nil {
+		t.Fatalf("ApplyBindings() error = %v", err)
+	}
+
+	keys := keymap.BindingKeys()["delete_item"]
+	if len(keys) != 2 || keys[0] != "x" || keys[1] != "delete" {
+		t.Errorf("delete_item keys = %v", keys)
+	}
+	if keymap.DeleteItem.Help().Key != "x/delete" {
+		t.Errorf("Help key = %q, want x/delete", keymap.DeleteItem.Help().Key)
+	}
+
+	if err := keymap.ApplyBindings(map[string][]string{"teleport": {"t"}}); err == nil {
+		t.Error("Expected error for unknown action")
+	}
+	if err := keymap.ApplyBindings(map[string][]string{"quit": {}}); err == nil {
+		t.Error("Expected error for a binding without keys")
+	}
+}
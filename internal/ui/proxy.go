@@ -0,0 +1,308 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// Fields of the proxy settings form. The environment proxy is only shown
+// while an environment is active.
+const (
+	proxyFieldURL = iota
+	proxyFieldNoProxy
+	proxyFieldFromEnv
+	proxyFieldEnvProxy
+	proxyFieldCount
+)
+
+// proxyForm holds the proxy settings screen while it is open
+type proxyForm struct {
+	inputs  [proxyFieldCount]textinput.Model
+	fromEnv bool
+	focus   int
+	back    AppState
+	err     string
+	notice  string
+}
+
+// SetProxy sets the global proxy settings, and save keeps them when they
+// are changed on the proxy screen. A nil save keeps changes for the
+// session only.
+func (m *Model) SetProxy(settings httpclient.ProxySettings, save func(httpclient.ProxySettings) error) {
+	m.proxySettings = settings
+	m.saveProxy = save
+	m.applyProxy()
+}
+
+// activeEnvironmentProxy returns the proxy of the active environment, ""
+// when it has none
+func (m *Model) activeEnvironmentProxy() string {
+	if env := m.envs.config.Active(); env != nil {
+		return env.Proxy
+	}
+	return ""
+}
+
+// effectiveProxy returns the global settings with the proxy of the active
+// environment, if it has one, in place of the global proxy
+func (m *Model) effectiveProxy() httpclient.ProxySettings {
+	settings := m.proxySettings
+	if proxy := m.activeEnvironmentProxy(); proxy != "" {
+		settings.URL = proxy
+	}
+	return settings
+}
+
+// applyProxy points the client at the effective proxy. The client keeps
+// its connections when nothing changed, which is the usual case on a send.
+func (m *Model) applyProxy() {
+	settings := m.effectiveProxy()
+	if settings == m.httpClient.Proxy() {
+		return
+	}
+	if err := m.httpClient.SetProxy(settings); err != nil {
+		// A hand-edited environment file can hold an invalid proxy; the
+		// global settings are validated before they get here
+		m.httpClient.SetProxy(m.proxySettings)
+	}
+}
+
+// openProxySettings shows the proxy settings screen filled with the
+// current settings
+func (m *Model) openProxySettings(back AppState) {
+	width := m.layout.InputWidth
+	if width <= 0 {
+		width = 60
+	}
+	placeholders := [proxyFieldCount]string{
+		proxyFieldURL:      "http://proxy:3128 or socks5://localhost:1080",
+		proxyFieldNoProxy:  "localhost,.internal.example.com,10.0.0.0/8",
+		proxyFieldEnvProxy: i18n.T("proxy.env_placeholder"),
+	}
+	values := [proxyFieldCount]string{
+		proxyFieldURL:      m.proxySettings.URL,
+		proxyFieldNoProxy:  m.proxySettings.NoProxy,
+		proxyFieldEnvProxy: m.activeEnvironmentProxy(),
+	}
+	form := &m.proxyForm
+	for i := range form.inputs {
+		input := textinput.New()
+		input.Placeholder = placeholders[i]
+		input.CharLimit = 500
+		input.Width = width
+		input.SetValue(values[i])
+		form.inputs[i] = input
+	}
+	form.fromEnv = m.proxySettings.FromEnvironment
+	form.focus = proxyFieldURL
+	form.inputs[proxyFieldURL].Focus()
+	form.back = back
+	form.err = ""
+	form.notice = ""
+	m.state = StateProxySettings
+}
+
+// proxyFields returns the fields shown, in focus order
+func (m Model) proxyFields() []int {
+	fields := []int{proxyFieldURL, proxyFieldNoProxy, proxyFieldFromEnv}
+	if m.envs.config.Active() != nil {
+		fields = append(fields, proxyFieldEnvProxy)
+	}
+	return fields
+}
+
+func (m *Model) focusProxyField(delta int) {
+	form := &m.proxyForm
+	fields := m.proxyFields()
+	current := 0
+	for i, field := range fields {
+		if field == form.focus {
+			current = i
+		}
+	}
+	form.inputs[form.focus].Blur()
+	form.focus = fields[(current+delta+len(fields))%len(fields)]
+	form.inputs[form.focus].Focus()
+}
+
+// saveProxySettings applies the typed settings and keeps them: the global
+// ones through saveProxy, the environment proxy in the environment
+func (m *Model) saveProxySettings() {
+	form := &m.proxyForm
+	settings := httpclient.ProxySettings{
+		URL:             strings.TrimSpace(form.inputs[proxyFieldURL].Value()),
+		NoProxy:         strings.TrimSpace(form.inputs[proxyFieldNoProxy].Value()),
+		FromEnvironment: form.fromEnv,
+	}
+	envProxy := strings.TrimSpace(form.inputs[proxyFieldEnvProxy].Value())
+	if err := settings.Validate(); err != nil {
+		form.err = err.Error()
+		return
+	}
+	if envProxy != "" {
+		if _, err := httpclient.ParseProxyURL(envProxy); err != nil {
+			form.err = err.Error()
+			return
+		}
+	}
+
+	if m.blockedByReadOnly("save proxy settings") {
+		return
+	}
+	if m.saveProxy != nil {
+		if err := m.saveProxy(settings); err != nil {
+			form.err = err.Error()
+			return
+		}
+	}
+	m.proxySettings = settings
+
+	if env := m.envs.config.Active(); env != nil && env.Proxy != envProxy && m.storage != nil {
+		if err := m.storage.SetEnvironmentProxy(env.Name, envProxy); err != nil {
+			form.err = err.Error()
+			return
+		}
+		m.reportStorageError("failed to reload environments", m.envs.reload(m.storage))
+	}
+
+	m.applyProxy()
+	form.err = ""
+	form.notice = i18n.T("proxy.saved")
+}
+
+func (m Model) handleProxyKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	form := &m.proxyForm
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return m, tea.Quit
+
+	case "esc":
+		form.inputs[form.focus].Blur()
+		m.state = form.back
+		return m, nil
+
+	case "tab", "down":
+		m.focusProxyField(1)
+		return m, nil
+
+	case "shift+tab", "up":
+		m.focusProxyField(-1)
+		return m, nil
+
+	case "ctrl+s", "enter":
+		m.saveProxySettings()
+		return m, nil
+
+	case " ", "left", "right":
+		if form.focus == proxyFieldFromEnv {
+			form.fromEnv = !form.fromEnv
+			return m, nil
+		}
+	}
+
+	if form.focus == proxyFieldFromEnv {
+		return m, nil
+	}
+	form.inputs[form.focus], cmd = form.inputs[form.focus].Update(msg)
+	return m, cmd
+}
+
+func (m Model) viewProxySettings() string {
+	var b strings.Builder
+	form := m.proxyForm
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.proxy_settings")))
+	b.WriteString("\n\n")
+
+	for _, field := range m.proxyFields() {
+		focused := field == form.focus
+		switch field {
+		case proxyFieldURL:
+			b.WriteString(viewLabeledInput(i18n.T("proxy.url"), form.inputs[field], focused))
+		case proxyFieldNoProxy:
+			b.WriteString(viewLabeledInput(i18n.T("proxy.no_proxy"), form.inputs[field], focused))
+		case proxyFieldFromEnv:
+			check := "[ ]"
+			if form.fromEnv {
+				check = "[x]"
+			}
+			line := check + " " + i18n.T("proxy.from_env")
+			if focused {
+				b.WriteString(ButtonActive.Render(line))
+			} else {
+				b.WriteString(TextStyle.Render(line))
+			}
+			b.WriteString("\n")
+			b.WriteString(MutedStyle.Render(m.viewProxyEnvironment()))
+			b.WriteString("\n")
+		case proxyFieldEnvProxy:
+			b.WriteString("\n")
+			label := i18n.Tf("proxy.env_proxy", m.envs.config.ActiveEnvironment)
+			b.WriteString(viewLabeledInput(label, form.inputs[field], focused))
+		}
+	}
+	b.WriteString("\n")
+
+	if proxy := m.effectiveProxy().URL; proxy != "" {
+		b.WriteString(TextStyle.Render(i18n.Tf("proxy.in_use", redactProxy(proxy))))
+	} else if form.fromEnv {
+		b.WriteString(MutedStyle.Render(i18n.T("proxy.in_use_env")))
+	} else {
+		b.WriteString(MutedStyle.Render(i18n.T("proxy.in_use_none")))
+	}
+	b.WriteString("\n")
+
+	if form.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + form.err))
+		b.WriteString("\n")
+	} else if form.notice != "" {
+		b.WriteString(SuccessStyle.Render(form.notice))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(RenderFooter(i18n.T("footer.proxy")))
+
+	return Center(m.width, m.height, b.String())
+}
+
+// viewProxyEnvironment shows the proxy variables as the client reads them
+func (m Model) viewProxyEnvironment() string {
+	var parts []string
+	for _, name := range []string{"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY"} {
+		value := httpclient.ProxyEnvVar(name)
+		if value == "" {
+			value = "-"
+		} else if name != "NO_PROXY" {
+			value = redactProxy(value)
+		}
+		parts = append(parts, fmt.Sprintf("%s=%s", name, value))
+	}
+	return "    " + strings.Join(parts, "  ")
+}
+
+// redactProxy hides the password of a proxy URL
+func redactProxy(raw string) string {
+	u, err := httpclient.ParseProxyURL(raw)
+	if err != nil {
+		return raw
+	}
+	return u.Redacted()
+}
+
+// proxyHost returns the host:port of a proxy URL for the builder title
+func proxyHost(raw string) string {
+	u, err := httpclient.ParseProxyURL(raw)
+	if err != nil {
+		return raw
+	}
+	return u.Host
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowProxySettings(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	for _, name := range []string{"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY", "http_proxy", "https_proxy", "no_proxy"} {
+		t.Setenv(name, "")
+	}
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("proxied " + r.URL.String()))
+	}))
+	defer proxy.Close()
+	proxyHost := strings.TrimPrefix(proxy.URL, "http://")
+
+	var saved []httpclient.ProxySettings
+	m := NewModel()
+	m.SetProxy(httpclient.DefaultProxySettings(), func(s httpclient.ProxySettings) error {
+		saved = append(saved, s)
+		return nil
+	})
+
+	d := tuitest.New(t, *m).Resize(160, 50)
+	d.Press("p").AssertView("Proxy Settings", "[x] Use HTTP_PROXY", "HTTP_PROXY=-", "Requests follow the proxy environment variables")
+	d.Type("ftp://" + proxyHost).Press("enter").AssertView(`unsupported proxy scheme "ftp"`)
+	d.Press("ctrl+u").Type(proxy.URL).Press("tab").Type("internal.example.com").Press("enter")
+	d.AssertView("✓ Proxy settings saved", "Requests go through "+proxy.URL)
+	if len(saved) != 1 || saved[0].URL != proxy.URL || saved[0].NoProxy != "internal.example.com" || !saved[0].FromEnvironment {
+		t.Fatalf("saved = %+v", saved)
+	}
+
+	d.Press("esc", "a").Type("http://api.example.invalid/x").Press("tab").AssertView("[PROXY: " + proxyHost + "]")
+	d.Press("shift+tab", "enter").WaitFor("proxied http://api.example.invalid/x")
+
+	d.Press("esc", "tab", "P", "ctrl+u", "tab", "tab", " ").AssertView("[ ] Use HTTP_PROXY")
+	d.Press("enter").AssertView("✓ Proxy settings saved", "Requests are sent directly")
+	d.Press("esc").AssertNoView("[PROXY:")
+}
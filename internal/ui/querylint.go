@@ -0,0 +1,25 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/abneribeiro/godev/internal/database"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// viewQueryLint warns about the mistakes the linter finds in the query
+// being edited, as it is typed, so they are seen before it runs
+func (d *DatabaseExplorer) viewQueryLint() string {
+	warnings := database.LintQuery(d.editor.Value())
+	if len(warnings) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, w := range warnings {
+		message := i18n.Tf("lint."+string(w.Rule), w.Detail)
+		b.WriteString(WarningStyle.Render("⚠ " + i18n.Tf("lint.line", w.Line, message)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/database"
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestQueryEditorShowsLintWarnings(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	m := NewModel()
+	m.state = StateDatabaseQueryEditor
+	m.db.editor.SetValue("SELECT *\nFROM users;\nDELETE FROM sessions")
+
+	d := tuitest.New(t, *m).Resize(160, 50)
+	d.AssertView("⚠ line 1: SELECT * fetches every column", "⚠ line 3: DELETE without WHERE changes every row")
+
+	m.db.editor.SetValue("SELECT id FROM users WHERE id = 1")
+	tuitest.New(t, *m).Resize(160, 50).AssertNoView("⚠")
+}
+
+func TestSavedQueryListCountsLintWarnings(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	m := NewModel()
+	m.state = StateDatabaseQueryList
+	m.db.savedQueries = []database.SavedQuery{
+		{Name: "all users", Query: "SELECT * FROM users"},
+		{Name: "one user", Query: "SELECT id FROM users WHERE id = 1"},
+	}
+
+	d := tuitest.New(t, *m).Resize(160, 50)
+	d.AssertView("all users ⚠ 1")
+}
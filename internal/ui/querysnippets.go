@@ -0,0 +1,209 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/database"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// QuerySnippets is the picker of the query templates, opened from the SQL
+// editor with Ctrl+O
+type QuerySnippets struct {
+	templates []database.QueryTemplate
+	selected  int
+}
+
+func newQuerySnippets() QuerySnippets {
+	return QuerySnippets{templates: database.BuiltInQueryTemplates()}
+}
+
+// open shows the picker from the top
+func (q *QuerySnippets) open(h host) {
+	q.selected = 0
+	h.navigate(StateQuerySnippets)
+}
+
+func (q *QuerySnippets) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc", "q":
+		h.navigate(StateDatabaseQueryEditor)
+
+	case "up", "k":
+		if q.selected > 0 {
+			q.selected--
+		}
+
+	case "down", "j":
+		if q.selected < len(q.templates)-1 {
+			q.selected++
+		}
+
+	case "enter":
+		if len(q.templates) > 0 {
+			h.databaseExplorer().insertTemplate(h, q.templates[q.selected])
+		}
+	}
+	return nil
+}
+
+func (q *QuerySnippets) View(h host) string {
+	width, height := h.size()
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.Tf("title.query_snippets", len(q.templates))))
+	b.WriteString("\n\n")
+
+	for i, tmpl := range q.templates {
+		if i == q.selected {
+			b.WriteString(ListItemSelectedStyle.Render("> " + tmpl.Name))
+		} else {
+			b.WriteString(ListItemStyle.Render("  " + tmpl.Name))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(q.templates) > 0 {
+		tmpl := q.templates[q.selected]
+		snippet := database.ParseSnippet(tmpl.Body)
+		preview, _ := snippet.Expand(snippet.Defaults(), 0)
+		b.WriteString("\n")
+		b.WriteString(TextStyle.Render(tmpl.Description))
+		b.WriteString("\n\n")
+		b.WriteString(MutedStyle.Render(preview))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(RenderFooter(i18n.T("footer.query_snippets")))
+
+	return Center(width, height, b.String())
+}
+
+// snippetFill follows the tab stops of a query template inserted into the
+// SQL editor. What is typed goes to the current stop, and the editor is
+// rewritten so every use of the stop shows it.
+type snippetFill struct {
+	name    string
+	snippet database.Snippet
+	values  map[int]string
+	// prefix is the query the template was added after
+	prefix  string
+	current int
+	// typed is false until the current stop is typed into; the first key
+	// then replaces its default
+	typed bool
+}
+
+// insertTemplate adds tmpl to the end of the query in the editor and
+// starts filling its tab stops
+func (d *DatabaseExplorer) insertTemplate(h host, tmpl database.QueryTemplate) {
+	prefix := strings.TrimRight(d.editor.Value(), "\n ")
+	if prefix != "" {
+		prefix += "\n\n"
+	}
+	snippet := database.ParseSnippet(tmpl.Body)
+	fill := &snippetFill{name: tmpl.Name, snippet: snippet, values: snippet.Defaults(), prefix: prefix}
+
+	h.navigate(StateDatabaseQueryEditor)
+	d.editor.Focus()
+	if len(snippet.Stops) == 0 {
+		text, _ := snippet.Expand(fill.values, 0)
+		d.editor.SetValue(prefix + text)
+		return
+	}
+	d.snippetFill = fill
+	d.renderSnippetFill()
+}
+
+// renderSnippetFill writes the template with the values so far into the
+// editor, with the cursor after the current stop
+func (d *DatabaseExplorer) renderSnippetFill() {
+	fill := d.snippetFill
+	text, cursor := fill.snippet.Expand(fill.values, fill.snippet.Stops[fill.current].Number)
+	d.editor.SetValue(fill.prefix + text)
+	moveTextareaCursor(&d.editor, fill.prefix+text[:cursor])
+}
+
+// handleSnippetFillKeys fills the tab stops: Tab and Shift+Tab move
+// between them, Enter or Esc keeps the query as it is. Other keys end the
+// filling and work as usual.
+func (d *DatabaseExplorer) handleSnippetFillKeys(msg tea.KeyMsg) (handled bool) {
+	fill := d.snippetFill
+	number := fill.snippet.Stops[fill.current].Number
+
+	switch msg.Type {
+	case tea.KeyTab, tea.KeyShiftTab:
+		delta := 1
+		if msg.Type == tea.KeyShiftTab {
+			delta = -1
+		}
+		next := fill.current + delta
+		if next < 0 || next >= len(fill.snippet.Stops) {
+			d.finishSnippetFill()
+			return true
+		}
+		fill.current = next
+		fill.typed = false
+	case tea.KeyEnter, tea.KeyEsc:
+		d.finishSnippetFill()
+		return true
+	case tea.KeyBackspace:
+		value := []rune(fill.values[number])
+		if !fill.typed {
+			value = nil
+		} else if len(value) > 0 {
+			value = value[:len(value)-1]
+		}
+		fill.values[number] = string(value)
+		fill.typed = true
+	case tea.KeyRunes, tea.KeySpace:
+		if !fill.typed {
+			fill.values[number] = ""
+		}
+		fill.values[number] += string(msg.Runes)
+		fill.typed = true
+	default:
+		d.finishSnippetFill()
+		return false
+	}
+	d.renderSnippetFill()
+	return true
+}
+
+// finishSnippetFill leaves the query as filled, with the cursor at its end
+func (d *DatabaseExplorer) finishSnippetFill() {
+	fill := d.snippetFill
+	d.snippetFill = nil
+	text, _ := fill.snippet.Expand(fill.values, 0)
+	d.editor.SetValue(fill.prefix + text)
+}
+
+// viewSnippetFill tells which stop is being filled and how to move on
+func (d *DatabaseExplorer) viewSnippetFill() string {
+	fill := d.snippetFill
+	if fill == nil {
+		return ""
+	}
+	stop := fill.snippet.Stops[fill.current]
+	status := i18n.Tf("snippets.filling", fill.name, fill.current+1, len(fill.snippet.Stops), stop.Default)
+	return WarningStyle.Render(status) + "\n" + MutedStyle.Render(i18n.T("snippets.fill_hint")) + "\n\n"
+}
+
+// moveTextareaCursor puts the cursor of a textarea holding more text after
+// before, the text up to where the cursor goes
+func moveTextareaCursor(ta *textarea.Model, before string) {
+	lines := strings.Split(before, "\n")
+	row := len(lines) - 1
+	// CursorUp moves by screen line, so a wrapped line takes several
+	for i := 0; ta.Line() > row && i < 10000; i++ {
+		ta.CursorUp()
+	}
+	ta.SetCursor(len([]rune(lines[row])))
+}
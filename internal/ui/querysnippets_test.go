@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowQuerySnippetTabStops(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	m := NewModel()
+	m.state = StateDatabaseQueryEditor
+	m.db.editor.SetValue("SELECT 1;")
+	m.db.editor.Focus()
+
+	d := tuitest.New(t, *m).Resize(160, 50)
+	d.Press("ctrl+o").AssertView("Query Snippets", "Top N per group", "Duplicate finder")
+	d.Press("down", "enter").AssertView("Snippet Duplicate finder: filling 1 of 2 (table_name)")
+
+	d.Type("users").Press("tab").AssertView("filling 2 of 2 (column)")
+	d.Type("emial").Press("backspace", "backspace", "backspace").Type("ail")
+	if value := d.Model().(Model).db.editor.Value(); !strings.Contains(value, "SELECT email, count(*)") {
+		t.Errorf("Editor = %q, want every use of the stop filled as it is typed", value)
+	}
+
+	d.Press("enter").AssertNoView("filling")
+	want := "SELECT 1;\n\nSELECT email, count(*) AS copies\nFROM users\nGROUP BY email\n"
+	if value := d.Model().(Model).db.editor.Value(); !strings.HasPrefix(value, want) {
+		t.Errorf("Editor = %q, want the snippet after the query with its stops filled", value)
+	}
+}
+
+func TestSnippetFillEndsOnOtherKeys(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	m := NewModel()
+	m.state = StateDatabaseQueryEditor
+	m.db.insertTemplate(m, m.querySnippets.templates[2])
+
+	d := tuitest.New(t, *m).Resize(160, 50)
+	d.AssertView("filling 1 of 1 (20)")
+	d.Press("up").AssertNoView("filling")
+	if value := d.Model().(Model).db.editor.Value(); !strings.HasSuffix(value, "LIMIT 20;") {
+		t.Errorf("Editor = %q, want the defaults kept", value)
+	}
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/abneribeiro/godev/internal/database"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// linkQuery ties the query in the editor to the saved query it was opened
+// from, taking whether it fills in variables from it. nil starts a query
+// of its own, with variables off.
+func (d *DatabaseExplorer) linkQuery(saved *database.SavedQuery) {
+	if saved == nil {
+		d.savedID = ""
+		d.variables = false
+		return
+	}
+	d.savedID = saved.ID
+	d.variables = saved.UseVariables
+}
+
+// toggleQueryVariables turns filling in environment variables on or off
+// for the query in the editor, and for the saved query it came from
+func (d *DatabaseExplorer) toggleQueryVariables(h host) {
+	d.variables = !d.variables
+	if d.savedID == "" || d.storage == nil || h.blockedByReadOnly("change saved query") {
+		return
+	}
+	h.reportStorageError("failed to save query variables", d.storage.SetQueryVariables(d.savedID, d.variables))
+	d.savedQueries = d.storage.GetQueries()
+}
+
+// queryToRun returns the query the editor runs, with the variables of the
+// active environment filled in when the query opted in, and the names of
+// the variables that have no value. The variables are read from the store,
+// so that the query runs with their latest values.
+func (d *DatabaseExplorer) queryToRun(h host) (string, []string) {
+	if !d.variables {
+		return strings.TrimSpace(d.editor.Value()), nil
+	}
+	var vars []storage.Variable
+	if store := h.store(); store != nil {
+		vars, _ = store.GetActiveEnvironmentVariables()
+	}
+	return d.fillQueryVariables(vars)
+}
+
+// fillQueryVariables fills vars into the query in the editor, returning
+// the names of the variables left without a value
+func (d *DatabaseExplorer) fillQueryVariables(vars []storage.Variable) (string, []string) {
+	query := storage.ReplaceVariables(strings.TrimSpace(d.editor.Value()), vars)
+	return query, storage.UnresolvedVariables(query)
+}
+
+// viewQueryVariables tells whether variables are filled in and which of
+// them are missing, or how to fill them in when the query has some. It
+// uses the environments on screen, which are reloaded when they change,
+// rather than reading the store on every frame.
+func (d *DatabaseExplorer) viewQueryVariables(h host) string {
+	if !d.variables {
+		if len(storage.UnresolvedVariables(d.editor.Value())) == 0 {
+			return ""
+		}
+		return MutedStyle.Render(i18n.T("query_vars.off")) + "\n\n"
+	}
+	env := h.activeEnvironment()
+	var vars []storage.Variable
+	if env != nil {
+		vars = env.Variables
+	}
+	if _, missing := d.fillQueryVariables(vars); len(missing) > 0 {
+		return WarningStyle.Render(i18n.Tf("query_vars.missing", strings.Join(missing, ", "))) + "\n\n"
+	}
+	if env == nil {
+		return MutedStyle.Render(i18n.T("query_vars.on_no_env")) + "\n\n"
+	}
+	return SuccessStyle.Render(i18n.Tf("query_vars.on", env.Name)) + "\n\n"
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowQueryVariablesAreOptInPerSavedQuery(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	m := NewModel()
+	for _, err := range []error{
+		m.storage.AddEnvironment("staging"),
+		m.storage.AddVariable("staging", "TENANT_ID", "42"),
+		m.storage.SetActiveEnvironment("staging"),
+		m.db.storage.SaveQuery("tenant orders", "SELECT * FROM orders WHERE tenant_id = {{TENANT_ID}} AND region = '{{REGION}}'"),
+	} {
+		if err != nil {
+			t.Fatalf("setup error = %v", err)
+		}
+	}
+	m.db.savedQueries = m.db.storage.GetQueries()
+	if err := m.envs.reload(m.storage); err != nil {
+		t.Fatalf("reload() error = %v", err)
+	}
+	m.state = StateDatabaseQueryList
+
+	d := tuitest.New(t, *m).Resize(160, 50)
+	d.Press("enter").AssertView("{{VARIABLES}} are sent as written • Ctrl+G: fill them from the environment")
+
+	d.Press("ctrl+g").AssertView("⚠ Not run until these variables have a value: REGION")
+	d.Press("ctrl+k")
+	if got := d.Model().(Model); got.state != StateDatabaseQueryEditor {
+		t.Fatalf("State = %v, the query ran with a variable missing", got.state)
+	}
+
+	if err := m.storage.AddVariable("staging", "REGION", "eu"); err != nil {
+		t.Fatalf("AddVariable() error = %v", err)
+	}
+	// The view shows the environments on screen, which the next tick reloads
+	d.Press("end").AssertView("⚠ Not run until these variables have a value: REGION")
+	d.Send(tickMsg(time.Now())).AssertView("✓ {{VARIABLES}} filled from environment staging")
+
+	got := d.Model().(Model)
+	if query, missing := got.db.queryToRun(&got); query != "SELECT * FROM orders WHERE tenant_id = 42 AND region = 'eu'" || len(missing) > 0 {
+		t.Errorf("queryToRun() = %q, %v", query, missing)
+	}
+	if saved := got.db.storage.GetQueries(); !saved[0].UseVariables {
+		t.Error("The saved query did not keep filling in variables")
+	}
+
+	got.db.editQuery(&got, "SELECT {{TENANT_ID}}")
+	if got.db.variables {
+		t.Error("A new query in the editor kept filling in variables")
+	}
+}
+
+func TestQueryVariablesViewDoesNotReadTheStore(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv(paths.HomeEnv, home)
+	m := NewModel()
+	m.db.editQuery(m, "SELECT {{TENANT_ID}}")
+	m.db.variables = true
+
+	dir, err := m.storage.Dir()
+	if err != nil {
+		t.Fatalf("Dir() error = %v", err)
+	}
+	envPath := filepath.Join(dir, "environments.json")
+	if err := os.Remove(envPath); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+
+	m.db.viewQueryVariables(m)
+	if _, err := os.Stat(envPath); !os.IsNotExist(err) {
+		t.Errorf("Rendering the query variables touched %s: %v", envPath, err)
+	}
+}
@@ -0,0 +1,345 @@
+package ui
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/rawsock"
+)
+
+// rawSocketRefresh is how often the transcript of an open connection is redrawn
+const rawSocketRefresh = 100 * time.Millisecond
+
+// rawSocketDialTimeout bounds connecting to an address
+const rawSocketDialTimeout = 10 * time.Second
+
+// RawSocket is the raw socket scratchpad: a connection over TCP, TLS or UDP
+// to a service that does not speak HTTP, bytes typed as text or hex, and a
+// transcript of what went out and came back
+type RawSocket struct {
+	addr  textinput.Model
+	data  textinput.Model
+	focus int
+
+	network  int
+	insecure bool
+	hexInput bool
+	hexView  bool
+
+	conn *rawsock.Conn
+	// attempt tells the result of the latest dial from ones that were
+	// abandoned by leaving the screen or dialing again
+	attempt    int
+	connecting bool
+	err        string
+	// scroll is how many transcript lines the view is scrolled up
+	scroll int
+}
+
+type rawDialMsg struct {
+	attempt int
+	conn    *rawsock.Conn
+	err     error
+}
+
+type rawTickMsg struct{}
+
+func rawTickCmd() tea.Cmd {
+	return tea.Tick(rawSocketRefresh, func(time.Time) tea.Msg {
+		return rawTickMsg{}
+	})
+}
+
+func newRawSocket() RawSocket {
+	addr := textinput.New()
+	addr.Placeholder = "localhost:6379"
+	addr.CharLimit = 255
+	addr.Width = 50
+
+	data := textinput.New()
+	data.Placeholder = `PING\r\n`
+	data.CharLimit = 4096
+	data.Width = 70
+
+	return RawSocket{addr: addr, data: data}
+}
+
+// open shows the scratchpad with the address focused
+func (r *RawSocket) open(h host) {
+	r.focusInput(0)
+	h.navigate(StateRawSocket)
+}
+
+func (r *RawSocket) focusInput(i int) {
+	r.focus = i
+	if i == 0 {
+		r.data.Blur()
+		r.addr.Focus()
+	} else {
+		r.addr.Blur()
+		r.data.Focus()
+	}
+}
+
+func (r *RawSocket) networkName() rawsock.Network {
+	return rawsock.Networks[r.network]
+}
+
+// connected reports whether a connection is up
+func (r *RawSocket) connected() bool {
+	return r.conn != nil && !r.conn.Closed()
+}
+
+// disconnect closes the connection, keeping its transcript on screen
+func (r *RawSocket) disconnect() {
+	if r.connected() {
+		r.conn.Close()
+	}
+}
+
+// dial connects to the typed address, replacing any open connection
+func (r *RawSocket) dial() tea.Cmd {
+	addr := strings.TrimSpace(r.addr.Value())
+	if addr == "" {
+		r.err = i18n.T("raw.no_address")
+		return nil
+	}
+	r.disconnect()
+	r.conn = nil
+	r.err = ""
+	r.scroll = 0
+	r.connecting = true
+	r.attempt++
+
+	attempt, network := r.attempt, r.networkName()
+	opts := rawsock.Options{Timeout: rawSocketDialTimeout, Insecure: r.insecure}
+	return func() tea.Msg {
+		conn, err := rawsock.Dial(network, addr, opts)
+		return rawDialMsg{attempt: attempt, conn: conn, err: err}
+	}
+}
+
+// dialed keeps the connection of the latest dial and closes any other
+func (r *RawSocket) dialed(msg rawDialMsg) tea.Cmd {
+	if msg.attempt != r.attempt {
+		if msg.conn != nil {
+			msg.conn.Close()
+		}
+		return nil
+	}
+	r.connecting = false
+	if msg.err != nil {
+		r.err = msg.err.Error()
+		return nil
+	}
+	r.conn = msg.conn
+	r.focusInput(1)
+	return rawTickCmd()
+}
+
+// tick keeps redrawing while data can still arrive
+func (r *RawSocket) tick() tea.Cmd {
+	if r.connected() {
+		return rawTickCmd()
+	}
+	return nil
+}
+
+// send writes the typed input, read as text with escapes or as hex
+func (r *RawSocket) send(h host) {
+	if !r.connected() {
+		r.err = i18n.T("raw.not_connected")
+		return
+	}
+	if h.blockedByReadOnly("raw socket send") {
+		return
+	}
+	parse := rawsock.ParseText
+	if r.hexInput {
+		parse = rawsock.ParseHex
+	}
+	payload, err := parse(r.data.Value())
+	if err != nil {
+		r.err = err.Error()
+		return
+	}
+	if err := r.conn.Send(payload); err != nil {
+		r.err = err.Error()
+		return
+	}
+	r.err = ""
+	r.scroll = 0
+	r.data.SetValue("")
+}
+
+func (r *RawSocket) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		r.disconnect()
+		return tea.Quit
+
+	case "esc":
+		// Leaving abandons a dial in progress too
+		r.disconnect()
+		r.attempt++
+		r.connecting = false
+		h.navigate(StateHome)
+		return nil
+
+	case "tab", "shift+tab":
+		r.focusInput(1 - r.focus)
+		return nil
+
+	case "enter":
+		if r.focus == 0 {
+			return r.dial()
+		}
+		r.send(h)
+		return nil
+
+	case "ctrl+n":
+		if !r.connected() {
+			r.network = (r.network + 1) % len(rawsock.Networks)
+		}
+		return nil
+
+	case "ctrl+k":
+		r.insecure = !r.insecure
+		return nil
+
+	case "ctrl+x":
+		r.hexInput = !r.hexInput
+		return nil
+
+	case "ctrl+o":
+		r.hexView = !r.hexView
+		r.scroll = 0
+		return nil
+
+	case "ctrl+d":
+		r.disconnect()
+		return nil
+
+	case "up", "pgup":
+		r.scroll++
+		return nil
+
+	case "down", "pgdown":
+		if r.scroll > 0 {
+			r.scroll--
+		}
+		return nil
+	}
+
+	var cmd tea.Cmd
+	if r.focus == 0 {
+		r.addr, cmd = r.addr.Update(msg)
+	} else {
+		r.data, cmd = r.data.Update(msg)
+	}
+	return cmd
+}
+
+func (r *RawSocket) View(h host) string {
+	width, height := h.size()
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.raw_socket")))
+	b.WriteString("\n\n")
+
+	network := strings.ToUpper(string(r.networkName()))
+	if r.networkName() == rawsock.TLS && r.insecure {
+		network += " " + i18n.T("raw.insecure")
+	}
+	b.WriteString(TextStyle.Render(i18n.Tf("raw.settings", network)))
+	b.WriteString("\n")
+	b.WriteString(r.viewInput(i18n.T("raw.address"), r.addr, r.focus == 0, width))
+	b.WriteString(r.viewStatus())
+	b.WriteString("\n\n")
+
+	b.WriteString(HeaderStyle.Render(i18n.T("raw.transcript")))
+	b.WriteString("\n")
+	b.WriteString(r.viewTranscript(max(height-22, 5)))
+	b.WriteString("\n\n")
+
+	label := i18n.T("raw.data_text")
+	if r.hexInput {
+		label = i18n.T("raw.data_hex")
+	}
+	b.WriteString(r.viewInput(label, r.data, r.focus == 1, width))
+
+	if r.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + r.err))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(RenderFooter(i18n.T("footer.raw_socket")))
+
+	return Center(width, height, b.String())
+}
+
+func (r *RawSocket) viewInput(label string, input textinput.Model, focused bool, width int) string {
+	input.Width = min(input.Width, max(width-20, 10))
+	return viewLabeledInput(label, input, focused)
+}
+
+// viewStatus tells whether the connection is up, and why it ended
+func (r *RawSocket) viewStatus() string {
+	switch {
+	case r.connecting:
+		return MutedStyle.Render(i18n.T("raw.connecting"))
+	case r.conn == nil:
+		return MutedStyle.Render(i18n.T("raw.disconnected"))
+	case r.connected():
+		return SuccessStyle.Render(i18n.Tf("raw.connected", r.conn.RemoteAddr()))
+	case rawsock.ClosedByPeer(r.conn.Err()):
+		return WarningStyle.Render(i18n.T("raw.closed_by_peer"))
+	case r.conn.Err() != nil:
+		return ErrorStyle.Render(i18n.Tf("raw.closed_error", r.conn.Err()))
+	default:
+		return MutedStyle.Render(i18n.T("raw.closed"))
+	}
+}
+
+// viewTranscript renders the last lines of the transcript that fit, or
+// earlier ones when scrolled up
+func (r *RawSocket) viewTranscript(visible int) string {
+	if r.conn == nil || len(r.conn.Transcript()) == 0 {
+		return MutedStyle.Render(i18n.T("raw.empty"))
+	}
+
+	var lines []string
+	for _, e := range r.conn.Transcript() {
+		lines = append(lines, r.exchangeLines(e)...)
+	}
+
+	r.scroll = min(r.scroll, max(len(lines)-visible, 0))
+	end := len(lines) - r.scroll
+	start := max(end-visible, 0)
+	return strings.Join(lines[start:end], "\n")
+}
+
+// exchangeLines renders one entry of the transcript: a header with the
+// direction, time and size, and the bytes as escaped text or a hex dump
+func (r *RawSocket) exchangeLines(e rawsock.Exchange) []string {
+	arrow, style := "←", TextStyle
+	if e.Sent {
+		arrow, style = "→", MutedStyle
+	}
+	header := fmt.Sprintf("%s %s  %d B", arrow, e.At.Format("15:04:05.000"), len(e.Data))
+
+	if r.hexView {
+		lines := []string{style.Render(header)}
+		for _, line := range strings.Split(strings.TrimRight(hex.Dump(e.Data), "\n"), "\n") {
+			lines = append(lines, style.Render("  "+line))
+		}
+		return lines
+	}
+	return []string{style.Render(header + "  " + rawsock.Escape(e.Data))}
+}
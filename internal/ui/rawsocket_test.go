@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+// lineServer answers every line with "+" and the line upper-cased, and
+// closes the connection after QUIT
+func lineServer(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					line = strings.TrimSpace(line)
+					if line == "QUIT" {
+						return
+					}
+					conn.Write([]byte("+" + strings.ToUpper(line) + "\r\n"))
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestFlowRawSocketSendsTextAndHex(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	addr := lineServer(t)
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("3").AssertView("Raw Socket", "Transport: TCP", "Not connected")
+	d.Type(addr).Press("enter").WaitFor("● Connected to " + addr)
+
+	d.Type(`ping\r\n`).Press("enter")
+	d.WaitFor(`ping\r\n`, `+PING\r\n`)
+
+	// 68 69 0a is "hi\n"
+	d.Press("ctrl+x").AssertView("Send as hex bytes:")
+	d.Type("68 69 0a").Press("enter").WaitFor(`+HI\r\n`)
+
+	d.Press("ctrl+o").AssertView("2b 48 49 0d 0a")
+
+	d.Press("ctrl+x").Type(`QUIT\n`).Press("enter").WaitFor("Connection closed by the server")
+	d.Press("enter").AssertView("Not connected: focus the address and press Enter")
+
+	d.Press("esc").AssertView("[ 3 ] Raw Socket")
+}
+
+func TestFlowRawSocketReportsDialErrors(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("3", "enter").AssertView("Enter an address as host:port")
+	d.Type("localhost").Press("enter").WaitFor(`invalid address "localhost": want host:port`)
+
+	d.Press("ctrl+n").AssertView("Transport: TLS")
+	d.Press("ctrl+k").AssertView("TLS (certificate not verified)")
+	d.Press("ctrl+n").AssertView("Transport: UDP")
+}
+
+func TestRawSocketSendIsBlockedInReadOnlyMode(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	addr := lineServer(t)
+
+	m := NewModel()
+	m.SetReadOnly(true)
+	d := tuitest.New(t, *m).Resize(160, 50)
+	d.Press("3").Type(addr).Press("enter").WaitFor("● Connected to " + addr)
+	d.Type("ping").Press("enter").AssertView("Disabled in read-only mode: raw socket send")
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// SetReadOnly disables every action that changes saved data, remote APIs or
+// the database. Only safe HTTP methods and read-only SQL can be executed.
+func (m *Model) SetReadOnly(readOnly bool) {
+	m.readOnly = readOnly
+	m.db.setReadOnly(readOnly)
+}
+
+// blockedByReadOnly reports whether a mutating action must be skipped and
+// flashes a notice naming it
+func (m *Model) blockedByReadOnly(action string) bool {
+	if !m.readOnly {
+		return false
+	}
+	m.readOnlyNotice = action
+	m.readOnlyNoticeTimer = 3
+	return true
+}
+
+// isSafeMethod reports whether an HTTP method does not modify server state
+func isSafeMethod(method string) bool {
+	switch strings.ToUpper(method) {
+	case "GET", "HEAD", "OPTIONS":
+		return true
+	default:
+		return false
+	}
+}
+
+// withReadOnlyBanner replaces the top line of a rendered view with the
+// read-only indicator, keeping the view height unchanged
+func (m Model) withReadOnlyBanner(view string) string {
+	banner := WarningStyle.Render(i18n.T("readonly.banner"))
+	if m.readOnlyNotice != "" {
+		banner = ErrorStyle.Render(i18n.Tf("readonly.blocked", m.readOnlyNotice))
+	}
+	banner = lipgloss.PlaceHorizontal(m.width, lipgloss.Center, banner)
+
+	lines := strings.Split(view, "\n")
+	if len(lines) > 1 && strings.TrimSpace(lines[0]) == "" {
+		lines[0] = banner
+		return strings.Join(lines, "\n")
+	}
+	return banner + "\n" + view
+}
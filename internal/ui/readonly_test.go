@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+func TestIsSafeMethod(t *testing.T) {
+	for _, method := range []string{"GET", "head", "OPTIONS"} {
+		if !isSafeMethod(method) {
+			t.Errorf("Expected %s to be safe", method)
+		}
+	}
+	for _, method := range []string{"POST", "PUT", "PATCH", "DELETE"} {
+		if isSafeMethod(method) {
+			t.Errorf("Expected %s to be unsafe", method)
+		}
+	}
+}
+
+func TestBlockedByReadOnly(t *testing.T) {
+	m := Model{}
+	if m.blockedByReadOnly("save request") {
+		t.Error("Expected actions to be allowed outside read-only mode")
+	}
+
+	m.readOnly = true
+	if !m.blockedByReadOnly("save request") {
+		t.Error("Expected action to be blocked in read-only mode")
+	}
+	if m.readOnlyNotice != "save request" || m.readOnlyNoticeTimer == 0 {
+		t.Errorf("Expected notice to be flashed, got %q (%d)", m.readOnlyNotice, m.readOnlyNoticeTimer)
+	}
+}
+
+func TestWithReadOnlyBanner(t *testing.T) {
+	m := Model{width: 40, readOnly: true}
+	view := m.withReadOnlyBanner("\ncontent\n")
+
+	lines := strings.Split(view, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected view height to be preserved, got %d lines", len(lines))
+	}
+	if !strings.Contains(lines[0], "READ-ONLY MODE") {
+		t.Errorf("Expected banner on the first line, got %q", lines[0])
+	}
+}
+
+func TestReadOnlyBannerIsTranslated(t *testing.T) {
+	if err := i18n.SetLanguage("pt-BR"); err != nil {
+		t.Fatal(err)
+	}
+	defer i18n.SetLanguage("en")
+
+	m := Model{width: 60, readOnly: true}
+	if view := m.withReadOnlyBanner("\ncontent\n"); !strings.Contains(view, "MODO SOMENTE LEITURA") {
+		t.Errorf("Expected the pt-BR banner, got %q", view)
+	}
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// viewRedirectChain lists the redirects the response went through, hop
+// by hop, marking the ones that lead back to a URL already visited. A
+// redirect left unfollowed shows where it points instead.
+func (v *ResponseViewer) viewRedirectChain() string {
+	resp := v.response
+	hops := resp.Redirects
+	if len(hops) == 0 {
+		if location := resp.Headers["Location"]; len(location) > 0 && resp.StatusCode >= 300 && resp.StatusCode < 400 {
+			return MutedStyle.Render(i18n.Tf("redirects.not_followed", location[0])) + "\n\n"
+		}
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(TextStyle.Render(i18n.Tf("redirects.title", len(hops))))
+	b.WriteString("\n")
+	for i, hop := range hops {
+		line := fmt.Sprintf("%d. %d %s → %s", i+1, hop.StatusCode, hop.URL, hop.Location)
+		if httpclient.Revisits(hops, i) {
+			b.WriteString(WarningStyle.Render(line + "  " + i18n.T("redirects.loop")))
+		} else {
+			b.WriteString(GetStatusStyle(hop.StatusCode).Render(line))
+		}
+		b.WriteString("\n")
+	}
+	if resp.Error == nil {
+		final := fmt.Sprintf("%d. %d %s  (%s)", len(hops)+1, resp.StatusCode, hops[len(hops)-1].Location, i18n.T("redirects.final"))
+		b.WriteString(GetStatusStyle(resp.StatusCode).Render(final))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
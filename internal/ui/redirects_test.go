@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowRedirectChainAndFollowToggle(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("arrived"))
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 60)
+	d.Press("a").Type(server.URL + "/old").Press("enter").WaitFor("arrived")
+	d.AssertView("Redirects followed: 1", "1. 301 "+server.URL+"/old → "+server.URL+"/new", "2. 200 "+server.URL+"/new  (final)")
+
+	d.Press("esc", "tab", "t").AssertView("[x] Follow redirects")
+	d.Press("ctrl+f", "enter").AssertView("[ ] Follow redirects", "✓ Timeout and retries saved")
+	d.Press("esc", "shift+tab", "enter").WaitFor("Status: 301")
+	d.AssertView("Redirect not followed: Location /new").AssertNoView("Redirects followed")
+
+	d.Press("esc", "tab", "t", "ctrl+f", "enter", "esc", "shift+tab", "ctrl+u").Type(server.URL + "/loop").Press("enter")
+	d.WaitFor("stopped after 10 redirects").AssertView("Redirects followed: 10", "↺ loop")
+}
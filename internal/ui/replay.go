@@ -0,0 +1,218 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+const (
+	defaultReplayCount = 10
+	maxReplayCount     = 50
+)
+
+type replayResultMsg []httpclient.ReplayResult
+
+// HistoryReplay is the history replay screen: the latest history entries
+// sent again and checked against the responses recorded with them
+type HistoryReplay struct {
+	history []storage.RequestExecution
+	count   int
+	running bool
+	results []httpclient.ReplayResult
+	scroll  int
+}
+
+// open shows the replay of the latest entries of history
+func (r *HistoryReplay) open(h host, history []storage.RequestExecution) {
+	h.navigate(StateHistoryReplay)
+	r.history = history
+	r.results = nil
+	r.scroll = 0
+}
+
+// finish shows the results of the replay
+func (r *HistoryReplay) finish(msg replayResultMsg) {
+	r.running = false
+	r.results = []httpclient.ReplayResult(msg)
+}
+
+// buildRequests picks the replay candidates from history and resolves them
+// against the active environment
+func (r *HistoryReplay) buildRequests(h host) []httpclient.ReplayRequest {
+	candidates := storage.SelectReplayCandidates(r.history, r.count)
+
+	var vars []storage.Variable
+	var aliases []storage.ServiceAlias
+	if store := h.store(); store != nil {
+		if envVars, err := store.GetActiveEnvironmentVariables(); err == nil {
+			vars = envVars
+		}
+		aliases, _ = store.GetActiveAliases()
+	}
+
+	requests := make([]httpclient.ReplayRequest, 0, len(candidates))
+	for _, exec := range candidates {
+		headers := make(map[string]string, len(exec.Headers))
+		for k, v := range exec.Headers {
+			headers[k] = storage.ReplaceVariables(v, vars)
+		}
+
+		url, _ := storage.ExpandAlias(exec.URL, aliases)
+		requests = append(requests, httpclient.ReplayRequest{
+			Request: httpclient.Request{
+				Method:  exec.Method,
+				URL:     storage.ReplaceVariables(url, vars),
+				Headers: headers,
+			},
+			ExpectedStatus: exec.StatusCode,
+			ExpectedBody:   exec.ResponseBody,
+		})
+	}
+
+	return requests
+}
+
+func runReplayCmd(client *httpclient.Client, requests []httpclient.ReplayRequest) tea.Cmd {
+	return func() tea.Msg {
+		return replayResultMsg(httpclient.RunReplay(client, requests))
+	}
+}
+
+func (r *HistoryReplay) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		if r.running {
+			return nil
+		}
+		h.navigate(StateHistory)
+		return nil
+
+	case "left", "-":
+		if !r.running && r.count > 1 {
+			r.count--
+		}
+		return nil
+
+	case "right", "+":
+		if !r.running && r.count < maxReplayCount {
+			r.count++
+		}
+		return nil
+
+	case "up", "k":
+		if r.scroll > 0 {
+			r.scroll--
+		}
+		return nil
+
+	case "down", "j":
+		r.scroll++
+		return nil
+
+	case "enter", "r":
+		if r.running {
+			return nil
+		}
+
+		requests := r.buildRequests(h)
+		if len(requests) == 0 {
+			return nil
+		}
+
+		r.running = true
+		r.results = nil
+		r.scroll = 0
+		return tea.Batch(h.spinnerTick(), runReplayCmd(h.requestClient(), requests))
+	}
+
+	return nil
+}
+
+func (r *HistoryReplay) View(h host) string {
+	width, height := h.size()
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.replay")))
+	b.WriteString("\n\n")
+
+	candidates := storage.SelectReplayCandidates(r.history, r.count)
+	b.WriteString(TextStyle.Render(i18n.Tf("replay.intro", r.count)))
+	b.WriteString("\n")
+
+	envInfo := i18n.T("replay.no_env")
+	if h.environments().current != "" {
+		envInfo = i18n.Tf("replay.env", h.environments().current)
+	}
+	b.WriteString(MutedStyle.Render(i18n.Tf("replay.found", len(candidates), envInfo)))
+	b.WriteString("\n\n")
+
+	switch {
+	case r.running:
+		b.WriteString(SpinnerStyle.Render(h.spinnerView()) + "  " + TextStyle.Render(i18n.Tf("replay.running", len(candidates))))
+
+	case r.results != nil:
+		lines := strings.Split(strings.TrimRight(httpclient.FormatReplayResults(r.results), "\n"), "\n")
+
+		maxLines := height - 16
+		if maxLines < 5 {
+			maxLines = 5
+		}
+		start := r.scroll
+		if start > len(lines)-maxLines {
+			start = len(lines) - maxLines
+		}
+		if start < 0 {
+			start = 0
+		}
+		end := start + maxLines
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		for _, line := range lines[start:end] {
+			trimmed := strings.TrimSpace(line)
+			switch {
+			case strings.HasPrefix(trimmed, "✓"):
+				b.WriteString(SuccessStyle.Render(line))
+			case strings.HasPrefix(trimmed, "✗"):
+				b.WriteString(ErrorStyle.Render(line))
+			case strings.HasPrefix(line, "    "):
+				b.WriteString(WarningStyle.Render(line))
+			default:
+				b.WriteString(HeaderStyle.Render(line))
+			}
+			b.WriteString("\n")
+		}
+
+	case len(candidates) == 0:
+		b.WriteString(MutedStyle.Render(i18n.T("replay.empty")))
+		b.WriteString("\n")
+
+	default:
+		for _, exec := range candidates {
+			b.WriteString(renderRequestItem(exec.Method, exec.URL, false))
+			b.WriteString("\n")
+			if h.detailedLists() {
+				b.WriteString(renderItemDetail(exec.Timestamp.Format(time.DateTime)) +
+					MutedStyle.Render(" • ") + RenderStatusPill(exec.StatusCode, exec.Status) +
+					MutedStyle.Render(fmt.Sprintf(" • %dms", exec.ResponseTime)))
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(RenderFooter(i18n.T("footer.replay")))
+
+	return Center(width, height, b.String())
+}
@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowReplayChecksHistoryAgainstRecordedResponses(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"ok": true}`)
+	}))
+	defer server.Close()
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL).Press("enter").WaitFor("200")
+	d.Press("esc", "tab", "ctrl+r", "r").AssertView("Replay Smoke Test", "1 found in history")
+	d.Press("enter").WaitFor("Replayed 1 requests: 1 passed, 0 failed")
+
+	if m := d.Model().(Model); m.replay.running || calls != 2 {
+		t.Errorf("Expected the request sent again, got %d calls", calls)
+	}
+	d.Press("esc")
+	if m := d.Model().(Model); m.state != StateHistory {
+		t.Errorf("Expected Esc to return to the history, got state %v", m.state)
+	}
+}
@@ -0,0 +1,548 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// RequestBuilder is the request builder screen: the method, URL, query
+// params, headers and body of the request being edited, and the editors of
+// the params, headers and body.
+type RequestBuilder struct {
+	method     string
+	urlInput   textinput.Model
+	headers    map[string]string
+	body       string
+	focusIndex int
+	urlError   string
+
+	// newConnection sends requests on a new connection instead of reusing
+	// one kept alive, to tell connection pool problems from API ones
+	newConnection bool
+
+	// sendChecks is the checklist of the last send that failed it
+	sendChecks []sendCheck
+
+	headerKeyInput   textinput.Model
+	headerValueInput textinput.Model
+	headerList       []string
+	selectedHeader   int
+	editingHeader    bool
+
+	bodyEditor textarea.Model
+	bodyError  string
+
+	queryParams     map[string]string
+	queryKeyInput   textinput.Model
+	queryValueInput textinput.Model
+	queryList       []string
+	selectedQuery   int
+	editingQuery    bool
+
+	// requestSaved is whether the request is unchanged since it was saved
+	// as currentRequestSavedID
+	requestSaved          bool
+	currentRequestSavedID string
+	curlCopySuccess       bool
+	curlCopySuccessTimer  int
+
+	// fixingRequest is set while the body or headers are edited with the
+	// error of a 4xx response pinned beside them
+	fixingRequest   bool
+	fixErrorStatus  string
+	fixErrorMessage string
+}
+
+func newRequestBuilder() RequestBuilder {
+	urlInput := textinput.New()
+	urlInput.Placeholder = "https://api.example.com/endpoint"
+	urlInput.Focus()
+	urlInput.CharLimit = 2000
+	urlInput.Width = 60
+
+	headerKey := textinput.New()
+	headerKey.Placeholder = "Header-Name"
+	headerKey.CharLimit = 100
+	headerKey.Width = 30
+
+	headerValue := textinput.New()
+	headerValue.Placeholder = "Header Value"
+	headerValue.CharLimit = 500
+	headerValue.Width = 50
+
+	queryKey := textinput.New()
+	queryKey.Placeholder = "Param Name"
+	queryKey.CharLimit = 100
+	queryKey.Width = 30
+
+	queryValue := textinput.New()
+	queryValue.Placeholder = "Param Value"
+	queryValue.CharLimit = 500
+	queryValue.Width = 50
+
+	bodyEditor := textarea.New()
+	bodyEditor.Placeholder = "{\n  \"key\": \"value\"\n}"
+	bodyEditor.CharLimit = 10000
+	bodyEditor.SetWidth(80)
+	bodyEditor.SetHeight(10)
+
+	return RequestBuilder{
+		method:           "GET",
+		urlInput:         urlInput,
+		headers:          make(map[string]string),
+		focusIndex:       1,
+		headerKeyInput:   headerKey,
+		headerValueInput: headerValue,
+		headerList:       []string{},
+		bodyEditor:       bodyEditor,
+		queryParams:      make(map[string]string),
+		queryKeyInput:    queryKey,
+		queryValueInput:  queryValue,
+		queryList:        []string{},
+	}
+}
+
+func (r *RequestBuilder) resize(layout LayoutConfig) {
+	r.urlInput.Width = layout.InputWidth
+	r.headerKeyInput.Width = layout.InputWidth / 2
+	r.headerValueInput.Width = layout.InputWidth / 2
+	r.queryKeyInput.Width = layout.InputWidth / 2
+	r.queryValueInput.Width = layout.InputWidth / 2
+}
+
+// tick counts down the copy notice
+func (r *RequestBuilder) tick() {
+	if r.curlCopySuccessTimer > 0 {
+		r.curlCopySuccessTimer--
+		if r.curlCopySuccessTimer == 0 {
+			r.curlCopySuccess = false
+		}
+	}
+}
+
+// Update handles a key for the builder or the editor showing
+func (r *RequestBuilder) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	switch h.screenState() {
+	case StateHeaderEditor:
+		return r.updateHeaderEditor(h, msg)
+	case StateBodyEditor:
+		return r.updateBodyEditor(h, msg)
+	case StateQueryEditor:
+		return r.updateQueryEditor(h, msg)
+	}
+	return r.updateBuilder(h, msg)
+}
+
+func (r *RequestBuilder) View(h host) string {
+	switch h.screenState() {
+	case StateHeaderEditor:
+		return r.viewHeaderEditor(h)
+	case StateBodyEditor:
+		return r.viewBodyEditor(h)
+	case StateQueryEditor:
+		return r.viewQueryEditor(h)
+	}
+	return r.viewBuilder(h)
+}
+
+// typing reports whether keys go to the URL, so that shortcuts are typed
+// instead
+func (r *RequestBuilder) typing() bool {
+	return r.focusIndex == 1
+}
+
+// updateURL types msg into the URL; the keys that leave it or send the
+// request are handled by the builder
+func (r *RequestBuilder) updateURL(msg tea.KeyMsg) (tea.Cmd, bool) {
+	var cmd tea.Cmd
+	switch msg.String() {
+	case "ctrl+q", "tab", "shift+tab", "enter", "ctrl+l", "ctrl+?":
+		return nil, false
+	case "ctrl+c":
+		if r.urlInput.Value() == "" {
+			return nil, false
+		}
+		r.urlInput, cmd = r.urlInput.Update(msg)
+		return cmd, true
+	}
+	r.urlInput, cmd = r.urlInput.Update(msg)
+	r.requestSaved = false
+	return cmd, true
+}
+
+// focus moves the focus by delta through the fields and buttons
+func (r *RequestBuilder) focus(delta int) {
+	r.focusIndex = (r.focusIndex + delta + 8) % 8
+	if r.focusIndex == 1 {
+		r.urlInput.Focus()
+	} else {
+		r.urlInput.Blur()
+	}
+}
+
+// cycleMethod moves the method by delta through the methods the builder offers
+func (r *RequestBuilder) cycleMethod(delta int) {
+	methods := []string{"GET", "POST", "PUT", "DELETE", "PATCH"}
+	for i, method := range methods {
+		if r.method == method {
+			if next := i + delta; next >= 0 && next < len(methods) {
+				r.method = methods[next]
+			}
+			return
+		}
+	}
+}
+
+// send sends the request unless its URL is empty
+func (r *RequestBuilder) send(h host) tea.Cmd {
+	if r.urlInput.Value() == "" {
+		return nil
+	}
+	return h.sendRequest()
+}
+
+func (r *RequestBuilder) updateBuilder(h host, msg tea.KeyMsg) tea.Cmd {
+	if r.typing() {
+		if cmd, typed := r.updateURL(msg); typed {
+			return cmd
+		}
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "ctrl+h", "?":
+		h.navigate(StateHelp)
+
+	case "ctrl+enter":
+		return r.send(h)
+
+	case "ctrl+l":
+		h.navigate(StateRequestList)
+
+	case "ctrl+d":
+		h.navigate(StateDatabase)
+
+	case "ctrl+e":
+		h.navigate(StateEnvironments)
+
+	case "tab":
+		r.focus(1)
+
+	case "shift+tab":
+		r.focus(-1)
+
+	case "left":
+		if r.focusIndex == 0 {
+			r.cycleMethod(-1)
+		}
+
+	case "right":
+		if r.focusIndex == 0 {
+			r.cycleMethod(1)
+		}
+
+	case "h":
+		r.openHeaderEditor(h)
+
+	case "b":
+		h.editBody()
+
+	case "q":
+		r.openQueryEditor(h)
+
+	case "N":
+		r.newConnection = !r.newConnection
+
+	case "enter":
+		switch r.focusIndex {
+		case 1:
+			// A curl command pasted into the URL is imported, not sent
+			if isCurlCommand(r.urlInput.Value()) {
+				h.pasteCurl(r.urlInput.Value())
+				return nil
+			}
+			return r.send(h)
+		case 2:
+			r.openQueryEditor(h)
+		case 3:
+			r.openHeaderEditor(h)
+		case 4:
+			h.editBody()
+		case 5:
+			return r.send(h)
+		case 6:
+			h.navigate(StateRequestList)
+		case 7:
+			return tea.Quit
+		}
+
+	case "x":
+		if r.urlInput.Value() != "" {
+			r.copyCurl()
+		}
+	}
+	return nil
+}
+
+// copyCurl copies the request as a curl command
+func (r *RequestBuilder) copyCurl() {
+	req := httpclient.Request{
+		Method:  r.method,
+		URL:     r.buildURLWithQueryParams(),
+		Headers: r.headers,
+		Body:    r.body,
+	}
+	if err := clipboard.WriteAll(httpclient.RequestToCurl(req)); err == nil {
+		r.curlCopySuccess = true
+		r.curlCopySuccessTimer = 3
+	}
+}
+
+func validateURL(urlStr string) error {
+	if urlStr == "" {
+		return fmt.Errorf("url cannot be empty")
+	}
+
+	parsedURL, err := url.Parse(urlStr)
+	if err != nil {
+		return fmt.Errorf("invalid url: %v", err)
+	}
+
+	if parsedURL.Scheme == "" {
+		return fmt.Errorf("url must include protocol (http:// or https://)")
+	}
+
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return fmt.Errorf("protocol must be http or https")
+	}
+
+	if parsedURL.Host == "" {
+		return fmt.Errorf("url must include a valid host")
+	}
+
+	return nil
+}
+
+// validateBody checks a body against its Content-Type: form fields for a
+// multipart body, JSON for a raw one. Any text encodes as a form.
+func (r *RequestBuilder) validateBody(body string) error {
+	switch r.bodyMode() {
+	case httpclient.BodyMultipart:
+		_, err := httpclient.ParseFormFields(body)
+		return err
+	case httpclient.BodyForm:
+		return nil
+	default:
+		return validateJSON(body)
+	}
+}
+
+func validateJSON(body string) error {
+	if body == "" {
+		return nil
+	}
+
+	var js interface{}
+	if err := json.Unmarshal([]byte(body), &js); err != nil {
+		return fmt.Errorf("invalid json: %v", err)
+	}
+	return nil
+}
+
+func (r *RequestBuilder) buildURLWithQueryParams() string {
+	baseURL := r.urlInput.Value()
+	if len(r.queryParams) == 0 {
+		return baseURL
+	}
+
+	parsedURL, err := url.Parse(baseURL)
+	if err != nil {
+		return baseURL
+	}
+
+	q := parsedURL.Query()
+	for key, value := range r.queryParams {
+		q.Set(key, value)
+	}
+	parsedURL.RawQuery = q.Encode()
+
+	return parsedURL.String()
+}
+
+// urlEncodingHint flags encoding surprises in the URL being typed. URLs
+// with variables are left to the inspector, which resolves them.
+func (r *RequestBuilder) urlEncodingHint() string {
+	rawURL := r.urlInput.Value()
+	if rawURL == "" || strings.Contains(rawURL, "{{") {
+		return ""
+	}
+	inspection, err := httpclient.InspectURL(rawURL)
+	if err != nil || len(inspection.Warnings) == 0 {
+		return ""
+	}
+	return i18n.Tf("inspect.hint", len(inspection.Warnings))
+}
+
+// aliasPreview shows what the URL typed expands to with the aliases and
+// variables of env, or "" when no alias applies
+func (r *RequestBuilder) aliasPreview(env *storage.Environment) string {
+	if env == nil {
+		return ""
+	}
+	expanded, alias := storage.ExpandAlias(r.buildURLWithQueryParams(), env.Aliases)
+	if alias == "" {
+		return ""
+	}
+	return fmt.Sprintf("→ %s: %s", alias, storage.ReplaceVariables(expanded, env.Variables))
+}
+
+// viewSendChecks shows the checklist of the last send that did not pass it
+func (r *RequestBuilder) viewSendChecks() string {
+	if len(r.sendChecks) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(ErrorStyle.Render(i18n.T("precheck.title")))
+	b.WriteString("\n")
+	for _, check := range r.sendChecks {
+		if check.err != nil {
+			b.WriteString(ErrorStyle.Render(fmt.Sprintf("  ✗ %s: %v", i18n.T(check.label), check.err)))
+		} else {
+			b.WriteString(SuccessStyle.Render(fmt.Sprintf("  ✓ %s", i18n.T(check.label))))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (r *RequestBuilder) viewBuilder(h host) string {
+	var b strings.Builder
+
+	title := "GoDev v0.4.0"
+	if r.requestSaved {
+		title += i18n.T("title.saved")
+	}
+	title += h.requestBadges()
+	b.WriteString(TitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	methodLabel := "Method: "
+	methodSection := methodLabel
+	if r.focusIndex == 0 {
+		methodSection = TextStyle.Render(methodLabel) + ButtonActive.Render("[ "+r.method+" ▾ ]")
+	} else {
+		methodSection = MutedStyle.Render(methodLabel) + TextStyle.Render(r.method+" ▾")
+	}
+	b.WriteString(methodSection)
+	b.WriteString("\n\n")
+
+	urlLabel := "URL: "
+	b.WriteString(TextStyle.Render(urlLabel))
+	b.WriteString("\n")
+
+	borderColor := ColorBorder
+	if r.focusIndex == 1 {
+		borderColor = ColorAccent
+	}
+	styledInput := lipgloss.NewStyle().
+		Border(roundedBorder()).
+		BorderForeground(lipgloss.Color(borderColor)).
+		Padding(0, 1).
+		Width(r.urlInput.Width + 2).
+		Render(r.urlInput.View())
+	b.WriteString(styledInput)
+	b.WriteString("\n")
+
+	if len(r.queryParams) > 0 {
+		finalURL := r.buildURLWithQueryParams()
+		b.WriteString(MutedStyle.Render(fmt.Sprintf("    → Final URL: %s", finalURL)))
+		b.WriteString("\n")
+	}
+	if preview := r.aliasPreview(h.activeEnvironment()); preview != "" {
+		b.WriteString(MutedStyle.Render("    " + preview))
+		b.WriteString("\n")
+	}
+	if hint := r.urlEncodingHint(); hint != "" {
+		b.WriteString(WarningStyle.Render("    " + hint))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	queryCount := len(r.queryParams)
+	queryText := fmt.Sprintf("Query Params: (%d)", queryCount)
+	if r.focusIndex == 2 {
+		b.WriteString(ButtonActive.Render("[ " + queryText + " ]"))
+	} else {
+		b.WriteString(MutedStyle.Render(queryText))
+	}
+	b.WriteString("\n")
+
+	headersCount := len(r.headers)
+	headersText := fmt.Sprintf("Headers: (%d)", headersCount)
+	if r.focusIndex == 3 {
+		b.WriteString(ButtonActive.Render("[ " + headersText + " ]"))
+	} else {
+		b.WriteString(MutedStyle.Render(headersText))
+	}
+	b.WriteString("\n")
+
+	bodyPreview := "empty"
+	if r.body != "" {
+		bodyStr := strings.ReplaceAll(r.body, "\n", " ")
+		bodyStr = strings.TrimSpace(bodyStr)
+		bodyPreview = truncateWidth(bodyStr, 83, "...")
+	}
+	bodyText := fmt.Sprintf("Body: (%s)", bodyPreview)
+	if h.editingGraphQL() {
+		bodyText = fmt.Sprintf("Body: (%s)", truncateWidth(graphqlBodyPreview(r.body), 83, "..."))
+	} else if r.isMultipartBody() {
+		bodyText = fmt.Sprintf("Body: (%s)", truncateWidth(formBodyPreview(r.body), 83, "..."))
+	} else if r.bodyMode() == httpclient.BodyForm && r.body != "" {
+		bodyText = fmt.Sprintf("Body: (%s)", truncateWidth(i18n.Tf("body.form_preview", httpclient.EncodeFormBody(r.body)), 83, "..."))
+	}
+	if r.focusIndex == 4 {
+		b.WriteString(ButtonActive.Render("[ " + bodyText + " ]"))
+	} else {
+		b.WriteString(MutedStyle.Render(bodyText))
+	}
+	b.WriteString("\n\n")
+
+	buttons := RenderButton("Send Request", r.focusIndex == 5) + "  "
+	buttons += RenderButton("Load Saved", r.focusIndex == 6) + "  "
+	buttons += RenderButton("Quit", r.focusIndex == 7)
+	b.WriteString(buttons)
+
+	b.WriteString("\n")
+
+	if checks := r.viewSendChecks(); checks != "" {
+		b.WriteString("\n")
+		b.WriteString(checks)
+	}
+
+	if r.curlCopySuccess {
+		b.WriteString(SuccessStyle.Render("✓ cURL command copied to clipboard!"))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(h.viewRequestPrompts())
+
+	b.WriteString("\n")
+	b.WriteString(RenderFooter(i18n.T("footer.builder")))
+
+	width, height := h.size()
+	return Center(width, height, b.String())
+}
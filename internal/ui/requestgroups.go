@@ -0,0 +1,193 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// requestListRow is one line of the grouped request list: a group header
+// when request is -1, otherwise an index into the displayed requests
+type requestListRow struct {
+	host    string
+	count   int
+	request int
+}
+
+// displayed returns the saved requests the list shows, filtered by
+// the search when one is active
+func (l *RequestList) displayed() []storage.SavedRequest {
+	if l.filtered != nil {
+		return l.filtered
+	}
+	return l.saved
+}
+
+// groups groups the displayed requests by host, recognizing the
+// aliases of the active environment
+func (l *RequestList) groups(h host) []storage.RequestGroup {
+	var aliases []storage.ServiceAlias
+	if env := h.activeEnvironment(); env != nil {
+		aliases = env.Aliases
+	}
+	return storage.GroupRequestsByHost(l.displayed(), aliases)
+}
+
+// rows lays out the groups with one header per host, leaving out
+// the requests of collapsed groups
+func (l *RequestList) rows(groups []storage.RequestGroup) []requestListRow {
+	var rows []requestListRow
+	for _, group := range groups {
+		rows = append(rows, requestListRow{host: group.Host, count: len(group.Requests), request: -1})
+		if l.collapsed[group.Host] {
+			continue
+		}
+		for _, i := range group.Requests {
+			rows = append(rows, requestListRow{host: group.Host, request: i})
+		}
+	}
+	return rows
+}
+
+// groupedCursor returns the row the cursor is on. A selected request hidden
+// in a collapsed group puts the cursor on the group's header.
+func (l *RequestList) groupedCursor(groups []storage.RequestGroup, rows []requestListRow) int {
+	header := l.selectedHeader
+	if header == "" {
+		for _, group := range groups {
+			for _, i := range group.Requests {
+				if i == l.selected {
+					header = group.Host
+				}
+			}
+		}
+	}
+
+	for i, row := range rows {
+		if l.selectedHeader == "" && row.request == l.selected {
+			return i
+		}
+	}
+	for i, row := range rows {
+		if row.request == -1 && row.host == header {
+			return i
+		}
+	}
+	return 0
+}
+
+// moveGroupedCursor puts the cursor on a row: a header selects the group, a
+// request becomes the selected request the list actions apply to
+func (l *RequestList) moveGroupedCursor(rows []requestListRow, to int) {
+	if len(rows) == 0 {
+		return
+	}
+	to = clampIndex(to, len(rows))
+	if rows[to].request == -1 {
+		l.selectedHeader = rows[to].host
+		return
+	}
+	l.selectedHeader = ""
+	l.selected = rows[to].request
+}
+
+// toggleGrouping switches between the flat and the grouped list
+func (l *RequestList) toggleGrouping() {
+	l.grouped = !l.grouped
+	l.selectedHeader = ""
+	if l.collapsed == nil {
+		l.collapsed = make(map[string]bool)
+	}
+}
+
+// updateGrouped handles the keys that behave differently in the grouped
+// list and reports whether it did
+func (l *RequestList) updateGrouped(h host, msg tea.KeyMsg) bool {
+	groups := l.groups(h)
+	rows := l.rows(groups)
+	cursor := l.groupedCursor(groups, rows)
+	onHeader := len(rows) > 0 && rows[cursor].request == -1
+
+	switch msg.String() {
+	case "up", "k":
+		l.moveGroupedCursor(rows, cursor-1)
+		return true
+
+	case "down", "j":
+		l.moveGroupedCursor(rows, cursor+1)
+		return true
+
+	case "enter", " ":
+		if !onHeader {
+			return msg.String() == " "
+		}
+		host := rows[cursor].host
+		l.collapsed[host] = !l.collapsed[host]
+		return true
+
+	case "left", "h":
+		if len(rows) > 0 {
+			host := rows[cursor].host
+			l.collapsed[host] = true
+			l.selectedHeader = host
+		}
+		return true
+
+	case "right", "l":
+		if onHeader {
+			delete(l.collapsed, rows[cursor].host)
+		}
+		return true
+
+	case "d", "y":
+		// Headers are not requests; deleting needs a request selected
+		return onHeader && !l.confirmingDelete
+	}
+
+	return false
+}
+
+// viewGrouped renders the grouped list
+func (l *RequestList) viewGrouped(h host) string {
+	var b strings.Builder
+
+	requests := l.displayed()
+	groups := l.groups(h)
+	rows := l.rows(groups)
+	cursor := l.groupedCursor(groups, rows)
+	for i, row := range rows {
+		selected := i == cursor
+		if row.request == -1 {
+			marker := "▾"
+			if l.collapsed[row.host] {
+				marker = "▸"
+			}
+			line := fmt.Sprintf("%s %s (%d)", marker, row.host, row.count)
+			if selected {
+				b.WriteString(ListItemSelectedStyle.Render("> " + line))
+			} else {
+				b.WriteString(HeaderStyle.Render(line))
+			}
+			b.WriteString("\n")
+			continue
+		}
+
+		req := requests[row.request]
+		// Indented under the host of the group
+		b.WriteString("  ")
+		b.WriteString(renderRequestItem(req.Method, requestListLabel(req, requests), selected))
+		b.WriteString("\n")
+		if h.detailedLists() {
+			b.WriteString("  " + renderItemDetail(req.URL))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString(MutedStyle.Render(i18n.T("requests.grouped_hint")))
+	b.WriteString("\n")
+
+	return b.String()
+}
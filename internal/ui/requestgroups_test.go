@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+func pressKey(t *testing.T, m Model, key string) Model {
+	t.Helper()
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	switch key {
+	case "up":
+		msg = tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		msg = tea.KeyMsg{Type: tea.KeyDown}
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	}
+	m.requests.Update(&m, msg)
+	return m
+}
+
+func TestGroupedRequestList(t *testing.T) {
+	m := Model{
+		state: StateRequestList,
+		requests: RequestList{saved: []storage.SavedRequest{
+			{ID: "1", Name: "list users", Method: "GET", URL: "https://users.example.com/users"},
+			{ID: "2", Name: "create order", Method: "POST", URL: "https://orders.example.com/orders"},
+			{ID: "3", Name: "get user", Method: "GET", URL: "https://users.example.com/users/1"},
+		}},
+	}
+
+	m = pressKey(t, m, "g")
+	view := m.requests.viewGrouped(&m)
+	if !strings.Contains(view, "orders.example.com (1)") || !strings.Contains(view, "users.example.com (2)") {
+		t.Fatalf("Expected one header per host:\n%s", view)
+	}
+
+	// Rows: orders header, create order, users header, list users, get user.
+	// The cursor stays on the selected request when grouping is turned on.
+	m = pressKey(t, m, "up")
+	if m.requests.selectedHeader != "users.example.com" {
+		t.Fatalf("Expected the cursor on the users header, got %q", m.requests.selectedHeader)
+	}
+	m = pressKey(t, m, "d")
+	if m.requests.confirmingDelete {
+		t.Error("Expected d on a header not to start a delete")
+	}
+
+	m = pressKey(t, m, "enter")
+	if !m.requests.collapsed["users.example.com"] || strings.Contains(m.requests.viewGrouped(&m), "list users") {
+		t.Error("Expected Enter on a header to collapse its group")
+	}
+	m = pressKey(t, m, "l")
+	m = pressKey(t, m, "down")
+	m = pressKey(t, m, "enter")
+	if m.state != StateRequestBuilder || m.builder.currentRequestSavedID != "1" {
+		t.Errorf("Expected Enter on a request to load it, got state %v request %q", m.state, m.builder.currentRequestSavedID)
+	}
+}
@@ -0,0 +1,271 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// RequestList is the list of saved requests, searched by name and URL or
+// grouped by host
+type RequestList struct {
+	saved []storage.SavedRequest
+	// filtered are the requests matching the search, nil without one
+	filtered []storage.SavedRequest
+	selected int
+
+	search    textinput.Model
+	searching bool
+
+	confirmingDelete bool
+	toDelete         int
+
+	// grouped shows the requests under one header per host; selectedHeader
+	// is set while the cursor is on a header
+	grouped        bool
+	collapsed      map[string]bool
+	selectedHeader string
+}
+
+func newRequestList() RequestList {
+	search := textinput.New()
+	search.Placeholder = "Search requests..."
+	search.CharLimit = 100
+	search.Width = 50
+
+	return RequestList{search: search}
+}
+
+func (l *RequestList) typing() bool { return l.searching }
+
+func (l *RequestList) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	store := h.store()
+
+	if l.searching {
+		switch msg.String() {
+		case "ctrl+c", "ctrl+q":
+			return tea.Quit
+		case "esc":
+			l.searching = false
+			l.search.Blur()
+			l.search.SetValue("")
+			l.filtered = l.saved
+			l.selected = 0
+			return nil
+		case "enter":
+			l.searching = false
+			l.search.Blur()
+			return nil
+		default:
+			l.search, cmd = l.search.Update(msg)
+			if store != nil {
+				l.filtered = store.FilterRequests(l.search.Value())
+				if l.selected >= len(l.filtered) {
+					l.selected = 0
+				}
+			}
+			return cmd
+		}
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		if l.confirmingDelete {
+			l.confirmingDelete = false
+			return nil
+		}
+		h.navigate(StateRequestBuilder)
+		l.search.SetValue("")
+		l.filtered = nil
+		return nil
+
+	case "/":
+		l.searching = true
+		l.search.Focus()
+		if l.filtered == nil {
+			l.filtered = l.saved
+		}
+		return nil
+
+	case "g":
+		l.toggleGrouping()
+		return nil
+	}
+
+	if l.grouped && l.updateGrouped(h, msg) {
+		return nil
+	}
+
+	switch msg.String() {
+
+	case "up", "k":
+		if l.selected > 0 {
+			l.selected--
+		}
+		return nil
+
+	case "down", "j":
+		displayList := l.saved
+		if l.filtered != nil {
+			displayList = l.filtered
+		}
+		if l.selected < len(displayList)-1 {
+			l.selected++
+		}
+		return nil
+
+	case "enter":
+		displayList := l.saved
+		if l.filtered != nil {
+			displayList = l.filtered
+		}
+		if len(displayList) > 0 && l.selected < len(displayList) {
+			req := displayList[l.selected]
+			h.openSavedRequest(req)
+		}
+		return nil
+
+	case "d":
+		displayList := l.saved
+		if l.filtered != nil {
+			displayList = l.filtered
+		}
+		if h.blockedByReadOnly("delete request") {
+			return nil
+		}
+		if len(displayList) > 0 && l.selected < len(displayList) {
+			if !l.confirmingDelete {
+				l.confirmingDelete = true
+				l.toDelete = l.selected
+				return nil
+			}
+		}
+		return nil
+
+	case "y":
+		if l.confirmingDelete && store != nil {
+			displayList := l.saved
+			if l.filtered != nil {
+				displayList = l.filtered
+			}
+			if l.toDelete < len(displayList) {
+				req := displayList[l.toDelete]
+				h.reportStorageError("failed to delete request", store.DeleteRequest(req.ID))
+				l.saved = store.GetRequests()
+				if l.search.Value() != "" {
+					l.filtered = store.FilterRequests(l.search.Value())
+				} else {
+					l.filtered = nil
+				}
+				displayList = l.saved
+				if l.filtered != nil {
+					displayList = l.filtered
+				}
+				// Deleting a request also removes its variants
+				if l.selected >= len(displayList) {
+					l.selected = len(displayList) - 1
+				}
+				if l.selected < 0 {
+					l.selected = 0
+				}
+			}
+			l.confirmingDelete = false
+			return nil
+		}
+		return nil
+
+	case "n":
+		h.newRequest()
+		return nil
+	}
+
+	return nil
+}
+
+func (l *RequestList) View(h host) string {
+	var b strings.Builder
+	store := h.store()
+
+	title := i18n.Tf("title.saved_requests", len(l.saved))
+	b.WriteString(TitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	if l.searching || l.search.Value() != "" {
+		searchLabel := "Search: "
+		b.WriteString(TextStyle.Render(searchLabel))
+		b.WriteString("\n")
+
+		inputView := l.search.View()
+		var styledInput string
+		if l.searching {
+			styledInput = lipgloss.NewStyle().
+				Border(roundedBorder()).
+				BorderForeground(lipgloss.Color(ColorAccent)).
+				Padding(0, 1).
+				Width(l.search.Width + 2).
+				Render(inputView)
+		} else {
+			styledInput = lipgloss.NewStyle().
+				Border(roundedBorder()).
+				BorderForeground(lipgloss.Color(ColorBorder)).
+				Padding(0, 1).
+				Width(l.search.Width + 2).
+				Render(inputView)
+		}
+		b.WriteString(styledInput)
+		b.WriteString("\n\n")
+	}
+
+	displayList := l.saved
+	if l.filtered != nil {
+		displayList = l.filtered
+	}
+
+	if len(displayList) == 0 {
+		if l.search.Value() != "" {
+			b.WriteString(MutedStyle.Render("No matching requests"))
+		} else {
+			b.WriteString(MutedStyle.Render("No saved requests"))
+		}
+	} else if l.grouped {
+		b.WriteString(l.viewGrouped(h))
+	} else {
+		for i, req := range displayList {
+			label := requestListLabel(req, displayList)
+			b.WriteString(renderRequestItem(req.Method, label, i == l.selected))
+			b.WriteString("\n")
+			if h.detailedLists() {
+				b.WriteString(renderItemDetail(req.URL))
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	b.WriteString("\n\n")
+
+	if l.confirmingDelete && len(displayList) > 0 && l.toDelete < len(displayList) {
+		target := displayList[l.toDelete]
+		confirmMsg := i18n.Tf("confirm.delete_request", target.Name)
+		if store != nil {
+			if variants := len(store.GetVariants(target.ID)); variants > 0 {
+				confirmMsg = i18n.Tf("confirm.delete_variants", target.Name, variants)
+			}
+		}
+		b.WriteString(WarningStyle.Render(confirmMsg))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(RenderFooter(i18n.T("footer.request_list")))
+
+	width, height := h.size()
+	return Center(width, height, b.String())
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/storage"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestRequestListSearchAndShortcuts(t *testing.T) {
+	store, err := storage.NewStorageAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	for _, name := range []string{"list users", "create order"} {
+		if err := store.SaveRequest(name, "GET", "https://api.example.com/"+name, nil, "", nil); err != nil {
+			t.Fatalf("SaveRequest() error = %v", err)
+		}
+	}
+
+	m := *NewModel()
+	m.storage = store
+	m.requests.saved = store.GetRequests()
+	m.state = StateRequestList
+	press := func(key string) {
+		msg, _ := tuitest.ParseKey(key)
+		updated, _ := m.handleKeyPress(msg)
+		m = updated.(Model)
+	}
+
+	// Keys typed into the search are not shortcuts
+	press("/")
+	press("c")
+	press("r")
+	if m.state != StateRequestList || len(m.requests.filtered) != 1 || m.requests.filtered[0].Name != "create order" {
+		t.Fatalf("Expected the search to find create order, got state %v %+v", m.state, m.requests.filtered)
+	}
+
+	press("enter")
+	press("enter")
+	if m.state != StateRequestBuilder || !m.builder.requestSaved || m.builder.urlInput.Value() != "https://api.example.com/create order" {
+		t.Fatalf("Expected Enter to open the found request, got state %v URL %q", m.state, m.builder.urlInput.Value())
+	}
+
+	m.state = StateRequestList
+	press("c")
+	if m.state != StateCollections {
+		t.Errorf("Expected c to open the collections, got %v", m.state)
+	}
+}
@@ -0,0 +1,475 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// ResponseViewer is the response screen: the body, headers and timing of
+// the response, how it is filtered, searched and compared, and the prompts
+// that act on it.
+type ResponseViewer struct {
+	response     *httpclient.Response
+	scrollOffset int
+
+	viewResponseHeaders bool
+	viewResponseTiming  bool
+	viewRawResponse     bool
+	responseColorsOff   bool
+
+	// displayTransform is the jq or JSONPath expression the body is shown
+	// through
+	displayTransform string
+	transformInput   textinput.Model
+	editingTransform bool
+
+	schemaDrift     *httpclient.BodyDiff
+	viewSchemaDrift bool
+
+	pluginViewName    string
+	pluginViewOutput  string
+	pluginViewError   string
+	pluginViewLoading bool
+
+	budgetInput   textinput.Model
+	editingBudget bool
+	budgetError   string
+	scaffoldError string
+
+	goldenDiff      *httpclient.DiffResult
+	goldenPinnedAt  time.Time
+	viewGoldenDiff  bool
+	volatileInput   textinput.Model
+	editingVolatile bool
+	goldenError     string
+
+	saveBody         bodySaver
+	search           responseSearch
+	copySuccess      bool
+	copySuccessTimer int
+}
+
+func newResponseViewer() ResponseViewer {
+	transformInput := textinput.New()
+	transformInput.Placeholder = `.items[] | select(.id == 1) or $..id`
+	transformInput.CharLimit = 200
+	transformInput.Width = 50
+
+	budgetInput := textinput.New()
+	budgetInput.Placeholder = "300"
+	budgetInput.CharLimit = 7
+	budgetInput.Width = 10
+
+	volatileInput := textinput.New()
+	volatileInput.Placeholder = "updated_at, $.data[*].id"
+	volatileInput.CharLimit = 500
+	volatileInput.Width = 50
+
+	return ResponseViewer{
+		transformInput: transformInput,
+		budgetInput:    budgetInput,
+		volatileInput:  volatileInput,
+	}
+}
+
+func (v *ResponseViewer) resize(layout LayoutConfig) {
+	v.transformInput.Width = layout.InputWidth
+}
+
+// tick counts down the copy notice
+func (v *ResponseViewer) tick() {
+	if v.copySuccessTimer > 0 {
+		v.copySuccessTimer--
+		if v.copySuccessTimer == 0 {
+			v.copySuccess = false
+		}
+	}
+}
+
+// typing reports whether keys go to one of the prompts, so that shortcuts
+// are typed instead
+func (v *ResponseViewer) typing() bool {
+	return v.editingTransform || v.editingBudget || v.editingVolatile || v.saveBody.active || v.search.active
+}
+
+// close leaves the response, dropping what was shown of it
+func (v *ResponseViewer) close() {
+	v.dropSpooledBody()
+	v.response = nil
+	v.viewResponseHeaders = false
+	v.viewResponseTiming = false
+	v.viewSchemaDrift = false
+	v.budgetError = ""
+	v.scaffoldError = ""
+	v.saveBody = bodySaver{}
+	v.viewGoldenDiff = false
+	v.goldenError = ""
+}
+
+// Update handles a key for the prompt being typed in, or the response
+func (v *ResponseViewer) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case v.editingTransform:
+		return v.updateTransform(h, msg)
+	case v.editingBudget:
+		return v.updateBudget(h, msg)
+	case v.editingVolatile:
+		return v.updateVolatile(h, msg)
+	case v.saveBody.active:
+		return v.updateSaveBody(h, msg)
+	case v.search.active:
+		return v.updateSearch(h, msg)
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		if v.search.query != "" || v.search.notice != "" {
+			v.clearResponseSearch()
+			return nil
+		}
+		h.navigate(StateRequestBuilder)
+		h.clearResponseChecks()
+		v.close()
+
+	case "c":
+		if v.response != nil && v.response.Error == nil {
+			if err := clipboard.WriteAll(v.response.Body); err == nil {
+				v.copySuccess = true
+				v.copySuccessTimer = 3
+			}
+		}
+
+	case "h":
+		v.viewResponseHeaders = !v.viewResponseHeaders
+		v.scrollOffset = 0
+		v.search.current = 0
+
+	case "/":
+		if v.response != nil && v.response.Error == nil {
+			v.startResponseSearch(h)
+		}
+
+	case "n", "N":
+		if v.search.query != "" {
+			step := 1
+			if msg.String() == "N" {
+				step = -1
+			}
+			v.moveToMatch(h, v.search.current+step)
+		}
+
+	case "W":
+		if v.response != nil && v.response.Timing != nil {
+			v.viewResponseTiming = !v.viewResponseTiming
+			v.scrollOffset = 0
+		}
+
+	case "D":
+		if v.schemaDrift != nil {
+			v.viewSchemaDrift = !v.viewSchemaDrift
+			v.scrollOffset = 0
+		}
+
+	case "t":
+		if v.response != nil && v.response.Error == nil {
+			v.editingTransform = true
+			v.transformInput.SetValue(v.displayTransform)
+			v.transformInput.CursorEnd()
+			v.transformInput.Focus()
+		}
+
+	case "C":
+		v.responseColorsOff = !v.responseColorsOff
+
+	case "g":
+		if v.goldenDiff != nil {
+			v.viewGoldenDiff = !v.viewGoldenDiff
+			v.viewSchemaDrift = false
+			v.scrollOffset = 0
+		}
+
+	case "r":
+		if v.displayTransform != "" {
+			v.viewRawResponse = !v.viewRawResponse
+			v.scrollOffset = 0
+		}
+
+	case "up", "k":
+		if v.scrollOffset > 0 {
+			v.scrollOffset--
+		}
+
+	case "down", "j":
+		v.scrollOffset++
+		v.clampSpooledScroll(h)
+
+	case "pgup":
+		v.scrollOffset = max(v.scrollOffset-v.responsePageSize(h), 0)
+
+	case "pgdown":
+		v.scrollOffset += v.responsePageSize(h)
+		v.clampSpooledScroll(h)
+
+	case "home":
+		v.scrollOffset = 0
+	}
+	return nil
+}
+
+func (v *ResponseViewer) View(h host) string {
+	width, height := h.size()
+	if v.response == nil {
+		return Center(width, height, ErrorStyle.Render("No response"))
+	}
+
+	var b strings.Builder
+
+	title := "Response"
+	if v.viewResponseHeaders {
+		title = "Response Headers"
+	} else if v.viewResponseTiming {
+		title = i18n.T("title.timing")
+	}
+	b.WriteString(TitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	b.WriteString(h.viewResponseRequest())
+
+	if v.response.Error != nil {
+		b.WriteString(renderErrorPanel(v.response.Error, width-10))
+		if v.response.Attempts > 1 {
+			b.WriteString("\n")
+			b.WriteString(MutedStyle.Render(i18n.Tf("policy.attempts", v.response.Attempts)))
+			b.WriteString("\n\n")
+		}
+		if len(v.response.Redirects) > 0 && v.response.Attempts <= 1 {
+			b.WriteString("\n")
+		}
+		b.WriteString(v.viewRedirectChain())
+		b.WriteString(v.viewConnection(h))
+		b.WriteString(v.viewDownloadResult())
+	} else {
+		statusStyle := GetStatusStyle(v.response.StatusCode)
+		statusLine := fmt.Sprintf("Status: %s • %s • %s",
+			v.response.Status,
+			httpclient.FormatDuration(v.response.ResponseTime),
+			httpclient.FormatSize(v.response.Size))
+		// HTTP/1.1 is the usual case, so only other protocols are called out
+		if proto := v.response.Proto; proto != "" && proto != "HTTP/1.1" {
+			statusLine += " • " + proto
+		}
+		if v.response.Attempts > 1 {
+			statusLine += " • " + i18n.Tf("policy.attempts", v.response.Attempts)
+		}
+		b.WriteString(statusStyle.Render(statusLine))
+		if conn := v.response.Conn; conn != nil && conn.InsecureTLS {
+			b.WriteString("  ")
+			b.WriteString(WarningStyle.Render(i18n.T("tls.badge_insecure")))
+		}
+		b.WriteString("\n\n")
+		b.WriteString(v.viewRedirectChain())
+		b.WriteString(v.viewConnection(h))
+		b.WriteString(v.viewTimingSummary())
+		b.WriteString(v.viewTrailerHint())
+		b.WriteString(v.viewJWTHint())
+		b.WriteString(v.viewDownloadResult())
+
+		if v.response.StatusCode >= 400 && v.response.StatusCode < 500 {
+			b.WriteString(WarningStyle.Render(i18n.T("fix.hint")))
+			b.WriteString("\n\n")
+		}
+
+		if budget := h.activeLatencyBudget(); v.overBudget(budget) {
+			b.WriteString(WarningStyle.Render(i18n.Tf("budget.exceeded",
+				httpclient.FormatDuration(v.response.ResponseTime), budget)))
+			b.WriteString("\n\n")
+		}
+
+		if v.editingBudget {
+			b.WriteString(TextStyle.Render(i18n.T("budget.prompt")))
+			b.WriteString("\n")
+			b.WriteString(lipgloss.NewStyle().
+				Border(roundedBorder()).
+				BorderForeground(lipgloss.Color(ColorAccent)).
+				Padding(0, 1).
+				Width(v.budgetInput.Width + 2).
+				Render(v.budgetInput.View()))
+			b.WriteString("\n\n")
+		}
+
+		if v.budgetError != "" {
+			b.WriteString(ErrorStyle.Render("✗ " + v.budgetError))
+			b.WriteString("\n\n")
+		}
+
+		if v.scaffoldError != "" {
+			b.WriteString(ErrorStyle.Render("✗ " + v.scaffoldError))
+			b.WriteString("\n\n")
+		}
+
+		b.WriteString(h.viewResponseChecks())
+		b.WriteString(v.viewSaveBody())
+		if pinned := h.viewPinned(paneResponse); pinned != "" {
+			b.WriteString(pinned)
+			b.WriteString("\n\n")
+		}
+		b.WriteString(v.viewGoldenStatus())
+
+		if v.copySuccess {
+			b.WriteString(SuccessStyle.Render("✓ Copied to clipboard!"))
+			b.WriteString("\n\n")
+		}
+
+		if v.editingTransform {
+			b.WriteString(TextStyle.Render("Filter with jq or JSONPath, shown as you type (Enter: apply • Esc: cancel • empty: show raw):"))
+			b.WriteString("\n")
+			b.WriteString(lipgloss.NewStyle().
+				Border(roundedBorder()).
+				BorderForeground(lipgloss.Color(ColorAccent)).
+				Padding(0, 1).
+				Width(v.transformInput.Width + 2).
+				Render(v.transformInput.View()))
+			b.WriteString("\n\n")
+		}
+
+		body, matches, transformErr := v.responseDisplayBody()
+		if transformErr != "" {
+			b.WriteString(ErrorStyle.Render(fmt.Sprintf("✗ Transform failed: %s (showing raw body)", transformErr)))
+			b.WriteString("\n\n")
+		} else if v.editingTransform && matches >= 0 {
+			b.WriteString(MutedStyle.Render(fmt.Sprintf("Preview • %s", transformMatches(matches))))
+			b.WriteString("\n\n")
+		} else if v.displayTransform != "" && !v.viewResponseHeaders && !v.viewSchemaDrift && !v.viewGoldenDiff {
+			mode := "transformed body (" + transformMatches(matches) + ")"
+			if v.viewRawResponse {
+				mode = "raw body"
+			}
+			b.WriteString(MutedStyle.Render(fmt.Sprintf("Showing %s • transform: %s • r: toggle raw", mode, v.displayTransform)))
+			b.WriteString("\n\n")
+		}
+
+		b.WriteString(v.viewResponseSearch())
+
+		if v.schemaDrift != nil {
+			b.WriteString(WarningStyle.Render(fmt.Sprintf("⚠ Response structure changed since last run (%s) • D: details • A: accept", v.schemaDrift.Summary)))
+			b.WriteString("\n\n")
+		}
+
+		if v.pluginViewError != "" {
+			b.WriteString(ErrorStyle.Render("✗ Viewer plugin: " + v.pluginViewError))
+			b.WriteString("\n\n")
+		} else if v.pluginViewName != "" {
+			status := "v: back to body"
+			if v.pluginViewLoading {
+				status = h.spinnerView() + " rendering…"
+			}
+			b.WriteString(MutedStyle.Render(fmt.Sprintf("Rendered by plugin %s • %s", v.pluginViewName, status)))
+			b.WriteString("\n\n")
+		}
+
+		var content string
+		showsBody := false
+		if v.viewResponseTiming && !v.viewResponseHeaders {
+			content = v.viewTimingWaterfall(h)
+		} else if v.pluginViewName != "" && v.pluginViewError == "" && !v.viewResponseHeaders {
+			content = v.pluginViewOutput
+		} else if v.viewSchemaDrift && v.schemaDrift != nil {
+			content = HighlightDiff(httpclient.FormatSchemaDrift(v.schemaDrift))
+		} else if v.viewGoldenDiff && v.goldenDiff != nil {
+			content = HighlightDiff(httpclient.FormatDiff(v.goldenDiff))
+		} else if v.viewResponseHeaders {
+			content = v.responseHeaderText()
+		} else if v.responseIsBinary() {
+			content = v.viewBinarySummary()
+		} else if !v.showsSpooledBody() {
+			content = body
+			showsBody = true
+		}
+
+		maxLines := height - 17
+		var visibleLines []string
+		var start, end, totalLines int
+		if v.showsSpooledBody() {
+			b.WriteString(MutedStyle.Render(i18n.Tf("spool.notice", httpclient.FormatSize(v.response.Size))))
+			b.WriteString("\n\n")
+			visibleLines, start, totalLines = v.spooledPage(maxLines)
+			end = start + len(visibleLines)
+		} else {
+			lines := strings.Split(content, "\n")
+			totalLines = len(lines)
+
+			start = v.scrollOffset
+			end = start + maxLines
+			if end > totalLines {
+				end = totalLines
+			}
+			if start >= totalLines {
+				start = totalLines - maxLines
+				if start < 0 {
+					start = 0
+				}
+			}
+			if start < totalLines {
+				visibleLines = lines[start:end]
+			}
+			if showsBody {
+				visibleLines = v.highlightResponseBody(visibleLines, start)
+			} else if v.search.query != "" {
+				visibleLines = v.highlightSearchLines(visibleLines, start)
+			}
+		}
+
+		responsePanel := ""
+		if start < totalLines {
+			responseContent := strings.Join(visibleLines, "\n")
+
+			scrollInfo := ""
+			if totalLines > maxLines {
+				scrollInfo = fmt.Sprintf("\n\n%s Lines %d-%d of %d",
+					MutedStyle.Render("│"),
+					start+1,
+					end,
+					totalLines)
+			}
+
+			responsePanel = lipgloss.NewStyle().
+				Border(roundedBorder()).
+				BorderForeground(lipgloss.Color(ColorBorder)).
+				Padding(1, 2).
+				Width(width - 10).
+				Render(CodeStyle.Render(responseContent) + scrollInfo)
+		}
+		b.WriteString(responsePanel)
+	}
+
+	b.WriteString("\n\n")
+
+	buttons := RenderButton("Back (Esc)", true) + "  "
+	buttons += RenderButton("Save (s)", false) + "  "
+	if v.response.Error == nil {
+		buttons += RenderButton("Copy (c)", false) + "  "
+		if v.viewResponseHeaders {
+			buttons += RenderButton("Body (h)", false)
+		} else {
+			buttons += RenderButton("Headers (h)", false)
+		}
+	}
+	b.WriteString(buttons)
+
+	b.WriteString("\n\n")
+	b.WriteString(RenderFooter(i18n.T("footer.response")))
+
+	return Center(width, height, b.String())
+}
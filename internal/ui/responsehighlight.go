@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// bodySyntax is the language the response body is colored as
+type bodySyntax int
+
+const (
+	syntaxNone bodySyntax = iota
+	syntaxJSON
+	syntaxMarkup
+)
+
+// responseSyntax tells how to color the body of the response from its
+// Content-Type, or from its first character when the server sent none. A
+// filtered body is always JSON.
+func (v *ResponseViewer) responseSyntax() bodySyntax {
+	if v.responseColorsOff || plainOutput || v.response == nil || v.response.Error != nil {
+		return syntaxNone
+	}
+	if !v.viewRawResponse && v.activeTransform() != "" {
+		return syntaxJSON
+	}
+
+	mediaType := strings.ToLower(firstHeader(v.response.Headers, "Content-Type"))
+	switch {
+	case strings.Contains(mediaType, "json"):
+		return syntaxJSON
+	case strings.Contains(mediaType, "xml"), strings.Contains(mediaType, "html"):
+		return syntaxMarkup
+	case mediaType != "" && !strings.HasPrefix(mediaType, "text/plain"):
+		return syntaxNone
+	}
+
+	switch body := strings.TrimSpace(v.response.Body); {
+	case strings.HasPrefix(body, "{"), strings.HasPrefix(body, "["):
+		return syntaxJSON
+	case strings.HasPrefix(body, "<"):
+		return syntaxMarkup
+	}
+	return syntaxNone
+}
+
+// highlightResponseBody colors the lines of the body on screen, first is
+// the index of the first of them. Lines with search matches show the
+// matches instead.
+func (v *ResponseViewer) highlightResponseBody(lines []string, first int) []string {
+	syntax := v.responseSyntax()
+	if syntax == syntaxNone {
+		if v.search.query != "" {
+			return v.highlightSearchLines(lines, first)
+		}
+		return lines
+	}
+
+	sh := &SyntaxHighlighter{Theme: responseTheme()}
+	text := strings.Join(lines, "\n")
+	if syntax == syntaxJSON {
+		text = sh.HighlightJSON(text)
+	} else {
+		text = sh.HighlightXML(text)
+	}
+	colored := strings.Split(text, "\n")
+	if len(colored) != len(lines) {
+		return lines
+	}
+
+	if v.search.query != "" {
+		searched := v.highlightSearchLines(lines, first)
+		for i := range lines {
+			if searched[i] != lines[i] {
+				colored[i] = searched[i]
+			}
+		}
+	}
+	return colored
+}
+
+// responseTheme is the highlighting theme on the background of the
+// response panel
+func responseTheme() HighlightTheme {
+	theme := DefaultDarkTheme()
+	for _, style := range []*lipgloss.Style{
+		&theme.Keyword, &theme.String, &theme.Number, &theme.Comment, &theme.Property,
+	} {
+		*style = style.Inherit(CodeStyle)
+	}
+	theme.Text = CodeStyle
+	return theme
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestResponseSyntax(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+		want        bodySyntax
+	}{
+		{"application/json; charset=utf-8", `{"a": 1}`, syntaxJSON},
+		{"application/problem+json", `{}`, syntaxJSON},
+		{"application/xml", `<a/>`, syntaxMarkup},
+		{"text/html", `<!DOCTYPE html>`, syntaxMarkup},
+		{"text/csv", `[1]`, syntaxNone},
+		{"text/plain", `  [1, 2]`, syntaxJSON},
+		{"", `<feed/>`, syntaxMarkup},
+		{"", `plain`, syntaxNone},
+	}
+	for _, tt := range tests {
+		m := NewModel()
+		m.viewer.response = &httpclient.Response{Body: tt.body, Headers: map[string][]string{}}
+		if tt.contentType != "" {
+			m.viewer.response.Headers["Content-Type"] = []string{tt.contentType}
+		}
+		if got := m.viewer.responseSyntax(); got != tt.want {
+			t.Errorf("responseSyntax(%q, %q) = %v, want %v", tt.contentType, tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestFlowResponseBodyIsHighlighted(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name": "godev", "stars": 42}`))
+	}))
+	defer server.Close()
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL).Press("enter").WaitFor(`"stars"`)
+
+	key := responseTheme().Property.Render(`"name"`)
+	if view := d.Model().View(); !strings.Contains(view, key) {
+		t.Fatalf("The JSON keys of the response are not highlighted:\n%q", view)
+	}
+
+	d.Press("/").Type("godev").Press("enter").AssertView(`"name": "godev"`)
+	if view := d.Model().View(); !strings.Contains(view, responseTheme().Number.Render("42")) {
+		t.Error("Lines without search matches lost their colors")
+	}
+
+	d.Press("esc", "C").AssertView(`"stars": 42`)
+	if view := d.Model().View(); strings.Contains(view, key) {
+		t.Error("C did not turn the colors off")
+	}
+}
@@ -0,0 +1,258 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// responseSearch holds the text searched in the response view. The
+// matches are found again on every render, so they follow the headers
+// toggle and the filter of the body.
+type responseSearch struct {
+	active bool
+	input  textinput.Model
+	query  string
+	// current is the match n and N moved to, counted over every match
+	current int
+	notice  string
+}
+
+// searchMatch is where the query was found: bytes start to end of a line
+type searchMatch struct {
+	line, start, end int
+}
+
+// findMatches returns every place query is found in text, ignoring case
+func findMatches(text, query string) []searchMatch {
+	if query == "" {
+		return nil
+	}
+	re := regexp.MustCompile("(?i)" + regexp.QuoteMeta(query))
+	var matches []searchMatch
+	for i, line := range strings.Split(text, "\n") {
+		for _, loc := range re.FindAllStringIndex(line, -1) {
+			matches = append(matches, searchMatch{line: i, start: loc[0], end: loc[1]})
+		}
+	}
+	return matches
+}
+
+// responseHeaderText lists the response headers sorted by name, then the
+// trailers
+func (v *ResponseViewer) responseHeaderText() string {
+	keys := make([]string, 0, len(v.response.Headers))
+	for key := range v.response.Headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var headerLines []string
+	for _, key := range keys {
+		for _, value := range v.response.Headers[key] {
+			headerLines = append(headerLines, fmt.Sprintf("%s : %s", padRightWidth(key, 30), value))
+		}
+	}
+	headerLines = append(headerLines, v.trailerLines()...)
+	return strings.Join(headerLines, "\n")
+}
+
+// searchableResponse returns the text the response view shows that can be
+// searched: the headers or the body as plain text. Timing, diffs, plugin
+// output and spooled bodies are not searched.
+func (v *ResponseViewer) searchableResponse() (string, bool) {
+	if v.response == nil || v.response.Error != nil {
+		return "", false
+	}
+	if v.viewResponseHeaders {
+		return v.responseHeaderText(), true
+	}
+	if v.viewResponseTiming || v.pluginViewName != "" || v.viewSchemaDrift || v.viewGoldenDiff ||
+		v.responseIsBinary() || v.showsSpooledBody() {
+		return "", false
+	}
+	body, _, _ := v.responseDisplayBody()
+	return body, true
+}
+
+// responseSearchMatches returns the matches of the search in what the
+// response view shows
+func (v *ResponseViewer) responseSearchMatches() []searchMatch {
+	text, ok := v.searchableResponse()
+	if !ok {
+		return nil
+	}
+	return findMatches(text, v.search.query)
+}
+
+// startResponseSearch opens the search box on the previous query
+func (v *ResponseViewer) startResponseSearch(h host) {
+	s := &v.search
+	s.notice = ""
+	if _, ok := v.searchableResponse(); !ok {
+		s.notice = i18n.T("response_search.unavailable")
+		return
+	}
+
+	width := h.screenLayout().InputWidth
+	if width <= 0 {
+		width = 60
+	}
+	s.input = textinput.New()
+	s.input.Placeholder = i18n.T("response_search.placeholder")
+	s.input.CharLimit = 200
+	s.input.Width = width
+	s.input.SetValue(s.query)
+	s.input.CursorEnd()
+	s.input.Focus()
+	s.active = true
+}
+
+// clearResponseSearch drops the search and its highlights
+func (v *ResponseViewer) clearResponseSearch() {
+	v.search = responseSearch{}
+}
+
+// updateSearch handles typing in the search box. Matches are
+// highlighted as the query is typed; Enter keeps them for n and N.
+func (v *ResponseViewer) updateSearch(h host, msg tea.KeyMsg) tea.Cmd {
+	s := &v.search
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		v.clearResponseSearch()
+		return nil
+
+	case "enter":
+		s.active = false
+		s.input.Blur()
+		if s.query == "" {
+			v.clearResponseSearch()
+		}
+		return nil
+	}
+
+	var cmd tea.Cmd
+	s.input, cmd = s.input.Update(msg)
+	if query := s.input.Value(); query != s.query {
+		s.query = query
+		v.moveToMatch(h, v.firstVisibleMatch())
+	}
+	return cmd
+}
+
+// firstVisibleMatch returns the first match at or below the top of the
+// view, so typing does not jump back to the start of a long body
+func (v *ResponseViewer) firstVisibleMatch() int {
+	for i, match := range v.responseSearchMatches() {
+		if match.line >= v.scrollOffset {
+			return i
+		}
+	}
+	return 0
+}
+
+// moveToMatch makes match i the current one, wrapping around at both
+// ends, and scrolls it into view
+func (v *ResponseViewer) moveToMatch(h host, i int) {
+	matches := v.responseSearchMatches()
+	if len(matches) == 0 {
+		v.search.current = 0
+		return
+	}
+	i = (i%len(matches) + len(matches)) % len(matches)
+	v.search.current = i
+
+	line, page := matches[i].line, v.responsePageSize(h)
+	if line < v.scrollOffset || line >= v.scrollOffset+page {
+		v.scrollOffset = max(line-page/2, 0)
+	}
+}
+
+// viewResponseSearch renders the search box or where the search stands
+func (v *ResponseViewer) viewResponseSearch() string {
+	s := v.search
+	var b strings.Builder
+
+	if s.notice != "" {
+		b.WriteString(MutedStyle.Render(s.notice))
+		b.WriteString("\n\n")
+	}
+	if s.active {
+		b.WriteString(TextStyle.Render(i18n.T("response_search.prompt")))
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().
+			Border(roundedBorder()).
+			BorderForeground(lipgloss.Color(ColorAccent)).
+			Padding(0, 1).
+			Width(s.input.Width + 2).
+			Render(s.input.View()))
+		b.WriteString("\n")
+	}
+	if s.query == "" {
+		if s.active {
+			b.WriteString("\n")
+		}
+		return b.String()
+	}
+
+	if _, ok := v.searchableResponse(); !ok {
+		b.WriteString(MutedStyle.Render(i18n.T("response_search.unavailable")))
+	} else if matches := v.responseSearchMatches(); len(matches) == 0 {
+		b.WriteString(WarningStyle.Render(i18n.Tf("response_search.none", s.query)))
+	} else {
+		b.WriteString(MutedStyle.Render(i18n.Tf("response_search.status", s.query, s.current%len(matches)+1, len(matches))))
+	}
+	b.WriteString("\n\n")
+	return b.String()
+}
+
+// highlightSearchLines marks the matches in lines, which start at line
+// first of the searched text. The current match stands out from the rest.
+func (v *ResponseViewer) highlightSearchLines(lines []string, first int) []string {
+	matches := v.responseSearchMatches()
+	if len(matches) == 0 {
+		return lines
+	}
+	current := v.search.current % len(matches)
+	matchStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorBg)).
+		Background(lipgloss.Color(ColorWarning))
+	currentStyle := matchStyle.Background(lipgloss.Color(ColorAccent)).Bold(true)
+
+	out := make([]string, len(lines))
+	copy(out, lines)
+	for i := 0; i < len(matches); {
+		line := matches[i].line - first
+		if line < 0 || line >= len(lines) {
+			i++
+			continue
+		}
+
+		var sb strings.Builder
+		text, pos := lines[line], 0
+		for ; i < len(matches) && matches[i].line-first == line; i++ {
+			match := matches[i]
+			sb.WriteString(CodeStyle.Render(text[pos:match.start]))
+			style := matchStyle
+			if i == current {
+				style = currentStyle
+			}
+			sb.WriteString(style.Render(text[match.start:match.end]))
+			pos = match.end
+		}
+		sb.WriteString(CodeStyle.Render(text[pos:]))
+		out[line] = sb.String()
+	}
+	return out
+}
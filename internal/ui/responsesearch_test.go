@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFindMatchesIgnoresCase(t *testing.T) {
+	got := findMatches("Token: abc\nno match\ntoken token", "TOKEN")
+	want := []searchMatch{{0, 0, 5}, {2, 0, 5}, {2, 6, 11}}
+	if len(got) != len(want) {
+		t.Fatalf("findMatches() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("findMatches()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if matches := findMatches("a.b", "."); len(matches) != 1 {
+		t.Errorf("findMatches() read the query as a pattern: %v", matches)
+	}
+}
+
+func TestFlowResponseSearchMovesBetweenMatches(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Session-Token", "abc")
+		w.Write([]byte("first token\nsecond line\nthird Token and token"))
+	}))
+	defer server.Close()
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL).Press("enter").WaitFor("second line")
+
+	d.Press("/").Type("TOKEN").AssertView(`Search "TOKEN": match 1 of 3`)
+	d.Press("enter", "n").AssertView("match 2 of 3")
+	d.Press("N", "N").AssertView("match 3 of 3")
+
+	d.Press("h").AssertView("X-Session-Token", `Search "TOKEN": match 1 of 1`)
+	d.Press("/", "ctrl+u").Type("nothing here").AssertView(`No matches for "nothing here"`)
+
+	d.Press("esc").AssertNoView("Search", "No matches")
+	d.Press("esc").AssertNoView("X-Session-Token")
+}
@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/database"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// resultLimiter holds the bar of the result view that runs the query again
+// with another LIMIT or a TABLESAMPLE of its table
+type resultLimiter struct {
+	active bool
+	limit  textinput.Model
+	sample textinput.Model
+	err    string
+}
+
+// startLimit opens the bar with the LIMIT and the sample the query in the
+// editor has now
+func (d *DatabaseExplorer) startLimit() {
+	r := &d.limit
+	query := d.editor.Value()
+	r.err = ""
+
+	r.limit = textinput.New()
+	r.limit.Prompt = ""
+	r.limit.Placeholder = i18n.T("result_limit.no_limit")
+	r.limit.CharLimit = 9
+	r.limit.Width = 12
+	if n := database.QueryLimit(query); n > 0 {
+		r.limit.SetValue(strconv.Itoa(n))
+	}
+
+	r.sample = textinput.New()
+	r.sample.Prompt = ""
+	r.sample.Placeholder = i18n.T("result_limit.all_rows")
+	r.sample.CharLimit = 9
+	r.sample.Width = 12
+	if p := database.QuerySample(query); p > 0 {
+		r.sample.SetValue(strconv.FormatFloat(p, 'f', -1, 64))
+	}
+
+	r.limit.CursorEnd()
+	r.limit.Focus()
+	r.active = true
+}
+
+// updateLimit handles input in the limit bar. Enter writes the LIMIT and
+// TABLESAMPLE into the query in the editor and runs it; an empty field
+// removes its clause.
+func (d *DatabaseExplorer) updateLimit(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	r := &d.limit
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		r.active = false
+		r.err = ""
+		return nil
+
+	case "tab", "shift+tab":
+		if r.limit.Focused() {
+			r.limit.Blur()
+			r.sample.Focus()
+		} else {
+			r.sample.Blur()
+			r.limit.Focus()
+		}
+		return nil
+
+	case "enter":
+		query, err := r.apply(d.editor.Value())
+		if err != "" {
+			r.err = err
+			return nil
+		}
+		d.editor.SetValue(query)
+		run := d.runQuery(h)
+		if run == nil {
+			_, missing := d.queryToRun(h)
+			r.err = i18n.Tf("result_limit.missing_vars", strings.Join(missing, ", "))
+			return nil
+		}
+		r.active = false
+		r.err = ""
+		return run
+	}
+
+	if r.limit.Focused() {
+		r.limit, cmd = r.limit.Update(msg)
+	} else {
+		r.sample, cmd = r.sample.Update(msg)
+	}
+	return cmd
+}
+
+// apply returns query with the LIMIT and sample of the bar, or why they
+// cannot be set
+func (r resultLimiter) apply(query string) (string, string) {
+	limit := 0
+	if value := strings.TrimSpace(r.limit.Value()); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return "", i18n.Tf("result_limit.bad_limit", value)
+		}
+		limit = n
+	}
+	percent := 0.0
+	if value := strings.TrimSuffix(strings.TrimSpace(r.sample.Value()), "%"); value != "" {
+		p, err := strconv.ParseFloat(value, 64)
+		if err != nil || p <= 0 || p > 100 {
+			return "", i18n.Tf("result_limit.bad_sample", value)
+		}
+		percent = p
+	}
+
+	query, err := database.SetQuerySample(query, percent)
+	if err == nil {
+		query, err = database.SetQueryLimit(query, limit)
+	}
+	if err != nil {
+		return "", i18n.Tf("result_limit.failed", err.Error())
+	}
+	return query, ""
+}
+
+// viewLimit renders the limit bar and why the last change failed
+func (d *DatabaseExplorer) viewLimit() string {
+	r := d.limit
+	if !r.active {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(TextStyle.Render(i18n.T("result_limit.limit")) + " " + r.limit.View())
+	b.WriteString("   ")
+	b.WriteString(TextStyle.Render(i18n.T("result_limit.sample")) + " " + r.sample.View())
+	b.WriteString("\n")
+	b.WriteString(MutedStyle.Render(i18n.T("result_limit.hint")))
+	b.WriteString("\n\n")
+	if r.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + r.err))
+		b.WriteString("\n\n")
+	}
+	return b.String()
+}
+
+// runQuery runs the query in the editor, with its variables filled in
+// when it opted in. It returns nil when there is nothing to run or a
+// variable has no value.
+func (d *DatabaseExplorer) runQuery(h host) tea.Cmd {
+	query, missing := d.queryToRun(h)
+	if query == "" || len(missing) > 0 {
+		return nil
+	}
+
+	ctx, tick := h.startLoading(opQuery)
+	return tea.Batch(tick, executeDatabaseQueryCmd(ctx, d.client, query))
+}
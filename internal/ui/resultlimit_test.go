@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/database"
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowResultLimitRerunsQuery(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	m := NewModel()
+	m.state = StateDatabaseResult
+	m.db.editor.SetValue("SELECT * FROM events e\nWHERE e.kind = 'click'\nLIMIT 100;")
+	m.db.result = &database.QueryResult{
+		Columns: []string{"id", "kind"},
+		Rows:    [][]string{{"1", "click"}},
+	}
+	m.db.table = NewBubblesTableWrapper(m.db.result.Columns, m.db.result.Rows, 120, 30)
+
+	d := tuitest.New(t, *m).Resize(160, 50)
+	d.Press("L").AssertView("Limit: 100", "Enter: run again with this LIMIT and TABLESAMPLE")
+
+	d.Press("tab").Type("abc").Press("enter").AssertView("✗ The sample must be a percentage above 0 and up to 100, not abc")
+
+	d.Press("ctrl+u").Type("5%").Press("shift+tab", "backspace", "backspace").Press("enter")
+	d.WaitFor("not connected to database")
+
+	got := d.Model().(Model)
+	want := "SELECT * FROM events e TABLESAMPLE BERNOULLI (5)\nWHERE e.kind = 'click'\nLIMIT 1;"
+	if query := got.db.editor.Value(); query != want {
+		t.Errorf("Query = %q, want %q", query, want)
+	}
+	if got.db.limit.active {
+		t.Error("The limit bar stayed open after the query ran")
+	}
+}
+
+func TestFlowResultLimitKeepsQueryItCannotChange(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	m := NewModel()
+	m.state = StateDatabaseResult
+	m.db.editor.SetValue("SELECT * FROM generate_series(1, 10)")
+	m.db.result = &database.QueryResult{
+		Columns: []string{"generate_series"},
+		Rows:    [][]string{{"1"}},
+	}
+
+	d := tuitest.New(t, *m).Resize(160, 50)
+	d.Press("L", "tab").Type("10").Press("enter").
+		AssertView("✗ Cannot change the query: the query reads from the function generate_series, not a table")
+	d.Press("esc").AssertNoView("Limit:")
+
+	if got := d.Model().(Model); got.db.editor.Value() != "SELECT * FROM generate_series(1, 10)" {
+		t.Errorf("Query = %q, it was changed", got.db.editor.Value())
+	}
+}
@@ -0,0 +1,886 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// A screen owns the state of one part of the UI and handles its keys and
+// rendering. Screens reach the rest of the program only through host.
+type screen interface {
+	Update(h host, msg tea.KeyMsg) tea.Cmd
+	View(h host) string
+}
+
+// host is what a screen may use of the program around it
+type host interface {
+	// Where the screen is shown
+	screenState() AppState
+	navigate(to AppState)
+	size() (width, height int)
+	screenLayout() LayoutConfig
+	keys() KeyMap
+	detailedLists() bool
+	listItemLines() int
+
+	// What every screen shares
+	store() *storage.Storage
+	readOnlyMode() bool
+	blockedByReadOnly(action string) bool
+	reportStorageError(action string, err error)
+	startLoading(kind int) (context.Context, tea.Cmd)
+	viewPinned(kind string) string
+
+	// The request builder and the database explorer
+	sendRequest() tea.Cmd
+	builtRequest() httpclient.Request
+	editBody()
+	pasteCurl(command string)
+	editingGraphQL() bool
+	graphQLBody() string
+	setGraphQLBody(body string)
+	openGraphQLEditor()
+	setRequestBody(body string)
+	requestLine() string
+	saveGraphQLOperation() (storage.GraphQLOperation, error)
+	loadGraphQLOperation(op storage.GraphQLOperation, variables string) error
+	forgetGraphQLOperation(id string)
+	activeEnvironment() *storage.Environment
+	requestBadges() string
+	viewRequestPrompts() string
+	openAliases()
+	loadRequest(req storage.SavedRequest)
+	openSavedRequest(req storage.SavedRequest)
+	newRequest()
+	loadExecution(exec storage.RequestExecution)
+	loadCollectionRequest(req storage.SavedRequest)
+	savedRequestList() []storage.SavedRequest
+	savedRequestID() string
+	reloadSavedRequests()
+	saveExecution(exec storage.RequestExecution) bool
+	requestClient() *httpclient.Client
+	diffIgnoreRules() httpclient.IgnoreRules
+	sendDefaults() RequestDefaults
+	storeExtracted(vars []storage.Variable) (string, error)
+	databaseExplorer() *DatabaseExplorer
+	responseViewer() *ResponseViewer
+	environments() *Environments
+
+	// The response viewer
+	activeLatencyBudget() int64
+	saveLatencyBudget(budget int64) error
+	saveVolatileFields(fields []string) error
+	setDisplayTransform(expr string)
+	clearResponseChecks()
+	connStats(host string) httpclient.HostConnStats
+	spinnerView() string
+	spinnerTick() tea.Cmd
+	viewResponseRequest() string
+	viewResponseChecks() string
+
+	// The workspaces and the trash
+	switchWorkspace(name string) error
+	restoreTrashItem(item storage.TrashItem) error
+}
+
+func (m *Model) screenState() AppState                    { return m.state }
+func (m *Model) navigate(to AppState)                     { m.state = to }
+func (m *Model) size() (int, int)                         { return m.width, m.height }
+func (m *Model) screenLayout() LayoutConfig               { return m.layout }
+func (m *Model) keys() KeyMap                             { return m.keymap }
+func (m *Model) store() *storage.Storage                  { return m.storage }
+func (m *Model) viewPinned(kind string) string            { return m.split.viewPinned(kind) }
+func (m *Model) databaseExplorer() *DatabaseExplorer      { return &m.db }
+func (m *Model) responseViewer() *ResponseViewer          { return &m.viewer }
+func (m *Model) environments() *Environments              { return &m.envs }
+func (m *Model) openAliases()                             { m.aliases.open(m) }
+func (m *Model) readOnlyMode() bool                       { return m.readOnly }
+func (m *Model) spinnerView() string                      { return m.spinner.View() }
+func (m *Model) spinnerTick() tea.Cmd                     { return m.spinner.Tick }
+func (m *Model) builtRequest() httpclient.Request         { return m.buildRequest() }
+func (m *Model) savedRequestList() []storage.SavedRequest { return m.requests.saved }
+
+// startLoading shows the loading screen for an operation of kind, returning
+// the context that cancels it and the command that animates the spinner
+func (m *Model) startLoading(kind int) (context.Context, tea.Cmd) {
+	m.state = StateLoading
+	m.loading = true
+	m.err = nil
+	return m.startOperation(kind), m.spinner.Tick
+}
+
+func (m *Model) diffIgnoreRules() httpclient.IgnoreRules { return m.diffIgnore }
+func (m *Model) sendDefaults() RequestDefaults           { return m.requestDefaults }
+
+// requestClient returns the client with the proxy and client certificate
+// of the active environment applied
+func (m *Model) requestClient() *httpclient.Client {
+	m.applyProxy()
+	m.applyTLS()
+	return m.httpClient
+}
+
+// openSavedRequest opens req in the request builder with everything saved
+// with it, as the saved request being edited
+func (m *Model) openSavedRequest(req storage.SavedRequest) {
+	m.builder.method = req.Method
+	m.builder.urlInput.SetValue(req.URL)
+	m.builder.headers = req.Headers
+	m.builder.body = req.Body
+	if req.QueryParams != nil {
+		m.builder.queryParams = req.QueryParams
+	} else {
+		m.builder.queryParams = make(map[string]string)
+	}
+	m.state = StateRequestBuilder
+	m.builder.requestSaved = true
+	m.builder.currentRequestSavedID = req.ID
+	m.currentGraphQLOpID = ""
+	m.graphqlMode = isGraphQLBody(req.Body)
+	m.viewer.displayTransform = req.DisplayTransform
+	m.latencyBudget = req.LatencyBudgetMs
+	m.assertions.list = req.Assertions
+	m.hmacAuth = req.HMAC
+	m.requestAuth = req.Auth
+	m.requestPolicy = req.Policy
+
+	if m.storage != nil && !m.readOnly {
+		m.reportStorageError("failed to update request", m.storage.UpdateLastUsed(req.ID))
+	}
+}
+
+// newRequest empties the request builder for a new GET request
+func (m *Model) newRequest() {
+	m.builder.method = "GET"
+	m.builder.urlInput.SetValue("")
+	m.builder.headers = make(map[string]string)
+	m.builder.body = ""
+	m.currentGraphQLOpID = ""
+	m.graphqlMode = false
+	m.state = StateRequestBuilder
+}
+
+// loadRequest opens req in the request builder as a new, unsaved request
+func (m *Model) loadRequest(req storage.SavedRequest) {
+	m.loadExecution(storage.RequestExecution{
+		Method:      req.Method,
+		URL:         req.URL,
+		Headers:     req.Headers,
+		Body:        req.Body,
+		QueryParams: req.QueryParams,
+	})
+	m.builder.currentRequestSavedID = ""
+	m.builder.focusIndex = 1
+	m.builder.urlInput.Focus()
+}
+
+// editBody opens the body in the GraphQL editor in GraphQL mode, or in the
+// editor of its mode
+func (m *Model) editBody() {
+	if m.graphqlMode {
+		m.openGraphQLEditor()
+		return
+	}
+	m.builder.openBodyEditor(m)
+}
+
+// pasteCurl imports a curl command pasted into the URL, showing the import
+// screen with the error when it does not parse
+func (m *Model) pasteCurl(command string) {
+	m.curlImport.open(m)
+	m.curlImport.editor.SetValue(command)
+	m.curlImport.importCommand(m, command)
+}
+
+func (m *Model) editingGraphQL() bool { return m.graphqlMode }
+
+func (m *Model) openGraphQLEditor() { m.gqlEditor.open(m) }
+
+// setRequestBody replaces the body of the request being edited, which
+// then differs from the saved request
+func (m *Model) setRequestBody(body string) {
+	if body != m.builder.body {
+		m.builder.body = body
+		m.builder.requestSaved = false
+	}
+}
+
+// requestLine is the method and the URL as typed in the builder
+func (m *Model) requestLine() string {
+	return m.builder.method + " " + m.builder.urlInput.Value()
+}
+
+func (m *Model) graphQLBody() string { return m.builder.body }
+
+// setGraphQLBody replaces the body with a GraphQL request, sent as a JSON
+// POST and edited in GraphQL mode
+func (m *Model) setGraphQLBody(body string) {
+	m.builder.body = body
+	m.builder.method = "POST"
+	if m.builder.headers == nil {
+		m.builder.headers = make(map[string]string)
+	}
+	if _, ok := m.builder.headers["Content-Type"]; !ok {
+		m.builder.headers["Content-Type"] = "application/json"
+	}
+	m.builder.requestSaved = false
+	m.graphqlMode = true
+}
+
+func (m *Model) activeEnvironment() *storage.Environment { return m.envs.config.Active() }
+
+// requestBadges names what the request is sent with beyond what the builder
+// shows: auto-save, a new connection, auth, cookies, a proxy and the
+// environment
+func (m *Model) requestBadges() string {
+	var badges string
+	if m.autoSave {
+		badges += i18n.T("title.autosave")
+	}
+	if m.builder.newConnection {
+		badges += i18n.T("title.new_conn")
+	}
+	if m.requestAuth != nil {
+		badges += i18n.Tf("title.auth", authTypeLabel(m.requestAuth.Type))
+	}
+	if jar := m.httpClient.CookieJar(); jar != nil {
+		badges += i18n.Tf("title.cookie_jar", jar.Len())
+	}
+	if proxy := m.effectiveProxy().URL; proxy != "" {
+		badges += i18n.Tf("title.proxy", proxyHost(proxy))
+	}
+	if m.envs.config != nil && m.envs.config.ActiveEnvironment != "" {
+		badges += i18n.Tf("title.env", m.envs.config.ActiveEnvironment)
+	}
+	return badges
+}
+
+// viewRequestPrompts shows the prompts the builder asks in: the name of a
+// variant or a session and the path of a download
+func (m *Model) viewRequestPrompts() string {
+	return m.viewVariantSection() + m.viewSessionSection() + m.viewDownloadSection()
+}
+
+func (m *Model) connStats(host string) httpclient.HostConnStats {
+	return m.httpClient.ConnStats(host)
+}
+
+// clearResponseChecks drops the errors of the assertions and extractions
+// run on the response being left
+func (m *Model) clearResponseChecks() {
+	m.assertions.err = ""
+	m.extractions.err = ""
+}
+
+// viewResponseRequest shows what the response answered and the notices
+// about the request: the session being recorded, a save and a copied curl
+func (m *Model) viewResponseRequest() string {
+	var b strings.Builder
+	b.WriteString(MutedStyle.Render(fmt.Sprintf("%s %s", m.builder.method, m.builder.buildURLWithQueryParams())))
+	b.WriteString("\n\n")
+
+	if m.recording != nil {
+		b.WriteString(m.viewRecordingBanner())
+		b.WriteString("\n\n")
+	}
+
+	if m.saveSuccess {
+		b.WriteString(SuccessStyle.Render("✓ Request saved successfully!"))
+		b.WriteString("\n\n")
+	} else if m.autoSaveNotice != "" {
+		b.WriteString(SuccessStyle.Render(m.autoSaveNotice))
+		b.WriteString("\n\n")
+	}
+
+	if m.builder.curlCopySuccess {
+		b.WriteString(SuccessStyle.Render("✓ cURL command copied to clipboard!"))
+		b.WriteString("\n\n")
+	}
+	return b.String()
+}
+
+// viewResponseChecks shows how the assertions and extractions of the saved
+// request did on the response
+func (m *Model) viewResponseChecks() string {
+	return m.assertions.viewSummary(m) + m.viewExtractionSummary()
+}
+
+// saveBuiltRequest saves the request in the builder under its method and
+// URL, reporting whether it was saved; one of that name is kept as it is
+func (m *Model) saveBuiltRequest() bool {
+	name := fmt.Sprintf("%s %s", m.builder.method, m.builder.urlInput.Value())
+	if m.storage.RequestExists(name) {
+		return false
+	}
+	err := m.storage.SaveRequest(name, m.builder.method, m.builder.urlInput.Value(), m.builder.headers, m.builder.body, m.builder.queryParams)
+	if err != nil {
+		return false
+	}
+	m.requests.saved = m.storage.GetRequests()
+	m.saveSuccess = true
+	m.saveSuccessTimer = 3
+	return true
+}
+
+// savedRequestID returns the saved request loaded into the builder, or ""
+// once the request has changed
+func (m *Model) savedRequestID() string {
+	if !m.builder.requestSaved {
+		return ""
+	}
+	return m.builder.currentRequestSavedID
+}
+
+func (m *Model) reloadSavedRequests() { m.requests.saved = m.storage.GetRequests() }
+
+// saveExecution saves a history entry as a request under its method and
+// URL, reporting whether it was saved; one of that name is kept as it is
+func (m *Model) saveExecution(exec storage.RequestExecution) bool {
+	name := fmt.Sprintf("%s %s", exec.Method, exec.URL)
+	if m.storage.RequestExists(name) {
+		return false
+	}
+	queryParams := exec.QueryParams
+	if queryParams == nil {
+		queryParams = make(map[string]string)
+	}
+	if err := m.storage.SaveRequest(name, exec.Method, exec.URL, exec.Headers, exec.Body, queryParams); err != nil {
+		return false
+	}
+	m.requests.saved = m.storage.GetRequests()
+	return true
+}
+
+// A route is how the router reaches the screen shown in a state
+type route struct {
+	keys func(Model, tea.KeyMsg) (tea.Model, tea.Cmd)
+	view func(Model) string
+}
+
+// screenRoute routes a state to a screen; field picks the screen from the
+// model so that its changes are kept
+func screenRoute(field func(*Model) screen) route {
+	return route{
+		keys: func(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+			cmd := field(&m).Update(&m, msg)
+			return m, cmd
+		},
+		view: func(m Model) string {
+			return field(&m).View(&m)
+		},
+	}
+}
+
+// shortcuts are the keys of a screen that open another screen. Only the
+// program reaches both, so the router handles them before the screen.
+type shortcuts map[string]func(m *Model) tea.Cmd
+
+// A typist takes keys as text at times; its shortcuts are typed then
+type typist interface {
+	typing() bool
+}
+
+// shortcutRoute routes a state to a screen like screenRoute, opening other
+// screens on keys
+func shortcutRoute(field func(*Model) screen, keys shortcuts) route {
+	r := screenRoute(field)
+	next := r.keys
+	r.keys = func(m Model, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+		open, ok := keys[msg.String()]
+		if t, isTypist := field(&m).(typist); ok && !(isTypist && t.typing()) {
+			cmd := open(&m)
+			return m, cmd
+		}
+		return next(m, msg)
+	}
+	return r
+}
+
+func builderScreen(m *Model) screen { return &m.builder }
+
+// builderRoute reaches the request builder, whose shortcuts open the tools
+// that work on the request being edited
+var builderRoute = shortcutRoute(builderScreen, shortcuts{
+	"ctrl+r": func(m *Model) tea.Cmd {
+		m.history.open(m)
+		return nil
+	},
+	"G": func(m *Model) tea.Cmd {
+		m.toggleGraphQLMode()
+		return nil
+	},
+	"g": func(m *Model) tea.Cmd {
+		if m.builder.urlInput.Value() == "" {
+			return nil
+		}
+		return m.schemaBrowser.open(m, false)
+	},
+	"o": func(m *Model) tea.Cmd {
+		m.gqlOperations.open(m)
+		return nil
+	},
+	"u": func(m *Model) tea.Cmd {
+		m.bulk.open(m)
+		return nil
+	},
+	"v": func(m *Model) tea.Cmd {
+		if !m.blockedByReadOnly("save variant") {
+			m.startVariantNaming()
+		}
+		return nil
+	},
+	"a": func(m *Model) tea.Cmd {
+		m.openSigning()
+		return nil
+	},
+	"i": func(m *Model) tea.Cmd {
+		m.openURLInspector()
+		return nil
+	},
+	"c": func(m *Model) tea.Cmd {
+		m.curlImport.open(m)
+		return nil
+	},
+	"T": func(m *Model) tea.Cmd {
+		m.templates.open(m)
+		return nil
+	},
+	"f": func(m *Model) tea.Cmd {
+		m.startDownloadPrompt()
+		return nil
+	},
+	"A": func(m *Model) tea.Cmd {
+		m.openAuth()
+		return nil
+	},
+	"t": func(m *Model) tea.Cmd {
+		m.openRequestPolicy()
+		return nil
+	},
+	"d": func(m *Model) tea.Cmd {
+		m.dnsLookup.open(m, hostOf(m.buildRequest().URL), StateRequestBuilder)
+		return nil
+	},
+	"K": func(m *Model) tea.Cmd {
+		m.cookies.open(m)
+		return nil
+	},
+	"J": func(m *Model) tea.Cmd {
+		return m.jwt.open(m, m.requestJWTs(), i18n.T("jwt.none_in_request"), StateRequestBuilder)
+	},
+	"U": func(m *Model) tea.Cmd {
+		m.utilities.open(m, "", StateRequestBuilder)
+		return nil
+	},
+	"P": func(m *Model) tea.Cmd {
+		m.openProxySettings(StateRequestBuilder)
+		return nil
+	},
+	"L": func(m *Model) tea.Cmd {
+		m.openTLSSettings(StateRequestBuilder)
+		return nil
+	},
+	"R": func(m *Model) tea.Cmd {
+		if m.recording != nil || !m.blockedByReadOnly("record session") {
+			m.toggleSessionRecording()
+		}
+		return nil
+	},
+	"s": func(m *Model) tea.Cmd {
+		if m.blockedByReadOnly("save request") || m.storage == nil || m.builder.urlInput.Value() == "" {
+			return nil
+		}
+		m.saveBuiltRequest()
+		return nil
+	},
+})
+
+// responseRoute reaches the response viewer, whose shortcuts act on the
+// saved request or open the tools that work on the response
+var responseRoute = shortcutRoute(func(m *Model) screen { return &m.viewer }, shortcuts{
+	"s": func(m *Model) tea.Cmd {
+		if m.blockedByReadOnly("save request") || m.storage == nil || m.viewer.response == nil {
+			return nil
+		}
+		if m.saveBuiltRequest() && len(m.requests.saved) > 0 {
+			m.builder.requestSaved = true
+			m.builder.currentRequestSavedID = m.requests.saved[len(m.requests.saved)-1].ID
+			m.assertions.list = nil
+			if m.hmacAuth != nil {
+				m.reportStorageError("failed to save signing", m.storage.UpdateHMACAuth(m.builder.currentRequestSavedID, m.hmacAuth))
+			}
+			if m.requestAuth != nil {
+				m.reportStorageError("failed to save auth", m.storage.UpdateRequestAuth(m.builder.currentRequestSavedID, m.requestAuth))
+			}
+			if m.requestPolicy != nil {
+				m.reportStorageError("failed to save timeout and retries", m.storage.UpdateRequestPolicy(m.builder.currentRequestSavedID, m.requestPolicy))
+			}
+			m.acceptResponseSchema()
+			if m.viewer.displayTransform != "" {
+				m.setDisplayTransform(m.viewer.displayTransform)
+			}
+		}
+		return nil
+	},
+	"x": func(m *Model) tea.Cmd {
+		m.builder.copyCurl()
+		return nil
+	},
+	"A": func(m *Model) tea.Cmd {
+		if !m.blockedByReadOnly("accept response schema") && m.viewer.schemaDrift != nil {
+			m.acceptResponseSchema()
+		}
+		return nil
+	},
+	"b": func(m *Model) tea.Cmd {
+		if !m.blockedByReadOnly("set latency budget") {
+			m.startBudgetEdit()
+		}
+		return nil
+	},
+	"a": func(m *Model) tea.Cmd {
+		m.assertions.open(m)
+		return nil
+	},
+	"E": func(m *Model) tea.Cmd {
+		m.openExtractions()
+		return nil
+	},
+	"w": func(m *Model) tea.Cmd {
+		m.viewer.startSaveBody(m, m.builder.urlInput.Value())
+		return nil
+	},
+	"Q": func(m *Model) tea.Cmd {
+		m.openResponseScaffold()
+		return nil
+	},
+	"|": func(m *Model) tea.Cmd {
+		if m.viewer.response != nil && m.viewer.response.Error == nil {
+			m.split.pinOrOpen(m, m.responsePane(), StateViewResponse)
+		}
+		return nil
+	},
+	"G": func(m *Model) tea.Cmd {
+		if !m.blockedByReadOnly("pin golden response") {
+			m.pinGolden()
+		}
+		return nil
+	},
+	"i": func(m *Model) tea.Cmd {
+		if !m.blockedByReadOnly("set volatile fields") {
+			m.startVolatileEdit()
+		}
+		return nil
+	},
+	"p": func(m *Model) tea.Cmd {
+		m.duplicate.open(m, m.builder.method, m.builder.urlInput.Value())
+		return nil
+	},
+	"f": func(m *Model) tea.Cmd {
+		if m.viewer.response != nil && m.viewer.response.Error == nil {
+			m.pagination.open(m, m.builder.method, m.builder.urlInput.Value(), m.viewer.response)
+		}
+		return nil
+	},
+	"v": func(m *Model) tea.Cmd {
+		return m.togglePluginView()
+	},
+	"e": func(m *Model) tea.Cmd {
+		m.builder.startFixAndResend(m, m.viewer.response)
+		return nil
+	},
+	"J": func(m *Model) tea.Cmd {
+		return m.jwt.open(m, m.viewer.responseJWTs(0), i18n.T("jwt.none_in_response"), StateViewResponse)
+	},
+	"U": func(m *Model) tea.Cmd {
+		if m.viewer.response != nil && m.viewer.response.Error == nil {
+			m.utilities.open(m, m.viewer.response.Body, StateViewResponse)
+		}
+		return nil
+	},
+	"T": func(m *Model) tea.Cmd {
+		if m.viewer.response != nil && m.viewer.response.Error == nil {
+			m.timestamps.open(m, m.viewer.responseTimestamps(), StateViewResponse)
+		}
+		return nil
+	},
+})
+
+// editorsRoute reaches the editors of the query params, headers and body
+var editorsRoute = screenRoute(builderScreen)
+
+// environmentsRoute reaches the list and the editor of the environments
+var environmentsRoute = screenRoute(func(m *Model) screen { return &m.envs })
+
+func databaseScreen(m *Model) screen { return &m.db }
+
+// databaseRoute reaches the parts of the database explorer that lead
+// nowhere else
+var databaseRoute = screenRoute(databaseScreen)
+
+var databaseMenuRoute = shortcutRoute(databaseScreen, shortcuts{
+	"f": func(m *Model) tea.Cmd {
+		if m.db.connected() {
+			m.valueSearch.open(m, m.db.tables, "", StateDatabase)
+		}
+		return nil
+	},
+})
+
+var databaseEditorRoute = shortcutRoute(databaseScreen, shortcuts{
+	"ctrl+o": func(m *Model) tea.Cmd {
+		if m.db.snippetFill != nil {
+			m.db.finishSnippetFill()
+		}
+		m.querySnippets.open(m)
+		return nil
+	},
+})
+
+var databaseResultRoute = shortcutRoute(databaseScreen, shortcuts{
+	"a": func(m *Model) tea.Cmd {
+		d := &m.db
+		if d.table != nil && d.result != nil && len(d.result.Rows) > 0 {
+			index := d.table.SelectedIndex()
+			m.rowRequest.open(m, d.editor.Value(), d.result.Columns, d.result.Rows[index], index)
+		}
+		return nil
+	},
+	"T": func(m *Model) tea.Cmd {
+		if m.db.result != nil && len(m.db.result.Columns) > 0 {
+			m.timestamps.open(m, m.db.resultTimestamps(), StateDatabaseResult)
+		}
+		return nil
+	},
+	"|": func(m *Model) tea.Cmd {
+		if d := &m.db; d.result != nil && d.result.Error == nil && len(d.result.Columns) > 0 {
+			m.split.pinOrOpen(m, d.resultPane(), StateDatabaseResult)
+		}
+		return nil
+	},
+})
+
+var databaseSchemaRoute = shortcutRoute(databaseScreen, shortcuts{
+	"f": func(m *Model) tea.Cmd {
+		if d := &m.db; len(d.tables) > 0 && d.selectedTable < len(d.tables) {
+			m.valueSearch.open(m, d.tables, d.tables[d.selectedTable], StateDatabaseSchema)
+		}
+		return nil
+	},
+})
+
+var templatesRoute = screenRoute(func(m *Model) screen { return &m.templates })
+
+var collectionRunRoute = screenRoute(func(m *Model) screen { return &m.collectionRun })
+
+var rawSocketRoute = screenRoute(func(m *Model) screen { return &m.rawSocket })
+
+var dnsLookupRoute = screenRoute(func(m *Model) screen { return &m.dnsLookup })
+
+var cookiesRoute = screenRoute(func(m *Model) screen { return &m.cookies })
+
+var jwtRoute = screenRoute(func(m *Model) screen { return &m.jwt })
+
+var utilitiesRoute = screenRoute(func(m *Model) screen { return &m.utilities })
+
+var timestampsRoute = screenRoute(func(m *Model) screen { return &m.timestamps })
+
+var splitViewRoute = screenRoute(func(m *Model) screen { return &m.split })
+
+var querySnippetsRoute = screenRoute(func(m *Model) screen { return &m.querySnippets })
+
+var valueSearchRoute = screenRoute(func(m *Model) screen { return &m.valueSearch })
+
+var rowRequestRoute = screenRoute(func(m *Model) screen { return &m.rowRequest })
+
+var trashRoute = screenRoute(func(m *Model) screen { return &m.trash })
+
+var aliasesRoute = screenRoute(func(m *Model) screen { return &m.aliases })
+
+var workspacesRoute = screenRoute(func(m *Model) screen { return &m.workspaces })
+
+var bulkRunnerRoute = screenRoute(func(m *Model) screen { return &m.bulk })
+
+var paginationRoute = screenRoute(func(m *Model) screen { return &m.pagination })
+
+var duplicateCompareRoute = screenRoute(func(m *Model) screen { return &m.duplicate })
+
+var urlInspectorRoute = screenRoute(func(m *Model) screen { return &m.urlInspector })
+
+var curlImportRoute = screenRoute(func(m *Model) screen { return &m.curlImport })
+
+var schemaBrowserRoute = screenRoute(func(m *Model) screen { return &m.schemaBrowser })
+
+var gqlOperationsRoute = screenRoute(func(m *Model) screen { return &m.gqlOperations })
+
+var replayRoute = screenRoute(func(m *Model) screen { return &m.replay })
+
+var historyDiffRoute = screenRoute(func(m *Model) screen { return &m.historyDiff })
+
+var assertionsRoute = screenRoute(func(m *Model) screen { return &m.assertions })
+
+var onboardingRoute = screenRoute(func(m *Model) screen { return &m.onboarding })
+
+// requestListRoute reaches the saved requests, whose shortcuts open their
+// collections and run them all
+var requestListRoute = shortcutRoute(func(m *Model) screen { return &m.requests }, shortcuts{
+	"c": func(m *Model) tea.Cmd {
+		m.collections.open(m)
+		return nil
+	},
+	"r": func(m *Model) tea.Cmd {
+		return m.collectionRun.open(m, storage.Collection{Name: i18n.T("run.all_saved"), Requests: m.requests.saved}, StateRequestList)
+	},
+})
+
+// gqlEditorRoute reaches the GraphQL editor, whose shortcut browses the
+// schema of the endpoint
+var gqlEditorRoute = shortcutRoute(func(m *Model) screen { return &m.gqlEditor }, shortcuts{
+	"ctrl+g": func(m *Model) tea.Cmd {
+		// The schema browser inserts into the body, so the draft goes first
+		if m.builder.urlInput.Value() == "" || !m.gqlEditor.apply(m) {
+			return nil
+		}
+		return m.schemaBrowser.open(m, true)
+	},
+})
+
+// collectionsRoute reaches the collections, whose shortcut runs the one
+// open or selected
+var collectionsRoute = shortcutRoute(func(m *Model) screen { return &m.collections }, shortcuts{
+	"r": func(m *Model) tea.Cmd {
+		if c := m.collections.running(); c != nil {
+			return m.collectionRun.open(m, *c, StateCollections)
+		}
+		return nil
+	},
+})
+
+// historyRoute reaches the history and its bookmarks, whose shortcuts
+// replay and compare the entries
+var historyRoute = shortcutRoute(func(m *Model) screen { return &m.history }, shortcuts{
+	"r": func(m *Model) tea.Cmd {
+		if m.state == StateHistory {
+			m.replay.open(m, m.history.entries)
+		}
+		return nil
+	},
+	"D": func(m *Model) tea.Cmd {
+		if m.state != StateHistory {
+			return nil
+		}
+		if a, b, ok := m.history.diffPair(); ok {
+			m.history.diffErr = ""
+			m.historyDiff.open(m, a, b)
+		} else {
+			m.history.diffErr = i18n.T("history_diff.need_two")
+		}
+		return nil
+	},
+})
+
+// routes maps every state to its screen. Screens that still keep their
+// state in Model are reached through its handlers.
+var routes = map[AppState]route{
+	StateHome:                 {Model.handleHomeKeys, Model.viewHome},
+	StateRequestBuilder:       builderRoute,
+	StateLoading:              {Model.handleLoadingKeys, Model.viewLoading},
+	StateViewResponse:         responseRoute,
+	StateRequestList:          requestListRoute,
+	StateHeaderEditor:         editorsRoute,
+	StateBodyEditor:           editorsRoute,
+	StateQueryEditor:          editorsRoute,
+	StateHelp:                 {Model.handleHelpKeys, Model.viewHelp},
+	StateHistory:              historyRoute,
+	StateDatabase:             databaseMenuRoute,
+	StateDatabaseConnect:      databaseRoute,
+	StateDatabaseQueryEditor:  databaseEditorRoute,
+	StateDatabaseResult:       databaseResultRoute,
+	StateDatabaseQueryList:    databaseRoute,
+	StateDatabaseSchema:       databaseSchemaRoute,
+	StateDatabaseQueryHistory: databaseRoute,
+	StateDatabaseExport:       databaseRoute,
+	StateEnvironments:         environmentsRoute,
+	StateEnvironmentEditor:    environmentsRoute,
+	StateHistoryReplay:        replayRoute,
+	StateWorkspaces:           workspacesRoute,
+	StateDuplicateCompare:     duplicateCompareRoute,
+	StateGraphQLSchema:        schemaBrowserRoute,
+	StateGraphQLOperations:    gqlOperationsRoute,
+	StatePagination:           paginationRoute,
+	StateBulkRunner:           bulkRunnerRoute,
+	StateTrash:                trashRoute,
+	StateBookmarks:            historyRoute,
+	StateStorageUnavailable:   {Model.handleStorageUnavailableKeys, Model.viewStorageUnavailable},
+	StateAssertions:           assertionsRoute,
+	StateSigning:              {Model.handleSigningKeys, Model.viewSigning},
+	StateURLInspector:         urlInspectorRoute,
+	StateAliases:              aliasesRoute,
+	StateTour:                 onboardingRoute,
+	StateWhatsNew:             onboardingRoute,
+	StateCollections:          collectionsRoute,
+	StateStats:                {Model.handleStatsKeys, Model.viewStats},
+	StateCurlImport:           curlImportRoute,
+	StateGraphQLEditor:        gqlEditorRoute,
+	StateHistoryDiff:          historyDiffRoute,
+	StateTemplates:            templatesRoute,
+	StateCollectionRun:        collectionRunRoute,
+	StateRawSocket:            rawSocketRoute,
+	StateAuth:                 {Model.handleAuthKeys, Model.viewAuth},
+	StateDNSLookup:            dnsLookupRoute,
+	StateCookies:              cookiesRoute,
+	StateJWT:                  jwtRoute,
+	StateUtilities:            utilitiesRoute,
+	StateProxySettings:        {Model.handleProxyKeys, Model.viewProxySettings},
+	StateTLSSettings:          {Model.handleTLSKeys, Model.viewTLSSettings},
+	StateTimestamps:           timestampsRoute,
+	StateExtractions:          {Model.handleExtractionsKeys, Model.viewExtractions},
+	StateSplitView:            splitViewRoute,
+	StateRequestPolicy:        {Model.handleRequestPolicyKeys, Model.viewRequestPolicy},
+	StateQuerySnippets:        querySnippetsRoute,
+	StateDatabaseValueSearch:  valueSearchRoute,
+	StateDatabaseRowRequest:   rowRequestRoute,
+}
+
+func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "ctrl+t" && densityStates[m.state] {
+		m.toggleListDensity()
+		return m, nil
+	}
+	r, ok := routes[m.state]
+	if !ok {
+		return m, nil
+	}
+	next, cmd := r.keys(m, msg)
+	if updated, ok := next.(Model); ok && updated.state != m.state {
+		// Help closes on any key
+		back := msg.String() == "esc" || m.state == StateHelp
+		updated.followNavigation(m.state, back)
+		return updated, cmd
+	}
+	return next, cmd
+}
+
+func (m Model) viewState() string {
+	if r, ok := routes[m.state]; ok {
+		return r.view(m)
+	}
+	return ""
+}
+
+func (m Model) handleLoadingKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.cancelLoading()
+	}
+	return m, nil
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestEveryStateHasARoute(t *testing.T) {
+	for state := StateHome; state <= StateDatabaseRowRequest; state++ {
+		if _, ok := routes[state]; !ok {
+			t.Errorf("State %d has no route", state)
+		}
+	}
+}
+
+func TestFlowEnvironmentsScreen(t *testing.T) {
+	d := newFlowDriver(t)
+
+	// Off the URL field, ctrl+e opens the environments
+	d.Press("a", "tab", "ctrl+e").AssertView("No environments found")
+	d.Press("n").Type("dev").Press("ctrl+s")
+	d.Press("n").Type("API_URL").Press("tab").Type("https://dev.example.com").Press("enter")
+	d.AssertView("API_URL = https://dev.example.com")
+
+	d.Press("esc", "s").AssertView("dev ★", "(1 vars)")
+	m := d.Model().(Model)
+	if m.state != StateEnvironments || m.envs.config.Active() == nil {
+		t.Fatalf("Expected dev to be active, got state %v", m.state)
+	}
+	if vars, err := m.storage.GetActiveEnvironmentVariables(); err != nil || len(vars) != 1 || vars[0].Value != "https://dev.example.com" {
+		t.Errorf("Expected the variable to be saved, got %v (%v)", vars, err)
+	}
+
+	d.Press("esc")
+	if m := d.Model().(Model); m.state != StateRequestBuilder {
+		t.Errorf("Expected esc to return to the builder, got state %v", m.state)
+	}
+}
+
+func TestEnvironmentsKeepsEditsThroughTheRouter(t *testing.T) {
+	m := Model{state: StateEnvironmentEditor, envs: newEnvironments(), builder: RequestBuilder{urlInput: textinput.New()}}
+	m.envs.current = "dev"
+	key := func(name string) tea.KeyMsg {
+		msg, _ := tuitest.ParseKey(name)
+		return msg
+	}
+
+	updated, _ := m.handleKeyPress(key("n"))
+	m = updated.(Model)
+	if !m.envs.editingVar {
+		t.Fatal("Expected n to open the variable form")
+	}
+	updated, _ = m.handleKeyPress(key("esc"))
+	if m = updated.(Model); m.envs.editingVar || m.state != StateEnvironmentEditor {
+		t.Errorf("Expected esc to close the form only, got state %v", m.state)
+	}
+}
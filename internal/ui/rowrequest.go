@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/database"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// RowRequest builds the API request of a row of the query results from
+// the endpoint saved for its table, so a record can be fetched through the
+// API it was written by
+type RowRequest struct {
+	table    textinput.Model
+	endpoint textinput.Model
+	// loadedFor is the table the endpoint was filled in for
+	loadedFor string
+	columns   []string
+	row       []string
+	index     int
+	err       string
+}
+
+func newRowRequest() RowRequest {
+	table := textinput.New()
+	table.Placeholder = "users"
+	table.CharLimit = 100
+	table.Width = 30
+
+	endpoint := textinput.New()
+	endpoint.Placeholder = "GET {{API_URL}}/users/{id}"
+	endpoint.CharLimit = 500
+	endpoint.Width = 60
+	return RowRequest{table: table, endpoint: endpoint}
+}
+
+// open shows the request of the row at index of the results of query
+func (r *RowRequest) open(h host, query string, columns, row []string, index int) {
+	r.columns = columns
+	r.row = row
+	r.index = index
+	r.err = ""
+	r.table.SetValue(database.QueryTable(query))
+	r.loadEndpoint(h)
+	r.table.Blur()
+	r.endpoint.Focus()
+	r.endpoint.CursorEnd()
+	h.navigate(StateDatabaseRowRequest)
+}
+
+// loadEndpoint fills in the endpoint saved for the table, or one keyed by
+// the primary key of the table
+func (r *RowRequest) loadEndpoint(h host) {
+	table := strings.TrimSpace(r.table.Value())
+	r.loadedFor = table
+	if dbStorage := h.databaseExplorer().storage; dbStorage != nil && table != "" {
+		if saved := dbStorage.RowEndpoint(table); saved != "" {
+			r.endpoint.SetValue(saved)
+			return
+		}
+	}
+
+	var keys []string
+	if client := h.databaseExplorer().connectedClient(); client != nil && table != "" {
+		if metadata, err := client.GetTableMetadata(table); err == nil {
+			keys = metadata.PrimaryKeys
+		}
+	}
+	if len(keys) == 0 && len(r.columns) > 0 {
+		keys = []string{r.columns[0]}
+		for _, column := range r.columns {
+			if strings.EqualFold(column, "id") {
+				keys = []string{column}
+				break
+			}
+		}
+	}
+	r.endpoint.SetValue(database.DefaultRowEndpoint(table, keys))
+}
+
+// send opens the request of the row in the request builder and keeps the
+// endpoint for the next rows of the table
+func (r *RowRequest) send(h host) {
+	template := strings.TrimSpace(r.endpoint.Value())
+	method, url, err := database.FillRowEndpoint(template, r.columns, r.row)
+	if err != nil {
+		r.err = err.Error()
+		return
+	}
+
+	table := strings.TrimSpace(r.table.Value())
+	if dbStorage := h.databaseExplorer().storage; dbStorage != nil && table != "" && dbStorage.RowEndpoint(table) != template {
+		if !h.blockedByReadOnly("save row endpoint") {
+			if err := dbStorage.SaveRowEndpoint(table, template); err != nil {
+				h.reportStorageError("save row endpoint", err)
+			}
+		}
+	}
+	h.loadRequest(storage.SavedRequest{Method: method, URL: url})
+}
+
+func (r *RowRequest) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		h.navigate(StateDatabaseResult)
+		return nil
+
+	case "tab", "shift+tab":
+		if r.table.Focused() {
+			r.table.Blur()
+			if strings.TrimSpace(r.table.Value()) != r.loadedFor {
+				r.loadEndpoint(h)
+			}
+			r.endpoint.Focus()
+		} else {
+			r.endpoint.Blur()
+			r.table.Focus()
+		}
+		return nil
+
+	case "enter":
+		r.send(h)
+		return nil
+	}
+
+	r.err = ""
+	var cmd tea.Cmd
+	if r.table.Focused() {
+		r.table, cmd = r.table.Update(msg)
+	} else {
+		r.endpoint, cmd = r.endpoint.Update(msg)
+	}
+	return cmd
+}
+
+func (r *RowRequest) View(h host) string {
+	width, height := h.size()
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.Tf("title.row_request", r.index+1)))
+	b.WriteString("\n\n")
+	b.WriteString(viewLabeledInput(i18n.T("row_request.table"), r.table, r.table.Focused()))
+	b.WriteString(viewLabeledInput(i18n.T("row_request.endpoint"), r.endpoint, r.endpoint.Focused()))
+	b.WriteString(MutedStyle.Render(i18n.T("row_request.hint")))
+	b.WriteString("\n\n")
+
+	if r.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + r.err))
+	} else if method, url, err := database.FillRowEndpoint(r.endpoint.Value(), r.columns, r.row); err != nil {
+		b.WriteString(WarningStyle.Render("⚠ " + err.Error()))
+	} else {
+		b.WriteString(HeaderStyle.Render(i18n.T("row_request.preview")))
+		b.WriteString("\n")
+		b.WriteString(TextStyle.Render(truncateWidth(method+" "+url, max(width-8, 20), "…")))
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(RenderFooter(i18n.T("footer.row_request")))
+
+	return Center(width, height, b.String())
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/database"
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowRowRequestOpensSelectedRowInBuilder(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	m := NewModel()
+	m.state = StateDatabaseResult
+	m.db.editor.SetValue("SELECT id, email FROM users ORDER BY id")
+	m.db.result = &database.QueryResult{
+		Columns: []string{"id", "email"},
+		Rows:    [][]string{{"7", "ana@example.com"}, {"12", "bo@example.com"}},
+	}
+	m.db.table = NewBubblesTableWrapper(m.db.result.Columns, m.db.result.Rows, 120, 30)
+
+	d := tuitest.New(t, *m).Resize(160, 50)
+	d.Press("down", "a").AssertView("API Request for Row 2", "GET {{API_URL}}/users/{id}", "GET {{API_URL}}/users/12")
+
+	d.Press("ctrl+u").Type("PUT {{API_URL}}/accounts/{email}").AssertView("PUT {{API_URL}}/accounts/bo@example.com")
+	d.Press("enter")
+	got := d.Model().(Model)
+	if got.state != StateRequestBuilder {
+		t.Fatalf("State = %v, want the request builder", got.state)
+	}
+	if got.builder.method != "PUT" {
+		t.Errorf("Method = %s, want PUT", got.builder.method)
+	}
+	if url := got.builder.urlInput.Value(); url != "{{API_URL}}/accounts/bo@example.com" {
+		t.Errorf("URL = %q, want the account of the row", url)
+	}
+	if saved := got.db.storage.RowEndpoint("users"); saved != "PUT {{API_URL}}/accounts/{email}" {
+		t.Errorf("Saved endpoint = %q, want the edited one", saved)
+	}
+}
+
+func TestFlowRowRequestReportsMissingColumn(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	m := NewModel()
+	m.state = StateDatabaseResult
+	m.db.result = &database.QueryResult{
+		Columns: []string{"slug"},
+		Rows:    [][]string{{"hello"}},
+	}
+	m.db.table = NewBubblesTableWrapper(m.db.result.Columns, m.db.result.Rows, 120, 30)
+
+	d := tuitest.New(t, *m).Resize(160, 50)
+	d.Press("a").AssertView("GET {{API_URL}}/hello")
+	d.Press("ctrl+u").Type("/posts/{id}").AssertView("⚠ the row has no column id")
+	d.Press("enter").AssertView("✗ the row has no column id")
+	d.Press("esc").AssertView("Query Result")
+}
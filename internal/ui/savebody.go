@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// bodySaver holds the prompt that writes the response body to a file
+type bodySaver struct {
+	active bool
+	input  textinput.Model
+	// overwrite is the existing file Enter was pressed on once; pressing
+	// it again on the same path replaces the file
+	overwrite string
+	notice    string
+	err       string
+}
+
+// startSaveBody asks where to write the response body, suggesting a name
+// from the response and the URL
+func (v *ResponseViewer) startSaveBody(h host, url string) {
+	s := &v.saveBody
+	s.notice, s.err, s.overwrite = "", "", ""
+	if v.response == nil || v.response.Error != nil || v.response.Download != nil {
+		s.err = i18n.T("savebody.nothing")
+		return
+	}
+
+	width := h.screenLayout().InputWidth
+	if width <= 0 {
+		width = 60
+	}
+	s.input = textinput.New()
+	s.input.Placeholder = "~/Downloads/response.json"
+	s.input.CharLimit = 500
+	s.input.Width = width
+	s.input.SetValue(httpclient.SuggestFilename(v.response.Headers, url))
+	s.input.CursorEnd()
+	s.input.Focus()
+	s.active = true
+}
+
+// updateSaveBody handles input while choosing the file the body is
+// written to. An existing file is only replaced after a second Enter.
+func (v *ResponseViewer) updateSaveBody(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	s := &v.saveBody
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		s.active = false
+		s.overwrite = ""
+		s.input.Blur()
+		return nil
+
+	case "enter":
+		path := strings.TrimSpace(s.input.Value())
+		if path == "" {
+			s.err = i18n.T("savebody.empty_path")
+			return nil
+		}
+		if info, err := os.Stat(httpclient.ExpandHome(path)); err == nil && s.overwrite != path {
+			if info.IsDir() {
+				s.err = i18n.Tf("savebody.is_dir", path)
+				return nil
+			}
+			s.overwrite = path
+			s.err = ""
+			return nil
+		}
+
+		// A spooled body is copied from its file, as only its beginning
+		// is in memory
+		body, size := v.response.RawBody(), v.response.Size
+		save := func() error { return httpclient.SaveBody(path, body) }
+		if spool := v.response.Spooled; spool != nil {
+			save = func() error { return spool.SaveTo(path) }
+		} else {
+			size = int64(len(body))
+		}
+		if err := save(); err != nil {
+			s.err = err.Error()
+			s.overwrite = ""
+			return nil
+		}
+		s.active = false
+		s.overwrite = ""
+		s.err = ""
+		s.input.Blur()
+		s.notice = i18n.Tf("savebody.saved", httpclient.FormatSize(size), path)
+		return nil
+	}
+
+	s.input, cmd = s.input.Update(msg)
+	if s.overwrite != strings.TrimSpace(s.input.Value()) {
+		s.overwrite = ""
+	}
+	return cmd
+}
+
+// viewSaveBody renders the save prompt and what the last save did
+func (v *ResponseViewer) viewSaveBody() string {
+	var b strings.Builder
+	s := v.saveBody
+
+	if s.active {
+		b.WriteString(TextStyle.Render(i18n.T("savebody.path")))
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().
+			Border(roundedBorder()).
+			BorderForeground(lipgloss.Color(ColorAccent)).
+			Padding(0, 1).
+			Width(s.input.Width + 2).
+			Render(s.input.View()))
+		b.WriteString("\n")
+		if s.overwrite != "" {
+			b.WriteString(WarningStyle.Render(i18n.Tf("savebody.exists", s.overwrite)))
+		} else {
+			b.WriteString(MutedStyle.Render(i18n.T("savebody.hint")))
+		}
+		b.WriteString("\n\n")
+	} else if s.notice != "" {
+		b.WriteString(SuccessStyle.Render("✓ " + s.notice))
+		b.WriteString("\n\n")
+	}
+
+	if s.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + s.err))
+		b.WriteString("\n\n")
+	}
+	return b.String()
+}
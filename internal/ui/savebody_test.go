@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowSaveResponseBody(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="orders.csv"`)
+		w.Write([]byte("id,total\n1,9.90\n"))
+	}))
+	defer server.Close()
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL).Press("enter").WaitFor("200")
+
+	d.Press("w").AssertView("Save the response body to:", "orders.csv")
+
+	path := filepath.Join(t.TempDir(), "orders.csv")
+	if err := os.WriteFile(path, []byte("old"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	d.Press("ctrl+u").Type(path).Press("enter").AssertView("already exists")
+	if saved, _ := os.ReadFile(path); string(saved) != "old" {
+		t.Fatalf("Expected the first Enter to keep the existing file, got %q", saved)
+	}
+
+	d.Press("enter").AssertView("Saved 16 B to " + path)
+	if saved, _ := os.ReadFile(path); string(saved) != "id,total\n1,9.90\n" {
+		t.Errorf("Expected the body in the file, got %q", saved)
+	}
+}
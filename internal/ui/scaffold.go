@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"encoding/json"
+
+	"github.com/abneribeiro/godev/internal/database"
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// scaffoldBody returns the JSON the SQL scaffold is built from: the body as
+// shown, so a filter like .items picks the records. Several filter results
+// are taken as one array.
+func (v *ResponseViewer) scaffoldBody() string {
+	expr := v.activeTransform()
+	if v.viewRawResponse || expr == "" {
+		return v.response.Body
+	}
+	results, err := httpclient.EvalTransform(v.response.Body, expr)
+	if err != nil || len(results) == 0 {
+		return v.response.Body
+	}
+
+	var data interface{} = results
+	if len(results) == 1 {
+		data = results[0]
+	}
+	body, err := json.Marshal(data)
+	if err != nil {
+		return v.response.Body
+	}
+	return string(body)
+}
+
+// openResponseScaffold opens in the SQL editor a CREATE TABLE and INSERT
+// that load the JSON of the response into a scratch table named after the
+// URL
+func (m *Model) openResponseScaffold() {
+	m.viewer.scaffoldError = ""
+	if m.viewer.response == nil || m.viewer.response.Error != nil {
+		return
+	}
+	if m.viewer.showsSpooledBody() {
+		m.viewer.scaffoldError = i18n.T("scaffold.spooled")
+		return
+	}
+
+	table := database.ScaffoldTableName(m.builder.urlInput.Value())
+	scaffold, err := database.NewScaffold(table, m.viewer.scaffoldBody())
+	if err != nil {
+		m.viewer.scaffoldError = i18n.Tf("scaffold.failed", err.Error())
+		return
+	}
+	// A filter gives back the fields sorted by name
+	scaffold.OrderColumns(m.viewer.response.Body)
+	m.db.editQuery(m, scaffold.SQL())
+}
@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowResponseScaffoldOpensSQL(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/text" {
+			w.Write([]byte("plain text"))
+			return
+		}
+		w.Write([]byte(`{"data": {"users": [{"id": 1, "email": "a@example.com"}, {"id": 2, "email": "b@example.com"}]}}`))
+	}))
+	defer server.Close()
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL + "/text").Press("enter").WaitFor("plain text")
+	d.Press("Q").AssertView("✗ Cannot build a SQL table from the response: the body is not JSON")
+	d.Press("esc", "ctrl+u").Type(server.URL + "/v1/users").Press("enter").WaitFor(`"email"`)
+
+	d.Press("t").Type(".data.users").Press("enter", "Q")
+	got := d.Model().(Model)
+	if got.state != StateDatabaseQueryEditor {
+		t.Fatalf("State = %v, want the SQL editor", got.state)
+	}
+	sql := got.db.editor.Value()
+	for _, want := range []string{`CREATE TABLE "users"`, `"email" text`, `(1, 'a@example.com')`} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("SQL = %q, want %s", sql, want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"maps"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// sessionRecording is a session being recorded into a named flow
+type sessionRecording struct {
+	name    string
+	started time.Time
+	steps   []storage.SavedRequest
+}
+
+// toggleSessionRecording asks for a flow name to start recording, or saves
+// the flow when a session is already being recorded
+func (m *Model) toggleSessionRecording() {
+	m.sessionNotice = ""
+	if m.recording != nil {
+		m.stopSessionRecording()
+		return
+	}
+	if m.storage == nil {
+		m.sessionNotice = i18n.T("session.no_storage")
+		return
+	}
+
+	m.namingSession = true
+	m.sessionInput.SetValue("")
+	m.sessionInput.Focus()
+}
+
+// stopSessionRecording saves the recorded requests as a collection
+func (m *Model) stopSessionRecording() {
+	rec := m.recording
+	m.recording = nil
+
+	if len(rec.steps) == 0 {
+		m.sessionNotice = i18n.Tf("session.empty", rec.name)
+		return
+	}
+	collection, err := m.storage.SaveSessionFlow(rec.name, rec.steps)
+	if err != nil {
+		m.sessionNotice = err.Error()
+		return
+	}
+	m.sessionNotice = i18n.Tf("session.saved", collection.Name, len(collection.Requests), collection.Name)
+}
+
+// recordSessionStep adds the request just sent to the session being
+// recorded. Variables are kept as typed so the flow replays against any
+// environment.
+func (m *Model) recordSessionStep() {
+	if m.recording == nil {
+		return
+	}
+	m.recording.steps = append(m.recording.steps, storage.SavedRequest{
+		Method:      m.builder.method,
+		URL:         m.builder.urlInput.Value(),
+		Headers:     maps.Clone(m.builder.headers),
+		Body:        m.builder.body,
+		QueryParams: maps.Clone(m.builder.queryParams),
+	})
+}
+
+// handleSessionNameKeys handles input while naming a session to record
+func (m Model) handleSessionNameKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return m, tea.Quit
+
+	case "esc":
+		m.namingSession = false
+		m.sessionInput.Blur()
+		return m, nil
+
+	case "enter":
+		name := strings.TrimSpace(m.sessionInput.Value())
+		if name == "" {
+			m.sessionNotice = i18n.T("session.need_name")
+			return m, nil
+		}
+		if config, err := m.storage.LoadCollections(); err == nil && storage.FindCollectionByName(config.Collections, name) != nil {
+			m.sessionNotice = i18n.Tf("session.exists", name)
+			return m, nil
+		}
+
+		m.namingSession = false
+		m.sessionInput.Blur()
+		m.sessionNotice = ""
+		m.recording = &sessionRecording{name: name, started: time.Now()}
+		return m, nil
+	}
+
+	m.sessionInput, cmd = m.sessionInput.Update(msg)
+	return m, cmd
+}
+
+// viewSessionSection renders the session name input, the recording banner
+// and the last session notice
+func (m Model) viewSessionSection() string {
+	var b strings.Builder
+
+	if m.namingSession {
+		b.WriteString(TextStyle.Render(i18n.T("session.name")))
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().
+			Border(roundedBorder()).
+			BorderForeground(lipgloss.Color(ColorAccent)).
+			Padding(0, 1).
+			Width(m.sessionInput.Width + 2).
+			Render(m.sessionInput.View()))
+		b.WriteString("\n")
+		b.WriteString(MutedStyle.Render(i18n.T("session.name_hint")))
+		b.WriteString("\n")
+	}
+
+	if m.recording != nil {
+		b.WriteString(m.viewRecordingBanner())
+		b.WriteString("\n")
+	}
+
+	if m.sessionNotice != "" {
+		if strings.HasPrefix(m.sessionNotice, "✓") {
+			b.WriteString(SuccessStyle.Render(m.sessionNotice))
+		} else {
+			b.WriteString(WarningStyle.Render(m.sessionNotice))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// viewRecordingBanner shows which session is being recorded and how many
+// requests it holds so far
+func (m Model) viewRecordingBanner() string {
+	if m.recording == nil {
+		return ""
+	}
+	elapsed := time.Since(m.recording.started).Truncate(time.Second)
+	return ErrorStyle.Render(i18n.Tf("session.recording", m.recording.name, len(m.recording.steps), elapsed))
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+func TestRecordSessionSavesFlow(t *testing.T) {
+	s, err := storage.NewStorageAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	m := Model{storage: s, sessionInput: textinput.New(), builder: RequestBuilder{urlInput: textinput.New()}}
+
+	m.toggleSessionRecording()
+	if !m.namingSession {
+		t.Fatal("Expected the session name input")
+	}
+	m.sessionInput.SetValue("signup")
+	next, _ := m.handleSessionNameKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(Model)
+	if m.recording == nil || m.recording.name != "signup" {
+		t.Fatalf("Expected recording to start, got %+v (notice %q)", m.recording, m.sessionNotice)
+	}
+
+	m.builder.method = "POST"
+	m.builder.urlInput.SetValue("{{base_url}}/users")
+	m.builder.headers = map[string]string{"Content-Type": "application/json"}
+	m.builder.body = `{"name": "a"}`
+	m.recordSessionStep()
+	m.builder.headers["Content-Type"] = "text/plain"
+
+	m.builder.method = "GET"
+	m.builder.urlInput.SetValue("{{base_url}}/users/1")
+	m.builder.body = ""
+	m.recordSessionStep()
+
+	if banner := m.viewRecordingBanner(); !strings.Contains(banner, "signup") {
+		t.Errorf("Expected the banner to name the session, got %q", banner)
+	}
+
+	m.toggleSessionRecording()
+	if m.recording != nil || !strings.HasPrefix(m.sessionNotice, "✓") {
+		t.Fatalf("Expected recording to stop and save, got notice %q", m.sessionNotice)
+	}
+
+	config, err := s.LoadCollections()
+	if err != nil {
+		t.Fatalf("LoadCollections() error = %v", err)
+	}
+	flow := storage.FindCollectionByName(config.Collections, "signup")
+	if flow == nil || len(flow.Requests) != 2 {
+		t.Fatalf("Expected a collection with 2 steps, got %+v", flow)
+	}
+	if flow.Requests[0].Method != "POST" || flow.Requests[0].Headers["Content-Type"] != "application/json" {
+		t.Errorf("Unexpected first step: %+v", flow.Requests[0])
+	}
+
+	// The name of an existing collection is refused
+	m.toggleSessionRecording()
+	m.sessionInput.SetValue("signup")
+	next, _ = m.handleSessionNameKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(Model)
+	if m.recording != nil || m.sessionNotice == "" {
+		t.Errorf("Expected the name to be refused, got recording=%+v notice=%q", m.recording, m.sessionNotice)
+	}
+}
@@ -0,0 +1,338 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// Fields of the HMAC signing form, in focus order. Algorithm and encoding
+// are picked from a list; the others are typed.
+const (
+	signingFieldAlgorithm = iota
+	signingFieldKey
+	signingFieldTemplate
+	signingFieldHeader
+	signingFieldPrefix
+	signingFieldEncoding
+	signingFieldTimestamp
+	signingFieldCount
+)
+
+var signingFieldLabels = [signingFieldCount]string{
+	"Algorithm", "Key", "String to sign", "Header", "Prefix", "Encoding", "Timestamp header",
+}
+
+// signingForm holds the HMAC signing panel while it is open
+type signingForm struct {
+	inputs    [signingFieldCount]textinput.Model
+	algorithm string
+	encoding  string
+	focus     int
+	err       string
+	notice    string
+}
+
+// signer returns the HMAC signer of the request being edited, or nil when
+// it is not signed
+func (m Model) signer() *httpclient.HMACSigner {
+	if m.hmacAuth == nil {
+		return nil
+	}
+	signer := m.resolveSigner(*m.hmacAuth)
+	return &signer
+}
+
+// resolveSigner builds the signer for auth with its key resolved against
+// the active environment
+func (m Model) resolveSigner(auth storage.HMACAuth) httpclient.HMACSigner {
+	signer := httpclient.HMACSigner(auth)
+	signer.Key = m.resolveVariables(signer.Key)
+	return signer
+}
+
+// openSigning shows the HMAC signing form filled with the current settings
+func (m *Model) openSigning() {
+	auth := storage.HMACAuth{Template: httpclient.DefaultHMACTemplate, Prefix: "HMAC "}
+	if m.hmacAuth != nil {
+		auth = *m.hmacAuth
+	}
+
+	width := m.layout.InputWidth
+	if width <= 0 {
+		width = 60
+	}
+	placeholders := [signingFieldCount]string{
+		signingFieldKey:       "{{HMAC_SECRET}} or base64:...",
+		signingFieldTemplate:  httpclient.DefaultHMACTemplate,
+		signingFieldHeader:    "Authorization",
+		signingFieldPrefix:    "HMAC ",
+		signingFieldTimestamp: "X-Timestamp",
+	}
+	values := [signingFieldCount]string{
+		signingFieldKey:       auth.Key,
+		signingFieldTemplate:  auth.Template,
+		signingFieldHeader:    auth.Header,
+		signingFieldPrefix:    auth.Prefix,
+		signingFieldTimestamp: auth.TimestampHeader,
+	}
+	form := &m.signingForm
+	for i := range form.inputs {
+		input := textinput.New()
+		input.Placeholder = placeholders[i]
+		input.CharLimit = 512
+		input.Width = width
+		input.SetValue(values[i])
+		form.inputs[i] = input
+	}
+	form.inputs[signingFieldKey].EchoMode = textinput.EchoPassword
+
+	form.algorithm = auth.Algorithm
+	if form.algorithm == "" {
+		form.algorithm = httpclient.HMACAlgorithms[0]
+	}
+	form.encoding = auth.Encoding
+	if form.encoding == "" {
+		form.encoding = httpclient.HMACEncodings[0]
+	}
+
+	form.focus = signingFieldKey
+	form.inputs[form.focus].Focus()
+	form.err = ""
+	form.notice = ""
+	m.state = StateSigning
+}
+
+// signingDraft returns the settings as currently typed in the form
+func (m Model) signingDraft() storage.HMACAuth {
+	form := m.signingForm
+	return storage.HMACAuth{
+		Algorithm:       form.algorithm,
+		Key:             form.inputs[signingFieldKey].Value(),
+		Template:        form.inputs[signingFieldTemplate].Value(),
+		Header:          strings.TrimSpace(form.inputs[signingFieldHeader].Value()),
+		Prefix:          form.inputs[signingFieldPrefix].Value(),
+		Encoding:        form.encoding,
+		TimestampHeader: strings.TrimSpace(form.inputs[signingFieldTimestamp].Value()),
+	}
+}
+
+// saveSigning applies the signing settings to the request being edited and
+// stores them with the loaded saved request, if any. A nil auth removes
+// signing.
+func (m *Model) saveSigning(auth *storage.HMACAuth) {
+	form := &m.signingForm
+	if auth != nil {
+		if err := httpclient.HMACSigner(*auth).Validate(); err != nil {
+			form.err = err.Error()
+			return
+		}
+	}
+
+	if m.storage != nil && m.builder.requestSaved && m.builder.currentRequestSavedID != "" {
+		if m.blockedByReadOnly("save signing") {
+			return
+		}
+		if err := m.storage.UpdateHMACAuth(m.builder.currentRequestSavedID, auth); err != nil {
+			form.err = err.Error()
+			return
+		}
+		m.requests.saved = m.storage.GetRequests()
+	}
+
+	m.hmacAuth = auth
+	form.err = ""
+	if auth == nil {
+		form.notice = i18n.T("signing.removed")
+	} else {
+		form.notice = i18n.T("signing.saved")
+	}
+}
+
+func (m *Model) focusSigningField(field int) {
+	form := &m.signingForm
+	form.inputs[form.focus].Blur()
+	form.focus = (field + signingFieldCount) % signingFieldCount
+	form.inputs[form.focus].Focus()
+}
+
+// cycleOption moves through options from current by delta, wrapping around
+func cycleOption(options []string, current string, delta int) string {
+	for i, option := range options {
+		if option == current {
+			return options[(i+delta+len(options))%len(options)]
+		}
+	}
+	return options[0]
+}
+
+func (m Model) handleSigningKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	form := &m.signingForm
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return m, tea.Quit
+
+	case "esc":
+		form.inputs[form.focus].Blur()
+		m.state = StateRequestBuilder
+		return m, nil
+
+	case "tab", "down":
+		m.focusSigningField(form.focus + 1)
+		return m, nil
+
+	case "shift+tab", "up":
+		m.focusSigningField(form.focus - 1)
+		return m, nil
+
+	case "ctrl+s", "enter":
+		draft := m.signingDraft()
+		m.saveSigning(&draft)
+		return m, nil
+
+	case "ctrl+d":
+		m.saveSigning(nil)
+		return m, nil
+
+	case "left", "right":
+		delta := 1
+		if msg.String() == "left" {
+			delta = -1
+		}
+		switch form.focus {
+		case signingFieldAlgorithm:
+			form.algorithm = cycleOption(httpclient.HMACAlgorithms, form.algorithm, delta)
+			return m, nil
+		case signingFieldEncoding:
+			form.encoding = cycleOption(httpclient.HMACEncodings, form.encoding, delta)
+			return m, nil
+		}
+	}
+
+	if form.focus == signingFieldAlgorithm || form.focus == signingFieldEncoding {
+		return m, nil
+	}
+	form.inputs[form.focus], cmd = form.inputs[form.focus].Update(msg)
+	return m, cmd
+}
+
+func (m Model) viewSigning() string {
+	form := m.signingForm
+	var b strings.Builder
+
+	title := i18n.T("title.signing")
+	if m.hmacAuth != nil {
+		title += " • " + i18n.T("signing.active")
+	}
+	b.WriteString(TitleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	for field := 0; field < signingFieldCount; field++ {
+		label := fmt.Sprintf("%-17s", signingFieldLabels[field]+":")
+		focused := field == form.focus
+
+		labelStyle := MutedStyle
+		if focused {
+			labelStyle = TextStyle
+		}
+		b.WriteString(labelStyle.Render(label))
+
+		switch field {
+		case signingFieldAlgorithm, signingFieldEncoding:
+			value := form.algorithm
+			if field == signingFieldEncoding {
+				value = form.encoding
+			}
+			if focused {
+				b.WriteString(ButtonActive.Render("◂ " + value + " ▸"))
+			} else {
+				b.WriteString(TextStyle.Render(value))
+			}
+		default:
+			border := ColorBorder
+			if focused {
+				border = ColorAccent
+			}
+			b.WriteString("\n")
+			b.WriteString(lipgloss.NewStyle().
+				Border(roundedBorder()).
+				BorderForeground(lipgloss.Color(border)).
+				Padding(0, 1).
+				Width(form.inputs[field].Width + 2).
+				Render(form.inputs[field].View()))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(MutedStyle.Render(i18n.Tf("signing.placeholders", strings.Join(httpclient.HMACPlaceholders, " "))))
+	b.WriteString("\n\n")
+
+	b.WriteString(m.viewSigningPreview())
+
+	if form.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + form.err))
+		b.WriteString("\n")
+	} else if form.notice != "" {
+		b.WriteString(SuccessStyle.Render(form.notice))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(RenderFooter(i18n.T("footer.signing")))
+
+	return Center(m.width, m.height, b.String())
+}
+
+// viewSigningPreview shows the exact string that would be signed for the
+// request being edited and the signature it yields right now
+func (m Model) viewSigningPreview() string {
+	var b strings.Builder
+
+	b.WriteString(TextStyle.Render(i18n.T("signing.preview")))
+	b.WriteString("\n")
+
+	draft := m.signingDraft()
+	req, preview, err := m.resolveSigner(draft).Sign(m.buildRequest(), time.Now())
+	if err != nil {
+		b.WriteString(WarningStyle.Render("⚠ " + err.Error()))
+		b.WriteString("\n\n")
+		return b.String()
+	}
+
+	// Line ends are marked so trailing spaces and empty lines are visible
+	lines := strings.Split(preview.StringToSign, "\n")
+	for i, line := range lines {
+		if i < len(lines)-1 {
+			line += MutedStyle.Render("↵")
+		}
+		lines[i] = line
+	}
+	b.WriteString(lipgloss.NewStyle().
+		Border(roundedBorder()).
+		BorderForeground(lipgloss.Color(ColorBorder)).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n")))
+	b.WriteString("\n")
+
+	sentAs := i18n.T("signing.sent_as")
+	b.WriteString(MutedStyle.Render(i18n.T("signing.signature")) + TextStyle.Render(preview.Signature))
+	b.WriteString("\n")
+	b.WriteString(MutedStyle.Render(sentAs) + TextStyle.Render(preview.Header+": "+preview.HeaderValue))
+	b.WriteString("\n")
+	if draft.TimestampHeader != "" {
+		b.WriteString(MutedStyle.Render(strings.Repeat(" ", lipgloss.Width(sentAs))) + TextStyle.Render(draft.TimestampHeader+": "+req.Headers[draft.TimestampHeader]))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+func TestSigningFormSavesToLoadedRequest(t *testing.T) {
+	s, err := storage.NewStorageAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	if err := s.SaveRequest("orders", "POST", "https://api.example.com/orders", nil, `{"id": 1}`, nil); err != nil {
+		t.Fatalf("SaveRequest() error = %v", err)
+	}
+	saved := s.GetRequests()[0]
+
+	m := Model{
+		storage: s,
+		builder: RequestBuilder{
+			urlInput:              textinput.New(),
+			method:                "POST",
+			body:                  saved.Body,
+			requestSaved:          true,
+			currentRequestSavedID: saved.ID,
+		},
+	}
+	m.builder.urlInput.SetValue(saved.URL)
+
+	m.openSigning()
+	if m.state != StateSigning || m.signingForm.focus != signingFieldKey {
+		t.Fatalf("Expected the form to open on the key field, got state %v focus %d", m.state, m.signingForm.focus)
+	}
+
+	// An empty key is refused
+	next, _ := m.handleSigningKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(Model)
+	if m.hmacAuth != nil || m.signingForm.err == "" {
+		t.Fatalf("Expected an empty key to be refused, got %+v", m.hmacAuth)
+	}
+
+	m.signingForm.inputs[signingFieldKey].SetValue("secret")
+	m.signingForm.focus = signingFieldAlgorithm
+	next, _ = m.handleSigningKeys(tea.KeyMsg{Type: tea.KeyRight})
+	m = next.(Model)
+	if m.signingForm.algorithm != "sha512" {
+		t.Errorf("Expected right to pick the next algorithm, got %s", m.signingForm.algorithm)
+	}
+
+	view := m.viewSigningPreview()
+	if !strings.Contains(view, "/orders") || !strings.Contains(view, "Authorization: HMAC ") {
+		t.Errorf("Expected the preview to show the string to sign and header, got:\n%s", view)
+	}
+
+	next, _ = m.handleSigningKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(Model)
+	if m.hmacAuth == nil || m.hmacAuth.Key != "secret" || m.hmacAuth.Algorithm != "sha512" {
+		t.Fatalf("Expected signing to be saved, got %+v (error %q)", m.hmacAuth, m.signingForm.err)
+	}
+	stored, err := s.GetRequest(saved.ID)
+	if err != nil {
+		t.Fatalf("GetRequest() error = %v", err)
+	}
+	if stored.HMAC == nil || stored.HMAC.Template == "" {
+		t.Errorf("Expected signing to be stored with the request, got %+v", stored.HMAC)
+	}
+
+	signer := m.signer()
+	req, _, err := signer.Sign(m.buildRequest(), time.Now())
+	if err != nil || !strings.HasPrefix(req.Headers["Authorization"], "HMAC ") {
+		t.Errorf("Expected sends to be signed, got %v (%v)", req.Headers, err)
+	}
+
+	next, _ = m.handleSigningKeys(tea.KeyMsg{Type: tea.KeyCtrlD})
+	m = next.(Model)
+	if m.hmacAuth != nil || m.signer() != nil {
+		t.Error("Expected Ctrl+D to remove signing")
+	}
+}
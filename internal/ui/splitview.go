@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/database"
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// Kinds of panes; a response is only put next to a response and a query
+// result next to a query result
+const (
+	paneResponse = "response"
+	paneQuery    = "query"
+)
+
+// splitPane is one side of the split view
+type splitPane struct {
+	kind     string
+	title    string
+	subtitle string
+	lines    []string
+}
+
+// SplitView shows two responses or two query results side by side,
+// scrolling together. One is pinned first and stays pinned while other
+// requests or queries run, so it can be put next to each of them.
+type SplitView struct {
+	pinned *splitPane
+	right  splitPane
+	scroll int
+	back   AppState
+}
+
+// pinOrOpen pins pane when nothing of its kind is pinned, and otherwise
+// opens the split view with the pinned pane on the left and pane on the
+// right, returning to back on Esc
+func (sv *SplitView) pinOrOpen(h host, pane splitPane, back AppState) {
+	if sv.pinned == nil || sv.pinned.kind != pane.kind {
+		sv.pinned = &pane
+		return
+	}
+	sv.right = pane
+	sv.scroll = 0
+	sv.back = back
+	h.navigate(StateSplitView)
+}
+
+// pinnedKind returns the kind of the pinned pane, "" when none is pinned
+func (sv SplitView) pinnedKind() string {
+	if sv.pinned == nil {
+		return ""
+	}
+	return sv.pinned.kind
+}
+
+// viewPinned tells, on the screens panes of kind come from, what is pinned
+// and how to compare with it; "" when nothing of kind is pinned
+func (sv SplitView) viewPinned(kind string) string {
+	if sv.pinnedKind() != kind {
+		return ""
+	}
+	return MutedStyle.Render(i18n.Tf("split.pinned", sv.pinned.title))
+}
+
+func (sv *SplitView) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	_, height := h.size()
+	page := max(splitVisibleLines(height)-1, 1)
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		h.navigate(sv.back)
+
+	case "up", "k":
+		sv.scroll--
+	case "down", "j":
+		sv.scroll++
+	case "pgup":
+		sv.scroll -= page
+	case "pgdown", " ":
+		sv.scroll += page
+	case "home", "g":
+		sv.scroll = 0
+	case "end", "G":
+		sv.scroll = sv.maxScroll(height)
+
+	case "s":
+		right := sv.right
+		sv.right = *sv.pinned
+		sv.pinned = &right
+
+	case "x":
+		sv.pinned = nil
+		h.navigate(sv.back)
+		return nil
+	}
+
+	sv.scroll = max(min(sv.scroll, sv.maxScroll(height)), 0)
+	return nil
+}
+
+// splitVisibleLines is how many lines of each pane fit on the screen
+func splitVisibleLines(height int) int {
+	return max(height-12, 5)
+}
+
+func (sv SplitView) maxScroll(height int) int {
+	longest := len(sv.right.lines)
+	if sv.pinned != nil {
+		longest = max(longest, len(sv.pinned.lines))
+	}
+	return max(longest-splitVisibleLines(height), 0)
+}
+
+func (sv *SplitView) View(h host) string {
+	width, height := h.size()
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.split")))
+	b.WriteString("\n\n")
+	if sv.pinned == nil {
+		return Center(width, height, b.String())
+	}
+	left, right := *sv.pinned, sv.right
+
+	paneWidth := max((width-7)/2, 20)
+	row := func(l, r string) string {
+		l, r = strings.ReplaceAll(l, "\t", "    "), strings.ReplaceAll(r, "\t", "    ")
+		return padRightWidth(truncateWidth(l, paneWidth, "…"), paneWidth) + " │ " + padRightWidth(truncateWidth(r, paneWidth, "…"), paneWidth)
+	}
+
+	b.WriteString(HeaderStyle.Render(row(i18n.T("split.pinned_side")+" "+left.title, right.title)))
+	b.WriteString("\n")
+	b.WriteString(MutedStyle.Render(row(left.subtitle, right.subtitle)))
+	b.WriteString("\n")
+	b.WriteString(MutedStyle.Render(strings.Repeat("─", paneWidth) + "─┼─" + strings.Repeat("─", paneWidth)))
+	b.WriteString("\n")
+
+	visible := splitVisibleLines(height)
+	total := max(len(left.lines), len(right.lines))
+	end := min(sv.scroll+visible, total)
+	for i := sv.scroll; i < end; i++ {
+		var l, r string
+		if i < len(left.lines) {
+			l = left.lines[i]
+		}
+		if i < len(right.lines) {
+			r = right.lines[i]
+		}
+		// Lines that differ stand out, as the diff view would show them
+		if l != r {
+			b.WriteString(WarningStyle.Render(row(l, r)))
+		} else {
+			b.WriteString(TextStyle.Render(row(l, r)))
+		}
+		b.WriteString("\n")
+	}
+
+	if total > visible {
+		b.WriteString("\n")
+		b.WriteString(MutedStyle.Render(i18n.Tf("split.lines", sv.scroll+1, end, total)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(RenderFooter(i18n.T("footer.split")))
+
+	return Center(width, height, b.String())
+}
+
+// responsePane captures the response for the split view, its body as the
+// response view shows it
+func (m Model) responsePane() splitPane {
+	pane := splitPane{
+		kind:  paneResponse,
+		title: fmt.Sprintf("%s %s", m.builder.method, m.builder.buildURLWithQueryParams()),
+		subtitle: fmt.Sprintf("%s • %s • %s", m.viewer.response.Status,
+			httpclient.FormatDuration(m.viewer.response.ResponseTime), httpclient.FormatSize(m.viewer.response.Size)),
+	}
+	body, _, _ := m.viewer.responseDisplayBody()
+	if m.viewer.responseIsBinary() {
+		body = m.viewer.viewBinarySummary()
+	}
+	pane.lines = strings.Split(body, "\n")
+	return pane
+}
+
+// resultPane captures the query result for the split view as a plain
+// table, one row per line
+func (d *DatabaseExplorer) resultPane() splitPane {
+	result := d.result
+	query := strings.Join(strings.Fields(d.editor.Value()), " ")
+	return splitPane{
+		kind:     paneQuery,
+		title:    query,
+		subtitle: i18n.Tf("split.rows", len(result.Rows), result.ExecutionTime.Milliseconds()),
+		lines:    queryResultLines(result),
+	}
+}
+
+// queryResultLines lays out the columns and rows of result in aligned
+// columns
+func queryResultLines(result *database.QueryResult) []string {
+	widths := make([]int, len(result.Columns))
+	for i, column := range result.Columns {
+		widths[i] = displayWidth(column)
+	}
+	for _, row := range result.Rows {
+		for i, cell := range row {
+			if i < len(widths) {
+				widths[i] = max(widths[i], displayWidth(cell))
+			}
+		}
+	}
+
+	format := func(cells []string) string {
+		parts := make([]string, len(widths))
+		for i := range widths {
+			var cell string
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			parts[i] = padRightWidth(cell, widths[i])
+		}
+		return strings.TrimRight(strings.Join(parts, "  "), " ")
+	}
+
+	lines := []string{format(result.Columns)}
+	for _, row := range result.Rows {
+		lines = append(lines, format(row))
+	}
+	return lines
+}
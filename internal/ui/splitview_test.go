@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/database"
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowCompareResponsesSideBySide(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("region " + r.URL.Path[1:] + "\nstatus ok\n"))
+	}))
+	defer server.Close()
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL + "/eu").Press("enter").WaitFor("200")
+	d.Press("|").AssertView("compare side by side with the pinned GET " + server.URL + "/eu")
+
+	d.Press("esc", "ctrl+u").Type(server.URL + "/us").Press("enter").WaitFor("region us")
+	d.Press("|").AssertView("Side by Side", "Pinned: GET "+server.URL+"/eu", "region eu", "region us", "status ok")
+	if state := d.Model().(Model).state; state != StateSplitView {
+		t.Fatalf("Expected the split view, got state %v", state)
+	}
+
+	d.Press("s").AssertView("Pinned: GET " + server.URL + "/us")
+	d.Press("esc")
+	if state := d.Model().(Model).state; state != StateViewResponse {
+		t.Errorf("Expected Esc to return to the response, got state %v", state)
+	}
+}
+
+func TestQueryResultLines(t *testing.T) {
+	result := &database.QueryResult{
+		Columns: []string{"id", "name"},
+		Rows:    [][]string{{"1", "Ana"}, {"20", "Bruno"}},
+	}
+	want := []string{"id  name", "1   Ana", "20  Bruno"}
+	if got := queryResultLines(result); !reflect.DeepEqual(got, want) {
+		t.Errorf("queryResultLines() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,53 @@
+package ui
+
+import "github.com/abneribeiro/godev/internal/i18n"
+
+// showsSpooledBody reports whether the response view pages the body from
+// the file it was spooled to. A display transform still works on the
+// beginning kept in memory.
+func (v *ResponseViewer) showsSpooledBody() bool {
+	if v.response == nil || v.response.Spooled == nil || v.response.Error != nil {
+		return false
+	}
+	if v.viewResponseHeaders || v.viewResponseTiming || v.viewSchemaDrift || v.viewGoldenDiff || v.pluginViewName != "" || v.responseIsBinary() {
+		return false
+	}
+	return v.activeTransform() == "" || v.viewRawResponse
+}
+
+// responsePageSize is how many lines of the body the response view shows
+func (v *ResponseViewer) responsePageSize(h host) int {
+	_, height := h.size()
+	return max(height-17, 1)
+}
+
+// clampSpooledScroll keeps the scroll of a spooled body on its last page,
+// as it is read from disk rather than split in the view
+func (v *ResponseViewer) clampSpooledScroll(h host) {
+	if !v.showsSpooledBody() {
+		return
+	}
+	last := max(v.response.Spooled.Lines()-v.responsePageSize(h), 0)
+	v.scrollOffset = min(v.scrollOffset, last)
+}
+
+// spooledPage reads the lines of the spooled body the response view
+// shows, with the line they start at and the number of lines in the body
+func (v *ResponseViewer) spooledPage(maxLines int) (lines []string, start, total int) {
+	spool := v.response.Spooled
+	total = spool.Lines()
+	start = min(v.scrollOffset, max(total-maxLines, 0))
+	lines, err := spool.ReadLines(start, maxLines)
+	if err != nil {
+		return []string{ErrorStyle.Render(i18n.Tf("spool.read_failed", err))}, start, total
+	}
+	return lines, start, total
+}
+
+// dropSpooledBody deletes the file the body of the response was spooled
+// to, once the response is left
+func (v *ResponseViewer) dropSpooledBody() {
+	if v.response != nil && v.response.Spooled != nil {
+		v.response.Spooled.Remove()
+	}
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowLargeResponseIsPagedFromDisk(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+
+	defer func(threshold int64) { httpclient.SpoolThreshold = threshold }(httpclient.SpoolThreshold)
+	httpclient.SpoolThreshold = 64 * 1024
+
+	// Lines of 16 bytes, enough of them to go past the threshold
+	lines := int(httpclient.SpoolThreshold/16) + 1000
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < lines; i++ {
+			fmt.Fprintf(w, "line %010d\n", i)
+		}
+	}))
+	defer server.Close()
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL).Press("enter")
+	d.WaitFor("Large response (79.62 KB) paged from a temporary file", "line 0000000000")
+
+	m := d.Model().(Model)
+	spool := m.viewer.response.Spooled
+	if spool == nil {
+		t.Fatal("Expected the body to be spooled")
+	}
+	page := m.viewer.responsePageSize(&m)
+	d.Press("pgdown").AssertView(fmt.Sprintf("line %010d", page))
+	for i := 0; i < 3; i++ {
+		d.Press("down")
+	}
+	if got := d.Model().(Model).viewer.scrollOffset; got != page+3 {
+		t.Errorf("scrollOffset = %d after a page and 3 lines", got)
+	}
+
+	d.Press("esc")
+	if _, err := os.Stat(spool.Path); !os.IsNotExist(err) {
+		t.Errorf("Expected the spool file to be deleted on leaving the response, got %v", err)
+	}
+}
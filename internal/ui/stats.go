@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// maxStatsEndpoints bounds the most used endpoints shown
+const maxStatsEndpoints = 10
+
+// usageReport is what the stats screen shows, computed when it opens from
+// the local history only
+type usageReport struct {
+	requests      storage.UsageStats
+	queries       int
+	failedQueries int
+	avgQueryMs    int64
+	dataDir       string
+	diskBytes     int64
+	diskErr       error
+}
+
+// openStats computes the usage statistics and shows them
+func (m *Model) openStats() {
+	report := &usageReport{}
+
+	if m.storage != nil {
+		var aliases []storage.ServiceAlias
+		if env := m.envs.config.Active(); env != nil {
+			aliases = env.Aliases
+		}
+		report.requests = storage.ComputeUsageStats(m.storage.GetHistory(), aliases)
+	}
+
+	if m.db.storage != nil {
+		var total int64
+		for _, exec := range m.db.storage.GetQueryHistory() {
+			report.queries++
+			if exec.Error != "" {
+				report.failedQueries++
+				continue
+			}
+			total += exec.ExecutionTime
+		}
+		if answered := report.queries - report.failedQueries; answered > 0 {
+			report.avgQueryMs = total / int64(answered)
+		}
+	}
+
+	report.dataDir, report.diskErr = paths.DataDir()
+	if report.diskErr == nil {
+		report.diskBytes, report.diskErr = storage.DiskUsage(report.dataDir)
+	}
+
+	m.usage = report
+	m.state = StateStats
+}
+
+func (m Model) handleStatsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return m, tea.Quit
+
+	case "esc", "q":
+		m.state = StateHome
+		return m, nil
+
+	case "r":
+		m.openStats()
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m Model) viewStats() string {
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.stats")))
+	b.WriteString("\n")
+	b.WriteString(MutedStyle.Render(i18n.T("stats.local_only")))
+	b.WriteString("\n\n")
+
+	report := m.usage
+	if report == nil {
+		report = &usageReport{}
+	}
+	requests := report.requests
+
+	b.WriteString(HeaderStyle.Render(i18n.T("stats.activity")))
+	b.WriteString("\n")
+	if requests.Requests > 0 {
+		b.WriteString(TextStyle.Render(i18n.Tf("stats.requests", requests.Requests, requests.Failed, requests.Since.Format("2006-01-02"))))
+	} else {
+		b.WriteString(MutedStyle.Render(i18n.T("stats.no_requests")))
+	}
+	b.WriteString("\n")
+	b.WriteString(TextStyle.Render(i18n.Tf("stats.queries", report.queries, report.failedQueries, report.avgQueryMs)))
+	b.WriteString("\n\n")
+
+	if len(requests.Endpoints) > 0 {
+		b.WriteString(HeaderStyle.Render(i18n.T("stats.endpoints")))
+		b.WriteString("\n")
+		for i, endpoint := range requests.Endpoints {
+			if i == maxStatsEndpoints {
+				break
+			}
+			b.WriteString(TextStyle.Render(fmt.Sprintf("  %4d×  %-7s %s", endpoint.Count, endpoint.Method, endpoint.Endpoint)))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+
+		b.WriteString(HeaderStyle.Render(i18n.T("stats.services")))
+		b.WriteString("\n")
+		for _, service := range requests.Services {
+			line := fmt.Sprintf("  %s  %s", padRightWidth(service.Host, 30), i18n.Tf("stats.service", service.Requests, service.AvgLatencyMs))
+			if service.Failed > 0 {
+				line += "  " + i18n.Tf("stats.service_failed", service.Failed)
+			}
+			b.WriteString(TextStyle.Render(line))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(HeaderStyle.Render(i18n.T("stats.storage")))
+	b.WriteString("\n")
+	if report.diskErr != nil {
+		b.WriteString(ErrorStyle.Render("✗ " + report.diskErr.Error()))
+	} else {
+		b.WriteString(TextStyle.Render(i18n.Tf("stats.disk", httpclient.FormatSize(report.diskBytes), report.dataDir)))
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(RenderFooter(i18n.T("footer.stats")))
+
+	return Center(m.width, m.height, b.String())
+}
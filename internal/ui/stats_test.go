@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+func TestStatsScreen(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(paths.HomeEnv, dir)
+	store, err := storage.NewStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	for _, id := range []string{"1", "2", "3"} {
+		exec := storage.RequestExecution{Method: "GET", URL: "https://api.example.com/orders/" + id, StatusCode: 200, ResponseTime: 120}
+		if err := store.AddExecution(exec); err != nil {
+			t.Fatalf("AddExecution() error = %v", err)
+		}
+	}
+
+	m := Model{storage: store, width: 120, height: 40}
+	m.openStats()
+	if m.state != StateStats {
+		t.Fatalf("Expected the stats screen, got state %v", m.state)
+	}
+
+	view := m.viewStats()
+	for _, want := range []string{"3 HTTP requests in history (0 failed)", "GET     api.example.com/orders/:id", "3 requests • avg 120ms", "Nothing is sent anywhere"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Stats view does not contain %q:\n%s", want, view)
+		}
+	}
+	if m.usage.diskErr != nil || m.usage.diskBytes == 0 {
+		t.Errorf("Expected the size of the data directory, got %d, %v", m.usage.diskBytes, m.usage.diskErr)
+	}
+}
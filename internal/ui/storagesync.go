@@ -0,0 +1,86 @@
+package ui
+
+import "github.com/abneribeiro/godev/internal/storage"
+
+// SetHistoryWriter moves history writes to a background writer, for the
+// current storage and any storage opened later
+func (m *Model) SetHistoryWriter(opts storage.HistoryWriterOptions) {
+	m.historyWriter = &opts
+	if m.storage != nil {
+		m.storage.StartHistoryWriter(opts)
+	}
+}
+
+// Close writes the history still queued by the background writer, and
+// deletes the file a large response body was spooled to
+func (m Model) Close() error {
+	m.viewer.dropSpooledBody()
+	if m.storage == nil {
+		return nil
+	}
+	return m.storage.Close()
+}
+
+// syncExternalChanges reloads the lists on screen when another godev
+// instance wrote to the stores, keeping the selections in range. It runs on
+// every tick, and only rereads a store when its file changed on disk.
+func (m *Model) syncExternalChanges() {
+	if m.storage != nil {
+		m.reportStorageError("failed to record history", m.storage.HistoryWriteErr())
+
+		changed, err := m.storage.Refresh()
+		m.reportStorageError("failed to reload saved requests", err)
+		if changed {
+			m.reloadRequestLists()
+		}
+
+		// The environment editor works on its own copy until it saves
+		if m.state != StateEnvironmentEditor && m.storage.EnvironmentsChanged() {
+			if m.envs.reload(m.storage) == nil {
+				m.envs.selected = clampIndex(m.envs.selected, len(m.envs.list))
+			}
+		}
+	}
+
+	if m.db.storage != nil {
+		changed, err := m.db.storage.Refresh()
+		m.reportStorageError("failed to reload saved queries", err)
+		if changed {
+			m.db.savedQueries = m.db.storage.GetQueries()
+			m.db.selectedQuery = clampIndex(m.db.selectedQuery, len(m.db.savedQueries))
+		}
+	}
+}
+
+func (m *Model) reloadRequestLists() {
+	m.requests.saved = m.storage.GetRequests()
+	if m.requests.filtered != nil {
+		if m.requests.search.Value() != "" {
+			m.requests.filtered = m.storage.FilterRequests(m.requests.search.Value())
+		} else {
+			m.requests.filtered = m.requests.saved
+		}
+	}
+	displayList := m.requests.saved
+	if m.requests.filtered != nil {
+		displayList = m.requests.filtered
+	}
+	m.requests.selected = clampIndex(m.requests.selected, len(displayList))
+
+	m.history.refresh(m)
+	m.history.selected = clampIndex(m.history.selected, len(m.history.entries))
+	if m.state == StateBookmarks {
+		m.history.reloadBookmarks(m)
+	}
+}
+
+// clampIndex keeps a list selection within a list of n items
+func clampIndex(idx, n int) int {
+	if idx >= n {
+		idx = n - 1
+	}
+	if idx < 0 {
+		idx = 0
+	}
+	return idx
+}
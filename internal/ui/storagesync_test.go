@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+func TestEnvironmentsChangedBetweenSendAndTickAreReloaded(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	other, err := storage.NewStorageAt(dir)
+	if err != nil {
+		t.Fatalf("NewStorageAt() error = %v", err)
+	}
+	if err := store.AddEnvironment("dev"); err != nil {
+		t.Fatalf("AddEnvironment() error = %v", err)
+	}
+
+	m := Model{storage: store, builder: RequestBuilder{urlInput: textinput.New(), method: "GET"}}
+	if err := m.envs.reload(store); err != nil {
+		t.Fatalf("reload() error = %v", err)
+	}
+
+	if err := other.AddEnvironment("staging"); err != nil {
+		t.Fatalf("AddEnvironment() error = %v", err)
+	}
+	// Sending reads the environments before the next tick looks for changes
+	m.builder.urlInput.SetValue("https://example.com")
+	m.buildRequest()
+
+	updated, _ := m.Update(tickMsg(time.Now()))
+	if got := len(updated.(Model).envs.list); got != 2 {
+		t.Errorf("Expected the environment added by the other instance to be listed, got %d environments", got)
+	}
+}
@@ -1,8 +1,13 @@
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"strings"
 
-const (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme colors, changed by ApplyTheme
+var (
 	ColorBg      = "#0D0D0D"
 	ColorPanel   = "#1A1A1A"
 	ColorBorder  = "#2D2D2D"
@@ -17,7 +22,14 @@ const (
 	Color3xx     = "#FFA726"
 	Color4xx     = "#FF5722"
 	Color5xx     = "#D32F2F"
+	ColorGET     = "#00C853"
+	ColorPOST    = "#FFD600"
+	ColorPUT     = "#2979FF"
+	ColorPATCH   = "#AA00FF"
+	ColorDELETE  = "#D32F2F"
+)
 
+const (
 	// Responsive breakpoints
 	BreakpointSmall  = 80   // Small terminal (80x24)
 	BreakpointMedium = 120  // Medium terminal
@@ -131,100 +143,143 @@ func (lc LayoutConfig) GetPaginationSize() int {
 }
 
 var (
+	TitleStyle             lipgloss.Style
+	TextStyle              lipgloss.Style
+	MutedStyle             lipgloss.Style
+	DimStyle               lipgloss.Style
+	PanelStyle             lipgloss.Style
+	ButtonActive           lipgloss.Style
+	ButtonInactive         lipgloss.Style
+	InputStyle             lipgloss.Style
+	InputFocused           lipgloss.Style
+	StatusSuccessStyle     lipgloss.Style
+	StatusRedirectStyle    lipgloss.Style
+	StatusClientErrorStyle lipgloss.Style
+	StatusServerErrorStyle lipgloss.Style
+	MethodBadgeStyle       lipgloss.Style
+	StatusPillStyle        lipgloss.Style
+	ErrorStyle             lipgloss.Style
+	SuccessStyle           lipgloss.Style
+	WarningStyle           lipgloss.Style
+	FooterStyle            lipgloss.Style
+	HeaderStyle            lipgloss.Style
+	ListItemStyle          lipgloss.Style
+	ListItemSelectedStyle  lipgloss.Style
+	SpinnerStyle           lipgloss.Style
+	CodeStyle              lipgloss.Style
+)
+
+func init() {
+	buildStyles()
+}
+
+// buildStyles creates the shared styles from the theme colors
+func buildStyles() {
 	TitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color(ColorAccent)).
-			MarginBottom(1)
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccent)).
+		MarginBottom(1)
 
 	TextStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorText))
+		Foreground(lipgloss.Color(ColorText))
 
 	MutedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorMuted))
+		Foreground(lipgloss.Color(ColorMuted))
 
 	DimStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorDim))
+		Foreground(lipgloss.Color(ColorDim))
 
 	PanelStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color(ColorBorder)).
-			Padding(1, 2).
-			Background(lipgloss.Color(ColorPanel))
+		Border(roundedBorder()).
+		BorderForeground(lipgloss.Color(ColorBorder)).
+		Padding(1, 2).
+		Background(lipgloss.Color(ColorPanel))
 
 	ButtonActive = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorBg)).
-			Background(lipgloss.Color(ColorAccent)).
-			Padding(0, 2).
-			Bold(true)
+		Foreground(lipgloss.Color(ColorBg)).
+		Background(lipgloss.Color(ColorAccent)).
+		Padding(0, 2).
+		Bold(true)
 
 	ButtonInactive = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorText)).
-			Padding(0, 2)
+		Foreground(lipgloss.Color(ColorText)).
+		Padding(0, 2)
 
 	InputStyle = lipgloss.NewStyle().
-			Border(lipgloss.NormalBorder()).
-			BorderForeground(lipgloss.Color(ColorBorder)).
-			Padding(0, 1)
+		Border(normalBorder()).
+		BorderForeground(lipgloss.Color(ColorBorder)).
+		Padding(0, 1)
 
 	InputFocused = lipgloss.NewStyle().
-			Border(lipgloss.NormalBorder()).
-			BorderForeground(lipgloss.Color(ColorAccent)).
-			Padding(0, 1)
+		Border(normalBorder()).
+		BorderForeground(lipgloss.Color(ColorAccent)).
+		Padding(0, 1)
 
 	StatusSuccessStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color(Color2xx)).
-				Bold(true)
+		Foreground(lipgloss.Color(Color2xx)).
+		Bold(true)
 
 	StatusRedirectStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color(Color3xx)).
-				Bold(true)
+		Foreground(lipgloss.Color(Color3xx)).
+		Bold(true)
 
 	StatusClientErrorStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color(Color4xx)).
-				Bold(true)
+		Foreground(lipgloss.Color(Color4xx)).
+		Bold(true)
 
 	StatusServerErrorStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color(Color5xx)).
-				Bold(true)
+		Foreground(lipgloss.Color(Color5xx)).
+		Bold(true)
+
+	MethodBadgeStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorBg)).
+		Width(methodBadgeWidth).
+		Padding(0, 1).
+		Bold(true)
+
+	StatusPillStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(ColorBg)).
+		Padding(0, 1).
+		Bold(true)
 
 	ErrorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorError)).
-			Bold(true)
+		Foreground(lipgloss.Color(ColorError)).
+		Bold(true)
 
 	SuccessStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorSuccess)).
-			Bold(true)
+		Foreground(lipgloss.Color(ColorSuccess)).
+		Bold(true)
 
 	WarningStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorWarning)).
-			Bold(true)
+		Foreground(lipgloss.Color(ColorWarning)).
+		Bold(true)
 
 	FooterStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorMuted)).
-			MarginTop(1)
+		Foreground(lipgloss.Color(ColorMuted)).
+		MarginTop(1)
 
 	HeaderStyle = lipgloss.NewStyle().
-			Background(lipgloss.Color(ColorPanel)).
-			Foreground(lipgloss.Color(ColorAccent)).
-			Padding(0, 1).
-			Bold(true)
+		Background(lipgloss.Color(ColorPanel)).
+		Foreground(lipgloss.Color(ColorAccent)).
+		Padding(0, 1).
+		Bold(true)
 
 	ListItemStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorText)).
-			PaddingLeft(2)
+		Foreground(lipgloss.Color(ColorText)).
+		PaddingLeft(2)
 
 	ListItemSelectedStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color(ColorAccent)).
-				PaddingLeft(0).
-				Bold(true)
+		Foreground(lipgloss.Color(ColorAccent)).
+		PaddingLeft(0).
+		Bold(true)
 
 	SpinnerStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorAccent))
+		Foreground(lipgloss.Color(ColorAccent))
 
 	CodeStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color(ColorText)).
-			Background(lipgloss.Color(ColorBg))
-)
+		Foreground(lipgloss.Color(ColorText)).
+		Background(lipgloss.Color(ColorBg))
+}
 
 // Responsive style functions
 func GetResponsivePanelStyle(layout LayoutConfig) lipgloss.Style {
@@ -278,6 +333,69 @@ func GetStatusStyle(statusCode int) lipgloss.Style {
 	}
 }
 
+// methodBadgeWidth fits the longest method, OPTIONS, so badges line up in
+// lists
+const methodBadgeWidth = 9
+
+// methodColor is the badge color of an HTTP method. Methods without a color
+// of their own, like HEAD and OPTIONS, are muted.
+func methodColor(method string) string {
+	switch method {
+	case "GET":
+		return ColorGET
+	case "POST":
+		return ColorPOST
+	case "PUT":
+		return ColorPUT
+	case "PATCH":
+		return ColorPATCH
+	case "DELETE":
+		return ColorDELETE
+	default:
+		return ColorMuted
+	}
+}
+
+// RenderMethodBadge renders an HTTP method as a badge colored by method
+func RenderMethodBadge(method string) string {
+	method = strings.ToUpper(method)
+	return MethodBadgeStyle.Background(lipgloss.Color(methodColor(method))).Render(method)
+}
+
+// statusColor is the pill color of a status code. No code means the request
+// failed without a response.
+func statusColor(statusCode int) string {
+	switch {
+	case statusCode >= 200 && statusCode < 300:
+		return Color2xx
+	case statusCode >= 300 && statusCode < 400:
+		return Color3xx
+	case statusCode >= 400 && statusCode < 500:
+		return Color4xx
+	case statusCode >= 500:
+		return Color5xx
+	case statusCode > 0:
+		return ColorMuted
+	default:
+		return ColorError
+	}
+}
+
+// RenderStatusPill renders text, like "200 OK", as a pill colored by the
+// class of the status code
+func RenderStatusPill(statusCode int, text string) string {
+	return StatusPillStyle.Background(lipgloss.Color(statusColor(statusCode))).Render(text)
+}
+
+// renderRequestItem renders a line of a request list: the method badge first,
+// so the methods of a long list line up, then the label
+func renderRequestItem(method, label string, selected bool) string {
+	if selected {
+		return ListItemSelectedStyle.Render("> ") + RenderMethodBadge(method) + " " + ListItemSelectedStyle.Render(label)
+	}
+	return "  " + RenderMethodBadge(method) + " " + TextStyle.Render(label)
+}
+
 func RenderButton(text string, active bool) string {
 	if active {
 		return ButtonActive.Render("[ " + text + " ]")
@@ -290,7 +408,7 @@ func RenderPanel(title, content string) string {
 		lipgloss.Left,
 		TitleStyle.Render(title),
 		lipgloss.NewStyle().
-			BorderStyle(lipgloss.NormalBorder()).
+			BorderStyle(normalBorder()).
 			BorderTop(true).
 			BorderForeground(lipgloss.Color(ColorBorder)).
 			Render(""),
@@ -307,7 +425,7 @@ func RenderResponsivePanel(title, content string, layout LayoutConfig) string {
 		lipgloss.Left,
 		titleStyle.Render(title),
 		lipgloss.NewStyle().
-			BorderStyle(lipgloss.NormalBorder()).
+			BorderStyle(normalBorder()).
 			BorderTop(true).
 			BorderForeground(lipgloss.Color(ColorBorder)).
 			Render(""),
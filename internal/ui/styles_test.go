@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestRenderMethodBadge(t *testing.T) {
+	for _, method := range []string{"GET", "DELETE", "OPTIONS", "patch"} {
+		badge := RenderMethodBadge(method)
+		if lipgloss.Width(badge) != methodBadgeWidth {
+			t.Errorf("Expected %s badge to be %d wide, got %q", method, methodBadgeWidth, badge)
+		}
+		if !strings.Contains(badge, strings.ToUpper(method)) {
+			t.Errorf("Expected badge to show %s, got %q", strings.ToUpper(method), badge)
+		}
+	}
+
+	tests := []struct {
+		method string
+		want   string
+	}{
+		{"GET", ColorGET},
+		{"POST", ColorPOST},
+		{"PUT", ColorPUT},
+		{"PATCH", ColorPATCH},
+		{"DELETE", ColorDELETE},
+		{"HEAD", ColorMuted},
+	}
+	for _, tt := range tests {
+		if got := methodColor(tt.method); got != tt.want {
+			t.Errorf("methodColor(%s) = %s, want %s", tt.method, got, tt.want)
+		}
+	}
+}
+
+func TestStatusColor(t *testing.T) {
+	tests := []struct {
+		code int
+		want string
+	}{
+		{200, Color2xx},
+		{304, Color3xx},
+		{404, Color4xx},
+		{503, Color5xx},
+		{101, ColorMuted},
+		{0, ColorError},
+	}
+	for _, tt := range tests {
+		if got := statusColor(tt.code); got != tt.want {
+			t.Errorf("statusColor(%d) = %s, want %s", tt.code, got, tt.want)
+		}
+	}
+}
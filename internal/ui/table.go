@@ -3,7 +3,6 @@ package ui
 import (
 	"fmt"
 	"strings"
-	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/lipgloss"
@@ -114,14 +113,14 @@ func calculateTableColumns(columns []string, rows [][]string, maxWidth int) []ta
 
 	// Start with header lengths
 	for i, col := range columns {
-		columnWidths[i] = utf8.RuneCountInString(col)
+		columnWidths[i] = displayWidth(col)
 	}
 
 	// Check all row data
 	for _, row := range rows {
 		for i, cell := range row {
 			if i < len(columnWidths) {
-				cellLen := utf8.RuneCountInString(cell)
+				cellLen := displayWidth(cell)
 				if cellLen > columnWidths[i] {
 					columnWidths[i] = cellLen
 				}
@@ -191,7 +190,7 @@ func getPageRows(allRows []table.Row, page, pageSize int) []table.Row {
 func getTableStyles() table.Styles {
 	s := table.DefaultStyles()
 	s.Header = s.Header.
-		BorderStyle(lipgloss.NormalBorder()).
+		BorderStyle(normalBorder()).
 		BorderForeground(lipgloss.Color(ColorBorder)).
 		BorderBottom(true).
 		Bold(true).
@@ -229,12 +228,32 @@ func (btw *BubblesTableWrapper) PrevPage() {
 func (btw *BubblesTableWrapper) updateDisplayRows() {
 	displayRows := getPageRows(btw.allRows, btw.currentPage, btw.pageSize)
 	btw.table.SetRows(displayRows)
+	btw.table.SetCursor(btw.table.Cursor())
 
 	// Update table height based on number of rows
 	newHeight := min(len(displayRows)+2, btw.height-4)
 	btw.table.SetHeight(newHeight)
 }
 
+// MoveCursor moves the selected row by delta, turning the page when it
+// leaves the current one
+func (btw *BubblesTableWrapper) MoveCursor(delta int) {
+	if len(btw.allRows) == 0 {
+		return
+	}
+	index := max(0, min(btw.SelectedIndex()+delta, len(btw.allRows)-1))
+	if page := index / btw.pageSize; page != btw.currentPage {
+		btw.currentPage = page
+		btw.updateDisplayRows()
+	}
+	btw.table.SetCursor(index - btw.currentPage*btw.pageSize)
+}
+
+// SelectedIndex returns the index of the selected row among all rows
+func (btw *BubblesTableWrapper) SelectedIndex() int {
+	return btw.currentPage*btw.pageSize + btw.table.Cursor()
+}
+
 // Render returns the rendered table
 func (btw *BubblesTableWrapper) Render() string {
 	return btw.table.View()
@@ -451,13 +470,13 @@ func (t *TableRenderer) calculateColumnWidths() {
 	t.columnWidths = make([]int, len(t.columns))
 
 	for i, col := range t.columns {
-		t.columnWidths[i] = utf8.RuneCountInString(col)
+		t.columnWidths[i] = displayWidth(col)
 	}
 
 	for _, row := range t.rows {
 		for i, cell := range row {
 			if i < len(t.columnWidths) {
-				cellLen := utf8.RuneCountInString(cell)
+				cellLen := displayWidth(cell)
 				if cellLen > t.columnWidths[i] {
 					t.columnWidths[i] = cellLen
 				}
@@ -476,30 +495,15 @@ func (t *TableRenderer) calculateColumnWidths() {
 }
 
 func (t *TableRenderer) truncate(s string, width int) string {
-	runes := []rune(s)
-	if len(runes) <= width {
-		return s
-	}
-	if width <= 3 {
-		return "..."
-	}
-	return string(runes[:width-3]) + "..."
+	return truncateWidth(s, width, "...")
 }
 
 func (t *TableRenderer) padRight(s string, width int) string {
-	current := utf8.RuneCountInString(s)
-	if current >= width {
-		return s
-	}
-	return s + strings.Repeat(" ", width-current)
+	return padRightWidth(s, width)
 }
 
 func (t *TableRenderer) padLeft(s string, width int) string {
-	current := utf8.RuneCountInString(s)
-	if current >= width {
-		return s
-	}
-	return strings.Repeat(" ", width-current) + s
+	return padLeftWidth(s, width)
 }
 
 func (t *TableRenderer) isNumeric(s string) bool {
@@ -0,0 +1,231 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// Templates is the templates screen: the built-in request templates grouped
+// by category, and a form to fill in the variables of the chosen one
+type Templates struct {
+	templates []storage.RequestTemplate
+	selected  int
+
+	// filling is set while the variable form is open
+	filling bool
+	inputs  []textinput.Model
+	focus   int
+}
+
+func newTemplates() Templates {
+	templates := storage.GetBuiltInTemplates()
+	sort.SliceStable(templates, func(i, j int) bool {
+		return templates[i].Category < templates[j].Category
+	})
+	return Templates{templates: templates}
+}
+
+// open shows the list from the top
+func (t *Templates) open(h host) {
+	t.selected = 0
+	t.filling = false
+	h.navigate(StateTemplates)
+}
+
+func (t *Templates) current() storage.RequestTemplate {
+	return t.templates[t.selected]
+}
+
+// openForm asks for the variables of the selected template
+func (t *Templates) openForm(width int) {
+	tmpl := t.current()
+	t.inputs = make([]textinput.Model, len(tmpl.Variables))
+	for i, name := range tmpl.Variables {
+		input := textinput.New()
+		input.Placeholder = "{{" + name + "}}"
+		input.CharLimit = 500
+		input.Width = width
+		t.inputs[i] = input
+	}
+	t.focus = 0
+	if len(t.inputs) > 0 {
+		t.inputs[0].Focus()
+	}
+	t.filling = true
+}
+
+func (t *Templates) focusInput(i int) {
+	t.inputs[t.focus].Blur()
+	t.focus = (i + len(t.inputs)) % len(t.inputs)
+	t.inputs[t.focus].Focus()
+}
+
+// apply loads the template into the request builder. Variables left empty
+// keep their {{NAME}} placeholder, so the active environment fills them.
+func (t *Templates) apply(h host) {
+	tmpl := t.current()
+	values := make(map[string]string)
+	for i, name := range tmpl.Variables {
+		if value := strings.TrimSpace(t.inputs[i].Value()); value != "" {
+			values[name] = value
+		}
+	}
+	t.filling = false
+	h.loadRequest(storage.ApplyTemplate(tmpl, values))
+}
+
+func (t *Templates) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	if t.filling {
+		return t.updateForm(h, msg)
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc", "q":
+		h.navigate(StateRequestBuilder)
+
+	case "up", "k":
+		if t.selected > 0 {
+			t.selected--
+		}
+
+	case "down", "j":
+		if t.selected < len(t.templates)-1 {
+			t.selected++
+		}
+
+	case "enter":
+		if len(t.templates) == 0 {
+			return nil
+		}
+		if len(t.current().Variables) == 0 {
+			t.apply(h)
+			return nil
+		}
+		width, _ := h.size()
+		t.openForm(min(max(width/2, 30), 60))
+	}
+
+	return nil
+}
+
+func (t *Templates) updateForm(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		t.filling = false
+		return nil
+
+	case "tab", "down":
+		t.focusInput(t.focus + 1)
+		return nil
+
+	case "shift+tab", "up":
+		t.focusInput(t.focus - 1)
+		return nil
+
+	case "ctrl+s":
+		t.apply(h)
+		return nil
+
+	case "enter":
+		if t.focus < len(t.inputs)-1 {
+			t.focusInput(t.focus + 1)
+			return nil
+		}
+		t.apply(h)
+		return nil
+	}
+
+	t.inputs[t.focus], cmd = t.inputs[t.focus].Update(msg)
+	return cmd
+}
+
+func (t *Templates) View(h host) string {
+	width, height := h.size()
+	if t.filling {
+		return Center(width, height, t.viewForm())
+	}
+	return Center(width, height, t.viewList())
+}
+
+func (t *Templates) viewList() string {
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.Tf("title.templates", len(t.templates))))
+	b.WriteString("\n\n")
+
+	category := ""
+	for i, tmpl := range t.templates {
+		if tmpl.Category != category {
+			if category != "" {
+				b.WriteString("\n")
+			}
+			category = tmpl.Category
+			b.WriteString(HeaderStyle.Render(category))
+			b.WriteString("\n")
+		}
+		line := fmt.Sprintf("%-7s %s", tmpl.Method, tmpl.Name)
+		if i == t.selected {
+			b.WriteString(ListItemSelectedStyle.Render("> " + line))
+		} else {
+			b.WriteString(ListItemStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(t.templates) > 0 {
+		tmpl := t.current()
+		b.WriteString("\n")
+		b.WriteString(TextStyle.Render(tmpl.Description))
+		b.WriteString("\n")
+		b.WriteString(MutedStyle.Render(tmpl.Method + " " + tmpl.URL))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(RenderFooter(i18n.T("footer.templates")))
+
+	return b.String()
+}
+
+func (t *Templates) viewForm() string {
+	var b strings.Builder
+
+	tmpl := t.current()
+	b.WriteString(TitleStyle.Render(tmpl.Name))
+	b.WriteString("\n")
+	b.WriteString(MutedStyle.Render(tmpl.Method + " " + tmpl.URL))
+	b.WriteString("\n\n")
+
+	for i, name := range tmpl.Variables {
+		if i == t.focus {
+			b.WriteString(TextStyle.Render(name))
+		} else {
+			b.WriteString(MutedStyle.Render(name))
+		}
+		b.WriteString("\n")
+		b.WriteString(inputBox(t.inputs[i], i == t.focus))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(MutedStyle.Render(i18n.T("templates.empty_hint")))
+	b.WriteString("\n\n")
+	b.WriteString(RenderFooter(i18n.T("footer.template_form")))
+
+	return b.String()
+}
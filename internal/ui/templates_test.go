@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFlowApplyTemplate(t *testing.T) {
+	d := newFlowDriver(t)
+	d.Press("a", "tab", "T").AssertView("Request Templates", "REST - POST Request", "GraphQL")
+
+	m := d.Model().(Model)
+	for m.templates.current().ID != "rest-post" {
+		d.Press("down")
+		if next := d.Model().(Model); next.templates.selected == m.templates.selected {
+			t.Fatal("rest-post template not found")
+		}
+		m = d.Model().(Model)
+	}
+
+	// API_URL is left empty for the active environment to fill in
+	d.Press("enter").AssertView("RESOURCE_NAME", "RESOURCE_VALUE")
+	d.Press("tab").Type("Alice").Press("tab").Type("42").Press("enter")
+
+	m = d.Model().(Model)
+	if m.state != StateRequestBuilder || m.builder.method != "POST" || m.builder.urlInput.Value() != "{{API_URL}}/resources" {
+		t.Fatalf("Expected the template in the builder, got state %v: %s %s", m.state, m.builder.method, m.builder.urlInput.Value())
+	}
+	if !strings.Contains(m.builder.body, `"name": "Alice"`) || !strings.Contains(m.builder.body, `"value": "42"`) || m.builder.requestSaved {
+		t.Errorf("Unexpected body %s", m.builder.body)
+	}
+	if m.builder.headers["Content-Type"] != "application/json" {
+		t.Errorf("Expected the template headers, got %v", m.builder.headers)
+	}
+}
+
+func TestTemplatesAreGroupedByCategory(t *testing.T) {
+	templates := newTemplates().templates
+	seen := make(map[string]bool)
+	for i, tmpl := range templates {
+		if i > 0 && tmpl.Category != templates[i-1].Category && seen[tmpl.Category] {
+			t.Errorf("Category %s is split in the list", tmpl.Category)
+		}
+		seen[tmpl.Category] = true
+	}
+}
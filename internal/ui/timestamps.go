@@ -0,0 +1,284 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/codec"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// maxFoundTimestamps bounds how many values of a response or query result
+// the converter offers to step through
+const maxFoundTimestamps = 200
+
+// foundTimestamp is a value that looks like a timestamp and where it was
+// found
+type foundTimestamp struct {
+	source string
+	value  string
+}
+
+// appendTimestamps adds the timestamps in text to found, skipping values
+// already there. Text that is a date as a whole, like an HTTP Date header
+// or a date column, is taken in any format the converter reads.
+func appendTimestamps(found []foundTimestamp, source, text string) []foundTimestamp {
+	values := codec.FindTimestamps(text)
+	if _, unit, err := codec.ParseTimestamp(text); err == nil && unit == codec.UnitDate {
+		values = []string{strings.TrimSpace(text)}
+	}
+
+next:
+	for _, value := range values {
+		if len(found) >= maxFoundTimestamps {
+			break
+		}
+		for _, f := range found {
+			if f.value == value {
+				continue next
+			}
+		}
+		found = append(found, foundTimestamp{source: source, value: value})
+	}
+	return found
+}
+
+// responseTimestamps finds the timestamps in the headers and body of the
+// response, like Date, Last-Modified and created_at fields
+func (v *ResponseViewer) responseTimestamps() []foundTimestamp {
+	if v.response == nil || v.response.Error != nil {
+		return nil
+	}
+	keys := make([]string, 0, len(v.response.Headers))
+	for key := range v.response.Headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var found []foundTimestamp
+	for _, key := range keys {
+		for _, value := range v.response.Headers[key] {
+			found = appendTimestamps(found, i18n.Tf("jwt.in_header", key), value)
+		}
+	}
+	return appendTimestamps(found, i18n.T("jwt.in_body"), v.response.Body)
+}
+
+// resultTimestamps finds the timestamps in the cells of the query result,
+// row by row
+func (d *DatabaseExplorer) resultTimestamps() []foundTimestamp {
+	if d.result == nil || d.result.Error != nil {
+		return nil
+	}
+	var found []foundTimestamp
+	for _, row := range d.result.Rows {
+		for i, cell := range row {
+			if i < len(d.result.Columns) {
+				found = appendTimestamps(found, i18n.Tf("time.in_column", d.result.Columns[i]), cell)
+			}
+		}
+		if len(found) >= maxFoundTimestamps {
+			break
+		}
+	}
+	return found
+}
+
+// Timestamps is the timestamp converter: a value as epoch seconds and
+// milliseconds and as ISO-8601 in several time zones. Opened on a response
+// or query result, it steps through the timestamps found there.
+type Timestamps struct {
+	input    textinput.Model
+	found    []foundTimestamp
+	current  int
+	selected int
+	back     AppState
+	notice   string
+	err      string
+}
+
+func newTimestamps() Timestamps {
+	input := textinput.New()
+	input.Placeholder = i18n.T("time.placeholder")
+	input.CharLimit = 64
+	input.Width = 40
+	return Timestamps{input: input}
+}
+
+// open shows the converter on the first of found, or on the current time
+// when nothing was found, and returns to back on Esc
+func (ts *Timestamps) open(h host, found []foundTimestamp, back AppState) {
+	ts.found = found
+	ts.current = 0
+	ts.selected = 0
+	ts.back = back
+	ts.notice = ""
+	ts.err = ""
+	if len(found) > 0 {
+		ts.input.SetValue(found[0].value)
+	} else {
+		ts.input.SetValue(codec.ConvertTime(time.Now())[0].Value)
+	}
+	ts.input.CursorEnd()
+	ts.input.Focus()
+	h.navigate(StateTimestamps)
+}
+
+// conversions converts the input, nil when it is no timestamp
+func (ts *Timestamps) conversions() ([]codec.Conversion, time.Time, codec.TimeUnit, error) {
+	t, unit, err := codec.ParseTimestamp(ts.input.Value())
+	if err != nil {
+		return nil, time.Time{}, "", err
+	}
+	return codec.ConvertTime(t), t, unit, nil
+}
+
+// step moves to the next or previous timestamp found
+func (ts *Timestamps) step(delta int) {
+	if len(ts.found) == 0 {
+		return
+	}
+	ts.current = (ts.current + delta + len(ts.found)) % len(ts.found)
+	ts.input.SetValue(ts.found[ts.current].value)
+	ts.input.CursorEnd()
+	ts.notice, ts.err = "", ""
+}
+
+func (ts *Timestamps) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		ts.input.Blur()
+		h.navigate(ts.back)
+		return nil
+
+	case "tab":
+		ts.step(1)
+		return nil
+
+	case "shift+tab":
+		ts.step(-1)
+		return nil
+
+	case "up":
+		if conversions, _, _, err := ts.conversions(); err == nil {
+			ts.selected = (ts.selected + len(conversions) - 1) % len(conversions)
+		}
+		return nil
+
+	case "down":
+		if conversions, _, _, err := ts.conversions(); err == nil {
+			ts.selected = (ts.selected + 1) % len(conversions)
+		}
+		return nil
+
+	case "enter":
+		conversions, _, _, err := ts.conversions()
+		if err != nil {
+			return nil
+		}
+		c := conversions[min(ts.selected, len(conversions)-1)]
+		ts.notice, ts.err = "", ""
+		if err := clipboard.WriteAll(c.Value); err != nil {
+			ts.err = i18n.Tf("utils.copy_failed", err)
+			return nil
+		}
+		ts.notice = i18n.Tf("utils.copied", c.Name)
+		return nil
+
+	case "ctrl+n":
+		ts.input.SetValue(codec.ConvertTime(time.Now())[0].Value)
+		ts.input.CursorEnd()
+		ts.notice, ts.err = "", ""
+		return nil
+	}
+
+	ts.input, cmd = ts.input.Update(msg)
+	return cmd
+}
+
+func (ts *Timestamps) View(h host) string {
+	width, height := h.size()
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.timestamps")))
+	b.WriteString("\n")
+	if len(ts.found) > 0 {
+		found := ts.found[ts.current]
+		b.WriteString(MutedStyle.Render(i18n.Tf("time.found", ts.current+1, len(ts.found), found.source)))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(viewLabeledInput(i18n.T("time.input"), ts.input, true))
+	b.WriteString("\n")
+
+	conversions, t, unit, err := ts.conversions()
+	if err != nil {
+		if strings.TrimSpace(ts.input.Value()) != "" {
+			b.WriteString(ErrorStyle.Render("✗ " + err.Error()))
+			b.WriteString("\n")
+		}
+	} else {
+		now := time.Now()
+		relative := i18n.Tf("jwt.ago", now.Sub(t).Round(time.Second))
+		if t.After(now) {
+			relative = i18n.Tf("jwt.from_now", t.Sub(now).Round(time.Second))
+		}
+		b.WriteString(TextStyle.Render(i18n.Tf("time.read_as", timeUnitLabel(unit), relative)))
+		b.WriteString("\n\n")
+
+		nameWidth := 0
+		for _, c := range conversions {
+			nameWidth = max(nameWidth, len(c.Name))
+		}
+		selected := min(ts.selected, len(conversions)-1)
+		for i, c := range conversions {
+			label := padRightWidth(c.Name, nameWidth+2)
+			if i == selected {
+				b.WriteString(ListItemSelectedStyle.Render("> " + label + c.Value))
+			} else {
+				b.WriteString(ListItemStyle.Render("  "+label) + TextStyle.Render(c.Value))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if ts.err != "" {
+		b.WriteString("\n")
+		b.WriteString(ErrorStyle.Render("✗ " + ts.err))
+		b.WriteString("\n")
+	} else if ts.notice != "" {
+		b.WriteString("\n")
+		b.WriteString(SuccessStyle.Render(ts.notice))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(RenderFooter(i18n.T("footer.timestamps")))
+
+	return Center(width, height, b.String())
+}
+
+// timeUnitLabel names how the input was read, in the interface language
+func timeUnitLabel(unit codec.TimeUnit) string {
+	switch unit {
+	case codec.UnitSeconds:
+		return i18n.T("time.seconds")
+	case codec.UnitMillis:
+		return i18n.T("time.millis")
+	case codec.UnitMicros:
+		return i18n.T("time.micros")
+	case codec.UnitNanos:
+		return i18n.T("time.nanos")
+	default:
+		return i18n.T("time.date")
+	}
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/database"
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowTimestampsFromResponse(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Last-Modified", "Tue, 14 Nov 2023 22:13:20 GMT")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 42, "created_at": 1700000000, "expires_ms": 1700003600000}`))
+	}))
+	defer server.Close()
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL).Press("enter").WaitFor("1700003600000")
+
+	// The Date header comes first, then Last-Modified and the body
+	d.Press("T").AssertView("Timestamp Converter", "Value 1 of 4 • Found in the Date header", "Read as a date")
+	d.Press("tab").AssertView("Value 2 of 4 • Found in the Last-Modified header", "> Epoch seconds", "1700000000",
+		"ISO-8601 UTC", "2023-11-14T22:13:20Z", "America/Sao_Paulo", "2023-11-14T19:13:20-03:00")
+	d.Press("tab").AssertView("Value 3 of 4 • Found in the response body", "Read as epoch seconds")
+	d.Press("tab").AssertView("Value 4 of 4", "Read as epoch milliseconds", "2023-11-14T23:13:20Z")
+	d.Press("tab").AssertView("Value 1 of 4")
+
+	d.Press("ctrl+u").Type("soon").AssertView("✗ not a timestamp")
+	d.Press("ctrl+u").Type("2024-02-29").AssertView("1709164800000")
+	d.Press("esc").AssertView("Status: 200")
+}
+
+func TestFlowTimestampsFromQueryResult(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	m := NewModel()
+	m.state = StateDatabaseResult
+	m.db.result = &database.QueryResult{
+		Columns: []string{"id", "created_at"},
+		Rows: [][]string{
+			{"1", "2023-11-14 22:13:20+00"},
+			{"2", "2024-01-01T00:00:00Z"},
+		},
+	}
+
+	d := tuitest.New(t, *m).Resize(160, 50)
+	d.Press("T").AssertView("Value 1 of 2 • column created_at", "2023-11-14T22:13:20Z")
+	d.Press("shift+tab").AssertView("Value 2 of 2", "1704067200")
+	d.Press("esc").AssertView("Query Result")
+}
+
+func TestFlowTimestampsFromHome(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("6").AssertView("Timestamp Converter", "Read as epoch seconds").AssertNoView("Value 1 of")
+	d.Press("esc").AssertView("[ 6 ] Timestamps")
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// timingLabelWidth is the width of the phase names in the waterfall
+const timingLabelWidth = 10
+
+// viewTimingSummary shows the phases of the response on one line, with the
+// key that opens the waterfall
+func (v *ResponseViewer) viewTimingSummary() string {
+	timing := v.response.Timing
+	if timing == nil || len(timing.Phases) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(timing.Phases)+1)
+	for _, phase := range timing.Phases {
+		parts = append(parts, i18n.T("timing."+phase.Name)+" "+httpclient.FormatDuration(phase.Duration))
+	}
+	parts = append(parts, i18n.T("timing.hint"))
+	return MutedStyle.Render(strings.Join(parts, " • ")) + "\n\n"
+}
+
+// viewTimingWaterfall draws each phase of the response as a bar placed at
+// when it started, all on the scale of the total duration
+func (v *ResponseViewer) viewTimingWaterfall(h host) string {
+	timing := v.response.Timing
+	screenWidth, _ := h.size()
+	width := max(min(screenWidth-50, 60), 20)
+	scale := func(d int64) int {
+		if timing.Total <= 0 {
+			return 0
+		}
+		return int(d * int64(width) / int64(timing.Total))
+	}
+
+	lines := []string{
+		padRightWidth("", timingLabelWidth) + "  " + padRightWidth("0", width) + "  " + httpclient.FormatDuration(timing.Total),
+	}
+	for _, phase := range timing.Phases {
+		offset := min(scale(int64(phase.Start)), width-1)
+		length := min(max(scale(int64(phase.Duration)), 1), width-offset)
+		bar := strings.Repeat(" ", offset) + strings.Repeat("█", length) + strings.Repeat(" ", width-offset-length)
+		lines = append(lines, fmt.Sprintf("%s │%s│ %s  %s",
+			padRightWidth(i18n.T("timing."+phase.Name), timingLabelWidth), bar,
+			padRightWidth(httpclient.FormatDuration(phase.Duration), 8),
+			i18n.Tf("timing.starts_at", httpclient.FormatDuration(phase.Start))))
+	}
+	lines = append(lines, "", padRightWidth(i18n.T("timing.total"), timingLabelWidth)+"  "+httpclient.FormatDuration(timing.Total))
+	if _, ok := timing.Phase(httpclient.PhaseConnect); !ok {
+		lines = append(lines, "", i18n.T("timing.reused"))
+	}
+	return strings.Join(lines, "\n")
+}
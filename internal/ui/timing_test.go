@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowResponseTimingWaterfall(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("timed body"))
+	}))
+	defer server.Close()
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL).Press("enter").WaitFor("timed body")
+	d.AssertView("TCP ", "TTFB ", "Download ", "W: waterfall")
+
+	d.Press("W").AssertView("Response Timing", "TCP        │", "TTFB       │", "Total").AssertNoView("timed body")
+	d.Press("W").AssertView("timed body")
+}
+
+func TestResponseTimingWithoutResponse(t *testing.T) {
+	m := NewModel()
+	m.state = StateViewResponse
+
+	updated, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("W")})
+	if got := updated.(Model); got.viewer.viewResponseTiming {
+		t.Error("W showed the timing of a response that has not arrived")
+	}
+}
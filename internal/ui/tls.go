@@ -0,0 +1,269 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// Fields of the TLS settings form. The client certificate belongs to the
+// active environment and is only shown while one is active.
+const (
+	tlsFieldInsecure = iota
+	tlsFieldCAFile
+	tlsFieldCertFile
+	tlsFieldKeyFile
+	tlsFieldCount
+)
+
+// tlsForm holds the TLS settings screen while it is open
+type tlsForm struct {
+	inputs   [tlsFieldCount]textinput.Model
+	insecure bool
+	focus    int
+	back     AppState
+	err      string
+	notice   string
+}
+
+// SetTLS sets the global TLS settings, and save keeps them when they are
+// changed on the TLS screen. A nil save keeps changes for the session only.
+func (m *Model) SetTLS(settings httpclient.TLSSettings, save func(httpclient.TLSSettings) error) error {
+	m.tlsSettings = settings
+	m.saveTLS = save
+	return m.applyTLS()
+}
+
+// effectiveTLS returns the global settings with the client certificate of
+// the active environment
+func (m *Model) effectiveTLS() httpclient.TLSSettings {
+	settings := m.tlsSettings
+	if env := m.envs.config.Active(); env != nil {
+		settings.CertFile = env.ClientCert
+		settings.KeyFile = env.ClientKey
+	}
+	return settings
+}
+
+// applyTLS points the client at the effective TLS settings. When they
+// cannot be loaded the client falls back to the global ones and the error
+// is returned, so a request is not sent with the certificate of another
+// environment.
+func (m *Model) applyTLS() error {
+	settings := m.effectiveTLS()
+	if settings == m.httpClient.TLS() {
+		return nil
+	}
+	err := m.httpClient.SetTLS(settings)
+	if err != nil {
+		m.httpClient.SetTLS(m.tlsSettings)
+	}
+	return err
+}
+
+// openTLSSettings shows the TLS settings screen filled with the current
+// settings
+func (m *Model) openTLSSettings(back AppState) {
+	width := m.layout.InputWidth
+	if width <= 0 {
+		width = 60
+	}
+	placeholders := [tlsFieldCount]string{
+		tlsFieldCAFile:   "~/certs/internal-ca.pem",
+		tlsFieldCertFile: "~/certs/client.pem",
+		tlsFieldKeyFile:  "~/certs/client-key.pem",
+	}
+	settings := m.effectiveTLS()
+	values := [tlsFieldCount]string{
+		tlsFieldCAFile:   settings.CAFile,
+		tlsFieldCertFile: settings.CertFile,
+		tlsFieldKeyFile:  settings.KeyFile,
+	}
+	form := &m.tlsForm
+	for i := range form.inputs {
+		input := textinput.New()
+		input.Placeholder = placeholders[i]
+		input.CharLimit = 1024
+		input.Width = width
+		input.SetValue(values[i])
+		form.inputs[i] = input
+	}
+	form.insecure = settings.InsecureSkipVerify
+	form.focus = tlsFieldInsecure
+	form.back = back
+	form.err = ""
+	form.notice = ""
+	m.state = StateTLSSettings
+}
+
+// tlsFields returns the fields shown, in focus order
+func (m Model) tlsFields() []int {
+	fields := []int{tlsFieldInsecure, tlsFieldCAFile}
+	if m.envs.config.Active() != nil {
+		fields = append(fields, tlsFieldCertFile, tlsFieldKeyFile)
+	}
+	return fields
+}
+
+func (m *Model) focusTLSField(delta int) {
+	form := &m.tlsForm
+	fields := m.tlsFields()
+	current := 0
+	for i, field := range fields {
+		if field == form.focus {
+			current = i
+		}
+	}
+	form.inputs[form.focus].Blur()
+	form.focus = fields[(current+delta+len(fields))%len(fields)]
+	form.inputs[form.focus].Focus()
+}
+
+// saveTLSSettings applies the typed settings and keeps them: verification
+// through saveTLS, the client certificate in the active environment
+func (m *Model) saveTLSSettings() {
+	form := &m.tlsForm
+	settings := httpclient.TLSSettings{
+		InsecureSkipVerify: form.insecure,
+		CAFile:             strings.TrimSpace(form.inputs[tlsFieldCAFile].Value()),
+	}
+	env := m.envs.config.Active()
+	effective := settings
+	if env != nil {
+		effective.CertFile = strings.TrimSpace(form.inputs[tlsFieldCertFile].Value())
+		effective.KeyFile = strings.TrimSpace(form.inputs[tlsFieldKeyFile].Value())
+	}
+	if err := effective.Validate(); err != nil {
+		form.err = err.Error()
+		return
+	}
+
+	if m.blockedByReadOnly("save TLS settings") {
+		return
+	}
+	if m.saveTLS != nil {
+		if err := m.saveTLS(settings); err != nil {
+			form.err = err.Error()
+			return
+		}
+	}
+	m.tlsSettings = settings
+
+	if env != nil && m.storage != nil && (env.ClientCert != effective.CertFile || env.ClientKey != effective.KeyFile) {
+		if err := m.storage.SetEnvironmentClientCert(env.Name, effective.CertFile, effective.KeyFile); err != nil {
+			form.err = err.Error()
+			return
+		}
+		m.reportStorageError("failed to reload environments", m.envs.reload(m.storage))
+	}
+
+	// Set even when unchanged, so edited files on disk are read again
+	if err := m.httpClient.SetTLS(m.effectiveTLS()); err != nil {
+		form.err = err.Error()
+		return
+	}
+	form.err = ""
+	form.notice = i18n.T("tls.saved")
+}
+
+func (m Model) handleTLSKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	form := &m.tlsForm
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return m, tea.Quit
+
+	case "esc":
+		form.inputs[form.focus].Blur()
+		m.state = form.back
+		return m, nil
+
+	case "tab", "down":
+		m.focusTLSField(1)
+		return m, nil
+
+	case "shift+tab", "up":
+		m.focusTLSField(-1)
+		return m, nil
+
+	case "ctrl+s", "enter":
+		m.saveTLSSettings()
+		return m, nil
+
+	case " ", "left", "right":
+		if form.focus == tlsFieldInsecure {
+			form.insecure = !form.insecure
+			return m, nil
+		}
+	}
+
+	if form.focus == tlsFieldInsecure {
+		return m, nil
+	}
+	form.inputs[form.focus], cmd = form.inputs[form.focus].Update(msg)
+	return m, cmd
+}
+
+func (m Model) viewTLSSettings() string {
+	var b strings.Builder
+	form := m.tlsForm
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.tls_settings")))
+	b.WriteString("\n\n")
+
+	for _, field := range m.tlsFields() {
+		focused := field == form.focus
+		switch field {
+		case tlsFieldInsecure:
+			check := "[ ]"
+			if form.insecure {
+				check = "[x]"
+			}
+			line := check + " " + i18n.T("tls.insecure")
+			if focused {
+				b.WriteString(ButtonActive.Render(line))
+			} else {
+				b.WriteString(TextStyle.Render(line))
+			}
+			b.WriteString("\n")
+			if form.insecure {
+				b.WriteString(WarningStyle.Render(i18n.T("tls.insecure_warning")))
+				b.WriteString("\n")
+			}
+			b.WriteString("\n")
+		case tlsFieldCAFile:
+			b.WriteString(viewLabeledInput(i18n.T("tls.ca_file"), form.inputs[field], focused))
+		case tlsFieldCertFile:
+			b.WriteString("\n")
+			b.WriteString(HeaderStyle.Render(i18n.Tf("tls.client_cert", m.envs.config.ActiveEnvironment)))
+			b.WriteString("\n")
+			b.WriteString(viewLabeledInput(i18n.T("tls.cert_file"), form.inputs[field], focused))
+		case tlsFieldKeyFile:
+			b.WriteString(viewLabeledInput(i18n.T("tls.key_file"), form.inputs[field], focused))
+		}
+	}
+	if m.envs.config.Active() == nil {
+		b.WriteString("\n")
+		b.WriteString(MutedStyle.Render(i18n.T("tls.no_env")))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if form.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + form.err))
+		b.WriteString("\n")
+	} else if form.notice != "" {
+		b.WriteString(SuccessStyle.Render(form.notice))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(RenderFooter(i18n.T("footer.tls")))
+
+	return Center(m.width, m.height, b.String())
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowTLSSkipVerify(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("secure hello"))
+	}))
+	defer server.Close()
+
+	var saved []httpclient.TLSSettings
+	m := NewModel()
+	if err := m.SetTLS(httpclient.TLSSettings{}, func(s httpclient.TLSSettings) error {
+		saved = append(saved, s)
+		return nil
+	}); err != nil {
+		t.Fatalf("SetTLS() error = %v", err)
+	}
+
+	d := tuitest.New(t, *m).Resize(160, 50)
+	d.Press("a").Type(server.URL).Press("enter").WaitFor("Secure connection failed")
+
+	d.Press("esc", "tab", "L").AssertView("TLS Settings", "[ ] Skip verifying", "Activate an environment")
+	d.Press("tab").Type(filepath.Join(t.TempDir(), "missing.pem")).Press("enter").AssertView("failed to read CA bundle")
+	d.Press("ctrl+u", "shift+tab", " ").AssertView("[x] Skip verifying", "⚠ Any certificate is accepted")
+	d.Press("enter").AssertView("✓ TLS settings saved")
+	if len(saved) != 1 || !saved[0].InsecureSkipVerify || saved[0].CAFile != "" {
+		t.Fatalf("saved = %+v", saved)
+	}
+
+	d.Press("esc", "shift+tab", "enter").WaitFor("secure hello").AssertView("⚠ TLS NOT VERIFIED")
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// trailerLines lists the trailers of the response like the headers view
+// lists headers, under a heading of their own
+func (v *ResponseViewer) trailerLines() []string {
+	if len(v.response.Trailers) == 0 {
+		return nil
+	}
+	lines := []string{"", i18n.T("trailers.heading")}
+	for _, key := range v.trailerNames() {
+		for _, value := range v.response.Trailers[key] {
+			lines = append(lines, fmt.Sprintf("%s : %s", padRightWidth(key, 30), value))
+		}
+	}
+	return lines
+}
+
+// viewTrailerHint points at the trailers while the body is shown, since
+// they arrive after it and are easy to miss
+func (v *ResponseViewer) viewTrailerHint() string {
+	if len(v.response.Trailers) == 0 || v.viewResponseHeaders {
+		return ""
+	}
+	return MutedStyle.Render(i18n.Tf("trailers.hint", strings.Join(v.trailerNames(), ", "))) + "\n\n"
+}
+
+func (v *ResponseViewer) trailerNames() []string {
+	keys := make([]string, 0, len(v.response.Trailers))
+	for key := range v.response.Trailers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowResponseShowsTrailers(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+		w.Write([]byte(`{"ok":true}`))
+		w.Header().Set("Grpc-Status", "0")
+		w.Header().Set("Grpc-Message", "done")
+	}))
+	defer server.Close()
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL).Press("enter")
+	d.WaitFor("Trailers: Grpc-Message, Grpc-Status • h: view")
+
+	d.Press("h").AssertView("── Trailers ──", "Grpc-Status", "done")
+}
+
+func TestViewResponseShowsNonDefaultProtocol(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	m := NewModel()
+	m.width, m.height = 160, 50
+	m.viewer.response = &httpclient.Response{StatusCode: 200, Status: "200 OK", Body: "{}", Proto: "HTTP/2.0"}
+	if got := m.viewer.View(m); !strings.Contains(got, "• HTTP/2.0") {
+		t.Errorf("Expected the status line to name HTTP/2.0, got:\n%s", got)
+	}
+
+	m.viewer.response.Proto = "HTTP/1.1"
+	if got := m.viewer.View(m); strings.Contains(got, "HTTP/1.1") {
+		t.Errorf("Expected HTTP/1.1 to be left out of the status line, got:\n%s", got)
+	}
+}
@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// transferRefresh is how often the progress bar is redrawn
+const transferRefresh = 100 * time.Millisecond
+
+// transfer follows a body while it is sent or received. The goroutine
+// moving the bytes records how many went through; the view reads them on
+// every transferTickMsg.
+type transfer struct {
+	done     atomic.Int64
+	total    atomic.Int64
+	start    time.Time
+	cancel   context.CancelFunc
+	canceled bool
+
+	// download is set when the body goes to a file. A resumed download
+	// starts at the bytes already on disk, which base keeps out of the rate.
+	download bool
+	base     atomic.Int64
+	reported atomic.Bool
+}
+
+type transferTickMsg struct{}
+
+func transferTickCmd() tea.Cmd {
+	return tea.Tick(transferRefresh, func(time.Time) tea.Msg {
+		return transferTickMsg{}
+	})
+}
+
+func newTransfer(cancel context.CancelFunc, download bool) *transfer {
+	t := &transfer{start: time.Now(), cancel: cancel, download: download}
+	if download {
+		// The length of a download is unknown until its headers arrive
+		t.total.Store(-1)
+	}
+	return t
+}
+
+func (t *transfer) report(done, total int64) {
+	if t.download && t.reported.CompareAndSwap(false, true) {
+		t.base.Store(done)
+	}
+	t.total.Store(total)
+	t.done.Store(done)
+}
+
+// rate is the average transfer rate so far, in bytes per second
+func (t *transfer) rate() float64 {
+	elapsed := time.Since(t.start).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(t.done.Load()-t.base.Load()) / elapsed
+}
+
+// canceledMessage replaces the error of a response to a canceled transfer
+func (t *transfer) canceledMessage() string {
+	if t.download {
+		return i18n.T("download.canceled")
+	}
+	return i18n.T("upload.canceled")
+}
+
+// finishTransfer releases the transfer once its response arrived
+func (m *Model) finishTransfer() {
+	if m.transfer != nil {
+		m.transfer.cancel()
+		m.transfer = nil
+	}
+}
+
+// progressBar draws a bar width cells wide, filled to fraction of it
+func progressBar(fraction float64, width int) string {
+	filled := min(max(int(fraction*float64(width)), 0), width)
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(ColorAccent)).Render(strings.Repeat("█", filled)) +
+		MutedStyle.Render(strings.Repeat("░", width-filled))
+}
+
+func (m Model) viewTransfer() string {
+	var b strings.Builder
+
+	title, footer, canceling := "title.uploading", "footer.upload", "upload.canceling"
+	if m.transfer.download {
+		title, footer, canceling = "title.downloading", "footer.download", "download.canceling"
+	}
+	b.WriteString(TitleStyle.Render(i18n.T(title)))
+	b.WriteString("\n\n")
+	b.WriteString(TextStyle.Render(fmt.Sprintf("%s %s", m.builder.method, m.builder.urlInput.Value())))
+	b.WriteString("\n")
+	if m.transfer.download {
+		b.WriteString(MutedStyle.Render(i18n.Tf("download.to", m.downloadPath)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	done, total := m.transfer.done.Load(), m.transfer.total.Load()
+	rate := httpclient.FormatSize(int64(m.transfer.rate()))
+	if total >= 0 {
+		fraction := 0.0
+		if total > 0 {
+			fraction = float64(done) / float64(total)
+		}
+		width := min(max(m.layout.InputWidth, 20), 60)
+		b.WriteString(progressBar(fraction, width))
+		b.WriteString(TextStyle.Render(fmt.Sprintf(" %3.0f%%", fraction*100)))
+		b.WriteString("\n")
+		b.WriteString(MutedStyle.Render(i18n.Tf("upload.progress",
+			httpclient.FormatSize(done), httpclient.FormatSize(total), rate)))
+	} else {
+		// Without a length there is nothing to fill the bar against
+		b.WriteString(MutedStyle.Render(i18n.Tf("download.progress_unknown", httpclient.FormatSize(done), rate)))
+	}
+	b.WriteString("\n")
+	if base := m.transfer.base.Load(); m.transfer.download && base > 0 {
+		b.WriteString(MutedStyle.Render(i18n.Tf("download.resumed_at", httpclient.FormatSize(base))))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if m.transfer.canceled {
+		b.WriteString(WarningStyle.Render(i18n.T(canceling)))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(RenderFooter(i18n.T(footer)))
+
+	return Center(m.width, m.height, b.String())
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+)
+
+// activeTransform is the expression the body is shown through: while it
+// is being edited, what is typed so far, so the body is filtered live
+func (v *ResponseViewer) activeTransform() string {
+	if v.editingTransform {
+		return strings.TrimSpace(v.transformInput.Value())
+	}
+	return v.displayTransform
+}
+
+// responseDisplayBody returns the response body with the display transform
+// applied, with how many results it gave or -1 without a transform. When
+// the transform fails the raw body is shown with the error.
+func (v *ResponseViewer) responseDisplayBody() (string, int, string) {
+	if v.response == nil {
+		return "", -1, ""
+	}
+	expr := v.activeTransform()
+	if v.viewRawResponse || expr == "" {
+		return v.response.Body, -1, ""
+	}
+
+	results, err := httpclient.EvalTransform(v.response.Body, expr)
+	if err != nil {
+		return v.response.Body, -1, err.Error()
+	}
+	transformed, err := httpclient.FormatTransformResults(results)
+	if err != nil {
+		return v.response.Body, -1, err.Error()
+	}
+	return transformed, len(results), ""
+}
+
+// updateTransform handles input while editing the display transform
+func (v *ResponseViewer) updateTransform(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		v.editingTransform = false
+		v.transformInput.Blur()
+		return nil
+
+	case "enter":
+		v.editingTransform = false
+		v.transformInput.Blur()
+		h.setDisplayTransform(v.transformInput.Value())
+		return nil
+	}
+
+	before := v.transformInput.Value()
+	v.transformInput, cmd = v.transformInput.Update(msg)
+	if v.transformInput.Value() != before {
+		// The body shown changes with every key
+		v.scrollOffset = 0
+	}
+	return cmd
+}
+
+// setDisplayTransform applies the expression and stores it with the saved request
+func (m *Model) setDisplayTransform(expr string) {
+	m.viewer.displayTransform = expr
+	m.viewer.viewRawResponse = false
+	m.viewer.scrollOffset = 0
+
+	if m.storage == nil || m.readOnly || !m.builder.requestSaved || m.builder.currentRequestSavedID == "" {
+		return
+	}
+
+	if err := m.storage.UpdateDisplayTransform(m.builder.currentRequestSavedID, expr); err == nil {
+		m.requests.saved = m.storage.GetRequests()
+	}
+}
+
+// transformMatches tells how many results a transform gave
+func transformMatches(n int) string {
+	if n == 1 {
+		return "1 match"
+	}
+	return fmt.Sprintf("%d matches", n)
+}
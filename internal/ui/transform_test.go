@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowResponseFilterPreviewsAsTyped(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items": [{"id": 1, "status": "ok"}, {"id": 2, "status": "failed", "error": "timeout"}]}`))
+	}))
+	defer server.Close()
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("a").Type(server.URL).Press("enter").WaitFor(`"status": "failed"`)
+
+	d.Press("t").Type("$..id").AssertView("Preview • 2 matches").AssertNoView(`"status"`)
+	d.Press("esc").AssertView(`"status": "ok"`).AssertNoView("Preview")
+
+	d.Press("t").Type(`.items[] | select(.status == "failed") | .error`)
+	d.AssertView("Preview • 1 match", `"timeout"`).AssertNoView(`"ok"`)
+	d.Press("enter").AssertView("Showing transformed body (1 match)", `"timeout"`)
+
+	d.Press("r").AssertView("Showing raw body", `"status": "ok"`)
+}
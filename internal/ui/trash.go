@@ -0,0 +1,260 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/database"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// Trash is the trash screen: the deleted requests, queries and environments,
+// restored or deleted for good
+type Trash struct {
+	items    []storage.TrashItem
+	selected int
+
+	// confirmingDelete and confirmingEmpty are set while a permanent
+	// deletion of the selected item or of every item is confirmed
+	confirmingDelete bool
+	confirmingEmpty  bool
+	err              string
+	notice           string
+}
+
+// open lists the trash from the top
+func (t *Trash) open(h host) {
+	h.navigate(StateTrash)
+	t.err = ""
+	t.notice = ""
+	t.confirmingDelete = false
+	t.confirmingEmpty = false
+	t.reload(h)
+	t.selected = 0
+}
+
+func (t *Trash) reload(h host) {
+	t.items = nil
+	if h.store() == nil {
+		return
+	}
+
+	items, err := h.store().GetTrash()
+	if err != nil {
+		t.err = err.Error()
+		return
+	}
+	t.items = items
+
+	if t.selected >= len(t.items) {
+		t.selected = len(t.items) - 1
+	}
+	if t.selected < 0 {
+		t.selected = 0
+	}
+}
+
+// archiveQuery keeps a copy of a saved query in the trash of store before
+// it is deleted
+func archiveQuery(store *storage.Storage, query database.SavedQuery) error {
+	if store == nil {
+		return nil
+	}
+	return store.MoveToTrash(storage.TrashKindQuery, query.Name, query)
+}
+
+// restoreTrashItem puts an item back where it was deleted from and reloads it
+func (m *Model) restoreTrashItem(item storage.TrashItem) error {
+	if item.Kind == storage.TrashKindQuery {
+		if m.db.storage == nil {
+			return fmt.Errorf("database storage is not available")
+		}
+		var query database.SavedQuery
+		if err := json.Unmarshal(item.Data, &query); err != nil {
+			return fmt.Errorf("failed to parse deleted query: %w", err)
+		}
+		if err := m.db.storage.RestoreQuery(query); err != nil {
+			return err
+		}
+		if _, err := m.storage.DeleteFromTrash(item.ID); err != nil {
+			return err
+		}
+		m.db.savedQueries = m.db.storage.GetQueries()
+		return nil
+	}
+
+	if err := m.storage.RestoreFromTrash(item.ID); err != nil {
+		return err
+	}
+
+	switch item.Kind {
+	case storage.TrashKindRequest:
+		m.requests.saved = m.storage.GetRequests()
+		m.requests.filtered = nil
+	case storage.TrashKindEnvironment:
+		m.envs.reload(m.storage)
+	}
+	return nil
+}
+
+func (t *Trash) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		if t.confirmingDelete || t.confirmingEmpty {
+			t.confirmingDelete = false
+			t.confirmingEmpty = false
+			return nil
+		}
+		h.navigate(StateHome)
+		return nil
+
+	case "up", "k":
+		if t.selected > 0 {
+			t.selected--
+		}
+		return nil
+
+	case "down", "j":
+		if t.selected < len(t.items)-1 {
+			t.selected++
+		}
+		return nil
+
+	case "enter", "r":
+		if h.blockedByReadOnly("restore from trash") {
+			return nil
+		}
+		if t.selected < len(t.items) {
+			item := t.items[t.selected]
+			t.err = ""
+			t.notice = ""
+			if err := h.restoreTrashItem(item); err != nil {
+				t.err = err.Error()
+				return nil
+			}
+			t.notice = fmt.Sprintf("✓ Restored %s %q", item.Kind, item.Name)
+			t.reload(h)
+		}
+		return nil
+
+	case "d":
+		if h.blockedByReadOnly("delete permanently") {
+			return nil
+		}
+		if t.selected < len(t.items) {
+			t.confirmingDelete = true
+			t.confirmingEmpty = false
+		}
+		return nil
+
+	case "D":
+		if h.blockedByReadOnly("empty trash") {
+			return nil
+		}
+		if len(t.items) > 0 {
+			t.confirmingEmpty = true
+			t.confirmingDelete = false
+		}
+		return nil
+
+	case "y":
+		switch {
+		case t.confirmingDelete && t.selected < len(t.items):
+			item := t.items[t.selected]
+			if _, err := h.store().DeleteFromTrash(item.ID); err != nil {
+				t.err = err.Error()
+			} else {
+				t.notice = fmt.Sprintf("✓ Permanently deleted %s %q", item.Kind, item.Name)
+			}
+		case t.confirmingEmpty:
+			if err := h.store().EmptyTrash(); err != nil {
+				t.err = err.Error()
+			} else {
+				t.notice = "✓ Trash emptied"
+			}
+		}
+		t.confirmingDelete = false
+		t.confirmingEmpty = false
+		t.reload(h)
+		return nil
+
+	case "n":
+		t.confirmingDelete = false
+		t.confirmingEmpty = false
+		return nil
+	}
+
+	return nil
+}
+
+func (t *Trash) View(h host) string {
+	width, height := h.size()
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.Tf("title.trash", len(t.items))))
+	b.WriteString("\n")
+	b.WriteString(MutedStyle.Render(i18n.T("trash.subtitle")))
+	b.WriteString("\n\n")
+
+	if len(t.items) == 0 {
+		b.WriteString(MutedStyle.Render(i18n.T("trash.empty")))
+		b.WriteString("\n")
+	} else {
+		maxItems := height - 14
+		if maxItems < 5 {
+			maxItems = 5
+		}
+		start := 0
+		if t.selected >= maxItems {
+			start = t.selected - maxItems + 1
+		}
+		end := start + maxItems
+		if end > len(t.items) {
+			end = len(t.items)
+		}
+
+		for i := start; i < end; i++ {
+			item := t.items[i]
+			line := fmt.Sprintf("%-11s %s", item.Kind, item.Name)
+			if i == t.selected {
+				b.WriteString(ListItemSelectedStyle.Render("> " + line))
+			} else {
+				b.WriteString(ListItemStyle.Render("  " + line))
+			}
+			b.WriteString("  ")
+			b.WriteString(MutedStyle.Render(i18n.Tf("trash.deleted", item.DeletedAt.Format(time.DateTime))))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+
+	switch {
+	case t.confirmingDelete && t.selected < len(t.items):
+		b.WriteString(WarningStyle.Render(i18n.Tf("confirm.purge_trash_item", t.items[t.selected].Name)))
+		b.WriteString("\n\n")
+	case t.confirmingEmpty:
+		b.WriteString(WarningStyle.Render(i18n.Tf("confirm.empty_trash", len(t.items))))
+		b.WriteString("\n\n")
+	}
+
+	if t.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + t.err))
+		b.WriteString("\n\n")
+	}
+	if t.notice != "" {
+		b.WriteString(SuccessStyle.Render(t.notice))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(RenderFooter(i18n.T("footer.trash")))
+
+	return Center(width, height, b.String())
+}
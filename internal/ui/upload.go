@@ -0,0 +1,36 @@
+package ui
+
+import (
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// isMultipartHeaders reports whether headers ask for a multipart body
+func isMultipartHeaders(headers map[string]string) bool {
+	return httpclient.IsMultipart(contentType(headers))
+}
+
+// isUpload reports whether a request streams a multipart body
+func isUpload(req httpclient.Request) bool {
+	return isMultipartHeaders(req.Headers)
+}
+
+// isMultipartBody reports whether the body being edited is form fields
+func (r *RequestBuilder) isMultipartBody() bool {
+	return isMultipartHeaders(r.headers)
+}
+
+// formBodyPreview summarizes a multipart body for the request builder
+func formBodyPreview(body string) string {
+	fields, err := httpclient.ParseFormFields(body)
+	if err != nil {
+		return err.Error()
+	}
+	files := 0
+	for _, field := range fields {
+		if field.File != "" {
+			files++
+		}
+	}
+	return i18n.Tf("upload.preview", len(fields), files)
+}
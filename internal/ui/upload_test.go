@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowUploadShowsProgressAndCancels(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	path := filepath.Join(t.TempDir(), "video.bin")
+	if err := os.WriteFile(path, make([]byte, 16<<20), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The server never reads the body, so the upload stalls until canceled
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer server.Close()
+	defer close(done)
+
+	m := *NewModel()
+	m.builder.method = "POST"
+	m.builder.headers = map[string]string{"Content-Type": "multipart/form-data"}
+	m.builder.body = "title=Demo\nvideo=@" + path
+	d := tuitest.New(t, m).Resize(160, 50)
+	d.Press("a").AssertView("Body: (form: 2 fields, 1 files)")
+
+	d.Type(server.URL).Press("enter")
+	d.WaitFor("Uploading...", "of 16.00 MB", "Esc: cancel upload")
+
+	d.Press("esc").WaitFor("upload canceled")
+	if m := d.Model().(Model); m.transfer != nil || m.state != StateViewResponse {
+		t.Errorf("Expected the canceled upload to end in the response view, got state %v", m.state)
+	}
+}
+
+func TestBodyEditorValidatesFormFields(t *testing.T) {
+	m := testGraphQLModel()
+	m.builder.headers = map[string]string{"Content-Type": "multipart/form-data"}
+	if err := m.builder.validateBody("title=Demo\nfile=@/tmp/a.png"); err != nil {
+		t.Errorf("Expected form fields to be accepted, got %v", err)
+	}
+	if err := m.builder.validateBody(`{"title": "Demo"}`); err == nil {
+		t.Error("Expected JSON to be rejected as a multipart body")
+	}
+}
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// URLInspector shows how the URL of the request being edited is sent
+type URLInspector struct {
+	inspection *httpclient.URLInspection
+	err        error
+}
+
+// open inspects sent, the URL with variables resolved and query params
+// applied. The warnings come from typed, the URL as typed, when it is set:
+// params from the query editor are encoded by godev itself.
+func (u *URLInspector) open(h host, sent, typed string) {
+	u.inspection, u.err = httpclient.InspectURL(sent)
+	if u.err == nil && typed != "" {
+		if in, err := httpclient.InspectURL(typed); err == nil {
+			u.inspection.Warnings = in.Warnings
+		}
+	}
+	h.navigate(StateURLInspector)
+}
+
+// openURLInspector inspects the URL of the request being edited as it will
+// be sent
+func (m *Model) openURLInspector() {
+	typed := ""
+	if len(m.builder.queryParams) > 0 {
+		typed = m.resolveVariables(m.builder.urlInput.Value())
+	}
+	m.urlInspector.open(m, m.buildRequest().URL, typed)
+}
+
+// resolveVariables replaces {{name}} references with the values of the
+// active environment
+func (m Model) resolveVariables(text string) string {
+	if m.storage == nil {
+		return text
+	}
+	vars, err := m.storage.GetActiveEnvironmentVariables()
+	if err != nil || len(vars) == 0 {
+		return text
+	}
+	return storage.ReplaceVariables(text, vars)
+}
+
+func (u *URLInspector) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+	case "esc", "enter", "i":
+		h.navigate(StateRequestBuilder)
+	}
+	return nil
+}
+
+func (u *URLInspector) View(h host) string {
+	width, height := h.size()
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.inspect")))
+	b.WriteString("\n\n")
+
+	if u.err != nil {
+		b.WriteString(ErrorStyle.Render("✗ " + u.err.Error()))
+		b.WriteString("\n\n")
+		b.WriteString(RenderFooter(i18n.T("footer.inspect")))
+		return Center(width, height, b.String())
+	}
+	in := u.inspection
+
+	row := func(label, value string) {
+		b.WriteString(MutedStyle.Render(fmt.Sprintf("%-10s", label)))
+		b.WriteString(TextStyle.Render(value))
+		b.WriteString("\n")
+	}
+
+	row("Sent as", in.Sent)
+	b.WriteString("\n")
+	row("Scheme", in.Scheme)
+	host := in.Host
+	if in.ASCIIHost != in.Host {
+		host += "  →  " + in.ASCIIHost
+	}
+	row("Host", host)
+	if in.Port != "" {
+		row("Port", in.Port)
+	}
+	if in.User != "" {
+		row("User", in.User)
+	}
+	b.WriteString("\n")
+
+	b.WriteString(TextStyle.Render(i18n.Tf("inspect.segments", len(in.Segments))))
+	b.WriteString("\n")
+	for i, segment := range in.Segments {
+		b.WriteString(MutedStyle.Render(fmt.Sprintf("  %2d  ", i+1)))
+		b.WriteString(TextStyle.Render(fmt.Sprintf("%q", segment)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	b.WriteString(TextStyle.Render(i18n.Tf("inspect.query", len(in.Query))))
+	b.WriteString("\n")
+	for _, param := range in.Query {
+		b.WriteString(MutedStyle.Render("  " + param.Key + " = "))
+		b.WriteString(TextStyle.Render(fmt.Sprintf("%q", param.Value)))
+		b.WriteString("\n")
+	}
+	if in.Fragment != "" {
+		b.WriteString("\n")
+		row("Fragment", in.Fragment)
+	}
+	b.WriteString("\n")
+
+	if len(in.Warnings) == 0 {
+		b.WriteString(SuccessStyle.Render(i18n.T("inspect.clean")))
+		b.WriteString("\n")
+	}
+	for _, warning := range in.Warnings {
+		b.WriteString(WarningStyle.Render("⚠ " + warning))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(RenderFooter(i18n.T("footer.inspect")))
+
+	return Center(width, height, b.String())
+}
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+func TestURLInspectorUsesFinalURL(t *testing.T) {
+	m := Model{builder: RequestBuilder{urlInput: textinput.New(), queryParams: map[string]string{"q": "a b"}}}
+	m.builder.urlInput.SetValue("https://münchen.de/my files?tag=c++")
+
+	if hint := m.builder.urlEncodingHint(); !strings.Contains(hint, "3 URL encoding notes") {
+		t.Errorf("Expected the builder hint to count the host and path notes, got %q", hint)
+	}
+
+	m.openURLInspector()
+	if m.state != StateURLInspector || m.urlInspector.err != nil {
+		t.Fatalf("Expected the inspector to open, got state %v error %v", m.state, m.urlInspector.err)
+	}
+	if m.urlInspector.inspection.Sent != "https://xn--mnchen-3ya.de/my%20files?q=a+b&tag=c++" {
+		t.Errorf("Expected the query params to be applied, got %s", m.urlInspector.inspection.Sent)
+	}
+
+	view := m.urlInspector.View(&m)
+	for _, want := range []string{"xn--mnchen-3ya.de", `"my files"`, `q = "a b"`, "sent as %20", "%2B for a literal plus"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the inspector to show %q", want)
+		}
+	}
+
+	m.builder.urlInput.SetValue("{{base_url}}/my files")
+	if hint := m.builder.urlEncodingHint(); hint != "" {
+		t.Errorf("Expected no hint for URLs with variables, got %q", hint)
+	}
+}
@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/codec"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// readClipboard fills the utilities input when it is opened without text;
+// tests replace it to stay off the system clipboard
+var readClipboard = clipboard.ReadAll
+
+// Utilities is the scratchpad screen: the input text run through every
+// encoding and hash at once, one picked to copy or to feed back in
+type Utilities struct {
+	input    textinput.Model
+	selected int
+	back     AppState
+	notice   string
+	err      string
+}
+
+func newUtilities() Utilities {
+	input := textinput.New()
+	input.Placeholder = i18n.T("utils.placeholder")
+	input.CharLimit = 64 << 10
+	input.Width = 70
+	return Utilities{input: input}
+}
+
+// open shows the scratchpad filled with text, or with the clipboard when
+// text is empty, and returns to back on Esc
+func (u *Utilities) open(h host, text string, back AppState) {
+	if text == "" {
+		text, _ = readClipboard()
+	}
+	u.input.SetValue(strings.TrimSpace(text))
+	u.input.CursorEnd()
+	u.input.Focus()
+	u.selected = 0
+	u.back = back
+	u.notice = ""
+	u.err = ""
+	h.navigate(StateUtilities)
+}
+
+// result runs the selected conversion on the input
+func (u *Utilities) result() (string, error) {
+	return codec.Ops[u.selected].Apply(u.input.Value())
+}
+
+func (u *Utilities) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		u.input.Blur()
+		h.navigate(u.back)
+		return nil
+
+	case "up", "shift+tab":
+		u.selected = (u.selected + len(codec.Ops) - 1) % len(codec.Ops)
+		return nil
+
+	case "down", "tab":
+		u.selected = (u.selected + 1) % len(codec.Ops)
+		return nil
+
+	case "enter":
+		out, err := u.result()
+		if err != nil {
+			return nil
+		}
+		u.notice, u.err = "", ""
+		if err := clipboard.WriteAll(out); err != nil {
+			u.err = i18n.Tf("utils.copy_failed", err)
+			return nil
+		}
+		u.notice = i18n.Tf("utils.copied", codec.Ops[u.selected].Name)
+		return nil
+
+	case "ctrl+e":
+		// Chain conversions, like decoding base64 and then the URL escapes
+		if out, err := u.result(); err == nil {
+			u.input.SetValue(out)
+			u.input.CursorEnd()
+			u.notice, u.err = "", ""
+		}
+		return nil
+
+	case "ctrl+g":
+		u.input.SetValue(codec.NewUUID())
+		u.input.CursorEnd()
+		u.notice, u.err = i18n.T("utils.uuid"), ""
+		return nil
+	}
+
+	u.input, cmd = u.input.Update(msg)
+	return cmd
+}
+
+func (u *Utilities) View(h host) string {
+	width, height := h.size()
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.utilities")))
+	b.WriteString("\n\n")
+	b.WriteString(viewLabeledInput(i18n.T("utils.input"), u.input, true))
+	b.WriteString("\n")
+
+	nameWidth := 0
+	for _, op := range codec.Ops {
+		nameWidth = max(nameWidth, len(op.Name))
+	}
+	valueWidth := max(width-nameWidth-16, 20)
+	for i, op := range codec.Ops {
+		label := padRightWidth(op.Name, nameWidth+2)
+		out, err := op.Apply(u.input.Value())
+		value := truncateWidth(printable(out), valueWidth, "…")
+		if err != nil {
+			value = "✗ " + truncateWidth(err.Error(), valueWidth-2, "…")
+		}
+
+		switch {
+		case i == u.selected:
+			b.WriteString(ListItemSelectedStyle.Render("> " + label + value))
+		case err != nil:
+			b.WriteString(MutedStyle.Render("  " + label + value))
+		default:
+			b.WriteString(ListItemStyle.Render("  "+label) + TextStyle.Render(value))
+		}
+		b.WriteString("\n")
+	}
+
+	if u.err != "" {
+		b.WriteString("\n")
+		b.WriteString(ErrorStyle.Render("✗ " + u.err))
+		b.WriteString("\n")
+	} else if u.notice != "" {
+		b.WriteString("\n")
+		b.WriteString(SuccessStyle.Render(u.notice))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(RenderFooter(i18n.T("footer.utilities")))
+
+	return Center(width, height, b.String())
+}
+
+// printable returns s as is when it is printable text, and quoted with
+// escapes otherwise, so decoded binary does not garble the screen
+func printable(s string) string {
+	for _, r := range s {
+		if r == unicode.ReplacementChar || (!unicode.IsPrint(r) && r != ' ') {
+			quoted := strconv.Quote(s)
+			return quoted[1 : len(quoted)-1]
+		}
+	}
+	return s
+}
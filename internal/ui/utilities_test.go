@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowUtilitiesFromClipboard(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	saved := readClipboard
+	readClipboard = func() (string, error) { return "aGVsbG8lMjB3b3JsZA==\n", nil }
+	defer func() { readClipboard = saved }()
+
+	d := tuitest.New(t, *NewModel()).Resize(160, 50)
+	d.Press("5").AssertView("Utilities", "aGVsbG8lMjB3b3JsZA==", "> Base64 encode", "Base64 decode     hello%20world")
+
+	// Decoding twice turns base64 of a URL-escaped string into plain text
+	d.Press("down", "ctrl+e").AssertView("Base64 decode     ✗ illegal base64 data", "URL decode        hello world")
+	d.Press("down", "down", "down", "ctrl+e").AssertView("SHA-256           b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9")
+
+	d.Press("ctrl+g").AssertView("✓ New random UUID (version 4)")
+	d.Press("esc").AssertView("[ 5 ] Utilities")
+}
+
+func TestPrintable(t *testing.T) {
+	tests := map[string]string{
+		"hello world": "hello world",
+		"olá":         "olá",
+		"a\nb":        `a\nb`,
+		"\xff\x00":    `\xff\x00`,
+	}
+	for in, want := range tests {
+		if got := printable(in); got != want {
+			t.Errorf("printable(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
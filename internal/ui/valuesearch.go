@@ -0,0 +1,375 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/database"
+	"github.com/abneribeiro/godev/internal/i18n"
+)
+
+// valueSearchTimeout bounds a search over every picked table
+const valueSearchTimeout = 60 * time.Second
+
+// valueSearchTablesShown is how many tables the picker lists at once
+const valueSearchTablesShown = 8
+
+// Parts of the value search that take the keys
+const (
+	valueSearchFocusValue = iota
+	valueSearchFocusTables
+	valueSearchFocusMatches
+)
+
+// ValueSearch looks for a literal in the text columns of the picked
+// tables, to find where data created through the API ended up
+type ValueSearch struct {
+	value  textinput.Model
+	tables []string
+	picked map[string]bool
+	table  int
+	focus  int
+	back   AppState
+
+	// attempt tells the answer of the latest search from earlier ones
+	attempt  int
+	cancel   context.CancelFunc
+	loading  bool
+	searched string
+	results  []database.TableSearch
+	err      error
+	match    int
+}
+
+type valueSearchMsg struct {
+	attempt int
+	results []database.TableSearch
+	err     error
+}
+
+// valueSearchMatch is a match with the table it was found in
+type valueSearchMatch struct {
+	table string
+	database.ValueMatch
+}
+
+func newValueSearch() ValueSearch {
+	value := textinput.New()
+	value.Placeholder = "test-user@example.com"
+	value.CharLimit = 200
+	value.Width = 50
+	return ValueSearch{value: value, picked: make(map[string]bool)}
+}
+
+// open shows the search over tables with only is picked, or all of them
+// when only is empty, and returns to back on Esc
+func (v *ValueSearch) open(h host, tables []string, only string, back AppState) {
+	v.tables = tables
+	v.picked = make(map[string]bool, len(tables))
+	v.table = 0
+	for i, table := range tables {
+		v.picked[table] = only == "" || table == only
+		if table == only {
+			v.table = i
+		}
+	}
+	v.back = back
+	v.setFocus(valueSearchFocusValue)
+	h.navigate(StateDatabaseValueSearch)
+}
+
+func (v *ValueSearch) setFocus(focus int) {
+	v.focus = focus
+	if focus == valueSearchFocusValue {
+		v.value.Focus()
+	} else {
+		v.value.Blur()
+	}
+}
+
+// pickedTables returns the picked tables in the order they are listed
+func (v *ValueSearch) pickedTables() []string {
+	var tables []string
+	for _, table := range v.tables {
+		if v.picked[table] {
+			tables = append(tables, table)
+		}
+	}
+	return tables
+}
+
+// search runs the queries on the picked tables, one table at a time
+func (v *ValueSearch) search(h host) tea.Cmd {
+	value := strings.TrimSpace(v.value.Value())
+	tables := v.pickedTables()
+	if value == "" || len(tables) == 0 {
+		return nil
+	}
+	client := h.databaseExplorer().connectedClient()
+	if client == nil {
+		return nil
+	}
+
+	v.stop()
+	v.attempt++
+	v.loading = true
+	v.searched = value
+	v.results = nil
+	v.err = nil
+	v.match = 0
+
+	ctx, cancel := context.WithTimeout(context.Background(), valueSearchTimeout)
+	v.cancel = cancel
+	attempt := v.attempt
+	return func() tea.Msg {
+		defer cancel()
+		results, err := client.SearchValue(ctx, value, tables, database.ValueSearchLimit)
+		return valueSearchMsg{attempt: attempt, results: results, err: err}
+	}
+}
+
+// stop cancels the search running, if any
+func (v *ValueSearch) stop() {
+	if v.cancel != nil {
+		v.cancel()
+		v.cancel = nil
+	}
+	v.loading = false
+}
+
+// finish keeps the answer of the latest search
+func (v *ValueSearch) finish(msg valueSearchMsg) {
+	if msg.attempt != v.attempt {
+		return
+	}
+	v.cancel = nil
+	v.loading = false
+	v.results = msg.results
+	v.err = msg.err
+	if len(v.matches()) > 0 {
+		v.setFocus(valueSearchFocusMatches)
+	}
+}
+
+// matches lists what the latest search found, table by table
+func (v *ValueSearch) matches() []valueSearchMatch {
+	var matches []valueSearchMatch
+	for _, result := range v.results {
+		for _, match := range result.Matches {
+			matches = append(matches, valueSearchMatch{table: result.Table, ValueMatch: match})
+		}
+	}
+	return matches
+}
+
+func (v *ValueSearch) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		if v.loading {
+			v.attempt++
+			v.stop()
+			return nil
+		}
+		h.navigate(v.back)
+		return nil
+
+	case "tab", "shift+tab":
+		parts := 2
+		if len(v.matches()) > 0 {
+			parts = 3
+		}
+		step := 1
+		if msg.String() == "shift+tab" {
+			step = parts - 1
+		}
+		v.setFocus((v.focus + step) % parts)
+		return nil
+	}
+
+	switch v.focus {
+	case valueSearchFocusTables:
+		return v.updateTables(h, msg)
+	case valueSearchFocusMatches:
+		return v.updateMatches(h, msg)
+	}
+
+	if msg.String() == "enter" {
+		return v.search(h)
+	}
+	var cmd tea.Cmd
+	v.value, cmd = v.value.Update(msg)
+	return cmd
+}
+
+func (v *ValueSearch) updateTables(h host, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "k":
+		if v.table > 0 {
+			v.table--
+		}
+	case "down", "j":
+		if v.table < len(v.tables)-1 {
+			v.table++
+		}
+	case " ", "x":
+		if len(v.tables) > 0 {
+			table := v.tables[v.table]
+			v.picked[table] = !v.picked[table]
+		}
+	case "a":
+		// Pick every table, or none when they all are
+		all := len(v.pickedTables()) < len(v.tables)
+		for _, table := range v.tables {
+			v.picked[table] = all
+		}
+	case "enter":
+		return v.search(h)
+	}
+	return nil
+}
+
+func (v *ValueSearch) updateMatches(h host, msg tea.KeyMsg) tea.Cmd {
+	matches := v.matches()
+	switch msg.String() {
+	case "up", "k":
+		if v.match > 0 {
+			v.match--
+		}
+	case "down", "j":
+		if v.match < len(matches)-1 {
+			v.match++
+		}
+	case "enter":
+		if v.match < len(matches) {
+			match := matches[v.match]
+			h.databaseExplorer().editQuery(h, database.ValueMatchQuery(match.table, match.Column, v.searched, 100))
+		}
+	}
+	return nil
+}
+
+func (v *ValueSearch) View(h host) string {
+	width, height := h.size()
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.T("title.value_search")))
+	b.WriteString("\n\n")
+	b.WriteString(viewLabeledInput(i18n.T("value_search.value"), v.value, v.focus == valueSearchFocusValue))
+	b.WriteString("\n")
+
+	b.WriteString(v.viewTables())
+	b.WriteString("\n")
+
+	switch {
+	case v.loading:
+		b.WriteString(MutedStyle.Render(i18n.Tf("value_search.searching", v.searched)))
+		b.WriteString("\n\n")
+	case v.results != nil || v.err != nil:
+		b.WriteString(v.viewResults(width))
+	}
+
+	b.WriteString(RenderFooter(i18n.T("footer.value_search")))
+
+	return Center(width, height, b.String())
+}
+
+// viewTables lists the tables around the selected one with whether they
+// are searched
+func (v *ValueSearch) viewTables() string {
+	var b strings.Builder
+	b.WriteString(HeaderStyle.Render(i18n.Tf("value_search.tables", len(v.pickedTables()), len(v.tables))))
+	b.WriteString("\n")
+	if len(v.tables) == 0 {
+		b.WriteString(MutedStyle.Render(i18n.T("value_search.no_tables")))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	start := v.table - valueSearchTablesShown/2
+	if start > len(v.tables)-valueSearchTablesShown {
+		start = len(v.tables) - valueSearchTablesShown
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := min(start+valueSearchTablesShown, len(v.tables))
+	for i := start; i < end; i++ {
+		check := "[ ] "
+		if v.picked[v.tables[i]] {
+			check = "[x] "
+		}
+		if i == v.table && v.focus == valueSearchFocusTables {
+			b.WriteString(ListItemSelectedStyle.Render("> " + check + v.tables[i]))
+		} else {
+			b.WriteString(ListItemStyle.Render("  " + check + v.tables[i]))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// viewResults lists the matches, then the tables that could not be
+// searched
+func (v *ValueSearch) viewResults(width int) string {
+	var b strings.Builder
+	if v.err != nil {
+		b.WriteString(ErrorStyle.Render("✗ " + v.err.Error()))
+		b.WriteString("\n\n")
+	}
+
+	found, skipped := 0, 0
+	for _, result := range v.results {
+		switch {
+		case result.Rows > 0:
+			found++
+		case result.Err == nil && len(result.Columns) == 0:
+			skipped++
+		}
+	}
+	if v.results != nil {
+		b.WriteString(TextStyle.Render(i18n.Tf("value_search.summary", v.searched, found, len(v.results))))
+		if skipped > 0 {
+			b.WriteString(MutedStyle.Render(" " + i18n.Tf("value_search.no_text_columns", skipped)))
+		}
+		b.WriteString("\n\n")
+	}
+
+	i := 0
+	for _, result := range v.results {
+		if result.Rows == 0 {
+			continue
+		}
+		rows := i18n.Tf("value_search.rows", result.Rows)
+		if result.Truncated {
+			rows = i18n.Tf("value_search.rows_limited", result.Rows)
+		}
+		b.WriteString(HeaderStyle.Render(result.Table) + MutedStyle.Render(" "+rows))
+		b.WriteString("\n")
+		for _, match := range result.Matches {
+			line := truncateWidth(match.Column+": "+strings.Join(strings.Fields(match.Value), " "), max(width-16, 20), "…")
+			if i == v.match && v.focus == valueSearchFocusMatches {
+				b.WriteString(ListItemSelectedStyle.Render("> " + line))
+			} else {
+				b.WriteString(ListItemStyle.Render("  " + line))
+			}
+			b.WriteString("\n")
+			i++
+		}
+	}
+
+	for _, result := range v.results {
+		if result.Err != nil {
+			b.WriteString(WarningStyle.Render(i18n.Tf("value_search.table_failed", result.Table, result.Err.Error())))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/abneribeiro/godev/internal/database"
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/tuitest"
+)
+
+func TestFlowValueSearchListsMatchesAndOpensQuery(t *testing.T) {
+	t.Setenv(paths.HomeEnv, t.TempDir())
+	m := NewModel()
+	m.valueSearch.open(m, []string{"audit", "orders", "users"}, "users", StateDatabaseSchema)
+	m.valueSearch.value.SetValue("test-user")
+	m.valueSearch.searched = "test-user"
+
+	d := tuitest.New(t, *m).Resize(160, 50)
+	d.AssertView("Find Value in Tables", "Tables to search (1 of 3)", "[ ] orders", "[x] users")
+	d.Press("tab", "up", " ").AssertView("Tables to search (2 of 3)", "[x] orders")
+	d.Press("a").AssertView("Tables to search (3 of 3)")
+
+	d.Send(valueSearchMsg{results: []database.TableSearch{
+		{Table: "audit", Err: errors.New("permission denied")},
+		{Table: "orders"},
+		{Table: "users", Columns: []string{"email", "note"}, Rows: 2, Matches: []database.ValueMatch{
+			{Column: "email", Value: "Test-User@example.com"},
+			{Column: "note", Value: "created by test-user"},
+		}},
+	}})
+	d.AssertView(`Tables with "test-user": 1 of 3.`, "Without text columns: 1.", "users", "rows: 2",
+		"> email: Test-User@example.com", "note: created by test-user", "⚠ audit not searched: permission denied")
+
+	d.Press("down", "enter")
+	got := d.Model().(Model)
+	if got.state != StateDatabaseQueryEditor {
+		t.Fatalf("State = %v, want the query editor", got.state)
+	}
+	if query := got.db.editor.Value(); !strings.Contains(query, `WHERE "note"::text ILIKE '%test-user%'`) {
+		t.Errorf("Query = %q, want the rows of users with the value in note", query)
+	}
+}
+
+func TestValueSearchEscCancelsRunningSearch(t *testing.T) {
+	var v ValueSearch
+	canceled := false
+	v.loading = true
+	v.cancel = func() { canceled = true }
+
+	m := NewModel()
+	m.state = StateDatabaseValueSearch
+	v.Update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if !canceled || v.loading {
+		t.Errorf("Esc left the search running: canceled = %v, loading = %v", canceled, v.loading)
+	}
+	if m.state != StateDatabaseValueSearch {
+		t.Errorf("Esc while searching left the screen")
+	}
+
+	v.finish(valueSearchMsg{attempt: v.attempt - 1, results: []database.TableSearch{{Table: "users"}}})
+	if v.results != nil {
+		t.Error("The answer of the canceled search was kept")
+	}
+}
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// startVariantNaming asks for the name of a new variant of the loaded request
+func (m *Model) startVariantNaming() {
+	m.variantNotice = ""
+	if m.storage == nil || m.builder.currentRequestSavedID == "" {
+		m.variantNotice = i18n.T("variant.load_first")
+		return
+	}
+
+	m.namingVariant = true
+	m.variantInput.SetValue("")
+	m.variantInput.Focus()
+}
+
+// handleVariantNameKeys handles input while naming a new variant
+func (m Model) handleVariantNameKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return m, tea.Quit
+
+	case "esc":
+		m.namingVariant = false
+		m.variantInput.Blur()
+		return m, nil
+
+	case "enter":
+		variant, err := m.storage.SaveVariant(m.builder.currentRequestSavedID, m.variantInput.Value(),
+			m.builder.method, m.builder.urlInput.Value(), m.builder.headers, m.builder.body, m.builder.queryParams)
+		if err != nil {
+			m.variantNotice = err.Error()
+			return m, nil
+		}
+
+		m.namingVariant = false
+		m.variantInput.Blur()
+		m.requests.saved = m.storage.GetRequests()
+		m.builder.currentRequestSavedID = variant.ID
+		m.builder.requestSaved = true
+		m.variantNotice = i18n.Tf("variant.saved", variant.VariantName)
+		return m, nil
+	}
+
+	m.variantInput, cmd = m.variantInput.Update(msg)
+	return m, cmd
+}
+
+// viewVariantSection renders the variant name input and the last variant notice
+func (m Model) viewVariantSection() string {
+	var b strings.Builder
+
+	if m.namingVariant {
+		b.WriteString(TextStyle.Render(i18n.T("variant.name")))
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().
+			Border(roundedBorder()).
+			BorderForeground(lipgloss.Color(ColorAccent)).
+			Padding(0, 1).
+			Width(m.variantInput.Width + 2).
+			Render(m.variantInput.View()))
+		b.WriteString("\n")
+		b.WriteString(MutedStyle.Render(i18n.T("variant.hint")))
+		b.WriteString("\n")
+	}
+
+	if m.variantNotice != "" {
+		if strings.HasPrefix(m.variantNotice, "✓") {
+			b.WriteString(SuccessStyle.Render(m.variantNotice))
+		} else {
+			b.WriteString(WarningStyle.Render(m.variantNotice))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// requestListLabel shows variants indented under their parent request
+func requestListLabel(req storage.SavedRequest, requests []storage.SavedRequest) string {
+	if !req.IsVariant() {
+		return req.Name
+	}
+	for _, parent := range requests {
+		if parent.ID == req.ParentID {
+			return "  └ " + req.VariantName
+		}
+	}
+	return req.Name
+}
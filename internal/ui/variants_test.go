@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+func TestRequestListLabel(t *testing.T) {
+	parent := storage.SavedRequest{ID: "p", Name: "create user"}
+	variant := storage.SavedRequest{ID: "v", Name: "create user – full", ParentID: "p", VariantName: "full"}
+	requests := []storage.SavedRequest{parent, variant}
+
+	if got := requestListLabel(parent, requests); got != "create user" {
+		t.Errorf("Expected parent name, got %q", got)
+	}
+	if got := requestListLabel(variant, requests); got != "  └ full" {
+		t.Errorf("Expected indented variant, got %q", got)
+	}
+	// Search results without the parent show the full name
+	if got := requestListLabel(variant, []storage.SavedRequest{variant}); got != variant.Name {
+		t.Errorf("Expected full name for orphaned variant, got %q", got)
+	}
+}
+
+func TestStartVariantNamingRequiresSavedRequest(t *testing.T) {
+	m := Model{}
+
+	m.startVariantNaming()
+
+	if m.namingVariant || m.variantNotice == "" {
+		t.Errorf("Expected a notice instead of the name input, got naming=%v notice=%q", m.namingVariant, m.variantNotice)
+	}
+}
@@ -0,0 +1,31 @@
+package ui
+
+import "github.com/mattn/go-runewidth"
+
+// displayWidth returns how many terminal columns s takes. Wide characters
+// such as CJK and most emoji take two columns, combining marks none.
+func displayWidth(s string) int {
+	return runewidth.StringWidth(s)
+}
+
+// truncateWidth cuts s to at most width columns, ending with tail when cut.
+// Grapheme clusters are never split.
+func truncateWidth(s string, width int, tail string) string {
+	if displayWidth(s) <= width {
+		return s
+	}
+	if width <= displayWidth(tail) {
+		return tail
+	}
+	return runewidth.Truncate(s, width, tail)
+}
+
+// padRightWidth fills s with spaces up to width columns
+func padRightWidth(s string, width int) string {
+	return runewidth.FillRight(s, width)
+}
+
+// padLeftWidth fills s with leading spaces up to width columns
+func padLeftWidth(s string, width int) string {
+	return runewidth.FillLeft(s, width)
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestTruncateWidth(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"hello", 10, "hello"},
+		{"hello world", 8, "hello..."},
+		{"日本語のテキスト", 10, "日本語..."},
+		{"👍👍👍👍👍", 6, "👍..."},
+		{"anything", 2, "..."},
+	}
+
+	for _, tt := range tests {
+		got := truncateWidth(tt.s, tt.width, "...")
+		if got != tt.want {
+			t.Errorf("truncateWidth(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+		if displayWidth(got) > tt.width && tt.width >= 3 {
+			t.Errorf("truncateWidth(%q, %d) is %d columns wide", tt.s, tt.width, displayWidth(got))
+		}
+	}
+}
+
+func TestPadWidth(t *testing.T) {
+	if got := padRightWidth("日本", 6); got != "日本  " {
+		t.Errorf("padRightWidth() = %q", got)
+	}
+	if got := padLeftWidth("👍", 4); got != "  👍" {
+		t.Errorf("padLeftWidth() = %q", got)
+	}
+}
+
+func TestTableRendererWideCharacters(t *testing.T) {
+	columns := []string{"id", "name"}
+	rows := [][]string{
+		{"1", "Alice"},
+		{"2", "東京タワー"},
+		{"3", "party 🎉"},
+	}
+
+	lines := strings.Split(NewTableRenderer(columns, rows, 80).Render(), "\n")
+	want := lipgloss.Width(lines[0])
+	for i, line := range lines {
+		if i == 1 {
+			// The header row carries the header style padding
+			continue
+		}
+		if w := lipgloss.Width(line); w != want {
+			t.Errorf("Line %d is %d columns wide, want %d: %q", i, w, want, line)
+		}
+	}
+}
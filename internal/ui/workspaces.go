@@ -0,0 +1,214 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/abneribeiro/godev/internal/database"
+	"github.com/abneribeiro/godev/internal/i18n"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// openDatabaseStorage keeps saved connections and queries inside the workspace
+func openDatabaseStorage(store *storage.Storage) (*database.DatabaseStorage, error) {
+	if store == nil {
+		return database.NewDatabaseStorage()
+	}
+
+	dir, err := store.Dir()
+	if err != nil {
+		return nil, err
+	}
+	return database.NewDatabaseStorageAt(dir)
+}
+
+// Workspaces is the workspace switcher: the workspaces listed, opened and
+// created
+type Workspaces struct {
+	names    []string
+	selected int
+
+	// creating is set while the name of a new workspace is typed
+	creating bool
+	input    textinput.Model
+	err      string
+}
+
+func newWorkspaces() Workspaces {
+	input := textinput.New()
+	input.Placeholder = "client-a"
+	input.CharLimit = 64
+	input.Width = 40
+	return Workspaces{input: input}
+}
+
+// open shows the workspace switcher with the active workspace selected
+func (w *Workspaces) open(h host) {
+	h.navigate(StateWorkspaces)
+	w.err = ""
+	w.creating = false
+
+	names, err := storage.ListWorkspaces()
+	if err != nil {
+		w.err = err.Error()
+		names = []string{storage.DefaultWorkspace}
+	}
+	w.names = names
+
+	w.selected = 0
+	if h.store() != nil {
+		for i, name := range names {
+			if name == h.store().Workspace() {
+				w.selected = i
+				break
+			}
+		}
+	}
+}
+
+// switchWorkspace reopens every store from the workspace root and reloads the UI data
+func (m *Model) switchWorkspace(name string) error {
+	if err := storage.SetActiveWorkspace(name); err != nil {
+		return err
+	}
+
+	store, err := storage.NewWorkspaceStorage(name)
+	if err != nil {
+		return err
+	}
+
+	return m.attachStorage(store)
+}
+
+func (w *Workspaces) Update(h host, msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	if w.creating {
+		switch msg.String() {
+		case "ctrl+c", "ctrl+q":
+			return tea.Quit
+		case "esc":
+			w.creating = false
+			w.input.Blur()
+			w.input.SetValue("")
+			return nil
+		case "enter":
+			name := strings.TrimSpace(w.input.Value())
+			if err := storage.ValidateWorkspaceName(name); err != nil {
+				w.err = err.Error()
+				return nil
+			}
+			w.creating = false
+			w.input.Blur()
+			w.input.SetValue("")
+			if err := h.switchWorkspace(name); err != nil {
+				w.err = err.Error()
+				return nil
+			}
+			h.navigate(StateHome)
+			return nil
+		default:
+			w.input, cmd = w.input.Update(msg)
+			return cmd
+		}
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return tea.Quit
+
+	case "esc":
+		h.navigate(StateHome)
+		return nil
+
+	case "up", "k":
+		if w.selected > 0 {
+			w.selected--
+		}
+		return nil
+
+	case "down", "j":
+		if w.selected < len(w.names)-1 {
+			w.selected++
+		}
+		return nil
+
+	case "n":
+		if h.blockedByReadOnly("create workspace") {
+			return nil
+		}
+		w.creating = true
+		w.err = ""
+		w.input.Focus()
+		return nil
+
+	case "enter":
+		if w.selected < len(w.names) {
+			if err := h.switchWorkspace(w.names[w.selected]); err != nil {
+				w.err = err.Error()
+				return nil
+			}
+			h.navigate(StateHome)
+		}
+		return nil
+	}
+
+	return nil
+}
+
+func (w *Workspaces) View(h host) string {
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(i18n.Tf("title.workspaces", len(w.names))))
+	b.WriteString("\n")
+	b.WriteString(MutedStyle.Render(i18n.T("workspaces.intro")))
+	b.WriteString("\n\n")
+
+	active := ""
+	if h.store() != nil {
+		active = h.store().Workspace()
+	}
+
+	for i, name := range w.names {
+		line := name
+		if name == active {
+			line += i18n.T("workspaces.active")
+		}
+		if i == w.selected {
+			b.WriteString(ListItemSelectedStyle.Render("> " + line))
+		} else {
+			b.WriteString(ListItemStyle.Render("  " + line))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if w.creating {
+		b.WriteString(TextStyle.Render(i18n.T("workspaces.new_name")))
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().
+			Border(roundedBorder()).
+			BorderForeground(lipgloss.Color(ColorAccent)).
+			Padding(0, 1).
+			Width(w.input.Width + 2).
+			Render(w.input.View()))
+		b.WriteString("\n\n")
+	}
+
+	if w.err != "" {
+		b.WriteString(ErrorStyle.Render("✗ " + w.err))
+		b.WriteString("\n\n")
+	}
+
+	if w.creating {
+		b.WriteString(RenderFooter(i18n.T("footer.workspace_new")))
+	} else {
+		b.WriteString(RenderFooter(i18n.T("footer.workspaces")))
+	}
+
+	width, height := h.size()
+	return Center(width, height, b.String())
+}
@@ -2,20 +2,53 @@ package main
 
 import (
 	"context"
+	_ "embed"
+	"flag"
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/abneribeiro/godev/internal/config"
+	"github.com/abneribeiro/godev/internal/errors"
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/i18n"
 	"github.com/abneribeiro/godev/internal/logging"
+	"github.com/abneribeiro/godev/internal/paths"
+	"github.com/abneribeiro/godev/internal/plugin"
+	"github.com/abneribeiro/godev/internal/storage"
 	"github.com/abneribeiro/godev/internal/ui"
 )
 
+// changelogText feeds the "what's new" screen shown after an upgrade
+//
+//go:embed CHANGELOG.md
+var changelogText string
+
+// commands maps subcommand names to their entry points
+var commands = map[string]func(ctx context.Context, args []string) error{
+	"collection": runCollectionCommand,
+	"docs":       runDocsCommand,
+	"import":     runImportCommand,
+	"mock":       runMockCommand,
+	"profile":    runProfileCommand,
+	"proxy":      runProxyCommand,
+	"pull":       runPullCommand,
+	"run":        runRunCommand,
+	"send":       runSendCommand,
+	"serve":      runServeCommand,
+}
+
 func main() {
+	// --config-dir applies to subcommands too, so it is taken out before
+	// anything else reads the arguments or the storage location
+	args, configDir := extractConfigDir(os.Args[1:])
+	paths.SetOverride(configDir)
+
 	// Load configuration
 	cfg, err := config.LoadFromEnv()
 	if err != nil {
@@ -43,6 +76,13 @@ func main() {
 		"config_dir", cfg.ConfigDir,
 	)
 
+	if moved, err := paths.Migrate(); err != nil {
+		logger.Warn("Failed to migrate data to the XDG data directory", "error", err)
+	} else if moved {
+		dataDir, _ := paths.DataDir()
+		logger.Info("Migrated existing data", "data_dir", dataDir)
+	}
+
 	// Setup graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -57,14 +97,121 @@ func main() {
 		cancel()
 	}()
 
+	// Run a subcommand instead of the UI when one is given
+	if len(args) > 0 {
+		if command, ok := commands[args[0]]; ok {
+			if err := command(ctx, args[1:]); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", args[0], err)
+				if category := errors.Classify(err); category != errors.CategoryUnknown {
+					fmt.Fprintf(os.Stderr, "hint: %s\n", i18n.T("error."+string(category)+".hint"))
+				}
+				code := 1
+				if exit, ok := err.(exitError); ok {
+					code = exit.code
+				}
+				os.Exit(code)
+			}
+			return
+		}
+	}
+
+	flags := flag.NewFlagSet("godev", flag.ExitOnError)
+	flags.BoolVar(&cfg.ReadOnly, "read-only", cfg.ReadOnly, "disable saving, deleting, non-GET requests and non-SELECT SQL")
+	flags.BoolVar(&cfg.AutoSave, "auto-save", cfg.AutoSave, "save or update the request definition whenever it returns 2xx")
+	flags.BoolVar(&cfg.RestoreSession, "restore", cfg.RestoreSession, "reopen the screen, request and SQL editor left on the last exit")
+	flags.BoolVar(&cfg.NotifyBell, "bell", cfg.NotifyBell, "ring the terminal bell when a long request or query finishes")
+	flags.BoolVar(&cfg.NotifyOSC, "notify", cfg.NotifyOSC, "send a desktop notification (OSC 9) when a long request or query finishes")
+	flags.StringVar(&cfg.Language, "lang", cfg.Language, "interface language: en or pt-BR")
+	flags.StringVar(&cfg.Proxy, "proxy", cfg.Proxy, "send requests through this proxy: http://, https://, socks5:// or socks5h:// URL")
+	flags.BoolVar(&cfg.TLSInsecure, "insecure", cfg.TLSInsecure, "skip verifying server certificates, for self-signed test servers")
+	flags.StringVar(&cfg.CAFile, "ca-file", cfg.CAFile, "trust the certificates in this PEM bundle on top of the system roots")
+	noColor := flags.Bool("no-color", !cfg.EnableColors, "plain output for screen readers and limited terminals: no colors, ASCII borders and text labels")
+	// Listed for -help only; extractConfigDir has already applied it
+	flags.String("config-dir", configDir, "keep data and settings in this directory (default: $GODEV_HOME, XDG dirs or ~/.godev)")
+	flags.Parse(args)
+
+	if err := i18n.SetLanguage(cfg.Language); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid language: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Apply the theme before any view is rendered
+	if err := ui.ApplyTheme(cfg.Theme); err != nil {
+		logger.Warn("Ignoring profile theme", "error", err)
+	}
+
 	// Start UI application
 	m := ui.NewModel()
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	if err := m.SetKeyBindings(cfg.KeyBindings); err != nil {
+		logger.Warn("Ignoring profile key bindings", "error", err)
+	}
+	if dir, err := plugin.Dir(); err == nil {
+		plugins, errs := plugin.Load(dir)
+		for _, err := range errs {
+			logger.Warn("Skipping plugin", "error", err)
+		}
+		m.SetPlugins(plugins)
+	}
+	m.SetReadOnly(cfg.ReadOnly)
+	m.SetAutoSave(cfg.AutoSave)
+	density, err := ui.ParseListDensity(cfg.ListDensity)
+	if err != nil {
+		logger.Warn("Ignoring list density", "error", err)
+	}
+	m.SetListDensity(density, cfg.SaveListDensity)
+	m.SetRestoreSession(cfg.RestoreSession)
+	m.SetRequestDefaults(requestDefaults(cfg, 0))
+	if err := cfg.ProxySettings().Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid proxy: %v\n", err)
+		os.Exit(1)
+	}
+	m.SetProxy(cfg.ProxySettings(), cfg.SaveProxySettings)
+	if err := m.SetTLS(cfg.TLSSettings(), cfg.SaveTLSSettings); err != nil {
+		logger.Warn("Ignoring TLS settings", "error", err)
+	}
+	if cfg.TLSInsecure {
+		logger.Warn("Server certificates are not verified")
+	}
+	m.SetOnboarding(cfg.Version, changelogText)
+	if rules, err := httpclient.ParseIgnoreRules(cfg.DiffIgnore); err == nil {
+		m.SetDiffIgnore(rules)
+	}
+	if policy, err := storage.ParseSyncPolicy(cfg.HistorySync); err == nil {
+		m.SetHistoryWriter(storage.HistoryWriterOptions{
+			Interval: cfg.HistoryFlushInterval,
+			Sync:     policy,
+		})
+	}
+	if *noColor {
+		m.SetAccessible(true)
+		logger.Info("Accessible no-color mode enabled")
+	}
+	m.SetNotifications(ui.NotifyConfig{
+		After: cfg.NotifyAfter,
+		Bell:  cfg.NotifyBell,
+		OSC:   cfg.NotifyOSC,
+	})
+	if cfg.ReadOnly {
+		logger.Info("Read-only mode enabled")
+	}
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithReportFocus())
 
 	// Run application in a goroutine
 	done := make(chan error, 1)
 	go func() {
-		_, err := p.Run()
+		final, err := p.Run()
+		if fm, ok := final.(ui.Model); ok {
+			if err := fm.SaveSession(); err != nil {
+				logger.Error("Failed to save the session", "error", err)
+			}
+			if err := fm.Close(); err != nil {
+				logger.Error("Failed to write history", "error", err)
+			}
+			if path := fm.CrashReport(); path != "" {
+				logger.Error("Recovered from a crash", "report", path)
+				fmt.Fprintf(os.Stderr, "godev recovered from an internal error; a crash report was saved to %s\n", path)
+			}
+		}
 		done <- err
 	}()
 
@@ -111,3 +258,29 @@ func parseLogLevel(level string) logging.Level {
 		return logging.LevelInfo
 	}
 }
+
+// extractConfigDir takes the --config-dir flag (or -config-dir, with the
+// value attached by "=" or as the next argument) out of args, wherever it
+// appears before a "--"
+func extractConfigDir(args []string) ([]string, string) {
+	var dir string
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "config-dir" {
+			rest = append(rest, arg)
+			continue
+		}
+		if !hasValue && i+1 < len(args) {
+			i++
+			value = args[i]
+		}
+		dir = value
+	}
+	return rest, dir
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/abneribeiro/godev/internal/logging"
+	"github.com/abneribeiro/godev/internal/mock"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// runMockCommand serves example responses from an OpenAPI document, given
+// either as a JSON file or as the name of a collection imported from OpenAPI.
+// It only listens on loopback unless -addr asks for more.
+func runMockCommand(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("mock", flag.ContinueOnError)
+	addr := fs.String("addr", "127.0.0.1:8080", "address to listen on; use :8080 to accept other machines")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: godev mock [-addr 127.0.0.1:8080] <openapi.json | collection name>")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected an OpenAPI file or collection name")
+	}
+
+	spec, err := loadMockSpec(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	server := mock.NewServer(spec)
+
+	fmt.Printf("Mocking %s on %s\n", spec.Info.Title, *addr)
+	for _, route := range server.Routes() {
+		fmt.Printf("  %s\n", route)
+	}
+
+	logging.GetLogger().Info("Starting mock server", "addr", *addr, "routes", len(server.Routes()))
+	return server.ListenAndServe(ctx, *addr)
+}
+
+// loadMockSpec reads the spec from a file, falling back to an imported collection
+func loadMockSpec(source string) (*storage.OpenAPISpec, error) {
+	if data, err := os.ReadFile(source); err == nil {
+		return storage.ParseOpenAPISpec(data)
+	}
+
+	store, err := storage.NewStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := store.LoadCollections()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range config.Collections {
+		if config.Collections[i].Name == source {
+			return config.Collections[i].Contract()
+		}
+	}
+
+	return nil, fmt.Errorf("no OpenAPI file or imported collection named %q", source)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/abneribeiro/godev/internal/config"
+	"github.com/abneribeiro/godev/internal/ui"
+)
+
+// runProfileCommand exports the current settings, theme and key bindings to a
+// profile file, or imports one as the active profile
+func runProfileCommand(_ context.Context, args []string) error {
+	fs := flag.NewFlagSet("profile", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: godev profile export <file> | godev profile import <file>")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("expected export or import and a file")
+	}
+
+	cfg, err := config.LoadFromEnv()
+	if err != nil {
+		return err
+	}
+
+	switch fs.Arg(0) {
+	case "export":
+		return exportProfile(cfg, fs.Arg(1))
+	case "import":
+		return importProfile(cfg, fs.Arg(1))
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown profile action %q", fs.Arg(0))
+	}
+}
+
+// exportProfile writes the effective configuration with the full theme and
+// key map, so the file also documents every name that can be changed
+func exportProfile(cfg *config.Config, path string) error {
+	if err := ui.ApplyTheme(cfg.Theme); err != nil {
+		return err
+	}
+	keymap := ui.DefaultKeyMap()
+	if err := keymap.ApplyBindings(cfg.KeyBindings); err != nil {
+		return err
+	}
+
+	profile := config.NewProfile(cfg)
+	profile.Theme = ui.ThemeColors()
+	profile.KeyBindings = keymap.BindingKeys()
+
+	if err := profile.Save(path); err != nil {
+		return err
+	}
+	fmt.Printf("Exported profile to %s\n", path)
+	return nil
+}
+
+// importProfile validates a profile file and makes it the active profile
+func importProfile(cfg *config.Config, path string) error {
+	profile, err := config.LoadProfile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("profile not found: %s", path)
+		}
+		return err
+	}
+
+	check := config.DefaultConfig()
+	if err := profile.Apply(check); err != nil {
+		return err
+	}
+	if err := check.Validate(); err != nil {
+		return err
+	}
+	if err := ui.ValidateTheme(profile.Theme); err != nil {
+		return err
+	}
+	keymap := ui.DefaultKeyMap()
+	if err := keymap.ApplyBindings(profile.KeyBindings); err != nil {
+		return err
+	}
+
+	if err := profile.Save(cfg.ProfilePath()); err != nil {
+		return err
+	}
+	fmt.Printf("Imported profile into %s\n", cfg.ProfilePath())
+	return nil
+}
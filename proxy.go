@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/abneribeiro/godev/internal/logging"
+	"github.com/abneribeiro/godev/internal/proxy"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// runProxyCommand starts a forward proxy that records every request into
+// history. It only listens on loopback unless -addr asks for more, as the
+// proxy forwards to any host for whoever can reach it.
+func runProxyCommand(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("proxy", flag.ContinueOnError)
+	addr := fs.String("addr", "127.0.0.1:8888", "address to listen on; use :8888 to accept other machines, which can then relay through the proxy")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: godev proxy [-addr 127.0.0.1:8888]")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	store, err := storage.NewStorage()
+	if err != nil {
+		return err
+	}
+
+	logger := logging.GetLogger()
+
+	// Storage is not safe for concurrent use, proxied requests arrive in parallel
+	var mu sync.Mutex
+	recorder := func(e proxy.Exchange) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err := store.AddToHistory(e.Method, e.URL, e.Headers, e.Body, nil,
+			e.StatusCode, e.Status, e.ResponseBody, e.ResponseTime.Milliseconds(), e.Error); err != nil {
+			logger.Error("Failed to record proxied request", "error", err)
+			return
+		}
+
+		status := e.Status
+		if e.Error != nil {
+			status = e.Error.Error()
+		}
+		fmt.Printf("%-7s %s -> %s (%dms)\n", e.Method, e.URL, status, e.ResponseTime.Milliseconds())
+	}
+
+	fmt.Printf("Recording proxy listening on %s\n", *addr)
+	fmt.Printf("Set HTTP_PROXY=http://%s in your app; HTTPS is tunnelled without interception\n", dialAddr(*addr))
+
+	logger.Info("Starting recording proxy", "addr", *addr)
+	return proxy.New(recorder).ListenAndServe(ctx, *addr)
+}
+
+// dialAddr returns the address clients on this machine reach a listener
+// on addr with: an empty or unspecified host becomes localhost
+func dialAddr(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, port)
+}
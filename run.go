@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/abneribeiro/godev/internal/config"
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/runner"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// runRunCommand sends a saved request by name without the UI and prints the
+// response. The exit code follows the status class, so scripts and CI can
+// branch on the outcome.
+func runRunCommand(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	env := fs.String("env", "", "resolve variables and aliases against this environment (default: the active one)")
+	bodyOnly := fs.Bool("body", false, "print only the response body")
+	noHistory := fs.Bool("no-history", false, "do not record the request in history")
+	timeout := fs.Duration("timeout", 0, "request timeout (default: GODEV_HTTP_TIMEOUT of the settings, or 30s)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: godev run [-env name] [-body] [-no-history] <saved request name>")
+		fmt.Fprintln(fs.Output(), "Exits 0 on 2xx, 3 on 3xx, 4 on 4xx, 5 on 5xx and 1 when no response arrives.")
+		fs.PrintDefaults()
+	}
+
+	names, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(names) != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected a saved request name")
+	}
+
+	store, err := storage.NewStorage()
+	if err != nil {
+		return err
+	}
+
+	saved := storage.FindRequestByName(store.GetRequests(), names[0])
+	if saved == nil {
+		return fmt.Errorf("no saved request named %q", names[0])
+	}
+
+	envs, err := store.LoadEnvironments()
+	if err != nil {
+		return err
+	}
+	envName := *env
+	if envName == "" {
+		envName = envs.ActiveEnvironment
+	}
+	var environment storage.Environment
+	if envName != "" {
+		found := envs.Find(envName)
+		if found == nil {
+			return fmt.Errorf("no environment named %q", envName)
+		}
+		environment = *found
+	}
+
+	cfg, err := config.LoadFromEnv()
+	if err != nil {
+		return err
+	}
+	defaults := requestDefaults(cfg, *timeout)
+
+	req, err := prepareSavedRequest(*saved, environment, time.Now())
+	if err != nil {
+		return err
+	}
+	req.Timeout, req.Retry = defaults.Policy(req.Method, saved.Policy)
+
+	client, err := newHTTPClient(cfg, &environment, defaults.Timeout)
+	if err != nil {
+		return err
+	}
+
+	resp := client.SendWithContext(ctx, req)
+
+	if !*noHistory {
+		execution := newExecution(runner.PrepareRequest(*saved), resp, envName)
+		execution.BudgetMs = saved.LatencyBudgetMs
+		recordExecution(store, execution)
+	}
+
+	if resp.Error != nil {
+		return resp.Error
+	}
+
+	fmt.Print(formatSendResponse(resp, *bodyOnly))
+
+	if code := statusExitCode(resp.StatusCode); code != 0 {
+		return exitError{code: code, err: fmt.Errorf("%s", resp.Status)}
+	}
+	return nil
+}
+
+// prepareSavedRequest resolves a saved request against env the way the
+// request builder does: aliases first, then variables and auth, then HMAC signing
+func prepareSavedRequest(saved storage.SavedRequest, env storage.Environment, now time.Time) (httpclient.Request, error) {
+	req := runner.ResolveRequest(saved, env.Variables, env.Aliases)
+	return runner.Sign(saved, req, env.Variables, now)
+}
+
+// statusExitCode maps a status to the exit code of godev run: 0 for 2xx,
+// and the first digit of the status for redirects and errors
+func statusExitCode(status int) int {
+	switch class := status / 100; class {
+	case 2:
+		return 0
+	case 3, 4, 5:
+		return class
+	default:
+		return 1
+	}
+}
+
+// parseInterspersed parses fs from args allowing flags after the positional
+// arguments, as in "godev run list-users --env prod". Everything after a
+// "--" is positional.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// exitError makes a subcommand exit with code instead of 1
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e exitError) Error() string { return e.err.Error() }
+
+func (e exitError) Unwrap() error { return e.err }
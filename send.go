@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/abneribeiro/godev/internal/config"
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// runSendCommand sends a .http or curl snippet and prints the response, so
+// editors can pipe the request under the cursor through godev
+func runSendCommand(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("send", flag.ContinueOnError)
+	stdin := fs.Bool("stdin", false, "read the request from stdin")
+	bodyOnly := fs.Bool("body", false, "print only the response body")
+	noHistory := fs.Bool("no-history", false, "do not record the request in history")
+	timeout := fs.Duration("timeout", 0, "request timeout (default: GODEV_HTTP_TIMEOUT of the settings, or 30s)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: godev send [-body] [-no-history] (--stdin | <file.http>)")
+		fmt.Fprintln(fs.Output(), "Reads one request in .http format or as a curl command.")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var input []byte
+	var err error
+	switch {
+	case *stdin && fs.NArg() == 0:
+		input, err = io.ReadAll(os.Stdin)
+	case !*stdin && fs.NArg() == 1:
+		input, err = os.ReadFile(fs.Arg(0))
+	default:
+		fs.Usage()
+		return fmt.Errorf("expected --stdin or a single file")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read request: %w", err)
+	}
+
+	req, err := httpclient.ParseSnippet(string(input))
+	if err != nil {
+		return err
+	}
+
+	store, err := storage.NewStorage()
+	if err != nil {
+		return err
+	}
+
+	sent := req
+	if aliases, err := store.GetActiveAliases(); err == nil {
+		sent.URL, _ = storage.ExpandAlias(req.URL, aliases)
+	}
+	if vars, err := store.GetActiveEnvironmentVariables(); err == nil && len(vars) > 0 {
+		sent.URL = storage.ReplaceVariables(sent.URL, vars)
+		sent.Body = storage.ReplaceVariables(req.Body, vars)
+		sent.Headers = make(map[string]string, len(req.Headers))
+		for k, v := range req.Headers {
+			sent.Headers[k] = storage.ReplaceVariables(v, vars)
+		}
+	}
+
+	cfg, err := config.LoadFromEnv()
+	if err != nil {
+		return err
+	}
+	envs, err := store.LoadEnvironments()
+	if err != nil {
+		return err
+	}
+	defaults := requestDefaults(cfg, *timeout)
+	sent.Timeout, sent.Retry = defaults.Policy(sent.Method, nil)
+	client, err := newHTTPClient(cfg, envs.Active(), defaults.Timeout)
+	if err != nil {
+		return err
+	}
+
+	resp := client.SendWithContext(ctx, sent)
+
+	if !*noHistory {
+		recordExecution(store, newExecution(req, resp, store.ActiveEnvironmentName()))
+	}
+
+	if resp.Error != nil {
+		return resp.Error
+	}
+
+	fmt.Print(formatSendResponse(resp, *bodyOnly))
+	return nil
+}
+
+// newExecution builds the history entry of a request sent from the command
+// line. req is the request as written, before variables were replaced.
+func newExecution(req httpclient.Request, resp httpclient.Response, env string) storage.RequestExecution {
+	execution := storage.RequestExecution{
+		Method:       req.Method,
+		URL:          req.URL,
+		Headers:      req.Headers,
+		Body:         req.Body,
+		StatusCode:   resp.StatusCode,
+		Status:       resp.Status,
+		ResponseBody: resp.Body,
+		ResponseTime: resp.ResponseTime.Milliseconds(),
+		Environment:  env,
+	}
+	if resp.Error != nil {
+		execution.Error = resp.Error.Error()
+	}
+	return execution
+}
+
+// recordExecution adds an entry to history, warning on stderr when history
+// cannot be written
+func recordExecution(store *storage.Storage, execution storage.RequestExecution) {
+	if err := store.AddExecution(execution); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to record history: %v\n", err)
+	}
+}
+
+// formatSendResponse renders the status line, sorted headers and body
+func formatSendResponse(resp httpclient.Response, bodyOnly bool) string {
+	var b strings.Builder
+
+	if !bodyOnly {
+		fmt.Fprintf(&b, "%s  (%s, %s)\n", resp.Status, httpclient.FormatDuration(resp.ResponseTime), httpclient.FormatSize(resp.Size))
+
+		keys := make([]string, 0, len(resp.Headers))
+		for key := range resp.Headers {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			for _, value := range resp.Headers[key] {
+				fmt.Fprintf(&b, "%s: %s\n", key, value)
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(resp.Body)
+	if !strings.HasSuffix(resp.Body, "\n") {
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
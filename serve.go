@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/abneribeiro/godev/internal/config"
+	"github.com/abneribeiro/godev/internal/database"
+	httpclient "github.com/abneribeiro/godev/internal/http"
+	"github.com/abneribeiro/godev/internal/logging"
+	"github.com/abneribeiro/godev/internal/rpc"
+	"github.com/abneribeiro/godev/internal/storage"
+)
+
+// runServeCommand exposes saved requests, queries and collections as a
+// JSON-RPC API on a Unix socket
+func runServeCommand(ctx context.Context, args []string) error {
+	// GODEV_READ_ONLY and the settings profile apply here as in the UI
+	cfg, err := config.LoadFromEnv()
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	socket := fs.String("socket", "", "Unix socket to listen on (default godev.sock in the data directory)")
+	readOnly := fs.Bool("read-only", cfg.ReadOnly, "refuse non-GET requests and non-SELECT SQL")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: godev serve [-socket path] [-read-only]")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	store, err := storage.NewStorage()
+	if err != nil {
+		return err
+	}
+
+	dir, err := store.Dir()
+	if err != nil {
+		return err
+	}
+
+	dbStore, err := database.NewDatabaseStorageAt(dir)
+	if err != nil {
+		return err
+	}
+
+	path := *socket
+	if path == "" {
+		path = filepath.Join(dir, "godev.sock")
+	}
+	if err := removeStaleSocket(path); err != nil {
+		return err
+	}
+
+	ln, err := listenPrivate(path)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	defer os.Remove(path)
+
+	defaults := requestDefaults(cfg, 0)
+	server := rpc.NewServer(store, dbStore, httpclient.NewClient(defaults.Timeout))
+	server.SetClientSetup(func(env *storage.Environment) (*httpclient.Client, error) {
+		return newHTTPClient(cfg, env, defaults.Timeout)
+	})
+	server.SetRequestDefaults(defaults)
+	server.SetReadOnly(*readOnly)
+
+	fmt.Printf("JSON-RPC server listening on %s\n", path)
+	fmt.Printf("Methods: %s\n", strings.Join(server.Methods(), ", "))
+
+	logging.GetLogger().Info("Starting RPC server", "socket", path, "read_only", *readOnly)
+	return server.Serve(ctx, ln)
+}
+
+// listenPrivate listens on the Unix socket path so that only the owner can
+// ever connect, as the server sends requests and reads stored credentials.
+// The socket is created in a new 0700 directory, restricted to 0600 there
+// and only then moved to path.
+func listenPrivate(path string) (net.Listener, error) {
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".godev-socket-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	private := filepath.Join(dir, "sock")
+	ln, err := net.Listen("unix", private)
+	if err != nil {
+		return nil, err
+	}
+	// The socket is removed from path once it was moved there
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+
+	if err := os.Chmod(private, 0o600); err != nil {
+		ln.Close()
+		return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+	if err := os.Rename(private, path); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	return ln, nil
+}
+
+// removeStaleSocket deletes a socket file left behind by a server that did
+// not shut down, and refuses to start when another server is still running
+// or path is not a socket at all
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		conn.Close()
+		return fmt.Errorf("another server is already listening on %s", path)
+	}
+
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+	return nil
+}